@@ -14,10 +14,18 @@ import (
 )
 
 const (
-	Ref                                  = "refs/gittuf/attestations"
-	referenceAuthorizationsTreeEntryName = "reference-authorizations"
-	initialCommitMessage                 = "Initial commit"
-	defaultCommitMessage                 = "Update attestations"
+	Ref                                      = "refs/gittuf/attestations"
+	referenceAuthorizationsTreeEntryName     = "reference-authorizations"
+	githubPullRequestApprovalsTreeEntryName  = "github-pull-request-approvals"
+	gitlabMergeRequestApprovalsTreeEntryName = "gitlab-merge-request-approvals"
+	codeReviewsTreeEntryName                 = "code-reviews"
+	pushAuthorizationsTreeEntryName          = "push-authorizations"
+	provenanceTreeEntryName                  = "provenance"
+	verificationSummariesTreeEntryName       = "verification-summaries"
+	transparencyLogEntriesTreeEntryName      = "transparency-log-entries"
+	signatureTimestampsTreeEntryName         = "signature-timestamps"
+	initialCommitMessage                     = "Initial commit"
+	defaultCommitMessage                     = "Update attestations"
 )
 
 var ErrAttestationsExist = errors.New("cannot initialize attestations namespace as it exists already")
@@ -53,6 +61,47 @@ type Attestations struct {
 	// `refs/heads/main/<commit-A>-<commit-B>` indicates the authorization is
 	// for the action of moving `refs/heads/main` from `commit-A` to `commit-B`.
 	referenceAuthorizations map[string]plumbing.Hash
+
+	// githubPullRequestApprovals maps each change approved in a GitHub pull
+	// request to the blob ID of the attestation. The keys are of the same
+	// form as referenceAuthorizations.
+	githubPullRequestApprovals map[string]plumbing.Hash
+
+	// gitlabMergeRequestApprovals maps each change approved in a GitLab merge
+	// request to the blob ID of the attestation. The keys are of the same form
+	// as referenceAuthorizations.
+	gitlabMergeRequestApprovals map[string]plumbing.Hash
+
+	// codeReviews maps each reviewer's review of a change to the blob ID of
+	// the attestation. The keys are of the form
+	// `<reference authorization path>/<hash of reviewer>`.
+	codeReviews map[string]plumbing.Hash
+
+	// pushAuthorizations maps each authorized ref update to the blob ID of the
+	// attestation. The keys are of the same form as referenceAuthorizations,
+	// except that the second ID is that of the ref's new tip rather than a
+	// tree.
+	pushAuthorizations map[string]plumbing.Hash
+
+	// provenance maps each release tag to the blob ID of its SLSA provenance
+	// attestation. The keys are the absolute tag ref paths such as
+	// `refs/tags/v1.0.0`.
+	provenance map[string]plumbing.Hash
+
+	// verificationSummaries maps each verified ref tip to the blob ID of the
+	// verification summary attestation. The keys are of the form
+	// `<ref-path>/<commit-id>`.
+	verificationSummaries map[string]plumbing.Hash
+
+	// transparencyLogEntries maps each signature recorded in a transparency
+	// log to the blob ID of the log entry. The keys are of the form
+	// `<hash of envelope payload>/<hash of key ID>`.
+	transparencyLogEntries map[string]plumbing.Hash
+
+	// signatureTimestamps maps each timestamped signature to the blob ID of
+	// the RFC 3161 timestamp response. The keys are the hashes of the
+	// signatures.
+	signatureTimestamps map[string]plumbing.Hash
 }
 
 // LoadCurrentAttestations inspects the repository's attestations namespace and
@@ -94,10 +143,27 @@ func LoadAttestationsForEntry(repo *git.Repository, entry *rsl.ReferenceEntry) (
 		return &Attestations{}, nil
 	}
 
-	var authorizationsTreeID plumbing.Hash
+	var authorizationsTreeID, githubPullRequestApprovalsTreeID, gitlabMergeRequestApprovalsTreeID, codeReviewsTreeID, pushAuthorizationsTreeID, provenanceTreeID, verificationSummariesTreeID, transparencyLogEntriesTreeID, signatureTimestampsTreeID plumbing.Hash
 	for _, e := range attestationsRootTree.Entries {
-		if e.Name == referenceAuthorizationsTreeEntryName {
+		switch e.Name {
+		case referenceAuthorizationsTreeEntryName:
 			authorizationsTreeID = e.Hash
+		case githubPullRequestApprovalsTreeEntryName:
+			githubPullRequestApprovalsTreeID = e.Hash
+		case gitlabMergeRequestApprovalsTreeEntryName:
+			gitlabMergeRequestApprovalsTreeID = e.Hash
+		case codeReviewsTreeEntryName:
+			codeReviewsTreeID = e.Hash
+		case pushAuthorizationsTreeEntryName:
+			pushAuthorizationsTreeID = e.Hash
+		case provenanceTreeEntryName:
+			provenanceTreeID = e.Hash
+		case verificationSummariesTreeEntryName:
+			verificationSummariesTreeID = e.Hash
+		case transparencyLogEntriesTreeEntryName:
+			transparencyLogEntriesTreeID = e.Hash
+		case signatureTimestampsTreeEntryName:
+			signatureTimestampsTreeID = e.Hash
 		}
 	}
 
@@ -113,6 +179,102 @@ func LoadAttestationsForEntry(repo *git.Repository, entry *rsl.ReferenceEntry) (
 		return nil, err
 	}
 
+	if !githubPullRequestApprovalsTreeID.IsZero() {
+		githubPullRequestApprovalsTree, err := gitinterface.GetTree(repo, githubPullRequestApprovalsTreeID)
+		if err != nil {
+			return nil, err
+		}
+
+		attestations.githubPullRequestApprovals, err = gitinterface.GetAllFilesInTree(githubPullRequestApprovalsTree)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if !gitlabMergeRequestApprovalsTreeID.IsZero() {
+		gitlabMergeRequestApprovalsTree, err := gitinterface.GetTree(repo, gitlabMergeRequestApprovalsTreeID)
+		if err != nil {
+			return nil, err
+		}
+
+		attestations.gitlabMergeRequestApprovals, err = gitinterface.GetAllFilesInTree(gitlabMergeRequestApprovalsTree)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if !codeReviewsTreeID.IsZero() {
+		codeReviewsTree, err := gitinterface.GetTree(repo, codeReviewsTreeID)
+		if err != nil {
+			return nil, err
+		}
+
+		attestations.codeReviews, err = gitinterface.GetAllFilesInTree(codeReviewsTree)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if !pushAuthorizationsTreeID.IsZero() {
+		pushAuthorizationsTree, err := gitinterface.GetTree(repo, pushAuthorizationsTreeID)
+		if err != nil {
+			return nil, err
+		}
+
+		attestations.pushAuthorizations, err = gitinterface.GetAllFilesInTree(pushAuthorizationsTree)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if !provenanceTreeID.IsZero() {
+		provenanceTree, err := gitinterface.GetTree(repo, provenanceTreeID)
+		if err != nil {
+			return nil, err
+		}
+
+		attestations.provenance, err = gitinterface.GetAllFilesInTree(provenanceTree)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if !verificationSummariesTreeID.IsZero() {
+		verificationSummariesTree, err := gitinterface.GetTree(repo, verificationSummariesTreeID)
+		if err != nil {
+			return nil, err
+		}
+
+		attestations.verificationSummaries, err = gitinterface.GetAllFilesInTree(verificationSummariesTree)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if !transparencyLogEntriesTreeID.IsZero() {
+		transparencyLogEntriesTree, err := gitinterface.GetTree(repo, transparencyLogEntriesTreeID)
+		if err != nil {
+			return nil, err
+		}
+
+		attestations.transparencyLogEntries, err = gitinterface.GetAllFilesInTree(transparencyLogEntriesTree)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if !signatureTimestampsTreeID.IsZero() {
+		signatureTimestampsTree, err := gitinterface.GetTree(repo, signatureTimestampsTreeID)
+		if err != nil {
+			return nil, err
+		}
+
+		attestations.signatureTimestamps, err = gitinterface.GetAllFilesInTree(signatureTimestampsTree)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return attestations, nil
 }
 
@@ -138,6 +300,110 @@ func (a *Attestations) Commit(repo *git.Repository, commitMessage string, signCo
 		Hash: authorizationsTreeID,
 	})
 
+	if len(a.githubPullRequestApprovals) > 0 {
+		// Add GitHub pull request approvals tree
+		githubPullRequestApprovalsTreeID, err := treeBuilder.WriteRootTreeFromBlobIDs(a.githubPullRequestApprovals)
+		if err != nil {
+			return err
+		}
+		attestationsTreeEntries = append(attestationsTreeEntries, object.TreeEntry{
+			Name: githubPullRequestApprovalsTreeEntryName,
+			Mode: filemode.Dir,
+			Hash: githubPullRequestApprovalsTreeID,
+		})
+	}
+
+	if len(a.gitlabMergeRequestApprovals) > 0 {
+		// Add GitLab merge request approvals tree
+		gitlabMergeRequestApprovalsTreeID, err := treeBuilder.WriteRootTreeFromBlobIDs(a.gitlabMergeRequestApprovals)
+		if err != nil {
+			return err
+		}
+		attestationsTreeEntries = append(attestationsTreeEntries, object.TreeEntry{
+			Name: gitlabMergeRequestApprovalsTreeEntryName,
+			Mode: filemode.Dir,
+			Hash: gitlabMergeRequestApprovalsTreeID,
+		})
+	}
+
+	if len(a.codeReviews) > 0 {
+		// Add code reviews tree
+		codeReviewsTreeID, err := treeBuilder.WriteRootTreeFromBlobIDs(a.codeReviews)
+		if err != nil {
+			return err
+		}
+		attestationsTreeEntries = append(attestationsTreeEntries, object.TreeEntry{
+			Name: codeReviewsTreeEntryName,
+			Mode: filemode.Dir,
+			Hash: codeReviewsTreeID,
+		})
+	}
+
+	if len(a.pushAuthorizations) > 0 {
+		// Add push authorizations tree
+		pushAuthorizationsTreeID, err := treeBuilder.WriteRootTreeFromBlobIDs(a.pushAuthorizations)
+		if err != nil {
+			return err
+		}
+		attestationsTreeEntries = append(attestationsTreeEntries, object.TreeEntry{
+			Name: pushAuthorizationsTreeEntryName,
+			Mode: filemode.Dir,
+			Hash: pushAuthorizationsTreeID,
+		})
+	}
+
+	if len(a.provenance) > 0 {
+		// Add provenance tree
+		provenanceTreeID, err := treeBuilder.WriteRootTreeFromBlobIDs(a.provenance)
+		if err != nil {
+			return err
+		}
+		attestationsTreeEntries = append(attestationsTreeEntries, object.TreeEntry{
+			Name: provenanceTreeEntryName,
+			Mode: filemode.Dir,
+			Hash: provenanceTreeID,
+		})
+	}
+
+	if len(a.verificationSummaries) > 0 {
+		// Add verification summaries tree
+		verificationSummariesTreeID, err := treeBuilder.WriteRootTreeFromBlobIDs(a.verificationSummaries)
+		if err != nil {
+			return err
+		}
+		attestationsTreeEntries = append(attestationsTreeEntries, object.TreeEntry{
+			Name: verificationSummariesTreeEntryName,
+			Mode: filemode.Dir,
+			Hash: verificationSummariesTreeID,
+		})
+	}
+
+	if len(a.transparencyLogEntries) > 0 {
+		// Add transparency log entries tree
+		transparencyLogEntriesTreeID, err := treeBuilder.WriteRootTreeFromBlobIDs(a.transparencyLogEntries)
+		if err != nil {
+			return err
+		}
+		attestationsTreeEntries = append(attestationsTreeEntries, object.TreeEntry{
+			Name: transparencyLogEntriesTreeEntryName,
+			Mode: filemode.Dir,
+			Hash: transparencyLogEntriesTreeID,
+		})
+	}
+
+	if len(a.signatureTimestamps) > 0 {
+		// Add signature timestamps tree
+		signatureTimestampsTreeID, err := treeBuilder.WriteRootTreeFromBlobIDs(a.signatureTimestamps)
+		if err != nil {
+			return err
+		}
+		attestationsTreeEntries = append(attestationsTreeEntries, object.TreeEntry{
+			Name: signatureTimestampsTreeEntryName,
+			Mode: filemode.Dir,
+			Hash: signatureTimestampsTreeID,
+		})
+	}
+
 	attestationsTreeID, err := gitinterface.WriteTree(repo, attestationsTreeEntries)
 	if err != nil {
 		return err
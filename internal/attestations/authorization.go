@@ -8,7 +8,6 @@ import (
 	"fmt"
 	"path"
 
-	"github.com/gittuf/gittuf/internal/gitinterface"
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
 	ita "github.com/in-toto/attestation/go/v1"
@@ -84,12 +83,7 @@ func (a *Attestations) SetReferenceAuthorization(repo *git.Repository, env *ssli
 		return err
 	}
 
-	envBytes, err := json.Marshal(env)
-	if err != nil {
-		return err
-	}
-
-	blobID, err := gitinterface.WriteBlob(repo, envBytes)
+	blobID, err := writeEnvelope(repo, env)
 	if err != nil {
 		return err
 	}
@@ -123,16 +117,11 @@ func (a *Attestations) GetReferenceAuthorizationFor(repo *git.Repository, refNam
 		return nil, ErrAuthorizationNotFound
 	}
 
-	envBytes, err := gitinterface.ReadBlob(repo, blobID)
+	env, err := readEnvelope(repo, blobID)
 	if err != nil {
 		return nil, err
 	}
 
-	env := &sslibdsse.Envelope{}
-	if err := json.Unmarshal(envBytes, env); err != nil {
-		return nil, err
-	}
-
 	if err := validateReferenceAuthorization(env, refName, fromRevisionID, targetTreeID); err != nil {
 		return nil, err
 	}
@@ -0,0 +1,194 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package attestations
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	ita "github.com/in-toto/attestation/go/v1"
+	sslibdsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const (
+	CodeReviewPredicateType = "https://gittuf.dev/code-review/v0.1"
+
+	// CodeReviewVerdictApproved indicates the reviewer approved the change.
+	CodeReviewVerdictApproved = "approved"
+
+	// CodeReviewVerdictChangesRequested indicates the reviewer requested
+	// changes before the change can be merged.
+	CodeReviewVerdictChangesRequested = "changes-requested"
+)
+
+var (
+	ErrInvalidCodeReview        = errors.New("code review attestation does not match expected details")
+	ErrInvalidCodeReviewVerdict = errors.New("unknown code review verdict")
+)
+
+// CodeReview records a reviewer's verdict for the change of targetRef from
+// fromRevisionID to a commit with the tree targetTreeID. The reviewer is
+// identified by the ID of the key used to sign the attestation. It is meant to
+// be used as a "predicate" in an in-toto attestation.
+type CodeReview struct {
+	TargetRef      string `json:"targetRef"`
+	FromRevisionID string `json:"fromRevisionID"`
+	TargetTreeID   string `json:"targetTreeID"`
+	Reviewer       string `json:"reviewer"`
+	Verdict        string `json:"verdict"`
+}
+
+// SignedCodeReview holds a code review along with the envelope it was
+// recorded in.
+type SignedCodeReview struct {
+	Review   *CodeReview
+	Envelope *sslibdsse.Envelope
+}
+
+// NewCodeReview creates a new code review for the provided information. The
+// review is embedded in an in-toto "statement" and returned with the
+// appropriate "predicate type" set.
+func NewCodeReview(targetRef, fromRevisionID, targetTreeID, reviewer, verdict string) (*ita.Statement, error) {
+	if verdict != CodeReviewVerdictApproved && verdict != CodeReviewVerdictChangesRequested {
+		return nil, ErrInvalidCodeReviewVerdict
+	}
+
+	predicate := &CodeReview{
+		TargetRef:      targetRef,
+		FromRevisionID: fromRevisionID,
+		TargetTreeID:   targetTreeID,
+		Reviewer:       reviewer,
+		Verdict:        verdict,
+	}
+
+	predicateBytes, err := json.Marshal(predicate)
+	if err != nil {
+		return nil, err
+	}
+
+	predicateInterface := &map[string]any{}
+	if err := json.Unmarshal(predicateBytes, predicateInterface); err != nil {
+		return nil, err
+	}
+
+	predicateStruct, err := structpb.NewStruct(*predicateInterface)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ita.Statement{
+		Type: ita.StatementTypeUri,
+		Subject: []*ita.ResourceDescriptor{
+			{
+				Digest: map[string]string{digestGitTreeKey: targetTreeID},
+			},
+		},
+		PredicateType: CodeReviewPredicateType,
+		Predicate:     predicateStruct,
+	}, nil
+}
+
+// SetCodeReview writes the code review attestation to the object store and
+// tracks it in the current attestations state. A change may be reviewed by
+// several reviewers, but an existing review by the same reviewer is replaced.
+func (a *Attestations) SetCodeReview(repo *git.Repository, env *sslibdsse.Envelope, refName, fromRevisionID, targetTreeID string) error {
+	review, err := validateCodeReview(env, refName, fromRevisionID, targetTreeID)
+	if err != nil {
+		return err
+	}
+
+	blobID, err := writeEnvelope(repo, env)
+	if err != nil {
+		return err
+	}
+
+	if a.codeReviews == nil {
+		a.codeReviews = map[string]plumbing.Hash{}
+	}
+
+	a.codeReviews[CodeReviewPath(refName, fromRevisionID, targetTreeID, review.Reviewer)] = blobID
+	return nil
+}
+
+// GetCodeReviewsFor returns the code reviews recorded for the change, sorted
+// by reviewer.
+func (a *Attestations) GetCodeReviewsFor(repo *git.Repository, refName, fromRevisionID, targetTreeID string) ([]*SignedCodeReview, error) {
+	prefix := ReferenceAuthorizationPath(refName, fromRevisionID, targetTreeID) + "/"
+
+	reviews := []*SignedCodeReview{}
+	for reviewPath, blobID := range a.codeReviews {
+		if !strings.HasPrefix(reviewPath, prefix) {
+			continue
+		}
+
+		env, err := readEnvelope(repo, blobID)
+		if err != nil {
+			return nil, err
+		}
+
+		review, err := validateCodeReview(env, refName, fromRevisionID, targetTreeID)
+		if err != nil {
+			return nil, err
+		}
+
+		reviews = append(reviews, &SignedCodeReview{Review: review, Envelope: env})
+	}
+
+	sort.Slice(reviews, func(i, j int) bool {
+		return reviews[i].Review.Reviewer < reviews[j].Review.Reviewer
+	})
+
+	return reviews, nil
+}
+
+// CodeReviewPath constructs the expected path on-disk for the code review
+// attestation of the reviewer. As reviewer identities may contain characters
+// that are not valid in tree entry names, the reviewer is hashed.
+func CodeReviewPath(refName, fromID, toID, reviewer string) string {
+	reviewerHash := sha256.Sum256([]byte(reviewer))
+	return path.Join(ReferenceAuthorizationPath(refName, fromID, toID), hex.EncodeToString(reviewerHash[:]))
+}
+
+func validateCodeReview(env *sslibdsse.Envelope, targetRef, fromRevisionID, targetTreeID string) (*CodeReview, error) {
+	payload, err := env.DecodeB64Payload()
+	if err != nil {
+		return nil, err
+	}
+
+	attestation := &ita.Statement{}
+	if err := json.Unmarshal(payload, attestation); err != nil {
+		return nil, err
+	}
+
+	if attestation.PredicateType != CodeReviewPredicateType {
+		return nil, ErrInvalidCodeReview
+	}
+
+	if len(attestation.Subject) == 0 || attestation.Subject[0].Digest[digestGitTreeKey] != targetTreeID {
+		return nil, ErrInvalidCodeReview
+	}
+
+	predicateBytes, err := json.Marshal(attestation.Predicate.AsMap())
+	if err != nil {
+		return nil, err
+	}
+
+	review := &CodeReview{}
+	if err := json.Unmarshal(predicateBytes, review); err != nil {
+		return nil, err
+	}
+
+	if review.TargetRef != targetRef || review.FromRevisionID != fromRevisionID || review.TargetTreeID != targetTreeID || review.Reviewer == "" {
+		return nil, ErrInvalidCodeReview
+	}
+
+	return review, nil
+}
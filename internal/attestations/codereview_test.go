@@ -0,0 +1,93 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package attestations
+
+import (
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/internal/signerverifier/dsse"
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/storage/memory"
+	sslibdsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCodeReview(t *testing.T) {
+	testRef := "refs/heads/main"
+	testAnotherRef := "refs/heads/feature"
+	testID := plumbing.ZeroHash.String()
+
+	createReview := func(t *testing.T, reviewer, verdict string) *sslibdsse.Envelope {
+		t.Helper()
+
+		review, err := NewCodeReview(testRef, testID, testID, reviewer, verdict)
+		if err != nil {
+			t.Fatal(err)
+		}
+		env, err := dsse.CreateEnvelope(review)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return env
+	}
+
+	_, err := NewCodeReview(testRef, testID, testID, "alice", "lgtm")
+	assert.ErrorIs(t, err, ErrInvalidCodeReviewVerdict)
+
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := rsl.InitializeNamespace(repo); err != nil {
+		t.Fatal(err)
+	}
+	if err := InitializeNamespace(repo); err != nil {
+		t.Fatal(err)
+	}
+
+	attestations := &Attestations{}
+
+	err = attestations.SetCodeReview(repo, createReview(t, "alice", CodeReviewVerdictApproved), testAnotherRef, testID, testID)
+	assert.ErrorIs(t, err, ErrInvalidCodeReview)
+
+	err = attestations.SetCodeReview(repo, createReferenceAuthorizationAttestationEnvelopes(t, testRef, testID, testID), testRef, testID, testID)
+	assert.ErrorIs(t, err, ErrInvalidCodeReview)
+
+	bobApproval := createReview(t, "bob", CodeReviewVerdictApproved)
+	if err := attestations.SetCodeReview(repo, createReview(t, "alice", CodeReviewVerdictApproved), testRef, testID, testID); err != nil {
+		t.Fatal(err)
+	}
+	if err := attestations.SetCodeReview(repo, bobApproval, testRef, testID, testID); err != nil {
+		t.Fatal(err)
+	}
+	// Replaces alice's earlier review
+	aliceRejection := createReview(t, "alice", CodeReviewVerdictChangesRequested)
+	if err := attestations.SetCodeReview(repo, aliceRejection, testRef, testID, testID); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := attestations.Commit(repo, "Add code reviews", false); err != nil {
+		t.Fatal(err)
+	}
+
+	attestations, err = LoadCurrentAttestations(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reviews, err := attestations.GetCodeReviewsFor(repo, testRef, testID, testID)
+	assert.Nil(t, err)
+	if assert.Len(t, reviews, 2) {
+		assert.Equal(t, &CodeReview{TargetRef: testRef, FromRevisionID: testID, TargetTreeID: testID, Reviewer: "alice", Verdict: CodeReviewVerdictChangesRequested}, reviews[0].Review)
+		assert.Equal(t, aliceRejection, reviews[0].Envelope)
+		assert.Equal(t, "bob", reviews[1].Review.Reviewer)
+		assert.Equal(t, bobApproval, reviews[1].Envelope)
+	}
+
+	reviews, err = attestations.GetCodeReviewsFor(repo, testAnotherRef, testID, testID)
+	assert.Nil(t, err)
+	assert.Empty(t, reviews)
+}
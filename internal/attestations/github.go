@@ -0,0 +1,154 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package attestations
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	ita "github.com/in-toto/attestation/go/v1"
+	sslibdsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const (
+	GitHubPullRequestApprovalPredicateType = "https://gittuf.dev/github-pull-request-approval/v0.1"
+)
+
+var (
+	ErrInvalidGitHubPullRequestApproval  = errors.New("GitHub pull request approval attestation does not match expected details")
+	ErrGitHubPullRequestApprovalNotFound = errors.New("requested GitHub pull request approval not found")
+)
+
+// GitHubPullRequestApproval records the users who approved a GitHub pull
+// request that changed targetRef from fromRevisionID to a commit with the tree
+// targetTreeID. It is meant to be used as a "predicate" in an in-toto
+// attestation.
+type GitHubPullRequestApproval struct {
+	TargetRef      string   `json:"targetRef"`
+	FromRevisionID string   `json:"fromRevisionID"`
+	TargetTreeID   string   `json:"targetTreeID"`
+	PullRequest    string   `json:"pullRequest"`
+	Approvers      []string `json:"approvers"`
+}
+
+// NewGitHubPullRequestApproval creates a new GitHub pull request approval for
+// the provided information. The pull request is identified by its URL, and the
+// approvers by their GitHub logins. The approval is embedded in an in-toto
+// "statement" and returned with the appropriate "predicate type" set.
+func NewGitHubPullRequestApproval(targetRef, fromRevisionID, targetTreeID, pullRequest string, approvers []string) (*ita.Statement, error) {
+	predicate := &GitHubPullRequestApproval{
+		TargetRef:      targetRef,
+		FromRevisionID: fromRevisionID,
+		TargetTreeID:   targetTreeID,
+		PullRequest:    pullRequest,
+		Approvers:      approvers,
+	}
+
+	predicateBytes, err := json.Marshal(predicate)
+	if err != nil {
+		return nil, err
+	}
+
+	predicateInterface := &map[string]any{}
+	if err := json.Unmarshal(predicateBytes, predicateInterface); err != nil {
+		return nil, err
+	}
+
+	predicateStruct, err := structpb.NewStruct(*predicateInterface)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ita.Statement{
+		Type: ita.StatementTypeUri,
+		Subject: []*ita.ResourceDescriptor{
+			{
+				Digest: map[string]string{digestGitTreeKey: targetTreeID},
+			},
+		},
+		PredicateType: GitHubPullRequestApprovalPredicateType,
+		Predicate:     predicateStruct,
+	}, nil
+}
+
+// SetGitHubPullRequestApproval writes the GitHub pull request approval
+// attestation to the object store and tracks it in the current attestations
+// state.
+func (a *Attestations) SetGitHubPullRequestApproval(repo *git.Repository, env *sslibdsse.Envelope, refName, fromRevisionID, targetTreeID string) error {
+	if _, err := validateGitHubPullRequestApproval(env, refName, fromRevisionID, targetTreeID); err != nil {
+		return err
+	}
+
+	blobID, err := writeEnvelope(repo, env)
+	if err != nil {
+		return err
+	}
+
+	if a.githubPullRequestApprovals == nil {
+		a.githubPullRequestApprovals = map[string]plumbing.Hash{}
+	}
+
+	a.githubPullRequestApprovals[ReferenceAuthorizationPath(refName, fromRevisionID, targetTreeID)] = blobID
+	return nil
+}
+
+// GetGitHubPullRequestApprovalFor returns the requested GitHub pull request
+// approval attestation (with its signatures), along with the approvers it
+// records.
+func (a *Attestations) GetGitHubPullRequestApprovalFor(repo *git.Repository, refName, fromRevisionID, targetTreeID string) (*sslibdsse.Envelope, []string, error) {
+	blobID, has := a.githubPullRequestApprovals[ReferenceAuthorizationPath(refName, fromRevisionID, targetTreeID)]
+	if !has {
+		return nil, nil, ErrGitHubPullRequestApprovalNotFound
+	}
+
+	env, err := readEnvelope(repo, blobID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	approval, err := validateGitHubPullRequestApproval(env, refName, fromRevisionID, targetTreeID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return env, approval.Approvers, nil
+}
+
+func validateGitHubPullRequestApproval(env *sslibdsse.Envelope, targetRef, fromRevisionID, targetTreeID string) (*GitHubPullRequestApproval, error) {
+	payload, err := env.DecodeB64Payload()
+	if err != nil {
+		return nil, err
+	}
+
+	attestation := &ita.Statement{}
+	if err := json.Unmarshal(payload, attestation); err != nil {
+		return nil, err
+	}
+
+	if attestation.PredicateType != GitHubPullRequestApprovalPredicateType {
+		return nil, ErrInvalidGitHubPullRequestApproval
+	}
+
+	if len(attestation.Subject) == 0 || attestation.Subject[0].Digest[digestGitTreeKey] != targetTreeID {
+		return nil, ErrInvalidGitHubPullRequestApproval
+	}
+
+	predicateBytes, err := json.Marshal(attestation.Predicate.AsMap())
+	if err != nil {
+		return nil, err
+	}
+
+	approval := &GitHubPullRequestApproval{}
+	if err := json.Unmarshal(predicateBytes, approval); err != nil {
+		return nil, err
+	}
+
+	if approval.TargetRef != targetRef || approval.FromRevisionID != fromRevisionID || approval.TargetTreeID != targetTreeID {
+		return nil, ErrInvalidGitHubPullRequestApproval
+	}
+
+	return approval, nil
+}
@@ -0,0 +1,154 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package attestations
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	ita "github.com/in-toto/attestation/go/v1"
+	sslibdsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const (
+	GitLabMergeRequestApprovalPredicateType = "https://gittuf.dev/gitlab-merge-request-approval/v0.1"
+)
+
+var (
+	ErrInvalidGitLabMergeRequestApproval  = errors.New("GitLab merge request approval attestation does not match expected details")
+	ErrGitLabMergeRequestApprovalNotFound = errors.New("requested GitLab merge request approval not found")
+)
+
+// GitLabMergeRequestApproval records the users who approved a GitLab merge
+// request that changed targetRef from fromRevisionID to a commit with the tree
+// targetTreeID. It is meant to be used as a "predicate" in an in-toto
+// attestation.
+type GitLabMergeRequestApproval struct {
+	TargetRef      string   `json:"targetRef"`
+	FromRevisionID string   `json:"fromRevisionID"`
+	TargetTreeID   string   `json:"targetTreeID"`
+	MergeRequest   string   `json:"mergeRequest"`
+	Approvers      []string `json:"approvers"`
+}
+
+// NewGitLabMergeRequestApproval creates a new GitLab merge request approval for
+// the provided information. The merge request is identified by its URL, and the
+// approvers by their GitLab usernames. The approval is embedded in an in-toto
+// "statement" and returned with the appropriate "predicate type" set.
+func NewGitLabMergeRequestApproval(targetRef, fromRevisionID, targetTreeID, mergeRequest string, approvers []string) (*ita.Statement, error) {
+	predicate := &GitLabMergeRequestApproval{
+		TargetRef:      targetRef,
+		FromRevisionID: fromRevisionID,
+		TargetTreeID:   targetTreeID,
+		MergeRequest:   mergeRequest,
+		Approvers:      approvers,
+	}
+
+	predicateBytes, err := json.Marshal(predicate)
+	if err != nil {
+		return nil, err
+	}
+
+	predicateInterface := &map[string]any{}
+	if err := json.Unmarshal(predicateBytes, predicateInterface); err != nil {
+		return nil, err
+	}
+
+	predicateStruct, err := structpb.NewStruct(*predicateInterface)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ita.Statement{
+		Type: ita.StatementTypeUri,
+		Subject: []*ita.ResourceDescriptor{
+			{
+				Digest: map[string]string{digestGitTreeKey: targetTreeID},
+			},
+		},
+		PredicateType: GitLabMergeRequestApprovalPredicateType,
+		Predicate:     predicateStruct,
+	}, nil
+}
+
+// SetGitLabMergeRequestApproval writes the GitLab merge request approval
+// attestation to the object store and tracks it in the current attestations
+// state.
+func (a *Attestations) SetGitLabMergeRequestApproval(repo *git.Repository, env *sslibdsse.Envelope, refName, fromRevisionID, targetTreeID string) error {
+	if _, err := validateGitLabMergeRequestApproval(env, refName, fromRevisionID, targetTreeID); err != nil {
+		return err
+	}
+
+	blobID, err := writeEnvelope(repo, env)
+	if err != nil {
+		return err
+	}
+
+	if a.gitlabMergeRequestApprovals == nil {
+		a.gitlabMergeRequestApprovals = map[string]plumbing.Hash{}
+	}
+
+	a.gitlabMergeRequestApprovals[ReferenceAuthorizationPath(refName, fromRevisionID, targetTreeID)] = blobID
+	return nil
+}
+
+// GetGitLabMergeRequestApprovalFor returns the requested GitLab merge request
+// approval attestation (with its signatures), along with the approvers it
+// records.
+func (a *Attestations) GetGitLabMergeRequestApprovalFor(repo *git.Repository, refName, fromRevisionID, targetTreeID string) (*sslibdsse.Envelope, []string, error) {
+	blobID, has := a.gitlabMergeRequestApprovals[ReferenceAuthorizationPath(refName, fromRevisionID, targetTreeID)]
+	if !has {
+		return nil, nil, ErrGitLabMergeRequestApprovalNotFound
+	}
+
+	env, err := readEnvelope(repo, blobID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	approval, err := validateGitLabMergeRequestApproval(env, refName, fromRevisionID, targetTreeID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return env, approval.Approvers, nil
+}
+
+func validateGitLabMergeRequestApproval(env *sslibdsse.Envelope, targetRef, fromRevisionID, targetTreeID string) (*GitLabMergeRequestApproval, error) {
+	payload, err := env.DecodeB64Payload()
+	if err != nil {
+		return nil, err
+	}
+
+	attestation := &ita.Statement{}
+	if err := json.Unmarshal(payload, attestation); err != nil {
+		return nil, err
+	}
+
+	if attestation.PredicateType != GitLabMergeRequestApprovalPredicateType {
+		return nil, ErrInvalidGitLabMergeRequestApproval
+	}
+
+	if len(attestation.Subject) == 0 || attestation.Subject[0].Digest[digestGitTreeKey] != targetTreeID {
+		return nil, ErrInvalidGitLabMergeRequestApproval
+	}
+
+	predicateBytes, err := json.Marshal(attestation.Predicate.AsMap())
+	if err != nil {
+		return nil, err
+	}
+
+	approval := &GitLabMergeRequestApproval{}
+	if err := json.Unmarshal(predicateBytes, approval); err != nil {
+		return nil, err
+	}
+
+	if approval.TargetRef != targetRef || approval.FromRevisionID != fromRevisionID || approval.TargetTreeID != targetTreeID {
+		return nil, ErrInvalidGitLabMergeRequestApproval
+	}
+
+	return approval, nil
+}
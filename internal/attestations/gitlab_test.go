@@ -0,0 +1,73 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package attestations
+
+import (
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/internal/signerverifier/dsse"
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGitLabMergeRequestApproval(t *testing.T) {
+	testRef := "refs/heads/main"
+	testAnotherRef := "refs/heads/feature"
+	testID := plumbing.ZeroHash.String()
+	testMergeRequest := "https://gitlab.com/gittuf/gittuf/-/merge_requests/1"
+	testApprovers := []string{"alice", "bob"}
+
+	approval, err := NewGitLabMergeRequestApproval(testRef, testID, testID, testMergeRequest, testApprovers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, GitLabMergeRequestApprovalPredicateType, approval.PredicateType)
+
+	env, err := dsse.CreateEnvelope(approval)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := rsl.InitializeNamespace(repo); err != nil {
+		t.Fatal(err)
+	}
+	if err := InitializeNamespace(repo); err != nil {
+		t.Fatal(err)
+	}
+
+	attestations := &Attestations{}
+
+	err = attestations.SetGitLabMergeRequestApproval(repo, env, testAnotherRef, testID, testID)
+	assert.ErrorIs(t, err, ErrInvalidGitLabMergeRequestApproval)
+
+	err = attestations.SetGitLabMergeRequestApproval(repo, createReferenceAuthorizationAttestationEnvelopes(t, testRef, testID, testID), testRef, testID, testID)
+	assert.ErrorIs(t, err, ErrInvalidGitLabMergeRequestApproval)
+
+	err = attestations.SetGitLabMergeRequestApproval(repo, env, testRef, testID, testID)
+	assert.Nil(t, err)
+
+	if err := attestations.Commit(repo, "Add GitLab merge request approval", false); err != nil {
+		t.Fatal(err)
+	}
+
+	attestations, err = LoadCurrentAttestations(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	approvalEnv, approvers, err := attestations.GetGitLabMergeRequestApprovalFor(repo, testRef, testID, testID)
+	assert.Nil(t, err)
+	assert.Equal(t, env, approvalEnv)
+	assert.Equal(t, testApprovers, approvers)
+
+	_, _, err = attestations.GetGitLabMergeRequestApprovalFor(repo, testAnotherRef, testID, testID)
+	assert.ErrorIs(t, err, ErrGitLabMergeRequestApprovalNotFound)
+}
@@ -0,0 +1,134 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package attestations
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	ita "github.com/in-toto/attestation/go/v1"
+	sslibdsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
+)
+
+const SLSAProvenancePredicateType = "https://slsa.dev/provenance/v1"
+
+var (
+	ErrInvalidProvenance  = errors.New("attestation is not a valid SLSA provenance attestation")
+	ErrProvenanceNotFound = errors.New("requested provenance not found")
+)
+
+// Provenance is the subset of the SLSA v1 provenance predicate that gittuf
+// uses to verify release tags.
+type Provenance struct {
+	BuildDefinition ProvenanceBuildDefinition `json:"buildDefinition"`
+	RunDetails      ProvenanceRunDetails      `json:"runDetails"`
+}
+
+type ProvenanceBuildDefinition struct {
+	BuildType            string                         `json:"buildType"`
+	ResolvedDependencies []ProvenanceResourceDescriptor `json:"resolvedDependencies,omitempty"`
+}
+
+type ProvenanceResourceDescriptor struct {
+	URI    string            `json:"uri,omitempty"`
+	Digest map[string]string `json:"digest,omitempty"`
+}
+
+// GitCommit returns the Git commit ID recorded in the descriptor's digest.
+func (d *ProvenanceResourceDescriptor) GitCommit() string {
+	return d.Digest[digestGitCommitKey]
+}
+
+type ProvenanceRunDetails struct {
+	Builder ProvenanceBuilder `json:"builder"`
+}
+
+type ProvenanceBuilder struct {
+	ID string `json:"id"`
+}
+
+// Source returns the resolved dependency that identifies the Git commit the
+// artifacts were built from. If the provenance does not record a Git commit,
+// nil is returned.
+func (p *Provenance) Source() *ProvenanceResourceDescriptor {
+	for i := range p.BuildDefinition.ResolvedDependencies {
+		if _, has := p.BuildDefinition.ResolvedDependencies[i].Digest[digestGitCommitKey]; has {
+			return &p.BuildDefinition.ResolvedDependencies[i]
+		}
+	}
+
+	return nil
+}
+
+// SetProvenance writes the SLSA provenance attestation for the release tag to
+// the object store and tracks it in the current attestations state. Any
+// provenance previously attached to the tag is replaced.
+func (a *Attestations) SetProvenance(repo *git.Repository, env *sslibdsse.Envelope, tagRef string) error {
+	if _, err := ParseProvenance(env); err != nil {
+		return err
+	}
+
+	blobID, err := writeEnvelope(repo, env)
+	if err != nil {
+		return err
+	}
+
+	if a.provenance == nil {
+		a.provenance = map[string]plumbing.Hash{}
+	}
+
+	a.provenance[tagRef] = blobID
+	return nil
+}
+
+// GetProvenanceFor returns the SLSA provenance attestation (with its
+// signatures) attached to the release tag.
+func (a *Attestations) GetProvenanceFor(repo *git.Repository, tagRef string) (*sslibdsse.Envelope, error) {
+	blobID, has := a.provenance[tagRef]
+	if !has {
+		return nil, ErrProvenanceNotFound
+	}
+
+	env, err := readEnvelope(repo, blobID)
+	if err != nil {
+		return nil, err
+	}
+
+	return env, nil
+}
+
+// ParseProvenance returns the SLSA provenance predicate embedded in the
+// envelope's in-toto statement.
+func ParseProvenance(env *sslibdsse.Envelope) (*Provenance, error) {
+	payload, err := env.DecodeB64Payload()
+	if err != nil {
+		return nil, err
+	}
+
+	attestation := &ita.Statement{}
+	if err := json.Unmarshal(payload, attestation); err != nil {
+		return nil, errors.Join(ErrInvalidProvenance, err)
+	}
+
+	if attestation.Type != ita.StatementTypeUri || attestation.PredicateType != SLSAProvenancePredicateType || attestation.Predicate == nil {
+		return nil, ErrInvalidProvenance
+	}
+
+	predicateBytes, err := json.Marshal(attestation.Predicate.AsMap())
+	if err != nil {
+		return nil, err
+	}
+
+	provenance := &Provenance{}
+	if err := json.Unmarshal(predicateBytes, provenance); err != nil {
+		return nil, errors.Join(ErrInvalidProvenance, err)
+	}
+
+	if provenance.RunDetails.Builder.ID == "" {
+		return nil, ErrInvalidProvenance
+	}
+
+	return provenance, nil
+}
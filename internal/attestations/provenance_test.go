@@ -0,0 +1,108 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package attestations
+
+import (
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/internal/signerverifier/dsse"
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/storage/memory"
+	ita "github.com/in-toto/attestation/go/v1"
+	sslibdsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestProvenance(t *testing.T) {
+	testTagRef := "refs/tags/v1.0.0"
+	testBuilderID := "https://github.com/slsa-framework/slsa-github-generator/.github/workflows/generator_generic_slsa3.yml@refs/tags/v2.0.0"
+	testSourceURI := "git+https://github.com/gittuf/gittuf@refs/tags/v1.0.0"
+	testCommitID := "2f5d1d9b7c1b8b6f0b4c0e6a1d1c2c3b4a5f6e7d"
+
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := rsl.InitializeNamespace(repo); err != nil {
+		t.Fatal(err)
+	}
+	if err := InitializeNamespace(repo); err != nil {
+		t.Fatal(err)
+	}
+
+	attestations := &Attestations{}
+
+	_, err = attestations.GetProvenanceFor(repo, testTagRef)
+	assert.ErrorIs(t, err, ErrProvenanceNotFound)
+
+	err = attestations.SetProvenance(repo, createReferenceAuthorizationAttestationEnvelopes(t, testTagRef, testCommitID, testCommitID), testTagRef)
+	assert.ErrorIs(t, err, ErrInvalidProvenance)
+
+	err = attestations.SetProvenance(repo, createProvenanceEnvelope(t, "", testSourceURI, testCommitID), testTagRef)
+	assert.ErrorIs(t, err, ErrInvalidProvenance)
+
+	env := createProvenanceEnvelope(t, testBuilderID, testSourceURI, testCommitID)
+	err = attestations.SetProvenance(repo, env, testTagRef)
+	assert.Nil(t, err)
+
+	if err := attestations.Commit(repo, "Add provenance", false); err != nil {
+		t.Fatal(err)
+	}
+
+	attestations, err = LoadCurrentAttestations(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	provenanceEnv, err := attestations.GetProvenanceFor(repo, testTagRef)
+	assert.Nil(t, err)
+	assert.Equal(t, env, provenanceEnv)
+
+	provenance, err := ParseProvenance(provenanceEnv)
+	assert.Nil(t, err)
+	assert.Equal(t, testBuilderID, provenance.RunDetails.Builder.ID)
+	assert.Equal(t, testSourceURI, provenance.Source().URI)
+	assert.Equal(t, testCommitID, provenance.Source().GitCommit())
+}
+
+func createProvenanceEnvelope(t *testing.T, builderID, sourceURI, commitID string) *sslibdsse.Envelope {
+	t.Helper()
+
+	predicate, err := structpb.NewStruct(map[string]any{
+		"buildDefinition": map[string]any{
+			"buildType": "https://slsa-framework.github.io/github-actions-buildtypes/workflow/v1",
+			"resolvedDependencies": []any{
+				map[string]any{
+					"uri":    sourceURI,
+					"digest": map[string]any{digestGitCommitKey: commitID},
+				},
+			},
+		},
+		"runDetails": map[string]any{
+			"builder": map[string]any{"id": builderID},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	env, err := dsse.CreateEnvelope(&ita.Statement{
+		Type: ita.StatementTypeUri,
+		Subject: []*ita.ResourceDescriptor{
+			{
+				Name:   "gittuf-linux-amd64",
+				Digest: map[string]string{"sha256": "5b5fe4f0c5ab3d3d7e62e0ad7ea1f2c9d8d1e0cd1c19b2d2b48e0f7a64b7a4e1"},
+			},
+		},
+		PredicateType: SLSAProvenancePredicateType,
+		Predicate:     predicate,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return env
+}
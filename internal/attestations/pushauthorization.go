@@ -0,0 +1,162 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package attestations
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	ita "github.com/in-toto/attestation/go/v1"
+	sslibdsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const (
+	PushAuthorizationPredicateType = "https://gittuf.dev/push-authorization/v0.1"
+	digestGitCommitKey             = "gitCommit"
+)
+
+var (
+	ErrInvalidPushAuthorization  = errors.New("push authorization attestation does not match expected details")
+	ErrPushAuthorizationNotFound = errors.New("requested push authorization not found")
+)
+
+// PushAuthorization is a detached authorization of a specific update of
+// targetRef from fromRevisionID to targetRevisionID. Unlike a
+// ReferenceAuthorization, it identifies the new tip of the ref directly, so the
+// update can be performed by anyone, such as a bot, without the authorizer
+// computing the expected merge tree. It is meant to be used as a "predicate" in
+// an in-toto attestation.
+type PushAuthorization struct {
+	TargetRef        string `json:"targetRef"`
+	FromRevisionID   string `json:"fromRevisionID"`
+	TargetRevisionID string `json:"targetRevisionID"`
+}
+
+// NewPushAuthorization creates a new push authorization for the provided
+// information. The authorization is embedded in an in-toto "statement" and
+// returned with the appropriate "predicate type" set.
+func NewPushAuthorization(targetRef, fromRevisionID, targetRevisionID string) (*ita.Statement, error) {
+	predicate := &PushAuthorization{
+		TargetRef:        targetRef,
+		FromRevisionID:   fromRevisionID,
+		TargetRevisionID: targetRevisionID,
+	}
+
+	predicateBytes, err := json.Marshal(predicate)
+	if err != nil {
+		return nil, err
+	}
+
+	predicateInterface := &map[string]any{}
+	if err := json.Unmarshal(predicateBytes, predicateInterface); err != nil {
+		return nil, err
+	}
+
+	predicateStruct, err := structpb.NewStruct(*predicateInterface)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ita.Statement{
+		Type: ita.StatementTypeUri,
+		Subject: []*ita.ResourceDescriptor{
+			{
+				Digest: map[string]string{digestGitCommitKey: targetRevisionID},
+			},
+		},
+		PredicateType: PushAuthorizationPredicateType,
+		Predicate:     predicateStruct,
+	}, nil
+}
+
+// SetPushAuthorization writes the push authorization attestation to the object
+// store and tracks it in the current attestations state.
+func (a *Attestations) SetPushAuthorization(repo *git.Repository, env *sslibdsse.Envelope, refName, fromRevisionID, targetRevisionID string) error {
+	if err := validatePushAuthorization(env, refName, fromRevisionID, targetRevisionID); err != nil {
+		return err
+	}
+
+	blobID, err := writeEnvelope(repo, env)
+	if err != nil {
+		return err
+	}
+
+	if a.pushAuthorizations == nil {
+		a.pushAuthorizations = map[string]plumbing.Hash{}
+	}
+
+	a.pushAuthorizations[ReferenceAuthorizationPath(refName, fromRevisionID, targetRevisionID)] = blobID
+	return nil
+}
+
+// RemovePushAuthorization removes a set push authorization attestation
+// entirely. The object, however, isn't removed from the object store as prior
+// states may still need it.
+func (a *Attestations) RemovePushAuthorization(refName, fromRevisionID, targetRevisionID string) error {
+	authPath := ReferenceAuthorizationPath(refName, fromRevisionID, targetRevisionID)
+	if _, has := a.pushAuthorizations[authPath]; !has {
+		return ErrPushAuthorizationNotFound
+	}
+
+	delete(a.pushAuthorizations, authPath)
+	return nil
+}
+
+// GetPushAuthorizationFor returns the requested push authorization attestation
+// (with its signatures).
+func (a *Attestations) GetPushAuthorizationFor(repo *git.Repository, refName, fromRevisionID, targetRevisionID string) (*sslibdsse.Envelope, error) {
+	blobID, has := a.pushAuthorizations[ReferenceAuthorizationPath(refName, fromRevisionID, targetRevisionID)]
+	if !has {
+		return nil, ErrPushAuthorizationNotFound
+	}
+
+	env, err := readEnvelope(repo, blobID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := validatePushAuthorization(env, refName, fromRevisionID, targetRevisionID); err != nil {
+		return nil, err
+	}
+
+	return env, nil
+}
+
+func validatePushAuthorization(env *sslibdsse.Envelope, targetRef, fromRevisionID, targetRevisionID string) error {
+	payload, err := env.DecodeB64Payload()
+	if err != nil {
+		return err
+	}
+
+	attestation := &ita.Statement{}
+	if err := json.Unmarshal(payload, attestation); err != nil {
+		return err
+	}
+
+	if attestation.PredicateType != PushAuthorizationPredicateType {
+		return ErrInvalidPushAuthorization
+	}
+
+	if len(attestation.Subject) == 0 || attestation.Subject[0].Digest[digestGitCommitKey] != targetRevisionID {
+		return ErrInvalidPushAuthorization
+	}
+
+	predicateBytes, err := json.Marshal(attestation.Predicate.AsMap())
+	if err != nil {
+		return err
+	}
+
+	authorization := &PushAuthorization{}
+	if err := json.Unmarshal(predicateBytes, authorization); err != nil {
+		return err
+	}
+
+	if authorization.TargetRef != targetRef || authorization.FromRevisionID != fromRevisionID || authorization.TargetRevisionID != targetRevisionID {
+		return ErrInvalidPushAuthorization
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package attestations
+
+import (
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/internal/signerverifier/dsse"
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPushAuthorization(t *testing.T) {
+	testRef := "refs/heads/main"
+	testAnotherRef := "refs/heads/feature"
+	testFromID := plumbing.ZeroHash.String()
+	testToID := "2f5d1d9b7c1b8b6f0b4c0e6a1d1c2c3b4a5f6e7d"
+
+	authorization, err := NewPushAuthorization(testRef, testFromID, testToID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, PushAuthorizationPredicateType, authorization.PredicateType)
+	assert.Equal(t, testToID, authorization.Subject[0].Digest[digestGitCommitKey])
+
+	env, err := dsse.CreateEnvelope(authorization)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := rsl.InitializeNamespace(repo); err != nil {
+		t.Fatal(err)
+	}
+	if err := InitializeNamespace(repo); err != nil {
+		t.Fatal(err)
+	}
+
+	attestations := &Attestations{}
+
+	err = attestations.SetPushAuthorization(repo, env, testAnotherRef, testFromID, testToID)
+	assert.ErrorIs(t, err, ErrInvalidPushAuthorization)
+
+	err = attestations.SetPushAuthorization(repo, createReferenceAuthorizationAttestationEnvelopes(t, testRef, testFromID, testToID), testRef, testFromID, testToID)
+	assert.ErrorIs(t, err, ErrInvalidPushAuthorization)
+
+	err = attestations.SetPushAuthorization(repo, env, testRef, testFromID, testToID)
+	assert.Nil(t, err)
+
+	if err := attestations.Commit(repo, "Add push authorization", false); err != nil {
+		t.Fatal(err)
+	}
+
+	attestations, err = LoadCurrentAttestations(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	authorizationEnv, err := attestations.GetPushAuthorizationFor(repo, testRef, testFromID, testToID)
+	assert.Nil(t, err)
+	assert.Equal(t, env, authorizationEnv)
+
+	// The tree-based reference authorization is unaffected
+	_, err = attestations.GetReferenceAuthorizationFor(repo, testRef, testFromID, testToID)
+	assert.ErrorIs(t, err, ErrAuthorizationNotFound)
+
+	err = attestations.RemovePushAuthorization(testAnotherRef, testFromID, testToID)
+	assert.ErrorIs(t, err, ErrPushAuthorizationNotFound)
+
+	err = attestations.RemovePushAuthorization(testRef, testFromID, testToID)
+	assert.Nil(t, err)
+
+	_, err = attestations.GetPushAuthorizationFor(repo, testRef, testFromID, testToID)
+	assert.ErrorIs(t, err, ErrPushAuthorizationNotFound)
+}
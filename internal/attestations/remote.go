@@ -0,0 +1,96 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package attestations
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/gittuf/gittuf/internal/attestationstore"
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	sslibdsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
+)
+
+const (
+	// StoreConfigSection and StoreConfigKey identify the Git config option
+	// `gittuf.attestationStore` that sets the external store attestations
+	// are written to. When unset, attestations are written to the repository.
+	StoreConfigSection = "gittuf"
+	StoreConfigKey     = "attestationStore"
+)
+
+// writeEnvelope writes the envelope to the object store, returning the ID of
+// the blob that must be tracked in the attestations state. If an external
+// attestation store is configured for the repository, the envelope is written
+// to the store and the blob only records a pointer to it.
+func writeEnvelope(repo *git.Repository, env *sslibdsse.Envelope) (plumbing.Hash, error) {
+	envBytes, err := json.Marshal(env)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	store, err := getConfiguredStore(repo)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	if store != nil {
+		slog.Debug(fmt.Sprintf("Writing attestation to store '%s'...", store.Location()))
+		pointer, err := attestationstore.Upload(context.Background(), store, envBytes)
+		if err != nil {
+			return plumbing.ZeroHash, err
+		}
+
+		envBytes, err = pointer.Bytes()
+		if err != nil {
+			return plumbing.ZeroHash, err
+		}
+	}
+
+	return gitinterface.WriteBlob(repo, envBytes)
+}
+
+// readEnvelope returns the envelope tracked using the blob. If the blob
+// records a pointer to an attestation in an external store, the attestation
+// is fetched from the store and checked against the digest in the pointer.
+func readEnvelope(repo *git.Repository, blobID plumbing.Hash) (*sslibdsse.Envelope, error) {
+	envBytes, err := gitinterface.ReadBlob(repo, blobID)
+	if err != nil {
+		return nil, err
+	}
+
+	if pointer, isPointer := attestationstore.ParsePointer(envBytes); isPointer {
+		slog.Debug(fmt.Sprintf("Fetching attestation '%s' from store '%s'...", pointer.Ref, pointer.Store))
+		envBytes, err = pointer.Fetch(context.Background())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	env := &sslibdsse.Envelope{}
+	if err := json.Unmarshal(envBytes, env); err != nil {
+		return nil, err
+	}
+
+	return env, nil
+}
+
+// getConfiguredStore returns the external attestation store configured for
+// the repository, if any.
+func getConfiguredStore(repo *git.Repository) (attestationstore.Store, error) {
+	config, err := repo.Config()
+	if err != nil {
+		return nil, err
+	}
+
+	location := config.Raw.Section(StoreConfigSection).Option(StoreConfigKey)
+	if location == "" {
+		return nil, nil
+	}
+
+	return attestationstore.NewStore(location)
+}
@@ -0,0 +1,96 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package attestations
+
+import (
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/attestationstore"
+	"github.com/gittuf/gittuf/internal/common"
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRemoteAttestationStore(t *testing.T) {
+	testRef := "refs/heads/main"
+	testFromID := plumbing.ZeroHash.String()
+	testToID := "2f5d1d9b7c1b8b6f0b4c0e6a1d1c2c3b4a5f6e7d"
+
+	server := common.NewTestArchivistaServer(t)
+	storeLocation := attestationstore.ArchivistaScheme + server.URL
+
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := rsl.InitializeNamespace(repo); err != nil {
+		t.Fatal(err)
+	}
+	if err := InitializeNamespace(repo); err != nil {
+		t.Fatal(err)
+	}
+
+	config, err := repo.Config()
+	if err != nil {
+		t.Fatal(err)
+	}
+	config.Raw.Section(StoreConfigSection).SetOption(StoreConfigKey, storeLocation)
+	if err := repo.SetConfig(config); err != nil {
+		t.Fatal(err)
+	}
+
+	env := createReferenceAuthorizationAttestationEnvelopes(t, testRef, testFromID, testToID)
+
+	attestations := &Attestations{}
+	err = attestations.SetReferenceAuthorization(repo, env, testRef, testFromID, testToID)
+	assert.Nil(t, err)
+
+	if err := attestations.Commit(repo, "Add remote attestation", false); err != nil {
+		t.Fatal(err)
+	}
+
+	// The repository only records a pointer to the attestation
+	blob, err := gitinterface.ReadBlob(repo, attestations.referenceAuthorizations[ReferenceAuthorizationPath(testRef, testFromID, testToID)])
+	if err != nil {
+		t.Fatal(err)
+	}
+	pointer, isPointer := attestationstore.ParsePointer(blob)
+	assert.True(t, isPointer)
+	assert.Equal(t, storeLocation, pointer.Store)
+
+	// The attestation is fetched from the store without the store being
+	// configured
+	config.Raw.Section(StoreConfigSection).RemoveOption(StoreConfigKey)
+	if err := repo.SetConfig(config); err != nil {
+		t.Fatal(err)
+	}
+
+	attestations, err = LoadCurrentAttestations(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fetchedEnv, err := attestations.GetReferenceAuthorizationFor(repo, testRef, testFromID, testToID)
+	assert.Nil(t, err)
+	assert.Equal(t, env, fetchedEnv)
+
+	// The attestation must match the digest recorded in the pointer
+	pointer.Digest = "sha256:0000000000000000000000000000000000000000000000000000000000000000"
+	pointerBytes, err := pointer.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	blobID, err := gitinterface.WriteBlob(repo, pointerBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	attestations.referenceAuthorizations[ReferenceAuthorizationPath(testRef, testFromID, testToID)] = blobID
+
+	_, err = attestations.GetReferenceAuthorizationFor(repo, testRef, testFromID, testToID)
+	assert.ErrorIs(t, err, attestationstore.ErrDigestMismatch)
+}
@@ -0,0 +1,50 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package attestations
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+var ErrSignatureTimestampNotFound = errors.New("requested signature timestamp not found")
+
+// SetSignatureTimestamp writes the RFC 3161 timestamp response issued for the
+// signature to the object store and tracks it in the current attestations
+// state.
+func (a *Attestations) SetSignatureTimestamp(repo *git.Repository, signature, response []byte) error {
+	blobID, err := gitinterface.WriteBlob(repo, response)
+	if err != nil {
+		return err
+	}
+
+	if a.signatureTimestamps == nil {
+		a.signatureTimestamps = map[string]plumbing.Hash{}
+	}
+
+	a.signatureTimestamps[SignatureTimestampPath(signature)] = blobID
+	return nil
+}
+
+// GetSignatureTimestampFor returns the RFC 3161 timestamp response issued for
+// the signature.
+func (a *Attestations) GetSignatureTimestampFor(repo *git.Repository, signature []byte) ([]byte, error) {
+	blobID, has := a.signatureTimestamps[SignatureTimestampPath(signature)]
+	if !has {
+		return nil, ErrSignatureTimestampNotFound
+	}
+
+	return gitinterface.ReadBlob(repo, blobID)
+}
+
+// SignatureTimestampPath returns the path used to track the timestamp for the
+// signature, which is the hex encoded SHA-256 hash of the signature.
+func SignatureTimestampPath(signature []byte) string {
+	hash := sha256.Sum256(signature)
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,53 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package attestations
+
+import (
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignatureTimestamp(t *testing.T) {
+	testSignature := []byte("signature")
+	testResponse := []byte("response")
+
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := rsl.InitializeNamespace(repo); err != nil {
+		t.Fatal(err)
+	}
+	if err := InitializeNamespace(repo); err != nil {
+		t.Fatal(err)
+	}
+
+	attestations := &Attestations{}
+
+	_, err = attestations.GetSignatureTimestampFor(repo, testSignature)
+	assert.ErrorIs(t, err, ErrSignatureTimestampNotFound)
+
+	err = attestations.SetSignatureTimestamp(repo, testSignature, testResponse)
+	assert.Nil(t, err)
+
+	if err := attestations.Commit(repo, "Add signature timestamp", false); err != nil {
+		t.Fatal(err)
+	}
+
+	attestations, err = LoadCurrentAttestations(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response, err := attestations.GetSignatureTimestampFor(repo, testSignature)
+	assert.Nil(t, err)
+	assert.Equal(t, testResponse, response)
+
+	_, err = attestations.GetSignatureTimestampFor(repo, []byte("other signature"))
+	assert.ErrorIs(t, err, ErrSignatureTimestampNotFound)
+}
@@ -0,0 +1,103 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package attestations
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"path"
+	"sort"
+
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	sslibdsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
+)
+
+var ErrTransparencyLogEntryNotFound = errors.New("requested transparency log entry not found")
+
+// SetTransparencyLogEntry writes the transparency log entry that records the
+// signature by the key with keyID on the envelope to the object store and
+// tracks it in the current attestations state.
+func (a *Attestations) SetTransparencyLogEntry(repo *git.Repository, env *sslibdsse.Envelope, keyID string, entry []byte) error {
+	entryPath, err := TransparencyLogEntryPath(env, keyID)
+	if err != nil {
+		return err
+	}
+
+	blobID, err := gitinterface.WriteBlob(repo, entry)
+	if err != nil {
+		return err
+	}
+
+	if a.transparencyLogEntries == nil {
+		a.transparencyLogEntries = map[string]plumbing.Hash{}
+	}
+
+	a.transparencyLogEntries[entryPath] = blobID
+	return nil
+}
+
+// GetTransparencyLogEntryFor returns the transparency log entry that records
+// the signature by the key with keyID on the envelope.
+func (a *Attestations) GetTransparencyLogEntryFor(repo *git.Repository, env *sslibdsse.Envelope, keyID string) ([]byte, error) {
+	entryPath, err := TransparencyLogEntryPath(env, keyID)
+	if err != nil {
+		return nil, err
+	}
+
+	blobID, has := a.transparencyLogEntries[entryPath]
+	if !has {
+		return nil, ErrTransparencyLogEntryNotFound
+	}
+
+	return gitinterface.ReadBlob(repo, blobID)
+}
+
+// GetAllEnvelopes returns every attestation tracked in the current attestations
+// state. Transparency log entries are not included as they are not signed
+// envelopes.
+func (a *Attestations) GetAllEnvelopes(repo *git.Repository) ([]*sslibdsse.Envelope, error) {
+	envelopes := []*sslibdsse.Envelope{}
+	for _, attestations := range []map[string]plumbing.Hash{
+		a.referenceAuthorizations,
+		a.githubPullRequestApprovals,
+		a.gitlabMergeRequestApprovals,
+		a.codeReviews,
+		a.pushAuthorizations,
+		a.provenance,
+		a.verificationSummaries,
+	} {
+		paths := make([]string, 0, len(attestations))
+		for attestationPath := range attestations {
+			paths = append(paths, attestationPath)
+		}
+		sort.Strings(paths)
+
+		for _, attestationPath := range paths {
+			env, err := readEnvelope(repo, attestations[attestationPath])
+			if err != nil {
+				return nil, err
+			}
+
+			envelopes = append(envelopes, env)
+		}
+	}
+
+	return envelopes, nil
+}
+
+// TransparencyLogEntryPath returns the path used to track the transparency log
+// entry for the signature by the key with keyID on the envelope. The path is of
+// the form `<hash of payload>/<hash of key ID>`.
+func TransparencyLogEntryPath(env *sslibdsse.Envelope, keyID string) (string, error) {
+	payload, err := env.DecodeB64Payload()
+	if err != nil {
+		return "", err
+	}
+
+	payloadHash := sha256.Sum256(payload)
+	keyIDHash := sha256.Sum256([]byte(keyID))
+	return path.Join(hex.EncodeToString(payloadHash[:]), hex.EncodeToString(keyIDHash[:])), nil
+}
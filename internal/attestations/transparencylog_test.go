@@ -0,0 +1,68 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package attestations
+
+import (
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransparencyLogEntry(t *testing.T) {
+	testRef := "refs/heads/main"
+	testFromID := plumbing.ZeroHash.String()
+	testToID := "2f5d1d9b7c1b8b6f0b4c0e6a1d1c2c3b4a5f6e7d"
+	testKeyID := "SHA256:ESJezAOo+BsiEpddzRXS6+wtF16FID4NCd+3gj96rFo"
+	testEntry := []byte(`{"logIndex": 1}`)
+
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := rsl.InitializeNamespace(repo); err != nil {
+		t.Fatal(err)
+	}
+	if err := InitializeNamespace(repo); err != nil {
+		t.Fatal(err)
+	}
+
+	env := createReferenceAuthorizationAttestationEnvelopes(t, testRef, testFromID, testToID)
+
+	attestations := &Attestations{}
+	if err := attestations.SetReferenceAuthorization(repo, env, testRef, testFromID, testToID); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = attestations.GetTransparencyLogEntryFor(repo, env, testKeyID)
+	assert.ErrorIs(t, err, ErrTransparencyLogEntryNotFound)
+
+	err = attestations.SetTransparencyLogEntry(repo, env, testKeyID, testEntry)
+	assert.Nil(t, err)
+
+	if err := attestations.Commit(repo, "Add transparency log entry", false); err != nil {
+		t.Fatal(err)
+	}
+
+	attestations, err = LoadCurrentAttestations(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entry, err := attestations.GetTransparencyLogEntryFor(repo, env, testKeyID)
+	assert.Nil(t, err)
+	assert.Equal(t, testEntry, entry)
+
+	_, err = attestations.GetTransparencyLogEntryFor(repo, env, "other-key")
+	assert.ErrorIs(t, err, ErrTransparencyLogEntryNotFound)
+
+	// Log entries are not included in the envelopes
+	envelopes, err := attestations.GetAllEnvelopes(repo)
+	assert.Nil(t, err)
+	assert.Len(t, envelopes, 1)
+	assert.Equal(t, env, envelopes[0])
+}
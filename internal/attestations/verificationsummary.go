@@ -0,0 +1,176 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package attestations
+
+import (
+	"encoding/json"
+	"errors"
+	"path"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	ita "github.com/in-toto/attestation/go/v1"
+	sslibdsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+const (
+	VerificationSummaryPredicateType = "https://slsa.dev/verification_summary/v1"
+	VerificationSummaryVerifierID    = "https://gittuf.dev/verifier"
+
+	VerificationResultPassed = "PASSED"
+	VerificationResultFailed = "FAILED"
+)
+
+var (
+	ErrInvalidVerificationSummary  = errors.New("attestation is not a valid verification summary attestation")
+	ErrVerificationSummaryNotFound = errors.New("requested verification summary not found")
+)
+
+// VerificationSummary is a SLSA verification summary attestation (VSA)
+// predicate recording the result of gittuf verifying a ref. The subject of the
+// attestation is the ref's tip that was verified.
+type VerificationSummary struct {
+	Verifier           VerificationSummaryVerifier   `json:"verifier"`
+	TimeVerified       string                        `json:"timeVerified"`
+	ResourceURI        string                        `json:"resourceUri"`
+	Policy             VerificationSummaryResource   `json:"policy"`
+	InputAttestations  []VerificationSummaryResource `json:"inputAttestations,omitempty"`
+	VerificationResult string                        `json:"verificationResult"`
+	VerifiedLevels     []string                      `json:"verifiedLevels"`
+}
+
+type VerificationSummaryVerifier struct {
+	ID      string            `json:"id"`
+	Version map[string]string `json:"version,omitempty"`
+}
+
+type VerificationSummaryResource struct {
+	URI    string            `json:"uri"`
+	Digest map[string]string `json:"digest"`
+}
+
+// NewVerificationSummary creates a new verification summary recording whether
+// the ref at commitID passed verification against the policy. The summary is
+// embedded in an in-toto "statement" and returned with the appropriate
+// "predicate type" set.
+func NewVerificationSummary(refName, commitID, resourceURI string, policy VerificationSummaryResource, inputAttestations []VerificationSummaryResource, verifierVersion string, passed bool, timeVerified time.Time) (*ita.Statement, error) {
+	result := VerificationResultFailed
+	if passed {
+		result = VerificationResultPassed
+	}
+
+	predicate := &VerificationSummary{
+		Verifier: VerificationSummaryVerifier{
+			ID:      VerificationSummaryVerifierID,
+			Version: map[string]string{"gittuf": verifierVersion},
+		},
+		TimeVerified:       timeVerified.UTC().Format(time.RFC3339),
+		ResourceURI:        resourceURI,
+		Policy:             policy,
+		InputAttestations:  inputAttestations,
+		VerificationResult: result,
+		VerifiedLevels:     []string{},
+	}
+
+	predicateBytes, err := json.Marshal(predicate)
+	if err != nil {
+		return nil, err
+	}
+
+	predicateInterface := &map[string]any{}
+	if err := json.Unmarshal(predicateBytes, predicateInterface); err != nil {
+		return nil, err
+	}
+
+	predicateStruct, err := structpb.NewStruct(*predicateInterface)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ita.Statement{
+		Type: ita.StatementTypeUri,
+		Subject: []*ita.ResourceDescriptor{
+			{
+				Name:   refName,
+				Digest: map[string]string{digestGitCommitKey: commitID},
+			},
+		},
+		PredicateType: VerificationSummaryPredicateType,
+		Predicate:     predicateStruct,
+	}, nil
+}
+
+// SetVerificationSummary writes the verification summary attestation to the
+// object store and tracks it in the current attestations state. The summary is
+// tracked for the ref and commit in its subject, replacing any prior summary
+// for them.
+func (a *Attestations) SetVerificationSummary(repo *git.Repository, env *sslibdsse.Envelope) error {
+	refName, commitID, _, err := ParseVerificationSummary(env)
+	if err != nil {
+		return err
+	}
+
+	blobID, err := writeEnvelope(repo, env)
+	if err != nil {
+		return err
+	}
+
+	if a.verificationSummaries == nil {
+		a.verificationSummaries = map[string]plumbing.Hash{}
+	}
+
+	a.verificationSummaries[path.Join(refName, commitID)] = blobID
+	return nil
+}
+
+// GetVerificationSummaryFor returns the verification summary attestation (with
+// its signatures) recorded for the ref at commitID.
+func (a *Attestations) GetVerificationSummaryFor(repo *git.Repository, refName, commitID string) (*sslibdsse.Envelope, error) {
+	blobID, has := a.verificationSummaries[path.Join(refName, commitID)]
+	if !has {
+		return nil, ErrVerificationSummaryNotFound
+	}
+
+	env, err := readEnvelope(repo, blobID)
+	if err != nil {
+		return nil, err
+	}
+
+	return env, nil
+}
+
+// ParseVerificationSummary returns the ref and commit ID the verification
+// summary was created for along with the summary itself.
+func ParseVerificationSummary(env *sslibdsse.Envelope) (string, string, *VerificationSummary, error) {
+	payload, err := env.DecodeB64Payload()
+	if err != nil {
+		return "", "", nil, err
+	}
+
+	attestation := &ita.Statement{}
+	if err := json.Unmarshal(payload, attestation); err != nil {
+		return "", "", nil, errors.Join(ErrInvalidVerificationSummary, err)
+	}
+
+	if attestation.PredicateType != VerificationSummaryPredicateType || attestation.Predicate == nil {
+		return "", "", nil, ErrInvalidVerificationSummary
+	}
+
+	if len(attestation.Subject) != 1 || attestation.Subject[0].Name == "" || attestation.Subject[0].Digest[digestGitCommitKey] == "" {
+		return "", "", nil, ErrInvalidVerificationSummary
+	}
+
+	predicateBytes, err := json.Marshal(attestation.Predicate.AsMap())
+	if err != nil {
+		return "", "", nil, err
+	}
+
+	summary := &VerificationSummary{}
+	if err := json.Unmarshal(predicateBytes, summary); err != nil {
+		return "", "", nil, errors.Join(ErrInvalidVerificationSummary, err)
+	}
+
+	return attestation.Subject[0].Name, attestation.Subject[0].Digest[digestGitCommitKey], summary, nil
+}
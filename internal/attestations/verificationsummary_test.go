@@ -0,0 +1,83 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package attestations
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/internal/signerverifier/dsse"
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerificationSummary(t *testing.T) {
+	testRef := "refs/heads/main"
+	testCommitID := "2f5d1d9b7c1b8b6f0b4c0e6a1d1c2c3b4a5f6e7d"
+	testPolicy := VerificationSummaryResource{
+		URI:    "git+https://example.com/repo@refs/gittuf/policy",
+		Digest: map[string]string{digestGitCommitKey: "7ae1e1e8a4b4b2ad6e5a0c5b1f0d4c6c8e9a7b3d"},
+	}
+	testTime := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+	summary, err := NewVerificationSummary(testRef, testCommitID, "git+https://example.com/repo@refs/heads/main", testPolicy, nil, "v0.1.0", true, testTime)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, VerificationSummaryPredicateType, summary.PredicateType)
+	assert.Equal(t, testRef, summary.Subject[0].Name)
+	assert.Equal(t, testCommitID, summary.Subject[0].Digest[digestGitCommitKey])
+
+	env, err := dsse.CreateEnvelope(summary)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := rsl.InitializeNamespace(repo); err != nil {
+		t.Fatal(err)
+	}
+	if err := InitializeNamespace(repo); err != nil {
+		t.Fatal(err)
+	}
+
+	attestations := &Attestations{}
+
+	err = attestations.SetVerificationSummary(repo, createReferenceAuthorizationAttestationEnvelopes(t, testRef, testCommitID, testCommitID))
+	assert.ErrorIs(t, err, ErrInvalidVerificationSummary)
+
+	err = attestations.SetVerificationSummary(repo, env)
+	assert.Nil(t, err)
+
+	if err := attestations.Commit(repo, "Add verification summary", false); err != nil {
+		t.Fatal(err)
+	}
+
+	attestations, err = LoadCurrentAttestations(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = attestations.GetVerificationSummaryFor(repo, "refs/heads/feature", testCommitID)
+	assert.ErrorIs(t, err, ErrVerificationSummaryNotFound)
+
+	summaryEnv, err := attestations.GetVerificationSummaryFor(repo, testRef, testCommitID)
+	assert.Nil(t, err)
+	assert.Equal(t, env, summaryEnv)
+
+	refName, commitID, predicate, err := ParseVerificationSummary(summaryEnv)
+	assert.Nil(t, err)
+	assert.Equal(t, testRef, refName)
+	assert.Equal(t, testCommitID, commitID)
+	assert.Equal(t, VerificationResultPassed, predicate.VerificationResult)
+	assert.Equal(t, VerificationSummaryVerifierID, predicate.Verifier.ID)
+	assert.Equal(t, "v0.1.0", predicate.Verifier.Version["gittuf"])
+	assert.Equal(t, "2024-01-01T00:00:00Z", predicate.TimeVerified)
+	assert.Equal(t, testPolicy, predicate.Policy)
+}
@@ -0,0 +1,88 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package attestationstore
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+type archivistaStore struct {
+	location string
+	url      string
+}
+
+func newArchivistaStore(location string) (*archivistaStore, error) {
+	storeURL := strings.TrimSuffix(strings.TrimPrefix(location, ArchivistaScheme), "/")
+	parsedURL, err := url.Parse(storeURL)
+	if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || parsedURL.Host == "" {
+		return nil, fmt.Errorf("%w: '%s'", ErrInvalidLocation, location)
+	}
+
+	return &archivistaStore{location: location, url: storeURL}, nil
+}
+
+func (s *archivistaStore) Location() string {
+	return s.location
+}
+
+// Put uploads the contents to Archivista, returning the gitoid Archivista
+// assigns to them.
+func (s *archivistaStore) Put(ctx context.Context, contents []byte) (string, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url+"/upload", bytes.NewReader(contents))
+	if err != nil {
+		return "", err
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	responseBytes, err := doArchivistaRequest(request)
+	if err != nil {
+		return "", err
+	}
+
+	response := struct {
+		Gitoid string `json:"gitoid"`
+	}{}
+	if err := json.Unmarshal(responseBytes, &response); err != nil {
+		return "", err
+	}
+	if response.Gitoid == "" {
+		return "", fmt.Errorf("archivista did not return gitoid for upload")
+	}
+
+	return response.Gitoid, nil
+}
+
+func (s *archivistaStore) Get(ctx context.Context, ref string) ([]byte, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url+"/download/"+url.PathEscape(ref), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return doArchivistaRequest(request)
+}
+
+func doArchivistaRequest(request *http.Request) ([]byte, error) {
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close() //nolint:errcheck
+
+	responseBytes, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("archivista request to '%s' failed with status %d: %s", request.URL.Path, response.StatusCode, strings.TrimSpace(string(responseBytes)))
+	}
+
+	return responseBytes, nil
+}
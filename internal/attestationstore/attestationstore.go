@@ -0,0 +1,135 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Package attestationstore stores gittuf attestations in an external store
+// such as Archivista or an OCI registry. The attestations namespace then only
+// records a pointer to each attestation, identified by the digest of its
+// contents, rather than the attestation itself.
+package attestationstore
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+const (
+	ArchivistaScheme = "archivista+"
+	OCIScheme        = "oci://"
+
+	digestAlgorithm = "sha256"
+)
+
+var (
+	ErrUnknownStore    = errors.New("unknown attestation store, expected location prefixed with 'archivista+' or 'oci://'")
+	ErrDigestMismatch  = errors.New("attestation fetched from store does not match recorded digest")
+	ErrInvalidPointer  = errors.New("invalid remote attestation pointer")
+	ErrInvalidLocation = errors.New("invalid attestation store location")
+)
+
+// Store is an external store for attestations.
+type Store interface {
+	// Location returns the location of the store, which can be passed to
+	// NewStore to connect to the store again.
+	Location() string
+
+	// Put writes the contents to the store and returns the reference used
+	// by the store to identify the contents.
+	Put(ctx context.Context, contents []byte) (string, error)
+
+	// Get returns the contents identified by the reference in the store.
+	Get(ctx context.Context, ref string) ([]byte, error)
+}
+
+// NewStore returns the store at the location. Archivista instances are
+// identified as `archivista+<url>`, while OCI repositories are identified as
+// `oci://<registry>/<repository>`.
+func NewStore(location string) (Store, error) {
+	switch {
+	case strings.HasPrefix(location, ArchivistaScheme):
+		return newArchivistaStore(location)
+	case strings.HasPrefix(location, OCIScheme):
+		return newOCIStore(location)
+	default:
+		return nil, ErrUnknownStore
+	}
+}
+
+// Pointer identifies an attestation written to an external store.
+type Pointer struct {
+	Store  string `json:"store"`
+	Ref    string `json:"ref"`
+	Digest string `json:"digest"`
+}
+
+type pointerWrapper struct {
+	RemoteAttestation *Pointer `json:"remoteAttestation"`
+}
+
+// Upload writes the contents to the store and returns a pointer to them.
+func Upload(ctx context.Context, store Store, contents []byte) (*Pointer, error) {
+	ref, err := store.Put(ctx, contents)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Pointer{
+		Store:  store.Location(),
+		Ref:    ref,
+		Digest: computeDigest(contents),
+	}, nil
+}
+
+// ParsePointer returns the pointer encoded in contents. If contents do not
+// encode a pointer, false is returned.
+func ParsePointer(contents []byte) (*Pointer, bool) {
+	if !bytes.Contains(contents, []byte(`"remoteAttestation"`)) {
+		return nil, false
+	}
+
+	wrapper := &pointerWrapper{}
+	if err := json.Unmarshal(contents, wrapper); err != nil || wrapper.RemoteAttestation == nil {
+		return nil, false
+	}
+
+	return wrapper.RemoteAttestation, true
+}
+
+// Bytes returns the encoded pointer.
+func (p *Pointer) Bytes() ([]byte, error) {
+	return json.Marshal(&pointerWrapper{RemoteAttestation: p})
+}
+
+// Fetch returns the contents the pointer identifies. The contents are looked
+// up using the digest recorded in the pointer, so contents that have been
+// modified in the store are rejected.
+func (p *Pointer) Fetch(ctx context.Context) ([]byte, error) {
+	if p.Store == "" || p.Ref == "" || p.Digest == "" {
+		return nil, ErrInvalidPointer
+	}
+
+	store, err := NewStore(p.Store)
+	if err != nil {
+		return nil, err
+	}
+
+	contents, err := store.Get(ctx, p.Ref)
+	if err != nil {
+		return nil, err
+	}
+
+	if computeDigest(contents) != p.Digest {
+		return nil, fmt.Errorf("%w: '%s' in '%s'", ErrDigestMismatch, p.Ref, p.Store)
+	}
+
+	return contents, nil
+}
+
+func computeDigest(contents []byte) string {
+	digest := sha256.Sum256(contents)
+	return fmt.Sprintf("%s:%s", digestAlgorithm, hex.EncodeToString(digest[:]))
+}
@@ -0,0 +1,86 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package attestationstore
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/common"
+	"github.com/google/go-containerregistry/pkg/registry"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUploadAndFetch(t *testing.T) {
+	ctx := context.Background()
+	contents := []byte(`{"payloadType":"application/vnd.in-toto+json","payload":"e30=","signatures":[]}`)
+
+	archivistaServer := common.NewTestArchivistaServer(t)
+	registryServer := httptest.NewServer(registry.New())
+	t.Cleanup(registryServer.Close)
+
+	tests := map[string]struct {
+		location string
+	}{
+		"archivista": {location: ArchivistaScheme + archivistaServer.URL},
+		"oci":        {location: OCIScheme + strings.TrimPrefix(registryServer.URL, "http://") + "/gittuf/attestations"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			store, err := NewStore(test.location)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			pointer, err := Upload(ctx, store, contents)
+			assert.Nil(t, err)
+			assert.Equal(t, test.location, pointer.Store)
+			assert.True(t, strings.HasPrefix(pointer.Digest, "sha256:"))
+
+			pointerBytes, err := pointer.Bytes()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			parsedPointer, isPointer := ParsePointer(pointerBytes)
+			assert.True(t, isPointer)
+			assert.Equal(t, pointer, parsedPointer)
+
+			fetchedContents, err := parsedPointer.Fetch(ctx)
+			assert.Nil(t, err)
+			assert.Equal(t, contents, fetchedContents)
+
+			// Contents are looked up by their digest
+			parsedPointer.Digest = "sha256:0000000000000000000000000000000000000000000000000000000000000000"
+			_, err = parsedPointer.Fetch(ctx)
+			assert.ErrorIs(t, err, ErrDigestMismatch)
+		})
+	}
+}
+
+func TestNewStore(t *testing.T) {
+	_, err := NewStore("https://archivista.example.com")
+	assert.ErrorIs(t, err, ErrUnknownStore)
+
+	_, err = NewStore("archivista+ftp://archivista.example.com")
+	assert.ErrorIs(t, err, ErrInvalidLocation)
+
+	_, err = NewStore("oci://INVALID")
+	assert.ErrorIs(t, err, ErrInvalidLocation)
+
+	store, err := NewStore("oci://ghcr.io/gittuf/attestations")
+	assert.Nil(t, err)
+	assert.Equal(t, "oci://ghcr.io/gittuf/attestations", store.Location())
+}
+
+func TestParsePointer(t *testing.T) {
+	_, isPointer := ParsePointer([]byte(`{"payloadType":"application/vnd.in-toto+json","payload":"e30=","signatures":[]}`))
+	assert.False(t, isPointer)
+
+	pointer, isPointer := ParsePointer([]byte(`{"remoteAttestation":{"store":"oci://ghcr.io/gittuf/attestations","ref":"sha256:abcd","digest":"sha256:abcd"}}`))
+	assert.True(t, isPointer)
+	assert.Equal(t, &Pointer{Store: "oci://ghcr.io/gittuf/attestations", Ref: "sha256:abcd", Digest: "sha256:abcd"}, pointer)
+}
@@ -0,0 +1,95 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package attestationstore
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+)
+
+const (
+	// attestationMediaType is the media type of the layer holding the
+	// attestation in the OCI manifest.
+	attestationMediaType types.MediaType = "application/vnd.dsse.envelope.v1+json"
+)
+
+type ociStore struct {
+	location   string
+	repository name.Repository
+}
+
+func newOCIStore(location string) (*ociStore, error) {
+	repository, err := name.NewRepository(strings.TrimPrefix(location, OCIScheme))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidLocation, err)
+	}
+
+	return &ociStore{location: location, repository: repository}, nil
+}
+
+func (s *ociStore) Location() string {
+	return s.location
+}
+
+// Put pushes a manifest with the contents as its only layer to the OCI
+// repository, returning the digest of the manifest.
+func (s *ociStore) Put(ctx context.Context, contents []byte) (string, error) {
+	image, err := mutate.AppendLayers(empty.Image, static.NewLayer(contents, attestationMediaType))
+	if err != nil {
+		return "", err
+	}
+	image = mutate.MediaType(image, types.OCIManifestSchema1)
+	image = mutate.ConfigMediaType(image, types.OCIConfigJSON)
+
+	digest, err := image.Digest()
+	if err != nil {
+		return "", err
+	}
+
+	if err := remote.Write(s.repository.Digest(digest.String()), image, s.options(ctx)...); err != nil {
+		return "", err
+	}
+
+	return digest.String(), nil
+}
+
+func (s *ociStore) Get(ctx context.Context, ref string) ([]byte, error) {
+	image, err := remote.Image(s.repository.Digest(ref), s.options(ctx)...)
+	if err != nil {
+		return nil, err
+	}
+
+	layers, err := image.Layers()
+	if err != nil {
+		return nil, err
+	}
+	if len(layers) != 1 {
+		return nil, fmt.Errorf("expected single layer in '%s@%s', found %d", s.repository.String(), ref, len(layers))
+	}
+
+	// The layer is stored as is, so its compressed form is the attestation
+	reader, err := layers[0].Compressed()
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close() //nolint:errcheck
+
+	return io.ReadAll(reader)
+}
+
+func (s *ociStore) options(ctx context.Context) []remote.Option {
+	return []remote.Option{
+		remote.WithContext(ctx),
+		remote.WithAuthFromKeychain(authn.DefaultKeychain),
+	}
+}
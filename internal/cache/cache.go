@@ -0,0 +1,144 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+const (
+	// Ref is the local ref used to store the verification cache. It is not
+	// synced with remotes.
+	Ref = "refs/gittuf/verification-cache"
+
+	cacheTreeEntryName = "cache.json"
+	commitMessage      = "Update verification cache"
+)
+
+var ErrInvalidVerificationCache = errors.New("invalid verification cache")
+
+// VerificationCache records the latest RSL entry that was successfully verified
+// for each reference. The cache is only valid for the policy it was created
+// with. When the policy changes, all the verified entries are invalidated.
+type VerificationCache struct {
+	// PolicyEntryID is the ID of the RSL entry for the policy that was used to
+	// verify the cached entries.
+	PolicyEntryID string `json:"policyEntryID"`
+
+	// VerifiedEntries maps each reference to the latest RSL entry verified
+	// for it.
+	VerifiedEntries map[string]string `json:"verifiedEntries"`
+}
+
+// LoadVerificationCache loads the repository's verification cache. If the cache
+// does not exist, an empty cache is returned.
+func LoadVerificationCache(repo *git.Repository) (*VerificationCache, error) {
+	ref, err := repo.Reference(plumbing.ReferenceName(Ref), true)
+	if err != nil {
+		if errors.Is(err, plumbing.ErrReferenceNotFound) {
+			return &VerificationCache{VerifiedEntries: map[string]string{}}, nil
+		}
+		return nil, err
+	}
+
+	commit, err := gitinterface.GetCommit(repo, ref.Hash())
+	if err != nil {
+		return nil, err
+	}
+
+	tree, err := gitinterface.GetTree(repo, commit.TreeHash)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, entry := range tree.Entries {
+		if entry.Name != cacheTreeEntryName {
+			continue
+		}
+
+		contents, err := gitinterface.ReadBlob(repo, entry.Hash)
+		if err != nil {
+			return nil, err
+		}
+
+		verificationCache := &VerificationCache{}
+		if err := json.Unmarshal(contents, verificationCache); err != nil {
+			return nil, errors.Join(ErrInvalidVerificationCache, err)
+		}
+		if verificationCache.VerifiedEntries == nil {
+			verificationCache.VerifiedEntries = map[string]string{}
+		}
+
+		return verificationCache, nil
+	}
+
+	return nil, ErrInvalidVerificationCache
+}
+
+// GetVerifiedEntry returns the latest verified RSL entry for the reference. If
+// the reference has no verified entry, or the cache was created with a
+// different policy, the zero hash is returned.
+func (c *VerificationCache) GetVerifiedEntry(policyEntryID plumbing.Hash, refName string) plumbing.Hash {
+	entryID, has := c.VerifiedEntries[refName]
+	if c.PolicyEntryID != policyEntryID.String() || !has {
+		return plumbing.ZeroHash
+	}
+
+	return plumbing.NewHash(entryID)
+}
+
+// SetVerifiedEntry records entryID as the latest verified RSL entry for the
+// reference. If the cache was created with a different policy, the entries
+// verified using that policy are discarded.
+func (c *VerificationCache) SetVerifiedEntry(policyEntryID plumbing.Hash, refName string, entryID plumbing.Hash) {
+	if c.PolicyEntryID != policyEntryID.String() {
+		c.PolicyEntryID = policyEntryID.String()
+		c.VerifiedEntries = map[string]string{}
+	}
+
+	c.VerifiedEntries[refName] = entryID.String()
+}
+
+// Commit writes the verification cache to the repository. The cache's prior
+// state is not retained in the ref's history.
+func (c *VerificationCache) Commit(repo *git.Repository) error {
+	contents, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+
+	blobID, err := gitinterface.WriteBlob(repo, contents)
+	if err != nil {
+		return err
+	}
+
+	treeID, err := gitinterface.WriteTree(repo, []object.TreeEntry{{Name: cacheTreeEntryName, Mode: filemode.Regular, Hash: blobID}})
+	if err != nil {
+		return err
+	}
+
+	// Reset the ref so the commit is created without a parent
+	if err := repo.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(Ref), plumbing.ZeroHash)); err != nil {
+		return err
+	}
+
+	_, err = gitinterface.Commit(repo, treeID, Ref, commitMessage, false)
+	return err
+}
+
+// Reset removes the repository's verification cache. The cache is rebuilt the
+// next time references are verified.
+func Reset(repo *git.Repository) error {
+	err := repo.Storer.RemoveReference(plumbing.ReferenceName(Ref))
+	if errors.Is(err, plumbing.ErrReferenceNotFound) {
+		return nil
+	}
+	return err
+}
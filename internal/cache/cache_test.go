@@ -0,0 +1,63 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package cache
+
+import (
+	"testing"
+
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerificationCache(t *testing.T) {
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	refName := "refs/heads/main"
+	policyEntryID := plumbing.NewHash("1111111111111111111111111111111111111111")
+	entryID := plumbing.NewHash("2222222222222222222222222222222222222222")
+
+	verificationCache, err := LoadVerificationCache(repo)
+	assert.Nil(t, err)
+	assert.Equal(t, plumbing.ZeroHash, verificationCache.GetVerifiedEntry(policyEntryID, refName))
+
+	verificationCache.SetVerifiedEntry(policyEntryID, refName, entryID)
+	if err := verificationCache.Commit(repo); err != nil {
+		t.Fatal(err)
+	}
+
+	verificationCache, err = LoadVerificationCache(repo)
+	assert.Nil(t, err)
+	assert.Equal(t, entryID, verificationCache.GetVerifiedEntry(policyEntryID, refName))
+	assert.Equal(t, plumbing.ZeroHash, verificationCache.GetVerifiedEntry(policyEntryID, "refs/heads/feature"))
+
+	// Policy changed, cached entries are invalidated
+	newPolicyEntryID := plumbing.NewHash("3333333333333333333333333333333333333333")
+	assert.Equal(t, plumbing.ZeroHash, verificationCache.GetVerifiedEntry(newPolicyEntryID, refName))
+
+	verificationCache.SetVerifiedEntry(newPolicyEntryID, "refs/heads/feature", entryID)
+	if err := verificationCache.Commit(repo); err != nil {
+		t.Fatal(err)
+	}
+
+	verificationCache, err = LoadVerificationCache(repo)
+	assert.Nil(t, err)
+	assert.Equal(t, plumbing.ZeroHash, verificationCache.GetVerifiedEntry(newPolicyEntryID, refName))
+	assert.Equal(t, entryID, verificationCache.GetVerifiedEntry(newPolicyEntryID, "refs/heads/feature"))
+
+	// The cache's history is not retained
+	ref, err := repo.Reference(plumbing.ReferenceName(Ref), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	commit, err := repo.CommitObject(ref.Hash())
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Empty(t, commit.ParentHashes)
+}
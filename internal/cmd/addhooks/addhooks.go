@@ -48,6 +48,7 @@ func New() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:               "add-hooks",
 		Short:             "Add git hooks that automatically create and sync RSL",
+		Deprecated:        `use "gittuf hooks install" instead`,
 		RunE:              o.Run,
 		DisableAutoGenTag: true,
 	}
@@ -0,0 +1,32 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package attest
+
+import (
+	"github.com/gittuf/gittuf/internal/cmd/attest/authorizepush"
+	"github.com/gittuf/gittuf/internal/cmd/attest/githubapproval"
+	"github.com/gittuf/gittuf/internal/cmd/attest/gitlabapproval"
+	"github.com/gittuf/gittuf/internal/cmd/attest/provenance"
+	"github.com/gittuf/gittuf/internal/cmd/attest/review"
+	"github.com/gittuf/gittuf/internal/cmd/attest/signaturetimestamps"
+	"github.com/gittuf/gittuf/internal/cmd/attest/transparencylog"
+	"github.com/spf13/cobra"
+)
+
+func New() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "attest",
+		Short:             "Tools for recording attestations used during gittuf verification",
+		DisableAutoGenTag: true,
+	}
+
+	cmd.AddCommand(authorizepush.New())
+	cmd.AddCommand(githubapproval.New())
+	cmd.AddCommand(gitlabapproval.New())
+	cmd.AddCommand(provenance.New())
+	cmd.AddCommand(review.New())
+	cmd.AddCommand(signaturetimestamps.New())
+	cmd.AddCommand(transparencylog.New())
+
+	return cmd
+}
@@ -0,0 +1,84 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package authorizepush
+
+import (
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	signingKey string
+	from       string
+	to         string
+	revoke     bool
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(
+		&o.signingKey,
+		"signing-key",
+		"k",
+		"",
+		"signing key to use for creating or revoking the authorization",
+	)
+	cmd.MarkFlagRequired("signing-key") //nolint:errcheck
+
+	cmd.Flags().StringVar(
+		&o.from,
+		"from",
+		"",
+		"current tip of the ref (default: the ref's tip per the RSL)",
+	)
+
+	cmd.Flags().StringVar(
+		&o.to,
+		"to",
+		"",
+		"new tip of the ref to authorize, either a revision or a full commit ID",
+	)
+	cmd.MarkFlagRequired("to") //nolint:errcheck
+
+	cmd.Flags().BoolVarP(
+		&o.revoke,
+		"revoke",
+		"r",
+		false,
+		"revoke existing authorization",
+	)
+}
+
+func (o *options) Run(cmd *cobra.Command, args []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	signer, err := common.GetSigner(o.signingKey)
+	if err != nil {
+		return err
+	}
+
+	if o.revoke {
+		return repo.RemovePushAuthorization(cmd.Context(), signer, args[0], o.from, o.to, true)
+	}
+
+	return repo.AddPushAuthorization(cmd.Context(), signer, args[0], o.from, o.to, true)
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "authorize-push <targetRef>",
+		Short:             "Authorize or revoke an update of a ref to a specific commit",
+		Long:              "This command records a signed authorization for updating the target ref from its current tip to the specified commit. The update may then be pushed by anyone, such as a bot, and its signatures count towards the thresholds of the ref's rules during verification.",
+		Args:              cobra.ExactArgs(1),
+		PreRunE:           common.CheckIfSigningViable,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
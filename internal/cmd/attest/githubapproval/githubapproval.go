@@ -0,0 +1,93 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package githubapproval
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/github"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+const tokenEnvKey = "GITHUB_TOKEN"
+
+type options struct {
+	signingKey        string
+	repository        string
+	pullRequestNumber int
+	baseURL           string
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(
+		&o.signingKey,
+		"signing-key",
+		"k",
+		"",
+		"GitHub app's signing key to use for signing the attestation",
+	)
+	cmd.MarkFlagRequired("signing-key") //nolint:errcheck
+
+	cmd.Flags().StringVar(
+		&o.repository,
+		"repository",
+		"",
+		"GitHub repository in the form <owner>/<repository>",
+	)
+	cmd.MarkFlagRequired("repository") //nolint:errcheck
+
+	cmd.Flags().IntVar(
+		&o.pullRequestNumber,
+		"pull-request",
+		0,
+		"number of the merged pull request",
+	)
+	cmd.MarkFlagRequired("pull-request") //nolint:errcheck
+
+	cmd.Flags().StringVar(
+		&o.baseURL,
+		"base-url",
+		github.DefaultBaseURL,
+		"location of the GitHub API",
+	)
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	owner, repositoryName, found := strings.Cut(o.repository, "/")
+	if !found || owner == "" || repositoryName == "" {
+		return fmt.Errorf("invalid repository '%s', expected <owner>/<repository>", o.repository)
+	}
+
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	signer, err := common.GetSigner(o.signingKey)
+	if err != nil {
+		return err
+	}
+
+	client := github.NewClient(o.baseURL, os.Getenv(tokenEnvKey))
+
+	return repo.RecordGitHubPullRequestApproval(cmd.Context(), signer, client, owner, repositoryName, o.pullRequestNumber, true)
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "github-approval",
+		Short:             "Record the approvals of a merged GitHub pull request",
+		Long:              fmt.Sprintf("This command fetches the approvals of a merged GitHub pull request and records them in an attestation signed by the GitHub app's key. The pull request's merge commit must be present in the local repository. If set, the token in %s is used to authenticate with the GitHub API.", tokenEnvKey),
+		PreRunE:           common.CheckIfSigningViable,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
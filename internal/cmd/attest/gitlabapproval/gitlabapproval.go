@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package gitlabapproval
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/gitlab"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+const tokenEnvKey = "GITLAB_TOKEN"
+
+type options struct {
+	signingKey      string
+	project         string
+	mergeRequestIID int
+	baseURL         string
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(
+		&o.signingKey,
+		"signing-key",
+		"k",
+		"",
+		"GitLab app's signing key to use for signing the attestation",
+	)
+	cmd.MarkFlagRequired("signing-key") //nolint:errcheck
+
+	cmd.Flags().StringVar(
+		&o.project,
+		"project",
+		"",
+		"full path of the GitLab project, such as <group>/<project>",
+	)
+	cmd.MarkFlagRequired("project") //nolint:errcheck
+
+	cmd.Flags().IntVar(
+		&o.mergeRequestIID,
+		"merge-request",
+		0,
+		"IID of the merged merge request",
+	)
+	cmd.MarkFlagRequired("merge-request") //nolint:errcheck
+
+	cmd.Flags().StringVar(
+		&o.baseURL,
+		"base-url",
+		gitlab.DefaultBaseURL,
+		"location of the GitLab API",
+	)
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	signer, err := common.GetSigner(o.signingKey)
+	if err != nil {
+		return err
+	}
+
+	client := gitlab.NewClient(o.baseURL, os.Getenv(tokenEnvKey))
+
+	return repo.RecordGitLabMergeRequestApproval(cmd.Context(), signer, client, o.project, o.mergeRequestIID, true)
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "gitlab-approval",
+		Short:             "Record the approvals of a merged GitLab merge request",
+		Long:              fmt.Sprintf("This command fetches the approvals of a merged GitLab merge request and records them in an attestation signed by the GitLab app's key. The commit the merge request was merged as must be present in the local repository. If set, the token in %s is used to authenticate with the GitLab API.", tokenEnvKey),
+		PreRunE:           common.CheckIfSigningViable,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
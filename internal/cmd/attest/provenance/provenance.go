@@ -0,0 +1,55 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package provenance
+
+import (
+	"os"
+
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	provenance string
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.provenance,
+		"provenance",
+		"",
+		"path to SLSA provenance attestation in a DSSE envelope",
+	)
+	cmd.MarkFlagRequired("provenance") //nolint:errcheck
+}
+
+func (o *options) Run(_ *cobra.Command, args []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	envBytes, err := os.ReadFile(o.provenance)
+	if err != nil {
+		return err
+	}
+
+	return repo.AddProvenance(args[0], envBytes, true)
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "provenance <tag>",
+		Short:             "Attach SLSA provenance to a release tag",
+		Long:              "This command attaches a SLSA provenance attestation to the specified tag. The provenance can then be required when verifying the tag using 'gittuf verify-tag --require-provenance'.",
+		Args:              cobra.ExactArgs(1),
+		PreRunE:           common.CheckIfSigningViable,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
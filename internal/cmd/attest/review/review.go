@@ -0,0 +1,80 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package review
+
+import (
+	"fmt"
+
+	"github.com/gittuf/gittuf/internal/attestations"
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/dev"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	signingKey string
+	fromRef    string
+	verdict    string
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(
+		&o.signingKey,
+		"signing-key",
+		"k",
+		"",
+		"signing key of the reviewer",
+	)
+	cmd.MarkFlagRequired("signing-key") //nolint:errcheck
+
+	cmd.Flags().StringVarP(
+		&o.fromRef,
+		"from-ref",
+		"f",
+		"",
+		"ref with the changes being reviewed",
+	)
+	cmd.MarkFlagRequired("from-ref") //nolint:errcheck
+
+	cmd.Flags().StringVar(
+		&o.verdict,
+		"verdict",
+		attestations.CodeReviewVerdictApproved,
+		fmt.Sprintf("verdict of the review, one of '%s' or '%s'", attestations.CodeReviewVerdictApproved, attestations.CodeReviewVerdictChangesRequested),
+	)
+}
+
+func (o *options) Run(cmd *cobra.Command, args []string) error {
+	if !dev.InDevMode() {
+		return dev.ErrNotInDevMode
+	}
+
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	signer, err := common.GetSigner(o.signingKey)
+	if err != nil {
+		return err
+	}
+
+	return repo.AttestReview(cmd.Context(), signer, args[0], o.fromRef, o.verdict, true)
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "review <targetRef>",
+		Short:             fmt.Sprintf("Record a code review of merging changes into a ref (developer mode only, set %s=1)", dev.DevModeKey),
+		Long:              "This command records a signed code review of merging the changes in the from ref into the target ref. Reviews that approve the changes count towards the thresholds of rules that trust the reviewer's key.",
+		Args:              cobra.ExactArgs(1),
+		PreRunE:           common.CheckIfSigningViable,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,48 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package signaturetimestamps
+
+import (
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	tsaURL string
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.tsaURL,
+		"tsa-url",
+		"",
+		"URL of RFC 3161 timestamp authority",
+	)
+	cmd.MarkFlagRequired("tsa-url") //nolint:errcheck
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	return repo.TimestampSignatures(cmd.Context(), o.tsaURL, true)
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "signature-timestamps",
+		Short:             "Record RFC 3161 timestamps for signatures on policy metadata and RSL entries",
+		Long:              "This command obtains RFC 3161 timestamps from a timestamp authority for the signatures on the current policy metadata, attestations, and RSL entries, and stores them in the attestations namespace. Signatures that were timestamped previously are skipped. The timestamps can be checked using the --tsa-cert-chain flag of 'gittuf verify-ref' and 'gittuf verify-tag', which also accepts signatures made using keys that were revoked after the signatures were timestamped.",
+		Args:              cobra.NoArgs,
+		PreRunE:           common.CheckIfSigningViable,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,48 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package transparencylog
+
+import (
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/gittuf/gittuf/internal/tlog"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	rekorURL string
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.rekorURL,
+		"rekor-url",
+		tlog.DefaultRekorURL,
+		"URL of Rekor transparency log",
+	)
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	return repo.UploadToTransparencyLog(cmd.Context(), o.rekorURL, true)
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "transparency-log",
+		Short:             "Record signatures on policy metadata and attestations in a transparency log",
+		Long:              "This command uploads the signatures on the current policy metadata and attestations to a Rekor transparency log, and stores the resulting log entries with their inclusion proofs in the attestations namespace. Signatures that were uploaded previously are skipped, as are signatures by keys that are not supported by the log, such as GPG and SSH keys. The log entries can be checked using the --verify-transparency-log flag of 'gittuf verify-ref' and 'gittuf verify-tag'.",
+		Args:              cobra.NoArgs,
+		PreRunE:           common.CheckIfSigningViable,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
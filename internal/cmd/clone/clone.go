@@ -3,12 +3,15 @@
 package clone
 
 import (
+	"github.com/gittuf/gittuf/internal/cmd/common"
 	"github.com/gittuf/gittuf/internal/repository"
 	"github.com/spf13/cobra"
 )
 
 type options struct {
-	branch string
+	branch     string
+	rootKeys   []string
+	rootKeyIDs []string
 }
 
 func (o *options) AddFlags(cmd *cobra.Command) {
@@ -19,6 +22,20 @@ func (o *options) AddFlags(cmd *cobra.Command) {
 		"",
 		"specify branch to check out",
 	)
+
+	cmd.Flags().StringArrayVar(
+		&o.rootKeys,
+		"root-key",
+		[]string{},
+		"expected root public key for the repository's initial policy",
+	)
+
+	cmd.Flags().StringArrayVar(
+		&o.rootKeyIDs,
+		"root-key-id",
+		[]string{},
+		"key ID or fingerprint of expected root key for the repository's initial policy",
+	)
 }
 
 func (o *options) Run(cmd *cobra.Command, args []string) error {
@@ -26,15 +43,29 @@ func (o *options) Run(cmd *cobra.Command, args []string) error {
 	if len(args) > 1 {
 		dir = args[1]
 	}
-	_, err := repository.Clone(cmd.Context(), args[0], dir, o.branch)
+
+	expectedRootKeyIDs := []string{}
+	for _, key := range o.rootKeys {
+		keyObj, err := common.LoadPublicKey(key)
+		if err != nil {
+			return err
+		}
+		expectedRootKeyIDs = append(expectedRootKeyIDs, keyObj.KeyID)
+	}
+	expectedRootKeyIDs = append(expectedRootKeyIDs, o.rootKeyIDs...)
+
+	_, err := repository.Clone(cmd.Context(), args[0], dir, o.branch, expectedRootKeyIDs)
 	return err
 }
 
 func New() *cobra.Command {
 	o := &options{}
 	cmd := &cobra.Command{
-		Use:               "clone",
-		Short:             "Clone repository and its gittuf references",
+		Use:   "clone",
+		Short: "Clone repository and its gittuf references",
+		Long: `The 'clone' command clones the repository along with its gittuf references, and performs a full verification of the checked out branch before reporting success.
+
+The repository's initial root of trust is trusted on first use unless expected root keys are specified using --root-key or --root-key-id, in which case the initial root of trust must be signed by a threshold of those keys.`,
 		Args:              cobra.MinimumNArgs(1),
 		RunE:              o.Run,
 		DisableAutoGenTag: true,
@@ -3,14 +3,28 @@
 package common
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"os/exec"
 	"strings"
 
+	"github.com/gittuf/gittuf/internal/github"
 	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/gittuf/gittuf/internal/gitlab"
 	"github.com/gittuf/gittuf/internal/signerverifier"
+	"github.com/gittuf/gittuf/internal/signerverifier/azurekms"
+	"github.com/gittuf/gittuf/internal/signerverifier/external"
+	"github.com/gittuf/gittuf/internal/signerverifier/gcpkms"
 	"github.com/gittuf/gittuf/internal/signerverifier/gpg"
+	"github.com/gittuf/gittuf/internal/signerverifier/hashivault"
+	"github.com/gittuf/gittuf/internal/signerverifier/keychain"
+	"github.com/gittuf/gittuf/internal/signerverifier/piv"
+	"github.com/gittuf/gittuf/internal/signerverifier/pkcs11"
+	"github.com/gittuf/gittuf/internal/signerverifier/sigstore"
+	"github.com/gittuf/gittuf/internal/signerverifier/sshagent"
+	"github.com/gittuf/gittuf/internal/signerverifier/sshca"
+	"github.com/gittuf/gittuf/internal/signerverifier/x509"
 	sslibsv "github.com/gittuf/gittuf/internal/third_party/go-securesystemslib/signerverifier"
 	"github.com/gittuf/gittuf/internal/tuf"
 	sslibdsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
@@ -20,10 +34,30 @@ import (
 const (
 	GPGKeyPrefix = "gpg:"
 	FulcioPrefix = "fulcio:"
+	GitHubPrefix = "github:"
+	GitLabPrefix = "gitlab:"
+
+	// X509Prefix identifies an X.509 identity in the format
+	// "x509:<identity>::<root-certificates-path>", where the file contains
+	// the PEM encoded roots the identity's certificates must be issued by.
+	X509Prefix = x509.KeyReferencePrefix
+
+	// SSHCAPrefix identifies an SSH certificate authority in the format
+	// "ssh-ca:<principal>::<ca-public-key-path>". Signatures made using SSH
+	// user certificates issued by the authority for the principal are trusted.
+	SSHCAPrefix = "ssh-ca:"
+
+	// FulcioRegExpPrefix identifies a pattern of Sigstore identities in the
+	// format "fulcio-regexp:<identity-regexp>::<issuer-regexp>".
+	FulcioRegExpPrefix = "fulcio-regexp:"
 )
 
-// LoadPublicKey returns a tuf.Key object for a PGP / Sigstore Fulcio / SSH
-// (on-disk) key for use in gittuf metadata.
+// LoadPublicKey returns a tuf.Key object for a PGP / Sigstore Fulcio identity /
+// Sigstore Fulcio identity pattern / X.509 identity / SSH certificate
+// authority / Cloud KMS / Azure Key
+// Vault / Vault transit / PKCS#11 / YubiKey PIV / ssh-agent / external signer
+// program / OS keychain / GitHub identity / GitLab identity / SSH (on-disk) key
+// for use in gittuf metadata.
 func LoadPublicKey(key string) (*tuf.Key, error) {
 	var keyObj *tuf.Key
 
@@ -48,14 +82,110 @@ func LoadPublicKey(key string) (*tuf.Key, error) {
 			return nil, fmt.Errorf("incorrect format for fulcio identity")
 		}
 
-		keyObj = &sslibsv.SSLibKey{
-			KeyID:   keyID,
-			KeyType: signerverifier.FulcioKeyType,
-			Scheme:  signerverifier.FulcioKeyScheme,
-			KeyVal: sslibsv.KeyVal{
-				Identity: ks[0],
-				Issuer:   ks[1],
-			},
+		keyObj = sigstore.NewKey(ks[0], ks[1])
+	case strings.HasPrefix(key, FulcioRegExpPrefix):
+		patterns := strings.TrimPrefix(key, FulcioRegExpPrefix)
+		identityPattern, issuerPattern, found := strings.Cut(patterns, "::")
+		if !found {
+			return nil, fmt.Errorf("incorrect format for fulcio identity pattern")
+		}
+
+		var err error
+		keyObj, err = sigstore.NewPatternKey(identityPattern, issuerPattern)
+		if err != nil {
+			return nil, err
+		}
+	case strings.HasPrefix(key, X509Prefix):
+		identity, rootsPath, found := strings.Cut(strings.TrimPrefix(key, X509Prefix), "::")
+		if !found || identity == "" || rootsPath == "" {
+			return nil, fmt.Errorf("incorrect format for x509 identity")
+		}
+
+		rootsPEM, err := os.ReadFile(rootsPath)
+		if err != nil {
+			return nil, err
+		}
+
+		keyObj, err = x509.NewKey(identity, rootsPEM)
+		if err != nil {
+			return nil, err
+		}
+	case strings.HasPrefix(key, SSHCAPrefix):
+		principal, caPath, found := strings.Cut(strings.TrimPrefix(key, SSHCAPrefix), "::")
+		if !found || principal == "" || caPath == "" {
+			return nil, fmt.Errorf("incorrect format for ssh certificate authority")
+		}
+
+		caPublicKey, err := os.ReadFile(caPath)
+		if err != nil {
+			return nil, err
+		}
+
+		keyObj, err = sshca.NewKey(principal, caPublicKey)
+		if err != nil {
+			return nil, err
+		}
+	case strings.HasPrefix(key, GitHubPrefix):
+		login := strings.TrimSpace(strings.TrimPrefix(key, GitHubPrefix))
+		if login == "" {
+			return nil, fmt.Errorf("incorrect format for github identity")
+		}
+
+		keyObj = github.NewIdentityKey(login)
+	case strings.HasPrefix(key, GitLabPrefix):
+		username := strings.TrimSpace(strings.TrimPrefix(key, GitLabPrefix))
+		if username == "" {
+			return nil, fmt.Errorf("incorrect format for gitlab identity")
+		}
+
+		keyObj = gitlab.NewIdentityKey(username)
+	case strings.HasPrefix(key, gcpkms.KeyReferencePrefix):
+		var err error
+		keyObj, err = gcpkms.LoadPublicKey(context.Background(), key)
+		if err != nil {
+			return nil, err
+		}
+	case strings.HasPrefix(key, azurekms.KeyReferencePrefix):
+		var err error
+		keyObj, err = azurekms.LoadPublicKey(context.Background(), key)
+		if err != nil {
+			return nil, err
+		}
+	case strings.HasPrefix(key, hashivault.KeyReferencePrefix):
+		var err error
+		keyObj, err = hashivault.LoadPublicKey(context.Background(), key)
+		if err != nil {
+			return nil, err
+		}
+	case strings.HasPrefix(key, pkcs11.KeyReferencePrefix):
+		var err error
+		keyObj, err = pkcs11.LoadPublicKey(context.Background(), key)
+		if err != nil {
+			return nil, err
+		}
+	case strings.HasPrefix(key, sshagent.KeyReferencePrefix):
+		var err error
+		keyObj, err = sshagent.LoadPublicKey(context.Background(), key)
+		if err != nil {
+			return nil, err
+		}
+	case strings.HasPrefix(key, piv.KeyReferencePrefix):
+		var err error
+		keyObj, err = piv.LoadPublicKey(context.Background(), key)
+		if err != nil {
+			return nil, err
+		}
+	case strings.HasPrefix(key, external.KeyReferencePrefix):
+		var err error
+		keyObj, err = external.LoadPublicKey(context.Background(), key)
+		if err != nil {
+			return nil, err
+		}
+	case strings.HasPrefix(key, keychain.KeyReferencePrefix):
+		var err error
+		keyObj, err = keychain.LoadPublicKey(context.Background(), key)
+		if err != nil {
+			return nil, err
 		}
 	default:
 		kb, err := os.ReadFile(key)
@@ -84,6 +214,58 @@ func LoadSigner(keyBytes []byte) (sslibdsse.SignerVerifier, error) {
 	return signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(keyBytes) //nolint:staticcheck
 }
 
+// GetSigner returns a signer for the specified signing key. The key is either
+// the path to a private key on disk, a gcpkms:// reference to a Cloud KMS key
+// version, an azurekms:// reference to an Azure Key Vault key, a hashivault://
+// reference to a Vault transit key, a pkcs11: URI for a key on an HSM or
+// smartcard, a piv: reference to a YubiKey PIV slot, an ssh-agent: reference
+// to a key held by ssh-agent, an exec: reference to an external signer
+// program, a keychain: reference to a key stored in the OS keychain, or, when
+// it has the fulcio: prefix, a request to sign keylessly using Sigstore. An
+// identity may be specified after the fulcio: prefix as identity::issuer to
+// ensure the expected identity is used. Private keys on disk may be encrypted,
+// in which case the passphrase is read from GITTUF_PASSPHRASE or prompted for.
+func GetSigner(key string) (sslibdsse.SignerVerifier, error) {
+	switch {
+	case strings.HasPrefix(key, gcpkms.KeyReferencePrefix):
+		return gcpkms.NewSignerVerifierFromURI(context.Background(), key)
+	case strings.HasPrefix(key, azurekms.KeyReferencePrefix):
+		return azurekms.NewSignerVerifierFromURI(context.Background(), key)
+	case strings.HasPrefix(key, hashivault.KeyReferencePrefix):
+		return hashivault.NewSignerVerifierFromURI(context.Background(), key)
+	case strings.HasPrefix(key, pkcs11.KeyReferencePrefix):
+		return pkcs11.NewSignerVerifierFromURI(context.Background(), key)
+	case strings.HasPrefix(key, sshagent.KeyReferencePrefix):
+		return sshagent.NewSignerVerifierFromURI(context.Background(), key)
+	case strings.HasPrefix(key, piv.KeyReferencePrefix):
+		return piv.NewSignerVerifierFromURI(context.Background(), key)
+	case strings.HasPrefix(key, external.KeyReferencePrefix):
+		return external.NewSignerVerifierFromURI(context.Background(), key)
+	case strings.HasPrefix(key, keychain.KeyReferencePrefix):
+		return keychain.NewSignerVerifierFromURI(context.Background(), key)
+	case strings.HasPrefix(key, FulcioPrefix):
+		keyID := strings.TrimPrefix(key, FulcioPrefix)
+		if keyID == "" {
+			return sigstore.NewSigner("", ""), nil
+		}
+
+		ks := strings.Split(keyID, "::")
+		if len(ks) != 2 {
+			return nil, fmt.Errorf("incorrect format for fulcio identity")
+		}
+		return sigstore.NewSigner(ks[0], ks[1]), nil
+	case strings.HasPrefix(key, x509.KeyReferencePrefix):
+		return x509.NewSignerFromURI(key)
+	default:
+		keyBytes, err := ReadPrivateKey(key)
+		if err != nil {
+			return nil, err
+		}
+
+		return LoadSigner(keyBytes)
+	}
+}
+
 func CheckIfSigningViable(_ *cobra.Command, _ []string) error {
 	_, _, err := gitinterface.GetSigningCommand()
 
@@ -5,8 +5,11 @@ package common
 import (
 	"context"
 	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/gittuf/gittuf/internal/signerverifier"
 	artifacts "github.com/gittuf/gittuf/internal/testartifacts"
 	"github.com/stretchr/testify/assert"
 )
@@ -28,3 +31,57 @@ func TestLoadSigner(t *testing.T) {
 		assert.Nil(t, err)
 	}
 }
+
+func TestGetSignerEncryptedKey(t *testing.T) {
+	keyPath := filepath.Join(t.TempDir(), "ecdsa")
+	if err := os.WriteFile(keyPath, artifacts.EncryptedPKCS8ECDSAPrivate, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("passphrase set", func(t *testing.T) {
+		t.Setenv(PassphraseEnvVar, artifacts.EncryptedKeyPassphrase)
+
+		signer, err := GetSigner(keyPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = signer.Sign(context.Background(), nil)
+		assert.Nil(t, err)
+	})
+
+	t.Run("incorrect passphrase", func(t *testing.T) {
+		t.Setenv(PassphraseEnvVar, "incorrect")
+
+		_, err := GetSigner(keyPath)
+		assert.ErrorIs(t, err, signerverifier.ErrIncorrectPassphrase)
+	})
+
+	t.Run("passphrase not set", func(t *testing.T) {
+		t.Setenv(PassphraseEnvVar, "")
+
+		// Tests are not run in a terminal, so the passphrase cannot be
+		// prompted for
+		_, err := GetSigner(keyPath)
+		assert.ErrorIs(t, err, ErrNoPassphrase)
+	})
+}
+
+func TestReadPassphrase(t *testing.T) {
+	t.Run("passphrase set", func(t *testing.T) {
+		t.Setenv(PassphraseEnvVar, "passphrase")
+
+		passphrase, err := ReadPassphrase("Enter passphrase: ")
+		assert.Nil(t, err)
+		assert.Equal(t, []byte("passphrase"), passphrase)
+	})
+
+	t.Run("passphrase not set", func(t *testing.T) {
+		t.Setenv(PassphraseEnvVar, "")
+
+		// Tests are not run in a terminal, so the passphrase cannot be
+		// prompted for
+		_, err := ReadPassphrase("Enter passphrase: ")
+		assert.ErrorIs(t, err, ErrNoPassphrase)
+	})
+}
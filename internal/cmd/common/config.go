@@ -0,0 +1,125 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"fmt"
+	"log/slog"
+	"strconv"
+
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+// configFlag binds a flag to the gittuf setting used as its default value.
+type configFlag struct {
+	flag string
+	key  string
+
+	// value returns the flag value for the setting's value, and false if the
+	// flag must be left unset. If nil, the setting's value is used as is.
+	value func(string) (string, bool)
+
+	// conflicts are flags that, when set, prevent the setting from being
+	// applied, such as flags that are mutually exclusive with flag.
+	conflicts []string
+}
+
+var configFlags = []*configFlag{
+	{flag: "signing-key", key: repository.ConfigSigningKey},
+	{flag: "remote", key: repository.ConfigRemote},
+	{
+		flag: "no-fetch",
+		key:  repository.ConfigAutoFetch,
+		value: func(autoFetch string) (string, bool) {
+			fetch, err := strconv.ParseBool(autoFetch)
+			if err != nil || fetch {
+				return "", false
+			}
+			return "true", true
+		},
+	},
+	{
+		flag: "latest-only",
+		key:  repository.ConfigVerificationDepth,
+		value: func(depth string) (string, bool) {
+			if depth != repository.VerificationDepthLatest {
+				return "", false
+			}
+			return "true", true
+		},
+		conflicts: []string{"from-entry", "from-commit"},
+	},
+}
+
+// configLookup is implemented by repository.Repository.
+type configLookup interface {
+	LookupConfigValue(key string) (string, bool, error)
+}
+
+// ApplyConfigDefaults sets the flags of the command that were not specified to
+// the values of the gittuf settings bound to them, such as the signing key and
+// the remote. Settings are read from the Git config of the repository in the
+// current directory, if any. Flags specified by the user always take precedence.
+func ApplyConfigDefaults(cmd *cobra.Command) error {
+	hasConfigFlags := false
+	for _, binding := range configFlags {
+		if cmd.Flags().Lookup(binding.flag) != nil {
+			hasConfigFlags = true
+			break
+		}
+	}
+	if !hasConfigFlags {
+		return nil
+	}
+
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		// Commands such as clone are not invoked in a repository
+		slog.Debug(fmt.Sprintf("Not applying gittuf config: %s", err.Error()))
+		return nil
+	}
+
+	return applyConfigDefaults(cmd, repo)
+}
+
+func applyConfigDefaults(cmd *cobra.Command, config configLookup) error {
+	for _, binding := range configFlags {
+		flag := cmd.Flags().Lookup(binding.flag)
+		if flag == nil || flag.Changed || anyFlagChanged(cmd, binding.conflicts) {
+			continue
+		}
+
+		value, isSet, err := config.LookupConfigValue(binding.key)
+		if err != nil {
+			return err
+		}
+		if !isSet {
+			continue
+		}
+
+		if binding.value != nil {
+			var apply bool
+			value, apply = binding.value(value)
+			if !apply {
+				continue
+			}
+		}
+
+		slog.Debug(fmt.Sprintf("Setting --%s to '%s' from '%s'...", binding.flag, value, binding.key))
+		if err := cmd.Flags().Set(binding.flag, value); err != nil {
+			return fmt.Errorf("%w '%s': %w", repository.ErrInvalidConfigValue, binding.key, err)
+		}
+	}
+
+	return nil
+}
+
+func anyFlagChanged(cmd *cobra.Command, flags []string) bool {
+	for _, name := range flags {
+		if flag := cmd.Flags().Lookup(name); flag != nil && flag.Changed {
+			return true
+		}
+	}
+	return false
+}
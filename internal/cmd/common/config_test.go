@@ -0,0 +1,99 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+type testConfig map[string]string
+
+func (c testConfig) LookupConfigValue(key string) (string, bool, error) {
+	value, isSet := c[key]
+	return value, isSet, nil
+}
+
+func TestApplyConfigDefaults(t *testing.T) {
+	config := testConfig{
+		repository.ConfigSigningKey:        "ssh-agent:SHA256:abc",
+		repository.ConfigAutoFetch:         "false",
+		repository.ConfigVerificationDepth: repository.VerificationDepthLatest,
+	}
+
+	// newCommand returns a command with flags bound to settings, with args
+	// parsed
+	newCommand := func(t *testing.T, args ...string) *cobra.Command {
+		t.Helper()
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("signing-key", "", "")
+		cmd.Flags().String("remote", "origin", "")
+		cmd.Flags().Bool("no-fetch", false, "")
+		cmd.Flags().Bool("latest-only", false, "")
+		cmd.Flags().String("from-entry", "", "")
+		if err := cmd.ParseFlags(args); err != nil {
+			t.Fatal(err)
+		}
+		return cmd
+	}
+
+	t.Run("flags not specified", func(t *testing.T) {
+		cmd := newCommand(t)
+
+		err := applyConfigDefaults(cmd, config)
+		assert.Nil(t, err)
+
+		signingKey, _ := cmd.Flags().GetString("signing-key")
+		assert.Equal(t, "ssh-agent:SHA256:abc", signingKey)
+
+		// gittuf.remote is not set
+		remote, _ := cmd.Flags().GetString("remote")
+		assert.Equal(t, "origin", remote)
+		assert.False(t, cmd.Flags().Lookup("remote").Changed)
+
+		noFetch, _ := cmd.Flags().GetBool("no-fetch")
+		assert.True(t, noFetch)
+
+		latestOnly, _ := cmd.Flags().GetBool("latest-only")
+		assert.True(t, latestOnly)
+	})
+
+	t.Run("flags specified", func(t *testing.T) {
+		cmd := newCommand(t, "--signing-key", "root.pem", "--from-entry", "abcdef")
+
+		err := applyConfigDefaults(cmd, config)
+		assert.Nil(t, err)
+
+		signingKey, _ := cmd.Flags().GetString("signing-key")
+		assert.Equal(t, "root.pem", signingKey)
+
+		// --latest-only conflicts with --from-entry
+		latestOnly, _ := cmd.Flags().GetBool("latest-only")
+		assert.False(t, latestOnly)
+	})
+
+	t.Run("default values", func(t *testing.T) {
+		cmd := newCommand(t)
+
+		err := applyConfigDefaults(cmd, testConfig{
+			repository.ConfigAutoFetch:         "true",
+			repository.ConfigVerificationDepth: repository.VerificationDepthFull,
+		})
+		assert.Nil(t, err)
+
+		noFetch, _ := cmd.Flags().GetBool("no-fetch")
+		assert.False(t, noFetch)
+
+		latestOnly, _ := cmd.Flags().GetBool("latest-only")
+		assert.False(t, latestOnly)
+	})
+
+	t.Run("command without bound flags", func(t *testing.T) {
+		cmd := &cobra.Command{}
+		assert.Nil(t, applyConfigDefaults(cmd, config))
+	})
+}
@@ -0,0 +1,210 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"context"
+	"errors"
+	"net"
+	"os/exec"
+
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/gittuf/gittuf/internal/notify"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+)
+
+// Exit codes returned by gittuf. Scripts can use these to branch on the class
+// of failure instead of parsing the error printed to stderr. The values are
+// part of gittuf's interface and must not be changed or reused.
+const (
+	// ExitCodeSuccess indicates the command completed successfully.
+	ExitCodeSuccess = 0
+
+	// ExitCodeError indicates a failure that does not fall into any of the
+	// classes below.
+	ExitCodeError = 1
+
+	// ExitCodeUsage indicates the command was invoked incorrectly, such as
+	// with an unknown flag or an invalid flag value.
+	ExitCodeUsage = 2
+
+	// ExitCodeNoPolicy indicates the repository does not have a gittuf
+	// policy, or the requested policy metadata does not exist.
+	ExitCodeNoPolicy = 3
+
+	// ExitCodeStaleRSL indicates the local RSL is out of date or has
+	// diverged from the remote, or the RSL may be withholding entries.
+	ExitCodeStaleRSL = 4
+
+	// ExitCodeSignatureInvalid indicates verification failed because a
+	// required signature is missing, invalid, revoked, or unauthorized.
+	ExitCodeSignatureInvalid = 5
+
+	// ExitCodeThresholdUnmet indicates valid signatures were found, but not
+	// enough of them to meet the threshold required by the policy.
+	ExitCodeThresholdUnmet = 6
+
+	// ExitCodeInfrastructure indicates a failure outside of gittuf's
+	// verification, such as a network error, an error interacting with Git or
+	// a remote, or a timeout.
+	ExitCodeInfrastructure = 7
+)
+
+// ExitError associates an error with the exit code gittuf must exit with. It
+// can be used by commands whose error does not wrap one of the errors
+// recognized by ExitCode.
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+// NewExitError returns an ExitError for err with the specified exit code.
+func NewExitError(code int, err error) *ExitError {
+	return &ExitError{Code: code, Err: err}
+}
+
+func (e *ExitError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
+var (
+	noPolicyErrors = []error{
+		policy.ErrPolicyNotFound,
+		policy.ErrMetadataNotFound,
+		repository.ErrRemoteHasNoRSL,
+	}
+
+	staleRSLErrors = []error{
+		policy.ErrRSLNotFresh,
+		policy.ErrTimestampExpired,
+		repository.ErrRemoteRSLHasUpdates,
+		repository.ErrDivergedRSL,
+		repository.ErrRefStateDoesNotMatchRSL,
+		repository.ErrRefMissingFromRemote,
+		repository.ErrMirrorInconsistent,
+	}
+
+	thresholdUnmetErrors = []error{
+		policy.ErrThresholdUnmet,
+		repository.ErrStagedPolicyPending,
+	}
+
+	signatureInvalidErrors = []error{
+		policy.ErrUnauthorizedSignature,
+		policy.ErrVerifierConditionsUnmet,
+		policy.ErrKeyRevoked,
+		policy.ErrUnauthorizedForcePush,
+		policy.ErrUnauthorizedDeletion,
+		policy.ErrSignatureAlgorithmNotAllowed,
+		policy.ErrUntrustedRootOfTrust,
+		policy.ErrUntrustedByOrganization,
+		policy.ErrUnverifiedCheckpoint,
+		policy.ErrTargetDigestMismatch,
+		policy.ErrSubmoduleVerificationFailed,
+		policy.ErrConstraintViolated,
+		policy.ErrMetadataRollback,
+		policy.ErrMetadataVersionNotIncremented,
+		gitinterface.ErrIncorrectVerificationKey,
+		gitinterface.ErrInvalidSignature,
+		gitinterface.ErrVerifyingSSHSignature,
+		gitinterface.ErrVerifyingSigstoreSignature,
+	}
+
+	infrastructureErrors = []error{
+		context.Canceled,
+		context.DeadlineExceeded,
+		exec.ErrNotFound,
+		transport.ErrAuthenticationRequired,
+		transport.ErrAuthorizationFailed,
+		transport.ErrRepositoryNotFound,
+		repository.ErrCloningRepository,
+		repository.ErrPushingRSL,
+		repository.ErrPullingRSL,
+		repository.ErrPushingWithRSL,
+		repository.ErrPullingWithRSL,
+		repository.ErrPushingPolicy,
+		repository.ErrPullingPolicy,
+		repository.ErrPushingTimestamp,
+		repository.ErrPullingTimestamp,
+	}
+)
+
+// ExitCode returns the exit code for err. An ExitError's code is used as is,
+// otherwise the code is determined by the class of the errors err wraps. When
+// err wraps errors of more than one class, the more specific class is used,
+// e.g., a stale RSL takes precedence over a failure to pull it.
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitCodeSuccess
+	}
+
+	var exitErr *ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.Code
+	}
+
+	switch {
+	case errors.Is(err, ErrUnknownOutputFormat), errors.Is(err, notify.ErrUnknownWebhookFormat), errors.Is(err, repository.ErrUnknownConfigKey), errors.Is(err, repository.ErrInvalidConfigValue),
+		errors.Is(err, repository.ErrSigningProfileNotFound), errors.Is(err, repository.ErrInvalidSigningProfileName), errors.Is(err, repository.ErrSignatureNotFound),
+		errors.Is(err, policy.ErrInvalidRegoPolicy), errors.Is(err, policy.ErrInvalidRuleCondition),
+		errors.Is(err, policy.ErrInvalidCommitMessagePattern), errors.Is(err, policy.ErrInvalidFileRestriction),
+		errors.Is(err, policy.ErrInvalidCodeOwners), errors.Is(err, policy.ErrInvalidCodeOwnersMapping), errors.Is(err, policy.ErrCodeOwnerNotMapped),
+		errors.Is(err, policy.ErrNoBranchProtectionPrincipals), errors.Is(err, repository.ErrBranchNotProtected):
+		return ExitCodeUsage
+	case isAny(err, noPolicyErrors):
+		return ExitCodeNoPolicy
+	case isAny(err, staleRSLErrors):
+		return ExitCodeStaleRSL
+	case isAny(err, thresholdUnmetErrors):
+		return ExitCodeThresholdUnmet
+	case isAny(err, signatureInvalidErrors):
+		return ExitCodeSignatureInvalid
+	case isAny(err, infrastructureErrors):
+		return ExitCodeInfrastructure
+	}
+
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return ExitCodeInfrastructure
+	}
+
+	return ExitCodeError
+}
+
+func isAny(err error, targets []error) bool {
+	for _, target := range targets {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// FailureType returns the name of the class of failure err belongs to, such as
+// "signature_invalid", see ExitCode. It returns an empty string if err is nil.
+func FailureType(err error) string {
+	switch ExitCode(err) {
+	case ExitCodeSuccess:
+		return ""
+	case ExitCodeUsage:
+		return "usage"
+	case ExitCodeNoPolicy:
+		return "no_policy"
+	case ExitCodeStaleRSL:
+		return "stale_rsl"
+	case ExitCodeSignatureInvalid:
+		return "signature_invalid"
+	case ExitCodeThresholdUnmet:
+		return "threshold_unmet"
+	case ExitCodeInfrastructure:
+		return "infrastructure"
+	default:
+		return "error"
+	}
+}
@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExitCode(t *testing.T) {
+	tests := map[string]struct {
+		err          error
+		expectedCode int
+	}{
+		"no error": {
+			err:          nil,
+			expectedCode: ExitCodeSuccess,
+		},
+		"unclassified error": {
+			err:          errors.New("unknown"),
+			expectedCode: ExitCodeError,
+		},
+		"unknown output format": {
+			err:          ErrUnknownOutputFormat,
+			expectedCode: ExitCodeUsage,
+		},
+		"no policy": {
+			err:          fmt.Errorf("unable to load policy: %w", policy.ErrPolicyNotFound),
+			expectedCode: ExitCodeNoPolicy,
+		},
+		"stale RSL": {
+			err:          fmt.Errorf("%w: %w", repository.ErrPullingRSL, repository.ErrDivergedRSL),
+			expectedCode: ExitCodeStaleRSL,
+		},
+		"signature invalid": {
+			err:          fmt.Errorf("verifying Git namespace policies failed, %w", policy.ErrUnauthorizedSignature),
+			expectedCode: ExitCodeSignatureInvalid,
+		},
+		"threshold unmet": {
+			err:          fmt.Errorf("verifying Git namespace policies failed, %w: %w", policy.ErrUnauthorizedSignature, fmt.Errorf("%w: %w", policy.ErrVerifierConditionsUnmet, policy.ErrThresholdUnmet)),
+			expectedCode: ExitCodeThresholdUnmet,
+		},
+		"infrastructure error": {
+			err:          fmt.Errorf("unable to fetch: %w", context.DeadlineExceeded),
+			expectedCode: ExitCodeInfrastructure,
+		},
+		"joined errors": {
+			err:          errors.Join(errors.New("unknown"), policy.ErrKeyRevoked),
+			expectedCode: ExitCodeSignatureInvalid,
+		},
+		"explicit exit code": {
+			err:          NewExitError(ExitCodeUsage, policy.ErrUnauthorizedSignature),
+			expectedCode: ExitCodeUsage,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expectedCode, ExitCode(test.err))
+		})
+	}
+}
+
+func TestFailureType(t *testing.T) {
+	assert.Equal(t, "", FailureType(nil))
+	assert.Equal(t, "error", FailureType(errors.New("unknown")))
+	assert.Equal(t, "stale_rsl", FailureType(repository.ErrRefMissingFromRemote))
+	assert.Equal(t, "signature_invalid", FailureType(policy.ErrUnauthorizedSignature))
+	assert.Equal(t, "infrastructure", FailureType(context.DeadlineExceeded))
+}
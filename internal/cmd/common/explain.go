@@ -0,0 +1,25 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+// NewExplainer returns a policy.ExplainFunc that writes each step of the
+// decision trace to w, prefixed with the ID of the RSL entry it applies to.
+// Steps for entries verified concurrently are written one line at a time.
+func NewExplainer(w io.Writer) policy.ExplainFunc {
+	var mu sync.Mutex
+	return func(entryID plumbing.Hash, step string) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		fmt.Fprintf(w, "Entry %s: %s\n", entryID.String(), step)
+	}
+}
@@ -0,0 +1,61 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	// OutputFormatText is the default output format, intended to be read by
+	// people.
+	OutputFormatText = "text"
+
+	// OutputFormatJSON emits a stable, machine-readable structure for use in
+	// automation.
+	OutputFormatJSON = "json"
+
+	outputFormatFlagName = "format"
+)
+
+var ErrUnknownOutputFormat = errors.New("unknown output format")
+
+// AddOutputFormatFlag adds the global --format flag to the command.
+func AddOutputFormatFlag(cmd *cobra.Command, format *string) {
+	cmd.PersistentFlags().StringVar(
+		format,
+		outputFormatFlagName,
+		OutputFormatText,
+		fmt.Sprintf("output format (%s, %s)", OutputFormatText, OutputFormatJSON),
+	)
+}
+
+// CheckOutputFormat returns an error if the output format is not supported.
+func CheckOutputFormat(format string) error {
+	switch format {
+	case OutputFormatText, OutputFormatJSON:
+		return nil
+	default:
+		return fmt.Errorf("%w '%s'", ErrUnknownOutputFormat, format)
+	}
+}
+
+// IsJSONOutput returns true if JSON output is requested for the command. Some
+// commands define their own --format flag with additional formats, which
+// takes the place of the global flag.
+func IsJSONOutput(cmd *cobra.Command) bool {
+	flag := cmd.Flags().Lookup(outputFormatFlagName)
+	return flag != nil && flag.Value.String() == OutputFormatJSON
+}
+
+// PrintJSON writes the value to standard output as indented JSON.
+func PrintJSON(value any) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(value)
+}
@@ -0,0 +1,48 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOutputFormat(t *testing.T) {
+	assert.Nil(t, CheckOutputFormat(OutputFormatText))
+	assert.Nil(t, CheckOutputFormat(OutputFormatJSON))
+	assert.ErrorIs(t, CheckOutputFormat("yaml"), ErrUnknownOutputFormat)
+
+	var format string
+	root := &cobra.Command{Use: "root"}
+	AddOutputFormatFlag(root, &format)
+
+	child := &cobra.Command{Use: "child", Run: func(*cobra.Command, []string) {}}
+	root.AddCommand(child)
+
+	root.SetArgs([]string{"child"})
+	if err := root.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	assert.False(t, IsJSONOutput(child))
+
+	root.SetArgs([]string{"child", "--format", "json"})
+	if err := root.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, IsJSONOutput(child))
+
+	// A command's own --format flag takes the place of the global flag
+	var childFormat string
+	other := &cobra.Command{Use: "other", Run: func(*cobra.Command, []string) {}}
+	other.Flags().StringVar(&childFormat, "format", "tree", "")
+	root.AddCommand(other)
+
+	root.SetArgs([]string{"other", "--format", "json"})
+	if err := root.Execute(); err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, IsJSONOutput(other))
+	assert.Equal(t, "json", childFormat)
+}
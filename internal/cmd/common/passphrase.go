@@ -0,0 +1,71 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/gittuf/gittuf/internal/signerverifier"
+	"golang.org/x/term"
+)
+
+// PassphraseEnvVar is the environment variable the passphrase of encrypted
+// private keys is read from, both when generating and when signing using them.
+// When it is not set, the passphrase is prompted for on the terminal.
+const PassphraseEnvVar = "GITTUF_PASSPHRASE"
+
+var ErrNoPassphrase = fmt.Errorf("passphrase for private key required, set %s or run gittuf in a terminal to enter it", PassphraseEnvVar)
+
+// ReadPassphrase returns the passphrase set in GITTUF_PASSPHRASE, or prompts
+// for it on the terminal using the specified prompt. An empty passphrase is
+// rejected.
+func ReadPassphrase(prompt string) ([]byte, error) {
+	if passphrase := os.Getenv(PassphraseEnvVar); passphrase != "" {
+		return []byte(passphrase), nil
+	}
+
+	fd := int(os.Stdin.Fd())
+	if !term.IsTerminal(fd) {
+		return nil, ErrNoPassphrase
+	}
+
+	fmt.Fprint(os.Stderr, prompt)
+	passphrase, err := term.ReadPassword(fd)
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return nil, err
+	}
+	if len(passphrase) == 0 {
+		return nil, signerverifier.ErrEmptyPassphrase
+	}
+	return passphrase, nil
+}
+
+// ReadPrivateKey reads the private key at keyPath. If the key is encrypted, it
+// is decrypted using the passphrase read using ReadPassphrase.
+func ReadPrivateKey(keyPath string) ([]byte, error) {
+	keyBytes, err := os.ReadFile(keyPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if !signerverifier.IsEncryptedPrivateKey(keyBytes) {
+		return keyBytes, nil
+	}
+
+	passphrase, err := ReadPassphrase(fmt.Sprintf("Enter passphrase for '%s': ", keyPath))
+	if err != nil {
+		return nil, err
+	}
+
+	decryptedKeyBytes, err := signerverifier.DecryptPrivateKey(keyBytes, passphrase)
+	if err != nil {
+		if errors.Is(err, signerverifier.ErrIncorrectPassphrase) {
+			return nil, fmt.Errorf("%w '%s'", signerverifier.ErrIncorrectPassphrase, keyPath)
+		}
+		return nil, err
+	}
+	return decryptedKeyBytes, nil
+}
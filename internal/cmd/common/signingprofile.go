@@ -0,0 +1,59 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+// SigningProfileFlag is the name of the persistent flag used to select a
+// signing profile.
+const SigningProfileFlag = "signing-profile"
+
+// signingProfileLookup is implemented by repository.Repository.
+type signingProfileLookup interface {
+	GetSigningProfile(name string) (*repository.SigningProfile, error)
+}
+
+// ApplySigningProfile applies the signing profile selected using
+// --signing-profile, if any. The profile's signing key is used when
+// --signing-key is not specified, and its Git signing settings are used when
+// gittuf signs commits. The profile takes precedence over the gittuf.signingKey
+// setting, so this must be called before ApplyConfigDefaults.
+func ApplySigningProfile(cmd *cobra.Command) error {
+	flag := cmd.Flags().Lookup(SigningProfileFlag)
+	if flag == nil || flag.Value.String() == "" {
+		return nil
+	}
+
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	return applySigningProfile(cmd, flag.Value.String(), repo)
+}
+
+func applySigningProfile(cmd *cobra.Command, name string, profiles signingProfileLookup) error {
+	profile, err := profiles.GetSigningProfile(name)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug(fmt.Sprintf("Using signing profile '%s'...", profile.Name))
+
+	if flag := cmd.Flags().Lookup("signing-key"); flag != nil && !flag.Changed && profile.SigningKey != "" {
+		slog.Debug(fmt.Sprintf("Setting --signing-key to '%s' from signing profile...", profile.SigningKey))
+		if err := cmd.Flags().Set("signing-key", profile.SigningKey); err != nil {
+			return err
+		}
+	}
+
+	gitinterface.SetConfigOverrides(profile.GitConfigOverrides())
+	return nil
+}
@@ -0,0 +1,73 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+type testSigningProfiles map[string]*repository.SigningProfile
+
+func (p testSigningProfiles) GetSigningProfile(name string) (*repository.SigningProfile, error) {
+	profile, has := p[name]
+	if !has {
+		return nil, fmt.Errorf("%w: '%s'", repository.ErrSigningProfileNotFound, name)
+	}
+	return profile, nil
+}
+
+func TestApplySigningProfile(t *testing.T) {
+	profiles := testSigningProfiles{
+		"root": {Name: "root", SigningKey: "hsm:root"},
+	}
+
+	newCommand := func(t *testing.T, args ...string) *cobra.Command {
+		t.Helper()
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("signing-key", "", "")
+		if err := cmd.ParseFlags(args); err != nil {
+			t.Fatal(err)
+		}
+		return cmd
+	}
+
+	t.Run("signing key not specified", func(t *testing.T) {
+		cmd := newCommand(t)
+
+		err := applySigningProfile(cmd, "root", profiles)
+		assert.Nil(t, err)
+
+		signingKey, _ := cmd.Flags().GetString("signing-key")
+		assert.Equal(t, "hsm:root", signingKey)
+
+		// The profile takes precedence over gittuf.signingKey
+		err = applyConfigDefaults(cmd, testConfig{repository.ConfigSigningKey: "root.pem"})
+		assert.Nil(t, err)
+
+		signingKey, _ = cmd.Flags().GetString("signing-key")
+		assert.Equal(t, "hsm:root", signingKey)
+	})
+
+	t.Run("signing key specified", func(t *testing.T) {
+		cmd := newCommand(t, "--signing-key", "root.pem")
+
+		err := applySigningProfile(cmd, "root", profiles)
+		assert.Nil(t, err)
+
+		signingKey, _ := cmd.Flags().GetString("signing-key")
+		assert.Equal(t, "root.pem", signingKey)
+	})
+
+	t.Run("unknown profile", func(t *testing.T) {
+		cmd := newCommand(t)
+
+		err := applySigningProfile(cmd, "developer", profiles)
+		assert.ErrorIs(t, err, repository.ErrSigningProfileNotFound)
+	})
+}
@@ -0,0 +1,79 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/gittuf/gittuf/internal/notify"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+// WebhookOptions configures the webhook that is notified of violations.
+type WebhookOptions struct {
+	URL    string
+	Format string
+}
+
+// AddFlags adds the --webhook-url and --webhook-format flags to the command.
+func (o *WebhookOptions) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.URL,
+		"webhook-url",
+		"",
+		"URL to POST a description of each violation to, including the offending RSL entries and who recorded them",
+	)
+
+	cmd.Flags().StringVar(
+		&o.Format,
+		"webhook-format",
+		notify.FormatGeneric,
+		fmt.Sprintf("format of the webhook's payload (%s, %s)", notify.FormatGeneric, notify.FormatSlack),
+	)
+}
+
+// NewWebhook returns the webhook to notify of violations, or nil if no webhook
+// is configured.
+func (o *WebhookOptions) NewWebhook() (*notify.Webhook, error) {
+	if o.URL == "" {
+		return nil, nil
+	}
+
+	return notify.NewWebhook(o.URL, o.Format)
+}
+
+// NotifyViolation notifies the webhook, if set, that verification failed with
+// err. The violation is completed using the RSL entries that caused err. A
+// failure to notify the webhook is reported as a warning.
+func NotifyViolation(cmd *cobra.Command, webhook *notify.Webhook, repo *repository.Repository, violation *notify.Violation, err error) {
+	if webhook == nil || err == nil {
+		return
+	}
+
+	if violation.Repository == "" {
+		violation.Repository, _ = os.Getwd() //nolint:errcheck
+	}
+	violation.Error = err.Error()
+	violation.Time = time.Now().UTC()
+
+	for _, entry := range repo.GetViolatingEntries(err) {
+		violationEntry := &notify.Entry{
+			ID:       entry.ID.String(),
+			Ref:      entry.RefName,
+			TargetID: entry.TargetID.String(),
+			Error:    entry.Err.Error(),
+		}
+		if entry.SignerName != "" || entry.SignerEmail != "" {
+			violationEntry.Signer = &notify.Signer{Name: entry.SignerName, Email: entry.SignerEmail, Signed: entry.Signed}
+		}
+		violation.Entries = append(violation.Entries, violationEntry)
+	}
+
+	if err := webhook.Notify(cmd.Context(), violation); err != nil {
+		slog.Warn(fmt.Sprintf("Unable to notify webhook: %s", err.Error()))
+	}
+}
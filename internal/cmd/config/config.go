@@ -0,0 +1,33 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package config
+
+import (
+	"github.com/gittuf/gittuf/internal/cmd/config/get"
+	"github.com/gittuf/gittuf/internal/cmd/config/list"
+	"github.com/gittuf/gittuf/internal/cmd/config/set"
+	"github.com/gittuf/gittuf/internal/cmd/config/unset"
+	"github.com/spf13/cobra"
+)
+
+func New() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Tools to manage gittuf's settings",
+		Long: `These commands manage gittuf's settings, which provide the default values of flags used across commands. The settings are stored in the "gittuf" section of the repository's Git config. Settings that are not set in the repository are read from the global and system Git configs, so defaults for all of a user's repositories can be set using "git config --global", such as "git config --global gittuf.signingKey <key>". Flags specified on the command line always take precedence over settings.
+
+The supported settings are:
+  gittuf.signingKey         the signing key used when --signing-key is not specified
+  gittuf.remote             the remote used when --remote is not specified
+  gittuf.autoFetch          if false, gittuf references are not fetched before verification, as with --no-fetch
+  gittuf.verificationDepth  if "latest", only the latest RSL entry is verified by default, as with --latest-only`,
+		DisableAutoGenTag: true,
+	}
+
+	cmd.AddCommand(get.New())
+	cmd.AddCommand(list.New())
+	cmd.AddCommand(set.New())
+	cmd.AddCommand(unset.New())
+
+	return cmd
+}
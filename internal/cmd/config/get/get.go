@@ -0,0 +1,44 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package get
+
+import (
+	"fmt"
+
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct{}
+
+func (o *options) AddFlags(_ *cobra.Command) {}
+
+func (o *options) Run(_ *cobra.Command, args []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	value, err := repo.GetConfigValue(args[0])
+	if err != nil {
+		return err
+	}
+
+	fmt.Println(value)
+	return nil
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "get <key>",
+		Short:             "Print the value of a gittuf setting",
+		Long:              "This command prints the value of the specified gittuf setting, such as gittuf.signingKey. If the setting is not set in the repository's, the global, or the system Git config, its default value is printed.",
+		Args:              cobra.ExactArgs(1),
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
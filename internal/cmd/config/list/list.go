@@ -0,0 +1,69 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package list
+
+import (
+	"fmt"
+
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+// settingValue is the value of a setting printed with --format json.
+type settingValue struct {
+	*repository.Setting
+	Value string `json:"value"`
+	IsSet bool   `json:"set"`
+}
+
+type options struct{}
+
+func (o *options) AddFlags(_ *cobra.Command) {}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	values := []*settingValue{}
+	for _, setting := range repository.Settings() {
+		value, isSet, err := repo.LookupConfigValue(setting.Key)
+		if err != nil {
+			return err
+		}
+		if !isSet {
+			value = setting.DefaultValue
+		}
+		values = append(values, &settingValue{Setting: setting, Value: value, IsSet: isSet})
+	}
+
+	if common.IsJSONOutput(cmd) {
+		return common.PrintJSON(values)
+	}
+
+	for _, value := range values {
+		if value.IsSet {
+			fmt.Printf("%s=%s\n", value.Key, value.Value)
+		} else {
+			fmt.Printf("%s=%s (default)\n", value.Key, value.Value)
+		}
+	}
+	return nil
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "list",
+		Short:             "List gittuf's settings and their values",
+		Long:              "This command lists all of gittuf's settings along with their current values. Settings that are not set in the repository's, the global, or the system Git config are listed with their default values.",
+		Args:              cobra.NoArgs,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,36 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package set
+
+import (
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct{}
+
+func (o *options) AddFlags(_ *cobra.Command) {}
+
+func (o *options) Run(_ *cobra.Command, args []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	return repo.SetConfigValue(args[0], args[1])
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "set <key> <value>",
+		Short:             "Set a gittuf setting in the repository",
+		Long:              "This command sets the specified gittuf setting, such as gittuf.signingKey, in the repository's Git config. To set a default for all repositories, use \"git config --global\" instead.",
+		Args:              cobra.ExactArgs(2),
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
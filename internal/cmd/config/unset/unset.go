@@ -0,0 +1,36 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package unset
+
+import (
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct{}
+
+func (o *options) AddFlags(_ *cobra.Command) {}
+
+func (o *options) Run(_ *cobra.Command, args []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	return repo.UnsetConfigValue(args[0])
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "unset <key>",
+		Short:             "Remove a gittuf setting from the repository",
+		Long:              "This command removes the specified gittuf setting from the repository's Git config. The setting's value in the global or system Git config, if any, or its default value is used instead.",
+		Args:              cobra.ExactArgs(1),
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
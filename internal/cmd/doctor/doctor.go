@@ -0,0 +1,57 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package doctor
+
+import (
+	"fmt"
+
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct{}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	report, err := repo.Diagnose(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	if common.IsJSONOutput(cmd) {
+		if err := common.PrintJSON(report); err != nil {
+			return err
+		}
+	} else {
+		for _, diagnostic := range report.Diagnostics {
+			fmt.Printf("%s [%s] %s\n", diagnostic.Severity, diagnostic.Check, diagnostic.Message)
+			if diagnostic.Fix != "" {
+				fmt.Printf("    fix: %s\n", diagnostic.Fix)
+			}
+		}
+	}
+
+	if errorCount := report.ErrorCount(); errorCount > 0 {
+		return fmt.Errorf("found %d problem(s) that prevent gittuf from working correctly", errorCount)
+	}
+	return nil
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "doctor",
+		Short:             "Check the environment for problems that affect gittuf",
+		Long:              "This command checks the environment gittuf runs in for common problems and suggests how to fix them. It checks that the Git binary is available and recent enough, that the signing program configured in Git for gpg.format is available, that gittuf's hooks are installed, that the RSL and policy refs exist, whether the repository's remotes are configured to fetch gittuf refs, and that the local clock is not behind the timestamp of the latest RSL entry. The command fails if any errors are found, warnings are reported but do not cause a failure.",
+		Args:              cobra.NoArgs,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+
+	return cmd
+}
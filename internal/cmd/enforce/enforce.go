@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package enforce
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	preReceive        bool
+	expiryGracePeriod time.Duration
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(
+		&o.preReceive,
+		"pre-receive",
+		false,
+		"verify reference updates passed on stdin in the format used by Git's pre-receive hook",
+	)
+
+	cmd.Flags().DurationVar(
+		&o.expiryGracePeriod,
+		"expiry-grace-period",
+		policy.DefaultExpiryGracePeriod,
+		"duration after expiry during which policy metadata is accepted with a warning",
+	)
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	if !o.preReceive {
+		return errors.New("enforcement mode must be specified (supported: --pre-receive)")
+	}
+
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	updates, err := gitinterface.ParseReferenceUpdates(cmd.InOrStdin())
+	if err != nil {
+		return err
+	}
+
+	if err := repo.VerifyProposedUpdates(cmd.Context(), updates, getQuarantineObjectDirs(), repository.WithExpiryGracePeriod(o.expiryGracePeriod)); err != nil {
+		return fmt.Errorf("gittuf rejected push: %w", err)
+	}
+
+	fmt.Fprintf(cmd.OutOrStdout(), "gittuf verified %d reference update(s)\n", len(updates))
+	return nil
+}
+
+// getQuarantineObjectDirs returns the object directories Git uses for objects
+// received by a push that has not been accepted yet.
+func getQuarantineObjectDirs() []string {
+	objectDirs := []string{}
+	if objectDir := os.Getenv("GIT_OBJECT_DIRECTORY"); objectDir != "" {
+		objectDirs = append(objectDirs, objectDir)
+	}
+	objectDirs = append(objectDirs, filepath.SplitList(os.Getenv("GIT_ALTERNATE_OBJECT_DIRECTORIES"))...)
+
+	return objectDirs
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "enforce",
+		Short:             "Enforce gittuf policies on a Git server",
+		Long:              `This command enforces gittuf policies for pushes received by a Git server. With --pre-receive, it is meant to be invoked by the repository's pre-receive hook, such as the one installed using "gittuf hooks install --hook pre-receive". The reference updates are read from stdin. Each updated reference must be recorded in the pushed RSL, and its new RSL entries must meet the repository's policy. RSL entries accepted prior to the push are trusted. The push is rejected if any update fails verification or if the push rewrites the RSL. Note that this means compacted RSLs must be applied on the server directly.`,
+		Args:              cobra.NoArgs,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
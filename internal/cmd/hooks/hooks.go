@@ -0,0 +1,20 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package hooks
+
+import (
+	"github.com/gittuf/gittuf/internal/cmd/hooks/install"
+	"github.com/spf13/cobra"
+)
+
+func New() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "hooks",
+		Short:             "Tools to manage gittuf's Git hooks",
+		DisableAutoGenTag: true,
+	}
+
+	cmd.AddCommand(install.New())
+
+	return cmd
+}
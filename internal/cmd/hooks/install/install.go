@@ -0,0 +1,77 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package install
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	hooks []string
+	force bool
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	hookTypes := []string{}
+	for _, hookType := range repository.HookTypes() {
+		hookTypes = append(hookTypes, string(hookType))
+	}
+
+	cmd.Flags().StringSliceVar(
+		&o.hooks,
+		"hook",
+		hookTypes,
+		fmt.Sprintf("hooks to install (%s, or %s on Git servers)", strings.Join(hookTypes, ", "), repository.HookPreReceive),
+	)
+
+	cmd.Flags().BoolVarP(
+		&o.force,
+		"force",
+		"f",
+		false,
+		"overwrite existing hooks instead of chaining them",
+	)
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	for _, hook := range o.hooks {
+		hookType := repository.HookType(hook)
+		chained, err := repo.InstallHook(hookType, o.force)
+		if err != nil {
+			return err
+		}
+
+		if chained {
+			fmt.Fprintf(cmd.OutOrStdout(), "Installed '%s' hook, existing hook is invoked first\n", hookType)
+		} else {
+			fmt.Fprintf(cmd.OutOrStdout(), "Installed '%s' hook\n", hookType)
+		}
+	}
+
+	return nil
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:   "install",
+		Short: "Install Git hooks that record and verify RSL entries",
+		Long: `This command installs Git hooks that invoke gittuf. The pre-push hook fetches the remote's RSL, records and verifies RSL entries for the pushed references, and pushes the RSL. The post-merge hook verifies the current branch after a merge. The pre-commit hook warns if commit signing is not enabled. On Git servers, the pre-receive hook can be installed to reject pushes that fail verification using "gittuf enforce --pre-receive".
+
+If a hook that was not installed by gittuf already exists, it is renamed with the ".chained" suffix and invoked by the gittuf hook before gittuf runs. Use --force to overwrite existing hooks instead. Hooks previously installed by gittuf are always updated.`,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
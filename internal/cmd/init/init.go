@@ -0,0 +1,411 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package init
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/gittuf/gittuf/internal/signerverifier"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/go-git/go-git/v5"
+	"github.com/spf13/cobra"
+)
+
+const (
+	rootKeyName   = "root"
+	policyKeyName = "policy"
+)
+
+type options struct {
+	interactive    bool
+	rootKey        string
+	policyKey      string
+	generateKeys   string
+	protectBranch  string
+	authorizedKeys []string
+	installHooks   bool
+	remoteName     string
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVarP(
+		&o.interactive,
+		"interactive",
+		"i",
+		false,
+		"prompt for each step of the setup",
+	)
+
+	cmd.Flags().StringVar(
+		&o.rootKey,
+		"root-key",
+		"",
+		"signing key to use for the root of trust",
+	)
+
+	cmd.Flags().StringVar(
+		&o.policyKey,
+		"policy-key",
+		"",
+		"signing key to use for the primary policy file",
+	)
+
+	cmd.Flags().StringVar(
+		&o.generateKeys,
+		"generate-keys",
+		"",
+		"directory to write new root and policy keys to, used instead of --root-key and --policy-key",
+	)
+
+	cmd.Flags().StringVar(
+		&o.protectBranch,
+		"protect-branch",
+		"main",
+		"branch to protect with a rule in the primary policy file, empty to skip",
+	)
+
+	cmd.Flags().StringArrayVar(
+		&o.authorizedKeys,
+		"authorize-key",
+		[]string{},
+		"public key authorized to write to the protected branch",
+	)
+
+	cmd.Flags().BoolVar(
+		&o.installHooks,
+		"install-hooks",
+		true,
+		"install gittuf's Git hooks",
+	)
+
+	cmd.Flags().StringVar(
+		&o.remoteName,
+		"remote",
+		gitinterface.DefaultRemoteName,
+		"remote to configure to fetch gittuf refs, empty to skip",
+	)
+}
+
+func (o *options) PreRunE(cmd *cobra.Command, args []string) error {
+	if o.interactive {
+		return common.CheckIfSigningViable(cmd, args)
+	}
+
+	if o.generateKeys == "" && (o.rootKey == "" || o.policyKey == "") {
+		return common.NewExitError(common.ExitCodeUsage, errors.New("--root-key and --policy-key, or --generate-keys, must be specified"))
+	}
+	if o.protectBranch != "" && len(o.authorizedKeys) == 0 {
+		return common.NewExitError(common.ExitCodeUsage, errors.New("--authorize-key must be specified to protect a branch, set --protect-branch to empty to skip"))
+	}
+
+	return common.CheckIfSigningViable(cmd, args)
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	if o.interactive {
+		if err := o.prompt(cmd.InOrStdin(), cmd.OutOrStdout()); err != nil {
+			return err
+		}
+	}
+
+	out := cmd.OutOrStdout()
+
+	if o.generateKeys != "" {
+		if err := o.writeKeys(out); err != nil {
+			return err
+		}
+	}
+
+	rootSigner, err := common.GetSigner(o.rootKey)
+	if err != nil {
+		return err
+	}
+	policySigner, err := common.GetSigner(o.policyKey)
+	if err != nil {
+		return err
+	}
+	policyPublicKey, err := signerverifier.NewKeyFromSigner(policySigner)
+	if err != nil {
+		return err
+	}
+
+	if err := repo.InitializeRoot(cmd.Context(), rootSigner, true); err != nil {
+		return err
+	}
+	fmt.Fprintln(out, "Initialized root of trust")
+
+	if err := repo.AddTopLevelTargetsKey(cmd.Context(), rootSigner, policyPublicKey, true); err != nil {
+		return err
+	}
+	if err := repo.InitializeTargets(cmd.Context(), policySigner, policy.TargetsRoleName, true); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "Initialized policy '%s' with key '%s'\n", policy.TargetsRoleName, policyPublicKey.KeyID)
+
+	if o.protectBranch != "" {
+		authorizedKeys := []*tuf.Key{}
+		for _, key := range o.authorizedKeys {
+			key, err := common.LoadPublicKey(key)
+			if err != nil {
+				return err
+			}
+			authorizedKeys = append(authorizedKeys, key)
+		}
+
+		ruleName := fmt.Sprintf("protect-%s", o.protectBranch)
+		rulePattern := fmt.Sprintf("git:%s%s", gitinterface.BranchRefPrefix, o.protectBranch)
+		if err := repo.AddDelegation(cmd.Context(), policySigner, policy.TargetsRoleName, ruleName, authorizedKeys, []string{rulePattern}, 1, true); err != nil {
+			return err
+		}
+		fmt.Fprintf(out, "Added rule '%s' protecting '%s'\n", ruleName, rulePattern)
+	}
+
+	if o.installHooks {
+		for _, hookType := range repository.HookTypes() {
+			chained, err := repo.InstallHook(hookType, false)
+			if err != nil {
+				return err
+			}
+
+			if chained {
+				fmt.Fprintf(out, "Installed '%s' hook, existing hook is invoked first\n", hookType)
+			} else {
+				fmt.Fprintf(out, "Installed '%s' hook\n", hookType)
+			}
+		}
+	}
+
+	if o.remoteName != "" {
+		added, err := repo.TrackRemoteGittufRefs(o.remoteName)
+		switch {
+		case errors.Is(err, git.ErrRemoteNotFound):
+			fmt.Fprintf(out, "Remote '%s' not found, skipping fetch refspec\n", o.remoteName)
+		case err != nil:
+			return err
+		case added:
+			fmt.Fprintf(out, "Configured '%s' to fetch gittuf refs\n", o.remoteName)
+		}
+	}
+
+	fmt.Fprintln(out, "Push the policy and RSL using 'gittuf policy remote push <remote>'")
+	return nil
+}
+
+// writeKeys generates the root and policy keys, writes them to the generateKeys
+// directory, and selects them for signing.
+func (o *options) writeKeys(out io.Writer) error {
+	if err := os.MkdirAll(o.generateKeys, 0o700); err != nil {
+		return err
+	}
+
+	keyPaths := map[string]*string{rootKeyName: &o.rootKey, policyKeyName: &o.policyKey}
+	for _, keyName := range []string{rootKeyName, policyKeyName} {
+		privateKeyPath := filepath.Join(o.generateKeys, keyName)
+		publicKeyPath := privateKeyPath + ".pub"
+
+		for _, path := range []string{privateKeyPath, publicKeyPath} {
+			if _, err := os.Stat(path); err == nil {
+				return fmt.Errorf("'%s' already exists, not overwriting it", path)
+			}
+		}
+
+		privateKey, publicKey, err := signerverifier.GenerateKeyPair(signerverifier.ECDSAKeyType)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(privateKeyPath, privateKey, 0o600); err != nil {
+			return err
+		}
+		if err := os.WriteFile(publicKeyPath, publicKey, 0o644); err != nil { //nolint:gosec
+			return err
+		}
+
+		*keyPaths[keyName] = privateKeyPath
+		fmt.Fprintf(out, "Generated %s key '%s' (public key '%s')\n", keyName, privateKeyPath, publicKeyPath)
+	}
+
+	return nil
+}
+
+// prompt walks the user through each step of the setup, setting the options
+// from their answers. Flags that were set are used as the default answers.
+func (o *options) prompt(in io.Reader, out io.Writer) error {
+	p := &prompter{in: bufio.NewReader(in), out: out}
+
+	fmt.Fprintln(out, "This will initialize gittuf's root of trust and policy for the repository.")
+
+	generate, err := p.confirm("Generate new root and policy keys?", o.rootKey == "" && o.policyKey == "")
+	if err != nil {
+		return err
+	}
+	if generate {
+		defaultDir := o.generateKeys
+		if defaultDir == "" {
+			defaultDir = defaultKeysDirectory()
+		}
+		o.generateKeys, err = p.ask("Directory to write keys to", defaultDir)
+		if err != nil {
+			return err
+		}
+	} else {
+		o.generateKeys = ""
+		fmt.Fprintln(out, "Keys may be paths to private keys or references such as gcpkms://, piv:, or ssh-agent:.")
+		if o.rootKey, err = p.askRequired("Root of trust signing key", o.rootKey); err != nil {
+			return err
+		}
+		if o.policyKey, err = p.askRequired("Policy signing key", o.policyKey); err != nil {
+			return err
+		}
+	}
+
+	if o.protectBranch, err = p.ask("Branch to protect (empty to skip)", o.protectBranch); err != nil {
+		return err
+	}
+	if o.protectBranch != "" {
+		fmt.Fprintln(out, `Authorized keys may be paths to public keys, "gpg:<fingerprint>", or "fulcio:<identity>::<issuer>".`)
+		keys, err := p.askRequired(fmt.Sprintf("Keys authorized to write to '%s' (comma separated)", o.protectBranch), strings.Join(o.authorizedKeys, ","))
+		if err != nil {
+			return err
+		}
+		o.authorizedKeys = splitList(keys)
+	}
+
+	if o.installHooks, err = p.confirm("Install gittuf's Git hooks?", o.installHooks); err != nil {
+		return err
+	}
+
+	if o.remoteName, err = p.ask("Remote to configure to fetch gittuf refs (empty to skip)", o.remoteName); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// defaultKeysDirectory returns the directory generated keys are written to by
+// default, which is outside the repository so they are not committed.
+func defaultKeysDirectory() string {
+	name := "repository"
+	if wd, err := os.Getwd(); err == nil {
+		name = filepath.Base(wd)
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return filepath.Join("..", fmt.Sprintf("%s-gittuf-keys", name))
+	}
+	return filepath.Join(home, ".gittuf", "keys", name)
+}
+
+func splitList(value string) []string {
+	items := []string{}
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// prompter reads answers to questions from the user.
+type prompter struct {
+	in  *bufio.Reader
+	out io.Writer
+}
+
+// ask returns the user's answer to the question, or defaultAnswer if the
+// answer is empty.
+func (p *prompter) ask(question, defaultAnswer string) (string, error) {
+	if defaultAnswer != "" {
+		fmt.Fprintf(p.out, "%s [%s]: ", question, defaultAnswer)
+	} else {
+		fmt.Fprintf(p.out, "%s: ", question)
+	}
+
+	answer, err := p.in.ReadString('\n')
+	if err != nil && (!errors.Is(err, io.EOF) || answer == "") {
+		if errors.Is(err, io.EOF) {
+			return "", io.ErrUnexpectedEOF
+		}
+		return "", err
+	}
+
+	answer = strings.TrimSpace(answer)
+	if answer == "" {
+		return defaultAnswer, nil
+	}
+	return answer, nil
+}
+
+// askRequired asks the question until the user provides a non-empty answer.
+func (p *prompter) askRequired(question, defaultAnswer string) (string, error) {
+	for {
+		answer, err := p.ask(question, defaultAnswer)
+		if err != nil {
+			return "", err
+		}
+		if answer != "" {
+			return answer, nil
+		}
+		fmt.Fprintln(p.out, "An answer is required.")
+	}
+}
+
+// confirm asks a yes or no question.
+func (p *prompter) confirm(question string, defaultAnswer bool) (bool, error) {
+	options := "y/N"
+	if defaultAnswer {
+		options = "Y/n"
+	}
+
+	for {
+		answer, err := p.ask(fmt.Sprintf("%s (%s)", question, options), "")
+		if err != nil {
+			return false, err
+		}
+
+		switch strings.ToLower(answer) {
+		case "":
+			return defaultAnswer, nil
+		case "y", "yes":
+			return true, nil
+		case "n", "no":
+			return false, nil
+		}
+		fmt.Fprintln(p.out, "Answer 'y' or 'n'.")
+	}
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:   "init",
+		Short: "Set up gittuf for the repository",
+		Long: `This command sets up gittuf for a repository in a single step. It initializes the root of trust using the root key, authorizes the policy key for the primary policy file and initializes it, adds a rule protecting a branch (main by default) that authorizes the keys specified using --authorize-key, installs gittuf's Git hooks, and configures the remote to fetch gittuf refs into its remote tracking namespace.
+
+With --generate-keys, new ECDSA root and policy keys are written to the specified directory and used instead of --root-key and --policy-key. With --interactive, gittuf walks through each step, prompting for the keys to use or generate, the branch to protect and the keys authorized to write to it, whether to install hooks, and the remote to configure. Flags set alongside --interactive are used as the default answers.
+
+Policy changes are committed and recorded in the RSL using the Git signing configuration, so commit signing must be configured.`,
+		Args:              cobra.NoArgs,
+		PreRunE:           o.PreRunE,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,123 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package inspect
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/signerverifier"
+	sslibsv "github.com/gittuf/gittuf/internal/third_party/go-securesystemslib/signerverifier"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/spf13/cobra"
+	"golang.org/x/crypto/ssh"
+)
+
+// keyDetails is the key printed with --format json.
+type keyDetails struct {
+	KeyID        string                       `json:"keyID"`
+	KeyType      string                       `json:"keyType"`
+	Scheme       string                       `json:"scheme"`
+	PublicKey    string                       `json:"publicKey"`
+	Fingerprints *signerverifier.Fingerprints `json:"fingerprints,omitempty"`
+}
+
+type options struct{}
+
+func (o *options) AddFlags(_ *cobra.Command) {}
+
+func (o *options) Run(cmd *cobra.Command, args []string) error {
+	key, err := loadKey(args[0])
+	if err != nil {
+		return err
+	}
+
+	details := &keyDetails{
+		KeyID:     key.KeyID,
+		KeyType:   key.KeyType,
+		Scheme:    key.Scheme,
+		PublicKey: key.KeyVal.Public,
+	}
+
+	// Keys such as Sigstore identities and GPG keys do not have the
+	// fingerprints of raw public keys
+	fingerprints, err := signerverifier.GetFingerprints(key)
+	if err == nil {
+		details.Fingerprints = fingerprints
+	}
+
+	if common.IsJSONOutput(cmd) {
+		return common.PrintJSON(details)
+	}
+
+	out := cmd.OutOrStdout()
+	fmt.Fprintf(out, "Key ID:    %s\n", details.KeyID)
+	fmt.Fprintf(out, "Key Type:  %s\n", details.KeyType)
+	fmt.Fprintf(out, "Scheme:    %s\n", details.Scheme)
+	if details.Fingerprints != nil {
+		fmt.Fprintln(out, "Fingerprints:")
+		fmt.Fprintf(out, "    SSH (SHA256):           %s\n", details.Fingerprints.SSHSHA256)
+		fmt.Fprintf(out, "    SSH (MD5):              %s\n", details.Fingerprints.SSHMD5)
+		fmt.Fprintf(out, "    PKIX public key SHA256: %s\n", details.Fingerprints.PKIXSHA256)
+		fmt.Fprintf(out, "    SSH authorized key:     %s\n", details.Fingerprints.AuthorizedKey)
+	}
+	if details.PublicKey != "" {
+		fmt.Fprintln(out, "Public Key:")
+		for _, line := range strings.Split(strings.TrimSpace(details.PublicKey), "\n") {
+			fmt.Fprintf(out, "    %s\n", line)
+		}
+	}
+
+	return nil
+}
+
+// loadKey returns the public key for keyRef, which is loaded like the keys
+// passed to policy commands. Private keys and OpenSSH public keys on disk are
+// also accepted, so the key ID of a signing key can be confirmed before it is
+// used.
+func loadKey(keyRef string) (*tuf.Key, error) {
+	key, err := common.LoadPublicKey(keyRef)
+	if err == nil {
+		return key, nil
+	}
+
+	keyBytes, readErr := os.ReadFile(keyRef)
+	if readErr != nil {
+		// keyRef is not a file, so it is not a private key either
+		return nil, err
+	}
+
+	if publicKey, _, _, _, err := ssh.ParseAuthorizedKey(keyBytes); err == nil {
+		cryptoPublicKey, ok := publicKey.(ssh.CryptoPublicKey)
+		if !ok {
+			return nil, fmt.Errorf("unsupported SSH key type '%s'", publicKey.Type())
+		}
+		return sslibsv.NewKey(cryptoPublicKey.CryptoPublicKey())
+	}
+
+	signer, signerErr := common.GetSigner(keyRef)
+	if signerErr != nil {
+		return nil, errors.Join(err, signerErr)
+	}
+	return signerverifier.NewKeyFromSigner(signer)
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:   "inspect <file|uri>",
+		Short: "Print the gittuf key ID and fingerprints of a key",
+		Long: `This command prints the key ID gittuf computes for the specified key, which is the ID recorded in gittuf's policy when the key is authorized, along with the key's algorithm and fingerprints in the formats used by ssh-keygen and openssl. This can be used to confirm which key will be added to the policy before signing.
+
+The key may be specified in any form accepted by policy commands, such as the path to a public key, a gpg: fingerprint, or a gcpkms://, ssh-agent:, or keychain: reference. The paths to private keys and OpenSSH public keys (e.g., id_ed25519.pub) are also accepted.`,
+		Args:              cobra.ExactArgs(1),
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
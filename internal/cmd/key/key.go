@@ -0,0 +1,20 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package key
+
+import (
+	"github.com/gittuf/gittuf/internal/cmd/key/inspect"
+	"github.com/spf13/cobra"
+)
+
+func New() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "key",
+		Short:             "Tools to work with signing keys",
+		DisableAutoGenTag: true,
+	}
+
+	cmd.AddCommand(inspect.New())
+
+	return cmd
+}
@@ -0,0 +1,59 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package importkey
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/signerverifier/keychain"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	deleteFile bool
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(
+		&o.deleteFile,
+		"delete",
+		false,
+		"delete the private key file once it is stored in the keychain",
+	)
+}
+
+func (o *options) Run(cmd *cobra.Command, args []string) error {
+	name, keyPath := args[0], args[1]
+
+	keyBytes, err := common.ReadPrivateKey(keyPath)
+	if err != nil {
+		return err
+	}
+
+	if err := keychain.StoreKey(cmd.Context(), name, keyBytes); err != nil {
+		return err
+	}
+	fmt.Fprintf(cmd.OutOrStdout(), "Stored key '%s' in the OS keychain, sign using '--signing-key %s%s'\n", keyPath, keychain.KeyReferencePrefix, name)
+
+	if o.deleteFile {
+		return os.Remove(keyPath)
+	}
+	return nil
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "import <name> <private-key>",
+		Short:             "Store a private key in the OS keychain",
+		Long:              "This command stores the specified private key in the OS keychain with the specified name, replacing any key with the same name. Encrypted private keys are decrypted before they are stored, using the passphrase read from GITTUF_PASSPHRASE or prompted for.",
+		Args:              cobra.ExactArgs(2),
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
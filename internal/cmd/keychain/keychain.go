@@ -0,0 +1,25 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package keychain
+
+import (
+	"github.com/gittuf/gittuf/internal/cmd/keychain/importkey"
+	"github.com/gittuf/gittuf/internal/cmd/keychain/removekey"
+	"github.com/spf13/cobra"
+)
+
+func New() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "keychain",
+		Short: "Tools to manage signing keys stored in the OS keychain",
+		Long: `These commands manage gittuf signing keys stored in the operating system's keychain, so that private keys do not have to be kept in files on disk. Keys are stored in the macOS Keychain, the Windows Credential Manager, or, on other platforms, the Secret Service (e.g., GNOME Keyring or KWallet) using libsecret's secret-tool.
+
+A key stored with the name <name> is used for signing by specifying "--signing-key keychain:<name>", and its public key can be added to gittuf's policy in the same way. New keys can be generated directly into the keychain using "gittuf keygen --keychain <name>".`,
+		DisableAutoGenTag: true,
+	}
+
+	cmd.AddCommand(importkey.New())
+	cmd.AddCommand(removekey.New())
+
+	return cmd
+}
@@ -0,0 +1,30 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package removekey
+
+import (
+	"github.com/gittuf/gittuf/internal/signerverifier/keychain"
+	"github.com/spf13/cobra"
+)
+
+type options struct{}
+
+func (o *options) AddFlags(_ *cobra.Command) {}
+
+func (o *options) Run(cmd *cobra.Command, args []string) error {
+	return keychain.DeleteKey(cmd.Context(), args[0])
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "remove <name>",
+		Short:             "Remove a private key from the OS keychain",
+		Args:              cobra.ExactArgs(1),
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
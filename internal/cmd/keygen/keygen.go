@@ -0,0 +1,227 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package keygen
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/signerverifier"
+	"github.com/gittuf/gittuf/internal/signerverifier/keychain"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/spf13/cobra"
+)
+
+// generatedKey is the key printed with --format json.
+type generatedKey struct {
+	KeyID          string `json:"keyID"`
+	KeyType        string `json:"keyType"`
+	PrivateKeyPath string `json:"privateKeyPath,omitempty"`
+	PublicKeyPath  string `json:"publicKeyPath,omitempty"`
+	Keychain       string `json:"keychain,omitempty"`
+	PublicKey      string `json:"publicKey"`
+	Encrypted      bool   `json:"encrypted"`
+}
+
+type options struct {
+	keyType    string
+	output     string
+	passphrase bool
+	keychain   string
+	force      bool
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(
+		&o.keyType,
+		"type",
+		"t",
+		signerverifier.ED25519KeyType,
+		fmt.Sprintf("type of key to generate (%s or %s)", signerverifier.ED25519KeyType, signerverifier.ECDSAKeyType),
+	)
+
+	cmd.Flags().StringVarP(
+		&o.output,
+		"output",
+		"o",
+		"",
+		"path to write the private key to, the public key is written to the same path with the .pub extension",
+	)
+
+	cmd.Flags().BoolVar(
+		&o.passphrase,
+		"passphrase",
+		false,
+		"protect the private key with a passphrase, read from GITTUF_PASSPHRASE or prompted for",
+	)
+
+	cmd.Flags().StringVar(
+		&o.keychain,
+		"keychain",
+		"",
+		"store the private key in the OS keychain with the specified name instead of writing it to --output",
+	)
+	cmd.MarkFlagsMutuallyExclusive("keychain", "passphrase")
+
+	cmd.Flags().BoolVar(
+		&o.force,
+		"force",
+		false,
+		"overwrite existing key files or keychain entries",
+	)
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	if o.keyType != signerverifier.ED25519KeyType && o.keyType != signerverifier.ECDSAKeyType {
+		return common.NewExitError(common.ExitCodeUsage, fmt.Errorf("unsupported key type '%s', must be %s or %s", o.keyType, signerverifier.ED25519KeyType, signerverifier.ECDSAKeyType))
+	}
+
+	if o.output == "" && o.keychain == "" {
+		return common.NewExitError(common.ExitCodeUsage, errors.New("--output or --keychain must be specified"))
+	}
+
+	// When the private key is stored in the keychain, only the public key is
+	// written to disk
+	var privateKeyPath, publicKeyPath string
+	if o.output != "" {
+		publicKeyPath = o.output + ".pub"
+		if o.keychain == "" {
+			privateKeyPath = o.output
+		}
+	}
+
+	if !o.force {
+		for _, path := range []string{privateKeyPath, publicKeyPath} {
+			if path == "" {
+				continue
+			}
+			if _, err := os.Stat(path); err == nil {
+				return fmt.Errorf("'%s' already exists, use --force to overwrite it", path)
+			}
+		}
+
+		if o.keychain != "" {
+			hasKey, err := keychain.HasKey(cmd.Context(), o.keychain)
+			if err != nil {
+				return err
+			}
+			if hasKey {
+				return fmt.Errorf("key '%s' already exists in the OS keychain, use --force to overwrite it", o.keychain)
+			}
+		}
+	}
+
+	privateKey, publicKey, err := signerverifier.GenerateKeyPair(o.keyType)
+	if err != nil {
+		return err
+	}
+
+	if o.passphrase {
+		passphrase, err := readPassphrase()
+		if err != nil {
+			return err
+		}
+
+		privateKey, err = signerverifier.EncryptPrivateKey(privateKey, passphrase)
+		if err != nil {
+			return err
+		}
+	}
+
+	key, err := tuf.LoadKeyFromBytes(publicKey)
+	if err != nil {
+		return err
+	}
+
+	signingKey := privateKeyPath
+	if o.keychain != "" {
+		if err := keychain.StoreKey(cmd.Context(), o.keychain, privateKey); err != nil {
+			return err
+		}
+		signingKey = keychain.KeyReferencePrefix + o.keychain
+	} else if err := os.WriteFile(privateKeyPath, privateKey, 0o600); err != nil {
+		return err
+	}
+	if publicKeyPath != "" {
+		if err := os.WriteFile(publicKeyPath, publicKey, 0o644); err != nil { //nolint:gosec
+			return err
+		}
+	}
+
+	if common.IsJSONOutput(cmd) {
+		return common.PrintJSON(&generatedKey{
+			KeyID:          key.KeyID,
+			KeyType:        key.KeyType,
+			PrivateKeyPath: privateKeyPath,
+			PublicKeyPath:  publicKeyPath,
+			Keychain:       o.keychain,
+			PublicKey:      string(publicKey),
+			Encrypted:      o.passphrase,
+		})
+	}
+
+	out := cmd.OutOrStdout()
+	switch {
+	case o.keychain == "":
+		fmt.Fprintf(out, "Generated %s key '%s' (public key '%s')\n", key.KeyType, privateKeyPath, publicKeyPath)
+	case publicKeyPath != "":
+		fmt.Fprintf(out, "Generated %s key '%s' in the OS keychain (public key '%s')\n", key.KeyType, o.keychain, publicKeyPath)
+	default:
+		fmt.Fprintf(out, "Generated %s key '%s' in the OS keychain\n", key.KeyType, o.keychain)
+	}
+	fmt.Fprintf(out, "Key ID: %s\n\n", key.KeyID)
+	fmt.Fprint(out, string(publicKey))
+	fmt.Fprintln(out)
+	fmt.Fprintln(out, "Authorize the key in the policy using, for example:")
+	authorizedKey := publicKeyPath
+	if authorizedKey == "" {
+		authorizedKey = signingKey
+	}
+	fmt.Fprintf(out, "    gittuf policy add-key --signing-key <policy-key> --authorize-key %s\n", authorizedKey)
+	fmt.Fprintf(out, "Sign using the key with '--signing-key %s'\n", signingKey)
+
+	return nil
+}
+
+// readPassphrase reads the passphrase to encrypt the private key with using
+// common.ReadPassphrase. When the passphrase is prompted for, it is read twice,
+// returning an error if the entries do not match.
+func readPassphrase() ([]byte, error) {
+	passphrase, err := common.ReadPassphrase("Enter passphrase: ")
+	if err != nil {
+		return nil, err
+	}
+
+	confirmation, err := common.ReadPassphrase("Confirm passphrase: ")
+	if err != nil {
+		return nil, err
+	}
+
+	if string(passphrase) != string(confirmation) {
+		return nil, errors.New("passphrases do not match")
+	}
+	return passphrase, nil
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:   "keygen",
+		Short: "Generate a signing key for use with gittuf",
+		Long: `This command generates an ED25519 or ECDSA (P-256) signing key. The private key is written to the path specified using --output as a PEM encoded PKCS #8 key, and the public key is written alongside it with the .pub extension as a PEM encoded PKIX key. These are the formats gittuf consumes for signing keys and authorized public keys respectively.
+
+If --keychain is specified, the private key is stored in the OS keychain instead of on disk, and is used for signing with "--signing-key keychain:<name>". The public key is written to <output>.pub if --output is also specified.
+
+If --passphrase is specified, the private key is encrypted using a passphrase, and written in the OpenSSH private key format. The passphrase is read from GITTUF_PASSPHRASE or prompted for. gittuf asks for the passphrase in the same way when the key is used for signing.
+
+The key ID and the public key are printed so the key can be added to gittuf's policy, for example using "gittuf policy add-key" or "gittuf trust add-root-key".`,
+		Args:              cobra.NoArgs,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,149 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package migrate
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/spf13/cobra"
+)
+
+// migrationResult is printed with --format json.
+type migrationResult struct {
+	SchemaVersion  int                 `json:"schemaVersion"`
+	SchemaVersions map[string]int      `json:"roleSchemaVersions,omitempty"`
+	Migrations     []*policy.Migration `json:"migrations,omitempty"`
+	Migrated       []string            `json:"migrated,omitempty"`
+}
+
+type options struct {
+	signingKey string
+	dryRun     bool
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(
+		&o.signingKey,
+		"signing-key",
+		"k",
+		"",
+		"signing key to sign migrated metadata with",
+	)
+
+	cmd.Flags().BoolVar(
+		&o.dryRun,
+		"dry-run",
+		false,
+		"list the metadata that must be migrated without migrating it",
+	)
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	if !o.dryRun && o.signingKey == "" {
+		return common.NewExitError(common.ExitCodeUsage, errors.New("--signing-key must be specified unless --dry-run is set"))
+	}
+
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	if o.dryRun {
+		return o.listPending(cmd, repo)
+	}
+
+	if err := common.CheckIfSigningViable(cmd, nil); err != nil {
+		return err
+	}
+
+	signer, err := common.GetSigner(o.signingKey)
+	if err != nil {
+		return err
+	}
+
+	migrated, err := repo.MigratePolicy(cmd.Context(), signer, true)
+	if err != nil {
+		return err
+	}
+
+	if common.IsJSONOutput(cmd) {
+		return common.PrintJSON(&migrationResult{SchemaVersion: tuf.SchemaVersion, Migrated: migrated})
+	}
+
+	out := cmd.OutOrStdout()
+	if len(migrated) == 0 {
+		fmt.Fprintf(out, "Policy metadata is already at schema version %d\n", tuf.SchemaVersion)
+		return nil
+	}
+	fmt.Fprintf(out, "Migrated policy metadata to schema version %d:\n", tuf.SchemaVersion)
+	for _, roleName := range migrated {
+		fmt.Fprintf(out, "    %s\n", roleName)
+	}
+	fmt.Fprintln(out, "Metadata not yet signed by a threshold of its keys is staged, see 'gittuf policy list-pending'")
+
+	return nil
+}
+
+func (o *options) listPending(cmd *cobra.Command, repo *repository.Repository) error {
+	schemaVersions, err := repo.GetPolicySchemaVersions(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	oldestSchemaVersion := tuf.SchemaVersion
+	for _, schemaVersion := range schemaVersions {
+		oldestSchemaVersion = min(oldestSchemaVersion, schemaVersion)
+	}
+	migrations := policy.Migrations(oldestSchemaVersion)
+
+	if common.IsJSONOutput(cmd) {
+		return common.PrintJSON(&migrationResult{SchemaVersion: tuf.SchemaVersion, SchemaVersions: schemaVersions, Migrations: migrations})
+	}
+
+	out := cmd.OutOrStdout()
+	if len(migrations) == 0 {
+		fmt.Fprintf(out, "Policy metadata is already at schema version %d\n", tuf.SchemaVersion)
+		return nil
+	}
+
+	roleNames := []string{}
+	for roleName, schemaVersion := range schemaVersions {
+		if schemaVersion < tuf.SchemaVersion {
+			roleNames = append(roleNames, roleName)
+		}
+	}
+	sort.Strings(roleNames)
+
+	fmt.Fprintf(out, "Metadata to migrate to schema version %d:\n", tuf.SchemaVersion)
+	for _, roleName := range roleNames {
+		fmt.Fprintf(out, "    %s (schema version %d)\n", roleName, schemaVersions[roleName])
+	}
+	fmt.Fprintln(out, "Migrations:")
+	for _, migration := range migrations {
+		fmt.Fprintf(out, "    %d: %s\n", migration.SchemaVersion, migration.Description)
+	}
+
+	return nil
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Upgrade policy metadata to the current schema version",
+		Long: `This command upgrades the root of trust and policy files that were created using an older version of gittuf's metadata schema to the current version. The version of each migrated piece of metadata is incremented and it is re-signed using the signing key, with the root of trust only signed if the signing key is a root key. Metadata that is not signed by a threshold of its trusted keys after the migration is staged until other key holders sign it using 'gittuf trust sign' or 'gittuf policy sign'.
+
+RSL entries are not rewritten, as each entry is referenced by the entries and signatures that follow it. New entries are always recorded in the current format.`,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,83 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package addperson
+
+import (
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/cmd/policy/persistent"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	p          *persistent.Options
+	policyName string
+	personID   string
+	publicKeys []string
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.policyName,
+		"policy-name",
+		policy.TargetsRoleName,
+		"name of policy file to add person to",
+	)
+
+	cmd.Flags().StringVar(
+		&o.personID,
+		"person-id",
+		"",
+		"identifier of person",
+	)
+	cmd.MarkFlagRequired("person-id") //nolint:errcheck
+
+	cmd.Flags().StringArrayVar(
+		&o.publicKeys,
+		"public-key",
+		[]string{},
+		"public key belonging to person",
+	)
+	cmd.MarkFlagRequired("public-key") //nolint:errcheck
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	signer, err := common.GetSigner(o.p.SigningKey)
+	if err != nil {
+		return err
+	}
+
+	publicKeys := []*tuf.Key{}
+	for _, key := range o.publicKeys {
+		key, err := common.LoadPublicKey(key)
+		if err != nil {
+			return err
+		}
+
+		publicKeys = append(publicKeys, key)
+	}
+
+	return repo.AddPerson(cmd.Context(), signer, o.policyName, o.personID, publicKeys, true)
+}
+
+func New(persistent *persistent.Options) *cobra.Command {
+	o := &options{p: persistent}
+	cmd := &cobra.Command{
+		Use:               "add-person",
+		Short:             "Add a person and their keys to a policy file",
+		Long:              `This command adds a person, identified by the specified ID, to the specified policy file along with the public keys that belong to them, such as their GPG key, SSH key, and Sigstore identity. Rules that authorize the person using "gittuf policy set-authorized-persons" trust all of the person's keys, and the person counts once towards the rule's threshold regardless of how many of their keys are used. If the person already exists, their keys are replaced with the specified keys, so keys can be rotated without changing any rules. Note that the keys can be specified from disk, from the GPG keyring using the "gpg:<fingerprint>" format, or as a Sigstore identity as "fulcio:<identity>::<issuer>".`,
+		PreRunE:           common.CheckIfSigningViable,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
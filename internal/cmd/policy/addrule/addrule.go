@@ -3,8 +3,6 @@
 package addrule
 
 import (
-	"os"
-
 	"github.com/gittuf/gittuf/internal/cmd/common"
 	"github.com/gittuf/gittuf/internal/cmd/policy/persistent"
 	"github.com/gittuf/gittuf/internal/policy"
@@ -68,11 +66,7 @@ func (o *options) Run(cmd *cobra.Command, _ []string) error {
 		return err
 	}
 
-	keyBytes, err := os.ReadFile(o.p.SigningKey)
-	if err != nil {
-		return err
-	}
-	signer, err := common.LoadSigner(keyBytes)
+	signer, err := common.GetSigner(o.p.SigningKey)
 	if err != nil {
 		return err
 	}
@@ -95,7 +89,7 @@ func New(persistent *persistent.Options) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:               "add-rule",
 		Short:             "Add a new rule to a policy file",
-		Long:              `This command allows users to add a new rule to the specified policy file. By default, the main policy file is selected. Note that authorized keys can be specified from disk, from the GPG keyring using the "gpg:<fingerprint>" format, or as a Sigstore identity as "fulcio:<identity>::<issuer>".`,
+		Long:              `This command allows users to add a new rule to the specified policy file. By default, the main policy file is selected. Note that authorized keys can be specified from disk, from the GPG keyring using the "gpg:<fingerprint>" format, as a Sigstore identity as "fulcio:<identity>::<issuer>", as an X.509 identity issued by the root certificates in a PEM file as "x509:<identity>::<root-certificates-path>", as an SSH certificate authority trusted to issue user certificates for a principal as "ssh-ca:<principal>::<ca-public-key-path>", or as a pattern of Sigstore identities as "fulcio-regexp:<identity-regexp>::<issuer-regexp>". Identity patterns are regular expressions that must match the entire subject alternative name (such as an email address or URI) and OIDC issuer of the Fulcio certificate used to sign, and all identities matching a pattern count once towards the rule's threshold.`,
 		PreRunE:           common.CheckIfSigningViable,
 		RunE:              o.Run,
 		DisableAutoGenTag: true,
@@ -0,0 +1,92 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package addteammembers
+
+import (
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/cmd/policy/persistent"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	p             *persistent.Options
+	policyName    string
+	teamID        string
+	memberKeys    []string
+	memberPersons []string
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.policyName,
+		"policy-name",
+		policy.TargetsRoleName,
+		"name of policy file containing the team",
+	)
+
+	cmd.Flags().StringVar(
+		&o.teamID,
+		"team-id",
+		"",
+		"identifier of team",
+	)
+	cmd.MarkFlagRequired("team-id") //nolint:errcheck
+
+	cmd.Flags().StringArrayVar(
+		&o.memberKeys,
+		"member-key",
+		[]string{},
+		"public key to add to team",
+	)
+
+	cmd.Flags().StringArrayVar(
+		&o.memberPersons,
+		"member-person",
+		[]string{},
+		"identifier of person to add to team",
+	)
+
+	cmd.MarkFlagsOneRequired("member-key", "member-person")
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	signer, err := common.GetSigner(o.p.SigningKey)
+	if err != nil {
+		return err
+	}
+
+	memberKeys := []*tuf.Key{}
+	for _, key := range o.memberKeys {
+		key, err := common.LoadPublicKey(key)
+		if err != nil {
+			return err
+		}
+
+		memberKeys = append(memberKeys, key)
+	}
+
+	return repo.AddTeamMembers(cmd.Context(), signer, o.policyName, o.teamID, memberKeys, o.memberPersons, true)
+}
+
+func New(persistent *persistent.Options) *cobra.Command {
+	o := &options{p: persistent}
+	cmd := &cobra.Command{
+		Use:               "add-team-members",
+		Short:             "Add keys and people to a team",
+		Long:              `This command adds the specified keys and people to a team in the specified policy file, creating the team if it does not exist. People must first be added to the policy file using "gittuf policy add-person". Rules that authorize the team using "gittuf policy set-authorized-teams" trust the new members without being changed. Note that the keys can be specified from disk, from the GPG keyring using the "gpg:<fingerprint>" format, or as a Sigstore identity as "fulcio:<identity>::<issuer>".`,
+		PreRunE:           common.CheckIfSigningViable,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
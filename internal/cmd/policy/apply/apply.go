@@ -0,0 +1,35 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package apply
+
+import (
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct{}
+
+func (o *options) AddFlags(_ *cobra.Command) {}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	return repo.ApplyStagedPolicy(cmd.Context(), true)
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "apply",
+		Short:             "Apply the staged policy",
+		Long:              `This command verifies that the staged policy is signed by a threshold of keys for every role, and then applies it to the policy namespace, recording the change in the RSL. Policy changes are staged when they require signatures from other key holders, who can add them using 'gittuf trust sign', 'gittuf policy sign', and 'gittuf policy sign-snapshot'. The roles that still need signatures can be listed using 'gittuf policy list-pending'.`,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
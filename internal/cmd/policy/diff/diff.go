@@ -0,0 +1,126 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package diff
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+const indent = "    "
+
+type options struct {
+	from string
+	to   string
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.from,
+		"from",
+		"",
+		"RSL entry to use as the base policy, defaults to the current policy",
+	)
+
+	cmd.Flags().StringVar(
+		&o.to,
+		"to",
+		"",
+		"RSL entry to compare against the base policy, defaults to the staged policy",
+	)
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	diff, err := repo.DiffPolicy(cmd.Context(), o.from, o.to)
+	if err != nil {
+		return err
+	}
+
+	if common.IsJSONOutput(cmd) {
+		return common.PrintJSON(diff)
+	}
+
+	if diff.IsEmpty() {
+		fmt.Println("No differences found in policy")
+		return nil
+	}
+
+	if len(diff.Roles) > 0 {
+		fmt.Println("Roles:")
+		for _, role := range diff.Roles {
+			fmt.Printf("%s%s role '%s'\n", indent, role.Change, role.Name)
+			printList("Added keys", role.AddedKeyIDs)
+			printList("Removed keys", role.RemovedKeyIDs)
+			printThreshold(role.OldThreshold, role.NewThreshold)
+		}
+	}
+
+	if len(diff.Rules) > 0 {
+		fmt.Println("Rules:")
+		for _, rule := range diff.Rules {
+			fmt.Printf("%s%s rule '%s' in policy file '%s'\n", indent, rule.Change, rule.Name, rule.PolicyName)
+			printList("Added paths", rule.AddedPaths)
+			printList("Removed paths", rule.RemovedPaths)
+			printList("Added keys", rule.AddedKeyIDs)
+			printList("Removed keys", rule.RemovedKeyIDs)
+			printList("Added people", rule.AddedPersonIDs)
+			printList("Removed people", rule.RemovedPersonIDs)
+			printList("Added teams", rule.AddedTeamIDs)
+			printList("Removed teams", rule.RemovedTeamIDs)
+			printThreshold(rule.OldThreshold, rule.NewThreshold)
+		}
+	}
+
+	if len(diff.Signers) > 0 {
+		fmt.Println("Signatures:")
+		for _, signers := range diff.Signers {
+			fmt.Printf("%smetadata '%s'\n", indent, signers.Name)
+			printList("Added signatures from", signers.AddedKeyIDs)
+			printList("Removed signatures from", signers.RemovedKeyIDs)
+		}
+	}
+
+	return nil
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "diff",
+		Short:             "Show changes between policy states",
+		Long:              "This command summarizes the roles, rules, keys, thresholds, and signatures added, removed, or modified between two policy states. By default, the current policy is compared with the staged policy. Policy states at specific RSL entries can be compared using the --from and --to flags.",
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
+
+func printList(title string, items []string) {
+	if len(items) == 0 {
+		return
+	}
+
+	fmt.Printf("%s%s:\n", strings.Repeat(indent, 2), title)
+	for _, item := range items {
+		fmt.Printf("%s%s\n", strings.Repeat(indent, 3), item)
+	}
+}
+
+func printThreshold(oldThreshold, newThreshold int) {
+	if oldThreshold == newThreshold {
+		return
+	}
+
+	fmt.Printf("%sThreshold: %d -> %d\n", strings.Repeat(indent, 2), oldThreshold, newThreshold)
+}
@@ -0,0 +1,35 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package discard
+
+import (
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct{}
+
+func (o *options) AddFlags(_ *cobra.Command) {}
+
+func (o *options) Run(_ *cobra.Command, _ []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	return repo.DiscardStagedPolicy()
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "discard",
+		Short:             "Discard the staged policy",
+		Long:              `This command discards the staged policy, including any changes and signatures that have not been applied. The current policy is not affected.`,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
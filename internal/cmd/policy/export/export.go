@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package export
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	formatYAML = "yaml"
+	formatJSON = "json"
+)
+
+type options struct {
+	format string
+	output string
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.format,
+		"format",
+		formatYAML,
+		fmt.Sprintf("format of exported policy (%s, %s)", formatYAML, formatJSON),
+	)
+
+	cmd.Flags().StringVarP(
+		&o.output,
+		"output",
+		"o",
+		"",
+		"file to write exported policy to, defaults to standard output",
+	)
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	document, err := repo.ExportPolicy(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	var contents []byte
+	switch o.format {
+	case formatYAML:
+		contents, err = yaml.Marshal(document)
+	case formatJSON:
+		contents, err = json.MarshalIndent(document, "", "  ")
+		contents = append(contents, '\n')
+	default:
+		return fmt.Errorf("unknown format '%s'", o.format)
+	}
+	if err != nil {
+		return err
+	}
+
+	if o.output == "" {
+		_, err = os.Stdout.Write(contents)
+		return err
+	}
+
+	return os.WriteFile(o.output, contents, 0o644) // nolint:gosec
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "export",
+		Short:             "Export policy as a declarative document",
+		Long:              "This command exports the root of trust and all policy files of the staged policy, or the current policy if nothing is staged, as a single YAML or JSON document. The document can be edited and applied using 'gittuf policy import'.",
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,134 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package exportgithubbranchprotection
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/github"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+const (
+	tokenEnvKey = "GITHUB_TOKEN"
+	indent      = "    "
+)
+
+type options struct {
+	repository string
+	baseURL    string
+	dryRun     bool
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.repository,
+		"repository",
+		"",
+		"GitHub repository in the form <owner>/<repository>",
+	)
+	cmd.MarkFlagRequired("repository") //nolint:errcheck
+
+	cmd.Flags().StringVar(
+		&o.baseURL,
+		"base-url",
+		github.DefaultBaseURL,
+		"location of the GitHub API",
+	)
+
+	cmd.Flags().BoolVar(
+		&o.dryRun,
+		"dry-run",
+		false,
+		"show the changes to the branch protection settings without updating them",
+	)
+}
+
+type exportResult struct {
+	Updates []*repository.BranchProtectionUpdate `json:"updates"`
+	Notes   []string                             `json:"notes"`
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	owner, repositoryName, found := strings.Cut(o.repository, "/")
+	if !found || owner == "" || repositoryName == "" {
+		return fmt.Errorf("invalid repository '%s', expected <owner>/<repository>", o.repository)
+	}
+
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	client := github.NewClient(o.baseURL, os.Getenv(tokenEnvKey))
+
+	updates, notes, err := repo.ExportGitHubBranchProtection(cmd.Context(), client, owner, repositoryName, o.dryRun)
+	if err != nil {
+		return err
+	}
+
+	if common.IsJSONOutput(cmd) {
+		return common.PrintJSON(&exportResult{Updates: updates, Notes: notes})
+	}
+
+	printResult(updates, notes)
+
+	return nil
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:   "export-github-branch-protection",
+		Short: "Update the branch protection settings of a GitHub repository to match the policy",
+		Long: fmt.Sprintf(`This command updates the branch protection settings of a GitHub repository so that GitHub enforces the same requirements as the rules in the current policy when pull requests are merged. For each branch protected by a rule, the number of required approving reviews is set to one less than the largest threshold of the rules protecting it, as the person pushing a change also counts towards the threshold, and stale approvals are dismissed as gittuf only counts approvals of the commit being merged. Force pushes and deletions are allowed only if a rule authorizes them, and the settings apply to administrators too.
+
+Settings that do not correspond to gittuf policy, such as required status checks, are left unchanged, as are branches not protected by any rule. Rules that protect branches using wildcards cannot be exported and are listed.
+
+The branches whose settings are updated are printed. Use --dry-run to preview the changes. The token in %s is used to authenticate with the GitHub API, and must have access to the repository's administration settings.`, tokenEnvKey),
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
+
+func printResult(updates []*repository.BranchProtectionUpdate, notes []string) {
+	if len(updates) == 0 {
+		fmt.Println("Branch protection settings are up to date")
+	}
+	for _, update := range updates {
+		current := update.Current
+		if current == nil {
+			fmt.Printf("Protect branch '%s'\n", update.Branch)
+			current = &github.BranchProtection{}
+		} else {
+			fmt.Printf("Update branch '%s'\n", update.Branch)
+		}
+
+		printChange("Required approving reviews", current.RequiredApprovingReviewCount, update.Updated.RequiredApprovingReviewCount)
+		printChange("Dismiss stale reviews", current.DismissStaleReviews, update.Updated.DismissStaleReviews)
+		printChange("Enforce for administrators", current.EnforceAdmins, update.Updated.EnforceAdmins)
+		printChange("Allow force pushes", current.AllowForcePushes, update.Updated.AllowForcePushes)
+		printChange("Allow deletions", current.AllowDeletions, update.Updated.AllowDeletions)
+	}
+
+	if len(notes) > 0 {
+		fmt.Println("Notes:")
+		for _, note := range notes {
+			fmt.Printf("%s%s\n", indent, note)
+		}
+	}
+}
+
+func printChange[T comparable](title string, current, updated T) {
+	if current == updated {
+		return
+	}
+	fmt.Printf("%s%s: %v -> %v\n", indent, title, current, updated)
+}
@@ -0,0 +1,65 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package exportsshallowedsigners
+
+import (
+	"os"
+	"strings"
+
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	output string
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(
+		&o.output,
+		"output",
+		"o",
+		"",
+		"file to write allowed signers to, defaults to standard output",
+	)
+}
+
+func (o *options) Run(cmd *cobra.Command, args []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	allowedSigners, err := repo.ExportSSHAllowedSigners(cmd.Context(), args[0])
+	if err != nil {
+		return err
+	}
+
+	var contents strings.Builder
+	for _, allowedSigner := range allowedSigners {
+		contents.WriteString(allowedSigner.String())
+		contents.WriteString("\n")
+	}
+
+	if o.output == "" {
+		_, err = os.Stdout.WriteString(contents.String())
+		return err
+	}
+
+	return os.WriteFile(o.output, []byte(contents.String()), 0o644) // nolint:gosec
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "export-ssh-allowed-signers <ref>",
+		Short:             "Export the SSH keys trusted for a ref as a Git allowed signers file",
+		Long:              "This command renders the SSH keys and SSH certificate authorities that the current policy trusts to sign for the specified ref in the allowed signers format used by Git and ssh-keygen. Setting gpg.ssh.allowedSignersFile to the exported file allows commands such as 'git verify-commit' to verify SSH signatures using the keys managed by gittuf. Keys that belong to a person use the person's ID as the principal, while other keys use their key ID. Revoked keys are omitted, and the file must be exported again when the policy changes.",
+		Args:              cobra.ExactArgs(1),
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,167 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package importcodeowners
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/cmd/policy/persistent"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/repository"
+	sslibdsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
+	"github.com/spf13/cobra"
+)
+
+const indent = "    "
+
+type options struct {
+	p           *persistent.Options
+	policyName  string
+	mappingFile string
+	dryRun      bool
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.policyName,
+		"policy-name",
+		policy.TargetsRoleName,
+		"name of policy file to add rules to",
+	)
+
+	cmd.Flags().StringVar(
+		&o.mappingFile,
+		"mapping",
+		"",
+		"YAML or JSON file mapping CODEOWNERS owners to people, teams, or keys in the policy",
+	)
+	cmd.MarkFlagRequired("mapping") //nolint:errcheck
+
+	cmd.Flags().BoolVar(
+		&o.dryRun,
+		"dry-run",
+		false,
+		"show the changes to the rules without updating the policy",
+	)
+}
+
+type importResult struct {
+	Rules   []*policy.RuleDiff        `json:"rules"`
+	Skipped []*policy.CodeOwnersEntry `json:"skipped"`
+}
+
+func (o *options) Run(cmd *cobra.Command, args []string) error {
+	codeOwnersContents, err := os.ReadFile(args[0])
+	if err != nil {
+		return err
+	}
+	entries, err := policy.ParseCodeOwners(codeOwnersContents)
+	if err != nil {
+		return err
+	}
+
+	mappingContents, err := os.ReadFile(o.mappingFile)
+	if err != nil {
+		return err
+	}
+	mapping, err := policy.LoadCodeOwnersMapping(mappingContents)
+	if err != nil {
+		return err
+	}
+
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	var signer sslibdsse.SignerVerifier
+	if !o.dryRun {
+		signer, err = common.GetSigner(o.p.SigningKey)
+		if err != nil {
+			return err
+		}
+	}
+
+	diff, skipped, err := repo.ImportCodeOwners(cmd.Context(), signer, o.policyName, entries, mapping, o.dryRun, true)
+	if err != nil {
+		return err
+	}
+
+	if common.IsJSONOutput(cmd) {
+		return common.PrintJSON(&importResult{Rules: diff, Skipped: skipped})
+	}
+
+	printResult(diff, skipped)
+
+	return nil
+}
+
+func New(persistent *persistent.Options) *cobra.Command {
+	o := &options{p: persistent}
+	cmd := &cobra.Command{
+		Use:   "import-codeowners <codeowners-file>",
+		Short: "Generate rules from a CODEOWNERS file",
+		Long: fmt.Sprintf(`This command generates a rule for each pattern in a CODEOWNERS file, so that changes to the files matched by the pattern must be approved by one of its owners. Rules are named '%s<pattern>' and added before the implicit allow rule. Running the command again updates the previously imported rules in place, retaining other settings such as their conditions, and removes the rules of patterns no longer in the file.
+
+Owners, such as "@octocat", "@org/team", or an email address, are mapped to the people, teams, and keys in the policy file using the file passed with --mapping. For example:
+
+  "@octocat":
+    person: octocat
+  "@org/security":
+    team: security
+  "dev@example.com":
+    keys:
+      - <key-id>
+
+Patterns are translated to file rule patterns, where "**" matches any number of directories. Patterns without a leading or inner "/" match at any depth, and patterns naming a directory match everything in it. Negated patterns are not supported, and patterns without owners are skipped.
+
+Note that in CODEOWNERS files, only the last pattern that matches a file applies, while in gittuf, a change to a file can be approved by the owners of any matching rule.
+
+The changes made to the rules are printed. Use --dry-run to preview the changes before the policy is signed and updated.`, policy.CodeOwnersRuleNamePrefix),
+		Args:              cobra.ExactArgs(1),
+		PreRunE:           common.CheckIfSigningViable,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
+
+func printResult(diff []*policy.RuleDiff, skipped []*policy.CodeOwnersEntry) {
+	if len(diff) == 0 {
+		fmt.Println("Rules are up to date")
+	}
+	for _, rule := range diff {
+		fmt.Printf("%s rule '%s'\n", rule.Change, rule.Name)
+		printList("Added paths", rule.AddedPaths)
+		printList("Removed paths", rule.RemovedPaths)
+		printList("Added people", rule.AddedPersonIDs)
+		printList("Removed people", rule.RemovedPersonIDs)
+		printList("Added teams", rule.AddedTeamIDs)
+		printList("Removed teams", rule.RemovedTeamIDs)
+		printList("Added keys", rule.AddedKeyIDs)
+		printList("Removed keys", rule.RemovedKeyIDs)
+	}
+
+	if len(skipped) > 0 {
+		fmt.Println("Skipped patterns without owners:")
+		for _, entry := range skipped {
+			fmt.Printf("%s%s (line %d)\n", indent, entry.Pattern, entry.Line)
+		}
+	}
+}
+
+func printList(title string, items []string) {
+	if len(items) == 0 {
+		return
+	}
+
+	fmt.Printf("%s%s:\n", indent, title)
+	for _, item := range items {
+		fmt.Printf("%s%s\n", strings.Repeat(indent, 2), item)
+	}
+}
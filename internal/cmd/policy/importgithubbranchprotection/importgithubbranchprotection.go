@@ -0,0 +1,180 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package importgithubbranchprotection
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/cmd/policy/persistent"
+	"github.com/gittuf/gittuf/internal/github"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/repository"
+	sslibdsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
+	"github.com/spf13/cobra"
+)
+
+const (
+	tokenEnvKey = "GITHUB_TOKEN"
+	indent      = "    "
+)
+
+type options struct {
+	p          *persistent.Options
+	policyName string
+	repository string
+	branches   []string
+	personIDs  []string
+	teamIDs    []string
+	baseURL    string
+	dryRun     bool
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.policyName,
+		"policy-name",
+		policy.TargetsRoleName,
+		"name of policy file to add rules to",
+	)
+
+	cmd.Flags().StringVar(
+		&o.repository,
+		"repository",
+		"",
+		"GitHub repository in the form <owner>/<repository>",
+	)
+	cmd.MarkFlagRequired("repository") //nolint:errcheck
+
+	cmd.Flags().StringArrayVar(
+		&o.branches,
+		"branch",
+		[]string{},
+		"protected branch to import, defaults to all protected branches",
+	)
+
+	cmd.Flags().StringArrayVar(
+		&o.personIDs,
+		"authorize-person",
+		[]string{},
+		"identifier of person authorized by the generated rules",
+	)
+
+	cmd.Flags().StringArrayVar(
+		&o.teamIDs,
+		"authorize-team",
+		[]string{},
+		"identifier of team authorized by the generated rules",
+	)
+
+	cmd.Flags().StringVar(
+		&o.baseURL,
+		"base-url",
+		github.DefaultBaseURL,
+		"location of the GitHub API",
+	)
+
+	cmd.Flags().BoolVar(
+		&o.dryRun,
+		"dry-run",
+		false,
+		"show the changes to the rules without updating the policy",
+	)
+}
+
+type importResult struct {
+	Rules []*policy.RuleDiff `json:"rules"`
+	Notes []string           `json:"notes"`
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	owner, repositoryName, found := strings.Cut(o.repository, "/")
+	if !found || owner == "" || repositoryName == "" {
+		return fmt.Errorf("invalid repository '%s', expected <owner>/<repository>", o.repository)
+	}
+
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	var signer sslibdsse.SignerVerifier
+	if !o.dryRun {
+		signer, err = common.GetSigner(o.p.SigningKey)
+		if err != nil {
+			return err
+		}
+	}
+
+	client := github.NewClient(o.baseURL, os.Getenv(tokenEnvKey))
+
+	diff, notes, err := repo.ImportGitHubBranchProtection(cmd.Context(), signer, o.policyName, client, owner, repositoryName, o.branches, o.personIDs, o.teamIDs, o.dryRun, true)
+	if err != nil {
+		return err
+	}
+
+	if common.IsJSONOutput(cmd) {
+		return common.PrintJSON(&importResult{Rules: diff, Notes: notes})
+	}
+
+	printResult(diff, notes)
+
+	return nil
+}
+
+func New(persistent *persistent.Options) *cobra.Command {
+	o := &options{p: persistent}
+	cmd := &cobra.Command{
+		Use:   "import-github-branch-protection",
+		Short: "Generate rules from the branch protection settings of a GitHub repository",
+		Long: fmt.Sprintf(`This command fetches the branch protection settings of a GitHub repository and generates a rule for each protected branch, so that gittuf enforces the same review requirements as GitHub. Rules are named '%s<branch>' and authorize the people and teams passed with --authorize-person and --authorize-team, as GitHub does not record the signing keys of the users who may push. The threshold of each rule is one more than the number of approving reviews GitHub requires, as the person pushing a change also counts towards the threshold.
+
+Running the command again updates the previously imported rules in place, retaining other settings such as their keys and conditions. If no branches are passed with --branch, every protected branch is imported, and previously imported rules for branches that are no longer protected are removed. Settings that cannot be represented in the generated rules, such as allowing force pushes, are listed so that they can be configured separately.
+
+The changes made to the rules are printed. Use --dry-run to preview the changes before the policy is signed and updated. If set, the token in %s is used to authenticate with the GitHub API, which requires access to the repository's administration settings.`, policy.BranchProtectionRuleNamePrefix, tokenEnvKey),
+		PreRunE:           common.CheckIfSigningViable,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
+
+func printResult(diff []*policy.RuleDiff, notes []string) {
+	if len(diff) == 0 {
+		fmt.Println("Rules are up to date")
+	}
+	for _, rule := range diff {
+		fmt.Printf("%s rule '%s'\n", rule.Change, rule.Name)
+		if rule.OldThreshold != rule.NewThreshold {
+			fmt.Printf("%sThreshold: %d -> %d\n", indent, rule.OldThreshold, rule.NewThreshold)
+		}
+		printList("Added paths", rule.AddedPaths)
+		printList("Removed paths", rule.RemovedPaths)
+		printList("Added people", rule.AddedPersonIDs)
+		printList("Removed people", rule.RemovedPersonIDs)
+		printList("Added teams", rule.AddedTeamIDs)
+		printList("Removed teams", rule.RemovedTeamIDs)
+	}
+
+	if len(notes) > 0 {
+		fmt.Println("Notes:")
+		for _, note := range notes {
+			fmt.Printf("%s%s\n", indent, note)
+		}
+	}
+}
+
+func printList(title string, items []string) {
+	if len(items) == 0 {
+		return
+	}
+
+	fmt.Printf("%s%s:\n", indent, title)
+	for _, item := range items {
+		fmt.Printf("%s%s\n", strings.Repeat(indent, 2), item)
+	}
+}
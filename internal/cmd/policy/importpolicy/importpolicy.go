@@ -0,0 +1,59 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package importpolicy
+
+import (
+	"os"
+
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/cmd/policy/persistent"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	p *persistent.Options
+}
+
+func (o *options) AddFlags(_ *cobra.Command) {}
+
+func (o *options) Run(cmd *cobra.Command, args []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	contents, err := os.ReadFile(args[0])
+	if err != nil {
+		return err
+	}
+
+	document, err := policy.LoadDocument(contents)
+	if err != nil {
+		return err
+	}
+
+	signer, err := common.GetSigner(o.p.SigningKey)
+	if err != nil {
+		return err
+	}
+
+	return repo.ApplyPolicyDocument(cmd.Context(), signer, document, true)
+}
+
+func New(persistent *persistent.Options) *cobra.Command {
+	o := &options{p: persistent}
+	cmd := &cobra.Command{
+		Use:               "import <file>",
+		Short:             "Apply a declarative policy document",
+		Long:              "This command updates the policy to match a YAML or JSON document created using 'gittuf policy export'. Only metadata that differs from the document is regenerated and signed, so applying the same document again makes no changes. Policy files not listed in the document are removed.",
+		Args:              cobra.ExactArgs(1),
+		PreRunE:           common.CheckIfSigningViable,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
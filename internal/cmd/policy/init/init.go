@@ -3,18 +3,22 @@
 package init
 
 import (
-	"os"
+	"fmt"
+	"strings"
 
 	"github.com/gittuf/gittuf/internal/cmd/common"
 	"github.com/gittuf/gittuf/internal/cmd/policy/persistent"
 	"github.com/gittuf/gittuf/internal/policy"
 	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/gittuf/gittuf/internal/tuf"
 	"github.com/spf13/cobra"
 )
 
 type options struct {
-	p          *persistent.Options
-	policyName string
+	p              *persistent.Options
+	policyName     string
+	template       string
+	authorizedKeys []string
 }
 
 func (o *options) AddFlags(cmd *cobra.Command) {
@@ -24,6 +28,20 @@ func (o *options) AddFlags(cmd *cobra.Command) {
 		policy.TargetsRoleName,
 		"name of policy file to create",
 	)
+
+	cmd.Flags().StringVar(
+		&o.template,
+		"template",
+		"",
+		fmt.Sprintf("name of template to populate policy file with (%s)", strings.Join(templateNames(), ", ")),
+	)
+
+	cmd.Flags().StringArrayVar(
+		&o.authorizedKeys,
+		"authorize-key",
+		[]string{},
+		"authorized public key for the template's rules",
+	)
 }
 
 func (o *options) Run(cmd *cobra.Command, _ []string) error {
@@ -32,16 +50,38 @@ func (o *options) Run(cmd *cobra.Command, _ []string) error {
 		return err
 	}
 
-	keyBytes, err := os.ReadFile(o.p.SigningKey)
+	signer, err := common.GetSigner(o.p.SigningKey)
 	if err != nil {
 		return err
 	}
-	signer, err := common.LoadSigner(keyBytes)
-	if err != nil {
-		return err
+
+	if o.template == "" {
+		if len(o.authorizedKeys) != 0 {
+			return fmt.Errorf("--authorize-key can only be used with --template")
+		}
+
+		return repo.InitializeTargets(cmd.Context(), signer, o.policyName, true)
 	}
 
-	return repo.InitializeTargets(cmd.Context(), signer, o.policyName, true)
+	authorizedKeys := []*tuf.Key{}
+	for _, key := range o.authorizedKeys {
+		key, err := common.LoadPublicKey(key)
+		if err != nil {
+			return err
+		}
+
+		authorizedKeys = append(authorizedKeys, key)
+	}
+
+	return repo.InitializeTargetsFromTemplate(cmd.Context(), signer, o.policyName, o.template, authorizedKeys, true)
+}
+
+func templateNames() []string {
+	names := []string{}
+	for _, template := range policy.Templates() {
+		names = append(names, template.Name)
+	}
+	return names
 }
 
 func New(persistent *persistent.Options) *cobra.Command {
@@ -49,6 +89,7 @@ func New(persistent *persistent.Options) *cobra.Command {
 	cmd := &cobra.Command{
 		Use:               "init",
 		Short:             "Initialize policy file",
+		Long:              longDescription(),
 		PreRunE:           common.CheckIfSigningViable,
 		RunE:              o.Run,
 		DisableAutoGenTag: true,
@@ -57,3 +98,11 @@ func New(persistent *persistent.Options) *cobra.Command {
 
 	return cmd
 }
+
+func longDescription() string {
+	description := "This command initializes a policy file. A template can be specified to populate the policy file with rules for a common setup, authorizing the keys specified using --authorize-key. The available templates are:\n"
+	for _, template := range policy.Templates() {
+		description += fmt.Sprintf("\n  %s: %s (requires at least %d key(s))", template.Name, template.Description, template.MinimumKeys)
+	}
+	return description
+}
@@ -0,0 +1,81 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package lint
+
+import (
+	"fmt"
+
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	jsonOutput bool
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(
+		&o.jsonOutput,
+		"json",
+		false,
+		"print findings as JSON",
+	)
+	cmd.Flags().MarkDeprecated("json", "use --format json instead") //nolint:errcheck
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	findings, err := repo.LintPolicy(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	errorCount := 0
+	for _, finding := range findings {
+		if finding.Severity == policy.LintError {
+			errorCount++
+		}
+	}
+
+	if o.jsonOutput || common.IsJSONOutput(cmd) {
+		if err := common.PrintJSON(findings); err != nil {
+			return err
+		}
+	} else {
+		for _, finding := range findings {
+			location := finding.PolicyName
+			if finding.RuleName != "" {
+				location = fmt.Sprintf("%s: %s", finding.PolicyName, finding.RuleName)
+			}
+			fmt.Printf("%s [%s] %s: %s\n", finding.Severity, finding.Check, location, finding.Message)
+		}
+		if len(findings) == 0 {
+			fmt.Println("No problems found in policy")
+		}
+	}
+
+	if errorCount > 0 {
+		return fmt.Errorf("policy has %d error(s)", errorCount)
+	}
+	return nil
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "lint",
+		Short:             "Check policy for problems",
+		Long:              "This command checks the staged policy, or the current policy if nothing is staged, for rules that can never be evaluated, thresholds that cannot be met, duplicate patterns, keys, people, and teams that are trusted but not defined, and keys that use weak algorithms. The command fails if any errors are found.",
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
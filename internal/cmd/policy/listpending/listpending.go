@@ -0,0 +1,63 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package listpending
+
+import (
+	"fmt"
+
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct{}
+
+// pendingResult is the JSON representation of the roles pending signatures in
+// the staged policy.
+type pendingResult struct {
+	Applicable   bool     `json:"applicable"`
+	PendingRoles []string `json:"pending_roles"`
+}
+
+func (o *options) AddFlags(_ *cobra.Command) {}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	pendingRoles, err := repo.ListPendingPolicyRoles(cmd.Context())
+	if err != nil {
+		return err
+	}
+
+	if common.IsJSONOutput(cmd) {
+		return common.PrintJSON(&pendingResult{Applicable: len(pendingRoles) == 0, PendingRoles: pendingRoles})
+	}
+
+	if len(pendingRoles) == 0 {
+		fmt.Println("Staged policy is signed by a threshold of keys for all roles and can be applied")
+		return nil
+	}
+
+	fmt.Println("Roles pending signatures in staged policy:")
+	for _, roleName := range pendingRoles {
+		fmt.Printf("    %s\n", roleName)
+	}
+	return nil
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "list-pending",
+		Short:             "List roles in the staged policy that need more signatures",
+		Long:              `This command lists the roles in the staged policy whose metadata is not yet signed by a threshold of their trusted keys. The root of trust must be signed by a threshold of the currently trusted root keys, and the snapshot metadata, if required, must be updated to match the staged policy files.`,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
@@ -3,16 +3,34 @@
 package listrules
 
 import (
+	"encoding/json"
 	"fmt"
+	"os"
 	"strings"
 
+	"github.com/gittuf/gittuf/internal/policy"
 	"github.com/gittuf/gittuf/internal/repository"
 	"github.com/spf13/cobra"
 )
 
-type options struct{}
+const (
+	formatList = "list"
+	formatTree = "tree"
+	formatJSON = "json"
+)
+
+type options struct {
+	format string
+}
 
-func (o *options) AddFlags(_ *cobra.Command) {}
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.format,
+		"format",
+		formatList,
+		fmt.Sprintf("format of listed rules (%s, %s, %s)", formatList, formatTree, formatJSON),
+	)
+}
 
 func (o *options) Run(cmd *cobra.Command, _ []string) error {
 	repo, err := repository.LoadRepository()
@@ -20,6 +38,27 @@ func (o *options) Run(cmd *cobra.Command, _ []string) error {
 		return err
 	}
 
+	switch o.format {
+	case formatList:
+	case formatTree, formatJSON:
+		tree, err := repo.GetRuleTree(cmd.Context())
+		if err != nil {
+			return err
+		}
+
+		if o.format == formatTree {
+			fmt.Printf("Policy file %s\n", tree.PolicyName)
+			printTree(ruleTreeNodes(tree), "")
+			return nil
+		}
+
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(tree)
+	default:
+		return fmt.Errorf("unknown format '%s'", o.format)
+	}
+
 	rules, err := repo.ListRules(cmd.Context())
 	if err != nil {
 		return err
@@ -30,6 +69,12 @@ func (o *options) Run(cmd *cobra.Command, _ []string) error {
 
 	for _, curRule := range rules {
 		fmt.Printf(strings.Repeat("    ", curRule.Depth)+"Rule %s:\n", curRule.Delegation.Name)
+		if curRule.Delegation.Effect == policy.RuleEffectDeny {
+			fmt.Println(strings.Repeat("    ", curRule.Depth+1) + "Effect: deny")
+		}
+		if curRule.Delegation.Priority != 0 {
+			fmt.Printf(strings.Repeat("    ", curRule.Depth+1)+"Priority: %d\n", curRule.Delegation.Priority)
+		}
 		gitpaths, filepaths := []string{}, []string{}
 		for _, path := range curRule.Delegation.Paths {
 			if strings.HasPrefix(path, "git:") {
@@ -55,15 +100,108 @@ func (o *options) Run(cmd *cobra.Command, _ []string) error {
 		for _, key := range curRule.Delegation.Role.KeyIDs {
 			fmt.Printf(strings.Repeat("    ", curRule.Depth+2)+"%s\n", key)
 		}
+
+		if len(curRule.Delegation.PersonIDs) > 0 {
+			fmt.Println(strings.Repeat("    ", curRule.Depth+1) + "Authorized people:")
+			for _, person := range curRule.Delegation.PersonIDs {
+				fmt.Printf(strings.Repeat("    ", curRule.Depth+2)+"%s\n", person)
+			}
+		}
+
+		if len(curRule.Delegation.TeamIDs) > 0 {
+			fmt.Println(strings.Repeat("    ", curRule.Depth+1) + "Authorized teams:")
+			for _, team := range curRule.Delegation.TeamIDs {
+				fmt.Printf(strings.Repeat("    ", curRule.Depth+2)+"%s\n", team)
+			}
+		}
 	}
 	return nil
 }
 
+// treeNode is a line in the tree form of the rules, with the lines nested
+// under it.
+type treeNode struct {
+	label    string
+	children []*treeNode
+}
+
+func ruleTreeNodes(tree *policy.RuleTree) []*treeNode {
+	nodes := make([]*treeNode, 0, len(tree.Rules))
+	for _, rule := range tree.Rules {
+		node := &treeNode{label: fmt.Sprintf("Rule %s", rule.Name)}
+
+		if rule.Effect == policy.RuleEffectDeny {
+			node.children = append(node.children, &treeNode{label: "Effect: deny"})
+		}
+		if rule.Priority != 0 {
+			node.children = append(node.children, &treeNode{label: fmt.Sprintf("Priority: %d", rule.Priority)})
+		}
+		if rule.Terminating {
+			node.children = append(node.children, &treeNode{label: "Terminating: true"})
+		}
+		node.children = append(node.children, listNode("Patterns", rule.Paths))
+		node.children = append(node.children, &treeNode{label: fmt.Sprintf("Threshold: %d", rule.Threshold)})
+
+		keys := make([]string, 0, len(rule.AuthorizedKeys))
+		for _, key := range rule.AuthorizedKeys {
+			description := key.KeyID
+			switch {
+			case key.Identity != "":
+				description = fmt.Sprintf("%s (%s, %s, %s)", key.KeyID, key.KeyType, key.Identity, key.Issuer)
+			case key.KeyType != "":
+				description = fmt.Sprintf("%s (%s)", key.KeyID, key.KeyType)
+			}
+			keys = append(keys, description)
+		}
+		if len(keys) > 0 {
+			node.children = append(node.children, listNode("Authorized keys", keys))
+		}
+		if len(rule.AuthorizedPeople) > 0 {
+			node.children = append(node.children, listNode("Authorized people", rule.AuthorizedPeople))
+		}
+		if len(rule.AuthorizedTeams) > 0 {
+			node.children = append(node.children, listNode("Authorized teams", rule.AuthorizedTeams))
+		}
+
+		if rule.DelegatedRules != nil {
+			node.children = append(node.children, &treeNode{
+				label:    fmt.Sprintf("Policy file %s", rule.DelegatedRules.PolicyName),
+				children: ruleTreeNodes(rule.DelegatedRules),
+			})
+		}
+
+		nodes = append(nodes, node)
+	}
+
+	return nodes
+}
+
+func listNode(label string, items []string) *treeNode {
+	node := &treeNode{label: label + ":"}
+	for _, item := range items {
+		node.children = append(node.children, &treeNode{label: item})
+	}
+	return node
+}
+
+func printTree(nodes []*treeNode, prefix string) {
+	for i, node := range nodes {
+		connector, childPrefix := "├── ", "│   "
+		if i == len(nodes)-1 {
+			connector, childPrefix = "└── ", "    "
+		}
+
+		fmt.Println(prefix + connector + node.label)
+		printTree(node.children, prefix+childPrefix)
+	}
+}
+
 func New() *cobra.Command {
 	o := &options{}
 	cmd := &cobra.Command{
 		Use:               "list-rules",
 		Short:             "List rules for the current state",
+		Long:              `This command lists the rules in the current policy. By default, the rules are listed in the order they are evaluated, indented by the depth of their delegation. With "--format tree", the full delegation tree is printed, including the policy file each rule delegates to, the rule's patterns and threshold, and the keys, people, and teams it authorizes. With "--format json", the delegation tree is printed as JSON.`,
 		RunE:              o.Run,
 		DisableAutoGenTag: true,
 	}
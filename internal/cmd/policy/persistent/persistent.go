@@ -14,7 +14,7 @@ func (o *Options) AddPersistentFlags(cmd *cobra.Command) {
 		"signing-key",
 		"k",
 		"",
-		"signing key to use to sign policy file",
+		"signing key to use to sign policy file (file path, KMS, PKCS#11, piv:, ssh-agent:, exec:, or x509:<private-key>::<certificate-chain> key reference, or fulcio: to sign keylessly using Sigstore)",
 	)
 	cmd.MarkPersistentFlagRequired("signing-key") //nolint:errcheck
 }
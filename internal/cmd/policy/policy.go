@@ -4,14 +4,52 @@ package policy
 
 import (
 	"github.com/gittuf/gittuf/internal/cmd/policy/addkey"
+	"github.com/gittuf/gittuf/internal/cmd/policy/addperson"
 	"github.com/gittuf/gittuf/internal/cmd/policy/addrule"
+	"github.com/gittuf/gittuf/internal/cmd/policy/addteammembers"
+	"github.com/gittuf/gittuf/internal/cmd/policy/apply"
+	"github.com/gittuf/gittuf/internal/cmd/policy/diff"
+	"github.com/gittuf/gittuf/internal/cmd/policy/discard"
+	"github.com/gittuf/gittuf/internal/cmd/policy/export"
+	"github.com/gittuf/gittuf/internal/cmd/policy/exportgithubbranchprotection"
+	"github.com/gittuf/gittuf/internal/cmd/policy/exportsshallowedsigners"
+	"github.com/gittuf/gittuf/internal/cmd/policy/importcodeowners"
+	"github.com/gittuf/gittuf/internal/cmd/policy/importgithubbranchprotection"
+	"github.com/gittuf/gittuf/internal/cmd/policy/importpolicy"
 	i "github.com/gittuf/gittuf/internal/cmd/policy/init"
+	"github.com/gittuf/gittuf/internal/cmd/policy/lint"
+	"github.com/gittuf/gittuf/internal/cmd/policy/listpending"
 	"github.com/gittuf/gittuf/internal/cmd/policy/listrules"
 	"github.com/gittuf/gittuf/internal/cmd/policy/persistent"
+	"github.com/gittuf/gittuf/internal/cmd/policy/removeperson"
 	"github.com/gittuf/gittuf/internal/cmd/policy/removerule"
+	"github.com/gittuf/gittuf/internal/cmd/policy/removesignature"
+	"github.com/gittuf/gittuf/internal/cmd/policy/removeteam"
+	"github.com/gittuf/gittuf/internal/cmd/policy/removeteammembers"
+	"github.com/gittuf/gittuf/internal/cmd/policy/renew"
+	"github.com/gittuf/gittuf/internal/cmd/policy/reorderrules"
+	"github.com/gittuf/gittuf/internal/cmd/policy/setauthorizedpersons"
+	"github.com/gittuf/gittuf/internal/cmd/policy/setauthorizedteams"
+	"github.com/gittuf/gittuf/internal/cmd/policy/setcommitmessagerules"
+	"github.com/gittuf/gittuf/internal/cmd/policy/setdeletionauthorizers"
+	"github.com/gittuf/gittuf/internal/cmd/policy/setfilerestrictions"
+	"github.com/gittuf/gittuf/internal/cmd/policy/setforcepushauthorizers"
+	"github.com/gittuf/gittuf/internal/cmd/policy/setmergestrategy"
+	"github.com/gittuf/gittuf/internal/cmd/policy/setregopolicy"
+	"github.com/gittuf/gittuf/internal/cmd/policy/setruleconditions"
+	"github.com/gittuf/gittuf/internal/cmd/policy/setruleeffect"
+	"github.com/gittuf/gittuf/internal/cmd/policy/setrulepriority"
+	"github.com/gittuf/gittuf/internal/cmd/policy/setsubmoduleconstraints"
+	"github.com/gittuf/gittuf/internal/cmd/policy/show"
 	"github.com/gittuf/gittuf/internal/cmd/policy/sign"
+	"github.com/gittuf/gittuf/internal/cmd/policy/signsnapshot"
+	"github.com/gittuf/gittuf/internal/cmd/policy/simulate"
+	"github.com/gittuf/gittuf/internal/cmd/policy/syncgithubteam"
 	"github.com/gittuf/gittuf/internal/cmd/policy/updaterule"
+	"github.com/gittuf/gittuf/internal/cmd/policy/whocan"
 	"github.com/gittuf/gittuf/internal/cmd/trustpolicy/remote"
+	"github.com/gittuf/gittuf/internal/cmd/trustpolicy/remote/pull"
+	"github.com/gittuf/gittuf/internal/cmd/trustpolicy/remote/push"
 	"github.com/spf13/cobra"
 )
 
@@ -26,12 +64,42 @@ func New() *cobra.Command {
 
 	cmd.AddCommand(i.New(o))
 	cmd.AddCommand(addkey.New(o))
+	cmd.AddCommand(addperson.New(o))
 	cmd.AddCommand(addrule.New(o))
+	cmd.AddCommand(addteammembers.New(o))
+	cmd.AddCommand(importpolicy.New(o))
+	cmd.AddCommand(importcodeowners.New(o))
+	cmd.AddCommand(importgithubbranchprotection.New(o))
 	cmd.AddCommand(listrules.New())
+	cmd.AddCommand(removeperson.New(o))
 	cmd.AddCommand(removerule.New(o))
+	cmd.AddCommand(removeteam.New(o))
+	cmd.AddCommand(removeteammembers.New(o))
+	cmd.AddCommand(renew.New(o))
+	cmd.AddCommand(reorderrules.New(o))
+	cmd.AddCommand(setauthorizedpersons.New(o))
+	cmd.AddCommand(setauthorizedteams.New(o))
+	cmd.AddCommand(setcommitmessagerules.New(o))
+	cmd.AddCommand(setdeletionauthorizers.New(o))
+	cmd.AddCommand(setfilerestrictions.New(o))
+	cmd.AddCommand(setforcepushauthorizers.New(o))
+	cmd.AddCommand(setmergestrategy.New(o))
+	cmd.AddCommand(setregopolicy.New(o))
+	cmd.AddCommand(setruleconditions.New(o))
+	cmd.AddCommand(setruleeffect.New(o))
+	cmd.AddCommand(setrulepriority.New(o))
+	cmd.AddCommand(setsubmoduleconstraints.New(o))
 	cmd.AddCommand(sign.New(o))
+	cmd.AddCommand(signsnapshot.New(o))
+	cmd.AddCommand(syncgithubteam.New(o))
 	cmd.AddCommand(updaterule.New(o))
 
+	// set signing-key as not required for commands that do not sign policy
+	for _, readOnlyCmd := range []*cobra.Command{apply.New(), diff.New(), discard.New(), export.New(), exportgithubbranchprotection.New(), exportsshallowedsigners.New(), lint.New(), listpending.New(), pull.New(), push.New(), removesignature.New(), show.New(), simulate.New(), whocan.New()} {
+		cmd.AddCommand(readOnlyCmd)
+		readOnlyCmd.InheritedFlags().SetAnnotation("signing-key", cobra.BashCompOneRequiredFlag, []string{"false"}) // nolint:errcheck
+	}
+
 	remoteCmd := remote.New()
 	cmd.AddCommand(remoteCmd)
 	// set signing-key as not required for remote command
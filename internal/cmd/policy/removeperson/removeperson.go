@@ -0,0 +1,63 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package removeperson
+
+import (
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/cmd/policy/persistent"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	p          *persistent.Options
+	policyName string
+	personID   string
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.policyName,
+		"policy-name",
+		policy.TargetsRoleName,
+		"name of policy file to remove person from",
+	)
+
+	cmd.Flags().StringVar(
+		&o.personID,
+		"person-id",
+		"",
+		"identifier of person",
+	)
+	cmd.MarkFlagRequired("person-id") //nolint:errcheck
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	signer, err := common.GetSigner(o.p.SigningKey)
+	if err != nil {
+		return err
+	}
+
+	return repo.RemovePerson(cmd.Context(), signer, o.policyName, o.personID, true)
+}
+
+func New(persistent *persistent.Options) *cobra.Command {
+	o := &options{p: persistent}
+	cmd := &cobra.Command{
+		Use:               "remove-person",
+		Short:             "Remove a person from a policy file",
+		Long:              `This command removes the specified person from the specified policy file. A person cannot be removed while a rule in the policy file authorizes them.`,
+		PreRunE:           common.CheckIfSigningViable,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,56 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package removesignature
+
+import (
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	policyName string
+	keyID      string
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.policyName,
+		"policy-name",
+		policy.TargetsRoleName,
+		"name of policy file to remove signature from, or root or snapshot",
+	)
+
+	cmd.Flags().StringVar(
+		&o.keyID,
+		"key-id",
+		"",
+		"ID of key whose signature must be removed",
+	)
+	cmd.MarkFlagRequired("key-id") //nolint:errcheck
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	return repo.RemoveSignature(cmd.Context(), o.policyName, o.keyID, true)
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "remove-signature",
+		Short:             "Remove a signature from policy metadata",
+		Long:              `This command removes the signature made by the specified key from a policy file, the root of trust, or the snapshot metadata, such as a signature made using the wrong key or by a signer whose key has been revoked. If the metadata is no longer signed by a threshold of its trusted keys, the change is staged until another key holder signs it.`,
+		PreRunE:           common.CheckIfSigningViable,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
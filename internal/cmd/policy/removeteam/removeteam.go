@@ -0,0 +1,63 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package removeteam
+
+import (
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/cmd/policy/persistent"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	p          *persistent.Options
+	policyName string
+	teamID     string
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.policyName,
+		"policy-name",
+		policy.TargetsRoleName,
+		"name of policy file to remove team from",
+	)
+
+	cmd.Flags().StringVar(
+		&o.teamID,
+		"team-id",
+		"",
+		"identifier of team",
+	)
+	cmd.MarkFlagRequired("team-id") //nolint:errcheck
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	signer, err := common.GetSigner(o.p.SigningKey)
+	if err != nil {
+		return err
+	}
+
+	return repo.RemoveTeam(cmd.Context(), signer, o.policyName, o.teamID, true)
+}
+
+func New(persistent *persistent.Options) *cobra.Command {
+	o := &options{p: persistent}
+	cmd := &cobra.Command{
+		Use:               "remove-team",
+		Short:             "Remove a team from a policy file",
+		Long:              `This command removes the specified team from the specified policy file. A team cannot be removed while a rule in the policy file authorizes it.`,
+		PreRunE:           common.CheckIfSigningViable,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,91 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package removeteammembers
+
+import (
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/cmd/policy/persistent"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	p             *persistent.Options
+	policyName    string
+	teamID        string
+	memberKeys    []string
+	memberPersons []string
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.policyName,
+		"policy-name",
+		policy.TargetsRoleName,
+		"name of policy file containing the team",
+	)
+
+	cmd.Flags().StringVar(
+		&o.teamID,
+		"team-id",
+		"",
+		"identifier of team",
+	)
+	cmd.MarkFlagRequired("team-id") //nolint:errcheck
+
+	cmd.Flags().StringArrayVar(
+		&o.memberKeys,
+		"member-key",
+		[]string{},
+		"public key to remove from team",
+	)
+
+	cmd.Flags().StringArrayVar(
+		&o.memberPersons,
+		"member-person",
+		[]string{},
+		"identifier of person to remove from team",
+	)
+
+	cmd.MarkFlagsOneRequired("member-key", "member-person")
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	signer, err := common.GetSigner(o.p.SigningKey)
+	if err != nil {
+		return err
+	}
+
+	memberKeyIDs := []string{}
+	for _, key := range o.memberKeys {
+		key, err := common.LoadPublicKey(key)
+		if err != nil {
+			return err
+		}
+
+		memberKeyIDs = append(memberKeyIDs, key.KeyID)
+	}
+
+	return repo.RemoveTeamMembers(cmd.Context(), signer, o.policyName, o.teamID, memberKeyIDs, o.memberPersons, true)
+}
+
+func New(persistent *persistent.Options) *cobra.Command {
+	o := &options{p: persistent}
+	cmd := &cobra.Command{
+		Use:               "remove-team-members",
+		Short:             "Remove keys and people from a team",
+		Long:              `This command removes the specified keys and people from a team in the specified policy file. Rules that authorize the team no longer trust the removed members, unless they are authorized by the rule in some other way.`,
+		PreRunE:           common.CheckIfSigningViable,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
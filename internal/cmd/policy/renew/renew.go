@@ -0,0 +1,65 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package renew
+
+import (
+	"time"
+
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/cmd/policy/persistent"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	p             *persistent.Options
+	policyName    string
+	expiresInDays int
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.policyName,
+		"policy-name",
+		policy.TargetsRoleName,
+		"name of policy file to renew, use 'root' to renew the root of trust",
+	)
+
+	cmd.Flags().IntVar(
+		&o.expiresInDays,
+		"expires-in-days",
+		365,
+		"number of days from now after which the metadata expires",
+	)
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	signer, err := common.GetSigner(o.p.SigningKey)
+	if err != nil {
+		return err
+	}
+
+	expires := time.Now().AddDate(0, 0, o.expiresInDays)
+	return repo.RenewPolicy(cmd.Context(), signer, o.policyName, expires, true)
+}
+
+func New(persistent *persistent.Options) *cobra.Command {
+	o := &options{p: persistent}
+	cmd := &cobra.Command{
+		Use:               "renew",
+		Short:             "Renew expiry date of policy metadata",
+		Long:              "This command allows users to extend the expiry date of the specified policy file or the root of trust and sign it. Expired metadata is rejected during verification once the grace period has passed.",
+		PreRunE:           common.CheckIfSigningViable,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
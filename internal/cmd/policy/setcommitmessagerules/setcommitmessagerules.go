@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package setcommitmessagerules
+
+import (
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/cmd/policy/persistent"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	p                          *persistent.Options
+	policyName                 string
+	ruleName                   string
+	requireSignoff             bool
+	requireConventionalCommits bool
+	pattern                    string
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.policyName,
+		"policy-name",
+		policy.TargetsRoleName,
+		"name of policy file containing the rule",
+	)
+
+	cmd.Flags().StringVar(
+		&o.ruleName,
+		"rule-name",
+		"",
+		"name of rule",
+	)
+	cmd.MarkFlagRequired("rule-name") //nolint:errcheck
+
+	cmd.Flags().BoolVar(
+		&o.requireSignoff,
+		"require-signoff",
+		false,
+		"require commit messages to include a Signed-off-by trailer for the commit's author",
+	)
+
+	cmd.Flags().BoolVar(
+		&o.requireConventionalCommits,
+		"require-conventional-commits",
+		false,
+		"require commit messages to follow the Conventional Commits specification",
+	)
+
+	cmd.Flags().StringVar(
+		&o.pattern,
+		"pattern",
+		"",
+		"regular expression that commit messages must match, such as an issue reference",
+	)
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	signer, err := common.GetSigner(o.p.SigningKey)
+	if err != nil {
+		return err
+	}
+
+	return repo.SetCommitMessageRules(cmd.Context(), signer, o.policyName, o.ruleName, o.requireSignoff, o.requireConventionalCommits, o.pattern, true)
+}
+
+func New(persistent *persistent.Options) *cobra.Command {
+	o := &options{p: persistent}
+	cmd := &cobra.Command{
+		Use:               "set-commit-message-rules",
+		Short:             "Set constraints on the messages of commits protected by a rule",
+		Long:              `This command sets constraints on the messages of commits added to the Git references protected by the specified rule. When --require-signoff is set, each commit message must include a Developer Certificate of Origin "Signed-off-by" trailer with the commit author's email. When --require-conventional-commits is set, each commit message must start with a Conventional Commits header such as "fix(cli): handle empty input". When --pattern is set, each commit message must match the regular expression, e.g., "#[0-9]+" to require an issue reference. Merge commits are not checked. Running the command without these flags removes the constraints.`,
+		PreRunE:           common.CheckIfSigningViable,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package setfilerestrictions
+
+import (
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/cmd/policy/persistent"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	p                   *persistent.Options
+	policyName          string
+	ruleName            string
+	maxFileSize         int64
+	forbiddenExtensions []string
+	forbidBinaryFiles   bool
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.policyName,
+		"policy-name",
+		policy.TargetsRoleName,
+		"name of policy file containing the rule",
+	)
+
+	cmd.Flags().StringVar(
+		&o.ruleName,
+		"rule-name",
+		"",
+		"name of rule",
+	)
+	cmd.MarkFlagRequired("rule-name") //nolint:errcheck
+
+	cmd.Flags().Int64Var(
+		&o.maxFileSize,
+		"max-file-size",
+		0,
+		"maximum size in bytes of files at paths protected by the rule (0 for no limit)",
+	)
+
+	cmd.Flags().StringArrayVar(
+		&o.forbiddenExtensions,
+		"forbidden-extension",
+		[]string{},
+		"file extension, such as .exe, that files at paths protected by the rule may not have",
+	)
+
+	cmd.Flags().BoolVar(
+		&o.forbidBinaryFiles,
+		"forbid-binary-files",
+		false,
+		"prevent binary files from being added at paths protected by the rule",
+	)
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	signer, err := common.GetSigner(o.p.SigningKey)
+	if err != nil {
+		return err
+	}
+
+	return repo.SetFileRestrictions(cmd.Context(), signer, o.policyName, o.ruleName, o.maxFileSize, o.forbiddenExtensions, o.forbidBinaryFiles, true)
+}
+
+func New(persistent *persistent.Options) *cobra.Command {
+	o := &options{p: persistent}
+	cmd := &cobra.Command{
+		Use:               "set-file-restrictions",
+		Short:             "Set restrictions on the contents of files protected by a rule",
+		Long:              `This command sets restrictions on the files added or modified at the file paths protected by the specified rule. A commit is rejected if it adds or modifies a protected file that is larger than --max-file-size bytes, has one of the extensions specified using --forbidden-extension, or, when --forbid-binary-files is set, contains binary content. Files are checked for every commit verified using the rule, and binary files are detected using Git's heuristic of looking for a NUL byte. Running the command without these flags removes the restrictions.`,
+		PreRunE:           common.CheckIfSigningViable,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,74 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package setmergestrategy
+
+import (
+	"fmt"
+
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/cmd/policy/persistent"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	p          *persistent.Options
+	policyName string
+	ruleName   string
+	strategy   string
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.policyName,
+		"policy-name",
+		policy.TargetsRoleName,
+		"name of policy file containing the rule",
+	)
+
+	cmd.Flags().StringVar(
+		&o.ruleName,
+		"rule-name",
+		"",
+		"name of rule",
+	)
+	cmd.MarkFlagRequired("rule-name") //nolint:errcheck
+
+	cmd.Flags().StringVar(
+		&o.strategy,
+		"strategy",
+		"",
+		fmt.Sprintf("merge strategy for the rule (one of %s, %s, %s, %s)", policy.MergeStrategyAllCommits, policy.MergeStrategyFirstParent, policy.MergeStrategyMergeCommitter, policy.MergeStrategyReviewAttestation),
+	)
+	cmd.MarkFlagRequired("strategy") //nolint:errcheck
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	signer, err := common.GetSigner(o.p.SigningKey)
+	if err != nil {
+		return err
+	}
+
+	return repo.SetMergeStrategy(cmd.Context(), signer, o.policyName, o.ruleName, o.strategy, true)
+}
+
+func New(persistent *persistent.Options) *cobra.Command {
+	o := &options{p: persistent}
+	cmd := &cobra.Command{
+		Use:               "set-merge-strategy",
+		Short:             "Set how merge commits are verified for a rule",
+		Long:              `This command sets how merge commits are verified for the specified rule. With "all-commits" (the default), every commit brought in by a merge must be signed by an authorized key. With "first-parent", only commits on the first-parent history of the protected reference are verified against their first parent. With "merge-committer", only the merge commit is verified, using the changes it introduces relative to the previous tip. With "review-attestation", the merge is verified like "merge-committer" and must additionally be accompanied by a reference authorization attestation signed by an authorized key.`,
+		PreRunE:           common.CheckIfSigningViable,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
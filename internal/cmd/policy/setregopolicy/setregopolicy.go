@@ -0,0 +1,82 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package setregopolicy
+
+import (
+	"os"
+
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/cmd/policy/persistent"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	p          *persistent.Options
+	policyName string
+	ruleName   string
+	policyFile string
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.policyName,
+		"policy-name",
+		policy.TargetsRoleName,
+		"name of policy file containing the rule",
+	)
+
+	cmd.Flags().StringVar(
+		&o.ruleName,
+		"rule-name",
+		"",
+		"name of rule",
+	)
+	cmd.MarkFlagRequired("rule-name") //nolint:errcheck
+
+	cmd.Flags().StringVar(
+		&o.policyFile,
+		"policy-file",
+		"",
+		"path to Rego module that changes protected by the rule must meet",
+	)
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	module := ""
+	if o.policyFile != "" {
+		contents, err := os.ReadFile(o.policyFile)
+		if err != nil {
+			return err
+		}
+		module = string(contents)
+	}
+
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	signer, err := common.GetSigner(o.p.SigningKey)
+	if err != nil {
+		return err
+	}
+
+	return repo.SetRegoPolicy(cmd.Context(), signer, o.policyName, o.ruleName, module, true)
+}
+
+func New(persistent *persistent.Options) *cobra.Command {
+	o := &options{p: persistent}
+	cmd := &cobra.Command{
+		Use:               "set-rego-policy",
+		Short:             "Set a Rego policy that changes protected by a rule must meet",
+		Long:              `This command sets an Open Policy Agent Rego policy on the specified rule. The module must declare "package gittuf" and define a "deny" set of messages. Each change to a Git reference protected by the rule is evaluated with the following input: the reference ("ref"), the RSL entry ID ("entryID"), the author of the RSL entry ("pusher") and when it was created ("pushedAt"), the commit the reference points to ("commit", with "id", "message", "author", "committer", and "parents"), and the principals that approved the change ("approvers"). The change is rejected if "deny" contains any messages. Running the command without --policy-file removes the rule's Rego policy.`,
+		PreRunE:           common.CheckIfSigningViable,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
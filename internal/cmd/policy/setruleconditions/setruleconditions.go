@@ -0,0 +1,71 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package setruleconditions
+
+import (
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/cmd/policy/persistent"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	p          *persistent.Options
+	policyName string
+	ruleName   string
+	conditions []string
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.policyName,
+		"policy-name",
+		policy.TargetsRoleName,
+		"name of policy file containing the rule",
+	)
+
+	cmd.Flags().StringVar(
+		&o.ruleName,
+		"rule-name",
+		"",
+		"name of rule",
+	)
+	cmd.MarkFlagRequired("rule-name") //nolint:errcheck
+
+	cmd.Flags().StringArrayVar(
+		&o.conditions,
+		"condition",
+		[]string{},
+		"CEL expression that changes protected by the rule must meet",
+	)
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	signer, err := common.GetSigner(o.p.SigningKey)
+	if err != nil {
+		return err
+	}
+
+	return repo.SetRuleConditions(cmd.Context(), signer, o.policyName, o.ruleName, o.conditions, true)
+}
+
+func New(persistent *persistent.Options) *cobra.Command {
+	o := &options{p: persistent}
+	cmd := &cobra.Command{
+		Use:               "set-rule-conditions",
+		Short:             "Set CEL conditions that changes protected by a rule must meet",
+		Long:              `This command sets Common Expression Language (CEL) conditions on the specified rule. Each condition must evaluate to true for a change to a Git reference protected by the rule to be accepted. Conditions can use the following variables: "ref" and "entryID" (strings), "pusher" (a map with "name", "email", and "time"), "pushedAt" (a timestamp), "commit" (a map with "id", "message", "author", "committer", and "parents", empty if the reference does not point to a commit), and "approvers" (a list of strings). For example, "commit.author.email.endsWith('@example.com')" requires commits to be authored using an example.com address, and "pushedAt.getDayOfWeek() >= 1 && pushedAt.getDayOfWeek() <= 5" requires changes to be made Monday through Friday in UTC. Running the command without --condition removes the rule's conditions.`,
+		PreRunE:           common.CheckIfSigningViable,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
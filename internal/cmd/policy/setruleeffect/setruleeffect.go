@@ -0,0 +1,74 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package setruleeffect
+
+import (
+	"fmt"
+
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/cmd/policy/persistent"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	p          *persistent.Options
+	policyName string
+	ruleName   string
+	effect     string
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.policyName,
+		"policy-name",
+		policy.TargetsRoleName,
+		"name of policy file containing the rule",
+	)
+
+	cmd.Flags().StringVar(
+		&o.ruleName,
+		"rule-name",
+		"",
+		"name of rule",
+	)
+	cmd.MarkFlagRequired("rule-name") //nolint:errcheck
+
+	cmd.Flags().StringVar(
+		&o.effect,
+		"effect",
+		"",
+		fmt.Sprintf("effect of the rule (one of %s, %s)", policy.RuleEffectAllow, policy.RuleEffectDeny),
+	)
+	cmd.MarkFlagRequired("effect") //nolint:errcheck
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	signer, err := common.GetSigner(o.p.SigningKey)
+	if err != nil {
+		return err
+	}
+
+	return repo.SetRuleEffect(cmd.Context(), signer, o.policyName, o.ruleName, o.effect, true)
+}
+
+func New(persistent *persistent.Options) *cobra.Command {
+	o := &options{p: persistent}
+	cmd := &cobra.Command{
+		Use:               "set-rule-effect",
+		Short:             "Set whether a rule allows or denies changes",
+		Long:              `This command sets whether the specified rule allows or denies changes to the namespaces it protects. With "allow" (the default), the rule's authorized keys, people, and teams may make changes, and meeting any one of the allow rules that protect a namespace is sufficient. With "deny", nobody may make changes except the rule's authorized keys, people, and teams, who are the exceptions to the rule. Deny rules take precedence over allow rules: when a deny rule protects a namespace, the allow rules for it are ignored, and every deny rule that protects it must be met. For example, an allow rule for "file:*" combined with a deny rule for "file:.github/workflows/*" that authorizes the release team prevents anyone but the release team from modifying workflows.`,
+		PreRunE:           common.CheckIfSigningViable,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
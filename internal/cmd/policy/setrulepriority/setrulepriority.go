@@ -0,0 +1,72 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package setrulepriority
+
+import (
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/cmd/policy/persistent"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	p          *persistent.Options
+	policyName string
+	ruleName   string
+	priority   int
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.policyName,
+		"policy-name",
+		policy.TargetsRoleName,
+		"name of policy file containing the rule",
+	)
+
+	cmd.Flags().StringVar(
+		&o.ruleName,
+		"rule-name",
+		"",
+		"name of rule",
+	)
+	cmd.MarkFlagRequired("rule-name") //nolint:errcheck
+
+	cmd.Flags().IntVar(
+		&o.priority,
+		"priority",
+		0,
+		"priority of the rule",
+	)
+	cmd.MarkFlagRequired("priority") //nolint:errcheck
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	signer, err := common.GetSigner(o.p.SigningKey)
+	if err != nil {
+		return err
+	}
+
+	return repo.SetRulePriority(cmd.Context(), signer, o.policyName, o.ruleName, o.priority, true)
+}
+
+func New(persistent *persistent.Options) *cobra.Command {
+	o := &options{p: persistent}
+	cmd := &cobra.Command{
+		Use:               "set-rule-priority",
+		Short:             "Set the priority of a rule",
+		Long:              `This command sets the priority of the specified rule. The rules in a policy file are evaluated in order of priority, highest first, and rules with the same priority are evaluated in the order they are listed. By default, every rule has a priority of 0. As the first terminating rule that protects a namespace stops the evaluation of subsequent rules, the order of rules can determine who may make changes.`,
+		PreRunE:           common.CheckIfSigningViable,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,79 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package setsubmoduleconstraints
+
+import (
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/cmd/policy/persistent"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	p                   *persistent.Options
+	policyName          string
+	ruleName            string
+	allowedURLs         []string
+	requireVerification bool
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.policyName,
+		"policy-name",
+		policy.TargetsRoleName,
+		"name of policy file containing the rule",
+	)
+
+	cmd.Flags().StringVar(
+		&o.ruleName,
+		"rule-name",
+		"",
+		"name of rule",
+	)
+	cmd.MarkFlagRequired("rule-name") //nolint:errcheck
+
+	cmd.Flags().StringArrayVar(
+		&o.allowedURLs,
+		"allowed-url",
+		[]string{},
+		"URL or glob pattern of URLs that submodules at paths protected by the rule may point to",
+	)
+
+	cmd.Flags().BoolVar(
+		&o.requireVerification,
+		"require-verification",
+		false,
+		"require commits pinned by submodules at paths protected by the rule to verify against the submodules' RSLs",
+	)
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	signer, err := common.GetSigner(o.p.SigningKey)
+	if err != nil {
+		return err
+	}
+
+	return repo.SetSubmoduleConstraints(cmd.Context(), signer, o.policyName, o.ruleName, o.allowedURLs, o.requireVerification, true)
+}
+
+func New(persistent *persistent.Options) *cobra.Command {
+	o := &options{p: persistent}
+	cmd := &cobra.Command{
+		Use:               "set-submodule-constraints",
+		Short:             "Set constraints on updates to submodules protected by a rule",
+		Long:              `This command sets constraints on submodules at the file paths protected by the specified rule. When URLs are specified, a commit that adds or updates a protected submodule is only accepted if the submodule's URL in .gitmodules matches one of them. When --require-verification is set, the commit pinned by the submodule must be recorded in the submodule's RSL and verify against the submodule's gittuf policy. The submodule's repository must be initialized, for example using "git submodule update --init". Running the command without these flags removes the constraints.`,
+		PreRunE:           common.CheckIfSigningViable,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,103 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package show
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+)
+
+const (
+	formatYAML = "yaml"
+	formatJSON = "json"
+
+	dateLayout = "2006-01-02"
+)
+
+type options struct {
+	at     string
+	format string
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.at,
+		"at",
+		"",
+		fmt.Sprintf("RSL entry ID, RFC 3339 timestamp, or date (%s) to show the policy in effect at, defaults to now", dateLayout),
+	)
+
+	cmd.Flags().StringVar(
+		&o.format,
+		"format",
+		formatYAML,
+		fmt.Sprintf("format of shown policy (%s, %s)", formatYAML, formatJSON),
+	)
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	var historicalPolicy *policy.HistoricalPolicy
+	if o.at == "" {
+		historicalPolicy, err = repo.GetPolicyInEffectAtTime(cmd.Context(), time.Now())
+	} else if at, parseErr := parseTime(o.at); parseErr == nil {
+		historicalPolicy, err = repo.GetPolicyInEffectAtTime(cmd.Context(), at)
+	} else {
+		historicalPolicy, err = repo.GetPolicyInEffectForEntry(cmd.Context(), o.at)
+	}
+	if err != nil {
+		return err
+	}
+
+	var contents []byte
+	switch o.format {
+	case formatYAML:
+		contents, err = yaml.Marshal(historicalPolicy)
+	case formatJSON:
+		contents, err = json.MarshalIndent(historicalPolicy, "", "  ")
+		contents = append(contents, '\n')
+	default:
+		return fmt.Errorf("unknown format '%s'", o.format)
+	}
+	if err != nil {
+		return err
+	}
+
+	_, err = os.Stdout.Write(contents)
+	return err
+}
+
+// parseTime parses value as an RFC 3339 timestamp or as a date, which is
+// interpreted as the start of the day in UTC.
+func parseTime(value string) (time.Time, error) {
+	if at, err := time.Parse(time.RFC3339, value); err == nil {
+		return at, nil
+	}
+
+	return time.Parse(dateLayout, value)
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "show",
+		Short:             "Show the policy in effect at a point in time",
+		Long:              `This command reconstructs and shows the verified policy that was in effect at the specified point in the RSL, along with the RSL entry that recorded it. When "--at" is an RSL entry, the policy shown is the one the entry is verified against, i.e., the latest policy recorded before the entry. When "--at" is a timestamp or date, the policy shown is the latest policy recorded at or before that time. Without "--at", the current policy is shown. The policy is shown in the same format as 'gittuf policy export'.`,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
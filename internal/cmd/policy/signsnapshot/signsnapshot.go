@@ -0,0 +1,42 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package signsnapshot
+
+import (
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/cmd/policy/persistent"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	p *persistent.Options
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	signer, err := common.GetSigner(o.p.SigningKey)
+	if err != nil {
+		return err
+	}
+
+	return repo.SignSnapshot(cmd.Context(), signer, true)
+}
+
+func New(persistent *persistent.Options) *cobra.Command {
+	o := &options{p: persistent}
+	cmd := &cobra.Command{
+		Use:               "sign-snapshot",
+		Short:             "Sign snapshot metadata for policy files",
+		Long:              "This command allows snapshot key holders to record the staged policy files, or the current policy files if no changes are staged, in snapshot metadata and sign it. Staged changes are applied once the snapshot metadata is signed by a threshold of snapshot keys.",
+		PreRunE:           common.CheckIfSigningViable,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+
+	return cmd
+}
@@ -0,0 +1,115 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package simulate
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	refName    string
+	target     string
+	paths      []string
+	signerKeys []string
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.refName,
+		"ref",
+		"",
+		"reference to simulate an update for",
+	)
+	cmd.MarkFlagRequired("ref") //nolint:errcheck
+
+	cmd.Flags().StringVar(
+		&o.target,
+		"target",
+		"",
+		"revision the reference is updated to, files changed since the reference's latest RSL entry are checked",
+	)
+
+	cmd.Flags().StringArrayVar(
+		&o.paths,
+		"path",
+		[]string{},
+		"path of a file changed by the update",
+	)
+
+	cmd.Flags().StringArrayVar(
+		&o.signerKeys,
+		"signer-key",
+		[]string{},
+		"public key of a signer of the update",
+	)
+	cmd.MarkFlagRequired("signer-key") //nolint:errcheck
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	keyIDs := []string{}
+	for _, signerKey := range o.signerKeys {
+		key, err := common.LoadPublicKey(signerKey)
+		if err != nil {
+			return err
+		}
+		keyIDs = append(keyIDs, key.KeyID)
+	}
+
+	result, err := repo.SimulateVerify(cmd.Context(), o.refName, o.target, o.paths, keyIDs)
+	if err != nil {
+		return err
+	}
+
+	if common.IsJSONOutput(cmd) {
+		if err := common.PrintJSON(result); err != nil {
+			return err
+		}
+		if !result.Allowed {
+			return fmt.Errorf("simulated update would fail verification, %w", policy.ErrUnauthorizedSignature)
+		}
+		return nil
+	}
+
+	for _, check := range result.Checks {
+		switch {
+		case len(check.Rules) == 0:
+			fmt.Printf("%s: allowed, not protected by any rule\n", check.Target)
+		case check.Allowed:
+			fmt.Printf("%s: allowed by rule '%s'\n", check.Target, check.SatisfiedBy)
+		default:
+			fmt.Printf("%s: denied, requires one of rules '%s'\n", check.Target, strings.Join(check.Rules, "', '"))
+		}
+	}
+
+	if !result.Allowed {
+		return fmt.Errorf("simulated update would fail verification, %w", policy.ErrUnauthorizedSignature)
+	}
+
+	fmt.Println("Simulated update would pass verification")
+	return nil
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "simulate",
+		Short:             "Check if the staged policy permits an update without recording it",
+		Long:              "This command reports whether an update to a reference, signed by the specified keys, would be permitted by the staged policy or the current policy if nothing is staged. The files changed by the update can be listed explicitly or computed from a target revision. No RSL entries are created.",
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,159 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package syncgithubteam
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/cmd/policy/persistent"
+	"github.com/gittuf/gittuf/internal/github"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/repository"
+	sslibdsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
+	"github.com/spf13/cobra"
+)
+
+const (
+	tokenEnvKey = "GITHUB_TOKEN"
+	indent      = "    "
+)
+
+type options struct {
+	p          *persistent.Options
+	policyName string
+	githubTeam string
+	teamID     string
+	baseURL    string
+	dryRun     bool
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.policyName,
+		"policy-name",
+		policy.TargetsRoleName,
+		"name of policy file containing the team",
+	)
+
+	cmd.Flags().StringVar(
+		&o.githubTeam,
+		"github-team",
+		"",
+		"GitHub team in the form <organization>/<team-slug>",
+	)
+	cmd.MarkFlagRequired("github-team") //nolint:errcheck
+
+	cmd.Flags().StringVar(
+		&o.teamID,
+		"team-id",
+		"",
+		"identifier of team in policy, defaults to the GitHub team's slug",
+	)
+
+	cmd.Flags().StringVar(
+		&o.baseURL,
+		"base-url",
+		github.DefaultBaseURL,
+		"location of the GitHub API",
+	)
+
+	cmd.Flags().BoolVar(
+		&o.dryRun,
+		"dry-run",
+		false,
+		"show the changes to the team without updating the policy",
+	)
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	org, teamSlug, found := strings.Cut(o.githubTeam, "/")
+	if !found || org == "" || teamSlug == "" {
+		return fmt.Errorf("invalid GitHub team '%s', expected <organization>/<team-slug>", o.githubTeam)
+	}
+
+	teamID := o.teamID
+	if teamID == "" {
+		teamID = teamSlug
+	}
+
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	var signer sslibdsse.SignerVerifier
+	if !o.dryRun {
+		signer, err = common.GetSigner(o.p.SigningKey)
+		if err != nil {
+			return err
+		}
+	}
+
+	client := github.NewClient(o.baseURL, os.Getenv(tokenEnvKey))
+
+	diff, err := repo.SyncGitHubTeam(cmd.Context(), signer, o.policyName, client, org, teamSlug, teamID, o.dryRun, true)
+	if err != nil {
+		return err
+	}
+
+	if common.IsJSONOutput(cmd) {
+		return common.PrintJSON(diff)
+	}
+
+	printDiff(diff)
+
+	return nil
+}
+
+func New(persistent *persistent.Options) *cobra.Command {
+	o := &options{p: persistent}
+	cmd := &cobra.Command{
+		Use:   "sync-github-team",
+		Short: "Sync a team with the members of a GitHub team",
+		Long: fmt.Sprintf(`This command fetches the members of a GitHub organization's team along with the SSH signing keys and GPG keys they have registered with GitHub, and updates the people in the corresponding team in the specified policy file to match. Each member is recorded as a person identified by their GitHub login, and the team is created if it does not exist. Members without usable keys are skipped, and people who have left the GitHub team are removed from the team but not from the policy file. Rules must authorize the team using "gittuf policy set-authorized-teams" to trust its members.
+
+The changes made to the team are printed. Use --dry-run to preview the changes before the policy is signed and updated. If set, the token in %s is used to authenticate with the GitHub API, which is required to list the members of private teams.`, tokenEnvKey),
+		PreRunE:           common.CheckIfSigningViable,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
+
+func printDiff(diff *policy.TeamDiff) {
+	if diff.IsEmpty() {
+		fmt.Printf("Team '%s' is up to date\n", diff.TeamID)
+	} else {
+		fmt.Printf("%s team '%s'\n", diff.Change, diff.TeamID)
+		printList("Added people", diff.AddedPersonIDs)
+		printList("Removed people", diff.RemovedPersonIDs)
+		for _, person := range diff.People {
+			fmt.Printf("%s%s person '%s'\n", indent, person.Change, person.PersonID)
+			printList("Added keys", person.AddedKeyIDs)
+			printList("Removed keys", person.RemovedKeyIDs)
+		}
+	}
+
+	if len(diff.SkippedPersonIDs) > 0 {
+		fmt.Println("Skipped members without usable keys:")
+		for _, personID := range diff.SkippedPersonIDs {
+			fmt.Printf("%s%s\n", indent, personID)
+		}
+	}
+}
+
+func printList(title string, items []string) {
+	if len(items) == 0 {
+		return
+	}
+
+	fmt.Printf("%s%s:\n", indent, title)
+	for _, item := range items {
+		fmt.Printf("%s%s\n", strings.Repeat(indent, 2), item)
+	}
+}
@@ -0,0 +1,96 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package whocan
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	jsonOutput bool
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(
+		&o.jsonOutput,
+		"json",
+		false,
+		"print authorization as JSON",
+	)
+	cmd.Flags().MarkDeprecated("json", "use --format json instead") //nolint:errcheck
+}
+
+func (o *options) Run(cmd *cobra.Command, args []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	authorization, err := repo.WhoCanAuthorize(cmd.Context(), args[0])
+	if err != nil {
+		return err
+	}
+
+	if o.jsonOutput || common.IsJSONOutput(cmd) {
+		return common.PrintJSON(authorization)
+	}
+
+	switch {
+	case !authorization.Protected:
+		fmt.Printf("No rules protect '%s', any change is permitted\n", authorization.Path)
+	case authorization.RequireAll:
+		fmt.Printf("Changes to '%s' must meet every one of these deny rules:\n", authorization.Path)
+	default:
+		fmt.Printf("Changes to '%s' must meet any one of these rules:\n", authorization.Path)
+	}
+	for _, rule := range authorization.Rules {
+		printRuleAuthorization(rule)
+	}
+
+	if len(authorization.GlobalRules) > 0 {
+		fmt.Println("Changes must also meet every one of these global rules:")
+		for _, rule := range authorization.GlobalRules {
+			printRuleAuthorization(rule)
+		}
+	}
+
+	return nil
+}
+
+func printRuleAuthorization(rule *policy.RuleAuthorization) {
+	fmt.Printf("    Rule %s (threshold %d):\n", rule.Name, rule.Threshold)
+	if len(rule.Principals) == 0 {
+		fmt.Println("        No principals can meet this rule")
+	}
+	for _, principal := range rule.Principals {
+		if len(principal.KeyIDs) == 1 && principal.KeyIDs[0] == principal.ID {
+			fmt.Printf("        %s\n", principal.ID)
+			continue
+		}
+		fmt.Printf("        %s (keys: %s)\n", principal.ID, strings.Join(principal.KeyIDs, ", "))
+	}
+	for _, keyID := range rule.RevokedKeyIDs {
+		fmt.Printf("        %s (revoked)\n", keyID)
+	}
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "who-can <ref | file:path>",
+		Short:             "Show who can authorize changes to a ref or file",
+		Long:              `This command shows the rules, thresholds, and principals that can authorize changes to the specified Git reference or file under the current policy. Files must be prefixed with "file:". A principal is either a person, along with the keys they can use, or a key that does not belong to a person. A rule is met when its threshold of distinct principals approve a change. Revoked keys are listed separately as they can no longer authorize changes.`,
+		Args:              cobra.ExactArgs(1),
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
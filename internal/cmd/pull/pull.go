@@ -0,0 +1,38 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package pull
+
+import (
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct{}
+
+func (o *options) AddFlags(_ *cobra.Command) {}
+
+func (o *options) Run(cmd *cobra.Command, args []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	return repo.PullWithRSL(cmd.Context(), args[0], args[1])
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:   "pull <remote> <ref>",
+		Short: "Pull a Git reference and the RSL from the specified remote and verify them",
+		Long: `The 'pull' command fetches the specified Git reference from the remote along with the RSL and the gittuf policy and attestations references. The new RSL entries for the reference are verified against the policy, and the local reference is fast-forwarded only if verification passes.
+
+If verification fails, the local gittuf references are restored to their prior state and the local reference is left unchanged.`,
+		Args:              cobra.ExactArgs(2),
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,40 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package push
+
+import (
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct{}
+
+func (o *options) AddFlags(_ *cobra.Command) {}
+
+func (o *options) Run(cmd *cobra.Command, args []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	return repo.PushWithRSL(cmd.Context(), args[0], args[1], true)
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:   "push <remote> <ref>",
+		Short: "Push a Git reference and the RSL atomically to the specified remote",
+		Long: `The 'push' command records the latest state of the specified Git reference in the RSL if it has not been recorded yet, and pushes the reference, the RSL, and the gittuf policy and attestations references to the remote in a single atomic push.
+
+If the remote RSL has been updated since it was last fetched, the local RSL entries that are not in the remote RSL are replayed on top of it and the push is retried.`,
+		Args:              cobra.ExactArgs(2),
+		PreRunE:           common.CheckIfSigningViable,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
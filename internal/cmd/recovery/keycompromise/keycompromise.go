@@ -0,0 +1,180 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package keycompromise
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	signingKey string
+	keyIDs     []string
+	reason     string
+	since      string
+	dryRun     bool
+	reportPath string
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(
+		&o.signingKey,
+		"signing-key",
+		"k",
+		"",
+		"signing key to use to sign root of trust (file path, KMS, PKCS#11, piv:, ssh-agent:, exec:, or x509:<private-key>::<certificate-chain> key reference, or fulcio: to sign keylessly using Sigstore)",
+	)
+	cmd.MarkFlagRequired("signing-key") //nolint:errcheck
+
+	cmd.Flags().StringArrayVar(
+		&o.keyIDs,
+		"key-ID",
+		[]string{},
+		"ID of compromised key, can be specified multiple times",
+	)
+	cmd.MarkFlagRequired("key-ID") //nolint:errcheck
+
+	cmd.Flags().StringVar(
+		&o.reason,
+		"reason",
+		"",
+		"reason for revoking the compromised keys, recorded in the root of trust",
+	)
+	cmd.MarkFlagRequired("reason") //nolint:errcheck
+
+	cmd.Flags().StringVar(
+		&o.since,
+		"since",
+		"",
+		"RSL entry from which to look for entries signed using the compromised keys, defaults to the beginning of the RSL",
+	)
+
+	cmd.Flags().BoolVar(
+		&o.dryRun,
+		"dry-run",
+		false,
+		"report the recovery actions without making any changes",
+	)
+
+	cmd.Flags().StringVar(
+		&o.reportPath,
+		"report",
+		"",
+		"path to write the recovery report to in JSON",
+	)
+}
+
+func (o *options) PreRunE(cmd *cobra.Command, args []string) error {
+	if o.dryRun {
+		return nil
+	}
+
+	return common.CheckIfSigningViable(cmd, args)
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	signer, err := common.GetSigner(o.signingKey)
+	if err != nil {
+		return err
+	}
+
+	keyIDs := make([]string, 0, len(o.keyIDs))
+	for _, keyID := range o.keyIDs {
+		keyIDs = append(keyIDs, strings.ToLower(keyID))
+	}
+
+	report, err := repo.RecoverFromKeyCompromise(cmd.Context(), signer, keyIDs, o.reason, o.since, o.dryRun, true)
+	if err != nil {
+		return err
+	}
+
+	if o.reportPath != "" {
+		reportBytes, err := json.MarshalIndent(report, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(o.reportPath, reportBytes, 0o644); err != nil { // nolint:gosec
+			return err
+		}
+	}
+
+	printReport(cmd, report)
+	return nil
+}
+
+func printReport(cmd *cobra.Command, report *repository.RecoveryReport) {
+	out := cmd.OutOrStdout()
+	if report.DryRun {
+		fmt.Fprintln(out, "Dry run, no changes were made.")
+	}
+
+	if len(report.RevokedKeyIDs) == 0 {
+		fmt.Fprintln(out, "Compromised keys were already revoked.")
+	} else {
+		fmt.Fprintln(out, "Revoked keys:")
+		for _, keyID := range report.RevokedKeyIDs {
+			fmt.Fprintf(out, "    %s\n", keyID)
+		}
+	}
+
+	if len(report.SkippedEntries) == 0 {
+		fmt.Fprintln(out, "No RSL entries were signed using the compromised keys.")
+	} else {
+		fmt.Fprintln(out, "Skipped RSL entries:")
+		for _, skippedEntry := range report.SkippedEntries {
+			signedBy := "follows compromised entry"
+			if skippedEntry.SignedBy != "" {
+				signedBy = fmt.Sprintf("signed using '%s'", skippedEntry.SignedBy)
+			}
+			fmt.Fprintf(out, "    %s (%s -> %s, %s)\n", skippedEntry.EntryID, skippedEntry.RefName, skippedEntry.TargetID, signedBy)
+		}
+		if report.AnnotationEntryID != "" {
+			fmt.Fprintf(out, "    Recorded in annotation %s\n", report.AnnotationEntryID)
+		}
+	}
+
+	if len(report.RestoredRefs) > 0 {
+		fmt.Fprintln(out, "Restored references:")
+		for _, restoredRef := range report.RestoredRefs {
+			fmt.Fprintf(out, "    %s: %s restored to state of %s", restoredRef.RefName, restoredRef.CompromisedTarget, restoredRef.GoodTarget)
+			if restoredRef.Target != "" {
+				fmt.Fprintf(out, " as %s (RSL entry %s)", restoredRef.Target, restoredRef.EntryID)
+			}
+			fmt.Fprintln(out)
+		}
+	}
+
+	if len(report.UnrestoredRefs) > 0 {
+		fmt.Fprintln(out, "References without a known good state, these must be remediated manually:")
+		for _, refName := range report.UnrestoredRefs {
+			fmt.Fprintf(out, "    %s\n", refName)
+		}
+	}
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "key-compromise",
+		Short:             "Recover the repository after one or more keys are compromised",
+		Long:              `This command recovers the repository after one or more keys are compromised, as a sequence of signed updates. First, the compromised keys are revoked in the root of trust, which requires a root key. Next, the RSL entries signed using the compromised keys, along with the subsequent entries for the same references, are skipped using an RSL annotation. Finally, each affected reference is restored to its latest state before the compromise and the restored state is recorded in the RSL. Branches are restored using a new commit that is tree-same with the known good commit, so that clones can fast-forward. References without a known good state are reported for manual remediation. Use --dry-run to review the actions first, and push the gittuf references and restored branches once recovery is complete.`,
+		Args:              cobra.NoArgs,
+		PreRunE:           o.PreRunE,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,20 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package recovery
+
+import (
+	"github.com/gittuf/gittuf/internal/cmd/recovery/keycompromise"
+	"github.com/spf13/cobra"
+)
+
+func New() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "recover",
+		Short:             "Tools to recover the repository after a security incident",
+		DisableAutoGenTag: true,
+	}
+
+	cmd.AddCommand(keycompromise.New())
+
+	return cmd
+}
@@ -3,20 +3,44 @@
 package root
 
 import (
+	"context"
 	"log/slog"
 	"os"
+	"time"
 
 	"github.com/gittuf/gittuf/internal/cmd/addhooks"
+	"github.com/gittuf/gittuf/internal/cmd/attest"
 	"github.com/gittuf/gittuf/internal/cmd/clone"
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/cmd/config"
 	"github.com/gittuf/gittuf/internal/cmd/dev"
+	"github.com/gittuf/gittuf/internal/cmd/doctor"
+	"github.com/gittuf/gittuf/internal/cmd/enforce"
+	"github.com/gittuf/gittuf/internal/cmd/hooks"
+	i "github.com/gittuf/gittuf/internal/cmd/init"
+	"github.com/gittuf/gittuf/internal/cmd/key"
+	"github.com/gittuf/gittuf/internal/cmd/keychain"
+	"github.com/gittuf/gittuf/internal/cmd/keygen"
+	"github.com/gittuf/gittuf/internal/cmd/migrate"
 	"github.com/gittuf/gittuf/internal/cmd/policy"
 	"github.com/gittuf/gittuf/internal/cmd/profile"
+	"github.com/gittuf/gittuf/internal/cmd/pull"
+	"github.com/gittuf/gittuf/internal/cmd/push"
+	"github.com/gittuf/gittuf/internal/cmd/recovery"
 	"github.com/gittuf/gittuf/internal/cmd/rsl"
+	"github.com/gittuf/gittuf/internal/cmd/serve"
+	"github.com/gittuf/gittuf/internal/cmd/signingprofile"
+	"github.com/gittuf/gittuf/internal/cmd/status"
 	"github.com/gittuf/gittuf/internal/cmd/trust"
+	"github.com/gittuf/gittuf/internal/cmd/upstream"
 	"github.com/gittuf/gittuf/internal/cmd/verifycommit"
+	"github.com/gittuf/gittuf/internal/cmd/verifyfreshness"
+	"github.com/gittuf/gittuf/internal/cmd/verifymirror"
+	"github.com/gittuf/gittuf/internal/cmd/verifyorganizationroot"
 	"github.com/gittuf/gittuf/internal/cmd/verifyref"
 	"github.com/gittuf/gittuf/internal/cmd/verifytag"
 	"github.com/gittuf/gittuf/internal/cmd/version"
+	"github.com/gittuf/gittuf/internal/cmd/watch"
 	"github.com/spf13/cobra"
 )
 
@@ -25,6 +49,11 @@ type options struct {
 	profile           bool
 	cpuProfileFile    string
 	memoryProfileFile string
+	timeout           time.Duration
+	format            string
+	signingProfile    string
+
+	cancel context.CancelFunc
 }
 
 func (o *options) AddFlags(cmd *cobra.Command) {
@@ -55,9 +84,29 @@ func (o *options) AddFlags(cmd *cobra.Command) {
 		"memory.prof",
 		"file to store memory profile",
 	)
+
+	cmd.PersistentFlags().DurationVar(
+		&o.timeout,
+		"timeout",
+		0,
+		"abort the command if it does not complete within the specified duration (e.g., 30s, 5m), 0 disables the timeout",
+	)
+
+	cmd.PersistentFlags().StringVar(
+		&o.signingProfile,
+		common.SigningProfileFlag,
+		"",
+		"name of the signing profile to use, configured using 'gittuf signing-profile'",
+	)
+
+	common.AddOutputFormatFlag(cmd, &o.format)
 }
 
-func (o *options) PreRunE(_ *cobra.Command, _ []string) error {
+func (o *options) PreRunE(cmd *cobra.Command, _ []string) error {
+	if err := common.CheckOutputFormat(o.format); err != nil {
+		return err
+	}
+
 	// Setup logging
 	level := slog.LevelInfo
 
@@ -69,6 +118,20 @@ func (o *options) PreRunE(_ *cobra.Command, _ []string) error {
 		Level: level,
 	})))
 
+	if err := common.ApplySigningProfile(cmd); err != nil {
+		return err
+	}
+
+	if err := common.ApplyConfigDefaults(cmd); err != nil {
+		return err
+	}
+
+	if o.timeout > 0 {
+		ctx, cancel := context.WithTimeout(cmd.Context(), o.timeout)
+		cmd.SetContext(ctx)
+		o.cancel = cancel
+	}
+
 	// Start profiling if flag is set
 	if o.profile {
 		return profile.StartProfiling(o.cpuProfileFile, o.memoryProfileFile)
@@ -77,6 +140,12 @@ func (o *options) PreRunE(_ *cobra.Command, _ []string) error {
 	return nil
 }
 
+func (o *options) PostRun(_ *cobra.Command, _ []string) {
+	if o.cancel != nil {
+		o.cancel()
+	}
+}
+
 func New() *cobra.Command {
 	o := &options{}
 	cmd := &cobra.Command{
@@ -85,20 +154,46 @@ func New() *cobra.Command {
 		SilenceUsage:      true,
 		DisableAutoGenTag: true,
 		PersistentPreRunE: o.PreRunE,
+		PersistentPostRun: o.PostRun,
 	}
 
 	o.AddFlags(cmd)
 
+	cmd.SetFlagErrorFunc(func(_ *cobra.Command, err error) error {
+		return common.NewExitError(common.ExitCodeUsage, err)
+	})
+
 	cmd.AddCommand(addhooks.New())
+	cmd.AddCommand(attest.New())
 	cmd.AddCommand(clone.New())
+	cmd.AddCommand(config.New())
 	cmd.AddCommand(dev.New())
+	cmd.AddCommand(doctor.New())
+	cmd.AddCommand(enforce.New())
+	cmd.AddCommand(hooks.New())
+	cmd.AddCommand(i.New())
+	cmd.AddCommand(key.New())
+	cmd.AddCommand(keychain.New())
+	cmd.AddCommand(keygen.New())
+	cmd.AddCommand(migrate.New())
 	cmd.AddCommand(trust.New())
 	cmd.AddCommand(policy.New())
+	cmd.AddCommand(pull.New())
+	cmd.AddCommand(push.New())
+	cmd.AddCommand(recovery.New())
 	cmd.AddCommand(rsl.New())
+	cmd.AddCommand(serve.New())
+	cmd.AddCommand(signingprofile.New())
+	cmd.AddCommand(status.New())
+	cmd.AddCommand(upstream.New())
 	cmd.AddCommand(verifycommit.New())
+	cmd.AddCommand(verifyfreshness.New())
+	cmd.AddCommand(verifymirror.New())
+	cmd.AddCommand(verifyorganizationroot.New())
 	cmd.AddCommand(verifyref.New())
 	cmd.AddCommand(verifytag.New())
 	cmd.AddCommand(version.New())
+	cmd.AddCommand(watch.New())
 
 	return cmd
 }
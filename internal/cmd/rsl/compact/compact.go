@@ -0,0 +1,53 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package compact
+
+import (
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	signingKey string
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(
+		&o.signingKey,
+		"signing-key",
+		"k",
+		"",
+		"root key to sign checkpoint with",
+	)
+	cmd.MarkFlagRequired("signing-key") //nolint:errcheck
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	signer, err := common.GetSigner(o.signingKey)
+	if err != nil {
+		return err
+	}
+
+	return repo.CompactRSL(cmd.Context(), signer, true)
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "compact",
+		Short:             "Replace the RSL with a signed checkpoint",
+		Long:              `This command seals all existing RSL entries into a checkpoint that records the latest state of every reference. The checkpoint is signed using a root key and becomes the first entry of the RSL, and subsequent verification trusts it in place of the sealed entries. The history of every reference is verified before it is sealed. The sealed entries are retained locally in an archive ref under "refs/gittuf/reference-state-log-archive/". As the RSL is rewritten, it must be force pushed to remotes, and other clones must fetch the compacted RSL.`,
+		PreRunE:           common.CheckIfSigningViable,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
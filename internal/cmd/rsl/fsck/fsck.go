@@ -0,0 +1,85 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package fsck
+
+import (
+	"fmt"
+
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	jsonOutput     bool
+	rebuildIndexes bool
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(
+		&o.jsonOutput,
+		"json",
+		false,
+		"print report as JSON",
+	)
+	cmd.Flags().MarkDeprecated("json", "use --format json instead") //nolint:errcheck
+
+	cmd.Flags().BoolVar(
+		&o.rebuildIndexes,
+		"rebuild-indexes",
+		false,
+		"discard indexes derived from the RSL, such as the verification cache, so they are rebuilt",
+	)
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	report, err := repo.CheckRSL(o.rebuildIndexes)
+	if err != nil {
+		return err
+	}
+
+	if o.jsonOutput || common.IsJSONOutput(cmd) {
+		if err := common.PrintJSON(report); err != nil {
+			return err
+		}
+	} else {
+		for _, finding := range report.Findings {
+			location := "RSL"
+			if finding.EntryID != "" {
+				location = finding.EntryID
+			}
+			fmt.Printf("%s [%s] %s: %s\n", finding.Severity, finding.Check, location, finding.Message)
+		}
+		if len(report.Findings) == 0 {
+			fmt.Printf("No problems found in RSL (%d entries checked)\n", report.EntriesChecked)
+		}
+		for _, index := range report.RebuiltIndexes {
+			fmt.Printf("Reset '%s', it will be rebuilt during verification\n", index)
+		}
+	}
+
+	if errorCount := report.ErrorCount(); errorCount > 0 {
+		return fmt.Errorf("RSL has %d error(s)", errorCount)
+	}
+	return nil
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "fsck",
+		Short:             "Check the integrity of the RSL",
+		Long:              "This command checks the structural integrity of the RSL. Every entry must be present, have a single parent, and be correctly formatted, annotations must refer to prior reference entries, and checkpoints must be the first entry and match their signed payload. Unsigned entries and entries timestamped before their parents are reported as warnings. The verification cache is also checked against the RSL, and --rebuild-indexes discards it so that it is rebuilt the next time references are verified. Signatures are not verified against policy, use verify-ref for that. The command fails if any errors are found.",
+		Args:              cobra.NoArgs,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
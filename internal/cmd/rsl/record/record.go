@@ -8,9 +8,28 @@ import (
 	"github.com/spf13/cobra"
 )
 
-type options struct{}
+type options struct {
+	deletion bool
+	dualHash bool
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVar(
+		&o.deletion,
+		"delete",
+		false,
+		"record the deletion of the Git reference",
+	)
 
-func (o *options) AddFlags(_ *cobra.Command) {}
+	cmd.Flags().BoolVar(
+		&o.dualHash,
+		"dual-hash",
+		false,
+		"also record the SHA-256 digest of the target's contents so that verification can detect SHA-1 collisions",
+	)
+
+	cmd.MarkFlagsMutuallyExclusive("delete", "dual-hash")
+}
 
 func (o *options) Run(_ *cobra.Command, args []string) error {
 	repo, err := repository.LoadRepository()
@@ -18,7 +37,16 @@ func (o *options) Run(_ *cobra.Command, args []string) error {
 		return err
 	}
 
-	return repo.RecordRSLEntryForReference(args[0], true)
+	if o.deletion {
+		return repo.RecordRSLEntryForDeletion(args[0], true)
+	}
+
+	opts := []repository.RecordRSLEntryOption{}
+	if o.dualHash {
+		opts = append(opts, repository.WithTargetDigest())
+	}
+
+	return repo.RecordRSLEntryForReference(args[0], true, opts...)
 }
 
 func New() *cobra.Command {
@@ -26,6 +54,7 @@ func New() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:               "record",
 		Short:             "Record latest state of a Git reference in the RSL",
+		Long:              "This command records the latest state of the specified Git reference in the RSL. Use --dual-hash to also record a SHA-256 digest of the contents of the reference's target, which is checked during verification to detect SHA-1 collisions. Use --delete to record that the reference has been deleted instead; the reference need not exist locally, and deletions of protected references must be signed using a key authorized to delete them.",
 		Args:              cobra.ExactArgs(1),
 		PreRunE:           common.CheckIfSigningViable,
 		RunE:              o.Run,
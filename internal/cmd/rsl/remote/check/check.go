@@ -5,6 +5,7 @@ package check
 import (
 	"fmt"
 
+	"github.com/gittuf/gittuf/internal/cmd/common"
 	"github.com/gittuf/gittuf/internal/repository"
 	"github.com/spf13/cobra"
 )
@@ -12,6 +13,13 @@ import (
 type options struct {
 }
 
+// checkResult is the JSON representation of the state of the remote's RSL.
+type checkResult struct {
+	Remote      string `json:"remote"`
+	HasUpdates  bool   `json:"has_updates"`
+	HasDiverged bool   `json:"has_diverged"`
+}
+
 func (o *options) Run(cmd *cobra.Command, args []string) error {
 	repo, err := repository.LoadRepository()
 	if err != nil {
@@ -23,6 +31,10 @@ func (o *options) Run(cmd *cobra.Command, args []string) error {
 		return err
 	}
 
+	if common.IsJSONOutput(cmd) {
+		return common.PrintJSON(&checkResult{Remote: args[0], HasUpdates: hasUpdates, HasDiverged: hasDiverged})
+	}
+
 	if hasUpdates {
 		fmt.Printf("RSL at remote %s has updates", args[0])
 		if hasDiverged {
@@ -24,6 +24,7 @@ func New() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:               "pull <remote>",
 		Short:             "Pull RSL from the specified remote",
+		Long:              "This command fetches the RSL from the specified remote. The pull is aborted if the local and remote RSLs have diverged, leaving the local RSL unchanged.",
 		Args:              cobra.ExactArgs(1),
 		RunE:              o.Run,
 		DisableAutoGenTag: true,
@@ -4,8 +4,14 @@ package rsl
 
 import (
 	"github.com/gittuf/gittuf/internal/cmd/rsl/annotate"
+	"github.com/gittuf/gittuf/internal/cmd/rsl/compact"
+	"github.com/gittuf/gittuf/internal/cmd/rsl/fsck"
 	"github.com/gittuf/gittuf/internal/cmd/rsl/record"
 	"github.com/gittuf/gittuf/internal/cmd/rsl/remote"
+	"github.com/gittuf/gittuf/internal/cmd/rsl/remote/pull"
+	"github.com/gittuf/gittuf/internal/cmd/rsl/remote/push"
+	"github.com/gittuf/gittuf/internal/cmd/rsl/skip"
+	"github.com/gittuf/gittuf/internal/cmd/rsl/timestamp"
 	"github.com/spf13/cobra"
 )
 
@@ -17,8 +23,14 @@ func New() *cobra.Command {
 	}
 
 	cmd.AddCommand(annotate.New())
+	cmd.AddCommand(compact.New())
+	cmd.AddCommand(fsck.New())
+	cmd.AddCommand(pull.New())
+	cmd.AddCommand(push.New())
 	cmd.AddCommand(record.New())
 	cmd.AddCommand(remote.New())
+	cmd.AddCommand(skip.New())
+	cmd.AddCommand(timestamp.New())
 
 	return cmd
 }
@@ -0,0 +1,71 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package skip
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	reason  string
+	message string
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	reasons := []string{}
+	for _, reason := range rsl.SkipReasons() {
+		reasons = append(reasons, string(reason))
+	}
+
+	cmd.Flags().StringVarP(
+		&o.reason,
+		"reason",
+		"r",
+		"",
+		fmt.Sprintf("reason for skipping entries (%s)", strings.Join(reasons, ", ")),
+	)
+	cmd.MarkFlagRequired("reason") //nolint:errcheck
+
+	cmd.Flags().StringVarP(
+		&o.message,
+		"message",
+		"m",
+		"",
+		"annotation message",
+	)
+}
+
+func (o *options) Run(_ *cobra.Command, args []string) error {
+	reason, err := rsl.ParseSkipReason(o.reason)
+	if err != nil {
+		return err
+	}
+
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	return repo.SkipRSLEntries(args, reason, o.message, true)
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "skip",
+		Short:             "Mark prior RSL entries as skipped with a reason",
+		Args:              cobra.MinimumNArgs(1),
+		PreRunE:           common.CheckIfSigningViable,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
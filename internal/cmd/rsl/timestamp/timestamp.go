@@ -0,0 +1,79 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package timestamp
+
+import (
+	"time"
+
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	signingKey string
+	validity   time.Duration
+	remoteName string
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(
+		&o.signingKey,
+		"signing-key",
+		"k",
+		"",
+		"timestamp key to sign RSL tip with",
+	)
+	cmd.MarkFlagRequired("signing-key") //nolint:errcheck
+
+	cmd.Flags().DurationVar(
+		&o.validity,
+		"validity",
+		24*time.Hour,
+		"duration for which the timestamp is valid",
+	)
+
+	cmd.Flags().StringVar(
+		&o.remoteName,
+		"push",
+		"",
+		"push timestamp to specified remote after signing",
+	)
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	signer, err := common.GetSigner(o.signingKey)
+	if err != nil {
+		return err
+	}
+
+	if err := repo.UpdateTimestamp(cmd.Context(), signer, o.validity, true); err != nil {
+		return err
+	}
+
+	if o.remoteName != "" {
+		return repo.PushTimestamp(cmd.Context(), o.remoteName)
+	}
+
+	return nil
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "timestamp",
+		Short:             "Sign the tip of the RSL using a timestamp key",
+		Long:              `This command allows holders of timestamp keys to sign the current tip of the RSL. Timestamps must be refreshed periodically, as "gittuf verify-freshness" rejects expired timestamps to detect remotes that withhold newer RSL entries.`,
+		PreRunE:           common.CheckIfSigningViable,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,116 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package serve
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/metrics"
+	"github.com/gittuf/gittuf/internal/server"
+	"github.com/spf13/cobra"
+)
+
+const shutdownTimeout = 10 * time.Second
+
+type options struct {
+	address      string
+	repositories []string
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.address,
+		"listen",
+		"localhost:8080",
+		"address to listen on",
+	)
+
+	cmd.Flags().StringArrayVar(
+		&o.repositories,
+		"repository",
+		[]string{},
+		"repository to serve, specified as <name>=<path>",
+	)
+	cmd.MarkFlagRequired("repository") //nolint:errcheck
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repositoryPaths := map[string]string{}
+	for _, repository := range o.repositories {
+		name, path, found := strings.Cut(repository, "=")
+		if !found || name == "" || path == "" {
+			return fmt.Errorf("invalid repository '%s', must be specified as <name>=<path>", repository)
+		}
+		if _, has := repositoryPaths[name]; has {
+			return fmt.Errorf("repository name '%s' is used more than once", name)
+		}
+		repositoryPaths[name] = path
+	}
+
+	s, err := server.New(repositoryPaths, server.WithMetrics(metrics.New(common.FailureType)))
+	if err != nil {
+		return err
+	}
+
+	listener, err := net.Listen("tcp", o.address)
+	if err != nil {
+		return err
+	}
+
+	httpServer := &http.Server{
+		Handler:           s.Handler(),
+		ReadHeaderTimeout: 10 * time.Second,
+		BaseContext: func(net.Listener) context.Context {
+			return cmd.Context()
+		},
+	}
+
+	go func() {
+		<-cmd.Context().Done()
+		slog.Debug("Shutting down server...")
+		ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		httpServer.Shutdown(ctx) //nolint:errcheck
+	}()
+
+	fmt.Fprintf(cmd.ErrOrStderr(), "Serving %d repositories on %s\n", len(repositoryPaths), listener.Addr().String())
+	if err := httpServer.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+
+	return nil
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:   "serve",
+		Short: "Serve gittuf verification and policy queries over HTTP",
+		Long: `The 'serve' command runs a server that exposes gittuf verification and policy queries for the specified repositories as a JSON API over HTTP, so that platforms can centralize gittuf enforcement instead of invoking the CLI for each request. Each repository is served using the name it is specified with, and the server verifies the repository's local state, so the repositories must be kept up to date separately, such as by mirroring. The following endpoints are available:
+
+  GET  /healthz
+  GET  /v1/repositories
+  POST /v1/repositories/{name}/verify-ref    {"ref": "...", "latest_only": false, "from_entry": "", "from_commit": ""}
+  POST /v1/repositories/{name}/verify-tag    {"tag": "..."}
+  GET  /v1/repositories/{name}/rules
+  GET  /v1/repositories/{name}/who-can-authorize?target=<target>
+  GET  /v1/repositories/{name}/rsl?ref=<ref>&limit=<limit>
+  GET  /metrics
+
+A verification failure is reported in the response's "verified" and "error" fields. The /metrics endpoint exports metrics in the Prometheus text format, including the verifications performed, failures by type, the time since each repository's latest RSL entry was recorded, and the versions of each repository's policy metadata.`,
+		Args:              cobra.NoArgs,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,72 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package add
+
+import (
+	"fmt"
+
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	signingKey       string
+	gitSigningFormat string
+	gitSigningKey    string
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.signingKey,
+		"signing-key",
+		"",
+		"signing key used when --signing-key is not specified",
+	)
+
+	cmd.Flags().StringVar(
+		&o.gitSigningFormat,
+		"git-signing-format",
+		"",
+		"format of the Git signing key (openpgp, x509, or ssh), used instead of gpg.format",
+	)
+
+	cmd.Flags().StringVar(
+		&o.gitSigningKey,
+		"git-signing-key",
+		"",
+		"Git signing key, used instead of user.signingkey",
+	)
+}
+
+func (o *options) Run(_ *cobra.Command, args []string) error {
+	if o.signingKey == "" && o.gitSigningFormat == "" && o.gitSigningKey == "" {
+		return fmt.Errorf("one of --signing-key, --git-signing-format, or --git-signing-key must be specified")
+	}
+
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	return repo.SetSigningProfile(&repository.SigningProfile{
+		Name:             args[0],
+		SigningKey:       o.signingKey,
+		GitSigningFormat: o.gitSigningFormat,
+		GitSigningKey:    o.gitSigningKey,
+	})
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "add <name>",
+		Short:             "Add or replace a signing profile in the repository",
+		Long:              "This command adds the specified signing profile to the repository's Git config, replacing any existing profile with the same name. To add a profile for all repositories, use \"git config --global gittuf-profile.<name>.signingKey <key>\" instead.",
+		Args:              cobra.ExactArgs(1),
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
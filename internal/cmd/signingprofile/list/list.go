@@ -0,0 +1,60 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package list
+
+import (
+	"fmt"
+
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct{}
+
+func (o *options) AddFlags(_ *cobra.Command) {}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	profiles, err := repo.ListSigningProfiles()
+	if err != nil {
+		return err
+	}
+
+	if common.IsJSONOutput(cmd) {
+		return common.PrintJSON(profiles)
+	}
+
+	for _, profile := range profiles {
+		fmt.Printf("Profile '%s':\n", profile.Name)
+		if profile.SigningKey != "" {
+			fmt.Printf("    Signing Key:        %s\n", profile.SigningKey)
+		}
+		if profile.GitSigningFormat != "" {
+			fmt.Printf("    Git Signing Format: %s\n", profile.GitSigningFormat)
+		}
+		if profile.GitSigningKey != "" {
+			fmt.Printf("    Git Signing Key:    %s\n", profile.GitSigningKey)
+		}
+	}
+	return nil
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "list",
+		Short:             "List signing profiles",
+		Long:              "This command lists the signing profiles in the repository's, the global, and the system Git configs.",
+		Args:              cobra.NoArgs,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,31 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package signingprofile
+
+import (
+	"github.com/gittuf/gittuf/internal/cmd/signingprofile/add"
+	"github.com/gittuf/gittuf/internal/cmd/signingprofile/list"
+	"github.com/gittuf/gittuf/internal/cmd/signingprofile/remove"
+	"github.com/spf13/cobra"
+)
+
+func New() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "signing-profile",
+		Short: "Tools to manage named signing profiles",
+		Long: `These commands manage signing profiles, which are named sets of keys a user signs with when acting in a particular role. For example, a maintainer may sign policy changes with a root key held in a hardware token and sign everyday pushes with a developer key held in ssh-agent. A profile is selected using the global --signing-profile flag, e.g., "gittuf --signing-profile root policy sign".
+
+A profile may set:
+  the gittuf signing key, used when --signing-key is not specified
+  the Git signing format and key, used instead of gpg.format and user.signingkey when gittuf signs commits such as RSL entries
+
+Profiles are stored in the "gittuf-profile" section of the repository's Git config. Profiles that are not set in the repository are read from the global and system Git configs.`,
+		DisableAutoGenTag: true,
+	}
+
+	cmd.AddCommand(add.New())
+	cmd.AddCommand(list.New())
+	cmd.AddCommand(remove.New())
+
+	return cmd
+}
@@ -0,0 +1,134 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package status
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	remoteName string
+	noFetch    bool
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.remoteName,
+		"remote",
+		gitinterface.DefaultRemoteName,
+		"remote to compare the local RSL with",
+	)
+
+	cmd.Flags().BoolVar(
+		&o.noFetch,
+		"no-fetch",
+		false,
+		"compare the local RSL with the last fetched state of the remote's RSL instead of fetching it",
+	)
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	status, err := repo.GetStatus(cmd.Context(), o.remoteName, !o.noFetch)
+	if err != nil {
+		return err
+	}
+
+	if common.IsJSONOutput(cmd) {
+		return common.PrintJSON(status)
+	}
+
+	printRSLStatus(status.RSL)
+	printPolicyStatus(status.Policy)
+	printBranchStatus(status.Branch)
+
+	return nil
+}
+
+func printRSLStatus(rslStatus *repository.RSLStatus) {
+	if rslStatus == nil {
+		fmt.Println("RSL: not initialized")
+		return
+	}
+
+	fmt.Printf("RSL: local tip %s\n", rslStatus.LocalTip)
+	switch rslStatus.State {
+	case "":
+		fmt.Println("    remote RSL is unknown")
+	case repository.SyncStateUpToDate:
+		fmt.Printf("    up to date with '%s'\n", rslStatus.Remote)
+	case repository.SyncStateAhead:
+		fmt.Printf("    ahead of '%s' (remote tip %s), push with 'gittuf rsl remote push %s'\n", rslStatus.Remote, rslStatus.RemoteTip, rslStatus.Remote)
+	case repository.SyncStateBehind:
+		fmt.Printf("    behind '%s' (remote tip %s), pull with 'gittuf rsl remote pull %s'\n", rslStatus.Remote, rslStatus.RemoteTip, rslStatus.Remote)
+	case repository.SyncStateDiverged:
+		fmt.Printf("    diverged from '%s' (remote tip %s), reconcile with 'gittuf rsl remote pull %s'\n", rslStatus.Remote, rslStatus.RemoteTip, rslStatus.Remote)
+	}
+}
+
+func printPolicyStatus(policyStatus *repository.PolicyStatus) {
+	if policyStatus == nil {
+		fmt.Println("Policy: not initialized")
+		return
+	}
+
+	fmt.Printf("Policy: tip %s\n", policyStatus.Tip)
+	if len(policyStatus.StagedRoles) == 0 {
+		fmt.Println("    no staged changes")
+		return
+	}
+
+	fmt.Printf("    staged changes not applied: %s\n", strings.Join(policyStatus.StagedRoles, ", "))
+	if len(policyStatus.OutdatedRoles) != 0 {
+		fmt.Printf("    staged changes are outdated, updated in policy since they were staged: %s\n", strings.Join(policyStatus.OutdatedRoles, ", "))
+	}
+	if len(policyStatus.PendingRoles) != 0 {
+		fmt.Printf("    awaiting signatures to meet thresholds: %s\n", strings.Join(policyStatus.PendingRoles, ", "))
+	} else {
+		fmt.Println("    signed by a threshold of keys for all roles, apply with 'gittuf policy apply'")
+	}
+}
+
+func printBranchStatus(branchStatus *repository.BranchStatus) {
+	if branchStatus == nil {
+		fmt.Println("Branch: not on a branch with commits")
+		return
+	}
+
+	branchName := plumbing.ReferenceName(branchStatus.Name).Short()
+	fmt.Printf("Branch: %s at %s\n", branchName, branchStatus.Tip)
+	switch {
+	case branchStatus.Recorded:
+		fmt.Printf("    recorded in RSL entry %s\n", branchStatus.LatestEntry)
+	case branchStatus.LatestEntry != "":
+		fmt.Printf("    tip not recorded in the RSL, latest entry is %s, record with 'gittuf rsl record %s'\n", branchStatus.LatestEntry, branchName)
+	default:
+		fmt.Printf("    not recorded in the RSL, record with 'gittuf rsl record %s'\n", branchName)
+	}
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "status",
+		Short:             "Show the status of the repository's gittuf state",
+		Long:              `The 'status' command summarizes the repository's gittuf state: whether the local RSL is up to date with, ahead of, behind, or diverged from the remote's RSL, the roles with staged policy changes that have not been applied, staged changes that are outdated because the policy has been updated since they were staged, staged roles awaiting signatures to meet their thresholds, and whether the tip of the current branch is recorded in the RSL. The remote's RSL is fetched to its remote tracker unless --no-fetch is set. With --format json, the status is printed as JSON.`,
+		Args:              cobra.NoArgs,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
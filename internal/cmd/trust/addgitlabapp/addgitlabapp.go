@@ -0,0 +1,59 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package addgitlabapp
+
+import (
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/cmd/trust/persistent"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	p      *persistent.Options
+	appKey string
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.appKey,
+		"app-key",
+		"",
+		"key used by the GitLab app to sign merge request approval attestations",
+	)
+	cmd.MarkFlagRequired("app-key") //nolint:errcheck
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	signer, err := common.GetSigner(o.p.SigningKey)
+	if err != nil {
+		return err
+	}
+
+	appKey, err := common.LoadPublicKey(o.appKey)
+	if err != nil {
+		return err
+	}
+
+	return repo.AddGitLabAppKey(cmd.Context(), signer, appKey, true)
+}
+
+func New(persistent *persistent.Options) *cobra.Command {
+	o := &options{p: persistent}
+	cmd := &cobra.Command{
+		Use:               "add-gitlab-app",
+		Short:             "Add GitLab app key to gittuf root of trust",
+		Long:              "The add-gitlab-app command sets the key trusted to sign GitLab merge request approval attestations. Approvals recorded in such attestations count towards the threshold of rules that list the approving users as 'gitlab:<username>' keys.",
+		PreRunE:           common.CheckIfSigningViable,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,73 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package addglobalrule
+
+import (
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/cmd/trust/persistent"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	p            *persistent.Options
+	ruleName     string
+	rulePatterns []string
+	threshold    int
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.ruleName,
+		"rule-name",
+		"",
+		"name of global rule",
+	)
+	cmd.MarkFlagRequired("rule-name") //nolint:errcheck
+
+	cmd.Flags().StringArrayVar(
+		&o.rulePatterns,
+		"rule-pattern",
+		[]string{},
+		"patterns used to identify Git references global rule applies to",
+	)
+	cmd.MarkFlagRequired("rule-pattern") //nolint:errcheck
+
+	cmd.Flags().IntVar(
+		&o.threshold,
+		"threshold",
+		1,
+		"threshold of signatures required by global rule",
+	)
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	signer, err := common.GetSigner(o.p.SigningKey)
+	if err != nil {
+		return err
+	}
+
+	return repo.AddGlobalRule(cmd.Context(), signer, o.ruleName, o.rulePatterns, o.threshold, true)
+}
+
+func New(persistent *persistent.Options) *cobra.Command {
+	o := &options{p: persistent}
+	cmd := &cobra.Command{
+		Use:   "add-global-rule",
+		Short: "Add a global rule to the root of trust",
+		Long: `This command adds a global rule to the root of trust. A global rule requires a threshold of signatures for changes to the Git references that match its patterns, regardless of the rules in policy. The signatures are counted across the keys trusted by all the rules that apply to a reference, or across all the keys in policy if no rule applies to it.
+
+For example, a global rule with the patterns "git:refs/heads/*" and "git:refs/tags/*" and a threshold of 1 requires every update to a branch or tag to be signed, while a global rule with the pattern "git:refs/heads/main" and a threshold of 2 requires two signatures for changes to the main branch.`,
+		PreRunE:           common.CheckIfSigningViable,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,81 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package addrepositoryroot
+
+import (
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/cmd/trust/persistent"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	p              *persistent.Options
+	repositoryName string
+	rootKeys       []string
+	threshold      int
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.repositoryName,
+		"repository-name",
+		"",
+		"name of the repository in the organization",
+	)
+	cmd.MarkFlagRequired("repository-name") //nolint:errcheck
+
+	cmd.Flags().StringArrayVar(
+		&o.rootKeys,
+		"root-key",
+		[]string{},
+		"root key expected to sign the repository's root of trust",
+	)
+	cmd.MarkFlagRequired("root-key") //nolint:errcheck
+
+	cmd.Flags().IntVar(
+		&o.threshold,
+		"threshold",
+		1,
+		"threshold of root keys expected to sign the repository's root of trust",
+	)
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	signer, err := common.GetSigner(o.p.SigningKey)
+	if err != nil {
+		return err
+	}
+
+	rootKeys := make([]*tuf.Key, 0, len(o.rootKeys))
+	for _, key := range o.rootKeys {
+		rootKey, err := common.LoadPublicKey(key)
+		if err != nil {
+			return err
+		}
+		rootKeys = append(rootKeys, rootKey)
+	}
+
+	return repo.AddRepositoryRoot(cmd.Context(), signer, o.repositoryName, rootKeys, o.threshold, true)
+}
+
+func New(persistent *persistent.Options) *cobra.Command {
+	o := &options{p: persistent}
+	cmd := &cobra.Command{
+		Use:               "add-repository-root",
+		Short:             "Add the expected root of trust of an organization's repository",
+		Long:              "This command records the root keys and threshold expected to sign the root of trust of a repository in the organization, when the current repository is the organization's root of trust. Repositories that set this repository as their organization root of trust are verified against this entry.",
+		PreRunE:           common.CheckIfSigningViable,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,59 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package addsnapshotkey
+
+import (
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/cmd/trust/persistent"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	p           *persistent.Options
+	snapshotKey string
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.snapshotKey,
+		"snapshot-key",
+		"",
+		"snapshot key to add to root of trust",
+	)
+	cmd.MarkFlagRequired("snapshot-key") //nolint:errcheck
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	signer, err := common.GetSigner(o.p.SigningKey)
+	if err != nil {
+		return err
+	}
+
+	snapshotKey, err := common.LoadPublicKey(o.snapshotKey)
+	if err != nil {
+		return err
+	}
+
+	return repo.AddSnapshotKey(cmd.Context(), signer, snapshotKey, true)
+}
+
+func New(persistent *persistent.Options) *cobra.Command {
+	o := &options{p: persistent}
+	cmd := &cobra.Command{
+		Use:               "add-snapshot-key",
+		Short:             "Add Snapshot key to gittuf root of trust",
+		Long:              `This command allows users to add a new trusted key for the snapshot role. Once the snapshot role is defined, every policy change is staged until it is recorded in snapshot metadata using "gittuf policy sign-snapshot", which protects against policy files from different policy states being mixed.`,
+		PreRunE:           common.CheckIfSigningViable,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
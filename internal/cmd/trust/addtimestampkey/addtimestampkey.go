@@ -0,0 +1,59 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package addtimestampkey
+
+import (
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/cmd/trust/persistent"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	p            *persistent.Options
+	timestampKey string
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.timestampKey,
+		"timestamp-key",
+		"",
+		"timestamp key to add to root of trust",
+	)
+	cmd.MarkFlagRequired("timestamp-key") //nolint:errcheck
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	signer, err := common.GetSigner(o.p.SigningKey)
+	if err != nil {
+		return err
+	}
+
+	timestampKey, err := common.LoadPublicKey(o.timestampKey)
+	if err != nil {
+		return err
+	}
+
+	return repo.AddTimestampKey(cmd.Context(), signer, timestampKey, true)
+}
+
+func New(persistent *persistent.Options) *cobra.Command {
+	o := &options{p: persistent}
+	cmd := &cobra.Command{
+		Use:               "add-timestamp-key",
+		Short:             "Add Timestamp key to gittuf root of trust",
+		Long:              `This command allows users to add a new trusted key for the timestamp role. Holders of timestamp keys periodically sign the tip of the RSL using "gittuf rsl timestamp", which allows "gittuf verify-freshness" to detect remotes that withhold newer RSL entries.`,
+		PreRunE:           common.CheckIfSigningViable,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
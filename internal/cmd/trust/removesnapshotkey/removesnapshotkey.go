@@ -0,0 +1,56 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package removesnapshotkey
+
+import (
+	"strings"
+
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/cmd/trust/persistent"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	p             *persistent.Options
+	snapshotKeyID string
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.snapshotKeyID,
+		"snapshot-key-ID",
+		"",
+		"ID of Snapshot key to be removed from root of trust",
+	)
+	cmd.MarkFlagRequired("snapshot-key-ID") //nolint:errcheck
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	signer, err := common.GetSigner(o.p.SigningKey)
+	if err != nil {
+		return err
+	}
+
+	return repo.RemoveSnapshotKey(cmd.Context(), signer, strings.ToLower(o.snapshotKeyID), true)
+}
+
+func New(persistent *persistent.Options) *cobra.Command {
+	o := &options{p: persistent}
+	cmd := &cobra.Command{
+		Use:               "remove-snapshot-key",
+		Short:             "Remove Snapshot key from gittuf root of trust",
+		Long:              "This command allows users to remove a trusted key for the snapshot role. Removing the last key disables the snapshot role.",
+		PreRunE:           common.CheckIfSigningViable,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,56 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package removetimestampkey
+
+import (
+	"strings"
+
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/cmd/trust/persistent"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	p              *persistent.Options
+	timestampKeyID string
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.timestampKeyID,
+		"timestamp-key-ID",
+		"",
+		"ID of Timestamp key to be removed from root of trust",
+	)
+	cmd.MarkFlagRequired("timestamp-key-ID") //nolint:errcheck
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	signer, err := common.GetSigner(o.p.SigningKey)
+	if err != nil {
+		return err
+	}
+
+	return repo.RemoveTimestampKey(cmd.Context(), signer, strings.ToLower(o.timestampKeyID), true)
+}
+
+func New(persistent *persistent.Options) *cobra.Command {
+	o := &options{p: persistent}
+	cmd := &cobra.Command{
+		Use:               "remove-timestamp-key",
+		Short:             "Remove Timestamp key from gittuf root of trust",
+		Long:              "This command allows users to remove a trusted key for the timestamp role. Removing the last key disables the timestamp role.",
+		PreRunE:           common.CheckIfSigningViable,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
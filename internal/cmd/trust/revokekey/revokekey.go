@@ -0,0 +1,65 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package revokekey
+
+import (
+	"strings"
+
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/cmd/trust/persistent"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	p      *persistent.Options
+	keyID  string
+	reason string
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.keyID,
+		"key-ID",
+		"",
+		"ID of key to be revoked",
+	)
+	cmd.MarkFlagRequired("key-ID") //nolint:errcheck
+
+	cmd.Flags().StringVar(
+		&o.reason,
+		"reason",
+		"",
+		"reason for revoking the key, recorded in the root of trust",
+	)
+	cmd.MarkFlagRequired("reason") //nolint:errcheck
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	signer, err := common.GetSigner(o.p.SigningKey)
+	if err != nil {
+		return err
+	}
+
+	return repo.RevokeKey(cmd.Context(), signer, strings.ToLower(o.keyID), o.reason, true)
+}
+
+func New(persistent *persistent.Options) *cobra.Command {
+	o := &options{p: persistent}
+	cmd := &cobra.Command{
+		Use:               "revoke-key",
+		Short:             "Revoke a key in gittuf root of trust",
+		Long:              `This command allows users to revoke a key. The key is removed from the root of trust and recorded as revoked along with the reason and time of revocation. A revoked key is no longer trusted by any rule, even if the rule's policy file still lists it, and signatures using it are reported as revoked during verification.`,
+		PreRunE:           common.CheckIfSigningViable,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,88 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package rotatekey
+
+import (
+	"strings"
+
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/cmd/trust/persistent"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/secure-systems-lab/go-securesystemslib/dsse"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	p             *persistent.Options
+	oldKeyID      string
+	newKey        string
+	newSigningKey string
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.oldKeyID,
+		"old-key-ID",
+		"",
+		"ID of key to be replaced",
+	)
+	cmd.MarkFlagRequired("old-key-ID") //nolint:errcheck
+
+	cmd.Flags().StringVar(
+		&o.newKey,
+		"new-key",
+		"",
+		"public key to replace the old key with",
+	)
+	cmd.MarkFlagRequired("new-key") //nolint:errcheck
+
+	cmd.Flags().StringVar(
+		&o.newSigningKey,
+		"new-signing-key",
+		"",
+		"signing key corresponding to the new key, used to additionally sign modified metadata",
+	)
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	signer, err := common.GetSigner(o.p.SigningKey)
+	if err != nil {
+		return err
+	}
+	signers := []dsse.SignerVerifier{signer}
+
+	if len(o.newSigningKey) > 0 {
+		newSigner, err := common.GetSigner(o.newSigningKey)
+		if err != nil {
+			return err
+		}
+		signers = append(signers, newSigner)
+	}
+
+	newKey, err := common.LoadPublicKey(o.newKey)
+	if err != nil {
+		return err
+	}
+
+	return repo.RotateKey(cmd.Context(), signers, strings.ToLower(o.oldKeyID), newKey, true)
+}
+
+func New(persistent *persistent.Options) *cobra.Command {
+	o := &options{p: persistent}
+	cmd := &cobra.Command{
+		Use:               "rotate-key",
+		Short:             "Replace a key with a new key across gittuf root of trust and policy",
+		Long:              `This command allows users to replace a key with a new key everywhere it is trusted, in the root of trust as well as in all rules. All modified metadata is signed and recorded in a single policy change. If the key being replaced is needed to meet a threshold, use --new-signing-key to also sign using the new key.`,
+		PreRunE:           common.CheckIfSigningViable,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,76 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package setalgorithmpolicy
+
+import (
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/cmd/trust/persistent"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	p               *persistent.Options
+	allowedKeyTypes []string
+	minimumRSABits  int
+	rejectSHA1      bool
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringArrayVar(
+		&o.allowedKeyTypes,
+		"allowed-key-type",
+		[]string{},
+		"key type that may be used in policy, such as ed25519, ecdsa, rsa, gpg, sigstore-oidc, x509, or ssh-ca (can be specified multiple times, all key types are allowed if unspecified)",
+	)
+
+	cmd.Flags().IntVar(
+		&o.minimumRSABits,
+		"minimum-rsa-bits",
+		0,
+		"minimum size of RSA keys, including RSA keys used for GPG signatures",
+	)
+
+	cmd.Flags().BoolVar(
+		&o.rejectSHA1,
+		"reject-sha1",
+		false,
+		"reject GPG signatures that use SHA-1",
+	)
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	signer, err := common.GetSigner(o.p.SigningKey)
+	if err != nil {
+		return err
+	}
+
+	algorithmPolicy := &tuf.AlgorithmPolicy{
+		AllowedKeyTypes: o.allowedKeyTypes,
+		MinimumRSABits:  o.minimumRSABits,
+		RejectSHA1:      o.rejectSHA1,
+	}
+
+	return repo.SetAlgorithmPolicy(cmd.Context(), signer, algorithmPolicy, true)
+}
+
+func New(persistent *persistent.Options) *cobra.Command {
+	o := &options{p: persistent}
+	cmd := &cobra.Command{
+		Use:               "set-algorithm-policy",
+		Short:             "Set the minimum acceptable signing algorithms for the repository",
+		Long:              "This command records the key types, minimum RSA key size, and GPG signature hash algorithms that the repository accepts in the root of trust. Keys that do not meet the algorithm policy cannot be added to the policy, and signatures made using them are not trusted during verification. Keys already in the root of trust must meet the new algorithm policy. Running this command without any restrictions unsets the algorithm policy.",
+		PreRunE:           common.CheckIfSigningViable,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,62 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package setorganizationroot
+
+import (
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/cmd/trust/persistent"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	p              *persistent.Options
+	location       string
+	repositoryName string
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.location,
+		"location",
+		"",
+		"URL of the organization's root-of-trust repository, unsets the organization root of trust if empty",
+	)
+	cmd.MarkFlagRequired("location") //nolint:errcheck
+
+	cmd.Flags().StringVar(
+		&o.repositoryName,
+		"repository-name",
+		"",
+		"name of this repository in the organization's root of trust",
+	)
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	signer, err := common.GetSigner(o.p.SigningKey)
+	if err != nil {
+		return err
+	}
+
+	return repo.SetOrganizationRoot(cmd.Context(), signer, o.location, o.repositoryName, true)
+}
+
+func New(persistent *persistent.Options) *cobra.Command {
+	o := &options{p: persistent}
+	cmd := &cobra.Command{
+		Use:               "set-organization-root",
+		Short:             "Set the organization root of trust that delegates to this repository's root of trust",
+		Long:              "This command records the location of the organization's root-of-trust repository and the name this repository is recorded under in it. The 'verify-organization-root' command uses it to verify this repository's root of trust against the organization's.",
+		PreRunE:           common.CheckIfSigningViable,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
@@ -3,13 +3,31 @@
 package trust
 
 import (
+	"github.com/gittuf/gittuf/internal/cmd/trust/addgithubapp"
+	"github.com/gittuf/gittuf/internal/cmd/trust/addgitlabapp"
+	"github.com/gittuf/gittuf/internal/cmd/trust/addglobalrule"
 	"github.com/gittuf/gittuf/internal/cmd/trust/addpolicykey"
+	"github.com/gittuf/gittuf/internal/cmd/trust/addrepositoryroot"
 	"github.com/gittuf/gittuf/internal/cmd/trust/addrootkey"
+	"github.com/gittuf/gittuf/internal/cmd/trust/addsnapshotkey"
+	"github.com/gittuf/gittuf/internal/cmd/trust/addtimestampkey"
 	i "github.com/gittuf/gittuf/internal/cmd/trust/init"
 	"github.com/gittuf/gittuf/internal/cmd/trust/persistent"
+	"github.com/gittuf/gittuf/internal/cmd/trust/removegithubapp"
+	"github.com/gittuf/gittuf/internal/cmd/trust/removegitlabapp"
+	"github.com/gittuf/gittuf/internal/cmd/trust/removeglobalrule"
 	"github.com/gittuf/gittuf/internal/cmd/trust/removepolicykey"
+	"github.com/gittuf/gittuf/internal/cmd/trust/removerepositoryroot"
 	"github.com/gittuf/gittuf/internal/cmd/trust/removerootkey"
+	"github.com/gittuf/gittuf/internal/cmd/trust/removesnapshotkey"
+	"github.com/gittuf/gittuf/internal/cmd/trust/removetimestampkey"
+	"github.com/gittuf/gittuf/internal/cmd/trust/revokekey"
+	"github.com/gittuf/gittuf/internal/cmd/trust/rotatekey"
+	"github.com/gittuf/gittuf/internal/cmd/trust/setalgorithmpolicy"
+	"github.com/gittuf/gittuf/internal/cmd/trust/setorganizationroot"
+	"github.com/gittuf/gittuf/internal/cmd/trust/sign"
 	"github.com/gittuf/gittuf/internal/cmd/trust/updatepolicythreshold"
+	"github.com/gittuf/gittuf/internal/cmd/trust/updaterootthreshold"
 	"github.com/gittuf/gittuf/internal/cmd/trustpolicy/remote"
 	"github.com/spf13/cobra"
 )
@@ -24,11 +42,29 @@ func New() *cobra.Command {
 	o.AddPersistentFlags(cmd)
 
 	cmd.AddCommand(i.New(o))
+	cmd.AddCommand(addgithubapp.New(o))
+	cmd.AddCommand(addgitlabapp.New(o))
+	cmd.AddCommand(addglobalrule.New(o))
 	cmd.AddCommand(addpolicykey.New(o))
+	cmd.AddCommand(addrepositoryroot.New(o))
 	cmd.AddCommand(addrootkey.New(o))
+	cmd.AddCommand(addsnapshotkey.New(o))
+	cmd.AddCommand(addtimestampkey.New(o))
+	cmd.AddCommand(removegithubapp.New(o))
+	cmd.AddCommand(removegitlabapp.New(o))
+	cmd.AddCommand(removeglobalrule.New(o))
 	cmd.AddCommand(removepolicykey.New(o))
+	cmd.AddCommand(removerepositoryroot.New(o))
 	cmd.AddCommand(removerootkey.New(o))
+	cmd.AddCommand(removesnapshotkey.New(o))
+	cmd.AddCommand(removetimestampkey.New(o))
+	cmd.AddCommand(revokekey.New(o))
+	cmd.AddCommand(rotatekey.New(o))
+	cmd.AddCommand(setalgorithmpolicy.New(o))
+	cmd.AddCommand(setorganizationroot.New(o))
+	cmd.AddCommand(sign.New(o))
 	cmd.AddCommand(updatepolicythreshold.New(o))
+	cmd.AddCommand(updaterootthreshold.New(o))
 
 	remoteCmd := remote.New()
 	cmd.AddCommand(remoteCmd)
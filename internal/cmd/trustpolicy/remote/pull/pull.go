@@ -24,6 +24,7 @@ func New() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:               "pull <remote>",
 		Short:             "Pull policy from the specified remote",
+		Long:              "This command fetches the policy along with the RSL from the specified remote. The pull is aborted if the local and remote RSLs have diverged. The fetched policy is verified before it is accepted locally, and the local policy and RSL are left unchanged if verification fails.",
 		Args:              cobra.ExactArgs(1),
 		RunE:              o.Run,
 		DisableAutoGenTag: true,
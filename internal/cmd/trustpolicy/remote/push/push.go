@@ -24,6 +24,7 @@ func New() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:               "push <remote>",
 		Short:             "Push policy to the specified remote",
+		Long:              "This command pushes the policy along with the RSL to the specified remote. The push is aborted if the remote RSL has entries that are not in the local RSL, which must be pulled first.",
 		Args:              cobra.ExactArgs(1),
 		RunE:              o.Run,
 		DisableAutoGenTag: true,
@@ -0,0 +1,33 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sync
+
+import (
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct{}
+
+func (o *options) Run(cmd *cobra.Command, args []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	return repo.PropagateUpstream(cmd.Context(), args[0], args[1])
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "sync <upstream name> <upstream URL>",
+		Short:             "Mirror the gittuf references of an upstream repository",
+		Long:              "This command fetches the RSL, policy, and attestations of the upstream repository into 'refs/gittuf/upstream/<upstream name>/'. The upstream's root of trust is verified, and the mirrored references are not updated if the upstream's RSL was rewritten since the last sync.",
+		Args:              cobra.ExactArgs(2),
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+
+	return cmd
+}
@@ -0,0 +1,23 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package upstream
+
+import (
+	"github.com/gittuf/gittuf/internal/cmd/upstream/sync"
+	"github.com/gittuf/gittuf/internal/cmd/upstream/verifyref"
+	"github.com/spf13/cobra"
+)
+
+func New() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:               "upstream",
+		Short:             "Tools to propagate and verify against the policy of an upstream repository",
+		Long:              "The 'upstream' command mirrors the gittuf references of an upstream repository into a fork or downstream repository, and verifies the downstream repository's changes against the rules controlled by the upstream.",
+		DisableAutoGenTag: true,
+	}
+
+	cmd.AddCommand(sync.New())
+	cmd.AddCommand(verifyref.New())
+
+	return cmd
+}
@@ -0,0 +1,33 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package verifyref
+
+import (
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct{}
+
+func (o *options) Run(cmd *cobra.Command, args []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	return repo.VerifyRefAgainstUpstream(cmd.Context(), args[0], args[1])
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "verify-ref <upstream name> <ref>",
+		Short:             "Verify a reference's changes against the policy of an upstream repository",
+		Long:              "This command verifies the RSL entries for the reference that are not known to the upstream repository against the upstream's current policy. The upstream must be mirrored first using 'gittuf upstream sync'.",
+		Args:              cobra.ExactArgs(2),
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+
+	return cmd
+}
@@ -5,12 +5,20 @@ package verifycommit
 import (
 	"fmt"
 
+	"github.com/gittuf/gittuf/internal/cmd/common"
 	"github.com/gittuf/gittuf/internal/repository"
 	"github.com/spf13/cobra"
 )
 
 type options struct{}
 
+// commitResult is the JSON representation of the verification status of a
+// commit.
+type commitResult struct {
+	ID     string `json:"id"`
+	Status string `json:"status"`
+}
+
 func (o *options) AddFlags(_ *cobra.Command) {}
 
 func (o *options) Run(cmd *cobra.Command, args []string) error {
@@ -21,6 +29,14 @@ func (o *options) Run(cmd *cobra.Command, args []string) error {
 
 	status := repo.VerifyCommit(cmd.Context(), args...)
 
+	if common.IsJSONOutput(cmd) {
+		results := make([]*commitResult, 0, len(args))
+		for _, id := range args {
+			results = append(results, &commitResult{ID: id, Status: status[id]})
+		}
+		return common.PrintJSON(results)
+	}
+
 	for _, id := range args {
 		fmt.Printf("%s: %s\n", id, status[id])
 	}
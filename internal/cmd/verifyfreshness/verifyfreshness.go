@@ -0,0 +1,46 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package verifyfreshness
+
+import (
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct {
+	remoteName string
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.remoteName,
+		"remote",
+		"",
+		"fetch the RSL and timestamp from specified remote before verification",
+	)
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	_, err = repo.VerifyFreshness(cmd.Context(), o.remoteName)
+	return err
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "verify-freshness",
+		Short:             "Verify the RSL is up to date using the timestamp role",
+		Long:              "This command checks that the RSL contains the tip signed by the repository's timestamp role and that the timestamp has not expired, which detects remotes that withhold newer RSL entries.",
+		Args:              cobra.NoArgs,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
@@ -0,0 +1,101 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package verifymirror
+
+import (
+	"fmt"
+
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+const indent = "    "
+
+type options struct {
+	upstreamName string
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(
+		&o.upstreamName,
+		"upstream",
+		"",
+		"remote of the authoritative upstream repository",
+	)
+	cmd.MarkFlagRequired("upstream") //nolint:errcheck
+}
+
+func (o *options) Run(cmd *cobra.Command, args []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	report, err := repo.VerifyMirror(cmd.Context(), o.upstreamName, args[0])
+	if err != nil {
+		return err
+	}
+
+	if common.IsJSONOutput(cmd) {
+		if err := common.PrintJSON(report); err != nil {
+			return err
+		}
+	} else {
+		printReport(args[0], report)
+	}
+
+	if !report.IsConsistent() {
+		return repository.ErrMirrorInconsistent
+	}
+	return nil
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:   "verify-mirror <mirror>",
+		Short: "Verify a mirror is consistent with the upstream's RSL",
+		Long: `This command compares the RSL and references of a mirror, such as a cache or a GitHub mirror of a self-hosted repository, with the RSL of the authoritative upstream repository. Both the mirror and the upstream must be configured as remotes. The mirror's RSL must match the upstream's RSL, and each reference recorded in the upstream's RSL must point to the target recorded in its latest entry.
+
+References the mirror has rewound to an earlier recorded target, removed, or pointed to a target never recorded upstream are reported, as are branches and tags that the upstream neither records nor advertises. The command fails if the mirror is inconsistent with the upstream. Only the remotes' RSLs are fetched, and the local references are not modified. Use "gittuf verify-ref" to verify the upstream's RSL entries against its policy.`,
+		Args:              cobra.ExactArgs(1),
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
+
+func printReport(mirrorName string, report *repository.MirrorReport) {
+	switch report.RSLStatus {
+	case repository.MirrorRSLInSync:
+		fmt.Printf("RSL of '%s' matches upstream\n", mirrorName)
+	case repository.MirrorRSLBehind:
+		fmt.Printf("RSL of '%s' is behind upstream by %d entries\n", mirrorName, report.EntriesBehind)
+	case repository.MirrorRSLAhead:
+		fmt.Printf("RSL of '%s' has entries not in upstream's RSL\n", mirrorName)
+	case repository.MirrorRSLDiverged:
+		fmt.Printf("RSL of '%s' has diverged from upstream\n", mirrorName)
+	case repository.MirrorRSLMissing:
+		fmt.Printf("'%s' does not have an RSL\n", mirrorName)
+	}
+
+	if len(report.Refs) == 0 {
+		fmt.Printf("References of '%s' match upstream's RSL\n", mirrorName)
+		return
+	}
+
+	fmt.Printf("References of '%s' inconsistent with upstream's RSL:\n", mirrorName)
+	for _, finding := range report.Refs {
+		switch finding.Change {
+		case repository.MirrorRefAdded:
+			fmt.Printf("%s%s: added at %s\n", indent, finding.RefName, finding.MirrorTargetID)
+		case repository.MirrorRefRemoved:
+			fmt.Printf("%s%s: removed, expected %s\n", indent, finding.RefName, finding.ExpectedTargetID)
+		default:
+			fmt.Printf("%s%s: %s to %s, expected %s\n", indent, finding.RefName, finding.Change, finding.MirrorTargetID, finding.ExpectedTargetID)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package verifyorganizationroot
+
+import (
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/spf13/cobra"
+)
+
+type options struct{}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	return repo.VerifyOrganizationRoot(cmd.Context())
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:               "verify-organization-root",
+		Short:             "Verify the repository's root of trust against the organization root of trust",
+		Long:              "This command fetches the policy of the organization root of trust set for the repository and checks that the repository's current root of trust is signed by a threshold of the root keys the organization expects for it.",
+		Args:              cobra.NoArgs,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+
+	return cmd
+}
@@ -3,16 +3,38 @@
 package verifyref
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"os"
+	"time"
 
-	"github.com/gittuf/gittuf/internal/dev"
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/gittuf/gittuf/internal/notify"
+	"github.com/gittuf/gittuf/internal/policy"
 	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/gittuf/gittuf/internal/tlog"
+	"github.com/gittuf/gittuf/internal/tsa"
 	"github.com/spf13/cobra"
 )
 
 type options struct {
-	latestOnly bool
-	fromEntry  string
+	latestOnly        bool
+	fromEntry         string
+	fromCommit        string
+	expiryGracePeriod time.Duration
+	verifyTLog        bool
+	tsaCertChain      string
+	noCache           bool
+	jobs              int
+	vsaSigningKey     string
+	vsaOutput         string
+	vsaStore          bool
+	noFetch           bool
+	remoteName        string
+	explain           bool
+	webhook           common.WebhookOptions
 }
 
 func (o *options) AddFlags(cmd *cobra.Command) {
@@ -27,35 +49,291 @@ func (o *options) AddFlags(cmd *cobra.Command) {
 		&o.fromEntry,
 		"from-entry",
 		"",
-		fmt.Sprintf("perform verification from specified RSL entry (developer mode only, set %s=1)", dev.DevModeKey),
+		"perform verification from specified RSL entry, trusting prior entries",
 	)
 
-	cmd.MarkFlagsMutuallyExclusive("latest-only", "from-entry")
+	cmd.Flags().StringVar(
+		&o.fromCommit,
+		"from-commit",
+		"",
+		"perform verification from the first RSL entry that records specified commit, trusting prior entries",
+	)
+
+	cmd.Flags().DurationVar(
+		&o.expiryGracePeriod,
+		"expiry-grace-period",
+		policy.DefaultExpiryGracePeriod,
+		"duration after expiry during which policy metadata is accepted with a warning",
+	)
+
+	cmd.Flags().BoolVar(
+		&o.verifyTLog,
+		"verify-transparency-log",
+		false,
+		"require signatures on current policy metadata and attestations to be recorded in the Rekor transparency log",
+	)
+
+	cmd.Flags().StringVar(
+		&o.tsaCertChain,
+		"tsa-cert-chain",
+		"",
+		"path to PEM certificate chain of timestamp authority trusted to timestamp signatures on policy metadata, attestations, and RSL entries",
+	)
+
+	cmd.Flags().BoolVar(
+		&o.noCache,
+		"no-cache",
+		false,
+		"verify the entire RSL without using entries verified in prior runs",
+	)
+
+	cmd.Flags().IntVar(
+		&o.jobs,
+		"jobs",
+		1,
+		"maximum number of RSL entries or references verified concurrently",
+	)
+
+	cmd.Flags().StringVar(
+		&o.vsaSigningKey,
+		"vsa-signing-key",
+		"",
+		"signing key to use for creating a verification summary attestation (VSA) for each reference",
+	)
+
+	cmd.Flags().StringVar(
+		&o.vsaOutput,
+		"vsa-output",
+		"",
+		"path to write verification summary attestations to, one per line",
+	)
+
+	cmd.Flags().BoolVar(
+		&o.vsaStore,
+		"vsa-store",
+		false,
+		"record verification summary attestations in the repository's attestations",
+	)
+
+	cmd.Flags().BoolVar(
+		&o.noFetch,
+		"no-fetch",
+		false,
+		"verify using local gittuf references and objects without fetching newer or missing ones from the remote",
+	)
+
+	cmd.Flags().StringVar(
+		&o.remoteName,
+		"remote",
+		gitinterface.DefaultRemoteName,
+		"remote to fetch gittuf references from when the local references are behind, and objects missing from shallow or partial clones",
+	)
+
+	cmd.Flags().BoolVar(
+		&o.explain,
+		"explain",
+		false,
+		"print the decision trace for each verified RSL entry to standard error, including the rules that matched, the keys counted towards each rule's threshold, and the signatures that were rejected",
+	)
+
+	o.webhook.AddFlags(cmd)
+
+	cmd.MarkFlagsMutuallyExclusive("latest-only", "from-entry", "from-commit")
 }
 
 func (o *options) Run(cmd *cobra.Command, args []string) error {
+	if (o.vsaSigningKey != "") != (o.vsaOutput != "" || o.vsaStore) {
+		return errors.New("--vsa-signing-key must be used with --vsa-output or --vsa-store")
+	}
+
+	webhook, err := o.webhook.NewWebhook()
+	if err != nil {
+		return err
+	}
+
 	repo, err := repository.LoadRepository()
 	if err != nil {
 		return err
 	}
 
-	if o.fromEntry != "" {
-		if !dev.InDevMode() {
-			return dev.ErrNotInDevMode
+	// notifyFailure notifies the webhook if verifying the target failed
+	notifyFailure := func(target string, err error) error {
+		common.NotifyViolation(cmd, webhook, repo, &notify.Violation{Ref: target}, err)
+		return err
+	}
+
+	if !o.noFetch {
+		if _, err := repo.FetchGittufRefsIfStale(cmd.Context(), o.remoteName); err != nil {
+			return fmt.Errorf("unable to fetch gittuf references from '%s', use --no-fetch to verify using local references: %w", o.remoteName, err)
 		}
+	}
+
+	opts := []repository.VerifyRefOption{repository.WithExpiryGracePeriod(o.expiryGracePeriod), repository.WithJobs(o.jobs)}
+	if !o.noFetch {
+		// Objects missing from shallow or partial clones are fetched on
+		// demand
+		opts = append(opts, repository.WithMissingObjectsFetchedFrom(o.remoteName))
+	}
+
+	if o.explain {
+		opts = append(opts, repository.WithExplanation(common.NewExplainer(cmd.ErrOrStderr())))
+	}
+
+	if o.verifyTLog {
+		trustedLogs, err := tlog.GetTrustedLogs(cmd.Context())
+		if err != nil {
+			return err
+		}
+		opts = append(opts, repository.WithTransparencyLog(trustedLogs))
+	}
+
+	if o.tsaCertChain != "" {
+		chainPEM, err := os.ReadFile(o.tsaCertChain)
+		if err != nil {
+			return err
+		}
+		chain, err := tsa.LoadCertificateChain(chainPEM)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, repository.WithTimestampAuthority(chain))
+	}
+
+	if o.fromEntry != "" || o.fromCommit != "" {
+		if len(args) > 1 {
+			return errors.New("only one reference can be verified from an RSL entry or commit")
+		}
+
+		verify := func(target string) error {
+			if o.fromEntry != "" {
+				return notifyFailure(target, repo.VerifyRefFromEntry(cmd.Context(), target, o.fromEntry, opts...))
+			}
+			return notifyFailure(target, repo.VerifyRefFromCommit(cmd.Context(), target, o.fromCommit, opts...))
+		}
+
+		if o.vsaSigningKey != "" {
+			return o.verifyWithSummaries(cmd, repo, args, verify)
+		}
+		if common.IsJSONOutput(cmd) {
+			return printResults([]*repository.RefVerificationResult{repository.NewRefVerificationResult(args[0], verify(args[0]))})
+		}
+		return verify(args[0])
+	}
+
+	if o.noCache {
+		opts = append(opts, repository.WithoutCache())
+	}
+
+	if o.vsaSigningKey != "" {
+		// Each reference is verified separately so that its result can be
+		// recorded in its summary
+		return o.verifyWithSummaries(cmd, repo, args, func(target string) error {
+			return notifyFailure(target, repo.VerifyRef(cmd.Context(), target, o.latestOnly, opts...))
+		})
+	}
+
+	if common.IsJSONOutput(cmd) || webhook != nil {
+		// Every reference is verified so that each failure is reported
+		results, err := repo.VerifyRefsWithResults(cmd.Context(), args, o.latestOnly, opts...)
+		if err != nil {
+			return err
+		}
+		for _, result := range results {
+			notifyFailure(result.Ref, result.Err()) //nolint:errcheck
+		}
+
+		if common.IsJSONOutput(cmd) {
+			return printResults(results)
+		}
+		return joinResultErrors(results)
+	}
+
+	return repo.VerifyRefs(cmd.Context(), args, o.latestOnly, opts...)
+}
+
+// printResults prints the verification results as JSON, and returns the errors
+// of the references that failed verification.
+func printResults(results []*repository.RefVerificationResult) error {
+	if err := common.PrintJSON(results); err != nil {
+		return err
+	}
+
+	return joinResultErrors(results)
+}
+
+// joinResultErrors returns the errors of the references that failed
+// verification.
+func joinResultErrors(results []*repository.RefVerificationResult) error {
+	verificationErrs := []error{}
+	for _, result := range results {
+		if result.Err() != nil {
+			verificationErrs = append(verificationErrs, fmt.Errorf("unable to verify '%s': %w", result.Ref, result.Err()))
+		}
+	}
+
+	return errors.Join(verificationErrs...)
+}
+
+// verifyWithSummaries verifies each target using the verify function and
+// creates a verification summary attestation for the result. The summaries are
+// written to the output file and/or recorded in the repository as requested.
+func (o *options) verifyWithSummaries(cmd *cobra.Command, repo *repository.Repository, targets []string, verify func(string) error) error {
+	if o.vsaStore {
+		if err := common.CheckIfSigningViable(cmd, targets); err != nil {
+			return err
+		}
+	}
+
+	signer, err := common.GetSigner(o.vsaSigningKey)
+	if err != nil {
+		return err
+	}
+
+	summaries := []byte{}
+	results := []*repository.RefVerificationResult{}
+	for _, target := range targets {
+		verificationErr := verify(target)
+		results = append(results, repository.NewRefVerificationResult(target, verificationErr))
+
+		env, err := repo.CreateVerificationSummary(cmd.Context(), signer, target, verificationErr == nil)
+		if err != nil {
+			return err
+		}
+
+		if o.vsaStore {
+			if err := repo.AddVerificationSummary(env, true); err != nil {
+				return err
+			}
+		}
+
+		envBytes, err := json.Marshal(env)
+		if err != nil {
+			return err
+		}
+		summaries = append(summaries, envBytes...)
+		summaries = append(summaries, '\n')
+	}
+
+	if o.vsaOutput != "" {
+		if err := os.WriteFile(o.vsaOutput, summaries, 0o644); err != nil { // nolint:gosec
+			return err
+		}
+	}
 
-		return repo.VerifyRefFromEntry(cmd.Context(), args[0], o.fromEntry)
+	if common.IsJSONOutput(cmd) {
+		return printResults(results)
 	}
 
-	return repo.VerifyRef(cmd.Context(), args[0], o.latestOnly)
+	return joinResultErrors(results)
 }
 
 func New() *cobra.Command {
 	o := &options{}
 	cmd := &cobra.Command{
-		Use:               "verify-ref",
+		Use:               "verify-ref <ref>...",
 		Short:             "Tools for verifying gittuf policies",
-		Args:              cobra.ExactArgs(1),
+		Long:              "The 'verify-ref' command verifies the RSL entries of one or more references against the applicable gittuf policies. By default, all entries for a reference are verified, reusing the results of prior runs. Use --from-entry or --from-commit to only verify entries recorded from that point, such as the changes introduced by a push or pull request in CI. Use --vsa-signing-key with --vsa-output or --vsa-store to emit a signed SLSA verification summary attestation (VSA) recording the result for each reference, for consumption by deployment gates or policy engines. If the remote's RSL has entries that are not in the local RSL, the RSL, policy, and attestations are fetched from the remote before verification, unless --no-fetch is set. In shallow and partial clones, the objects required to verify the selected entries are fetched from the remote on demand; with --no-fetch, the missing objects are reported instead. With --format json, the result for each reference is printed as JSON. Use --explain to print why each entry passed or failed verification; combine it with --no-cache to explain entries verified in prior runs. With --webhook-url, each reference that fails verification is posted to the webhook along with the offending RSL entries and who recorded them.",
+		Args:              cobra.MinimumNArgs(1),
 		RunE:              o.Run,
 		DisableAutoGenTag: true,
 	}
@@ -4,25 +4,162 @@ package verifytag
 
 import (
 	"fmt"
+	"os"
+	"time"
 
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/notify"
+	"github.com/gittuf/gittuf/internal/policy"
 	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/gittuf/gittuf/internal/tlog"
+	"github.com/gittuf/gittuf/internal/tsa"
 	"github.com/spf13/cobra"
 )
 
-type options struct{}
+type options struct {
+	expiryGracePeriod time.Duration
+	verifyTLog        bool
+	tsaCertChain      string
+	requireProvenance bool
+	builderIDs        []string
+	sourceURI         string
+	explain           bool
+	webhook           common.WebhookOptions
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().DurationVar(
+		&o.expiryGracePeriod,
+		"expiry-grace-period",
+		policy.DefaultExpiryGracePeriod,
+		"duration after expiry during which policy metadata is accepted with a warning",
+	)
+
+	cmd.Flags().BoolVar(
+		&o.verifyTLog,
+		"verify-transparency-log",
+		false,
+		"require signatures on current policy metadata and attestations to be recorded in the Rekor transparency log",
+	)
+
+	cmd.Flags().StringVar(
+		&o.tsaCertChain,
+		"tsa-cert-chain",
+		"",
+		"path to PEM certificate chain of timestamp authority trusted to timestamp signatures on policy metadata, attestations, and RSL entries",
+	)
+
+	cmd.Flags().BoolVar(
+		&o.requireProvenance,
+		"require-provenance",
+		false,
+		"require SLSA provenance from a trusted builder for each tag",
+	)
+
+	cmd.Flags().StringArrayVar(
+		&o.builderIDs,
+		"builder-id",
+		[]string{},
+		"ID of builder trusted to generate provenance",
+	)
+
+	cmd.Flags().StringVar(
+		&o.sourceURI,
+		"source-uri",
+		"",
+		"location of repository the provenance's source must match (default: URL of origin remote)",
+	)
+
+	cmd.Flags().BoolVar(
+		&o.explain,
+		"explain",
+		false,
+		"print the decision trace for each verified RSL entry to standard error, including the rules that matched, the keys counted towards each rule's threshold, and the signatures that were rejected",
+	)
 
-func (o *options) AddFlags(_ *cobra.Command) {}
+	o.webhook.AddFlags(cmd)
+
+	cmd.MarkFlagsRequiredTogether("require-provenance", "builder-id")
+}
 
 func (o *options) Run(cmd *cobra.Command, args []string) error {
+	webhook, err := o.webhook.NewWebhook()
+	if err != nil {
+		return err
+	}
+
 	repo, err := repository.LoadRepository()
 	if err != nil {
 		return err
 	}
 
-	status := repo.VerifyTag(cmd.Context(), args)
+	opts := []repository.VerifyRefOption{repository.WithExpiryGracePeriod(o.expiryGracePeriod)}
 
+	if o.verifyTLog {
+		trustedLogs, err := tlog.GetTrustedLogs(cmd.Context())
+		if err != nil {
+			return err
+		}
+		opts = append(opts, repository.WithTransparencyLog(trustedLogs))
+	}
+
+	if o.tsaCertChain != "" {
+		chainPEM, err := os.ReadFile(o.tsaCertChain)
+		if err != nil {
+			return err
+		}
+		chain, err := tsa.LoadCertificateChain(chainPEM)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, repository.WithTimestampAuthority(chain))
+	}
+
+	if o.requireProvenance {
+		opts = append(opts, repository.WithProvenance(o.builderIDs, o.sourceURI))
+	}
+
+	if o.explain {
+		opts = append(opts, repository.WithExplanation(common.NewExplainer(cmd.ErrOrStderr())))
+	}
+
+	results := make([]*repository.RefVerificationResult, 0, len(args))
 	for _, id := range args {
-		fmt.Printf("%s: %s\n", id, status[id])
+		err := repo.VerifyTagRef(cmd.Context(), id, opts...)
+		common.NotifyViolation(cmd, webhook, repo, &notify.Violation{Ref: id}, err)
+		results = append(results, repository.NewRefVerificationResult(id, err))
+	}
+
+	if common.IsJSONOutput(cmd) {
+		if err := common.PrintJSON(results); err != nil {
+			return err
+		}
+	}
+
+	failedCount := 0
+	var firstErr error
+	for _, result := range results {
+		if !result.Verified {
+			failedCount++
+			if firstErr == nil {
+				firstErr = result.Err()
+			}
+		}
+
+		if common.IsJSONOutput(cmd) {
+			continue
+		}
+		if !result.Verified {
+			fmt.Printf("%s: %s\n", result.Ref, result.Error)
+			continue
+		}
+		fmt.Printf("%s: good signature for RSL entry and tag\n", result.Ref)
+	}
+
+	if failedCount > 0 {
+		// Exit with the code for the first failure so scripts can tell why
+		// verification failed
+		return common.NewExitError(common.ExitCode(firstErr), fmt.Errorf("verification failed for %d tag(s)", failedCount))
 	}
 
 	return nil
@@ -31,8 +168,9 @@ func (o *options) Run(cmd *cobra.Command, args []string) error {
 func New() *cobra.Command {
 	o := &options{}
 	cmd := &cobra.Command{
-		Use:               "verify-tag",
+		Use:               "verify-tag <tag>...",
 		Short:             "Verify tag signatures using gittuf metadata",
+		Long:              `This command verifies each specified tag end-to-end. The tag's RSL entry and the tag object must be signed by keys authorized for the tag, using rules for patterns such as "git:refs/tags/*". If no rule protects the tag, any key in the applicable policy is accepted. The policies used are verified from the start of the RSL, the tag must not have been moved after it was first recorded in the RSL, and the local tag must match the RSL. Tags can be specified by name, reference, or tag object ID. With --require-provenance, each tag must also have SLSA provenance attached using 'gittuf attest provenance' that was generated by one of the specified builders from the tag's commit in this repository. With --webhook-url, each tag that fails verification is posted to the webhook along with the offending RSL entries and who recorded them.`,
 		Args:              cobra.MinimumNArgs(1),
 		RunE:              o.Run,
 		DisableAutoGenTag: true,
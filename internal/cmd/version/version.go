@@ -5,6 +5,7 @@ package version
 import (
 	"fmt"
 
+	"github.com/gittuf/gittuf/internal/cmd/common"
 	"github.com/gittuf/gittuf/internal/dev"
 	"github.com/gittuf/gittuf/internal/version"
 	"github.com/spf13/cobra"
@@ -12,13 +13,24 @@ import (
 
 type options struct{}
 
+// versionResult is the JSON representation of the version of gittuf.
+type versionResult struct {
+	Version string `json:"version"`
+	DevMode bool   `json:"dev_mode"`
+}
+
 func (o *options) AddFlags(_ *cobra.Command) {}
 
-func (o *options) Run(_ *cobra.Command, _ []string) error {
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
 	v := version.GetVersion()
 	if v[0] == 'v' {
 		v = v[1:]
 	}
+
+	if common.IsJSONOutput(cmd) {
+		return common.PrintJSON(&versionResult{Version: v, DevMode: dev.InDevMode()})
+	}
+
 	fmt.Printf("gittuf version %s\n", v)
 
 	if dev.InDevMode() {
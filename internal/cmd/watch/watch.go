@@ -0,0 +1,265 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package watch
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/gittuf/gittuf/internal/cmd/common"
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/gittuf/gittuf/internal/metrics"
+	"github.com/gittuf/gittuf/internal/notify"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/spf13/cobra"
+)
+
+const (
+	onViolationLog  = "log"
+	onViolationExit = "exit"
+
+	shutdownTimeout = 10 * time.Second
+)
+
+type options struct {
+	remotes           []string
+	interval          time.Duration
+	onViolation       string
+	webhook           common.WebhookOptions
+	expiryGracePeriod time.Duration
+	jobs              int
+	metricsAddress    string
+}
+
+func (o *options) AddFlags(cmd *cobra.Command) {
+	cmd.Flags().StringArrayVar(
+		&o.remotes,
+		"remote",
+		[]string{gitinterface.DefaultRemoteName},
+		"remote to watch, can be specified multiple times",
+	)
+
+	cmd.Flags().DurationVar(
+		&o.interval,
+		"interval",
+		5*time.Minute,
+		"duration between checks of the remotes",
+	)
+
+	cmd.Flags().StringVar(
+		&o.onViolation,
+		"on-violation",
+		onViolationLog,
+		fmt.Sprintf("action to take when a violation is detected, one of '%s' or '%s'", onViolationLog, onViolationExit),
+	)
+
+	o.webhook.AddFlags(cmd)
+
+	cmd.Flags().DurationVar(
+		&o.expiryGracePeriod,
+		"expiry-grace-period",
+		policy.DefaultExpiryGracePeriod,
+		"duration after expiry during which policy metadata is accepted with a warning",
+	)
+
+	cmd.Flags().IntVar(
+		&o.jobs,
+		"jobs",
+		1,
+		"maximum number of RSL entries or references verified concurrently",
+	)
+
+	cmd.Flags().StringVar(
+		&o.metricsAddress,
+		"metrics-listen",
+		"",
+		"address to serve metrics in the Prometheus text format on at /metrics, metrics are not served if unset",
+	)
+}
+
+func (o *options) Run(cmd *cobra.Command, _ []string) error {
+	if o.onViolation != onViolationLog && o.onViolation != onViolationExit {
+		return fmt.Errorf("invalid value '%s' for --on-violation, must be one of '%s' or '%s'", o.onViolation, onViolationLog, onViolationExit)
+	}
+	if o.interval <= 0 {
+		return errors.New("--interval must be positive")
+	}
+
+	webhook, err := o.webhook.NewWebhook()
+	if err != nil {
+		return err
+	}
+
+	repo, err := repository.LoadRepository()
+	if err != nil {
+		return err
+	}
+
+	opts := []repository.VerifyRefOption{repository.WithExpiryGracePeriod(o.expiryGracePeriod), repository.WithJobs(o.jobs)}
+
+	var m *metrics.Metrics
+	if o.metricsAddress != "" {
+		m = metrics.New(common.FailureType)
+		if err := serveMetrics(cmd, o.metricsAddress, m); err != nil {
+			return err
+		}
+	}
+
+	// The remote state last checked for each remote, so that unchanged remotes
+	// are not verified again
+	checked := map[string]map[string]plumbing.Hash{}
+
+	ticker := time.NewTicker(o.interval)
+	defer ticker.Stop()
+
+	for {
+		for _, remoteName := range o.remotes {
+			if err := o.check(cmd, repo, remoteName, checked, webhook, m, opts); err != nil {
+				return err
+			}
+		}
+
+		select {
+		case <-cmd.Context().Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// check verifies the state of the remote if it has changed since it was last
+// checked, notifying the webhook of violations and recording the result in m
+// if they are set. An error is returned only if a violation is detected and
+// gittuf must exit.
+func (o *options) check(cmd *cobra.Command, repo *repository.Repository, remoteName string, checked map[string]map[string]plumbing.Hash, webhook *notify.Webhook, m *metrics.Metrics, opts []repository.VerifyRefOption) error {
+	ctx := cmd.Context()
+
+	slog.Debug(fmt.Sprintf("Listing references of '%s'...", remoteName))
+	remoteRefs, err := repo.ListRemoteRefs(ctx, remoteName)
+	if err != nil {
+		if ctx.Err() == nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Unable to check '%s', retrying at next interval: %s\n", remoteName, err.Error())
+		}
+		return nil
+	}
+
+	if lastChecked, has := checked[remoteName]; has && maps.Equal(lastChecked, remoteRefs) {
+		slog.Debug(fmt.Sprintf("No changes in '%s' since last check", remoteName))
+		recordRemoteState(ctx, repo, remoteName, remoteRefs, m)
+		return nil
+	}
+
+	slog.Debug(fmt.Sprintf("Verifying '%s'...", remoteName))
+	err = repo.VerifyRemoteRefs(ctx, remoteName, remoteRefs, opts...)
+	if m != nil && ctx.Err() == nil {
+		m.RecordVerification(remoteName, err)
+	}
+	if err != nil && common.ExitCode(err) == common.ExitCodeInfrastructure {
+		if ctx.Err() == nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Unable to check '%s', retrying at next interval: %s\n", remoteName, err.Error())
+		}
+		return nil
+	}
+
+	// The state is recorded even if it has violations so that each violation
+	// is only reported once
+	checked[remoteName] = remoteRefs
+	recordRemoteState(ctx, repo, remoteName, remoteRefs, m)
+
+	if err == nil {
+		fmt.Fprintf(cmd.ErrOrStderr(), "Verified '%s'\n", remoteName)
+		return nil
+	}
+
+	fmt.Fprintf(cmd.ErrOrStderr(), "Violation detected in '%s': %s\n", remoteName, err.Error())
+
+	common.NotifyViolation(cmd, webhook, repo, &notify.Violation{Remote: remoteName}, err)
+
+	if o.onViolation == onViolationExit {
+		return fmt.Errorf("violation detected in '%s': %w", remoteName, err)
+	}
+
+	return nil
+}
+
+// recordRemoteState records the RSL lag and policy versions of the remote's
+// fetched state in m, if it is set.
+func recordRemoteState(ctx context.Context, repo *repository.Repository, remoteName string, remoteRefs map[string]plumbing.Hash, m *metrics.Metrics) {
+	if m == nil {
+		return
+	}
+
+	remoteView, err := repo.ViewRemote(remoteRefs)
+	if err != nil {
+		slog.Debug(fmt.Sprintf("Unable to inspect state of '%s': %s", remoteName, err.Error()))
+		return
+	}
+
+	if latestEntryTime, err := remoteView.GetLatestRSLEntryTime(); err == nil {
+		m.SetRSLLag(remoteName, time.Since(latestEntryTime))
+	} else {
+		slog.Debug(fmt.Sprintf("Unable to determine RSL lag of '%s': %s", remoteName, err.Error()))
+	}
+
+	if versions, err := remoteView.GetPolicyVersions(ctx); err == nil {
+		m.SetPolicyVersions(remoteName, versions)
+	} else {
+		slog.Debug(fmt.Sprintf("Unable to determine policy versions of '%s': %s", remoteName, err.Error()))
+	}
+}
+
+// serveMetrics serves m at /metrics on address until the command's context is
+// done.
+func serveMetrics(cmd *cobra.Command, address string, m *metrics.Metrics) error {
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return err
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("GET /metrics", m.Handler())
+	metricsServer := &http.Server{Handler: mux, ReadHeaderTimeout: 10 * time.Second}
+
+	go func() {
+		<-cmd.Context().Done()
+		ctx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		metricsServer.Shutdown(ctx) //nolint:errcheck
+	}()
+
+	go func() {
+		if err := metricsServer.Serve(listener); !errors.Is(err, http.ErrServerClosed) {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Unable to serve metrics: %s\n", err.Error())
+		}
+	}()
+
+	fmt.Fprintf(cmd.ErrOrStderr(), "Serving metrics on %s\n", listener.Addr().String())
+	return nil
+}
+
+func New() *cobra.Command {
+	o := &options{}
+	cmd := &cobra.Command{
+		Use:   "watch",
+		Short: "Continuously verify the state of remotes",
+		Long: `The 'watch' command monitors the specified remotes for tampering. At each interval, it checks the references advertised by each remote and, if they have changed since the last check, fetches the remote's RSL and the references it records into dedicated remote tracking references, and verifies them using the remote's RSL and policy. Every reference recorded in the remote's RSL must be valid and match its latest RSL entry. Local references are not modified.
+
+Violations are printed to standard error and, if --webhook-url is set, posted to the webhook along with the offending RSL entries and who recorded them, either as JSON or as a Slack message with --webhook-format=slack. With --on-violation=exit, gittuf exits on the first violation using the exit code for the class of failure. Failures to reach a remote are reported and retried at the next interval.
+
+If --metrics-listen is set, metrics are served in the Prometheus text format at /metrics, including the verifications performed, failures by type, the time since each remote's latest RSL entry was recorded, and the versions of each remote's policy metadata.`,
+		Args:              cobra.NoArgs,
+		RunE:              o.Run,
+		DisableAutoGenTag: true,
+	}
+	o.AddFlags(cmd)
+
+	return cmd
+}
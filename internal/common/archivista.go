@@ -0,0 +1,66 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// NewTestArchivistaServer is a test helper that returns a server implementing
+// the subset of the Archivista API used to upload and download attestations.
+func NewTestArchivistaServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	var mu sync.Mutex
+	objects := map[string][]byte{}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/upload", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		contents, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		hash := sha256.Sum256(append([]byte(fmt.Sprintf("blob %d\x00", len(contents))), contents...))
+		gitoid := hex.EncodeToString(hash[:])
+
+		mu.Lock()
+		objects[gitoid] = contents
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]string{"gitoid": gitoid}) //nolint:errcheck
+	})
+	mux.HandleFunc("/download/", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		contents, has := objects[strings.TrimPrefix(r.URL.Path, "/download/")]
+		mu.Unlock()
+
+		if !has {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Write(contents) //nolint:errcheck
+	})
+
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	return server
+}
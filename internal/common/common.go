@@ -108,6 +108,9 @@ func CreateTestRSLAnnotationEntryCommit(t *testing.T, repo *git.Repository, anno
 
 	if annotation.Skip {
 		lines = append(lines, fmt.Sprintf("%s: true", rsl.SkipKey))
+		if annotation.SkipReason != rsl.SkipReasonUnspecified {
+			lines = append(lines, fmt.Sprintf("%s: %s", rsl.SkipReasonKey, annotation.SkipReason))
+		}
 	} else {
 		lines = append(lines, fmt.Sprintf("%s: false", rsl.SkipKey))
 	}
@@ -0,0 +1,139 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/sigstore/cosign/v2/pkg/cosign"
+	"github.com/sigstore/rekor/pkg/generated/models"
+	dssev001 "github.com/sigstore/rekor/pkg/types/dsse/v0.0.1"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"github.com/sigstore/sigstore/pkg/tuf"
+)
+
+// NewTestRekorServer is a test helper that returns a server implementing the
+// subset of the Rekor API used to record DSSE entries, along with the trusted
+// keys for the log. Each entry is recorded in its own single leaf tree, which
+// is sufficient to verify inclusion proofs and signed entry timestamps.
+func NewTestRekorServer(t *testing.T) (*httptest.Server, *cosign.TrustedTransparencyLogPubKeys) {
+	t.Helper()
+
+	logKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	logKeyPEM, err := cryptoutils.MarshalPublicKeyToPEM(logKey.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+	logID, err := cosign.GetTransparencyLogID(logKey.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	trustedLogs := cosign.NewTrustedTransparencyLogPubKeys()
+	if err := trustedLogs.AddTransparencyLogPubKey(logKeyPEM, tuf.Active); err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		mu       sync.Mutex
+		logIndex int64
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v1/log/entries" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		requestBody, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		proposedEntry := &models.DSSE{}
+		if err := json.Unmarshal(requestBody, proposedEntry); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		entry := &dssev001.V001Entry{}
+		if err := entry.Unmarshal(proposedEntry); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		body, err := entry.Canonicalize(context.Background())
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		mu.Lock()
+		index := logIndex
+		logIndex++
+		mu.Unlock()
+
+		leafHash := sha256.Sum256(append([]byte{0}, body...))
+		encodedBody := base64.StdEncoding.EncodeToString(body)
+		integratedTime := time.Now().Unix()
+
+		// The keys of the signed payload are in canonical order
+		setPayload, err := json.Marshal(map[string]any{
+			"body":           encodedBody,
+			"integratedTime": integratedTime,
+			"logID":          logID,
+			"logIndex":       index,
+		})
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		setDigest := sha256.Sum256(setPayload)
+		set, err := ecdsa.SignASN1(rand.Reader, logKey, setDigest[:])
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		response := map[string]any{
+			hex.EncodeToString(leafHash[:]): map[string]any{
+				"body":           encodedBody,
+				"integratedTime": integratedTime,
+				"logID":          logID,
+				"logIndex":       index,
+				"verification": map[string]any{
+					"inclusionProof": map[string]any{
+						"checkpoint": "test",
+						"hashes":     []string{},
+						"logIndex":   0,
+						"rootHash":   hex.EncodeToString(leafHash[:]),
+						"treeSize":   1,
+					},
+					"signedEntryTimestamp": set,
+				},
+			},
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Location", fmt.Sprintf("/api/v1/log/entries/%x", leafHash))
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(response) //nolint:errcheck
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &trustedLogs
+}
@@ -0,0 +1,126 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package common
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"io"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/digitorus/timestamp"
+	"github.com/jonboulle/clockwork"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+)
+
+var (
+	extKeyUsageOID    = asn1.ObjectIdentifier{2, 5, 29, 37}
+	timeStampingOID   = asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 3, 8}
+	testTSAPolicyOID  = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 2}
+	testCertNotBefore = time.Date(1990, time.January, 1, 0, 0, 0, 0, time.UTC)
+	testCertNotAfter  = time.Date(2100, time.January, 1, 0, 0, 0, 0, time.UTC)
+)
+
+// NewTestTimestampAuthority is a test helper that returns a server acting as
+// an RFC 3161 timestamp authority, along with the PEM encoded certificate
+// chain used to verify its timestamps. The timestamps issued record the time
+// reported by clock.
+func NewTestTimestampAuthority(t *testing.T, clock clockwork.Clock) (*httptest.Server, []byte) {
+	t.Helper()
+
+	rootKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "gittuf test TSA root"},
+		NotBefore:             testCertNotBefore,
+		NotAfter:              testCertNotAfter,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	rootCertBytes, err := x509.CreateCertificate(rand.Reader, rootTemplate, rootTemplate, rootKey.Public(), rootKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootCert, err := x509.ParseCertificate(rootCertBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// RFC 3161 requires the timestamping EKU to be critical
+	extKeyUsage, err := asn1.Marshal([]asn1.ObjectIdentifier{timeStampingOID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	leafKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leafTemplate := &x509.Certificate{
+		SerialNumber:    big.NewInt(2),
+		Subject:         pkix.Name{CommonName: "gittuf test TSA"},
+		NotBefore:       testCertNotBefore,
+		NotAfter:        testCertNotAfter,
+		KeyUsage:        x509.KeyUsageDigitalSignature,
+		ExtraExtensions: []pkix.Extension{{Id: extKeyUsageOID, Critical: true, Value: extKeyUsage}},
+	}
+	leafCertBytes, err := x509.CreateCertificate(rand.Reader, leafTemplate, rootCert, leafKey.Public(), rootKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leafCert, err := x509.ParseCertificate(leafCertBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	chain, err := cryptoutils.MarshalCertificatesToPEM([]*x509.Certificate{leafCert, rootCert})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestBytes, err := io.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		request, err := timestamp.ParseRequest(requestBytes)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		ts := &timestamp.Timestamp{
+			HashAlgorithm:     request.HashAlgorithm,
+			HashedMessage:     request.HashedMessage,
+			Time:              clock.Now(),
+			Nonce:             request.Nonce,
+			Policy:            testTSAPolicyOID,
+			AddTSACertificate: request.Certificates,
+		}
+		responseBytes, err := ts.CreateResponseWithOpts(leafCert, leafKey, crypto.SHA256)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/timestamp-reply")
+		w.Write(responseBytes) //nolint:errcheck
+	}))
+	t.Cleanup(server.Close)
+
+	return server, chain
+}
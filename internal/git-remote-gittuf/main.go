@@ -0,0 +1,45 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// git-remote-gittuf is a Git remote helper invoked by Git for remotes with the
+// "gittuf::" prefix, such as "gittuf::https://example.com/repository".
+package main
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"os"
+
+	"github.com/gittuf/gittuf/internal/remotehelper"
+	"github.com/gittuf/gittuf/internal/repository"
+)
+
+func main() {
+	if len(os.Args) < 3 {
+		fmt.Fprintln(os.Stderr, "usage: git-remote-gittuf <remote> <url>")
+		os.Exit(1)
+	}
+
+	if os.Getenv("GITTUF_VERBOSE") != "" {
+		slog.SetDefault(slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug})))
+	}
+
+	// Git sets GIT_DIR for remote helpers, but invokes them from the user's
+	// working directory
+	gitDir := os.Getenv("GIT_DIR")
+	if gitDir == "" {
+		fmt.Fprintln(os.Stderr, "git-remote-gittuf: GIT_DIR is not set, this program must be invoked by Git")
+		os.Exit(1)
+	}
+
+	repo, err := repository.LoadRepositoryAt(gitDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "git-remote-gittuf: %s\n", err.Error())
+		os.Exit(1)
+	}
+
+	if err := remotehelper.Run(context.Background(), repo, os.Args[2], os.Stdin, os.Stdout, os.Stderr); err != nil {
+		fmt.Fprintf(os.Stderr, "git-remote-gittuf: %s\n", err.Error())
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,196 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// BranchProtection contains the branch protection settings of a GitHub
+// repository's branch that are relevant to gittuf.
+type BranchProtection struct {
+	Branch string `json:"branch"`
+
+	// RequiredApprovingReviewCount is the number of approving reviews a pull
+	// request requires before it can be merged. If zero, pull requests are
+	// not required.
+	RequiredApprovingReviewCount int  `json:"required_approving_review_count"`
+	RequireCodeOwnerReviews      bool `json:"require_code_owner_reviews"`
+	DismissStaleReviews          bool `json:"dismiss_stale_reviews"`
+
+	EnforceAdmins        bool `json:"enforce_admins"`
+	RequireSignedCommits bool `json:"require_signed_commits"`
+	RequireLinearHistory bool `json:"require_linear_history"`
+	AllowForcePushes     bool `json:"allow_force_pushes"`
+	AllowDeletions       bool `json:"allow_deletions"`
+
+	// requiredStatusChecks and restrictions are not used by gittuf, and are
+	// retained so that updating the branch protection does not remove them.
+	requiredStatusChecks *statusChecks
+	restrictions         *pushRestrictions
+}
+
+type statusChecks struct {
+	Strict   bool     `json:"strict"`
+	Contexts []string `json:"contexts"`
+}
+
+type pushRestrictions struct {
+	Users []string `json:"users"`
+	Teams []string `json:"teams"`
+	Apps  []string `json:"apps"`
+}
+
+type enabledSetting struct {
+	Enabled bool `json:"enabled"`
+}
+
+// GetProtectedBranches returns the names of the protected branches of the
+// repository.
+func (c *Client) GetProtectedBranches(ctx context.Context, owner, repository string) ([]string, error) {
+	type branch struct {
+		Name string `json:"name"`
+	}
+
+	names := []string{}
+	for page := 1; ; page++ {
+		branches := []branch{}
+		path := fmt.Sprintf("/repos/%s/%s/branches?protected=true&per_page=%d&page=%d", owner, repository, resultsPerPage, page)
+		if err := c.get(ctx, path, &branches); err != nil {
+			return nil, err
+		}
+
+		for _, b := range branches {
+			names = append(names, b.Name)
+		}
+
+		if len(branches) < resultsPerPage {
+			break
+		}
+	}
+
+	return names, nil
+}
+
+// GetBranchProtection returns the branch protection settings of the branch. If
+// the branch is not protected, nil is returned.
+func (c *Client) GetBranchProtection(ctx context.Context, owner, repository, branch string) (*BranchProtection, error) {
+	type login struct {
+		Login string `json:"login"`
+	}
+	type slug struct {
+		Slug string `json:"slug"`
+	}
+
+	response := struct {
+		RequiredPullRequestReviews *struct {
+			RequiredApprovingReviewCount int  `json:"required_approving_review_count"`
+			RequireCodeOwnerReviews      bool `json:"require_code_owner_reviews"`
+			DismissStaleReviews          bool `json:"dismiss_stale_reviews"`
+		} `json:"required_pull_request_reviews"`
+		RequiredStatusChecks *statusChecks `json:"required_status_checks"`
+		Restrictions         *struct {
+			Users []login `json:"users"`
+			Teams []slug  `json:"teams"`
+			Apps  []slug  `json:"apps"`
+		} `json:"restrictions"`
+		EnforceAdmins         enabledSetting `json:"enforce_admins"`
+		RequiredSignatures    enabledSetting `json:"required_signatures"`
+		RequiredLinearHistory enabledSetting `json:"required_linear_history"`
+		AllowForcePushes      enabledSetting `json:"allow_force_pushes"`
+		AllowDeletions        enabledSetting `json:"allow_deletions"`
+	}{}
+
+	if err := c.get(ctx, branchProtectionPath(owner, repository, branch), &response); err != nil {
+		if errors.Is(err, ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	protection := &BranchProtection{
+		Branch:               branch,
+		EnforceAdmins:        response.EnforceAdmins.Enabled,
+		RequireSignedCommits: response.RequiredSignatures.Enabled,
+		RequireLinearHistory: response.RequiredLinearHistory.Enabled,
+		AllowForcePushes:     response.AllowForcePushes.Enabled,
+		AllowDeletions:       response.AllowDeletions.Enabled,
+		requiredStatusChecks: response.RequiredStatusChecks,
+	}
+	if reviews := response.RequiredPullRequestReviews; reviews != nil {
+		protection.RequiredApprovingReviewCount = reviews.RequiredApprovingReviewCount
+		protection.RequireCodeOwnerReviews = reviews.RequireCodeOwnerReviews
+		protection.DismissStaleReviews = reviews.DismissStaleReviews
+	}
+	if restrictions := response.Restrictions; restrictions != nil {
+		protection.restrictions = &pushRestrictions{Users: []string{}, Teams: []string{}, Apps: []string{}}
+		for _, user := range restrictions.Users {
+			protection.restrictions.Users = append(protection.restrictions.Users, user.Login)
+		}
+		for _, team := range restrictions.Teams {
+			protection.restrictions.Teams = append(protection.restrictions.Teams, team.Slug)
+		}
+		for _, app := range restrictions.Apps {
+			protection.restrictions.Apps = append(protection.restrictions.Apps, app.Slug)
+		}
+	}
+
+	return protection, nil
+}
+
+// UpdateBranchProtection replaces the branch protection settings of the branch
+// with protection. Required status checks and push restrictions are retained
+// if protection was returned by GetBranchProtection.
+func (c *Client) UpdateBranchProtection(ctx context.Context, owner, repository string, protection *BranchProtection) error {
+	type pullRequestReviews struct {
+		RequiredApprovingReviewCount int  `json:"required_approving_review_count"`
+		RequireCodeOwnerReviews      bool `json:"require_code_owner_reviews"`
+		DismissStaleReviews          bool `json:"dismiss_stale_reviews"`
+	}
+
+	request := struct {
+		RequiredStatusChecks       *statusChecks       `json:"required_status_checks"`
+		EnforceAdmins              bool                `json:"enforce_admins"`
+		RequiredPullRequestReviews *pullRequestReviews `json:"required_pull_request_reviews"`
+		Restrictions               *pushRestrictions   `json:"restrictions"`
+		RequiredLinearHistory      bool                `json:"required_linear_history"`
+		AllowForcePushes           bool                `json:"allow_force_pushes"`
+		AllowDeletions             bool                `json:"allow_deletions"`
+	}{
+		RequiredStatusChecks:  protection.requiredStatusChecks,
+		EnforceAdmins:         protection.EnforceAdmins,
+		Restrictions:          protection.restrictions,
+		RequiredLinearHistory: protection.RequireLinearHistory,
+		AllowForcePushes:      protection.AllowForcePushes,
+		AllowDeletions:        protection.AllowDeletions,
+	}
+	if protection.RequiredApprovingReviewCount > 0 || protection.RequireCodeOwnerReviews {
+		request.RequiredPullRequestReviews = &pullRequestReviews{
+			RequiredApprovingReviewCount: protection.RequiredApprovingReviewCount,
+			RequireCodeOwnerReviews:      protection.RequireCodeOwnerReviews,
+			DismissStaleReviews:          protection.DismissStaleReviews,
+		}
+	}
+
+	path := branchProtectionPath(owner, repository, protection.Branch)
+	if err := c.do(ctx, http.MethodPut, path, &request, nil); err != nil {
+		return err
+	}
+
+	// Required signatures are managed using a separate endpoint
+	if protection.RequireSignedCommits {
+		return c.do(ctx, http.MethodPost, path+"/required_signatures", nil, nil)
+	}
+	if err := c.do(ctx, http.MethodDelete, path+"/required_signatures", nil, nil); err != nil && !errors.Is(err, ErrNotFound) {
+		return err
+	}
+	return nil
+}
+
+func branchProtectionPath(owner, repository, branch string) string {
+	return fmt.Sprintf("/repos/%s/%s/branches/%s/protection", owner, repository, url.PathEscape(branch))
+}
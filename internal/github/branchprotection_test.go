@@ -0,0 +1,113 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClientBranchProtection(t *testing.T) {
+	updateRequests := []map[string]any{}
+	signatureRequests := []string{}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/gittuf/gittuf/branches", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "true", r.URL.Query().Get("protected"))
+		fmt.Fprint(w, `[{"name": "main"}, {"name": "release/v1"}]`)
+	})
+	mux.HandleFunc("/repos/gittuf/gittuf/branches/main/protection", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			fmt.Fprint(w, `{
+				"required_pull_request_reviews": {"required_approving_review_count": 2, "dismiss_stale_reviews": true, "require_code_owner_reviews": false},
+				"required_status_checks": {"strict": true, "contexts": ["ci"]},
+				"restrictions": {"users": [{"login": "alice"}], "teams": [{"slug": "maintainers"}], "apps": []},
+				"enforce_admins": {"enabled": true},
+				"required_signatures": {"enabled": true},
+				"required_linear_history": {"enabled": false},
+				"allow_force_pushes": {"enabled": true},
+				"allow_deletions": {"enabled": false}
+			}`)
+		case http.MethodPut:
+			request := map[string]any{}
+			if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+				t.Fatal(err)
+			}
+			updateRequests = append(updateRequests, request)
+			fmt.Fprint(w, `{}`)
+		}
+	})
+	mux.HandleFunc("/repos/gittuf/gittuf/branches/main/protection/required_signatures", func(w http.ResponseWriter, r *http.Request) {
+		signatureRequests = append(signatureRequests, r.Method)
+		if r.Method == http.MethodDelete {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		fmt.Fprint(w, `{"enabled": true}`)
+	})
+	mux.HandleFunc("/repos/gittuf/gittuf/branches/develop/protection", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"message": "Branch not protected"}`)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := NewClient(server.URL, "token")
+
+	branches, err := client.GetProtectedBranches(context.Background(), "gittuf", "gittuf")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"main", "release/v1"}, branches)
+
+	protection, err := client.GetBranchProtection(context.Background(), "gittuf", "gittuf", "main")
+	assert.Nil(t, err)
+	assert.Equal(t, "main", protection.Branch)
+	assert.Equal(t, 2, protection.RequiredApprovingReviewCount)
+	assert.True(t, protection.DismissStaleReviews)
+	assert.True(t, protection.EnforceAdmins)
+	assert.True(t, protection.RequireSignedCommits)
+	assert.True(t, protection.AllowForcePushes)
+	assert.False(t, protection.AllowDeletions)
+
+	protection, err = client.GetBranchProtection(context.Background(), "gittuf", "gittuf", "develop")
+	assert.Nil(t, err)
+	assert.Nil(t, protection)
+
+	t.Run("update retains status checks and restrictions", func(t *testing.T) {
+		protection, err := client.GetBranchProtection(context.Background(), "gittuf", "gittuf", "main")
+		if err != nil {
+			t.Fatal(err)
+		}
+		protection.AllowForcePushes = false
+		protection.RequiredApprovingReviewCount = 1
+
+		err = client.UpdateBranchProtection(context.Background(), "gittuf", "gittuf", protection)
+		assert.Nil(t, err)
+		assert.Len(t, updateRequests, 1)
+		assert.Equal(t, false, updateRequests[0]["allow_force_pushes"])
+		assert.Equal(t, map[string]any{"strict": true, "contexts": []any{"ci"}}, updateRequests[0]["required_status_checks"])
+		assert.Equal(t, map[string]any{"users": []any{"alice"}, "teams": []any{"maintainers"}, "apps": []any{}}, updateRequests[0]["restrictions"])
+		assert.Equal(t, float64(1), updateRequests[0]["required_pull_request_reviews"].(map[string]any)["required_approving_review_count"])
+		assert.Equal(t, []string{http.MethodPost}, signatureRequests)
+	})
+
+	t.Run("update without reviews or signatures", func(t *testing.T) {
+		err := client.UpdateBranchProtection(context.Background(), "gittuf", "gittuf", &BranchProtection{Branch: "main"})
+		assert.Nil(t, err)
+		assert.Len(t, updateRequests, 2)
+		assert.Nil(t, updateRequests[1]["required_pull_request_reviews"])
+		assert.Nil(t, updateRequests[1]["required_status_checks"])
+		assert.Equal(t, []string{http.MethodPost, http.MethodDelete}, signatureRequests)
+	})
+
+	t.Run("update fails", func(t *testing.T) {
+		err := client.UpdateBranchProtection(context.Background(), "gittuf", "gittuf", &BranchProtection{Branch: "develop"})
+		assert.ErrorIs(t, err, ErrUnexpectedResponse)
+	})
+}
@@ -0,0 +1,311 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Package github implements the subset of the GitHub REST API used by gittuf
+// to record pull request approvals, to sync team members into policy, and to
+// keep branch protection consistent with policy, along with the key type used
+// to authorize GitHub identities in gittuf policy.
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/gittuf/gittuf/internal/signerverifier/gpg"
+	sslibsv "github.com/gittuf/gittuf/internal/third_party/go-securesystemslib/signerverifier"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	DefaultBaseURL = "https://api.github.com"
+
+	// IdentityKeyType is the key type used to authorize a GitHub user in
+	// gittuf policy. Such keys cannot be used to verify signatures, instead
+	// they're met by approvals recorded in GitHub pull request approval
+	// attestations.
+	IdentityKeyType   = "github-identity"
+	IdentityKeyScheme = "github"
+
+	reviewStateApproved         = "APPROVED"
+	reviewStateChangesRequested = "CHANGES_REQUESTED"
+	reviewStateDismissed        = "DISMISSED"
+
+	resultsPerPage = 100
+)
+
+var (
+	ErrPullRequestNotMerged = errors.New("pull request has not been merged")
+	ErrUnexpectedResponse   = errors.New("unexpected response from GitHub API")
+	ErrNotFound             = errors.New("not found")
+)
+
+// IdentityKeyID returns the key ID for the GitHub user with the specified
+// login.
+func IdentityKeyID(login string) string {
+	return fmt.Sprintf("github:%s", login)
+}
+
+// NewIdentityKey returns a gittuf key for the GitHub user with the specified
+// login.
+func NewIdentityKey(login string) *tuf.Key {
+	return &tuf.Key{
+		KeyID:   IdentityKeyID(login),
+		KeyType: IdentityKeyType,
+		Scheme:  IdentityKeyScheme,
+		KeyVal: sslibsv.KeyVal{
+			Identity: login,
+			Issuer:   "https://github.com",
+		},
+	}
+}
+
+// PullRequest contains the details of a GitHub pull request that are relevant
+// to gittuf.
+type PullRequest struct {
+	Number         int
+	HTMLURL        string
+	BaseRef        string
+	HeadSHA        string
+	Merged         bool
+	MergeCommitSHA string
+}
+
+// Client is a minimal GitHub REST API client.
+type Client struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+}
+
+// NewClient returns a client for the GitHub API at baseURL, which is
+// DefaultBaseURL for github.com. If token is set, it is used to authenticate
+// requests.
+func NewClient(baseURL, token string) *Client {
+	return &Client{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		token:      token,
+		httpClient: http.DefaultClient,
+	}
+}
+
+// GetPullRequest returns the specified pull request.
+func (c *Client) GetPullRequest(ctx context.Context, owner, repository string, number int) (*PullRequest, error) {
+	response := struct {
+		Number  int    `json:"number"`
+		HTMLURL string `json:"html_url"`
+		Base    struct {
+			Ref string `json:"ref"`
+		} `json:"base"`
+		Head struct {
+			SHA string `json:"sha"`
+		} `json:"head"`
+		Merged         bool   `json:"merged"`
+		MergeCommitSHA string `json:"merge_commit_sha"`
+	}{}
+
+	if err := c.get(ctx, fmt.Sprintf("/repos/%s/%s/pulls/%d", owner, repository, number), &response); err != nil {
+		return nil, err
+	}
+
+	return &PullRequest{
+		Number:         response.Number,
+		HTMLURL:        response.HTMLURL,
+		BaseRef:        response.Base.Ref,
+		HeadSHA:        response.Head.SHA,
+		Merged:         response.Merged,
+		MergeCommitSHA: response.MergeCommitSHA,
+	}, nil
+}
+
+// GetApprovers returns the logins of the users who approved the pull request
+// at the commit headSHA. A user's approval only counts if it is their latest
+// review that approves or requests changes, and if it was not dismissed.
+func (c *Client) GetApprovers(ctx context.Context, owner, repository string, number int, headSHA string) ([]string, error) {
+	type review struct {
+		User struct {
+			Login string `json:"login"`
+		} `json:"user"`
+		State    string `json:"state"`
+		CommitID string `json:"commit_id"`
+	}
+
+	// Reviews are returned in chronological order
+	latestReviews := map[string]review{}
+	logins := []string{}
+	for page := 1; ; page++ {
+		reviews := []review{}
+		path := fmt.Sprintf("/repos/%s/%s/pulls/%d/reviews?per_page=%d&page=%d", owner, repository, number, resultsPerPage, page)
+		if err := c.get(ctx, path, &reviews); err != nil {
+			return nil, err
+		}
+
+		for _, r := range reviews {
+			switch r.State {
+			case reviewStateApproved, reviewStateChangesRequested, reviewStateDismissed:
+				if _, seen := latestReviews[r.User.Login]; !seen {
+					logins = append(logins, r.User.Login)
+				}
+				latestReviews[r.User.Login] = r
+			}
+		}
+
+		if len(reviews) < resultsPerPage {
+			break
+		}
+	}
+
+	approvers := []string{}
+	for _, login := range logins {
+		r := latestReviews[login]
+		if r.State == reviewStateApproved && r.CommitID == headSHA {
+			approvers = append(approvers, login)
+		}
+	}
+
+	return approvers, nil
+}
+
+// GetTeamMembers returns the logins of the members of the team identified by
+// teamSlug in the organization org, including members of child teams.
+func (c *Client) GetTeamMembers(ctx context.Context, org, teamSlug string) ([]string, error) {
+	type member struct {
+		Login string `json:"login"`
+	}
+
+	logins := []string{}
+	for page := 1; ; page++ {
+		members := []member{}
+		path := fmt.Sprintf("/orgs/%s/teams/%s/members?per_page=%d&page=%d", org, teamSlug, resultsPerPage, page)
+		if err := c.get(ctx, path, &members); err != nil {
+			return nil, err
+		}
+
+		for _, m := range members {
+			logins = append(logins, m.Login)
+		}
+
+		if len(members) < resultsPerPage {
+			break
+		}
+	}
+
+	return logins, nil
+}
+
+// GetSigningKeys returns the SSH signing keys and GPG keys registered by the
+// user with the specified login. Keys that cannot be used in gittuf policy
+// are skipped.
+func (c *Client) GetSigningKeys(ctx context.Context, login string) ([]*tuf.Key, error) {
+	sshKeys := []struct {
+		Key string `json:"key"`
+	}{}
+	if err := c.get(ctx, fmt.Sprintf("/users/%s/ssh_signing_keys?per_page=%d", login, resultsPerPage), &sshKeys); err != nil {
+		return nil, err
+	}
+
+	gpgKeys := []struct {
+		KeyID  string `json:"key_id"`
+		RawKey string `json:"raw_key"`
+	}{}
+	if err := c.get(ctx, fmt.Sprintf("/users/%s/gpg_keys?per_page=%d", login, resultsPerPage), &gpgKeys); err != nil {
+		return nil, err
+	}
+
+	keys := []*tuf.Key{}
+	for _, sshKey := range sshKeys {
+		key, err := loadSSHPublicKey(sshKey.Key)
+		if err != nil {
+			slog.Debug(fmt.Sprintf("Skipping SSH signing key of '%s': %v", login, err))
+			continue
+		}
+		keys = append(keys, key)
+	}
+	for _, gpgKey := range gpgKeys {
+		if gpgKey.RawKey == "" {
+			slog.Debug(fmt.Sprintf("Skipping GPG key '%s' of '%s' as GitHub did not return the armored key", gpgKey.KeyID, login))
+			continue
+		}
+
+		key, err := gpg.LoadGPGKeyFromBytes([]byte(gpgKey.RawKey))
+		if err != nil {
+			slog.Debug(fmt.Sprintf("Skipping GPG key '%s' of '%s': %v", gpgKey.KeyID, login, err))
+			continue
+		}
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+// loadSSHPublicKey returns a gittuf key for an SSH public key in the
+// authorized_keys format.
+func loadSSHPublicKey(authorizedKey string) (*tuf.Key, error) {
+	publicKey, _, _, _, err := ssh.ParseAuthorizedKey([]byte(authorizedKey))
+	if err != nil {
+		return nil, err
+	}
+
+	cryptoPublicKey, ok := publicKey.(ssh.CryptoPublicKey)
+	if !ok {
+		return nil, fmt.Errorf("unsupported key type '%s'", publicKey.Type())
+	}
+
+	return sslibsv.NewKey(cryptoPublicKey.CryptoPublicKey())
+}
+
+func (c *Client) get(ctx context.Context, path string, response any) error {
+	return c.do(ctx, http.MethodGet, path, nil, response)
+}
+
+// do sends a request with body encoded as JSON, if set, and decodes the
+// response into response, if set.
+func (c *Client) do(ctx context.Context, method, path string, body, response any) error {
+	var requestBody io.Reader
+	if body != nil {
+		bodyBytes, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		requestBody = bytes.NewReader(bodyBytes)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, requestBody)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Accept", "application/vnd.github+json")
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		request.Header.Set("Authorization", fmt.Sprintf("Bearer %s", c.token))
+	}
+
+	httpResponse, err := c.httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer httpResponse.Body.Close() //nolint:errcheck
+
+	switch {
+	case httpResponse.StatusCode == http.StatusNotFound:
+		responseBody, _ := io.ReadAll(io.LimitReader(httpResponse.Body, 1024))
+		return fmt.Errorf("%w: %w: %s", ErrUnexpectedResponse, ErrNotFound, strings.TrimSpace(string(responseBody)))
+	case httpResponse.StatusCode < 200 || httpResponse.StatusCode > 299:
+		responseBody, _ := io.ReadAll(io.LimitReader(httpResponse.Body, 1024))
+		return fmt.Errorf("%w: %s: %s", ErrUnexpectedResponse, httpResponse.Status, strings.TrimSpace(string(responseBody)))
+	}
+
+	if response == nil || httpResponse.StatusCode == http.StatusNoContent {
+		return nil
+	}
+
+	return json.NewDecoder(httpResponse.Body).Decode(response)
+}
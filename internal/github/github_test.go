@@ -0,0 +1,100 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	artifacts "github.com/gittuf/gittuf/internal/testartifacts"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient(t *testing.T) {
+	headSHA := "2f5d1d9b7c1b8b6f0b4c0e6a1d1c2c3b4a5f6e7d"
+	oldSHA := "1f5d1d9b7c1b8b6f0b4c0e6a1d1c2c3b4a5f6e7d"
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/gittuf/gittuf/pulls/1", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		fmt.Fprintf(w, `{"number": 1, "html_url": "https://github.com/gittuf/gittuf/pull/1", "base": {"ref": "main"}, "head": {"sha": "%s"}, "merged": true, "merge_commit_sha": "abc"}`, headSHA)
+	})
+	mux.HandleFunc("/repos/gittuf/gittuf/pulls/1/reviews", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprintf(w, `[
+			{"user": {"login": "alice"}, "state": "APPROVED", "commit_id": "%[1]s"},
+			{"user": {"login": "bob"}, "state": "APPROVED", "commit_id": "%[1]s"},
+			{"user": {"login": "bob"}, "state": "CHANGES_REQUESTED", "commit_id": "%[1]s"},
+			{"user": {"login": "carol"}, "state": "APPROVED", "commit_id": "%[2]s"},
+			{"user": {"login": "dave"}, "state": "CHANGES_REQUESTED", "commit_id": "%[1]s"},
+			{"user": {"login": "dave"}, "state": "APPROVED", "commit_id": "%[1]s"},
+			{"user": {"login": "dave"}, "state": "COMMENTED", "commit_id": "%[1]s"}
+		]`, headSHA, oldSHA)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := NewClient(server.URL, "token")
+
+	pullRequest, err := client.GetPullRequest(context.Background(), "gittuf", "gittuf", 1)
+	assert.Nil(t, err)
+	assert.Equal(t, &PullRequest{Number: 1, HTMLURL: "https://github.com/gittuf/gittuf/pull/1", BaseRef: "main", HeadSHA: headSHA, Merged: true, MergeCommitSHA: "abc"}, pullRequest)
+
+	approvers, err := client.GetApprovers(context.Background(), "gittuf", "gittuf", 1, headSHA)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"alice", "dave"}, approvers)
+
+	_, err = client.GetPullRequest(context.Background(), "gittuf", "gittuf", 2)
+	assert.ErrorIs(t, err, ErrUnexpectedResponse)
+}
+
+func TestClientTeamSync(t *testing.T) {
+	sshKey, err := tuf.LoadKeyFromBytes(artifacts.SSHECDSAPublic)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/orgs/gittuf/teams/maintainers/members", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("page") == "1" {
+			// Return a full page to ensure the next page is requested
+			members := []string{}
+			for i := 0; i < resultsPerPage; i++ {
+				members = append(members, fmt.Sprintf(`{"login": "user%d"}`, i))
+			}
+			fmt.Fprintf(w, "[%s]", strings.Join(members, ","))
+			return
+		}
+		fmt.Fprint(w, `[{"login": "alice"}]`)
+	})
+	mux.HandleFunc("/users/alice/ssh_signing_keys", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `[
+			{"key": "ecdsa-sha2-nistp256 AAAAE2VjZHNhLXNoYTItbmlzdHAyNTYAAAAIbmlzdHAyNTYAAABBBDbRysP/xlGQAPlCM4I2G4cFAsvbYfYxx6SdIa6MnRE1gwaR3BVOa1b+AgLTFqdpSmikEnCEE2mC4LU1mSZv/40="},
+			{"key": "not-a-key"}
+		]`)
+	})
+	mux.HandleFunc("/users/alice/gpg_keys", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `[{"key_id": "ABC", "raw_key": ""}]`)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := NewClient(server.URL, "")
+
+	members, err := client.GetTeamMembers(context.Background(), "gittuf", "maintainers")
+	assert.Nil(t, err)
+	assert.Equal(t, resultsPerPage+1, len(members))
+	assert.Equal(t, "alice", members[resultsPerPage])
+
+	keys, err := client.GetSigningKeys(context.Background(), "alice")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, len(keys))
+	assert.Equal(t, sshKey.KeyID, keys[0].KeyID)
+
+	_, err = client.GetSigningKeys(context.Background(), "bob")
+	assert.ErrorIs(t, err, ErrUnexpectedResponse)
+}
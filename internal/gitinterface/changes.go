@@ -15,19 +15,22 @@ import (
 // This strictly enumerates all the files recursively in the commit object's
 // tree.
 func GetCommitFilePaths(commit *object.Commit) ([]string, error) {
-	filesIter, err := commit.Files()
+	tree, err := commit.Tree()
 	if err != nil {
 		return nil, err
 	}
 
-	paths := []string{}
-	if err := filesIter.ForEach(func(f *object.File) error {
-		paths = append(paths, f.Name)
-		return nil
-	}); err != nil {
+	// Blobs are not loaded, so that paths can be identified in partial clones
+	files, err := GetAllFilesInTree(tree)
+	if err != nil {
 		return nil, err
 	}
 
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+
 	sort.Slice(paths, func(i, j int) bool {
 		return paths[i] < paths[j]
 	})
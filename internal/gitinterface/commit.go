@@ -6,8 +6,12 @@ import (
 	"context"
 	"errors"
 	"io"
+	"time"
 
 	"github.com/gittuf/gittuf/internal/signerverifier"
+	"github.com/gittuf/gittuf/internal/signerverifier/sigstore"
+	"github.com/gittuf/gittuf/internal/signerverifier/sshca"
+	"github.com/gittuf/gittuf/internal/signerverifier/x509"
 	"github.com/gittuf/gittuf/internal/tuf"
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/config"
@@ -153,7 +157,32 @@ func VerifyCommitSignature(ctx context.Context, commit *object.Commit, key *tuf.
 		}
 		commitSignature := []byte(commit.PGPSignature)
 
-		if err := verifyGitsignSignature(ctx, key, commitContents, commitSignature); err != nil {
+		if err := sigstore.VerifyGitSignature(ctx, key, commitContents, commitSignature); err != nil {
+			return errors.Join(ErrIncorrectVerificationKey, err)
+		}
+
+		return nil
+	case signerverifier.X509KeyType:
+		commitContents, err := getCommitBytesWithoutSignature(commit)
+		if err != nil {
+			return errors.Join(ErrVerifyingX509Signature, err)
+		}
+		commitSignature := []byte(commit.PGPSignature)
+
+		if err := x509.VerifySignature(key, commitContents, commitSignature); err != nil {
+			return errors.Join(ErrIncorrectVerificationKey, err)
+		}
+
+		return nil
+	case signerverifier.SSHCAKeyType:
+		commitContents, err := getCommitBytesWithoutSignature(commit)
+		if err != nil {
+			return errors.Join(ErrVerifyingSSHSignature, err)
+		}
+		commitSignature := []byte(commit.PGPSignature)
+
+		// The certificate must be valid when the signature is verified
+		if err := sshca.VerifySignature(key, commitContents, commitSignature, time.Now()); err != nil {
 			return errors.Join(ErrIncorrectVerificationKey, err)
 		}
 
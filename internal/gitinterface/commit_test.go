@@ -5,6 +5,7 @@ package gitinterface
 import (
 	"bytes"
 	"context"
+	"encoding/pem"
 	"strings"
 	"testing"
 	"time"
@@ -12,6 +13,7 @@ import (
 	"github.com/ProtonMail/go-crypto/openpgp"
 	"github.com/gittuf/gittuf/internal/signerverifier"
 	"github.com/gittuf/gittuf/internal/signerverifier/gpg"
+	"github.com/gittuf/gittuf/internal/signerverifier/x509"
 	artifacts "github.com/gittuf/gittuf/internal/testartifacts"
 	sslibsv "github.com/gittuf/gittuf/internal/third_party/go-securesystemslib/signerverifier"
 	"github.com/go-git/go-billy/v5/memfs"
@@ -122,6 +124,7 @@ oYBpMWLgg6AUzpxx9mITZ2EKr4c=
 	}
 
 	sshCommits := createTestSSHSignedCommits(t)
+	x509SignedCommit := createTestX509SignedCommit(t)
 
 	gpgKey, err := gpg.LoadGPGKeyFromBytes(gpgPublicKey)
 	if err != nil {
@@ -183,6 +186,28 @@ oYBpMWLgg6AUzpxx9mITZ2EKr4c=
 		err = VerifyCommitSignature(context.Background(), sshCommits[1], rsaKey)
 		assert.ErrorIs(t, err, ErrIncorrectVerificationKey)
 	})
+
+	t.Run("x509 signed commit", func(t *testing.T) {
+		x509Key, err := x509.NewKey("jane.doe@example.com", artifacts.X509Root)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = VerifyCommitSignature(context.Background(), x509SignedCommit, x509Key)
+		assert.Nil(t, err)
+
+		err = VerifyCommitSignature(context.Background(), gpgSignedCommit, x509Key)
+		assert.ErrorIs(t, err, ErrIncorrectVerificationKey)
+
+		// The certificate must be issued for the expected identity
+		x509Key, err = x509.NewKey("john.doe@example.com", artifacts.X509Root)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = VerifyCommitSignature(context.Background(), x509SignedCommit, x509Key)
+		assert.ErrorIs(t, err, ErrIncorrectVerificationKey)
+	})
 }
 
 func TestKnowsCommit(t *testing.T) {
@@ -302,6 +327,44 @@ func createTestSignedCommit(t *testing.T) *object.Commit {
 	return testCommit
 }
 
+func createTestX509SignedCommit(t *testing.T) *object.Commit {
+	t.Helper()
+
+	testCommit := &object.Commit{
+		Author: object.Signature{
+			Name:  testName,
+			Email: testEmail,
+			When:  testClock.Now(),
+		},
+		Committer: object.Signature{
+			Name:  testName,
+			Email: testEmail,
+			When:  testClock.Now(),
+		},
+		Message:  "Test commit",
+		TreeHash: EmptyTree(),
+	}
+
+	commitBytes, err := getCommitBytesWithoutSignature(testCommit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := x509.NewSigner(artifacts.X509SignerPrivate, artifacts.X509SignerChain)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signature, err := signer.Sign(context.Background(), commitBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Git armors X.509 signatures as signed messages
+	testCommit.PGPSignature = string(pem.EncodeToMemory(&pem.Block{Type: "SIGNED MESSAGE", Bytes: signature}))
+
+	return testCommit
+}
+
 func createTestSSHSignedCommits(t *testing.T) []*object.Commit {
 	t.Helper()
 
@@ -5,13 +5,15 @@ package gitinterface
 import (
 	"bufio"
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"io"
-	"os/exec"
 	"strings"
 
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/config"
+	format "github.com/go-git/go-git/v5/plumbing/format/config"
 )
 
 var (
@@ -19,6 +21,32 @@ var (
 	getGitConfig            = getRealGitConfig
 )
 
+// configOverrides take precedence over the values in the user's Git config.
+var configOverrides = map[string]string{}
+
+// SetConfigOverrides sets values that take precedence over the user's Git
+// config when gittuf reads it, such as to sign using a different key than
+// user.signingkey. Keys are specified as in `git config`, e.g., "gpg.format".
+// Overrides replace those set previously.
+func SetConfigOverrides(overrides map[string]string) {
+	configOverrides = map[string]string{}
+	for key, value := range overrides {
+		configOverrides[normalizeConfigKey(key)] = value
+	}
+}
+
+// normalizeConfigKey returns the key in the form used by `git config
+// --get-regexp`, where the section and option names are lowercase and the
+// subsection name, if any, is case sensitive.
+func normalizeConfigKey(key string) string {
+	firstDot := strings.Index(key, ".")
+	lastDot := strings.LastIndex(key, ".")
+	if firstDot == lastDot {
+		return strings.ToLower(key)
+	}
+	return strings.ToLower(key[:firstDot]) + key[firstDot:lastDot] + strings.ToLower(key[lastDot:])
+}
+
 // GetConfig parses the user's Git config. It shells out to the Git binary
 // because go-git has difficulty combining local, global, and system configs
 // while maintaining all of their fields.
@@ -41,20 +69,85 @@ func getConfig() (map[string]string, error) {
 		config[data[0]] = strings.Join(data[1:], " ")
 	}
 
+	for key, value := range configOverrides {
+		config[key] = value
+	}
+
 	return config, nil
 }
 
+// execGitConfig returns the output of `git config --get-regexp`. When the Git
+// binary is not used, the equivalent output is generated using go-git.
 func execGitConfig() (io.Reader, error) {
-	cmd := exec.Command("git", "config", "--get-regexp", `.*`)
-	stdout := &bytes.Buffer{}
-	stderr := &bytes.Buffer{}
-	cmd.Stdout = stdout
-	cmd.Stderr = stderr
-	if err := cmd.Run(); err != nil {
-		return nil, fmt.Errorf("%w: %s", err, stderr.String())
+	if !UseGitBinary() {
+		gitConfig, err := getConfigUsingGoGit()
+		if err != nil {
+			return nil, err
+		}
+
+		output := &bytes.Buffer{}
+		for key, value := range gitConfig {
+			fmt.Fprintf(output, "%s %s\n", key, value)
+		}
+		return output, nil
 	}
 
-	return stdout, nil
+	// Reading the config is local and quick, so it is not tied to a caller's
+	// context
+	stdout, err := newGitExecutor(context.Background(), "config", "--get-regexp", `.*`).execute()
+	if err != nil {
+		return nil, err
+	}
+
+	return bytes.NewReader(stdout), nil
+}
+
+// getConfigUsingGoGit combines the system, global, and local configs of the
+// repository in the current directory, if any, using go-git. Unlike Git, it
+// does not process include directives.
+func getConfigUsingGoGit() (map[string]string, error) {
+	gitConfig := map[string]string{}
+
+	for _, scope := range []config.Scope{config.SystemScope, config.GlobalScope} {
+		scopedConfig, err := config.LoadConfig(scope)
+		if err != nil {
+			return nil, err
+		}
+		addRawConfig(gitConfig, scopedConfig.Raw)
+	}
+
+	repo, err := git.PlainOpenWithOptions(".", &git.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		if errors.Is(err, git.ErrRepositoryNotExists) {
+			return gitConfig, nil
+		}
+		return nil, err
+	}
+
+	localConfig, err := repo.Config()
+	if err != nil {
+		return nil, err
+	}
+	addRawConfig(gitConfig, localConfig.Raw)
+
+	return gitConfig, nil
+}
+
+// addRawConfig adds the options in the config to gitConfig, using the same
+// keys as `git config --get-regexp`.
+func addRawConfig(gitConfig map[string]string, rawConfig *format.Config) {
+	for _, section := range rawConfig.Sections {
+		sectionName := strings.ToLower(section.Name)
+		for _, option := range section.Options {
+			gitConfig[fmt.Sprintf("%s.%s", sectionName, strings.ToLower(option.Key))] = option.Value
+		}
+
+		for _, subsection := range section.Subsections {
+			for _, option := range subsection.Options {
+				gitConfig[fmt.Sprintf("%s.%s.%s", sectionName, subsection.Name, strings.ToLower(option.Key))] = option.Value
+			}
+		}
+	}
 }
 
 func getRealGitConfig(repo *git.Repository) (*config.Config, error) {
@@ -0,0 +1,58 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package gitinterface
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	format "github.com/go-git/go-git/v5/plumbing/format/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddRawConfig(t *testing.T) {
+	rawConfig := format.New()
+	rawConfig.Section("user").SetOption("signingKey", "key")
+	rawConfig.Section("gpg").SetOption("format", "ssh")
+	rawConfig.Section("gpg").Subsection("ssh").SetOption("program", "ssh-keygen")
+
+	gitConfig := map[string]string{"user.signingkey": "old-key", "user.name": "Jane Doe"}
+	addRawConfig(gitConfig, rawConfig)
+
+	expectedConfig := map[string]string{
+		"user.signingkey": "key",
+		"user.name":       "Jane Doe",
+		"gpg.format":      "ssh",
+		"gpg.ssh.program": "ssh-keygen",
+	}
+	assert.Equal(t, expectedConfig, gitConfig)
+}
+
+func TestSetConfigOverrides(t *testing.T) {
+	getGitConfigFromCommand = func() (io.Reader, error) {
+		return strings.NewReader("user.signingkey key\ngpg.format gpg\nuser.name Jane Doe\n"), nil
+	}
+	t.Cleanup(func() {
+		getGitConfigFromCommand = execGitConfig
+		SetConfigOverrides(nil)
+	})
+
+	SetConfigOverrides(map[string]string{"user.signingKey": "other-key", "gpg.format": "ssh", "gpg.SSH.Program": "ssh-keygen"})
+
+	gitConfig, err := getConfig()
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]string{
+		"user.signingkey": "other-key",
+		"gpg.format":      "ssh",
+		"gpg.SSH.program": "ssh-keygen",
+		"user.name":       "Jane Doe",
+	}, gitConfig)
+
+	SetConfigOverrides(nil)
+
+	gitConfig, err = getConfig()
+	assert.Nil(t, err)
+	assert.Equal(t, "key", gitConfig["user.signingkey"])
+	assert.Equal(t, "gpg", gitConfig["gpg.format"])
+}
@@ -0,0 +1,131 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package gitinterface
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+)
+
+var ErrUnsupportedDigestObjectType = errors.New("unable to compute digest for object type")
+
+// ComputeSHA256Digest returns the hex encoded SHA-256 digest of the contents of
+// the specified commit or tag. Unlike the object's SHA-1 ID, the digest covers
+// the contents of every object reachable from the commit's tree (or the tag's
+// target) using SHA-256 throughout, so that two objects with colliding SHA-1
+// IDs have different digests. The digest of a blob is the SHA-256 of its
+// encoding, the digest of a tree replaces the IDs of its entries with their
+// digests, and the digest of a commit or tag is the SHA-256 of its encoding
+// followed by the digest of its tree or target. Parent commits are not
+// included as they are recorded in prior RSL entries.
+func ComputeSHA256Digest(repo *git.Repository, objectID plumbing.Hash) (string, error) {
+	digester := &sha256Digester{repo: repo, digests: map[plumbing.Hash][]byte{}}
+	digest, err := digester.digest(objectID)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(digest), nil
+}
+
+type sha256Digester struct {
+	repo    *git.Repository
+	digests map[plumbing.Hash][]byte
+}
+
+func (d *sha256Digester) digest(objectID plumbing.Hash) ([]byte, error) {
+	if digest, has := d.digests[objectID]; has {
+		return digest, nil
+	}
+
+	obj, err := d.repo.Storer.EncodedObject(plumbing.AnyObject, objectID)
+	if err != nil {
+		return nil, err
+	}
+
+	hasher := sha256.New()
+	switch obj.Type() {
+	case plumbing.BlobObject:
+		if err := writeEncodedObject(hasher, obj); err != nil {
+			return nil, err
+		}
+	case plumbing.TreeObject:
+		tree, err := GetTree(d.repo, objectID)
+		if err != nil {
+			return nil, err
+		}
+
+		fmt.Fprintf(hasher, "tree %d\x00", len(tree.Entries))
+		for _, entry := range tree.Entries {
+			var entryDigest []byte
+			if entry.Mode == filemode.Submodule {
+				// The submodule's commit is not in the repository
+				entryDigest = entry.Hash[:]
+			} else {
+				entryDigest, err = d.digest(entry.Hash)
+				if err != nil {
+					return nil, err
+				}
+			}
+
+			fmt.Fprintf(hasher, "%o %s\x00", entry.Mode, entry.Name)
+			hasher.Write(entryDigest) //nolint:errcheck
+		}
+	case plumbing.CommitObject:
+		commit, err := GetCommit(d.repo, objectID)
+		if err != nil {
+			return nil, err
+		}
+		treeDigest, err := d.digest(commit.TreeHash)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := writeEncodedObject(hasher, obj); err != nil {
+			return nil, err
+		}
+		hasher.Write(treeDigest) //nolint:errcheck
+	case plumbing.TagObject:
+		tag, err := GetTag(d.repo, objectID)
+		if err != nil {
+			return nil, err
+		}
+		targetDigest, err := d.digest(tag.Target)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := writeEncodedObject(hasher, obj); err != nil {
+			return nil, err
+		}
+		hasher.Write(targetDigest) //nolint:errcheck
+	default:
+		return nil, fmt.Errorf("%w '%s'", ErrUnsupportedDigestObjectType, obj.Type())
+	}
+
+	digest := hasher.Sum(nil)
+	d.digests[objectID] = digest
+	return digest, nil
+}
+
+// writeEncodedObject writes the object's header and contents to w, as used to
+// compute the object's ID.
+func writeEncodedObject(w io.Writer, obj plumbing.EncodedObject) error {
+	fmt.Fprintf(w, "%s %d\x00", obj.Type(), obj.Size())
+
+	reader, err := obj.Reader()
+	if err != nil {
+		return err
+	}
+	defer reader.Close() //nolint:errcheck
+
+	_, err = io.Copy(w, reader)
+	return err
+}
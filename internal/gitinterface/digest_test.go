@@ -0,0 +1,77 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package gitinterface
+
+import (
+	"testing"
+
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComputeSHA256Digest(t *testing.T) {
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	refName := "refs/heads/main"
+	clock = testClock
+	getGitConfig = func(_ *git.Repository) (*config.Config, error) {
+		return testGitConfig, nil
+	}
+
+	createCommit := func(contents string) plumbing.Hash {
+		blobID, err := WriteBlob(repo, []byte(contents))
+		if err != nil {
+			t.Fatal(err)
+		}
+		subTreeID, err := WriteTree(repo, []object.TreeEntry{{Name: "file", Mode: filemode.Regular, Hash: blobID}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		treeID, err := WriteTree(repo, []object.TreeEntry{{Name: "dir", Mode: filemode.Dir, Hash: subTreeID}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		commitID, err := Commit(repo, treeID, refName, "Test commit", false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return commitID
+	}
+
+	firstCommitID := createCommit("a")
+	secondCommitID := createCommit("b")
+
+	firstDigest, err := ComputeSHA256Digest(repo, firstCommitID)
+	assert.Nil(t, err)
+	assert.Len(t, firstDigest, 64)
+
+	// The digest is deterministic
+	digest, err := ComputeSHA256Digest(repo, firstCommitID)
+	assert.Nil(t, err)
+	assert.Equal(t, firstDigest, digest)
+
+	// Changes to nested contents change the digest
+	secondDigest, err := ComputeSHA256Digest(repo, secondCommitID)
+	assert.Nil(t, err)
+	assert.NotEqual(t, firstDigest, secondDigest)
+
+	tagID, err := Tag(repo, secondCommitID, "v1", "v1", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tagDigest, err := ComputeSHA256Digest(repo, tagID)
+	assert.Nil(t, err)
+	assert.NotEqual(t, secondDigest, tagDigest)
+
+	_, err = ComputeSHA256Digest(repo, plumbing.ZeroHash)
+	assert.ErrorIs(t, err, plumbing.ErrObjectNotFound)
+}
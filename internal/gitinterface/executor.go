@@ -0,0 +1,74 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package gitinterface
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+)
+
+const gitBinary = "git"
+
+// executor runs an external program such as the Git binary. Each invocation is
+// bound to a context, so the program is killed if the context is cancelled or
+// its deadline passes.
+type executor struct {
+	ctx    context.Context
+	name   string
+	args   []string
+	stdin  io.Reader
+	stderr io.Writer
+}
+
+// newGitExecutor returns an executor that invokes the Git binary with the
+// specified arguments.
+func newGitExecutor(ctx context.Context, args ...string) *executor {
+	return newExecutor(ctx, gitBinary, args...)
+}
+
+// newExecutor returns an executor that invokes the specified program with the
+// specified arguments.
+func newExecutor(ctx context.Context, name string, args ...string) *executor {
+	return &executor{ctx: ctx, name: name, args: args}
+}
+
+// withStdin sets the reader used as the program's standard input.
+func (e *executor) withStdin(stdin io.Reader) *executor {
+	e.stdin = stdin
+	return e
+}
+
+// withStderr sets the writer the program's standard error is copied to, in
+// addition to being included in the returned error on failure.
+func (e *executor) withStderr(stderr io.Writer) *executor {
+	e.stderr = stderr
+	return e
+}
+
+// execute runs the program and returns its standard output. If the program
+// fails because the context is done, the returned error wraps the context's
+// error.
+func (e *executor) execute() ([]byte, error) {
+	cmd := exec.CommandContext(e.ctx, e.name, e.args...) //nolint:gosec
+
+	stdout := &bytes.Buffer{}
+	stderr := &bytes.Buffer{}
+	cmd.Stdin = e.stdin
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	if e.stderr != nil {
+		cmd.Stderr = io.MultiWriter(stderr, e.stderr)
+	}
+
+	if err := cmd.Run(); err != nil {
+		if ctxErr := e.ctx.Err(); ctxErr != nil {
+			return nil, fmt.Errorf("unable to run '%s': %w", e.name, ctxErr)
+		}
+		return nil, fmt.Errorf("%w: %s", err, stderr.String())
+	}
+
+	return stdout.Bytes(), nil
+}
@@ -0,0 +1,52 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package gitinterface
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecutor(t *testing.T) {
+	t.Run("successful command", func(t *testing.T) {
+		stdout, err := newGitExecutor(context.Background(), "--version").execute()
+		assert.Nil(t, err)
+		assert.True(t, strings.HasPrefix(string(stdout), "git version"))
+	})
+
+	t.Run("command with stdin", func(t *testing.T) {
+		stdout, err := newGitExecutor(context.Background(), "hash-object", "--stdin").withStdin(bytes.NewReader([]byte("gittuf"))).execute()
+		assert.Nil(t, err)
+		assert.Equal(t, plumbing.ComputeHash(plumbing.BlobObject, []byte("gittuf")).String(), strings.TrimSpace(string(stdout)))
+	})
+
+	t.Run("failed command", func(t *testing.T) {
+		stderr := &bytes.Buffer{}
+		_, err := newGitExecutor(context.Background(), "not-a-git-command").withStderr(stderr).execute()
+		assert.NotNil(t, err)
+		assert.Contains(t, err.Error(), "not-a-git-command")
+		assert.Contains(t, stderr.String(), "not-a-git-command")
+	})
+
+	t.Run("cancelled context", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := newGitExecutor(ctx, "--version").execute()
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("deadline exceeded", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		_, err := newExecutor(ctx, "sleep", "5").execute()
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
@@ -7,10 +7,8 @@ import (
 	"context"
 	"encoding/pem"
 	"errors"
-	"fmt"
-	"io"
 	"os"
-	"os/exec"
+	"slices"
 	"strings"
 
 	"github.com/hiddeco/sshsig"
@@ -18,10 +16,6 @@ import (
 	"github.com/ProtonMail/go-crypto/openpgp"
 	"github.com/gittuf/gittuf/internal/signerverifier"
 	"github.com/gittuf/gittuf/internal/tuf"
-	"github.com/sigstore/cosign/v2/pkg/cosign"
-	gitsignVerifier "github.com/sigstore/gitsign/pkg/git"
-	gitsignRekor "github.com/sigstore/gitsign/pkg/rekor"
-	"github.com/sigstore/sigstore/pkg/fulcioroots"
 	"golang.org/x/crypto/ssh"
 )
 
@@ -32,6 +26,7 @@ var (
 	ErrIncorrectVerificationKey   = errors.New("incorrect key provided to verify signature")
 	ErrVerifyingSigstoreSignature = errors.New("unable to verify Sigstore signature")
 	ErrVerifyingSSHSignature      = errors.New("unable to verify SSH signature")
+	ErrVerifyingX509Signature     = errors.New("unable to verify X.509 signature")
 	ErrInvalidSignature           = errors.New("unable to parse signature / signature has unexpected header")
 )
 
@@ -51,6 +46,7 @@ const (
 
 const (
 	namespaceSSHSignature      string = "git"
+	sshLiteralKeyPrefix        string = "key::"
 	gpgPrivateKeyPEMHeader     string = "PGP PRIVATE KEY"
 	opensshPrivateKeyPEMHeader string = "OPENSSH PRIVATE KEY"
 	rsaPrivateKeyPEMHeader     string = "RSA PRIVATE KEY"
@@ -83,8 +79,13 @@ func GetSigningCommand() (string, []string, error) {
 		args = []string{
 			"-Y", "sign",
 			"-n", "git", // Git namespace
-			"-f", keyInfo,
 		}
+		if strings.HasPrefix(keyInfo, sshLiteralKeyPrefix) {
+			// The public key of a key held by ssh-agent is specified directly,
+			// signGitObject writes it to a file for ssh-keygen
+			args = append(args, "-U") // U -> private key is in ssh-agent
+		}
+		args = append(args, "-f", keyInfo)
 	case SigningMethodX509:
 		if len(keyInfo) == 0 {
 			args = []string{
@@ -178,59 +179,54 @@ func signGitObject(contents []byte) (string, error) {
 		return "", err
 	}
 
-	cmd := exec.Command(command, args...)
-
-	stdInWriter, err := cmd.StdinPipe()
+	args, cleanup, err := writeSSHLiteralKey(args)
 	if err != nil {
 		return "", err
 	}
+	defer cleanup()
 
-	stdOutReader, err := cmd.StdoutPipe()
+	// Signing programs may wait on the user, such as to enter a PIN, so they
+	// are not bound to a deadline
+	sig, err := newExecutor(context.Background(), command, args...).withStdin(bytes.NewReader(contents)).withStderr(os.Stderr).execute()
 	if err != nil {
 		return "", err
 	}
-	defer stdOutReader.Close()
 
-	stdErrReader, err := cmd.StderrPipe()
-	if err != nil {
-		return "", err
-	}
-	defer stdErrReader.Close()
-
-	if err = cmd.Start(); err != nil {
-		return "", err
+	if len(sig) == 0 {
+		return "", ErrUnableToSign
 	}
 
-	if _, err := stdInWriter.Write(contents); err != nil {
-		return "", err
-	}
-	if err := stdInWriter.Close(); err != nil {
-		return "", err
-	}
+	return string(sig), nil
+}
 
-	sig, err := io.ReadAll(stdOutReader)
-	if err != nil {
-		return "", err
+// writeSSHLiteralKey writes a public key specified in the Git config using the
+// key:: prefix to a temporary file, as ssh-keygen expects the signing key to be
+// a file. The returned cleanup function removes the file.
+func writeSSHLiteralKey(args []string) ([]string, func(), error) {
+	index := slices.Index(args, "-f")
+	if index == -1 || index+1 >= len(args) || !strings.HasPrefix(args[index+1], sshLiteralKeyPrefix) {
+		return args, func() {}, nil
 	}
 
-	e, err := io.ReadAll(stdErrReader)
+	keyFile, err := os.CreateTemp("", "gittuf-ssh-key-")
 	if err != nil {
-		return "", err
-	}
-
-	if len(e) > 0 {
-		fmt.Fprint(os.Stderr, string(e))
+		return nil, nil, err
 	}
+	cleanup := func() { os.Remove(keyFile.Name()) } //nolint:errcheck
 
-	if err = cmd.Wait(); err != nil {
-		return "", err
+	if _, err := keyFile.WriteString(strings.TrimPrefix(args[index+1], sshLiteralKeyPrefix) + "\n"); err != nil {
+		keyFile.Close() //nolint:errcheck
+		cleanup()
+		return nil, nil, err
 	}
-
-	if len(sig) == 0 {
-		return "", ErrUnableToSign
+	if err := keyFile.Close(); err != nil {
+		cleanup()
+		return nil, nil, err
 	}
 
-	return string(sig), nil
+	args = slices.Clone(args)
+	args[index+1] = keyFile.Name()
+	return args, cleanup, nil
 }
 
 func signGitObjectUsingKey(contents, pemKeyBytes []byte) (string, error) {
@@ -284,60 +280,6 @@ func signGitObjectUsingSSHKey(contents, pemKeyBytes []byte) (string, error) {
 	return string(sigBytes), nil
 }
 
-// verifyGitsignSignature handles the Sigstore-specific workflow involved in
-// verifying commit or tag signatures issued by gitsign.
-func verifyGitsignSignature(ctx context.Context, key *tuf.Key, data, signature []byte) error {
-	root, err := fulcioroots.Get()
-	if err != nil {
-		return errors.Join(ErrVerifyingSigstoreSignature, err)
-	}
-	intermediate, err := fulcioroots.GetIntermediates()
-	if err != nil {
-		return errors.Join(ErrVerifyingSigstoreSignature, err)
-	}
-
-	verifier, err := gitsignVerifier.NewCertVerifier(
-		gitsignVerifier.WithRootPool(root),
-		gitsignVerifier.WithIntermediatePool(intermediate),
-	)
-	if err != nil {
-		return errors.Join(ErrVerifyingSigstoreSignature, err)
-	}
-
-	verifiedCert, err := verifier.Verify(ctx, data, signature, true)
-	if err != nil {
-		return ErrIncorrectVerificationKey
-	}
-
-	rekor, err := gitsignRekor.NewWithOptions(ctx, signerverifier.RekorServer)
-	if err != nil {
-		return errors.Join(ErrVerifyingSigstoreSignature, err)
-	}
-
-	ctPub, err := cosign.GetCTLogPubs(ctx)
-	if err != nil {
-		return errors.Join(ErrVerifyingSigstoreSignature, err)
-	}
-
-	checkOpts := &cosign.CheckOpts{
-		RekorClient:       rekor.Rekor,
-		RootCerts:         root,
-		IntermediateCerts: intermediate,
-		CTLogPubKeys:      ctPub,
-		RekorPubKeys:      rekor.PublicKeys(),
-		Identities: []cosign.Identity{{
-			Issuer:  key.KeyVal.Issuer,
-			Subject: key.KeyVal.Identity,
-		}},
-	}
-
-	if _, err := cosign.ValidateAndUnpackCert(verifiedCert, checkOpts); err != nil {
-		return errors.Join(ErrIncorrectVerificationKey, err)
-	}
-
-	return nil
-}
-
 // verifySSHKeySignature verifies Git signatures issued by SSH keys.
 func verifySSHKeySignature(key *tuf.Key, data, signature []byte) error {
 	verifier, err := signerverifier.NewSignerVerifierFromTUFKey(key) //nolint:staticcheck
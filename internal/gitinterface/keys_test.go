@@ -5,6 +5,7 @@ package gitinterface
 import (
 	"bytes"
 	"io"
+	"os"
 	"testing"
 
 	artifacts "github.com/gittuf/gittuf/internal/testartifacts"
@@ -176,3 +177,35 @@ func TestGetSigningInfo(t *testing.T) {
 		}
 	}
 }
+
+func TestWriteSSHLiteralKey(t *testing.T) {
+	t.Run("literal key", func(t *testing.T) {
+		publicKey := "ssh-ed25519 AAAAC3NzaC1lZDI1NTE5AAAAIDkfmNgABKMiRMRUFX3JR3+2vZ0Dzo9vRAn7vw1iIcRi gittuf@example.com"
+		args := []string{"-Y", "sign", "-n", "git", "-U", "-f", "key::" + publicKey}
+
+		updatedArgs, cleanup, err := writeSSHLiteralKey(args)
+		assert.Nil(t, err)
+		defer cleanup()
+
+		assert.Equal(t, args[:6], updatedArgs[:6])
+		assert.NotEqual(t, args[6], updatedArgs[6])
+
+		contents, err := os.ReadFile(updatedArgs[6])
+		assert.Nil(t, err)
+		assert.Equal(t, publicKey+"\n", string(contents))
+
+		cleanup()
+		_, err = os.Stat(updatedArgs[6])
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("key file", func(t *testing.T) {
+		args := []string{"-Y", "sign", "-n", "git", "-f", "/home/user/.ssh/id_ed25519"}
+
+		updatedArgs, cleanup, err := writeSSHLiteralKey(args)
+		assert.Nil(t, err)
+		defer cleanup()
+
+		assert.Equal(t, args, updatedArgs)
+	})
+}
@@ -76,3 +76,42 @@ func GetCommitsBetweenRange(repo *git.Repository, commitNewID, commitOldID plumb
 
 	return commits, nil
 }
+
+// GetFirstParentCommitsBetweenRange returns the commits (including the new
+// commit, excluding the old) reached by following the first parent of each
+// commit from the new commit. The walk stops at the first commit reachable from
+// the old commit. If the old commit ID is set to zero, the walk continues until
+// a commit with no parents is reached. The returned commits are ordered from
+// the new commit.
+func GetFirstParentCommitsBetweenRange(repo *git.Repository, commitNewID, commitOldID plumbing.Hash) ([]*object.Commit, error) {
+	reachableFromCommitOld := []plumbing.Hash{}
+	if !commitOldID.IsZero() {
+		var err error
+		reachableFromCommitOld, err = revlist.Objects(repo.Storer, []plumbing.Hash{commitOldID}, nil)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	seen := make(map[plumbing.Hash]bool, len(reachableFromCommitOld))
+	for _, id := range reachableFromCommitOld {
+		seen[id] = true
+	}
+
+	commits := []*object.Commit{}
+	iteratorID := commitNewID
+	for !iteratorID.IsZero() && !seen[iteratorID] {
+		commit, err := GetCommit(repo, iteratorID)
+		if err != nil {
+			return nil, err
+		}
+		commits = append(commits, commit)
+
+		iteratorID = plumbing.ZeroHash
+		if len(commit.ParentHashes) > 0 {
+			iteratorID = commit.ParentHashes[0]
+		}
+	}
+
+	return commits, nil
+}
@@ -317,3 +317,77 @@ func createChildrenCommits(t *testing.T, repo *git.Repository, treeHashes []plum
 	}
 	return children
 }
+
+func TestGetFirstParentCommitsBetweenRange(t *testing.T) {
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	emptyBlobHash, err := WriteBlob(repo, []byte{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	treeHashes := createTestTrees(t, repo, emptyBlobHash, 5)
+
+	commit := CreateCommitObject(testGitConfig, treeHashes[0], nil, "Test commit 1", testClock)
+	firstCommitID, err := WriteCommit(repo, commit)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// commit 2 and commit 3 are children of commit 1
+	children := createChildrenCommits(t, repo, treeHashes, firstCommitID, 2)
+
+	// commit 4 merges commit 3 into commit 2
+	commit = CreateCommitObject(testGitConfig, treeHashes[3], children, "Test commit 4", testClock)
+	mergeCommitID, err := WriteCommit(repo, commit)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	commit = CreateCommitObject(testGitConfig, treeHashes[4], []plumbing.Hash{mergeCommitID}, "Test commit 5", testClock)
+	latestCommitID, err := WriteCommit(repo, commit)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		commitNewID       plumbing.Hash
+		commitOldID       plumbing.Hash
+		expectedCommitIDs []plumbing.Hash
+	}{
+		"all first parent commits": {
+			commitNewID:       latestCommitID,
+			commitOldID:       plumbing.ZeroHash,
+			expectedCommitIDs: []plumbing.Hash{latestCommitID, mergeCommitID, children[0], firstCommitID},
+		},
+		"first parent commits since first commit": {
+			commitNewID:       latestCommitID,
+			commitOldID:       firstCommitID,
+			expectedCommitIDs: []plumbing.Hash{latestCommitID, mergeCommitID, children[0]},
+		},
+		"old commit merged in as second parent": {
+			commitNewID:       mergeCommitID,
+			commitOldID:       children[1],
+			expectedCommitIDs: []plumbing.Hash{mergeCommitID, children[0]},
+		},
+		"same commit": {
+			commitNewID:       mergeCommitID,
+			commitOldID:       mergeCommitID,
+			expectedCommitIDs: []plumbing.Hash{},
+		},
+	}
+
+	for name, test := range tests {
+		commits, err := GetFirstParentCommitsBetweenRange(repo, test.commitNewID, test.commitOldID)
+		assert.Nil(t, err, fmt.Sprintf("unexpected error in test '%s'", name))
+
+		commitIDs := []plumbing.Hash{}
+		for _, commit := range commits {
+			commitIDs = append(commitIDs, commit.Hash)
+		}
+		assert.Equal(t, test.expectedCommitIDs, commitIDs, fmt.Sprintf("unexpected commits in test '%s'", name))
+	}
+}
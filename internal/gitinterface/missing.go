@@ -0,0 +1,237 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package gitinterface
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/filesystem"
+)
+
+var (
+	ErrMissingObjects         = errors.New("objects required for verification are missing from the repository")
+	ErrCannotFetchObjectsByID = errors.New("fetching objects by ID requires the Git binary and a repository on disk")
+)
+
+// MissingObjectsError identifies the objects that are missing from a shallow
+// or partial clone.
+type MissingObjectsError struct {
+	ObjectIDs []plumbing.Hash
+}
+
+func (e *MissingObjectsError) Error() string {
+	objectIDs := make([]string, 0, len(e.ObjectIDs))
+	for _, objectID := range e.ObjectIDs {
+		objectIDs = append(objectIDs, objectID.String())
+	}
+	return fmt.Sprintf("%s: %s", ErrMissingObjects.Error(), strings.Join(objectIDs, ", "))
+}
+
+func (e *MissingObjectsError) Unwrap() error {
+	return ErrMissingObjects
+}
+
+// IsShallowClone indicates if the repository is a shallow clone, i.e., the
+// history of some of its commits is truncated.
+func IsShallowClone(repo *git.Repository) (bool, error) {
+	shallowCommits, err := repo.Storer.Shallow()
+	if err != nil {
+		return false, err
+	}
+
+	return len(shallowCommits) > 0, nil
+}
+
+// IsPartialClone indicates if the repository is a partial clone, such as a
+// blobless or treeless clone, where objects are fetched from a promisor
+// remote on demand.
+func IsPartialClone(repo *git.Repository) (bool, error) {
+	config, err := repo.Config()
+	if err != nil {
+		return false, err
+	}
+
+	if config.Raw.Section("extensions").Option("partialClone") != "" {
+		return true, nil
+	}
+
+	for _, subsection := range config.Raw.Section("remote").Subsections {
+		if subsection.Option("promisor") == "true" {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// GetMissingObjects returns the IDs of the objects that are required to
+// inspect the commits introduced by commitNewID relative to commitOldID but
+// are not in the repository. This includes the commits themselves and their
+// trees, as well as the parents of these commits that are reachable from
+// commitOldID and their trees, which are used to identify the changes made by
+// each commit. Blobs are only included if includeBlobs is set, as their
+// contents are not otherwise read. If commitOldID is the zero hash, every
+// commit reachable from commitNewID is inspected.
+func GetMissingObjects(repo *git.Repository, commitNewID, commitOldID plumbing.Hash, includeBlobs bool) ([]plumbing.Hash, error) {
+	oldHistory := map[plumbing.Hash]bool{}
+	if !commitOldID.IsZero() {
+		// Objects missing from the history of the old commit are only
+		// required if they are parents of new commits
+		if err := walkCommits(repo, commitOldID, oldHistory, func(*object.Commit) error { return nil }, func(plumbing.Hash) {}); err != nil {
+			return nil, err
+		}
+	}
+
+	missing := []plumbing.Hash{}
+	addMissing := func(objectID plumbing.Hash) {
+		missing = append(missing, objectID)
+	}
+
+	seen := make(map[plumbing.Hash]bool, len(oldHistory))
+	for commitID := range oldHistory {
+		seen[commitID] = true
+	}
+	seenTrees := map[plumbing.Hash]bool{}
+	boundaryParents := []plumbing.Hash{}
+	err := walkCommits(repo, commitNewID, seen, func(commit *object.Commit) error {
+		for _, parentID := range commit.ParentHashes {
+			if oldHistory[parentID] {
+				boundaryParents = append(boundaryParents, parentID)
+			}
+		}
+		return findMissingTreeObjects(repo, commit.TreeHash, includeBlobs, seenTrees, addMissing)
+	}, addMissing)
+	if err != nil {
+		return nil, err
+	}
+
+	seenBoundaryParents := map[plumbing.Hash]bool{}
+	for _, parentID := range boundaryParents {
+		if seenBoundaryParents[parentID] {
+			continue
+		}
+		seenBoundaryParents[parentID] = true
+
+		parent, err := GetCommit(repo, parentID)
+		if err != nil {
+			if errors.Is(err, plumbing.ErrObjectNotFound) {
+				addMissing(parentID)
+				continue
+			}
+			return nil, err
+		}
+		if err := findMissingTreeObjects(repo, parent.TreeHash, false, seenTrees, addMissing); err != nil {
+			return nil, err
+		}
+	}
+
+	return missing, nil
+}
+
+// FetchObjects fetches the specified objects from the remote using the Git
+// binary. When the repository is a shallow clone, the history of the fetched
+// commits is fetched as well, stopping at commits already in the repository.
+// As go-git caches the repository's packfiles, a new handle must be used to
+// read the fetched objects.
+func FetchObjects(ctx context.Context, repo *git.Repository, remoteName string, objectIDs []plumbing.Hash) error {
+	fsStorage, isFilesystem := repo.Storer.(*filesystem.Storage)
+	if !isFilesystem || !UseGitBinary() {
+		return ErrCannotFetchObjectsByID
+	}
+
+	args := []string{
+		"--git-dir", fsStorage.Filesystem().Root(),
+		"-c", "fetch.negotiationAlgorithm=noop",
+		"fetch", "--quiet", "--no-tags", "--no-write-fetch-head", "--recurse-submodules=no",
+		remoteName,
+	}
+	for _, objectID := range objectIDs {
+		args = append(args, objectID.String())
+	}
+
+	_, err := newGitExecutor(ctx, args...).execute()
+	return err
+}
+
+// walkCommits visits the commits reachable from commitID that are not in
+// seen, invoking visit for each. Commits that are not in the repository are
+// passed to onMissing, and their history is not walked.
+func walkCommits(repo *git.Repository, commitID plumbing.Hash, seen map[plumbing.Hash]bool, visit func(*object.Commit) error, onMissing func(plumbing.Hash)) error {
+	queue := []plumbing.Hash{commitID}
+	for len(queue) != 0 {
+		currentID := queue[0]
+		queue = queue[1:]
+
+		if seen[currentID] {
+			continue
+		}
+		seen[currentID] = true
+
+		commit, err := GetCommit(repo, currentID)
+		if err != nil {
+			if errors.Is(err, plumbing.ErrObjectNotFound) {
+				onMissing(currentID)
+				continue
+			}
+			return err
+		}
+
+		if err := visit(commit); err != nil {
+			return err
+		}
+
+		queue = append(queue, commit.ParentHashes...)
+	}
+
+	return nil
+}
+
+// findMissingTreeObjects passes the IDs of the tree and its subtrees, and
+// optionally blobs, that are not in the repository to onMissing.
+func findMissingTreeObjects(repo *git.Repository, treeID plumbing.Hash, includeBlobs bool, seen map[plumbing.Hash]bool, onMissing func(plumbing.Hash)) error {
+	if seen[treeID] {
+		return nil
+	}
+	seen[treeID] = true
+
+	tree, err := GetTree(repo, treeID)
+	if err != nil {
+		if errors.Is(err, plumbing.ErrObjectNotFound) {
+			onMissing(treeID)
+			return nil
+		}
+		return err
+	}
+
+	for _, entry := range tree.Entries {
+		switch entry.Mode {
+		case filemode.Submodule:
+			continue
+		case filemode.Dir:
+			if err := findMissingTreeObjects(repo, entry.Hash, includeBlobs, seen, onMissing); err != nil {
+				return err
+			}
+		default:
+			if !includeBlobs || seen[entry.Hash] {
+				continue
+			}
+			seen[entry.Hash] = true
+
+			if err := repo.Storer.HasEncodedObject(entry.Hash); err != nil {
+				if !errors.Is(err, plumbing.ErrObjectNotFound) {
+					return err
+				}
+				onMissing(entry.Hash)
+			}
+		}
+	}
+
+	return nil
+}
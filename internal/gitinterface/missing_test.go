@@ -0,0 +1,149 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package gitinterface
+
+import (
+	"testing"
+
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsShallowClone(t *testing.T) {
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	isShallow, err := IsShallowClone(repo)
+	assert.Nil(t, err)
+	assert.False(t, isShallow)
+
+	if err := repo.Storer.SetShallow([]plumbing.Hash{plumbing.NewHash("b5c3b5b5b1f8a54f3e2fb1d2b3cf3b8bd7aa1b2a")}); err != nil {
+		t.Fatal(err)
+	}
+
+	isShallow, err = IsShallowClone(repo)
+	assert.Nil(t, err)
+	assert.True(t, isShallow)
+}
+
+func TestIsPartialClone(t *testing.T) {
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	isPartial, err := IsPartialClone(repo)
+	assert.Nil(t, err)
+	assert.False(t, isPartial)
+
+	repoConfig, err := repo.Config()
+	if err != nil {
+		t.Fatal(err)
+	}
+	repoConfig.Raw.Section("remote").Subsection(DefaultRemoteName).SetOption("promisor", "true")
+	repoConfig.Raw.Section("remote").Subsection(DefaultRemoteName).SetOption("partialclonefilter", "blob:none")
+	if err := repo.SetConfig(repoConfig); err != nil {
+		t.Fatal(err)
+	}
+
+	isPartial, err = IsPartialClone(repo)
+	assert.Nil(t, err)
+	assert.True(t, isPartial)
+}
+
+func TestGetMissingObjects(t *testing.T) {
+	clock = testClock
+	getGitConfig = func(_ *git.Repository) (*config.Config, error) {
+		return testGitConfig, nil
+	}
+
+	storage := memory.NewStorage()
+	repo, err := git.Init(storage, memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	refName := "refs/heads/main"
+	createCommit := func(contents string) (plumbing.Hash, plumbing.Hash, plumbing.Hash) {
+		blobID, err := WriteBlob(repo, []byte(contents))
+		if err != nil {
+			t.Fatal(err)
+		}
+		subTreeID, err := WriteTree(repo, []object.TreeEntry{{Name: "file", Mode: filemode.Regular, Hash: blobID}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		treeID, err := WriteTree(repo, []object.TreeEntry{{Name: "dir", Mode: filemode.Dir, Hash: subTreeID}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		commitID, err := Commit(repo, treeID, refName, "Test commit", false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return commitID, subTreeID, blobID
+	}
+
+	firstCommitID, _, firstBlobID := createCommit("a")
+	secondCommitID, _, secondBlobID := createCommit("b")
+	thirdCommitID, thirdSubTreeID, _ := createCommit("c")
+
+	t.Run("no missing objects", func(t *testing.T) {
+		missing, err := GetMissingObjects(repo, thirdCommitID, plumbing.ZeroHash, true)
+		assert.Nil(t, err)
+		assert.Empty(t, missing)
+	})
+
+	// Simulate a partial clone without the first two blobs and a subtree
+	delete(storage.Blobs, firstBlobID)
+	delete(storage.Objects, firstBlobID)
+	delete(storage.Blobs, secondBlobID)
+	delete(storage.Objects, secondBlobID)
+	delete(storage.Trees, thirdSubTreeID)
+	delete(storage.Objects, thirdSubTreeID)
+
+	t.Run("blobs are only required when requested", func(t *testing.T) {
+		missing, err := GetMissingObjects(repo, thirdCommitID, plumbing.ZeroHash, false)
+		assert.Nil(t, err)
+		assert.Equal(t, []plumbing.Hash{thirdSubTreeID}, missing)
+
+		missing, err = GetMissingObjects(repo, thirdCommitID, plumbing.ZeroHash, true)
+		assert.Nil(t, err)
+		assert.ElementsMatch(t, []plumbing.Hash{thirdSubTreeID, firstBlobID, secondBlobID}, missing)
+	})
+
+	t.Run("objects in the history of the old commit are not required", func(t *testing.T) {
+		missing, err := GetMissingObjects(repo, thirdCommitID, secondCommitID, true)
+		assert.Nil(t, err)
+		assert.Equal(t, []plumbing.Hash{thirdSubTreeID}, missing)
+	})
+
+	// Simulate a shallow clone without the first commit
+	delete(storage.Commits, firstCommitID)
+	delete(storage.Objects, firstCommitID)
+
+	t.Run("missing parent commit", func(t *testing.T) {
+		missing, err := GetMissingObjects(repo, secondCommitID, plumbing.ZeroHash, false)
+		assert.Nil(t, err)
+		assert.Equal(t, []plumbing.Hash{firstCommitID}, missing)
+
+		missing, err = GetMissingObjects(repo, thirdCommitID, secondCommitID, false)
+		assert.Nil(t, err)
+		assert.Equal(t, []plumbing.Hash{thirdSubTreeID}, missing)
+	})
+
+	t.Run("error identifies missing objects", func(t *testing.T) {
+		err := &MissingObjectsError{ObjectIDs: []plumbing.Hash{firstCommitID, thirdSubTreeID}}
+		assert.ErrorIs(t, err, ErrMissingObjects)
+		assert.Contains(t, err.Error(), firstCommitID.String())
+		assert.Contains(t, err.Error(), thirdSubTreeID.String())
+	})
+}
@@ -0,0 +1,179 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package gitinterface
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/go-git/go-billy/v5/helper/mount"
+	"github.com/go-git/go-billy/v5/helper/polyfill"
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-billy/v5/osfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/cache"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+	"github.com/go-git/go-git/v5/storage"
+	"github.com/go-git/go-git/v5/storage/filesystem"
+)
+
+var ErrInvalidReferenceUpdate = errors.New("invalid reference update")
+
+// ReferenceUpdate is a proposed update to a reference, such as one received by
+// Git's pre-receive hook. A zero NewID indicates that the reference is deleted,
+// and a zero OldID indicates that the reference is created.
+type ReferenceUpdate struct {
+	RefName string
+	OldID   plumbing.Hash
+	NewID   plumbing.Hash
+}
+
+// ParseReferenceUpdates parses reference updates in the format Git passes to
+// the pre-receive hook, i.e., "<old-oid> SP <new-oid> SP <ref-name> LF" for
+// each reference.
+func ParseReferenceUpdates(r io.Reader) ([]*ReferenceUpdate, error) {
+	updates := []*ReferenceUpdate{}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		split := strings.Fields(line)
+		if len(split) != 3 || !plumbing.IsHash(split[0]) || !plumbing.IsHash(split[1]) {
+			return nil, fmt.Errorf("%w: '%s'", ErrInvalidReferenceUpdate, line)
+		}
+
+		updates = append(updates, &ReferenceUpdate{
+			OldID:   plumbing.NewHash(split[0]),
+			NewID:   plumbing.NewHash(split[1]),
+			RefName: split[2],
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return updates, nil
+}
+
+// NewRepositoryWithProposedUpdates returns a read-only view of the repository
+// in which the specified updates have been applied to references and objects
+// are also read from objectDirs. This is used to inspect pushed changes before
+// they are accepted, such as in Git's pre-receive hook where new objects are
+// held in a quarantine directory. The original repository is not modified.
+func NewRepositoryWithProposedUpdates(repo *git.Repository, objectDirs []string, updates []*ReferenceUpdate) (*git.Repository, error) {
+	overlay := &proposedUpdatesStorage{
+		Storer:     repo.Storer,
+		references: map[plumbing.ReferenceName]plumbing.Hash{},
+	}
+
+	for _, objectDir := range objectDirs {
+		// go-git expects an objects directory within a Git directory, so we
+		// mount the specified directory accordingly
+		fs := polyfill.New(mount.New(memfs.New(), "objects", osfs.New(objectDir)))
+		overlay.objectStorers = append(overlay.objectStorers, filesystem.NewStorage(fs, cache.NewObjectLRUDefault()))
+	}
+
+	for _, update := range updates {
+		overlay.references[plumbing.ReferenceName(update.RefName)] = update.NewID
+	}
+
+	wt, err := repo.Worktree()
+	if err != nil {
+		if errors.Is(err, git.ErrIsBareRepository) {
+			return git.Open(overlay, nil)
+		}
+		return nil, err
+	}
+
+	return git.Open(overlay, wt.Filesystem)
+}
+
+// proposedUpdatesStorage wraps a repository's storage to include objects from
+// additional object directories and proposed reference updates.
+type proposedUpdatesStorage struct {
+	storage.Storer
+	objectStorers []storer.EncodedObjectStorer
+	references    map[plumbing.ReferenceName]plumbing.Hash
+}
+
+func (s *proposedUpdatesStorage) EncodedObject(objectType plumbing.ObjectType, objectID plumbing.Hash) (plumbing.EncodedObject, error) {
+	for _, objectStorer := range s.objectStorers {
+		object, err := objectStorer.EncodedObject(objectType, objectID)
+		if err == nil {
+			return object, nil
+		}
+		if !errors.Is(err, plumbing.ErrObjectNotFound) {
+			return nil, err
+		}
+	}
+
+	return s.Storer.EncodedObject(objectType, objectID)
+}
+
+func (s *proposedUpdatesStorage) HasEncodedObject(objectID plumbing.Hash) error {
+	for _, objectStorer := range s.objectStorers {
+		if err := objectStorer.HasEncodedObject(objectID); err == nil {
+			return nil
+		}
+	}
+
+	return s.Storer.HasEncodedObject(objectID)
+}
+
+func (s *proposedUpdatesStorage) EncodedObjectSize(objectID plumbing.Hash) (int64, error) {
+	for _, objectStorer := range s.objectStorers {
+		size, err := objectStorer.EncodedObjectSize(objectID)
+		if err == nil {
+			return size, nil
+		}
+		if !errors.Is(err, plumbing.ErrObjectNotFound) {
+			return 0, err
+		}
+	}
+
+	return s.Storer.EncodedObjectSize(objectID)
+}
+
+func (s *proposedUpdatesStorage) Reference(name plumbing.ReferenceName) (*plumbing.Reference, error) {
+	if newID, ok := s.references[name]; ok {
+		if newID.IsZero() {
+			return nil, plumbing.ErrReferenceNotFound
+		}
+		return plumbing.NewHashReference(name, newID), nil
+	}
+
+	return s.Storer.Reference(name)
+}
+
+func (s *proposedUpdatesStorage) IterReferences() (storer.ReferenceIter, error) {
+	iter, err := s.Storer.IterReferences()
+	if err != nil {
+		return nil, err
+	}
+
+	references := []*plumbing.Reference{}
+	if err := iter.ForEach(func(ref *plumbing.Reference) error {
+		if _, ok := s.references[ref.Name()]; !ok {
+			references = append(references, ref)
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	for name, newID := range s.references {
+		if !newID.IsZero() {
+			references = append(references, plumbing.NewHashReference(name, newID))
+		}
+	}
+
+	return storer.NewReferenceSliceIter(references), nil
+}
@@ -0,0 +1,126 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package gitinterface
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseReferenceUpdates(t *testing.T) {
+	oldID := "abcdef12345678900987654321fedcbaabcdef12"
+	newID := "1234567890abcdef1234567890abcdef12345678"
+
+	t.Run("valid input", func(t *testing.T) {
+		input := strings.Join([]string{
+			oldID + " " + newID + " refs/heads/main",
+			"",
+			plumbing.ZeroHash.String() + " " + newID + " refs/heads/feature",
+		}, "\n")
+
+		updates, err := ParseReferenceUpdates(strings.NewReader(input))
+		assert.Nil(t, err)
+		assert.Equal(t, []*ReferenceUpdate{
+			{RefName: "refs/heads/main", OldID: plumbing.NewHash(oldID), NewID: plumbing.NewHash(newID)},
+			{RefName: "refs/heads/feature", OldID: plumbing.ZeroHash, NewID: plumbing.NewHash(newID)},
+		}, updates)
+	})
+
+	t.Run("invalid input", func(t *testing.T) {
+		_, err := ParseReferenceUpdates(strings.NewReader(oldID + " refs/heads/main"))
+		assert.ErrorIs(t, err, ErrInvalidReferenceUpdate)
+
+		_, err = ParseReferenceUpdates(strings.NewReader("not-a-hash " + newID + " refs/heads/main"))
+		assert.ErrorIs(t, err, ErrInvalidReferenceUpdate)
+	})
+}
+
+func TestNewRepositoryWithProposedUpdates(t *testing.T) {
+	repo, err := git.PlainInit(t.TempDir(), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	emptyTreeHash, err := WriteTree(repo, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	existingCommitID, err := Commit(repo, emptyTreeHash, "refs/heads/main", "Existing commit", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Commit(repo, emptyTreeHash, "refs/heads/feature", "Feature commit", false); err != nil {
+		t.Fatal(err)
+	}
+
+	// The proposed objects are written to a separate repository, whose objects
+	// directory acts like a quarantine directory
+	quarantineDir := t.TempDir()
+	quarantineRepo, err := git.PlainInit(quarantineDir, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := WriteTree(quarantineRepo, nil); err != nil {
+		t.Fatal(err)
+	}
+	commit := CreateCommitObject(testGitConfig, emptyTreeHash, []plumbing.Hash{existingCommitID}, "Proposed commit", testClock)
+	proposedCommitID, err := WriteCommit(quarantineRepo, commit)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The proposed commit is only in the quarantine
+	_, err = GetCommit(repo, proposedCommitID)
+	assert.ErrorIs(t, err, plumbing.ErrObjectNotFound)
+
+	updates := []*ReferenceUpdate{
+		{RefName: "refs/heads/main", OldID: existingCommitID, NewID: proposedCommitID},
+		{RefName: "refs/heads/feature", NewID: plumbing.ZeroHash},
+	}
+	proposed, err := NewRepositoryWithProposedUpdates(repo, []string{filepath.Join(quarantineDir, "objects")}, updates)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tip, err := GetTip(proposed, "refs/heads/main")
+	assert.Nil(t, err)
+	assert.Equal(t, proposedCommitID, tip)
+
+	_, err = GetTip(proposed, "refs/heads/feature")
+	assert.ErrorIs(t, err, plumbing.ErrReferenceNotFound)
+
+	proposedCommit, err := GetCommit(proposed, proposedCommitID)
+	assert.Nil(t, err)
+	assert.Equal(t, "Proposed commit", proposedCommit.Message)
+
+	// Objects in the repository are still available
+	parentCommit, err := proposedCommit.Parent(0)
+	assert.Nil(t, err)
+	assert.Equal(t, existingCommitID, parentCommit.Hash)
+
+	refs, err := proposed.References()
+	if err != nil {
+		t.Fatal(err)
+	}
+	refNames := []string{}
+	if err := refs.ForEach(func(ref *plumbing.Reference) error {
+		refNames = append(refNames, ref.Name().String())
+		return nil
+	}); err != nil {
+		t.Fatal(err)
+	}
+	assert.Contains(t, refNames, "refs/heads/main")
+	assert.NotContains(t, refNames, "refs/heads/feature")
+
+	// The original repository is unchanged
+	tip, err = GetTip(repo, "refs/heads/main")
+	assert.Nil(t, err)
+	assert.Equal(t, existingCommitID, tip)
+	_, err = GetCommit(repo, proposedCommitID)
+	assert.ErrorIs(t, err, plumbing.ErrObjectNotFound)
+}
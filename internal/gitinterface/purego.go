@@ -0,0 +1,32 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package gitinterface
+
+import (
+	"os"
+	"os/exec"
+	"sync"
+)
+
+// NoGitBinaryKey is the environment variable that, when set to 1, prevents
+// gittuf from invoking the Git binary.
+const NoGitBinaryKey = "GITTUF_NO_GIT_BINARY"
+
+var gitBinaryInPath = sync.OnceValue(func() bool {
+	_, err := exec.LookPath(gitBinary)
+	return err == nil
+})
+
+// UseGitBinary indicates if the Git binary is used for the operations that
+// gittuf cannot otherwise perform faithfully using go-git, such as reading the
+// user's combined Git config. The binary is not used when gittuf is built with
+// the gittuf_purego tag, when NoGitBinaryKey is set, or when the binary cannot
+// be found. In these cases, go-git is used exclusively, enabling gittuf to run
+// in environments where Git is unavailable.
+func UseGitBinary() bool {
+	if pureGoBuild || os.Getenv(NoGitBinaryKey) == "1" {
+		return false
+	}
+
+	return gitBinaryInPath()
+}
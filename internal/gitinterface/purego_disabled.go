@@ -0,0 +1,7 @@
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !gittuf_purego
+
+package gitinterface
+
+const pureGoBuild = false
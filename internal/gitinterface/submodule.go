@@ -0,0 +1,151 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package gitinterface
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"path"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/cache"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/filesystem"
+)
+
+const gitModulesFile = ".gitmodules"
+
+var (
+	ErrSubmoduleNotConfigured = errors.New("submodule is not configured in .gitmodules")
+	ErrSubmoduleNotFound      = errors.New("submodule repository not found")
+)
+
+// GetSubmoduleUpdates returns the submodules added or updated by the commit
+// relative to its first parent, mapping the path of each submodule to the
+// commit it pins. If the commit has no parents, every submodule in its tree is
+// returned.
+func GetSubmoduleUpdates(repo *git.Repository, commit *object.Commit) (map[string]plumbing.Hash, error) {
+	submodules, err := getSubmodulesInTree(repo, commit.TreeHash)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(commit.ParentHashes) == 0 || len(submodules) == 0 {
+		return submodules, nil
+	}
+
+	parentCommit, err := GetCommit(repo, commit.ParentHashes[0])
+	if err != nil {
+		return nil, err
+	}
+
+	parentSubmodules, err := getSubmodulesInTree(repo, parentCommit.TreeHash)
+	if err != nil {
+		return nil, err
+	}
+
+	for submodulePath, commitID := range submodules {
+		if parentSubmodules[submodulePath] == commitID {
+			delete(submodules, submodulePath)
+		}
+	}
+
+	return submodules, nil
+}
+
+// GetSubmoduleConfig returns the configuration recorded in the commit's
+// .gitmodules file for the submodule at the specified path.
+func GetSubmoduleConfig(repo *git.Repository, commit *object.Commit, submodulePath string) (*config.Submodule, error) {
+	tree, err := GetTree(repo, commit.TreeHash)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := tree.File(gitModulesFile)
+	if err != nil {
+		if errors.Is(err, object.ErrFileNotFound) {
+			return nil, fmt.Errorf("%w: '%s'", ErrSubmoduleNotConfigured, submodulePath)
+		}
+		return nil, err
+	}
+
+	reader, err := file.Reader()
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close() //nolint:errcheck
+
+	contents, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+
+	modules := config.NewModules()
+	if err := modules.Unmarshal(contents); err != nil {
+		return nil, err
+	}
+
+	for _, submodule := range modules.Submodules {
+		if path.Clean(submodule.Path) == submodulePath {
+			return submodule, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: '%s'", ErrSubmoduleNotConfigured, submodulePath)
+}
+
+// GetSubmoduleRepository opens the repository of the submodule with the
+// specified name. The submodule's repository must have been initialized in the
+// Git directory of the superproject, as is done by `git submodule update
+// --init`.
+func GetSubmoduleRepository(repo *git.Repository, name string) (*git.Repository, error) {
+	fsStorage, isFilesystem := repo.Storer.(*filesystem.Storage)
+	if !isFilesystem {
+		return nil, fmt.Errorf("%w: '%s'", ErrSubmoduleNotFound, name)
+	}
+
+	submoduleDir := path.Join("modules", name)
+	if _, err := fsStorage.Filesystem().Stat(submoduleDir); err != nil {
+		return nil, fmt.Errorf("%w: '%s'", ErrSubmoduleNotFound, name)
+	}
+
+	submoduleFS, err := fsStorage.Filesystem().Chroot(submoduleDir)
+	if err != nil {
+		return nil, err
+	}
+
+	return git.Open(filesystem.NewStorage(submoduleFS, cache.NewObjectLRUDefault()), nil)
+}
+
+// getSubmodulesInTree returns the path of each submodule in the tree mapped to
+// the commit it pins.
+func getSubmodulesInTree(repo *git.Repository, treeID plumbing.Hash) (map[string]plumbing.Hash, error) {
+	tree, err := GetTree(repo, treeID)
+	if err != nil {
+		return nil, err
+	}
+
+	treeWalker := object.NewTreeWalker(tree, true, nil)
+	defer treeWalker.Close()
+
+	submodules := map[string]plumbing.Hash{}
+	for {
+		name, entry, err := treeWalker.Next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, err
+		}
+
+		if entry.Mode == filemode.Submodule {
+			submodules[name] = entry.Hash
+		}
+	}
+
+	return submodules, nil
+}
@@ -0,0 +1,167 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package gitinterface
+
+import (
+	"testing"
+
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/cache"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/filesystem"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetSubmoduleUpdates(t *testing.T) {
+	clock = testClock
+	getGitConfig = func(_ *git.Repository) (*config.Config, error) {
+		return testGitConfig, nil
+	}
+
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	refName := "refs/heads/main"
+	libCommitID := plumbing.NewHash("b5c3b5b5b1f8a54f3e2fb1d2b3cf3b8bd7aa1b2a")
+	otherCommitID := plumbing.NewHash("c6d4c6c6c2f9b65f4f3fc2e3c4df4c9ce8bb2c3b")
+
+	createCommit := func(submodules map[string]plumbing.Hash) *object.Commit {
+		entries := []object.TreeEntry{}
+		for name, commitID := range submodules {
+			entries = append(entries, object.TreeEntry{Name: name, Mode: filemode.Submodule, Hash: commitID})
+		}
+		vendorTreeID, err := WriteTree(repo, entries)
+		if err != nil {
+			t.Fatal(err)
+		}
+		treeID, err := WriteTree(repo, []object.TreeEntry{{Name: "vendor", Mode: filemode.Dir, Hash: vendorTreeID}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		commitID, err := Commit(repo, treeID, refName, "Test commit", false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		commit, err := GetCommit(repo, commitID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return commit
+	}
+
+	commit := createCommit(map[string]plumbing.Hash{"lib": libCommitID})
+	updates, err := GetSubmoduleUpdates(repo, commit)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]plumbing.Hash{"vendor/lib": libCommitID}, updates)
+
+	// Unchanged submodules are not included
+	commit = createCommit(map[string]plumbing.Hash{"lib": libCommitID, "other": otherCommitID})
+	updates, err = GetSubmoduleUpdates(repo, commit)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]plumbing.Hash{"vendor/other": otherCommitID}, updates)
+
+	commit = createCommit(map[string]plumbing.Hash{"lib": otherCommitID, "other": otherCommitID})
+	updates, err = GetSubmoduleUpdates(repo, commit)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]plumbing.Hash{"vendor/lib": otherCommitID}, updates)
+
+	// Removed submodules are not included
+	commit = createCommit(map[string]plumbing.Hash{"lib": otherCommitID})
+	updates, err = GetSubmoduleUpdates(repo, commit)
+	assert.Nil(t, err)
+	assert.Empty(t, updates)
+}
+
+func TestGetSubmoduleConfig(t *testing.T) {
+	clock = testClock
+	getGitConfig = func(_ *git.Repository) (*config.Config, error) {
+		return testGitConfig, nil
+	}
+
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	createCommit := func(entries []object.TreeEntry) *object.Commit {
+		treeID, err := WriteTree(repo, entries)
+		if err != nil {
+			t.Fatal(err)
+		}
+		commitID, err := Commit(repo, treeID, "refs/heads/main", "Test commit", false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		commit, err := GetCommit(repo, commitID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return commit
+	}
+
+	commit := createCommit(nil)
+	_, err = GetSubmoduleConfig(repo, commit, "vendor/lib")
+	assert.ErrorIs(t, err, ErrSubmoduleNotConfigured)
+
+	gitModulesID, err := WriteBlob(repo, []byte("[submodule \"lib\"]\n\tpath = vendor/lib\n\turl = https://github.com/gittuf/lib\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	commit = createCommit([]object.TreeEntry{{Name: ".gitmodules", Mode: filemode.Regular, Hash: gitModulesID}})
+
+	submoduleConfig, err := GetSubmoduleConfig(repo, commit, "vendor/lib")
+	assert.Nil(t, err)
+	assert.Equal(t, "lib", submoduleConfig.Name)
+	assert.Equal(t, "https://github.com/gittuf/lib", submoduleConfig.URL)
+
+	_, err = GetSubmoduleConfig(repo, commit, "vendor/other")
+	assert.ErrorIs(t, err, ErrSubmoduleNotConfigured)
+}
+
+func TestGetSubmoduleRepository(t *testing.T) {
+	t.Run("repository in memory", func(t *testing.T) {
+		repo, err := git.Init(memory.NewStorage(), memfs.New())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = GetSubmoduleRepository(repo, "lib")
+		assert.ErrorIs(t, err, ErrSubmoduleNotFound)
+	})
+
+	t.Run("repository on filesystem", func(t *testing.T) {
+		gitDir := memfs.New()
+		repo, err := git.Init(filesystem.NewStorage(gitDir, cache.NewObjectLRUDefault()), memfs.New())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = GetSubmoduleRepository(repo, "lib")
+		assert.ErrorIs(t, err, ErrSubmoduleNotFound)
+
+		submoduleDir, err := gitDir.Chroot("modules/lib")
+		if err != nil {
+			t.Fatal(err)
+		}
+		submoduleRepo, err := git.Init(filesystem.NewStorage(submoduleDir, cache.NewObjectLRUDefault()), nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		blobID, err := WriteBlob(submoduleRepo, []byte("gittuf"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		submoduleRepo, err = GetSubmoduleRepository(repo, "lib")
+		assert.Nil(t, err)
+		_, err = submoduleRepo.BlobObject(blobID)
+		assert.Nil(t, err)
+	})
+}
@@ -109,6 +109,34 @@ func Fetch(ctx context.Context, repo *git.Repository, remoteName string, refs []
 	return FetchRefSpec(ctx, repo, remoteName, refSpecs)
 }
 
+// ListRemoteRefs returns the tips of the references advertised by the
+// specified remote. Symbolic references such as HEAD are not included. If the
+// remote is empty, no references are returned.
+func ListRemoteRefs(ctx context.Context, repo *git.Repository, remoteName string) (map[string]plumbing.Hash, error) {
+	remote, err := repo.Remote(remoteName)
+	if err != nil {
+		return nil, err
+	}
+
+	refs, err := remote.ListContext(ctx, &git.ListOptions{})
+	if err != nil {
+		if errors.Is(err, transport.ErrEmptyRemoteRepository) {
+			return map[string]plumbing.Hash{}, nil
+		}
+		return nil, err
+	}
+
+	tips := map[string]plumbing.Hash{}
+	for _, ref := range refs {
+		if ref.Type() != plumbing.HashReference {
+			continue
+		}
+		tips[ref.Name().String()] = ref.Hash()
+	}
+
+	return tips, nil
+}
+
 // CloneAndFetch clones a repository using the specified URL and additionally
 // fetches the specified refs.
 func CloneAndFetch(ctx context.Context, remoteURL, dir, initialBranch string, refs []string) (*git.Repository, error) {
@@ -131,6 +159,21 @@ func CloneAndFetchToMemory(ctx context.Context, remoteURL, initialBranch string,
 	return fetchRefs(ctx, repo, refs, true)
 }
 
+// FetchToMemory fetches only the specified refs from the repository at the
+// specified URL into a new in-memory repository.
+func FetchToMemory(ctx context.Context, remoteURL string, refs []string) (*git.Repository, error) {
+	repo, err := git.Init(memory.NewStorage(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := repo.CreateRemote(&config.RemoteConfig{Name: DefaultRemoteName, URLs: []string{remoteURL}}); err != nil {
+		return nil, err
+	}
+
+	return fetchRefs(ctx, repo, refs, true)
+}
+
 func createCloneOptions(remoteURL, initialBranch string) *git.CloneOptions {
 	cloneOptions := &git.CloneOptions{
 		URL:      remoteURL,
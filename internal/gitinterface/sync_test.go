@@ -526,6 +526,50 @@ func TestFetch(t *testing.T) {
 	})
 }
 
+func TestListRemoteRefs(t *testing.T) {
+	remoteName := "origin"
+	refName := "refs/heads/main"
+
+	repoLocal, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tmpDir := t.TempDir()
+	repoRemote, err := git.PlainInit(tmpDir, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = repoLocal.CreateRemote(&config.RemoteConfig{
+		Name: remoteName,
+		URLs: []string{tmpDir},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	refs, err := ListRemoteRefs(context.Background(), repoLocal, remoteName)
+	assert.Nil(t, err)
+	assert.Empty(t, refs)
+
+	emptyTreeHash, err := WriteTree(repoRemote, []object.TreeEntry{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	commitID, err := Commit(repoRemote, emptyTreeHash, refName, "Test commit", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	refs, err = ListRemoteRefs(context.Background(), repoLocal, remoteName)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]plumbing.Hash{refName: commitID}, refs)
+
+	_, err = ListRemoteRefs(context.Background(), repoLocal, "unknown")
+	assert.ErrorIs(t, err, git.ErrRemoteNotFound)
+}
+
 func TestCloneAndFetch(t *testing.T) {
 	refName := "refs/heads/main"
 	anotherRefName := "refs/heads/feature"
@@ -793,3 +837,38 @@ func assertLocalRefAndRemoteTrackerRef(t *testing.T, repo *git.Repository, refNa
 	}
 	assert.Equal(t, expectedCommitID, localRemoteTrackerRef.Hash())
 }
+
+func TestFetchToMemory(t *testing.T) {
+	refName := "refs/heads/main"
+	anotherRefName := "refs/heads/feature"
+
+	remoteTmpDir := t.TempDir()
+	remoteRepo, err := git.PlainInit(remoteTmpDir, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	emptyTreeHash, err := WriteTree(remoteRepo, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Commit(remoteRepo, emptyTreeHash, refName, "Commit to main", false); err != nil {
+		t.Fatal(err)
+	}
+	otherCommitID, err := Commit(remoteRepo, emptyTreeHash, anotherRefName, "Commit to feature", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	localRepo, err := FetchToMemory(context.Background(), remoteTmpDir, []string{anotherRefName})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	localOtherCommitID, err := localRepo.ResolveRevision(plumbing.Revision(anotherRefName))
+	assert.Nil(t, err)
+	assert.Equal(t, otherCommitID, *localOtherCommitID)
+
+	_, err = localRepo.Reference(plumbing.ReferenceName(refName), true)
+	assert.ErrorIs(t, err, plumbing.ErrReferenceNotFound)
+}
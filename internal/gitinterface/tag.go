@@ -7,8 +7,12 @@ import (
 	"errors"
 	"io"
 	"strings"
+	"time"
 
 	"github.com/gittuf/gittuf/internal/signerverifier"
+	"github.com/gittuf/gittuf/internal/signerverifier/sigstore"
+	"github.com/gittuf/gittuf/internal/signerverifier/sshca"
+	"github.com/gittuf/gittuf/internal/signerverifier/x509"
 	"github.com/gittuf/gittuf/internal/tuf"
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/config"
@@ -132,7 +136,32 @@ func VerifyTagSignature(ctx context.Context, tag *object.Tag, key *tuf.Key) erro
 		}
 		tagSignature := []byte(tag.PGPSignature)
 
-		if err := verifyGitsignSignature(ctx, key, tagContents, tagSignature); err != nil {
+		if err := sigstore.VerifyGitSignature(ctx, key, tagContents, tagSignature); err != nil {
+			return errors.Join(ErrIncorrectVerificationKey, err)
+		}
+
+		return nil
+	case signerverifier.X509KeyType:
+		tagContents, err := getTagBytesWithoutSignature(tag)
+		if err != nil {
+			return errors.Join(ErrVerifyingX509Signature, err)
+		}
+		tagSignature := []byte(tag.PGPSignature)
+
+		if err := x509.VerifySignature(key, tagContents, tagSignature); err != nil {
+			return errors.Join(ErrIncorrectVerificationKey, err)
+		}
+
+		return nil
+	case signerverifier.SSHCAKeyType:
+		tagContents, err := getTagBytesWithoutSignature(tag)
+		if err != nil {
+			return errors.Join(ErrVerifyingSSHSignature, err)
+		}
+		tagSignature := []byte(tag.PGPSignature)
+
+		// The certificate must be valid when the signature is verified
+		if err := sshca.VerifySignature(key, tagContents, tagSignature, time.Now()); err != nil {
 			return errors.Join(ErrIncorrectVerificationKey, err)
 		}
 
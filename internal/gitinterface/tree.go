@@ -3,9 +3,10 @@
 package gitinterface
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"io"
-	"os/exec"
 	"path"
 	"sort"
 	"strings"
@@ -18,6 +19,8 @@ import (
 	"github.com/go-git/go-git/v5/storage/memory"
 )
 
+var ErrMergeConflict = errors.New("unable to merge commits due to conflict")
+
 // WriteTree creates a Git tree with the specified entries. It sorts the entries
 // prior to creating the tree.
 func WriteTree(repo *git.Repository, entries []object.TreeEntry) (plumbing.Hash, error) {
@@ -79,13 +82,24 @@ func GetAllFilesInTree(tree *object.Tree) (map[string]plumbing.Hash, error) {
 	return files, nil
 }
 
-func GetMergeTree(_ *git.Repository, commitAID, commitBID string) (string, error) {
+// GetMergeTree returns the ID of the tree created by merging the two commits.
+// The merge is computed by the Git binary, which is killed if ctx is done. When
+// the Git binary is not used, the merge is computed using go-git, see
+// getMergeTreeUsingGoGit.
+func GetMergeTree(ctx context.Context, repo *git.Repository, commitAID, commitBID string) (string, error) {
 	if !dev.InDevMode() {
 		return "", dev.ErrNotInDevMode
 	}
 
-	command := exec.Command("git", "merge-tree", commitAID, commitBID) //nolint:gosec
-	stdOut, err := command.Output()
+	if !UseGitBinary() {
+		treeID, err := getMergeTreeUsingGoGit(repo, plumbing.NewHash(commitAID), plumbing.NewHash(commitBID))
+		if err != nil {
+			return "", err
+		}
+		return treeID.String(), nil
+	}
+
+	stdOut, err := newGitExecutor(ctx, "merge-tree", commitAID, commitBID).execute()
 	if err != nil {
 		return "", err
 	}
@@ -94,6 +108,134 @@ func GetMergeTree(_ *git.Repository, commitAID, commitBID string) (string, error
 	return stdOutString, nil
 }
 
+// getMergeTreeUsingGoGit returns the ID of the tree created by merging the
+// two commits relative to their merge base. Unlike Git, it does not merge
+// changes made to the same file on both sides, which are reported as conflicts.
+// If commitA is the zero hash, the tree of commitB is returned.
+func getMergeTreeUsingGoGit(repo *git.Repository, commitAID, commitBID plumbing.Hash) (plumbing.Hash, error) {
+	commitB, err := GetCommit(repo, commitBID)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	if commitAID.IsZero() {
+		return commitB.TreeHash, nil
+	}
+
+	commitA, err := GetCommit(repo, commitAID)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	mergeBases, err := commitA.MergeBase(commitB)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	baseTree := &object.Tree{}
+	switch len(mergeBases) {
+	case 0:
+		// Unrelated histories are merged relative to an empty tree
+	case 1:
+		baseTree, err = mergeBases[0].Tree()
+		if err != nil {
+			return plumbing.ZeroHash, err
+		}
+	default:
+		return plumbing.ZeroHash, fmt.Errorf("%w: commits have multiple merge bases", ErrMergeConflict)
+	}
+
+	treeA, err := commitA.Tree()
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	treeB, err := commitB.Tree()
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	return mergeTrees(repo, baseTree, treeA, treeB, "")
+}
+
+// mergeTrees performs a three-way merge of the entries in trees A and B
+// relative to the base tree, recursing into subtrees modified on both sides.
+func mergeTrees(repo *git.Repository, baseTree, treeA, treeB *object.Tree, parent string) (plumbing.Hash, error) {
+	baseEntries := getTreeEntries(baseTree)
+	entriesA := getTreeEntries(treeA)
+	entriesB := getTreeEntries(treeB)
+
+	names := map[string]bool{}
+	for _, entries := range []map[string]object.TreeEntry{baseEntries, entriesA, entriesB} {
+		for name := range entries {
+			names[name] = true
+		}
+	}
+
+	mergedEntries := []object.TreeEntry{}
+	for name := range names {
+		baseEntry, inBase := baseEntries[name]
+		entryA, inA := entriesA[name]
+		entryB, inB := entriesB[name]
+
+		var (
+			merged   object.TreeEntry
+			inMerged bool
+		)
+		switch {
+		case inA == inB && entryA == entryB:
+			// Unchanged, or changed identically on both sides
+			merged, inMerged = entryA, inA
+		case inBase == inA && baseEntry == entryA:
+			// Changed only in B
+			merged, inMerged = entryB, inB
+		case inBase == inB && baseEntry == entryB:
+			// Changed only in A
+			merged, inMerged = entryA, inA
+		case inA && inB && entryA.Mode == filemode.Dir && entryB.Mode == filemode.Dir:
+			subtreeBase := &object.Tree{}
+			if inBase && baseEntry.Mode == filemode.Dir {
+				subtree, err := GetTree(repo, baseEntry.Hash)
+				if err != nil {
+					return plumbing.ZeroHash, err
+				}
+				subtreeBase = subtree
+			}
+			subtreeA, err := GetTree(repo, entryA.Hash)
+			if err != nil {
+				return plumbing.ZeroHash, err
+			}
+			subtreeB, err := GetTree(repo, entryB.Hash)
+			if err != nil {
+				return plumbing.ZeroHash, err
+			}
+
+			subtreeID, err := mergeTrees(repo, subtreeBase, subtreeA, subtreeB, path.Join(parent, name))
+			if err != nil {
+				return plumbing.ZeroHash, err
+			}
+			merged = object.TreeEntry{Name: name, Mode: filemode.Dir, Hash: subtreeID}
+			inMerged = subtreeID != EmptyTree()
+		default:
+			return plumbing.ZeroHash, fmt.Errorf("%w: '%s' changed on both sides", ErrMergeConflict, path.Join(parent, name))
+		}
+
+		if inMerged {
+			mergedEntries = append(mergedEntries, merged)
+		}
+	}
+
+	return WriteTree(repo, mergedEntries)
+}
+
+// getTreeEntries returns the entries of the tree indexed by their names.
+func getTreeEntries(tree *object.Tree) map[string]object.TreeEntry {
+	entries := make(map[string]object.TreeEntry, len(tree.Entries))
+	for _, entry := range tree.Entries {
+		entries[entry.Name] = entry
+	}
+	return entries
+}
+
 // TreeBuilder is used to create multi-level trees in a repository.
 // Based on `buildTreeHelper` in go-git.
 type TreeBuilder struct {
@@ -3,10 +3,13 @@
 package gitinterface
 
 import (
+	"context"
 	"testing"
 
+	"github.com/gittuf/gittuf/internal/dev"
 	"github.com/go-git/go-billy/v5/memfs"
 	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/filemode"
 	"github.com/go-git/go-git/v5/plumbing/object"
@@ -267,3 +270,74 @@ func TestTreeBuilder(t *testing.T) {
 		assert.Equal(t, blobB, entryB.Hash)
 	})
 }
+
+func TestGetMergeTreeWithoutGitBinary(t *testing.T) {
+	t.Setenv(dev.DevModeKey, "1")
+	t.Setenv(NoGitBinaryKey, "1")
+
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	clock = testClock
+	getGitConfig = func(_ *git.Repository) (*config.Config, error) {
+		return testGitConfig, nil
+	}
+
+	blobIDs := map[string]plumbing.Hash{}
+	for _, contents := range []string{"a", "b", "c", "d"} {
+		blobID, err := WriteBlob(repo, []byte(contents))
+		if err != nil {
+			t.Fatal(err)
+		}
+		blobIDs[contents] = blobID
+	}
+
+	createCommit := func(refName string, parentID plumbing.Hash, files map[string]plumbing.Hash) plumbing.Hash {
+		if err := repo.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(refName), parentID)); err != nil {
+			t.Fatal(err)
+		}
+		treeID, err := NewTreeBuilder(repo).WriteRootTreeFromBlobIDs(files)
+		if err != nil {
+			t.Fatal(err)
+		}
+		commitID, err := Commit(repo, treeID, refName, "Test commit", false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return commitID
+	}
+
+	baseCommitID := createCommit("refs/heads/main", plumbing.ZeroHash, map[string]plumbing.Hash{"file": blobIDs["a"], "dir/file": blobIDs["a"]})
+	commitAID := createCommit("refs/heads/a", baseCommitID, map[string]plumbing.Hash{"file": blobIDs["b"], "dir/file": blobIDs["a"]})
+	commitBID := createCommit("refs/heads/b", baseCommitID, map[string]plumbing.Hash{"file": blobIDs["a"], "dir/file": blobIDs["a"], "dir/new-file": blobIDs["c"]})
+	conflictingCommitID := createCommit("refs/heads/c", baseCommitID, map[string]plumbing.Hash{"file": blobIDs["d"], "dir/file": blobIDs["a"]})
+
+	t.Run("merge changes from both sides", func(t *testing.T) {
+		expectedTreeID, err := NewTreeBuilder(repo).WriteRootTreeFromBlobIDs(map[string]plumbing.Hash{"file": blobIDs["b"], "dir/file": blobIDs["a"], "dir/new-file": blobIDs["c"]})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		treeID, err := GetMergeTree(context.Background(), repo, commitAID.String(), commitBID.String())
+		assert.Nil(t, err)
+		assert.Equal(t, expectedTreeID.String(), treeID)
+	})
+
+	t.Run("merge into zero commit", func(t *testing.T) {
+		commitB, err := GetCommit(repo, commitBID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		treeID, err := GetMergeTree(context.Background(), repo, plumbing.ZeroHash.String(), commitBID.String())
+		assert.Nil(t, err)
+		assert.Equal(t, commitB.TreeHash.String(), treeID)
+	})
+
+	t.Run("conflicting changes", func(t *testing.T) {
+		_, err := GetMergeTree(context.Background(), repo, commitAID.String(), conflictingCommitID.String())
+		assert.ErrorIs(t, err, ErrMergeConflict)
+	})
+}
@@ -11,6 +11,8 @@ import (
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/cache"
+	"github.com/go-git/go-git/v5/storage/filesystem"
 	"github.com/jonboulle/clockwork"
 )
 
@@ -37,16 +39,43 @@ func GetTip(repo *git.Repository, refName string) (plumbing.Hash, error) {
 	return ref.Hash(), nil
 }
 
+// NewRepositoryHandle returns a new handle for the repository that has its own
+// object cache. go-git's on-disk storage is not safe for concurrent use, so
+// each goroutine reading from the repository must use a separate handle.
+// Repositories that are not stored on disk are returned as is.
+func NewRepositoryHandle(repo *git.Repository) (*git.Repository, error) {
+	fsStorage, isFilesystem := repo.Storer.(*filesystem.Storage)
+	if !isFilesystem {
+		return repo, nil
+	}
+
+	storage := filesystem.NewStorage(fsStorage.Filesystem(), cache.NewObjectLRUDefault())
+
+	wt, err := repo.Worktree()
+	if err != nil {
+		if errors.Is(err, git.ErrIsBareRepository) {
+			return git.Open(storage, nil)
+		}
+		return nil, err
+	}
+
+	return git.Open(storage, wt.Filesystem)
+}
+
 // ResetCommit sets a Git reference with the name refName to the commit
 // specified by its hash as commitID. Note that the commit must already be in
-// the repository's object store.
+// the repository's object store. In bare repositories, the reference is set
+// directly as there is no worktree to update.
 func ResetCommit(repo *git.Repository, refName string, commitID plumbing.Hash) error {
-	currentHEAD, err := repo.Head()
+	wt, err := repo.Worktree()
 	if err != nil {
+		if errors.Is(err, git.ErrIsBareRepository) {
+			return repo.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(refName), commitID))
+		}
 		return err
 	}
 
-	wt, err := repo.Worktree()
+	currentHEAD, err := repo.Head()
 	if err != nil {
 		return err
 	}
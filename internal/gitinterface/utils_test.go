@@ -14,6 +14,77 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+func TestNewRepositoryHandle(t *testing.T) {
+	t.Run("on-disk repository", func(t *testing.T) {
+		repo, err := git.PlainInit(t.TempDir(), true)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		emptyTreeHash, err := WriteTree(repo, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		commitID, err := Commit(repo, emptyTreeHash, "refs/heads/main", "Test Commit", false)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		handle, err := NewRepositoryHandle(repo)
+		assert.Nil(t, err)
+		assert.NotSame(t, repo, handle)
+		assert.NotSame(t, repo.Storer, handle.Storer)
+
+		tip, err := GetTip(handle, "refs/heads/main")
+		assert.Nil(t, err)
+		assert.Equal(t, commitID, tip)
+
+		commit, err := GetCommit(handle, commitID)
+		assert.Nil(t, err)
+		assert.Equal(t, "Test Commit", commit.Message)
+	})
+
+	t.Run("in-memory repository", func(t *testing.T) {
+		repo, err := git.Init(memory.NewStorage(), memfs.New())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		handle, err := NewRepositoryHandle(repo)
+		assert.Nil(t, err)
+		assert.Same(t, repo, handle)
+	})
+}
+
+func TestResetCommit(t *testing.T) {
+	t.Run("bare repository", func(t *testing.T) {
+		repo, err := git.PlainInit(t.TempDir(), true)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		refName := "refs/gittuf/test"
+		emptyTreeHash, err := WriteTree(repo, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		firstCommitID, err := Commit(repo, emptyTreeHash, refName, "First commit", false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := Commit(repo, emptyTreeHash, refName, "Second commit", false); err != nil {
+			t.Fatal(err)
+		}
+
+		err = ResetCommit(repo, refName, firstCommitID)
+		assert.Nil(t, err)
+
+		tip, err := GetTip(repo, refName)
+		assert.Nil(t, err)
+		assert.Equal(t, firstCommitID, tip)
+	})
+}
+
 func TestRefSpec(t *testing.T) {
 	repo, err := git.Init(memory.NewStorage(), memfs.New())
 	if err != nil {
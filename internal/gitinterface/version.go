@@ -0,0 +1,82 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package gitinterface
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// MinimumGitVersion is the earliest version of Git that supports all the
+// signing methods used with gittuf. Git added support for SSH signatures in
+// 2.34.0.
+const MinimumGitVersion = "2.34.0"
+
+var ErrUnknownGitVersion = errors.New("unable to parse Git version")
+
+// GetGitVersion returns the version of the Git binary, such as "2.43.0".
+func GetGitVersion(ctx context.Context) (string, error) {
+	stdout, err := newGitExecutor(ctx, "--version").execute()
+	if err != nil {
+		return "", err
+	}
+
+	// The output is of the form "git version 2.43.0", optionally followed by
+	// platform information such as " (Apple Git-146)"
+	fields := strings.Fields(string(stdout))
+	if len(fields) < 3 || fields[0] != "git" || fields[1] != "version" {
+		return "", ErrUnknownGitVersion
+	}
+
+	return fields[2], nil
+}
+
+// IsGitVersionAtLeast indicates if the specified Git version is the same as or
+// later than minimum. Only the numeric major, minor, and patch components are
+// compared, so suffixes such as ".windows.1" are ignored.
+func IsGitVersionAtLeast(version, minimum string) (bool, error) {
+	versionComponents, err := parseGitVersion(version)
+	if err != nil {
+		return false, err
+	}
+	minimumComponents, err := parseGitVersion(minimum)
+	if err != nil {
+		return false, err
+	}
+
+	for i := range versionComponents {
+		if versionComponents[i] != minimumComponents[i] {
+			return versionComponents[i] > minimumComponents[i], nil
+		}
+	}
+
+	return true, nil
+}
+
+func parseGitVersion(version string) ([3]int, error) {
+	components := [3]int{}
+
+	parts := strings.Split(version, ".")
+	if len(parts) < 2 {
+		return components, fmt.Errorf("%w: '%s'", ErrUnknownGitVersion, version)
+	}
+
+	for i := 0; i < len(components) && i < len(parts); i++ {
+		// Release candidates are of the form "2.44.0-rc1"
+		part, _, _ := strings.Cut(parts[i], "-")
+		component, err := strconv.Atoi(part)
+		if err != nil {
+			if i == 2 {
+				// Some builds do not include a numeric patch version
+				break
+			}
+			return components, fmt.Errorf("%w: '%s'", ErrUnknownGitVersion, version)
+		}
+		components[i] = component
+	}
+
+	return components, nil
+}
@@ -0,0 +1,53 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package gitinterface
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetGitVersion(t *testing.T) {
+	if !UseGitBinary() {
+		t.Skip("Git binary is not used")
+	}
+
+	version, err := GetGitVersion(context.Background())
+	assert.Nil(t, err)
+
+	_, err = IsGitVersionAtLeast(version, MinimumGitVersion)
+	assert.Nil(t, err)
+}
+
+func TestIsGitVersionAtLeast(t *testing.T) {
+	tests := map[string]struct {
+		version  string
+		expected bool
+		err      error
+	}{
+		"same version":         {version: "2.34.0", expected: true},
+		"later patch version":  {version: "2.34.1", expected: true},
+		"later minor version":  {version: "2.43.0", expected: true},
+		"later major version":  {version: "3.0.0", expected: true},
+		"earlier version":      {version: "2.25.1", expected: false},
+		"platform suffix":      {version: "2.45.2.windows.1", expected: true},
+		"release candidate":    {version: "2.44.0-rc1", expected: true},
+		"no patch version":     {version: "2.40", expected: true},
+		"unparseable version":  {version: "unknown", err: ErrUnknownGitVersion},
+		"non-numeric versions": {version: "a.b.c", err: ErrUnknownGitVersion},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			atLeast, err := IsGitVersionAtLeast(test.version, MinimumGitVersion)
+			if test.err != nil {
+				assert.ErrorIs(t, err, test.err)
+				return
+			}
+			assert.Nil(t, err)
+			assert.Equal(t, test.expected, atLeast)
+		})
+	}
+}
@@ -0,0 +1,178 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Package gitlab implements the subset of the GitLab REST API used by gittuf
+// to record merge request approvals, along with the key type used to authorize
+// GitLab identities in gittuf policy.
+package gitlab
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	sslibsv "github.com/gittuf/gittuf/internal/third_party/go-securesystemslib/signerverifier"
+	"github.com/gittuf/gittuf/internal/tuf"
+)
+
+const (
+	DefaultBaseURL = "https://gitlab.com/api/v4"
+
+	// IdentityKeyType is the key type used to authorize a GitLab user in
+	// gittuf policy. Such keys cannot be used to verify signatures, instead
+	// they're met by approvals recorded in GitLab merge request approval
+	// attestations.
+	IdentityKeyType   = "gitlab-identity"
+	IdentityKeyScheme = "gitlab"
+
+	mergeRequestStateMerged = "merged"
+)
+
+var (
+	ErrMergeRequestNotMerged = errors.New("merge request has not been merged")
+	ErrUnexpectedResponse    = errors.New("unexpected response from GitLab API")
+)
+
+// IdentityKeyID returns the key ID for the GitLab user with the specified
+// username.
+func IdentityKeyID(username string) string {
+	return fmt.Sprintf("gitlab:%s", username)
+}
+
+// NewIdentityKey returns a gittuf key for the GitLab user with the specified
+// username.
+func NewIdentityKey(username string) *tuf.Key {
+	return &tuf.Key{
+		KeyID:   IdentityKeyID(username),
+		KeyType: IdentityKeyType,
+		Scheme:  IdentityKeyScheme,
+		KeyVal: sslibsv.KeyVal{
+			Identity: username,
+			Issuer:   "https://gitlab.com",
+		},
+	}
+}
+
+// MergeRequest contains the details of a GitLab merge request that are
+// relevant to gittuf.
+type MergeRequest struct {
+	IID          int
+	WebURL       string
+	TargetBranch string
+	HeadSHA      string
+	Merged       bool
+
+	// MergedCommitSHA is the commit the target branch was updated to when
+	// the merge request was merged. This is the merge commit or the squash
+	// commit if one was created, and the merge request's head otherwise.
+	MergedCommitSHA string
+}
+
+// Client is a minimal GitLab REST API client.
+type Client struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+}
+
+// NewClient returns a client for the GitLab API at baseURL, which is
+// DefaultBaseURL for gitlab.com. If token is set, it is used to authenticate
+// requests.
+func NewClient(baseURL, token string) *Client {
+	return &Client{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		token:      token,
+		httpClient: http.DefaultClient,
+	}
+}
+
+// GetMergeRequest returns the specified merge request. The project is
+// identified by its full path, such as "gittuf/gittuf".
+func (c *Client) GetMergeRequest(ctx context.Context, project string, iid int) (*MergeRequest, error) {
+	response := struct {
+		IID             int    `json:"iid"`
+		WebURL          string `json:"web_url"`
+		TargetBranch    string `json:"target_branch"`
+		SHA             string `json:"sha"`
+		State           string `json:"state"`
+		MergeCommitSHA  string `json:"merge_commit_sha"`
+		SquashCommitSHA string `json:"squash_commit_sha"`
+	}{}
+
+	if err := c.get(ctx, fmt.Sprintf("/projects/%s/merge_requests/%d", url.PathEscape(project), iid), &response); err != nil {
+		return nil, err
+	}
+
+	mergedCommitSHA := response.MergeCommitSHA
+	if mergedCommitSHA == "" {
+		mergedCommitSHA = response.SquashCommitSHA
+	}
+	if mergedCommitSHA == "" {
+		// Fast-forward merge
+		mergedCommitSHA = response.SHA
+	}
+
+	mergeRequest := &MergeRequest{
+		IID:          response.IID,
+		WebURL:       response.WebURL,
+		TargetBranch: response.TargetBranch,
+		HeadSHA:      response.SHA,
+		Merged:       response.State == mergeRequestStateMerged,
+	}
+	if mergeRequest.Merged {
+		mergeRequest.MergedCommitSHA = mergedCommitSHA
+	}
+
+	return mergeRequest, nil
+}
+
+// GetApprovers returns the usernames of the users who currently approve the
+// merge request. GitLab tracks approvals for the merge request's latest
+// changes, so approvals that were reset by new commits are not included.
+func (c *Client) GetApprovers(ctx context.Context, project string, iid int) ([]string, error) {
+	response := struct {
+		ApprovedBy []struct {
+			User struct {
+				Username string `json:"username"`
+			} `json:"user"`
+		} `json:"approved_by"`
+	}{}
+
+	if err := c.get(ctx, fmt.Sprintf("/projects/%s/merge_requests/%d/approvals", url.PathEscape(project), iid), &response); err != nil {
+		return nil, err
+	}
+
+	approvers := []string{}
+	for _, approval := range response.ApprovedBy {
+		approvers = append(approvers, approval.User.Username)
+	}
+
+	return approvers, nil
+}
+
+func (c *Client) get(ctx context.Context, path string, response any) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+path, nil)
+	if err != nil {
+		return err
+	}
+	if c.token != "" {
+		request.Header.Set("PRIVATE-TOKEN", c.token)
+	}
+
+	httpResponse, err := c.httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer httpResponse.Body.Close() //nolint:errcheck
+
+	if httpResponse.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(httpResponse.Body, 1024))
+		return fmt.Errorf("%w: %s: %s", ErrUnexpectedResponse, httpResponse.Status, strings.TrimSpace(string(body)))
+	}
+
+	return json.NewDecoder(httpResponse.Body).Decode(response)
+}
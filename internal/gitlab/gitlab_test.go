@@ -0,0 +1,59 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient(t *testing.T) {
+	headSHA := "2f5d1d9b7c1b8b6f0b4c0e6a1d1c2c3b4a5f6e7d"
+	mergeSHA := "1f5d1d9b7c1b8b6f0b4c0e6a1d1c2c3b4a5f6e7d"
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "token", r.Header.Get("PRIVATE-TOKEN"))
+
+		switch r.URL.EscapedPath() {
+		case "/projects/gittuf%2Fgittuf/merge_requests/1":
+			fmt.Fprintf(w, `{"iid": 1, "web_url": "https://gitlab.com/gittuf/gittuf/-/merge_requests/1", "target_branch": "main", "sha": "%s", "state": "merged", "merge_commit_sha": "%s"}`, headSHA, mergeSHA)
+		case "/projects/gittuf%2Fgittuf/merge_requests/2":
+			fmt.Fprintf(w, `{"iid": 2, "target_branch": "main", "sha": "%s", "state": "merged"}`, headSHA)
+		case "/projects/gittuf%2Fgittuf/merge_requests/3":
+			fmt.Fprintf(w, `{"iid": 3, "target_branch": "main", "sha": "%s", "state": "opened"}`, headSHA)
+		case "/projects/gittuf%2Fgittuf/merge_requests/1/approvals":
+			fmt.Fprint(w, `{"approved_by": [{"user": {"username": "alice"}}, {"user": {"username": "bob"}}]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "token")
+
+	mergeRequest, err := client.GetMergeRequest(context.Background(), "gittuf/gittuf", 1)
+	assert.Nil(t, err)
+	assert.Equal(t, &MergeRequest{IID: 1, WebURL: "https://gitlab.com/gittuf/gittuf/-/merge_requests/1", TargetBranch: "main", HeadSHA: headSHA, Merged: true, MergedCommitSHA: mergeSHA}, mergeRequest)
+
+	// Fast-forward merges don't create a new commit
+	mergeRequest, err = client.GetMergeRequest(context.Background(), "gittuf/gittuf", 2)
+	assert.Nil(t, err)
+	assert.Equal(t, headSHA, mergeRequest.MergedCommitSHA)
+
+	mergeRequest, err = client.GetMergeRequest(context.Background(), "gittuf/gittuf", 3)
+	assert.Nil(t, err)
+	assert.False(t, mergeRequest.Merged)
+	assert.Empty(t, mergeRequest.MergedCommitSHA)
+
+	approvers, err := client.GetApprovers(context.Background(), "gittuf/gittuf", 1)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"alice", "bob"}, approvers)
+
+	_, err = client.GetMergeRequest(context.Background(), "gittuf/gittuf", 4)
+	assert.ErrorIs(t, err, ErrUnexpectedResponse)
+}
@@ -0,0 +1,172 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Package metrics implements the metrics exported by gittuf's long running
+// modes, such as serve and watch, for operational monitoring. Metrics are
+// exposed in the Prometheus text exposition format.
+package metrics
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	resultSuccess = "success"
+	resultFailure = "failure"
+
+	contentType = "text/plain; version=0.0.4; charset=utf-8"
+)
+
+// Metrics records the metrics for a set of repositories, identified by the
+// name they are served or watched as. Metrics is safe for concurrent use.
+type Metrics struct {
+	mu sync.Mutex
+
+	// classify returns the type of a verification failure.
+	classify func(error) string
+
+	verifications  *family
+	failures       *family
+	rslLag         *family
+	policyVersions *family
+}
+
+// New returns an empty set of metrics. Verification failures are labeled using
+// the type returned by classify.
+func New(classify func(error) string) *Metrics {
+	return &Metrics{
+		classify:       classify,
+		verifications:  newFamily("gittuf_verifications_total", "Number of verifications performed.", "counter", "repository", "result"),
+		failures:       newFamily("gittuf_verification_failures_total", "Number of verifications that failed, by type of failure.", "counter", "repository", "type"),
+		rslLag:         newFamily("gittuf_rsl_lag_seconds", "Time since the latest RSL entry was recorded.", "gauge", "repository"),
+		policyVersions: newFamily("gittuf_policy_version", "Version of the current policy's metadata.", "gauge", "repository", "metadata"),
+	}
+}
+
+// RecordVerification records a verification of the repository, which failed
+// if err is not nil.
+func (m *Metrics) RecordVerification(repository string, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if err == nil {
+		m.verifications.add(1, repository, resultSuccess)
+		return
+	}
+
+	m.verifications.add(1, repository, resultFailure)
+	m.failures.add(1, repository, m.classify(err))
+}
+
+// SetRSLLag records the time elapsed since the repository's latest RSL entry
+// was recorded.
+func (m *Metrics) SetRSLLag(repository string, lag time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.rslLag.set(lag.Seconds(), repository)
+}
+
+// SetPolicyVersions records the versions of the repository's current policy
+// metadata, keyed by role name.
+func (m *Metrics) SetPolicyVersions(repository string, versions map[string]int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for metadata, version := range versions {
+		m.policyVersions.set(float64(version), repository, metadata)
+	}
+}
+
+// WriteTo writes the metrics to w in the Prometheus text exposition format.
+func (m *Metrics) WriteTo(w io.Writer) (int64, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var b strings.Builder
+	for _, f := range []*family{m.verifications, m.failures, m.rslLag, m.policyVersions} {
+		f.write(&b)
+	}
+
+	n, err := io.WriteString(w, b.String())
+	return int64(n), err
+}
+
+// Handler returns the HTTP handler that serves the metrics.
+func (m *Metrics) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", contentType)
+		m.WriteTo(w) //nolint:errcheck
+	})
+}
+
+// family is a metric and its samples, one for each combination of label
+// values.
+type family struct {
+	name       string
+	help       string
+	kind       string
+	labelNames []string
+	samples    map[string]*sample
+}
+
+type sample struct {
+	labelValues []string
+	value       float64
+}
+
+func newFamily(name, help, kind string, labelNames ...string) *family {
+	return &family{name: name, help: help, kind: kind, labelNames: labelNames, samples: map[string]*sample{}}
+}
+
+func (f *family) get(labelValues []string) *sample {
+	key := strings.Join(labelValues, "\xff")
+	s, has := f.samples[key]
+	if !has {
+		s = &sample{labelValues: labelValues}
+		f.samples[key] = s
+	}
+	return s
+}
+
+func (f *family) add(delta float64, labelValues ...string) {
+	f.get(labelValues).value += delta
+}
+
+func (f *family) set(value float64, labelValues ...string) {
+	f.get(labelValues).value = value
+}
+
+func (f *family) write(b *strings.Builder) {
+	fmt.Fprintf(b, "# HELP %s %s\n", f.name, f.help)
+	fmt.Fprintf(b, "# TYPE %s %s\n", f.name, f.kind)
+
+	keys := make([]string, 0, len(f.samples))
+	for key := range f.samples {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		s := f.samples[key]
+
+		labels := make([]string, 0, len(f.labelNames))
+		for i, labelName := range f.labelNames {
+			labels = append(labels, fmt.Sprintf("%s=\"%s\"", labelName, escapeLabelValue(s.labelValues[i])))
+		}
+
+		fmt.Fprintf(b, "%s{%s} %s\n", f.name, strings.Join(labels, ","), strconv.FormatFloat(s.value, 'g', -1, 64))
+	}
+}
+
+var labelValueEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+func escapeLabelValue(value string) string {
+	return labelValueEscaper.Replace(value)
+}
@@ -0,0 +1,60 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetrics(t *testing.T) {
+	m := New(func(err error) string { return err.Error() })
+
+	m.RecordVerification("repo", nil)
+	m.RecordVerification("repo", nil)
+	m.RecordVerification("repo", errors.New("stale_rsl"))
+	m.RecordVerification(`other "repo"`, errors.New("signature_invalid"))
+	m.SetRSLLag("repo", 90*time.Second)
+	m.SetPolicyVersions("repo", map[string]int{"root": 1, "targets": 3})
+	m.SetPolicyVersions("repo", map[string]int{"targets": 4})
+
+	expected := `# HELP gittuf_verifications_total Number of verifications performed.
+# TYPE gittuf_verifications_total counter
+gittuf_verifications_total{repository="other \"repo\"",result="failure"} 1
+gittuf_verifications_total{repository="repo",result="failure"} 1
+gittuf_verifications_total{repository="repo",result="success"} 2
+# HELP gittuf_verification_failures_total Number of verifications that failed, by type of failure.
+# TYPE gittuf_verification_failures_total counter
+gittuf_verification_failures_total{repository="other \"repo\"",type="signature_invalid"} 1
+gittuf_verification_failures_total{repository="repo",type="stale_rsl"} 1
+# HELP gittuf_rsl_lag_seconds Time since the latest RSL entry was recorded.
+# TYPE gittuf_rsl_lag_seconds gauge
+gittuf_rsl_lag_seconds{repository="repo"} 90
+# HELP gittuf_policy_version Version of the current policy's metadata.
+# TYPE gittuf_policy_version gauge
+gittuf_policy_version{repository="repo",metadata="root"} 1
+gittuf_policy_version{repository="repo",metadata="targets"} 4
+`
+
+	t.Run("write", func(t *testing.T) {
+		var b strings.Builder
+		_, err := m.WriteTo(&b)
+		assert.Nil(t, err)
+		assert.Equal(t, expected, b.String())
+	})
+
+	t.Run("handler", func(t *testing.T) {
+		recorder := httptest.NewRecorder()
+		m.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+		assert.Equal(t, http.StatusOK, recorder.Code)
+		assert.Equal(t, contentType, recorder.Header().Get("Content-Type"))
+		assert.Equal(t, expected, recorder.Body.String())
+	})
+}
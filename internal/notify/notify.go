@@ -0,0 +1,148 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Package notify notifies external services, such as chat or incident
+// management systems, of violations of a repository's gittuf policy.
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	// FormatGeneric posts the Violation as JSON.
+	FormatGeneric = "generic"
+
+	// FormatSlack posts a message for a Slack incoming webhook.
+	FormatSlack = "slack"
+
+	webhookTimeout = 10 * time.Second
+)
+
+var (
+	ErrUnknownWebhookFormat = errors.New("unknown webhook format")
+	ErrWebhookFailed        = errors.New("webhook did not accept notification")
+)
+
+// Violation describes a violation of a repository's gittuf policy.
+type Violation struct {
+	// Repository identifies the repository that was verified.
+	Repository string `json:"repository"`
+
+	// Remote is the remote whose state was verified, if any.
+	Remote string `json:"remote,omitempty"`
+
+	// Ref is the reference that failed verification, if the violation is
+	// for a single reference.
+	Ref string `json:"ref,omitempty"`
+
+	// Error is the reason verification failed.
+	Error string `json:"error"`
+
+	// Entries are the RSL entries that failed verification.
+	Entries []*Entry `json:"rsl_entries,omitempty"`
+
+	// Time is when the violation was detected.
+	Time time.Time `json:"time"`
+}
+
+// Entry is an RSL entry that failed verification.
+type Entry struct {
+	ID       string  `json:"id"`
+	Ref      string  `json:"ref"`
+	TargetID string  `json:"target_id"`
+	Signer   *Signer `json:"signer"`
+	Error    string  `json:"error"`
+}
+
+// Signer identifies who recorded an RSL entry.
+type Signer struct {
+	Name   string `json:"name"`
+	Email  string `json:"email"`
+	Signed bool   `json:"signed"`
+}
+
+// Webhook posts notifications to a URL.
+type Webhook struct {
+	url    string
+	format string
+	client *http.Client
+}
+
+// NewWebhook returns a webhook that posts notifications to url in the
+// specified format, either FormatGeneric or FormatSlack.
+func NewWebhook(url, format string) (*Webhook, error) {
+	if format != FormatGeneric && format != FormatSlack {
+		return nil, fmt.Errorf("%w '%s', must be one of '%s' or '%s'", ErrUnknownWebhookFormat, format, FormatGeneric, FormatSlack)
+	}
+
+	return &Webhook{url: url, format: format, client: &http.Client{Timeout: webhookTimeout}}, nil
+}
+
+// Notify posts the violation to the webhook.
+func (w *Webhook) Notify(ctx context.Context, violation *Violation) error {
+	var payload any = violation
+	if w.format == FormatSlack {
+		payload = &slackMessage{Text: slackText(violation)}
+	}
+
+	payloadBytes, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, w.url, bytes.NewReader(payloadBytes))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+
+	response, err := w.client.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close() //nolint:errcheck
+
+	if response.StatusCode < 200 || response.StatusCode >= 300 {
+		return fmt.Errorf("%w: responded with status '%s'", ErrWebhookFailed, response.Status)
+	}
+
+	return nil
+}
+
+type slackMessage struct {
+	Text string `json:"text"`
+}
+
+// slackText returns the violation as a message using Slack's markdown.
+func slackText(violation *Violation) string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, ":rotating_light: *gittuf policy violation* in `%s`", violation.Repository)
+	if violation.Remote != "" {
+		fmt.Fprintf(&b, " (remote `%s`)", violation.Remote)
+	}
+	if violation.Ref != "" {
+		fmt.Fprintf(&b, " for `%s`", violation.Ref)
+	}
+	fmt.Fprintf(&b, "\n```%s```", violation.Error)
+
+	for _, entry := range violation.Entries {
+		fmt.Fprintf(&b, "\n• RSL entry `%s` sets `%s` to `%s`", entry.ID, entry.Ref, entry.TargetID)
+		if entry.Signer != nil {
+			fmt.Fprintf(&b, ", recorded by %s <%s>", entry.Signer.Name, entry.Signer.Email)
+			if !entry.Signer.Signed {
+				b.WriteString(" (unsigned)")
+			}
+		}
+		fmt.Fprintf(&b, ": %s", entry.Error)
+	}
+
+	return b.String()
+}
@@ -0,0 +1,94 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhook(t *testing.T) {
+	violation := &Violation{
+		Repository: "/repo",
+		Remote:     "origin",
+		Error:      "unauthorized signature",
+		Entries: []*Entry{{
+			ID:       "abcdef",
+			Ref:      "refs/heads/main",
+			TargetID: "123456",
+			Signer:   &Signer{Name: "Jane Doe", Email: "jane@example.com"},
+			Error:    "unauthorized signature",
+		}},
+		Time: time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+	}
+
+	// receive returns a server that decodes the payload it receives into
+	// payload and responds with status
+	receive := func(t *testing.T, payload any, status int) *httptest.Server {
+		t.Helper()
+
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+			if err := json.NewDecoder(r.Body).Decode(payload); err != nil {
+				t.Error(err)
+			}
+			w.WriteHeader(status)
+		}))
+		t.Cleanup(server.Close)
+
+		return server
+	}
+
+	t.Run("generic", func(t *testing.T) {
+		received := &Violation{}
+		server := receive(t, received, http.StatusOK)
+
+		webhook, err := NewWebhook(server.URL, FormatGeneric)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = webhook.Notify(context.Background(), violation)
+		assert.Nil(t, err)
+		assert.Equal(t, violation, received)
+	})
+
+	t.Run("slack", func(t *testing.T) {
+		received := &slackMessage{}
+		server := receive(t, received, http.StatusOK)
+
+		webhook, err := NewWebhook(server.URL, FormatSlack)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = webhook.Notify(context.Background(), violation)
+		assert.Nil(t, err)
+		assert.Contains(t, received.Text, "*gittuf policy violation* in `/repo` (remote `origin`)")
+		assert.Contains(t, received.Text, "RSL entry `abcdef` sets `refs/heads/main` to `123456`, recorded by Jane Doe <jane@example.com> (unsigned)")
+	})
+
+	t.Run("webhook failure", func(t *testing.T) {
+		server := receive(t, &Violation{}, http.StatusInternalServerError)
+
+		webhook, err := NewWebhook(server.URL, FormatGeneric)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = webhook.Notify(context.Background(), violation)
+		assert.ErrorIs(t, err, ErrWebhookFailed)
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		_, err := NewWebhook("http://localhost", "email")
+		assert.ErrorIs(t, err, ErrUnknownWebhookFormat)
+	})
+}
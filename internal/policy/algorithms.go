@@ -0,0 +1,198 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"crypto"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/gittuf/gittuf/internal/github"
+	"github.com/gittuf/gittuf/internal/gitlab"
+	"github.com/gittuf/gittuf/internal/signerverifier"
+	"github.com/gittuf/gittuf/internal/signerverifier/gpg"
+	"github.com/gittuf/gittuf/internal/tuf"
+)
+
+var (
+	ErrInvalidAlgorithmPolicy       = errors.New("invalid algorithm policy")
+	ErrKeyAlgorithmNotAllowed       = errors.New("key does not meet the algorithm policy")
+	ErrSignatureAlgorithmNotAllowed = errors.New("signature algorithm does not meet the algorithm policy")
+)
+
+// supportedAlgorithmKeyTypes are the key types an algorithm policy can allow.
+var supportedAlgorithmKeyTypes = []string{
+	signerverifier.ED25519KeyType,
+	signerverifier.ECDSAKeyType,
+	signerverifier.RSAKeyType,
+	signerverifier.GPGKeyType,
+	signerverifier.FulcioKeyType,
+	signerverifier.X509KeyType,
+	signerverifier.SSHCAKeyType,
+	github.IdentityKeyType,
+	gitlab.IdentityKeyType,
+}
+
+// SetAlgorithmPolicy records the minimum acceptable signing algorithms in the
+// root of trust. If algorithmPolicy is nil or places no restrictions, the
+// algorithm policy is unset. The keys already trusted by the root of trust
+// must meet the new algorithm policy, so that it cannot lock out the owners
+// of the repository.
+func SetAlgorithmPolicy(rootMetadata *tuf.RootMetadata, algorithmPolicy *tuf.AlgorithmPolicy) (*tuf.RootMetadata, error) {
+	if rootMetadata == nil {
+		return nil, ErrRootMetadataNil
+	}
+
+	if algorithmPolicy == nil || (len(algorithmPolicy.AllowedKeyTypes) == 0 && algorithmPolicy.MinimumRSABits == 0 && !algorithmPolicy.RejectSHA1) {
+		rootMetadata.AlgorithmPolicy = nil
+		return rootMetadata, nil
+	}
+
+	if algorithmPolicy.MinimumRSABits < 0 {
+		return nil, fmt.Errorf("%w: minimum RSA key size cannot be negative", ErrInvalidAlgorithmPolicy)
+	}
+	for _, keyType := range algorithmPolicy.AllowedKeyTypes {
+		if !slices.Contains(supportedAlgorithmKeyTypes, keyType) {
+			return nil, fmt.Errorf("%w: unknown key type '%s'", ErrInvalidAlgorithmPolicy, keyType)
+		}
+	}
+
+	keyIDs := make([]string, 0, len(rootMetadata.Keys))
+	for keyID := range rootMetadata.Keys {
+		keyIDs = append(keyIDs, keyID)
+	}
+	sort.Strings(keyIDs)
+	for _, keyID := range keyIDs {
+		if err := CheckKeyAlgorithm(algorithmPolicy, rootMetadata.Keys[keyID]); err != nil {
+			return nil, err
+		}
+	}
+
+	rootMetadata.AlgorithmPolicy = algorithmPolicy
+	return rootMetadata, nil
+}
+
+// CheckKeyAlgorithm returns an error if key does not meet algorithmPolicy. A
+// nil algorithm policy allows all keys.
+func CheckKeyAlgorithm(algorithmPolicy *tuf.AlgorithmPolicy, key *tuf.Key) error {
+	if algorithmPolicy == nil || key == nil {
+		return nil
+	}
+
+	if len(algorithmPolicy.AllowedKeyTypes) > 0 && !slices.Contains(algorithmPolicy.AllowedKeyTypes, key.KeyType) {
+		return fmt.Errorf("%w: key '%s' has type '%s', which is not allowed", ErrKeyAlgorithmNotAllowed, key.KeyID, key.KeyType)
+	}
+
+	if algorithmPolicy.MinimumRSABits == 0 {
+		return nil
+	}
+
+	var rsaKeySizes []int
+	switch key.KeyType {
+	case signerverifier.RSAKeyType:
+		verifier, err := signerverifier.NewSignerVerifierFromTUFKey(key) //nolint:staticcheck
+		if err != nil {
+			return err
+		}
+		rsaPublicKey, isRSA := verifier.Public().(*rsa.PublicKey)
+		if !isRSA {
+			return fmt.Errorf("%w: unable to determine size of RSA key '%s'", ErrKeyAlgorithmNotAllowed, key.KeyID)
+		}
+		rsaKeySizes = []int{rsaPublicKey.N.BitLen()}
+	case signerverifier.GPGKeyType:
+		var err error
+		rsaKeySizes, err = gpg.RSAKeySizes(key)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, size := range rsaKeySizes {
+		if size < algorithmPolicy.MinimumRSABits {
+			return fmt.Errorf("%w: key '%s' uses a %d-bit RSA key, at least %d bits are required", ErrKeyAlgorithmNotAllowed, key.KeyID, size, algorithmPolicy.MinimumRSABits)
+		}
+	}
+
+	return nil
+}
+
+// CheckGitSignatureAlgorithm returns an error if the Git signature made using
+// key does not meet algorithmPolicy.
+func CheckGitSignatureAlgorithm(algorithmPolicy *tuf.AlgorithmPolicy, key *tuf.Key, signature string) error {
+	if algorithmPolicy == nil || !algorithmPolicy.RejectSHA1 || key.KeyType != signerverifier.GPGKeyType {
+		return nil
+	}
+
+	hash, err := gpg.SignatureHash([]byte(signature))
+	if err != nil {
+		// The signature cannot have been made using a GPG key
+		return nil //nolint:nilerr
+	}
+	if hash == crypto.SHA1 {
+		return fmt.Errorf("%w: GPG signature uses SHA-1", ErrSignatureAlgorithmNotAllowed)
+	}
+
+	return nil
+}
+
+// VerifyNewKeyAlgorithms checks that every key that is in the State but not
+// in previous meets the State's algorithm policy. Keys that were already
+// trusted are not checked, so that tightening the algorithm policy does not
+// prevent other changes to policy.
+func (s *State) VerifyNewKeyAlgorithms(previous *State) error {
+	rootMetadata, err := s.GetRootMetadata()
+	if err != nil {
+		return err
+	}
+	if rootMetadata.AlgorithmPolicy == nil {
+		return nil
+	}
+
+	keys, err := s.keysForAlgorithmPolicy()
+	if err != nil {
+		return err
+	}
+	previousKeys := map[string]*tuf.Key{}
+	if previous != nil {
+		previousKeys, err = previous.keysForAlgorithmPolicy()
+		if err != nil {
+			return err
+		}
+	}
+
+	keyIDs := make([]string, 0, len(keys))
+	for keyID := range keys {
+		if _, existing := previousKeys[keyID]; !existing {
+			keyIDs = append(keyIDs, keyID)
+		}
+	}
+	sort.Strings(keyIDs)
+
+	for _, keyID := range keyIDs {
+		if err := CheckKeyAlgorithm(rootMetadata.AlgorithmPolicy, keys[keyID]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// keysForAlgorithmPolicy returns all the keys in the State, including those
+// in the root of trust before any policy file is created.
+func (s *State) keysForAlgorithmPolicy() (map[string]*tuf.Key, error) {
+	keys, rootMetadata, err := s.allPublicKeys()
+	if err != nil {
+		return nil, err
+	}
+	if keys == nil {
+		keys = map[string]*tuf.Key{}
+		for keyID, key := range rootMetadata.Keys {
+			keys[keyID] = key
+		}
+	}
+
+	return keys, nil
+}
@@ -0,0 +1,176 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"bytes"
+	"crypto"
+	"testing"
+
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
+	"github.com/gittuf/gittuf/internal/signerverifier"
+	"github.com/gittuf/gittuf/internal/signerverifier/gpg"
+	artifacts "github.com/gittuf/gittuf/internal/testartifacts"
+	sslibsv "github.com/gittuf/gittuf/internal/third_party/go-securesystemslib/signerverifier"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSetAlgorithmPolicy(t *testing.T) {
+	key, err := tuf.LoadKeyFromBytes(rootPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("set and unset", func(t *testing.T) {
+		rootMetadata := InitializeRootMetadata(key)
+
+		algorithmPolicy := &tuf.AlgorithmPolicy{
+			AllowedKeyTypes: []string{signerverifier.ED25519KeyType, signerverifier.GPGKeyType},
+			MinimumRSABits:  3072,
+			RejectSHA1:      true,
+		}
+		rootMetadata, err := SetAlgorithmPolicy(rootMetadata, algorithmPolicy)
+		assert.Nil(t, err)
+		assert.Equal(t, algorithmPolicy, rootMetadata.AlgorithmPolicy)
+
+		rootMetadata, err = SetAlgorithmPolicy(rootMetadata, &tuf.AlgorithmPolicy{})
+		assert.Nil(t, err)
+		assert.Nil(t, rootMetadata.AlgorithmPolicy)
+	})
+
+	t.Run("unknown key type", func(t *testing.T) {
+		rootMetadata := InitializeRootMetadata(key)
+
+		_, err := SetAlgorithmPolicy(rootMetadata, &tuf.AlgorithmPolicy{AllowedKeyTypes: []string{"dsa"}})
+		assert.ErrorIs(t, err, ErrInvalidAlgorithmPolicy)
+	})
+
+	t.Run("negative key size", func(t *testing.T) {
+		rootMetadata := InitializeRootMetadata(key)
+
+		_, err := SetAlgorithmPolicy(rootMetadata, &tuf.AlgorithmPolicy{MinimumRSABits: -1})
+		assert.ErrorIs(t, err, ErrInvalidAlgorithmPolicy)
+	})
+
+	t.Run("root key not allowed", func(t *testing.T) {
+		rootMetadata := InitializeRootMetadata(key)
+
+		_, err := SetAlgorithmPolicy(rootMetadata, &tuf.AlgorithmPolicy{AllowedKeyTypes: []string{signerverifier.RSAKeyType}})
+		assert.ErrorIs(t, err, ErrKeyAlgorithmNotAllowed)
+	})
+}
+
+func TestCheckKeyAlgorithm(t *testing.T) {
+	rsaKey, err := sslibsv.LoadKey(artifacts.SSHRSAPublic)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gpgKey, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ed25519Key, err := tuf.LoadKeyFromBytes(targets1PubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		algorithmPolicy *tuf.AlgorithmPolicy
+		key             *tuf.Key
+		allowed         bool
+	}{
+		"no algorithm policy": {
+			key:     rsaKey,
+			allowed: true,
+		},
+		"allowed key type": {
+			algorithmPolicy: &tuf.AlgorithmPolicy{AllowedKeyTypes: []string{signerverifier.ED25519KeyType}},
+			key:             ed25519Key,
+			allowed:         true,
+		},
+		"disallowed key type": {
+			algorithmPolicy: &tuf.AlgorithmPolicy{AllowedKeyTypes: []string{signerverifier.ED25519KeyType}},
+			key:             rsaKey,
+		},
+		"sufficient rsa key size": {
+			algorithmPolicy: &tuf.AlgorithmPolicy{MinimumRSABits: 3072},
+			key:             rsaKey,
+			allowed:         true,
+		},
+		"insufficient rsa key size": {
+			algorithmPolicy: &tuf.AlgorithmPolicy{MinimumRSABits: 4096},
+			key:             rsaKey,
+		},
+		"sufficient gpg key size": {
+			algorithmPolicy: &tuf.AlgorithmPolicy{MinimumRSABits: 3072},
+			key:             gpgKey,
+			allowed:         true,
+		},
+		"insufficient gpg key size": {
+			algorithmPolicy: &tuf.AlgorithmPolicy{MinimumRSABits: 4096},
+			key:             gpgKey,
+		},
+		"key size does not apply to ed25519": {
+			algorithmPolicy: &tuf.AlgorithmPolicy{MinimumRSABits: 4096},
+			key:             ed25519Key,
+			allowed:         true,
+		},
+	}
+
+	for name, test := range tests {
+		err := CheckKeyAlgorithm(test.algorithmPolicy, test.key)
+		if test.allowed {
+			assert.Nil(t, err, name)
+		} else {
+			assert.ErrorIs(t, err, ErrKeyAlgorithmNotAllowed, name)
+		}
+	}
+}
+
+func TestCheckGitSignatureAlgorithm(t *testing.T) {
+	gpgKey, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyring, err := openpgp.ReadArmoredKeyRing(bytes.NewReader(gpgKeyBytes))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sha256Signature := &bytes.Buffer{}
+	if err := openpgp.ArmoredDetachSign(sha256Signature, keyring[0], bytes.NewReader([]byte("data")), &packet.Config{DefaultHash: crypto.SHA256}); err != nil {
+		t.Fatal(err)
+	}
+
+	algorithmPolicy := &tuf.AlgorithmPolicy{RejectSHA1: true}
+
+	err = CheckGitSignatureAlgorithm(algorithmPolicy, gpgKey, sha1Signature)
+	assert.ErrorIs(t, err, ErrSignatureAlgorithmNotAllowed)
+
+	err = CheckGitSignatureAlgorithm(algorithmPolicy, gpgKey, sha256Signature.String())
+	assert.Nil(t, err)
+
+	err = CheckGitSignatureAlgorithm(&tuf.AlgorithmPolicy{}, gpgKey, sha1Signature)
+	assert.Nil(t, err)
+}
+
+// sha1Signature is a signature over "data" made using GPGKey1 and SHA-1. It
+// is generated using the GPG CLI as go-crypto does not create SHA-1
+// signatures.
+const sha1Signature = `-----BEGIN PGP SIGNATURE-----
+
+iQGyBAABAgAdFiEEFXUHu+FR43jOgSbB3P4EPN0tuW4FAmrQ/nwACgkQ3P4EPN0t
+uW6q4gv3fvp3taGGHiVlvZCRsqAcVaPsYiFnFF1qt00Dwvuv25PGTcZT+s+VXmX3
+R68yYkOWp0hwKtGTCOpBlYvA30XS8j+fL/5pVDZWGset+KtQ4W/Qjc/dOEZ0NG/w
+FSuIvMc05qDVA5U/j4XRO724eru1oLiKOEwMbzim/KqjprjSTmqceAzfCL5hBdxM
+uLRgCWQnEu5LKGgasrvh5UAsSJY4XS1lkDlcOKb0ZqXwpVjXxFzriQOJ7V4j6rzw
+uAoW5Eq1Y7HS48qtIT+E34E+i2oFZveuF76fm+wk4bC777dV6kAivrWyfwrFr1IC
++Dfrxh+BHxSHiG4r3U2Pu++k5iRrns/6e0SBhWYkneirThlNhogQ4IwdjnlfrZTu
+FW1GQkTmrpjZQMVfiD/hdmdY84vA/mRY+8Y4033JEOTK553MexNr10v6Bh+3m+hi
+k6xt0ydTOAvxpVM1wDxvXcofDbXRB65VZvkTuYozA8JXlvYGlbnv920jxciR+zUT
+vt017nk=
+=5arV
+-----END PGP SIGNATURE-----
+`
@@ -0,0 +1,118 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+
+	"github.com/gittuf/gittuf/internal/signerverifier"
+	"github.com/gittuf/gittuf/internal/signerverifier/sshca"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"golang.org/x/crypto/ssh"
+)
+
+const allowedSignersNamespace = "git"
+
+// AllowedSigner is an entry in the allowed signers file Git uses to verify
+// SSH signatures, configured using gpg.ssh.allowedSignersFile. See the
+// ALLOWED SIGNERS section of ssh-keygen(1) for the format.
+type AllowedSigner struct {
+	Principal     string
+	CertAuthority bool
+	PublicKey     string
+}
+
+// String returns the entry as a line of an allowed signers file.
+func (a *AllowedSigner) String() string {
+	options := fmt.Sprintf("namespaces=\"%s\"", allowedSignersNamespace)
+	if a.CertAuthority {
+		options = "cert-authority," + options
+	}
+
+	return fmt.Sprintf("%s %s %s", a.Principal, options, a.PublicKey)
+}
+
+// FindAllowedSignersForPath returns the allowed signers entries for the SSH
+// keys and SSH certificate authorities trusted by the rules that protect
+// path. Keys that belong to a person use the person's ID as the principal,
+// other keys use their key ID. Certificate authorities use the principal
+// recorded in policy, which must be listed in the certificates they issue.
+// If deny rules protect path, only their exceptions are included. Revoked
+// keys and keys that cannot be used for SSH signatures are omitted.
+func (s *State) FindAllowedSignersForPath(path string) ([]*AllowedSigner, error) {
+	verifiers, err := s.FindVerifiersForPath(path)
+	if err != nil {
+		return nil, err
+	}
+	verifiers, _ = applyDenyRules(verifiers)
+
+	seen := map[string]bool{}
+	allowedSigners := []*AllowedSigner{}
+	for _, verifier := range verifiers {
+		for _, key := range verifier.keys {
+			allowedSigner, err := newAllowedSigner(key, verifier.principal(key.KeyID))
+			if err != nil {
+				slog.Debug(fmt.Sprintf("Skipping key '%s' for allowed signers: %s", key.KeyID, err.Error()))
+				continue
+			}
+			if allowedSigner == nil {
+				continue
+			}
+
+			line := allowedSigner.String()
+			if seen[line] {
+				continue
+			}
+			seen[line] = true
+			allowedSigners = append(allowedSigners, allowedSigner)
+		}
+	}
+
+	sort.Slice(allowedSigners, func(i, j int) bool {
+		return allowedSigners[i].String() < allowedSigners[j].String()
+	})
+
+	return allowedSigners, nil
+}
+
+// newAllowedSigner returns the allowed signers entry for key, or nil if the
+// key is not used for SSH signatures.
+func newAllowedSigner(key *tuf.Key, principal string) (*AllowedSigner, error) {
+	switch key.KeyType {
+	case signerverifier.RSAKeyType, signerverifier.ECDSAKeyType, signerverifier.ED25519KeyType:
+		verifier, err := signerverifier.NewSignerVerifierFromTUFKey(key) //nolint:staticcheck
+		if err != nil {
+			return nil, err
+		}
+		publicKey, err := ssh.NewPublicKey(verifier.Public())
+		if err != nil {
+			return nil, err
+		}
+
+		return &AllowedSigner{
+			Principal: quotePrincipal(principal),
+			PublicKey: strings.TrimSpace(string(ssh.MarshalAuthorizedKey(publicKey))),
+		}, nil
+	case sshca.KeyType:
+		return &AllowedSigner{
+			Principal:     quotePrincipal(key.KeyVal.Identity),
+			CertAuthority: true,
+			PublicKey:     key.KeyVal.Public,
+		}, nil
+	}
+
+	return nil, nil
+}
+
+// quotePrincipal quotes principals that contain whitespace, as the fields of
+// an allowed signers entry are separated by whitespace.
+func quotePrincipal(principal string) string {
+	if strings.ContainsAny(principal, " \t") {
+		return fmt.Sprintf("\"%s\"", principal)
+	}
+
+	return principal
+}
@@ -0,0 +1,125 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/signerverifier"
+	"github.com/gittuf/gittuf/internal/signerverifier/dsse"
+	"github.com/gittuf/gittuf/internal/signerverifier/gpg"
+	"github.com/gittuf/gittuf/internal/signerverifier/sshca"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestStateFindAllowedSignersForPath(t *testing.T) {
+	state := createTestStateWithPolicy(t)
+
+	signer, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(rootKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+	personKey, err := tuf.LoadKeyFromBytes(targets1PubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := tuf.LoadKeyFromBytes(targets2PubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gpgKey, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	caPublicKey, _, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sshCAPublicKey, err := ssh.NewPublicKey(caPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	caKey, err := sshca.NewKey("jane.doe", ssh.MarshalAuthorizedKey(sshCAPublicKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targetsMetadata, err := state.GetTargetsMetadata(TargetsRoleName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsMetadata, err = AddPerson(targetsMetadata, "alice", []*tuf.Key{personKey})
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsMetadata, err = AddDelegation(targetsMetadata, "protect-release", []*tuf.Key{key, caKey, gpgKey}, []string{"git:refs/heads/release"}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsMetadata, err = SetAuthorizedPersons(targetsMetadata, "protect-release", []string{"alice"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targetsEnv, err := dsse.CreateEnvelope(targetsMetadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsEnv, err = dsse.SignEnvelope(context.Background(), targetsEnv, signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	state.TargetsEnvelope = targetsEnv
+
+	t.Run("ssh keys and certificate authorities", func(t *testing.T) {
+		allowedSigners, err := state.FindAllowedSignersForPath("git:refs/heads/release")
+		assert.Nil(t, err)
+
+		lines := []string{}
+		for _, allowedSigner := range allowedSigners {
+			lines = append(lines, allowedSigner.String())
+		}
+
+		expectedLines := []string{
+			fmt.Sprintf("alice namespaces=\"git\" %s", sshAuthorizedKey(t, personKey)),
+			fmt.Sprintf("%s namespaces=\"git\" %s", key.KeyID, sshAuthorizedKey(t, key)),
+			fmt.Sprintf("jane.doe cert-authority,namespaces=\"git\" %s", strings.TrimSpace(string(ssh.MarshalAuthorizedKey(sshCAPublicKey)))),
+		}
+		assert.ElementsMatch(t, expectedLines, lines)
+	})
+
+	t.Run("unprotected path", func(t *testing.T) {
+		allowedSigners, err := state.FindAllowedSignersForPath("git:refs/heads/feature")
+		assert.Nil(t, err)
+		assert.Empty(t, allowedSigners)
+	})
+
+	t.Run("only gpg keys", func(t *testing.T) {
+		allowedSigners, err := state.FindAllowedSignersForPath("git:refs/heads/main")
+		assert.Nil(t, err)
+		assert.Empty(t, allowedSigners)
+	})
+}
+
+func sshAuthorizedKey(t *testing.T, key *tuf.Key) string {
+	t.Helper()
+
+	verifier, err := signerverifier.NewSignerVerifierFromTUFKey(key) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicKey, err := ssh.NewPublicKey(verifier.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return strings.TrimSpace(string(ssh.MarshalAuthorizedKey(publicKey)))
+}
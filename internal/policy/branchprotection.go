@@ -0,0 +1,184 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gittuf/gittuf/internal/github"
+	"github.com/gittuf/gittuf/internal/tuf"
+)
+
+// BranchProtectionRuleNamePrefix prefixes the names of rules generated from
+// GitHub branch protection settings. The rest of the name is the branch name.
+const BranchProtectionRuleNamePrefix = "github-branch-protection:"
+
+// maxGitHubRequiredApprovals is the largest number of approving reviews GitHub
+// branch protection can require.
+const maxGitHubRequiredApprovals = 6
+
+var ErrNoBranchProtectionPrincipals = errors.New("rules generated from branch protection must authorize at least one person or team")
+
+// ImportBranchProtections adds or updates a rule in targetsMetadata for each
+// of the GitHub branch protection settings in protections. Each rule protects
+// the branch, and authorizes the specified people and teams, which must
+// already be recorded in targetsMetadata. As the person pushing a change also
+// counts towards a rule's threshold, the threshold is one more than the number
+// of approving reviews required by GitHub. Existing rules are updated in
+// place, so other settings such as their keys and conditions are retained. If
+// removeStale is set, previously imported rules for branches not in
+// protections are removed. The returned notes describe settings that cannot
+// be represented in the generated rules.
+func ImportBranchProtections(targetsMetadata *tuf.TargetsMetadata, protections []*github.BranchProtection, personIDs, teamIDs []string, removeStale bool) (*tuf.TargetsMetadata, []string, error) {
+	if len(personIDs) == 0 && len(teamIDs) == 0 {
+		return nil, nil, ErrNoBranchProtectionPrincipals
+	}
+	for _, personID := range personIDs {
+		if _, has := targetsMetadata.Delegations.People[personID]; !has {
+			return nil, nil, fmt.Errorf("%w: '%s'", ErrPersonNotFound, personID)
+		}
+	}
+	for _, teamID := range teamIDs {
+		if _, has := targetsMetadata.Delegations.Teams[teamID]; !has {
+			return nil, nil, fmt.Errorf("%w: '%s'", ErrTeamNotFound, teamID)
+		}
+	}
+
+	notes := []string{}
+	importedRules := map[string]tuf.Delegation{}
+	ruleNames := []string{}
+	for _, protection := range protections {
+		rule := tuf.Delegation{
+			Name:      BranchProtectionRuleNamePrefix + protection.Branch,
+			Paths:     []string{fmt.Sprintf("%s:refs/heads/%s", gitReferenceRuleScheme, protection.Branch)},
+			PersonIDs: personIDs,
+			TeamIDs:   teamIDs,
+			Role:      tuf.Role{KeyIDs: []string{}, Threshold: protection.RequiredApprovingReviewCount + 1},
+		}
+		importedRules[rule.Name] = rule
+		ruleNames = append(ruleNames, rule.Name)
+
+		if protection.AllowForcePushes {
+			notes = append(notes, fmt.Sprintf("Branch '%s' allows force pushes, which the rule rejects unless force push authorizers are set", protection.Branch))
+		}
+		if protection.AllowDeletions {
+			notes = append(notes, fmt.Sprintf("Branch '%s' allows deletion, which the rule rejects unless deletion authorizers are set", protection.Branch))
+		}
+		if protection.RequireCodeOwnerReviews {
+			notes = append(notes, fmt.Sprintf("Branch '%s' requires code owner reviews, which must be imported separately from the CODEOWNERS file", protection.Branch))
+		}
+	}
+
+	rules := []tuf.Delegation{}
+	for _, rule := range targetsMetadata.Delegations.Roles {
+		if rule.Name == AllowRuleName {
+			continue
+		}
+		if !strings.HasPrefix(rule.Name, BranchProtectionRuleNamePrefix) {
+			rules = append(rules, rule)
+			continue
+		}
+
+		importedRule, imported := importedRules[rule.Name]
+		if !imported {
+			if !removeStale {
+				rules = append(rules, rule)
+			}
+			continue
+		}
+
+		rule.Paths = importedRule.Paths
+		rule.PersonIDs = importedRule.PersonIDs
+		rule.TeamIDs = importedRule.TeamIDs
+		rule.Threshold = importedRule.Threshold
+		rules = append(rules, rule)
+		delete(importedRules, rule.Name)
+	}
+	for _, ruleName := range ruleNames {
+		if rule, isNew := importedRules[ruleName]; isNew {
+			rules = append(rules, rule)
+			delete(importedRules, ruleName)
+		}
+	}
+	rules = append(rules, AllowRule())
+	targetsMetadata.Delegations.Roles = rules
+
+	return targetsMetadata, notes, nil
+}
+
+// BranchProtections summarizes the rules in the State that protect branches
+// as GitHub branch protection settings, so that GitHub enforces the same
+// requirements when pull requests are merged. Only the settings that
+// correspond to gittuf policy are set: the number of approving reviews is one
+// less than the largest threshold of the rules protecting a branch, as the
+// person pushing a change counts towards the threshold, and force pushes and
+// deletions are allowed if any rule authorizes them. Rules that protect
+// branches using wildcards and deny rules are skipped, and are described in
+// the returned notes.
+func (s *State) BranchProtections() ([]*github.BranchProtection, []string, error) {
+	rulesByPolicyFile, err := s.rulesByPolicyFile()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	policyNames := mapKeys(rulesByPolicyFile)
+	sort.Strings(policyNames)
+
+	notes := []string{}
+	protections := map[string]*github.BranchProtection{}
+	branches := []string{}
+	branchPrefix := fmt.Sprintf("%s:refs/heads/", gitReferenceRuleScheme)
+	for _, policyName := range policyNames {
+		for _, rule := range rulesByPolicyFile[policyName] {
+			for _, pattern := range rule.Paths {
+				branch, isBranch := strings.CutPrefix(pattern, branchPrefix)
+				if !isBranch {
+					continue
+				}
+				if rule.Effect == RuleEffectDeny {
+					notes = append(notes, fmt.Sprintf("Skipping deny rule '%s' in policy '%s'", rule.Name, policyName))
+					break
+				}
+				if strings.ContainsAny(branch, "*?[") {
+					notes = append(notes, fmt.Sprintf("Skipping pattern '%s' of rule '%s' in policy '%s' as it contains wildcards", pattern, rule.Name, policyName))
+					continue
+				}
+
+				protection, has := protections[branch]
+				if !has {
+					protection = &github.BranchProtection{Branch: branch, EnforceAdmins: true}
+					protections[branch] = protection
+					branches = append(branches, branch)
+				}
+
+				requiredApprovals := rule.Threshold - 1
+				if requiredApprovals > maxGitHubRequiredApprovals {
+					notes = append(notes, fmt.Sprintf("Rule '%s' in policy '%s' requires %d approvals, but GitHub can require at most %d", rule.Name, policyName, requiredApprovals, maxGitHubRequiredApprovals))
+					requiredApprovals = maxGitHubRequiredApprovals
+				}
+				if requiredApprovals > protection.RequiredApprovingReviewCount {
+					protection.RequiredApprovingReviewCount = requiredApprovals
+					// gittuf only counts approvals of the commit being merged
+					protection.DismissStaleReviews = true
+				}
+				if len(rule.ForcePushKeyIDs) > 0 {
+					protection.AllowForcePushes = true
+				}
+				if len(rule.DeletionKeyIDs) > 0 {
+					protection.AllowDeletions = true
+				}
+			}
+		}
+	}
+
+	sort.Strings(branches)
+	summary := make([]*github.BranchProtection, 0, len(branches))
+	for _, branch := range branches {
+		summary = append(summary, protections[branch])
+	}
+
+	return summary, notes, nil
+}
@@ -0,0 +1,148 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/github"
+	"github.com/gittuf/gittuf/internal/signerverifier"
+	"github.com/gittuf/gittuf/internal/signerverifier/dsse"
+	"github.com/gittuf/gittuf/internal/signerverifier/gpg"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportBranchProtections(t *testing.T) {
+	newTargetsMetadata := func(t *testing.T) *tuf.TargetsMetadata {
+		t.Helper()
+
+		targetsMetadata := InitializeTargetsMetadata()
+		targetsMetadata, err := AddTeamMembers(targetsMetadata, "maintainers", []*tuf.Key{{KeyID: "maintainer-key"}}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsMetadata, err = AddDelegation(targetsMetadata, "protect-files", nil, []string{"file:*"}, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return targetsMetadata
+	}
+
+	protections := []*github.BranchProtection{
+		{Branch: "main", RequiredApprovingReviewCount: 2, AllowForcePushes: true},
+		{Branch: "release", RequireCodeOwnerReviews: true},
+	}
+
+	t.Run("rules generated", func(t *testing.T) {
+		targetsMetadata, notes, err := ImportBranchProtections(newTargetsMetadata(t), protections, nil, []string{"maintainers"}, true)
+		assert.Nil(t, err)
+		assert.Len(t, notes, 2)
+
+		rules := targetsMetadata.Delegations.Roles
+		assert.Len(t, rules, 4)
+		assert.Equal(t, "protect-files", rules[0].Name)
+		assert.Equal(t, "github-branch-protection:main", rules[1].Name)
+		assert.Equal(t, []string{"git:refs/heads/main"}, rules[1].Paths)
+		assert.Equal(t, []string{"maintainers"}, rules[1].TeamIDs)
+		assert.Equal(t, 3, rules[1].Threshold)
+		assert.Equal(t, "github-branch-protection:release", rules[2].Name)
+		assert.Equal(t, 1, rules[2].Threshold)
+		assert.Equal(t, AllowRuleName, rules[3].Name)
+	})
+
+	t.Run("rules updated and removed", func(t *testing.T) {
+		targetsMetadata, _, err := ImportBranchProtections(newTargetsMetadata(t), protections, nil, []string{"maintainers"}, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsMetadata, err = SetForcePushAuthorizers(targetsMetadata, "github-branch-protection:main", []*tuf.Key{{KeyID: "maintainer-key"}})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		updated := []*github.BranchProtection{{Branch: "main", RequiredApprovingReviewCount: 1}}
+
+		// Rules for other branches are retained unless stale rules are removed
+		targetsMetadata, _, err = ImportBranchProtections(targetsMetadata, updated, nil, []string{"maintainers"}, false)
+		assert.Nil(t, err)
+		assert.Len(t, targetsMetadata.Delegations.Roles, 4)
+
+		targetsMetadata, _, err = ImportBranchProtections(targetsMetadata, updated, nil, []string{"maintainers"}, true)
+		assert.Nil(t, err)
+		rules := targetsMetadata.Delegations.Roles
+		assert.Len(t, rules, 3)
+		assert.Equal(t, "github-branch-protection:main", rules[1].Name)
+		assert.Equal(t, 2, rules[1].Threshold)
+		assert.Equal(t, []string{"maintainer-key"}, rules[1].ForcePushKeyIDs)
+	})
+
+	t.Run("no principals", func(t *testing.T) {
+		_, _, err := ImportBranchProtections(newTargetsMetadata(t), protections, nil, nil, true)
+		assert.ErrorIs(t, err, ErrNoBranchProtectionPrincipals)
+	})
+
+	t.Run("unknown principals", func(t *testing.T) {
+		_, _, err := ImportBranchProtections(newTargetsMetadata(t), protections, []string{"alice"}, nil, true)
+		assert.ErrorIs(t, err, ErrPersonNotFound)
+
+		_, _, err = ImportBranchProtections(newTargetsMetadata(t), protections, nil, []string{"security"}, true)
+		assert.ErrorIs(t, err, ErrTeamNotFound)
+	})
+}
+
+func TestStateBranchProtections(t *testing.T) {
+	state := createTestStateWithPolicy(t)
+
+	gpgKey, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targetsMetadata, err := state.GetTargetsMetadata(TargetsRoleName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsMetadata, err = AddDelegation(targetsMetadata, "main-approvals", []*tuf.Key{gpgKey}, []string{"git:refs/heads/main"}, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsMetadata, err = SetDeletionAuthorizers(targetsMetadata, "main-approvals", []*tuf.Key{gpgKey})
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsMetadata, err = AddDelegation(targetsMetadata, "protect-releases", []*tuf.Key{gpgKey}, []string{"git:refs/heads/release/*"}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsMetadata, err = AddDelegation(targetsMetadata, "freeze-develop", []*tuf.Key{gpgKey}, []string{"git:refs/heads/develop"}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsMetadata, err = SetRuleEffect(targetsMetadata, "freeze-develop", RuleEffectDeny)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signer, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(rootKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsEnv, err := dsse.CreateEnvelope(targetsMetadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsEnv, err = dsse.SignEnvelope(context.Background(), targetsEnv, signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	state.TargetsEnvelope = targetsEnv
+
+	protections, notes, err := state.BranchProtections()
+	assert.Nil(t, err)
+	assert.Equal(t, []*github.BranchProtection{
+		{Branch: "main", RequiredApprovingReviewCount: 2, DismissStaleReviews: true, EnforceAdmins: true, AllowDeletions: true},
+	}, protections)
+	assert.Len(t, notes, 2)
+}
@@ -0,0 +1,95 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/cel-go/cel"
+)
+
+var ErrInvalidRuleCondition = errors.New("invalid rule condition")
+
+// newConditionEnv returns the CEL environment that rule conditions are
+// compiled in. The variables declared match the facts of a change, except that
+// pushedAt is a timestamp so that conditions can use CEL's timestamp functions,
+// such as getDayOfWeek().
+func newConditionEnv() (*cel.Env, error) {
+	return cel.NewEnv(
+		cel.Variable("ref", cel.StringType),
+		cel.Variable("entryID", cel.StringType),
+		cel.Variable("pusher", cel.MapType(cel.StringType, cel.StringType)),
+		cel.Variable("pushedAt", cel.TimestampType),
+		cel.Variable("commit", cel.MapType(cel.StringType, cel.DynType)),
+		cel.Variable("approvers", cel.ListType(cel.StringType)),
+	)
+}
+
+// compileCondition compiles the CEL expression condition, which must evaluate
+// to a bool.
+func compileCondition(condition string) (cel.Program, error) {
+	env, err := newConditionEnv()
+	if err != nil {
+		return nil, err
+	}
+
+	ast, issues := env.Compile(condition)
+	if issues != nil && issues.Err() != nil {
+		return nil, fmt.Errorf("%w '%s': %w", ErrInvalidRuleCondition, condition, issues.Err())
+	}
+	if outputType := ast.OutputType(); !outputType.IsExactType(cel.BoolType) && !outputType.IsExactType(cel.DynType) {
+		return nil, fmt.Errorf("%w '%s': expression must evaluate to bool, found %s", ErrInvalidRuleCondition, condition, outputType)
+	}
+
+	return env.Program(ast)
+}
+
+// ValidateRuleCondition checks that condition is a valid CEL expression that
+// evaluates to a bool.
+func ValidateRuleCondition(condition string) error {
+	_, err := compileCondition(condition)
+	return err
+}
+
+// evaluateCondition evaluates the CEL expression condition of the rule using
+// the facts of a change, and returns true if the change meets the condition.
+func evaluateCondition(ruleName, condition string, facts *changeFacts) (bool, error) {
+	program, err := compileCondition(condition)
+	if err != nil {
+		return false, fmt.Errorf("rule '%s': %w", ruleName, err)
+	}
+
+	// Pass the facts as generic JSON values, so that they are accessed using
+	// their JSON field names
+	factsBytes, err := json.Marshal(facts)
+	if err != nil {
+		return false, err
+	}
+	activation := map[string]any{}
+	if err := json.Unmarshal(factsBytes, &activation); err != nil {
+		return false, err
+	}
+	if _, hasCommit := activation["commit"]; !hasCommit {
+		activation["commit"] = map[string]any{}
+	}
+	pushedAt, err := time.Parse(time.RFC3339, facts.PushedAt)
+	if err != nil {
+		return false, err
+	}
+	activation["pushedAt"] = pushedAt
+
+	result, _, err := program.Eval(activation)
+	if err != nil {
+		return false, fmt.Errorf("evaluating condition '%s' of rule '%s' failed: %w", condition, ruleName, err)
+	}
+
+	met, ok := result.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("%w '%s' of rule '%s': expression must evaluate to bool", ErrInvalidRuleCondition, condition, ruleName)
+	}
+
+	return met, nil
+}
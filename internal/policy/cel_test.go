@@ -0,0 +1,80 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateRuleCondition(t *testing.T) {
+	t.Run("valid condition", func(t *testing.T) {
+		err := ValidateRuleCondition("commit.author.email.endsWith('@example.com')")
+		assert.Nil(t, err)
+
+		err = ValidateRuleCondition("pushedAt.getDayOfWeek() >= 1 && pushedAt.getDayOfWeek() <= 5")
+		assert.Nil(t, err)
+	})
+
+	t.Run("syntax error", func(t *testing.T) {
+		err := ValidateRuleCondition("ref ==")
+		assert.ErrorIs(t, err, ErrInvalidRuleCondition)
+	})
+
+	t.Run("undeclared variable", func(t *testing.T) {
+		err := ValidateRuleCondition("branch == 'main'")
+		assert.ErrorIs(t, err, ErrInvalidRuleCondition)
+	})
+
+	t.Run("not a bool", func(t *testing.T) {
+		err := ValidateRuleCondition("size(approvers)")
+		assert.ErrorIs(t, err, ErrInvalidRuleCondition)
+	})
+}
+
+func TestEvaluateCondition(t *testing.T) {
+	facts := &changeFacts{
+		Ref:      "refs/heads/main",
+		Pusher:   &identityFacts{Name: "Jane Doe", Email: "jane.doe@example.com"},
+		PushedAt: "2024-01-06T12:00:00Z", // a Saturday
+		Commit: &commitFacts{
+			ID:        "abcdef",
+			Message:   "Add feature",
+			Author:    &identityFacts{Name: "Jane Doe", Email: "jane.doe@example.com"},
+			Committer: &identityFacts{Name: "Jane Doe", Email: "jane.doe@example.com"},
+			Parents:   []string{},
+		},
+		Approvers: []string{"github:john"},
+	}
+
+	tests := map[string]struct {
+		condition string
+		expected  bool
+	}{
+		"author email matches":        {condition: "commit.author.email.endsWith('@example.com')", expected: true},
+		"author email does not match": {condition: "commit.author.email.endsWith('@corp.com')", expected: false},
+		"pushed on weekday":           {condition: "pushedAt.getDayOfWeek() >= 1 && pushedAt.getDayOfWeek() <= 5", expected: false},
+		"pushed on weekend":           {condition: "pushedAt.getDayOfWeek() in [0, 6]", expected: true},
+		"approved":                    {condition: "size(approvers) > 0", expected: true},
+		"ref":                         {condition: "ref == 'refs/heads/main' && pusher.name == 'Jane Doe'", expected: true},
+	}
+
+	for name, test := range tests {
+		met, err := evaluateCondition("protect-main", test.condition, facts)
+		assert.Nil(t, err, name)
+		assert.Equal(t, test.expected, met, name)
+	}
+
+	t.Run("no commit", func(t *testing.T) {
+		tagFacts := *facts
+		tagFacts.Commit = nil
+
+		met, err := evaluateCondition("protect-main", "!has(commit.id)", &tagFacts)
+		assert.Nil(t, err)
+		assert.True(t, met)
+
+		_, err = evaluateCondition("protect-main", "commit.author.email.endsWith('@example.com')", &tagFacts)
+		assert.NotNil(t, err)
+	})
+}
@@ -0,0 +1,255 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/gittuf/gittuf/internal/tuf"
+	"sigs.k8s.io/yaml"
+)
+
+// CodeOwnersRuleNamePrefix prefixes the names of rules generated from a
+// CODEOWNERS file. The rest of the name is the CODEOWNERS pattern.
+const CodeOwnersRuleNamePrefix = "codeowners:"
+
+var (
+	ErrInvalidCodeOwners        = errors.New("invalid CODEOWNERS file")
+	ErrInvalidCodeOwnersMapping = errors.New("invalid CODEOWNERS mapping")
+	ErrCodeOwnerNotMapped       = errors.New("CODEOWNERS owners not mapped to principals in policy")
+)
+
+// CodeOwnersEntry is a line in a CODEOWNERS file that assigns owners to the
+// files matching a pattern.
+type CodeOwnersEntry struct {
+	Line    int      `json:"line"`
+	Pattern string   `json:"pattern"`
+	Owners  []string `json:"owners"`
+}
+
+// CodeOwnerPrincipal identifies the principals in a policy file that a
+// CODEOWNERS owner, such as "@octocat", "@org/team", or an email address,
+// corresponds to.
+type CodeOwnerPrincipal struct {
+	PersonID string   `json:"person,omitempty"`
+	TeamID   string   `json:"team,omitempty"`
+	KeyIDs   []string `json:"keys,omitempty"`
+}
+
+// CodeOwnersMapping maps CODEOWNERS owners to principals in a policy file.
+type CodeOwnersMapping map[string]*CodeOwnerPrincipal
+
+// ParseCodeOwners parses the entries in a CODEOWNERS file. Comments, blank
+// lines, and section headers are skipped.
+func ParseCodeOwners(contents []byte) ([]*CodeOwnersEntry, error) {
+	entries := []*CodeOwnersEntry{}
+
+	scanner := bufio.NewScanner(bytes.NewReader(contents))
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "[") || strings.HasPrefix(line, "^[") {
+			continue
+		}
+
+		fields := strings.Fields(line)
+		entry := &CodeOwnersEntry{Line: lineNumber, Pattern: strings.ReplaceAll(fields[0], `\#`, "#"), Owners: []string{}}
+		if strings.HasPrefix(entry.Pattern, "!") {
+			return nil, fmt.Errorf("%w: negated pattern '%s' on line %d is not supported", ErrInvalidCodeOwners, entry.Pattern, lineNumber)
+		}
+
+		for _, owner := range fields[1:] {
+			if strings.HasPrefix(owner, "#") {
+				// Rest of the line is a comment
+				break
+			}
+			if !strings.Contains(owner, "@") {
+				return nil, fmt.Errorf("%w: invalid owner '%s' on line %d", ErrInvalidCodeOwners, owner, lineNumber)
+			}
+			entry.Owners = append(entry.Owners, owner)
+		}
+
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Join(ErrInvalidCodeOwners, err)
+	}
+
+	return entries, nil
+}
+
+// LoadCodeOwnersMapping parses a CodeOwnersMapping from YAML or JSON.
+func LoadCodeOwnersMapping(contents []byte) (CodeOwnersMapping, error) {
+	mapping := CodeOwnersMapping{}
+	if err := yaml.UnmarshalStrict(contents, &mapping); err != nil {
+		return nil, errors.Join(ErrInvalidCodeOwnersMapping, err)
+	}
+
+	for owner, principal := range mapping {
+		if principal == nil || (principal.PersonID == "" && principal.TeamID == "" && len(principal.KeyIDs) == 0) {
+			return nil, fmt.Errorf("%w: owner '%s' is not mapped to a person, team, or keys", ErrInvalidCodeOwnersMapping, owner)
+		}
+	}
+
+	return mapping, nil
+}
+
+// CodeOwnersPatterns returns the rule patterns that protect the files matched
+// by a CODEOWNERS pattern. As in CODEOWNERS files, a pattern that starts with
+// or contains a "/" is relative to the root of the repository, and other
+// patterns match at any depth. A pattern that ends with "/" or a name without
+// wildcards also matches everything in the directories it matches.
+func CodeOwnersPatterns(pattern string) []string {
+	anchored := strings.HasPrefix(pattern, "/")
+	filePattern := strings.TrimPrefix(pattern, "/")
+	isDirectory := strings.HasSuffix(filePattern, "/")
+	filePattern = strings.TrimSuffix(filePattern, "/")
+
+	if filePattern == "" {
+		return []string{fmt.Sprintf("%s:**", fileRuleScheme)}
+	}
+
+	if strings.Contains(filePattern, "/") {
+		anchored = true
+	}
+	if !anchored && !strings.HasPrefix(filePattern, "**/") {
+		filePattern = "**/" + filePattern
+	}
+
+	filePattern = fmt.Sprintf("%s:%s", fileRuleScheme, filePattern)
+	if isDirectory {
+		return []string{filePattern + "/**"}
+	}
+
+	name := filePattern[strings.LastIndex(filePattern, "/")+1:]
+	if strings.ContainsAny(name, "*?[") {
+		return []string{filePattern}
+	}
+
+	return []string{filePattern, filePattern + "/**"}
+}
+
+// ImportCodeOwners replaces the rules in targetsMetadata that were previously
+// imported from a CODEOWNERS file with rules generated from entries. Each rule
+// protects the files matched by an entry's pattern, and is met by a signature
+// from any of the entry's owners, which are mapped to the people, teams, and
+// keys in targetsMetadata using mapping. Existing imported rules are updated in
+// place, so other settings such as their conditions are retained. Entries
+// without owners are skipped and returned, as they do not require approval
+// from anyone.
+func ImportCodeOwners(targetsMetadata *tuf.TargetsMetadata, entries []*CodeOwnersEntry, mapping CodeOwnersMapping) (*tuf.TargetsMetadata, []*CodeOwnersEntry, error) {
+	if err := validateCodeOwnersMapping(targetsMetadata, mapping); err != nil {
+		return nil, nil, err
+	}
+
+	unmappedOwners := []string{}
+	for _, entry := range entries {
+		for _, owner := range entry.Owners {
+			if _, mapped := mapping[owner]; !mapped && !slices.Contains(unmappedOwners, owner) {
+				unmappedOwners = append(unmappedOwners, owner)
+			}
+		}
+	}
+	if len(unmappedOwners) > 0 {
+		sort.Strings(unmappedOwners)
+		return nil, nil, fmt.Errorf("%w: %s", ErrCodeOwnerNotMapped, strings.Join(unmappedOwners, ", "))
+	}
+
+	// A later entry for the same pattern overrides earlier ones
+	lastEntries := map[string]*CodeOwnersEntry{}
+	for _, entry := range entries {
+		lastEntries[entry.Pattern] = entry
+	}
+
+	skipped := []*CodeOwnersEntry{}
+	importedRules := []tuf.Delegation{}
+	for _, entry := range entries {
+		if lastEntries[entry.Pattern] != entry {
+			continue
+		}
+		if len(entry.Owners) == 0 {
+			skipped = append(skipped, entry)
+			continue
+		}
+
+		rule := tuf.Delegation{
+			Name:  CodeOwnersRuleNamePrefix + entry.Pattern,
+			Paths: CodeOwnersPatterns(entry.Pattern),
+			Role:  tuf.Role{KeyIDs: []string{}, Threshold: 1},
+		}
+		for _, owner := range entry.Owners {
+			principal := mapping[owner]
+			if principal.PersonID != "" && !slices.Contains(rule.PersonIDs, principal.PersonID) {
+				rule.PersonIDs = append(rule.PersonIDs, principal.PersonID)
+			}
+			if principal.TeamID != "" && !slices.Contains(rule.TeamIDs, principal.TeamID) {
+				rule.TeamIDs = append(rule.TeamIDs, principal.TeamID)
+			}
+			for _, keyID := range principal.KeyIDs {
+				if !slices.Contains(rule.KeyIDs, keyID) {
+					rule.KeyIDs = append(rule.KeyIDs, keyID)
+				}
+			}
+		}
+
+		if existing := findRule(targetsMetadata.Delegations.Roles, rule.Name); existing != nil {
+			existing.Paths = rule.Paths
+			existing.Role = rule.Role
+			existing.PersonIDs = rule.PersonIDs
+			existing.TeamIDs = rule.TeamIDs
+			rule = *existing
+		}
+		importedRules = append(importedRules, rule)
+	}
+
+	// Rules that were not imported from CODEOWNERS keep their position, and
+	// the imported rules are placed before the allow rule
+	rules := []tuf.Delegation{}
+	for _, rule := range targetsMetadata.Delegations.Roles {
+		if rule.Name == AllowRuleName || strings.HasPrefix(rule.Name, CodeOwnersRuleNamePrefix) {
+			continue
+		}
+		rules = append(rules, rule)
+	}
+	rules = append(rules, importedRules...)
+	rules = append(rules, AllowRule())
+	targetsMetadata.Delegations.Roles = rules
+
+	return targetsMetadata, skipped, nil
+}
+
+// validateCodeOwnersMapping checks that the people, teams, and keys that
+// owners are mapped to exist in targetsMetadata.
+func validateCodeOwnersMapping(targetsMetadata *tuf.TargetsMetadata, mapping CodeOwnersMapping) error {
+	owners := mapKeys(mapping)
+	sort.Strings(owners)
+
+	for _, owner := range owners {
+		principal := mapping[owner]
+		if principal.PersonID != "" {
+			if _, exists := targetsMetadata.Delegations.People[principal.PersonID]; !exists {
+				return fmt.Errorf("%w: person '%s' for owner '%s'", ErrPersonNotFound, principal.PersonID, owner)
+			}
+		}
+		if principal.TeamID != "" {
+			if _, exists := targetsMetadata.Delegations.Teams[principal.TeamID]; !exists {
+				return fmt.Errorf("%w: team '%s' for owner '%s'", ErrTeamNotFound, principal.TeamID, owner)
+			}
+		}
+		for _, keyID := range principal.KeyIDs {
+			if _, exists := targetsMetadata.Delegations.Keys[keyID]; !exists {
+				return fmt.Errorf("%w: key '%s' for owner '%s' not found in policy", ErrInvalidCodeOwnersMapping, keyID, owner)
+			}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,175 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCodeOwners(t *testing.T) {
+	t.Run("valid file", func(t *testing.T) {
+		contents := []byte(`# Default owners
+*       @org/maintainers
+
+[Docs]
+/docs/  @octocat docs@example.com # docs team
+*.md
+`)
+
+		entries, err := ParseCodeOwners(contents)
+		assert.Nil(t, err)
+		assert.Equal(t, []*CodeOwnersEntry{
+			{Line: 2, Pattern: "*", Owners: []string{"@org/maintainers"}},
+			{Line: 5, Pattern: "/docs/", Owners: []string{"@octocat", "docs@example.com"}},
+			{Line: 6, Pattern: "*.md", Owners: []string{}},
+		}, entries)
+	})
+
+	t.Run("negated pattern", func(t *testing.T) {
+		_, err := ParseCodeOwners([]byte("!*.go @octocat\n"))
+		assert.ErrorIs(t, err, ErrInvalidCodeOwners)
+	})
+
+	t.Run("invalid owner", func(t *testing.T) {
+		_, err := ParseCodeOwners([]byte("*.go octocat\n"))
+		assert.ErrorIs(t, err, ErrInvalidCodeOwners)
+	})
+}
+
+func TestLoadCodeOwnersMapping(t *testing.T) {
+	mapping, err := LoadCodeOwnersMapping([]byte(`"@octocat":
+  person: octocat
+"@org/team":
+  team: team
+`))
+	assert.Nil(t, err)
+	assert.Equal(t, CodeOwnersMapping{
+		"@octocat":  {PersonID: "octocat"},
+		"@org/team": {TeamID: "team"},
+	}, mapping)
+
+	_, err = LoadCodeOwnersMapping([]byte(`"@octocat": {}`))
+	assert.ErrorIs(t, err, ErrInvalidCodeOwnersMapping)
+
+	_, err = LoadCodeOwnersMapping([]byte(`"@octocat": {user: octocat}`))
+	assert.ErrorIs(t, err, ErrInvalidCodeOwnersMapping)
+}
+
+func TestCodeOwnersPatterns(t *testing.T) {
+	tests := map[string][]string{
+		"*":              {"file:**/*"},
+		"/":              {"file:**"},
+		"*.go":           {"file:**/*.go"},
+		"/build.sh":      {"file:build.sh", "file:build.sh/**"},
+		"docs/":          {"file:**/docs/**"},
+		"/docs/":         {"file:docs/**"},
+		"apps":           {"file:**/apps", "file:**/apps/**"},
+		"src/internal":   {"file:src/internal", "file:src/internal/**"},
+		"docs/*.md":      {"file:docs/*.md"},
+		"**/logs":        {"file:**/logs", "file:**/logs/**"},
+		"/src/**/tests/": {"file:src/**/tests/**"},
+	}
+
+	for pattern, expected := range tests {
+		assert.Equal(t, expected, CodeOwnersPatterns(pattern), pattern)
+	}
+
+	// The generated patterns match the same files as the CODEOWNERS pattern
+	assert.True(t, tuf.MatchPattern(CodeOwnersPatterns("*.go")[0], "file:main.go"))
+	assert.True(t, tuf.MatchPattern(CodeOwnersPatterns("*.go")[0], "file:internal/policy/policy.go"))
+	assert.True(t, tuf.MatchPattern(CodeOwnersPatterns("/docs/")[0], "file:docs/cli/gittuf.md"))
+	assert.False(t, tuf.MatchPattern(CodeOwnersPatterns("/docs/")[0], "file:internal/docs/README.md"))
+}
+
+func TestImportCodeOwners(t *testing.T) {
+	newTargetsMetadata := func(t *testing.T) *tuf.TargetsMetadata {
+		t.Helper()
+
+		targetsMetadata := InitializeTargetsMetadata()
+		targetsMetadata, err := AddPerson(targetsMetadata, "octocat", []*tuf.Key{{KeyID: "octocat-key"}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsMetadata, err = AddDelegation(targetsMetadata, "protect-main", nil, []string{"git:refs/heads/main"}, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return targetsMetadata
+	}
+
+	mapping := CodeOwnersMapping{"@octocat": {PersonID: "octocat"}}
+
+	t.Run("rules generated", func(t *testing.T) {
+		entries := []*CodeOwnersEntry{
+			{Line: 1, Pattern: "*.go", Owners: []string{"@octocat"}},
+			{Line: 2, Pattern: "*.md", Owners: []string{}},
+			{Line: 3, Pattern: "/docs/", Owners: []string{"@octocat"}},
+		}
+
+		targetsMetadata, skipped, err := ImportCodeOwners(newTargetsMetadata(t), entries, mapping)
+		assert.Nil(t, err)
+		assert.Equal(t, []*CodeOwnersEntry{entries[1]}, skipped)
+
+		rules := targetsMetadata.Delegations.Roles
+		assert.Len(t, rules, 4)
+		assert.Equal(t, "protect-main", rules[0].Name)
+		assert.Equal(t, "codeowners:*.go", rules[1].Name)
+		assert.Equal(t, []string{"file:**/*.go"}, rules[1].Paths)
+		assert.Equal(t, []string{"octocat"}, rules[1].PersonIDs)
+		assert.Equal(t, 1, rules[1].Threshold)
+		assert.Equal(t, "codeowners:/docs/", rules[2].Name)
+		assert.Equal(t, AllowRuleName, rules[3].Name)
+	})
+
+	t.Run("rules updated and removed", func(t *testing.T) {
+		targetsMetadata, _, err := ImportCodeOwners(newTargetsMetadata(t), []*CodeOwnersEntry{
+			{Pattern: "*.go", Owners: []string{"@octocat"}},
+			{Pattern: "*.md", Owners: []string{"@octocat"}},
+		}, mapping)
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsMetadata, err = SetRuleConditions(targetsMetadata, "codeowners:*.go", []string{`ref == "refs/heads/main"`})
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsMetadata.Delegations.AddKey(&tuf.Key{KeyID: "key"})
+
+		targetsMetadata, _, err = ImportCodeOwners(targetsMetadata, []*CodeOwnersEntry{
+			{Pattern: "*.go", Owners: []string{"@octocat", "@dev"}},
+		}, CodeOwnersMapping{"@octocat": {PersonID: "octocat"}, "@dev": {KeyIDs: []string{"key"}}})
+		assert.Nil(t, err)
+
+		rules := targetsMetadata.Delegations.Roles
+		assert.Len(t, rules, 3)
+		assert.Equal(t, "codeowners:*.go", rules[1].Name)
+		assert.Equal(t, []string{"key"}, rules[1].KeyIDs)
+		assert.Equal(t, []string{"octocat"}, rules[1].PersonIDs)
+		assert.Equal(t, []string{`ref == "refs/heads/main"`}, rules[1].Conditions)
+	})
+
+	t.Run("last entry for pattern wins", func(t *testing.T) {
+		targetsMetadata, _, err := ImportCodeOwners(newTargetsMetadata(t), []*CodeOwnersEntry{
+			{Pattern: "*.go", Owners: []string{"@octocat"}},
+			{Pattern: "*.go", Owners: []string{}},
+		}, mapping)
+		assert.Nil(t, err)
+		assert.Len(t, targetsMetadata.Delegations.Roles, 2)
+	})
+
+	t.Run("unmapped owners", func(t *testing.T) {
+		_, _, err := ImportCodeOwners(newTargetsMetadata(t), []*CodeOwnersEntry{
+			{Pattern: "*.go", Owners: []string{"@dev", "@octocat", "@ops"}},
+		}, mapping)
+		assert.ErrorIs(t, err, ErrCodeOwnerNotMapped)
+		assert.Contains(t, err.Error(), "@dev, @ops")
+	})
+
+	t.Run("unknown principal", func(t *testing.T) {
+		_, _, err := ImportCodeOwners(newTargetsMetadata(t), nil, CodeOwnersMapping{"@org/team": {TeamID: "team"}})
+		assert.ErrorIs(t, err, ErrTeamNotFound)
+	})
+}
@@ -0,0 +1,104 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"strings"
+
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// conventionalCommitRegex matches the header of a commit message that follows
+// the Conventional Commits specification, e.g., "feat(cli)!: add command".
+var conventionalCommitRegex = regexp.MustCompile(`^[a-zA-Z]+(\([^()\r\n]+\))?!?: \S`)
+
+// hasCommitMessageRules returns true if the verifier constrains the messages of
+// commits.
+func (v *Verifier) hasCommitMessageRules() bool {
+	return v.requireSignoff || v.requireConventionalCommits || v.commitMessagePattern != ""
+}
+
+// verifyCommitMessages checks that the messages of the commits added to the
+// reference by entry meet the commit message rules of the verifiers. Merge
+// commits are not checked, as their messages are typically generated by Git or
+// a forge. The verifiers of deny rules are ignored.
+func verifyCommitMessages(ctx context.Context, repo *git.Repository, verifiers []*Verifier, entry *rsl.ReferenceEntry) error {
+	messageVerifiers := []*Verifier{}
+	for _, verifier := range verifiers {
+		if !verifier.deny && verifier.hasCommitMessageRules() {
+			messageVerifiers = append(messageVerifiers, verifier)
+		}
+	}
+	if len(messageVerifiers) == 0 || entry.IsDeletion() {
+		return nil
+	}
+
+	commits, err := getCommits(repo, entry)
+	if err != nil {
+		return err
+	}
+	slog.Debug(fmt.Sprintf("Checking messages of %d commit(s) added to '%s'...", len(commits), entry.RefName))
+
+	for _, commit := range commits {
+		if len(commit.ParentHashes) > 1 {
+			continue
+		}
+
+		for _, verifier := range messageVerifiers {
+			if err := verifier.verifyCommitMessage(commit); err != nil {
+				explain(ctx, "Message of commit '%s' does not meet rule '%s': %s", commit.Hash, verifier.Name(), err)
+				return err
+			}
+		}
+	}
+	explain(ctx, "Messages of %d commit(s) meet commit message rules", len(commits))
+
+	return nil
+}
+
+// verifyCommitMessage checks that the message of commit meets the verifier's
+// commit message rules.
+func (v *Verifier) verifyCommitMessage(commit *object.Commit) error {
+	if v.requireSignoff && !isSignedOff(commit) {
+		return fmt.Errorf("%w '%s': commit '%s' is not signed off by its author '%s'", ErrConstraintViolated, v.Name(), commit.Hash, commit.Author.Email)
+	}
+
+	if v.requireConventionalCommits && !conventionalCommitRegex.MatchString(commit.Message) {
+		return fmt.Errorf("%w '%s': message of commit '%s' does not follow Conventional Commits", ErrConstraintViolated, v.Name(), commit.Hash)
+	}
+
+	if v.commitMessagePattern != "" {
+		pattern, err := regexp.Compile(v.commitMessagePattern)
+		if err != nil {
+			return fmt.Errorf("%w of rule '%s': %w", ErrInvalidCommitMessagePattern, v.Name(), err)
+		}
+		if !pattern.MatchString(commit.Message) {
+			return fmt.Errorf("%w '%s': message of commit '%s' does not match '%s'", ErrConstraintViolated, v.Name(), commit.Hash, v.commitMessagePattern)
+		}
+	}
+
+	return nil
+}
+
+// isSignedOff returns true if the commit's message has a Signed-off-by trailer
+// for the commit's author.
+func isSignedOff(commit *object.Commit) bool {
+	for _, line := range strings.Split(commit.Message, "\n") {
+		value, found := strings.CutPrefix(strings.TrimSpace(line), "Signed-off-by:")
+		if !found {
+			continue
+		}
+
+		if strings.HasSuffix(strings.ToLower(strings.TrimSpace(value)), "<"+strings.ToLower(commit.Author.Email)+">") {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,82 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"testing"
+
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyCommitMessage(t *testing.T) {
+	newCommit := func(message string) *object.Commit {
+		return &object.Commit{
+			Hash:    plumbing.NewHash("abcdef"),
+			Author:  object.Signature{Name: "Jane Doe", Email: "jane.doe@example.com"},
+			Message: message,
+		}
+	}
+
+	tests := map[string]struct {
+		verifier    *Verifier
+		message     string
+		expectedErr error
+	}{
+		"no rules": {
+			verifier: &Verifier{name: "protect-main"},
+			message:  "Add feature",
+		},
+		"signed off": {
+			verifier: &Verifier{name: "protect-main", requireSignoff: true},
+			message:  "Add feature\n\nSigned-off-by: Jane Doe <Jane.Doe@example.com>\n",
+		},
+		"not signed off": {
+			verifier:    &Verifier{name: "protect-main", requireSignoff: true},
+			message:     "Add feature\n",
+			expectedErr: ErrConstraintViolated,
+		},
+		"signed off by someone else": {
+			verifier:    &Verifier{name: "protect-main", requireSignoff: true},
+			message:     "Add feature\n\nSigned-off-by: John Doe <john.doe@example.com>\n",
+			expectedErr: ErrConstraintViolated,
+		},
+		"conventional commit": {
+			verifier: &Verifier{name: "protect-main", requireConventionalCommits: true},
+			message:  "feat(cli)!: add feature\n",
+		},
+		"not conventional commit": {
+			verifier:    &Verifier{name: "protect-main", requireConventionalCommits: true},
+			message:     "Add feature\n",
+			expectedErr: ErrConstraintViolated,
+		},
+		"matches pattern": {
+			verifier: &Verifier{name: "protect-main", commitMessagePattern: `#[0-9]+`},
+			message:  "Add feature\n\nFixes #42\n",
+		},
+		"does not match pattern": {
+			verifier:    &Verifier{name: "protect-main", commitMessagePattern: `#[0-9]+`},
+			message:     "Add feature\n",
+			expectedErr: ErrConstraintViolated,
+		},
+		"invalid pattern": {
+			verifier:    &Verifier{name: "protect-main", commitMessagePattern: `[`},
+			message:     "Add feature\n",
+			expectedErr: ErrInvalidCommitMessagePattern,
+		},
+		"all rules met": {
+			verifier: &Verifier{name: "protect-main", requireSignoff: true, requireConventionalCommits: true, commitMessagePattern: `#[0-9]+`},
+			message:  "fix: handle empty input\n\nFixes #42\n\nSigned-off-by: Jane Doe <jane.doe@example.com>\n",
+		},
+	}
+
+	for name, test := range tests {
+		err := test.verifier.verifyCommitMessage(newCommit(test.message))
+		if test.expectedErr == nil {
+			assert.Nil(t, err, name)
+		} else {
+			assert.ErrorIs(t, err, test.expectedErr, name)
+		}
+	}
+}
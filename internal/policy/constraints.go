@@ -0,0 +1,135 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+var ErrConstraintViolated = errors.New("change violates constraint of rule")
+
+// changeFacts describes a change to a Git reference recorded in the RSL. It is
+// the input that the constraints of the rules protecting the reference, such as
+// CEL conditions and Rego policies, are evaluated against.
+type changeFacts struct {
+	Ref     string `json:"ref"`
+	EntryID string `json:"entryID"`
+
+	// Pusher is the author of the RSL entry, and PushedAt is when the entry
+	// was created.
+	Pusher   *identityFacts `json:"pusher"`
+	PushedAt string         `json:"pushedAt"`
+
+	// Commit is the commit the reference is set to. It is not set if the
+	// reference points to another type of object.
+	Commit *commitFacts `json:"commit,omitempty"`
+
+	// Approvers are the principals that approved the change.
+	Approvers []string `json:"approvers"`
+}
+
+// commitFacts describes a commit.
+type commitFacts struct {
+	ID        string         `json:"id"`
+	Message   string         `json:"message"`
+	Author    *identityFacts `json:"author"`
+	Committer *identityFacts `json:"committer"`
+	Parents   []string       `json:"parents"`
+}
+
+// identityFacts describes the author or committer of a Git object.
+type identityFacts struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+	Time  string `json:"time"`
+}
+
+// newChangeFacts returns the facts of the change recorded in entry.
+func newChangeFacts(repo *git.Repository, entry *rsl.ReferenceEntry, entryCommit *object.Commit, approvers []string) *changeFacts {
+	facts := &changeFacts{
+		Ref:       entry.RefName,
+		EntryID:   entry.ID.String(),
+		Pusher:    newIdentityFacts(entryCommit.Author),
+		PushedAt:  entryCommit.Author.When.UTC().Format(time.RFC3339),
+		Approvers: approvers,
+	}
+	if facts.Approvers == nil {
+		facts.Approvers = []string{}
+	}
+
+	commit, err := gitinterface.GetCommit(repo, entry.TargetID)
+	if err != nil {
+		// The reference does not point to a commit
+		return facts
+	}
+
+	parents := make([]string, 0, len(commit.ParentHashes))
+	for _, parentID := range commit.ParentHashes {
+		parents = append(parents, parentID.String())
+	}
+	facts.Commit = &commitFacts{
+		ID:        commit.Hash.String(),
+		Message:   commit.Message,
+		Author:    newIdentityFacts(commit.Author),
+		Committer: newIdentityFacts(commit.Committer),
+		Parents:   parents,
+	}
+
+	return facts
+}
+
+func newIdentityFacts(signature object.Signature) *identityFacts {
+	return &identityFacts{
+		Name:  signature.Name,
+		Email: signature.Email,
+		Time:  signature.When.UTC().Format(time.RFC3339),
+	}
+}
+
+// verifyConstraints checks that the change described by facts meets the
+// constraints of every rule that protects the reference, i.e., the rule's CEL
+// conditions and Rego policy. The verifiers of deny rules are ignored, as their
+// keys are exceptions to the rule rather than the rule's requirements.
+func verifyConstraints(ctx context.Context, verifiers []*Verifier, facts *changeFacts) error {
+	for _, verifier := range verifiers {
+		if verifier.deny {
+			continue
+		}
+
+		for _, condition := range verifier.conditions {
+			met, err := evaluateCondition(verifier.Name(), condition, facts)
+			if err != nil {
+				return err
+			}
+			if !met {
+				explain(ctx, "Condition '%s' of rule '%s' not met", condition, verifier.Name())
+				return fmt.Errorf("%w '%s': condition '%s' not met", ErrConstraintViolated, verifier.Name(), condition)
+			}
+			explain(ctx, "Condition '%s' of rule '%s' met", condition, verifier.Name())
+		}
+
+		if verifier.regoPolicy == "" {
+			continue
+		}
+
+		messages, err := evaluateRegoPolicy(ctx, verifier.Name(), verifier.regoPolicy, facts)
+		if err != nil {
+			return err
+		}
+		explain(ctx, "Rego policy of rule '%s' denied %d time(s)", verifier.Name(), len(messages))
+		if len(messages) > 0 {
+			return fmt.Errorf("%w '%s': %s", ErrConstraintViolated, verifier.Name(), strings.Join(messages, "; "))
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,335 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"sort"
+
+	"github.com/gittuf/gittuf/internal/common/set"
+	"github.com/gittuf/gittuf/internal/tuf"
+	sslibdsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
+)
+
+// ChangeType indicates how an item in a policy state differs from the same
+// item in another policy state.
+type ChangeType string
+
+const (
+	ChangeAdded    ChangeType = "added"
+	ChangeRemoved  ChangeType = "removed"
+	ChangeModified ChangeType = "modified"
+)
+
+// RoleDiff records the changes to a top level role in the root of trust.
+type RoleDiff struct {
+	Name          string     `json:"name"`
+	Change        ChangeType `json:"change"`
+	AddedKeyIDs   []string   `json:"added_keyids"`
+	RemovedKeyIDs []string   `json:"removed_keyids"`
+	OldThreshold  int        `json:"old_threshold"`
+	NewThreshold  int        `json:"new_threshold"`
+}
+
+// RuleDiff records the changes to a rule in a policy file.
+type RuleDiff struct {
+	PolicyName    string     `json:"policy_name"`
+	Name          string     `json:"name"`
+	Change        ChangeType `json:"change"`
+	AddedKeyIDs   []string   `json:"added_keyids"`
+	RemovedKeyIDs []string   `json:"removed_keyids"`
+	AddedPaths    []string   `json:"added_paths"`
+	RemovedPaths  []string   `json:"removed_paths"`
+	OldThreshold  int        `json:"old_threshold"`
+	NewThreshold  int        `json:"new_threshold"`
+
+	AddedPersonIDs   []string `json:"added_personids"`
+	RemovedPersonIDs []string `json:"removed_personids"`
+	AddedTeamIDs     []string `json:"added_teamids"`
+	RemovedTeamIDs   []string `json:"removed_teamids"`
+}
+
+// SignersDiff records the changes to the set of keys that have signed a piece
+// of metadata, identified by its role name.
+type SignersDiff struct {
+	Name          string   `json:"name"`
+	AddedKeyIDs   []string `json:"added_keyids"`
+	RemovedKeyIDs []string `json:"removed_keyids"`
+}
+
+// PersonDiff records the changes to the keys of a person in a policy file.
+type PersonDiff struct {
+	PersonID      string     `json:"personid"`
+	Change        ChangeType `json:"change"`
+	AddedKeyIDs   []string   `json:"added_keyids"`
+	RemovedKeyIDs []string   `json:"removed_keyids"`
+}
+
+// TeamDiff records the changes made to a team and its people when syncing the
+// team with an external source of membership.
+type TeamDiff struct {
+	TeamID           string        `json:"teamid"`
+	Change           ChangeType    `json:"change"`
+	AddedPersonIDs   []string      `json:"added_personids"`
+	RemovedPersonIDs []string      `json:"removed_personids"`
+	People           []*PersonDiff `json:"people"`
+
+	// SkippedPersonIDs are members that were not added to the team as they
+	// have no usable keys.
+	SkippedPersonIDs []string `json:"skipped_personids"`
+}
+
+// IsEmpty returns true if the team already matched its source of membership.
+func (d *TeamDiff) IsEmpty() bool {
+	return d.Change != ChangeAdded && len(d.AddedPersonIDs) == 0 && len(d.RemovedPersonIDs) == 0 && len(d.People) == 0
+}
+
+// StateDiff is a summary of the differences between two policy states.
+type StateDiff struct {
+	Roles   []*RoleDiff    `json:"roles"`
+	Rules   []*RuleDiff    `json:"rules"`
+	Signers []*SignersDiff `json:"signers"`
+}
+
+// IsEmpty returns true if the diff records no differences.
+func (d *StateDiff) IsEmpty() bool {
+	return len(d.Roles) == 0 && len(d.Rules) == 0 && len(d.Signers) == 0
+}
+
+// DiffStates returns the differences in roles, rules, and signatures between
+// oldState and newState.
+func DiffStates(oldState, newState *State) (*StateDiff, error) {
+	diff := &StateDiff{
+		Roles:   []*RoleDiff{},
+		Rules:   []*RuleDiff{},
+		Signers: []*SignersDiff{},
+	}
+
+	oldRootMetadata, err := oldState.GetRootMetadata()
+	if err != nil {
+		return nil, err
+	}
+	newRootMetadata, err := newState.GetRootMetadata()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, roleName := range sortedUnion(mapKeys(oldRootMetadata.Roles), mapKeys(newRootMetadata.Roles)) {
+		oldRole, inOld := oldRootMetadata.Roles[roleName]
+		newRole, inNew := newRootMetadata.Roles[roleName]
+
+		roleDiff := &RoleDiff{Name: roleName, OldThreshold: oldRole.Threshold, NewThreshold: newRole.Threshold}
+		roleDiff.AddedKeyIDs, roleDiff.RemovedKeyIDs = diffStrings(oldRole.KeyIDs, newRole.KeyIDs)
+		if change, changed := getChangeType(inOld, inNew, roleDiff.OldThreshold != roleDiff.NewThreshold || len(roleDiff.AddedKeyIDs) > 0 || len(roleDiff.RemovedKeyIDs) > 0); changed {
+			roleDiff.Change = change
+			diff.Roles = append(diff.Roles, roleDiff)
+		}
+	}
+
+	oldRules, err := oldState.rulesByPolicyFile()
+	if err != nil {
+		return nil, err
+	}
+	newRules, err := newState.rulesByPolicyFile()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, policyName := range sortedUnion(mapKeys(oldRules), mapKeys(newRules)) {
+		diff.Rules = append(diff.Rules, DiffRules(policyName, oldRules[policyName], newRules[policyName])...)
+	}
+
+	oldEnvelopes := oldState.envelopesByRoleName()
+	newEnvelopes := newState.envelopesByRoleName()
+	for _, roleName := range sortedUnion(mapKeys(oldEnvelopes), mapKeys(newEnvelopes)) {
+		signersDiff := &SignersDiff{Name: roleName}
+		signersDiff.AddedKeyIDs, signersDiff.RemovedKeyIDs = diffStrings(signerKeyIDs(oldEnvelopes[roleName]), signerKeyIDs(newEnvelopes[roleName]))
+		if len(signersDiff.AddedKeyIDs) > 0 || len(signersDiff.RemovedKeyIDs) > 0 {
+			diff.Signers = append(diff.Signers, signersDiff)
+		}
+	}
+
+	return diff, nil
+}
+
+// DiffRules returns the differences between the rules of the policy file
+// policyName before and after a change.
+func DiffRules(policyName string, oldRules, newRules []tuf.Delegation) []*RuleDiff {
+	diffs := []*RuleDiff{}
+
+	// Rules are reported in the order they appear in the policy file,
+	// followed by removed rules
+	ruleNames := []string{}
+	for _, rule := range newRules {
+		ruleNames = append(ruleNames, rule.Name)
+	}
+	for _, rule := range oldRules {
+		if findRule(newRules, rule.Name) == nil {
+			ruleNames = append(ruleNames, rule.Name)
+		}
+	}
+
+	for _, ruleName := range ruleNames {
+		oldRule := findRule(oldRules, ruleName)
+		newRule := findRule(newRules, ruleName)
+
+		ruleDiff := &RuleDiff{PolicyName: policyName, Name: ruleName}
+		var oldKeyIDs, newKeyIDs, oldPaths, newPaths, oldPersonIDs, newPersonIDs, oldTeamIDs, newTeamIDs []string
+		if oldRule != nil {
+			oldKeyIDs, oldPaths, ruleDiff.OldThreshold = oldRule.KeyIDs, oldRule.Paths, oldRule.Threshold
+			oldPersonIDs, oldTeamIDs = oldRule.PersonIDs, oldRule.TeamIDs
+		}
+		if newRule != nil {
+			newKeyIDs, newPaths, ruleDiff.NewThreshold = newRule.KeyIDs, newRule.Paths, newRule.Threshold
+			newPersonIDs, newTeamIDs = newRule.PersonIDs, newRule.TeamIDs
+		}
+		ruleDiff.AddedKeyIDs, ruleDiff.RemovedKeyIDs = diffStrings(oldKeyIDs, newKeyIDs)
+		ruleDiff.AddedPaths, ruleDiff.RemovedPaths = diffStrings(oldPaths, newPaths)
+		ruleDiff.AddedPersonIDs, ruleDiff.RemovedPersonIDs = diffStrings(oldPersonIDs, newPersonIDs)
+		ruleDiff.AddedTeamIDs, ruleDiff.RemovedTeamIDs = diffStrings(oldTeamIDs, newTeamIDs)
+
+		modified := ruleDiff.OldThreshold != ruleDiff.NewThreshold || len(ruleDiff.AddedKeyIDs) > 0 || len(ruleDiff.RemovedKeyIDs) > 0 || len(ruleDiff.AddedPaths) > 0 || len(ruleDiff.RemovedPaths) > 0 ||
+			len(ruleDiff.AddedPersonIDs) > 0 || len(ruleDiff.RemovedPersonIDs) > 0 || len(ruleDiff.AddedTeamIDs) > 0 || len(ruleDiff.RemovedTeamIDs) > 0
+		if change, changed := getChangeType(oldRule != nil, newRule != nil, modified); changed {
+			ruleDiff.Change = change
+			diffs = append(diffs, ruleDiff)
+		}
+	}
+
+	return diffs
+}
+
+// rulesByPolicyFile returns the rules defined in each of the State's policy
+// files, excluding the implicit allow rule.
+func (s *State) rulesByPolicyFile() (map[string][]tuf.Delegation, error) {
+	rules := map[string][]tuf.Delegation{}
+
+	roleNames := []string{}
+	if s.TargetsEnvelope != nil {
+		roleNames = append(roleNames, TargetsRoleName)
+	}
+	for roleName := range s.DelegationEnvelopes {
+		roleNames = append(roleNames, roleName)
+	}
+
+	for _, roleName := range roleNames {
+		targetsMetadata, err := s.GetTargetsMetadata(roleName)
+		if err != nil {
+			return nil, err
+		}
+
+		rules[roleName] = []tuf.Delegation{}
+		if targetsMetadata.Delegations == nil {
+			continue
+		}
+		for _, rule := range targetsMetadata.Delegations.Roles {
+			if rule.Name == AllowRuleName {
+				continue
+			}
+			rules[roleName] = append(rules[roleName], rule)
+		}
+	}
+
+	return rules, nil
+}
+
+// envelopesByRoleName returns every signed piece of metadata in the State
+// indexed by its role name.
+func (s *State) envelopesByRoleName() map[string]*sslibdsse.Envelope {
+	envelopes := map[string]*sslibdsse.Envelope{}
+	if s.RootEnvelope != nil {
+		envelopes[RootRoleName] = s.RootEnvelope
+	}
+	if s.TargetsEnvelope != nil {
+		envelopes[TargetsRoleName] = s.TargetsEnvelope
+	}
+	for roleName, env := range s.DelegationEnvelopes {
+		envelopes[roleName] = env
+	}
+	if s.SnapshotEnvelope != nil {
+		envelopes[SnapshotRoleName] = s.SnapshotEnvelope
+	}
+
+	return envelopes
+}
+
+func getChangeType(inOld, inNew, modified bool) (ChangeType, bool) {
+	switch {
+	case !inOld && inNew:
+		return ChangeAdded, true
+	case inOld && !inNew:
+		return ChangeRemoved, true
+	case modified:
+		return ChangeModified, true
+	default:
+		return "", false
+	}
+}
+
+func findRule(rules []tuf.Delegation, name string) *tuf.Delegation {
+	for i := range rules {
+		if rules[i].Name == name {
+			return &rules[i]
+		}
+	}
+	return nil
+}
+
+func signerKeyIDs(env *sslibdsse.Envelope) []string {
+	if env == nil {
+		return nil
+	}
+
+	keyIDs := make([]string, 0, len(env.Signatures))
+	for _, signature := range env.Signatures {
+		keyIDs = append(keyIDs, signature.KeyID)
+	}
+	return keyIDs
+}
+
+// diffStrings returns the sorted items present only in newItems and the sorted
+// items present only in oldItems.
+func diffStrings(oldItems, newItems []string) ([]string, []string) {
+	oldSet := set.NewSet[string]()
+	for _, item := range oldItems {
+		oldSet.Add(item)
+	}
+	newSet := set.NewSet[string]()
+	for _, item := range newItems {
+		newSet.Add(item)
+	}
+
+	added, removed := []string{}, []string{}
+	for _, item := range newSet.Contents() {
+		if !oldSet.Has(item) {
+			added = append(added, item)
+		}
+	}
+	for _, item := range oldSet.Contents() {
+		if !newSet.Has(item) {
+			removed = append(removed, item)
+		}
+	}
+	sort.Strings(added)
+	sort.Strings(removed)
+
+	return added, removed
+}
+
+func sortedUnion(a, b []string) []string {
+	union := set.NewSet[string]()
+	for _, item := range append(a, b...) {
+		union.Add(item)
+	}
+
+	items := union.Contents()
+	sort.Strings(items)
+	return items
+}
+
+func mapKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	return keys
+}
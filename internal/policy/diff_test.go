@@ -0,0 +1,105 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/signerverifier"
+	"github.com/gittuf/gittuf/internal/signerverifier/dsse"
+	"github.com/gittuf/gittuf/internal/signerverifier/gpg"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffStates(t *testing.T) {
+	oldState := createTestStateWithPolicy(t)
+
+	t.Run("no changes", func(t *testing.T) {
+		diff, err := DiffStates(oldState, createTestStateWithPolicy(t))
+		assert.Nil(t, err)
+		assert.True(t, diff.IsEmpty())
+	})
+
+	t.Run("changed roles, rules, and signers", func(t *testing.T) {
+		newState := createTestStateWithPolicy(t)
+
+		rootKey, err := tuf.LoadKeyFromBytes(rootPubKeyBytes)
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsKey, err := tuf.LoadKeyFromBytes(targets1PubKeyBytes)
+		if err != nil {
+			t.Fatal(err)
+		}
+		gpgKey, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(targets1KeyBytes) //nolint:staticcheck
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		rootMetadata, err := newState.GetRootMetadata()
+		if err != nil {
+			t.Fatal(err)
+		}
+		rootMetadata, err = AddTargetsKey(rootMetadata, targetsKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rootMetadata, err = UpdateTargetsThreshold(rootMetadata, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		newState.RootEnvelope, err = dsse.CreateEnvelope(rootMetadata)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		targetsMetadata, err := newState.GetTargetsMetadata(TargetsRoleName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsMetadata, err = UpdateDelegation(targetsMetadata, "protect-main", []*tuf.Key{gpgKey, targetsKey}, []string{"git:refs/heads/main"}, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsMetadata, err = RemoveDelegation(targetsMetadata, "protect-files-1-and-2")
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsMetadata, err = AddDelegation(targetsMetadata, "protect-feature", []*tuf.Key{gpgKey}, []string{"git:refs/heads/feature"}, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		newState.TargetsEnvelope, err = dsse.CreateEnvelope(targetsMetadata)
+		if err != nil {
+			t.Fatal(err)
+		}
+		newState.TargetsEnvelope, err = dsse.SignEnvelope(testCtx, newState.TargetsEnvelope, targetsSigner)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		diff, err := DiffStates(oldState, newState)
+		assert.Nil(t, err)
+		assert.False(t, diff.IsEmpty())
+
+		assert.Equal(t, []*RoleDiff{
+			{Name: TargetsRoleName, Change: ChangeModified, AddedKeyIDs: []string{targetsKey.KeyID}, RemovedKeyIDs: []string{}, OldThreshold: 1, NewThreshold: 2},
+		}, diff.Roles)
+
+		assert.Equal(t, []*RuleDiff{
+			{PolicyName: TargetsRoleName, Name: "protect-main", Change: ChangeModified, AddedKeyIDs: []string{targetsKey.KeyID}, RemovedKeyIDs: []string{}, AddedPaths: []string{}, RemovedPaths: []string{}, OldThreshold: 1, NewThreshold: 2, AddedPersonIDs: []string{}, RemovedPersonIDs: []string{}, AddedTeamIDs: []string{}, RemovedTeamIDs: []string{}},
+			{PolicyName: TargetsRoleName, Name: "protect-feature", Change: ChangeAdded, AddedKeyIDs: []string{gpgKey.KeyID}, RemovedKeyIDs: []string{}, AddedPaths: []string{"git:refs/heads/feature"}, RemovedPaths: []string{}, OldThreshold: 0, NewThreshold: 1, AddedPersonIDs: []string{}, RemovedPersonIDs: []string{}, AddedTeamIDs: []string{}, RemovedTeamIDs: []string{}},
+			{PolicyName: TargetsRoleName, Name: "protect-files-1-and-2", Change: ChangeRemoved, AddedKeyIDs: []string{}, RemovedKeyIDs: []string{gpgKey.KeyID}, AddedPaths: []string{}, RemovedPaths: []string{"file:1", "file:2"}, OldThreshold: 1, NewThreshold: 0, AddedPersonIDs: []string{}, RemovedPersonIDs: []string{}, AddedTeamIDs: []string{}, RemovedTeamIDs: []string{}},
+		}, diff.Rules)
+
+		assert.Equal(t, []*SignersDiff{
+			{Name: RootRoleName, AddedKeyIDs: []string{}, RemovedKeyIDs: []string{rootKey.KeyID}},
+			{Name: TargetsRoleName, AddedKeyIDs: []string{targetsKey.KeyID}, RemovedKeyIDs: []string{rootKey.KeyID}},
+		}, diff.Signers)
+	})
+}
@@ -0,0 +1,254 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/gittuf/gittuf/internal/tuf"
+	"sigs.k8s.io/yaml"
+)
+
+var ErrInvalidPolicyDocument = errors.New("invalid policy document")
+
+// Document is a declarative representation of a policy State that can be
+// reviewed and edited as code. It records the root of trust and every policy
+// file, but no signatures, versions, or the in-built allow rule.
+type Document struct {
+	Root        *RootDocument         `json:"root"`
+	PolicyFiles []*PolicyFileDocument `json:"policy_files"`
+}
+
+// RootDocument records the keys and top level roles of the root of trust.
+type RootDocument struct {
+	Expires     string               `json:"expires,omitempty"`
+	Keys        []*tuf.Key           `json:"keys"`
+	Roles       map[string]tuf.Role  `json:"roles"`
+	RevokedKeys []*tuf.KeyRevocation `json:"revoked_keys,omitempty"`
+}
+
+// PolicyFileDocument records the keys, people, teams, and rules of a single
+// policy file.
+type PolicyFileDocument struct {
+	Name    string           `json:"name"`
+	Expires string           `json:"expires,omitempty"`
+	Keys    []*tuf.Key       `json:"keys"`
+	People  []*tuf.Person    `json:"people,omitempty"`
+	Teams   []*tuf.Team      `json:"teams,omitempty"`
+	Rules   []tuf.Delegation `json:"rules"`
+}
+
+// LoadDocument parses a Document from YAML or JSON. Unknown fields are
+// rejected so that typos are not silently ignored.
+func LoadDocument(contents []byte) (*Document, error) {
+	document := &Document{}
+	if err := yaml.UnmarshalStrict(contents, document); err != nil {
+		return nil, errors.Join(ErrInvalidPolicyDocument, err)
+	}
+
+	return document, nil
+}
+
+// ExportDocument returns the Document representation of the State.
+func (s *State) ExportDocument() (*Document, error) {
+	rootMetadata, err := s.GetRootMetadata()
+	if err != nil {
+		return nil, err
+	}
+
+	document := &Document{
+		Root: &RootDocument{
+			Expires:     rootMetadata.Expires,
+			Keys:        sortedKeys(rootMetadata.Keys),
+			Roles:       rootMetadata.Roles,
+			RevokedKeys: []*tuf.KeyRevocation{},
+		},
+		PolicyFiles: []*PolicyFileDocument{},
+	}
+
+	revokedKeyIDs := mapKeys(rootMetadata.RevokedKeys)
+	sort.Strings(revokedKeyIDs)
+	for _, keyID := range revokedKeyIDs {
+		document.Root.RevokedKeys = append(document.Root.RevokedKeys, rootMetadata.RevokedKeys[keyID])
+	}
+
+	policyNames := []string{}
+	if s.TargetsEnvelope != nil {
+		policyNames = append(policyNames, TargetsRoleName)
+	}
+	delegationNames := mapKeys(s.DelegationEnvelopes)
+	sort.Strings(delegationNames)
+	policyNames = append(policyNames, delegationNames...)
+
+	for _, policyName := range policyNames {
+		targetsMetadata, err := s.GetTargetsMetadata(policyName)
+		if err != nil {
+			return nil, err
+		}
+
+		policyFile := &PolicyFileDocument{
+			Name:    policyName,
+			Expires: targetsMetadata.Expires,
+			Keys:    []*tuf.Key{},
+			Rules:   []tuf.Delegation{},
+		}
+		if targetsMetadata.Delegations != nil {
+			policyFile.Keys = sortedKeys(targetsMetadata.Delegations.Keys)
+
+			personIDs := mapKeys(targetsMetadata.Delegations.People)
+			sort.Strings(personIDs)
+			for _, personID := range personIDs {
+				policyFile.People = append(policyFile.People, targetsMetadata.Delegations.People[personID])
+			}
+
+			teamIDs := mapKeys(targetsMetadata.Delegations.Teams)
+			sort.Strings(teamIDs)
+			for _, teamID := range teamIDs {
+				policyFile.Teams = append(policyFile.Teams, targetsMetadata.Delegations.Teams[teamID])
+			}
+
+			for _, rule := range targetsMetadata.Delegations.Roles {
+				if rule.Name != AllowRuleName {
+					policyFile.Rules = append(policyFile.Rules, rule)
+				}
+			}
+		}
+
+		document.PolicyFiles = append(document.PolicyFiles, policyFile)
+	}
+
+	return document, nil
+}
+
+// MetadataFromDocument returns the root metadata and the metadata of every
+// policy file described by the document. The versions of existing metadata
+// are retained, so callers can compare the returned metadata with the State's
+// to identify what must be re-signed. Expiry dates not specified in the
+// document are retained from the State. Keys revoked in the State remain
+// revoked even if the document omits them.
+func (s *State) MetadataFromDocument(document *Document) (*tuf.RootMetadata, map[string]*tuf.TargetsMetadata, error) {
+	if document.Root == nil {
+		return nil, nil, fmt.Errorf("%w: root of trust is not defined", ErrInvalidPolicyDocument)
+	}
+	if _, has := document.Root.Roles[RootRoleName]; !has {
+		return nil, nil, fmt.Errorf("%w: root role is not defined", ErrInvalidPolicyDocument)
+	}
+
+	currentRootMetadata, err := s.GetRootMetadata()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rootMetadata := tuf.NewRootMetadata()
+	rootMetadata.SetVersion(currentRootMetadata.Version)
+	rootMetadata.SetExpires(currentRootMetadata.Expires)
+	if document.Root.Expires != "" {
+		rootMetadata.SetExpires(document.Root.Expires)
+	}
+	for _, key := range document.Root.Keys {
+		rootMetadata.AddKey(key)
+	}
+	for roleName, role := range document.Root.Roles {
+		if role.KeyIDs == nil {
+			role.KeyIDs = []string{}
+		}
+		rootMetadata.AddRole(roleName, role)
+	}
+	for _, revocation := range currentRootMetadata.RevokedKeys {
+		rootMetadata.AddRevokedKey(revocation)
+	}
+	for _, revocation := range document.Root.RevokedKeys {
+		if !rootMetadata.IsKeyRevoked(revocation.KeyID) {
+			rootMetadata.AddRevokedKey(revocation)
+		}
+	}
+
+	policyFiles := map[string]*tuf.TargetsMetadata{}
+	ruleNames := map[string]bool{}
+	for _, policyFile := range document.PolicyFiles {
+		if policyFile.Name == RootRoleName || policyFile.Name == SnapshotRoleName || policyFile.Name == TimestampRoleName {
+			return nil, nil, fmt.Errorf("%w: policy file cannot be named '%s'", ErrInvalidPolicyDocument, policyFile.Name)
+		}
+		if _, has := policyFiles[policyFile.Name]; has {
+			return nil, nil, fmt.Errorf("%w: policy file '%s' is defined more than once", ErrInvalidPolicyDocument, policyFile.Name)
+		}
+
+		targetsMetadata := InitializeTargetsMetadata()
+		if s.HasTargetsRole(policyFile.Name) {
+			currentTargetsMetadata, err := s.GetTargetsMetadata(policyFile.Name)
+			if err != nil {
+				return nil, nil, err
+			}
+			targetsMetadata.SetVersion(currentTargetsMetadata.Version)
+			targetsMetadata.SetExpires(currentTargetsMetadata.Expires)
+		}
+		if policyFile.Expires != "" {
+			targetsMetadata.SetExpires(policyFile.Expires)
+		}
+
+		for _, key := range policyFile.Keys {
+			targetsMetadata.Delegations.AddKey(key)
+		}
+		for _, person := range policyFile.People {
+			targetsMetadata.Delegations.AddPerson(person)
+		}
+		for _, team := range policyFile.Teams {
+			for _, personID := range team.PersonIDs {
+				if _, has := targetsMetadata.Delegations.People[personID]; !has {
+					return nil, nil, fmt.Errorf("%w: team '%s' has unknown person '%s'", ErrInvalidPolicyDocument, team.TeamID, personID)
+				}
+			}
+			targetsMetadata.Delegations.AddTeam(team)
+		}
+
+		rules := []tuf.Delegation{}
+		for _, rule := range policyFile.Rules {
+			if rule.Name == AllowRuleName {
+				return nil, nil, ErrCannotManipulateAllowRule
+			}
+			if rule.Name == RootRoleName || rule.Name == SnapshotRoleName || rule.Name == TimestampRoleName {
+				return nil, nil, fmt.Errorf("%w: rule cannot be named '%s'", ErrInvalidPolicyDocument, rule.Name)
+			}
+			if ruleNames[rule.Name] {
+				return nil, nil, fmt.Errorf("%w: '%s'", ErrDuplicatedRuleName, rule.Name)
+			}
+			ruleNames[rule.Name] = true
+
+			if rule.Paths == nil {
+				rule.Paths = []string{}
+			}
+			if rule.KeyIDs == nil {
+				rule.KeyIDs = []string{}
+			}
+			for _, personID := range rule.PersonIDs {
+				if _, has := targetsMetadata.Delegations.People[personID]; !has {
+					return nil, nil, fmt.Errorf("%w: rule '%s' authorizes unknown person '%s'", ErrInvalidPolicyDocument, rule.Name, personID)
+				}
+			}
+			for _, teamID := range rule.TeamIDs {
+				if _, has := targetsMetadata.Delegations.Teams[teamID]; !has {
+					return nil, nil, fmt.Errorf("%w: rule '%s' authorizes unknown team '%s'", ErrInvalidPolicyDocument, rule.Name, teamID)
+				}
+			}
+			rules = append(rules, rule)
+		}
+		targetsMetadata.Delegations.Roles = append(rules, AllowRule())
+
+		policyFiles[policyFile.Name] = targetsMetadata
+	}
+
+	return rootMetadata, policyFiles, nil
+}
+
+func sortedKeys(keys map[string]*tuf.Key) []*tuf.Key {
+	keyIDs := mapKeys(keys)
+	sort.Strings(keyIDs)
+
+	sorted := make([]*tuf.Key, 0, len(keyIDs))
+	for _, keyID := range keyIDs {
+		sorted = append(sorted, keys[keyID])
+	}
+	return sorted
+}
@@ -0,0 +1,183 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/stretchr/testify/assert"
+	"sigs.k8s.io/yaml"
+)
+
+func TestStateExportDocument(t *testing.T) {
+	state := createTestStateWithPolicy(t)
+
+	document, err := state.ExportDocument()
+	assert.Nil(t, err)
+
+	rootMetadata, err := state.GetRootMetadata()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, rootMetadata.Roles, document.Root.Roles)
+	assert.Len(t, document.Root.Keys, len(rootMetadata.Keys))
+
+	if assert.Len(t, document.PolicyFiles, 1) {
+		policyFile := document.PolicyFiles[0]
+		assert.Equal(t, TargetsRoleName, policyFile.Name)
+		assert.Len(t, policyFile.Keys, 1)
+		assert.Len(t, policyFile.Rules, 2)
+		assert.Equal(t, "protect-main", policyFile.Rules[0].Name)
+		assert.Equal(t, "protect-files-1-and-2", policyFile.Rules[1].Name)
+	}
+}
+
+func TestStateMetadataFromDocument(t *testing.T) {
+	state := createTestStateWithPolicy(t)
+
+	exported, err := state.ExportDocument()
+	if err != nil {
+		t.Fatal(err)
+	}
+	contents, err := yaml.Marshal(exported)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("round trip", func(t *testing.T) {
+		document, err := LoadDocument(contents)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		rootMetadata, policyFiles, err := state.MetadataFromDocument(document)
+		assert.Nil(t, err)
+
+		currentRootMetadata, err := state.GetRootMetadata()
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertSerializesEqual(t, currentRootMetadata, rootMetadata)
+
+		currentTargetsMetadata, err := state.GetTargetsMetadata(TargetsRoleName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assertSerializesEqual(t, currentTargetsMetadata, policyFiles[TargetsRoleName])
+	})
+
+	t.Run("new policy file", func(t *testing.T) {
+		document, err := LoadDocument(contents)
+		if err != nil {
+			t.Fatal(err)
+		}
+		document.PolicyFiles = append(document.PolicyFiles, &PolicyFileDocument{
+			Name: "protect-main",
+			Rules: []tuf.Delegation{
+				{Name: "protect-main-readme", Paths: []string{"file:README.md"}, Role: tuf.Role{Threshold: 1}},
+			},
+		})
+
+		_, policyFiles, err := state.MetadataFromDocument(document)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, policyFiles["protect-main"].Version)
+		assert.Equal(t, []string{}, policyFiles["protect-main"].Delegations.Roles[0].KeyIDs)
+		assert.Equal(t, AllowRuleName, policyFiles["protect-main"].Delegations.Roles[1].Name)
+	})
+
+	t.Run("duplicate rule name", func(t *testing.T) {
+		document, err := LoadDocument(contents)
+		if err != nil {
+			t.Fatal(err)
+		}
+		document.PolicyFiles[0].Rules = append(document.PolicyFiles[0].Rules, document.PolicyFiles[0].Rules[0])
+
+		_, _, err = state.MetadataFromDocument(document)
+		assert.ErrorIs(t, err, ErrDuplicatedRuleName)
+	})
+
+	t.Run("allow rule", func(t *testing.T) {
+		document, err := LoadDocument(contents)
+		if err != nil {
+			t.Fatal(err)
+		}
+		document.PolicyFiles[0].Rules = append(document.PolicyFiles[0].Rules, AllowRule())
+
+		_, _, err = state.MetadataFromDocument(document)
+		assert.ErrorIs(t, err, ErrCannotManipulateAllowRule)
+	})
+
+	t.Run("people", func(t *testing.T) {
+		document, err := LoadDocument(contents)
+		if err != nil {
+			t.Fatal(err)
+		}
+		keyID := document.PolicyFiles[0].Keys[0].KeyID
+		document.PolicyFiles[0].People = []*tuf.Person{{PersonID: "alice", KeyIDs: []string{keyID}}}
+		document.PolicyFiles[0].Rules[0].PersonIDs = []string{"alice"}
+
+		_, policyFiles, err := state.MetadataFromDocument(document)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{keyID}, policyFiles[TargetsRoleName].Delegations.People["alice"].KeyIDs)
+		assert.Equal(t, []string{"alice"}, policyFiles[TargetsRoleName].Delegations.Roles[0].PersonIDs)
+
+		document.PolicyFiles[0].Rules[0].PersonIDs = []string{"bob"}
+		_, _, err = state.MetadataFromDocument(document)
+		assert.ErrorIs(t, err, ErrInvalidPolicyDocument)
+	})
+
+	t.Run("teams", func(t *testing.T) {
+		document, err := LoadDocument(contents)
+		if err != nil {
+			t.Fatal(err)
+		}
+		keyID := document.PolicyFiles[0].Keys[0].KeyID
+		document.PolicyFiles[0].People = []*tuf.Person{{PersonID: "alice", KeyIDs: []string{keyID}}}
+		document.PolicyFiles[0].Teams = []*tuf.Team{{TeamID: "maintainers", PersonIDs: []string{"alice"}}}
+		document.PolicyFiles[0].Rules[0].TeamIDs = []string{"maintainers"}
+
+		_, policyFiles, err := state.MetadataFromDocument(document)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"alice"}, policyFiles[TargetsRoleName].Delegations.Teams["maintainers"].PersonIDs)
+		assert.Equal(t, []string{"maintainers"}, policyFiles[TargetsRoleName].Delegations.Roles[0].TeamIDs)
+
+		document.PolicyFiles[0].Rules[0].TeamIDs = []string{"reviewers"}
+		_, _, err = state.MetadataFromDocument(document)
+		assert.ErrorIs(t, err, ErrInvalidPolicyDocument)
+
+		document.PolicyFiles[0].Rules[0].TeamIDs = nil
+		document.PolicyFiles[0].Teams[0].PersonIDs = []string{"bob"}
+		_, _, err = state.MetadataFromDocument(document)
+		assert.ErrorIs(t, err, ErrInvalidPolicyDocument)
+	})
+
+	t.Run("missing root", func(t *testing.T) {
+		_, _, err := state.MetadataFromDocument(&Document{})
+		assert.ErrorIs(t, err, ErrInvalidPolicyDocument)
+	})
+}
+
+func TestLoadDocument(t *testing.T) {
+	_, err := LoadDocument([]byte("root:\n  roles: {}\nunknown: true\n"))
+	assert.ErrorIs(t, err, ErrInvalidPolicyDocument)
+
+	document, err := LoadDocument([]byte(`{"root": {"keys": [], "roles": {}}, "policy_files": []}`))
+	assert.Nil(t, err)
+	assert.NotNil(t, document.Root)
+}
+
+func assertSerializesEqual(t *testing.T, expected, actual any) {
+	t.Helper()
+
+	expectedBytes, err := json.Marshal(expected)
+	if err != nil {
+		t.Fatal(err)
+	}
+	actualBytes, err := json.Marshal(actual)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.JSONEq(t, string(expectedBytes), string(actualBytes))
+}
@@ -0,0 +1,101 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+)
+
+// DefaultExpiryGracePeriod is the duration for which expired metadata is
+// accepted with a warning during verification.
+const DefaultExpiryGracePeriod = 7 * 24 * time.Hour
+
+var ErrMetadataExpired = errors.New("gittuf policy metadata has expired")
+
+// ExpiredMetadata identifies a role whose metadata has expired.
+type ExpiredMetadata struct {
+	RoleName string
+	Expires  time.Time
+}
+
+// FindExpiredMetadata returns the roles in the State whose metadata expires
+// before now. Metadata without an expiry date is not considered expired.
+func (s *State) FindExpiredMetadata(now time.Time) ([]*ExpiredMetadata, error) {
+	expiries := map[string]string{}
+
+	rootMetadata, err := s.GetRootMetadata()
+	if err != nil {
+		return nil, err
+	}
+	expiries[RootRoleName] = rootMetadata.Expires
+
+	if s.TargetsEnvelope != nil {
+		targetsMetadata, err := s.GetTargetsMetadata(TargetsRoleName)
+		if err != nil {
+			return nil, err
+		}
+		expiries[TargetsRoleName] = targetsMetadata.Expires
+	}
+
+	if s.SnapshotEnvelope != nil {
+		snapshotMetadata, err := s.GetSnapshotMetadata()
+		if err != nil {
+			return nil, err
+		}
+		expiries[SnapshotRoleName] = snapshotMetadata.Expires
+	}
+
+	for roleName := range s.DelegationEnvelopes {
+		delegatedMetadata, err := s.GetTargetsMetadata(roleName)
+		if err != nil {
+			return nil, err
+		}
+		expiries[roleName] = delegatedMetadata.Expires
+	}
+
+	expired := []*ExpiredMetadata{}
+	for roleName, expiresString := range expiries {
+		if expiresString == "" {
+			continue
+		}
+
+		expires, err := time.Parse(time.RFC3339, expiresString)
+		if err != nil {
+			return nil, fmt.Errorf("unable to parse expiry date of '%s': %w", roleName, err)
+		}
+
+		if expires.Before(now) {
+			expired = append(expired, &ExpiredMetadata{RoleName: roleName, Expires: expires})
+		}
+	}
+
+	sort.Slice(expired, func(i, j int) bool {
+		return expired[i].RoleName < expired[j].RoleName
+	})
+
+	return expired, nil
+}
+
+// VerifyExpiration checks that none of the State's metadata has expired as of
+// now. Metadata that expired less than gracePeriod ago is logged as a warning,
+// while metadata that expired before that results in ErrMetadataExpired.
+func (s *State) VerifyExpiration(now time.Time, gracePeriod time.Duration) error {
+	expired, err := s.FindExpiredMetadata(now)
+	if err != nil {
+		return err
+	}
+
+	for _, metadata := range expired {
+		if metadata.Expires.Add(gracePeriod).Before(now) {
+			return fmt.Errorf("%w: '%s' expired at %s, renew it using 'gittuf policy renew'", ErrMetadataExpired, metadata.RoleName, metadata.Expires.Format(time.RFC3339))
+		}
+
+		slog.Warn(fmt.Sprintf("Metadata for '%s' expired at %s and is accepted during the grace period, renew it using 'gittuf policy renew'", metadata.RoleName, metadata.Expires.Format(time.RFC3339)))
+	}
+
+	return nil
+}
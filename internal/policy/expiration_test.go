@@ -0,0 +1,53 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStateFindExpiredMetadata(t *testing.T) {
+	state := createTestStateWithPolicy(t)
+
+	expired, err := state.FindExpiredMetadata(time.Now())
+	assert.Nil(t, err)
+	assert.Empty(t, expired)
+
+	expired, err = state.FindExpiredMetadata(time.Now().AddDate(2, 0, 0))
+	assert.Nil(t, err)
+	if assert.Len(t, expired, 2) {
+		assert.Equal(t, RootRoleName, expired[0].RoleName)
+		assert.Equal(t, TargetsRoleName, expired[1].RoleName)
+	}
+}
+
+func TestStateVerifyExpiration(t *testing.T) {
+	state := createTestStateWithPolicy(t)
+
+	rootMetadata, err := state.GetRootMetadata()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expires, err := time.Parse(time.RFC3339, rootMetadata.Expires)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("not expired", func(t *testing.T) {
+		err := state.VerifyExpiration(time.Now(), DefaultExpiryGracePeriod)
+		assert.Nil(t, err)
+	})
+
+	t.Run("expired within grace period", func(t *testing.T) {
+		err := state.VerifyExpiration(expires.Add(24*time.Hour), DefaultExpiryGracePeriod)
+		assert.Nil(t, err)
+	})
+
+	t.Run("expired beyond grace period", func(t *testing.T) {
+		err := state.VerifyExpiration(expires.Add(24*time.Hour), 0)
+		assert.ErrorIs(t, err, ErrMetadataExpired)
+	})
+}
@@ -0,0 +1,89 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+// ExplainFunc receives a step of the decision trace for the RSL entry
+// identified by entryID. When entries are verified concurrently, it may be
+// invoked concurrently.
+type ExplainFunc func(entryID plumbing.Hash, step string)
+
+type explainKey struct{}
+
+type explainer struct {
+	fn      ExplainFunc
+	entryID plumbing.Hash
+}
+
+// WithExplanation returns a copy of ctx that carries fn. When verifying using
+// a context that carries fn, every decision made while verifying an RSL entry
+// is passed to fn, such as the rules that matched the entry, the keys that were
+// counted towards each rule's threshold, and the signatures that were rejected.
+func WithExplanation(ctx context.Context, fn ExplainFunc) context.Context {
+	return context.WithValue(ctx, explainKey{}, &explainer{fn: fn})
+}
+
+// withExplainedEntry returns a copy of ctx that attributes the steps explained
+// using it to the entry. If ctx does not carry an ExplainFunc, ctx is returned
+// unchanged.
+func withExplainedEntry(ctx context.Context, entryID plumbing.Hash) context.Context {
+	e, ok := ctx.Value(explainKey{}).(*explainer)
+	if !ok {
+		return ctx
+	}
+
+	return context.WithValue(ctx, explainKey{}, &explainer{fn: e.fn, entryID: entryID})
+}
+
+// explain passes the step to the ExplainFunc carried by ctx, if any.
+func explain(ctx context.Context, format string, a ...any) {
+	e, ok := ctx.Value(explainKey{}).(*explainer)
+	if !ok {
+		return
+	}
+
+	e.fn(e.entryID, fmt.Sprintf(format, a...))
+}
+
+// explainMatchedRules explains which rules protect the subject described by
+// format and a.
+func explainMatchedRules(ctx context.Context, verifiers []*Verifier, denied bool, format string, a ...any) {
+	if _, ok := ctx.Value(explainKey{}).(*explainer); !ok {
+		return
+	}
+
+	subject := fmt.Sprintf(format, a...)
+	if len(verifiers) == 0 {
+		explain(ctx, "No rules protect %s", subject)
+		return
+	}
+
+	names := make([]string, 0, len(verifiers))
+	for _, verifier := range verifiers {
+		names = append(names, fmt.Sprintf("'%s' (threshold %d)", verifier.name, verifier.threshold))
+	}
+
+	if denied {
+		explain(ctx, "Deny rules protect %s, every rule must be met: %s", subject, strings.Join(names, ", "))
+		return
+	}
+	explain(ctx, "Rules protecting %s, any one rule must be met: %s", subject, strings.Join(names, ", "))
+}
+
+// explainRuleResult explains whether the verifier's rule was met, where err
+// is the error returned when verifying using the verifier.
+func explainRuleResult(ctx context.Context, verifier *Verifier, err error) {
+	if err == nil {
+		explain(ctx, "Rule '%s' met", verifier.name)
+		return
+	}
+
+	explain(ctx, "Rule '%s' not met: %s", verifier.name, err)
+}
@@ -0,0 +1,77 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/common"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyEntryWithExplanation(t *testing.T) {
+	refName := "refs/heads/main"
+
+	t.Run("rule met", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithPolicy)
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		entry.ID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+
+		steps := []string{}
+		ctx := WithExplanation(testCtx, func(id plumbing.Hash, step string) {
+			assert.Equal(t, entry.ID, id)
+			steps = append(steps, step)
+		})
+
+		err := verifyEntry(ctx, repo, state, nil, entry)
+		assert.Nil(t, err)
+
+		trace := strings.Join(steps, "\n")
+		assert.Contains(t, trace, "Rules protecting 'refs/heads/main', any one rule must be met: 'protect-main' (threshold 1)")
+		assert.Contains(t, trace, "Rule 'protect-main': counted signature on")
+		assert.Contains(t, trace, "Rule 'protect-main' met")
+	})
+
+	t.Run("rule not met", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithPolicy)
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		entry.ID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgUnauthorizedKeyBytes)
+
+		steps := []string{}
+		ctx := WithExplanation(testCtx, func(_ plumbing.Hash, step string) {
+			steps = append(steps, step)
+		})
+
+		err := verifyEntry(ctx, repo, state, nil, entry)
+		assert.ErrorIs(t, err, ErrUnauthorizedSignature)
+
+		trace := strings.Join(steps, "\n")
+		assert.Contains(t, trace, "Rule 'protect-main': signature on")
+		assert.Contains(t, trace, "is missing or not made using any of the rule's keys")
+		assert.Contains(t, trace, "Rule 'protect-main' not met")
+	})
+
+	t.Run("unprotected reference", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithPolicy)
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, "refs/heads/feature", 1, gpgKeyBytes)
+		entry := rsl.NewReferenceEntry("refs/heads/feature", commitIDs[0])
+		entry.ID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgUnauthorizedKeyBytes)
+
+		steps := []string{}
+		ctx := WithExplanation(testCtx, func(_ plumbing.Hash, step string) {
+			steps = append(steps, step)
+		})
+
+		err := verifyEntry(ctx, repo, state, nil, entry)
+		assert.Nil(t, err)
+		assert.Contains(t, strings.Join(steps, "\n"), "No rules protect 'refs/heads/feature'")
+	})
+}
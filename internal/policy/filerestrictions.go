@@ -0,0 +1,119 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// binaryDetectionSize is the number of bytes at the start of a file that are
+// inspected to determine if it is a binary file, matching Git's heuristic.
+const binaryDetectionSize = 8000
+
+// hasFileRestrictions returns true if the verifier restricts the contents of
+// files.
+func (v *Verifier) hasFileRestrictions() bool {
+	return v.maxFileSize > 0 || len(v.forbiddenExtensions) > 0 || v.forbidBinaryFiles
+}
+
+// verifyFileRestrictions checks that the file at filePath in commit meets the
+// content restrictions of the verifiers. Paths that were deleted by the commit
+// or that are not regular files, such as submodules, are not checked. The
+// verifiers of deny rules are ignored.
+func verifyFileRestrictions(ctx context.Context, verifiers []*Verifier, commit *object.Commit, filePath string) error {
+	var file *object.File
+	for _, verifier := range verifiers {
+		if verifier.deny || !verifier.hasFileRestrictions() {
+			continue
+		}
+
+		if file == nil {
+			var err error
+			file, err = getFile(commit, filePath)
+			if err != nil {
+				return err
+			}
+			if file == nil {
+				return nil
+			}
+		}
+
+		if err := verifier.verifyFile(file); err != nil {
+			explain(ctx, "Path '%s' in commit '%s' does not meet restrictions of rule '%s': %s", filePath, commit.Hash, verifier.Name(), err)
+			return fmt.Errorf("%w, commit '%s'", err, commit.Hash)
+		}
+	}
+
+	return nil
+}
+
+// getFile returns the file at filePath in commit, or nil if the path does not
+// exist in the commit or is not a regular file.
+func getFile(commit *object.Commit, filePath string) (*object.File, error) {
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := tree.FindEntry(filePath)
+	if err != nil {
+		if errors.Is(err, object.ErrEntryNotFound) || errors.Is(err, object.ErrDirectoryNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if !entry.Mode.IsFile() {
+		return nil, nil
+	}
+
+	return tree.TreeEntryFile(entry)
+}
+
+// verifyFile checks that file meets the verifier's content restrictions.
+func (v *Verifier) verifyFile(file *object.File) error {
+	if v.maxFileSize > 0 && file.Size > v.maxFileSize {
+		return fmt.Errorf("%w '%s': file '%s' is %d bytes, exceeding the maximum of %d bytes", ErrConstraintViolated, v.Name(), file.Name, file.Size, v.maxFileSize)
+	}
+
+	if extension := strings.ToLower(path.Ext(file.Name)); extension != "" && slices.Contains(v.forbiddenExtensions, extension) {
+		return fmt.Errorf("%w '%s': file '%s' has forbidden extension '%s'", ErrConstraintViolated, v.Name(), file.Name, extension)
+	}
+
+	if v.forbidBinaryFiles {
+		isBinary, err := isBinaryFile(file)
+		if err != nil {
+			return err
+		}
+		if isBinary {
+			return fmt.Errorf("%w '%s': file '%s' is a binary file", ErrConstraintViolated, v.Name(), file.Name)
+		}
+	}
+
+	return nil
+}
+
+// isBinaryFile returns true if the start of the file contains a NUL byte, the
+// heuristic Git uses to detect binary files.
+func isBinaryFile(file *object.File) (bool, error) {
+	reader, err := file.Reader()
+	if err != nil {
+		return false, err
+	}
+	defer reader.Close() //nolint:errcheck
+
+	contents, err := io.ReadAll(io.LimitReader(reader, binaryDetectionSize))
+	if err != nil {
+		return false, err
+	}
+
+	return bytes.IndexByte(contents, 0) != -1, nil
+}
@@ -0,0 +1,113 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/common"
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyFileRestrictions(t *testing.T) {
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	textID, err := gitinterface.WriteBlob(repo, []byte("hello world\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	binaryID, err := gitinterface.WriteBlob(repo, []byte{0x7f, 'E', 'L', 'F', 0x00, 0x01})
+	if err != nil {
+		t.Fatal(err)
+	}
+	vendorTreeID, err := gitinterface.WriteTree(repo, []object.TreeEntry{
+		{Name: "README.md", Mode: filemode.Regular, Hash: textID},
+		{Name: "lib", Mode: filemode.Submodule, Hash: plumbing.NewHash("b5c3b5b5b1f8a54f3e2fb1d2b3cf3b8bd7aa1b2a")},
+		{Name: "tool.EXE", Mode: filemode.Executable, Hash: binaryID},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	treeID, err := gitinterface.WriteTree(repo, []object.TreeEntry{{Name: "vendor", Mode: filemode.Dir, Hash: vendorTreeID}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	commit := gitinterface.CreateCommitObject(common.TestGitConfig, treeID, nil, "Add vendored files", common.TestClock)
+	commitID, err := gitinterface.WriteCommit(repo, commit)
+	if err != nil {
+		t.Fatal(err)
+	}
+	commit, err = gitinterface.GetCommit(repo, commitID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		verifier    *Verifier
+		path        string
+		expectedErr error
+	}{
+		"no restrictions": {
+			verifier: &Verifier{name: "protect-vendor"},
+			path:     "vendor/tool.EXE",
+		},
+		"file within size": {
+			verifier: &Verifier{name: "protect-vendor", maxFileSize: 12},
+			path:     "vendor/README.md",
+		},
+		"file too large": {
+			verifier:    &Verifier{name: "protect-vendor", maxFileSize: 11},
+			path:        "vendor/README.md",
+			expectedErr: ErrConstraintViolated,
+		},
+		"forbidden extension": {
+			verifier:    &Verifier{name: "protect-vendor", forbiddenExtensions: []string{".exe"}},
+			path:        "vendor/tool.EXE",
+			expectedErr: ErrConstraintViolated,
+		},
+		"allowed extension": {
+			verifier: &Verifier{name: "protect-vendor", forbiddenExtensions: []string{".exe"}},
+			path:     "vendor/README.md",
+		},
+		"binary file": {
+			verifier:    &Verifier{name: "protect-vendor", forbidBinaryFiles: true},
+			path:        "vendor/tool.EXE",
+			expectedErr: ErrConstraintViolated,
+		},
+		"text file": {
+			verifier: &Verifier{name: "protect-vendor", forbidBinaryFiles: true},
+			path:     "vendor/README.md",
+		},
+		"submodule not checked": {
+			verifier: &Verifier{name: "protect-vendor", forbidBinaryFiles: true, maxFileSize: 1},
+			path:     "vendor/lib",
+		},
+		"deleted file not checked": {
+			verifier: &Verifier{name: "protect-vendor", forbidBinaryFiles: true, maxFileSize: 1},
+			path:     "vendor/deleted.bin",
+		},
+		"deny rule not checked": {
+			verifier: &Verifier{name: "protect-vendor", forbidBinaryFiles: true, deny: true},
+			path:     "vendor/tool.EXE",
+		},
+	}
+
+	for name, test := range tests {
+		err := verifyFileRestrictions(testCtx, []*Verifier{test.verifier}, commit, test.path)
+		if test.expectedErr == nil {
+			assert.Nil(t, err, name)
+		} else {
+			assert.ErrorIs(t, err, test.expectedErr, name)
+		}
+	}
+}
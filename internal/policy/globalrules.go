@@ -0,0 +1,146 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+
+	"github.com/gittuf/gittuf/internal/attestations"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	sslibdsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
+)
+
+// findGlobalVerifiersForPath returns a verifier for each global rule in the
+// root of trust that applies to path. The verifiers of the rules delegated to
+// by policy for path are merged, so that each global verifier trusts all of
+// their keys with the global rule's threshold. If no delegated rule applies
+// to path, the global verifiers trust all the keys in policy.
+func (s *State) findGlobalVerifiersForPath(path string, verifiers []*Verifier) ([]*Verifier, error) {
+	rootMetadata, err := s.GetRootMetadata()
+	if err != nil {
+		return nil, err
+	}
+
+	globalRules := []*tuf.GlobalRule{}
+	for _, globalRule := range rootMetadata.GlobalRules {
+		if globalRule.Matches(path) {
+			globalRules = append(globalRules, globalRule)
+		}
+	}
+	if len(globalRules) == 0 {
+		return nil, nil
+	}
+
+	merged := &Verifier{algorithmPolicy: rootMetadata.AlgorithmPolicy}
+	if len(verifiers) > 0 {
+		seenKeys := map[string]bool{}
+		seenRevokedKeys := map[string]bool{}
+		for _, verifier := range verifiers {
+			for _, key := range verifier.keys {
+				if !seenKeys[key.KeyID] {
+					seenKeys[key.KeyID] = true
+					merged.keys = append(merged.keys, key)
+				}
+			}
+			for _, revoked := range verifier.revokedKeys {
+				if !seenRevokedKeys[revoked.key.KeyID] {
+					seenRevokedKeys[revoked.key.KeyID] = true
+					merged.revokedKeys = append(merged.revokedKeys, revoked)
+				}
+			}
+			for keyID, personID := range verifier.people {
+				merged.addPersonKey(keyID, personID)
+			}
+		}
+	} else {
+		slog.Debug(fmt.Sprintf("No rules apply to '%s', using all keys in policy for global rules...", path))
+		if err := s.addAllKeysToVerifier(merged, rootMetadata); err != nil {
+			return nil, err
+		}
+	}
+
+	globalVerifiers := make([]*Verifier, 0, len(globalRules))
+	for _, globalRule := range globalRules {
+		globalVerifier := *merged
+		globalVerifier.name = globalRule.Name
+		globalVerifier.threshold = globalRule.Threshold
+		globalVerifiers = append(globalVerifiers, &globalVerifier)
+	}
+
+	return globalVerifiers, nil
+}
+
+// addAllKeysToVerifier adds all the keys in policy that are not revoked and
+// meet the algorithm policy to verifier, along with the people they belong to.
+func (s *State) addAllKeysToVerifier(verifier *Verifier, rootMetadata *tuf.RootMetadata) error {
+	allKeys, err := s.PublicKeys()
+	if err != nil {
+		return err
+	}
+
+	keyIDs := make([]string, 0, len(allKeys))
+	for keyID := range allKeys {
+		keyIDs = append(keyIDs, keyID)
+	}
+	sort.Strings(keyIDs)
+
+	for _, keyID := range keyIDs {
+		if err := CheckKeyAlgorithm(rootMetadata.AlgorithmPolicy, allKeys[keyID]); err != nil {
+			slog.Debug(fmt.Sprintf("Key '%s' does not meet algorithm policy, skipping: %s", keyID, err.Error()))
+			continue
+		}
+		verifier.keys = append(verifier.keys, allKeys[keyID])
+	}
+
+	if !s.HasTargetsRole(TargetsRoleName) {
+		return nil
+	}
+	roleNames := []string{TargetsRoleName}
+	for roleName := range s.DelegationEnvelopes {
+		roleNames = append(roleNames, roleName)
+	}
+	for _, roleName := range roleNames {
+		targetsMetadata, err := s.GetTargetsMetadata(roleName)
+		if err != nil {
+			return err
+		}
+		for personID, person := range targetsMetadata.Delegations.People {
+			for _, keyID := range person.KeyIDs {
+				verifier.addPersonKey(keyID, personID)
+			}
+		}
+	}
+
+	return nil
+}
+
+// verifyGlobalRules checks that the change to refName meets every global rule
+// that applies to it. The verifiers are those of the rules delegated to by
+// policy for refName.
+func verifyGlobalRules(ctx context.Context, policy *State, refName string, verifiers []*Verifier, gitObject object.Object, env *sslibdsse.Envelope, approvers []string, reviews []*attestations.SignedCodeReview) error {
+	globalVerifiers, err := policy.findGlobalVerifiersForPath(fmt.Sprintf("%s:%s", gitReferenceRuleScheme, refName), verifiers)
+	if err != nil {
+		return err
+	}
+
+	for _, globalVerifier := range globalVerifiers {
+		err := globalVerifier.VerifyWithApprovers(ctx, gitObject, env, getApproversForVerifier(ctx, globalVerifier, approvers, reviews))
+		switch {
+		case err == nil:
+			slog.Debug(fmt.Sprintf("Global rule '%s' met for '%s'", globalVerifier.Name(), refName))
+		case errors.Is(err, ErrKeyRevoked):
+			return fmt.Errorf("verifying global rule '%s' failed, %w: %w", globalVerifier.Name(), ErrUnauthorizedSignature, err)
+		case errors.Is(err, ErrVerifierConditionsUnmet), errors.Is(err, ErrInvalidVerifier):
+			return fmt.Errorf("verifying global rule '%s' failed, %w", globalVerifier.Name(), ErrUnauthorizedSignature)
+		default:
+			return err
+		}
+	}
+
+	return nil
+}
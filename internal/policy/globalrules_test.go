@@ -0,0 +1,100 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/common"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/internal/signerverifier"
+	"github.com/gittuf/gittuf/internal/signerverifier/dsse"
+	artifacts "github.com/gittuf/gittuf/internal/testartifacts"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyEntryWithGlobalRules(t *testing.T) {
+	addGlobalRule := func(t *testing.T, state *State, ruleName string, rulePatterns []string, threshold int) {
+		t.Helper()
+
+		signer, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(rootKeyBytes) //nolint:staticcheck
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		rootMetadata, err := state.GetRootMetadata()
+		if err != nil {
+			t.Fatal(err)
+		}
+		rootMetadata, err = AddGlobalRule(rootMetadata, ruleName, rulePatterns, threshold)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		rootEnv, err := dsse.CreateEnvelope(rootMetadata)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rootEnv, err = dsse.SignEnvelope(testCtx, rootEnv, signer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		state.RootEnvelope = rootEnv
+	}
+
+	t.Run("unprotected reference signed by key in policy", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithPolicy)
+		addGlobalRule(t, state, "require-signatures", []string{"git:refs/heads/*"}, 1)
+
+		refName := "refs/heads/feature"
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		entry.ID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+
+		err := verifyEntry(testCtx, repo, state, nil, entry)
+		assert.Nil(t, err)
+	})
+
+	t.Run("unprotected reference signed by key not in policy", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithPolicy)
+
+		refName := "refs/heads/feature"
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, artifacts.GPGKey2Private)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		entry.ID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, artifacts.GPGKey2Private)
+
+		addGlobalRule(t, state, "require-signatures", []string{"git:refs/heads/*"}, 1)
+
+		err := verifyEntry(testCtx, repo, state, nil, entry)
+		assert.ErrorIs(t, err, ErrUnauthorizedSignature)
+		assert.ErrorContains(t, err, "require-signatures")
+	})
+
+	t.Run("global threshold higher than rule threshold", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithPolicy)
+		addGlobalRule(t, state, "protect-main-globally", []string{"git:refs/heads/main"}, 2)
+
+		refName := "refs/heads/main"
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		entry.ID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+
+		// The rule protecting main is met, but not the global rule
+		err := verifyEntry(testCtx, repo, state, nil, entry)
+		assert.ErrorIs(t, err, ErrUnauthorizedSignature)
+		assert.ErrorContains(t, err, "protect-main-globally")
+	})
+
+	t.Run("global rule does not match", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithPolicy)
+		addGlobalRule(t, state, "protect-release-globally", []string{"git:refs/heads/release"}, 2)
+
+		refName := "refs/heads/main"
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		entry.ID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+
+		err := verifyEntry(testCtx, repo, state, nil, entry)
+		assert.Nil(t, err)
+	})
+}
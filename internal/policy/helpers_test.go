@@ -4,9 +4,17 @@ package policy
 
 import (
 	"context"
+	"crypto"
+	"io"
+	"strings"
 	"testing"
 
+	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
+
 	"github.com/gittuf/gittuf/internal/attestations"
+	"github.com/gittuf/gittuf/internal/common"
 	"github.com/gittuf/gittuf/internal/rsl"
 	"github.com/gittuf/gittuf/internal/signerverifier"
 	"github.com/gittuf/gittuf/internal/signerverifier/dsse"
@@ -15,6 +23,7 @@ import (
 	"github.com/gittuf/gittuf/internal/tuf"
 	"github.com/go-git/go-billy/v5/memfs"
 	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/storage/memory"
 	sslibdsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
 )
@@ -378,3 +387,86 @@ func createTestStateWithTagPolicyForUnauthorizedTest(t *testing.T) *State {
 
 	return state
 }
+
+// setTestAlgorithmPolicy sets the algorithm policy in the state's root of
+// trust.
+func setTestAlgorithmPolicy(t *testing.T, state *State, algorithmPolicy *tuf.AlgorithmPolicy) {
+	t.Helper()
+
+	rootMetadata, err := state.GetRootMetadata()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootMetadata, err = SetAlgorithmPolicy(rootMetadata, algorithmPolicy)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signer, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(rootKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootEnv, err := dsse.CreateEnvelope(rootMetadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootEnv, err = dsse.SignEnvelope(testCtx, rootEnv, signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	state.RootEnvelope = rootEnv
+}
+
+// createTestSHA1Signature returns a GPG signature made using SHA-1 for the
+// specified Git commit or tag. go-crypto does not create SHA-1 signatures using
+// its high level APIs, so the signature packet is created directly.
+func createTestSHA1Signature(t *testing.T, gitObject interface {
+	EncodeWithoutSignature(plumbing.EncodedObject) error
+}, signingKeyBytes []byte) string {
+	t.Helper()
+
+	encoded := &plumbing.MemoryObject{}
+	if err := gitObject.EncodeWithoutSignature(encoded); err != nil {
+		t.Fatal(err)
+	}
+	r, err := encoded.Reader()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyring, err := openpgp.ReadArmoredKeyRing(strings.NewReader(string(signingKeyBytes)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	privateKey := keyring[0].PrivateKey
+
+	signature := &packet.Signature{
+		Version:      privateKey.Version,
+		SigType:      packet.SigTypeBinary,
+		PubKeyAlgo:   privateKey.PubKeyAlgo,
+		Hash:         crypto.SHA1,
+		CreationTime: common.TestClock.Now(),
+		IssuerKeyId:  &privateKey.KeyId,
+	}
+	h := crypto.SHA1.New()
+	if _, err := io.Copy(h, r); err != nil {
+		t.Fatal(err)
+	}
+	if err := signature.Sign(h, privateKey, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	armored := &strings.Builder{}
+	w, err := armor.Encode(armored, "PGP SIGNATURE", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := signature.Serialize(w); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	return armored.String()
+}
@@ -0,0 +1,118 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+// HistoricalPolicy is the policy in effect at some point in the RSL, along
+// with the RSL entry that recorded it.
+type HistoricalPolicy struct {
+	EntryID      string    `json:"rsl_entry"`
+	PolicyCommit string    `json:"policy_commit"`
+	RecordedAt   time.Time `json:"recorded_at"`
+	Policy       *Document `json:"policy"`
+}
+
+// GetPolicyInEffectForEntry returns the policy that governed the RSL entry,
+// which is the latest policy recorded in the RSL before the entry. This is
+// the policy that the entry is verified against.
+func GetPolicyInEffectForEntry(ctx context.Context, repo *git.Repository, entryID plumbing.Hash) (*HistoricalPolicy, error) {
+	if _, err := rsl.GetEntry(repo, entryID); err != nil {
+		return nil, err
+	}
+
+	policyEntry, _, err := rsl.GetLatestReferenceEntryForRefBefore(repo, PolicyRef, entryID)
+	if err != nil {
+		if errors.Is(err, rsl.ErrRSLEntryNotFound) {
+			return nil, ErrPolicyNotFound
+		}
+		return nil, err
+	}
+
+	return loadHistoricalPolicy(ctx, repo, policyEntry)
+}
+
+// GetPolicyInEffectAtTime returns the policy in effect at the specified time,
+// which is the latest policy recorded in the RSL at or before that time.
+func GetPolicyInEffectAtTime(ctx context.Context, repo *git.Repository, at time.Time) (*HistoricalPolicy, error) {
+	iteratorT, err := rsl.GetLatestEntry(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		entryCommit, err := gitinterface.GetCommit(repo, iteratorT.GetID())
+		if err != nil {
+			return nil, err
+		}
+
+		if !entryCommit.Committer.When.After(at) {
+			var policyEntry *rsl.ReferenceEntry
+			switch iterator := iteratorT.(type) {
+			case *rsl.ReferenceEntry:
+				if iterator.RefName == PolicyRef {
+					policyEntry = iterator
+				}
+			case *rsl.CheckpointEntry:
+				policyEntry = iterator.ReferenceEntryFor(PolicyRef)
+			}
+
+			if policyEntry == nil {
+				policyEntry, _, err = rsl.GetLatestReferenceEntryForRefBefore(repo, PolicyRef, iteratorT.GetID())
+				if err != nil {
+					if errors.Is(err, rsl.ErrRSLEntryNotFound) {
+						return nil, ErrPolicyNotFound
+					}
+					return nil, err
+				}
+			}
+
+			return loadHistoricalPolicy(ctx, repo, policyEntry)
+		}
+
+		iteratorT, err = rsl.GetParentForEntry(repo, iteratorT)
+		if err != nil {
+			if errors.Is(err, rsl.ErrRSLEntryNotFound) {
+				return nil, ErrPolicyNotFound
+			}
+			return nil, err
+		}
+	}
+}
+
+// loadHistoricalPolicy loads and verifies the policy recorded in the entry.
+func loadHistoricalPolicy(ctx context.Context, repo *git.Repository, policyEntry *rsl.ReferenceEntry) (*HistoricalPolicy, error) {
+	slog.Debug(fmt.Sprintf("Loading policy recorded in RSL entry '%s'...", policyEntry.ID.String()))
+	state, err := LoadState(ctx, repo, policyEntry)
+	if err != nil {
+		return nil, err
+	}
+
+	document, err := state.ExportDocument()
+	if err != nil {
+		return nil, err
+	}
+
+	entryCommit, err := gitinterface.GetCommit(repo, policyEntry.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &HistoricalPolicy{
+		EntryID:      policyEntry.ID.String(),
+		PolicyCommit: policyEntry.TargetID.String(),
+		RecordedAt:   entryCommit.Committer.When.UTC(),
+		Policy:       document,
+	}, nil
+}
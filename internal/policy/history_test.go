@@ -0,0 +1,127 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/internal/signerverifier"
+	"github.com/gittuf/gittuf/internal/signerverifier/dsse"
+	"github.com/gittuf/gittuf/internal/signerverifier/gpg"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetPolicyInEffect(t *testing.T) {
+	repo, _ := createTestRepository(t, createTestStateWithPolicy)
+
+	firstPolicyEntry, _, err := rsl.GetLatestReferenceEntryForRef(repo, PolicyRef)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	refName := "refs/heads/main"
+	emptyTreeHash, err := gitinterface.WriteTree(repo, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	commitID, err := gitinterface.Commit(repo, emptyTreeHash, refName, "Initial commit", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := rsl.NewReferenceEntry(refName, commitID).Commit(repo, false); err != nil {
+		t.Fatal(err)
+	}
+	firstEntry, err := rsl.GetLatestEntry(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Update policy
+	state, err := LoadCurrentState(context.Background(), repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsMetadata, err := state.GetTargetsMetadata(TargetsRoleName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsMetadata, err = AddDelegation(targetsMetadata, "new-rule", []*tuf.Key{key}, []string{"git:refs/heads/feature"}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsMetadata.SetVersion(targetsMetadata.Version + 1)
+	signer, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(rootKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsEnv, err := dsse.CreateEnvelope(targetsMetadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsEnv, err = dsse.SignEnvelope(context.Background(), targetsEnv, signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	state.TargetsEnvelope = targetsEnv
+	if err := state.Commit(context.Background(), repo, "Second state", false); err != nil {
+		t.Fatal(err)
+	}
+	secondPolicyEntry, _, err := rsl.GetLatestReferenceEntryForRef(repo, PolicyRef)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	commitID, err = gitinterface.Commit(repo, emptyTreeHash, refName, "Second commit", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := rsl.NewReferenceEntry(refName, commitID).Commit(repo, false); err != nil {
+		t.Fatal(err)
+	}
+	secondEntry, err := rsl.GetLatestEntry(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("for entry", func(t *testing.T) {
+		historicalPolicy, err := GetPolicyInEffectForEntry(context.Background(), repo, firstEntry.GetID())
+		assert.Nil(t, err)
+		assert.Equal(t, firstPolicyEntry.ID.String(), historicalPolicy.EntryID)
+		assert.Equal(t, firstPolicyEntry.TargetID.String(), historicalPolicy.PolicyCommit)
+		assert.Len(t, historicalPolicy.Policy.PolicyFiles[0].Rules, 2)
+
+		historicalPolicy, err = GetPolicyInEffectForEntry(context.Background(), repo, secondEntry.GetID())
+		assert.Nil(t, err)
+		assert.Equal(t, secondPolicyEntry.ID.String(), historicalPolicy.EntryID)
+		assert.Len(t, historicalPolicy.Policy.PolicyFiles[0].Rules, 3)
+
+		// A policy entry is governed by the policy before it
+		historicalPolicy, err = GetPolicyInEffectForEntry(context.Background(), repo, secondPolicyEntry.ID)
+		assert.Nil(t, err)
+		assert.Equal(t, firstPolicyEntry.ID.String(), historicalPolicy.EntryID)
+
+		_, err = GetPolicyInEffectForEntry(context.Background(), repo, firstPolicyEntry.ID)
+		assert.ErrorIs(t, err, ErrPolicyNotFound)
+
+		_, err = GetPolicyInEffectForEntry(context.Background(), repo, commitID)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("at time", func(t *testing.T) {
+		historicalPolicy, err := GetPolicyInEffectAtTime(context.Background(), repo, time.Now().Add(time.Hour))
+		assert.Nil(t, err)
+		assert.Equal(t, secondPolicyEntry.ID.String(), historicalPolicy.EntryID)
+
+		_, err = GetPolicyInEffectAtTime(context.Background(), repo, historicalPolicy.RecordedAt.Add(-time.Hour))
+		assert.ErrorIs(t, err, ErrPolicyNotFound)
+	})
+}
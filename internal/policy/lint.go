@@ -0,0 +1,332 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gittuf/gittuf/internal/third_party/go-securesystemslib/signerverifier"
+	"github.com/gittuf/gittuf/internal/tuf"
+)
+
+const (
+	minimumRSAKeySize   = 2048
+	minimumECDSAKeySize = 256
+)
+
+// LintSeverity indicates how serious a lint finding is. Errors are problems
+// that prevent the policy from working as intended, while warnings are likely
+// mistakes.
+type LintSeverity string
+
+const (
+	LintError   LintSeverity = "error"
+	LintWarning LintSeverity = "warning"
+)
+
+// Identifiers for each of the checks performed by Lint.
+const (
+	LintCheckUnreachableRule     = "unreachable-rule"
+	LintCheckUnmeetableThreshold = "unmeetable-threshold"
+	LintCheckDuplicatePattern    = "duplicate-pattern"
+	LintCheckMissingKey          = "missing-key"
+	LintCheckMissingPerson       = "missing-person"
+	LintCheckMissingTeam         = "missing-team"
+	LintCheckWeakKey             = "weak-key"
+)
+
+// LintFinding records a problem identified in a policy State.
+type LintFinding struct {
+	Severity LintSeverity `json:"severity"`
+	Check    string       `json:"check"`
+
+	// PolicyName is the name of the metadata the finding applies to, either
+	// the root of trust or a policy file.
+	PolicyName string `json:"policyName"`
+
+	// RuleName is the name of the role in the root of trust or the rule in
+	// the policy file the finding applies to, if any.
+	RuleName string `json:"ruleName,omitempty"`
+
+	Message string `json:"message"`
+}
+
+// Lint checks the State for rules that are shadowed by an earlier terminating
+// rule, roles and rules whose threshold cannot be met by their trusted keys,
+// patterns listed more than once, references to keys that are not defined,
+// and keys that use weak algorithms. Lint does not verify signatures.
+func (s *State) Lint() ([]*LintFinding, error) {
+	findings := []*LintFinding{}
+
+	rootMetadata, err := s.GetRootMetadata()
+	if err != nil {
+		return nil, err
+	}
+
+	roleNames := mapKeys(rootMetadata.Roles)
+	sort.Strings(roleNames)
+	for _, roleName := range roleNames {
+		role := rootMetadata.Roles[roleName]
+		findings = append(findings, lintKeyIDs(RootRoleName, roleName, role.KeyIDs, role.Threshold, rootMetadata.Keys, rootMetadata)...)
+	}
+	findings = append(findings, lintKeys(RootRoleName, rootMetadata.Keys)...)
+
+	policyNames := []string{}
+	if s.TargetsEnvelope != nil {
+		policyNames = append(policyNames, TargetsRoleName)
+	}
+	delegationNames := mapKeys(s.DelegationEnvelopes)
+	sort.Strings(delegationNames)
+	policyNames = append(policyNames, delegationNames...)
+
+	for _, policyName := range policyNames {
+		targetsMetadata, err := s.GetTargetsMetadata(policyName)
+		if err != nil {
+			return nil, err
+		}
+		if targetsMetadata.Delegations == nil {
+			continue
+		}
+
+		rules := []tuf.Delegation{}
+		for _, rule := range targetsMetadata.Delegations.Roles {
+			if rule.Name != AllowRuleName {
+				rules = append(rules, rule)
+			}
+		}
+
+		patternRules := map[string]string{}
+		for i, rule := range rules {
+			findings = append(findings, lintRuleKeyIDs(policyName, rule, targetsMetadata.Delegations, rootMetadata)...)
+
+			seenPatterns := map[string]bool{}
+			for _, pattern := range rule.Paths {
+				if seenPatterns[pattern] {
+					findings = append(findings, &LintFinding{
+						Severity:   LintWarning,
+						Check:      LintCheckDuplicatePattern,
+						PolicyName: policyName,
+						RuleName:   rule.Name,
+						Message:    fmt.Sprintf("pattern '%s' is listed more than once", pattern),
+					})
+					continue
+				}
+				seenPatterns[pattern] = true
+
+				if otherRule, has := patternRules[pattern]; has {
+					findings = append(findings, &LintFinding{
+						Severity:   LintWarning,
+						Check:      LintCheckDuplicatePattern,
+						PolicyName: policyName,
+						RuleName:   rule.Name,
+						Message:    fmt.Sprintf("pattern '%s' is also protected by rule '%s'", pattern, otherRule),
+					})
+					continue
+				}
+				patternRules[pattern] = rule.Name
+			}
+
+			for _, earlierRule := range rules[:i] {
+				// Terminating rules only stop the search when they have a
+				// policy file
+				if !earlierRule.Terminating || !s.HasTargetsRole(earlierRule.Name) {
+					continue
+				}
+
+				if coversPatterns(earlierRule.Paths, rule.Paths) {
+					findings = append(findings, &LintFinding{
+						Severity:   LintWarning,
+						Check:      LintCheckUnreachableRule,
+						PolicyName: policyName,
+						RuleName:   rule.Name,
+						Message:    fmt.Sprintf("rule is never evaluated as all of its patterns are matched by earlier terminating rule '%s'", earlierRule.Name),
+					})
+					break
+				}
+			}
+		}
+
+		findings = append(findings, lintKeys(policyName, targetsMetadata.Delegations.Keys)...)
+	}
+
+	return findings, nil
+}
+
+// lintKeyIDs checks that each key trusted for a role or rule is defined and
+// that enough of the keys are not revoked to meet the threshold.
+func lintKeyIDs(policyName, ruleName string, keyIDs []string, threshold int, keys map[string]*tuf.Key, rootMetadata *tuf.RootMetadata) []*LintFinding {
+	findings, usableKeys := lintUsableKeyIDs(policyName, ruleName, keyIDs, keys, rootMetadata)
+
+	if usableKeys < threshold {
+		findings = append(findings, &LintFinding{
+			Severity:   LintError,
+			Check:      LintCheckUnmeetableThreshold,
+			PolicyName: policyName,
+			RuleName:   ruleName,
+			Message:    fmt.Sprintf("threshold of %d cannot be met by %d usable key(s)", threshold, usableKeys),
+		})
+	}
+
+	return findings
+}
+
+// lintRuleKeyIDs is similar to lintKeyIDs, but additionally checks that each
+// person and team authorized by the rule is defined. A person is usable if at
+// least one of their keys is usable, and counts once towards the threshold.
+func lintRuleKeyIDs(policyName string, rule tuf.Delegation, delegations *tuf.Delegations, rootMetadata *tuf.RootMetadata) []*LintFinding {
+	if len(rule.PersonIDs) == 0 && len(rule.TeamIDs) == 0 {
+		return lintKeyIDs(policyName, rule.Name, rule.KeyIDs, rule.Threshold, delegations.Keys, rootMetadata)
+	}
+
+	findings := []*LintFinding{}
+
+	principals := resolvePrincipals(rule, delegations.People, delegations.Teams)
+	for _, personID := range principals.missingPeople {
+		findings = append(findings, &LintFinding{
+			Severity:   LintError,
+			Check:      LintCheckMissingPerson,
+			PolicyName: policyName,
+			RuleName:   rule.Name,
+			Message:    fmt.Sprintf("person '%s' is authorized but not defined", personID),
+		})
+	}
+	for _, teamID := range principals.missingTeams {
+		findings = append(findings, &LintFinding{
+			Severity:   LintError,
+			Check:      LintCheckMissingTeam,
+			PolicyName: policyName,
+			RuleName:   rule.Name,
+			Message:    fmt.Sprintf("team '%s' is authorized but not defined", teamID),
+		})
+	}
+
+	usablePrincipals := map[string]bool{}
+	for _, authorized := range principals.keys {
+		keyFindings, usableKeys := lintUsableKeyIDs(policyName, rule.Name, []string{authorized.keyID}, delegations.Keys, rootMetadata)
+		findings = append(findings, keyFindings...)
+		if usableKeys > 0 {
+			usablePrincipals[authorized.principalID] = true
+		}
+	}
+
+	if len(usablePrincipals) < rule.Threshold {
+		findings = append(findings, &LintFinding{
+			Severity:   LintError,
+			Check:      LintCheckUnmeetableThreshold,
+			PolicyName: policyName,
+			RuleName:   rule.Name,
+			Message:    fmt.Sprintf("threshold of %d cannot be met by %d usable key(s) or person(s)", rule.Threshold, len(usablePrincipals)),
+		})
+	}
+
+	return findings
+}
+
+// lintUsableKeyIDs checks that each key is defined, and returns the number of
+// keys that are defined and not revoked.
+func lintUsableKeyIDs(policyName, ruleName string, keyIDs []string, keys map[string]*tuf.Key, rootMetadata *tuf.RootMetadata) ([]*LintFinding, int) {
+	findings := []*LintFinding{}
+
+	usableKeys := 0
+	for _, keyID := range keyIDs {
+		if _, has := keys[keyID]; !has {
+			findings = append(findings, &LintFinding{
+				Severity:   LintError,
+				Check:      LintCheckMissingKey,
+				PolicyName: policyName,
+				RuleName:   ruleName,
+				Message:    fmt.Sprintf("key '%s' is trusted but not defined", keyID),
+			})
+			continue
+		}
+
+		if !rootMetadata.IsKeyRevoked(keyID) {
+			usableKeys++
+		}
+	}
+
+	return findings, usableKeys
+}
+
+// lintKeys checks that keys do not use a weak algorithm. Only keys in the
+// securesystemslib format can be inspected.
+func lintKeys(policyName string, keys map[string]*tuf.Key) []*LintFinding {
+	findings := []*LintFinding{}
+
+	keyIDs := mapKeys(keys)
+	sort.Strings(keyIDs)
+	for _, keyID := range keyIDs {
+		reason := weakKeyReason(keys[keyID])
+		if reason == "" {
+			continue
+		}
+
+		findings = append(findings, &LintFinding{
+			Severity:   LintWarning,
+			Check:      LintCheckWeakKey,
+			PolicyName: policyName,
+			Message:    fmt.Sprintf("key '%s' %s", keyID, reason),
+		})
+	}
+
+	return findings
+}
+
+func weakKeyReason(key *tuf.Key) string {
+	if strings.Contains(strings.ToLower(key.Scheme), "sha1") {
+		return fmt.Sprintf("uses SHA-1 based scheme '%s'", key.Scheme)
+	}
+
+	if key.KeyType != signerverifier.RSAKeyType && key.KeyType != signerverifier.ECDSAKeyType {
+		return ""
+	}
+
+	verifier, err := signerverifier.NewVerifierFromSSLibKey(key)
+	if err != nil {
+		return ""
+	}
+
+	switch publicKey := verifier.Public().(type) {
+	case *rsa.PublicKey:
+		if size := publicKey.N.BitLen(); size < minimumRSAKeySize {
+			return fmt.Sprintf("is a %d bit RSA key, at least %d bits are recommended", size, minimumRSAKeySize)
+		}
+	case *ecdsa.PublicKey:
+		if size := publicKey.Curve.Params().BitSize; size < minimumECDSAKeySize {
+			return fmt.Sprintf("uses a %d bit ECDSA curve, at least %d bits are recommended", size, minimumECDSAKeySize)
+		}
+	}
+
+	return ""
+}
+
+// coversPatterns returns true if every pattern in patterns is matched by one
+// of the patterns in coveringPatterns.
+func coversPatterns(coveringPatterns, patterns []string) bool {
+	if len(patterns) == 0 {
+		return false
+	}
+
+	for _, pattern := range patterns {
+		covered := false
+		for _, coveringPattern := range coveringPatterns {
+			if coveringPattern == pattern {
+				covered = true
+				break
+			}
+			if tuf.MatchPattern(coveringPattern, pattern) {
+				covered = true
+				break
+			}
+		}
+		if !covered {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,167 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/signerverifier/dsse"
+	"github.com/gittuf/gittuf/internal/signerverifier/gpg"
+	"github.com/gittuf/gittuf/internal/third_party/go-securesystemslib/signerverifier"
+	"github.com/gittuf/gittuf/internal/tuf"
+	sslibdsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStateLint(t *testing.T) {
+	t.Run("no findings", func(t *testing.T) {
+		state := createTestStateWithPolicy(t)
+
+		findings, err := state.Lint()
+		assert.Nil(t, err)
+		assert.Empty(t, findings)
+	})
+
+	t.Run("problematic rules", func(t *testing.T) {
+		state := createTestStateWithPolicy(t)
+
+		gpgKey, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		privateKey, err := ecdsa.GenerateKey(elliptic.P224(), rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		weakKey, err := signerverifier.NewKey(&privateKey.PublicKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		targetsMetadata, err := state.GetTargetsMetadata(TargetsRoleName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsMetadata, err = AddDelegation(targetsMetadata, "unmeetable", []*tuf.Key{gpgKey}, []string{"git:refs/heads/unmeetable"}, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsMetadata, err = AddDelegation(targetsMetadata, "duplicate", []*tuf.Key{weakKey}, []string{"file:3", "file:3", "file:1"}, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// protect-main has a policy file and is made terminating, so rules
+		// after it for the same ref are never evaluated
+		targetsMetadata.Delegations.Roles[0].Terminating = true
+		targetsMetadata, err = AddDelegation(targetsMetadata, "shadowed", []*tuf.Key{gpgKey}, []string{"git:refs/heads/main"}, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		state.DelegationEnvelopes = map[string]*sslibdsse.Envelope{}
+		state.DelegationEnvelopes["protect-main"], err = dsse.CreateEnvelope(InitializeTargetsMetadata())
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Reference an undefined key
+		allowRule := targetsMetadata.Delegations.Roles[len(targetsMetadata.Delegations.Roles)-1]
+		targetsMetadata.Delegations.Roles = append(targetsMetadata.Delegations.Roles[:len(targetsMetadata.Delegations.Roles)-1], tuf.Delegation{
+			Name:  "missing",
+			Paths: []string{"git:refs/heads/missing"},
+			Role:  tuf.Role{KeyIDs: []string{"missing-key-id"}, Threshold: 1},
+		}, allowRule)
+
+		state.TargetsEnvelope, err = dsse.CreateEnvelope(targetsMetadata)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		findings, err := state.Lint()
+		assert.Nil(t, err)
+
+		checks := map[string][]string{}
+		for _, finding := range findings {
+			checks[finding.Check] = append(checks[finding.Check], finding.RuleName)
+		}
+		assert.Equal(t, map[string][]string{
+			LintCheckUnmeetableThreshold: {"unmeetable", "missing"},
+			LintCheckDuplicatePattern:    {"duplicate", "duplicate", "shadowed"},
+			LintCheckUnreachableRule:     {"shadowed"},
+			LintCheckMissingKey:          {"missing"},
+			LintCheckWeakKey:             {""},
+		}, checks)
+	})
+
+	t.Run("people and teams", func(t *testing.T) {
+		state := createTestStateWithPolicy(t)
+
+		gpgKey, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+		if err != nil {
+			t.Fatal(err)
+		}
+		key, err := tuf.LoadKeyFromBytes(targets1PubKeyBytes)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		targetsMetadata, err := state.GetTargetsMetadata(TargetsRoleName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsMetadata, err = AddPerson(targetsMetadata, "alice", []*tuf.Key{gpgKey})
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsMetadata, err = AddDelegation(targetsMetadata, "people", []*tuf.Key{key}, []string{"git:refs/heads/people"}, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsMetadata, err = SetAuthorizedPersons(targetsMetadata, "people", []string{"alice"})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// alice's key is also trusted directly, but alice counts once
+		targetsMetadata, err = AddDelegation(targetsMetadata, "overlap", []*tuf.Key{gpgKey}, []string{"git:refs/heads/overlap"}, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsMetadata, err = SetAuthorizedPersons(targetsMetadata, "overlap", []string{"alice"})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Reference an undefined person and team
+		allowRule := targetsMetadata.Delegations.Roles[len(targetsMetadata.Delegations.Roles)-1]
+		targetsMetadata.Delegations.Roles = append(targetsMetadata.Delegations.Roles[:len(targetsMetadata.Delegations.Roles)-1], tuf.Delegation{
+			Name:      "missing",
+			Paths:     []string{"git:refs/heads/missing"},
+			PersonIDs: []string{"bob"},
+			TeamIDs:   []string{"reviewers"},
+			Role:      tuf.Role{KeyIDs: []string{}, Threshold: 1},
+		}, allowRule)
+
+		state.TargetsEnvelope, err = dsse.CreateEnvelope(targetsMetadata)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		findings, err := state.Lint()
+		assert.Nil(t, err)
+
+		checks := map[string][]string{}
+		for _, finding := range findings {
+			checks[finding.Check] = append(checks[finding.Check], finding.RuleName)
+		}
+		assert.Equal(t, map[string][]string{
+			LintCheckUnmeetableThreshold: {"overlap", "missing"},
+			LintCheckMissingPerson:       {"missing"},
+			LintCheckMissingTeam:         {"missing"},
+		}, checks)
+	})
+}
@@ -0,0 +1,150 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"fmt"
+	"log/slog"
+	"sort"
+
+	"github.com/gittuf/gittuf/internal/signerverifier/dsse"
+	"github.com/gittuf/gittuf/internal/tuf"
+)
+
+// Migration upgrades metadata to a newer version of gittuf's metadata schema.
+type Migration struct {
+	// SchemaVersion is the schema version of metadata once migrated.
+	SchemaVersion int    `json:"schemaVersion"`
+	Description   string `json:"description"`
+
+	// migrateRoot and migrateTargets update metadata in place. If nil, the
+	// migration does not change the contents of that type of metadata.
+	migrateRoot    func(*tuf.RootMetadata) error
+	migrateTargets func(*tuf.TargetsMetadata) error
+}
+
+// migrations must be ordered by schema version, with one migration for each
+// version up to tuf.SchemaVersion.
+var migrations = []*Migration{
+	{
+		// Migrated metadata is always encoded canonically, so no changes to
+		// its contents are needed
+		SchemaVersion: 1,
+		Description:   "encode metadata canonically so that signers of the same metadata sign identical payloads",
+	},
+}
+
+// Migrations returns the migrations that upgrade metadata at schemaVersion to
+// the current schema version, in the order they are applied.
+func Migrations(schemaVersion int) []*Migration {
+	pending := []*Migration{}
+	for _, migration := range migrations {
+		if migration.SchemaVersion > schemaVersion {
+			pending = append(pending, migration)
+		}
+	}
+	return pending
+}
+
+// SchemaVersions returns the schema version of the State's root of trust and
+// each of its policy files.
+func (s *State) SchemaVersions() (map[string]int, error) {
+	rootMetadata, err := s.GetRootMetadata()
+	if err != nil {
+		return nil, err
+	}
+	schemaVersions := map[string]int{RootRoleName: rootMetadata.SchemaVersion}
+
+	for _, roleName := range s.targetsRoleNames() {
+		targetsMetadata, err := s.GetTargetsMetadata(roleName)
+		if err != nil {
+			return nil, err
+		}
+		schemaVersions[roleName] = targetsMetadata.SchemaVersion
+	}
+
+	return schemaVersions, nil
+}
+
+// MigrateMetadata upgrades the State's root of trust and policy files that were
+// created using an older schema to the current schema version, and returns the
+// names of the migrated roles. The version of migrated metadata is
+// incremented, and its signatures are removed as they do not apply to the
+// migrated metadata. The migrated metadata must be signed by a threshold of its
+// trusted keys before the State can be committed.
+func (s *State) MigrateMetadata() ([]string, error) {
+	migrated := []string{}
+
+	rootMetadata, err := s.GetRootMetadata()
+	if err != nil {
+		return nil, err
+	}
+	if rootMetadata.SchemaVersion < tuf.SchemaVersion {
+		for _, migration := range Migrations(rootMetadata.SchemaVersion) {
+			slog.Debug(fmt.Sprintf("Migrating '%s' to schema version %d...", RootRoleName, migration.SchemaVersion))
+			if migration.migrateRoot != nil {
+				if err := migration.migrateRoot(rootMetadata); err != nil {
+					return nil, err
+				}
+			}
+		}
+		rootMetadata.SchemaVersion = tuf.SchemaVersion
+		rootMetadata.SetVersion(rootMetadata.Version + 1)
+
+		env, err := dsse.CreateEnvelope(rootMetadata)
+		if err != nil {
+			return nil, err
+		}
+		s.RootEnvelope = env
+		migrated = append(migrated, RootRoleName)
+	}
+
+	for _, roleName := range s.targetsRoleNames() {
+		targetsMetadata, err := s.GetTargetsMetadata(roleName)
+		if err != nil {
+			return nil, err
+		}
+		if targetsMetadata.SchemaVersion >= tuf.SchemaVersion {
+			continue
+		}
+
+		for _, migration := range Migrations(targetsMetadata.SchemaVersion) {
+			slog.Debug(fmt.Sprintf("Migrating '%s' to schema version %d...", roleName, migration.SchemaVersion))
+			if migration.migrateTargets != nil {
+				if err := migration.migrateTargets(targetsMetadata); err != nil {
+					return nil, err
+				}
+			}
+		}
+		targetsMetadata.SchemaVersion = tuf.SchemaVersion
+		targetsMetadata.SetVersion(targetsMetadata.Version + 1)
+
+		env, err := dsse.CreateEnvelope(targetsMetadata)
+		if err != nil {
+			return nil, err
+		}
+		if roleName == TargetsRoleName {
+			s.TargetsEnvelope = env
+		} else {
+			s.DelegationEnvelopes[roleName] = env
+		}
+		migrated = append(migrated, roleName)
+	}
+
+	return migrated, nil
+}
+
+// targetsRoleNames returns the names of the State's policy files, with the
+// top level policy file first and the rest sorted by name.
+func (s *State) targetsRoleNames() []string {
+	roleNames := []string{}
+	for roleName := range s.DelegationEnvelopes {
+		roleNames = append(roleNames, roleName)
+	}
+	sort.Strings(roleNames)
+
+	if s.TargetsEnvelope != nil {
+		roleNames = append([]string{TargetsRoleName}, roleNames...)
+	}
+	return roleNames
+}
@@ -0,0 +1,46 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/signerverifier/dsse"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrations(t *testing.T) {
+	assert.Equal(t, migrations, Migrations(0))
+	assert.Empty(t, Migrations(tuf.SchemaVersion))
+}
+
+func TestStateMigrateMetadata(t *testing.T) {
+	state := createTestStateWithPolicy(t)
+
+	migrated, err := state.MigrateMetadata()
+	assert.Nil(t, err)
+	assert.Empty(t, migrated)
+
+	targetsMetadata, err := state.GetTargetsMetadata(TargetsRoleName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsMetadata.SchemaVersion = 0
+	state.TargetsEnvelope, err = dsse.CreateEnvelope(targetsMetadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	migrated, err = state.MigrateMetadata()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{TargetsRoleName}, migrated)
+
+	migratedMetadata, err := state.GetTargetsMetadata(TargetsRoleName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, tuf.SchemaVersion, migratedMetadata.SchemaVersion)
+	assert.Equal(t, targetsMetadata.Version+1, migratedMetadata.Version)
+	assert.Empty(t, state.TargetsEnvelope.Signatures)
+}
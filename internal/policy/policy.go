@@ -9,8 +9,10 @@ import (
 	"fmt"
 	"log/slog"
 	"reflect"
+	"slices"
 	"sort"
 	"strings"
+	"sync"
 
 	"github.com/gittuf/gittuf/internal/common/set"
 	"github.com/gittuf/gittuf/internal/gitinterface"
@@ -36,6 +38,12 @@ const (
 	// TargetsRoleName defines the expected name for the top level gittuf policy file.
 	TargetsRoleName = "targets"
 
+	// GitHubAppRoleName defines the expected name for the role trusted to sign GitHub pull request approval attestations.
+	GitHubAppRoleName = "github-app"
+
+	// GitLabAppRoleName defines the expected name for the role trusted to sign GitLab merge request approval attestations.
+	GitLabAppRoleName = "gitlab-app"
+
 	// DefaultCommitMessage defines the fallback message to use when updating the policy ref if an action specific message is unavailable.
 	DefaultCommitMessage = "Update policy state"
 
@@ -56,6 +64,11 @@ var (
 	ErrPolicyNotFound             = errors.New("cannot find policy")
 	ErrDuplicatedRuleName         = errors.New("two rules with same name found in policy")
 	ErrUnableToMatchRootKeys      = errors.New("unable to match root public keys, gittuf policy is in a broken state")
+	ErrNoStagedPolicy             = errors.New("no staged policy changes found")
+	ErrUnverifiedCheckpoint       = errors.New("RSL checkpoint is not signed by a threshold of root keys")
+	ErrUntrustedRootOfTrust       = errors.New("initial root of trust is not signed by a threshold of expected root keys")
+	ErrNoOrganizationRoot         = errors.New("repository's root of trust does not delegate to an organization root of trust")
+	ErrUntrustedByOrganization    = errors.New("root of trust is not signed by a threshold of the keys expected by the organization root of trust")
 )
 
 // InitializeNamespace creates a git ref for the policy. Initially, the entry
@@ -86,10 +99,13 @@ type State struct {
 	RootEnvelope        *sslibdsse.Envelope
 	TargetsEnvelope     *sslibdsse.Envelope
 	DelegationEnvelopes map[string]*sslibdsse.Envelope
+	SnapshotEnvelope    *sslibdsse.Envelope
 	RootPublicKeys      []*tuf.Key
 
-	verifiersCache map[string][]*Verifier
-	ruleNames      *set.Set[string]
+	verifiersCache    map[string][]*Verifier
+	verifiersCacheMu  sync.Mutex
+	ruleNames         *set.Set[string]
+	revocationEntries map[string]plumbing.Hash
 }
 
 type DelegationWithDepth struct {
@@ -99,7 +115,9 @@ type DelegationWithDepth struct {
 
 // LoadState returns the State of the repository's policy corresponding to the
 // entry. It verifies the root of trust for the state from the initial policy
-// entry in the RSL.
+// entry in the RSL. If the RSL begins with a checkpoint, the policy recorded in
+// the checkpoint is the initial policy and the checkpoint must be signed by its
+// root of trust.
 func LoadState(ctx context.Context, repo *git.Repository, entry *rsl.ReferenceEntry) (*State, error) {
 	firstEntry, _, err := rsl.GetFirstEntry(repo)
 	if err != nil {
@@ -121,9 +139,22 @@ func LoadState(ctx context.Context, repo *git.Repository, entry *rsl.ReferenceEn
 		return nil, ErrPolicyNotFound
 	}
 
+	if err := initialState.verifyCheckpoint(ctx, repo, firstEntry); err != nil {
+		return nil, err
+	}
+
 	slog.Debug(fmt.Sprintf("Trusting root of trust for initial policy '%s'...", firstEntry.ID))
+	if err := initialState.trackRevocations(nil, firstEntry.ID); err != nil {
+		return nil, err
+	}
 	verifiedState := initialState
 	for _, entry := range allPolicyEntries[1:] {
+		if entry.RefName != PolicyRef {
+			// Entries for other gittuf namespaces such as attestations are
+			// also returned
+			continue
+		}
+
 		slog.Debug(fmt.Sprintf("Verifying root of trust for policy '%s'...", entry.ID))
 		currentState, err := loadStateForEntry(ctx, repo, entry)
 		if err != nil {
@@ -134,12 +165,91 @@ func LoadState(ctx context.Context, repo *git.Repository, entry *rsl.ReferenceEn
 			return nil, err
 		}
 
+		if err := currentState.trackRevocations(verifiedState, entry.ID); err != nil {
+			return nil, err
+		}
+
 		verifiedState = currentState
 	}
 
 	return verifiedState, nil
 }
 
+// VerifyRootOfTrust checks that the root of trust of the repository's initial
+// policy is signed by a threshold of the expected root keys, identified by their
+// key IDs. This allows the root of trust, which is otherwise trusted on first
+// use, to be established using root keys obtained out of band.
+func VerifyRootOfTrust(ctx context.Context, repo *git.Repository, expectedRootKeyIDs []string) error {
+	firstEntry, _, err := rsl.GetFirstEntry(repo)
+	if err != nil {
+		return err
+	}
+
+	initialState, err := loadStateForEntry(ctx, repo, firstEntry)
+	if err != nil {
+		return err
+	}
+
+	rootMetadata, err := initialState.GetRootMetadata()
+	if err != nil {
+		return err
+	}
+
+	verifier := &Verifier{threshold: rootMetadata.Roles[RootRoleName].Threshold}
+	for _, key := range initialState.RootPublicKeys {
+		if slices.Contains(expectedRootKeyIDs, key.KeyID) {
+			verifier.keys = append(verifier.keys, key)
+		}
+	}
+
+	slog.Debug(fmt.Sprintf("Verifying initial root of trust '%s' using expected root keys...", firstEntry.ID))
+	if err := verifier.Verify(ctx, nil, initialState.RootEnvelope); err != nil {
+		return errors.Join(ErrUntrustedRootOfTrust, err)
+	}
+
+	return initialState.verifyCheckpoint(ctx, repo, firstEntry)
+}
+
+// VerifyRootWithOrganizationRoot checks that the root of trust in state is
+// signed by a threshold of the keys the organization's root of trust in
+// organizationState expects for the repository. Keys revoked in the
+// organization's root of trust are not used.
+func VerifyRootWithOrganizationRoot(ctx context.Context, state, organizationState *State) error {
+	rootMetadata, err := state.GetRootMetadata()
+	if err != nil {
+		return err
+	}
+	if rootMetadata.OrganizationRoot == nil {
+		return ErrNoOrganizationRoot
+	}
+	repositoryName := rootMetadata.OrganizationRoot.RepositoryName
+
+	organizationRootMetadata, err := organizationState.GetRootMetadata()
+	if err != nil {
+		return err
+	}
+
+	repositoryRoot, has := organizationRootMetadata.RepositoryRoots[repositoryName]
+	if !has {
+		return fmt.Errorf("%w: '%s'", ErrRepositoryRootNil, repositoryName)
+	}
+
+	verifier := &Verifier{name: repositoryName, threshold: repositoryRoot.Threshold}
+	for keyID, key := range repositoryRoot.Keys {
+		if organizationRootMetadata.IsKeyRevoked(keyID) {
+			continue
+		}
+		verifier.keys = append(verifier.keys, key)
+	}
+
+	slog.Debug(fmt.Sprintf("Verifying root of trust using keys expected for repository '%s' by organization root of trust...", repositoryName))
+	if err := verifier.Verify(ctx, nil, state.RootEnvelope); err != nil {
+		return errors.Join(ErrUntrustedByOrganization, err)
+	}
+
+	return nil
+}
+
 // LoadCurrentState returns the State corresponding to the repository's current
 // active policy. It verifies the root of trust for the state starting from the
 // initial policy entry in the RSL.
@@ -175,8 +285,42 @@ func GetStateForCommit(ctx context.Context, repo *git.Repository, commit *object
 	return LoadState(ctx, repo, commitPolicyEntry)
 }
 
-// PublicKeys returns all the public keys associated with a state.
+// PublicKeys returns all the public keys associated with a state. Keys revoked
+// in the root of trust are not included.
 func (s *State) PublicKeys() (map[string]*tuf.Key, error) {
+	allKeys, rootMetadata, err := s.allPublicKeys()
+	if err != nil || allKeys == nil {
+		return nil, err
+	}
+
+	for keyID := range rootMetadata.RevokedKeys {
+		delete(allKeys, keyID)
+	}
+
+	return allKeys, nil
+}
+
+// RevokedPublicKeys returns the public keys associated with a state that have
+// been revoked in the root of trust.
+func (s *State) RevokedPublicKeys() (map[string]*tuf.Key, error) {
+	allKeys, rootMetadata, err := s.allPublicKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	revokedKeys := map[string]*tuf.Key{}
+	for keyID, key := range allKeys {
+		if rootMetadata.IsKeyRevoked(keyID) {
+			revokedKeys[keyID] = key
+		}
+	}
+
+	return revokedKeys, nil
+}
+
+// allPublicKeys returns all the public keys associated with a state, including
+// revoked keys, along with the root metadata.
+func (s *State) allPublicKeys() (map[string]*tuf.Key, *tuf.RootMetadata, error) {
 	allKeys := map[string]*tuf.Key{}
 
 	// Add root keys
@@ -188,7 +332,7 @@ func (s *State) PublicKeys() (map[string]*tuf.Key, error) {
 	// Add keys from the root metadata
 	rootMetadata, err := s.GetRootMetadata()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	for keyID, key := range rootMetadata.Keys {
 		key := key
@@ -198,11 +342,11 @@ func (s *State) PublicKeys() (map[string]*tuf.Key, error) {
 	// Add keys from top level targets metadata
 	if s.TargetsEnvelope == nil {
 		// Early states where this hasn't been initialized yet
-		return nil, err
+		return nil, rootMetadata, nil
 	}
 	targetsMetadata, err := s.GetTargetsMetadata(TargetsRoleName)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	for keyID, key := range targetsMetadata.Delegations.Keys {
 		key := key
@@ -213,7 +357,7 @@ func (s *State) PublicKeys() (map[string]*tuf.Key, error) {
 	for roleName := range s.DelegationEnvelopes {
 		delegatedMetadata, err := s.GetTargetsMetadata(roleName)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		for keyID, key := range delegatedMetadata.Delegations.Keys {
 			key := key
@@ -221,11 +365,13 @@ func (s *State) PublicKeys() (map[string]*tuf.Key, error) {
 		}
 	}
 
-	return allKeys, nil
+	return allKeys, rootMetadata, nil
 }
 
 // FindPublicKeysForPath identifies the trusted keys for the path. If the path
-// protected in gittuf policy, the trusted keys are returned.
+// protected in gittuf policy, the trusted keys are returned. Only the keys
+// authorized directly by rules are returned, the people and teams authorized
+// by rules are not considered.
 //
 // Deprecated: use FindVerifiersForPath.
 func (s *State) FindPublicKeysForPath(ctx context.Context, path string) ([]*tuf.Key, error) {
@@ -238,6 +384,11 @@ func (s *State) FindPublicKeysForPath(ctx context.Context, path string) ([]*tuf.
 		return nil, err
 	}
 
+	rootMetadata, err := s.GetRootMetadata()
+	if err != nil {
+		return nil, err
+	}
+
 	allPublicKeys := targetsMetadata.Delegations.Keys
 	delegationsQueue := targetsMetadata.Delegations.Roles
 	seenRoles := map[string]bool{TargetsRoleName: true}
@@ -253,6 +404,9 @@ func (s *State) FindPublicKeysForPath(ctx context.Context, path string) ([]*tuf.
 
 		if delegation.Matches(path) {
 			for _, keyID := range delegation.KeyIDs {
+				if rootMetadata.IsKeyRevoked(keyID) {
+					continue
+				}
 				key := allPublicKeys[keyID]
 				trustedKeys = append(trustedKeys, key)
 			}
@@ -291,14 +445,19 @@ func (s *State) FindPublicKeysForPath(ctx context.Context, path string) ([]*tuf.
 // specified path. While walking the delegation graph for the path, signatures
 // for delegated metadata files are verified using the verifier context.
 func (s *State) FindVerifiersForPath(path string) ([]*Verifier, error) {
+	// The cache is guarded as RSL entries may be verified concurrently using
+	// the same policy
+	s.verifiersCacheMu.Lock()
 	if s.verifiersCache == nil {
 		slog.Debug("Initializing path cache in policy...")
 		s.verifiersCache = map[string][]*Verifier{}
 	} else if verifiers, cacheHit := s.verifiersCache[path]; cacheHit {
+		s.verifiersCacheMu.Unlock()
 		// Cache hit for this path in this policy
 		slog.Debug(fmt.Sprintf("Found cached verifiers for path '%s'", path))
 		return verifiers, nil
 	}
+	s.verifiersCacheMu.Unlock()
 
 	if !s.HasTargetsRole(TargetsRoleName) {
 		// No policies exist
@@ -312,10 +471,24 @@ func (s *State) FindVerifiersForPath(path string) ([]*Verifier, error) {
 		return nil, err
 	}
 
+	// Revoked keys are recorded in the root of trust and apply to all rules
+	rootMetadata, err := s.GetRootMetadata()
+	if err != nil {
+		return nil, err
+	}
+
 	allPublicKeys := targetsMetadata.Delegations.Keys
+	allPeople := map[string]*tuf.Person{}
+	for personID, person := range targetsMetadata.Delegations.People {
+		allPeople[personID] = person
+	}
+	allTeams := map[string]*tuf.Team{}
+	for teamID, team := range targetsMetadata.Delegations.Teams {
+		allTeams[teamID] = team
+	}
 	// each entry is a list of delegations from a particular metadata file
 	groupedDelegations := [][]tuf.Delegation{
-		targetsMetadata.Delegations.Roles,
+		orderDelegations(targetsMetadata.Delegations.Roles),
 	}
 
 	seenRoles := map[string]bool{TargetsRoleName: true}
@@ -324,7 +497,9 @@ func (s *State) FindVerifiersForPath(path string) ([]*Verifier, error) {
 	verifiers := []*Verifier{}
 	for {
 		if len(groupedDelegations) == 0 {
+			s.verifiersCacheMu.Lock()
 			s.verifiersCache[path] = verifiers
+			s.verifiersCacheMu.Unlock()
 			return verifiers, nil
 		}
 
@@ -342,14 +517,70 @@ func (s *State) FindVerifiersForPath(path string) ([]*Verifier, error) {
 
 			if delegation.Matches(path) {
 				verifier := &Verifier{
-					name:      delegation.Name,
-					keys:      make([]*tuf.Key, 0, len(delegation.KeyIDs)),
-					threshold: delegation.Threshold,
+					name:                         delegation.Name,
+					keys:                         make([]*tuf.Key, 0, len(delegation.KeyIDs)),
+					threshold:                    delegation.Threshold,
+					mergeStrategy:                delegation.MergeStrategy,
+					submoduleURLs:                delegation.SubmoduleURLs,
+					requireSubmoduleVerification: delegation.RequireSubmoduleVerification,
+					regoPolicy:                   delegation.RegoPolicy,
+					conditions:                   delegation.Conditions,
+					requireSignoff:               delegation.RequireSignoff,
+					requireConventionalCommits:   delegation.RequireConventionalCommits,
+					commitMessagePattern:         delegation.CommitMessagePattern,
+					maxFileSize:                  delegation.MaxFileSize,
+					forbiddenExtensions:          delegation.ForbiddenExtensions,
+					forbidBinaryFiles:            delegation.ForbidBinaryFiles,
+					deny:                         delegation.Effect == RuleEffectDeny,
+					algorithmPolicy:              rootMetadata.AlgorithmPolicy,
+				}
+				principals := resolvePrincipals(delegation, allPeople, allTeams)
+				for _, personID := range principals.missingPeople {
+					slog.Debug(fmt.Sprintf("Person '%s' authorized by rule '%s' not found in policy, skipping...", personID, delegation.Name))
+				}
+				for _, teamID := range principals.missingTeams {
+					slog.Debug(fmt.Sprintf("Team '%s' authorized by rule '%s' not found in policy, skipping...", teamID, delegation.Name))
 				}
-				for _, keyID := range delegation.KeyIDs {
-					key := allPublicKeys[keyID]
+				for _, authorized := range principals.keys {
+					if authorized.principalID != authorized.keyID {
+						verifier.addPersonKey(authorized.keyID, authorized.principalID)
+					}
+
+					key := allPublicKeys[authorized.keyID]
+					if rootMetadata.IsKeyRevoked(authorized.keyID) {
+						verifier.revokedKeys = append(verifier.revokedKeys, &revokedKey{
+							key:        key,
+							revocation: rootMetadata.RevokedKeys[authorized.keyID],
+							entryID:    s.revocationEntries[authorized.keyID],
+						})
+						continue
+					}
+					if err := CheckKeyAlgorithm(rootMetadata.AlgorithmPolicy, key); err != nil {
+						slog.Debug(fmt.Sprintf("Key '%s' authorized by rule '%s' does not meet algorithm policy, skipping: %s", authorized.keyID, delegation.Name, err.Error()))
+						continue
+					}
 					verifier.keys = append(verifier.keys, key)
 				}
+				for _, keyID := range delegation.ForcePushKeyIDs {
+					if rootMetadata.IsKeyRevoked(keyID) {
+						continue
+					}
+					if err := CheckKeyAlgorithm(rootMetadata.AlgorithmPolicy, allPublicKeys[keyID]); err != nil {
+						slog.Debug(fmt.Sprintf("Key '%s' authorized to force push by rule '%s' does not meet algorithm policy, skipping: %s", keyID, delegation.Name, err.Error()))
+						continue
+					}
+					verifier.forcePushKeys = append(verifier.forcePushKeys, allPublicKeys[keyID])
+				}
+				for _, keyID := range delegation.DeletionKeyIDs {
+					if rootMetadata.IsKeyRevoked(keyID) {
+						continue
+					}
+					if err := CheckKeyAlgorithm(rootMetadata.AlgorithmPolicy, allPublicKeys[keyID]); err != nil {
+						slog.Debug(fmt.Sprintf("Key '%s' authorized to delete references by rule '%s' does not meet algorithm policy, skipping: %s", keyID, delegation.Name, err.Error()))
+						continue
+					}
+					verifier.deletionKeys = append(verifier.deletionKeys, allPublicKeys[keyID])
+				}
 				verifiers = append(verifiers, verifier)
 
 				if _, seen := seenRoles[delegation.Name]; seen {
@@ -367,10 +598,16 @@ func (s *State) FindVerifiersForPath(path string) ([]*Verifier, error) {
 					for keyID, key := range delegatedMetadata.Delegations.Keys {
 						allPublicKeys[keyID] = key
 					}
+					for personID, person := range delegatedMetadata.Delegations.People {
+						allPeople[personID] = person
+					}
+					for teamID, team := range delegatedMetadata.Delegations.Teams {
+						allTeams[teamID] = team
+					}
 
 					// Add the current metadata's further delegations upfront to
 					// be depth-first
-					groupedDelegations = append([][]tuf.Delegation{delegatedMetadata.Delegations.Roles}, groupedDelegations...)
+					groupedDelegations = append([][]tuf.Delegation{orderDelegations(delegatedMetadata.Delegations.Roles)}, groupedDelegations...)
 
 					if delegation.Terminating {
 						// Stop processing current delegation group, but proceed
@@ -387,32 +624,60 @@ func (s *State) FindVerifiersForPath(path string) ([]*Verifier, error) {
 // Specifically, it checks that the root keys in the root role match the ones
 // stored on disk in the state. Further, it also verifies the signatures of the
 // top level Targets role and all reachable delegated Targets roles. Any
-// unreachable role returns an error.
+// unreachable role returns an error. If the root of trust defines a snapshot
+// role, the snapshot metadata must match the policy files.
 func (s *State) Verify(ctx context.Context) error {
+	if err := s.verifyMetadata(ctx); err != nil {
+		return err
+	}
+
+	return s.VerifySnapshot(ctx)
+}
+
+// verifyMetadata performs all the checks of Verify except for those of the
+// snapshot metadata.
+func (s *State) verifyMetadata(ctx context.Context) error {
+	_, err := s.verifyMetadataWithPending(ctx, false)
+	return err
+}
+
+// verifyMetadataWithPending is similar to verifyMetadata. If allowPending is
+// set, policy files that are not yet signed by a threshold of their trusted
+// keys do not cause verification to fail, and are returned instead.
+func (s *State) verifyMetadataWithPending(ctx context.Context, allowPending bool) ([]string, error) {
+	pending := []string{}
+	checkPending := func(roleName string, err error) error {
+		if allowPending && errors.Is(err, ErrVerifierConditionsUnmet) {
+			pending = append(pending, roleName)
+			return nil
+		}
+		return err
+	}
+
 	rootKeys, err := s.GetRootKeys()
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if !verifyRootKeysMatch(rootKeys, s.RootPublicKeys) {
-		return ErrUnableToMatchRootKeys
+		return nil, ErrUnableToMatchRootKeys
 	}
 
 	if s.TargetsEnvelope == nil {
-		return nil
+		return pending, nil
 	}
 
 	targetsVerifier, err := s.getTargetsVerifier()
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	if err := targetsVerifier.Verify(ctx, nil, s.TargetsEnvelope); err != nil {
-		return err
+	if err := checkPending(TargetsRoleName, targetsVerifier.Verify(ctx, nil, s.TargetsEnvelope)); err != nil {
+		return nil, err
 	}
 
 	targetsMetadata, err := s.GetTargetsMetadata(TargetsRoleName)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	reachedDelegations := map[string]bool{}
@@ -422,6 +687,14 @@ func (s *State) Verify(ctx context.Context) error {
 
 	delegationsQueue := targetsMetadata.Delegations.Roles
 	delegationKeys := targetsMetadata.Delegations.Keys
+	delegationPeople := map[string]*tuf.Person{}
+	for personID, person := range targetsMetadata.Delegations.People {
+		delegationPeople[personID] = person
+	}
+	delegationTeams := map[string]*tuf.Team{}
+	for teamID, team := range targetsMetadata.Delegations.Teams {
+		delegationTeams[teamID] = team
+	}
 	for {
 		// The last entry in the queue is always the allow rule, which we don't
 		// process during DFS
@@ -437,40 +710,47 @@ func (s *State) Verify(ctx context.Context) error {
 
 			env := s.DelegationEnvelopes[delegation.Name]
 
-			keys := []*tuf.Key{}
-			for _, keyID := range delegation.KeyIDs {
-				keys = append(keys, delegationKeys[keyID])
-			}
-
 			verifier := &Verifier{
 				name:      delegation.Name,
-				keys:      keys,
+				keys:      []*tuf.Key{},
 				threshold: delegation.Threshold,
 			}
+			for _, authorized := range resolvePrincipals(delegation, delegationPeople, delegationTeams).keys {
+				verifier.keys = append(verifier.keys, delegationKeys[authorized.keyID])
+				if authorized.principalID != authorized.keyID {
+					verifier.addPersonKey(authorized.keyID, authorized.principalID)
+				}
+			}
 
-			if err := verifier.Verify(ctx, nil, env); err != nil {
-				return err
+			if err := checkPending(delegation.Name, verifier.Verify(ctx, nil, env)); err != nil {
+				return nil, err
 			}
 
 			delegatedMetadata, err := s.GetTargetsMetadata(delegation.Name)
 			if err != nil {
-				return err
+				return nil, err
 			}
 
 			delegationsQueue = append(delegatedMetadata.Delegations.Roles, delegationsQueue...)
 			for keyID, key := range delegatedMetadata.Delegations.Keys {
 				delegationKeys[keyID] = key
 			}
+			for personID, person := range delegatedMetadata.Delegations.People {
+				delegationPeople[personID] = person
+			}
+			for teamID, team := range delegatedMetadata.Delegations.Teams {
+				delegationTeams[teamID] = team
+			}
 		}
 	}
 
 	for _, reached := range reachedDelegations {
 		if !reached {
-			return ErrDanglingDelegationMetadata
+			return nil, ErrDanglingDelegationMetadata
 		}
 	}
 
-	return nil
+	return pending, nil
 }
 
 // Commit verifies and writes the State to the policy namespace. It also creates
@@ -484,6 +764,115 @@ func (s *State) Commit(ctx context.Context, repo *git.Repository, commitMessage
 		commitMessage = DefaultCommitMessage
 	}
 
+	policyRootTreeID, err := s.writeTree(repo)
+	if err != nil {
+		return err
+	}
+
+	ref, err := repo.Reference(plumbing.ReferenceName(PolicyRef), true)
+	if err != nil {
+		return err
+	}
+	originalCommitID := ref.Hash()
+
+	commitID, err := gitinterface.Commit(repo, policyRootTreeID, PolicyRef, commitMessage, signCommit)
+	if err != nil {
+		return err
+	}
+
+	// We must reset to original policy commit if err != nil from here onwards.
+
+	if err := rsl.NewReferenceEntry(PolicyRef, commitID).Commit(repo, signCommit); err != nil {
+		return gitinterface.ResetDueToError(err, repo, PolicyRef, originalCommitID)
+	}
+
+	return nil
+}
+
+// Stage verifies and writes the State to the policy staging namespace. Staged
+// changes are not recorded in the RSL, and are used to collect signatures
+// before the State is committed to the policy namespace. The snapshot metadata
+// of a staged State is not required to match its policy files, and its policy
+// files are not required to be signed by a threshold of their trusted keys.
+func (s *State) Stage(ctx context.Context, repo *git.Repository, commitMessage string, signCommit bool) error {
+	if _, err := s.verifyMetadataWithPending(ctx, true); err != nil {
+		return err
+	}
+
+	if len(commitMessage) == 0 {
+		commitMessage = DefaultCommitMessage
+	}
+
+	policyRootTreeID, err := s.writeTree(repo)
+	if err != nil {
+		return err
+	}
+
+	_, err = gitinterface.Commit(repo, policyRootTreeID, PolicyStagingRef, commitMessage, signCommit)
+	return err
+}
+
+// LoadStagedState returns the State in the policy staging namespace. If no
+// changes are staged, ErrNoStagedPolicy is returned.
+func LoadStagedState(ctx context.Context, repo *git.Repository) (*State, error) {
+	ref, err := repo.Reference(plumbing.ReferenceName(PolicyStagingRef), true)
+	if err != nil {
+		if errors.Is(err, plumbing.ErrReferenceNotFound) {
+			return nil, ErrNoStagedPolicy
+		}
+		return nil, err
+	}
+	if ref.Hash().IsZero() {
+		return nil, ErrNoStagedPolicy
+	}
+
+	// Staged policy files may not yet be signed by a threshold of their keys
+	return loadStateForCommit(ctx, repo, ref.Hash(), true)
+}
+
+// PendingRoles returns the names of the roles in the State whose metadata is
+// not yet signed by a threshold of their trusted keys. The root of trust must
+// be signed by a threshold of the root keys trusted by currentState, and the
+// snapshot metadata, if required, must also match the State's policy files.
+// The State can only be committed once no roles are pending.
+func (s *State) PendingRoles(ctx context.Context, currentState *State) ([]string, error) {
+	pendingRoles := []string{}
+
+	if err := currentState.VerifyNewState(ctx, s); err != nil {
+		if !errors.Is(err, ErrVerifierConditionsUnmet) {
+			return nil, err
+		}
+		pendingRoles = append(pendingRoles, RootRoleName)
+	}
+
+	pendingPolicyFiles, err := s.verifyMetadataWithPending(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	pendingRoles = append(pendingRoles, pendingPolicyFiles...)
+
+	if err := s.VerifySnapshot(ctx); err != nil {
+		if !errors.Is(err, ErrSnapshotOutdated) && !errors.Is(err, ErrVerifierConditionsUnmet) {
+			return nil, err
+		}
+		pendingRoles = append(pendingRoles, SnapshotRoleName)
+	}
+
+	return pendingRoles, nil
+}
+
+// DiscardStagedState removes all changes in the policy staging namespace.
+func DiscardStagedState(repo *git.Repository) error {
+	err := repo.Storer.RemoveReference(plumbing.ReferenceName(PolicyStagingRef))
+	if err != nil && !errors.Is(err, plumbing.ErrReferenceNotFound) {
+		return err
+	}
+	return nil
+}
+
+// writeTree writes the State's metadata and root keys to the object store and
+// returns the ID of the resulting policy tree.
+func (s *State) writeTree(repo *git.Repository) (plumbing.Hash, error) {
 	metadata := map[string]*sslibdsse.Envelope{}
 	metadata[RootRoleName] = s.RootEnvelope
 	if s.TargetsEnvelope != nil {
@@ -496,16 +885,20 @@ func (s *State) Commit(ctx context.Context, repo *git.Repository, commitMessage
 		}
 	}
 
+	if s.SnapshotEnvelope != nil {
+		metadata[SnapshotRoleName] = s.SnapshotEnvelope
+	}
+
 	metadataEntries := []object.TreeEntry{}
 	for name, env := range metadata {
 		metadataContents, err := json.Marshal(env)
 		if err != nil {
-			return err
+			return plumbing.ZeroHash, err
 		}
 
 		blobID, err := gitinterface.WriteBlob(repo, metadataContents)
 		if err != nil {
-			return err
+			return plumbing.ZeroHash, err
 		}
 
 		metadataEntries = append(metadataEntries, object.TreeEntry{
@@ -516,19 +909,19 @@ func (s *State) Commit(ctx context.Context, repo *git.Repository, commitMessage
 	}
 	metadataTreeID, err := gitinterface.WriteTree(repo, metadataEntries)
 	if err != nil {
-		return err
+		return plumbing.ZeroHash, err
 	}
 
 	keysEntries := []object.TreeEntry{}
 	for _, key := range s.RootPublicKeys {
 		keyContents, err := json.Marshal(key)
 		if err != nil {
-			return err
+			return plumbing.ZeroHash, err
 		}
 
 		blobID, err := gitinterface.WriteBlob(repo, keyContents)
 		if err != nil {
-			return err
+			return plumbing.ZeroHash, err
 		}
 
 		keysEntries = append(keysEntries, object.TreeEntry{
@@ -539,10 +932,10 @@ func (s *State) Commit(ctx context.Context, repo *git.Repository, commitMessage
 	}
 	keysTreeID, err := gitinterface.WriteTree(repo, keysEntries)
 	if err != nil {
-		return err
+		return plumbing.ZeroHash, err
 	}
 
-	policyRootTreeID, err := gitinterface.WriteTree(repo, []object.TreeEntry{
+	return gitinterface.WriteTree(repo, []object.TreeEntry{
 		{
 			Name: metadataTreeEntryName,
 			Mode: filemode.Dir,
@@ -554,28 +947,6 @@ func (s *State) Commit(ctx context.Context, repo *git.Repository, commitMessage
 			Hash: keysTreeID,
 		},
 	})
-	if err != nil {
-		return err
-	}
-
-	ref, err := repo.Reference(plumbing.ReferenceName(PolicyRef), true)
-	if err != nil {
-		return err
-	}
-	originalCommitID := ref.Hash()
-
-	commitID, err := gitinterface.Commit(repo, policyRootTreeID, PolicyRef, commitMessage, signCommit)
-	if err != nil {
-		return err
-	}
-
-	// We must reset to original policy commit if err != nil from here onwards.
-
-	if err := rsl.NewReferenceEntry(PolicyRef, commitID).Commit(repo, signCommit); err != nil {
-		return gitinterface.ResetDueToError(err, repo, PolicyRef, originalCommitID)
-	}
-
-	return nil
 }
 
 func (s *State) GetRootKeys() ([]*tuf.Key, error) {
@@ -644,6 +1015,11 @@ func (s *State) HasTargetsRole(roleName string) bool {
 }
 
 func (s *State) HasRuleName(name string) bool {
+	if s.ruleNames == nil {
+		// No policy files exist yet
+		return false
+	}
+
 	return s.ruleNames.Has(name)
 }
 
@@ -714,7 +1090,7 @@ func ListRules(ctx context.Context, repo *git.Repository) ([]*DelegationWithDept
 	delegationsToSearch := []*DelegationWithDepth{}
 	allDelegations := []*DelegationWithDepth{}
 
-	for _, topLevelDelegation := range topLevelTargetsMetadata.Delegations.Roles {
+	for _, topLevelDelegation := range orderDelegations(topLevelTargetsMetadata.Delegations.Roles) {
 		if topLevelDelegation.Name == AllowRuleName {
 			continue
 		}
@@ -745,7 +1121,7 @@ func ListRules(ctx context.Context, repo *git.Repository) ([]*DelegationWithDept
 			// We construct localDelegations first so that we preserve the order
 			// of delegations in currentMetadata in delegationsToSearch
 			localDelegations := []*DelegationWithDepth{}
-			for _, delegation := range currentMetadata.Delegations.Roles {
+			for _, delegation := range orderDelegations(currentMetadata.Delegations.Roles) {
 				if delegation.Name == AllowRuleName {
 					continue
 				}
@@ -767,6 +1143,28 @@ func ListRules(ctx context.Context, repo *git.Repository) ([]*DelegationWithDept
 // return true even if the role in question is not reachable for some path (or
 // at all).
 func (s *State) hasFileRule() (bool, error) {
+	return s.hasRule(func(delegation tuf.Delegation) bool {
+		for _, path := range delegation.Paths {
+			if strings.HasPrefix(path, "file:") {
+				return true
+			}
+		}
+		return false
+	})
+}
+
+// hasSubmoduleRule returns true if the policy state has a single rule in any
+// targets role that constrains submodule updates. Like hasFileRule, this
+// function has no concept of role reachability.
+func (s *State) hasSubmoduleRule() (bool, error) {
+	return s.hasRule(func(delegation tuf.Delegation) bool {
+		return len(delegation.SubmoduleURLs) > 0 || delegation.RequireSubmoduleVerification
+	})
+}
+
+// hasRule returns true if any rule other than the allow rule in any targets
+// role in the policy state matches the specified function.
+func (s *State) hasRule(matches func(tuf.Delegation) bool) (bool, error) {
 	if s.TargetsEnvelope == nil {
 		// No top level targets, we don't need to check for delegated roles
 		return false, nil
@@ -795,10 +1193,8 @@ func (s *State) hasFileRule() (bool, error) {
 				continue
 			}
 
-			for _, path := range delegation.Paths {
-				if strings.HasPrefix(path, "file:") {
-					return true, nil
-				}
+			if matches(delegation) {
+				return true, nil
 			}
 		}
 	}
@@ -806,6 +1202,33 @@ func (s *State) hasFileRule() (bool, error) {
 	return false, nil
 }
 
+// trackRevocations records the RSL entry that first revoked each key revoked
+// in the state. Entries identified for the previous state are retained, other
+// revocations are attributed to entryID.
+func (s *State) trackRevocations(previous *State, entryID plumbing.Hash) error {
+	rootMetadata, err := s.GetRootMetadata()
+	if err != nil {
+		return err
+	}
+
+	if len(rootMetadata.RevokedKeys) == 0 {
+		return nil
+	}
+
+	s.revocationEntries = map[string]plumbing.Hash{}
+	for keyID := range rootMetadata.RevokedKeys {
+		if previous != nil {
+			if previousEntryID, has := previous.revocationEntries[keyID]; has {
+				s.revocationEntries[keyID] = previousEntryID
+				continue
+			}
+		}
+		s.revocationEntries[keyID] = entryID
+	}
+
+	return nil
+}
+
 func (s *State) getRootVerifier() (*Verifier, error) {
 	rootMetadata, err := s.GetRootMetadata()
 	if err != nil {
@@ -833,6 +1256,37 @@ func (s *State) getTargetsVerifier() (*Verifier, error) {
 	return verifier, nil
 }
 
+// getPolicyKeysVerifier returns a verifier that is met by a signature from any
+// key in the policy that is not revoked and meets the algorithm policy. It is
+// used for tags that are not protected by any rule.
+func (s *State) getPolicyKeysVerifier() (*Verifier, error) {
+	allKeys, err := s.PublicKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	rootMetadata, err := s.GetRootMetadata()
+	if err != nil {
+		return nil, err
+	}
+
+	verifier := &Verifier{
+		name:            "policy keys",
+		keys:            make([]*tuf.Key, 0, len(allKeys)),
+		threshold:       1,
+		algorithmPolicy: rootMetadata.AlgorithmPolicy,
+	}
+	for keyID, key := range allKeys {
+		if err := CheckKeyAlgorithm(rootMetadata.AlgorithmPolicy, key); err != nil {
+			slog.Debug(fmt.Sprintf("Key '%s' does not meet algorithm policy, skipping: %s", keyID, err.Error()))
+			continue
+		}
+		verifier.keys = append(verifier.keys, key)
+	}
+
+	return verifier, nil
+}
+
 // loadStateForEntry returns the State for a specified RSL reference entry for
 // the policy namespace. This helper is focused on reading the Git object store
 // and loading the policy contents. Typically, LoadCurrentState of LoadState
@@ -843,7 +1297,23 @@ func loadStateForEntry(ctx context.Context, repo *git.Repository, entry *rsl.Ref
 		return nil, rsl.ErrRSLEntryDoesNotMatchRef
 	}
 
-	policyCommit, err := gitinterface.GetCommit(repo, entry.TargetID)
+	state, err := loadStateForCommit(ctx, repo, entry.TargetID, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := state.VerifySnapshot(ctx); err != nil {
+		return nil, err
+	}
+
+	return state, nil
+}
+
+// loadStateForCommit returns the State recorded in the specified commit in the
+// policy or policy staging namespaces. The snapshot metadata is not verified as
+// staged changes may not have been added to it yet.
+func loadStateForCommit(ctx context.Context, repo *git.Repository, commitID plumbing.Hash, allowPending bool) (*State, error) {
+	policyCommit, err := gitinterface.GetCommit(repo, commitID)
 	if err != nil {
 		return nil, err
 	}
@@ -901,6 +1371,8 @@ func loadStateForEntry(ctx context.Context, repo *git.Repository, entry *rsl.Ref
 			state.RootEnvelope = env
 		case fmt.Sprintf("%s.json", TargetsRoleName):
 			state.TargetsEnvelope = env
+		case fmt.Sprintf("%s.json", SnapshotRoleName):
+			state.SnapshotEnvelope = env
 		default:
 			if state.DelegationEnvelopes == nil {
 				state.DelegationEnvelopes = map[string]*sslibdsse.Envelope{}
@@ -932,7 +1404,7 @@ func loadStateForEntry(ctx context.Context, repo *git.Repository, entry *rsl.Ref
 		return nil, err
 	}
 
-	if err := state.Verify(ctx); err != nil {
+	if _, err := state.verifyMetadataWithPending(ctx, allowPending); err != nil {
 		return nil, err
 	}
 
@@ -954,3 +1426,32 @@ func verifyRootKeysMatch(keys1, keys2 []*tuf.Key) bool {
 
 	return reflect.DeepEqual(keys1, keys2)
 }
+
+// verifyCheckpoint checks that the checkpoint containing firstEntry, if any,
+// is signed by a threshold of the State's root keys.
+func (s *State) verifyCheckpoint(ctx context.Context, repo *git.Repository, firstEntry *rsl.ReferenceEntry) error {
+	entry, err := rsl.GetEntry(repo, firstEntry.ID)
+	if err != nil {
+		return err
+	}
+	checkpoint, isCheckpoint := entry.(*rsl.CheckpointEntry)
+	if !isCheckpoint {
+		return nil
+	}
+
+	slog.Debug(fmt.Sprintf("Verifying RSL checkpoint '%s' using root of trust...", checkpoint.ID))
+	if err := checkpoint.VerifyPayload(); err != nil {
+		return err
+	}
+
+	rootVerifier, err := s.getRootVerifier()
+	if err != nil {
+		return err
+	}
+
+	if err := rootVerifier.Verify(ctx, nil, checkpoint.Envelope); err != nil {
+		return errors.Join(ErrUnverifiedCheckpoint, err)
+	}
+
+	return nil
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/storage/memory"
+	sslibdsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -94,6 +95,92 @@ func TestLoadCurrentState(t *testing.T) {
 	assert.Equal(t, state, loadedState)
 }
 
+func TestVerifyRootOfTrust(t *testing.T) {
+	repo, state := createTestRepository(t, createTestStateWithOnlyRoot)
+
+	t.Run("expected root key", func(t *testing.T) {
+		err := VerifyRootOfTrust(context.Background(), repo, []string{state.RootPublicKeys[0].KeyID})
+		assert.Nil(t, err)
+	})
+
+	t.Run("unexpected root key", func(t *testing.T) {
+		err := VerifyRootOfTrust(context.Background(), repo, []string{"unknown"})
+		assert.ErrorIs(t, err, ErrUntrustedRootOfTrust)
+	})
+}
+
+func TestVerifyRootWithOrganizationRoot(t *testing.T) {
+	signer, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(rootKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootKey, err := tuf.LoadKeyFromBytes(rootPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, err := tuf.LoadKeyFromBytes(targets1PubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	createState := func(t *testing.T, rootMetadata *tuf.RootMetadata) *State {
+		t.Helper()
+
+		env, err := dsse.CreateEnvelope(rootMetadata)
+		if err != nil {
+			t.Fatal(err)
+		}
+		env, err = dsse.SignEnvelope(testCtx, env, signer)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return &State{RootPublicKeys: []*tuf.Key{rootKey}, RootEnvelope: env}
+	}
+
+	repositoryRootMetadata := InitializeRootMetadata(rootKey)
+	repositoryRootMetadata, err = SetOrganizationRoot(repositoryRootMetadata, "https://example.com/org-root", "repository")
+	if err != nil {
+		t.Fatal(err)
+	}
+	state := createState(t, repositoryRootMetadata)
+
+	t.Run("expected repository root key", func(t *testing.T) {
+		organizationRootMetadata, err := AddRepositoryRoot(InitializeRootMetadata(otherKey), "repository", []*tuf.Key{rootKey}, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = VerifyRootWithOrganizationRoot(testCtx, state, createState(t, organizationRootMetadata))
+		assert.Nil(t, err)
+	})
+
+	t.Run("unexpected repository root key", func(t *testing.T) {
+		organizationRootMetadata, err := AddRepositoryRoot(InitializeRootMetadata(otherKey), "repository", []*tuf.Key{otherKey}, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = VerifyRootWithOrganizationRoot(testCtx, state, createState(t, organizationRootMetadata))
+		assert.ErrorIs(t, err, ErrUntrustedByOrganization)
+	})
+
+	t.Run("repository not in organization root", func(t *testing.T) {
+		organizationRootMetadata, err := AddRepositoryRoot(InitializeRootMetadata(otherKey), "other-repository", []*tuf.Key{rootKey}, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = VerifyRootWithOrganizationRoot(testCtx, state, createState(t, organizationRootMetadata))
+		assert.ErrorIs(t, err, ErrRepositoryRootNil)
+	})
+
+	t.Run("no organization root", func(t *testing.T) {
+		err := VerifyRootWithOrganizationRoot(testCtx, createTestStateWithOnlyRoot(t), state)
+		assert.ErrorIs(t, err, ErrNoOrganizationRoot)
+	})
+}
+
 func TestLoadStateForEntry(t *testing.T) {
 	repo, state := createTestRepository(t, createTestStateWithOnlyRoot)
 
@@ -185,6 +272,85 @@ func TestStateCommit(t *testing.T) {
 	assert.Equal(t, entry.TargetID, policyRef.Hash())
 }
 
+func TestStateStage(t *testing.T) {
+	repo, state := createTestRepository(t, createTestStateWithOnlyRoot)
+
+	_, err := LoadStagedState(testCtx, repo)
+	assert.ErrorIs(t, err, ErrNoStagedPolicy)
+
+	rslRef, err := repo.Reference(plumbing.ReferenceName(rsl.Ref), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := state.Stage(testCtx, repo, "Stage test state", false); err != nil {
+		t.Fatal(err)
+	}
+
+	stagedState, err := LoadStagedState(testCtx, repo)
+	assert.Nil(t, err)
+	assert.Equal(t, state.RootEnvelope, stagedState.RootEnvelope)
+
+	// Staging must not create an RSL entry
+	newRSLRef, err := repo.Reference(plumbing.ReferenceName(rsl.Ref), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, rslRef.Hash(), newRSLRef.Hash())
+
+	assert.Nil(t, DiscardStagedState(repo))
+	_, err = LoadStagedState(testCtx, repo)
+	assert.ErrorIs(t, err, ErrNoStagedPolicy)
+
+	// Discarding again is a no-op
+	assert.Nil(t, DiscardStagedState(repo))
+}
+
+func TestStatePendingRoles(t *testing.T) {
+	currentState := createTestStateWithDelegatedPolicies(t)
+
+	t.Run("no pending roles", func(t *testing.T) {
+		state := createTestStateWithDelegatedPolicies(t)
+
+		pendingRoles, err := state.PendingRoles(testCtx, currentState)
+		assert.Nil(t, err)
+		assert.Empty(t, pendingRoles)
+	})
+
+	t.Run("unsigned policy files", func(t *testing.T) {
+		repo, _ := createTestRepository(t, createTestStateWithDelegatedPolicies)
+		state := createTestStateWithDelegatedPolicies(t)
+
+		targetsEnv := *state.TargetsEnvelope
+		targetsEnv.Signatures = nil
+		state.TargetsEnvelope = &targetsEnv
+
+		delegationEnv := *state.DelegationEnvelopes["1"]
+		delegationEnv.Signatures = nil
+		state.DelegationEnvelopes["1"] = &delegationEnv
+
+		pendingRoles, err := state.PendingRoles(testCtx, currentState)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{TargetsRoleName, "1"}, pendingRoles)
+
+		// Policy files pending signatures can be staged but not committed
+		assert.Nil(t, state.Stage(testCtx, repo, "Stage unsigned policy", false))
+		assert.ErrorIs(t, state.Commit(testCtx, repo, "Commit unsigned policy", false), ErrVerifierConditionsUnmet)
+	})
+
+	t.Run("unsigned root", func(t *testing.T) {
+		state := createTestStateWithDelegatedPolicies(t)
+
+		rootEnv := *state.RootEnvelope
+		rootEnv.Signatures = nil
+		state.RootEnvelope = &rootEnv
+
+		pendingRoles, err := state.PendingRoles(testCtx, currentState)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{RootRoleName}, pendingRoles)
+	})
+}
+
 func TestStateGetRootMetadata(t *testing.T) {
 	state := createTestStateWithOnlyRoot(t)
 
@@ -240,6 +406,144 @@ func TestStateFindVerifiersForPath(t *testing.T) {
 		}
 	})
 
+	t.Run("with people and teams", func(t *testing.T) {
+		state := createTestStateWithPolicy(t)
+
+		signer, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(rootKeyBytes) //nolint:staticcheck
+		if err != nil {
+			t.Fatal(err)
+		}
+		key, err := tuf.LoadKeyFromBytes(targets1PubKeyBytes)
+		if err != nil {
+			t.Fatal(err)
+		}
+		gpgKey, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		targetsMetadata, err := state.GetTargetsMetadata(TargetsRoleName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsMetadata, err = AddPerson(targetsMetadata, "alice", []*tuf.Key{key})
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsMetadata, err = AddDelegation(targetsMetadata, "protect-release", nil, []string{"git:refs/heads/release"}, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsMetadata, err = SetAuthorizedPersons(targetsMetadata, "protect-release", []string{"alice"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsMetadata, err = AddTeamMembers(targetsMetadata, "maintainers", []*tuf.Key{gpgKey}, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsMetadata, err = SetAuthorizedTeams(targetsMetadata, "protect-release", []string{"maintainers"})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		targetsEnv, err := dsse.CreateEnvelope(targetsMetadata)
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsEnv, err = dsse.SignEnvelope(context.Background(), targetsEnv, signer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		state.TargetsEnvelope = targetsEnv
+
+		verifiers, err := state.FindVerifiersForPath("git:refs/heads/release")
+		assert.Nil(t, err)
+		assert.Equal(t, []*Verifier{{
+			name:      "protect-release",
+			keys:      []*tuf.Key{gpgKey, key},
+			threshold: 1,
+			people:    map[string]string{key.KeyID: "alice"},
+		}}, verifiers)
+	})
+
+	t.Run("with priority", func(t *testing.T) {
+		signer, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(rootKeyBytes) //nolint:staticcheck
+		if err != nil {
+			t.Fatal(err)
+		}
+		key, err := tuf.LoadKeyFromBytes(targets1PubKeyBytes)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// protect-branches is a terminating rule listed after protect-main
+		createState := func(t *testing.T, priority int) *State {
+			t.Helper()
+
+			state := createTestStateWithPolicy(t)
+
+			targetsMetadata, err := state.GetTargetsMetadata(TargetsRoleName)
+			if err != nil {
+				t.Fatal(err)
+			}
+			targetsMetadata, err = AddDelegation(targetsMetadata, "protect-branches", []*tuf.Key{key}, []string{"git:refs/heads/*"}, 1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i := range targetsMetadata.Delegations.Roles {
+				if targetsMetadata.Delegations.Roles[i].Name == "protect-branches" {
+					targetsMetadata.Delegations.Roles[i].Terminating = true
+				}
+			}
+			targetsMetadata, err = SetRulePriority(targetsMetadata, "protect-branches", priority)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			targetsEnv, err := dsse.CreateEnvelope(targetsMetadata)
+			if err != nil {
+				t.Fatal(err)
+			}
+			targetsEnv, err = dsse.SignEnvelope(context.Background(), targetsEnv, signer)
+			if err != nil {
+				t.Fatal(err)
+			}
+			state.TargetsEnvelope = targetsEnv
+
+			delegatedEnv, err := dsse.CreateEnvelope(InitializeTargetsMetadata())
+			if err != nil {
+				t.Fatal(err)
+			}
+			if state.DelegationEnvelopes == nil {
+				state.DelegationEnvelopes = map[string]*sslibdsse.Envelope{}
+			}
+			state.DelegationEnvelopes["protect-branches"] = delegatedEnv
+
+			return state
+		}
+
+		getVerifierNames := func(t *testing.T, state *State) []string {
+			t.Helper()
+
+			verifiers, err := state.FindVerifiersForPath("git:refs/heads/main")
+			if err != nil {
+				t.Fatal(err)
+			}
+			names := []string{}
+			for _, verifier := range verifiers {
+				names = append(names, verifier.Name())
+			}
+			return names
+		}
+
+		assert.Equal(t, []string{"protect-main", "protect-branches"}, getVerifierNames(t, createState(t, 0)))
+
+		// The terminating rule is evaluated first, so protect-main is not
+		// reached
+		assert.Equal(t, []string{"protect-branches"}, getVerifierNames(t, createState(t, 10)))
+	})
+
 	t.Run("without policy", func(t *testing.T) {
 		state := createTestStateWithOnlyRoot(t)
 
@@ -0,0 +1,78 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"github.com/gittuf/gittuf/internal/tuf"
+)
+
+// authorizedKey is a key authorized by a rule, along with the ID of the
+// principal it belongs to. The principal is the person the key belongs to, or
+// the key itself if it does not belong to an authorized person.
+type authorizedKey struct {
+	keyID       string
+	principalID string
+}
+
+// authorizedPrincipals records the keys authorized by a rule, along with the
+// people and teams the rule refers to that are not defined.
+type authorizedPrincipals struct {
+	keys          []authorizedKey
+	missingPeople []string
+	missingTeams  []string
+}
+
+// resolvePrincipals expands the keys, people, and teams authorized by the
+// delegation into the keys they authorize. Each key is listed once, and a key
+// that belongs to an authorized person is attributed to that person.
+func resolvePrincipals(delegation tuf.Delegation, people map[string]*tuf.Person, teams map[string]*tuf.Team) *authorizedPrincipals {
+	principals := &authorizedPrincipals{}
+
+	keyIDs := append([]string{}, delegation.KeyIDs...)
+	personIDs := append([]string{}, delegation.PersonIDs...)
+	for _, teamID := range delegation.TeamIDs {
+		team, has := teams[teamID]
+		if !has {
+			principals.missingTeams = append(principals.missingTeams, teamID)
+			continue
+		}
+		keyIDs = append(keyIDs, team.KeyIDs...)
+		personIDs = append(personIDs, team.PersonIDs...)
+	}
+
+	personKeyIDs := []string{}
+	personForKeyID := map[string]string{}
+	seenPeople := map[string]bool{}
+	for _, personID := range personIDs {
+		if seenPeople[personID] {
+			continue
+		}
+		seenPeople[personID] = true
+
+		person, has := people[personID]
+		if !has {
+			principals.missingPeople = append(principals.missingPeople, personID)
+			continue
+		}
+		for _, keyID := range person.KeyIDs {
+			personKeyIDs = append(personKeyIDs, keyID)
+			personForKeyID[keyID] = personID
+		}
+	}
+
+	seenKeys := map[string]bool{}
+	for _, keyID := range append(keyIDs, personKeyIDs...) {
+		if seenKeys[keyID] {
+			continue
+		}
+		seenKeys[keyID] = true
+
+		principalID := keyID
+		if personID, has := personForKeyID[keyID]; has {
+			principalID = personID
+		}
+		principals.keys = append(principals.keys, authorizedKey{keyID: keyID, principalID: principalID})
+	}
+
+	return principals
+}
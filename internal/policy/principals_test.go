@@ -0,0 +1,67 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolvePrincipals(t *testing.T) {
+	people := map[string]*tuf.Person{
+		"alice": {PersonID: "alice", KeyIDs: []string{"alice-gpg", "alice-ssh"}},
+		"bob":   {PersonID: "bob", KeyIDs: []string{"bob-gpg"}},
+	}
+	teams := map[string]*tuf.Team{
+		"maintainers": {TeamID: "maintainers", KeyIDs: []string{"bot"}, PersonIDs: []string{"alice", "bob"}},
+	}
+
+	tests := map[string]struct {
+		delegation tuf.Delegation
+		expected   *authorizedPrincipals
+	}{
+		"keys only": {
+			delegation: tuf.Delegation{Role: tuf.Role{KeyIDs: []string{"key1", "key2"}}},
+			expected: &authorizedPrincipals{keys: []authorizedKey{
+				{keyID: "key1", principalID: "key1"},
+				{keyID: "key2", principalID: "key2"},
+			}},
+		},
+		"people": {
+			delegation: tuf.Delegation{PersonIDs: []string{"alice"}, Role: tuf.Role{KeyIDs: []string{"key1"}}},
+			expected: &authorizedPrincipals{keys: []authorizedKey{
+				{keyID: "key1", principalID: "key1"},
+				{keyID: "alice-gpg", principalID: "alice"},
+				{keyID: "alice-ssh", principalID: "alice"},
+			}},
+		},
+		"key of authorized person listed directly": {
+			delegation: tuf.Delegation{PersonIDs: []string{"alice"}, Role: tuf.Role{KeyIDs: []string{"alice-gpg"}}},
+			expected: &authorizedPrincipals{keys: []authorizedKey{
+				{keyID: "alice-gpg", principalID: "alice"},
+				{keyID: "alice-ssh", principalID: "alice"},
+			}},
+		},
+		"teams": {
+			delegation: tuf.Delegation{TeamIDs: []string{"maintainers"}, PersonIDs: []string{"bob"}},
+			expected: &authorizedPrincipals{keys: []authorizedKey{
+				{keyID: "bot", principalID: "bot"},
+				{keyID: "bob-gpg", principalID: "bob"},
+				{keyID: "alice-gpg", principalID: "alice"},
+				{keyID: "alice-ssh", principalID: "alice"},
+			}},
+		},
+		"missing people and teams": {
+			delegation: tuf.Delegation{TeamIDs: []string{"reviewers"}, PersonIDs: []string{"carol"}},
+			expected:   &authorizedPrincipals{missingPeople: []string{"carol"}, missingTeams: []string{"reviewers"}},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.expected, resolvePrincipals(test.delegation, people, teams))
+		})
+	}
+}
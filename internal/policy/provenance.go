@@ -0,0 +1,94 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+
+	"github.com/gittuf/gittuf/internal/attestations"
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+var (
+	ErrProvenanceBuilderNotTrusted = errors.New("provenance was not generated by a trusted builder")
+	ErrProvenanceSourceMismatch    = errors.New("provenance source does not match repository")
+	ErrProvenanceCommitMismatch    = errors.New("provenance was not generated from tag's commit")
+)
+
+// VerifyTagProvenance verifies the SLSA provenance attached to the tag in the
+// current attestations. The provenance must be generated by one of the trusted
+// builders, and its source must be the commit the tag points to in the
+// repository identified by sourceURI.
+func VerifyTagProvenance(repo *git.Repository, tagRef string, builderIDs []string, sourceURI string) error {
+	slog.Debug(fmt.Sprintf("Loading provenance for '%s'...", tagRef))
+	currentAttestations, err := attestations.LoadCurrentAttestations(repo)
+	if err != nil {
+		return err
+	}
+
+	env, err := currentAttestations.GetProvenanceFor(repo, tagRef)
+	if err != nil {
+		return err
+	}
+
+	provenance, err := attestations.ParseProvenance(env)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug(fmt.Sprintf("Verifying provenance was generated by trusted builder, found '%s'...", provenance.RunDetails.Builder.ID))
+	if !slices.Contains(builderIDs, provenance.RunDetails.Builder.ID) {
+		return ErrProvenanceBuilderNotTrusted
+	}
+
+	source := provenance.Source()
+	if source == nil || normalizeSourceURI(source.URI) != normalizeSourceURI(sourceURI) {
+		return ErrProvenanceSourceMismatch
+	}
+
+	ref, err := repo.Reference(plumbing.ReferenceName(tagRef), true)
+	if err != nil {
+		return err
+	}
+	commitID := ref.Hash()
+	if tagObj, err := gitinterface.GetTag(repo, commitID); err == nil {
+		commitID = tagObj.Target
+	}
+
+	if source.GitCommit() != commitID.String() {
+		return ErrProvenanceCommitMismatch
+	}
+
+	return nil
+}
+
+// normalizeSourceURI reduces a Git repository location to its host and path so
+// that the forms used in provenance, such as
+// `git+https://github.com/owner/repo@refs/tags/v1`, can be compared with the
+// forms used for remotes, such as `git@github.com:owner/repo.git`.
+func normalizeSourceURI(uri string) string {
+	uri = strings.TrimPrefix(uri, "git+")
+
+	if _, location, found := strings.Cut(uri, "://"); found {
+		host, _, _ := strings.Cut(location, "/")
+		if index := strings.LastIndex(host, "@"); index >= 0 {
+			location = location[index+1:]
+		}
+		uri = location
+	} else if user, location, found := strings.Cut(uri, "@"); found && !strings.Contains(user, "/") {
+		// scp-like syntax
+		uri = strings.Replace(location, ":", "/", 1)
+	}
+
+	// Remove the revision, if any
+	uri, _, _ = strings.Cut(uri, "@")
+
+	uri = strings.TrimSuffix(uri, "/")
+	return strings.TrimSuffix(uri, ".git")
+}
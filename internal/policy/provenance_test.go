@@ -0,0 +1,131 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/attestations"
+	"github.com/gittuf/gittuf/internal/common"
+	"github.com/gittuf/gittuf/internal/signerverifier/dsse"
+	ita "github.com/in-toto/attestation/go/v1"
+	sslibdsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/structpb"
+)
+
+func TestVerifyTagProvenance(t *testing.T) {
+	builderID := "https://github.com/slsa-framework/slsa-github-generator/.github/workflows/generator_generic_slsa3.yml@refs/tags/v2.0.0"
+	sourceURI := "https://github.com/gittuf/gittuf"
+	tagRef := "refs/tags/v1"
+
+	repo, _ := createTestRepository(t, createTestStateWithPolicy)
+	commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, "refs/heads/main", 2, gpgKeyBytes)
+	common.CreateTestSignedTag(t, repo, "v1", commitIDs[1], gpgKeyBytes)
+
+	err := VerifyTagProvenance(repo, tagRef, []string{builderID}, sourceURI)
+	assert.ErrorIs(t, err, attestations.ErrProvenanceNotFound)
+
+	tests := map[string]struct {
+		builderID string
+		sourceURI string
+		commitID  string
+		err       error
+	}{
+		"valid provenance": {
+			builderID: builderID,
+			sourceURI: "git+https://github.com/gittuf/gittuf@refs/tags/v1",
+			commitID:  commitIDs[1].String(),
+		},
+		"untrusted builder": {
+			builderID: "https://example.com/builder",
+			sourceURI: "git+https://github.com/gittuf/gittuf@refs/tags/v1",
+			commitID:  commitIDs[1].String(),
+			err:       ErrProvenanceBuilderNotTrusted,
+		},
+		"different source repository": {
+			builderID: builderID,
+			sourceURI: "git+https://github.com/gittuf/other@refs/tags/v1",
+			commitID:  commitIDs[1].String(),
+			err:       ErrProvenanceSourceMismatch,
+		},
+		"different source commit": {
+			builderID: builderID,
+			sourceURI: "git+https://github.com/gittuf/gittuf@refs/tags/v1",
+			commitID:  commitIDs[0].String(),
+			err:       ErrProvenanceCommitMismatch,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			currentAttestations, err := attestations.LoadCurrentAttestations(repo)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := currentAttestations.SetProvenance(repo, createProvenanceEnvelope(t, test.builderID, test.sourceURI, test.commitID), tagRef); err != nil {
+				t.Fatal(err)
+			}
+			if err := currentAttestations.Commit(repo, "Add provenance", false); err != nil {
+				t.Fatal(err)
+			}
+
+			err = VerifyTagProvenance(repo, tagRef, []string{builderID}, sourceURI)
+			if test.err != nil {
+				assert.ErrorIs(t, err, test.err, name)
+			} else {
+				assert.Nil(t, err, name)
+			}
+		})
+	}
+}
+
+func TestNormalizeSourceURI(t *testing.T) {
+	tests := map[string]string{
+		"https://github.com/gittuf/gittuf":                   "github.com/gittuf/gittuf",
+		"https://github.com/gittuf/gittuf.git":               "github.com/gittuf/gittuf",
+		"git+https://github.com/gittuf/gittuf@refs/tags/v1":  "github.com/gittuf/gittuf",
+		"ssh://git@github.com/gittuf/gittuf.git":             "github.com/gittuf/gittuf",
+		"git@github.com:gittuf/gittuf.git":                   "github.com/gittuf/gittuf",
+		"https://user@github.com/gittuf/gittuf/":             "github.com/gittuf/gittuf",
+		"git+https://gitlab.com/group/subgroup/project@main": "gitlab.com/group/subgroup/project",
+	}
+
+	for uri, expected := range tests {
+		assert.Equal(t, expected, normalizeSourceURI(uri), uri)
+	}
+}
+
+func createProvenanceEnvelope(t *testing.T, builderID, sourceURI, commitID string) *sslibdsse.Envelope {
+	t.Helper()
+
+	predicate, err := structpb.NewStruct(map[string]any{
+		"buildDefinition": map[string]any{
+			"buildType": "https://slsa-framework.github.io/github-actions-buildtypes/workflow/v1",
+			"resolvedDependencies": []any{
+				map[string]any{
+					"uri":    sourceURI,
+					"digest": map[string]any{"gitCommit": commitID},
+				},
+			},
+		},
+		"runDetails": map[string]any{
+			"builder": map[string]any{"id": builderID},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	env, err := dsse.CreateEnvelope(&ita.Statement{
+		Type:          ita.StatementTypeUri,
+		Subject:       []*ita.ResourceDescriptor{{Name: "gittuf", Digest: map[string]string{"sha256": "5b5fe4f0c5ab3d3d7e62e0ad7ea1f2c9d8d1e0cd1c19b2d2b48e0f7a64b7a4e1"}}},
+		PredicateType: attestations.SLSAProvenancePredicateType,
+		Predicate:     predicate,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return env
+}
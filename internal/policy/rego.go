@@ -0,0 +1,96 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/open-policy-agent/opa/ast"
+	"github.com/open-policy-agent/opa/rego"
+)
+
+const (
+	// RegoPackage is the package that Rego policies must declare.
+	RegoPackage = "gittuf"
+
+	// regoDenyQuery queries the messages produced by a Rego policy for a
+	// change it rejects.
+	regoDenyQuery = "data.gittuf.deny"
+)
+
+var ErrInvalidRegoPolicy = errors.New("invalid Rego policy")
+
+// ValidateRegoPolicy checks that module is a valid Rego module that declares
+// the package "gittuf".
+func ValidateRegoPolicy(module string) error {
+	parsed, err := ast.ParseModule(RegoPackage+".rego", module)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidRegoPolicy, err)
+	}
+	if parsed == nil {
+		return fmt.Errorf("%w: module is empty", ErrInvalidRegoPolicy)
+	}
+	if packagePath := parsed.Package.Path.String(); packagePath != "data."+RegoPackage {
+		return fmt.Errorf("%w: module must declare package '%s', found '%s'", ErrInvalidRegoPolicy, RegoPackage, parsed.Package.String())
+	}
+
+	if _, err := prepareRegoPolicy(context.Background(), RegoPackage, module); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidRegoPolicy, err)
+	}
+
+	return nil
+}
+
+// evaluateRegoPolicy evaluates the Rego policy of the rule with the facts of a
+// change as input, and returns the messages its deny rule produced for the
+// change, sorted.
+func evaluateRegoPolicy(ctx context.Context, ruleName, module string, facts *changeFacts) ([]string, error) {
+	query, err := prepareRegoPolicy(ctx, ruleName, module)
+	if err != nil {
+		return nil, fmt.Errorf("%w of rule '%s': %w", ErrInvalidRegoPolicy, ruleName, err)
+	}
+
+	// Pass the facts as generic JSON values, so that they are accessed using
+	// their JSON field names
+	factsBytes, err := json.Marshal(facts)
+	if err != nil {
+		return nil, err
+	}
+	var input map[string]any
+	if err := json.Unmarshal(factsBytes, &input); err != nil {
+		return nil, err
+	}
+
+	results, err := query.Eval(ctx, rego.EvalInput(input))
+	if err != nil {
+		return nil, fmt.Errorf("evaluating Rego policy of rule '%s' failed: %w", ruleName, err)
+	}
+
+	messages := []string{}
+	for _, result := range results {
+		for _, expression := range result.Expressions {
+			denied, ok := expression.Value.([]any)
+			if !ok {
+				return nil, fmt.Errorf("%w of rule '%s': deny must be a set of messages", ErrInvalidRegoPolicy, ruleName)
+			}
+			for _, message := range denied {
+				messages = append(messages, fmt.Sprint(message))
+			}
+		}
+	}
+	sort.Strings(messages)
+
+	return messages, nil
+}
+
+func prepareRegoPolicy(ctx context.Context, ruleName, module string) (rego.PreparedEvalQuery, error) {
+	return rego.New(
+		rego.Query(regoDenyQuery),
+		rego.Module(ruleName+".rego", module),
+		rego.StrictBuiltinErrors(true),
+	).PrepareForEval(ctx)
+}
@@ -0,0 +1,106 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testRegoPolicyRequireApproval = `package gittuf
+
+import rego.v1
+
+deny contains "change must be approved" if {
+	count(input.approvers) == 0
+}
+`
+
+	testRegoPolicyDenyWIP = `package gittuf
+
+import rego.v1
+
+deny contains msg if {
+	startswith(input.commit.message, "WIP")
+	msg := sprintf("commit '%s' is a work in progress", [input.commit.id])
+}
+
+deny contains "commit has no author email" if {
+	input.commit.author.email == ""
+}
+`
+)
+
+func TestValidateRegoPolicy(t *testing.T) {
+	t.Run("valid policy", func(t *testing.T) {
+		err := ValidateRegoPolicy(testRegoPolicyDenyWIP)
+		assert.Nil(t, err)
+	})
+
+	t.Run("syntax error", func(t *testing.T) {
+		err := ValidateRegoPolicy("package gittuf\n\ndeny contains {")
+		assert.ErrorIs(t, err, ErrInvalidRegoPolicy)
+	})
+
+	t.Run("wrong package", func(t *testing.T) {
+		err := ValidateRegoPolicy("package example\n\nimport rego.v1\n\ndeny contains \"no\" if true\n")
+		assert.ErrorIs(t, err, ErrInvalidRegoPolicy)
+		assert.Contains(t, err.Error(), "package 'gittuf'")
+	})
+
+	t.Run("empty policy", func(t *testing.T) {
+		err := ValidateRegoPolicy("")
+		assert.ErrorIs(t, err, ErrInvalidRegoPolicy)
+	})
+}
+
+func TestEvaluateRegoPolicy(t *testing.T) {
+	facts := &changeFacts{
+		Ref:      "refs/heads/main",
+		Pusher:   &identityFacts{Name: "Jane Doe", Email: "jane.doe@example.com"},
+		PushedAt: "2024-01-01T00:00:00Z",
+		Commit: &commitFacts{
+			ID:        "abcdef",
+			Message:   "WIP: add feature",
+			Author:    &identityFacts{Name: "Jane Doe"},
+			Committer: &identityFacts{Name: "Jane Doe"},
+			Parents:   []string{},
+		},
+		Approvers: []string{},
+	}
+
+	t.Run("change denied", func(t *testing.T) {
+		messages, err := evaluateRegoPolicy(testCtx, "protect-main", testRegoPolicyDenyWIP, facts)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"commit 'abcdef' is a work in progress", "commit has no author email"}, messages)
+
+		messages, err = evaluateRegoPolicy(testCtx, "protect-main", testRegoPolicyRequireApproval, facts)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"change must be approved"}, messages)
+	})
+
+	t.Run("change allowed", func(t *testing.T) {
+		allowedFacts := *facts
+		allowedFacts.Approvers = []string{"github:jane"}
+
+		messages, err := evaluateRegoPolicy(testCtx, "protect-main", testRegoPolicyRequireApproval, &allowedFacts)
+		assert.Nil(t, err)
+		assert.Empty(t, messages)
+	})
+
+	t.Run("no commit", func(t *testing.T) {
+		tagFacts := *facts
+		tagFacts.Commit = nil
+
+		messages, err := evaluateRegoPolicy(testCtx, "protect-main", testRegoPolicyDenyWIP, &tagFacts)
+		assert.Nil(t, err)
+		assert.Empty(t, messages)
+	})
+
+	t.Run("deny is not a set", func(t *testing.T) {
+		_, err := evaluateRegoPolicy(testCtx, "protect-main", "package gittuf\n\ndeny := true\n", facts)
+		assert.ErrorIs(t, err, ErrInvalidRegoPolicy)
+	})
+}
@@ -4,6 +4,8 @@ package policy
 
 import (
 	"errors"
+	"fmt"
+	"strings"
 	"time"
 
 	"github.com/gittuf/gittuf/internal/tuf"
@@ -16,6 +18,16 @@ var (
 	ErrTargetsMetadataNil  = errors.New("targetsMetadata not found")
 	ErrTargetsKeyNil       = errors.New("targetsKey is nil")
 	ErrKeyIDEmpty          = errors.New("keyID is empty")
+	ErrKeyNotInPolicy      = errors.New("key not found in policy")
+	ErrKeyAlreadyRevoked   = errors.New("key has already been revoked")
+	ErrRevocationReason    = errors.New("reason for revoking key must be specified")
+	ErrRepositoryNameEmpty = errors.New("repository name must be specified")
+	ErrRepositoryRootNil   = errors.New("repository not found in organization root of trust")
+	ErrGitHubAppKeyNil     = errors.New("GitHub app key is nil")
+	ErrGitLabAppKeyNil     = errors.New("GitLab app key is nil")
+	ErrInvalidGlobalRule   = errors.New("invalid global rule")
+	ErrGlobalRuleExists    = errors.New("global rule with same name already exists")
+	ErrGlobalRuleNotFound  = errors.New("global rule not found")
 )
 
 // InitializeRootMetadata initializes a new instance of tuf.RootMetadata with
@@ -81,6 +93,23 @@ func DeleteRootKey(rootMetadata *tuf.RootMetadata, keyID string) (*tuf.RootMetad
 	return rootMetadata, nil
 }
 
+// UpdateRootThreshold sets the threshold for the Root role.
+func UpdateRootThreshold(rootMetadata *tuf.RootMetadata, threshold int) (*tuf.RootMetadata, error) {
+	rootRole, ok := rootMetadata.Roles[RootRoleName]
+	if !ok {
+		return nil, ErrRootMetadataNil
+	}
+
+	if threshold < 1 || len(rootRole.KeyIDs) < threshold {
+		return nil, ErrCannotMeetThreshold
+	}
+
+	rootRole.Threshold = threshold
+	rootMetadata.Roles[RootRoleName] = rootRole
+
+	return rootMetadata, nil
+}
+
 // AddTargetsKey adds the 'targetsKey' as a trusted public key in 'rootMetadata'
 // for the top level Targets role.
 func AddTargetsKey(rootMetadata *tuf.RootMetadata, targetsKey *tuf.Key) (*tuf.RootMetadata, error) {
@@ -90,6 +119,9 @@ func AddTargetsKey(rootMetadata *tuf.RootMetadata, targetsKey *tuf.Key) (*tuf.Ro
 	if targetsKey == nil {
 		return nil, ErrTargetsKeyNil
 	}
+	if rootMetadata.IsKeyRevoked(targetsKey.KeyID) {
+		return nil, ErrKeyAlreadyRevoked
+	}
 
 	rootMetadata.Keys[targetsKey.KeyID] = targetsKey
 
@@ -163,3 +195,311 @@ func UpdateTargetsThreshold(rootMetadata *tuf.RootMetadata, threshold int) (*tuf
 
 	return rootMetadata, nil
 }
+
+// AddGitHubAppKey sets 'appKey' as the trusted public key in 'rootMetadata'
+// for the GitHub app role, which signs GitHub pull request approval
+// attestations.
+func AddGitHubAppKey(rootMetadata *tuf.RootMetadata, appKey *tuf.Key) (*tuf.RootMetadata, error) {
+	if rootMetadata == nil {
+		return nil, ErrRootMetadataNil
+	}
+	if appKey == nil {
+		return nil, ErrGitHubAppKeyNil
+	}
+	if rootMetadata.IsKeyRevoked(appKey.KeyID) {
+		return nil, ErrKeyAlreadyRevoked
+	}
+
+	rootMetadata.AddKey(appKey)
+	rootMetadata.AddRole(GitHubAppRoleName, tuf.Role{
+		KeyIDs:    []string{appKey.KeyID},
+		Threshold: 1,
+	})
+
+	return rootMetadata, nil
+}
+
+// DeleteGitHubAppKey removes the GitHub app role from 'rootMetadata'. GitHub
+// pull request approval attestations are no longer trusted after this.
+func DeleteGitHubAppKey(rootMetadata *tuf.RootMetadata) (*tuf.RootMetadata, error) {
+	if rootMetadata == nil {
+		return nil, ErrRootMetadataNil
+	}
+
+	delete(rootMetadata.Roles, GitHubAppRoleName)
+
+	return rootMetadata, nil
+}
+
+// AddGitLabAppKey sets 'appKey' as the trusted public key in 'rootMetadata'
+// for the GitLab app role, which signs GitLab merge request approval
+// attestations.
+func AddGitLabAppKey(rootMetadata *tuf.RootMetadata, appKey *tuf.Key) (*tuf.RootMetadata, error) {
+	if rootMetadata == nil {
+		return nil, ErrRootMetadataNil
+	}
+	if appKey == nil {
+		return nil, ErrGitLabAppKeyNil
+	}
+	if rootMetadata.IsKeyRevoked(appKey.KeyID) {
+		return nil, ErrKeyAlreadyRevoked
+	}
+
+	rootMetadata.AddKey(appKey)
+	rootMetadata.AddRole(GitLabAppRoleName, tuf.Role{
+		KeyIDs:    []string{appKey.KeyID},
+		Threshold: 1,
+	})
+
+	return rootMetadata, nil
+}
+
+// DeleteGitLabAppKey removes the GitLab app role from 'rootMetadata'. GitLab
+// merge request approval attestations are no longer trusted after this.
+func DeleteGitLabAppKey(rootMetadata *tuf.RootMetadata) (*tuf.RootMetadata, error) {
+	if rootMetadata == nil {
+		return nil, ErrRootMetadataNil
+	}
+
+	delete(rootMetadata.Roles, GitLabAppRoleName)
+
+	return rootMetadata, nil
+}
+
+// RevokeKey removes the key matching keyID from every role in rootMetadata and
+// records it as revoked along with the reason. Unlike deleting a key from a
+// role, a revoked key is also ignored when it is listed in policy files and
+// rules, and it cannot be added back to the root of trust.
+func RevokeKey(rootMetadata *tuf.RootMetadata, keyID, reason string) (*tuf.RootMetadata, error) {
+	if rootMetadata == nil {
+		return nil, ErrRootMetadataNil
+	}
+	if keyID == "" {
+		return nil, ErrKeyIDEmpty
+	}
+	if reason == "" {
+		return nil, ErrRevocationReason
+	}
+	if rootMetadata.IsKeyRevoked(keyID) {
+		return nil, ErrKeyAlreadyRevoked
+	}
+
+	updatedRoles := map[string]tuf.Role{}
+	for roleName, role := range rootMetadata.Roles {
+		newKeyIDs := []string{}
+		for _, k := range role.KeyIDs {
+			if k != keyID {
+				newKeyIDs = append(newKeyIDs, k)
+			}
+		}
+		if len(newKeyIDs) == len(role.KeyIDs) {
+			continue
+		}
+		if len(newKeyIDs) < role.Threshold {
+			return nil, ErrCannotMeetThreshold
+		}
+
+		role.KeyIDs = newKeyIDs
+		updatedRoles[roleName] = role
+	}
+
+	for repositoryName, repositoryRoot := range rootMetadata.RepositoryRoots {
+		if _, has := repositoryRoot.Keys[keyID]; has && len(repositoryRoot.Keys)-1 < repositoryRoot.Threshold {
+			return nil, fmt.Errorf("%w for root of trust of repository '%s'", ErrCannotMeetThreshold, repositoryName)
+		}
+	}
+
+	for roleName, role := range updatedRoles {
+		rootMetadata.Roles[roleName] = role
+	}
+	for _, repositoryRoot := range rootMetadata.RepositoryRoots {
+		delete(repositoryRoot.Keys, keyID)
+	}
+	delete(rootMetadata.Keys, keyID)
+	rootMetadata.AddRevokedKey(&tuf.KeyRevocation{
+		KeyID:     keyID,
+		Reason:    reason,
+		RevokedAt: time.Now().UTC().Format(time.RFC3339),
+	})
+
+	return rootMetadata, nil
+}
+
+// AddRepositoryRoot records rootKeys and threshold as the expected root of trust
+// for the repository named repositoryName, when rootMetadata is an
+// organization's root of trust. Any existing entry for the repository is
+// replaced.
+func AddRepositoryRoot(rootMetadata *tuf.RootMetadata, repositoryName string, rootKeys []*tuf.Key, threshold int) (*tuf.RootMetadata, error) {
+	if rootMetadata == nil {
+		return nil, ErrRootMetadataNil
+	}
+	if repositoryName == "" {
+		return nil, ErrRepositoryNameEmpty
+	}
+
+	repositoryRoot := &tuf.RepositoryRoot{Keys: map[string]*tuf.Key{}, Threshold: threshold}
+	for _, key := range rootKeys {
+		if rootMetadata.IsKeyRevoked(key.KeyID) {
+			return nil, ErrKeyAlreadyRevoked
+		}
+		repositoryRoot.Keys[key.KeyID] = key
+	}
+	if threshold < 1 || len(repositoryRoot.Keys) < threshold {
+		return nil, ErrCannotMeetThreshold
+	}
+
+	if rootMetadata.RepositoryRoots == nil {
+		rootMetadata.RepositoryRoots = map[string]*tuf.RepositoryRoot{}
+	}
+	rootMetadata.RepositoryRoots[repositoryName] = repositoryRoot
+
+	return rootMetadata, nil
+}
+
+// RemoveRepositoryRoot removes the expected root of trust for the repository
+// named repositoryName from rootMetadata.
+func RemoveRepositoryRoot(rootMetadata *tuf.RootMetadata, repositoryName string) (*tuf.RootMetadata, error) {
+	if rootMetadata == nil {
+		return nil, ErrRootMetadataNil
+	}
+	if _, has := rootMetadata.RepositoryRoots[repositoryName]; !has {
+		return nil, ErrRepositoryRootNil
+	}
+
+	delete(rootMetadata.RepositoryRoots, repositoryName)
+	if len(rootMetadata.RepositoryRoots) == 0 {
+		rootMetadata.RepositoryRoots = nil
+	}
+
+	return rootMetadata, nil
+}
+
+// AddGlobalRule adds a global rule named ruleName to rootMetadata. The rule
+// requires threshold signatures for changes to Git references that match any
+// of rulePatterns, which must use the "git:" scheme.
+func AddGlobalRule(rootMetadata *tuf.RootMetadata, ruleName string, rulePatterns []string, threshold int) (*tuf.RootMetadata, error) {
+	if rootMetadata == nil {
+		return nil, ErrRootMetadataNil
+	}
+	if ruleName == "" {
+		return nil, fmt.Errorf("%w: rule name must be specified", ErrInvalidGlobalRule)
+	}
+	if len(rulePatterns) == 0 {
+		return nil, fmt.Errorf("%w: at least one pattern must be specified", ErrInvalidGlobalRule)
+	}
+	for _, pattern := range rulePatterns {
+		if !strings.HasPrefix(pattern, gitReferenceRuleScheme+":") {
+			return nil, fmt.Errorf("%w: pattern '%s' does not protect Git references", ErrInvalidGlobalRule, pattern)
+		}
+	}
+	if threshold < 1 {
+		return nil, fmt.Errorf("%w: threshold must be at least 1", ErrInvalidGlobalRule)
+	}
+	for _, globalRule := range rootMetadata.GlobalRules {
+		if globalRule.Name == ruleName {
+			return nil, ErrGlobalRuleExists
+		}
+	}
+
+	rootMetadata.GlobalRules = append(rootMetadata.GlobalRules, &tuf.GlobalRule{
+		Name:      ruleName,
+		Paths:     rulePatterns,
+		Threshold: threshold,
+	})
+
+	return rootMetadata, nil
+}
+
+// RemoveGlobalRule removes the global rule named ruleName from rootMetadata.
+func RemoveGlobalRule(rootMetadata *tuf.RootMetadata, ruleName string) (*tuf.RootMetadata, error) {
+	if rootMetadata == nil {
+		return nil, ErrRootMetadataNil
+	}
+
+	globalRules := []*tuf.GlobalRule{}
+	for _, globalRule := range rootMetadata.GlobalRules {
+		if globalRule.Name != ruleName {
+			globalRules = append(globalRules, globalRule)
+		}
+	}
+	if len(globalRules) == len(rootMetadata.GlobalRules) {
+		return nil, ErrGlobalRuleNotFound
+	}
+
+	if len(globalRules) == 0 {
+		globalRules = nil
+	}
+	rootMetadata.GlobalRules = globalRules
+
+	return rootMetadata, nil
+}
+
+// SetOrganizationRoot records that the repository's root of trust must be
+// verified against the organization root of trust at location, where the
+// repository is recorded as repositoryName. If location is empty, the
+// organization root of trust is unset.
+func SetOrganizationRoot(rootMetadata *tuf.RootMetadata, location, repositoryName string) (*tuf.RootMetadata, error) {
+	if rootMetadata == nil {
+		return nil, ErrRootMetadataNil
+	}
+
+	if location == "" {
+		rootMetadata.OrganizationRoot = nil
+		return rootMetadata, nil
+	}
+
+	if repositoryName == "" {
+		return nil, ErrRepositoryNameEmpty
+	}
+
+	rootMetadata.OrganizationRoot = &tuf.OrganizationRoot{
+		Location:       location,
+		RepositoryName: repositoryName,
+	}
+
+	return rootMetadata, nil
+}
+
+// RotateRootMetadataKey replaces the key matching oldKeyID with newKey in every
+// role in rootMetadata that trusts it. It returns true if rootMetadata was
+// changed.
+func RotateRootMetadataKey(rootMetadata *tuf.RootMetadata, oldKeyID string, newKey *tuf.Key) (*tuf.RootMetadata, bool) {
+	if _, has := rootMetadata.Keys[oldKeyID]; !has {
+		return rootMetadata, false
+	}
+
+	for roleName, role := range rootMetadata.Roles {
+		role.KeyIDs = replaceKeyID(role.KeyIDs, oldKeyID, newKey.KeyID)
+		rootMetadata.Roles[roleName] = role
+	}
+
+	delete(rootMetadata.Keys, oldKeyID)
+	rootMetadata.AddKey(newKey)
+
+	return rootMetadata, true
+}
+
+// replaceKeyID replaces oldKeyID with newKeyID in keyIDs, retaining the order
+// of keys. If newKeyID is already present, oldKeyID is removed.
+func replaceKeyID(keyIDs []string, oldKeyID, newKeyID string) []string {
+	hasNewKeyID := false
+	for _, keyID := range keyIDs {
+		if keyID == newKeyID {
+			hasNewKeyID = true
+			break
+		}
+	}
+
+	updatedKeyIDs := make([]string, 0, len(keyIDs))
+	for _, keyID := range keyIDs {
+		if keyID == oldKeyID {
+			if hasNewKeyID {
+				continue
+			}
+			keyID = newKeyID
+		}
+		updatedKeyIDs = append(updatedKeyIDs, keyID)
+	}
+
+	return updatedKeyIDs
+}
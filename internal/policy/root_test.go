@@ -69,6 +69,32 @@ func TestRemoveRootKey(t *testing.T) {
 	assert.Nil(t, rootMetadata)
 }
 
+func TestUpdateRootThreshold(t *testing.T) {
+	key, err := tuf.LoadKeyFromBytes(rootKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rootMetadata := InitializeRootMetadata(key)
+
+	newRootKey, err := tuf.LoadKeyFromBytes(targets1KeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rootMetadata = AddRootKey(rootMetadata, newRootKey)
+
+	_, err = UpdateRootThreshold(rootMetadata, 3)
+	assert.ErrorIs(t, err, ErrCannotMeetThreshold)
+
+	_, err = UpdateRootThreshold(rootMetadata, 0)
+	assert.ErrorIs(t, err, ErrCannotMeetThreshold)
+
+	rootMetadata, err = UpdateRootThreshold(rootMetadata, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, rootMetadata.Roles[RootRoleName].Threshold)
+}
+
 func TestAddTargetsKey(t *testing.T) {
 	key, err := tuf.LoadKeyFromBytes(rootKeyBytes)
 	if err != nil {
@@ -134,3 +160,295 @@ func TestDeleteTargetsKey(t *testing.T) {
 	assert.ErrorIs(t, err, ErrCannotMeetThreshold)
 	assert.Nil(t, rootMetadata)
 }
+
+func TestRotateRootMetadataKey(t *testing.T) {
+	key, err := tuf.LoadKeyFromBytes(rootKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rootMetadata := InitializeRootMetadata(key)
+
+	targetsKey, err := tuf.LoadKeyFromBytes(targets1KeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootMetadata, err = AddTargetsKey(rootMetadata, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootMetadata, err = AddTargetsKey(rootMetadata, targetsKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newKey, err := tuf.LoadKeyFromBytes(targets2KeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("key not in root", func(t *testing.T) {
+		_, rotated := RotateRootMetadataKey(rootMetadata, "unknown", newKey)
+		assert.False(t, rotated)
+	})
+
+	t.Run("rotate key trusted for multiple roles", func(t *testing.T) {
+		rootMetadata, rotated := RotateRootMetadataKey(rootMetadata, key.KeyID, newKey)
+		assert.True(t, rotated)
+		assert.NotContains(t, rootMetadata.Keys, key.KeyID)
+		assert.Equal(t, newKey, rootMetadata.Keys[newKey.KeyID])
+		assert.Equal(t, []string{newKey.KeyID}, rootMetadata.Roles[RootRoleName].KeyIDs)
+		assert.Equal(t, []string{newKey.KeyID, targetsKey.KeyID}, rootMetadata.Roles[TargetsRoleName].KeyIDs)
+	})
+
+	t.Run("rotate to key that is already trusted", func(t *testing.T) {
+		rootMetadata, rotated := RotateRootMetadataKey(rootMetadata, newKey.KeyID, targetsKey)
+		assert.True(t, rotated)
+		assert.NotContains(t, rootMetadata.Keys, newKey.KeyID)
+		assert.Equal(t, []string{targetsKey.KeyID}, rootMetadata.Roles[RootRoleName].KeyIDs)
+		assert.Equal(t, []string{targetsKey.KeyID}, rootMetadata.Roles[TargetsRoleName].KeyIDs)
+	})
+}
+
+func TestAddGitHubAppKey(t *testing.T) {
+	key, err := tuf.LoadKeyFromBytes(rootKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	appKey, err := tuf.LoadKeyFromBytes(targets1PubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rootMetadata := InitializeRootMetadata(key)
+
+	_, err = AddGitHubAppKey(rootMetadata, nil)
+	assert.ErrorIs(t, err, ErrGitHubAppKeyNil)
+
+	rootMetadata, err = AddGitHubAppKey(rootMetadata, appKey)
+	assert.Nil(t, err)
+	assert.Equal(t, appKey, rootMetadata.Keys[appKey.KeyID])
+	assert.Equal(t, tuf.Role{KeyIDs: []string{appKey.KeyID}, Threshold: 1}, rootMetadata.Roles[GitHubAppRoleName])
+
+	rootMetadata, err = DeleteGitHubAppKey(rootMetadata)
+	assert.Nil(t, err)
+	assert.NotContains(t, rootMetadata.Roles, GitHubAppRoleName)
+}
+
+func TestAddGitLabAppKey(t *testing.T) {
+	key, err := tuf.LoadKeyFromBytes(rootKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	appKey, err := tuf.LoadKeyFromBytes(targets1PubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rootMetadata := InitializeRootMetadata(key)
+
+	_, err = AddGitLabAppKey(rootMetadata, nil)
+	assert.ErrorIs(t, err, ErrGitLabAppKeyNil)
+
+	rootMetadata, err = AddGitLabAppKey(rootMetadata, appKey)
+	assert.Nil(t, err)
+	assert.Equal(t, appKey, rootMetadata.Keys[appKey.KeyID])
+	assert.Equal(t, tuf.Role{KeyIDs: []string{appKey.KeyID}, Threshold: 1}, rootMetadata.Roles[GitLabAppRoleName])
+
+	rootMetadata, err = DeleteGitLabAppKey(rootMetadata)
+	assert.Nil(t, err)
+	assert.NotContains(t, rootMetadata.Roles, GitLabAppRoleName)
+}
+
+func TestRevokeKey(t *testing.T) {
+	key, err := tuf.LoadKeyFromBytes(rootKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rootMetadata := InitializeRootMetadata(key)
+
+	targetsKey1, err := tuf.LoadKeyFromBytes(targets1KeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsKey2, err := tuf.LoadKeyFromBytes(targets2KeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rootMetadata, err = AddTargetsKey(rootMetadata, targetsKey1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootMetadata, err = AddTargetsKey(rootMetadata, targetsKey2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = RevokeKey(nil, targetsKey1.KeyID, "compromised")
+	assert.ErrorIs(t, err, ErrRootMetadataNil)
+
+	_, err = RevokeKey(rootMetadata, "", "compromised")
+	assert.ErrorIs(t, err, ErrKeyIDEmpty)
+
+	_, err = RevokeKey(rootMetadata, targetsKey1.KeyID, "")
+	assert.ErrorIs(t, err, ErrRevocationReason)
+
+	_, err = RevokeKey(rootMetadata, key.KeyID, "compromised")
+	assert.ErrorIs(t, err, ErrCannotMeetThreshold)
+	assert.Equal(t, []string{key.KeyID}, rootMetadata.Roles[RootRoleName].KeyIDs)
+
+	rootMetadata, err = RevokeKey(rootMetadata, targetsKey1.KeyID, "compromised")
+	assert.Nil(t, err)
+	assert.NotContains(t, rootMetadata.Keys, targetsKey1.KeyID)
+	assert.Equal(t, []string{targetsKey2.KeyID}, rootMetadata.Roles[TargetsRoleName].KeyIDs)
+	assert.True(t, rootMetadata.IsKeyRevoked(targetsKey1.KeyID))
+	assert.Equal(t, "compromised", rootMetadata.RevokedKeys[targetsKey1.KeyID].Reason)
+
+	_, err = RevokeKey(rootMetadata, targetsKey1.KeyID, "compromised")
+	assert.ErrorIs(t, err, ErrKeyAlreadyRevoked)
+
+	_, err = AddTargetsKey(rootMetadata, targetsKey1)
+	assert.ErrorIs(t, err, ErrKeyAlreadyRevoked)
+}
+
+func TestAddRepositoryRoot(t *testing.T) {
+	key, err := tuf.LoadKeyFromBytes(rootKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	repositoryKey1, err := tuf.LoadKeyFromBytes(targets1KeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	repositoryKey2, err := tuf.LoadKeyFromBytes(targets2KeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rootMetadata := InitializeRootMetadata(key)
+
+	_, err = AddRepositoryRoot(nil, "repository", []*tuf.Key{repositoryKey1}, 1)
+	assert.ErrorIs(t, err, ErrRootMetadataNil)
+
+	_, err = AddRepositoryRoot(rootMetadata, "", []*tuf.Key{repositoryKey1}, 1)
+	assert.ErrorIs(t, err, ErrRepositoryNameEmpty)
+
+	_, err = AddRepositoryRoot(rootMetadata, "repository", []*tuf.Key{repositoryKey1}, 2)
+	assert.ErrorIs(t, err, ErrCannotMeetThreshold)
+
+	rootMetadata, err = AddRepositoryRoot(rootMetadata, "repository", []*tuf.Key{repositoryKey1, repositoryKey2}, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, rootMetadata.RepositoryRoots["repository"].Threshold)
+	assert.Equal(t, repositoryKey1, rootMetadata.RepositoryRoots["repository"].Keys[repositoryKey1.KeyID])
+	assert.Equal(t, repositoryKey2, rootMetadata.RepositoryRoots["repository"].Keys[repositoryKey2.KeyID])
+	assert.NotContains(t, rootMetadata.Keys, repositoryKey1.KeyID)
+
+	_, err = RevokeKey(rootMetadata, repositoryKey1.KeyID, "compromised")
+	assert.ErrorIs(t, err, ErrCannotMeetThreshold)
+
+	rootMetadata, err = AddRepositoryRoot(rootMetadata, "repository", []*tuf.Key{repositoryKey1, repositoryKey2}, 1)
+	assert.Nil(t, err)
+
+	rootMetadata, err = RevokeKey(rootMetadata, repositoryKey1.KeyID, "compromised")
+	assert.Nil(t, err)
+	assert.NotContains(t, rootMetadata.RepositoryRoots["repository"].Keys, repositoryKey1.KeyID)
+
+	_, err = AddRepositoryRoot(rootMetadata, "repository", []*tuf.Key{repositoryKey1}, 1)
+	assert.ErrorIs(t, err, ErrKeyAlreadyRevoked)
+}
+
+func TestRemoveRepositoryRoot(t *testing.T) {
+	key, err := tuf.LoadKeyFromBytes(rootKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	repositoryKey, err := tuf.LoadKeyFromBytes(targets1KeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rootMetadata := InitializeRootMetadata(key)
+
+	_, err = RemoveRepositoryRoot(rootMetadata, "repository")
+	assert.ErrorIs(t, err, ErrRepositoryRootNil)
+
+	rootMetadata, err = AddRepositoryRoot(rootMetadata, "repository", []*tuf.Key{repositoryKey}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rootMetadata, err = RemoveRepositoryRoot(rootMetadata, "repository")
+	assert.Nil(t, err)
+	assert.Nil(t, rootMetadata.RepositoryRoots)
+}
+
+func TestSetOrganizationRoot(t *testing.T) {
+	key, err := tuf.LoadKeyFromBytes(rootKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rootMetadata := InitializeRootMetadata(key)
+
+	_, err = SetOrganizationRoot(rootMetadata, "https://example.com/org-root", "")
+	assert.ErrorIs(t, err, ErrRepositoryNameEmpty)
+
+	rootMetadata, err = SetOrganizationRoot(rootMetadata, "https://example.com/org-root", "repository")
+	assert.Nil(t, err)
+	assert.Equal(t, &tuf.OrganizationRoot{Location: "https://example.com/org-root", RepositoryName: "repository"}, rootMetadata.OrganizationRoot)
+
+	rootMetadata, err = SetOrganizationRoot(rootMetadata, "", "")
+	assert.Nil(t, err)
+	assert.Nil(t, rootMetadata.OrganizationRoot)
+}
+
+func TestAddGlobalRule(t *testing.T) {
+	key, err := tuf.LoadKeyFromBytes(rootKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rootMetadata := InitializeRootMetadata(key)
+
+	_, err = AddGlobalRule(rootMetadata, "", []string{"git:*"}, 1)
+	assert.ErrorIs(t, err, ErrInvalidGlobalRule)
+
+	_, err = AddGlobalRule(rootMetadata, "require-signatures", nil, 1)
+	assert.ErrorIs(t, err, ErrInvalidGlobalRule)
+
+	_, err = AddGlobalRule(rootMetadata, "require-signatures", []string{"file:*"}, 1)
+	assert.ErrorIs(t, err, ErrInvalidGlobalRule)
+
+	_, err = AddGlobalRule(rootMetadata, "require-signatures", []string{"git:*"}, 0)
+	assert.ErrorIs(t, err, ErrInvalidGlobalRule)
+
+	rootMetadata, err = AddGlobalRule(rootMetadata, "require-signatures", []string{"git:*"}, 1)
+	assert.Nil(t, err)
+	assert.Equal(t, []*tuf.GlobalRule{{Name: "require-signatures", Paths: []string{"git:*"}, Threshold: 1}}, rootMetadata.GlobalRules)
+
+	_, err = AddGlobalRule(rootMetadata, "require-signatures", []string{"git:refs/heads/main"}, 2)
+	assert.ErrorIs(t, err, ErrGlobalRuleExists)
+}
+
+func TestRemoveGlobalRule(t *testing.T) {
+	key, err := tuf.LoadKeyFromBytes(rootKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rootMetadata := InitializeRootMetadata(key)
+
+	_, err = RemoveGlobalRule(rootMetadata, "require-signatures")
+	assert.ErrorIs(t, err, ErrGlobalRuleNotFound)
+
+	rootMetadata, err = AddGlobalRule(rootMetadata, "require-signatures", []string{"git:*"}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rootMetadata, err = RemoveGlobalRule(rootMetadata, "require-signatures")
+	assert.Nil(t, err)
+	assert.Nil(t, rootMetadata.GlobalRules)
+}
@@ -0,0 +1,122 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"context"
+
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/go-git/go-git/v5"
+)
+
+// RuleTree is a policy file in the delegation tree, along with the rules it
+// contains in the order they are evaluated.
+type RuleTree struct {
+	PolicyName string          `json:"policy_name"`
+	Rules      []*RuleTreeNode `json:"rules"`
+}
+
+// RuleTreeNode is a rule in the delegation tree. If the rule delegates to a
+// policy file, the rules in the policy file are recorded in DelegatedRules.
+type RuleTreeNode struct {
+	Name             string           `json:"name"`
+	Effect           string           `json:"effect"`
+	Priority         int              `json:"priority,omitempty"`
+	Terminating      bool             `json:"terminating,omitempty"`
+	Paths            []string         `json:"paths"`
+	Threshold        int              `json:"threshold"`
+	AuthorizedKeys   []*AuthorizedKey `json:"authorized_keys"`
+	AuthorizedPeople []string         `json:"authorized_people,omitempty"`
+	AuthorizedTeams  []string         `json:"authorized_teams,omitempty"`
+	DelegatedRules   *RuleTree        `json:"delegated_rules,omitempty"`
+}
+
+// AuthorizedKey describes a key authorized by a rule. The type and identity
+// are only recorded if the key is defined in the policy file containing the
+// rule.
+type AuthorizedKey struct {
+	KeyID    string `json:"keyid"`
+	KeyType  string `json:"keytype,omitempty"`
+	Identity string `json:"identity,omitempty"`
+	Issuer   string `json:"issuer,omitempty"`
+}
+
+// GetRuleTree returns the delegation tree of the repository's current policy,
+// starting at the top level policy file.
+func GetRuleTree(ctx context.Context, repo *git.Repository) (*RuleTree, error) {
+	state, err := LoadCurrentState(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	return state.GetRuleTree()
+}
+
+// GetRuleTree returns the delegation tree of the policy in the state, starting
+// at the top level policy file. A policy file that is reachable via more than
+// one rule is only expanded the first time it is encountered.
+func (s *State) GetRuleTree() (*RuleTree, error) {
+	if !s.HasTargetsRole(TargetsRoleName) {
+		return nil, ErrMetadataNotFound
+	}
+
+	seenRoles := map[string]bool{}
+	return s.getRuleTree(TargetsRoleName, seenRoles)
+}
+
+func (s *State) getRuleTree(roleName string, seenRoles map[string]bool) (*RuleTree, error) {
+	seenRoles[roleName] = true
+
+	targetsMetadata, err := s.GetTargetsMetadata(roleName)
+	if err != nil {
+		return nil, err
+	}
+
+	tree := &RuleTree{PolicyName: roleName, Rules: []*RuleTreeNode{}}
+	for _, delegation := range orderDelegations(targetsMetadata.Delegations.Roles) {
+		if delegation.Name == AllowRuleName {
+			continue
+		}
+
+		node := &RuleTreeNode{
+			Name:             delegation.Name,
+			Effect:           RuleEffectAllow,
+			Priority:         delegation.Priority,
+			Terminating:      delegation.Terminating,
+			Paths:            delegation.Paths,
+			Threshold:        delegation.Threshold,
+			AuthorizedKeys:   make([]*AuthorizedKey, 0, len(delegation.KeyIDs)),
+			AuthorizedPeople: delegation.PersonIDs,
+			AuthorizedTeams:  delegation.TeamIDs,
+		}
+		if delegation.Effect != "" {
+			node.Effect = delegation.Effect
+		}
+		for _, keyID := range delegation.KeyIDs {
+			node.AuthorizedKeys = append(node.AuthorizedKeys, newAuthorizedKey(keyID, targetsMetadata.Delegations.Keys[keyID]))
+		}
+
+		if !seenRoles[delegation.Name] && s.HasTargetsRole(delegation.Name) {
+			node.DelegatedRules, err = s.getRuleTree(delegation.Name, seenRoles)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		tree.Rules = append(tree.Rules, node)
+	}
+
+	return tree, nil
+}
+
+func newAuthorizedKey(keyID string, key *tuf.Key) *AuthorizedKey {
+	authorizedKey := &AuthorizedKey{KeyID: keyID}
+	if key == nil {
+		return authorizedKey
+	}
+
+	authorizedKey.KeyType = key.KeyType
+	authorizedKey.Identity = key.KeyVal.Identity
+	authorizedKey.Issuer = key.KeyVal.Issuer
+	return authorizedKey
+}
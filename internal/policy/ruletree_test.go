@@ -0,0 +1,90 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/signerverifier/dsse"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetRuleTree(t *testing.T) {
+	t.Run("no delegations", func(t *testing.T) {
+		repo, _ := createTestRepository(t, createTestStateWithPolicy)
+
+		tree, err := GetRuleTree(context.Background(), repo)
+		assert.Nil(t, err)
+		assert.Equal(t, &RuleTree{
+			PolicyName: TargetsRoleName,
+			Rules: []*RuleTreeNode{
+				{
+					Name:           "protect-main",
+					Effect:         RuleEffectAllow,
+					Paths:          []string{"git:refs/heads/main"},
+					Threshold:      1,
+					AuthorizedKeys: []*AuthorizedKey{{KeyID: "157507bbe151e378ce8126c1dcfe043cdd2db96e", KeyType: "gpg"}},
+				},
+				{
+					Name:           "protect-files-1-and-2",
+					Effect:         RuleEffectAllow,
+					Paths:          []string{"file:1", "file:2"},
+					Threshold:      1,
+					AuthorizedKeys: []*AuthorizedKey{{KeyID: "157507bbe151e378ce8126c1dcfe043cdd2db96e", KeyType: "gpg"}},
+				},
+			},
+		}, tree)
+	})
+
+	t.Run("with delegations", func(t *testing.T) {
+		state := createTestStateWithDelegatedPolicies(t)
+
+		tree, err := state.GetRuleTree()
+		assert.Nil(t, err)
+
+		ruleNames := []string{}
+		for _, rule := range tree.Rules {
+			ruleNames = append(ruleNames, rule.Name)
+		}
+		assert.Equal(t, []string{"1", "2"}, ruleNames)
+		assert.Nil(t, tree.Rules[1].DelegatedRules)
+
+		delegatedTree := tree.Rules[0].DelegatedRules
+		if assert.NotNil(t, delegatedTree) {
+			assert.Equal(t, "1", delegatedTree.PolicyName)
+			assert.Equal(t, "3", delegatedTree.Rules[0].Name)
+			assert.Equal(t, []string{"file:1/subpath1/*"}, delegatedTree.Rules[0].Paths)
+			assert.Equal(t, "4", delegatedTree.Rules[1].Name)
+		}
+	})
+
+	t.Run("deny rule with priority", func(t *testing.T) {
+		state := createTestStateWithPolicy(t)
+
+		targetsMetadata, err := state.GetTargetsMetadata(TargetsRoleName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsMetadata, err = SetRuleEffect(targetsMetadata, "protect-files-1-and-2", RuleEffectDeny)
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsMetadata, err = SetRulePriority(targetsMetadata, "protect-files-1-and-2", 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsEnv, err := dsse.CreateEnvelope(targetsMetadata)
+		if err != nil {
+			t.Fatal(err)
+		}
+		state.TargetsEnvelope = targetsEnv
+
+		tree, err := state.GetRuleTree()
+		assert.Nil(t, err)
+		assert.Equal(t, "protect-files-1-and-2", tree.Rules[0].Name)
+		assert.Equal(t, RuleEffectDeny, tree.Rules[0].Effect)
+		assert.Equal(t, 1, tree.Rules[0].Priority)
+		assert.Equal(t, "protect-main", tree.Rules[1].Name)
+	})
+}
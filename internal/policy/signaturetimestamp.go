@@ -0,0 +1,60 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// SignatureTimestampFunc returns the time a signature was made, as established
+// by a trusted timestamp.
+type SignatureTimestampFunc func(signature []byte) (time.Time, error)
+
+type signatureTimestampsKey struct{}
+
+// WithSignatureTimestamps returns a copy of ctx that carries fn. When
+// verifying using a context that carries fn, a Git signature made using a key
+// that has since been revoked is trusted if fn establishes that the signature
+// was made before the key was revoked.
+func WithSignatureTimestamps(ctx context.Context, fn SignatureTimestampFunc) context.Context {
+	return context.WithValue(ctx, signatureTimestampsKey{}, fn)
+}
+
+// signedBeforeRevocation returns true if the Git object's signature has a
+// trusted timestamp that predates the revocation.
+func signedBeforeRevocation(ctx context.Context, gitObject object.Object, revocation *tuf.KeyRevocation) bool {
+	fn, ok := ctx.Value(signatureTimestampsKey{}).(SignatureTimestampFunc)
+	if !ok {
+		return false
+	}
+
+	var signature string
+	switch o := gitObject.(type) {
+	case *object.Commit:
+		signature = o.PGPSignature
+	case *object.Tag:
+		signature = o.PGPSignature
+	}
+	if signature == "" {
+		return false
+	}
+
+	revokedAt, err := time.Parse(time.RFC3339, revocation.RevokedAt)
+	if err != nil {
+		return false
+	}
+
+	signedAt, err := fn([]byte(signature))
+	if err != nil {
+		slog.Debug(fmt.Sprintf("Unable to establish when signature using revoked key '%s' was made: %s", revocation.KeyID, err.Error()))
+		return false
+	}
+
+	return signedAt.Before(revokedAt)
+}
@@ -0,0 +1,97 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"fmt"
+)
+
+// SimulationCheck records whether the simulated signers meet the rules
+// protecting a single namespace, either a Git reference or a file path.
+type SimulationCheck struct {
+	// Target is the namespace checked, such as "git:refs/heads/main" or
+	// "file:src/main.go".
+	Target string `json:"target"`
+
+	// Rules contains the names of all rules that protect Target, in the order
+	// they are evaluated. If empty, Target is unprotected.
+	Rules []string `json:"rules"`
+
+	// SatisfiedBy is the name of the first rule whose threshold is met by the
+	// simulated signers. If deny rules protect Target, only they are
+	// considered and all of them must be met.
+	SatisfiedBy string `json:"satisfied_by,omitempty"`
+
+	Allowed bool `json:"allowed"`
+}
+
+// SimulationResult is the outcome of simulating verification of a change
+// against a policy State.
+type SimulationResult struct {
+	Allowed bool               `json:"allowed"`
+	Checks  []*SimulationCheck `json:"checks"`
+}
+
+// Simulate reports whether a change to refName that modifies the specified
+// paths would be permitted by the State if signed by the keys in keyIDs.
+// Signatures are not checked, instead each rule is considered met if a
+// threshold of its trusted keys are in keyIDs. This assumes that signatures
+// beyond the first are provided via attestations. Revoked keys never count
+// towards a rule's threshold.
+func (s *State) Simulate(refName string, paths []string, keyIDs []string) (*SimulationResult, error) {
+	signers := map[string]bool{}
+	for _, keyID := range keyIDs {
+		signers[keyID] = true
+	}
+
+	targets := []string{fmt.Sprintf("%s:%s", gitReferenceRuleScheme, refName)}
+	for _, path := range paths {
+		targets = append(targets, fmt.Sprintf("%s:%s", fileRuleScheme, path))
+	}
+
+	result := &SimulationResult{Allowed: true, Checks: make([]*SimulationCheck, 0, len(targets))}
+	for _, target := range targets {
+		verifiers, err := s.FindVerifiersForPath(target)
+		if err != nil {
+			return nil, err
+		}
+
+		check := &SimulationCheck{Target: target, Rules: []string{}}
+		for _, verifier := range verifiers {
+			check.Rules = append(check.Rules, verifier.Name())
+		}
+
+		verifiers, denied := applyDenyRules(verifiers)
+		for _, verifier := range verifiers {
+			count := 0
+			for _, key := range verifier.Keys() {
+				if signers[key.KeyID] {
+					count++
+				}
+			}
+			met := verifier.Threshold() >= 1 && count >= verifier.Threshold()
+
+			if met && check.SatisfiedBy == "" {
+				check.SatisfiedBy = verifier.Name()
+				if !denied {
+					break
+				}
+			}
+			if !met && denied {
+				// Every deny rule must be met
+				check.SatisfiedBy = ""
+				break
+			}
+		}
+
+		// No rules => no restrictions for the namespace
+		check.Allowed = len(verifiers) == 0 || check.SatisfiedBy != ""
+		if !check.Allowed {
+			result.Allowed = false
+		}
+
+		result.Checks = append(result.Checks, check)
+	}
+
+	return result, nil
+}
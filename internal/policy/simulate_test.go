@@ -0,0 +1,103 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/signerverifier"
+	"github.com/gittuf/gittuf/internal/signerverifier/dsse"
+	"github.com/gittuf/gittuf/internal/signerverifier/gpg"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStateSimulate(t *testing.T) {
+	state := createTestStateWithPolicy(t)
+
+	gpgKey, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootKey, err := tuf.LoadKeyFromBytes(rootPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("authorized signer", func(t *testing.T) {
+		result, err := state.Simulate("refs/heads/main", []string{"1", "3"}, []string{gpgKey.KeyID})
+		assert.Nil(t, err)
+		assert.True(t, result.Allowed)
+		assert.Equal(t, []*SimulationCheck{
+			{Target: "git:refs/heads/main", Rules: []string{"protect-main"}, SatisfiedBy: "protect-main", Allowed: true},
+			{Target: "file:1", Rules: []string{"protect-files-1-and-2"}, SatisfiedBy: "protect-files-1-and-2", Allowed: true},
+			{Target: "file:3", Rules: []string{}, Allowed: true},
+		}, result.Checks)
+	})
+
+	t.Run("unauthorized signer", func(t *testing.T) {
+		result, err := state.Simulate("refs/heads/main", []string{"2"}, []string{rootKey.KeyID})
+		assert.Nil(t, err)
+		assert.False(t, result.Allowed)
+		assert.Equal(t, []*SimulationCheck{
+			{Target: "git:refs/heads/main", Rules: []string{"protect-main"}, Allowed: false},
+			{Target: "file:2", Rules: []string{"protect-files-1-and-2"}, Allowed: false},
+		}, result.Checks)
+	})
+
+	t.Run("deny rule", func(t *testing.T) {
+		state := createTestStateWithPolicy(t)
+
+		signer, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(rootKeyBytes) //nolint:staticcheck
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		targetsMetadata, err := state.GetTargetsMetadata(TargetsRoleName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsMetadata, err = AddDelegation(targetsMetadata, "allow-all-files", []*tuf.Key{rootKey}, []string{"file:*"}, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsMetadata, err = AddDelegation(targetsMetadata, "deny-file-1", []*tuf.Key{gpgKey}, []string{"file:1"}, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsMetadata, err = SetRuleEffect(targetsMetadata, "deny-file-1", RuleEffectDeny)
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsEnv, err := dsse.CreateEnvelope(targetsMetadata)
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsEnv, err = dsse.SignEnvelope(testCtx, targetsEnv, signer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		state.TargetsEnvelope = targetsEnv
+
+		result, err := state.Simulate("refs/heads/feature", []string{"1", "3"}, []string{rootKey.KeyID})
+		assert.Nil(t, err)
+		assert.False(t, result.Allowed)
+		assert.Equal(t, []*SimulationCheck{
+			{Target: "git:refs/heads/feature", Rules: []string{}, Allowed: true},
+			{Target: "file:1", Rules: []string{"protect-files-1-and-2", "allow-all-files", "deny-file-1"}, Allowed: false},
+			{Target: "file:3", Rules: []string{"allow-all-files"}, SatisfiedBy: "allow-all-files", Allowed: true},
+		}, result.Checks)
+
+		result, err = state.Simulate("refs/heads/feature", []string{"1"}, []string{gpgKey.KeyID})
+		assert.Nil(t, err)
+		assert.True(t, result.Allowed)
+		assert.Equal(t, "deny-file-1", result.Checks[1].SatisfiedBy)
+	})
+
+	t.Run("unprotected ref", func(t *testing.T) {
+		result, err := state.Simulate("refs/heads/feature", nil, []string{rootKey.KeyID})
+		assert.Nil(t, err)
+		assert.True(t, result.Allowed)
+		assert.Empty(t, result.Checks[0].Rules)
+	})
+}
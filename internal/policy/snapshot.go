@@ -0,0 +1,308 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/gittuf/gittuf/internal/tuf"
+)
+
+// SnapshotRoleName defines the expected name for the optional snapshot role
+// that binds all policy files in a policy state together.
+const SnapshotRoleName = "snapshot"
+
+var (
+	ErrSnapshotKeyNil   = errors.New("snapshotKey is nil")
+	ErrSnapshotOutdated = errors.New("snapshot metadata does not match policy files, sign it using 'gittuf policy sign-snapshot'")
+	ErrSnapshotRollback = errors.New("snapshot metadata version is lower than the previous snapshot")
+)
+
+// AddSnapshotKey adds the 'snapshotKey' as a trusted public key in
+// 'rootMetadata' for the snapshot role. Adding the first key enables the
+// snapshot role for the repository.
+func AddSnapshotKey(rootMetadata *tuf.RootMetadata, snapshotKey *tuf.Key) (*tuf.RootMetadata, error) {
+	if snapshotKey == nil {
+		return nil, ErrSnapshotKeyNil
+	}
+
+	return addOptionalRoleKey(rootMetadata, SnapshotRoleName, snapshotKey)
+}
+
+// DeleteSnapshotKey removes the key matching 'keyID' from trusted public keys
+// for the snapshot role in 'rootMetadata'. Removing the last key disables the
+// snapshot role. Note: It doesn't remove the key entry itself as it doesn't
+// check if other roles can use the same key.
+func DeleteSnapshotKey(rootMetadata *tuf.RootMetadata, keyID string) (*tuf.RootMetadata, error) {
+	return deleteOptionalRoleKey(rootMetadata, SnapshotRoleName, keyID)
+}
+
+// HasSnapshotRole returns true if the State's root of trust requires policy
+// files to be bound together by snapshot metadata.
+func (s *State) HasSnapshotRole() (bool, error) {
+	rootMetadata, err := s.GetRootMetadata()
+	if err != nil {
+		return false, err
+	}
+
+	_, has := rootMetadata.Roles[SnapshotRoleName]
+	return has, nil
+}
+
+// GenerateSnapshotMetadata returns snapshot metadata recording the version and
+// hash of every policy file in the State. The version of the snapshot is one
+// more than the version of the State's current snapshot, if any.
+func (s *State) GenerateSnapshotMetadata() (*tuf.SnapshotMetadata, error) {
+	meta, err := s.snapshotFileMeta()
+	if err != nil {
+		return nil, err
+	}
+
+	version := 1
+	if s.SnapshotEnvelope != nil {
+		currentSnapshot, err := s.GetSnapshotMetadata()
+		if err != nil {
+			return nil, err
+		}
+		version = currentSnapshot.Version + 1
+	}
+
+	snapshotMetadata := tuf.NewSnapshotMetadata()
+	snapshotMetadata.SetVersion(version)
+	snapshotMetadata.SetExpires(time.Now().AddDate(1, 0, 0).Format(time.RFC3339))
+	snapshotMetadata.Meta = meta
+
+	return snapshotMetadata, nil
+}
+
+// GetSnapshotMetadata returns the deserialized payload of the State's
+// SnapshotEnvelope.
+func (s *State) GetSnapshotMetadata() (*tuf.SnapshotMetadata, error) {
+	if s.SnapshotEnvelope == nil {
+		return nil, ErrMetadataNotFound
+	}
+
+	payloadBytes, err := s.SnapshotEnvelope.DecodeB64Payload()
+	if err != nil {
+		return nil, err
+	}
+
+	snapshotMetadata := &tuf.SnapshotMetadata{}
+	if err := json.Unmarshal(payloadBytes, snapshotMetadata); err != nil {
+		return nil, err
+	}
+
+	return snapshotMetadata, nil
+}
+
+// IsSnapshotCurrent returns true if the State's snapshot metadata records
+// exactly the State's policy files. Its signatures are not verified.
+func (s *State) IsSnapshotCurrent() (bool, error) {
+	if s.SnapshotEnvelope == nil {
+		return false, nil
+	}
+
+	snapshotMetadata, err := s.GetSnapshotMetadata()
+	if err != nil {
+		return false, err
+	}
+
+	meta, err := s.snapshotFileMeta()
+	if err != nil {
+		return false, err
+	}
+
+	return reflect.DeepEqual(meta, snapshotMetadata.Meta), nil
+}
+
+// VerifySnapshot checks that the State's snapshot metadata is signed by a
+// threshold of the snapshot role's keys and that it matches the State's policy
+// files, so that policy files from different states cannot be mixed. If the
+// root of trust doesn't define a snapshot role, no checks are performed.
+func (s *State) VerifySnapshot(ctx context.Context) error {
+	hasSnapshotRole, err := s.HasSnapshotRole()
+	if err != nil {
+		return err
+	}
+	if !hasSnapshotRole {
+		return nil
+	}
+
+	current, err := s.IsSnapshotCurrent()
+	if err != nil {
+		return err
+	}
+	if !current {
+		return ErrSnapshotOutdated
+	}
+
+	snapshotVerifier, err := s.getOptionalRoleVerifier(SnapshotRoleName)
+	if err != nil {
+		return err
+	}
+
+	return snapshotVerifier.Verify(ctx, nil, s.SnapshotEnvelope)
+}
+
+// verifySnapshotVersion checks that newPolicy's snapshot metadata does not
+// roll back the version of the State's snapshot metadata.
+func (s *State) verifySnapshotVersion(newPolicy *State) error {
+	if s.SnapshotEnvelope == nil || newPolicy.SnapshotEnvelope == nil {
+		return nil
+	}
+
+	currentSnapshot, err := s.GetSnapshotMetadata()
+	if err != nil {
+		return err
+	}
+	newSnapshot, err := newPolicy.GetSnapshotMetadata()
+	if err != nil {
+		return err
+	}
+
+	if newSnapshot.Version < currentSnapshot.Version {
+		return fmt.Errorf("%w: version %d follows version %d", ErrSnapshotRollback, newSnapshot.Version, currentSnapshot.Version)
+	}
+
+	return nil
+}
+
+func (s *State) snapshotFileMeta() (map[string]*tuf.SnapshotFileMeta, error) {
+	roleNames := []string{}
+	if s.TargetsEnvelope != nil {
+		roleNames = append(roleNames, TargetsRoleName)
+	}
+	for roleName := range s.DelegationEnvelopes {
+		roleNames = append(roleNames, roleName)
+	}
+
+	meta := map[string]*tuf.SnapshotFileMeta{}
+	for _, roleName := range roleNames {
+		env := s.TargetsEnvelope
+		if roleName != TargetsRoleName {
+			env = s.DelegationEnvelopes[roleName]
+		}
+
+		payloadBytes, err := env.DecodeB64Payload()
+		if err != nil {
+			return nil, err
+		}
+
+		targetsMetadata, err := s.GetTargetsMetadata(roleName)
+		if err != nil {
+			return nil, err
+		}
+
+		digest := sha256.Sum256(payloadBytes)
+		meta[fmt.Sprintf("%s.json", roleName)] = &tuf.SnapshotFileMeta{
+			Version: targetsMetadata.Version,
+			Hashes:  map[string]string{"sha256": hex.EncodeToString(digest[:])},
+		}
+	}
+
+	return meta, nil
+}
+
+// addOptionalRoleKey adds key to the optional top level role roleName,
+// creating the role with a threshold of one if it doesn't exist.
+func addOptionalRoleKey(rootMetadata *tuf.RootMetadata, roleName string, key *tuf.Key) (*tuf.RootMetadata, error) {
+	if rootMetadata == nil {
+		return nil, ErrRootMetadataNil
+	}
+	if rootMetadata.IsKeyRevoked(key.KeyID) {
+		return nil, ErrKeyAlreadyRevoked
+	}
+
+	rootMetadata.AddKey(key)
+
+	role, ok := rootMetadata.Roles[roleName]
+	if !ok {
+		rootMetadata.AddRole(roleName, tuf.Role{
+			KeyIDs:    []string{key.KeyID},
+			Threshold: 1,
+		})
+		return rootMetadata, nil
+	}
+
+	if isKeyIDListed(role.KeyIDs, key.KeyID) {
+		return rootMetadata, nil
+	}
+
+	role.KeyIDs = append(role.KeyIDs, key.KeyID)
+	rootMetadata.Roles[roleName] = role
+
+	return rootMetadata, nil
+}
+
+// deleteOptionalRoleKey removes the key matching keyID from the optional top
+// level role roleName, removing the role itself along with its last key.
+func deleteOptionalRoleKey(rootMetadata *tuf.RootMetadata, roleName, keyID string) (*tuf.RootMetadata, error) {
+	if rootMetadata == nil {
+		return nil, ErrRootMetadataNil
+	}
+	if keyID == "" {
+		return nil, ErrKeyIDEmpty
+	}
+
+	role, ok := rootMetadata.Roles[roleName]
+	if !ok || !isKeyIDListed(role.KeyIDs, keyID) {
+		return nil, ErrKeyNotInPolicy
+	}
+
+	if len(role.KeyIDs) == 1 {
+		delete(rootMetadata.Roles, roleName)
+		return rootMetadata, nil
+	}
+
+	if len(role.KeyIDs) <= role.Threshold {
+		return nil, ErrCannotMeetThreshold
+	}
+
+	newKeyIDs := []string{}
+	for _, k := range role.KeyIDs {
+		if k != keyID {
+			newKeyIDs = append(newKeyIDs, k)
+		}
+	}
+	role.KeyIDs = newKeyIDs
+	rootMetadata.Roles[roleName] = role
+
+	return rootMetadata, nil
+}
+
+// getOptionalRoleVerifier returns a Verifier for the optional top level role
+// roleName using the keys and threshold recorded in the root of trust.
+func (s *State) getOptionalRoleVerifier(roleName string) (*Verifier, error) {
+	rootMetadata, err := s.GetRootMetadata()
+	if err != nil {
+		return nil, err
+	}
+
+	role := rootMetadata.Roles[roleName]
+	verifier := &Verifier{
+		name:      roleName,
+		keys:      make([]*tuf.Key, 0, len(role.KeyIDs)),
+		threshold: role.Threshold,
+	}
+	for _, keyID := range role.KeyIDs {
+		verifier.keys = append(verifier.keys, rootMetadata.Keys[keyID])
+	}
+
+	return verifier, nil
+}
+
+func isKeyIDListed(keyIDs []string, keyID string) bool {
+	for _, k := range keyIDs {
+		if k == keyID {
+			return true
+		}
+	}
+	return false
+}
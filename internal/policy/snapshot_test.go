@@ -0,0 +1,169 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/signerverifier"
+	"github.com/gittuf/gittuf/internal/signerverifier/dsse"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddSnapshotKey(t *testing.T) {
+	key, err := tuf.LoadKeyFromBytes(rootPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	snapshotKey, err := tuf.LoadKeyFromBytes(targets1PubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rootMetadata := InitializeRootMetadata(key)
+
+	rootMetadata, err = AddSnapshotKey(rootMetadata, snapshotKey)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{snapshotKey.KeyID}, rootMetadata.Roles[SnapshotRoleName].KeyIDs)
+	assert.Equal(t, 1, rootMetadata.Roles[SnapshotRoleName].Threshold)
+	assert.Equal(t, snapshotKey, rootMetadata.Keys[snapshotKey.KeyID])
+
+	// Adding the same key again is a no-op
+	rootMetadata, err = AddSnapshotKey(rootMetadata, snapshotKey)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{snapshotKey.KeyID}, rootMetadata.Roles[SnapshotRoleName].KeyIDs)
+
+	_, err = AddSnapshotKey(rootMetadata, nil)
+	assert.ErrorIs(t, err, ErrSnapshotKeyNil)
+}
+
+func TestDeleteSnapshotKey(t *testing.T) {
+	key, err := tuf.LoadKeyFromBytes(rootPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	snapshotKey1, err := tuf.LoadKeyFromBytes(targets1PubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	snapshotKey2, err := tuf.LoadKeyFromBytes(targets2PubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rootMetadata := InitializeRootMetadata(key)
+	rootMetadata, err = AddSnapshotKey(rootMetadata, snapshotKey1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootMetadata, err = AddSnapshotKey(rootMetadata, snapshotKey2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = DeleteSnapshotKey(rootMetadata, key.KeyID)
+	assert.ErrorIs(t, err, ErrKeyNotInPolicy)
+
+	rootMetadata, err = DeleteSnapshotKey(rootMetadata, snapshotKey1.KeyID)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{snapshotKey2.KeyID}, rootMetadata.Roles[SnapshotRoleName].KeyIDs)
+
+	// Removing the last key disables the snapshot role
+	rootMetadata, err = DeleteSnapshotKey(rootMetadata, snapshotKey2.KeyID)
+	assert.Nil(t, err)
+	assert.NotContains(t, rootMetadata.Roles, SnapshotRoleName)
+}
+
+func TestStateVerifySnapshot(t *testing.T) {
+	rootSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(rootKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+	snapshotSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(targets1KeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+	snapshotKey, err := tuf.LoadKeyFromBytes(targets1PubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	state := createTestStateWithPolicy(t)
+
+	// No snapshot role, nothing to verify
+	assert.Nil(t, state.VerifySnapshot(testCtx))
+
+	rootMetadata, err := state.GetRootMetadata()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootMetadata, err = AddSnapshotKey(rootMetadata, snapshotKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootEnv, err := dsse.CreateEnvelope(rootMetadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	state.RootEnvelope, err = dsse.SignEnvelope(testCtx, rootEnv, rootSigner)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = state.VerifySnapshot(testCtx)
+	assert.ErrorIs(t, err, ErrSnapshotOutdated)
+
+	snapshotMetadata, err := state.GenerateSnapshotMetadata()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 1, snapshotMetadata.Version)
+	assert.Contains(t, snapshotMetadata.Meta, "targets.json")
+
+	snapshotEnv, err := dsse.CreateEnvelope(snapshotMetadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("unsigned snapshot", func(t *testing.T) {
+		state.SnapshotEnvelope = snapshotEnv
+		err := state.VerifySnapshot(testCtx)
+		assert.ErrorIs(t, err, ErrVerifierConditionsUnmet)
+	})
+
+	t.Run("signed snapshot", func(t *testing.T) {
+		env, err := dsse.SignEnvelope(testCtx, snapshotEnv, snapshotSigner)
+		if err != nil {
+			t.Fatal(err)
+		}
+		state.SnapshotEnvelope = env
+
+		assert.Nil(t, state.VerifySnapshot(testCtx))
+		assert.Nil(t, state.Verify(testCtx))
+	})
+
+	t.Run("mixed policy files", func(t *testing.T) {
+		targetsMetadata, err := state.GetTargetsMetadata(TargetsRoleName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsMetadata, err = RemoveDelegation(targetsMetadata, "protect-main")
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsMetadata.SetVersion(targetsMetadata.Version + 1)
+
+		targetsEnv, err := dsse.CreateEnvelope(targetsMetadata)
+		if err != nil {
+			t.Fatal(err)
+		}
+		state.TargetsEnvelope, err = dsse.SignEnvelope(testCtx, targetsEnv, rootSigner)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = state.Verify(testCtx)
+		assert.ErrorIs(t, err, ErrSnapshotOutdated)
+	})
+}
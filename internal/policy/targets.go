@@ -4,6 +4,12 @@ package policy
 
 import (
 	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/gittuf/gittuf/internal/tuf"
@@ -11,7 +17,53 @@ import (
 
 const AllowRuleName = "gittuf-allow-rule"
 
-var ErrCannotManipulateAllowRule = errors.New("cannot change in-built gittuf-allow-rule")
+const (
+	// MergeStrategyAllCommits verifies every commit introduced by an update,
+	// including the commits brought in by merges. This is the default.
+	MergeStrategyAllCommits = "all-commits"
+
+	// MergeStrategyFirstParent verifies the commits along the first parent
+	// history of an update. Each commit is verified for the changes it makes
+	// to its first parent, so merge commits are responsible for the changes
+	// they bring in.
+	MergeStrategyFirstParent = "first-parent"
+
+	// MergeStrategyMergeCommitter verifies only the merge commit when an
+	// update's target is a merge, for all the changes made by the update.
+	MergeStrategyMergeCommitter = "merge-committer"
+
+	// MergeStrategyReviewAttestation verifies merges like
+	// MergeStrategyMergeCommitter, and additionally requires a reference
+	// authorization attestation for the update that meets the rule's threshold
+	// on its own.
+	MergeStrategyReviewAttestation = "review-attestation"
+)
+
+const (
+	// RuleEffectAllow authorizes the rule's principals to make changes to
+	// the namespaces it protects. This is the default.
+	RuleEffectAllow = "allow"
+
+	// RuleEffectDeny prevents changes to the namespaces the rule protects by
+	// anyone other than the rule's principals, who are the exceptions to the
+	// rule. Deny rules take precedence over allow rules.
+	RuleEffectDeny = "deny"
+)
+
+var (
+	ErrCannotManipulateAllowRule   = errors.New("cannot change in-built gittuf-allow-rule")
+	ErrUnknownMergeStrategy        = errors.New("unknown merge strategy")
+	ErrUnknownRuleEffect           = errors.New("unknown rule effect")
+	ErrIncompleteRuleOrder         = errors.New("rule order must include every rule in the policy file")
+	ErrPersonNotFound              = errors.New("person not found in policy")
+	ErrPersonInUse                 = errors.New("person is authorized by one or more rules")
+	ErrPersonHasNoKeys             = errors.New("person must have at least one key")
+	ErrTeamNotFound                = errors.New("team not found in policy")
+	ErrTeamInUse                   = errors.New("team is authorized by one or more rules")
+	ErrInvalidSubmoduleURLPattern  = errors.New("invalid submodule URL pattern")
+	ErrInvalidCommitMessagePattern = errors.New("invalid commit message pattern")
+	ErrInvalidFileRestriction      = errors.New("invalid file content restriction")
+)
 
 // InitializeTargetsMetadata creates a new instance of TargetsMetadata.
 func InitializeTargetsMetadata() *tuf.TargetsMetadata {
@@ -58,7 +110,25 @@ func UpdateDelegation(targetsMetadata *tuf.TargetsMetadata, ruleName string, aut
 		return nil, ErrCannotManipulateAllowRule
 	}
 
-	if len(authorizedKeys) < threshold {
+	// People and teams authorized by the rule are retained, and count
+	// towards the threshold
+	updatedRule := tuf.Delegation{Role: tuf.Role{KeyIDs: []string{}}}
+	for _, key := range authorizedKeys {
+		updatedRule.KeyIDs = append(updatedRule.KeyIDs, key.KeyID)
+	}
+	for _, delegation := range targetsMetadata.Delegations.Roles {
+		if delegation.Name == ruleName {
+			updatedRule.PersonIDs = delegation.PersonIDs
+			updatedRule.TeamIDs = delegation.TeamIDs
+			break
+		}
+	}
+
+	principals := map[string]bool{}
+	for _, authorized := range resolvePrincipals(updatedRule, targetsMetadata.Delegations.People, targetsMetadata.Delegations.Teams).keys {
+		principals[authorized.principalID] = true
+	}
+	if len(principals) < threshold {
 		return nil, ErrCannotMeetThreshold
 	}
 
@@ -97,6 +167,599 @@ func UpdateDelegation(targetsMetadata *tuf.TargetsMetadata, ruleName string, aut
 	return targetsMetadata, nil
 }
 
+// SetMergeStrategy sets the merge strategy used to verify the Git references
+// protected by the specified rule in TargetsMetadata.
+func SetMergeStrategy(targetsMetadata *tuf.TargetsMetadata, ruleName, mergeStrategy string) (*tuf.TargetsMetadata, error) {
+	if ruleName == AllowRuleName {
+		return nil, ErrCannotManipulateAllowRule
+	}
+
+	switch mergeStrategy {
+	case MergeStrategyAllCommits, MergeStrategyFirstParent, MergeStrategyMergeCommitter, MergeStrategyReviewAttestation:
+	default:
+		return nil, fmt.Errorf("%w: '%s'", ErrUnknownMergeStrategy, mergeStrategy)
+	}
+
+	for i, delegation := range targetsMetadata.Delegations.Roles {
+		if delegation.Name != ruleName {
+			continue
+		}
+
+		if mergeStrategy == MergeStrategyAllCommits {
+			// This is the default, so we don't record it explicitly
+			mergeStrategy = ""
+		}
+		targetsMetadata.Delegations.Roles[i].MergeStrategy = mergeStrategy
+		return targetsMetadata, nil
+	}
+
+	return nil, ErrDelegationNotFound
+}
+
+// SetRuleEffect sets whether the specified rule in TargetsMetadata allows or
+// denies changes to the namespaces it protects.
+func SetRuleEffect(targetsMetadata *tuf.TargetsMetadata, ruleName, effect string) (*tuf.TargetsMetadata, error) {
+	if ruleName == AllowRuleName {
+		return nil, ErrCannotManipulateAllowRule
+	}
+
+	switch effect {
+	case RuleEffectAllow, RuleEffectDeny:
+	default:
+		return nil, fmt.Errorf("%w: '%s'", ErrUnknownRuleEffect, effect)
+	}
+
+	for i, delegation := range targetsMetadata.Delegations.Roles {
+		if delegation.Name != ruleName {
+			continue
+		}
+
+		if effect == RuleEffectAllow {
+			// This is the default, so we don't record it explicitly
+			effect = ""
+		}
+		targetsMetadata.Delegations.Roles[i].Effect = effect
+		return targetsMetadata, nil
+	}
+
+	return nil, ErrDelegationNotFound
+}
+
+// SetRulePriority sets the priority of the specified rule in TargetsMetadata.
+// Rules with a higher priority are evaluated before other rules in the same
+// metadata file.
+func SetRulePriority(targetsMetadata *tuf.TargetsMetadata, ruleName string, priority int) (*tuf.TargetsMetadata, error) {
+	if ruleName == AllowRuleName {
+		return nil, ErrCannotManipulateAllowRule
+	}
+
+	for i, delegation := range targetsMetadata.Delegations.Roles {
+		if delegation.Name != ruleName {
+			continue
+		}
+
+		targetsMetadata.Delegations.Roles[i].Priority = priority
+		return targetsMetadata, nil
+	}
+
+	return nil, ErrDelegationNotFound
+}
+
+// ReorderRules changes the order of the rules in TargetsMetadata to match
+// ruleNames, which must include every rule in the metadata exactly once. The
+// in-built allow rule is always evaluated last, and must not be included.
+func ReorderRules(targetsMetadata *tuf.TargetsMetadata, ruleNames []string) (*tuf.TargetsMetadata, error) {
+	delegations := map[string]tuf.Delegation{}
+	hasAllowRule := false
+	for _, delegation := range targetsMetadata.Delegations.Roles {
+		if delegation.Name == AllowRuleName {
+			hasAllowRule = true
+			continue
+		}
+		delegations[delegation.Name] = delegation
+	}
+
+	reordered := make([]tuf.Delegation, 0, len(ruleNames)+1)
+	seen := map[string]bool{}
+	for _, ruleName := range ruleNames {
+		if ruleName == AllowRuleName {
+			return nil, ErrCannotManipulateAllowRule
+		}
+		if seen[ruleName] {
+			return nil, fmt.Errorf("%w: '%s'", ErrDuplicatedRuleName, ruleName)
+		}
+		seen[ruleName] = true
+
+		delegation, has := delegations[ruleName]
+		if !has {
+			return nil, fmt.Errorf("%w: '%s'", ErrDelegationNotFound, ruleName)
+		}
+		reordered = append(reordered, delegation)
+	}
+	if len(reordered) != len(delegations) {
+		return nil, ErrIncompleteRuleOrder
+	}
+
+	if hasAllowRule {
+		reordered = append(reordered, AllowRule())
+	}
+	targetsMetadata.Delegations.Roles = reordered
+	return targetsMetadata, nil
+}
+
+// orderDelegations returns delegations in the order they are evaluated. The
+// delegations are sorted by priority, and delegations with the same priority
+// retain their relative order. The in-built allow rule is always last.
+func orderDelegations(delegations []tuf.Delegation) []tuf.Delegation {
+	ordered := make([]tuf.Delegation, 0, len(delegations))
+	var allowRule *tuf.Delegation
+	for i, delegation := range delegations {
+		if delegation.Name == AllowRuleName {
+			allowRule = &delegations[i]
+			continue
+		}
+		ordered = append(ordered, delegation)
+	}
+
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].Priority > ordered[j].Priority
+	})
+
+	if allowRule != nil {
+		ordered = append(ordered, *allowRule)
+	}
+	return ordered
+}
+
+// SetForcePushAuthorizers sets the keys that may authorize rewriting the
+// history of the Git references protected by the specified rule in
+// TargetsMetadata. Passing no keys disallows history rewrites for the rule.
+func SetForcePushAuthorizers(targetsMetadata *tuf.TargetsMetadata, ruleName string, authorizedKeys []*tuf.Key) (*tuf.TargetsMetadata, error) {
+	if ruleName == AllowRuleName {
+		return nil, ErrCannotManipulateAllowRule
+	}
+
+	for i, delegation := range targetsMetadata.Delegations.Roles {
+		if delegation.Name != ruleName {
+			continue
+		}
+
+		var authorizedKeyIDs []string
+		for _, key := range authorizedKeys {
+			targetsMetadata.Delegations.AddKey(key)
+
+			authorizedKeyIDs = append(authorizedKeyIDs, key.KeyID)
+		}
+		targetsMetadata.Delegations.Roles[i].ForcePushKeyIDs = authorizedKeyIDs
+		return targetsMetadata, nil
+	}
+
+	return nil, ErrDelegationNotFound
+}
+
+// SetDeletionAuthorizers sets the keys that may delete the Git references
+// protected by the specified rule in TargetsMetadata. Passing no keys disallows
+// deleting the protected references.
+func SetDeletionAuthorizers(targetsMetadata *tuf.TargetsMetadata, ruleName string, authorizedKeys []*tuf.Key) (*tuf.TargetsMetadata, error) {
+	if ruleName == AllowRuleName {
+		return nil, ErrCannotManipulateAllowRule
+	}
+
+	for i, delegation := range targetsMetadata.Delegations.Roles {
+		if delegation.Name != ruleName {
+			continue
+		}
+
+		var authorizedKeyIDs []string
+		for _, key := range authorizedKeys {
+			targetsMetadata.Delegations.AddKey(key)
+
+			authorizedKeyIDs = append(authorizedKeyIDs, key.KeyID)
+		}
+		targetsMetadata.Delegations.Roles[i].DeletionKeyIDs = authorizedKeyIDs
+		return targetsMetadata, nil
+	}
+
+	return nil, ErrDelegationNotFound
+}
+
+// SetSubmoduleConstraints sets the URLs that submodules at the paths
+// protected by the specified rule may point to, and whether the commits they
+// pin must verify against the submodules' own RSLs. Passing no URLs permits
+// any URL.
+func SetSubmoduleConstraints(targetsMetadata *tuf.TargetsMetadata, ruleName string, allowedURLs []string, requireVerification bool) (*tuf.TargetsMetadata, error) {
+	if ruleName == AllowRuleName {
+		return nil, ErrCannotManipulateAllowRule
+	}
+
+	for _, allowedURL := range allowedURLs {
+		if _, err := path.Match(allowedURL, ""); err != nil {
+			return nil, fmt.Errorf("%w: '%s'", ErrInvalidSubmoduleURLPattern, allowedURL)
+		}
+	}
+
+	for i, delegation := range targetsMetadata.Delegations.Roles {
+		if delegation.Name != ruleName {
+			continue
+		}
+
+		targetsMetadata.Delegations.Roles[i].SubmoduleURLs = allowedURLs
+		targetsMetadata.Delegations.Roles[i].RequireSubmoduleVerification = requireVerification
+		return targetsMetadata, nil
+	}
+
+	return nil, ErrDelegationNotFound
+}
+
+// SetRegoPolicy sets the Rego module that changes to the Git references
+// protected by the specified rule must meet. The module must declare the
+// package "gittuf", and a change is rejected if the module's deny rule
+// produces any messages for it. Passing an empty module removes the rule's
+// Rego policy.
+func SetRegoPolicy(targetsMetadata *tuf.TargetsMetadata, ruleName, module string) (*tuf.TargetsMetadata, error) {
+	if ruleName == AllowRuleName {
+		return nil, ErrCannotManipulateAllowRule
+	}
+
+	if module != "" {
+		if err := ValidateRegoPolicy(module); err != nil {
+			return nil, err
+		}
+	}
+
+	for i, delegation := range targetsMetadata.Delegations.Roles {
+		if delegation.Name != ruleName {
+			continue
+		}
+
+		targetsMetadata.Delegations.Roles[i].RegoPolicy = module
+		return targetsMetadata, nil
+	}
+
+	return nil, ErrDelegationNotFound
+}
+
+// SetRuleConditions sets the CEL expressions that changes to the Git
+// references protected by the specified rule must meet. Each condition must
+// evaluate to true for a change to be accepted. Passing no conditions removes
+// the rule's conditions.
+func SetRuleConditions(targetsMetadata *tuf.TargetsMetadata, ruleName string, conditions []string) (*tuf.TargetsMetadata, error) {
+	if ruleName == AllowRuleName {
+		return nil, ErrCannotManipulateAllowRule
+	}
+
+	for _, condition := range conditions {
+		if err := ValidateRuleCondition(condition); err != nil {
+			return nil, err
+		}
+	}
+
+	for i, delegation := range targetsMetadata.Delegations.Roles {
+		if delegation.Name != ruleName {
+			continue
+		}
+
+		targetsMetadata.Delegations.Roles[i].Conditions = conditions
+		return targetsMetadata, nil
+	}
+
+	return nil, ErrDelegationNotFound
+}
+
+// SetCommitMessageRules sets the constraints on the messages of commits added
+// to the Git references protected by the specified rule: whether they must be
+// signed off by the commit's author, whether they must follow the Conventional
+// Commits specification, and a regular expression they must match. Passing
+// false for both and an empty pattern removes the constraints.
+func SetCommitMessageRules(targetsMetadata *tuf.TargetsMetadata, ruleName string, requireSignoff, requireConventionalCommits bool, pattern string) (*tuf.TargetsMetadata, error) {
+	if ruleName == AllowRuleName {
+		return nil, ErrCannotManipulateAllowRule
+	}
+
+	if pattern != "" {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidCommitMessagePattern, err)
+		}
+	}
+
+	for i, delegation := range targetsMetadata.Delegations.Roles {
+		if delegation.Name != ruleName {
+			continue
+		}
+
+		targetsMetadata.Delegations.Roles[i].RequireSignoff = requireSignoff
+		targetsMetadata.Delegations.Roles[i].RequireConventionalCommits = requireConventionalCommits
+		targetsMetadata.Delegations.Roles[i].CommitMessagePattern = pattern
+		return targetsMetadata, nil
+	}
+
+	return nil, ErrDelegationNotFound
+}
+
+// SetFileRestrictions sets the restrictions on the contents of files added or
+// modified at the paths protected by the specified rule: their maximum size in
+// bytes, the extensions they may not have, and whether they may be binary
+// files. Extensions are normalized to lowercase with a leading dot. Passing a
+// maximum size of zero, no extensions, and false removes the restrictions.
+func SetFileRestrictions(targetsMetadata *tuf.TargetsMetadata, ruleName string, maxFileSize int64, forbiddenExtensions []string, forbidBinaryFiles bool) (*tuf.TargetsMetadata, error) {
+	if ruleName == AllowRuleName {
+		return nil, ErrCannotManipulateAllowRule
+	}
+
+	if maxFileSize < 0 {
+		return nil, fmt.Errorf("%w: maximum file size must not be negative", ErrInvalidFileRestriction)
+	}
+
+	var extensions []string
+	for _, extension := range forbiddenExtensions {
+		normalized := strings.ToLower(strings.TrimPrefix(extension, "."))
+		if normalized == "" || strings.ContainsAny(normalized, "/*?[") {
+			return nil, fmt.Errorf("%w: invalid extension '%s'", ErrInvalidFileRestriction, extension)
+		}
+		normalized = "." + normalized
+		if !slices.Contains(extensions, normalized) {
+			extensions = append(extensions, normalized)
+		}
+	}
+
+	for i, delegation := range targetsMetadata.Delegations.Roles {
+		if delegation.Name != ruleName {
+			continue
+		}
+
+		targetsMetadata.Delegations.Roles[i].MaxFileSize = maxFileSize
+		targetsMetadata.Delegations.Roles[i].ForbiddenExtensions = extensions
+		targetsMetadata.Delegations.Roles[i].ForbidBinaryFiles = forbidBinaryFiles
+		return targetsMetadata, nil
+	}
+
+	return nil, ErrDelegationNotFound
+}
+
+// AddPerson adds a person with the specified keys to TargetsMetadata. If the
+// person already exists, their keys are replaced with the specified keys,
+// which allows rotating a person's keys without changing the rules that
+// authorize them.
+func AddPerson(targetsMetadata *tuf.TargetsMetadata, personID string, keys []*tuf.Key) (*tuf.TargetsMetadata, error) {
+	if len(keys) == 0 {
+		return nil, ErrPersonHasNoKeys
+	}
+
+	keyIDs := []string{}
+	for _, key := range keys {
+		targetsMetadata.Delegations.AddKey(key)
+
+		keyIDs = append(keyIDs, key.KeyID)
+	}
+
+	targetsMetadata.Delegations.AddPerson(&tuf.Person{PersonID: personID, KeyIDs: keyIDs})
+
+	return targetsMetadata, nil
+}
+
+// RemovePerson removes a person from TargetsMetadata. A person cannot be
+// removed while a rule authorizes them or while they are a member of a team.
+func RemovePerson(targetsMetadata *tuf.TargetsMetadata, personID string) (*tuf.TargetsMetadata, error) {
+	if _, has := targetsMetadata.Delegations.People[personID]; !has {
+		return nil, ErrPersonNotFound
+	}
+
+	for _, delegation := range targetsMetadata.Delegations.Roles {
+		if slices.Contains(delegation.PersonIDs, personID) {
+			return nil, fmt.Errorf("%w: '%s'", ErrPersonInUse, delegation.Name)
+		}
+	}
+	for _, team := range targetsMetadata.Delegations.Teams {
+		if slices.Contains(team.PersonIDs, personID) {
+			return nil, fmt.Errorf("%w: person is a member of team '%s'", ErrPersonInUse, team.TeamID)
+		}
+	}
+
+	delete(targetsMetadata.Delegations.People, personID)
+
+	return targetsMetadata, nil
+}
+
+// SetAuthorizedPersons sets the people authorized by the specified rule in
+// TargetsMetadata, in addition to the rule's keys. The people must already be
+// recorded in TargetsMetadata. Passing no people removes all people from the
+// rule.
+func SetAuthorizedPersons(targetsMetadata *tuf.TargetsMetadata, ruleName string, personIDs []string) (*tuf.TargetsMetadata, error) {
+	if ruleName == AllowRuleName {
+		return nil, ErrCannotManipulateAllowRule
+	}
+
+	for _, personID := range personIDs {
+		if _, has := targetsMetadata.Delegations.People[personID]; !has {
+			return nil, fmt.Errorf("%w: '%s'", ErrPersonNotFound, personID)
+		}
+	}
+
+	for i, delegation := range targetsMetadata.Delegations.Roles {
+		if delegation.Name != ruleName {
+			continue
+		}
+
+		if len(personIDs) == 0 {
+			personIDs = nil
+		}
+		targetsMetadata.Delegations.Roles[i].PersonIDs = personIDs
+		return targetsMetadata, nil
+	}
+
+	return nil, ErrDelegationNotFound
+}
+
+// AddTeamMembers adds the specified keys and people to a team in
+// TargetsMetadata, creating the team if it does not exist. The people must
+// already be recorded in TargetsMetadata. Rules that authorize the team trust
+// the new members without being changed.
+func AddTeamMembers(targetsMetadata *tuf.TargetsMetadata, teamID string, keys []*tuf.Key, personIDs []string) (*tuf.TargetsMetadata, error) {
+	for _, personID := range personIDs {
+		if _, has := targetsMetadata.Delegations.People[personID]; !has {
+			return nil, fmt.Errorf("%w: '%s'", ErrPersonNotFound, personID)
+		}
+	}
+
+	team, has := targetsMetadata.Delegations.Teams[teamID]
+	if !has {
+		team = &tuf.Team{TeamID: teamID}
+	}
+
+	for _, key := range keys {
+		targetsMetadata.Delegations.AddKey(key)
+
+		if !slices.Contains(team.KeyIDs, key.KeyID) {
+			team.KeyIDs = append(team.KeyIDs, key.KeyID)
+		}
+	}
+	for _, personID := range personIDs {
+		if !slices.Contains(team.PersonIDs, personID) {
+			team.PersonIDs = append(team.PersonIDs, personID)
+		}
+	}
+
+	targetsMetadata.Delegations.AddTeam(team)
+
+	return targetsMetadata, nil
+}
+
+// RemoveTeamMembers removes the specified keys and people from a team in
+// TargetsMetadata. The team is retained even if it has no remaining members.
+func RemoveTeamMembers(targetsMetadata *tuf.TargetsMetadata, teamID string, keyIDs []string, personIDs []string) (*tuf.TargetsMetadata, error) {
+	team, has := targetsMetadata.Delegations.Teams[teamID]
+	if !has {
+		return nil, ErrTeamNotFound
+	}
+
+	team.KeyIDs = slices.DeleteFunc(team.KeyIDs, func(keyID string) bool {
+		return slices.Contains(keyIDs, keyID)
+	})
+	team.PersonIDs = slices.DeleteFunc(team.PersonIDs, func(personID string) bool {
+		return slices.Contains(personIDs, personID)
+	})
+
+	return targetsMetadata, nil
+}
+
+// RemoveTeam removes a team from TargetsMetadata. A team cannot be removed
+// while a rule authorizes it.
+func RemoveTeam(targetsMetadata *tuf.TargetsMetadata, teamID string) (*tuf.TargetsMetadata, error) {
+	if _, has := targetsMetadata.Delegations.Teams[teamID]; !has {
+		return nil, ErrTeamNotFound
+	}
+
+	for _, delegation := range targetsMetadata.Delegations.Roles {
+		if slices.Contains(delegation.TeamIDs, teamID) {
+			return nil, fmt.Errorf("%w: '%s'", ErrTeamInUse, delegation.Name)
+		}
+	}
+
+	delete(targetsMetadata.Delegations.Teams, teamID)
+
+	return targetsMetadata, nil
+}
+
+// SetAuthorizedTeams sets the teams authorized by the specified rule in
+// TargetsMetadata. The teams must already be recorded in TargetsMetadata.
+// Passing no teams removes all teams from the rule.
+func SetAuthorizedTeams(targetsMetadata *tuf.TargetsMetadata, ruleName string, teamIDs []string) (*tuf.TargetsMetadata, error) {
+	if ruleName == AllowRuleName {
+		return nil, ErrCannotManipulateAllowRule
+	}
+
+	for _, teamID := range teamIDs {
+		if _, has := targetsMetadata.Delegations.Teams[teamID]; !has {
+			return nil, fmt.Errorf("%w: '%s'", ErrTeamNotFound, teamID)
+		}
+	}
+
+	for i, delegation := range targetsMetadata.Delegations.Roles {
+		if delegation.Name != ruleName {
+			continue
+		}
+
+		if len(teamIDs) == 0 {
+			teamIDs = nil
+		}
+		targetsMetadata.Delegations.Roles[i].TeamIDs = teamIDs
+		return targetsMetadata, nil
+	}
+
+	return nil, ErrDelegationNotFound
+}
+
+// SyncTeamPeople updates the people in a team in TargetsMetadata to match
+// members, which maps the person ID of each member to their keys. The team and
+// its people are created as needed, and the keys of existing people are
+// replaced. Members without keys are not added to the team as they cannot
+// sign. People removed from the team and the team's own keys are retained in
+// TargetsMetadata. The returned TeamDiff records the changes made.
+func SyncTeamPeople(targetsMetadata *tuf.TargetsMetadata, teamID string, members map[string][]*tuf.Key) (*tuf.TargetsMetadata, *TeamDiff, error) {
+	diff := &TeamDiff{
+		TeamID:           teamID,
+		Change:           ChangeModified,
+		People:           []*PersonDiff{},
+		SkippedPersonIDs: []string{},
+	}
+
+	oldPersonIDs := []string{}
+	team, has := targetsMetadata.Delegations.Teams[teamID]
+	if has {
+		oldPersonIDs = team.PersonIDs
+	} else {
+		diff.Change = ChangeAdded
+		team = &tuf.Team{TeamID: teamID}
+	}
+
+	personIDs := mapKeys(members)
+	sort.Strings(personIDs)
+
+	newPersonIDs := []string{}
+	for _, personID := range personIDs {
+		keys := members[personID]
+		if len(keys) == 0 {
+			diff.SkippedPersonIDs = append(diff.SkippedPersonIDs, personID)
+			continue
+		}
+		newPersonIDs = append(newPersonIDs, personID)
+
+		oldKeyIDs := []string{}
+		person, has := targetsMetadata.Delegations.People[personID]
+		if has {
+			oldKeyIDs = person.KeyIDs
+		}
+
+		newKeyIDs := []string{}
+		for _, key := range keys {
+			newKeyIDs = append(newKeyIDs, key.KeyID)
+		}
+
+		addedKeyIDs, removedKeyIDs := diffStrings(oldKeyIDs, newKeyIDs)
+		if change, changed := getChangeType(has, true, len(addedKeyIDs) > 0 || len(removedKeyIDs) > 0); changed {
+			diff.People = append(diff.People, &PersonDiff{
+				PersonID:      personID,
+				Change:        change,
+				AddedKeyIDs:   addedKeyIDs,
+				RemovedKeyIDs: removedKeyIDs,
+			})
+		}
+
+		var err error
+		targetsMetadata, err = AddPerson(targetsMetadata, personID, keys)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	diff.AddedPersonIDs, diff.RemovedPersonIDs = diffStrings(oldPersonIDs, newPersonIDs)
+
+	team.PersonIDs = newPersonIDs
+	targetsMetadata.Delegations.AddTeam(team)
+
+	return targetsMetadata, diff, nil
+}
+
 // RemoveDelegation deletes a delegation entry from TargetsMetadata.
 func RemoveDelegation(targetsMetadata *tuf.TargetsMetadata, ruleName string) (*tuf.TargetsMetadata, error) {
 	if ruleName == AllowRuleName {
@@ -125,6 +788,34 @@ func AddKeyToTargets(targetsMetadata *tuf.TargetsMetadata, authorizedKeys []*tuf
 	return targetsMetadata, nil
 }
 
+// RotateTargetsMetadataKey replaces the key matching oldKeyID with newKey in
+// every rule in targetsMetadata that trusts it. It returns true if
+// targetsMetadata was changed.
+func RotateTargetsMetadataKey(targetsMetadata *tuf.TargetsMetadata, oldKeyID string, newKey *tuf.Key) (*tuf.TargetsMetadata, bool) {
+	if targetsMetadata.Delegations == nil {
+		return targetsMetadata, false
+	}
+	if _, has := targetsMetadata.Delegations.Keys[oldKeyID]; !has {
+		return targetsMetadata, false
+	}
+
+	for i, delegation := range targetsMetadata.Delegations.Roles {
+		delegation.KeyIDs = replaceKeyID(delegation.KeyIDs, oldKeyID, newKey.KeyID)
+		targetsMetadata.Delegations.Roles[i] = delegation
+	}
+	for _, person := range targetsMetadata.Delegations.People {
+		person.KeyIDs = replaceKeyID(person.KeyIDs, oldKeyID, newKey.KeyID)
+	}
+	for _, team := range targetsMetadata.Delegations.Teams {
+		team.KeyIDs = replaceKeyID(team.KeyIDs, oldKeyID, newKey.KeyID)
+	}
+
+	delete(targetsMetadata.Delegations.Keys, oldKeyID)
+	targetsMetadata.Delegations.AddKey(newKey)
+
+	return targetsMetadata, true
+}
+
 // AllowRule returns the default, last rule for all policy files.
 func AllowRule() tuf.Delegation {
 	return tuf.Delegation{
@@ -108,6 +108,704 @@ func TestRemoveDelegation(t *testing.T) {
 	assert.Contains(t, targetsMetadata.Delegations.Keys, key.KeyID)
 }
 
+func TestSetMergeStrategy(t *testing.T) {
+	targetsMetadata := InitializeTargetsMetadata()
+
+	key, err := tuf.LoadKeyFromBytes(targets1PubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targetsMetadata, err = AddDelegation(targetsMetadata, "test-rule", []*tuf.Key{key}, []string{"git:refs/heads/main"}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targetsMetadata, err = SetMergeStrategy(targetsMetadata, "test-rule", MergeStrategyFirstParent)
+	assert.Nil(t, err)
+	assert.Equal(t, MergeStrategyFirstParent, targetsMetadata.Delegations.Roles[0].MergeStrategy)
+
+	// The merge strategy is retained when the rule is updated
+	targetsMetadata, err = UpdateDelegation(targetsMetadata, "test-rule", []*tuf.Key{key}, []string{"git:refs/heads/*"}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, MergeStrategyFirstParent, targetsMetadata.Delegations.Roles[0].MergeStrategy)
+
+	// The default is not recorded
+	targetsMetadata, err = SetMergeStrategy(targetsMetadata, "test-rule", MergeStrategyAllCommits)
+	assert.Nil(t, err)
+	assert.Empty(t, targetsMetadata.Delegations.Roles[0].MergeStrategy)
+
+	_, err = SetMergeStrategy(targetsMetadata, "test-rule", "squash")
+	assert.ErrorIs(t, err, ErrUnknownMergeStrategy)
+
+	_, err = SetMergeStrategy(targetsMetadata, "unknown-rule", MergeStrategyFirstParent)
+	assert.ErrorIs(t, err, ErrDelegationNotFound)
+
+	_, err = SetMergeStrategy(targetsMetadata, AllowRuleName, MergeStrategyFirstParent)
+	assert.ErrorIs(t, err, ErrCannotManipulateAllowRule)
+}
+
+func TestSetRuleEffect(t *testing.T) {
+	targetsMetadata := InitializeTargetsMetadata()
+
+	key, err := tuf.LoadKeyFromBytes(targets1PubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targetsMetadata, err = AddDelegation(targetsMetadata, "test-rule", []*tuf.Key{key}, []string{"file:.github/workflows/*"}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targetsMetadata, err = SetRuleEffect(targetsMetadata, "test-rule", RuleEffectDeny)
+	assert.Nil(t, err)
+	assert.Equal(t, RuleEffectDeny, targetsMetadata.Delegations.Roles[0].Effect)
+
+	// The effect is retained when the rule is updated
+	targetsMetadata, err = UpdateDelegation(targetsMetadata, "test-rule", []*tuf.Key{key}, []string{"file:.github/*"}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, RuleEffectDeny, targetsMetadata.Delegations.Roles[0].Effect)
+
+	// The default is not recorded
+	targetsMetadata, err = SetRuleEffect(targetsMetadata, "test-rule", RuleEffectAllow)
+	assert.Nil(t, err)
+	assert.Empty(t, targetsMetadata.Delegations.Roles[0].Effect)
+
+	_, err = SetRuleEffect(targetsMetadata, "test-rule", "block")
+	assert.ErrorIs(t, err, ErrUnknownRuleEffect)
+
+	_, err = SetRuleEffect(targetsMetadata, "unknown-rule", RuleEffectDeny)
+	assert.ErrorIs(t, err, ErrDelegationNotFound)
+
+	_, err = SetRuleEffect(targetsMetadata, AllowRuleName, RuleEffectDeny)
+	assert.ErrorIs(t, err, ErrCannotManipulateAllowRule)
+}
+
+func TestSetRulePriority(t *testing.T) {
+	targetsMetadata := InitializeTargetsMetadata()
+
+	key, err := tuf.LoadKeyFromBytes(targets1PubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targetsMetadata, err = AddDelegation(targetsMetadata, "test-rule", []*tuf.Key{key}, []string{"file:*"}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targetsMetadata, err = SetRulePriority(targetsMetadata, "test-rule", 10)
+	assert.Nil(t, err)
+	assert.Equal(t, 10, targetsMetadata.Delegations.Roles[0].Priority)
+
+	_, err = SetRulePriority(targetsMetadata, "unknown-rule", 10)
+	assert.ErrorIs(t, err, ErrDelegationNotFound)
+
+	_, err = SetRulePriority(targetsMetadata, AllowRuleName, 10)
+	assert.ErrorIs(t, err, ErrCannotManipulateAllowRule)
+}
+
+func TestReorderRules(t *testing.T) {
+	key, err := tuf.LoadKeyFromBytes(targets1PubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	createTargetsMetadata := func(t *testing.T) *tuf.TargetsMetadata {
+		t.Helper()
+
+		targetsMetadata := InitializeTargetsMetadata()
+		for _, ruleName := range []string{"rule-1", "rule-2", "rule-3"} {
+			targetsMetadata, err = AddDelegation(targetsMetadata, ruleName, []*tuf.Key{key}, []string{"file:*"}, 1)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+		return targetsMetadata
+	}
+
+	tests := map[string]struct {
+		ruleNames     []string
+		expectedOrder []string
+		expectedError error
+	}{
+		"reorder all rules": {
+			ruleNames:     []string{"rule-3", "rule-1", "rule-2"},
+			expectedOrder: []string{"rule-3", "rule-1", "rule-2", AllowRuleName},
+		},
+		"missing rule": {
+			ruleNames:     []string{"rule-3", "rule-1"},
+			expectedError: ErrIncompleteRuleOrder,
+		},
+		"duplicated rule": {
+			ruleNames:     []string{"rule-3", "rule-1", "rule-1"},
+			expectedError: ErrDuplicatedRuleName,
+		},
+		"unknown rule": {
+			ruleNames:     []string{"rule-3", "rule-1", "rule-4"},
+			expectedError: ErrDelegationNotFound,
+		},
+		"allow rule": {
+			ruleNames:     []string{"rule-3", "rule-1", "rule-2", AllowRuleName},
+			expectedError: ErrCannotManipulateAllowRule,
+		},
+	}
+
+	for name, test := range tests {
+		targetsMetadata, err := ReorderRules(createTargetsMetadata(t), test.ruleNames)
+		if test.expectedError != nil {
+			assert.ErrorIs(t, err, test.expectedError, name)
+			continue
+		}
+
+		assert.Nil(t, err, name)
+		ruleNames := []string{}
+		for _, delegation := range targetsMetadata.Delegations.Roles {
+			ruleNames = append(ruleNames, delegation.Name)
+		}
+		assert.Equal(t, test.expectedOrder, ruleNames, name)
+	}
+}
+
+func TestOrderDelegations(t *testing.T) {
+	delegations := []tuf.Delegation{
+		{Name: "rule-1"},
+		{Name: "rule-2", Priority: 5},
+		{Name: "rule-3"},
+		{Name: "rule-4", Priority: 10},
+		{Name: "rule-5", Priority: 5},
+		AllowRule(),
+	}
+
+	ruleNames := []string{}
+	for _, delegation := range orderDelegations(delegations) {
+		ruleNames = append(ruleNames, delegation.Name)
+	}
+	assert.Equal(t, []string{"rule-4", "rule-2", "rule-5", "rule-1", "rule-3", AllowRuleName}, ruleNames)
+}
+
+func TestSetForcePushAuthorizers(t *testing.T) {
+	targetsMetadata := InitializeTargetsMetadata()
+
+	key, err := tuf.LoadKeyFromBytes(targets1PubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gpgKey, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targetsMetadata, err = AddDelegation(targetsMetadata, "test-rule", []*tuf.Key{key}, []string{"git:refs/heads/main"}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targetsMetadata, err = SetForcePushAuthorizers(targetsMetadata, "test-rule", []*tuf.Key{gpgKey})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{gpgKey.KeyID}, targetsMetadata.Delegations.Roles[0].ForcePushKeyIDs)
+	assert.Equal(t, gpgKey, targetsMetadata.Delegations.Keys[gpgKey.KeyID])
+
+	// Force push authorizers are retained when the rule is updated
+	targetsMetadata, err = UpdateDelegation(targetsMetadata, "test-rule", []*tuf.Key{key}, []string{"git:refs/heads/*"}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{gpgKey.KeyID}, targetsMetadata.Delegations.Roles[0].ForcePushKeyIDs)
+
+	targetsMetadata, err = SetForcePushAuthorizers(targetsMetadata, "test-rule", nil)
+	assert.Nil(t, err)
+	assert.Empty(t, targetsMetadata.Delegations.Roles[0].ForcePushKeyIDs)
+
+	_, err = SetForcePushAuthorizers(targetsMetadata, "unknown-rule", []*tuf.Key{gpgKey})
+	assert.ErrorIs(t, err, ErrDelegationNotFound)
+
+	_, err = SetForcePushAuthorizers(targetsMetadata, AllowRuleName, []*tuf.Key{gpgKey})
+	assert.ErrorIs(t, err, ErrCannotManipulateAllowRule)
+}
+
+func TestSetDeletionAuthorizers(t *testing.T) {
+	targetsMetadata := InitializeTargetsMetadata()
+
+	key, err := tuf.LoadKeyFromBytes(targets1PubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gpgKey, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targetsMetadata, err = AddDelegation(targetsMetadata, "test-rule", []*tuf.Key{key}, []string{"git:refs/heads/main"}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targetsMetadata, err = SetDeletionAuthorizers(targetsMetadata, "test-rule", []*tuf.Key{gpgKey})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{gpgKey.KeyID}, targetsMetadata.Delegations.Roles[0].DeletionKeyIDs)
+	assert.Equal(t, gpgKey, targetsMetadata.Delegations.Keys[gpgKey.KeyID])
+
+	// Deletion authorizers are retained when the rule is updated
+	targetsMetadata, err = UpdateDelegation(targetsMetadata, "test-rule", []*tuf.Key{key}, []string{"git:refs/heads/*"}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{gpgKey.KeyID}, targetsMetadata.Delegations.Roles[0].DeletionKeyIDs)
+
+	targetsMetadata, err = SetDeletionAuthorizers(targetsMetadata, "test-rule", nil)
+	assert.Nil(t, err)
+	assert.Empty(t, targetsMetadata.Delegations.Roles[0].DeletionKeyIDs)
+
+	_, err = SetDeletionAuthorizers(targetsMetadata, "unknown-rule", []*tuf.Key{gpgKey})
+	assert.ErrorIs(t, err, ErrDelegationNotFound)
+
+	_, err = SetDeletionAuthorizers(targetsMetadata, AllowRuleName, []*tuf.Key{gpgKey})
+	assert.ErrorIs(t, err, ErrCannotManipulateAllowRule)
+}
+
+func TestSetSubmoduleConstraints(t *testing.T) {
+	targetsMetadata := InitializeTargetsMetadata()
+
+	key, err := tuf.LoadKeyFromBytes(targets1PubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targetsMetadata, err = AddDelegation(targetsMetadata, "test-rule", []*tuf.Key{key}, []string{"file:vendor/*"}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targetsMetadata, err = SetSubmoduleConstraints(targetsMetadata, "test-rule", []string{"https://github.com/gittuf/*"}, true)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"https://github.com/gittuf/*"}, targetsMetadata.Delegations.Roles[0].SubmoduleURLs)
+	assert.True(t, targetsMetadata.Delegations.Roles[0].RequireSubmoduleVerification)
+
+	// Submodule constraints are retained when the rule is updated
+	targetsMetadata, err = UpdateDelegation(targetsMetadata, "test-rule", []*tuf.Key{key}, []string{"file:third_party/*"}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{"https://github.com/gittuf/*"}, targetsMetadata.Delegations.Roles[0].SubmoduleURLs)
+	assert.True(t, targetsMetadata.Delegations.Roles[0].RequireSubmoduleVerification)
+
+	targetsMetadata, err = SetSubmoduleConstraints(targetsMetadata, "test-rule", nil, false)
+	assert.Nil(t, err)
+	assert.Empty(t, targetsMetadata.Delegations.Roles[0].SubmoduleURLs)
+	assert.False(t, targetsMetadata.Delegations.Roles[0].RequireSubmoduleVerification)
+
+	_, err = SetSubmoduleConstraints(targetsMetadata, "test-rule", []string{"https://github.com/[gittuf"}, false)
+	assert.ErrorIs(t, err, ErrInvalidSubmoduleURLPattern)
+
+	_, err = SetSubmoduleConstraints(targetsMetadata, "unknown-rule", nil, true)
+	assert.ErrorIs(t, err, ErrDelegationNotFound)
+
+	_, err = SetSubmoduleConstraints(targetsMetadata, AllowRuleName, nil, true)
+	assert.ErrorIs(t, err, ErrCannotManipulateAllowRule)
+}
+
+func TestSetRegoPolicy(t *testing.T) {
+	targetsMetadata := InitializeTargetsMetadata()
+
+	key, err := tuf.LoadKeyFromBytes(targets1PubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targetsMetadata, err = AddDelegation(targetsMetadata, "test-rule", []*tuf.Key{key}, []string{"git:refs/heads/main"}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targetsMetadata, err = SetRegoPolicy(targetsMetadata, "test-rule", testRegoPolicyRequireApproval)
+	assert.Nil(t, err)
+	assert.Equal(t, testRegoPolicyRequireApproval, targetsMetadata.Delegations.Roles[0].RegoPolicy)
+
+	// The Rego policy is retained when the rule is updated
+	targetsMetadata, err = UpdateDelegation(targetsMetadata, "test-rule", []*tuf.Key{key}, []string{"git:refs/heads/release"}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, testRegoPolicyRequireApproval, targetsMetadata.Delegations.Roles[0].RegoPolicy)
+
+	targetsMetadata, err = SetRegoPolicy(targetsMetadata, "test-rule", "")
+	assert.Nil(t, err)
+	assert.Empty(t, targetsMetadata.Delegations.Roles[0].RegoPolicy)
+
+	_, err = SetRegoPolicy(targetsMetadata, "test-rule", "package example\n")
+	assert.ErrorIs(t, err, ErrInvalidRegoPolicy)
+
+	_, err = SetRegoPolicy(targetsMetadata, "unknown-rule", testRegoPolicyRequireApproval)
+	assert.ErrorIs(t, err, ErrDelegationNotFound)
+
+	_, err = SetRegoPolicy(targetsMetadata, AllowRuleName, testRegoPolicyRequireApproval)
+	assert.ErrorIs(t, err, ErrCannotManipulateAllowRule)
+}
+
+func TestSetRuleConditions(t *testing.T) {
+	targetsMetadata := InitializeTargetsMetadata()
+
+	key, err := tuf.LoadKeyFromBytes(targets1PubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targetsMetadata, err = AddDelegation(targetsMetadata, "test-rule", []*tuf.Key{key}, []string{"git:refs/heads/main"}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	conditions := []string{"size(approvers) > 0", "commit.author.email.endsWith('@example.com')"}
+	targetsMetadata, err = SetRuleConditions(targetsMetadata, "test-rule", conditions)
+	assert.Nil(t, err)
+	assert.Equal(t, conditions, targetsMetadata.Delegations.Roles[0].Conditions)
+
+	// Conditions are retained when the rule is updated
+	targetsMetadata, err = UpdateDelegation(targetsMetadata, "test-rule", []*tuf.Key{key}, []string{"git:refs/heads/release"}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, conditions, targetsMetadata.Delegations.Roles[0].Conditions)
+
+	targetsMetadata, err = SetRuleConditions(targetsMetadata, "test-rule", nil)
+	assert.Nil(t, err)
+	assert.Empty(t, targetsMetadata.Delegations.Roles[0].Conditions)
+
+	_, err = SetRuleConditions(targetsMetadata, "test-rule", []string{"ref =="})
+	assert.ErrorIs(t, err, ErrInvalidRuleCondition)
+
+	_, err = SetRuleConditions(targetsMetadata, "unknown-rule", conditions)
+	assert.ErrorIs(t, err, ErrDelegationNotFound)
+
+	_, err = SetRuleConditions(targetsMetadata, AllowRuleName, conditions)
+	assert.ErrorIs(t, err, ErrCannotManipulateAllowRule)
+}
+
+func TestSetCommitMessageRules(t *testing.T) {
+	targetsMetadata := InitializeTargetsMetadata()
+
+	key, err := tuf.LoadKeyFromBytes(targets1PubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targetsMetadata, err = AddDelegation(targetsMetadata, "test-rule", []*tuf.Key{key}, []string{"git:refs/heads/main"}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targetsMetadata, err = SetCommitMessageRules(targetsMetadata, "test-rule", true, true, `#[0-9]+`)
+	assert.Nil(t, err)
+	assert.True(t, targetsMetadata.Delegations.Roles[0].RequireSignoff)
+	assert.True(t, targetsMetadata.Delegations.Roles[0].RequireConventionalCommits)
+	assert.Equal(t, `#[0-9]+`, targetsMetadata.Delegations.Roles[0].CommitMessagePattern)
+
+	targetsMetadata, err = SetCommitMessageRules(targetsMetadata, "test-rule", false, false, "")
+	assert.Nil(t, err)
+	assert.False(t, targetsMetadata.Delegations.Roles[0].RequireSignoff)
+	assert.False(t, targetsMetadata.Delegations.Roles[0].RequireConventionalCommits)
+	assert.Empty(t, targetsMetadata.Delegations.Roles[0].CommitMessagePattern)
+
+	_, err = SetCommitMessageRules(targetsMetadata, "test-rule", false, false, "[")
+	assert.ErrorIs(t, err, ErrInvalidCommitMessagePattern)
+
+	_, err = SetCommitMessageRules(targetsMetadata, "unknown-rule", true, false, "")
+	assert.ErrorIs(t, err, ErrDelegationNotFound)
+
+	_, err = SetCommitMessageRules(targetsMetadata, AllowRuleName, true, false, "")
+	assert.ErrorIs(t, err, ErrCannotManipulateAllowRule)
+}
+
+func TestSetFileRestrictions(t *testing.T) {
+	targetsMetadata := InitializeTargetsMetadata()
+
+	key, err := tuf.LoadKeyFromBytes(targets1PubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targetsMetadata, err = AddDelegation(targetsMetadata, "test-rule", []*tuf.Key{key}, []string{"file:vendor/*"}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targetsMetadata, err = SetFileRestrictions(targetsMetadata, "test-rule", 1024, []string{"EXE", ".dll", ".exe"}, true)
+	assert.Nil(t, err)
+	assert.Equal(t, int64(1024), targetsMetadata.Delegations.Roles[0].MaxFileSize)
+	assert.Equal(t, []string{".exe", ".dll"}, targetsMetadata.Delegations.Roles[0].ForbiddenExtensions)
+	assert.True(t, targetsMetadata.Delegations.Roles[0].ForbidBinaryFiles)
+
+	targetsMetadata, err = SetFileRestrictions(targetsMetadata, "test-rule", 0, nil, false)
+	assert.Nil(t, err)
+	assert.Zero(t, targetsMetadata.Delegations.Roles[0].MaxFileSize)
+	assert.Empty(t, targetsMetadata.Delegations.Roles[0].ForbiddenExtensions)
+	assert.False(t, targetsMetadata.Delegations.Roles[0].ForbidBinaryFiles)
+
+	_, err = SetFileRestrictions(targetsMetadata, "test-rule", -1, nil, false)
+	assert.ErrorIs(t, err, ErrInvalidFileRestriction)
+
+	_, err = SetFileRestrictions(targetsMetadata, "test-rule", 0, []string{"."}, false)
+	assert.ErrorIs(t, err, ErrInvalidFileRestriction)
+
+	_, err = SetFileRestrictions(targetsMetadata, "test-rule", 0, []string{"*.exe"}, false)
+	assert.ErrorIs(t, err, ErrInvalidFileRestriction)
+
+	_, err = SetFileRestrictions(targetsMetadata, "unknown-rule", 1024, nil, false)
+	assert.ErrorIs(t, err, ErrDelegationNotFound)
+
+	_, err = SetFileRestrictions(targetsMetadata, AllowRuleName, 1024, nil, false)
+	assert.ErrorIs(t, err, ErrCannotManipulateAllowRule)
+}
+
+func TestAddPerson(t *testing.T) {
+	targetsMetadata := InitializeTargetsMetadata()
+
+	key1, err := tuf.LoadKeyFromBytes(targets1PubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key2, err := tuf.LoadKeyFromBytes(targets2PubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targetsMetadata, err = AddPerson(targetsMetadata, "alice", []*tuf.Key{key1})
+	assert.Nil(t, err)
+	assert.Equal(t, &tuf.Person{PersonID: "alice", KeyIDs: []string{key1.KeyID}}, targetsMetadata.Delegations.People["alice"])
+	assert.Equal(t, key1, targetsMetadata.Delegations.Keys[key1.KeyID])
+
+	// Adding an existing person replaces their keys
+	targetsMetadata, err = AddPerson(targetsMetadata, "alice", []*tuf.Key{key1, key2})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{key1.KeyID, key2.KeyID}, targetsMetadata.Delegations.People["alice"].KeyIDs)
+	assert.Equal(t, key2, targetsMetadata.Delegations.Keys[key2.KeyID])
+
+	_, err = AddPerson(targetsMetadata, "bob", nil)
+	assert.ErrorIs(t, err, ErrPersonHasNoKeys)
+}
+
+func TestRemovePerson(t *testing.T) {
+	targetsMetadata := InitializeTargetsMetadata()
+
+	key, err := tuf.LoadKeyFromBytes(targets1PubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targetsMetadata, err = AddPerson(targetsMetadata, "alice", []*tuf.Key{key})
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsMetadata, err = AddDelegation(targetsMetadata, "test-rule", nil, []string{"git:refs/heads/main"}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsMetadata, err = SetAuthorizedPersons(targetsMetadata, "test-rule", []string{"alice"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = RemovePerson(targetsMetadata, "alice")
+	assert.ErrorIs(t, err, ErrPersonInUse)
+
+	targetsMetadata, err = SetAuthorizedPersons(targetsMetadata, "test-rule", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targetsMetadata, err = RemovePerson(targetsMetadata, "alice")
+	assert.Nil(t, err)
+	assert.NotContains(t, targetsMetadata.Delegations.People, "alice")
+
+	_, err = RemovePerson(targetsMetadata, "alice")
+	assert.ErrorIs(t, err, ErrPersonNotFound)
+}
+
+func TestSetAuthorizedPersons(t *testing.T) {
+	targetsMetadata := InitializeTargetsMetadata()
+
+	key1, err := tuf.LoadKeyFromBytes(targets1PubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key2, err := tuf.LoadKeyFromBytes(targets2PubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targetsMetadata, err = AddPerson(targetsMetadata, "alice", []*tuf.Key{key1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsMetadata, err = AddDelegation(targetsMetadata, "test-rule", []*tuf.Key{key2}, []string{"git:refs/heads/main"}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targetsMetadata, err = SetAuthorizedPersons(targetsMetadata, "test-rule", []string{"alice"})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"alice"}, targetsMetadata.Delegations.Roles[0].PersonIDs)
+
+	// People are retained and count towards the threshold when the rule is
+	// updated
+	targetsMetadata, err = UpdateDelegation(targetsMetadata, "test-rule", []*tuf.Key{key2}, []string{"git:refs/heads/*"}, 2)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"alice"}, targetsMetadata.Delegations.Roles[0].PersonIDs)
+
+	_, err = UpdateDelegation(targetsMetadata, "test-rule", []*tuf.Key{key2}, []string{"git:refs/heads/*"}, 3)
+	assert.ErrorIs(t, err, ErrCannotMeetThreshold)
+
+	_, err = SetAuthorizedPersons(targetsMetadata, "test-rule", []string{"bob"})
+	assert.ErrorIs(t, err, ErrPersonNotFound)
+
+	_, err = SetAuthorizedPersons(targetsMetadata, "unknown-rule", []string{"alice"})
+	assert.ErrorIs(t, err, ErrDelegationNotFound)
+
+	_, err = SetAuthorizedPersons(targetsMetadata, AllowRuleName, []string{"alice"})
+	assert.ErrorIs(t, err, ErrCannotManipulateAllowRule)
+}
+
+func TestTeams(t *testing.T) {
+	targetsMetadata := InitializeTargetsMetadata()
+
+	key1, err := tuf.LoadKeyFromBytes(targets1PubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key2, err := tuf.LoadKeyFromBytes(targets2PubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targetsMetadata, err = AddPerson(targetsMetadata, "alice", []*tuf.Key{key1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsMetadata, err = AddDelegation(targetsMetadata, "test-rule", nil, []string{"git:refs/heads/main"}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("add team members", func(t *testing.T) {
+		targetsMetadata, err = AddTeamMembers(targetsMetadata, "maintainers", nil, []string{"alice"})
+		assert.Nil(t, err)
+		assert.Equal(t, &tuf.Team{TeamID: "maintainers", PersonIDs: []string{"alice"}}, targetsMetadata.Delegations.Teams["maintainers"])
+
+		// Members are not duplicated
+		targetsMetadata, err = AddTeamMembers(targetsMetadata, "maintainers", []*tuf.Key{key2}, []string{"alice"})
+		assert.Nil(t, err)
+		assert.Equal(t, &tuf.Team{TeamID: "maintainers", KeyIDs: []string{key2.KeyID}, PersonIDs: []string{"alice"}}, targetsMetadata.Delegations.Teams["maintainers"])
+		assert.Equal(t, key2, targetsMetadata.Delegations.Keys[key2.KeyID])
+
+		_, err = AddTeamMembers(targetsMetadata, "maintainers", nil, []string{"bob"})
+		assert.ErrorIs(t, err, ErrPersonNotFound)
+	})
+
+	t.Run("set authorized teams", func(t *testing.T) {
+		targetsMetadata, err = SetAuthorizedTeams(targetsMetadata, "test-rule", []string{"maintainers"})
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"maintainers"}, targetsMetadata.Delegations.Roles[0].TeamIDs)
+
+		// Team members count towards the threshold when the rule is updated
+		targetsMetadata, err = UpdateDelegation(targetsMetadata, "test-rule", nil, []string{"git:refs/heads/main"}, 2)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{"maintainers"}, targetsMetadata.Delegations.Roles[0].TeamIDs)
+
+		_, err = UpdateDelegation(targetsMetadata, "test-rule", nil, []string{"git:refs/heads/main"}, 3)
+		assert.ErrorIs(t, err, ErrCannotMeetThreshold)
+
+		_, err = SetAuthorizedTeams(targetsMetadata, "test-rule", []string{"reviewers"})
+		assert.ErrorIs(t, err, ErrTeamNotFound)
+
+		_, err = SetAuthorizedTeams(targetsMetadata, "unknown-rule", []string{"maintainers"})
+		assert.ErrorIs(t, err, ErrDelegationNotFound)
+
+		_, err = SetAuthorizedTeams(targetsMetadata, AllowRuleName, []string{"maintainers"})
+		assert.ErrorIs(t, err, ErrCannotManipulateAllowRule)
+	})
+
+	t.Run("remove", func(t *testing.T) {
+		_, err = RemovePerson(targetsMetadata, "alice")
+		assert.ErrorIs(t, err, ErrPersonInUse)
+
+		_, err = RemoveTeam(targetsMetadata, "maintainers")
+		assert.ErrorIs(t, err, ErrTeamInUse)
+
+		targetsMetadata, err = RemoveTeamMembers(targetsMetadata, "maintainers", []string{key2.KeyID}, []string{"alice"})
+		assert.Nil(t, err)
+		assert.Empty(t, targetsMetadata.Delegations.Teams["maintainers"].KeyIDs)
+		assert.Empty(t, targetsMetadata.Delegations.Teams["maintainers"].PersonIDs)
+
+		targetsMetadata, err = SetAuthorizedTeams(targetsMetadata, "test-rule", nil)
+		assert.Nil(t, err)
+
+		targetsMetadata, err = RemoveTeam(targetsMetadata, "maintainers")
+		assert.Nil(t, err)
+		assert.NotContains(t, targetsMetadata.Delegations.Teams, "maintainers")
+
+		_, err = RemoveTeam(targetsMetadata, "maintainers")
+		assert.ErrorIs(t, err, ErrTeamNotFound)
+
+		_, err = RemoveTeamMembers(targetsMetadata, "maintainers", nil, nil)
+		assert.ErrorIs(t, err, ErrTeamNotFound)
+	})
+}
+
+func TestSyncTeamPeople(t *testing.T) {
+	targetsMetadata := InitializeTargetsMetadata()
+
+	key1, err := tuf.LoadKeyFromBytes(targets1PubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key2, err := tuf.LoadKeyFromBytes(targets2PubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targetsMetadata, diff, err := SyncTeamPeople(targetsMetadata, "maintainers", map[string][]*tuf.Key{
+		"alice": {key1},
+		"bob":   {key2},
+		"carol": nil,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, &tuf.Team{TeamID: "maintainers", PersonIDs: []string{"alice", "bob"}}, targetsMetadata.Delegations.Teams["maintainers"])
+	assert.Equal(t, &tuf.Person{PersonID: "alice", KeyIDs: []string{key1.KeyID}}, targetsMetadata.Delegations.People["alice"])
+	assert.Equal(t, ChangeAdded, diff.Change)
+	assert.Equal(t, []string{"alice", "bob"}, diff.AddedPersonIDs)
+	assert.Equal(t, []string{"carol"}, diff.SkippedPersonIDs)
+	assert.Equal(t, 2, len(diff.People))
+
+	// Syncing the same members makes no changes
+	targetsMetadata, diff, err = SyncTeamPeople(targetsMetadata, "maintainers", map[string][]*tuf.Key{
+		"alice": {key1},
+		"bob":   {key2},
+	})
+	assert.Nil(t, err)
+	assert.True(t, diff.IsEmpty())
+
+	// bob leaves the team and alice changes her key
+	targetsMetadata, diff, err = SyncTeamPeople(targetsMetadata, "maintainers", map[string][]*tuf.Key{
+		"alice": {key2},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, &tuf.Team{TeamID: "maintainers", PersonIDs: []string{"alice"}}, targetsMetadata.Delegations.Teams["maintainers"])
+	assert.Equal(t, &tuf.Person{PersonID: "alice", KeyIDs: []string{key2.KeyID}}, targetsMetadata.Delegations.People["alice"])
+	assert.Contains(t, targetsMetadata.Delegations.People, "bob")
+	assert.Equal(t, ChangeModified, diff.Change)
+	assert.Empty(t, diff.AddedPersonIDs)
+	assert.Equal(t, []string{"bob"}, diff.RemovedPersonIDs)
+	assert.Equal(t, []*PersonDiff{{PersonID: "alice", Change: ChangeModified, AddedKeyIDs: []string{key2.KeyID}, RemovedKeyIDs: []string{key1.KeyID}}}, diff.People)
+}
+
 func TestAddKeyToTargets(t *testing.T) {
 	gpgKey, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
 	if err != nil {
@@ -153,3 +851,41 @@ func TestAllowRule(t *testing.T) {
 	assert.Empty(t, allowRule.KeyIDs)
 	assert.Equal(t, 1, allowRule.Threshold)
 }
+
+func TestRotateTargetsMetadataKey(t *testing.T) {
+	targetsMetadata := InitializeTargetsMetadata()
+
+	key1, err := tuf.LoadKeyFromBytes(targets1PubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key2, err := tuf.LoadKeyFromBytes(targets2PubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	newKey, err := tuf.LoadKeyFromBytes(rootPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targetsMetadata, err = AddDelegation(targetsMetadata, "rule-1", []*tuf.Key{key1, key2}, []string{"test/"}, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsMetadata, err = AddDelegation(targetsMetadata, "rule-2", []*tuf.Key{key2}, []string{"test/"}, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, rotated := RotateTargetsMetadataKey(targetsMetadata, newKey.KeyID, key1)
+	assert.False(t, rotated)
+
+	targetsMetadata, rotated = RotateTargetsMetadataKey(targetsMetadata, key1.KeyID, newKey)
+	assert.True(t, rotated)
+	assert.NotContains(t, targetsMetadata.Delegations.Keys, key1.KeyID)
+	assert.Equal(t, newKey, targetsMetadata.Delegations.Keys[newKey.KeyID])
+	assert.Equal(t, []string{newKey.KeyID, key2.KeyID}, targetsMetadata.Delegations.Roles[0].KeyIDs)
+	assert.Equal(t, 2, targetsMetadata.Delegations.Roles[0].Threshold)
+	assert.Equal(t, []string{key2.KeyID}, targetsMetadata.Delegations.Roles[1].KeyIDs)
+	assert.Contains(t, targetsMetadata.Delegations.Roles, AllowRule())
+}
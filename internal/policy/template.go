@@ -0,0 +1,122 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/gittuf/gittuf/internal/tuf"
+)
+
+var (
+	ErrUnknownTemplate          = errors.New("unknown policy template")
+	ErrInsufficientTemplateKeys = errors.New("not enough keys provided for policy template")
+)
+
+// templateRule describes a rule created by a policy template.
+type templateRule struct {
+	name      string
+	patterns  []string
+	threshold int
+}
+
+// Template is a predefined set of rules for a common repository setup that
+// can be instantiated with a set of keys.
+type Template struct {
+	Name        string
+	Description string
+
+	// MinimumKeys is the number of keys that must be provided to instantiate
+	// the template.
+	MinimumKeys int
+
+	rules []templateRule
+}
+
+var templates = map[string]*Template{
+	"protect-main": {
+		Name:        "protect-main",
+		Description: "protect the main branch",
+		MinimumKeys: 1,
+		rules: []templateRule{
+			{name: "protect-main", patterns: []string{"git:refs/heads/main"}, threshold: 1},
+		},
+	},
+	"protect-main-and-tags": {
+		Name:        "protect-main-and-tags",
+		Description: "protect the main branch and all tags",
+		MinimumKeys: 1,
+		rules: []templateRule{
+			{name: "protect-main", patterns: []string{"git:refs/heads/main"}, threshold: 1},
+			{name: "protect-tags", patterns: []string{"git:refs/tags/*"}, threshold: 1},
+		},
+	},
+	"two-person-release-review": {
+		Name:        "two-person-release-review",
+		Description: "protect the main branch, and require two signatures for release branches and tags",
+		MinimumKeys: 2,
+		rules: []templateRule{
+			{name: "protect-main", patterns: []string{"git:refs/heads/main"}, threshold: 1},
+			{name: "protect-releases", patterns: []string{"git:refs/heads/release/*", "git:refs/tags/*"}, threshold: 2},
+		},
+	},
+	"protect-all-branches": {
+		Name:        "protect-all-branches",
+		Description: "protect every branch in the repository",
+		MinimumKeys: 1,
+		rules: []templateRule{
+			{name: "protect-branches", patterns: []string{"git:refs/heads/*"}, threshold: 1},
+		},
+	},
+}
+
+// Templates returns all available policy templates sorted by name.
+func Templates() []*Template {
+	names := mapKeys(templates)
+	sort.Strings(names)
+
+	allTemplates := make([]*Template, 0, len(names))
+	for _, name := range names {
+		allTemplates = append(allTemplates, templates[name])
+	}
+	return allTemplates
+}
+
+// GetTemplate returns the policy template with the specified name.
+func GetTemplate(name string) (*Template, error) {
+	template, has := templates[name]
+	if !has {
+		return nil, fmt.Errorf("%w: '%s'", ErrUnknownTemplate, name)
+	}
+	return template, nil
+}
+
+// Apply adds the template's rules to the targets metadata, authorizing every
+// key in authorizedKeys for each rule. Rules that already exist in the
+// metadata result in an error.
+func (t *Template) Apply(targetsMetadata *tuf.TargetsMetadata, authorizedKeys []*tuf.Key) (*tuf.TargetsMetadata, error) {
+	if len(authorizedKeys) < t.MinimumKeys {
+		return nil, fmt.Errorf("%w: template '%s' requires at least %d key(s)", ErrInsufficientTemplateKeys, t.Name, t.MinimumKeys)
+	}
+
+	for _, rule := range t.rules {
+		for _, existingRule := range targetsMetadata.Delegations.Roles {
+			if existingRule.Name == rule.name {
+				return nil, fmt.Errorf("%w: '%s'", ErrDuplicatedRuleName, rule.name)
+			}
+		}
+
+		patterns := make([]string, len(rule.patterns))
+		copy(patterns, rule.patterns)
+
+		var err error
+		targetsMetadata, err = AddDelegation(targetsMetadata, rule.name, authorizedKeys, patterns, rule.threshold)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return targetsMetadata, nil
+}
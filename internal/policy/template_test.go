@@ -0,0 +1,85 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTemplateApply(t *testing.T) {
+	key1, err := tuf.LoadKeyFromBytes(rootPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key2, err := tuf.LoadKeyFromBytes(targets1PubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("all templates", func(t *testing.T) {
+		for _, template := range Templates() {
+			targetsMetadata, err := template.Apply(InitializeTargetsMetadata(), []*tuf.Key{key1, key2})
+			assert.Nil(t, err, template.Name)
+			assert.Equal(t, len(template.rules)+1, len(targetsMetadata.Delegations.Roles), template.Name)
+			assert.Equal(t, AllowRule(), targetsMetadata.Delegations.Roles[len(targetsMetadata.Delegations.Roles)-1], template.Name)
+			assert.Contains(t, targetsMetadata.Delegations.Keys, key1.KeyID)
+			assert.Contains(t, targetsMetadata.Delegations.Keys, key2.KeyID)
+		}
+	})
+
+	t.Run("protect main and tags", func(t *testing.T) {
+		template, err := GetTemplate("protect-main-and-tags")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		targetsMetadata, err := template.Apply(InitializeTargetsMetadata(), []*tuf.Key{key1})
+		assert.Nil(t, err)
+		assert.Equal(t, []tuf.Delegation{
+			{
+				Name:  "protect-main",
+				Paths: []string{"git:refs/heads/main"},
+				Role:  tuf.Role{KeyIDs: []string{key1.KeyID}, Threshold: 1},
+			},
+			{
+				Name:  "protect-tags",
+				Paths: []string{"git:refs/tags/*"},
+				Role:  tuf.Role{KeyIDs: []string{key1.KeyID}, Threshold: 1},
+			},
+			AllowRule(),
+		}, targetsMetadata.Delegations.Roles)
+	})
+
+	t.Run("insufficient keys", func(t *testing.T) {
+		template, err := GetTemplate("two-person-release-review")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = template.Apply(InitializeTargetsMetadata(), []*tuf.Key{key1})
+		assert.ErrorIs(t, err, ErrInsufficientTemplateKeys)
+	})
+
+	t.Run("rule already exists", func(t *testing.T) {
+		template, err := GetTemplate("protect-main")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		targetsMetadata, err := template.Apply(InitializeTargetsMetadata(), []*tuf.Key{key1})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = template.Apply(targetsMetadata, []*tuf.Key{key1})
+		assert.ErrorIs(t, err, ErrDuplicatedRuleName)
+	})
+
+	t.Run("unknown template", func(t *testing.T) {
+		_, err := GetTemplate("does-not-exist")
+		assert.ErrorIs(t, err, ErrUnknownTemplate)
+	})
+}
@@ -0,0 +1,206 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	sslibdsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
+)
+
+const (
+	// TimestampRoleName defines the expected name for the optional timestamp
+	// role that periodically signs the tip of the RSL.
+	TimestampRoleName = "timestamp"
+
+	// TimestampRef defines the Git namespace used to store the latest
+	// timestamp metadata. It is not recorded in the RSL as every RSL entry
+	// would require a new timestamp.
+	TimestampRef = "refs/gittuf/timestamp"
+
+	timestampTreeEntryName = "timestamp.json"
+)
+
+var (
+	ErrTimestampKeyNil   = errors.New("timestampKey is nil")
+	ErrNoTimestampRole   = errors.New("timestamp role is not defined in the root of trust")
+	ErrTimestampNotFound = errors.New("timestamp metadata not found")
+	ErrTimestampExpired  = errors.New("timestamp metadata has expired, newer RSL entries may be withheld")
+	ErrRSLNotFresh       = errors.New("local RSL does not contain the RSL tip recorded in timestamp metadata, newer RSL entries may be withheld")
+)
+
+// AddTimestampKey adds the 'timestampKey' as a trusted public key in
+// 'rootMetadata' for the timestamp role. Adding the first key enables the
+// timestamp role for the repository.
+func AddTimestampKey(rootMetadata *tuf.RootMetadata, timestampKey *tuf.Key) (*tuf.RootMetadata, error) {
+	if timestampKey == nil {
+		return nil, ErrTimestampKeyNil
+	}
+
+	return addOptionalRoleKey(rootMetadata, TimestampRoleName, timestampKey)
+}
+
+// DeleteTimestampKey removes the key matching 'keyID' from trusted public keys
+// for the timestamp role in 'rootMetadata'. Removing the last key disables the
+// timestamp role.
+func DeleteTimestampKey(rootMetadata *tuf.RootMetadata, keyID string) (*tuf.RootMetadata, error) {
+	return deleteOptionalRoleKey(rootMetadata, TimestampRoleName, keyID)
+}
+
+// LoadTimestamp returns the latest timestamp envelope stored in the
+// repository.
+func LoadTimestamp(repo *git.Repository) (*sslibdsse.Envelope, error) {
+	commitID, err := gitinterface.GetTip(repo, TimestampRef)
+	if err != nil {
+		if errors.Is(err, plumbing.ErrReferenceNotFound) {
+			return nil, ErrTimestampNotFound
+		}
+		return nil, err
+	}
+	if commitID.IsZero() {
+		return nil, ErrTimestampNotFound
+	}
+
+	commit, err := gitinterface.GetCommit(repo, commitID)
+	if err != nil {
+		return nil, err
+	}
+
+	tree, err := gitinterface.GetTree(repo, commit.TreeHash)
+	if err != nil {
+		return nil, err
+	}
+
+	entry, err := tree.FindEntry(timestampTreeEntryName)
+	if err != nil {
+		return nil, ErrTimestampNotFound
+	}
+
+	contents, err := gitinterface.ReadBlob(repo, entry.Hash)
+	if err != nil {
+		return nil, err
+	}
+
+	env := &sslibdsse.Envelope{}
+	if err := json.Unmarshal(contents, env); err != nil {
+		return nil, err
+	}
+
+	return env, nil
+}
+
+// CommitTimestamp records the timestamp envelope in the timestamp namespace.
+func CommitTimestamp(repo *git.Repository, env *sslibdsse.Envelope, signCommit bool) error {
+	contents, err := json.Marshal(env)
+	if err != nil {
+		return err
+	}
+
+	blobID, err := gitinterface.WriteBlob(repo, contents)
+	if err != nil {
+		return err
+	}
+
+	treeID, err := gitinterface.WriteTree(repo, []object.TreeEntry{
+		{
+			Name: timestampTreeEntryName,
+			Mode: filemode.Regular,
+			Hash: blobID,
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	_, err = gitinterface.Commit(repo, treeID, TimestampRef, "Update timestamp", signCommit)
+	return err
+}
+
+// GetTimestampMetadata returns the deserialized payload of the timestamp
+// envelope.
+func GetTimestampMetadata(env *sslibdsse.Envelope) (*tuf.TimestampMetadata, error) {
+	payloadBytes, err := env.DecodeB64Payload()
+	if err != nil {
+		return nil, err
+	}
+
+	timestampMetadata := &tuf.TimestampMetadata{}
+	if err := json.Unmarshal(payloadBytes, timestampMetadata); err != nil {
+		return nil, err
+	}
+
+	return timestampMetadata, nil
+}
+
+// VerifyFreshness checks that the timestamp envelope is signed by a threshold
+// of the State's timestamp role keys, that it has not expired as of now, and
+// that the RSL tip it records is present in the repository's RSL. Together,
+// these checks detect a remote that withholds newer RSL entries.
+func (s *State) VerifyFreshness(ctx context.Context, repo *git.Repository, env *sslibdsse.Envelope, now time.Time) (*tuf.TimestampMetadata, error) {
+	rootMetadata, err := s.GetRootMetadata()
+	if err != nil {
+		return nil, err
+	}
+	if _, has := rootMetadata.Roles[TimestampRoleName]; !has {
+		return nil, ErrNoTimestampRole
+	}
+
+	timestampVerifier, err := s.getOptionalRoleVerifier(TimestampRoleName)
+	if err != nil {
+		return nil, err
+	}
+	if err := timestampVerifier.Verify(ctx, nil, env); err != nil {
+		return nil, err
+	}
+
+	timestampMetadata, err := GetTimestampMetadata(env)
+	if err != nil {
+		return nil, err
+	}
+
+	expires, err := time.Parse(time.RFC3339, timestampMetadata.Expires)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse expiry date of timestamp: %w", err)
+	}
+	if expires.Before(now) {
+		return nil, fmt.Errorf("%w: expired at %s", ErrTimestampExpired, timestampMetadata.Expires)
+	}
+
+	notFreshErr := fmt.Errorf("%w: timestamp signed at %s records '%s'", ErrRSLNotFresh, timestampMetadata.SignedAt, timestampMetadata.RSLTip)
+
+	// If the recorded tip is missing from the object store, it was never
+	// fetched
+	timestampedCommit, err := gitinterface.GetCommit(repo, plumbing.NewHash(timestampMetadata.RSLTip))
+	if err != nil {
+		if errors.Is(err, plumbing.ErrObjectNotFound) {
+			return nil, notFreshErr
+		}
+		return nil, err
+	}
+
+	rslTip, err := gitinterface.GetTip(repo, rsl.Ref)
+	if err != nil {
+		return nil, err
+	}
+
+	knows, err := gitinterface.KnowsCommit(repo, rslTip, timestampedCommit)
+	if err != nil {
+		return nil, err
+	}
+	if !knows {
+		return nil, notFreshErr
+	}
+
+	return timestampMetadata, nil
+}
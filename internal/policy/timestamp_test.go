@@ -0,0 +1,38 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAddAndDeleteTimestampKey(t *testing.T) {
+	key, err := tuf.LoadKeyFromBytes(rootPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	timestampKey, err := tuf.LoadKeyFromBytes(targets1PubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rootMetadata := InitializeRootMetadata(key)
+
+	_, err = AddTimestampKey(rootMetadata, nil)
+	assert.ErrorIs(t, err, ErrTimestampKeyNil)
+
+	rootMetadata, err = AddTimestampKey(rootMetadata, timestampKey)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{timestampKey.KeyID}, rootMetadata.Roles[TimestampRoleName].KeyIDs)
+	assert.Equal(t, 1, rootMetadata.Roles[TimestampRoleName].Threshold)
+
+	_, err = DeleteTimestampKey(rootMetadata, key.KeyID)
+	assert.ErrorIs(t, err, ErrKeyNotInPolicy)
+
+	rootMetadata, err = DeleteTimestampKey(rootMetadata, timestampKey.KeyID)
+	assert.Nil(t, err)
+	assert.NotContains(t, rootMetadata.Roles, TimestampRoleName)
+}
@@ -7,10 +7,15 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"path"
+	"slices"
 	"strings"
+	"sync"
 
 	"github.com/gittuf/gittuf/internal/attestations"
+	"github.com/gittuf/gittuf/internal/github"
 	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/gittuf/gittuf/internal/gitlab"
 	"github.com/gittuf/gittuf/internal/rsl"
 	"github.com/gittuf/gittuf/internal/signerverifier"
 	"github.com/gittuf/gittuf/internal/signerverifier/common"
@@ -40,14 +45,76 @@ const (
 )
 
 var (
-	ErrUnauthorizedSignature   = errors.New("unauthorized signature")
-	ErrInvalidEntryNotSkipped  = errors.New("invalid entry found not marked as skipped")
-	ErrLastGoodEntryIsSkipped  = errors.New("entry expected to be unskipped is marked as skipped")
-	ErrUnknownObjectType       = errors.New("unknown object type passed to verify signature")
-	ErrInvalidVerifier         = errors.New("verifier has invalid parameters (is threshold 0?)")
-	ErrVerifierConditionsUnmet = errors.New("verifier's key and threshold constraints not met")
+	ErrUnauthorizedSignature       = errors.New("unauthorized signature")
+	ErrInvalidEntryNotSkipped      = errors.New("invalid entry found not marked as skipped")
+	ErrLastGoodEntryIsSkipped      = errors.New("entry expected to be unskipped is marked as skipped")
+	ErrUnknownObjectType           = errors.New("unknown object type passed to verify signature")
+	ErrInvalidVerifier             = errors.New("verifier has invalid parameters (is threshold 0?)")
+	ErrVerifierConditionsUnmet     = errors.New("verifier's key and threshold constraints not met")
+	ErrThresholdUnmet              = errors.New("valid signatures found but not enough to meet threshold")
+	ErrKeyRevoked                  = errors.New("signing key has been revoked")
+	ErrNotTag                      = errors.New(nonTagMessage)
+	ErrMultipleTagRSLEntries       = errors.New(multipleTagRSLEntriesFoundMessage)
+	ErrUnauthorizedForcePush       = errors.New("unauthorized force push")
+	ErrUnauthorizedDeletion        = errors.New("unauthorized deletion")
+	ErrTargetDigestMismatch        = errors.New("target does not match SHA-256 digest recorded in RSL entry, it may be a SHA-1 collision")
+	ErrSubmoduleURLNotAllowed      = errors.New("submodule URL is not allowed by policy")
+	ErrSubmoduleUnavailable        = errors.New("submodule repository required for verification is unavailable")
+	ErrSubmoduleVerificationFailed = errors.New("commit pinned by submodule failed verification against the submodule's RSL")
 )
 
+// EntryVerificationError identifies the RSL entry that failed verification,
+// and wraps the reason it failed.
+type EntryVerificationError struct {
+	Entry *rsl.ReferenceEntry
+	Err   error
+}
+
+func (e *EntryVerificationError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *EntryVerificationError) Unwrap() error {
+	return e.Err
+}
+
+// GetEntryVerificationErrors returns the EntryVerificationErrors in err's tree,
+// such as the failures of each reference verified in a batch.
+func GetEntryVerificationErrors(err error) []*EntryVerificationError {
+	switch e := err.(type) { //nolint:errorlint
+	case nil:
+		return nil
+	case *EntryVerificationError:
+		return []*EntryVerificationError{e}
+	case interface{ Unwrap() []error }:
+		entryErrs := []*EntryVerificationError{}
+		for _, err := range e.Unwrap() {
+			entryErrs = append(entryErrs, GetEntryVerificationErrors(err)...)
+		}
+		return entryErrs
+	default:
+		return GetEntryVerificationErrors(errors.Unwrap(err))
+	}
+}
+
+// VerificationOptions contains the configurable parameters for verifying the
+// RSL entries of a reference.
+type VerificationOptions struct {
+	// Jobs is the maximum number of RSL entries that are verified
+	// concurrently.
+	Jobs int
+}
+
+type VerificationOption func(*VerificationOptions)
+
+// WithJobs sets the maximum number of RSL entries that are verified
+// concurrently. By default, entries are verified one at a time.
+func WithJobs(jobs int) VerificationOption {
+	return func(o *VerificationOptions) {
+		o.Jobs = jobs
+	}
+}
+
 // VerifyRef verifies the signature on the latest RSL entry for the target ref
 // using the latest policy. The expected Git ID for the ref in the latest RSL
 // entry is returned if the policy verification is successful.
@@ -80,7 +147,7 @@ func VerifyRef(ctx context.Context, repo *git.Repository, target string) (plumbi
 // VerifyRefFull verifies the entire RSL for the target ref from the first
 // entry. The expected Git ID for the ref in the latest RSL entry is returned if
 // the policy verification is successful.
-func VerifyRefFull(ctx context.Context, repo *git.Repository, target string) (plumbing.Hash, error) {
+func VerifyRefFull(ctx context.Context, repo *git.Repository, target string, opts ...VerificationOption) (plumbing.Hash, error) {
 	// Trace RSL back to the start
 	slog.Debug("Identifying first RSL entry...")
 	firstEntry, _, err := rsl.GetFirstEntry(repo)
@@ -98,13 +165,13 @@ func VerifyRefFull(ctx context.Context, repo *git.Repository, target string) (pl
 	// Do a relative verify from start entry to the latest entry (firstEntry here == policyEntry)
 	// Also, attestations is initially nil because we haven't seen any yet
 	slog.Debug("Verifying all entries...")
-	return latestEntry.TargetID, VerifyRelativeForRef(ctx, repo, firstEntry, nil, firstEntry, latestEntry, target)
+	return latestEntry.TargetID, VerifyRelativeForRef(ctx, repo, firstEntry, nil, firstEntry, latestEntry, target, opts...)
 }
 
 // VerifyRefFromEntry performs verification for the reference from a specific
 // RSL entry. The expected Git ID for the ref in the latest RSL entry is
 // returned if the policy verification is successful.
-func VerifyRefFromEntry(ctx context.Context, repo *git.Repository, target string, entryID plumbing.Hash) (plumbing.Hash, error) {
+func VerifyRefFromEntry(ctx context.Context, repo *git.Repository, target string, entryID plumbing.Hash, opts ...VerificationOption) (plumbing.Hash, error) {
 	// Load starting point entry
 	slog.Debug("Identifying starting RSL entry...")
 	fromEntryT, err := rsl.GetEntry(repo, entryID)
@@ -116,7 +183,7 @@ func VerifyRefFromEntry(ctx context.Context, repo *git.Repository, target string
 	// use that
 	fromEntry, isRefEntry := fromEntryT.(*rsl.ReferenceEntry)
 	if !isRefEntry {
-		return plumbing.ZeroHash, err
+		return plumbing.ZeroHash, fmt.Errorf("%w: starting entry '%s' is not a reference entry", rsl.ErrInvalidRSLEntry, entryID.String())
 	}
 
 	// Find latest entry for target
@@ -144,14 +211,49 @@ func VerifyRefFromEntry(ctx context.Context, repo *git.Repository, target string
 
 	// Do a relative verify from start entry to the latest entry
 	slog.Debug("Verifying all entries...")
-	return latestEntry.TargetID, VerifyRelativeForRef(ctx, repo, policyEntry, attestationsEntry, fromEntry, latestEntry, target)
+	return latestEntry.TargetID, VerifyRelativeForRef(ctx, repo, policyEntry, attestationsEntry, fromEntry, latestEntry, target, opts...)
+}
+
+// VerifyRefFromCommit performs verification for the reference from the first
+// RSL entry for the reference that records the specified commit or one of its
+// descendants. If the commit was recorded prior to an RSL checkpoint, the
+// entire RSL is verified. The expected Git ID for the ref in the latest RSL
+// entry is returned if the policy verification is successful.
+func VerifyRefFromCommit(ctx context.Context, repo *git.Repository, target string, commitID plumbing.Hash, opts ...VerificationOption) (plumbing.Hash, error) {
+	commit, err := gitinterface.GetCommit(repo, commitID)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	slog.Debug(fmt.Sprintf("Identifying first RSL entry for '%s' that records commit '%s'...", target, commitID.String()))
+	fromEntry, _, err := rsl.GetFirstReferenceEntryForCommitInRef(repo, target, commit)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	fromEntryT, err := rsl.GetEntry(repo, fromEntry.ID)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+	if _, isCheckpoint := fromEntryT.(*rsl.CheckpointEntry); isCheckpoint {
+		slog.Debug("Commit was recorded prior to RSL checkpoint, verifying all entries...")
+		return VerifyRefFull(ctx, repo, target, opts...)
+	}
+
+	return VerifyRefFromEntry(ctx, repo, target, fromEntry.ID, opts...)
 }
 
 // VerifyRelativeForRef verifies the RSL between specified start and end entries
-// using the provided policy entry for the first entry.
+// using the provided policy entry for the first entry. If ctx is cancelled or
+// its deadline passes, verification stops and the context's error is returned.
 //
 // TODO: should the policy entry be inferred from the specified first entry?
-func VerifyRelativeForRef(ctx context.Context, repo *git.Repository, initialPolicyEntry, initialAttestationsEntry, firstEntry, lastEntry *rsl.ReferenceEntry, target string) error {
+func VerifyRelativeForRef(ctx context.Context, repo *git.Repository, initialPolicyEntry, initialAttestationsEntry, firstEntry, lastEntry *rsl.ReferenceEntry, target string, opts ...VerificationOption) error {
+	options := &VerificationOptions{Jobs: 1}
+	for _, fn := range opts {
+		fn(options)
+	}
+
 	var (
 		currentPolicy       *State
 		currentAttestations *attestations.Attestations
@@ -174,6 +276,17 @@ func VerifyRelativeForRef(ctx context.Context, repo *git.Repository, initialPoli
 		currentAttestations = attestationsState
 	}
 
+	// Entries sealed by a checkpoint are trusted as the checkpoint has been
+	// verified when loading the initial policy
+	checkpointID := plumbing.ZeroHash
+	firstEntryT, err := rsl.GetEntry(repo, firstEntry.ID)
+	if err != nil {
+		return err
+	}
+	if _, isCheckpoint := firstEntryT.(*rsl.CheckpointEntry); isCheckpoint {
+		checkpointID = firstEntry.ID
+	}
+
 	// Enumerate RSL entries between firstEntry and lastEntry, ignoring irrelevant ones
 	slog.Debug("Identifying all entries in range...")
 	entries, annotations, err := rsl.GetReferenceEntriesInRangeForRef(repo, firstEntry.ID, lastEntry.ID, target)
@@ -181,10 +294,21 @@ func VerifyRelativeForRef(ctx context.Context, repo *git.Repository, initialPoli
 		return err
 	}
 
+	// The results of verifying entries concurrently are used in place of
+	// verifying each entry in the loop below
+	precomputed := &precomputedVerification{}
+	if options.Jobs > 1 {
+		precomputed = precomputeVerification(ctx, repo, currentPolicy, currentAttestations, entries, checkpointID, options.Jobs)
+	}
+
 	// Verify each entry, looking for a fix when an invalid entry is encountered
 	var invalidEntry *rsl.ReferenceEntry
 	var verificationErr error
 	for len(entries) != 0 {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
 		if invalidEntry == nil {
 			// Pop entry from queue
 			entry := entries[0]
@@ -194,15 +318,13 @@ func VerifyRelativeForRef(ctx context.Context, repo *git.Repository, initialPoli
 
 			slog.Debug("Checking if entry is for policy reference...")
 			if entry.RefName == PolicyRef {
-				// TODO: this is repetition if the firstEntry is for policy
-				newPolicy, err := loadStateForEntry(ctx, repo, entry)
-				if err != nil {
-					return err
-				}
-
-				slog.Debug("Verifying new policy using current policy...")
-				if err := currentPolicy.VerifyNewState(ctx, newPolicy); err != nil {
-					return err
+				newPolicy, verified := precomputed.policies[entry.ID]
+				if !verified {
+					// TODO: this is repetition if the firstEntry is for policy
+					newPolicy, err = loadNewState(ctx, repo, currentPolicy, entry)
+					if err != nil {
+						return err
+					}
 				}
 
 				slog.Debug("Updating current policy...")
@@ -212,26 +334,41 @@ func VerifyRelativeForRef(ctx context.Context, repo *git.Repository, initialPoli
 
 			slog.Debug("Checking if entry is for attestations reference...")
 			if entry.RefName == attestations.Ref {
-				newAttestationsState, err := attestations.LoadAttestationsForEntry(repo, entry)
-				if err != nil {
-					return err
+				newAttestationsState, loaded := precomputed.attestations[entry.ID]
+				if !loaded {
+					newAttestationsState, err = attestations.LoadAttestationsForEntry(repo, entry)
+					if err != nil {
+						return err
+					}
 				}
 
 				currentAttestations = newAttestationsState
 				continue
 			}
 
+			if entry.ID == checkpointID {
+				slog.Debug("Entry is sealed by RSL checkpoint, skipping...")
+				continue
+			}
+
 			slog.Debug("Verifying changes...")
-			if err := verifyEntry(ctx, repo, currentPolicy, currentAttestations, entry); err != nil {
+			err, verified := precomputed.results[entry.ID]
+			if !verified {
+				err = verifyEntry(ctx, repo, currentPolicy, currentAttestations, entry)
+			}
+			if err != nil {
+				err = &EntryVerificationError{Entry: entry, Err: err}
+
 				slog.Debug("Violation found, checking if entry has been revoked...")
 				// If the invalid entry is never marked as skipped, we return err
-				if !entry.SkippedBy(annotations[entry.ID]) {
+				skipReasons := entry.SkipReasons(annotations[entry.ID])
+				if len(skipReasons) == 0 {
 					return err
 				}
 
 				// The invalid entry's been marked as skipped but we still need
 				// to see if another entry fixed state for non-gittuf users
-				slog.Debug("Entry has been revoked, searching for fix entry...")
+				slog.Debug(fmt.Sprintf("Entry has been revoked (%s), searching for fix entry...", describeSkipReasons(skipReasons)))
 				invalidEntry = entry
 				verificationErr = err
 			}
@@ -261,16 +398,20 @@ func VerifyRelativeForRef(ctx context.Context, repo *git.Repository, initialPoli
 			return err
 		}
 		slog.Debug("Verifying identified last valid entry has not been revoked...")
-		if lastGoodEntry.SkippedBy(lastGoodEntryAnnotations) {
-			return ErrLastGoodEntryIsSkipped
-		}
-		lastGoodEntryCommit, err := gitinterface.GetCommit(repo, lastGoodEntry.TargetID)
-		if err != nil {
-			return err
+		if skipReasons := lastGoodEntry.SkipReasons(lastGoodEntryAnnotations); len(skipReasons) != 0 {
+			return fmt.Errorf("%w: entry '%s' skipped (%s)", ErrLastGoodEntryIsSkipped, lastGoodEntry.ID.String(), describeSkipReasons(skipReasons))
 		}
 		// gittuf requires the fix to point to a commit that is tree-same as the
+		// last good state, or to delete the reference if it was deleted in the
 		// last good state
-		lastGoodTreeID := lastGoodEntryCommit.TreeHash
+		var lastGoodTreeID plumbing.Hash
+		if !lastGoodEntry.IsDeletion() {
+			lastGoodEntryCommit, err := gitinterface.GetCommit(repo, lastGoodEntry.TargetID)
+			if err != nil {
+				return err
+			}
+			lastGoodTreeID = lastGoodEntryCommit.TreeHash
+		}
 
 		// 2. What entries do we have in the current verification set for the
 		// ref? The first one that is tree-same as lastGoodEntry's commit is the
@@ -293,13 +434,17 @@ func VerifyRelativeForRef(ctx context.Context, repo *git.Repository, initialPoli
 				continue
 			}
 
-			newEntryCommit, err := gitinterface.GetCommit(repo, newEntry.TargetID)
-			if err != nil {
-				return err
+			var newEntryTreeID plumbing.Hash
+			if !newEntry.IsDeletion() {
+				newEntryCommit, err := gitinterface.GetCommit(repo, newEntry.TargetID)
+				if err != nil {
+					return err
+				}
+				newEntryTreeID = newEntryCommit.TreeHash
 			}
 
 			slog.Debug("Checking if entry is tree-same with last valid state...")
-			if newEntryCommit.TreeHash == lastGoodTreeID {
+			if newEntryTreeID == lastGoodTreeID {
 				// Fix found, we append the rest of the current verification set
 				// to the new entry queue
 				// But first, we must check that this fix hasn't been skipped
@@ -324,13 +469,17 @@ func VerifyRelativeForRef(ctx context.Context, repo *git.Repository, initialPoli
 
 		if !fixed {
 			// If we haven't found a fix, return the original error
-			return verificationErr
+			return fmt.Errorf("%w (entry '%s' skipped (%s) but no fix entry found)", verificationErr, invalidEntry.ID.String(), describeSkipReasons(invalidEntry.SkipReasons(annotations[invalidEntry.ID])))
 		}
 
 		if len(invalidIntermediateEntries) != 0 {
 			// We may have found a fix but if an invalid intermediate entry
 			// wasn't skipped, return error
-			return ErrInvalidEntryNotSkipped
+			invalidEntryIDs := []string{}
+			for _, entry := range invalidIntermediateEntries {
+				invalidEntryIDs = append(invalidEntryIDs, entry.ID.String())
+			}
+			return fmt.Errorf("%w: %s", ErrInvalidEntryNotSkipped, strings.Join(invalidEntryIDs, ", "))
 		}
 
 		// Reset these trackers to continue verification with rest of the queue
@@ -341,9 +490,132 @@ func VerifyRelativeForRef(ctx context.Context, repo *git.Repository, initialPoli
 		entries = newEntryQueue
 	}
 
+	if invalidEntry != nil {
+		// The last entry verified was invalid, so there's no fix entry
+		return fmt.Errorf("%w (entry '%s' skipped (%s) but no fix entry found)", verificationErr, invalidEntry.ID.String(), describeSkipReasons(invalidEntry.SkipReasons(annotations[invalidEntry.ID])))
+	}
+
 	return nil
 }
 
+// loadNewState loads the policy for the entry and verifies it using the
+// current policy.
+func loadNewState(ctx context.Context, repo *git.Repository, currentPolicy *State, entry *rsl.ReferenceEntry) (*State, error) {
+	newPolicy, err := loadStateForEntry(ctx, repo, entry)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Debug("Verifying new policy using current policy...")
+	if err := currentPolicy.VerifyNewState(ctx, newPolicy); err != nil {
+		return nil, err
+	}
+
+	if err := newPolicy.trackRevocations(currentPolicy, entry.ID); err != nil {
+		return nil, err
+	}
+
+	return newPolicy, nil
+}
+
+// precomputedVerification holds the results of verifying RSL entries ahead of
+// the sequential verification workflow. Each map is keyed by entry ID. Entries
+// missing from the maps must be verified by the workflow.
+type precomputedVerification struct {
+	policies     map[plumbing.Hash]*State
+	attestations map[plumbing.Hash]*attestations.Attestations
+	results      map[plumbing.Hash]error
+}
+
+// precomputeVerification verifies the entries concurrently using a pool of
+// jobs workers, each with its own handle for the repository. The policy and
+// attestations applicable to each entry are first identified by loading them in
+// order. If a policy or attestations entry cannot be loaded, entries from that
+// point are left to the sequential workflow so that errors are reported in the
+// same order.
+func precomputeVerification(ctx context.Context, repo *git.Repository, currentPolicy *State, currentAttestations *attestations.Attestations, entries []*rsl.ReferenceEntry, checkpointID plumbing.Hash, jobs int) *precomputedVerification {
+	precomputed := &precomputedVerification{
+		policies:     map[plumbing.Hash]*State{},
+		attestations: map[plumbing.Hash]*attestations.Attestations{},
+		results:      map[plumbing.Hash]error{},
+	}
+
+	type task struct {
+		entry             *rsl.ReferenceEntry
+		policy            *State
+		attestationsState *attestations.Attestations
+	}
+
+	slog.Debug("Identifying policy applicable to each entry...")
+	tasks := []task{}
+loadStates:
+	for _, entry := range entries {
+		switch {
+		case entry.RefName == PolicyRef:
+			newPolicy, err := loadNewState(ctx, repo, currentPolicy, entry)
+			if err != nil {
+				break loadStates
+			}
+			precomputed.policies[entry.ID] = newPolicy
+			currentPolicy = newPolicy
+		case entry.RefName == attestations.Ref:
+			newAttestationsState, err := attestations.LoadAttestationsForEntry(repo, entry)
+			if err != nil {
+				break loadStates
+			}
+			precomputed.attestations[entry.ID] = newAttestationsState
+			currentAttestations = newAttestationsState
+		case entry.ID == checkpointID:
+			continue
+		default:
+			tasks = append(tasks, task{entry: entry, policy: currentPolicy, attestationsState: currentAttestations})
+		}
+	}
+
+	// Each worker reads from the repository using its own handle
+	handles := make([]*git.Repository, min(jobs, len(tasks)))
+	for i := range handles {
+		handle, err := gitinterface.NewRepositoryHandle(repo)
+		if err != nil {
+			slog.Debug(fmt.Sprintf("Unable to create repository handle, verifying entries sequentially: %v", err))
+			return precomputed
+		}
+		handles[i] = handle
+	}
+
+	slog.Debug(fmt.Sprintf("Verifying %d entries using %d jobs...", len(tasks), len(handles)))
+	results := make([]error, len(tasks))
+	queue := make(chan int)
+	var wg sync.WaitGroup
+	for _, handle := range handles {
+		wg.Add(1)
+		go func(handle *git.Repository) {
+			defer wg.Done()
+			for index := range queue {
+				if err := ctx.Err(); err != nil {
+					// Drain the queue without verifying remaining entries
+					results[index] = err
+					continue
+				}
+
+				t := tasks[index]
+				results[index] = verifyEntry(ctx, handle, t.policy, t.attestationsState, t.entry)
+			}
+		}(handle)
+	}
+	for index := range tasks {
+		queue <- index
+	}
+	close(queue)
+	wg.Wait()
+
+	for index, t := range tasks {
+		precomputed.results[t.entry.ID] = results[index]
+	}
+
+	return precomputed
+}
+
 // VerifyCommit verifies the signature on the specified commits (identified by
 // their hash or via a reference that is resolved). For each commit, the policy
 // applicable when the commit was first recorded (directly or indirectly) in the
@@ -444,27 +716,10 @@ func VerifyTag(ctx context.Context, repo *git.Repository, ids []string) map[stri
 	status := make(map[string]string, len(ids))
 
 	for _, id := range ids {
-		// Check if id is tag name or hash of tag obj
-		absPath, err := gitinterface.AbsoluteReference(repo, id)
-		if err == nil {
-			if !strings.HasPrefix(absPath, gitinterface.TagRefPrefix) {
-				status[id] = nonTagMessage
-				continue
-			}
-		} else {
-			if !errors.Is(err, gitinterface.ErrReferenceNotFound) {
-				status[id] = err.Error()
-				continue
-			}
-
-			// Must be a hash
-			// verifyTagEntry also finds the tag object, wasteful?
-			tagObj, err := gitinterface.GetTag(repo, plumbing.NewHash(id))
-			if err != nil {
-				status[id] = nonTagMessage
-				continue
-			}
-			absPath = string(plumbing.NewTagReferenceName(tagObj.Name))
+		absPath, err := resolveTagReference(repo, id)
+		if err != nil {
+			status[id] = err.Error()
+			continue
 		}
 
 		entry, _, err := rsl.GetLatestReferenceEntryForRef(repo, absPath)
@@ -500,15 +755,86 @@ func VerifyTag(ctx context.Context, repo *git.Repository, ids []string) map[stri
 	return status
 }
 
+// VerifyTagRef verifies the tag identified by id end-to-end. The tag must be
+// recorded exactly once in the RSL. The RSL is verified for the tag from the
+// first entry so that the policies used to verify the tag's RSL entry and tag
+// object are themselves verified. The tag's reference and the expected Git ID
+// for it in the RSL are returned if the policy verification is successful.
+func VerifyTagRef(ctx context.Context, repo *git.Repository, id string, opts ...VerificationOption) (string, plumbing.Hash, error) {
+	tagRef, err := resolveTagReference(repo, id)
+	if err != nil {
+		return "", plumbing.ZeroHash, err
+	}
+
+	slog.Debug(fmt.Sprintf("Identifying RSL entry for '%s'...", tagRef))
+	entry, _, err := rsl.GetLatestReferenceEntryForRef(repo, tagRef)
+	if err != nil {
+		return "", plumbing.ZeroHash, err
+	}
+
+	if _, _, err := rsl.GetLatestReferenceEntryForRefBefore(repo, tagRef, entry.GetID()); err == nil {
+		return "", plumbing.ZeroHash, ErrMultipleTagRSLEntries
+	} else if !errors.Is(err, rsl.ErrRSLEntryNotFound) {
+		return "", plumbing.ZeroHash, err
+	}
+
+	expectedTip, err := VerifyRefFull(ctx, repo, tagRef, opts...)
+	if err != nil {
+		return "", plumbing.ZeroHash, err
+	}
+
+	return tagRef, expectedTip, nil
+}
+
+// resolveTagReference returns the reference for the tag identified by id,
+// which may be the tag's name, its reference, or the ID of the tag object.
+func resolveTagReference(repo *git.Repository, id string) (string, error) {
+	absPath, err := gitinterface.AbsoluteReference(repo, id)
+	if err == nil {
+		if !strings.HasPrefix(absPath, gitinterface.TagRefPrefix) {
+			return "", ErrNotTag
+		}
+		return absPath, nil
+	}
+	if !errors.Is(err, gitinterface.ErrReferenceNotFound) {
+		return "", err
+	}
+
+	// Must be a hash
+	tagObj, err := gitinterface.GetTag(repo, plumbing.NewHash(id))
+	if err != nil {
+		return "", ErrNotTag
+	}
+	return string(plumbing.NewTagReferenceName(tagObj.Name)), nil
+}
+
 // VerifyNewState ensures that when a new policy is encountered, its root role
-// is signed by keys trusted in the current policy.
+// is signed by keys trusted in the current policy. It also ensures the new
+// policy does not roll back the versions of the current policy's root of trust,
+// policy files, and snapshot metadata.
 func (s *State) VerifyNewState(ctx context.Context, newPolicy *State) error {
 	rootVerifier, err := s.getRootVerifier()
 	if err != nil {
 		return err
 	}
 
-	return rootVerifier.Verify(ctx, nil, newPolicy.RootEnvelope)
+	if err := rootVerifier.Verify(ctx, nil, newPolicy.RootEnvelope); err != nil {
+		return err
+	}
+
+	if err := s.verifyMetadataVersions(newPolicy); err != nil {
+		return err
+	}
+
+	return s.verifySnapshotVersion(newPolicy)
+}
+
+// VerifyEntryWithState verifies entry using the specified policy State and
+// attestations rather than the ones recorded in the repository's RSL. This
+// allows changes in one repository to be verified against the policy of
+// another, such as a fork's changes against its upstream's policy.
+func VerifyEntryWithState(ctx context.Context, repo *git.Repository, state *State, attestationsState *attestations.Attestations, entry *rsl.ReferenceEntry) error {
+	return verifyEntry(ctx, repo, state, attestationsState, entry)
 }
 
 // verifyEntry is a helper to verify an entry's signature using the specified
@@ -518,10 +844,23 @@ func (s *State) VerifyNewState(ctx context.Context, newPolicy *State) error {
 // commit's first entry into the repository. If the commit is brand new to the
 // repository, the specified policy is used.
 func verifyEntry(ctx context.Context, repo *git.Repository, policy *State, attestationsState *attestations.Attestations, entry *rsl.ReferenceEntry) error {
+	ctx = withExplainedEntry(ctx, entry.ID)
+
 	if entry.RefName == PolicyRef || entry.RefName == attestations.Ref {
+		explain(ctx, "Entry records gittuf metadata in '%s', no rules apply", entry.RefName)
 		return nil
 	}
 
+	explain(ctx, "Verifying entry that sets '%s' to '%s'", entry.RefName, entry.TargetID)
+
+	if entry.IsDeletion() {
+		return verifyDeletionEntry(ctx, repo, policy, entry)
+	}
+
+	if err := verifyTargetDigest(repo, entry); err != nil {
+		return err
+	}
+
 	if strings.HasPrefix(entry.RefName, gitinterface.TagRefPrefix) {
 		return verifyTagEntry(ctx, repo, policy, entry)
 	}
@@ -532,10 +871,12 @@ func verifyEntry(ctx context.Context, repo *git.Repository, policy *State, attes
 	)
 
 	// Find authorized verifiers for entry's ref
-	verifiers, err := policy.FindVerifiersForPath(fmt.Sprintf("%s:%s", gitReferenceRuleScheme, entry.RefName))
+	matchedVerifiers, err := policy.FindVerifiersForPath(fmt.Sprintf("%s:%s", gitReferenceRuleScheme, entry.RefName))
 	if err != nil {
 		return err
 	}
+	verifiers, denied := applyDenyRules(matchedVerifiers)
+	explainMatchedRules(ctx, verifiers, denied, "'%s'", entry.RefName)
 
 	// No verifiers => no restrictions for the git namespace
 	if len(verifiers) == 0 {
@@ -555,25 +896,114 @@ func verifyEntry(ctx context.Context, repo *git.Repository, policy *State, attes
 			return err
 		}
 	}
+	if authorizationAttestation != nil {
+		explain(ctx, "Found authorization attestation with %d signature(s)", len(authorizationAttestation.Signatures))
+	}
+
+	approvers, err := getApprovers(ctx, repo, policy, attestationsState, entry)
+	if err != nil {
+		return err
+	}
+	if len(approvers) > 0 {
+		explain(ctx, "Found approvals from %s", strings.Join(approvers, ", "))
+	}
+
+	reviews, err := getCodeReviews(repo, attestationsState, entry)
+	if err != nil {
+		return err
+	}
+	if len(reviews) > 0 {
+		explain(ctx, "Found %d code review(s)", len(reviews))
+	}
 
 	// Use each verifier to verify signature
+	var revocationErr, thresholdErr error
 	for _, verifier := range verifiers {
-		err := verifier.Verify(ctx, commitObj, authorizationAttestation)
+		err := verifier.VerifyWithApprovers(ctx, commitObj, authorizationAttestation, getApproversForVerifier(ctx, verifier, approvers, reviews))
+		explainRuleResult(ctx, verifier, err)
 		if err == nil {
 			// Signature verification succeeded
 			gitNamespaceVerified = true
+			if denied {
+				// Every deny rule must be met
+				continue
+			}
 			break
 		} else if !errors.Is(err, ErrVerifierConditionsUnmet) {
 			// Unexpected error
 			return err
 		}
+		if errors.Is(err, ErrKeyRevoked) {
+			revocationErr = err
+		}
+		if errors.Is(err, ErrThresholdUnmet) {
+			thresholdErr = err
+		}
+		if denied {
+			gitNamespaceVerified = false
+			break
+		}
 		// Haven't found a valid verifier, continue with next
 	}
 
 	if !gitNamespaceVerified {
+		if revocationErr != nil {
+			return fmt.Errorf("verifying Git namespace policies failed, %w: %w", ErrUnauthorizedSignature, revocationErr)
+		}
+		if thresholdErr != nil {
+			return fmt.Errorf("verifying Git namespace policies failed, %w: %w", ErrUnauthorizedSignature, thresholdErr)
+		}
 		return fmt.Errorf("verifying Git namespace policies failed, %w", ErrUnauthorizedSignature)
 	}
 
+	if err := verifyGlobalRules(ctx, policy, entry.RefName, verifiers, commitObj, authorizationAttestation, approvers, reviews); err != nil {
+		return err
+	}
+
+	// The constraints of allow rules apply even when a deny rule matches the
+	// ref, so they're checked using all the matched verifiers
+	if err := verifyConstraints(ctx, matchedVerifiers, newChangeFacts(repo, entry, commitObj, approvers)); err != nil {
+		return err
+	}
+
+	if err := verifyCommitMessages(ctx, repo, matchedVerifiers, entry); err != nil {
+		return err
+	}
+
+	// As with constraints, the merge strategy and force push authorizers of
+	// allow rules apply even when a deny rule matches the ref
+	mergeStrategy := getMergeStrategy(matchedVerifiers)
+	if mergeStrategy == MergeStrategyReviewAttestation {
+		isMerge, err := isMergeEntry(repo, entry)
+		if err != nil {
+			return err
+		}
+
+		if isMerge && !isReviewed(ctx, verifiers, authorizationAttestation, approvers, reviews) {
+			return fmt.Errorf("verifying Git namespace policies failed, merge requires review attestation, %w", ErrUnauthorizedSignature)
+		}
+	}
+
+	if len(verifiers) > 0 && strings.HasPrefix(entry.RefName, gitinterface.BranchRefPrefix) {
+		isForcePush, err := isForcePushEntry(repo, entry)
+		if err != nil {
+			return err
+		}
+
+		if isForcePush {
+			slog.Debug(fmt.Sprintf("Entry '%s' rewrites history of '%s', checking for authorization...", entry.ID.String(), entry.RefName))
+			authorized, err := isForcePushAuthorized(ctx, repo, matchedVerifiers, entry)
+			if err != nil {
+				return err
+			}
+			explain(ctx, "Entry rewrites history of '%s', authorized: %t", entry.RefName, authorized)
+
+			if !authorized {
+				return fmt.Errorf("verifying Git namespace policies failed, %w: entry '%s' rewrites history of '%s' without an authorizing annotation", ErrUnauthorizedForcePush, entry.ID.String(), entry.RefName)
+			}
+		}
+	}
+
 	hasFileRule, err := policy.hasFileRule()
 	if err != nil {
 		return err
@@ -585,21 +1015,33 @@ func verifyEntry(ctx context.Context, repo *git.Repository, policy *State, attes
 
 	// Verify modified files
 
-	// First, get all commits between the current and last entry for the ref.
-	commits, err := getCommits(repo, entry) // note: this is ordered by commit ID
+	// First, get all commits between the current and last entry for the ref
+	// that must be verified using the merge strategy.
+	changes, err := getChangesForMergeStrategy(repo, entry, mergeStrategy)
+	if err != nil {
+		return err
+	}
+
+	hasSubmoduleRule, err := policy.hasSubmoduleRule()
 	if err != nil {
 		return err
 	}
 
-	commitsVerified := make([]bool, len(commits))
-	for i, commit := range commits {
+	// Only report threshold failures from file rules below
+	thresholdErr = nil
+
+	commitsVerified := make([]bool, len(changes))
+	for i, change := range changes {
 		// Assume the commit's paths are verified, if a path is left unverified,
 		// we flip this later.
 		commitsVerified[i] = true
 
-		paths, err := gitinterface.GetFilePathsChangedByCommit(repo, commit)
-		if err != nil {
-			return err
+		commit, paths := change.commit, change.paths
+
+		if hasSubmoduleRule {
+			if err := verifySubmoduleUpdates(ctx, repo, policy, commit); err != nil {
+				return fmt.Errorf("verifying file namespace policies failed, %w", err)
+			}
 		}
 
 		pathsVerified := make([]bool, len(paths))
@@ -610,12 +1052,19 @@ func verifyEntry(ctx context.Context, repo *git.Repository, policy *State, attes
 				return err
 			}
 
+			if err := verifyFileRestrictions(ctx, verifiers, commit, path); err != nil {
+				return fmt.Errorf("verifying file namespace policies failed, %w", err)
+			}
+
+			verifiers, denied := applyDenyRules(verifiers)
+			explainMatchedRules(ctx, verifiers, denied, "path '%s' in commit '%s'", path, commit.Hash)
+
 			if len(verifiers) == 0 {
 				pathsVerified[j] = true
 				continue
 			}
 
-			if len(verifiedUsing) > 0 {
+			if len(verifiedUsing) > 0 && !denied {
 				// We've already verified and identified commit signature, we
 				// can just check if that verifier is trusted for the new path.
 				// If not found, we don't make any assumptions about it being a
@@ -627,6 +1076,7 @@ func verifyEntry(ctx context.Context, repo *git.Repository, policy *State, attes
 				// policies
 				for _, verifier := range verifiers {
 					if verifier.Name() == verifiedUsing {
+						explain(ctx, "Rule '%s' protecting path '%s' was already met by commit '%s'", verifiedUsing, path, commit.Hash)
 						pathsVerified[j] = true
 						break
 					}
@@ -638,16 +1088,28 @@ func verifyEntry(ctx context.Context, repo *git.Repository, policy *State, attes
 			}
 
 			for _, verifier := range verifiers {
-				err := verifier.Verify(ctx, commit, authorizationAttestation)
+				err := verifier.VerifyWithApprovers(ctx, commit, authorizationAttestation, getApproversForVerifier(ctx, verifier, approvers, reviews))
+				explainRuleResult(ctx, verifier, err)
 				if err == nil {
 					// Signature verification succeeded
 					pathsVerified[j] = true
+					if denied {
+						// Every deny rule must be met
+						continue
+					}
 					verifiedUsing = verifier.Name()
 					break
 				} else if !errors.Is(err, ErrVerifierConditionsUnmet) {
 					// Unexpected error
 					return err
 				}
+				if errors.Is(err, ErrThresholdUnmet) {
+					thresholdErr = err
+				}
+				if denied {
+					pathsVerified[j] = false
+					break
+				}
 			}
 		}
 
@@ -671,64 +1133,239 @@ func verifyEntry(ctx context.Context, repo *git.Repository, policy *State, attes
 	}
 
 	if !pathNamespaceVerified {
+		if thresholdErr != nil {
+			return fmt.Errorf("verifying file namespace policies failed, %w: %w", ErrUnauthorizedSignature, thresholdErr)
+		}
 		return fmt.Errorf("verifying file namespace policies failed, %w", ErrUnauthorizedSignature)
 	}
 
 	return nil
 }
 
-func verifyTagEntry(ctx context.Context, repo *git.Repository, policy *State, entry *rsl.ReferenceEntry) error {
-	// 1. Find authorized public keys for tag's RSL entry
-	trustedKeys, err := policy.FindPublicKeysForPath(ctx, fmt.Sprintf("git:%s", entry.RefName))
+// verifySubmoduleUpdates verifies the submodules added or updated by the
+// commit against the rules protecting their paths. A submodule must point to a
+// URL allowed by every rule that restricts URLs. If any rule requires it, the
+// commit pinned by the submodule must also be recorded in and verified against
+// the submodule's own RSL.
+func verifySubmoduleUpdates(ctx context.Context, repo *git.Repository, policy *State, commit *object.Commit) error {
+	submodules, err := gitinterface.GetSubmoduleUpdates(repo, commit)
 	if err != nil {
 		return err
 	}
 
-	if len(trustedKeys) == 0 {
-		allKeys, err := policy.PublicKeys()
+	submodulePaths := make([]string, 0, len(submodules))
+	for submodulePath := range submodules {
+		submodulePaths = append(submodulePaths, submodulePath)
+	}
+	slices.Sort(submodulePaths)
+
+	for _, submodulePath := range submodulePaths {
+		verifiers, err := policy.FindVerifiersForPath(fmt.Sprintf("%s:%s", fileRuleScheme, submodulePath))
 		if err != nil {
 			return err
 		}
 
-		// FIXME: decide if we want to pass around map or slice for these APIs
-		for _, key := range allKeys {
-			trustedKeys = append(trustedKeys, key)
+		allowedURLs := [][]string{}
+		requireVerification := false
+		for _, verifier := range verifiers {
+			if len(verifier.submoduleURLs) > 0 {
+				allowedURLs = append(allowedURLs, verifier.submoduleURLs)
+			}
+			requireVerification = requireVerification || verifier.requireSubmoduleVerification
 		}
-	}
 
-	// 2. Find commit object for the RSL entry
-	commitObj, err := gitinterface.GetCommit(repo, entry.ID)
-	if err != nil {
-		return err
-	}
-
-	// 3. Use each trusted key to verify signature
-	rslEntryVerified := false
-	for _, key := range trustedKeys {
-		err := gitinterface.VerifyCommitSignature(ctx, commitObj, key)
-		if err == nil {
-			// Signature verification succeeded
-			rslEntryVerified = true
-			break
-		}
-		if errors.Is(err, gitinterface.ErrUnknownSigningMethod) {
-			// We encounter this for key types that can be used for gittuf
-			// policy metadata but not Git objects
+		if len(allowedURLs) == 0 && !requireVerification {
 			continue
 		}
-		if !errors.Is(err, gitinterface.ErrIncorrectVerificationKey) {
-			// Unexpected error
+
+		submoduleConfig, err := gitinterface.GetSubmoduleConfig(repo, commit, submodulePath)
+		if err != nil {
+			if errors.Is(err, gitinterface.ErrSubmoduleNotConfigured) {
+				return fmt.Errorf("%w: %w", ErrSubmoduleURLNotAllowed, err)
+			}
 			return err
 		}
-		// Haven't found a valid key, continue with next key
-	}
 
-	if !rslEntryVerified {
-		return fmt.Errorf("verifying RSL entry failed, %w", ErrUnauthorizedSignature)
-	}
+		for _, urls := range allowedURLs {
+			if !isSubmoduleURLAllowed(submoduleConfig.URL, urls) {
+				return fmt.Errorf("%w: submodule '%s' in commit '%s' points to '%s'", ErrSubmoduleURLNotAllowed, submodulePath, commit.Hash.String(), submoduleConfig.URL)
+			}
+		}
+
+		if requireVerification {
+			slog.Debug(fmt.Sprintf("Verifying commit '%s' pinned by submodule '%s'...", submodules[submodulePath].String(), submodulePath))
+			if err := verifySubmoduleCommit(ctx, repo, submoduleConfig.Name, submodules[submodulePath]); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// verifySubmoduleCommit verifies that the commit is recorded in the RSL of the
+// submodule's repository, and that the submodule's RSL verifies for the
+// reference the commit was first recorded for, up to that entry.
+func verifySubmoduleCommit(ctx context.Context, repo *git.Repository, submoduleName string, commitID plumbing.Hash) error {
+	submoduleRepo, err := gitinterface.GetSubmoduleRepository(repo, submoduleName)
+	if err != nil {
+		if errors.Is(err, gitinterface.ErrSubmoduleNotFound) {
+			return fmt.Errorf("%w: %w", ErrSubmoduleUnavailable, err)
+		}
+		return err
+	}
+
+	commit, err := gitinterface.GetCommit(submoduleRepo, commitID)
+	if err != nil {
+		if errors.Is(err, plumbing.ErrObjectNotFound) {
+			return fmt.Errorf("%w: commit '%s' not found in submodule '%s'", ErrSubmoduleUnavailable, commitID.String(), submoduleName)
+		}
+		return err
+	}
+
+	entry, _, err := rsl.GetFirstReferenceEntryForCommit(submoduleRepo, commit)
+	if err != nil {
+		return fmt.Errorf("%w: unable to find RSL entry for commit '%s' in submodule '%s': %w", ErrSubmoduleVerificationFailed, commitID.String(), submoduleName, err)
+	}
+
+	firstEntry, _, err := rsl.GetFirstEntry(submoduleRepo)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrSubmoduleVerificationFailed, err)
+	}
+
+	if err := VerifyRelativeForRef(ctx, submoduleRepo, firstEntry, nil, firstEntry, entry, entry.RefName); err != nil {
+		return fmt.Errorf("%w: submodule '%s': %w", ErrSubmoduleVerificationFailed, submoduleName, err)
+	}
+
+	return nil
+}
+
+// isSubmoduleURLAllowed returns true if the URL matches one of the allowed URL
+// patterns.
+func isSubmoduleURLAllowed(url string, allowedURLs []string) bool {
+	for _, allowedURL := range allowedURLs {
+		if matched, err := path.Match(allowedURL, url); err == nil && matched {
+			return true
+		}
+	}
+
+	return false
+}
+
+// verifyTargetDigest checks that the entry's target matches the SHA-256 digest
+// recorded in the entry, if one is recorded.
+func verifyTargetDigest(repo *git.Repository, entry *rsl.ReferenceEntry) error {
+	if entry.TargetSHA256 == "" {
+		return nil
+	}
+
+	digest, err := gitinterface.ComputeSHA256Digest(repo, entry.TargetID)
+	if err != nil {
+		return err
+	}
+	if digest != entry.TargetSHA256 {
+		return fmt.Errorf("%w: entry '%s' records '%s' for '%s', found '%s'", ErrTargetDigestMismatch, entry.ID.String(), entry.TargetSHA256, entry.TargetID.String(), digest)
+	}
+
+	return nil
+}
+
+// verifyDeletionEntry verifies an entry that records the deletion of a branch
+// or tag. A protected reference may only be deleted if the entry is signed by a
+// key authorized to delete references in a rule protecting the reference. If
+// deny rules apply, each deny rule must authorize the deletion.
+func verifyDeletionEntry(ctx context.Context, repo *git.Repository, policy *State, entry *rsl.ReferenceEntry) error {
+	verifiers, err := policy.FindVerifiersForPath(fmt.Sprintf("%s:%s", gitReferenceRuleScheme, entry.RefName))
+	if err != nil {
+		return err
+	}
+	verifiers, denied := applyDenyRules(verifiers)
+	explainMatchedRules(ctx, verifiers, denied, "deletion of '%s'", entry.RefName)
+
+	// No verifiers => the reference is not protected
+	if len(verifiers) == 0 {
+		return nil
+	}
+
+	commitObj, err := gitinterface.GetCommit(repo, entry.ID)
+	if err != nil {
+		return err
+	}
+
+	authorized := false
+	for _, verifier := range verifiers {
+		deletionVerifier := &Verifier{
+			name:            verifier.name,
+			keys:            verifier.deletionKeys,
+			threshold:       1,
+			algorithmPolicy: verifier.algorithmPolicy,
+		}
+
+		err := ErrVerifierConditionsUnmet
+		if len(deletionVerifier.keys) > 0 {
+			err = deletionVerifier.Verify(ctx, commitObj, nil)
+		} else {
+			explain(ctx, "Rule '%s' does not authorize any keys to delete references", verifier.name)
+		}
+		explainRuleResult(ctx, deletionVerifier, err)
+		if err == nil {
+			authorized = true
+			if denied {
+				// Every deny rule must be met
+				continue
+			}
+			break
+		} else if !errors.Is(err, ErrVerifierConditionsUnmet) {
+			return err
+		}
+		if denied {
+			authorized = false
+			break
+		}
+	}
+
+	if !authorized {
+		return fmt.Errorf("verifying Git namespace policies failed, %w: entry '%s' deletes '%s' without a signature from a key authorized to delete it", ErrUnauthorizedDeletion, entry.ID.String(), entry.RefName)
+	}
+
+	return nil
+}
+
+// verifyTagEntry verifies an entry that records a tag. The RSL entry and the
+// tag object must both be signed in accordance with the rules protecting the
+// tag. If no rule protects the tag, they must be signed using a key trusted in
+// the policy.
+func verifyTagEntry(ctx context.Context, repo *git.Repository, policy *State, entry *rsl.ReferenceEntry) error {
+	// 1. Find authorized verifiers for tag's RSL entry
+	matchedVerifiers, err := policy.FindVerifiersForPath(fmt.Sprintf("%s:%s", gitReferenceRuleScheme, entry.RefName))
+	if err != nil && !errors.Is(err, ErrMetadataNotFound) {
+		return err
+	}
+	verifiers, denied := applyDenyRules(matchedVerifiers)
+	explainMatchedRules(ctx, verifiers, denied, "'%s'", entry.RefName)
+
+	tagVerifiers := verifiers
+	if len(tagVerifiers) == 0 {
+		policyKeysVerifier, err := policy.getPolicyKeysVerifier()
+		if err != nil {
+			return err
+		}
+		tagVerifiers = []*Verifier{policyKeysVerifier}
+	}
+
+	// 2. Find commit object for the RSL entry
+	commitObj, err := gitinterface.GetCommit(repo, entry.ID)
+	if err != nil {
+		return err
+	}
+
+	// 3. Verify the RSL entry's signature
+	if err := verifyGitObjectSignature(ctx, tagVerifiers, denied, commitObj); err != nil {
+		explain(ctx, "Signature on entry for tag '%s' does not meet the rules protecting the tag", entry.RefName)
+		return fmt.Errorf("verifying RSL entry failed, %w", err)
+	}
 
 	// 4. Verify tag object
-	tagObjVerified := false
 	tagObj, err := gitinterface.GetTag(repo, entry.TargetID)
 	if err != nil {
 		// Likely indicates the ref is not pointing to a tag object
@@ -749,64 +1386,448 @@ func verifyTagEntry(ctx context.Context, repo *git.Repository, policy *State, en
 		return fmt.Errorf(noSignatureMessage)
 	}
 
-	for _, key := range trustedKeys {
-		err := gitinterface.VerifyTagSignature(ctx, tagObj, key)
+	if err := verifyGitObjectSignature(ctx, tagVerifiers, denied, tagObj); err != nil {
+		explain(ctx, "Signature on tag object '%s' does not meet the rules protecting the tag", tagObj.Hash)
+		return fmt.Errorf("verifying tag object's signature failed, %w", err)
+	}
+
+	// 5. Verify global rules using the RSL entry
+	if err := verifyGlobalRules(ctx, policy, entry.RefName, verifiers, commitObj, nil, nil, nil); err != nil {
+		return err
+	}
+
+	return verifyConstraints(ctx, matchedVerifiers, newChangeFacts(repo, entry, commitObj, nil))
+}
+
+// verifyGitObjectSignature checks that the signature on gitObject meets the
+// verifiers. If denied is set, every verifier must be met, otherwise any one
+// verifier is sufficient.
+func verifyGitObjectSignature(ctx context.Context, verifiers []*Verifier, denied bool, gitObject object.Object) error {
+	verified := false
+	var revocationErr, thresholdErr error
+	for _, verifier := range verifiers {
+		err := verifier.Verify(ctx, gitObject, nil)
+		explainRuleResult(ctx, verifier, err)
 		if err == nil {
-			// Signature verification succeeded
-			tagObjVerified = true
+			verified = true
+			if denied {
+				// Every deny rule must be met
+				continue
+			}
 			break
-		}
-		if errors.Is(err, gitinterface.ErrUnknownSigningMethod) {
-			// We encounter this for key types that can be used for gittuf
-			// policy metadata but not Git objects
-			continue
-		}
-		if !errors.Is(err, gitinterface.ErrIncorrectVerificationKey) {
+		} else if !errors.Is(err, ErrVerifierConditionsUnmet) {
 			// Unexpected error
 			return err
 		}
-		// Haven't found a valid key, continue with next key
+		if errors.Is(err, ErrKeyRevoked) {
+			revocationErr = err
+		}
+		if errors.Is(err, ErrThresholdUnmet) {
+			thresholdErr = err
+		}
+		if denied {
+			verified = false
+			break
+		}
 	}
 
-	if !tagObjVerified {
-		return fmt.Errorf("verifying tag object's signature failed, %w", ErrUnauthorizedSignature)
+	if !verified {
+		if revocationErr != nil {
+			return fmt.Errorf("%w: %w", ErrUnauthorizedSignature, revocationErr)
+		}
+		if thresholdErr != nil {
+			return fmt.Errorf("%w: %w", ErrUnauthorizedSignature, thresholdErr)
+		}
+		return ErrUnauthorizedSignature
 	}
 
 	return nil
 }
 
+// applyDenyRules returns the verifiers of the deny rules in verifiers and true
+// if any deny rule applies, as deny rules take precedence over allow rules.
+// Unlike allow rules, where meeting any one rule is sufficient, every deny
+// rule that applies must be met. If no deny rule applies, verifiers is
+// returned unchanged.
+func applyDenyRules(verifiers []*Verifier) ([]*Verifier, bool) {
+	denyVerifiers := []*Verifier{}
+	for _, verifier := range verifiers {
+		if verifier.deny {
+			denyVerifiers = append(denyVerifiers, verifier)
+		}
+	}
+	if len(denyVerifiers) == 0 {
+		return verifiers, false
+	}
+
+	return denyVerifiers, true
+}
+
+// getAuthorizationAttestation returns the detached authorization for the
+// change recorded in the entry. A push authorization for the entry's exact
+// target is preferred over a reference authorization for the target's tree.
 func getAuthorizationAttestation(repo *git.Repository, attestationsState *attestations.Attestations, entry *rsl.ReferenceEntry) (*sslibdsse.Envelope, error) {
-	firstEntry := false
+	fromID, treeID, err := getAttestationParameters(repo, entry)
+	if err != nil {
+		return nil, err
+	}
 
-	priorRefEntry, _, err := rsl.GetLatestReferenceEntryForRefBefore(repo, entry.RefName, entry.ID)
+	pushAuthorization, err := attestationsState.GetPushAuthorizationFor(repo, entry.RefName, fromID.String(), entry.TargetID.String())
+	if err == nil {
+		return pushAuthorization, nil
+	} else if !errors.Is(err, attestations.ErrPushAuthorizationNotFound) {
+		return nil, err
+	}
+
+	attestation, err := attestationsState.GetReferenceAuthorizationFor(repo, entry.RefName, fromID.String(), treeID.String())
 	if err != nil {
-		if !errors.Is(err, rsl.ErrRSLEntryNotFound) {
+		if errors.Is(err, attestations.ErrAuthorizationNotFound) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return attestation, nil
+}
+
+// getApprovers returns the key IDs of the forge identities that approved the
+// change recorded in the entry, as attested to by GitHub pull request and
+// GitLab merge request approval attestations.
+func getApprovers(ctx context.Context, repo *git.Repository, policy *State, attestationsState *attestations.Attestations, entry *rsl.ReferenceEntry) ([]string, error) {
+	if attestationsState == nil {
+		return nil, nil
+	}
+
+	rootMetadata, err := policy.GetRootMetadata()
+	if err != nil {
+		return nil, err
+	}
+
+	_, hasGitHubApp := rootMetadata.Roles[GitHubAppRoleName]
+	_, hasGitLabApp := rootMetadata.Roles[GitLabAppRoleName]
+	if !hasGitHubApp && !hasGitLabApp {
+		return nil, nil
+	}
+
+	fromID, treeID, err := getAttestationParameters(repo, entry)
+	if err != nil {
+		return nil, err
+	}
+
+	approvers := []string{}
+
+	if hasGitHubApp {
+		env, logins, err := attestationsState.GetGitHubPullRequestApprovalFor(repo, entry.RefName, fromID.String(), treeID.String())
+		if err == nil {
+			if err := verifyApprovalAttestation(ctx, rootMetadata, GitHubAppRoleName, env); err == nil {
+				for _, login := range logins {
+					approvers = append(approvers, github.IdentityKeyID(login))
+				}
+			} else {
+				slog.Debug(fmt.Sprintf("Unable to verify GitHub pull request approval attestation for entry '%s': %s", entry.ID.String(), err.Error()))
+			}
+		} else if !errors.Is(err, attestations.ErrGitHubPullRequestApprovalNotFound) {
 			return nil, err
 		}
+	}
 
-		firstEntry = true
+	if hasGitLabApp {
+		env, usernames, err := attestationsState.GetGitLabMergeRequestApprovalFor(repo, entry.RefName, fromID.String(), treeID.String())
+		if err == nil {
+			if err := verifyApprovalAttestation(ctx, rootMetadata, GitLabAppRoleName, env); err == nil {
+				for _, username := range usernames {
+					approvers = append(approvers, gitlab.IdentityKeyID(username))
+				}
+			} else {
+				slog.Debug(fmt.Sprintf("Unable to verify GitLab merge request approval attestation for entry '%s': %s", entry.ID.String(), err.Error()))
+			}
+		} else if !errors.Is(err, attestations.ErrGitLabMergeRequestApprovalNotFound) {
+			return nil, err
+		}
+	}
+
+	return approvers, nil
+}
+
+// verifyApprovalAttestation verifies the approval attestation using the keys
+// and threshold of the specified app role in the root of trust.
+func verifyApprovalAttestation(ctx context.Context, rootMetadata *tuf.RootMetadata, roleName string, env *sslibdsse.Envelope) error {
+	role := rootMetadata.Roles[roleName]
+
+	keys := []*tuf.Key{}
+	for _, keyID := range role.KeyIDs {
+		key, ok := rootMetadata.Keys[keyID]
+		if !ok || rootMetadata.IsKeyRevoked(keyID) {
+			continue
+		}
+		keys = append(keys, key)
+	}
+
+	verifier := &Verifier{name: roleName, keys: keys, threshold: role.Threshold}
+	return verifier.Verify(ctx, nil, env)
+}
+
+// getCodeReviews returns the code reviews recorded for the change in the
+// entry.
+func getCodeReviews(repo *git.Repository, attestationsState *attestations.Attestations, entry *rsl.ReferenceEntry) ([]*attestations.SignedCodeReview, error) {
+	if attestationsState == nil {
+		return nil, nil
 	}
 
+	fromID, treeID, err := getAttestationParameters(repo, entry)
+	if err != nil {
+		return nil, err
+	}
+
+	return attestationsState.GetCodeReviewsFor(repo, entry.RefName, fromID.String(), treeID.String())
+}
+
+// getApproversForVerifier combines the forge approvers of a change with the
+// verifier's keys that approved the change in code reviews.
+func getApproversForVerifier(ctx context.Context, verifier *Verifier, approvers []string, reviews []*attestations.SignedCodeReview) []string {
+	if len(reviews) == 0 {
+		return approvers
+	}
+
+	combined := make([]string, 0, len(approvers)+len(reviews))
+	combined = append(combined, approvers...)
+	return append(combined, verifier.approvingReviewers(ctx, reviews)...)
+}
+
+// getAttestationParameters returns the ID the entry's ref pointed to prior to
+// the entry and the tree ID of the entry's target, which together identify the
+// attestations that apply to the entry.
+func getAttestationParameters(repo *git.Repository, entry *rsl.ReferenceEntry) (plumbing.Hash, plumbing.Hash, error) {
 	fromID := plumbing.ZeroHash
-	if !firstEntry {
+
+	priorRefEntry, _, err := rsl.GetLatestReferenceEntryForRefBefore(repo, entry.RefName, entry.ID)
+	if err != nil {
+		if !errors.Is(err, rsl.ErrRSLEntryNotFound) {
+			return plumbing.ZeroHash, plumbing.ZeroHash, err
+		}
+	} else {
 		fromID = priorRefEntry.TargetID
 	}
 
 	currentCommit, err := gitinterface.GetCommit(repo, entry.TargetID)
 	if err != nil {
+		return plumbing.ZeroHash, plumbing.ZeroHash, err
+	}
+
+	return fromID, currentCommit.TreeHash, nil
+}
+
+// commitChanges records the paths changed by a commit that must be verified
+// using the commit's signature.
+type commitChanges struct {
+	commit *object.Commit
+	paths  []string
+}
+
+// getMergeStrategy returns the merge strategy of the first verifier that sets
+// one. If none of the verifiers set a merge strategy, all commits are verified.
+func getMergeStrategy(verifiers []*Verifier) string {
+	for _, verifier := range verifiers {
+		if verifier.mergeStrategy != "" {
+			return verifier.mergeStrategy
+		}
+	}
+
+	return MergeStrategyAllCommits
+}
+
+// isMergeEntry returns true if the entry's target is a merge commit.
+func isMergeEntry(repo *git.Repository, entry *rsl.ReferenceEntry) (bool, error) {
+	targetCommit, err := gitinterface.GetCommit(repo, entry.TargetID)
+	if err != nil {
+		return false, err
+	}
+
+	return len(targetCommit.ParentHashes) > 1, nil
+}
+
+// isReviewed returns true if the authorization attestation, forge approvers,
+// and code reviews meet the constraints of one of the verifiers without the
+// entry's signature.
+func isReviewed(ctx context.Context, verifiers []*Verifier, authorizationAttestation *sslibdsse.Envelope, approvers []string, reviews []*attestations.SignedCodeReview) bool {
+	if authorizationAttestation == nil && len(approvers) == 0 && len(reviews) == 0 {
+		return false
+	}
+
+	for _, verifier := range verifiers {
+		if err := verifier.VerifyWithApprovers(ctx, nil, authorizationAttestation, getApproversForVerifier(ctx, verifier, approvers, reviews)); err == nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isForcePushEntry indicates if the entry rewrites the history of its
+// reference, i.e., if the target of the last unskipped entry for the reference
+// is not reachable from the entry's target.
+func isForcePushEntry(repo *git.Repository, entry *rsl.ReferenceEntry) (bool, error) {
+	if entry.IsDeletion() {
+		// Deletions are not history rewrites
+		return false, nil
+	}
+
+	priorEntry, _, err := rsl.GetLatestUnskippedReferenceEntryForRefBefore(repo, entry.RefName, entry.ID)
+	if err != nil {
+		if errors.Is(err, rsl.ErrRSLEntryNotFound) {
+			// First entry for the reference
+			return false, nil
+		}
+		return false, err
+	}
+
+	if priorEntry.TargetID.IsZero() {
+		return false, nil
+	}
+
+	priorCommit, err := gitinterface.GetCommit(repo, priorEntry.TargetID)
+	if err != nil {
+		return false, err
+	}
+
+	knows, err := gitinterface.KnowsCommit(repo, entry.TargetID, priorCommit)
+	if err != nil {
+		return false, err
+	}
+
+	return !knows, nil
+}
+
+// isForcePushAuthorized checks if a history rewrite recorded in the entry is
+// authorized by an annotation signed by a key trusted to authorize force pushes
+// in one of the verifiers.
+func isForcePushAuthorized(ctx context.Context, repo *git.Repository, verifiers []*Verifier, entry *rsl.ReferenceEntry) (bool, error) {
+	annotations, err := rsl.GetAnnotationsForEntry(repo, entry.ID)
+	if err != nil {
+		return false, err
+	}
+
+	for _, annotation := range annotations {
+		if annotation.Skip {
+			continue
+		}
+
+		annotationCommit, err := gitinterface.GetCommit(repo, annotation.ID)
+		if err != nil {
+			return false, err
+		}
+
+		for _, verifier := range verifiers {
+			if len(verifier.forcePushKeys) == 0 {
+				continue
+			}
+
+			forcePushVerifier := &Verifier{
+				name:            verifier.name,
+				keys:            verifier.forcePushKeys,
+				threshold:       1,
+				algorithmPolicy: verifier.algorithmPolicy,
+			}
+			err := forcePushVerifier.Verify(ctx, annotationCommit, nil)
+			if err == nil {
+				return true, nil
+			} else if !errors.Is(err, ErrVerifierConditionsUnmet) {
+				return false, err
+			}
+		}
+	}
+
+	return false, nil
+}
+
+// getChangesForMergeStrategy identifies the commits introduced by the entry
+// that must be verified, along with the paths each commit is responsible for,
+// using the specified merge strategy.
+func getChangesForMergeStrategy(repo *git.Repository, entry *rsl.ReferenceEntry, mergeStrategy string) ([]*commitChanges, error) {
+	priorTargetID := plumbing.ZeroHash
+	priorRefEntry, _, err := rsl.GetLatestReferenceEntryForRefBefore(repo, entry.RefName, entry.ID)
+	if err == nil {
+		priorTargetID = priorRefEntry.TargetID
+	} else if !errors.Is(err, rsl.ErrRSLEntryNotFound) {
 		return nil, err
 	}
 
-	attestation, err := attestationsState.GetReferenceAuthorizationFor(repo, entry.RefName, fromID.String(), currentCommit.TreeHash.String())
+	switch mergeStrategy {
+	case MergeStrategyFirstParent:
+		commits, err := gitinterface.GetFirstParentCommitsBetweenRange(repo, entry.TargetID, priorTargetID)
+		if err != nil {
+			return nil, err
+		}
+
+		changes := make([]*commitChanges, 0, len(commits))
+		for _, commit := range commits {
+			var paths []string
+			if len(commit.ParentHashes) == 0 {
+				paths, err = gitinterface.GetCommitFilePaths(commit)
+			} else {
+				paths, err = getDiffFilePathsFromCommitID(repo, commit, commit.ParentHashes[0])
+			}
+			if err != nil {
+				return nil, err
+			}
+
+			changes = append(changes, &commitChanges{commit: commit, paths: paths})
+		}
+
+		return changes, nil
+
+	case MergeStrategyMergeCommitter, MergeStrategyReviewAttestation:
+		targetCommit, err := gitinterface.GetCommit(repo, entry.TargetID)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(targetCommit.ParentHashes) < 2 {
+			// Not a merge, so all commits are verified
+			break
+		}
+
+		var paths []string
+		if priorTargetID.IsZero() {
+			paths, err = gitinterface.GetCommitFilePaths(targetCommit)
+		} else {
+			paths, err = getDiffFilePathsFromCommitID(repo, targetCommit, priorTargetID)
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		return []*commitChanges{{commit: targetCommit, paths: paths}}, nil
+	}
+
+	commits, err := getCommits(repo, entry) // note: this is ordered by commit ID
 	if err != nil {
-		if errors.Is(err, attestations.ErrAuthorizationNotFound) {
-			return nil, nil
+		return nil, err
+	}
+
+	changes := make([]*commitChanges, 0, len(commits))
+	for _, commit := range commits {
+		paths, err := gitinterface.GetFilePathsChangedByCommit(repo, commit)
+		if err != nil {
+			return nil, err
 		}
 
+		changes = append(changes, &commitChanges{commit: commit, paths: paths})
+	}
+
+	return changes, nil
+}
+
+// getDiffFilePathsFromCommitID returns the paths that differ between the commit
+// and the commit identified by otherCommitID.
+func getDiffFilePathsFromCommitID(repo *git.Repository, commit *object.Commit, otherCommitID plumbing.Hash) ([]string, error) {
+	otherCommit, err := gitinterface.GetCommit(repo, otherCommitID)
+	if err != nil {
 		return nil, err
 	}
 
-	return attestation, nil
+	return gitinterface.GetDiffFilePaths(commit, otherCommit)
 }
 
 // getCommits identifies the commits introduced to the entry's ref since the
@@ -868,9 +1889,80 @@ func getChangedPaths(repo *git.Repository, entry *rsl.ReferenceEntry) ([]string,
 }
 
 type Verifier struct {
-	name      string
-	keys      []*tuf.Key
-	threshold int
+	name          string
+	keys          []*tuf.Key
+	threshold     int
+	revokedKeys   []*revokedKey
+	mergeStrategy string
+	forcePushKeys []*tuf.Key
+	deletionKeys  []*tuf.Key
+
+	// submoduleURLs and requireSubmoduleVerification constrain updates to
+	// submodules at the paths protected by the verifier.
+	submoduleURLs                []string
+	requireSubmoduleVerification bool
+
+	// regoPolicy is the Rego module that changes to the Git references
+	// protected by the verifier must meet.
+	regoPolicy string
+
+	// conditions are the CEL expressions that changes to the Git references
+	// protected by the verifier must meet.
+	conditions []string
+
+	// requireSignoff, requireConventionalCommits, and commitMessagePattern
+	// constrain the messages of commits added to the Git references protected
+	// by the verifier.
+	requireSignoff             bool
+	requireConventionalCommits bool
+	commitMessagePattern       string
+
+	// maxFileSize, forbiddenExtensions, and forbidBinaryFiles restrict the
+	// contents of files at the paths protected by the verifier.
+	maxFileSize         int64
+	forbiddenExtensions []string
+	forbidBinaryFiles   bool
+
+	// deny is set for verifiers of deny rules, whose keys are the exceptions
+	// to the rule.
+	deny bool
+
+	// people maps the IDs of keys that belong to a person to the person's
+	// ID. The threshold is met by distinct principals, where a principal is
+	// either a person or a key that does not belong to a person.
+	people map[string]string
+
+	// algorithmPolicy is the root of trust's algorithm policy that Git
+	// signatures must meet.
+	algorithmPolicy *tuf.AlgorithmPolicy
+}
+
+// revokedKey tracks a key that is listed in a rule but has been revoked in the
+// root of trust, so that signatures using it can be reported as such rather
+// than as signatures from an untrusted key.
+type revokedKey struct {
+	key        *tuf.Key
+	revocation *tuf.KeyRevocation
+	entryID    plumbing.Hash
+}
+
+// addPersonKey records that the key matching keyID belongs to the person
+// matching personID.
+func (v *Verifier) addPersonKey(keyID, personID string) {
+	if v.people == nil {
+		v.people = map[string]string{}
+	}
+
+	v.people[keyID] = personID
+}
+
+// principal returns the ID of the principal that the key matching keyID
+// belongs to.
+func (v *Verifier) principal(keyID string) string {
+	if personID, has := v.people[keyID]; has {
+		return personID
+	}
+	return keyID
 }
 
 func (v *Verifier) Name() string {
@@ -889,28 +1981,101 @@ func (v *Verifier) Threshold() int {
 // threshold of signatures may be met using a combination of at most one Git
 // signature and signatures embedded in a DSSE envelope. Verify does not inspect
 // the envelope's payload, but instead only verifies the signatures. The caller
-// must ensure the validity of the envelope's contents.
+// must ensure the validity of the envelope's contents. If the constraints are
+// not met and the Git object is signed using a key revoked for the verifier,
+// the returned error also wraps ErrKeyRevoked, unless a trusted timestamp
+// carried by ctx shows the signature was made before the key was revoked (see
+// WithSignatureTimestamps).
 func (v *Verifier) Verify(ctx context.Context, gitObject object.Object, env *sslibdsse.Envelope) error {
+	return v.VerifyWithApprovers(ctx, gitObject, env, nil)
+}
+
+// VerifyWithApprovers is similar to Verify, but additionally counts each of the
+// verifier's keys whose ID is in approvers towards the threshold. The approvers
+// are identities that are known to have approved the change out of band, such
+// as via a verified GitHub pull request approval attestation.
+func (v *Verifier) VerifyWithApprovers(ctx context.Context, gitObject object.Object, env *sslibdsse.Envelope, approvers []string) error {
+	err := v.verify(ctx, gitObject, env, approvers)
+	if errors.Is(err, ErrInvalidVerifier) && v.threshold > 0 && len(v.revokedKeys) > 0 {
+		// All of the verifier's keys have been revoked
+		err = ErrVerifierConditionsUnmet
+	}
+	if errors.Is(err, ErrInvalidVerifier) && v.deny {
+		// A deny rule without exceptions cannot be met
+		err = ErrVerifierConditionsUnmet
+	}
+	if !errors.Is(err, ErrVerifierConditionsUnmet) || gitObject == nil {
+		return err
+	}
+
+	for _, revoked := range v.revokedKeys {
+		var verifyErr error
+		switch o := gitObject.(type) {
+		case *object.Commit:
+			verifyErr = gitinterface.VerifyCommitSignature(ctx, o, revoked.key)
+		case *object.Tag:
+			verifyErr = gitinterface.VerifyTagSignature(ctx, o, revoked.key)
+		}
+		if verifyErr != nil {
+			continue
+		}
+
+		if signedBeforeRevocation(ctx, gitObject, revoked.revocation) {
+			slog.Debug(fmt.Sprintf("Signature using revoked key '%s' was made before revocation, trusting key...", revoked.key.KeyID))
+			v.explain(ctx, "signature made using revoked key '%s' has a trusted timestamp from before the revocation", revoked.key.KeyID)
+			trusted := *v
+			trusted.keys = append(slices.Clone(v.keys), revoked.key)
+			if trustedErr := trusted.verify(ctx, gitObject, env, approvers); trustedErr == nil {
+				return nil
+			}
+		}
+
+		v.explain(ctx, "rejected signature made using revoked key '%s'", revoked.key.KeyID)
+
+		revokedIn := ""
+		if !revoked.entryID.IsZero() {
+			revokedIn = fmt.Sprintf(" in RSL entry '%s'", revoked.entryID.String())
+		}
+		return fmt.Errorf("%w: %w: key '%s' was revoked%s at %s: %s", err, ErrKeyRevoked, revoked.key.KeyID, revokedIn, revoked.revocation.RevokedAt, revoked.revocation.Reason)
+	}
+
+	return err
+}
+
+func (v *Verifier) verify(ctx context.Context, gitObject object.Object, env *sslibdsse.Envelope, approvers []string) error {
 	if v.threshold < 1 || len(v.keys) < 1 {
 		return ErrInvalidVerifier
 	}
 
+	// Approvals from the verifier's identities count towards the threshold,
+	// the approving principals are not considered for signatures below
+	approved := map[string]bool{}
+	for keyID := range v.approvedKeyIDs(approvers) {
+		v.explain(ctx, "counted approval from key '%s' towards threshold of %d", keyID, v.threshold)
+		approved[v.principal(keyID)] = true
+	}
+	threshold := v.threshold - len(approved)
+	if threshold < 1 {
+		return nil
+	}
+
 	if gitObject == nil {
 		if env == nil {
 			// Nothing to verify, but fail closed
 			return ErrVerifierConditionsUnmet
-		} else if len(env.Signatures) < v.threshold {
+		} else if len(env.Signatures) < threshold {
 			// Envelope doesn't have enough signatures to meet threshold
 			return ErrVerifierConditionsUnmet
 		}
 	} else {
 		if env == nil {
-			if v.threshold > 1 {
+			if threshold > 1 {
 				// Single valid signature at most, so cannot meet threshold
+				v.explain(ctx, "no authorization attestation, a single Git signature cannot meet threshold of %d", threshold)
 				return ErrVerifierConditionsUnmet
 			}
 		} else {
-			if (1 + len(env.Signatures)) < v.threshold {
+			if (1 + len(env.Signatures)) < threshold {
 				// Combining the attestation and the git object we still do not
 				// have sufficient signatures
 				return ErrVerifierConditionsUnmet
@@ -926,9 +2091,18 @@ func (v *Verifier) Verify(ctx context.Context, gitObject object.Object, env *ssl
 		switch o := gitObject.(type) {
 		case *object.Commit:
 			for _, key := range v.keys {
+				if approved[v.principal(key.KeyID)] {
+					continue
+				}
+				if err := CheckGitSignatureAlgorithm(v.algorithmPolicy, key, o.PGPSignature); err != nil {
+					slog.Debug(fmt.Sprintf("Not verifying signature using key '%s': %s", key.KeyID, err.Error()))
+					v.explain(ctx, "rejected signature on '%s' for key '%s': %s", o.Hash, key.KeyID, err)
+					continue
+				}
 				err := gitinterface.VerifyCommitSignature(ctx, o, key)
 				if err == nil {
 					// Signature verification succeeded
+					v.explain(ctx, "counted signature on '%s' made using key '%s' towards threshold of %d", o.Hash, key.KeyID, v.threshold)
 					keyIDUsed = key.KeyID
 					gitObjectVerified = true
 					break
@@ -942,9 +2116,18 @@ func (v *Verifier) Verify(ctx context.Context, gitObject object.Object, env *ssl
 			}
 		case *object.Tag:
 			for _, key := range v.keys {
+				if approved[v.principal(key.KeyID)] {
+					continue
+				}
+				if err := CheckGitSignatureAlgorithm(v.algorithmPolicy, key, o.PGPSignature); err != nil {
+					slog.Debug(fmt.Sprintf("Not verifying signature using key '%s': %s", key.KeyID, err.Error()))
+					v.explain(ctx, "rejected signature on '%s' for key '%s': %s", o.Hash, key.KeyID, err)
+					continue
+				}
 				err := gitinterface.VerifyTagSignature(ctx, o, key)
 				if err == nil {
 					// Signature verification succeeded
+					v.explain(ctx, "counted signature on '%s' made using key '%s' towards threshold of %d", o.Hash, key.KeyID, v.threshold)
 					keyIDUsed = key.KeyID
 					gitObjectVerified = true
 					break
@@ -959,38 +2142,155 @@ func (v *Verifier) Verify(ctx context.Context, gitObject object.Object, env *ssl
 		default:
 			return ErrUnknownObjectType
 		}
+
+		if !gitObjectVerified {
+			v.explain(ctx, "signature on '%s' is missing or not made using any of the rule's keys", gitObject.ID())
+		}
 	}
 
 	// If threshold is 1 and the Git signature is verified, we can return
-	if v.threshold == 1 && gitObjectVerified {
+	if threshold == 1 && gitObjectVerified {
 		return nil
 	}
 
 	// Second, verify signatures on the attestation, subtracting the threshold
 	// by 1 to account for a verified Git signature
-	envelopeThreshold := v.threshold
+	envelopeThreshold := threshold
 	if gitObjectVerified {
 		envelopeThreshold--
 	}
 
 	verifiers := make([]sslibdsse.Verifier, 0, len(v.keys))
 	for _, key := range v.keys {
-		if key.KeyID == keyIDUsed {
-			// Do not create a DSSE verifier for the key used to verify the Git
-			// signature
+		principal := v.principal(key.KeyID)
+		if (gitObjectVerified && principal == v.principal(keyIDUsed)) || approved[principal] {
+			// Do not create a DSSE verifier for the principal that made the
+			// Git signature or for principals that have already approved
 			continue
 		}
 
 		verifier, err := signerverifier.NewSignerVerifierFromTUFKey(key) //nolint:staticcheck
-		if err != nil && !errors.Is(err, common.ErrUnknownKeyType) {
+		if err != nil {
+			if errors.Is(err, common.ErrUnknownKeyType) {
+				continue
+			}
 			return err
 		}
 		verifiers = append(verifiers, verifier)
 	}
 
-	if err := dsse.VerifyEnvelope(ctx, env, verifiers, envelopeThreshold); err != nil {
+	if envelopeThreshold > len(verifiers) {
+		v.explain(ctx, "%d more signature(s) required but only %d of the rule's principals can sign", envelopeThreshold, len(verifiers))
+		if gitObjectVerified {
+			return errThresholdUnmet()
+		}
+		return ErrVerifierConditionsUnmet
+	}
+
+	acceptedKeyIDs, err := dsse.GetAcceptedKeyIDs(ctx, env, verifiers)
+	if err != nil {
+		v.explain(ctx, "no signatures on authorization attestation made using the rule's keys, %d more required", envelopeThreshold)
+		if gitObjectVerified {
+			return errThresholdUnmet()
+		}
+		return ErrVerifierConditionsUnmet
+	}
+
+	// Multiple signatures from the same person count once
+	principals := map[string]bool{}
+	for _, keyID := range acceptedKeyIDs {
+		v.explain(ctx, "counted signature on authorization attestation made using key '%s' towards threshold of %d", keyID, v.threshold)
+		principals[v.principal(keyID)] = true
+	}
+	if len(principals) < envelopeThreshold {
+		v.explain(ctx, "found signatures from %d principal(s) on authorization attestation, %d required", len(principals), envelopeThreshold)
+		if gitObjectVerified || len(principals) > 0 {
+			return errThresholdUnmet()
+		}
 		return ErrVerifierConditionsUnmet
 	}
 
 	return nil
 }
+
+// explain passes the step to the ExplainFunc carried by ctx, attributing it to
+// the verifier's rule.
+func (v *Verifier) explain(ctx context.Context, format string, a ...any) {
+	explain(ctx, "Rule '%s': "+format, append([]any{v.name}, a...)...)
+}
+
+// errThresholdUnmet returns the error used when some of the verifier's
+// signatures are valid but there are not enough of them to meet its threshold.
+// The error wraps ErrVerifierConditionsUnmet so callers that do not distinguish
+// the two are unaffected.
+func errThresholdUnmet() error {
+	return fmt.Errorf("%w: %w", ErrVerifierConditionsUnmet, ErrThresholdUnmet)
+}
+
+// approvedKeyIDs returns the IDs of the verifier's keys that are in
+// approvers.
+func (v *Verifier) approvedKeyIDs(approvers []string) map[string]bool {
+	approved := map[string]bool{}
+	if len(approvers) == 0 {
+		return approved
+	}
+
+	isApprover := map[string]bool{}
+	for _, approver := range approvers {
+		isApprover[approver] = true
+	}
+
+	for _, key := range v.keys {
+		if isApprover[key.KeyID] {
+			approved[key.KeyID] = true
+		}
+	}
+
+	return approved
+}
+
+// approvingReviewers returns the IDs of the verifier's keys that approved the
+// change in one of the code reviews. A review only counts if its reviewer is
+// one of the verifier's keys, and if the review is signed using that key.
+func (v *Verifier) approvingReviewers(ctx context.Context, reviews []*attestations.SignedCodeReview) []string {
+	reviewers := []string{}
+	for _, review := range reviews {
+		if review.Review.Verdict != attestations.CodeReviewVerdictApproved {
+			continue
+		}
+
+		for _, key := range v.keys {
+			if key.KeyID != review.Review.Reviewer {
+				continue
+			}
+
+			verifier, err := signerverifier.NewSignerVerifierFromTUFKey(key) //nolint:staticcheck
+			if err != nil {
+				break
+			}
+
+			if err := dsse.VerifyEnvelope(ctx, review.Envelope, []sslibdsse.Verifier{verifier}, 1); err == nil {
+				reviewers = append(reviewers, key.KeyID)
+			}
+			break
+		}
+	}
+
+	return reviewers
+}
+
+// describeSkipReasons returns a human readable summary of the reasons entries
+// were skipped. Duplicate reasons are only listed once.
+func describeSkipReasons(reasons []rsl.SkipReason) string {
+	descriptions := []string{}
+	seen := map[rsl.SkipReason]bool{}
+	for _, reason := range reasons {
+		if seen[reason] {
+			continue
+		}
+		seen[reason] = true
+		descriptions = append(descriptions, reason.Description())
+	}
+
+	return strings.Join(descriptions, "; ")
+}
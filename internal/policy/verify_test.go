@@ -4,6 +4,7 @@ package policy
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sort"
 	"testing"
@@ -11,18 +12,23 @@ import (
 
 	"github.com/gittuf/gittuf/internal/attestations"
 	"github.com/gittuf/gittuf/internal/common"
+	"github.com/gittuf/gittuf/internal/github"
 	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/gittuf/gittuf/internal/gitlab"
 	"github.com/gittuf/gittuf/internal/rsl"
 	"github.com/gittuf/gittuf/internal/signerverifier"
 	"github.com/gittuf/gittuf/internal/signerverifier/dsse"
 	"github.com/gittuf/gittuf/internal/signerverifier/gpg"
+	artifacts "github.com/gittuf/gittuf/internal/testartifacts"
 	"github.com/gittuf/gittuf/internal/tuf"
 	"github.com/go-git/go-billy/v5/memfs"
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
 	"github.com/go-git/go-git/v5/plumbing/object"
 	"github.com/go-git/go-git/v5/storage/memory"
+	ita "github.com/in-toto/attestation/go/v1"
 	"github.com/jonboulle/clockwork"
 	sslibdsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
 	"github.com/stretchr/testify/assert"
@@ -115,6 +121,71 @@ func TestVerifyRefFromEntry(t *testing.T) {
 	currentTip, err := VerifyRefFromEntry(testCtx, repo, refName, entryID)
 	assert.Nil(t, err)
 	assert.Equal(t, commitIDs[1], currentTip)
+
+	// Verification cannot start from an annotation
+	annotationID := common.CreateTestRSLAnnotationEntryCommit(t, repo, rsl.NewAnnotationEntry([]plumbing.Hash{entryID}, false, "test annotation"), gpgKeyBytes)
+	_, err = VerifyRefFromEntry(testCtx, repo, refName, annotationID)
+	assert.ErrorIs(t, err, rsl.ErrInvalidRSLEntry)
+}
+
+func TestGetEntryVerificationErrors(t *testing.T) {
+	repo, _ := createTestRepository(t, createTestStateWithPolicy)
+	refName := "refs/heads/main"
+
+	if err := repo.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(refName), plumbing.ZeroHash)); err != nil {
+		t.Fatal(err)
+	}
+
+	commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgUnauthorizedKeyBytes)
+	entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, rsl.NewReferenceEntry(refName, commitIDs[0]), gpgUnauthorizedKeyBytes)
+
+	_, err := VerifyRefFull(testCtx, repo, refName)
+	assert.ErrorIs(t, err, ErrUnauthorizedSignature)
+
+	entryErrs := GetEntryVerificationErrors(errors.Join(errors.New("unrelated"), fmt.Errorf("unable to verify '%s': %w", refName, err)))
+	if assert.Len(t, entryErrs, 1) {
+		assert.Equal(t, entryID, entryErrs[0].Entry.ID)
+		assert.Equal(t, refName, entryErrs[0].Entry.RefName)
+		assert.Equal(t, commitIDs[0], entryErrs[0].Entry.TargetID)
+		assert.ErrorIs(t, entryErrs[0], ErrUnauthorizedSignature)
+	}
+
+	assert.Empty(t, GetEntryVerificationErrors(nil))
+	assert.Empty(t, GetEntryVerificationErrors(ErrUnauthorizedSignature))
+}
+
+func TestVerifyRefFromCommit(t *testing.T) {
+	repo, _ := createTestRepository(t, createTestStateWithPolicy)
+	refName := "refs/heads/main"
+
+	if err := repo.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(refName), plumbing.ZeroHash)); err != nil {
+		t.Fatal(err)
+	}
+
+	// Policy violation
+	violatingCommitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 2, gpgUnauthorizedKeyBytes)
+	entry := rsl.NewReferenceEntry(refName, violatingCommitIDs[1])
+	common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgUnauthorizedKeyBytes)
+
+	// Not policy violation by itself
+	commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 2, gpgKeyBytes)
+	entry = rsl.NewReferenceEntry(refName, commitIDs[1])
+	common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+
+	// Verification passes as entries that record the commit's descendants
+	// are verified
+	currentTip, err := VerifyRefFromCommit(testCtx, repo, refName, commitIDs[0])
+	assert.Nil(t, err)
+	assert.Equal(t, commitIDs[1], currentTip)
+
+	// Verification fails as the violating entry records the commit
+	_, err = VerifyRefFromCommit(testCtx, repo, refName, violatingCommitIDs[0])
+	assert.ErrorIs(t, err, ErrUnauthorizedSignature)
+
+	// Commit not recorded in the RSL
+	unrecordedCommitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+	_, err = VerifyRefFromCommit(testCtx, repo, refName, unrecordedCommitIDs[0])
+	assert.ErrorIs(t, err, rsl.ErrNoRecordOfCommit)
 }
 
 func TestVerifyRelativeForRef(t *testing.T) {
@@ -143,6 +214,118 @@ func TestVerifyRelativeForRef(t *testing.T) {
 		assert.ErrorIs(t, err, rsl.ErrRSLEntryNotFound)
 	})
 
+	t.Run("cancelled context", func(t *testing.T) {
+		repo, _ := createTestRepository(t, createTestStateWithPolicy)
+		refName := "refs/heads/main"
+
+		if err := repo.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(refName), plumbing.ZeroHash)); err != nil {
+			t.Fatal(err)
+		}
+
+		policyEntry, _, err := rsl.GetLatestReferenceEntryForRef(repo, PolicyRef)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		err = VerifyRelativeForRef(ctx, repo, policyEntry, nil, policyEntry, entry, refName)
+		assert.ErrorIs(t, err, context.Canceled)
+
+		err = VerifyRelativeForRef(ctx, repo, policyEntry, nil, policyEntry, entry, refName, WithJobs(2))
+		assert.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("skipped with reason, no recovery", func(t *testing.T) {
+		repo, _ := createTestRepository(t, createTestStateWithPolicy)
+		refName := "refs/heads/main"
+
+		if err := repo.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(refName), plumbing.ZeroHash)); err != nil {
+			t.Fatal(err)
+		}
+
+		policyEntry, _, err := rsl.GetLatestReferenceEntryForRef(repo, PolicyRef)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
+
+		commitIDs = common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgUnauthorizedKeyBytes)
+		entry = rsl.NewReferenceEntry(refName, commitIDs[0])
+		entryID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgUnauthorizedKeyBytes)
+		entry.ID = entryID
+
+		// Skip the invalid entry without moving the branch back
+		annotation := rsl.NewSkipAnnotationEntry([]plumbing.Hash{entryID}, rsl.SkipReasonMistakenPush, "invalid entry")
+		annotationID := common.CreateTestRSLAnnotationEntryCommit(t, repo, annotation, gpgKeyBytes)
+		annotation.ID = annotationID
+
+		err = VerifyRelativeForRef(context.Background(), repo, policyEntry, nil, policyEntry, entry, refName)
+		assert.ErrorIs(t, err, ErrUnauthorizedSignature)
+		assert.ErrorContains(t, err, rsl.SkipReasonMistakenPush.Description())
+	})
+
+	t.Run("concurrent verification", func(t *testing.T) {
+		repo, _ := createTestRepository(t, createTestStateWithPolicy)
+		refName := "refs/heads/main"
+
+		if err := repo.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(refName), plumbing.ZeroHash)); err != nil {
+			t.Fatal(err)
+		}
+
+		policyEntry, _, err := rsl.GetLatestReferenceEntryForRef(repo, PolicyRef)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		addEntry := func(signingKeyBytes []byte) *rsl.ReferenceEntry {
+			commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, signingKeyBytes)
+			entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+			entry.ID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, signingKeyBytes)
+			return entry
+		}
+
+		for i := 0; i < 3; i++ {
+			addEntry(gpgKeyBytes)
+		}
+		validEntry := addEntry(gpgKeyBytes)
+
+		// Invalid entry that is skipped and fixed
+		invalidEntry := addEntry(gpgUnauthorizedKeyBytes)
+		annotation := rsl.NewAnnotationEntry([]plumbing.Hash{invalidEntry.ID}, true, "invalid entry")
+		common.CreateTestRSLAnnotationEntryCommit(t, repo, annotation, gpgKeyBytes)
+		if err := repo.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(refName), validEntry.TargetID)); err != nil {
+			t.Fatal(err)
+		}
+		fixEntry := rsl.NewReferenceEntry(refName, validEntry.TargetID)
+		fixEntry.ID = common.CreateTestRSLReferenceEntryCommit(t, repo, fixEntry, gpgKeyBytes)
+
+		for i := 0; i < 3; i++ {
+			addEntry(gpgKeyBytes)
+		}
+		latestValidEntry := addEntry(gpgKeyBytes)
+
+		err = VerifyRelativeForRef(testCtx, repo, policyEntry, nil, policyEntry, latestValidEntry, refName, WithJobs(4))
+		assert.Nil(t, err)
+
+		unskippedInvalidEntry := addEntry(gpgUnauthorizedKeyBytes)
+
+		expectedErr := VerifyRelativeForRef(testCtx, repo, policyEntry, nil, policyEntry, unskippedInvalidEntry, refName)
+		err = VerifyRelativeForRef(testCtx, repo, policyEntry, nil, policyEntry, unskippedInvalidEntry, refName, WithJobs(4))
+		assert.ErrorIs(t, err, ErrUnauthorizedSignature)
+		assert.Equal(t, expectedErr, err)
+	})
+
 	t.Run("with recovery, commit-same, recovered by authorized user", func(t *testing.T) {
 		repo, _ := createTestRepository(t, createTestStateWithPolicy)
 		refName := "refs/heads/main"
@@ -900,6 +1083,57 @@ func TestVerifyEntry(t *testing.T) {
 		assert.Nil(t, err)
 	})
 
+	t.Run("signed using revoked key", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithPolicy)
+
+		gpgKey, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+		if err != nil {
+			t.Fatal(err)
+		}
+		signer, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(rootKeyBytes) //nolint:staticcheck
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		rootMetadata, err := state.GetRootMetadata()
+		if err != nil {
+			t.Fatal(err)
+		}
+		rootMetadata, err = RevokeKey(rootMetadata, gpgKey.KeyID, "compromised")
+		if err != nil {
+			t.Fatal(err)
+		}
+		rootMetadata.SetVersion(rootMetadata.Version + 1)
+		rootEnv, err := dsse.CreateEnvelope(rootMetadata)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rootEnv, err = dsse.SignEnvelope(testCtx, rootEnv, signer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		state.RootEnvelope = rootEnv
+		if err := state.Commit(testCtx, repo, "Revoke key", false); err != nil {
+			t.Fatal(err)
+		}
+
+		state, err = LoadCurrentState(testCtx, repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
+
+		err = verifyEntry(testCtx, repo, state, nil, entry)
+		assert.ErrorIs(t, err, ErrUnauthorizedSignature)
+		assert.ErrorIs(t, err, ErrKeyRevoked)
+		assert.ErrorContains(t, err, "compromised")
+		assert.ErrorContains(t, err, "revoked in RSL entry")
+	})
+
 	// FIXME: test for file policy passing for situations where a commit is seen
 	// by the RSL before its signing key is rotated out. This commit should be
 	// trusted for merges under the new policy because it predates the policy
@@ -908,113 +1142,1976 @@ func TestVerifyEntry(t *testing.T) {
 	// signature, unseen by the RSL.
 }
 
-func TestVerifyTagEntry(t *testing.T) {
-	t.Run("no tag specific policy", func(t *testing.T) {
-		repo, policy := createTestRepository(t, createTestStateWithPolicy)
+func TestVerifyTagRef(t *testing.T) {
+	t.Run("with tag specific policy", func(t *testing.T) {
+		repo, _ := createTestRepository(t, createTestStateWithTagPolicy)
 		refName := "refs/heads/main"
 
 		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 3, gpgKeyBytes)
 		entry := rsl.NewReferenceEntry(refName, commitIDs[len(commitIDs)-1])
-		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
-		entry.ID = entryID
+		common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
 
 		tagName := "v1"
+		tagRefName := string(plumbing.NewTagReferenceName(tagName))
 		tagID := common.CreateTestSignedTag(t, repo, tagName, commitIDs[len(commitIDs)-1], gpgKeyBytes)
 
-		entry = rsl.NewReferenceEntry(string(plumbing.NewTagReferenceName(tagName)), tagID)
-		entryID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
-		entry.ID = entryID
-
-		err := verifyTagEntry(context.Background(), repo, policy, entry)
-		assert.Nil(t, err)
-	})
+		_, _, err := VerifyTagRef(testCtx, repo, tagName)
+		assert.ErrorIs(t, err, rsl.ErrRSLEntryNotFound)
 
-	t.Run("with tag specific policy", func(t *testing.T) {
-		repo, policy := createTestRepository(t, createTestStateWithTagPolicy)
-		refName := "refs/heads/main"
+		entry = rsl.NewReferenceEntry(tagRefName, tagID)
+		common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
 
-		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 3, gpgKeyBytes)
-		entry := rsl.NewReferenceEntry(refName, commitIDs[len(commitIDs)-1])
-		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
-		entry.ID = entryID
+		for _, id := range []string{tagName, tagRefName, tagID.String()} {
+			ref, expectedTip, err := VerifyTagRef(testCtx, repo, id)
+			assert.Nil(t, err)
+			assert.Equal(t, tagRefName, ref)
+			assert.Equal(t, tagID, expectedTip)
+		}
 
-		tagName := "v1"
-		tagID := common.CreateTestSignedTag(t, repo, tagName, commitIDs[len(commitIDs)-1], gpgKeyBytes)
+		_, _, err = VerifyTagRef(testCtx, repo, "main")
+		assert.ErrorIs(t, err, ErrNotTag)
 
-		entry = rsl.NewReferenceEntry(string(plumbing.NewTagReferenceName(tagName)), tagID)
-		entryID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
-		entry.ID = entryID
+		// Move the tag
+		movedTagID := common.CreateTestSignedTag(t, repo, tagName, commitIDs[0], gpgKeyBytes)
+		entry = rsl.NewReferenceEntry(tagRefName, movedTagID)
+		common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
 
-		err := verifyTagEntry(context.Background(), repo, policy, entry)
-		assert.Nil(t, err)
+		_, _, err = VerifyTagRef(testCtx, repo, tagName)
+		assert.ErrorIs(t, err, ErrMultipleTagRSLEntries)
 	})
 
 	t.Run("with tag specific policy, unauthorized", func(t *testing.T) {
-		repo, policy := createTestRepository(t, createTestStateWithTagPolicyForUnauthorizedTest)
+		repo, _ := createTestRepository(t, createTestStateWithTagPolicyForUnauthorizedTest)
 		refName := "refs/heads/main"
 
 		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 3, gpgKeyBytes)
 		entry := rsl.NewReferenceEntry(refName, commitIDs[len(commitIDs)-1])
-		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
-		entry.ID = entryID
+		common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
 
 		tagName := "v1"
 		tagID := common.CreateTestSignedTag(t, repo, tagName, commitIDs[len(commitIDs)-1], gpgKeyBytes)
 
 		entry = rsl.NewReferenceEntry(string(plumbing.NewTagReferenceName(tagName)), tagID)
-		entryID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
-		entry.ID = entryID
+		common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
 
-		err := verifyTagEntry(context.Background(), repo, policy, entry)
+		_, _, err := VerifyTagRef(testCtx, repo, tagName)
 		assert.ErrorIs(t, err, ErrUnauthorizedSignature)
 	})
 }
 
-func TestGetCommits(t *testing.T) {
-	repo, _ := createTestRepository(t, createTestStateWithPolicy)
-
+func TestVerifyEntryForcePush(t *testing.T) {
 	refName := "refs/heads/main"
 
-	if err := repo.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(refName), plumbing.ZeroHash)); err != nil {
-		t.Fatal(err)
-	}
+	// createRewrite records two commits for main, and then an entry that
+	// rewrites main to a sibling of the second commit. The entries are
+	// returned in order.
+	createRewrite := func(t *testing.T, repo *git.Repository) []*rsl.ReferenceEntry {
+		t.Helper()
+
+		createCommit := func(parents []plumbing.Hash, message string) plumbing.Hash {
+			treeID, err := gitinterface.WriteTree(repo, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			commit := gitinterface.CreateCommitObject(common.TestGitConfig, treeID, parents, message, common.TestClock)
+			commit = common.SignTestCommit(t, repo, commit, gpgKeyBytes)
+			commitID, err := gitinterface.WriteCommit(repo, commit)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return commitID
+		}
 
-	// FIXME: this setup with RSL entries can be formalized using another
-	// helper like createTestStateWithPolicy. The RSL could then also
-	// incorporate policy changes and so on.
-	commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 5, gpgKeyBytes)
-	firstEntry := rsl.NewReferenceEntry(refName, commitIDs[0])
-	firstEntryID := common.CreateTestRSLReferenceEntryCommit(t, repo, firstEntry, gpgKeyBytes)
-	firstEntry.ID = firstEntryID
+		baseID := createCommit(nil, "Base commit")
+		firstID := createCommit([]plumbing.Hash{baseID}, "First commit")
+		rewrittenID := createCommit([]plumbing.Hash{baseID}, "Rewritten commit")
 
-	secondEntry := rsl.NewReferenceEntry(refName, commitIDs[4])
-	secondEntryID := common.CreateTestRSLReferenceEntryCommit(t, repo, secondEntry, gpgKeyBytes)
-	secondEntry.ID = secondEntryID
+		entries := []*rsl.ReferenceEntry{}
+		for _, commitID := range []plumbing.Hash{baseID, firstID, rewrittenID} {
+			entry := rsl.NewReferenceEntry(refName, commitID)
+			entry.ID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+			entries = append(entries, entry)
+		}
+		return entries
+	}
 
-	expectedCommitIDs := []plumbing.Hash{commitIDs[1], commitIDs[2], commitIDs[3], commitIDs[4]}
-	expectedCommits := make([]*object.Commit, 0, len(expectedCommitIDs))
-	for _, commitID := range expectedCommitIDs {
-		commit, err := gitinterface.GetCommit(repo, commitID)
+	// setForcePushAuthorizers sets the keys that may authorize force pushes
+	// for the rule protecting main.
+	setForcePushAuthorizers := func(t *testing.T, state *State, authorizedKeysBytes ...[]byte) {
+		t.Helper()
+
+		authorizedKeys := []*tuf.Key{}
+		for _, keyBytes := range authorizedKeysBytes {
+			key, err := gpg.LoadGPGKeyFromBytes(keyBytes)
+			if err != nil {
+				t.Fatal(err)
+			}
+			authorizedKeys = append(authorizedKeys, key)
+		}
+
+		targetsMetadata, err := state.GetTargetsMetadata(TargetsRoleName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsMetadata, err = SetForcePushAuthorizers(targetsMetadata, "protect-main", authorizedKeys)
 		if err != nil {
 			t.Fatal(err)
 		}
 
-		expectedCommits = append(expectedCommits, commit)
+		signer, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(rootKeyBytes) //nolint:staticcheck
+		if err != nil {
+			t.Fatal(err)
+		}
+		env, err := dsse.CreateEnvelope(targetsMetadata)
+		if err != nil {
+			t.Fatal(err)
+		}
+		env, err = dsse.SignEnvelope(testCtx, env, signer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		state.TargetsEnvelope = env
 	}
 
-	sort.Slice(expectedCommits, func(i, j int) bool {
-		return expectedCommits[i].ID().String() < expectedCommits[j].ID().String()
-	})
+	// addDenyRule adds a deny rule that matches main and exempts the GPG key
+	// that signs the commits and entries.
+	addDenyRule := func(t *testing.T, state *State) {
+		t.Helper()
 
-	commits, err := getCommits(repo, secondEntry)
-	assert.Nil(t, err)
-	assert.Equal(t, expectedCommits, commits)
-}
+		gpgKey, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+		if err != nil {
+			t.Fatal(err)
+		}
 
-func TestGetChangedPaths(t *testing.T) {
-	repo, _ := createTestRepository(t, createTestStateWithPolicy)
+		targetsMetadata, err := state.GetTargetsMetadata(TargetsRoleName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsMetadata, err = AddDelegation(targetsMetadata, "deny-main", []*tuf.Key{gpgKey}, []string{"git:" + refName}, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsMetadata, err = SetRuleEffect(targetsMetadata, "deny-main", RuleEffectDeny)
+		if err != nil {
+			t.Fatal(err)
+		}
 
-	refName := "refs/heads/main"
+		signer, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(rootKeyBytes) //nolint:staticcheck
+		if err != nil {
+			t.Fatal(err)
+		}
+		env, err := dsse.CreateEnvelope(targetsMetadata)
+		if err != nil {
+			t.Fatal(err)
+		}
+		env, err = dsse.SignEnvelope(testCtx, env, signer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		state.TargetsEnvelope = env
+	}
+
+	t.Run("fast-forward updates are not force pushes", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithPolicy)
+		entries := createRewrite(t, repo)
+
+		err := verifyEntry(testCtx, repo, state, nil, entries[1])
+		assert.Nil(t, err)
+	})
+
+	t.Run("no force push authorizers", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithPolicy)
+		entries := createRewrite(t, repo)
+
+		common.CreateTestRSLAnnotationEntryCommit(t, repo, rsl.NewAnnotationEntry([]plumbing.Hash{entries[2].ID}, false, "force push"), gpgKeyBytes)
+
+		err := verifyEntry(testCtx, repo, state, nil, entries[2])
+		assert.ErrorIs(t, err, ErrUnauthorizedForcePush)
+		assert.NotErrorIs(t, err, ErrUnauthorizedSignature)
+	})
+
+	t.Run("no authorizing annotation", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithPolicy)
+		setForcePushAuthorizers(t, state, artifacts.GPGKey2Public)
+		entries := createRewrite(t, repo)
+
+		err := verifyEntry(testCtx, repo, state, nil, entries[2])
+		assert.ErrorIs(t, err, ErrUnauthorizedForcePush)
+	})
+
+	t.Run("annotation signed by unauthorized key", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithPolicy)
+		setForcePushAuthorizers(t, state, artifacts.GPGKey2Public)
+		entries := createRewrite(t, repo)
+
+		common.CreateTestRSLAnnotationEntryCommit(t, repo, rsl.NewAnnotationEntry([]plumbing.Hash{entries[2].ID}, false, "force push"), gpgKeyBytes)
+
+		err := verifyEntry(testCtx, repo, state, nil, entries[2])
+		assert.ErrorIs(t, err, ErrUnauthorizedForcePush)
+	})
+
+	t.Run("annotation signed by authorized key", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithPolicy)
+		setForcePushAuthorizers(t, state, artifacts.GPGKey2Public)
+		entries := createRewrite(t, repo)
+
+		common.CreateTestRSLAnnotationEntryCommit(t, repo, rsl.NewAnnotationEntry([]plumbing.Hash{entries[2].ID}, false, "force push"), gpgUnauthorizedKeyBytes)
+
+		err := verifyEntry(testCtx, repo, state, nil, entries[2])
+		assert.Nil(t, err)
+	})
+
+	t.Run("annotation signed by authorized key with matching deny rule", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithPolicy)
+		setForcePushAuthorizers(t, state, artifacts.GPGKey2Public)
+		addDenyRule(t, state)
+		entries := createRewrite(t, repo)
+
+		common.CreateTestRSLAnnotationEntryCommit(t, repo, rsl.NewAnnotationEntry([]plumbing.Hash{entries[2].ID}, false, "force push"), gpgUnauthorizedKeyBytes)
+
+		err := verifyEntry(testCtx, repo, state, nil, entries[2])
+		assert.Nil(t, err)
+	})
+
+	t.Run("annotation signed using SHA-1 with algorithm policy rejecting SHA-1", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithPolicy)
+		setForcePushAuthorizers(t, state, artifacts.GPGKey2Public)
+		setTestAlgorithmPolicy(t, state, &tuf.AlgorithmPolicy{RejectSHA1: true})
+		entries := createRewrite(t, repo)
+
+		annotationID := common.CreateTestRSLAnnotationEntryCommit(t, repo, rsl.NewAnnotationEntry([]plumbing.Hash{entries[2].ID}, false, "force push"), gpgUnauthorizedKeyBytes)
+
+		// Replace the annotation with one signed using SHA-1
+		annotation, err := gitinterface.GetCommit(repo, annotationID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		annotation.PGPSignature = createTestSHA1Signature(t, annotation, gpgUnauthorizedKeyBytes)
+		annotationID, err = gitinterface.WriteCommit(repo, annotation)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := repo.Storer.SetReference(plumbing.NewHashReference(rsl.Ref, annotationID)); err != nil {
+			t.Fatal(err)
+		}
+
+		err = verifyEntry(testCtx, repo, state, nil, entries[2])
+		assert.ErrorIs(t, err, ErrUnauthorizedForcePush)
+
+		setTestAlgorithmPolicy(t, state, &tuf.AlgorithmPolicy{})
+		state.verifiersCache = nil
+
+		err = verifyEntry(testCtx, repo, state, nil, entries[2])
+		assert.Nil(t, err)
+	})
+
+	t.Run("skipped entries are not considered", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithPolicy)
+		entries := createRewrite(t, repo)
+
+		// Skipping the first commit's entry means the rewrite is relative to
+		// the base commit
+		common.CreateTestRSLAnnotationEntryCommit(t, repo, rsl.NewSkipAnnotationEntry([]plumbing.Hash{entries[1].ID}, rsl.SkipReasonMistakenPush, "mistaken push"), gpgKeyBytes)
+
+		err := verifyEntry(testCtx, repo, state, nil, entries[2])
+		assert.Nil(t, err)
+	})
+}
+
+func TestVerifyEntryDeletion(t *testing.T) {
+	refName := "refs/heads/main"
+
+	// createDeletion records a commit for the reference, and then the
+	// deletion of the reference signed using the specified key. The deletion
+	// entry is returned.
+	createDeletion := func(t *testing.T, repo *git.Repository, refName string, signingKeyBytes []byte) *rsl.ReferenceEntry {
+		t.Helper()
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		common.CreateTestRSLReferenceEntryCommit(t, repo, rsl.NewReferenceEntry(refName, commitIDs[0]), gpgKeyBytes)
+
+		entry := rsl.NewDeletionEntry(refName)
+		entry.ID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, signingKeyBytes)
+		return entry
+	}
+
+	// setDeletionAuthorizers sets the keys that may delete main.
+	setDeletionAuthorizers := func(t *testing.T, state *State, authorizedKeysBytes ...[]byte) {
+		t.Helper()
+
+		authorizedKeys := []*tuf.Key{}
+		for _, keyBytes := range authorizedKeysBytes {
+			key, err := gpg.LoadGPGKeyFromBytes(keyBytes)
+			if err != nil {
+				t.Fatal(err)
+			}
+			authorizedKeys = append(authorizedKeys, key)
+		}
+
+		targetsMetadata, err := state.GetTargetsMetadata(TargetsRoleName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsMetadata, err = SetDeletionAuthorizers(targetsMetadata, "protect-main", authorizedKeys)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		signer, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(rootKeyBytes) //nolint:staticcheck
+		if err != nil {
+			t.Fatal(err)
+		}
+		env, err := dsse.CreateEnvelope(targetsMetadata)
+		if err != nil {
+			t.Fatal(err)
+		}
+		env, err = dsse.SignEnvelope(testCtx, env, signer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		state.TargetsEnvelope = env
+	}
+
+	t.Run("unprotected reference", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithPolicy)
+		entry := createDeletion(t, repo, "refs/heads/feature", gpgUnauthorizedKeyBytes)
+
+		err := verifyEntry(testCtx, repo, state, nil, entry)
+		assert.Nil(t, err)
+	})
+
+	t.Run("no deletion authorizers", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithPolicy)
+		entry := createDeletion(t, repo, refName, gpgKeyBytes)
+
+		// Keys authorized to update the reference cannot delete it
+		err := verifyEntry(testCtx, repo, state, nil, entry)
+		assert.ErrorIs(t, err, ErrUnauthorizedDeletion)
+	})
+
+	t.Run("deletion signed by unauthorized key", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithPolicy)
+		setDeletionAuthorizers(t, state, artifacts.GPGKey2Public)
+		entry := createDeletion(t, repo, refName, gpgKeyBytes)
+
+		err := verifyEntry(testCtx, repo, state, nil, entry)
+		assert.ErrorIs(t, err, ErrUnauthorizedDeletion)
+	})
+
+	t.Run("deletion signed by authorized key", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithPolicy)
+		setDeletionAuthorizers(t, state, artifacts.GPGKey2Public)
+		entry := createDeletion(t, repo, refName, gpgUnauthorizedKeyBytes)
+
+		err := verifyEntry(testCtx, repo, state, nil, entry)
+		assert.Nil(t, err)
+	})
+
+	t.Run("protected tag", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithTagPolicy)
+
+		entry := rsl.NewDeletionEntry("refs/tags/v1")
+		entry.ID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgUnauthorizedKeyBytes)
+
+		err := verifyEntry(testCtx, repo, state, nil, entry)
+		assert.ErrorIs(t, err, ErrUnauthorizedDeletion)
+	})
+}
+
+func TestVerifyEntryTargetDigest(t *testing.T) {
+	refName := "refs/heads/main"
+
+	repo, state := createTestRepository(t, createTestStateWithPolicy)
+	commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 2, gpgKeyBytes)
+
+	digests := []string{}
+	for _, commitID := range commitIDs {
+		digest, err := gitinterface.ComputeSHA256Digest(repo, commitID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		digests = append(digests, digest)
+	}
+
+	entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+	entry.TargetSHA256 = digests[0]
+	entry.ID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+
+	err := verifyEntry(testCtx, repo, state, nil, entry)
+	assert.Nil(t, err)
+
+	// The recorded digest does not match the contents of the target
+	entry = rsl.NewReferenceEntry(refName, commitIDs[1])
+	entry.TargetSHA256 = digests[0]
+	entry.ID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+
+	err = verifyEntry(testCtx, repo, state, nil, entry)
+	assert.ErrorIs(t, err, ErrTargetDigestMismatch)
+}
+
+func TestVerifyEntrySubmodules(t *testing.T) {
+	refName := "refs/heads/main"
+	submoduleCommitID := plumbing.NewHash("b5c3b5b5b1f8a54f3e2fb1d2b3cf3b8bd7aa1b2a")
+
+	// setSubmoduleConstraints adds a rule protecting submodules in vendor
+	// with the specified constraints.
+	setSubmoduleConstraints := func(t *testing.T, state *State, allowedURLs []string, requireVerification bool) {
+		t.Helper()
+
+		gpgKey, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		targetsMetadata, err := state.GetTargetsMetadata(TargetsRoleName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsMetadata, err = AddDelegation(targetsMetadata, "protect-vendor", []*tuf.Key{gpgKey}, []string{"file:vendor/*"}, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsMetadata, err = SetSubmoduleConstraints(targetsMetadata, "protect-vendor", allowedURLs, requireVerification)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		signer, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(rootKeyBytes) //nolint:staticcheck
+		if err != nil {
+			t.Fatal(err)
+		}
+		env, err := dsse.CreateEnvelope(targetsMetadata)
+		if err != nil {
+			t.Fatal(err)
+		}
+		env, err = dsse.SignEnvelope(testCtx, env, signer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		state.TargetsEnvelope = env
+		if err := state.loadRuleNames(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// createSubmoduleCommit records a commit that adds a submodule at
+	// vendor/lib with the specified .gitmodules contents.
+	createSubmoduleCommit := func(t *testing.T, repo *git.Repository, gitModules string) *rsl.ReferenceEntry {
+		t.Helper()
+
+		gitModulesID, err := gitinterface.WriteBlob(repo, []byte(gitModules))
+		if err != nil {
+			t.Fatal(err)
+		}
+		vendorTreeID, err := gitinterface.WriteTree(repo, []object.TreeEntry{{Name: "lib", Mode: filemode.Submodule, Hash: submoduleCommitID}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		treeID, err := gitinterface.WriteTree(repo, []object.TreeEntry{
+			{Name: ".gitmodules", Mode: filemode.Regular, Hash: gitModulesID},
+			{Name: "vendor", Mode: filemode.Dir, Hash: vendorTreeID},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ref := plumbing.NewHashReference(plumbing.ReferenceName(refName), plumbing.ZeroHash)
+		if err := repo.Storer.SetReference(ref); err != nil {
+			t.Fatal(err)
+		}
+		commit := gitinterface.CreateCommitObject(common.TestGitConfig, treeID, nil, "Add submodule", common.TestClock)
+		commit = common.SignTestCommit(t, repo, commit, gpgKeyBytes)
+		commitID, err := gitinterface.ApplyCommit(repo, commit, ref)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		entry := rsl.NewReferenceEntry(refName, commitID)
+		entry.ID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		return entry
+	}
+
+	gitModules := "[submodule \"lib\"]\n\tpath = vendor/lib\n\turl = https://github.com/gittuf/lib\n"
+
+	t.Run("no submodule constraints", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithPolicy)
+		entry := createSubmoduleCommit(t, repo, gitModules)
+
+		err := verifyEntry(testCtx, repo, state, nil, entry)
+		assert.Nil(t, err)
+	})
+
+	t.Run("submodule URL allowed", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithPolicy)
+		setSubmoduleConstraints(t, state, []string{"https://github.com/gittuf/*"}, false)
+		entry := createSubmoduleCommit(t, repo, gitModules)
+
+		err := verifyEntry(testCtx, repo, state, nil, entry)
+		assert.Nil(t, err)
+	})
+
+	t.Run("submodule URL not allowed", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithPolicy)
+		setSubmoduleConstraints(t, state, []string{"https://github.com/gittuf/*"}, false)
+		entry := createSubmoduleCommit(t, repo, "[submodule \"lib\"]\n\tpath = vendor/lib\n\turl = https://example.com/lib\n")
+
+		err := verifyEntry(testCtx, repo, state, nil, entry)
+		assert.ErrorIs(t, err, ErrSubmoduleURLNotAllowed)
+	})
+
+	t.Run("submodule not configured", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithPolicy)
+		setSubmoduleConstraints(t, state, []string{"https://github.com/gittuf/*"}, false)
+		entry := createSubmoduleCommit(t, repo, "")
+
+		err := verifyEntry(testCtx, repo, state, nil, entry)
+		assert.ErrorIs(t, err, ErrSubmoduleURLNotAllowed)
+	})
+
+	t.Run("submodule verification required but repository unavailable", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithPolicy)
+		setSubmoduleConstraints(t, state, nil, true)
+		entry := createSubmoduleCommit(t, repo, gitModules)
+
+		err := verifyEntry(testCtx, repo, state, nil, entry)
+		assert.ErrorIs(t, err, ErrSubmoduleUnavailable)
+	})
+}
+
+func TestVerifyEntryRegoPolicy(t *testing.T) {
+	refName := "refs/heads/main"
+
+	// setRegoPolicy sets the Rego policy of the rule protecting main. If
+	// withDenyRule is set, a deny rule that matches main and exempts the
+	// rule's key is also added.
+	setRegoPolicy := func(t *testing.T, state *State, module string, withDenyRule bool) {
+		t.Helper()
+
+		targetsMetadata, err := state.GetTargetsMetadata(TargetsRoleName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsMetadata, err = SetRegoPolicy(targetsMetadata, "protect-main", module)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if withDenyRule {
+			gpgKey, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+			if err != nil {
+				t.Fatal(err)
+			}
+			targetsMetadata, err = AddDelegation(targetsMetadata, "deny-main", []*tuf.Key{gpgKey}, []string{"git:refs/heads/main"}, 1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			targetsMetadata, err = SetRuleEffect(targetsMetadata, "deny-main", RuleEffectDeny)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		signer, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(rootKeyBytes) //nolint:staticcheck
+		if err != nil {
+			t.Fatal(err)
+		}
+		env, err := dsse.CreateEnvelope(targetsMetadata)
+		if err != nil {
+			t.Fatal(err)
+		}
+		env, err = dsse.SignEnvelope(testCtx, env, signer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		state.TargetsEnvelope = env
+	}
+
+	t.Run("change allowed", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithPolicy)
+		setRegoPolicy(t, state, testRegoPolicyDenyWIP, false)
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		entry.ID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+
+		err := verifyEntry(testCtx, repo, state, nil, entry)
+		assert.Nil(t, err)
+	})
+
+	t.Run("change denied", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithPolicy)
+		setRegoPolicy(t, state, testRegoPolicyRequireApproval, false)
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		entry.ID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+
+		err := verifyEntry(testCtx, repo, state, nil, entry)
+		assert.ErrorIs(t, err, ErrConstraintViolated)
+		assert.Contains(t, err.Error(), "change must be approved")
+	})
+
+	t.Run("change denied with matching deny rule", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithPolicy)
+		setRegoPolicy(t, state, testRegoPolicyRequireApproval, true)
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		entry.ID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+
+		err := verifyEntry(testCtx, repo, state, nil, entry)
+		assert.ErrorIs(t, err, ErrConstraintViolated)
+		assert.Contains(t, err.Error(), "change must be approved")
+	})
+}
+
+func TestVerifyEntryRuleConditions(t *testing.T) {
+	refName := "refs/heads/main"
+
+	// setRuleConditions sets the conditions of the rule protecting main. If
+	// withDenyRule is set, a deny rule that matches main and exempts the
+	// rule's key is also added.
+	setRuleConditions := func(t *testing.T, state *State, conditions []string, withDenyRule bool) {
+		t.Helper()
+
+		targetsMetadata, err := state.GetTargetsMetadata(TargetsRoleName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsMetadata, err = SetRuleConditions(targetsMetadata, "protect-main", conditions)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if withDenyRule {
+			gpgKey, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+			if err != nil {
+				t.Fatal(err)
+			}
+			targetsMetadata, err = AddDelegation(targetsMetadata, "deny-main", []*tuf.Key{gpgKey}, []string{"git:refs/heads/main"}, 1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			targetsMetadata, err = SetRuleEffect(targetsMetadata, "deny-main", RuleEffectDeny)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		signer, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(rootKeyBytes) //nolint:staticcheck
+		if err != nil {
+			t.Fatal(err)
+		}
+		env, err := dsse.CreateEnvelope(targetsMetadata)
+		if err != nil {
+			t.Fatal(err)
+		}
+		env, err = dsse.SignEnvelope(testCtx, env, signer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		state.TargetsEnvelope = env
+	}
+
+	t.Run("conditions met", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithPolicy)
+		setRuleConditions(t, state, []string{"ref == 'refs/heads/main'", "commit.message.startsWith('Test')"}, false)
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		entry.ID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+
+		err := verifyEntry(testCtx, repo, state, nil, entry)
+		assert.Nil(t, err)
+	})
+
+	t.Run("condition not met", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithPolicy)
+		setRuleConditions(t, state, []string{"ref == 'refs/heads/main'", "size(approvers) > 0"}, false)
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		entry.ID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+
+		err := verifyEntry(testCtx, repo, state, nil, entry)
+		assert.ErrorIs(t, err, ErrConstraintViolated)
+		assert.Contains(t, err.Error(), "size(approvers) > 0")
+	})
+
+	t.Run("condition not met with matching deny rule", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithPolicy)
+		setRuleConditions(t, state, []string{"size(approvers) > 0"}, true)
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		entry.ID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+
+		err := verifyEntry(testCtx, repo, state, nil, entry)
+		assert.ErrorIs(t, err, ErrConstraintViolated)
+	})
+}
+
+func TestVerifyEntryCommitMessageRules(t *testing.T) {
+	refName := "refs/heads/main"
+
+	// setCommitMessageRules requires commits to main to be signed off. If
+	// withDenyRule is set, a deny rule that matches main and exempts the
+	// rule's key is also added.
+	setCommitMessageRules := func(t *testing.T, state *State, withDenyRule bool) {
+		t.Helper()
+
+		targetsMetadata, err := state.GetTargetsMetadata(TargetsRoleName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsMetadata, err = SetCommitMessageRules(targetsMetadata, "protect-main", true, false, "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if withDenyRule {
+			gpgKey, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+			if err != nil {
+				t.Fatal(err)
+			}
+			targetsMetadata, err = AddDelegation(targetsMetadata, "deny-main", []*tuf.Key{gpgKey}, []string{"git:refs/heads/main"}, 1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			targetsMetadata, err = SetRuleEffect(targetsMetadata, "deny-main", RuleEffectDeny)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		signer, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(rootKeyBytes) //nolint:staticcheck
+		if err != nil {
+			t.Fatal(err)
+		}
+		env, err := dsse.CreateEnvelope(targetsMetadata)
+		if err != nil {
+			t.Fatal(err)
+		}
+		env, err = dsse.SignEnvelope(testCtx, env, signer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		state.TargetsEnvelope = env
+	}
+
+	// createCommitWithMessage records a commit to main with the specified
+	// message.
+	createCommitWithMessage := func(t *testing.T, repo *git.Repository, message string) *rsl.ReferenceEntry {
+		t.Helper()
+
+		treeID, err := gitinterface.WriteTree(repo, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ref := plumbing.NewHashReference(plumbing.ReferenceName(refName), plumbing.ZeroHash)
+		if err := repo.Storer.SetReference(ref); err != nil {
+			t.Fatal(err)
+		}
+		commit := gitinterface.CreateCommitObject(common.TestGitConfig, treeID, nil, message, common.TestClock)
+		commit = common.SignTestCommit(t, repo, commit, gpgKeyBytes)
+		commitID, err := gitinterface.ApplyCommit(repo, commit, ref)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		entry := rsl.NewReferenceEntry(refName, commitID)
+		entry.ID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		return entry
+	}
+
+	t.Run("commit signed off", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithPolicy)
+		setCommitMessageRules(t, state, false)
+		entry := createCommitWithMessage(t, repo, "Add feature\n\nSigned-off-by: Jane Doe <jane.doe@example.com>\n")
+
+		err := verifyEntry(testCtx, repo, state, nil, entry)
+		assert.Nil(t, err)
+	})
+
+	t.Run("commit not signed off", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithPolicy)
+		setCommitMessageRules(t, state, false)
+		entry := createCommitWithMessage(t, repo, "Add feature\n")
+
+		err := verifyEntry(testCtx, repo, state, nil, entry)
+		assert.ErrorIs(t, err, ErrConstraintViolated)
+	})
+
+	t.Run("commit not signed off with matching deny rule", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithPolicy)
+		setCommitMessageRules(t, state, true)
+		entry := createCommitWithMessage(t, repo, "Add feature\n")
+
+		err := verifyEntry(testCtx, repo, state, nil, entry)
+		assert.ErrorIs(t, err, ErrConstraintViolated)
+	})
+}
+
+func TestVerifyEntryFileRestrictions(t *testing.T) {
+	refName := "refs/heads/main"
+
+	// setFileRestrictions adds a rule protecting vendor that forbids binary
+	// files.
+	setFileRestrictions := func(t *testing.T, state *State) {
+		t.Helper()
+
+		gpgKey, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		targetsMetadata, err := state.GetTargetsMetadata(TargetsRoleName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsMetadata, err = AddDelegation(targetsMetadata, "protect-vendor", []*tuf.Key{gpgKey}, []string{"file:vendor/*"}, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsMetadata, err = SetFileRestrictions(targetsMetadata, "protect-vendor", 0, nil, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		signer, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(rootKeyBytes) //nolint:staticcheck
+		if err != nil {
+			t.Fatal(err)
+		}
+		env, err := dsse.CreateEnvelope(targetsMetadata)
+		if err != nil {
+			t.Fatal(err)
+		}
+		env, err = dsse.SignEnvelope(testCtx, env, signer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		state.TargetsEnvelope = env
+		if err := state.loadRuleNames(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// createVendorCommit records a commit that adds vendor/file with the
+	// specified contents.
+	createVendorCommit := func(t *testing.T, repo *git.Repository, contents []byte) *rsl.ReferenceEntry {
+		t.Helper()
+
+		blobID, err := gitinterface.WriteBlob(repo, contents)
+		if err != nil {
+			t.Fatal(err)
+		}
+		vendorTreeID, err := gitinterface.WriteTree(repo, []object.TreeEntry{{Name: "file", Mode: filemode.Regular, Hash: blobID}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		treeID, err := gitinterface.WriteTree(repo, []object.TreeEntry{{Name: "vendor", Mode: filemode.Dir, Hash: vendorTreeID}})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ref := plumbing.NewHashReference(plumbing.ReferenceName(refName), plumbing.ZeroHash)
+		if err := repo.Storer.SetReference(ref); err != nil {
+			t.Fatal(err)
+		}
+		commit := gitinterface.CreateCommitObject(common.TestGitConfig, treeID, nil, "Add vendored file", common.TestClock)
+		commit = common.SignTestCommit(t, repo, commit, gpgKeyBytes)
+		commitID, err := gitinterface.ApplyCommit(repo, commit, ref)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		entry := rsl.NewReferenceEntry(refName, commitID)
+		entry.ID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		return entry
+	}
+
+	t.Run("text file", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithPolicy)
+		setFileRestrictions(t, state)
+		entry := createVendorCommit(t, repo, []byte("hello world\n"))
+
+		err := verifyEntry(testCtx, repo, state, nil, entry)
+		assert.Nil(t, err)
+	})
+
+	t.Run("binary file", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithPolicy)
+		setFileRestrictions(t, state)
+		entry := createVendorCommit(t, repo, []byte{0x00, 0x01, 0x02})
+
+		err := verifyEntry(testCtx, repo, state, nil, entry)
+		assert.ErrorIs(t, err, ErrConstraintViolated)
+	})
+}
+
+func TestVerifyEntryWithMergeStrategy(t *testing.T) {
+	refName := "refs/heads/main"
+
+	// createMerge records a base commit for main, and then a merge of a
+	// commit that changes a protected file and is signed using sideKeyBytes.
+	// The entry for the merge is returned.
+	createMerge := func(t *testing.T, repo *git.Repository, sideKeyBytes []byte) *rsl.ReferenceEntry {
+		t.Helper()
+
+		blobA, err := gitinterface.WriteBlob(repo, []byte("a"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		blobB, err := gitinterface.WriteBlob(repo, []byte("b"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		createCommit := func(entries []object.TreeEntry, parents []plumbing.Hash, signingKeyBytes []byte) plumbing.Hash {
+			treeID, err := gitinterface.WriteTree(repo, entries)
+			if err != nil {
+				t.Fatal(err)
+			}
+			commit := gitinterface.CreateCommitObject(common.TestGitConfig, treeID, parents, "Test commit", common.TestClock)
+			commit = common.SignTestCommit(t, repo, commit, signingKeyBytes)
+			commitID, err := gitinterface.WriteCommit(repo, commit)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return commitID
+		}
+
+		baseID := createCommit([]object.TreeEntry{{Name: "1", Hash: blobA}}, nil, gpgKeyBytes)
+		common.CreateTestRSLReferenceEntryCommit(t, repo, rsl.NewReferenceEntry(refName, baseID), gpgKeyBytes)
+
+		mainlineID := createCommit([]object.TreeEntry{{Name: "1", Hash: blobA}, {Name: "3", Hash: blobA}}, []plumbing.Hash{baseID}, gpgKeyBytes)
+		sideID := createCommit([]object.TreeEntry{{Name: "1", Hash: blobB}}, []plumbing.Hash{baseID}, sideKeyBytes)
+		mergeID := createCommit([]object.TreeEntry{{Name: "1", Hash: blobB}, {Name: "3", Hash: blobA}}, []plumbing.Hash{mainlineID, sideID}, gpgKeyBytes)
+		if err := repo.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(refName), mergeID)); err != nil {
+			t.Fatal(err)
+		}
+
+		entry := rsl.NewReferenceEntry(refName, mergeID)
+		entry.ID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		return entry
+	}
+
+	// setMergeStrategy updates the rule protecting main to also trust the
+	// targets1 key, and sets its merge strategy. If withDenyRule is set, a deny
+	// rule that matches main and exempts the GPG key is also added.
+	setMergeStrategy := func(t *testing.T, state *State, mergeStrategy string, withDenyRule bool) {
+		t.Helper()
+
+		gpgKey, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+		if err != nil {
+			t.Fatal(err)
+		}
+		targets1Key, err := tuf.LoadKeyFromBytes(targets1PubKeyBytes)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		targetsMetadata, err := state.GetTargetsMetadata(TargetsRoleName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsMetadata, err = UpdateDelegation(targetsMetadata, "protect-main", []*tuf.Key{gpgKey, targets1Key}, []string{"git:" + refName}, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsMetadata, err = SetMergeStrategy(targetsMetadata, "protect-main", mergeStrategy)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if withDenyRule {
+			targetsMetadata, err = AddDelegation(targetsMetadata, "deny-main", []*tuf.Key{gpgKey}, []string{"git:" + refName}, 1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			targetsMetadata, err = SetRuleEffect(targetsMetadata, "deny-main", RuleEffectDeny)
+			if err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		signer, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(rootKeyBytes) //nolint:staticcheck
+		if err != nil {
+			t.Fatal(err)
+		}
+		env, err := dsse.CreateEnvelope(targetsMetadata)
+		if err != nil {
+			t.Fatal(err)
+		}
+		env, err = dsse.SignEnvelope(testCtx, env, signer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		state.TargetsEnvelope = env
+	}
+
+	tests := map[string]struct {
+		mergeStrategy string
+		err           error
+	}{
+		"all commits": {
+			mergeStrategy: MergeStrategyAllCommits,
+			err:           ErrUnauthorizedSignature,
+		},
+		"first parent": {
+			mergeStrategy: MergeStrategyFirstParent,
+		},
+		"merge committer": {
+			mergeStrategy: MergeStrategyMergeCommitter,
+		},
+		"review attestation, no attestation": {
+			mergeStrategy: MergeStrategyReviewAttestation,
+			err:           ErrUnauthorizedSignature,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			repo, state := createTestRepository(t, createTestStateWithPolicy)
+			setMergeStrategy(t, state, test.mergeStrategy, false)
+
+			entry := createMerge(t, repo, gpgUnauthorizedKeyBytes)
+
+			err := verifyEntry(testCtx, repo, state, nil, entry)
+			if test.err != nil {
+				assert.ErrorIs(t, err, test.err)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+
+	t.Run("review attestation", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithPolicy)
+		setMergeStrategy(t, state, MergeStrategyReviewAttestation, false)
+
+		entry := createMerge(t, repo, gpgUnauthorizedKeyBytes)
+
+		priorEntry, _, err := rsl.GetLatestReferenceEntryForRefBefore(repo, refName, entry.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		mergeCommit, err := gitinterface.GetCommit(repo, entry.TargetID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		authorization, err := attestations.NewReferenceAuthorization(refName, priorEntry.TargetID.String(), mergeCommit.TreeHash.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		signer, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(targets1KeyBytes) //nolint:staticcheck
+		if err != nil {
+			t.Fatal(err)
+		}
+		env, err := dsse.CreateEnvelope(authorization)
+		if err != nil {
+			t.Fatal(err)
+		}
+		env, err = dsse.SignEnvelope(testCtx, env, signer)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		currentAttestations, err := attestations.LoadCurrentAttestations(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := currentAttestations.SetReferenceAuthorization(repo, env, refName, priorEntry.TargetID.String(), mergeCommit.TreeHash.String()); err != nil {
+			t.Fatal(err)
+		}
+
+		err = verifyEntry(testCtx, repo, state, currentAttestations, entry)
+		assert.Nil(t, err)
+	})
+
+	t.Run("review attestation, no attestation, with matching deny rule", func(t *testing.T) {
+		repo, state := createTestRepository(t, createTestStateWithPolicy)
+		setMergeStrategy(t, state, MergeStrategyReviewAttestation, true)
+
+		// All commits are signed using the GPG key, so the merge is only
+		// rejected for lacking a review
+		entry := createMerge(t, repo, gpgKeyBytes)
+
+		err := verifyEntry(testCtx, repo, state, nil, entry)
+		assert.ErrorIs(t, err, ErrUnauthorizedSignature)
+		assert.Contains(t, err.Error(), "merge requires review attestation")
+	})
+}
+
+func TestVerifyEntryWithForgeApproval(t *testing.T) {
+	refName := "refs/heads/main"
+	approver := "alice"
+
+	// setup requires a signature from the GPG key and an approval from either
+	// the GitHub or GitLab user for main, and trusts the targets1 key as the
+	// GitHub and GitLab apps. It returns an entry for a commit signed by the
+	// GPG key.
+	setup := func(t *testing.T) (*git.Repository, *State, *rsl.ReferenceEntry) {
+		t.Helper()
+
+		repo, state := createTestRepository(t, createTestStateWithPolicy)
+
+		signer, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(rootKeyBytes) //nolint:staticcheck
+		if err != nil {
+			t.Fatal(err)
+		}
+		appKey, err := tuf.LoadKeyFromBytes(targets1PubKeyBytes)
+		if err != nil {
+			t.Fatal(err)
+		}
+		gpgKey, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		rootMetadata, err := state.GetRootMetadata()
+		if err != nil {
+			t.Fatal(err)
+		}
+		rootMetadata, err = AddGitHubAppKey(rootMetadata, appKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rootMetadata, err = AddGitLabAppKey(rootMetadata, appKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rootEnv, err := dsse.CreateEnvelope(rootMetadata)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rootEnv, err = dsse.SignEnvelope(testCtx, rootEnv, signer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		state.RootEnvelope = rootEnv
+
+		targetsMetadata, err := state.GetTargetsMetadata(TargetsRoleName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsMetadata, err = UpdateDelegation(targetsMetadata, "protect-main", []*tuf.Key{gpgKey, github.NewIdentityKey(approver), gitlab.NewIdentityKey(approver)}, []string{"git:" + refName}, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsEnv, err := dsse.CreateEnvelope(targetsMetadata)
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsEnv, err = dsse.SignEnvelope(testCtx, targetsEnv, signer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		state.TargetsEnvelope = targetsEnv
+
+		commit := gitinterface.CreateCommitObject(common.TestGitConfig, gitinterface.EmptyTree(), nil, "Test commit", common.TestClock)
+		commit = common.SignTestCommit(t, repo, commit, gpgKeyBytes)
+		commitID, err := gitinterface.WriteCommit(repo, commit)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		entry := rsl.NewReferenceEntry(refName, commitID)
+		entry.ID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+
+		return repo, state, entry
+	}
+
+	// createApproval returns attestations with a GitHub or GitLab approval for
+	// the entry listing the approvers and signed using the specified key.
+	createApproval := func(t *testing.T, repo *git.Repository, entry *rsl.ReferenceEntry, forge string, approvers []string, signingKeyBytes []byte) *attestations.Attestations {
+		t.Helper()
+
+		commit, err := gitinterface.GetCommit(repo, entry.TargetID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fromID := plumbing.ZeroHash.String()
+		treeID := commit.TreeHash.String()
+
+		var approval *ita.Statement
+		if forge == "github" {
+			approval, err = attestations.NewGitHubPullRequestApproval(refName, fromID, treeID, "https://github.com/gittuf/gittuf/pull/1", approvers)
+		} else {
+			approval, err = attestations.NewGitLabMergeRequestApproval(refName, fromID, treeID, "https://gitlab.com/gittuf/gittuf/-/merge_requests/1", approvers)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		signer, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(signingKeyBytes) //nolint:staticcheck
+		if err != nil {
+			t.Fatal(err)
+		}
+		env, err := dsse.CreateEnvelope(approval)
+		if err != nil {
+			t.Fatal(err)
+		}
+		env, err = dsse.SignEnvelope(testCtx, env, signer)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		currentAttestations, err := attestations.LoadCurrentAttestations(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if forge == "github" {
+			err = currentAttestations.SetGitHubPullRequestApproval(repo, env, refName, fromID, treeID)
+		} else {
+			err = currentAttestations.SetGitLabMergeRequestApproval(repo, env, refName, fromID, treeID)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return currentAttestations
+	}
+
+	t.Run("no approval", func(t *testing.T) {
+		repo, state, entry := setup(t)
+
+		err := verifyEntry(testCtx, repo, state, nil, entry)
+		assert.ErrorIs(t, err, ErrUnauthorizedSignature)
+	})
+
+	for _, forge := range []string{"github", "gitlab"} {
+		t.Run(forge, func(t *testing.T) {
+			t.Run("approval", func(t *testing.T) {
+				repo, state, entry := setup(t)
+				currentAttestations := createApproval(t, repo, entry, forge, []string{approver}, targets1KeyBytes)
+
+				err := verifyEntry(testCtx, repo, state, currentAttestations, entry)
+				assert.Nil(t, err)
+			})
+
+			t.Run("approval from unauthorized user", func(t *testing.T) {
+				repo, state, entry := setup(t)
+				currentAttestations := createApproval(t, repo, entry, forge, []string{"bob"}, targets1KeyBytes)
+
+				err := verifyEntry(testCtx, repo, state, currentAttestations, entry)
+				assert.ErrorIs(t, err, ErrUnauthorizedSignature)
+			})
+
+			t.Run("approval signed by untrusted key", func(t *testing.T) {
+				repo, state, entry := setup(t)
+				currentAttestations := createApproval(t, repo, entry, forge, []string{approver}, targets2KeyBytes)
+
+				err := verifyEntry(testCtx, repo, state, currentAttestations, entry)
+				assert.ErrorIs(t, err, ErrUnauthorizedSignature)
+			})
+		})
+	}
+}
+
+func TestVerifyEntryWithCodeReview(t *testing.T) {
+	refName := "refs/heads/main"
+
+	// setup requires signatures from the GPG key and the targets1 key for
+	// main. It returns an entry for a commit signed by the GPG key.
+	setup := func(t *testing.T) (*git.Repository, *State, *rsl.ReferenceEntry) {
+		t.Helper()
+
+		repo, state := createTestRepository(t, createTestStateWithPolicy)
+
+		signer, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(rootKeyBytes) //nolint:staticcheck
+		if err != nil {
+			t.Fatal(err)
+		}
+		gpgKey, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+		if err != nil {
+			t.Fatal(err)
+		}
+		reviewerKey, err := tuf.LoadKeyFromBytes(targets1PubKeyBytes)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		targetsMetadata, err := state.GetTargetsMetadata(TargetsRoleName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsMetadata, err = UpdateDelegation(targetsMetadata, "protect-main", []*tuf.Key{gpgKey, reviewerKey}, []string{"git:" + refName}, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsEnv, err := dsse.CreateEnvelope(targetsMetadata)
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsEnv, err = dsse.SignEnvelope(testCtx, targetsEnv, signer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		state.TargetsEnvelope = targetsEnv
+
+		commit := gitinterface.CreateCommitObject(common.TestGitConfig, gitinterface.EmptyTree(), nil, "Test commit", common.TestClock)
+		commit = common.SignTestCommit(t, repo, commit, gpgKeyBytes)
+		commitID, err := gitinterface.WriteCommit(repo, commit)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		entry := rsl.NewReferenceEntry(refName, commitID)
+		entry.ID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+
+		return repo, state, entry
+	}
+
+	// createReview returns attestations with a review for the entry by the
+	// reviewer, signed using the specified key.
+	createReview := func(t *testing.T, repo *git.Repository, entry *rsl.ReferenceEntry, reviewer, verdict string, signingKeyBytes []byte) *attestations.Attestations {
+		t.Helper()
+
+		commit, err := gitinterface.GetCommit(repo, entry.TargetID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		fromID := plumbing.ZeroHash.String()
+		treeID := commit.TreeHash.String()
+
+		review, err := attestations.NewCodeReview(refName, fromID, treeID, reviewer, verdict)
+		if err != nil {
+			t.Fatal(err)
+		}
+		signer, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(signingKeyBytes) //nolint:staticcheck
+		if err != nil {
+			t.Fatal(err)
+		}
+		env, err := dsse.CreateEnvelope(review)
+		if err != nil {
+			t.Fatal(err)
+		}
+		env, err = dsse.SignEnvelope(testCtx, env, signer)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		currentAttestations, err := attestations.LoadCurrentAttestations(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := currentAttestations.SetCodeReview(repo, env, refName, fromID, treeID); err != nil {
+			t.Fatal(err)
+		}
+
+		return currentAttestations
+	}
+
+	reviewerKey, err := tuf.LoadKeyFromBytes(targets1PubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		reviewer        string
+		verdict         string
+		signingKeyBytes []byte
+		err             error
+	}{
+		"no review": {
+			err: ErrUnauthorizedSignature,
+		},
+		"approved": {
+			reviewer:        reviewerKey.KeyID,
+			verdict:         attestations.CodeReviewVerdictApproved,
+			signingKeyBytes: targets1KeyBytes,
+		},
+		"changes requested": {
+			reviewer:        reviewerKey.KeyID,
+			verdict:         attestations.CodeReviewVerdictChangesRequested,
+			signingKeyBytes: targets1KeyBytes,
+			err:             ErrUnauthorizedSignature,
+		},
+		"approved, signed by another key": {
+			reviewer:        reviewerKey.KeyID,
+			verdict:         attestations.CodeReviewVerdictApproved,
+			signingKeyBytes: targets2KeyBytes,
+			err:             ErrUnauthorizedSignature,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			repo, state, entry := setup(t)
+
+			var currentAttestations *attestations.Attestations
+			if test.reviewer != "" {
+				currentAttestations = createReview(t, repo, entry, test.reviewer, test.verdict, test.signingKeyBytes)
+			}
+
+			err := verifyEntry(testCtx, repo, state, currentAttestations, entry)
+			if test.err != nil {
+				assert.ErrorIs(t, err, test.err)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+}
+
+func TestVerifyEntryWithPushAuthorization(t *testing.T) {
+	refName := "refs/heads/main"
+
+	// setup trusts the GPG key and the targets1 key for main, and returns an
+	// entry for a commit pushed by an unauthorized key.
+	setup := func(t *testing.T) (*git.Repository, *State, *rsl.ReferenceEntry) {
+		t.Helper()
+
+		repo, state := createTestRepository(t, createTestStateWithPolicy)
+
+		signer, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(rootKeyBytes) //nolint:staticcheck
+		if err != nil {
+			t.Fatal(err)
+		}
+		gpgKey, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+		if err != nil {
+			t.Fatal(err)
+		}
+		targets1Key, err := tuf.LoadKeyFromBytes(targets1PubKeyBytes)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		targetsMetadata, err := state.GetTargetsMetadata(TargetsRoleName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsMetadata, err = UpdateDelegation(targetsMetadata, "protect-main", []*tuf.Key{gpgKey, targets1Key}, []string{"git:" + refName}, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsEnv, err := dsse.CreateEnvelope(targetsMetadata)
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsEnv, err = dsse.SignEnvelope(testCtx, targetsEnv, signer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		state.TargetsEnvelope = targetsEnv
+
+		commit := gitinterface.CreateCommitObject(common.TestGitConfig, gitinterface.EmptyTree(), nil, "Test commit", common.TestClock)
+		commit = common.SignTestCommit(t, repo, commit, gpgUnauthorizedKeyBytes)
+		commitID, err := gitinterface.WriteCommit(repo, commit)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		entry := rsl.NewReferenceEntry(refName, commitID)
+		entry.ID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgUnauthorizedKeyBytes)
+
+		return repo, state, entry
+	}
+
+	// createAuthorization returns attestations with a push authorization for
+	// the update of main to the target, signed using the targets1 key.
+	createAuthorization := func(t *testing.T, repo *git.Repository, targetID plumbing.Hash) *attestations.Attestations {
+		t.Helper()
+
+		fromID := plumbing.ZeroHash.String()
+
+		authorization, err := attestations.NewPushAuthorization(refName, fromID, targetID.String())
+		if err != nil {
+			t.Fatal(err)
+		}
+		signer, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(targets1KeyBytes) //nolint:staticcheck
+		if err != nil {
+			t.Fatal(err)
+		}
+		env, err := dsse.CreateEnvelope(authorization)
+		if err != nil {
+			t.Fatal(err)
+		}
+		env, err = dsse.SignEnvelope(testCtx, env, signer)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		currentAttestations, err := attestations.LoadCurrentAttestations(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := currentAttestations.SetPushAuthorization(repo, env, refName, fromID, targetID.String()); err != nil {
+			t.Fatal(err)
+		}
+
+		return currentAttestations
+	}
+
+	t.Run("no authorization", func(t *testing.T) {
+		repo, state, entry := setup(t)
+
+		err := verifyEntry(testCtx, repo, state, nil, entry)
+		assert.ErrorIs(t, err, ErrUnauthorizedSignature)
+	})
+
+	t.Run("authorized", func(t *testing.T) {
+		repo, state, entry := setup(t)
+		currentAttestations := createAuthorization(t, repo, entry.TargetID)
+
+		err := verifyEntry(testCtx, repo, state, currentAttestations, entry)
+		assert.Nil(t, err)
+	})
+
+	t.Run("authorization for another target", func(t *testing.T) {
+		repo, state, entry := setup(t)
+		currentAttestations := createAuthorization(t, repo, plumbing.NewHash("2f5d1d9b7c1b8b6f0b4c0e6a1d1c2c3b4a5f6e7d"))
+
+		err := verifyEntry(testCtx, repo, state, currentAttestations, entry)
+		assert.ErrorIs(t, err, ErrUnauthorizedSignature)
+	})
+}
+
+func TestVerifyEntryWithDenyRules(t *testing.T) {
+	refName := "refs/heads/main"
+
+	gpgKey, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, err := gpg.LoadGPGKeyFromBytes(artifacts.GPGKey2Public)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// createStateWithDenyRules returns a policy where both GPG keys may
+	// change main and all files. For each of exceptions, a deny rule for file
+	// 1 is added with the key as its exception.
+	createStateWithDenyRules := func(t *testing.T, exceptions ...*tuf.Key) func(*testing.T) *State {
+		t.Helper()
+
+		return func(t *testing.T) *State {
+			t.Helper()
+
+			// The targets metadata is replaced below
+			state := createTestStateWithPolicy(t)
+
+			signer, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(rootKeyBytes) //nolint:staticcheck
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			targetsMetadata := InitializeTargetsMetadata()
+			targetsMetadata, err = AddDelegation(targetsMetadata, "protect-main", []*tuf.Key{gpgKey, otherKey}, []string{"git:" + refName}, 1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			targetsMetadata, err = AddDelegation(targetsMetadata, "allow-all-files", []*tuf.Key{gpgKey, otherKey}, []string{"file:*"}, 1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i, key := range exceptions {
+				ruleName := fmt.Sprintf("deny-file-1-%d", i)
+				targetsMetadata, err = AddDelegation(targetsMetadata, ruleName, []*tuf.Key{key}, []string{"file:1"}, 1)
+				if err != nil {
+					t.Fatal(err)
+				}
+				targetsMetadata, err = SetRuleEffect(targetsMetadata, ruleName, RuleEffectDeny)
+				if err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			targetsEnv, err := dsse.CreateEnvelope(targetsMetadata)
+			if err != nil {
+				t.Fatal(err)
+			}
+			targetsEnv, err = dsse.SignEnvelope(testCtx, targetsEnv, signer)
+			if err != nil {
+				t.Fatal(err)
+			}
+			state.TargetsEnvelope = targetsEnv
+
+			if err := state.loadRuleNames(); err != nil {
+				t.Fatal(err)
+			}
+
+			return state
+		}
+	}
+
+	t.Run("no deny rules", func(t *testing.T) {
+		repo, state := createTestRepository(t, createStateWithDenyRules(t))
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgUnauthorizedKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		entry.ID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgUnauthorizedKeyBytes)
+
+		err := verifyEntry(testCtx, repo, state, nil, entry)
+		assert.Nil(t, err)
+	})
+
+	t.Run("change by exception to deny rule", func(t *testing.T) {
+		repo, state := createTestRepository(t, createStateWithDenyRules(t, gpgKey))
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		entry.ID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+
+		err := verifyEntry(testCtx, repo, state, nil, entry)
+		assert.Nil(t, err)
+	})
+
+	t.Run("deny rule takes precedence over allow rule", func(t *testing.T) {
+		repo, state := createTestRepository(t, createStateWithDenyRules(t, gpgKey))
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgUnauthorizedKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		entry.ID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgUnauthorizedKeyBytes)
+
+		err := verifyEntry(testCtx, repo, state, nil, entry)
+		assert.ErrorIs(t, err, ErrUnauthorizedSignature)
+	})
+
+	t.Run("every deny rule must be met", func(t *testing.T) {
+		repo, state := createTestRepository(t, createStateWithDenyRules(t, gpgKey, otherKey))
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		entry.ID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+
+		err := verifyEntry(testCtx, repo, state, nil, entry)
+		assert.ErrorIs(t, err, ErrUnauthorizedSignature)
+	})
+}
+
+func TestVerifyTagEntry(t *testing.T) {
+	t.Run("no tag specific policy", func(t *testing.T) {
+		repo, policy := createTestRepository(t, createTestStateWithPolicy)
+		refName := "refs/heads/main"
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 3, gpgKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[len(commitIDs)-1])
+		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
+
+		tagName := "v1"
+		tagID := common.CreateTestSignedTag(t, repo, tagName, commitIDs[len(commitIDs)-1], gpgKeyBytes)
+
+		entry = rsl.NewReferenceEntry(string(plumbing.NewTagReferenceName(tagName)), tagID)
+		entryID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
+
+		err := verifyTagEntry(context.Background(), repo, policy, entry)
+		assert.Nil(t, err)
+	})
+
+	t.Run("with tag specific policy", func(t *testing.T) {
+		repo, policy := createTestRepository(t, createTestStateWithTagPolicy)
+		refName := "refs/heads/main"
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 3, gpgKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[len(commitIDs)-1])
+		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
+
+		tagName := "v1"
+		tagID := common.CreateTestSignedTag(t, repo, tagName, commitIDs[len(commitIDs)-1], gpgKeyBytes)
+
+		entry = rsl.NewReferenceEntry(string(plumbing.NewTagReferenceName(tagName)), tagID)
+		entryID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
+
+		err := verifyTagEntry(context.Background(), repo, policy, entry)
+		assert.Nil(t, err)
+	})
+
+	t.Run("with tag specific policy, unauthorized", func(t *testing.T) {
+		repo, policy := createTestRepository(t, createTestStateWithTagPolicyForUnauthorizedTest)
+		refName := "refs/heads/main"
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 3, gpgKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[len(commitIDs)-1])
+		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
+
+		tagName := "v1"
+		tagID := common.CreateTestSignedTag(t, repo, tagName, commitIDs[len(commitIDs)-1], gpgKeyBytes)
+
+		entry = rsl.NewReferenceEntry(string(plumbing.NewTagReferenceName(tagName)), tagID)
+		entryID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+		entry.ID = entryID
+
+		err := verifyTagEntry(context.Background(), repo, policy, entry)
+		assert.ErrorIs(t, err, ErrUnauthorizedSignature)
+	})
+
+	gpgKey, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, err := gpg.LoadGPGKeyFromBytes(artifacts.GPGKey2Public)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// createStateWithTagRule returns a policy where main is protected as in
+	// createTestStateWithPolicy, and tags are protected by the rule added
+	// using addTagRule.
+	createStateWithTagRule := func(addTagRule func(*testing.T, *tuf.TargetsMetadata) *tuf.TargetsMetadata) func(*testing.T) *State {
+		return func(t *testing.T) *State {
+			t.Helper()
+
+			state := createTestStateWithPolicy(t)
+
+			targetsMetadata, err := state.GetTargetsMetadata(TargetsRoleName)
+			if err != nil {
+				t.Fatal(err)
+			}
+			targetsMetadata = addTagRule(t, targetsMetadata)
+
+			signer, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(rootKeyBytes) //nolint:staticcheck
+			if err != nil {
+				t.Fatal(err)
+			}
+			targetsEnv, err := dsse.CreateEnvelope(targetsMetadata)
+			if err != nil {
+				t.Fatal(err)
+			}
+			targetsEnv, err = dsse.SignEnvelope(testCtx, targetsEnv, signer)
+			if err != nil {
+				t.Fatal(err)
+			}
+			state.TargetsEnvelope = targetsEnv
+
+			if err := state.loadRuleNames(); err != nil {
+				t.Fatal(err)
+			}
+
+			return state
+		}
+	}
+
+	// createTag records a tag for a new commit to main. The tag object and its
+	// RSL entry are signed using keyBytes.
+	createTag := func(t *testing.T, repo *git.Repository, keyBytes []byte) *rsl.ReferenceEntry {
+		t.Helper()
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, "refs/heads/main", 1, gpgKeyBytes)
+
+		tagName := "v1"
+		tagID := common.CreateTestSignedTag(t, repo, tagName, commitIDs[0], keyBytes)
+
+		entry := rsl.NewReferenceEntry(string(plumbing.NewTagReferenceName(tagName)), tagID)
+		entry.ID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, keyBytes)
+		return entry
+	}
+
+	t.Run("with tag specific policy, threshold not met", func(t *testing.T) {
+		repo, policy := createTestRepository(t, createStateWithTagRule(func(t *testing.T, targetsMetadata *tuf.TargetsMetadata) *tuf.TargetsMetadata {
+			t.Helper()
+
+			targetsMetadata, err := AddDelegation(targetsMetadata, "protect-tags", []*tuf.Key{gpgKey, otherKey}, []string{"git:refs/tags/*"}, 2)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return targetsMetadata
+		}))
+
+		entry := createTag(t, repo, gpgKeyBytes)
+
+		err := verifyTagEntry(testCtx, repo, policy, entry)
+		assert.ErrorIs(t, err, ErrUnauthorizedSignature)
+	})
+
+	t.Run("with tag specific policy authorizing a team", func(t *testing.T) {
+		createState := createStateWithTagRule(func(t *testing.T, targetsMetadata *tuf.TargetsMetadata) *tuf.TargetsMetadata {
+			t.Helper()
+
+			targetsMetadata, err := AddTeamMembers(targetsMetadata, "release-managers", []*tuf.Key{otherKey}, nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+			targetsMetadata, err = AddDelegation(targetsMetadata, "protect-tags", nil, []string{"git:refs/tags/*"}, 1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			targetsMetadata, err = SetAuthorizedTeams(targetsMetadata, "protect-tags", []string{"release-managers"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			return targetsMetadata
+		})
+
+		repo, policy := createTestRepository(t, createState)
+		entry := createTag(t, repo, artifacts.GPGKey2Private)
+
+		err := verifyTagEntry(testCtx, repo, policy, entry)
+		assert.Nil(t, err)
+
+		// The policy's other keys may not sign tags
+		repo, policy = createTestRepository(t, createState)
+		entry = createTag(t, repo, gpgKeyBytes)
+
+		err = verifyTagEntry(testCtx, repo, policy, entry)
+		assert.ErrorIs(t, err, ErrUnauthorizedSignature)
+	})
+
+	t.Run("with tag specific policy authorizing a person", func(t *testing.T) {
+		createState := createStateWithTagRule(func(t *testing.T, targetsMetadata *tuf.TargetsMetadata) *tuf.TargetsMetadata {
+			t.Helper()
+
+			targetsMetadata, err := AddPerson(targetsMetadata, "jane.doe", []*tuf.Key{otherKey})
+			if err != nil {
+				t.Fatal(err)
+			}
+			targetsMetadata, err = AddDelegation(targetsMetadata, "protect-tags", nil, []string{"git:refs/tags/*"}, 1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			targetsMetadata, err = SetAuthorizedPersons(targetsMetadata, "protect-tags", []string{"jane.doe"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			return targetsMetadata
+		})
+
+		repo, policy := createTestRepository(t, createState)
+		entry := createTag(t, repo, artifacts.GPGKey2Private)
+
+		err := verifyTagEntry(testCtx, repo, policy, entry)
+		assert.Nil(t, err)
+
+		// The policy's other keys may not sign tags
+		repo, policy = createTestRepository(t, createState)
+		entry = createTag(t, repo, gpgKeyBytes)
+
+		err = verifyTagEntry(testCtx, repo, policy, entry)
+		assert.ErrorIs(t, err, ErrUnauthorizedSignature)
+	})
+
+	t.Run("with tag specific policy authorizing a team of people", func(t *testing.T) {
+		createState := createStateWithTagRule(func(t *testing.T, targetsMetadata *tuf.TargetsMetadata) *tuf.TargetsMetadata {
+			t.Helper()
+
+			targetsMetadata, err := AddPerson(targetsMetadata, "jane.doe", []*tuf.Key{otherKey})
+			if err != nil {
+				t.Fatal(err)
+			}
+			targetsMetadata, err = AddTeamMembers(targetsMetadata, "release-managers", nil, []string{"jane.doe"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			targetsMetadata, err = AddDelegation(targetsMetadata, "protect-tags", nil, []string{"git:refs/tags/*"}, 1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			targetsMetadata, err = SetAuthorizedTeams(targetsMetadata, "protect-tags", []string{"release-managers"})
+			if err != nil {
+				t.Fatal(err)
+			}
+			return targetsMetadata
+		})
+
+		repo, policy := createTestRepository(t, createState)
+		entry := createTag(t, repo, artifacts.GPGKey2Private)
+
+		err := verifyTagEntry(testCtx, repo, policy, entry)
+		assert.Nil(t, err)
+
+		// The policy's other keys may not sign tags
+		repo, policy = createTestRepository(t, createState)
+		entry = createTag(t, repo, gpgKeyBytes)
+
+		err = verifyTagEntry(testCtx, repo, policy, entry)
+		assert.ErrorIs(t, err, ErrUnauthorizedSignature)
+	})
+
+	t.Run("with tag specific deny rule", func(t *testing.T) {
+		repo, policy := createTestRepository(t, createStateWithTagRule(func(t *testing.T, targetsMetadata *tuf.TargetsMetadata) *tuf.TargetsMetadata {
+			t.Helper()
+
+			targetsMetadata, err := AddDelegation(targetsMetadata, "deny-tags", nil, []string{"git:refs/tags/*"}, 1)
+			if err != nil {
+				t.Fatal(err)
+			}
+			targetsMetadata, err = SetRuleEffect(targetsMetadata, "deny-tags", RuleEffectDeny)
+			if err != nil {
+				t.Fatal(err)
+			}
+			return targetsMetadata
+		}))
+
+		entry := createTag(t, repo, gpgKeyBytes)
+
+		err := verifyTagEntry(testCtx, repo, policy, entry)
+		assert.ErrorIs(t, err, ErrUnauthorizedSignature)
+	})
+
+	t.Run("with tag specific policy, tag signed using SHA-1 with algorithm policy rejecting SHA-1", func(t *testing.T) {
+		repo, policy := createTestRepository(t, createTestStateWithTagPolicy)
+		setTestAlgorithmPolicy(t, policy, &tuf.AlgorithmPolicy{RejectSHA1: true})
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, "refs/heads/main", 1, gpgKeyBytes)
+		commit, err := gitinterface.GetCommit(repo, commitIDs[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		tag := gitinterface.CreateTagObject(common.TestGitConfig, commit, "v1", "v1\n", common.TestClock)
+		tag.PGPSignature = createTestSHA1Signature(t, tag, gpgKeyBytes)
+		tagID, err := gitinterface.ApplyTag(repo, tag)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		entry := rsl.NewReferenceEntry(string(plumbing.NewTagReferenceName("v1")), tagID)
+		entry.ID = common.CreateTestRSLReferenceEntryCommit(t, repo, entry, gpgKeyBytes)
+
+		err = verifyTagEntry(testCtx, repo, policy, entry)
+		assert.ErrorIs(t, err, ErrUnauthorizedSignature)
+		assert.Contains(t, err.Error(), "tag object's signature")
+	})
+}
+
+func TestGetCommits(t *testing.T) {
+	repo, _ := createTestRepository(t, createTestStateWithPolicy)
+
+	refName := "refs/heads/main"
+
+	if err := repo.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(refName), plumbing.ZeroHash)); err != nil {
+		t.Fatal(err)
+	}
+
+	// FIXME: this setup with RSL entries can be formalized using another
+	// helper like createTestStateWithPolicy. The RSL could then also
+	// incorporate policy changes and so on.
+	commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 5, gpgKeyBytes)
+	firstEntry := rsl.NewReferenceEntry(refName, commitIDs[0])
+	firstEntryID := common.CreateTestRSLReferenceEntryCommit(t, repo, firstEntry, gpgKeyBytes)
+	firstEntry.ID = firstEntryID
+
+	secondEntry := rsl.NewReferenceEntry(refName, commitIDs[4])
+	secondEntryID := common.CreateTestRSLReferenceEntryCommit(t, repo, secondEntry, gpgKeyBytes)
+	secondEntry.ID = secondEntryID
+
+	expectedCommitIDs := []plumbing.Hash{commitIDs[1], commitIDs[2], commitIDs[3], commitIDs[4]}
+	expectedCommits := make([]*object.Commit, 0, len(expectedCommitIDs))
+	for _, commitID := range expectedCommitIDs {
+		commit, err := gitinterface.GetCommit(repo, commitID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expectedCommits = append(expectedCommits, commit)
+	}
+
+	sort.Slice(expectedCommits, func(i, j int) bool {
+		return expectedCommits[i].ID().String() < expectedCommits[j].ID().String()
+	})
+
+	commits, err := getCommits(repo, secondEntry)
+	assert.Nil(t, err)
+	assert.Equal(t, expectedCommits, commits)
+}
+
+func TestGetChangedPaths(t *testing.T) {
+	repo, _ := createTestRepository(t, createTestStateWithPolicy)
+
+	refName := "refs/heads/main"
 
 	if err := repo.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(refName), plumbing.ZeroHash)); err != nil {
 		t.Fatal(err)
@@ -1123,6 +3220,8 @@ func TestVerifier(t *testing.T) {
 		t.Fatal(err)
 	}
 
+	githubKey := github.NewIdentityKey("alice")
+
 	commit := gitinterface.CreateCommitObject(common.TestGitConfig, gitinterface.EmptyTree(), []plumbing.Hash{plumbing.ZeroHash}, "Test commit", common.TestClock)
 	commit = common.SignTestCommit(t, repo, commit, gpgKeyBytes)
 	// We need to do this because tag expects a valid target object
@@ -1174,6 +3273,8 @@ func TestVerifier(t *testing.T) {
 		threshold     int
 		gitObject     object.Object
 		attestation   *sslibdsse.Envelope
+		approvers     []string
+		people        map[string]string
 		expectedError error
 	}{
 		"commit, no attestation, valid key, threshold 1": {
@@ -1212,6 +3313,82 @@ func TestVerifier(t *testing.T) {
 			gitObject:   commit,
 			attestation: attestationWithTwoSigs,
 		},
+		"commit, no attestation, valid key, approval, threshold 2": {
+			keys:      []*tuf.Key{gpgKey, githubKey},
+			threshold: 2,
+			gitObject: commit,
+			approvers: []string{githubKey.KeyID},
+		},
+		"commit, no attestation, valid key, unauthorized approval, threshold 2": {
+			keys:          []*tuf.Key{gpgKey, githubKey},
+			threshold:     2,
+			gitObject:     commit,
+			approvers:     []string{github.IdentityKeyID("bob")},
+			expectedError: ErrVerifierConditionsUnmet,
+		},
+		"commit, no attestation, approval by commit signer, threshold 2": {
+			keys:          []*tuf.Key{gpgKey, githubKey},
+			threshold:     2,
+			gitObject:     commit,
+			approvers:     []string{gpgKey.KeyID},
+			expectedError: ErrVerifierConditionsUnmet,
+		},
+		"no object, attestation, approval by attestation signer, threshold 2": {
+			keys:          []*tuf.Key{rootPubKey, githubKey},
+			threshold:     2,
+			attestation:   attestation,
+			approvers:     []string{rootPubKey.KeyID},
+			expectedError: ErrVerifierConditionsUnmet,
+		},
+		"no object, attestation, approval, threshold 2": {
+			keys:        []*tuf.Key{rootPubKey, githubKey},
+			threshold:   2,
+			attestation: attestation,
+			approvers:   []string{githubKey.KeyID},
+		},
+		"commit, attestation, keys of different people, threshold 2": {
+			keys:        []*tuf.Key{gpgKey, rootPubKey},
+			threshold:   2,
+			gitObject:   commit,
+			attestation: attestation,
+			people:      map[string]string{gpgKey.KeyID: "alice", rootPubKey.KeyID: "bob"},
+		},
+		"commit, attestation, keys of same person, threshold 2": {
+			keys:          []*tuf.Key{gpgKey, rootPubKey},
+			threshold:     2,
+			gitObject:     commit,
+			attestation:   attestation,
+			people:        map[string]string{gpgKey.KeyID: "alice", rootPubKey.KeyID: "alice"},
+			expectedError: ErrVerifierConditionsUnmet,
+		},
+		"commit, attestation, key of person and key, threshold 3": {
+			keys:        []*tuf.Key{gpgKey, rootPubKey, targetsPubKey},
+			threshold:   3,
+			gitObject:   commit,
+			attestation: attestationWithTwoSigs,
+			people:      map[string]string{gpgKey.KeyID: "alice"},
+		},
+		"no object, attestation, keys of same person, threshold 2": {
+			keys:          []*tuf.Key{rootPubKey, targetsPubKey},
+			threshold:     2,
+			attestation:   attestationWithTwoSigs,
+			people:        map[string]string{rootPubKey.KeyID: "alice", targetsPubKey.KeyID: "alice"},
+			expectedError: ErrVerifierConditionsUnmet,
+		},
+		"no object, attestation, keys of same person, threshold 1": {
+			keys:        []*tuf.Key{rootPubKey, targetsPubKey},
+			threshold:   1,
+			attestation: attestationWithTwoSigs,
+			people:      map[string]string{rootPubKey.KeyID: "alice", targetsPubKey.KeyID: "alice"},
+		},
+		"commit, no attestation, approval by commit signer's other key, threshold 2": {
+			keys:          []*tuf.Key{gpgKey, githubKey},
+			threshold:     2,
+			gitObject:     commit,
+			approvers:     []string{githubKey.KeyID},
+			people:        map[string]string{gpgKey.KeyID: "alice", githubKey.KeyID: "alice"},
+			expectedError: ErrVerifierConditionsUnmet,
+		},
 		"tag, no attestation, valid key, threshold 1": {
 			keys:      []*tuf.Key{gpgKey},
 			threshold: 1,
@@ -1251,8 +3428,8 @@ func TestVerifier(t *testing.T) {
 	}
 
 	for name, test := range tests {
-		verifier := Verifier{name: "test-verifier", keys: test.keys, threshold: test.threshold}
-		err := verifier.Verify(context.Background(), test.gitObject, test.attestation)
+		verifier := Verifier{name: "test-verifier", keys: test.keys, threshold: test.threshold, people: test.people}
+		err := verifier.VerifyWithApprovers(context.Background(), test.gitObject, test.attestation, test.approvers)
 		if test.expectedError == nil {
 			assert.Nil(t, err, fmt.Sprintf("unexpected error in test '%s'", name))
 		} else {
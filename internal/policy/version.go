@@ -0,0 +1,82 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"errors"
+	"fmt"
+
+	sslibdsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
+)
+
+var (
+	ErrMetadataRollback              = errors.New("metadata version is lower than its previous version")
+	ErrMetadataVersionNotIncremented = errors.New("metadata was changed without incrementing its version")
+)
+
+// verifyMetadataVersions checks that newPolicy does not roll back the versions
+// of the State's root of trust and policy files. Metadata that is changed in
+// newPolicy must have a higher version than in the State, so that an older
+// version of a role's metadata, signed when its keys were still trusted, cannot
+// be reintroduced in place of the current version.
+func (s *State) verifyMetadataVersions(newPolicy *State) error {
+	currentRootMetadata, err := s.GetRootMetadata()
+	if err != nil {
+		return err
+	}
+	newRootMetadata, err := newPolicy.GetRootMetadata()
+	if err != nil {
+		return err
+	}
+	if err := verifyVersionIncrement(RootRoleName, s.RootEnvelope, newPolicy.RootEnvelope, currentRootMetadata.Version, newRootMetadata.Version); err != nil {
+		return err
+	}
+
+	roleNames := []string{}
+	if s.TargetsEnvelope != nil && newPolicy.TargetsEnvelope != nil {
+		roleNames = append(roleNames, TargetsRoleName)
+	}
+	for roleName := range s.DelegationEnvelopes {
+		if _, has := newPolicy.DelegationEnvelopes[roleName]; has {
+			roleNames = append(roleNames, roleName)
+		}
+	}
+
+	for _, roleName := range roleNames {
+		currentEnv, newEnv := s.TargetsEnvelope, newPolicy.TargetsEnvelope
+		if roleName != TargetsRoleName {
+			currentEnv, newEnv = s.DelegationEnvelopes[roleName], newPolicy.DelegationEnvelopes[roleName]
+		}
+
+		currentTargetsMetadata, err := s.GetTargetsMetadata(roleName)
+		if err != nil {
+			return err
+		}
+		newTargetsMetadata, err := newPolicy.GetTargetsMetadata(roleName)
+		if err != nil {
+			return err
+		}
+
+		if err := verifyVersionIncrement(roleName, currentEnv, newEnv, currentTargetsMetadata.Version, newTargetsMetadata.Version); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// verifyVersionIncrement checks that newVersion does not roll back
+// currentVersion, and that it is higher than currentVersion if the metadata
+// in the envelope has changed. Changes to signatures alone do not require a new
+// version.
+func verifyVersionIncrement(roleName string, currentEnv, newEnv *sslibdsse.Envelope, currentVersion, newVersion int) error {
+	if newVersion < currentVersion {
+		return fmt.Errorf("%w: '%s' version %d follows version %d", ErrMetadataRollback, roleName, newVersion, currentVersion)
+	}
+
+	if newVersion == currentVersion && newEnv.Payload != currentEnv.Payload {
+		return fmt.Errorf("%w: '%s' version %d", ErrMetadataVersionNotIncremented, roleName, newVersion)
+	}
+
+	return nil
+}
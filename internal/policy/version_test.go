@@ -0,0 +1,110 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/signerverifier/dsse"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyMetadataVersions(t *testing.T) {
+	currentPolicy := createTestStateWithPolicy(t)
+
+	// withRoot returns a copy of currentPolicy with its root metadata
+	// modified by update
+	withRoot := func(t *testing.T, update func(*tuf.RootMetadata)) *State {
+		t.Helper()
+
+		rootMetadata, err := currentPolicy.GetRootMetadata()
+		if err != nil {
+			t.Fatal(err)
+		}
+		update(rootMetadata)
+		env, err := dsse.CreateEnvelope(rootMetadata)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return &State{
+			RootPublicKeys:      currentPolicy.RootPublicKeys,
+			RootEnvelope:        env,
+			TargetsEnvelope:     currentPolicy.TargetsEnvelope,
+			DelegationEnvelopes: currentPolicy.DelegationEnvelopes,
+		}
+	}
+
+	// withTargets returns a copy of currentPolicy with its top level targets
+	// metadata modified by update
+	withTargets := func(t *testing.T, update func(*tuf.TargetsMetadata)) *State {
+		t.Helper()
+
+		targetsMetadata, err := currentPolicy.GetTargetsMetadata(TargetsRoleName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		update(targetsMetadata)
+		env, err := dsse.CreateEnvelope(targetsMetadata)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return &State{
+			RootPublicKeys:      currentPolicy.RootPublicKeys,
+			RootEnvelope:        currentPolicy.RootEnvelope,
+			TargetsEnvelope:     env,
+			DelegationEnvelopes: currentPolicy.DelegationEnvelopes,
+		}
+	}
+
+	t.Run("unchanged metadata", func(t *testing.T) {
+		err := currentPolicy.verifyMetadataVersions(currentPolicy)
+		assert.Nil(t, err)
+	})
+
+	t.Run("changed metadata with incremented versions", func(t *testing.T) {
+		newPolicy := withRoot(t, func(rootMetadata *tuf.RootMetadata) {
+			rootMetadata.SetExpires("2100-01-01T00:00:00Z")
+			rootMetadata.SetVersion(rootMetadata.Version + 1)
+		})
+		err := currentPolicy.verifyMetadataVersions(newPolicy)
+		assert.Nil(t, err)
+
+		newPolicy = withTargets(t, func(targetsMetadata *tuf.TargetsMetadata) {
+			targetsMetadata.SetExpires("2100-01-01T00:00:00Z")
+			targetsMetadata.SetVersion(targetsMetadata.Version + 1)
+		})
+		err = currentPolicy.verifyMetadataVersions(newPolicy)
+		assert.Nil(t, err)
+	})
+
+	t.Run("changed metadata without incremented versions", func(t *testing.T) {
+		newPolicy := withRoot(t, func(rootMetadata *tuf.RootMetadata) {
+			rootMetadata.SetExpires("2100-01-01T00:00:00Z")
+		})
+		err := currentPolicy.verifyMetadataVersions(newPolicy)
+		assert.ErrorIs(t, err, ErrMetadataVersionNotIncremented)
+
+		newPolicy = withTargets(t, func(targetsMetadata *tuf.TargetsMetadata) {
+			targetsMetadata.SetExpires("2100-01-01T00:00:00Z")
+		})
+		err = currentPolicy.verifyMetadataVersions(newPolicy)
+		assert.ErrorIs(t, err, ErrMetadataVersionNotIncremented)
+	})
+
+	t.Run("rolled back metadata", func(t *testing.T) {
+		newPolicy := withRoot(t, func(rootMetadata *tuf.RootMetadata) {
+			rootMetadata.SetVersion(rootMetadata.Version + 1)
+		})
+		err := newPolicy.verifyMetadataVersions(currentPolicy)
+		assert.ErrorIs(t, err, ErrMetadataRollback)
+
+		newPolicy = withTargets(t, func(targetsMetadata *tuf.TargetsMetadata) {
+			targetsMetadata.SetVersion(targetsMetadata.Version + 1)
+		})
+		err = newPolicy.verifyMetadataVersions(currentPolicy)
+		assert.ErrorIs(t, err, ErrMetadataRollback)
+	})
+}
@@ -0,0 +1,100 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"sort"
+)
+
+// Authorization describes who can make changes to a path under policy. If
+// RequireAll is set, the change must meet every rule in Rules, as is the case
+// when deny rules protect the path. Otherwise, meeting any one of the rules is
+// sufficient. In addition, the change must always meet every rule in
+// GlobalRules. If no rules protect the path, Protected is false and any change
+// is permitted, subject to the global rules.
+type Authorization struct {
+	Path        string               `json:"path"`
+	Protected   bool                 `json:"protected"`
+	RequireAll  bool                 `json:"require_all"`
+	Rules       []*RuleAuthorization `json:"rules"`
+	GlobalRules []*RuleAuthorization `json:"global_rules,omitempty"`
+}
+
+// RuleAuthorization describes the principals that can meet a rule. The rule
+// is met when Threshold distinct principals approve the change.
+type RuleAuthorization struct {
+	Name          string                 `json:"name"`
+	Threshold     int                    `json:"threshold"`
+	Principals    []*AuthorizedPrincipal `json:"principals"`
+	RevokedKeyIDs []string               `json:"revoked_keyids,omitempty"`
+}
+
+// AuthorizedPrincipal is a person or a key that does not belong to a person,
+// along with the keys it can use to approve changes.
+type AuthorizedPrincipal struct {
+	ID     string   `json:"id"`
+	KeyIDs []string `json:"keyids"`
+}
+
+// WhoCanAuthorize returns the rules, thresholds, and principals that can
+// authorize a change to path. Revoked keys and keys that do not meet the
+// root of trust's algorithm policy cannot authorize changes, and are not
+// included as principals.
+func (s *State) WhoCanAuthorize(path string) (*Authorization, error) {
+	verifiers, err := s.FindVerifiersForPath(path)
+	if err != nil {
+		return nil, err
+	}
+	verifiers, denied := applyDenyRules(verifiers)
+
+	authorization := &Authorization{
+		Path:       path,
+		Protected:  len(verifiers) > 0,
+		RequireAll: denied,
+		Rules:      make([]*RuleAuthorization, 0, len(verifiers)),
+	}
+	for _, verifier := range verifiers {
+		authorization.Rules = append(authorization.Rules, newRuleAuthorization(verifier))
+	}
+
+	globalVerifiers, err := s.findGlobalVerifiersForPath(path, verifiers)
+	if err != nil {
+		return nil, err
+	}
+	for _, globalVerifier := range globalVerifiers {
+		authorization.GlobalRules = append(authorization.GlobalRules, newRuleAuthorization(globalVerifier))
+	}
+
+	return authorization, nil
+}
+
+func newRuleAuthorization(verifier *Verifier) *RuleAuthorization {
+	principals := map[string]*AuthorizedPrincipal{}
+	for _, key := range verifier.keys {
+		principalID := verifier.principal(key.KeyID)
+		principal, has := principals[principalID]
+		if !has {
+			principal = &AuthorizedPrincipal{ID: principalID, KeyIDs: []string{}}
+			principals[principalID] = principal
+		}
+		principal.KeyIDs = append(principal.KeyIDs, key.KeyID)
+	}
+
+	ruleAuthorization := &RuleAuthorization{
+		Name:       verifier.name,
+		Threshold:  verifier.threshold,
+		Principals: make([]*AuthorizedPrincipal, 0, len(principals)),
+	}
+	for _, principal := range principals {
+		ruleAuthorization.Principals = append(ruleAuthorization.Principals, principal)
+	}
+	sort.Slice(ruleAuthorization.Principals, func(i, j int) bool {
+		return ruleAuthorization.Principals[i].ID < ruleAuthorization.Principals[j].ID
+	})
+
+	for _, revoked := range verifier.revokedKeys {
+		ruleAuthorization.RevokedKeyIDs = append(ruleAuthorization.RevokedKeyIDs, revoked.key.KeyID)
+	}
+
+	return ruleAuthorization
+}
@@ -0,0 +1,153 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package policy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/signerverifier"
+	"github.com/gittuf/gittuf/internal/signerverifier/dsse"
+	"github.com/gittuf/gittuf/internal/signerverifier/gpg"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStateWhoCanAuthorize(t *testing.T) {
+	signer, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(rootKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+	personKey, err := tuf.LoadKeyFromBytes(targets1PubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key, err := tuf.LoadKeyFromBytes(targets2PubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gpgKey, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	createState := func(t *testing.T) *State {
+		t.Helper()
+
+		state := createTestStateWithPolicy(t)
+
+		targetsMetadata, err := state.GetTargetsMetadata(TargetsRoleName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsMetadata, err = AddPerson(targetsMetadata, "alice", []*tuf.Key{personKey})
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsMetadata, err = AddDelegation(targetsMetadata, "protect-release", []*tuf.Key{key}, []string{"git:refs/heads/release", "file:release/*"}, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsMetadata, err = SetAuthorizedPersons(targetsMetadata, "protect-release", []string{"alice"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsMetadata, err = AddDelegation(targetsMetadata, "deny-release-notes", []*tuf.Key{gpgKey}, []string{"file:release/notes"}, 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsMetadata, err = SetRuleEffect(targetsMetadata, "deny-release-notes", RuleEffectDeny)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		targetsEnv, err := dsse.CreateEnvelope(targetsMetadata)
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsEnv, err = dsse.SignEnvelope(context.Background(), targetsEnv, signer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		state.TargetsEnvelope = targetsEnv
+
+		return state
+	}
+
+	t.Run("rule with people and keys", func(t *testing.T) {
+		state := createState(t)
+
+		authorization, err := state.WhoCanAuthorize("git:refs/heads/release")
+		assert.Nil(t, err)
+		assert.Equal(t, &Authorization{
+			Path:      "git:refs/heads/release",
+			Protected: true,
+			Rules: []*RuleAuthorization{{
+				Name:      "protect-release",
+				Threshold: 2,
+				Principals: []*AuthorizedPrincipal{
+					{ID: "alice", KeyIDs: []string{personKey.KeyID}},
+					{ID: key.KeyID, KeyIDs: []string{key.KeyID}},
+				},
+			}},
+		}, authorization)
+	})
+
+	t.Run("deny rule", func(t *testing.T) {
+		state := createState(t)
+
+		authorization, err := state.WhoCanAuthorize("file:release/notes")
+		assert.Nil(t, err)
+		assert.True(t, authorization.Protected)
+		assert.True(t, authorization.RequireAll)
+		assert.Equal(t, []*RuleAuthorization{{
+			Name:       "deny-release-notes",
+			Threshold:  1,
+			Principals: []*AuthorizedPrincipal{{ID: gpgKey.KeyID, KeyIDs: []string{gpgKey.KeyID}}},
+		}}, authorization.Rules)
+	})
+
+	t.Run("unprotected path", func(t *testing.T) {
+		state := createState(t)
+
+		authorization, err := state.WhoCanAuthorize("git:refs/heads/feature")
+		assert.Nil(t, err)
+		assert.Equal(t, &Authorization{
+			Path:  "git:refs/heads/feature",
+			Rules: []*RuleAuthorization{},
+		}, authorization)
+	})
+
+	t.Run("global rule", func(t *testing.T) {
+		state := createState(t)
+
+		rootMetadata, err := state.GetRootMetadata()
+		if err != nil {
+			t.Fatal(err)
+		}
+		rootMetadata, err = AddGlobalRule(rootMetadata, "require-two-approvals", []string{"git:refs/heads/*"}, 2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rootEnv, err := dsse.CreateEnvelope(rootMetadata)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rootEnv, err = dsse.SignEnvelope(context.Background(), rootEnv, signer)
+		if err != nil {
+			t.Fatal(err)
+		}
+		state.RootEnvelope = rootEnv
+
+		authorization, err := state.WhoCanAuthorize("git:refs/heads/release")
+		assert.Nil(t, err)
+		assert.Equal(t, []*RuleAuthorization{{
+			Name:      "require-two-approvals",
+			Threshold: 2,
+			Principals: []*AuthorizedPrincipal{
+				{ID: "alice", KeyIDs: []string{personKey.KeyID}},
+				{ID: key.KeyID, KeyIDs: []string{key.KeyID}},
+			},
+		}}, authorization.GlobalRules)
+	})
+}
@@ -0,0 +1,484 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Package remotehelper implements a Git remote helper for remotes whose URLs
+// use the "gittuf::" prefix. The underlying remote is accessed using Git, and
+// gittuf's references are synchronized and verified transparently during
+// fetches and pushes.
+package remotehelper
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os/exec"
+	"strings"
+
+	"github.com/gittuf/gittuf/internal/attestations"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+var (
+	ErrUnknownCommand   = errors.New("unknown remote helper command")
+	ErrNoGittufMetadata = errors.New("remote does not have gittuf metadata")
+	ErrRSLDiverged      = errors.New("local RSL has diverged from remote RSL")
+)
+
+// gittufRefs are the gittuf references synchronized with the remote.
+var gittufRefs = []string{rsl.Ref, policy.PolicyRef, attestations.Ref}
+
+type helper struct {
+	repo       *repository.Repository
+	url        string
+	signCommit bool
+	stdout     io.Writer
+	stderr     io.Writer
+
+	// remoteRefs contains the references on the remote and their tips, as
+	// of the last list command.
+	remoteRefs map[string]plumbing.Hash
+}
+
+// Run implements the remote helper protocol for the remote at url, reading
+// commands from stdin and writing responses to stdout. Git's output is written
+// to stderr. For more information on the protocol, please consult:
+// https://git-scm.com/docs/gitremote-helpers.
+func Run(ctx context.Context, repo *repository.Repository, url string, stdin io.Reader, stdout, stderr io.Writer) error {
+	h := &helper{
+		repo:       repo,
+		url:        url,
+		signCommit: true,
+		stdout:     stdout,
+		stderr:     stderr,
+	}
+
+	return h.run(ctx, stdin)
+}
+
+func (h *helper) run(ctx context.Context, stdin io.Reader) error {
+	scanner := bufio.NewScanner(stdin)
+	for scanner.Scan() {
+		line := scanner.Text()
+		slog.Debug(fmt.Sprintf("Received command '%s'...", line))
+
+		switch {
+		case line == "":
+			// Git ends the session with a blank line
+			return nil
+
+		case line == "capabilities":
+			fmt.Fprint(h.stdout, "fetch\npush\n\n")
+
+		case line == "list" || line == "list for-push":
+			if err := h.list(ctx); err != nil {
+				return err
+			}
+
+		case strings.HasPrefix(line, "fetch "):
+			batch, err := readBatch(scanner, line)
+			if err != nil {
+				return err
+			}
+
+			if err := h.fetch(ctx, batch); err != nil {
+				return err
+			}
+			fmt.Fprintln(h.stdout)
+
+		case strings.HasPrefix(line, "push "):
+			batch, err := readBatch(scanner, line)
+			if err != nil {
+				return err
+			}
+
+			for _, result := range h.push(ctx, batch) {
+				fmt.Fprintln(h.stdout, result)
+			}
+			fmt.Fprintln(h.stdout)
+
+		default:
+			return fmt.Errorf("%w: '%s'", ErrUnknownCommand, line)
+		}
+	}
+
+	return scanner.Err()
+}
+
+// list writes the references on the remote, identifying HEAD as a symbolic
+// reference where possible.
+func (h *helper) list(ctx context.Context) error {
+	output, err := h.git(ctx, "ls-remote", "--symref", h.url)
+	if err != nil {
+		return err
+	}
+
+	h.remoteRefs = map[string]plumbing.Hash{}
+	symRefs := map[string]string{}
+	lines := []string{}
+	for _, line := range strings.Split(strings.TrimSpace(output), "\n") {
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "ref: ") {
+			// Symbolic references are listed as "ref: <target>\t<name>"
+			split := strings.Split(strings.TrimPrefix(line, "ref: "), "\t")
+			if len(split) == 2 {
+				symRefs[split[1]] = split[0]
+				lines = append(lines, fmt.Sprintf("@%s %s", split[0], split[1]))
+			}
+			continue
+		}
+
+		split := strings.Split(line, "\t")
+		if len(split) != 2 {
+			continue
+		}
+		if _, isSymRef := symRefs[split[1]]; isSymRef {
+			continue
+		}
+
+		h.remoteRefs[split[1]] = plumbing.NewHash(split[0])
+		lines = append(lines, fmt.Sprintf("%s %s", split[0], split[1]))
+	}
+
+	for _, line := range lines {
+		fmt.Fprintln(h.stdout, line)
+	}
+	fmt.Fprintln(h.stdout)
+
+	return nil
+}
+
+// fetch fetches the requested references along with gittuf's references, and
+// verifies the requested branches and tags using the remote's RSL before
+// fast-forwarding the local gittuf references.
+func (h *helper) fetch(ctx context.Context, batch []string) error {
+	if h.remoteRefs == nil {
+		// We need the remote's gittuf references
+		if err := h.list(ctx); err != nil {
+			return err
+		}
+	}
+
+	remoteRSLTip, hasRSL := h.remoteRefs[rsl.Ref]
+	if !hasRSL {
+		return ErrNoGittufMetadata
+	}
+
+	refs := map[string]plumbing.Hash{}
+	for _, line := range batch {
+		// Each line is "fetch <sha> <name>"
+		split := strings.Fields(line)
+		if len(split) != 3 {
+			return fmt.Errorf("%w: '%s'", ErrUnknownCommand, line)
+		}
+
+		if !strings.HasPrefix(split[2], "refs/heads/") && !strings.HasPrefix(split[2], "refs/tags/") {
+			slog.Debug(fmt.Sprintf("Not verifying '%s'...", split[2]))
+			continue
+		}
+		refs[split[2]] = plumbing.NewHash(split[1])
+	}
+
+	fetchArgs := []string{"fetch", "--quiet", "--no-tags", "--no-write-fetch-head", h.url}
+	for _, line := range batch {
+		fetchArgs = append(fetchArgs, strings.Fields(line)[2])
+	}
+	for _, gittufRef := range gittufRefs {
+		if tip, has := h.remoteRefs[gittufRef]; has {
+			fetchArgs = append(fetchArgs, gittufRef)
+			refs[gittufRef] = tip
+		}
+	}
+
+	slog.Debug("Fetching requested references and gittuf references...")
+	if _, err := h.git(ctx, fetchArgs...); err != nil {
+		return err
+	}
+
+	localRSLTip, err := h.revParse(ctx, rsl.Ref)
+	if err != nil {
+		return err
+	}
+	if !localRSLTip.IsZero() {
+		isAncestor, err := h.isAncestor(ctx, localRSLTip, remoteRSLTip)
+		if err != nil {
+			return err
+		}
+		if !isAncestor {
+			isDescendant, err := h.isAncestor(ctx, remoteRSLTip, localRSLTip)
+			if err != nil {
+				return err
+			}
+			if !isDescendant {
+				return ErrRSLDiverged
+			}
+		}
+	}
+
+	slog.Debug("Verifying fetched references...")
+	if err := h.repo.VerifyFetchedRefs(ctx, refs); err != nil {
+		return err
+	}
+
+	// Fast-forward local gittuf references, leaving those with local changes
+	// unmodified
+	for _, gittufRef := range gittufRefs {
+		remoteTip, has := refs[gittufRef]
+		if !has {
+			continue
+		}
+
+		localTip, err := h.revParse(ctx, gittufRef)
+		if err != nil {
+			return err
+		}
+		if !localTip.IsZero() {
+			isAncestor, err := h.isAncestor(ctx, localTip, remoteTip)
+			if err != nil {
+				return err
+			}
+			if !isAncestor {
+				slog.Debug(fmt.Sprintf("Local '%s' has changes not on the remote, not updating...", gittufRef))
+				continue
+			}
+		}
+
+		if _, err := h.git(ctx, "update-ref", gittufRef, remoteTip.String(), localTip.String()); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// push records RSL entries for the pushed references and pushes them along
+// with gittuf's references atomically. It returns the status of each pushed
+// reference in the format expected by Git.
+func (h *helper) push(ctx context.Context, batch []string) []string {
+	type pushSpec struct {
+		refSpec string
+		src     string
+		dst     string
+	}
+
+	specs := make([]*pushSpec, 0, len(batch))
+	for _, line := range batch {
+		// Each line is "push [+]<src>:<dst>"
+		refSpec := strings.TrimPrefix(line, "push ")
+		src, dst, _ := strings.Cut(strings.TrimPrefix(refSpec, "+"), ":")
+		specs = append(specs, &pushSpec{refSpec: refSpec, src: src, dst: dst})
+	}
+
+	results := make([]string, 0, len(specs))
+	failAll := func(err error) []string {
+		for _, spec := range specs {
+			results = append(results, pushError(spec.dst, err))
+		}
+		return results
+	}
+
+	priorRSLTip, err := h.syncRSL(ctx)
+	if err != nil {
+		return failAll(err)
+	}
+
+	pushArgs := []string{"push", "--atomic", "--quiet", h.url}
+	pushed := []*pushSpec{}
+	for _, spec := range specs {
+		if spec.src == "" {
+			slog.Debug(fmt.Sprintf("Recording RSL entry for deletion of '%s'...", spec.dst))
+			if err := h.repo.RecordRSLEntryForDeletion(spec.dst, h.signCommit); err != nil {
+				results = append(results, pushError(spec.dst, err))
+				continue
+			}
+
+			pushArgs = append(pushArgs, spec.refSpec)
+			pushed = append(pushed, spec)
+			continue
+		}
+
+		target, err := h.revParse(ctx, spec.src)
+		if err == nil && target.IsZero() {
+			err = fmt.Errorf("unable to resolve '%s'", spec.src)
+		}
+		if err == nil {
+			slog.Debug(fmt.Sprintf("Recording RSL entry for '%s'...", spec.dst))
+			err = h.repo.RecordRSLEntryForRemoteReference(spec.dst, target, h.signCommit)
+		}
+		if err != nil {
+			results = append(results, pushError(spec.dst, err))
+			continue
+		}
+
+		pushArgs = append(pushArgs, spec.refSpec)
+		pushed = append(pushed, spec)
+	}
+
+	if len(pushed) == 0 {
+		return results
+	}
+
+	for _, gittufRef := range gittufRefs {
+		tip, err := h.revParse(ctx, gittufRef)
+		if err != nil {
+			return failAll(err)
+		}
+		if !tip.IsZero() {
+			pushArgs = append(pushArgs, fmt.Sprintf("%s:%s", gittufRef, gittufRef))
+		}
+	}
+
+	slog.Debug("Pushing references and gittuf references...")
+	if _, err := h.git(ctx, pushArgs...); err != nil {
+		// Discard the RSL entries that were not pushed so that the local RSL
+		// doesn't diverge from the remote
+		slog.Debug("Push failed, resetting local RSL...")
+		if priorRSLTip.IsZero() {
+			_, err = h.git(ctx, "update-ref", "-d", rsl.Ref)
+		} else {
+			_, err = h.git(ctx, "update-ref", rsl.Ref, priorRSLTip.String())
+		}
+		if err != nil {
+			slog.Debug(fmt.Sprintf("Unable to reset local RSL: %s", err.Error()))
+		}
+
+		for _, spec := range pushed {
+			results = append(results, pushError(spec.dst, errors.New("push rejected")))
+		}
+		return results
+	}
+
+	for _, spec := range pushed {
+		results = append(results, fmt.Sprintf("ok %s", spec.dst))
+	}
+	return results
+}
+
+// syncRSL fast-forwards the local RSL to the remote RSL so that new entries
+// extend it. The tip of the local RSL after syncing is returned.
+func (h *helper) syncRSL(ctx context.Context) (plumbing.Hash, error) {
+	localRSLTip, err := h.revParse(ctx, rsl.Ref)
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	remoteRSLTip, hasRSL := h.remoteRefs[rsl.Ref]
+	if !hasRSL {
+		if localRSLTip.IsZero() {
+			return plumbing.ZeroHash, ErrNoGittufMetadata
+		}
+		return localRSLTip, nil
+	}
+
+	slog.Debug("Fetching remote RSL...")
+	if _, err := h.git(ctx, "fetch", "--quiet", "--no-tags", "--no-write-fetch-head", h.url, rsl.Ref); err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	if !localRSLTip.IsZero() {
+		isAncestor, err := h.isAncestor(ctx, localRSLTip, remoteRSLTip)
+		if err != nil {
+			return plumbing.ZeroHash, err
+		}
+		if !isAncestor {
+			isDescendant, err := h.isAncestor(ctx, remoteRSLTip, localRSLTip)
+			if err != nil {
+				return plumbing.ZeroHash, err
+			}
+			if !isDescendant {
+				return plumbing.ZeroHash, ErrRSLDiverged
+			}
+
+			// The local RSL already extends the remote RSL
+			return localRSLTip, nil
+		}
+	}
+
+	if _, err := h.git(ctx, "update-ref", rsl.Ref, remoteRSLTip.String(), localRSLTip.String()); err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	return remoteRSLTip, nil
+}
+
+// git runs a Git command and returns its output. Git's errors are written to
+// stderr as the helper's stdout is reserved for the protocol.
+func (h *helper) git(ctx context.Context, args ...string) (string, error) {
+	var stdout bytes.Buffer
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Stdout = &stdout
+	cmd.Stderr = h.stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("running 'git %s': %w", args[0], err)
+	}
+
+	return stdout.String(), nil
+}
+
+// revParse returns the object ID the revision resolves to, or the zero hash if
+// it does not exist.
+func (h *helper) revParse(ctx context.Context, revision string) (plumbing.Hash, error) {
+	var stdout bytes.Buffer
+	cmd := exec.CommandContext(ctx, "git", "rev-parse", "--verify", "--quiet", revision+"^{}")
+	cmd.Stdout = &stdout
+	cmd.Stderr = h.stderr
+
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+			return plumbing.ZeroHash, nil
+		}
+		return plumbing.ZeroHash, err
+	}
+
+	return plumbing.NewHash(strings.TrimSpace(stdout.String())), nil
+}
+
+// isAncestor indicates if ancestor is an ancestor of, or the same as,
+// descendant.
+func (h *helper) isAncestor(ctx context.Context, ancestor, descendant plumbing.Hash) (bool, error) {
+	cmd := exec.CommandContext(ctx, "git", "merge-base", "--is-ancestor", ancestor.String(), descendant.String()) //nolint:gosec
+	cmd.Stderr = h.stderr
+
+	if err := cmd.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+			return false, nil
+		}
+		return false, err
+	}
+
+	return true, nil
+}
+
+// readBatch reads the commands in a batch, which is terminated by a blank
+// line. The first command of the batch has already been read.
+func readBatch(scanner *bufio.Scanner, first string) ([]string, error) {
+	batch := []string{first}
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			return batch, nil
+		}
+		batch = append(batch, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	// Git may close stdin after the final batch
+	return batch, nil
+}
+
+func pushError(dst string, err error) string {
+	return fmt.Sprintf("error %s %s", dst, strings.ReplaceAll(err.Error(), "\n", " "))
+}
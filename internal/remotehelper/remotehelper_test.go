@@ -0,0 +1,80 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package remotehelper
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun(t *testing.T) {
+	tmpDir := t.TempDir()
+	localDir := filepath.Join(tmpDir, "local")
+	remoteDir := filepath.Join(tmpDir, "remote.git")
+
+	runGit(t, tmpDir, "init", "-q", "-b", "main", localDir)
+	runGit(t, tmpDir, "init", "-q", "-b", "main", "--bare", remoteDir)
+	runGit(t, localDir, "-c", "user.name=Jane Doe", "-c", "user.email=jane.doe@example.com", "commit", "-q", "--allow-empty", "-m", "Initial commit")
+	runGit(t, localDir, "push", "-q", remoteDir, "main")
+	commitID := strings.TrimSpace(runGit(t, localDir, "rev-parse", "HEAD"))
+
+	gitDir := filepath.Join(localDir, ".git")
+	t.Setenv("GIT_DIR", gitDir)
+	repo, err := repository.LoadRepositoryAt(gitDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("capabilities", func(t *testing.T) {
+		stdout := &bytes.Buffer{}
+		err := Run(context.Background(), repo, remoteDir, strings.NewReader("capabilities\n\n"), stdout, &bytes.Buffer{})
+		assert.Nil(t, err)
+		assert.Equal(t, "fetch\npush\n\n", stdout.String())
+	})
+
+	t.Run("list", func(t *testing.T) {
+		stdout := &bytes.Buffer{}
+		err := Run(context.Background(), repo, remoteDir, strings.NewReader("list\n\n"), stdout, &bytes.Buffer{})
+		assert.Nil(t, err)
+		assert.Equal(t, fmt.Sprintf("@refs/heads/main HEAD\n%s refs/heads/main\n\n", commitID), stdout.String())
+	})
+
+	t.Run("fetch without gittuf metadata", func(t *testing.T) {
+		stdin := strings.NewReader(fmt.Sprintf("list\nfetch %s refs/heads/main\n\n", commitID))
+		err := Run(context.Background(), repo, remoteDir, stdin, &bytes.Buffer{}, &bytes.Buffer{})
+		assert.ErrorIs(t, err, ErrNoGittufMetadata)
+	})
+
+	t.Run("push without gittuf metadata", func(t *testing.T) {
+		stdout := &bytes.Buffer{}
+		err := Run(context.Background(), repo, remoteDir, strings.NewReader("list for-push\npush refs/heads/main:refs/heads/main\n\n"), stdout, &bytes.Buffer{})
+		assert.Nil(t, err)
+		assert.Contains(t, stdout.String(), fmt.Sprintf("error refs/heads/main %s", ErrNoGittufMetadata.Error()))
+	})
+
+	t.Run("unknown command", func(t *testing.T) {
+		err := Run(context.Background(), repo, remoteDir, strings.NewReader("connect git-upload-pack\n"), &bytes.Buffer{}, &bytes.Buffer{})
+		assert.ErrorIs(t, err, ErrUnknownCommand)
+	})
+}
+
+func runGit(t *testing.T, dir string, args ...string) string {
+	t.Helper()
+
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git %s: %s: %s", args[0], err, output)
+	}
+
+	return string(output)
+}
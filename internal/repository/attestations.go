@@ -4,14 +4,22 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"log/slog"
+	"strings"
+	"time"
 
 	"github.com/gittuf/gittuf/internal/attestations"
 	"github.com/gittuf/gittuf/internal/dev"
+	"github.com/gittuf/gittuf/internal/github"
 	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/gittuf/gittuf/internal/gitlab"
+	"github.com/gittuf/gittuf/internal/policy"
 	"github.com/gittuf/gittuf/internal/rsl"
 	"github.com/gittuf/gittuf/internal/signerverifier/dsse"
+	"github.com/gittuf/gittuf/internal/version"
 	"github.com/go-git/go-git/v5/plumbing"
 	sslibdsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
 )
@@ -29,14 +37,7 @@ func (r *Repository) AddReferenceAuthorization(ctx context.Context, signer sslib
 		return dev.ErrNotInDevMode
 	}
 
-	var err error
-
-	targetRef, err = gitinterface.AbsoluteReference(r.r, targetRef)
-	if err != nil {
-		return err
-	}
-
-	featureRef, err = gitinterface.AbsoluteReference(r.r, featureRef)
+	targetRef, fromID, toID, err := r.getMergeParameters(ctx, targetRef, featureRef)
 	if err != nil {
 		return err
 	}
@@ -46,36 +47,6 @@ func (r *Repository) AddReferenceAuthorization(ctx context.Context, signer sslib
 		return err
 	}
 
-	var (
-		fromID          string
-		featureCommitID string
-		toID            string
-	)
-
-	latestTargetEntry, _, err := rsl.GetLatestReferenceEntryForRef(r.r, targetRef)
-	if err == nil {
-		fromID = latestTargetEntry.TargetID.String()
-	} else {
-		if !errors.Is(err, rsl.ErrRSLEntryNotFound) {
-			return err
-		}
-		fromID = plumbing.ZeroHash.String()
-	}
-
-	latestFeatureEntry, _, err := rsl.GetLatestReferenceEntryForRef(r.r, featureRef)
-	if err != nil {
-		// We don't have an RSL entry for the feature ref to use to approve the
-		// merge
-		return err
-	}
-	featureCommitID = latestFeatureEntry.TargetID.String()
-
-	mergeTreeID, err := gitinterface.GetMergeTree(r.r, fromID, featureCommitID)
-	if err != nil {
-		return err
-	}
-	toID = mergeTreeID
-
 	// Does a reference authorization already exist for the parameters?
 	hasAuthorization := false
 	env, err := allAttestations.GetReferenceAuthorizationFor(r.r, targetRef, fromID, toID)
@@ -176,3 +147,471 @@ func (r *Repository) RemoveReferenceAuthorization(ctx context.Context, signer ss
 
 	return allAttestations.Commit(r.r, commitMessage, signCommit)
 }
+
+// RecordGitHubPullRequestApproval fetches the approvals of the specified merged
+// GitHub pull request and records them in a GitHub pull request approval
+// attestation signed using the GitHub app's signer. The merge commit created by
+// GitHub must be present in the local repository. The from ID is the merge
+// commit's first parent, and the to ID is the merge commit's tree.
+func (r *Repository) RecordGitHubPullRequestApproval(ctx context.Context, signer sslibdsse.SignerVerifier, client *github.Client, owner, repository string, pullRequestNumber int, signCommit bool) error {
+	pullRequest, err := client.GetPullRequest(ctx, owner, repository, pullRequestNumber)
+	if err != nil {
+		return err
+	}
+
+	if !pullRequest.Merged {
+		return github.ErrPullRequestNotMerged
+	}
+
+	mergeCommit, err := gitinterface.GetCommit(r.r, plumbing.NewHash(pullRequest.MergeCommitSHA))
+	if err != nil {
+		return fmt.Errorf("unable to find merge commit '%s' locally, has it been fetched?: %w", pullRequest.MergeCommitSHA, err)
+	}
+
+	fromID := plumbing.ZeroHash.String()
+	if len(mergeCommit.ParentHashes) > 0 {
+		fromID = mergeCommit.ParentHashes[0].String()
+	}
+	toID := mergeCommit.TreeHash.String()
+	targetRef := plumbing.NewBranchReferenceName(pullRequest.BaseRef).String()
+
+	slog.Debug(fmt.Sprintf("Fetching approvals for pull request #%d...", pullRequestNumber))
+	approvers, err := client.GetApprovers(ctx, owner, repository, pullRequestNumber, pullRequest.HeadSHA)
+	if err != nil {
+		return err
+	}
+
+	allAttestations, err := attestations.LoadCurrentAttestations(r.r)
+	if err != nil {
+		return err
+	}
+
+	statement, err := attestations.NewGitHubPullRequestApproval(targetRef, fromID, toID, pullRequest.HTMLURL, approvers)
+	if err != nil {
+		return err
+	}
+
+	env, err := dsse.CreateEnvelope(statement)
+	if err != nil {
+		return err
+	}
+
+	env, err = dsse.SignEnvelope(ctx, env, signer)
+	if err != nil {
+		return err
+	}
+
+	if err := allAttestations.SetGitHubPullRequestApproval(r.r, env, targetRef, fromID, toID); err != nil {
+		return err
+	}
+
+	commitMessage := fmt.Sprintf("Add GitHub pull request approval for '%s' from '%s' to '%s'", targetRef, fromID, toID)
+
+	return allAttestations.Commit(r.r, commitMessage, signCommit)
+}
+
+// RecordGitLabMergeRequestApproval fetches the approvals of the specified
+// merged GitLab merge request and records them in a GitLab merge request
+// approval attestation signed using the GitLab app's signer. The commit the
+// target branch was updated to by the merge must be present in the local
+// repository. The from ID is that commit's first parent, and the to ID is its
+// tree.
+func (r *Repository) RecordGitLabMergeRequestApproval(ctx context.Context, signer sslibdsse.SignerVerifier, client *gitlab.Client, project string, mergeRequestIID int, signCommit bool) error {
+	mergeRequest, err := client.GetMergeRequest(ctx, project, mergeRequestIID)
+	if err != nil {
+		return err
+	}
+
+	if !mergeRequest.Merged {
+		return gitlab.ErrMergeRequestNotMerged
+	}
+
+	mergedCommit, err := gitinterface.GetCommit(r.r, plumbing.NewHash(mergeRequest.MergedCommitSHA))
+	if err != nil {
+		return fmt.Errorf("unable to find merged commit '%s' locally, has it been fetched?: %w", mergeRequest.MergedCommitSHA, err)
+	}
+
+	fromID := plumbing.ZeroHash.String()
+	if len(mergedCommit.ParentHashes) > 0 {
+		fromID = mergedCommit.ParentHashes[0].String()
+	}
+	toID := mergedCommit.TreeHash.String()
+	targetRef := plumbing.NewBranchReferenceName(mergeRequest.TargetBranch).String()
+
+	slog.Debug(fmt.Sprintf("Fetching approvals for merge request !%d...", mergeRequestIID))
+	approvers, err := client.GetApprovers(ctx, project, mergeRequestIID)
+	if err != nil {
+		return err
+	}
+
+	allAttestations, err := attestations.LoadCurrentAttestations(r.r)
+	if err != nil {
+		return err
+	}
+
+	statement, err := attestations.NewGitLabMergeRequestApproval(targetRef, fromID, toID, mergeRequest.WebURL, approvers)
+	if err != nil {
+		return err
+	}
+
+	env, err := dsse.CreateEnvelope(statement)
+	if err != nil {
+		return err
+	}
+
+	env, err = dsse.SignEnvelope(ctx, env, signer)
+	if err != nil {
+		return err
+	}
+
+	if err := allAttestations.SetGitLabMergeRequestApproval(r.r, env, targetRef, fromID, toID); err != nil {
+		return err
+	}
+
+	commitMessage := fmt.Sprintf("Add GitLab merge request approval for '%s' from '%s' to '%s'", targetRef, fromID, toID)
+
+	return allAttestations.Commit(r.r, commitMessage, signCommit)
+}
+
+// AttestReview records the signer's code review of merging the feature ref into
+// the target ref. The reviewer is identified using the signer's key ID. The
+// from ID is identified using the last RSL entry for the target ref, and the to
+// ID is that of the expected Git tree created by merging the feature ref into
+// the target ref, as with reference authorizations. Any earlier review of the
+// same change by the signer is replaced. Currently, this is limited to
+// developer mode.
+func (r *Repository) AttestReview(ctx context.Context, signer sslibdsse.SignerVerifier, targetRef, featureRef, verdict string, signCommit bool) error {
+	if !dev.InDevMode() {
+		return dev.ErrNotInDevMode
+	}
+
+	reviewer, err := signer.KeyID()
+	if err != nil {
+		return err
+	}
+
+	targetRef, fromID, toID, err := r.getMergeParameters(ctx, targetRef, featureRef)
+	if err != nil {
+		return err
+	}
+
+	allAttestations, err := attestations.LoadCurrentAttestations(r.r)
+	if err != nil {
+		return err
+	}
+
+	statement, err := attestations.NewCodeReview(targetRef, fromID, toID, reviewer, verdict)
+	if err != nil {
+		return err
+	}
+
+	env, err := dsse.CreateEnvelope(statement)
+	if err != nil {
+		return err
+	}
+
+	env, err = dsse.SignEnvelope(ctx, env, signer)
+	if err != nil {
+		return err
+	}
+
+	if err := allAttestations.SetCodeReview(r.r, env, targetRef, fromID, toID); err != nil {
+		return err
+	}
+
+	commitMessage := fmt.Sprintf("Add code review by '%s' for '%s' from '%s' to '%s'", reviewer, targetRef, fromID, toID)
+
+	return allAttestations.Commit(r.r, commitMessage, signCommit)
+}
+
+// AddPushAuthorization adds the signer's signature to a push authorization
+// attestation for the update of the target ref to the specified revision, so
+// that the update can be recorded by a key that is not trusted for the target
+// ref. If fromID is empty, it is identified using the last RSL entry for the
+// target ref.
+func (r *Repository) AddPushAuthorization(ctx context.Context, signer sslibdsse.SignerVerifier, targetRef, fromID, toRevision string, signCommit bool) error {
+	targetRef, fromID, toID, err := r.getPushParameters(targetRef, fromID, toRevision)
+	if err != nil {
+		return err
+	}
+
+	allAttestations, err := attestations.LoadCurrentAttestations(r.r)
+	if err != nil {
+		return err
+	}
+
+	// Does a push authorization already exist for the parameters?
+	env, err := allAttestations.GetPushAuthorizationFor(r.r, targetRef, fromID, toID)
+	if err != nil {
+		if !errors.Is(err, attestations.ErrPushAuthorizationNotFound) {
+			return err
+		}
+
+		statement, err := attestations.NewPushAuthorization(targetRef, fromID, toID)
+		if err != nil {
+			return err
+		}
+
+		env, err = dsse.CreateEnvelope(statement)
+		if err != nil {
+			return err
+		}
+	}
+
+	env, err = dsse.SignEnvelope(ctx, env, signer)
+	if err != nil {
+		return err
+	}
+
+	if err := allAttestations.SetPushAuthorization(r.r, env, targetRef, fromID, toID); err != nil {
+		return err
+	}
+
+	commitMessage := fmt.Sprintf("Add push authorization for '%s' from '%s' to '%s'", targetRef, fromID, toID)
+
+	return allAttestations.Commit(r.r, commitMessage, signCommit)
+}
+
+// RemovePushAuthorization removes the signer's signature from the push
+// authorization for the specified parameters. The authorization is removed
+// altogether if no other signatures remain.
+func (r *Repository) RemovePushAuthorization(ctx context.Context, signer sslibdsse.SignerVerifier, targetRef, fromID, toRevision string, signCommit bool) error {
+	// Ensure only the key that signed a push authorization can remove it
+	_, err := signer.Sign(ctx, nil)
+	if err != nil {
+		return errors.Join(ErrNotSigningKey, err)
+	}
+	keyID, err := signer.KeyID()
+	if err != nil {
+		return err
+	}
+
+	targetRef, fromID, toID, err := r.getPushParameters(targetRef, fromID, toRevision)
+	if err != nil {
+		return err
+	}
+
+	allAttestations, err := attestations.LoadCurrentAttestations(r.r)
+	if err != nil {
+		return err
+	}
+
+	env, err := allAttestations.GetPushAuthorizationFor(r.r, targetRef, fromID, toID)
+	if err != nil {
+		if errors.Is(err, attestations.ErrPushAuthorizationNotFound) {
+			// No push authorization at all
+			return nil
+		}
+		return err
+	}
+
+	newSignatures := []sslibdsse.Signature{}
+	for _, signature := range env.Signatures {
+		if signature.KeyID != keyID {
+			newSignatures = append(newSignatures, signature)
+		}
+	}
+
+	if len(newSignatures) == 0 {
+		if err := allAttestations.RemovePushAuthorization(targetRef, fromID, toID); err != nil {
+			return err
+		}
+	} else {
+		env.Signatures = newSignatures
+		if err := allAttestations.SetPushAuthorization(r.r, env, targetRef, fromID, toID); err != nil {
+			return err
+		}
+	}
+
+	commitMessage := fmt.Sprintf("Remove push authorization for '%s' from '%s' to '%s' by '%s'", targetRef, fromID, toID, keyID)
+
+	return allAttestations.Commit(r.r, commitMessage, signCommit)
+}
+
+// AddProvenance attaches the SLSA provenance attestation in envBytes, a DSSE
+// envelope, to the tag. Any provenance previously attached to the tag is
+// replaced.
+func (r *Repository) AddProvenance(tag string, envBytes []byte, signCommit bool) error {
+	tagRef, err := gitinterface.AbsoluteReference(r.r, tag)
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(tagRef, gitinterface.TagRefPrefix) {
+		return policy.ErrNotTag
+	}
+
+	env := &sslibdsse.Envelope{}
+	if err := json.Unmarshal(envBytes, env); err != nil {
+		return fmt.Errorf("unable to parse provenance envelope: %w", err)
+	}
+
+	allAttestations, err := attestations.LoadCurrentAttestations(r.r)
+	if err != nil {
+		return err
+	}
+
+	if err := allAttestations.SetProvenance(r.r, env, tagRef); err != nil {
+		return err
+	}
+
+	commitMessage := fmt.Sprintf("Add provenance for '%s'", tagRef)
+
+	return allAttestations.Commit(r.r, commitMessage, signCommit)
+}
+
+// CreateVerificationSummary creates a SLSA verification summary attestation
+// (VSA) recording whether the target ref's current tip passed verification,
+// along with the policy and attestations states it was verified against. The
+// VSA is signed using the signer.
+func (r *Repository) CreateVerificationSummary(ctx context.Context, signer sslibdsse.SignerVerifier, target string, passed bool) (*sslibdsse.Envelope, error) {
+	target, err := gitinterface.AbsoluteReference(r.r, target)
+	if err != nil {
+		return nil, err
+	}
+
+	tip, err := gitinterface.GetTip(r.r, target)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Debug("Identifying policy and attestations states...")
+	policyEntry, _, err := rsl.GetLatestReferenceEntryForRef(r.r, policy.PolicyRef)
+	if err != nil {
+		return nil, err
+	}
+	policyResource := attestations.VerificationSummaryResource{
+		URI:    r.gitResourceURI(policy.PolicyRef),
+		Digest: map[string]string{"gitCommit": policyEntry.TargetID.String()},
+	}
+
+	inputAttestations := []attestations.VerificationSummaryResource{}
+	attestationsEntry, _, err := rsl.GetLatestReferenceEntryForRef(r.r, attestations.Ref)
+	if err == nil {
+		inputAttestations = append(inputAttestations, attestations.VerificationSummaryResource{
+			URI:    r.gitResourceURI(attestations.Ref),
+			Digest: map[string]string{"gitCommit": attestationsEntry.TargetID.String()},
+		})
+	} else if !errors.Is(err, rsl.ErrRSLEntryNotFound) {
+		return nil, err
+	}
+
+	statement, err := attestations.NewVerificationSummary(target, tip.String(), r.gitResourceURI(target), policyResource, inputAttestations, version.GetVersion(), passed, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	env, err := dsse.CreateEnvelope(statement)
+	if err != nil {
+		return nil, err
+	}
+
+	return dsse.SignEnvelope(ctx, env, signer)
+}
+
+// AddVerificationSummary records the verification summary attestation in the
+// repository's attestations for the ref and commit it was created for.
+func (r *Repository) AddVerificationSummary(env *sslibdsse.Envelope, signCommit bool) error {
+	refName, commitID, _, err := attestations.ParseVerificationSummary(env)
+	if err != nil {
+		return err
+	}
+
+	allAttestations, err := attestations.LoadCurrentAttestations(r.r)
+	if err != nil {
+		return err
+	}
+
+	if err := allAttestations.SetVerificationSummary(r.r, env); err != nil {
+		return err
+	}
+
+	commitMessage := fmt.Sprintf("Add verification summary for '%s' at '%s'", refName, commitID)
+
+	return allAttestations.Commit(r.r, commitMessage, signCommit)
+}
+
+// gitResourceURI returns the URI identifying the ref in the repository at the
+// origin remote. If the repository has no origin remote, the ref is returned
+// as is.
+func (r *Repository) gitResourceURI(refName string) string {
+	remote, err := r.r.Remote(gitinterface.DefaultRemoteName)
+	if err != nil || len(remote.Config().URLs) == 0 {
+		return refName
+	}
+
+	return fmt.Sprintf("git+%s@%s", remote.Config().URLs[0], refName)
+}
+
+// getPushParameters returns the absolute name of the target ref along with the
+// from and to commit IDs of the push. The to revision may be a full commit ID
+// that is not present locally, such as when a push is authorized before the
+// commit is fetched.
+func (r *Repository) getPushParameters(targetRef, fromID, toRevision string) (string, string, string, error) {
+	targetRef, err := gitinterface.AbsoluteReference(r.r, targetRef)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	if fromID == "" {
+		fromID = plumbing.ZeroHash.String()
+
+		latestTargetEntry, _, err := rsl.GetLatestReferenceEntryForRef(r.r, targetRef)
+		if err == nil {
+			fromID = latestTargetEntry.TargetID.String()
+		} else if !errors.Is(err, rsl.ErrRSLEntryNotFound) {
+			return "", "", "", err
+		}
+	}
+
+	if plumbing.IsHash(toRevision) {
+		return targetRef, fromID, toRevision, nil
+	}
+
+	toID, err := r.r.ResolveRevision(plumbing.Revision(toRevision))
+	if err != nil {
+		return "", "", "", err
+	}
+
+	return targetRef, fromID, toID.String(), nil
+}
+
+// getMergeParameters returns the absolute name of the target ref, the ID the
+// target ref currently points to per the RSL, and the ID of the Git tree
+// created by merging the feature ref into the target ref. The feature ref must
+// have an RSL entry.
+func (r *Repository) getMergeParameters(ctx context.Context, targetRef, featureRef string) (string, string, string, error) {
+	targetRef, err := gitinterface.AbsoluteReference(r.r, targetRef)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	featureRef, err = gitinterface.AbsoluteReference(r.r, featureRef)
+	if err != nil {
+		return "", "", "", err
+	}
+
+	var fromID string
+	latestTargetEntry, _, err := rsl.GetLatestReferenceEntryForRef(r.r, targetRef)
+	if err == nil {
+		fromID = latestTargetEntry.TargetID.String()
+	} else {
+		if !errors.Is(err, rsl.ErrRSLEntryNotFound) {
+			return "", "", "", err
+		}
+		fromID = plumbing.ZeroHash.String()
+	}
+
+	latestFeatureEntry, _, err := rsl.GetLatestReferenceEntryForRef(r.r, featureRef)
+	if err != nil {
+		// We don't have an RSL entry for the feature ref to use to approve the
+		// merge
+		return "", "", "", err
+	}
+
+	toID, err := gitinterface.GetMergeTree(ctx, r.r, fromID, latestFeatureEntry.TargetID.String())
+	if err != nil {
+		return "", "", "", err
+	}
+
+	return targetRef, fromID, toID, nil
+}
@@ -4,16 +4,30 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"testing"
 
 	"github.com/gittuf/gittuf/internal/attestations"
 	"github.com/gittuf/gittuf/internal/common"
 	"github.com/gittuf/gittuf/internal/dev"
+	"github.com/gittuf/gittuf/internal/github"
 	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/gittuf/gittuf/internal/gitlab"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/rsl"
 	"github.com/gittuf/gittuf/internal/signerverifier"
+	"github.com/gittuf/gittuf/internal/signerverifier/dsse"
+	"github.com/go-git/go-billy/v5/memfs"
 	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/storage/memory"
+	ita "github.com/in-toto/attestation/go/v1"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/structpb"
 )
 
 func TestAddAndRemoveReferenceAuthorization(t *testing.T) {
@@ -59,7 +73,7 @@ func TestAddAndRemoveReferenceAuthorization(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	targetTreeID, err := gitinterface.GetMergeTree(r, fromCommitID, featureCommitID)
+	targetTreeID, err := gitinterface.GetMergeTree(context.Background(), r, fromCommitID, featureCommitID)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -131,3 +145,380 @@ func TestAddAndRemoveReferenceAuthorization(t *testing.T) {
 	assert.Len(t, env.Signatures, 1)
 	assert.Equal(t, firstKeyID, env.Signatures[0].KeyID)
 }
+
+func TestRecordGitHubPullRequestApproval(t *testing.T) {
+	r, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo := &Repository{r: r}
+	if err := repo.InitializeNamespaces(); err != nil {
+		t.Fatal(err)
+	}
+
+	targetRef := "refs/heads/main"
+	commitIDs := common.AddNTestCommitsToSpecifiedRef(t, r, targetRef, 2, gpgKeyBytes)
+	mergeCommit, err := gitinterface.GetCommit(r, commitIDs[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	headSHA := "2f5d1d9b7c1b8b6f0b4c0e6a1d1c2c3b4a5f6e7d"
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/gittuf/gittuf/pulls/1", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprintf(w, `{"number": 1, "html_url": "https://github.com/gittuf/gittuf/pull/1", "base": {"ref": "main"}, "head": {"sha": "%s"}, "merged": true, "merge_commit_sha": "%s"}`, headSHA, mergeCommit.Hash.String())
+	})
+	mux.HandleFunc("/repos/gittuf/gittuf/pulls/1/reviews", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprintf(w, `[{"user": {"login": "alice"}, "state": "APPROVED", "commit_id": "%s"}]`, headSHA)
+	})
+	mux.HandleFunc("/repos/gittuf/gittuf/pulls/2", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `{"number": 2, "base": {"ref": "main"}, "merged": false}`)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := github.NewClient(server.URL, "")
+
+	signer, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(targetsKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = repo.RecordGitHubPullRequestApproval(testCtx, signer, client, "gittuf", "gittuf", 2, false)
+	assert.ErrorIs(t, err, github.ErrPullRequestNotMerged)
+
+	err = repo.RecordGitHubPullRequestApproval(testCtx, signer, client, "gittuf", "gittuf", 1, false)
+	assert.Nil(t, err)
+
+	allAttestations, err := attestations.LoadCurrentAttestations(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, approvers, err := allAttestations.GetGitHubPullRequestApprovalFor(r, targetRef, commitIDs[0].String(), mergeCommit.TreeHash.String())
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"alice"}, approvers)
+}
+
+func TestRecordGitLabMergeRequestApproval(t *testing.T) {
+	r, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo := &Repository{r: r}
+	if err := repo.InitializeNamespaces(); err != nil {
+		t.Fatal(err)
+	}
+
+	targetRef := "refs/heads/main"
+	commitIDs := common.AddNTestCommitsToSpecifiedRef(t, r, targetRef, 2, gpgKeyBytes)
+	mergedCommit, err := gitinterface.GetCommit(r, commitIDs[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.EscapedPath() {
+		case "/projects/gittuf%2Fgittuf/merge_requests/1":
+			fmt.Fprintf(w, `{"iid": 1, "web_url": "https://gitlab.com/gittuf/gittuf/-/merge_requests/1", "target_branch": "main", "sha": "%s", "state": "merged"}`, mergedCommit.Hash.String())
+		case "/projects/gittuf%2Fgittuf/merge_requests/1/approvals":
+			fmt.Fprint(w, `{"approved_by": [{"user": {"username": "alice"}}]}`)
+		case "/projects/gittuf%2Fgittuf/merge_requests/2":
+			fmt.Fprint(w, `{"iid": 2, "target_branch": "main", "state": "opened"}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := gitlab.NewClient(server.URL, "")
+
+	signer, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(targetsKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = repo.RecordGitLabMergeRequestApproval(testCtx, signer, client, "gittuf/gittuf", 2, false)
+	assert.ErrorIs(t, err, gitlab.ErrMergeRequestNotMerged)
+
+	err = repo.RecordGitLabMergeRequestApproval(testCtx, signer, client, "gittuf/gittuf", 1, false)
+	assert.Nil(t, err)
+
+	allAttestations, err := attestations.LoadCurrentAttestations(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, approvers, err := allAttestations.GetGitLabMergeRequestApprovalFor(r, targetRef, commitIDs[0].String(), mergedCommit.TreeHash.String())
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"alice"}, approvers)
+}
+
+func TestAttestReview(t *testing.T) {
+	t.Setenv(dev.DevModeKey, "1")
+
+	testDir := t.TempDir()
+
+	currentDir, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(testDir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(currentDir) //nolint:errcheck
+
+	r, err := git.PlainInit(testDir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo := &Repository{r: r}
+	if err := repo.InitializeNamespaces(); err != nil {
+		t.Fatal(err)
+	}
+
+	commitIDs := common.AddNTestCommitsToSpecifiedRef(t, r, "refs/heads/main", 1, gpgKeyBytes)
+	fromID := commitIDs[0].String()
+	if err := repo.RecordRSLEntryForReference("main", false); err != nil {
+		t.Fatal(err)
+	}
+
+	commitIDs = common.AddNTestCommitsToSpecifiedRef(t, r, "refs/heads/feature", 2, gpgKeyBytes)
+	if err := repo.RecordRSLEntryForReference("feature", false); err != nil {
+		t.Fatal(err)
+	}
+
+	targetTreeID, err := gitinterface.GetMergeTree(context.Background(), r, fromID, commitIDs[1].String())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signer, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(targetsKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+	reviewer, err := signer.KeyID()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = repo.AttestReview(testCtx, signer, "main", "feature", "lgtm", false)
+	assert.ErrorIs(t, err, attestations.ErrInvalidCodeReviewVerdict)
+
+	err = repo.AttestReview(testCtx, signer, "main", "feature", attestations.CodeReviewVerdictApproved, false)
+	assert.Nil(t, err)
+
+	allAttestations, err := attestations.LoadCurrentAttestations(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reviews, err := allAttestations.GetCodeReviewsFor(r, "refs/heads/main", fromID, targetTreeID)
+	assert.Nil(t, err)
+	if assert.Len(t, reviews, 1) {
+		assert.Equal(t, reviewer, reviews[0].Review.Reviewer)
+		assert.Equal(t, attestations.CodeReviewVerdictApproved, reviews[0].Review.Verdict)
+	}
+}
+
+func TestAddAndRemovePushAuthorization(t *testing.T) {
+	r, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo := &Repository{r: r}
+	if err := repo.InitializeNamespaces(); err != nil {
+		t.Fatal(err)
+	}
+
+	targetRef := "refs/heads/main"
+	commitIDs := common.AddNTestCommitsToSpecifiedRef(t, r, targetRef, 1, gpgKeyBytes)
+	fromID := commitIDs[0].String()
+	common.CreateTestRSLReferenceEntryCommit(t, r, rsl.NewReferenceEntry(targetRef, commitIDs[0]), gpgKeyBytes)
+
+	commitIDs = common.AddNTestCommitsToSpecifiedRef(t, r, "refs/heads/feature", 1, gpgKeyBytes)
+	toID := commitIDs[0].String()
+
+	firstSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(rootKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+	secondSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(targetsKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+	secondKeyID, err := secondSigner.KeyID()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The from ID is identified using the RSL and the target using its name
+	err = repo.AddPushAuthorization(testCtx, firstSigner, "main", "", "feature", false)
+	assert.Nil(t, err)
+
+	err = repo.AddPushAuthorization(testCtx, secondSigner, targetRef, fromID, toID, false)
+	assert.Nil(t, err)
+
+	allAttestations, err := attestations.LoadCurrentAttestations(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	env, err := allAttestations.GetPushAuthorizationFor(r, targetRef, fromID, toID)
+	assert.Nil(t, err)
+	assert.Len(t, env.Signatures, 2)
+
+	err = repo.RemovePushAuthorization(testCtx, firstSigner, targetRef, fromID, toID, false)
+	assert.Nil(t, err)
+
+	allAttestations, err = attestations.LoadCurrentAttestations(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	env, err = allAttestations.GetPushAuthorizationFor(r, targetRef, fromID, toID)
+	assert.Nil(t, err)
+	if assert.Len(t, env.Signatures, 1) {
+		assert.Equal(t, secondKeyID, env.Signatures[0].KeyID)
+	}
+
+	err = repo.RemovePushAuthorization(testCtx, secondSigner, targetRef, fromID, toID, false)
+	assert.Nil(t, err)
+
+	allAttestations, err = attestations.LoadCurrentAttestations(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = allAttestations.GetPushAuthorizationFor(r, targetRef, fromID, toID)
+	assert.ErrorIs(t, err, attestations.ErrPushAuthorizationNotFound)
+}
+
+func TestAddProvenance(t *testing.T) {
+	repo := createTestRepositoryWithPolicy(t, "")
+
+	commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo.r, "refs/heads/main", 1, gpgKeyBytes)
+	common.CreateTestSignedTag(t, repo.r, "v1", commitIDs[0], gpgKeyBytes)
+
+	envBytes := createProvenanceEnvelopeBytes(t, "https://example.com/builder", "git+https://example.com/repo@refs/tags/v1", commitIDs[0].String())
+
+	err := repo.AddProvenance("main", envBytes, false)
+	assert.ErrorIs(t, err, policy.ErrNotTag)
+
+	err = repo.AddProvenance("v1", []byte("not an envelope"), false)
+	assert.NotNil(t, err)
+
+	err = repo.AddProvenance("v1", envBytes, false)
+	assert.Nil(t, err)
+
+	allAttestations, err := attestations.LoadCurrentAttestations(repo.r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	env, err := allAttestations.GetProvenanceFor(repo.r, "refs/tags/v1")
+	assert.Nil(t, err)
+
+	provenance, err := attestations.ParseProvenance(env)
+	assert.Nil(t, err)
+	assert.Equal(t, "https://example.com/builder", provenance.RunDetails.Builder.ID)
+}
+
+func createProvenanceEnvelopeBytes(t *testing.T, builderID, sourceURI, commitID string) []byte {
+	t.Helper()
+
+	predicate, err := structpb.NewStruct(map[string]any{
+		"buildDefinition": map[string]any{
+			"buildType": "https://slsa-framework.github.io/github-actions-buildtypes/workflow/v1",
+			"resolvedDependencies": []any{
+				map[string]any{
+					"uri":    sourceURI,
+					"digest": map[string]any{"gitCommit": commitID},
+				},
+			},
+		},
+		"runDetails": map[string]any{
+			"builder": map[string]any{"id": builderID},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	env, err := dsse.CreateEnvelope(&ita.Statement{
+		Type:          ita.StatementTypeUri,
+		Subject:       []*ita.ResourceDescriptor{{Name: "artifact", Digest: map[string]string{"sha256": "5b5fe4f0c5ab3d3d7e62e0ad7ea1f2c9d8d1e0cd1c19b2d2b48e0f7a64b7a4e1"}}},
+		PredicateType: attestations.SLSAProvenancePredicateType,
+		Predicate:     predicate,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	envBytes, err := json.Marshal(env)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return envBytes
+}
+
+func TestCreateAndAddVerificationSummary(t *testing.T) {
+	repo := createTestRepositoryWithPolicy(t, "")
+
+	refName := "refs/heads/main"
+	commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo.r, refName, 1, gpgKeyBytes)
+	common.CreateTestRSLReferenceEntryCommit(t, repo.r, rsl.NewReferenceEntry(refName, commitIDs[0]), gpgKeyBytes)
+
+	signer, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(targetsKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyID, err := signer.KeyID()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	policyEntry, _, err := rsl.GetLatestReferenceEntryForRef(repo.r, policy.PolicyRef)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	env, err := repo.CreateVerificationSummary(testCtx, signer, "main", true)
+	assert.Nil(t, err)
+	assert.Len(t, env.Signatures, 1)
+	assert.Equal(t, keyID, env.Signatures[0].KeyID)
+
+	summaryRef, summaryCommitID, summary, err := attestations.ParseVerificationSummary(env)
+	assert.Nil(t, err)
+	assert.Equal(t, refName, summaryRef)
+	assert.Equal(t, commitIDs[0].String(), summaryCommitID)
+	assert.Equal(t, attestations.VerificationResultPassed, summary.VerificationResult)
+	assert.Equal(t, refName, summary.ResourceURI)
+	assert.Equal(t, policyEntry.TargetID.String(), summary.Policy.Digest["gitCommit"])
+
+	err = repo.AddVerificationSummary(env, false)
+	assert.Nil(t, err)
+
+	allAttestations, err := attestations.LoadCurrentAttestations(repo.r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	storedEnv, err := allAttestations.GetVerificationSummaryFor(repo.r, refName, commitIDs[0].String())
+	assert.Nil(t, err)
+	assert.Equal(t, env, storedEnv)
+
+	// The resource is identified using the origin remote, and the stored
+	// attestations are recorded as an input
+	if _, err := repo.r.CreateRemote(&config.RemoteConfig{Name: gitinterface.DefaultRemoteName, URLs: []string{"https://example.com/repo"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	env, err = repo.CreateVerificationSummary(testCtx, signer, refName, false)
+	assert.Nil(t, err)
+
+	_, _, summary, err = attestations.ParseVerificationSummary(env)
+	assert.Nil(t, err)
+	assert.Equal(t, attestations.VerificationResultFailed, summary.VerificationResult)
+	assert.Equal(t, "git+https://example.com/repo@refs/heads/main", summary.ResourceURI)
+	assert.Equal(t, "git+https://example.com/repo@refs/gittuf/policy", summary.Policy.URI)
+	assert.Len(t, summary.InputAttestations, 1)
+	assert.Equal(t, "git+https://example.com/repo@refs/gittuf/attestations", summary.InputAttestations[0].URI)
+}
@@ -0,0 +1,163 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/gittuf/gittuf/internal/github"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/tuf"
+	sslibdsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
+)
+
+var ErrBranchNotProtected = errors.New("branch is not protected on GitHub")
+
+// BranchProtectionUpdate records the change to the GitHub branch protection
+// settings of a branch made to match gittuf policy. Current is nil if the
+// branch was not protected.
+type BranchProtectionUpdate struct {
+	Branch  string                   `json:"branch"`
+	Current *github.BranchProtection `json:"current"`
+	Updated *github.BranchProtection `json:"updated"`
+}
+
+// ImportGitHubBranchProtection is the interface for the user to generate rules
+// in the specified policy file from the branch protection settings of a GitHub
+// repository. If branches is empty, every protected branch is imported, and
+// previously imported rules for branches that are no longer protected are
+// removed. The generated rules authorize the specified people and teams. The
+// returned diff summarizes the changes to the rules, and the notes describe
+// settings that cannot be represented in gittuf policy. If dryRun is set, or if
+// the rules are already up to date, the policy is not changed.
+func (r *Repository) ImportGitHubBranchProtection(ctx context.Context, signer sslibdsse.SignerVerifier, targetsRoleName string, client *github.Client, owner, repository string, branches, personIDs, teamIDs []string, dryRun, signCommit bool) ([]*policy.RuleDiff, []string, error) {
+	removeStale := len(branches) == 0
+	if removeStale {
+		slog.Debug(fmt.Sprintf("Fetching protected branches of '%s/%s'...", owner, repository))
+		protectedBranches, err := client.GetProtectedBranches(ctx, owner, repository)
+		if err != nil {
+			return nil, nil, err
+		}
+		branches = protectedBranches
+	}
+
+	protections := []*github.BranchProtection{}
+	for _, branch := range branches {
+		slog.Debug(fmt.Sprintf("Fetching branch protection settings of '%s'...", branch))
+		protection, err := client.GetBranchProtection(ctx, owner, repository, branch)
+		if err != nil {
+			return nil, nil, err
+		}
+		if protection == nil {
+			return nil, nil, fmt.Errorf("%w: '%s'", ErrBranchNotProtected, branch)
+		}
+		protections = append(protections, protection)
+	}
+
+	state, err := r.loadStateForUpdate(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !state.HasTargetsRole(targetsRoleName) {
+		return nil, nil, policy.ErrMetadataNotFound
+	}
+	targetsMetadata, err := state.GetTargetsMetadata(targetsRoleName)
+	if err != nil {
+		return nil, nil, err
+	}
+	oldRules := slices.Clone(targetsMetadata.Delegations.Roles)
+
+	targetsMetadata, notes, err := policy.ImportBranchProtections(targetsMetadata, protections, personIDs, teamIDs, removeStale)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	slog.Debug("Checking if rules with same names exist...")
+	for _, rule := range targetsMetadata.Delegations.Roles {
+		if rule.Name == policy.AllowRuleName || containsRule(oldRules, rule.Name) {
+			continue
+		}
+		if state.HasRuleName(rule.Name) {
+			return nil, nil, fmt.Errorf("%w: '%s'", policy.ErrDuplicatedRuleName, rule.Name)
+		}
+	}
+
+	diff := policy.DiffRules(targetsRoleName, oldRules, targetsMetadata.Delegations.Roles)
+	if dryRun || len(diff) == 0 {
+		return diff, notes, nil
+	}
+
+	commitMessage := fmt.Sprintf("Import branch protection of GitHub repository '%s/%s' into policy '%s'", owner, repository, targetsRoleName)
+	err = r.updateTargetsMetadata(ctx, signer, targetsRoleName, commitMessage, signCommit, func(targetsMetadata *tuf.TargetsMetadata) (*tuf.TargetsMetadata, error) {
+		slog.Debug("Importing branch protection settings as rules...")
+		targetsMetadata, _, err := policy.ImportBranchProtections(targetsMetadata, protections, personIDs, teamIDs, removeStale)
+		return targetsMetadata, err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return diff, notes, nil
+}
+
+// ExportGitHubBranchProtection is the interface for the user to update the
+// branch protection settings of a GitHub repository to match the rules in the
+// current policy that protect branches. Settings that do not correspond to
+// gittuf policy, such as required status checks, are retained. The returned
+// updates record the branches whose settings differ from the policy, and the
+// notes describe rules that cannot be represented as branch protection. If
+// dryRun is set, the settings are not updated.
+func (r *Repository) ExportGitHubBranchProtection(ctx context.Context, client *github.Client, owner, repository string, dryRun bool) ([]*BranchProtectionUpdate, []string, error) {
+	state, err := policy.LoadCurrentState(ctx, r.r)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	protections, notes, err := state.BranchProtections()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	updates := []*BranchProtectionUpdate{}
+	for _, protection := range protections {
+		slog.Debug(fmt.Sprintf("Fetching branch protection settings of '%s'...", protection.Branch))
+		current, err := client.GetBranchProtection(ctx, owner, repository, protection.Branch)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		updated := &github.BranchProtection{Branch: protection.Branch}
+		if current != nil {
+			updatedCopy := *current
+			updated = &updatedCopy
+		}
+		updated.RequiredApprovingReviewCount = protection.RequiredApprovingReviewCount
+		if protection.RequiredApprovingReviewCount > 0 {
+			updated.DismissStaleReviews = protection.DismissStaleReviews
+		}
+		updated.EnforceAdmins = protection.EnforceAdmins
+		updated.AllowForcePushes = protection.AllowForcePushes
+		updated.AllowDeletions = protection.AllowDeletions
+
+		if current != nil && *current == *updated {
+			slog.Debug(fmt.Sprintf("Branch protection settings of '%s' are up to date", protection.Branch))
+			continue
+		}
+		updates = append(updates, &BranchProtectionUpdate{Branch: protection.Branch, Current: current, Updated: updated})
+
+		if dryRun {
+			continue
+		}
+
+		slog.Debug(fmt.Sprintf("Updating branch protection settings of '%s'...", protection.Branch))
+		if err := client.UpdateBranchProtection(ctx, owner, repository, updated); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return updates, notes, nil
+}
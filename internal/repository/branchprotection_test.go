@@ -0,0 +1,152 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/github"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/signerverifier"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGitHubBranchProtection(t *testing.T) {
+	r := createTestRepositoryWithPolicy(t, "")
+
+	targetsSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(targetsKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsKey, err := tuf.LoadKeyFromBytes(targetsPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := r.AddTeamMembers(testCtx, targetsSigner, policy.TargetsRoleName, "maintainers", []*tuf.Key{targetsKey}, nil, false); err != nil {
+		t.Fatal(err)
+	}
+
+	updates := map[string]map[string]any{}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/repos/gittuf/gittuf/branches", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `[{"name": "release"}]`)
+	})
+	mux.HandleFunc("/repos/gittuf/gittuf/branches/release/protection", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			request := map[string]any{}
+			if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+				t.Fatal(err)
+			}
+			updates["release"] = request
+			fmt.Fprint(w, `{}`)
+			return
+		}
+		fmt.Fprint(w, `{
+			"required_pull_request_reviews": {"required_approving_review_count": 1},
+			"required_status_checks": {"strict": true, "contexts": ["ci"]},
+			"enforce_admins": {"enabled": false},
+			"allow_force_pushes": {"enabled": true}
+		}`)
+	})
+	mux.HandleFunc("/repos/gittuf/gittuf/branches/main/protection", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			request := map[string]any{}
+			if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+				t.Fatal(err)
+			}
+			updates["main"] = request
+			fmt.Fprint(w, `{}`)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	})
+	mux.HandleFunc("/repos/gittuf/gittuf/branches/main/protection/required_signatures", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	mux.HandleFunc("/repos/gittuf/gittuf/branches/release/protection/required_signatures", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := github.NewClient(server.URL, "")
+
+	t.Run("import dry run", func(t *testing.T) {
+		diff, notes, err := r.ImportGitHubBranchProtection(testCtx, nil, policy.TargetsRoleName, client, "gittuf", "gittuf", nil, nil, []string{"maintainers"}, true, false)
+		assert.Nil(t, err)
+		assert.Len(t, diff, 1)
+		assert.Equal(t, policy.ChangeAdded, diff[0].Change)
+		assert.Equal(t, 2, diff[0].NewThreshold)
+		assert.Len(t, notes, 1)
+
+		state, err := policy.LoadCurrentState(testCtx, r.r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsMetadata, err := state.GetTargetsMetadata(policy.TargetsRoleName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Len(t, targetsMetadata.Delegations.Roles, 2)
+	})
+
+	t.Run("import", func(t *testing.T) {
+		_, _, err := r.ImportGitHubBranchProtection(testCtx, targetsSigner, policy.TargetsRoleName, client, "gittuf", "gittuf", nil, nil, []string{"maintainers"}, false, false)
+		assert.Nil(t, err)
+
+		state, err := policy.LoadCurrentState(testCtx, r.r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsMetadata, err := state.GetTargetsMetadata(policy.TargetsRoleName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Len(t, targetsMetadata.Delegations.Roles, 3)
+		assert.Equal(t, "github-branch-protection:release", targetsMetadata.Delegations.Roles[1].Name)
+		assert.Equal(t, []string{"git:refs/heads/release"}, targetsMetadata.Delegations.Roles[1].Paths)
+
+		// Importing again makes no changes
+		diff, _, err := r.ImportGitHubBranchProtection(testCtx, targetsSigner, policy.TargetsRoleName, client, "gittuf", "gittuf", nil, nil, []string{"maintainers"}, false, false)
+		assert.Nil(t, err)
+		assert.Empty(t, diff)
+	})
+
+	t.Run("import unprotected branch", func(t *testing.T) {
+		_, _, err := r.ImportGitHubBranchProtection(testCtx, targetsSigner, policy.TargetsRoleName, client, "gittuf", "gittuf", []string{"main"}, nil, []string{"maintainers"}, false, false)
+		assert.ErrorIs(t, err, ErrBranchNotProtected)
+	})
+
+	t.Run("export dry run", func(t *testing.T) {
+		exported, _, err := r.ExportGitHubBranchProtection(testCtx, client, "gittuf", "gittuf", true)
+		assert.Nil(t, err)
+		assert.Len(t, exported, 2)
+		assert.Empty(t, updates)
+	})
+
+	t.Run("export", func(t *testing.T) {
+		exported, _, err := r.ExportGitHubBranchProtection(testCtx, client, "gittuf", "gittuf", false)
+		assert.Nil(t, err)
+		assert.Len(t, exported, 2)
+
+		// The policy does not authorize force pushes to the release branch
+		assert.Equal(t, "main", exported[0].Branch)
+		assert.Nil(t, exported[0].Current)
+		assert.Equal(t, "release", exported[1].Branch)
+		assert.True(t, exported[1].Current.AllowForcePushes)
+		assert.False(t, exported[1].Updated.AllowForcePushes)
+		assert.Equal(t, 1, exported[1].Updated.RequiredApprovingReviewCount)
+
+		assert.Equal(t, false, updates["release"]["allow_force_pushes"])
+		assert.Equal(t, true, updates["release"]["enforce_admins"])
+		assert.Equal(t, map[string]any{"strict": true, "contexts": []any{"ci"}}, updates["release"]["required_status_checks"])
+		assert.Nil(t, updates["main"]["required_pull_request_reviews"])
+	})
+}
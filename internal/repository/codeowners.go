@@ -0,0 +1,72 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"slices"
+
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/tuf"
+	sslibdsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
+)
+
+// ImportCodeOwners replaces the rules previously imported from a CODEOWNERS file
+// into the specified policy file with rules generated from entries, and
+// returns the changes to the policy file's rules and the entries that were
+// skipped as they have no owners. If dryRun is set, the changes are only
+// computed, and the signer is not used.
+func (r *Repository) ImportCodeOwners(ctx context.Context, signer sslibdsse.SignerVerifier, targetsRoleName string, entries []*policy.CodeOwnersEntry, mapping policy.CodeOwnersMapping, dryRun, signCommit bool) ([]*policy.RuleDiff, []*policy.CodeOwnersEntry, error) {
+	state, err := r.loadStateForUpdate(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !state.HasTargetsRole(targetsRoleName) {
+		return nil, nil, policy.ErrMetadataNotFound
+	}
+	targetsMetadata, err := state.GetTargetsMetadata(targetsRoleName)
+	if err != nil {
+		return nil, nil, err
+	}
+	oldRules := slices.Clone(targetsMetadata.Delegations.Roles)
+
+	targetsMetadata, skipped, err := policy.ImportCodeOwners(targetsMetadata, entries, mapping)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	slog.Debug("Checking if rules with same names exist...")
+	for _, rule := range targetsMetadata.Delegations.Roles {
+		if rule.Name == policy.AllowRuleName || containsRule(oldRules, rule.Name) {
+			continue
+		}
+		if state.HasRuleName(rule.Name) {
+			return nil, nil, fmt.Errorf("%w: '%s'", policy.ErrDuplicatedRuleName, rule.Name)
+		}
+	}
+
+	diff := policy.DiffRules(targetsRoleName, oldRules, targetsMetadata.Delegations.Roles)
+	if dryRun || len(diff) == 0 {
+		return diff, skipped, nil
+	}
+
+	commitMessage := fmt.Sprintf("Import CODEOWNERS into policy '%s'", targetsRoleName)
+	err = r.updateTargetsMetadata(ctx, signer, targetsRoleName, commitMessage, signCommit, func(targetsMetadata *tuf.TargetsMetadata) (*tuf.TargetsMetadata, error) {
+		slog.Debug("Importing CODEOWNERS entries as rules...")
+		targetsMetadata, _, err := policy.ImportCodeOwners(targetsMetadata, entries, mapping)
+		return targetsMetadata, err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return diff, skipped, nil
+}
+
+func containsRule(rules []tuf.Delegation, name string) bool {
+	return slices.ContainsFunc(rules, func(rule tuf.Delegation) bool {
+		return rule.Name == name
+	})
+}
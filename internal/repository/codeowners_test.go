@@ -0,0 +1,88 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/signerverifier"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImportCodeOwners(t *testing.T) {
+	r := createTestRepositoryWithPolicy(t, "")
+
+	targetsSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(targetsKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	state, err := policy.LoadCurrentState(context.Background(), r.r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsMetadata, err := state.GetTargetsMetadata(policy.TargetsRoleName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyID := targetsMetadata.Delegations.Roles[0].KeyIDs[0]
+
+	entries, err := policy.ParseCodeOwners([]byte("docs/ @docs\n*.go @dev\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	mapping := policy.CodeOwnersMapping{
+		"@docs": {KeyIDs: []string{keyID}},
+		"@dev":  {KeyIDs: []string{keyID}},
+	}
+
+	t.Run("dry run", func(t *testing.T) {
+		diff, skipped, err := r.ImportCodeOwners(testCtx, nil, policy.TargetsRoleName, entries, mapping, true, false)
+		assert.Nil(t, err)
+		assert.Empty(t, skipped)
+		assert.Len(t, diff, 2)
+
+		currentState, err := policy.LoadCurrentState(context.Background(), r.r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsMetadata, err := currentState.GetTargetsMetadata(policy.TargetsRoleName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Len(t, targetsMetadata.Delegations.Roles, 2)
+	})
+
+	t.Run("import", func(t *testing.T) {
+		diff, _, err := r.ImportCodeOwners(testCtx, targetsSigner, policy.TargetsRoleName, entries, mapping, false, false)
+		assert.Nil(t, err)
+		assert.Len(t, diff, 2)
+		assert.Equal(t, policy.ChangeAdded, diff[0].Change)
+		assert.Equal(t, []string{"file:**/docs/**"}, diff[0].AddedPaths)
+
+		currentState, err := policy.LoadCurrentState(context.Background(), r.r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsMetadata, err := currentState.GetTargetsMetadata(policy.TargetsRoleName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Len(t, targetsMetadata.Delegations.Roles, 4)
+		assert.Equal(t, "codeowners:docs/", targetsMetadata.Delegations.Roles[1].Name)
+		assert.Equal(t, "codeowners:*.go", targetsMetadata.Delegations.Roles[2].Name)
+	})
+
+	t.Run("import again", func(t *testing.T) {
+		diff, _, err := r.ImportCodeOwners(testCtx, targetsSigner, policy.TargetsRoleName, entries, mapping, false, false)
+		assert.Nil(t, err)
+		assert.Empty(t, diff)
+	})
+
+	t.Run("unmapped owner", func(t *testing.T) {
+		_, _, err := r.ImportCodeOwners(testCtx, targetsSigner, policy.TargetsRoleName, entries, policy.CodeOwnersMapping{}, false, false)
+		assert.ErrorIs(t, err, policy.ErrCodeOwnerNotMapped)
+	})
+}
@@ -0,0 +1,365 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/go-git/go-git/v5/config"
+	format "github.com/go-git/go-git/v5/plumbing/format/config"
+)
+
+// configSection is the Git config section gittuf's settings are stored in.
+const configSection = "gittuf"
+
+// Keys of gittuf's settings. The settings are read from the repository's Git
+// config, falling back to the global and system configs, so defaults that
+// apply to all of a user's repositories can be set using `git config --global`.
+const (
+	// ConfigSigningKey is the signing key used when --signing-key is not
+	// specified.
+	ConfigSigningKey = "gittuf.signingKey"
+
+	// ConfigRemote is the remote used when --remote is not specified.
+	ConfigRemote = "gittuf.remote"
+
+	// ConfigAutoFetch indicates if gittuf references are fetched from the
+	// remote before verification when they are behind, unless --no-fetch is
+	// specified.
+	ConfigAutoFetch = "gittuf.autoFetch"
+
+	// ConfigVerificationDepth is how much of the RSL is verified by default,
+	// either the full history of a reference or only its latest entry.
+	ConfigVerificationDepth = "gittuf.verificationDepth"
+)
+
+// Values of ConfigVerificationDepth.
+const (
+	VerificationDepthFull   = "full"
+	VerificationDepthLatest = "latest"
+)
+
+var (
+	ErrUnknownConfigKey   = errors.New("unknown gittuf config key")
+	ErrInvalidConfigValue = errors.New("invalid value for gittuf config key")
+)
+
+// Setting describes one of gittuf's settings.
+type Setting struct {
+	Key          string `json:"key"`
+	Description  string `json:"description"`
+	DefaultValue string `json:"default,omitempty"`
+
+	validate func(string) error
+}
+
+var settings = map[string]*Setting{
+	strings.ToLower(ConfigSigningKey): {
+		Key:         ConfigSigningKey,
+		Description: "signing key used when --signing-key is not specified",
+	},
+	strings.ToLower(ConfigRemote): {
+		Key:          ConfigRemote,
+		Description:  "remote used when --remote is not specified",
+		DefaultValue: gitinterface.DefaultRemoteName,
+	},
+	strings.ToLower(ConfigAutoFetch): {
+		Key:          ConfigAutoFetch,
+		Description:  "fetch gittuf references from the remote before verification when they are behind",
+		DefaultValue: "true",
+		validate: func(value string) error {
+			_, err := strconv.ParseBool(value)
+			return err
+		},
+	},
+	strings.ToLower(ConfigVerificationDepth): {
+		Key:          ConfigVerificationDepth,
+		Description:  fmt.Sprintf("how much of the RSL is verified by default (%s or %s)", VerificationDepthFull, VerificationDepthLatest),
+		DefaultValue: VerificationDepthFull,
+		validate: func(value string) error {
+			if value != VerificationDepthFull && value != VerificationDepthLatest {
+				return fmt.Errorf("must be %s or %s", VerificationDepthFull, VerificationDepthLatest)
+			}
+			return nil
+		},
+	},
+}
+
+// Settings returns all of gittuf's settings sorted by key.
+func Settings() []*Setting {
+	allSettings := make([]*Setting, 0, len(settings))
+	for _, setting := range settings {
+		allSettings = append(allSettings, setting)
+	}
+	sort.Slice(allSettings, func(i, j int) bool {
+		return allSettings[i].Key < allSettings[j].Key
+	})
+	return allSettings
+}
+
+// GetConfigValue returns the value of the specified setting, or its default
+// value if it is not set.
+func (r *Repository) GetConfigValue(key string) (string, error) {
+	setting, err := getSetting(key)
+	if err != nil {
+		return "", err
+	}
+
+	value, isSet, err := r.LookupConfigValue(setting.Key)
+	if err != nil {
+		return "", err
+	}
+	if !isSet {
+		return setting.DefaultValue, nil
+	}
+	return value, nil
+}
+
+// LookupConfigValue returns the value of the specified setting and whether it
+// is set in the repository's, the global, or the system Git config, in that
+// order of precedence.
+func (r *Repository) LookupConfigValue(key string) (string, bool, error) {
+	setting, err := getSetting(key)
+	if err != nil {
+		return "", false, err
+	}
+	optionName := getConfigOptionName(setting.Key)
+
+	repoConfig, err := r.r.Config()
+	if err != nil {
+		return "", false, err
+	}
+	if repoConfig.Raw.Section(configSection).HasOption(optionName) {
+		return repoConfig.Raw.Section(configSection).Option(optionName), true, nil
+	}
+
+	for _, scope := range []config.Scope{config.GlobalScope, config.SystemScope} {
+		scopedConfig, err := config.LoadConfig(scope)
+		if err != nil {
+			return "", false, err
+		}
+		if scopedConfig.Raw.Section(configSection).HasOption(optionName) {
+			return scopedConfig.Raw.Section(configSection).Option(optionName), true, nil
+		}
+	}
+
+	return "", false, nil
+}
+
+// SetConfigValue sets the specified setting in the repository's Git config.
+func (r *Repository) SetConfigValue(key, value string) error {
+	setting, err := getSetting(key)
+	if err != nil {
+		return err
+	}
+	if setting.validate != nil {
+		if err := setting.validate(value); err != nil {
+			return fmt.Errorf("%w '%s': %w", ErrInvalidConfigValue, setting.Key, err)
+		}
+	}
+
+	repoConfig, err := r.r.Config()
+	if err != nil {
+		return err
+	}
+
+	slog.Debug(fmt.Sprintf("Setting '%s' to '%s'...", setting.Key, value))
+	repoConfig.Raw.Section(configSection).SetOption(getConfigOptionName(setting.Key), value)
+	return r.r.SetConfig(repoConfig)
+}
+
+// UnsetConfigValue removes the specified setting from the repository's Git
+// config.
+func (r *Repository) UnsetConfigValue(key string) error {
+	setting, err := getSetting(key)
+	if err != nil {
+		return err
+	}
+
+	repoConfig, err := r.r.Config()
+	if err != nil {
+		return err
+	}
+
+	slog.Debug(fmt.Sprintf("Unsetting '%s'...", setting.Key))
+	repoConfig.Raw.Section(configSection).RemoveOption(getConfigOptionName(setting.Key))
+	return r.r.SetConfig(repoConfig)
+}
+
+// getSetting returns the setting for the key. As in Git, keys are case
+// insensitive.
+func getSetting(key string) (*Setting, error) {
+	setting, has := settings[strings.ToLower(key)]
+	if !has {
+		return nil, fmt.Errorf("%w: '%s'", ErrUnknownConfigKey, key)
+	}
+	return setting, nil
+}
+
+func getConfigOptionName(key string) string {
+	return strings.TrimPrefix(key, configSection+".")
+}
+
+// signingProfileSection is the Git config section signing profiles are stored
+// in, with a subsection per profile.
+const signingProfileSection = "gittuf-profile"
+
+// Options of a signing profile.
+const (
+	signingProfileSigningKey       = "signingKey"
+	signingProfileGitSigningFormat = "gitSigningFormat"
+	signingProfileGitSigningKey    = "gitSigningKey"
+)
+
+var signingProfileNamePattern = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+
+var (
+	ErrSigningProfileNotFound    = errors.New("signing profile not found")
+	ErrInvalidSigningProfileName = errors.New("signing profile names may only contain letters, digits, '.', '_', and '-'")
+)
+
+// SigningProfile is a named set of keys a user signs with when acting in a
+// particular role, such as a root key holder or a developer.
+type SigningProfile struct {
+	Name string `json:"name"`
+
+	// SigningKey is the signing key used when --signing-key is not
+	// specified, which may be a path or a reference to a key in a backend
+	// such as a KMS or hardware token.
+	SigningKey string `json:"signingKey,omitempty"`
+
+	// GitSigningFormat and GitSigningKey override gpg.format and
+	// user.signingkey in the Git config when gittuf signs commits, such as
+	// RSL entries and policy changes.
+	GitSigningFormat string `json:"gitSigningFormat,omitempty"`
+	GitSigningKey    string `json:"gitSigningKey,omitempty"`
+}
+
+// GitConfigOverrides returns the Git config values the profile overrides.
+func (p *SigningProfile) GitConfigOverrides() map[string]string {
+	overrides := map[string]string{}
+	if p.GitSigningFormat != "" {
+		overrides["gpg.format"] = p.GitSigningFormat
+	}
+	if p.GitSigningKey != "" {
+		overrides["user.signingkey"] = p.GitSigningKey
+	}
+	return overrides
+}
+
+// GetSigningProfile returns the specified signing profile from the
+// repository's, the global, or the system Git config, in that order of
+// precedence.
+func (r *Repository) GetSigningProfile(name string) (*SigningProfile, error) {
+	profiles, err := r.ListSigningProfiles()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, profile := range profiles {
+		if profile.Name == name {
+			return profile, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%w: '%s'", ErrSigningProfileNotFound, name)
+}
+
+// ListSigningProfiles returns the signing profiles in the repository's, the
+// global, and the system Git configs sorted by name. A profile in the
+// repository's config takes precedence over one with the same name in the
+// global or system configs.
+func (r *Repository) ListSigningProfiles() ([]*SigningProfile, error) {
+	repoConfig, err := r.r.Config()
+	if err != nil {
+		return nil, err
+	}
+	rawConfigs := []*format.Config{repoConfig.Raw}
+
+	for _, scope := range []config.Scope{config.GlobalScope, config.SystemScope} {
+		scopedConfig, err := config.LoadConfig(scope)
+		if err != nil {
+			return nil, err
+		}
+		rawConfigs = append(rawConfigs, scopedConfig.Raw)
+	}
+
+	profiles := map[string]*SigningProfile{}
+	for _, rawConfig := range rawConfigs {
+		for _, subsection := range rawConfig.Section(signingProfileSection).Subsections {
+			if _, has := profiles[subsection.Name]; has {
+				continue
+			}
+
+			profiles[subsection.Name] = &SigningProfile{
+				Name:             subsection.Name,
+				SigningKey:       subsection.Option(signingProfileSigningKey),
+				GitSigningFormat: subsection.Option(signingProfileGitSigningFormat),
+				GitSigningKey:    subsection.Option(signingProfileGitSigningKey),
+			}
+		}
+	}
+
+	allProfiles := make([]*SigningProfile, 0, len(profiles))
+	for _, profile := range profiles {
+		allProfiles = append(allProfiles, profile)
+	}
+	sort.Slice(allProfiles, func(i, j int) bool {
+		return allProfiles[i].Name < allProfiles[j].Name
+	})
+	return allProfiles, nil
+}
+
+// SetSigningProfile creates or replaces the signing profile in the
+// repository's Git config.
+func (r *Repository) SetSigningProfile(profile *SigningProfile) error {
+	if !signingProfileNamePattern.MatchString(profile.Name) {
+		return fmt.Errorf("%w: '%s'", ErrInvalidSigningProfileName, profile.Name)
+	}
+
+	repoConfig, err := r.r.Config()
+	if err != nil {
+		return err
+	}
+
+	slog.Debug(fmt.Sprintf("Setting signing profile '%s'...", profile.Name))
+	section := repoConfig.Raw.Section(signingProfileSection)
+	section.RemoveSubsection(profile.Name)
+	subsection := section.Subsection(profile.Name)
+	if profile.SigningKey != "" {
+		subsection.SetOption(signingProfileSigningKey, profile.SigningKey)
+	}
+	if profile.GitSigningFormat != "" {
+		subsection.SetOption(signingProfileGitSigningFormat, profile.GitSigningFormat)
+	}
+	if profile.GitSigningKey != "" {
+		subsection.SetOption(signingProfileGitSigningKey, profile.GitSigningKey)
+	}
+
+	return r.r.SetConfig(repoConfig)
+}
+
+// RemoveSigningProfile removes the signing profile from the repository's Git
+// config.
+func (r *Repository) RemoveSigningProfile(name string) error {
+	repoConfig, err := r.r.Config()
+	if err != nil {
+		return err
+	}
+
+	section := repoConfig.Raw.Section(signingProfileSection)
+	if !section.HasSubsection(name) {
+		return fmt.Errorf("%w: '%s'", ErrSigningProfileNotFound, name)
+	}
+
+	slog.Debug(fmt.Sprintf("Removing signing profile '%s'...", name))
+	section.RemoveSubsection(name)
+	return r.r.SetConfig(repoConfig)
+}
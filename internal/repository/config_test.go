@@ -0,0 +1,155 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"testing"
+
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigValues(t *testing.T) {
+	// Ensure the user's global config does not affect the test
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	r, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo := &Repository{r: r}
+
+	t.Run("unset value", func(t *testing.T) {
+		_, isSet, err := repo.LookupConfigValue(ConfigRemote)
+		assert.Nil(t, err)
+		assert.False(t, isSet)
+
+		value, err := repo.GetConfigValue(ConfigRemote)
+		assert.Nil(t, err)
+		assert.Equal(t, "origin", value)
+
+		value, err = repo.GetConfigValue(ConfigSigningKey)
+		assert.Nil(t, err)
+		assert.Empty(t, value)
+	})
+
+	t.Run("set and unset value", func(t *testing.T) {
+		err := repo.SetConfigValue(ConfigSigningKey, "ssh-agent:SHA256:abc")
+		assert.Nil(t, err)
+
+		value, isSet, err := repo.LookupConfigValue(ConfigSigningKey)
+		assert.Nil(t, err)
+		assert.True(t, isSet)
+		assert.Equal(t, "ssh-agent:SHA256:abc", value)
+
+		// Keys are case insensitive
+		value, err = repo.GetConfigValue("gittuf.signingkey")
+		assert.Nil(t, err)
+		assert.Equal(t, "ssh-agent:SHA256:abc", value)
+
+		err = repo.UnsetConfigValue(ConfigSigningKey)
+		assert.Nil(t, err)
+
+		_, isSet, err = repo.LookupConfigValue(ConfigSigningKey)
+		assert.Nil(t, err)
+		assert.False(t, isSet)
+	})
+
+	t.Run("validated values", func(t *testing.T) {
+		err := repo.SetConfigValue(ConfigAutoFetch, "false")
+		assert.Nil(t, err)
+
+		err = repo.SetConfigValue(ConfigAutoFetch, "sometimes")
+		assert.ErrorIs(t, err, ErrInvalidConfigValue)
+
+		err = repo.SetConfigValue(ConfigVerificationDepth, VerificationDepthLatest)
+		assert.Nil(t, err)
+
+		err = repo.SetConfigValue(ConfigVerificationDepth, "shallow")
+		assert.ErrorIs(t, err, ErrInvalidConfigValue)
+
+		value, err := repo.GetConfigValue(ConfigVerificationDepth)
+		assert.Nil(t, err)
+		assert.Equal(t, VerificationDepthLatest, value)
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		_, err := repo.GetConfigValue("gittuf.unknown")
+		assert.ErrorIs(t, err, ErrUnknownConfigKey)
+
+		err = repo.SetConfigValue("user.name", "Jane Doe")
+		assert.ErrorIs(t, err, ErrUnknownConfigKey)
+	})
+}
+
+func TestSigningProfiles(t *testing.T) {
+	// Ensure the user's global config does not affect the test
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("XDG_CONFIG_HOME", t.TempDir())
+
+	r, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo := &Repository{r: r}
+
+	t.Run("profile not found", func(t *testing.T) {
+		_, err := repo.GetSigningProfile("root")
+		assert.ErrorIs(t, err, ErrSigningProfileNotFound)
+
+		err = repo.RemoveSigningProfile("root")
+		assert.ErrorIs(t, err, ErrSigningProfileNotFound)
+	})
+
+	t.Run("invalid name", func(t *testing.T) {
+		err := repo.SetSigningProfile(&SigningProfile{Name: "root key"})
+		assert.ErrorIs(t, err, ErrInvalidSigningProfileName)
+	})
+
+	t.Run("set, list, and remove profiles", func(t *testing.T) {
+		rootProfile := &SigningProfile{
+			Name:       "root",
+			SigningKey: "hsm:root",
+		}
+		developerProfile := &SigningProfile{
+			Name:             "developer",
+			SigningKey:       "ssh-agent:SHA256:abc",
+			GitSigningFormat: "ssh",
+			GitSigningKey:    "~/.ssh/id_ed25519.pub",
+		}
+
+		err := repo.SetSigningProfile(rootProfile)
+		assert.Nil(t, err)
+		err = repo.SetSigningProfile(developerProfile)
+		assert.Nil(t, err)
+
+		profile, err := repo.GetSigningProfile("developer")
+		assert.Nil(t, err)
+		assert.Equal(t, developerProfile, profile)
+		assert.Equal(t, map[string]string{"gpg.format": "ssh", "user.signingkey": "~/.ssh/id_ed25519.pub"}, profile.GitConfigOverrides())
+
+		profiles, err := repo.ListSigningProfiles()
+		assert.Nil(t, err)
+		assert.Equal(t, []*SigningProfile{developerProfile, rootProfile}, profiles)
+
+		// Setting a profile replaces it entirely
+		developerProfile = &SigningProfile{Name: "developer", SigningKey: "developer.pem"}
+		err = repo.SetSigningProfile(developerProfile)
+		assert.Nil(t, err)
+
+		profile, err = repo.GetSigningProfile("developer")
+		assert.Nil(t, err)
+		assert.Equal(t, developerProfile, profile)
+		assert.Empty(t, profile.GitConfigOverrides())
+
+		err = repo.RemoveSigningProfile("root")
+		assert.Nil(t, err)
+
+		profiles, err = repo.ListSigningProfiles()
+		assert.Nil(t, err)
+		assert.Equal(t, []*SigningProfile{developerProfile}, profiles)
+	})
+}
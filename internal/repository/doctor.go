@@ -0,0 +1,298 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+// DiagnosticSeverity indicates the outcome of a diagnostic check. Errors are
+// problems that prevent gittuf from working correctly, while warnings are
+// likely to cause problems in some workflows.
+type DiagnosticSeverity string
+
+const (
+	DiagnosticOK      DiagnosticSeverity = "ok"
+	DiagnosticWarning DiagnosticSeverity = "warning"
+	DiagnosticError   DiagnosticSeverity = "error"
+)
+
+// Identifiers for each of the checks performed by Diagnose.
+const (
+	DiagnosticCheckGitVersion    = "git-version"
+	DiagnosticCheckSigning       = "signing"
+	DiagnosticCheckHooks         = "hooks"
+	DiagnosticCheckRefs          = "refs"
+	DiagnosticCheckFetchRefSpecs = "fetch-refspecs"
+	DiagnosticCheckClock         = "clock"
+)
+
+// clockSkewTolerance is how far in the future the latest RSL entry may be
+// timestamped before the local clock is considered to be behind.
+const clockSkewTolerance = 5 * time.Minute
+
+// Diagnostic records the result of a check of the environment gittuf runs in.
+type Diagnostic struct {
+	Severity DiagnosticSeverity `json:"severity"`
+	Check    string             `json:"check"`
+	Message  string             `json:"message"`
+
+	// Fix suggests how the problem can be resolved, if the check did not
+	// pass.
+	Fix string `json:"fix,omitempty"`
+}
+
+// DiagnosticReport records the results of Diagnose.
+type DiagnosticReport struct {
+	Diagnostics []*Diagnostic `json:"diagnostics"`
+}
+
+// ErrorCount returns the number of diagnostics that are errors.
+func (d *DiagnosticReport) ErrorCount() int {
+	count := 0
+	for _, diagnostic := range d.Diagnostics {
+		if diagnostic.Severity == DiagnosticError {
+			count++
+		}
+	}
+	return count
+}
+
+func (d *DiagnosticReport) add(severity DiagnosticSeverity, check, message, fix string) {
+	d.Diagnostics = append(d.Diagnostics, &Diagnostic{Severity: severity, Check: check, Message: message, Fix: fix})
+}
+
+// Diagnose checks the environment gittuf runs in for common problems: the Git
+// version, the signing configuration, the installation of gittuf's hooks, the
+// presence of gittuf's refs, the fetch refspecs of the repository's remotes,
+// and the local clock relative to the RSL.
+func (r *Repository) Diagnose(ctx context.Context) (*DiagnosticReport, error) {
+	report := &DiagnosticReport{Diagnostics: []*Diagnostic{}}
+
+	slog.Debug("Checking Git version...")
+	r.diagnoseGitVersion(ctx, report)
+
+	slog.Debug("Checking signing configuration...")
+	diagnoseSigning(report)
+
+	slog.Debug("Checking hooks...")
+	if err := r.diagnoseHooks(report); err != nil {
+		return nil, err
+	}
+
+	slog.Debug("Checking gittuf refs...")
+	if err := r.diagnoseRefs(report); err != nil {
+		return nil, err
+	}
+
+	slog.Debug("Checking fetch refspecs...")
+	if err := r.diagnoseFetchRefSpecs(report); err != nil {
+		return nil, err
+	}
+
+	slog.Debug("Checking clock...")
+	if err := r.diagnoseClock(report); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+func (r *Repository) diagnoseGitVersion(ctx context.Context, report *DiagnosticReport) {
+	installFix := fmt.Sprintf("install Git %s or later", gitinterface.MinimumGitVersion)
+
+	if !gitinterface.UseGitBinary() {
+		if _, err := exec.LookPath("git"); err != nil {
+			report.add(DiagnosticWarning, DiagnosticCheckGitVersion, "Git binary not found, gittuf uses go-git, which ignores include directives in the Git config", installFix)
+			return
+		}
+		report.add(DiagnosticOK, DiagnosticCheckGitVersion, "Git binary is disabled, gittuf uses go-git", "")
+		return
+	}
+
+	version, err := gitinterface.GetGitVersion(ctx)
+	if err != nil {
+		report.add(DiagnosticError, DiagnosticCheckGitVersion, fmt.Sprintf("unable to determine Git version: %s", err.Error()), installFix)
+		return
+	}
+
+	atLeast, err := gitinterface.IsGitVersionAtLeast(version, gitinterface.MinimumGitVersion)
+	switch {
+	case err != nil:
+		report.add(DiagnosticWarning, DiagnosticCheckGitVersion, fmt.Sprintf("unable to parse Git version '%s'", version), installFix)
+	case !atLeast:
+		report.add(DiagnosticWarning, DiagnosticCheckGitVersion, fmt.Sprintf("Git %s is older than %s, SSH signing is not supported", version, gitinterface.MinimumGitVersion), installFix)
+	default:
+		report.add(DiagnosticOK, DiagnosticCheckGitVersion, fmt.Sprintf("Git %s", version), "")
+	}
+}
+
+func diagnoseSigning(report *DiagnosticReport) {
+	program, _, err := gitinterface.GetSigningCommand()
+	if err != nil {
+		switch {
+		case errors.Is(err, gitinterface.ErrSigningKeyNotSpecified):
+			report.add(DiagnosticError, DiagnosticCheckSigning, "SSH signing is configured without a signing key", "set user.signingkey to the SSH public key used for signing")
+		case errors.Is(err, gitinterface.ErrUnknownSigningMethod):
+			report.add(DiagnosticError, DiagnosticCheckSigning, "gpg.format is not one of gpg, ssh, or x509", "set gpg.format to gpg, ssh, or x509")
+		default:
+			report.add(DiagnosticError, DiagnosticCheckSigning, fmt.Sprintf("unable to read signing configuration: %s", err.Error()), "check the Git config with 'git config --list'")
+		}
+		return
+	}
+
+	if _, err := exec.LookPath(program); err != nil {
+		report.add(DiagnosticError, DiagnosticCheckSigning, fmt.Sprintf("signing program '%s' not found", program), fmt.Sprintf("install '%s' or set gpg.program, gpg.ssh.program, or gpg.x509.program for the configured gpg.format", program))
+		return
+	}
+
+	report.add(DiagnosticOK, DiagnosticCheckSigning, fmt.Sprintf("signing with '%s'", program), "")
+}
+
+func (r *Repository) diagnoseHooks(report *DiagnosticReport) error {
+	hookFolder, err := r.getHooksFolder()
+	if err != nil {
+		report.add(DiagnosticWarning, DiagnosticCheckHooks, err.Error(), "")
+		return nil //nolint:nilerr
+	}
+
+	missing := []string{}
+	for _, hookType := range HookTypes() {
+		hookFile := filepath.Join(hookFolder, string(hookType))
+		content, err := os.ReadFile(hookFile)
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				missing = append(missing, string(hookType))
+				continue
+			}
+			return err
+		}
+
+		if !strings.Contains(string(content), gittufHookMarker) {
+			report.add(DiagnosticWarning, DiagnosticCheckHooks, fmt.Sprintf("'%s' hook was not installed by gittuf", hookType), fmt.Sprintf("run 'gittuf hooks install --hook %s' to chain the existing hook", hookType))
+			continue
+		}
+
+		info, err := os.Stat(hookFile)
+		if err != nil {
+			return err
+		}
+		if info.Mode()&0o111 == 0 {
+			report.add(DiagnosticWarning, DiagnosticCheckHooks, fmt.Sprintf("'%s' hook is not executable", hookType), fmt.Sprintf("run 'chmod +x %s'", hookFile))
+			continue
+		}
+
+		report.add(DiagnosticOK, DiagnosticCheckHooks, fmt.Sprintf("'%s' hook installed", hookType), "")
+	}
+
+	if len(missing) != 0 {
+		report.add(DiagnosticWarning, DiagnosticCheckHooks, fmt.Sprintf("hooks not installed: %s", strings.Join(missing, ", ")), "run 'gittuf hooks install'")
+	}
+
+	return nil
+}
+
+func (r *Repository) diagnoseRefs(report *DiagnosticReport) error {
+	hasRSL, err := r.hasRef(rsl.Ref)
+	if err != nil {
+		return err
+	}
+	if !hasRSL {
+		report.add(DiagnosticWarning, DiagnosticCheckRefs, fmt.Sprintf("'%s' not found", rsl.Ref), fmt.Sprintf("run 'gittuf rsl remote pull %s' if the remote uses gittuf, or 'gittuf trust init' to set up gittuf", gitinterface.DefaultRemoteName))
+		return nil
+	}
+	report.add(DiagnosticOK, DiagnosticCheckRefs, fmt.Sprintf("'%s' found", rsl.Ref), "")
+
+	hasPolicy, err := r.hasRef(policy.PolicyRef)
+	if err != nil {
+		return err
+	}
+	if !hasPolicy {
+		report.add(DiagnosticWarning, DiagnosticCheckRefs, fmt.Sprintf("'%s' not found", policy.PolicyRef), fmt.Sprintf("run 'gittuf rsl remote pull %s' if the remote has a policy, or 'gittuf trust init' followed by 'gittuf policy apply' to create one", gitinterface.DefaultRemoteName))
+		return nil
+	}
+	report.add(DiagnosticOK, DiagnosticCheckRefs, fmt.Sprintf("'%s' found", policy.PolicyRef), "")
+
+	return nil
+}
+
+func (r *Repository) hasRef(refName string) (bool, error) {
+	if _, err := r.r.Reference(plumbing.ReferenceName(refName), true); err != nil {
+		if errors.Is(err, plumbing.ErrReferenceNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func (r *Repository) diagnoseFetchRefSpecs(report *DiagnosticReport) error {
+	config, err := r.r.Config()
+	if err != nil {
+		return err
+	}
+
+	if len(config.Remotes) == 0 {
+		report.add(DiagnosticOK, DiagnosticCheckFetchRefSpecs, "no remotes configured", "")
+		return nil
+	}
+
+	remoteNames := make([]string, 0, len(config.Remotes))
+	for remoteName := range config.Remotes {
+		remoteNames = append(remoteNames, remoteName)
+	}
+	sort.Strings(remoteNames)
+
+	for _, remoteName := range remoteNames {
+		fetchesRSL := false
+		for _, refSpec := range config.Remotes[remoteName].Fetch {
+			if refSpec.Match(plumbing.ReferenceName(rsl.Ref)) {
+				fetchesRSL = true
+				break
+			}
+		}
+
+		if !fetchesRSL {
+			report.add(DiagnosticWarning, DiagnosticCheckFetchRefSpecs, fmt.Sprintf("'git fetch %s' does not fetch gittuf refs, use gittuf to sync them", remoteName), fmt.Sprintf("run 'git config --add remote.%s.fetch \"%s\"' to track them with 'git fetch'", remoteName, GittufFetchRefSpec(remoteName)))
+			continue
+		}
+
+		report.add(DiagnosticOK, DiagnosticCheckFetchRefSpecs, fmt.Sprintf("'git fetch %s' fetches gittuf refs", remoteName), "")
+	}
+
+	return nil
+}
+
+func (r *Repository) diagnoseClock(report *DiagnosticReport) error {
+	latestEntryTime, err := r.GetLatestRSLEntryTime()
+	if err != nil {
+		if errors.Is(err, rsl.ErrRSLEntryNotFound) || errors.Is(err, plumbing.ErrReferenceNotFound) {
+			report.add(DiagnosticOK, DiagnosticCheckClock, "no RSL entries to compare the clock with", "")
+			return nil
+		}
+		return err
+	}
+
+	now := time.Now()
+	if skew := latestEntryTime.Sub(now); skew > clockSkewTolerance {
+		report.add(DiagnosticError, DiagnosticCheckClock, fmt.Sprintf("latest RSL entry is timestamped %s in the future, the local clock may be behind", skew.Round(time.Second)), "synchronize the local clock, such as using NTP")
+		return nil
+	}
+
+	report.add(DiagnosticOK, DiagnosticCheckClock, "local clock is consistent with the RSL", "")
+	return nil
+}
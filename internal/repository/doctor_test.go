@@ -0,0 +1,119 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiagnose(t *testing.T) {
+	// getDiagnostics returns the diagnostics for the specified check
+	getDiagnostics := func(report *DiagnosticReport, check string) []*Diagnostic {
+		diagnostics := []*Diagnostic{}
+		for _, diagnostic := range report.Diagnostics {
+			if diagnostic.Check == check {
+				diagnostics = append(diagnostics, diagnostic)
+			}
+		}
+		return diagnostics
+	}
+
+	t.Run("no gittuf state", func(t *testing.T) {
+		r, err := git.PlainInit(t.TempDir(), false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		repo := &Repository{r: r}
+
+		report, err := repo.Diagnose(testCtx)
+		assert.Nil(t, err)
+
+		assert.Len(t, getDiagnostics(report, DiagnosticCheckGitVersion), 1)
+		assert.Len(t, getDiagnostics(report, DiagnosticCheckSigning), 1)
+
+		hookDiagnostics := getDiagnostics(report, DiagnosticCheckHooks)
+		if assert.Len(t, hookDiagnostics, 1) {
+			assert.Equal(t, DiagnosticWarning, hookDiagnostics[0].Severity)
+			assert.Equal(t, "run 'gittuf hooks install'", hookDiagnostics[0].Fix)
+		}
+
+		refDiagnostics := getDiagnostics(report, DiagnosticCheckRefs)
+		if assert.Len(t, refDiagnostics, 1) {
+			assert.Equal(t, DiagnosticWarning, refDiagnostics[0].Severity)
+			assert.Contains(t, refDiagnostics[0].Message, rsl.Ref)
+		}
+
+		refSpecDiagnostics := getDiagnostics(report, DiagnosticCheckFetchRefSpecs)
+		if assert.Len(t, refSpecDiagnostics, 1) {
+			assert.Equal(t, DiagnosticOK, refSpecDiagnostics[0].Severity)
+		}
+
+		clockDiagnostics := getDiagnostics(report, DiagnosticCheckClock)
+		if assert.Len(t, clockDiagnostics, 1) {
+			assert.Equal(t, DiagnosticOK, clockDiagnostics[0].Severity)
+		}
+	})
+
+	t.Run("gittuf configured", func(t *testing.T) {
+		repo := createTestRepositoryWithPolicy(t, t.TempDir())
+
+		for _, hookType := range HookTypes() {
+			if _, err := repo.InstallHook(hookType, false); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		if _, err := repo.r.CreateRemote(&config.RemoteConfig{
+			Name:  "origin",
+			URLs:  []string{"https://example.com/repo.git"},
+			Fetch: []config.RefSpec{"+refs/heads/*:refs/remotes/origin/*"},
+		}); err != nil {
+			t.Fatal(err)
+		}
+
+		report, err := repo.Diagnose(testCtx)
+		assert.Nil(t, err)
+
+		hookDiagnostics := getDiagnostics(report, DiagnosticCheckHooks)
+		assert.Len(t, hookDiagnostics, len(HookTypes()))
+		for _, diagnostic := range hookDiagnostics {
+			assert.Equal(t, DiagnosticOK, diagnostic.Severity)
+		}
+
+		refDiagnostics := getDiagnostics(report, DiagnosticCheckRefs)
+		if assert.Len(t, refDiagnostics, 2) {
+			assert.Equal(t, DiagnosticOK, refDiagnostics[0].Severity)
+			assert.Equal(t, DiagnosticOK, refDiagnostics[1].Severity)
+			assert.Contains(t, refDiagnostics[1].Message, policy.PolicyRef)
+		}
+
+		refSpecDiagnostics := getDiagnostics(report, DiagnosticCheckFetchRefSpecs)
+		if assert.Len(t, refSpecDiagnostics, 1) {
+			assert.Equal(t, DiagnosticWarning, refSpecDiagnostics[0].Severity)
+			assert.Contains(t, refSpecDiagnostics[0].Fix, "remote.origin.fetch")
+		}
+
+		clockDiagnostics := getDiagnostics(report, DiagnosticCheckClock)
+		if assert.Len(t, clockDiagnostics, 1) {
+			assert.Equal(t, DiagnosticOK, clockDiagnostics[0].Severity)
+		}
+
+		if _, err := repo.TrackRemoteGittufRefs("origin"); err != nil {
+			t.Fatal(err)
+		}
+
+		report, err = repo.Diagnose(testCtx)
+		assert.Nil(t, err)
+
+		refSpecDiagnostics = getDiagnostics(report, DiagnosticCheckFetchRefSpecs)
+		if assert.Len(t, refSpecDiagnostics, 1) {
+			assert.Equal(t, DiagnosticOK, refSpecDiagnostics[0].Severity)
+		}
+	})
+}
@@ -0,0 +1,175 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/signerverifier/dsse"
+	"github.com/gittuf/gittuf/internal/tuf"
+	sslibdsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
+)
+
+// ExportPolicy returns the declarative representation of the staged policy,
+// or the current policy if nothing is staged.
+func (r *Repository) ExportPolicy(ctx context.Context) (*policy.Document, error) {
+	state, err := r.loadStateForUpdate(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return state.ExportDocument()
+}
+
+// ApplyPolicyDocument updates the policy to match the document. Only the
+// metadata that differs from the document is regenerated and signed using the
+// signer, so applying the same document again is a no-op. Policy files that
+// are not in the document are removed, with the exception of the top level
+// policy file. If the root of trust changes, the signer must be trusted for the
+// Root role.
+func (r *Repository) ApplyPolicyDocument(ctx context.Context, signer sslibdsse.SignerVerifier, document *policy.Document, signCommit bool) error {
+	keyID, err := signer.KeyID()
+	if err != nil {
+		return err
+	}
+
+	state, err := r.loadStateForUpdate(ctx)
+	if err != nil {
+		return err
+	}
+
+	rootMetadata, policyFiles, err := state.MetadataFromDocument(document)
+	if err != nil {
+		return err
+	}
+
+	if state.TargetsEnvelope != nil {
+		if _, has := policyFiles[policy.TargetsRoleName]; !has {
+			return fmt.Errorf("%w: top level policy file cannot be removed", policy.ErrInvalidPolicyDocument)
+		}
+	}
+
+	modified := false
+
+	currentRootMetadata, err := state.GetRootMetadata()
+	if err != nil {
+		return err
+	}
+	rootMatches, err := metadataMatches(currentRootMetadata, rootMetadata)
+	if err != nil {
+		return err
+	}
+	if !rootMatches {
+		if !isKeyAuthorized(currentRootMetadata.Roles[policy.RootRoleName].KeyIDs, keyID) {
+			return ErrUnauthorizedKey
+		}
+
+		rootPublicKeys := []*tuf.Key{}
+		for _, rootKeyID := range rootMetadata.Roles[policy.RootRoleName].KeyIDs {
+			key, has := rootMetadata.Keys[rootKeyID]
+			if !has {
+				return fmt.Errorf("%w: root key '%s' is not defined", policy.ErrInvalidPolicyDocument, rootKeyID)
+			}
+			rootPublicKeys = append(rootPublicKeys, key)
+		}
+		state.RootPublicKeys = rootPublicKeys
+
+		rootMetadata.SetVersion(rootMetadata.Version + 1)
+		env, err := dsse.CreateEnvelope(rootMetadata)
+		if err != nil {
+			return err
+		}
+
+		slog.Debug(fmt.Sprintf("Signing updated root metadata using '%s'...", keyID))
+		state.RootEnvelope, err = dsse.SignEnvelope(ctx, env, signer)
+		if err != nil {
+			return err
+		}
+
+		modified = true
+	}
+
+	policyNames := make([]string, 0, len(policyFiles))
+	for policyName := range policyFiles {
+		policyNames = append(policyNames, policyName)
+	}
+	sort.Strings(policyNames)
+
+	for _, policyName := range policyNames {
+		targetsMetadata := policyFiles[policyName]
+
+		if state.HasTargetsRole(policyName) {
+			currentTargetsMetadata, err := state.GetTargetsMetadata(policyName)
+			if err != nil {
+				return err
+			}
+
+			matches, err := metadataMatches(currentTargetsMetadata, targetsMetadata)
+			if err != nil {
+				return err
+			}
+			if matches {
+				continue
+			}
+
+			targetsMetadata.SetVersion(targetsMetadata.Version + 1)
+		}
+
+		env, err := dsse.CreateEnvelope(targetsMetadata)
+		if err != nil {
+			return err
+		}
+
+		slog.Debug(fmt.Sprintf("Signing policy file '%s' using '%s'...", policyName, keyID))
+		env, err = dsse.SignEnvelope(ctx, env, signer)
+		if err != nil {
+			return err
+		}
+
+		if policyName == policy.TargetsRoleName {
+			state.TargetsEnvelope = env
+		} else {
+			if state.DelegationEnvelopes == nil {
+				state.DelegationEnvelopes = map[string]*sslibdsse.Envelope{}
+			}
+			state.DelegationEnvelopes[policyName] = env
+		}
+
+		modified = true
+	}
+
+	for policyName := range state.DelegationEnvelopes {
+		if _, has := policyFiles[policyName]; !has {
+			slog.Debug(fmt.Sprintf("Removing policy file '%s'...", policyName))
+			delete(state.DelegationEnvelopes, policyName)
+			modified = true
+		}
+	}
+
+	if !modified {
+		slog.Debug("Policy already matches document")
+		return nil
+	}
+
+	return r.commitPolicyUpdate(ctx, state, "Apply policy document", signCommit)
+}
+
+// metadataMatches returns true if the two pieces of metadata serialize
+// identically.
+func metadataMatches(a, b any) (bool, error) {
+	aBytes, err := tuf.EncodeCanonical(a)
+	if err != nil {
+		return false, err
+	}
+	bBytes, err := tuf.EncodeCanonical(b)
+	if err != nil {
+		return false, err
+	}
+
+	return bytes.Equal(aBytes, bBytes), nil
+}
@@ -0,0 +1,129 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/signerverifier"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyPolicyDocument(t *testing.T) {
+	r := createTestRepositoryWithPolicy(t, "")
+
+	rootSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(rootKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(targetsKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsKey, err := tuf.LoadKeyFromBytes(targetsPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("unchanged document", func(t *testing.T) {
+		document, err := r.ExportPolicy(testCtx)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		policyTip, err := gitinterface.GetTip(r.r, policy.PolicyRef)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = r.ApplyPolicyDocument(testCtx, targetsSigner, document, false)
+		assert.Nil(t, err)
+
+		newPolicyTip, err := gitinterface.GetTip(r.r, policy.PolicyRef)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, policyTip, newPolicyTip)
+	})
+
+	t.Run("add rule and policy file", func(t *testing.T) {
+		document, err := r.ExportPolicy(testCtx)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		targetsPolicyFile := document.PolicyFiles[0]
+		targetsPolicyFile.Keys = append(targetsPolicyFile.Keys, targetsKey)
+		targetsPolicyFile.Rules = append(targetsPolicyFile.Rules, tuf.Delegation{
+			Name:  "protect-feature",
+			Paths: []string{"git:refs/heads/feature"},
+			Role:  tuf.Role{KeyIDs: []string{targetsKey.KeyID}, Threshold: 1},
+		})
+		document.PolicyFiles = append(document.PolicyFiles, &policy.PolicyFileDocument{
+			Name:  "protect-feature",
+			Keys:  []*tuf.Key{},
+			Rules: []tuf.Delegation{},
+		})
+
+		err = r.ApplyPolicyDocument(testCtx, targetsSigner, document, false)
+		assert.Nil(t, err)
+
+		state, err := policy.LoadCurrentState(testCtx, r.r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.True(t, state.HasRuleName("protect-feature"))
+		assert.True(t, state.HasTargetsRole("protect-feature"))
+
+		targetsMetadata, err := state.GetTargetsMetadata(policy.TargetsRoleName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, 3, targetsMetadata.Version)
+
+		// Removing the policy file from the document removes it from policy
+		document.PolicyFiles = document.PolicyFiles[:1]
+		document.PolicyFiles[0].Rules = document.PolicyFiles[0].Rules[:1]
+		err = r.ApplyPolicyDocument(testCtx, targetsSigner, document, false)
+		assert.Nil(t, err)
+
+		state, err = policy.LoadCurrentState(testCtx, r.r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.False(t, state.HasRuleName("protect-feature"))
+		assert.False(t, state.HasTargetsRole("protect-feature"))
+	})
+
+	t.Run("change root of trust", func(t *testing.T) {
+		document, err := r.ExportPolicy(testCtx)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		document.Root.Keys = append(document.Root.Keys, targetsKey)
+		targetsRole := document.Root.Roles[policy.TargetsRoleName]
+		targetsRole.KeyIDs = append(targetsRole.KeyIDs, targetsKey.KeyID)
+		document.Root.Roles[policy.TargetsRoleName] = targetsRole
+
+		err = r.ApplyPolicyDocument(testCtx, targetsSigner, document, false)
+		assert.ErrorIs(t, err, ErrUnauthorizedKey)
+
+		err = r.ApplyPolicyDocument(testCtx, rootSigner, document, false)
+		assert.Nil(t, err)
+
+		state, err := policy.LoadCurrentState(testCtx, r.r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rootMetadata, err := state.GetRootMetadata()
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Contains(t, rootMetadata.Roles[policy.TargetsRoleName].KeyIDs, targetsKey.KeyID)
+		assert.Equal(t, 1, rootMetadata.Roles[policy.TargetsRoleName].Threshold)
+	})
+}
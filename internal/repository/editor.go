@@ -0,0 +1,237 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/signerverifier/dsse"
+	"github.com/gittuf/gittuf/internal/tuf"
+	sslibdsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
+)
+
+var ErrNoPolicyEdits = errors.New("no changes were made to the policy")
+
+// PolicyEditor composes changes to one or more policy files. The changes are
+// signed and committed together by EditPolicy, with each policy file that is
+// changed receiving a single new version, irrespective of the number of
+// changes made to it.
+type PolicyEditor struct {
+	state          *policy.State
+	policyFiles    map[string]*tuf.TargetsMetadata
+	addedRules     map[string]bool
+	removedRules   map[string]bool
+	changeMessages []string
+}
+
+// EditPolicy is the interface for the user to make several changes to gittuf
+// policy in a single update. The edit function is invoked with a PolicyEditor
+// used to compose the changes. If the edit function returns an error, no
+// changes are made to the policy. Otherwise, every policy file that was
+// changed is signed using signer, and the policy is committed once. If
+// commitMessage is empty, a message summarizing the changes is used.
+func (r *Repository) EditPolicy(ctx context.Context, signer sslibdsse.SignerVerifier, commitMessage string, signCommit bool, edit func(*PolicyEditor) error) error {
+	keyID, err := signer.KeyID()
+	if err != nil {
+		return err
+	}
+
+	state, err := r.loadStateForUpdate(ctx)
+	if err != nil {
+		return err
+	}
+
+	editor := newPolicyEditor(state)
+	if err := edit(editor); err != nil {
+		return err
+	}
+
+	if len(editor.policyFiles) == 0 {
+		return ErrNoPolicyEdits
+	}
+
+	policyNames := make([]string, 0, len(editor.policyFiles))
+	for policyName := range editor.policyFiles {
+		policyNames = append(policyNames, policyName)
+	}
+	sort.Strings(policyNames)
+
+	for _, policyName := range policyNames {
+		targetsMetadata := editor.policyFiles[policyName]
+		targetsMetadata.SetVersion(targetsMetadata.Version + 1)
+
+		env, err := dsse.CreateEnvelope(targetsMetadata)
+		if err != nil {
+			return err
+		}
+
+		slog.Debug(fmt.Sprintf("Signing updated policy file '%s' using '%s'...", policyName, keyID))
+		env, err = dsse.SignEnvelope(ctx, env, signer)
+		if err != nil {
+			return err
+		}
+
+		if policyName == policy.TargetsRoleName {
+			state.TargetsEnvelope = env
+		} else {
+			state.DelegationEnvelopes[policyName] = env
+		}
+	}
+
+	if commitMessage == "" {
+		commitMessage = fmt.Sprintf("Edit policy\n\n- %s", strings.Join(editor.changeMessages, "\n- "))
+	}
+
+	return r.commitPolicyUpdate(ctx, state, commitMessage, signCommit)
+}
+
+func newPolicyEditor(state *policy.State) *PolicyEditor {
+	return &PolicyEditor{
+		state:        state,
+		policyFiles:  map[string]*tuf.TargetsMetadata{},
+		addedRules:   map[string]bool{},
+		removedRules: map[string]bool{},
+	}
+}
+
+// Update applies updateFn to the specified policy file. It can be used to make
+// changes that are not covered by the other methods of the editor.
+func (e *PolicyEditor) Update(policyName, description string, updateFn func(*tuf.TargetsMetadata) (*tuf.TargetsMetadata, error)) error {
+	targetsMetadata, err := e.getPolicyFile(policyName)
+	if err != nil {
+		return err
+	}
+
+	targetsMetadata, err = updateFn(targetsMetadata)
+	if err != nil {
+		return err
+	}
+
+	e.policyFiles[policyName] = targetsMetadata
+	e.changeMessages = append(e.changeMessages, description)
+	return nil
+}
+
+// AddRule adds a rule to the specified policy file.
+func (e *PolicyEditor) AddRule(policyName, ruleName string, authorizedKeys []*tuf.Key, rulePatterns []string, threshold int) error {
+	if ruleName == policy.RootRoleName || ruleName == policy.SnapshotRoleName {
+		return ErrInvalidPolicyName
+	}
+
+	if e.hasRuleName(ruleName) {
+		return policy.ErrDuplicatedRuleName
+	}
+
+	err := e.Update(policyName, fmt.Sprintf("Add rule '%s' to policy '%s'", ruleName, policyName), func(targetsMetadata *tuf.TargetsMetadata) (*tuf.TargetsMetadata, error) {
+		return policy.AddDelegation(targetsMetadata, ruleName, authorizedKeys, rulePatterns, threshold)
+	})
+	if err != nil {
+		return err
+	}
+
+	e.addedRules[ruleName] = true
+	delete(e.removedRules, ruleName)
+	return nil
+}
+
+// UpdateRule updates an existing rule in the specified policy file.
+func (e *PolicyEditor) UpdateRule(policyName, ruleName string, authorizedKeys []*tuf.Key, rulePatterns []string, threshold int) error {
+	if ruleName == policy.RootRoleName || ruleName == policy.SnapshotRoleName {
+		return ErrInvalidPolicyName
+	}
+
+	return e.Update(policyName, fmt.Sprintf("Update rule '%s' in policy '%s'", ruleName, policyName), func(targetsMetadata *tuf.TargetsMetadata) (*tuf.TargetsMetadata, error) {
+		return policy.UpdateDelegation(targetsMetadata, ruleName, authorizedKeys, rulePatterns, threshold)
+	})
+}
+
+// RemoveRule removes a rule from the specified policy file.
+func (e *PolicyEditor) RemoveRule(policyName, ruleName string) error {
+	err := e.Update(policyName, fmt.Sprintf("Remove rule '%s' from policy '%s'", ruleName, policyName), func(targetsMetadata *tuf.TargetsMetadata) (*tuf.TargetsMetadata, error) {
+		return policy.RemoveDelegation(targetsMetadata, ruleName)
+	})
+	if err != nil {
+		return err
+	}
+
+	e.removedRules[ruleName] = true
+	delete(e.addedRules, ruleName)
+	return nil
+}
+
+// SetRuleEffect sets the effect of a rule in the specified policy file.
+func (e *PolicyEditor) SetRuleEffect(policyName, ruleName, effect string) error {
+	return e.Update(policyName, fmt.Sprintf("Set effect of rule '%s' in policy '%s' to '%s'", ruleName, policyName, effect), func(targetsMetadata *tuf.TargetsMetadata) (*tuf.TargetsMetadata, error) {
+		return policy.SetRuleEffect(targetsMetadata, ruleName, effect)
+	})
+}
+
+// SetRulePriority sets the priority of a rule in the specified policy file.
+func (e *PolicyEditor) SetRulePriority(policyName, ruleName string, priority int) error {
+	return e.Update(policyName, fmt.Sprintf("Set priority of rule '%s' in policy '%s' to %d", ruleName, policyName, priority), func(targetsMetadata *tuf.TargetsMetadata) (*tuf.TargetsMetadata, error) {
+		return policy.SetRulePriority(targetsMetadata, ruleName, priority)
+	})
+}
+
+// AddKeys adds keys to the specified policy file so they can be used in
+// rules.
+func (e *PolicyEditor) AddKeys(policyName string, keys []*tuf.Key) error {
+	keyIDs := make([]string, 0, len(keys))
+	for _, key := range keys {
+		keyIDs = append(keyIDs, key.KeyID)
+	}
+
+	return e.Update(policyName, fmt.Sprintf("Add keys '%s' to policy '%s'", strings.Join(keyIDs, ", "), policyName), func(targetsMetadata *tuf.TargetsMetadata) (*tuf.TargetsMetadata, error) {
+		return policy.AddKeyToTargets(targetsMetadata, keys)
+	})
+}
+
+// AddPerson adds a person and their keys to the specified policy file.
+func (e *PolicyEditor) AddPerson(policyName, personID string, keys []*tuf.Key) error {
+	return e.Update(policyName, fmt.Sprintf("Add person '%s' to policy '%s'", personID, policyName), func(targetsMetadata *tuf.TargetsMetadata) (*tuf.TargetsMetadata, error) {
+		return policy.AddPerson(targetsMetadata, personID, keys)
+	})
+}
+
+// RemovePerson removes a person from the specified policy file.
+func (e *PolicyEditor) RemovePerson(policyName, personID string) error {
+	return e.Update(policyName, fmt.Sprintf("Remove person '%s' from policy '%s'", personID, policyName), func(targetsMetadata *tuf.TargetsMetadata) (*tuf.TargetsMetadata, error) {
+		return policy.RemovePerson(targetsMetadata, personID)
+	})
+}
+
+// SetAuthorizedPersons sets the people authorized by a rule in the specified
+// policy file.
+func (e *PolicyEditor) SetAuthorizedPersons(policyName, ruleName string, personIDs []string) error {
+	return e.Update(policyName, fmt.Sprintf("Set people authorized by rule '%s' in policy '%s'", ruleName, policyName), func(targetsMetadata *tuf.TargetsMetadata) (*tuf.TargetsMetadata, error) {
+		return policy.SetAuthorizedPersons(targetsMetadata, ruleName, personIDs)
+	})
+}
+
+// getPolicyFile returns the policy file as edited so far, loading it from the
+// state the first time it is requested.
+func (e *PolicyEditor) getPolicyFile(policyName string) (*tuf.TargetsMetadata, error) {
+	if targetsMetadata, has := e.policyFiles[policyName]; has {
+		return targetsMetadata, nil
+	}
+
+	if !e.state.HasTargetsRole(policyName) {
+		return nil, policy.ErrMetadataNotFound
+	}
+
+	return e.state.GetTargetsMetadata(policyName)
+}
+
+func (e *PolicyEditor) hasRuleName(ruleName string) bool {
+	if e.addedRules[ruleName] {
+		return true
+	}
+
+	return e.state.HasRuleName(ruleName) && !e.removedRules[ruleName]
+}
@@ -0,0 +1,120 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/internal/signerverifier"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEditPolicy(t *testing.T) {
+	targetsSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(targetsKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targetsPubKey, err := tuf.LoadKeyFromBytes(targetsPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("several changes in one update", func(t *testing.T) {
+		r := createTestRepositoryWithPolicy(t, "")
+
+		entryBefore, err := rsl.GetLatestEntry(r.r)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = r.EditPolicy(testCtx, targetsSigner, "", false, func(editor *PolicyEditor) error {
+			if err := editor.AddRule(policy.TargetsRoleName, "protect-feature", []*tuf.Key{targetsPubKey}, []string{"git:refs/heads/feature"}, 1); err != nil {
+				return err
+			}
+			if err := editor.RemoveRule(policy.TargetsRoleName, "protect-main"); err != nil {
+				return err
+			}
+			// The name of the removed rule can be reused in the same update
+			if err := editor.AddRule(policy.TargetsRoleName, "protect-main", []*tuf.Key{targetsPubKey}, []string{"git:refs/heads/main"}, 1); err != nil {
+				return err
+			}
+			return editor.SetRulePriority(policy.TargetsRoleName, "protect-feature", 10)
+		})
+		assert.Nil(t, err)
+
+		entryAfter, err := rsl.GetLatestEntry(r.r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		parentEntry, err := rsl.GetParentForEntry(r.r, entryAfter)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, entryBefore.GetID(), parentEntry.GetID())
+
+		state, err := policy.LoadCurrentState(context.Background(), r.r)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		targetsMetadata, err := state.GetTargetsMetadata(policy.TargetsRoleName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, 3, targetsMetadata.Version)
+		assert.Equal(t, 3, len(targetsMetadata.Delegations.Roles))
+		assert.Equal(t, "protect-feature", targetsMetadata.Delegations.Roles[0].Name)
+		assert.Equal(t, 10, targetsMetadata.Delegations.Roles[0].Priority)
+		assert.Equal(t, "protect-main", targetsMetadata.Delegations.Roles[1].Name)
+		assert.Equal(t, []string{targetsPubKey.KeyID}, targetsMetadata.Delegations.Roles[1].KeyIDs)
+	})
+
+	t.Run("error in edit function", func(t *testing.T) {
+		r := createTestRepositoryWithPolicy(t, "")
+
+		entryBefore, err := rsl.GetLatestEntry(r.r)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		editErr := errors.New("edit failed")
+		err = r.EditPolicy(testCtx, targetsSigner, "", false, func(editor *PolicyEditor) error {
+			if err := editor.AddRule(policy.TargetsRoleName, "protect-feature", []*tuf.Key{targetsPubKey}, []string{"git:refs/heads/feature"}, 1); err != nil {
+				return err
+			}
+			return editErr
+		})
+		assert.ErrorIs(t, err, editErr)
+
+		entryAfter, err := rsl.GetLatestEntry(r.r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, entryBefore.GetID(), entryAfter.GetID())
+	})
+
+	t.Run("duplicated rule name", func(t *testing.T) {
+		r := createTestRepositoryWithPolicy(t, "")
+
+		err := r.EditPolicy(testCtx, targetsSigner, "", false, func(editor *PolicyEditor) error {
+			if err := editor.AddRule(policy.TargetsRoleName, "protect-feature", []*tuf.Key{targetsPubKey}, []string{"git:refs/heads/feature"}, 1); err != nil {
+				return err
+			}
+			return editor.AddRule(policy.TargetsRoleName, "protect-feature", []*tuf.Key{targetsPubKey}, []string{"git:refs/heads/feature"}, 1)
+		})
+		assert.ErrorIs(t, err, policy.ErrDuplicatedRuleName)
+	})
+
+	t.Run("no changes", func(t *testing.T) {
+		r := createTestRepositoryWithPolicy(t, "")
+
+		err := r.EditPolicy(testCtx, targetsSigner, "", false, func(*PolicyEditor) error { return nil })
+		assert.ErrorIs(t, err, ErrNoPolicyEdits)
+	})
+}
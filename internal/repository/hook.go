@@ -3,14 +3,25 @@
 package repository
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
 	"io/fs"
 	"log/slog"
 	"os"
-	"path"
+	"path/filepath"
+
+	"github.com/go-git/go-git/v5/storage/filesystem"
 )
 
+// gittufHookMarker is included in all hooks installed by gittuf. It is used to
+// distinguish hooks installed by gittuf from those written by the user.
+const gittufHookMarker = "# This hook was installed by gittuf."
+
+// chainedHookSuffix is appended to the name of an existing hook when it is
+// chained to a gittuf hook.
+const chainedHookSuffix = ".chained"
+
 type ErrHookExists struct {
 	HookType HookType
 }
@@ -21,33 +32,126 @@ func (e *ErrHookExists) Error() string {
 
 type HookType string
 
-var HookPrePush = HookType("pre-push")
+var (
+	HookPreCommit = HookType("pre-commit")
+	HookPostMerge = HookType("post-merge")
+	HookPrePush   = HookType("pre-push")
+
+	// HookPreReceive is installed on Git servers to enforce gittuf policies
+	// for pushes.
+	HookPreReceive = HookType("pre-receive")
+)
+
+// HookTypes returns the client-side hooks that are installed by gittuf by
+// default.
+func HookTypes() []HookType {
+	return []HookType{HookPreCommit, HookPostMerge, HookPrePush}
+}
+
+// InstallHook installs gittuf's hook of the specified type. If a hook that was
+// not installed by gittuf already exists, it is renamed and chained, i.e., it
+// is invoked by the gittuf hook before gittuf's checks. If force is set, the
+// existing hook is overwritten instead. A hook previously installed by gittuf
+// is always overwritten. InstallHook returns true if an existing hook was
+// chained.
+func (r *Repository) InstallHook(hookType HookType, force bool) (bool, error) {
+	content, ok := hookTemplates[hookType]
+	if !ok {
+		return false, fmt.Errorf("unknown hook '%s'", hookType)
+	}
+
+	hookFolder, err := r.getHooksFolder()
+	if err != nil {
+		return false, err
+	}
+	if err := os.MkdirAll(hookFolder, 0o750); err != nil {
+		return false, fmt.Errorf("making sure folder exist: %w", err)
+	}
+
+	hookFile := filepath.Join(hookFolder, string(hookType))
+	chainedHookFile := hookFile + chainedHookSuffix
+
+	existingContent, err := os.ReadFile(hookFile)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return false, fmt.Errorf("reading existing hook '%s': %w", hookFile, err)
+	}
+
+	chained := false
+	if existingContent != nil && !bytes.Contains(existingContent, []byte(gittufHookMarker)) && !force {
+		slog.Debug(fmt.Sprintf("Chaining existing '%s' hook...", hookType))
+		chainedHookExists, err := doesFileExist(chainedHookFile)
+		if err != nil {
+			return false, fmt.Errorf("checking if chained hook '%s' exists: %w", chainedHookFile, err)
+		}
+		if chainedHookExists {
+			// We don't want to clobber a previously chained hook
+			return false, &ErrHookExists{HookType: hookType}
+		}
+
+		if err := os.Rename(hookFile, chainedHookFile); err != nil {
+			return false, fmt.Errorf("chaining existing %s hook: %w", hookType, err)
+		}
+		chained = true
+	}
+
+	slog.Debug(fmt.Sprintf("Writing '%s' hook...", hookType))
+	if err := os.WriteFile(hookFile, content, 0o700); err != nil { // nolint:gosec
+		return false, fmt.Errorf("writing %s hook: %w", hookType, err)
+	}
+
+	return chained, nil
+}
+
+// getHooksFolder returns the path to the repository's hooks folder, respecting
+// core.hooksPath if it is set.
+func (r *Repository) getHooksFolder() (string, error) {
+	storage, isFilesystem := r.r.Storer.(*filesystem.Storage)
+	if !isFilesystem {
+		return "", fmt.Errorf("repository is not on disk, can't install hooks")
+	}
+	gitDir := storage.Filesystem().Root()
+
+	config, err := r.r.Config()
+	if err != nil {
+		return "", err
+	}
+
+	hooksPath := config.Raw.Section("core").Option("hooksPath")
+	if hooksPath == "" {
+		return filepath.Join(gitDir, "hooks"), nil
+	}
+
+	if filepath.IsAbs(hooksPath) {
+		return hooksPath, nil
+	}
+
+	// Relative hooks paths are relative to the root of the worktree for
+	// non-bare repositories, and the Git directory for bare repositories
+	if config.Core.IsBare {
+		return filepath.Join(gitDir, hooksPath), nil
+	}
+
+	tree, err := r.r.Worktree()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(tree.Filesystem.Root(), hooksPath), nil
+}
 
 // UpdateHook updates a git hook in the repositorie's .git/hooks folder.
 // Existing hook files are not overwritten, unless force flag is set.
 func (r *Repository) UpdateHook(hookType HookType, content []byte, force bool) error {
-	// TODO: rely on go-git to find .git folder, once
-	// https://github.com/go-git/go-git/issues/977 is available.
-	// Note, until then gittuf does not support separate git dir.
-
 	slog.Debug("Adding gittuf hooks...")
 
-	slog.Debug("Loading repository worktree...")
-	tree, err := r.r.Worktree()
+	hookFolder, err := r.getHooksFolder()
 	if err != nil {
-		return fmt.Errorf("reading worktree: %w", err)
-	}
-	if tree == nil {
-		return fmt.Errorf("worktree is nil, can't update hooks")
+		return err
 	}
-
-	repoRoot := tree.Filesystem.Root()
-	hookFolder := path.Join(repoRoot, ".git", "hooks")
 	if err := os.MkdirAll(hookFolder, 0o750); err != nil {
 		return fmt.Errorf("making sure folder exist: %w", err)
 	}
 
-	hookFile := path.Join(hookFolder, string(hookType))
+	hookFile := filepath.Join(hookFolder, string(hookType))
 	hookExists, err := doesFileExist(hookFile)
 	if err != nil {
 		return fmt.Errorf("checking if hookFile '%s' exists: %w", hookFile, err)
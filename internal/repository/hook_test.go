@@ -72,3 +72,129 @@ func TestUpdatePrePushHook(t *testing.T) {
 		assert.Equal(t, []byte("new hook script"), content)
 	})
 }
+
+func TestInstallHook(t *testing.T) {
+	t.Run("install hooks", func(t *testing.T) {
+		tmpDir := t.TempDir()
+
+		repo, err := git.PlainInit(tmpDir, false)
+		require.NoError(t, err)
+		r := &Repository{r: repo}
+
+		for _, hookType := range HookTypes() {
+			chained, err := r.InstallHook(hookType, false)
+			require.NoError(t, err)
+			assert.False(t, chained)
+
+			content, err := os.ReadFile(path.Join(tmpDir, ".git", "hooks", string(hookType)))
+			require.NoError(t, err)
+			assert.Equal(t, hookTemplates[hookType], content)
+		}
+	})
+
+	t.Run("reinstall gittuf hook", func(t *testing.T) {
+		tmpDir := t.TempDir()
+
+		repo, err := git.PlainInit(tmpDir, false)
+		require.NoError(t, err)
+		r := &Repository{r: repo}
+
+		hookFile := path.Join(tmpDir, ".git", "hooks", "pre-push")
+		err = os.Mkdir(path.Dir(hookFile), 0o750)
+		require.NoError(t, err)
+		err = os.WriteFile(hookFile, []byte("#!/bin/sh\n"+gittufHookMarker+"\nold gittuf hook\n"), 0o700) // nolint:gosec
+		require.NoError(t, err)
+
+		chained, err := r.InstallHook(HookPrePush, false)
+		require.NoError(t, err)
+		assert.False(t, chained)
+
+		content, err := os.ReadFile(hookFile)
+		require.NoError(t, err)
+		assert.Equal(t, hookTemplates[HookPrePush], content)
+
+		_, err = os.Stat(hookFile + chainedHookSuffix)
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("chain existing hook", func(t *testing.T) {
+		tmpDir := t.TempDir()
+
+		repo, err := git.PlainInit(tmpDir, false)
+		require.NoError(t, err)
+		r := &Repository{r: repo}
+
+		hookFile := path.Join(tmpDir, ".git", "hooks", "pre-push")
+		err = os.Mkdir(path.Dir(hookFile), 0o750)
+		require.NoError(t, err)
+		err = os.WriteFile(hookFile, []byte("existing hook script"), 0o700) // nolint:gosec
+		require.NoError(t, err)
+
+		chained, err := r.InstallHook(HookPrePush, false)
+		require.NoError(t, err)
+		assert.True(t, chained)
+
+		content, err := os.ReadFile(hookFile)
+		require.NoError(t, err)
+		assert.Equal(t, hookTemplates[HookPrePush], content)
+
+		content, err = os.ReadFile(hookFile + chainedHookSuffix)
+		require.NoError(t, err)
+		assert.Equal(t, []byte("existing hook script"), content)
+
+		// Replacing gittuf's hook again must not clobber the chained hook
+		err = os.WriteFile(hookFile, []byte("another hook script"), 0o700) // nolint:gosec
+		require.NoError(t, err)
+
+		_, err = r.InstallHook(HookPrePush, false)
+		var hookErr *ErrHookExists
+		if assert.ErrorAs(t, err, &hookErr) {
+			assert.Equal(t, HookPrePush, hookErr.HookType)
+		}
+	})
+
+	t.Run("force overwrite existing hook", func(t *testing.T) {
+		tmpDir := t.TempDir()
+
+		repo, err := git.PlainInit(tmpDir, false)
+		require.NoError(t, err)
+		r := &Repository{r: repo}
+
+		hookFile := path.Join(tmpDir, ".git", "hooks", "pre-commit")
+		err = os.Mkdir(path.Dir(hookFile), 0o750)
+		require.NoError(t, err)
+		err = os.WriteFile(hookFile, []byte("existing hook script"), 0o700) // nolint:gosec
+		require.NoError(t, err)
+
+		chained, err := r.InstallHook(HookPreCommit, true)
+		require.NoError(t, err)
+		assert.False(t, chained)
+
+		content, err := os.ReadFile(hookFile)
+		require.NoError(t, err)
+		assert.Equal(t, hookTemplates[HookPreCommit], content)
+
+		_, err = os.Stat(hookFile + chainedHookSuffix)
+		assert.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("core.hooksPath", func(t *testing.T) {
+		tmpDir := t.TempDir()
+
+		repo, err := git.PlainInit(tmpDir, false)
+		require.NoError(t, err)
+		r := &Repository{r: repo}
+
+		config, err := repo.Config()
+		require.NoError(t, err)
+		config.Raw.Section("core").SetOption("hooksPath", ".githooks")
+		require.NoError(t, repo.SetConfig(config))
+
+		_, err = r.InstallHook(HookPostMerge, false)
+		require.NoError(t, err)
+
+		content, err := os.ReadFile(path.Join(tmpDir, ".githooks", "post-merge"))
+		require.NoError(t, err)
+		assert.Equal(t, hookTemplates[HookPostMerge], content)
+	})
+}
@@ -0,0 +1,122 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+// hookTemplates contains the hooks installed by gittuf. Each hook invokes the
+// hook it replaced, if one was chained, before running gittuf.
+var hookTemplates = map[HookType][]byte{
+	HookPreCommit: []byte(`#!/bin/sh
+` + gittufHookMarker + `
+set -e
+
+if [ -x "$0` + chainedHookSuffix + `" ]
+then
+    "$0` + chainedHookSuffix + `" "$@"
+fi
+
+if [ "$(git config --bool commit.gpgsign)" != "true" ]
+then
+    echo "gittuf: commit signing is not enabled, the commit may not meet the repository's policy."
+    echo "gittuf: set commit.gpgsign to sign commits automatically."
+fi
+`),
+
+	HookPostMerge: []byte(`#!/bin/sh
+` + gittufHookMarker + `
+set -e
+
+if [ -x "$0` + chainedHookSuffix + `" ]
+then
+    "$0` + chainedHookSuffix + `" "$@"
+fi
+
+if ! command -v gittuf > /dev/null
+then
+    echo "gittuf could not be found"
+    echo "Download from: https://github.com/gittuf/gittuf/releases/latest"
+    exit 1
+fi
+
+ref=$(git symbolic-ref -q HEAD) || exit 0
+
+# The merge has already happened, so verification failures are reported but
+# cannot be prevented.
+if ! gittuf verify-ref --latest-only "${ref}"
+then
+    echo "gittuf: unable to verify ${ref} after merge."
+fi
+`),
+
+	HookPrePush: []byte(`#!/bin/sh
+` + gittufHookMarker + `
+set -e
+
+remote="$1"
+url="$2"
+
+# The refs being pushed are read from stdin, and must also be passed to the
+# chained hook.
+input=$(cat)
+
+if [ -x "$0` + chainedHookSuffix + `" ]
+then
+    printf '%s\n' "${input}" | "$0` + chainedHookSuffix + `" "$@"
+fi
+
+if ! command -v gittuf > /dev/null
+then
+    echo "gittuf could not be found"
+    echo "Download from: https://github.com/gittuf/gittuf/releases/latest"
+    exit 1
+fi
+
+echo "Pulling RSL from ${remote}."
+gittuf rsl remote pull "${remote}"
+
+zero=$(git hash-object --stdin < /dev/null | tr '0-9a-f' '0')
+printf '%s\n' "${input}" | while read -r local_ref local_oid remote_ref remote_oid
+do
+    if [ -z "${local_ref}" ]
+    then
+        continue
+    fi
+
+    if [ "${local_oid}" = "${zero}" ]
+    then
+        echo "Creating new RSL record for deletion of ${remote_ref}."
+        gittuf rsl record --delete "${remote_ref}"
+        continue
+    fi
+
+    echo "Creating new RSL record for ${local_ref}."
+    gittuf rsl record "${local_ref}"
+    echo "Verifying ${local_ref}."
+    gittuf verify-ref --latest-only "${local_ref}"
+done
+
+echo "Pushing RSL to ${remote}."
+gittuf rsl remote push "${remote}"
+`),
+
+	HookPreReceive: []byte(`#!/bin/sh
+` + gittufHookMarker + `
+set -e
+
+# The reference updates are read from stdin, and must also be passed to the
+# chained hook.
+input=$(cat)
+
+if [ -x "$0` + chainedHookSuffix + `" ]
+then
+    printf '%s\n' "${input}" | "$0` + chainedHookSuffix + `" "$@"
+fi
+
+if ! command -v gittuf > /dev/null
+then
+    echo "gittuf could not be found, rejecting push"
+    exit 1
+fi
+
+printf '%s\n' "${input}" | gittuf enforce --pre-receive
+`),
+}
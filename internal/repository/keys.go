@@ -0,0 +1,222 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"log/slog"
+
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/signerverifier/dsse"
+	"github.com/gittuf/gittuf/internal/tuf"
+	sslibdsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
+)
+
+// RotateKey is the interface for the user to replace a key with a new one
+// across the root of trust and all policy files that trust it. Every modified
+// piece of metadata, as well as every policy file that must now be signed by
+// the new key, is signed using each of the provided signers and the changes
+// are recorded in a single policy commit. When the root of trust is modified,
+// at least one of the signers must be trusted for the Root role.
+func (r *Repository) RotateKey(ctx context.Context, signers []sslibdsse.SignerVerifier, oldKeyID string, newKey *tuf.Key, signCommit bool) error {
+	signerKeyIDs := make([]string, 0, len(signers))
+	for _, signer := range signers {
+		keyID, err := signer.KeyID()
+		if err != nil {
+			return err
+		}
+		signerKeyIDs = append(signerKeyIDs, keyID)
+	}
+
+	state, err := r.loadStateForUpdate(ctx)
+	if err != nil {
+		return err
+	}
+
+	modified := false
+
+	slog.Debug("Loading current root metadata...")
+	rootMetadata, err := state.GetRootMetadata()
+	if err != nil {
+		return err
+	}
+	if rootMetadata.IsKeyRevoked(newKey.KeyID) {
+		return policy.ErrKeyAlreadyRevoked
+	}
+
+	authorized := false
+	for _, keyID := range signerKeyIDs {
+		if isKeyAuthorized(rootMetadata.Roles[policy.RootRoleName].KeyIDs, keyID) {
+			authorized = true
+			break
+		}
+	}
+
+	slog.Debug(fmt.Sprintf("Rotating key '%s' in root...", oldKeyID))
+	rootMetadata, rotated := policy.RotateRootMetadataKey(rootMetadata, oldKeyID, newKey)
+	if rotated {
+		if !authorized {
+			return ErrUnauthorizedKey
+		}
+
+		newRootPublicKeys := []*tuf.Key{}
+		for _, key := range state.RootPublicKeys {
+			if key.KeyID != oldKeyID && key.KeyID != newKey.KeyID {
+				newRootPublicKeys = append(newRootPublicKeys, key)
+			}
+		}
+		if isKeyAuthorized(rootMetadata.Roles[policy.RootRoleName].KeyIDs, newKey.KeyID) {
+			newRootPublicKeys = append(newRootPublicKeys, newKey)
+		}
+		state.RootPublicKeys = newRootPublicKeys
+
+		rootMetadata.SetVersion(rootMetadata.Version + 1)
+		rootMetadataBytes, err := tuf.EncodeCanonical(rootMetadata)
+		if err != nil {
+			return err
+		}
+
+		env := state.RootEnvelope
+		env.Signatures = []sslibdsse.Signature{}
+		env.Payload = base64.StdEncoding.EncodeToString(rootMetadataBytes)
+
+		slog.Debug("Signing updated root metadata...")
+		state.RootEnvelope, err = signEnvelope(ctx, env, signers)
+		if err != nil {
+			return err
+		}
+
+		modified = true
+	}
+
+	// Policy files signed using the old key must be re-signed even if they do
+	// not reference the old key themselves
+	resignRoleNames := map[string]bool{}
+	if rotated && isKeyAuthorized(rootMetadata.Roles[policy.TargetsRoleName].KeyIDs, newKey.KeyID) {
+		resignRoleNames[policy.TargetsRoleName] = true
+	}
+
+	targetsRoleNames := []string{}
+	if state.TargetsEnvelope != nil {
+		targetsRoleNames = append(targetsRoleNames, policy.TargetsRoleName)
+	}
+	for roleName := range state.DelegationEnvelopes {
+		targetsRoleNames = append(targetsRoleNames, roleName)
+	}
+
+	rotatedTargetsMetadata := map[string]*tuf.TargetsMetadata{}
+	for _, roleName := range targetsRoleNames {
+		targetsMetadata, err := state.GetTargetsMetadata(roleName)
+		if err != nil {
+			return err
+		}
+
+		if targetsMetadata.Delegations != nil {
+			for _, delegation := range targetsMetadata.Delegations.Roles {
+				if isKeyAuthorized(delegation.KeyIDs, oldKeyID) {
+					resignRoleNames[delegation.Name] = true
+				}
+			}
+		}
+
+		slog.Debug(fmt.Sprintf("Rotating key '%s' in policy file '%s'...", oldKeyID, roleName))
+		targetsMetadata, rotated := policy.RotateTargetsMetadataKey(targetsMetadata, oldKeyID, newKey)
+		if rotated {
+			rotatedTargetsMetadata[roleName] = targetsMetadata
+		}
+	}
+
+	for _, roleName := range targetsRoleNames {
+		targetsMetadata, rotated := rotatedTargetsMetadata[roleName]
+		if !rotated {
+			if !resignRoleNames[roleName] {
+				continue
+			}
+
+			targetsMetadata, err = state.GetTargetsMetadata(roleName)
+			if err != nil {
+				return err
+			}
+		}
+
+		targetsMetadata.SetVersion(targetsMetadata.Version + 1)
+
+		env, err := dsse.CreateEnvelope(targetsMetadata)
+		if err != nil {
+			return err
+		}
+
+		slog.Debug(fmt.Sprintf("Signing updated policy file '%s'...", roleName))
+		env, err = signEnvelope(ctx, env, signers)
+		if err != nil {
+			return err
+		}
+
+		if roleName == policy.TargetsRoleName {
+			state.TargetsEnvelope = env
+		} else {
+			state.DelegationEnvelopes[roleName] = env
+		}
+
+		modified = true
+	}
+
+	if !modified {
+		return policy.ErrKeyNotInPolicy
+	}
+
+	commitMessage := fmt.Sprintf("Rotate key '%s' to '%s'", oldKeyID, newKey.KeyID)
+	return r.commitPolicyUpdate(ctx, state, commitMessage, signCommit)
+}
+
+// RevokeKey is the interface for the user to revoke a key. The key is removed
+// from all roles in the root of trust and recorded as revoked along with the
+// reason, so that it is no longer trusted by any rule that lists it.
+func (r *Repository) RevokeKey(ctx context.Context, signer sslibdsse.SignerVerifier, keyID, reason string, signCommit bool) error {
+	rootKeyID, err := signer.KeyID()
+	if err != nil {
+		return err
+	}
+
+	state, err := r.loadStateForUpdate(ctx)
+	if err != nil {
+		return err
+	}
+
+	rootMetadata, err := r.loadRootMetadata(state, rootKeyID)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug(fmt.Sprintf("Revoking key '%s'...", keyID))
+	rootMetadata, err = policy.RevokeKey(rootMetadata, keyID, reason)
+	if err != nil {
+		return err
+	}
+
+	newRootPublicKeys := []*tuf.Key{}
+	for _, key := range state.RootPublicKeys {
+		if key.KeyID != keyID {
+			newRootPublicKeys = append(newRootPublicKeys, key)
+		}
+	}
+	state.RootPublicKeys = newRootPublicKeys
+
+	commitMessage := fmt.Sprintf("Revoke key '%s'\n\nReason: %s", keyID, reason)
+	return r.updateRootMetadata(ctx, state, signer, rootMetadata, commitMessage, signCommit)
+}
+
+// signEnvelope signs env using each of the signers.
+func signEnvelope(ctx context.Context, env *sslibdsse.Envelope, signers []sslibdsse.SignerVerifier) (*sslibdsse.Envelope, error) {
+	var err error
+	for _, signer := range signers {
+		env, err = dsse.SignEnvelope(ctx, env, signer)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return env, nil
+}
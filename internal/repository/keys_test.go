@@ -0,0 +1,190 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/signerverifier"
+	"github.com/gittuf/gittuf/internal/signerverifier/dsse"
+	"github.com/gittuf/gittuf/internal/signerverifier/gpg"
+	artifacts "github.com/gittuf/gittuf/internal/testartifacts"
+	"github.com/gittuf/gittuf/internal/tuf"
+	sslibdsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRotateKey(t *testing.T) {
+	r := createTestRepositoryWithPolicy(t, "")
+
+	rootSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(rootKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(targetsKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+	newSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(artifacts.SSLibKey3Private) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targetsKey, err := tuf.LoadKeyFromBytes(targetsPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	newKey, err := tuf.LoadKeyFromBytes(artifacts.SSLibKey3Public)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("key not in policy", func(t *testing.T) {
+		err := r.RotateKey(testCtx, []sslibdsse.SignerVerifier{rootSigner}, newKey.KeyID, targetsKey, false)
+		assert.ErrorIs(t, err, policy.ErrKeyNotInPolicy)
+	})
+
+	t.Run("unauthorized signer", func(t *testing.T) {
+		err := r.RotateKey(testCtx, []sslibdsse.SignerVerifier{targetsSigner, newSigner}, targetsKey.KeyID, newKey, false)
+		assert.ErrorIs(t, err, ErrUnauthorizedKey)
+	})
+
+	t.Run("rotate policy key", func(t *testing.T) {
+		err := r.RotateKey(testCtx, []sslibdsse.SignerVerifier{rootSigner, newSigner}, targetsKey.KeyID, newKey, false)
+		assert.Nil(t, err)
+
+		state, err := policy.LoadCurrentState(testCtx, r.r)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		rootMetadata, err := state.GetRootMetadata()
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, 3, rootMetadata.Version)
+		assert.NotContains(t, rootMetadata.Keys, targetsKey.KeyID)
+		assert.Equal(t, []string{newKey.KeyID}, rootMetadata.Roles[policy.TargetsRoleName].KeyIDs)
+
+		targetsMetadata, err := state.GetTargetsMetadata(policy.TargetsRoleName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, 3, targetsMetadata.Version)
+		assert.Equal(t, "protect-main", targetsMetadata.Delegations.Roles[0].Name)
+
+		err = dsse.VerifyEnvelope(testCtx, state.TargetsEnvelope, []sslibdsse.Verifier{newSigner}, 1)
+		assert.Nil(t, err)
+	})
+
+	t.Run("rotate rule key", func(t *testing.T) {
+		state, err := policy.LoadCurrentState(testCtx, r.r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsMetadata, err := state.GetTargetsMetadata(policy.TargetsRoleName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		oldKeyID := targetsMetadata.Delegations.Roles[0].KeyIDs[0]
+
+		err = r.RotateKey(testCtx, []sslibdsse.SignerVerifier{newSigner}, oldKeyID, targetsKey, false)
+		assert.Nil(t, err)
+
+		state, err = policy.LoadCurrentState(testCtx, r.r)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		rootMetadata, err := state.GetRootMetadata()
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, 3, rootMetadata.Version)
+
+		targetsMetadata, err = state.GetTargetsMetadata(policy.TargetsRoleName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, 4, targetsMetadata.Version)
+		assert.NotContains(t, targetsMetadata.Delegations.Keys, oldKeyID)
+		assert.Equal(t, []string{targetsKey.KeyID}, targetsMetadata.Delegations.Roles[0].KeyIDs)
+	})
+}
+
+func TestRevokeKey(t *testing.T) {
+	r := createTestRepositoryWithPolicy(t, "")
+
+	rootSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(rootKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(targetsKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targetsKey, err := tuf.LoadKeyFromBytes(targetsPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	newTargetsKey, err := tuf.LoadKeyFromBytes(artifacts.SSLibKey3Public)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = r.RevokeKey(testCtx, targetsSigner, targetsKey.KeyID, "compromised", false)
+	assert.ErrorIs(t, err, ErrUnauthorizedKey)
+
+	// The only policy key cannot be revoked
+	err = r.RevokeKey(testCtx, rootSigner, targetsKey.KeyID, "compromised", false)
+	assert.ErrorIs(t, err, policy.ErrCannotMeetThreshold)
+
+	gpgKey, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = r.RevokeKey(testCtx, rootSigner, gpgKey.KeyID, "compromised", false)
+	assert.Nil(t, err)
+
+	state, err := policy.LoadCurrentState(testCtx, r.r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootMetadata, err := state.GetRootMetadata()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 3, rootMetadata.Version)
+	assert.True(t, rootMetadata.IsKeyRevoked(gpgKey.KeyID))
+	assert.Equal(t, "compromised", rootMetadata.RevokedKeys[gpgKey.KeyID].Reason)
+
+	verifiers, err := state.FindVerifiersForPath("git:refs/heads/main")
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 1, len(verifiers))
+	assert.Empty(t, verifiers[0].Keys())
+
+	err = r.RevokeKey(testCtx, rootSigner, gpgKey.KeyID, "compromised", false)
+	assert.ErrorIs(t, err, policy.ErrKeyAlreadyRevoked)
+
+	// The policy file must be signed using a key that remains trusted before
+	// the policy key can be revoked
+	err = r.AddTopLevelTargetsKey(testCtx, rootSigner, newTargetsKey, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	newTargetsSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(artifacts.SSLibKey3Private) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = r.SignTargets(testCtx, newTargetsSigner, policy.TargetsRoleName, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = r.RevokeKey(testCtx, rootSigner, targetsKey.KeyID, "compromised", false)
+	assert.Nil(t, err)
+}
@@ -0,0 +1,98 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/signerverifier/dsse"
+	"github.com/gittuf/gittuf/internal/tuf"
+	sslibdsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
+)
+
+// GetPolicySchemaVersions returns the schema version of the root of trust and
+// each policy file in the staged policy if it exists, or the current policy
+// otherwise.
+func (r *Repository) GetPolicySchemaVersions(ctx context.Context) (map[string]int, error) {
+	state, err := r.loadStateForUpdate(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return state.SchemaVersions()
+}
+
+// MigratePolicy upgrades the root of trust and policy files that were created
+// using an older metadata schema to the current schema version, and returns
+// the names of the migrated roles. Migrated policy files are signed using the
+// signer, as is the root of trust if the signer is a root key holder. If the
+// migrated metadata is not signed by a threshold of its trusted keys, the
+// changes are staged until other key holders sign it.
+func (r *Repository) MigratePolicy(ctx context.Context, signer sslibdsse.SignerVerifier, signCommit bool) ([]string, error) {
+	keyID, err := signer.KeyID()
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := r.loadStateForUpdate(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	migrated, err := state.MigrateMetadata()
+	if err != nil {
+		return nil, err
+	}
+	if len(migrated) == 0 {
+		slog.Debug("Policy metadata is already at the current schema version")
+		return migrated, nil
+	}
+
+	for _, roleName := range migrated {
+		switch roleName {
+		case policy.RootRoleName:
+			rootMetadata, err := state.GetRootMetadata()
+			if err != nil {
+				return nil, err
+			}
+			if !isKeyAuthorized(rootMetadata.Roles[policy.RootRoleName].KeyIDs, keyID) {
+				slog.Debug(fmt.Sprintf("Not signing migrated root metadata as '%s' is not a root key", keyID))
+				continue
+			}
+
+			slog.Debug("Signing migrated root metadata...")
+			env, err := dsse.SignEnvelope(ctx, state.RootEnvelope, signer)
+			if err != nil {
+				return nil, err
+			}
+			state.RootEnvelope = env
+
+		case policy.TargetsRoleName:
+			slog.Debug(fmt.Sprintf("Signing migrated policy '%s'...", roleName))
+			env, err := dsse.SignEnvelope(ctx, state.TargetsEnvelope, signer)
+			if err != nil {
+				return nil, err
+			}
+			state.TargetsEnvelope = env
+
+		default:
+			slog.Debug(fmt.Sprintf("Signing migrated policy '%s'...", roleName))
+			env, err := dsse.SignEnvelope(ctx, state.DelegationEnvelopes[roleName], signer)
+			if err != nil {
+				return nil, err
+			}
+			state.DelegationEnvelopes[roleName] = env
+		}
+	}
+
+	commitMessage := fmt.Sprintf("Migrate policy metadata to schema version %d\n%s", tuf.SchemaVersion, strings.Join(migrated, "\n"))
+	if err := r.commitPolicyUpdate(ctx, state, commitMessage, signCommit); err != nil {
+		return nil, err
+	}
+
+	return migrated, nil
+}
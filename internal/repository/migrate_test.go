@@ -0,0 +1,103 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/signerverifier"
+	"github.com/gittuf/gittuf/internal/signerverifier/dsse"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigratePolicy(t *testing.T) {
+	rootSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(rootKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(targetsKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := createTestRepositoryWithPolicy(t, "")
+
+	// Rewrite the policy's metadata as if it was created before schema
+	// versions were recorded
+	state, err := policy.LoadCurrentState(testCtx, r.r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootMetadata, err := state.GetRootMetadata()
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootMetadata.SchemaVersion = 0
+	rootMetadata.SetVersion(rootMetadata.Version + 1)
+	rootEnv, err := dsse.CreateEnvelope(rootMetadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	state.RootEnvelope, err = dsse.SignEnvelope(testCtx, rootEnv, rootSigner)
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsMetadata, err := state.GetTargetsMetadata(policy.TargetsRoleName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsMetadata.SchemaVersion = 0
+	targetsMetadata.SetVersion(targetsMetadata.Version + 1)
+	targetsEnv, err := dsse.CreateEnvelope(targetsMetadata)
+	if err != nil {
+		t.Fatal(err)
+	}
+	state.TargetsEnvelope, err = dsse.SignEnvelope(testCtx, targetsEnv, targetsSigner)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.commitPolicyUpdate(testCtx, state, "Legacy policy", false); err != nil {
+		t.Fatal(err)
+	}
+
+	schemaVersions, err := r.GetPolicySchemaVersions(testCtx)
+	assert.Nil(t, err)
+	assert.Equal(t, map[string]int{policy.RootRoleName: 0, policy.TargetsRoleName: 0}, schemaVersions)
+
+	// The targets key cannot sign the root of trust, so the migration is
+	// staged
+	migrated, err := r.MigratePolicy(testCtx, targetsSigner, false)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{policy.RootRoleName, policy.TargetsRoleName}, migrated)
+
+	stagedState, err := policy.LoadStagedState(testCtx, r.r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Empty(t, stagedState.RootEnvelope.Signatures)
+	schemaVersions, err = stagedState.SchemaVersions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, map[string]int{policy.RootRoleName: tuf.SchemaVersion, policy.TargetsRoleName: tuf.SchemaVersion}, schemaVersions)
+
+	err = r.SignRoot(testCtx, rootSigner, false)
+	assert.Nil(t, err)
+
+	state, err = policy.LoadCurrentState(testCtx, r.r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	schemaVersions, err = state.SchemaVersions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, map[string]int{policy.RootRoleName: tuf.SchemaVersion, policy.TargetsRoleName: tuf.SchemaVersion}, schemaVersions)
+
+	// Nothing left to migrate
+	migrated, err = r.MigratePolicy(testCtx, rootSigner, false)
+	assert.Nil(t, err)
+	assert.Empty(t, migrated)
+}
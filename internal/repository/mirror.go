@@ -0,0 +1,257 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+var ErrMirrorInconsistent = errors.New("mirror is inconsistent with upstream's RSL")
+
+// MirrorRSLStatus describes how a mirror's RSL relates to the upstream's RSL.
+type MirrorRSLStatus string
+
+const (
+	MirrorRSLInSync   MirrorRSLStatus = "in-sync"
+	MirrorRSLBehind   MirrorRSLStatus = "behind"
+	MirrorRSLAhead    MirrorRSLStatus = "ahead"
+	MirrorRSLDiverged MirrorRSLStatus = "diverged"
+	MirrorRSLMissing  MirrorRSLStatus = "missing"
+)
+
+// MirrorRefChange describes how a reference on a mirror differs from the
+// state recorded for it in the upstream's RSL.
+type MirrorRefChange string
+
+const (
+	// MirrorRefAdded indicates the mirror has a reference that the upstream
+	// does not have.
+	MirrorRefAdded MirrorRefChange = "added"
+
+	// MirrorRefRemoved indicates the mirror does not have a reference
+	// recorded in the upstream's RSL.
+	MirrorRefRemoved MirrorRefChange = "removed"
+
+	// MirrorRefRewound indicates the mirror's reference points to a target
+	// recorded in an earlier entry for the reference in the upstream's RSL.
+	MirrorRefRewound MirrorRefChange = "rewound"
+
+	// MirrorRefModified indicates the mirror's reference points to a target
+	// never recorded for the reference in the upstream's RSL.
+	MirrorRefModified MirrorRefChange = "modified"
+)
+
+// MirrorRefFinding records a reference on a mirror that is inconsistent with
+// the upstream's RSL. ExpectedTargetID is the target recorded in the latest
+// entry for the reference in the upstream's RSL, and is empty if the reference
+// is not recorded or was deleted. MirrorTargetID is empty if the mirror does
+// not have the reference.
+type MirrorRefFinding struct {
+	RefName          string          `json:"ref"`
+	Change           MirrorRefChange `json:"change"`
+	ExpectedTargetID string          `json:"expected_target_id,omitempty"`
+	MirrorTargetID   string          `json:"mirror_target_id,omitempty"`
+}
+
+// MirrorReport is the result of comparing a mirror with the upstream's RSL.
+type MirrorReport struct {
+	UpstreamRSLTip string          `json:"upstream_rsl_tip"`
+	MirrorRSLTip   string          `json:"mirror_rsl_tip,omitempty"`
+	RSLStatus      MirrorRSLStatus `json:"rsl_status"`
+
+	// EntriesBehind is the number of entries in the upstream's RSL that are
+	// not in the mirror's RSL, and is only set if the mirror's RSL is behind.
+	EntriesBehind int `json:"entries_behind,omitempty"`
+
+	Refs []*MirrorRefFinding `json:"refs"`
+}
+
+// IsConsistent returns true if the mirror's RSL and references match the
+// upstream's RSL.
+func (m *MirrorReport) IsConsistent() bool {
+	return m.RSLStatus == MirrorRSLInSync && len(m.Refs) == 0
+}
+
+// VerifyMirror compares the RSL and references of the mirror remote with the
+// RSL of the authoritative upstream remote. The mirror's RSL must match the
+// upstream's RSL, and each reference recorded in the upstream's RSL must point
+// to the target recorded in its latest entry, which detects mirrors that have
+// rewound, removed, or modified references. Branches and tags on the mirror
+// that the upstream neither records nor advertises are reported as added.
+// Other references, such as those a hosting service adds for pull requests,
+// are not checked for additions. Only the remotes' RSLs are fetched, into
+// remote tracking references, and the local references are not modified.
+func (r *Repository) VerifyMirror(ctx context.Context, upstreamName, mirrorName string) (*MirrorReport, error) {
+	slog.Debug(fmt.Sprintf("Listing references of upstream '%s'...", upstreamName))
+	upstreamRefs, err := r.ListRemoteRefs(ctx, upstreamName)
+	if err != nil {
+		return nil, err
+	}
+	upstreamRSLTip, has := upstreamRefs[rsl.Ref]
+	if !has {
+		return nil, fmt.Errorf("%w: '%s'", ErrRemoteHasNoRSL, upstreamName)
+	}
+
+	slog.Debug(fmt.Sprintf("Listing references of mirror '%s'...", mirrorName))
+	mirrorRefs, err := r.ListRemoteRefs(ctx, mirrorName)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Debug(fmt.Sprintf("Fetching RSL from upstream '%s'...", upstreamName))
+	if err := r.fetchWatchedRefs(ctx, upstreamName, map[string]plumbing.Hash{rsl.Ref: upstreamRSLTip}); err != nil {
+		return nil, err
+	}
+
+	report := &MirrorReport{UpstreamRSLTip: upstreamRSLTip.String(), Refs: []*MirrorRefFinding{}}
+	if mirrorRSLTip, has := mirrorRefs[rsl.Ref]; has {
+		slog.Debug(fmt.Sprintf("Fetching RSL from mirror '%s'...", mirrorName))
+		if err := r.fetchWatchedRefs(ctx, mirrorName, map[string]plumbing.Hash{rsl.Ref: mirrorRSLTip}); err != nil {
+			return nil, err
+		}
+
+		report.MirrorRSLTip = mirrorRSLTip.String()
+		report.RSLStatus, report.EntriesBehind, err = r.compareRSLs(upstreamRSLTip, mirrorRSLTip)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		report.RSLStatus = MirrorRSLMissing
+	}
+
+	upstreamView, err := r.ViewRemote(upstreamRefs)
+	if err != nil {
+		return nil, err
+	}
+	entries, annotations, err := upstreamView.GetRSLReferenceEntries("", 0)
+	if err != nil {
+		return nil, err
+	}
+
+	// Entries are ordered from the latest to the earliest, so the first entry
+	// for a reference is its expected state and the rest are earlier states
+	latestEntries := map[string]*rsl.ReferenceEntry{}
+	earlierTargets := map[string]map[plumbing.Hash]bool{}
+	refNames := []string{}
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.RefName, rsl.GittufNamespacePrefix) || entry.SkippedBy(annotations[entry.ID]) {
+			continue
+		}
+
+		if _, has := latestEntries[entry.RefName]; !has {
+			latestEntries[entry.RefName] = entry
+			earlierTargets[entry.RefName] = map[plumbing.Hash]bool{}
+			refNames = append(refNames, entry.RefName)
+			continue
+		}
+		if !entry.IsDeletion() {
+			earlierTargets[entry.RefName][entry.TargetID] = true
+		}
+	}
+	sort.Strings(refNames)
+
+	for _, refName := range refNames {
+		entry := latestEntries[refName]
+		mirrorTip, onMirror := mirrorRefs[refName]
+
+		finding := &MirrorRefFinding{RefName: refName}
+		if !entry.IsDeletion() {
+			finding.ExpectedTargetID = entry.TargetID.String()
+		}
+		if onMirror {
+			finding.MirrorTargetID = mirrorTip.String()
+		}
+
+		switch {
+		case !onMirror && entry.IsDeletion():
+			continue
+		case !onMirror:
+			finding.Change = MirrorRefRemoved
+		case entry.IsDeletion():
+			finding.Change = MirrorRefAdded
+		case mirrorTip == entry.TargetID:
+			continue
+		case earlierTargets[refName][mirrorTip]:
+			finding.Change = MirrorRefRewound
+		default:
+			finding.Change = MirrorRefModified
+		}
+
+		slog.Debug(fmt.Sprintf("Reference '%s' on mirror is %s", refName, finding.Change))
+		report.Refs = append(report.Refs, finding)
+	}
+
+	addedRefNames := []string{}
+	for refName := range mirrorRefs {
+		if !strings.HasPrefix(refName, gitinterface.BranchRefPrefix) && !strings.HasPrefix(refName, gitinterface.TagRefPrefix) {
+			continue
+		}
+		if _, recorded := latestEntries[refName]; recorded {
+			continue
+		}
+		if _, onUpstream := upstreamRefs[refName]; onUpstream {
+			continue
+		}
+		addedRefNames = append(addedRefNames, refName)
+	}
+	sort.Strings(addedRefNames)
+	for _, refName := range addedRefNames {
+		slog.Debug(fmt.Sprintf("Reference '%s' on mirror is %s", refName, MirrorRefAdded))
+		report.Refs = append(report.Refs, &MirrorRefFinding{RefName: refName, Change: MirrorRefAdded, MirrorTargetID: mirrorRefs[refName].String()})
+	}
+
+	return report, nil
+}
+
+// compareRSLs returns how the mirror's RSL relates to the upstream's RSL, and
+// the number of entries the mirror's RSL is missing if it is behind.
+func (r *Repository) compareRSLs(upstreamRSLTip, mirrorRSLTip plumbing.Hash) (MirrorRSLStatus, int, error) {
+	if upstreamRSLTip == mirrorRSLTip {
+		return MirrorRSLInSync, 0, nil
+	}
+
+	upstreamCommit, err := gitinterface.GetCommit(r.r, upstreamRSLTip)
+	if err != nil {
+		return "", 0, err
+	}
+	mirrorCommit, err := gitinterface.GetCommit(r.r, mirrorRSLTip)
+	if err != nil {
+		return "", 0, err
+	}
+
+	behind, err := gitinterface.KnowsCommit(r.r, upstreamRSLTip, mirrorCommit)
+	if err != nil {
+		return "", 0, err
+	}
+	if behind {
+		entriesBehind := 0
+		entry, err := rsl.GetEntry(r.r, upstreamRSLTip)
+		for err == nil && entry.GetID() != mirrorRSLTip {
+			entriesBehind++
+			entry, err = rsl.GetParentForEntry(r.r, entry)
+		}
+		if err != nil {
+			return "", 0, err
+		}
+		return MirrorRSLBehind, entriesBehind, nil
+	}
+
+	ahead, err := gitinterface.KnowsCommit(r.r, mirrorRSLTip, upstreamCommit)
+	if err != nil {
+		return "", 0, err
+	}
+	if ahead {
+		return MirrorRSLAhead, 0, nil
+	}
+
+	return MirrorRSLDiverged, 0, nil
+}
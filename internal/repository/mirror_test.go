@@ -0,0 +1,150 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/common"
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyMirror(t *testing.T) {
+	upstreamName := "upstream"
+	mirrorName := "mirror"
+	refName := "refs/heads/main"
+
+	setup := func(t *testing.T) (*Repository, *git.Repository, []plumbing.Hash) {
+		t.Helper()
+
+		localRepo := createTestRepositoryWithPolicy(t, "")
+
+		remotes := map[string]*git.Repository{}
+		for _, remoteName := range []string{upstreamName, mirrorName} {
+			remoteTmpDir := t.TempDir()
+			remoteRepo, err := git.PlainInit(remoteTmpDir, true)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := localRepo.r.CreateRemote(&config.RemoteConfig{
+				Name: remoteName,
+				URLs: []string{remoteTmpDir},
+			}); err != nil {
+				t.Fatal(err)
+			}
+			remotes[remoteName] = remoteRepo
+		}
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, localRepo.r, refName, 1, gpgKeyBytes)
+		common.CreateTestRSLReferenceEntryCommit(t, localRepo.r, rsl.NewReferenceEntry(refName, commitIDs[0]), gpgKeyBytes)
+		for _, remoteName := range []string{upstreamName, mirrorName} {
+			if err := gitinterface.Push(testCtx, localRepo.r, remoteName, []string{refName, rsl.Ref, policy.PolicyRef}); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		return localRepo, remotes[mirrorName], commitIDs
+	}
+
+	t.Run("consistent mirror", func(t *testing.T) {
+		localRepo, _, _ := setup(t)
+
+		report, err := localRepo.VerifyMirror(testCtx, upstreamName, mirrorName)
+		assert.Nil(t, err)
+		assert.True(t, report.IsConsistent())
+		assert.Equal(t, MirrorRSLInSync, report.RSLStatus)
+		assert.Empty(t, report.Refs)
+	})
+
+	t.Run("mirror rewound", func(t *testing.T) {
+		localRepo, _, commitIDs := setup(t)
+
+		newCommitIDs := common.AddNTestCommitsToSpecifiedRef(t, localRepo.r, refName, 1, gpgKeyBytes)
+		common.CreateTestRSLReferenceEntryCommit(t, localRepo.r, rsl.NewReferenceEntry(refName, newCommitIDs[0]), gpgKeyBytes)
+		if err := gitinterface.Push(testCtx, localRepo.r, upstreamName, []string{refName, rsl.Ref}); err != nil {
+			t.Fatal(err)
+		}
+
+		report, err := localRepo.VerifyMirror(testCtx, upstreamName, mirrorName)
+		assert.Nil(t, err)
+		assert.False(t, report.IsConsistent())
+		assert.Equal(t, MirrorRSLBehind, report.RSLStatus)
+		assert.Equal(t, 1, report.EntriesBehind)
+		assert.Equal(t, []*MirrorRefFinding{
+			{RefName: refName, Change: MirrorRefRewound, ExpectedTargetID: newCommitIDs[0].String(), MirrorTargetID: commitIDs[0].String()},
+		}, report.Refs)
+	})
+
+	t.Run("mirror modified, added, and removed references", func(t *testing.T) {
+		localRepo, mirrorRepo, commitIDs := setup(t)
+
+		featureRefName := "refs/heads/feature"
+		featureCommitIDs := common.AddNTestCommitsToSpecifiedRef(t, localRepo.r, featureRefName, 1, gpgKeyBytes)
+		common.CreateTestRSLReferenceEntryCommit(t, localRepo.r, rsl.NewReferenceEntry(featureRefName, featureCommitIDs[0]), gpgKeyBytes)
+		if err := gitinterface.Push(testCtx, localRepo.r, upstreamName, []string{featureRefName, rsl.Ref}); err != nil {
+			t.Fatal(err)
+		}
+		if err := gitinterface.Push(testCtx, localRepo.r, mirrorName, []string{rsl.Ref}); err != nil {
+			t.Fatal(err)
+		}
+
+		mirrorCommitIDs := common.AddNTestCommitsToSpecifiedRef(t, mirrorRepo, refName, 1, gpgKeyBytes)
+		if err := mirrorRepo.Storer.SetReference(plumbing.NewHashReference("refs/heads/backdoor", commitIDs[0])); err != nil {
+			t.Fatal(err)
+		}
+
+		report, err := localRepo.VerifyMirror(testCtx, upstreamName, mirrorName)
+		assert.Nil(t, err)
+		assert.Equal(t, MirrorRSLInSync, report.RSLStatus)
+		assert.Equal(t, []*MirrorRefFinding{
+			{RefName: featureRefName, Change: MirrorRefRemoved, ExpectedTargetID: featureCommitIDs[0].String()},
+			{RefName: refName, Change: MirrorRefModified, ExpectedTargetID: commitIDs[0].String(), MirrorTargetID: mirrorCommitIDs[0].String()},
+			{RefName: "refs/heads/backdoor", Change: MirrorRefAdded, MirrorTargetID: commitIDs[0].String()},
+		}, report.Refs)
+	})
+
+	t.Run("mirror RSL diverged", func(t *testing.T) {
+		localRepo, mirrorRepo, commitIDs := setup(t)
+
+		common.CreateTestRSLReferenceEntryCommit(t, mirrorRepo, rsl.NewDeletionEntry(refName), gpgKeyBytes)
+		common.CreateTestRSLReferenceEntryCommit(t, localRepo.r, rsl.NewReferenceEntry("refs/heads/feature", commitIDs[0]), gpgKeyBytes)
+		if err := gitinterface.Push(testCtx, localRepo.r, upstreamName, []string{rsl.Ref}); err != nil {
+			t.Fatal(err)
+		}
+
+		report, err := localRepo.VerifyMirror(testCtx, upstreamName, mirrorName)
+		assert.Nil(t, err)
+		assert.Equal(t, MirrorRSLDiverged, report.RSLStatus)
+	})
+
+	t.Run("mirror without RSL", func(t *testing.T) {
+		localRepo, mirrorRepo, _ := setup(t)
+
+		if err := mirrorRepo.Storer.RemoveReference(plumbing.ReferenceName(rsl.Ref)); err != nil {
+			t.Fatal(err)
+		}
+
+		report, err := localRepo.VerifyMirror(testCtx, upstreamName, mirrorName)
+		assert.Nil(t, err)
+		assert.Equal(t, MirrorRSLMissing, report.RSLStatus)
+		assert.Empty(t, report.Refs)
+		assert.False(t, report.IsConsistent())
+	})
+
+	t.Run("upstream without RSL", func(t *testing.T) {
+		localRepo, mirrorRepo, _ := setup(t)
+
+		if err := mirrorRepo.Storer.RemoveReference(plumbing.ReferenceName(rsl.Ref)); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err := localRepo.VerifyMirror(testCtx, mirrorName, upstreamName)
+		assert.ErrorIs(t, err, ErrRemoteHasNoRSL)
+	})
+}
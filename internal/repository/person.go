@@ -0,0 +1,99 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/signerverifier/dsse"
+	"github.com/gittuf/gittuf/internal/tuf"
+	sslibdsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
+)
+
+// AddPerson is the interface for the user to add a person with the specified
+// keys to a rule file. If the person already exists, their keys are replaced,
+// and rules that authorize the person trust the new keys.
+func (r *Repository) AddPerson(ctx context.Context, signer sslibdsse.SignerVerifier, targetsRoleName, personID string, keys []*tuf.Key, signCommit bool) error {
+	commitMessage := fmt.Sprintf("Add person '%s' to policy '%s'", personID, targetsRoleName)
+
+	return r.updateTargetsMetadata(ctx, signer, targetsRoleName, commitMessage, signCommit, func(targetsMetadata *tuf.TargetsMetadata) (*tuf.TargetsMetadata, error) {
+		slog.Debug(fmt.Sprintf("Adding person '%s' to rule file...", personID))
+		return policy.AddPerson(targetsMetadata, personID, keys)
+	})
+}
+
+// RemovePerson is the interface for the user to remove a person from a rule
+// file. A person cannot be removed while a rule authorizes them.
+func (r *Repository) RemovePerson(ctx context.Context, signer sslibdsse.SignerVerifier, targetsRoleName, personID string, signCommit bool) error {
+	commitMessage := fmt.Sprintf("Remove person '%s' from policy '%s'", personID, targetsRoleName)
+
+	return r.updateTargetsMetadata(ctx, signer, targetsRoleName, commitMessage, signCommit, func(targetsMetadata *tuf.TargetsMetadata) (*tuf.TargetsMetadata, error) {
+		slog.Debug(fmt.Sprintf("Removing person '%s' from rule file...", personID))
+		return policy.RemovePerson(targetsMetadata, personID)
+	})
+}
+
+// SetAuthorizedPersons is the interface for the user to set the people
+// authorized by a rule, in addition to the rule's keys. Passing no people
+// removes all people from the rule.
+func (r *Repository) SetAuthorizedPersons(ctx context.Context, signer sslibdsse.SignerVerifier, targetsRoleName, ruleName string, personIDs []string, signCommit bool) error {
+	commitMessage := fmt.Sprintf("Set people authorized by rule '%s' in policy '%s'", ruleName, targetsRoleName)
+
+	return r.updateTargetsMetadata(ctx, signer, targetsRoleName, commitMessage, signCommit, func(targetsMetadata *tuf.TargetsMetadata) (*tuf.TargetsMetadata, error) {
+		slog.Debug("Setting people authorized by rule...")
+		return policy.SetAuthorizedPersons(targetsMetadata, ruleName, personIDs)
+	})
+}
+
+// updateTargetsMetadata applies update to the specified rule file, signs the
+// updated rule file using signer, and commits the resulting policy state.
+func (r *Repository) updateTargetsMetadata(ctx context.Context, signer sslibdsse.SignerVerifier, targetsRoleName, commitMessage string, signCommit bool, update func(*tuf.TargetsMetadata) (*tuf.TargetsMetadata, error)) error {
+	keyID, err := signer.KeyID()
+	if err != nil {
+		return err
+	}
+
+	state, err := r.loadStateForUpdate(ctx)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Loading current rule file...")
+	if !state.HasTargetsRole(targetsRoleName) {
+		return policy.ErrMetadataNotFound
+	}
+
+	targetsMetadata, err := state.GetTargetsMetadata(targetsRoleName)
+	if err != nil {
+		return err
+	}
+
+	targetsMetadata, err = update(targetsMetadata)
+	if err != nil {
+		return err
+	}
+
+	targetsMetadata.SetVersion(targetsMetadata.Version + 1)
+
+	env, err := dsse.CreateEnvelope(targetsMetadata)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug(fmt.Sprintf("Signing updated rule file using '%s'...", keyID))
+	env, err = dsse.SignEnvelope(ctx, env, signer)
+	if err != nil {
+		return err
+	}
+
+	if targetsRoleName == policy.TargetsRoleName {
+		state.TargetsEnvelope = env
+	} else {
+		state.DelegationEnvelopes[targetsRoleName] = env
+	}
+
+	return r.commitPolicyUpdate(ctx, state, commitMessage, signCommit)
+}
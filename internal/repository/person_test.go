@@ -0,0 +1,71 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/common"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/internal/signerverifier"
+	"github.com/gittuf/gittuf/internal/signerverifier/gpg"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPerson(t *testing.T) {
+	r := createTestRepositoryWithPolicy(t, "")
+
+	targetsSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(targetsKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gpgKey, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsKey, err := tuf.LoadKeyFromBytes(targetsPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = r.AddPerson(testCtx, targetsSigner, policy.TargetsRoleName, "alice", []*tuf.Key{gpgKey, targetsKey}, false)
+	assert.Nil(t, err)
+
+	err = r.SetAuthorizedPersons(testCtx, targetsSigner, policy.TargetsRoleName, "protect-main", []string{"alice"}, false)
+	assert.Nil(t, err)
+
+	state, err := policy.LoadCurrentState(testCtx, r.r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsMetadata, err := state.GetTargetsMetadata(policy.TargetsRoleName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{gpgKey.KeyID, targetsKey.KeyID}, targetsMetadata.Delegations.People["alice"].KeyIDs)
+	assert.Equal(t, []string{"alice"}, targetsMetadata.Delegations.Roles[0].PersonIDs)
+
+	// Commits signed using a key of a person authorized by the rule are
+	// accepted
+	refName := "refs/heads/main"
+	commitIDs := common.AddNTestCommitsToSpecifiedRef(t, r.r, refName, 1, gpgKeyBytes)
+	common.CreateTestRSLReferenceEntryCommit(t, r.r, rsl.NewReferenceEntry(refName, commitIDs[0]), gpgKeyBytes)
+
+	err = r.VerifyRef(testCtx, refName, true)
+	assert.Nil(t, err)
+
+	err = r.RemovePerson(testCtx, targetsSigner, policy.TargetsRoleName, "alice", false)
+	assert.ErrorIs(t, err, policy.ErrPersonInUse)
+
+	err = r.SetAuthorizedPersons(testCtx, targetsSigner, policy.TargetsRoleName, "protect-main", nil, false)
+	assert.Nil(t, err)
+
+	err = r.RemovePerson(testCtx, targetsSigner, policy.TargetsRoleName, "alice", false)
+	assert.Nil(t, err)
+
+	err = r.RemovePerson(testCtx, targetsSigner, policy.TargetsRoleName, "alice", false)
+	assert.ErrorIs(t, err, policy.ErrPersonNotFound)
+}
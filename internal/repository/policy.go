@@ -7,22 +7,36 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"strings"
+	"time"
 
 	"github.com/gittuf/gittuf/internal/gitinterface"
 	"github.com/gittuf/gittuf/internal/policy"
 	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/internal/signerverifier/dsse"
+	"github.com/go-git/go-git/v5/plumbing"
+	sslibdsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
 )
 
 var (
 	ErrPushingPolicy = errors.New("unable to push policy")
 	ErrPullingPolicy = errors.New("unable to pull policy")
+	ErrExpiryInPast  = errors.New("expiry date must be in the future")
+
+	ErrStagedPolicyPending = errors.New("staged policy is not signed by a threshold of keys for all roles")
 )
 
-// PushPolicy pushes the local gittuf policy to the specified remote. As this
-// push defaults to fast-forward only, divergent policy states are detected.
-// Note that this also pushes the RSL as the policy cannot change without an
-// update to the RSL.
+// PushPolicy pushes the local gittuf policy to the specified remote. Note that
+// this also pushes the RSL as the policy cannot change without an update to the
+// RSL. If the remote RSL has entries that are not in the local RSL, the push is
+// aborted so that the remote's updates can be pulled first. As the push
+// defaults to fast-forward only, concurrent updates to the remote are also
+// detected.
 func (r *Repository) PushPolicy(ctx context.Context, remoteName string) error {
+	if err := r.checkRemoteRSLBeforePush(ctx, remoteName); err != nil {
+		return errors.Join(ErrPushingPolicy, err)
+	}
+
 	slog.Debug(fmt.Sprintf("Pushing policy and RSL references to %s...", remoteName))
 	if err := gitinterface.Push(ctx, r.r, remoteName, []string{policy.PolicyRef, rsl.Ref}); err != nil {
 		return errors.Join(ErrPushingPolicy, err)
@@ -31,18 +45,331 @@ func (r *Repository) PushPolicy(ctx context.Context, remoteName string) error {
 	return nil
 }
 
-// PullPolicy fetches gittuf policy from the specified remote. The fetches is
-// marked as fast forward only to detect divergence. Note that this also fetches
-// the RSL as the policy must be updated in sync with the RSL.
+// PullPolicy fetches gittuf policy from the specified remote. Note that this
+// also fetches the RSL as the policy must be updated in sync with the RSL. If
+// the local and remote RSLs have diverged, the pull is aborted. The fetched
+// policy is verified before it is accepted: its root of trust and policy files
+// must be valid, and the policy reference must match the latest entry for it
+// in the fetched RSL. If verification fails, the local policy and RSL are
+// restored to their prior state.
 func (r *Repository) PullPolicy(ctx context.Context, remoteName string) error {
-	slog.Debug(fmt.Sprintf("Pulling policy and RSL references from %s...", remoteName))
-	if err := gitinterface.Fetch(ctx, r.r, remoteName, []string{policy.PolicyRef, rsl.Ref}, true); err != nil {
+	gittufRefs := []string{policy.PolicyRef, rsl.Ref}
+	priorRefs, err := r.checkRemoteRSLBeforePull(ctx, remoteName, gittufRefs)
+	if err != nil {
 		return errors.Join(ErrPullingPolicy, err)
 	}
 
+	slog.Debug(fmt.Sprintf("Pulling policy and RSL references from %s...", remoteName))
+	if err := gitinterface.Fetch(ctx, r.r, remoteName, gittufRefs, true); err != nil {
+		return errors.Join(ErrPullingPolicy, err, r.restoreReferences(gittufRefs, priorRefs))
+	}
+
+	slog.Debug("Verifying fetched policy...")
+	if err := r.verifyCurrentPolicy(ctx); err != nil {
+		return errors.Join(ErrPullingPolicy, err, r.restoreReferences(gittufRefs, priorRefs))
+	}
+
 	return nil
 }
 
+// verifyCurrentPolicy verifies the policy recorded in the latest RSL entry for
+// the policy reference, and checks that the policy reference matches it.
+func (r *Repository) verifyCurrentPolicy(ctx context.Context) error {
+	latestEntry, _, err := rsl.GetLatestReferenceEntryForRef(r.r, policy.PolicyRef)
+	if err != nil {
+		return err
+	}
+
+	state, err := policy.LoadState(ctx, r.r, latestEntry)
+	if err != nil {
+		return err
+	}
+
+	if err := state.Verify(ctx); err != nil {
+		return err
+	}
+
+	return r.verifyRefTip(policy.PolicyRef, latestEntry.TargetID)
+}
+
 func (r *Repository) ListRules(ctx context.Context) ([]*policy.DelegationWithDepth, error) {
 	return policy.ListRules(ctx, r.r)
 }
+
+// GetRuleTree returns the delegation tree of the current policy.
+func (r *Repository) GetRuleTree(ctx context.Context) (*policy.RuleTree, error) {
+	return policy.GetRuleTree(ctx, r.r)
+}
+
+// GetPolicyVersions returns the versions of the current policy's root and
+// top-level targets metadata, keyed by role name. The targets metadata's
+// version is omitted if the policy does not have top-level targets metadata.
+func (r *Repository) GetPolicyVersions(ctx context.Context) (map[string]int, error) {
+	state, err := policy.LoadCurrentState(ctx, r.r)
+	if err != nil {
+		return nil, err
+	}
+
+	rootMetadata, err := state.GetRootMetadata()
+	if err != nil {
+		return nil, err
+	}
+	versions := map[string]int{policy.RootRoleName: rootMetadata.Version}
+
+	if state.TargetsEnvelope != nil {
+		targetsMetadata, err := state.GetTargetsMetadata(policy.TargetsRoleName)
+		if err != nil {
+			return nil, err
+		}
+		versions[policy.TargetsRoleName] = targetsMetadata.Version
+	}
+
+	return versions, nil
+}
+
+// ExportSSHAllowedSigners returns the allowed signers entries for the SSH keys
+// and SSH certificate authorities that the current policy trusts to sign for
+// the specified ref.
+func (r *Repository) ExportSSHAllowedSigners(ctx context.Context, refName string) ([]*policy.AllowedSigner, error) {
+	absRefName, err := gitinterface.AbsoluteReference(r.r, refName)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := policy.LoadCurrentState(ctx, r.r)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Debug(fmt.Sprintf("Finding SSH keys trusted for '%s'...", absRefName))
+	return state.FindAllowedSignersForPath(fmt.Sprintf("git:%s", absRefName))
+}
+
+// WhoCanAuthorize returns the rules, thresholds, and principals that can
+// authorize a change to target under the current policy. The target may be a
+// Git reference, or a path prefixed with a namespace scheme such as "file:".
+func (r *Repository) WhoCanAuthorize(ctx context.Context, target string) (*policy.Authorization, error) {
+	path := target
+	if !strings.HasPrefix(target, "git:") && !strings.HasPrefix(target, "file:") {
+		absRefName, err := gitinterface.AbsoluteReference(r.r, target)
+		if err != nil {
+			return nil, err
+		}
+		path = fmt.Sprintf("git:%s", absRefName)
+	}
+
+	state, err := policy.LoadCurrentState(ctx, r.r)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Debug(fmt.Sprintf("Finding principals that can authorize changes to '%s'...", path))
+	return state.WhoCanAuthorize(path)
+}
+
+// GetPolicyInEffectForEntry returns the policy that governed the specified RSL
+// entry, which is the latest policy recorded in the RSL before the entry.
+func (r *Repository) GetPolicyInEffectForEntry(ctx context.Context, entryID string) (*policy.HistoricalPolicy, error) {
+	entryHash, err := r.r.ResolveRevision(plumbing.Revision(entryID))
+	if err != nil {
+		return nil, err
+	}
+
+	return policy.GetPolicyInEffectForEntry(ctx, r.r, *entryHash)
+}
+
+// GetPolicyInEffectAtTime returns the policy in effect at the specified time,
+// which is the latest policy recorded in the RSL at or before that time.
+func (r *Repository) GetPolicyInEffectAtTime(ctx context.Context, at time.Time) (*policy.HistoricalPolicy, error) {
+	return policy.GetPolicyInEffectAtTime(ctx, r.r, at)
+}
+
+// ListPendingPolicyRoles returns the names of the roles in the staged policy
+// that are not yet signed by a threshold of their trusted keys.
+func (r *Repository) ListPendingPolicyRoles(ctx context.Context) ([]string, error) {
+	slog.Debug("Loading staged policy...")
+	state, err := policy.LoadStagedState(ctx, r.r)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Debug("Loading current policy...")
+	currentState, err := policy.LoadCurrentState(ctx, r.r)
+	if err != nil {
+		return nil, err
+	}
+
+	return state.PendingRoles(ctx, currentState)
+}
+
+// ApplyStagedPolicy commits the staged policy to the policy namespace and
+// records it in the RSL. The staged policy must be signed by a threshold of
+// keys for every role.
+func (r *Repository) ApplyStagedPolicy(ctx context.Context, signCommit bool) error {
+	pendingRoles, err := r.ListPendingPolicyRoles(ctx)
+	if err != nil {
+		return err
+	}
+	if len(pendingRoles) > 0 {
+		return fmt.Errorf("%w, pending roles: %s", ErrStagedPolicyPending, strings.Join(pendingRoles, ", "))
+	}
+
+	state, err := policy.LoadStagedState(ctx, r.r)
+	if err != nil {
+		return err
+	}
+
+	commitMessage, err := r.loadStagedCommitMessage()
+	if err != nil {
+		return err
+	}
+
+	return r.commitPolicyUpdate(ctx, state, commitMessage, signCommit)
+}
+
+// DiscardStagedPolicy removes the staged policy, discarding any changes and
+// signatures that have not been applied.
+func (r *Repository) DiscardStagedPolicy() error {
+	if _, err := r.r.Reference(plumbing.ReferenceName(policy.PolicyStagingRef), true); err != nil {
+		if errors.Is(err, plumbing.ErrReferenceNotFound) {
+			return policy.ErrNoStagedPolicy
+		}
+		return err
+	}
+
+	slog.Debug("Discarding staged policy...")
+	return policy.DiscardStagedState(r.r)
+}
+
+// RenewPolicy sets the expiry date of the specified role's metadata and signs
+// it using the signer. The role may be the root of trust or any policy file.
+func (r *Repository) RenewPolicy(ctx context.Context, signer sslibdsse.SignerVerifier, roleName string, expires time.Time, signCommit bool) error {
+	if !expires.After(time.Now()) {
+		return ErrExpiryInPast
+	}
+
+	keyID, err := signer.KeyID()
+	if err != nil {
+		return err
+	}
+
+	expiresString := expires.UTC().Format(time.RFC3339)
+
+	if roleName == policy.RootRoleName {
+		state, err := r.loadStateForUpdate(ctx)
+		if err != nil {
+			return err
+		}
+
+		rootMetadata, err := r.loadRootMetadata(state, keyID)
+		if err != nil {
+			return err
+		}
+
+		slog.Debug("Renewing root metadata...")
+		rootMetadata.SetExpires(expiresString)
+
+		commitMessage := fmt.Sprintf("Renew root until %s", expiresString)
+		return r.updateRootMetadata(ctx, state, signer, rootMetadata, commitMessage, signCommit)
+	}
+
+	state, err := r.loadStateForUpdate(ctx)
+	if err != nil {
+		return err
+	}
+	if !state.HasTargetsRole(roleName) {
+		return policy.ErrMetadataNotFound
+	}
+
+	targetsMetadata, err := state.GetTargetsMetadata(roleName)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug(fmt.Sprintf("Renewing policy '%s'...", roleName))
+	targetsMetadata.SetExpires(expiresString)
+	targetsMetadata.SetVersion(targetsMetadata.Version + 1)
+
+	env, err := dsse.CreateEnvelope(targetsMetadata)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug(fmt.Sprintf("Signing renewed policy using '%s'...", keyID))
+	env, err = dsse.SignEnvelope(ctx, env, signer)
+	if err != nil {
+		return err
+	}
+
+	if roleName == policy.TargetsRoleName {
+		state.TargetsEnvelope = env
+	} else {
+		state.DelegationEnvelopes[roleName] = env
+	}
+
+	commitMessage := fmt.Sprintf("Renew policy '%s' until %s", roleName, expiresString)
+	return r.commitPolicyUpdate(ctx, state, commitMessage, signCommit)
+}
+
+// DiffPolicy returns the differences between two policy states. Each state is
+// identified by an RSL entry ID, in which case the policy applicable at that
+// entry is used. If fromEntryID is empty, the current policy is used. If
+// toEntryID is empty, the staged policy is used, or the current policy if
+// nothing is staged.
+func (r *Repository) DiffPolicy(ctx context.Context, fromEntryID, toEntryID string) (*policy.StateDiff, error) {
+	var (
+		fromState *policy.State
+		err       error
+	)
+	if fromEntryID == "" {
+		slog.Debug("Loading current policy...")
+		fromState, err = policy.LoadCurrentState(ctx, r.r)
+	} else {
+		fromState, err = r.loadStateAtEntry(ctx, fromEntryID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var toState *policy.State
+	if toEntryID == "" {
+		toState, err = r.loadStateForUpdate(ctx)
+	} else {
+		toState, err = r.loadStateAtEntry(ctx, toEntryID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return policy.DiffStates(fromState, toState)
+}
+
+// loadStateAtEntry returns the policy applicable at the RSL reference entry
+// identified by entryID.
+func (r *Repository) loadStateAtEntry(ctx context.Context, entryID string) (*policy.State, error) {
+	slog.Debug(fmt.Sprintf("Loading policy at RSL entry '%s'...", entryID))
+	entry, err := rsl.GetEntry(r.r, plumbing.NewHash(entryID))
+	if err != nil {
+		return nil, err
+	}
+
+	referenceEntry, isReferenceEntry := entry.(*rsl.ReferenceEntry)
+	if !isReferenceEntry {
+		return nil, rsl.ErrInvalidRSLEntry
+	}
+
+	return policy.LoadState(ctx, r.r, referenceEntry)
+}
+
+// LintPolicy checks the staged policy, or the current policy if nothing is
+// staged, for problems such as unreachable rules and thresholds that cannot be
+// met.
+func (r *Repository) LintPolicy(ctx context.Context) ([]*policy.LintFinding, error) {
+	state, err := r.loadStateForUpdate(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Debug("Linting policy...")
+	return state.Lint()
+}
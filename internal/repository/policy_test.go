@@ -5,9 +5,15 @@ package repository
 import (
 	"context"
 	"testing"
+	"time"
 
+	"github.com/gittuf/gittuf/internal/gitinterface"
 	"github.com/gittuf/gittuf/internal/policy"
 	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/internal/signerverifier"
+	"github.com/gittuf/gittuf/internal/signerverifier/dsse"
+	artifacts "github.com/gittuf/gittuf/internal/testartifacts"
+	"github.com/gittuf/gittuf/internal/tuf"
 	"github.com/go-git/go-billy/v5/memfs"
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/config"
@@ -73,6 +79,43 @@ func TestPushPolicy(t *testing.T) {
 		err = localRepo.PushPolicy(context.Background(), remoteName)
 		assert.ErrorIs(t, err, ErrPushingPolicy)
 	})
+
+	t.Run("remote has updates, unsuccessful push", func(t *testing.T) {
+		remoteTmpDir := t.TempDir()
+		remoteRepo := createTestRepositoryWithPolicy(t, remoteTmpDir)
+
+		localRepoR, err := git.Init(memory.NewStorage(), memfs.New())
+		if err != nil {
+			t.Fatal(err)
+		}
+		localRepo := &Repository{r: localRepoR}
+		if _, err := localRepo.r.CreateRemote(&config.RemoteConfig{
+			Name: remoteName,
+			URLs: []string{remoteTmpDir},
+		}); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := localRepo.PullPolicy(context.Background(), remoteName); err != nil {
+			t.Fatal(err)
+		}
+
+		targetsSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(targetsKeyBytes) //nolint:staticcheck
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsPubKey, err := tuf.LoadKeyFromBytes(targetsPubKeyBytes)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := remoteRepo.AddDelegation(testCtx, targetsSigner, policy.TargetsRoleName, "protect-feature", []*tuf.Key{targetsPubKey}, []string{"git:refs/heads/feature"}, 1, false); err != nil {
+			t.Fatal(err)
+		}
+
+		err = localRepo.PushPolicy(context.Background(), remoteName)
+		assert.ErrorIs(t, err, ErrPushingPolicy)
+		assert.ErrorIs(t, err, ErrRemoteRSLHasUpdates)
+	})
 }
 
 func TestPullPolicy(t *testing.T) {
@@ -132,5 +175,352 @@ func TestPullPolicy(t *testing.T) {
 
 		err = localRepo.PullPolicy(context.Background(), remoteName)
 		assert.ErrorIs(t, err, ErrPullingPolicy)
+		assert.ErrorIs(t, err, ErrDivergedRSL)
+	})
+
+	t.Run("policy does not match RSL, unsuccessful pull", func(t *testing.T) {
+		remoteTmpDir := t.TempDir()
+		remoteRepo := createTestRepositoryWithPolicy(t, remoteTmpDir)
+
+		// Add a commit to the remote's policy reference without recording it
+		// in the RSL
+		if _, err := gitinterface.Commit(remoteRepo.r, gitinterface.EmptyTree(), policy.PolicyRef, "Unrecorded policy change", false); err != nil {
+			t.Fatal(err)
+		}
+
+		localRepoR, err := git.Init(memory.NewStorage(), memfs.New())
+		if err != nil {
+			t.Fatal(err)
+		}
+		localRepo := &Repository{r: localRepoR}
+		if _, err := localRepo.r.CreateRemote(&config.RemoteConfig{
+			Name: remoteName,
+			URLs: []string{remoteTmpDir},
+		}); err != nil {
+			t.Fatal(err)
+		}
+
+		err = localRepo.PullPolicy(context.Background(), remoteName)
+		assert.ErrorIs(t, err, ErrPullingPolicy)
+		assert.ErrorIs(t, err, ErrRefStateDoesNotMatchRSL)
+
+		// The fetched references are not accepted locally
+		_, err = localRepo.r.Reference(plumbing.ReferenceName(policy.PolicyRef), true)
+		assert.ErrorIs(t, err, plumbing.ErrReferenceNotFound)
+		_, err = localRepo.r.Reference(plumbing.ReferenceName(rsl.Ref), true)
+		assert.ErrorIs(t, err, plumbing.ErrReferenceNotFound)
+	})
+}
+
+func TestRenewPolicy(t *testing.T) {
+	rootSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(rootKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(targetsKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expires := time.Now().AddDate(2, 0, 0)
+	expiresString := expires.UTC().Format(time.RFC3339)
+
+	t.Run("renew root", func(t *testing.T) {
+		r := createTestRepositoryWithPolicy(t, "")
+
+		err := r.RenewPolicy(testCtx, rootSigner, policy.RootRoleName, expires, false)
+		assert.Nil(t, err)
+
+		state, err := policy.LoadCurrentState(testCtx, r.r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rootMetadata, err := state.GetRootMetadata()
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, expiresString, rootMetadata.Expires)
+	})
+
+	t.Run("renew targets", func(t *testing.T) {
+		r := createTestRepositoryWithPolicy(t, "")
+
+		err := r.RenewPolicy(testCtx, targetsSigner, policy.TargetsRoleName, expires, false)
+		assert.Nil(t, err)
+
+		state, err := policy.LoadCurrentState(testCtx, r.r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsMetadata, err := state.GetTargetsMetadata(policy.TargetsRoleName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, expiresString, targetsMetadata.Expires)
+	})
+
+	t.Run("unauthorized root signer", func(t *testing.T) {
+		r := createTestRepositoryWithPolicy(t, "")
+
+		signer, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(artifacts.SSLibKey3Private) //nolint:staticcheck
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = r.RenewPolicy(testCtx, signer, policy.RootRoleName, expires, false)
+		assert.ErrorIs(t, err, ErrUnauthorizedKey)
+	})
+
+	t.Run("unknown policy file", func(t *testing.T) {
+		r := createTestRepositoryWithPolicy(t, "")
+
+		err := r.RenewPolicy(testCtx, targetsSigner, "does-not-exist", expires, false)
+		assert.ErrorIs(t, err, policy.ErrMetadataNotFound)
+	})
+
+	t.Run("expiry in the past", func(t *testing.T) {
+		r := createTestRepositoryWithPolicy(t, "")
+
+		err := r.RenewPolicy(testCtx, rootSigner, policy.RootRoleName, time.Now().Add(-time.Hour), false)
+		assert.ErrorIs(t, err, ErrExpiryInPast)
+	})
+}
+
+func TestDiffPolicy(t *testing.T) {
+	r := createTestRepositoryWithPolicy(t, "")
+
+	targetsSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(targetsKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsKey, err := tuf.LoadKeyFromBytes(targetsPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	entry, _, err := rsl.GetLatestReferenceEntryForRef(r.r, policy.PolicyRef)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := r.AddDelegation(testCtx, targetsSigner, policy.TargetsRoleName, "protect-feature", []*tuf.Key{targetsKey}, []string{"git:refs/heads/feature"}, 1, false); err != nil {
+		t.Fatal(err)
+	}
+
+	diff, err := r.DiffPolicy(testCtx, "", "")
+	assert.Nil(t, err)
+	assert.True(t, diff.IsEmpty())
+
+	diff, err = r.DiffPolicy(testCtx, entry.ID.String(), "")
+	assert.Nil(t, err)
+	assert.Empty(t, diff.Roles)
+	assert.Empty(t, diff.Signers)
+	if assert.Len(t, diff.Rules, 1) {
+		assert.Equal(t, "protect-feature", diff.Rules[0].Name)
+		assert.Equal(t, policy.ChangeAdded, diff.Rules[0].Change)
+	}
+
+	latestEntry, _, err := rsl.GetLatestReferenceEntryForRef(r.r, policy.PolicyRef)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	diff, err = r.DiffPolicy(testCtx, latestEntry.ID.String(), entry.ID.String())
+	assert.Nil(t, err)
+	if assert.Len(t, diff.Rules, 1) {
+		assert.Equal(t, policy.ChangeRemoved, diff.Rules[0].Change)
+	}
+
+	if err := rsl.NewAnnotationEntry([]plumbing.Hash{entry.ID}, false, "test annotation").Commit(r.r, false); err != nil {
+		t.Fatal(err)
+	}
+	annotation, err := rsl.GetLatestEntry(r.r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = r.DiffPolicy(testCtx, annotation.GetID().String(), "")
+	assert.ErrorIs(t, err, rsl.ErrInvalidRSLEntry)
+}
+
+func TestLintPolicy(t *testing.T) {
+	r := createTestRepositoryWithPolicy(t, "")
+
+	targetsSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(targetsKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsKey, err := tuf.LoadKeyFromBytes(targetsPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	findings, err := r.LintPolicy(testCtx)
+	assert.Nil(t, err)
+	assert.Empty(t, findings)
+
+	if err := r.AddDelegation(testCtx, targetsSigner, policy.TargetsRoleName, "protect-feature", []*tuf.Key{targetsKey}, []string{"git:refs/heads/feature"}, 2, false); err != nil {
+		t.Fatal(err)
+	}
+
+	findings, err = r.LintPolicy(testCtx)
+	assert.Nil(t, err)
+	assert.Equal(t, []*policy.LintFinding{
+		{
+			Severity:   policy.LintError,
+			Check:      policy.LintCheckUnmeetableThreshold,
+			PolicyName: policy.TargetsRoleName,
+			RuleName:   "protect-feature",
+			Message:    "threshold of 2 cannot be met by 1 usable key(s)",
+		},
+	}, findings)
+}
+
+func TestWhoCanAuthorize(t *testing.T) {
+	r := createTestRepositoryWithPolicy(t, "")
+
+	t.Run("ref", func(t *testing.T) {
+		authorization, err := r.WhoCanAuthorize(testCtx, "refs/heads/main")
+		assert.Nil(t, err)
+		assert.Equal(t, "git:refs/heads/main", authorization.Path)
+		assert.True(t, authorization.Protected)
+		if assert.Len(t, authorization.Rules, 1) {
+			assert.Equal(t, "protect-main", authorization.Rules[0].Name)
+			assert.Equal(t, 1, authorization.Rules[0].Threshold)
+			assert.Len(t, authorization.Rules[0].Principals, 1)
+		}
+	})
+
+	t.Run("file", func(t *testing.T) {
+		authorization, err := r.WhoCanAuthorize(testCtx, "file:README.md")
+		assert.Nil(t, err)
+		assert.Equal(t, "file:README.md", authorization.Path)
+		assert.False(t, authorization.Protected)
+	})
+
+	t.Run("unknown ref", func(t *testing.T) {
+		_, err := r.WhoCanAuthorize(testCtx, "unknown")
+		assert.ErrorIs(t, err, gitinterface.ErrReferenceNotFound)
 	})
 }
+
+func TestGetPolicyInEffect(t *testing.T) {
+	r := createTestRepositoryWithPolicy(t, "")
+
+	policyEntry, _, err := rsl.GetLatestReferenceEntryForRef(r.r, policy.PolicyRef)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	historicalPolicy, err := r.GetPolicyInEffectAtTime(testCtx, time.Now().Add(time.Hour))
+	assert.Nil(t, err)
+	assert.Equal(t, policyEntry.ID.String(), historicalPolicy.EntryID)
+
+	if err := rsl.NewReferenceEntry("refs/heads/main", plumbing.ZeroHash).Commit(r.r, false); err != nil {
+		t.Fatal(err)
+	}
+	latestEntry, err := rsl.GetLatestEntry(r.r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	historicalPolicy, err = r.GetPolicyInEffectForEntry(testCtx, latestEntry.GetID().String()[:12])
+	assert.Nil(t, err)
+	assert.Equal(t, policyEntry.ID.String(), historicalPolicy.EntryID)
+}
+
+func TestApplyAndDiscardStagedPolicy(t *testing.T) {
+	r, keyBytes := createTestRepositoryWithRoot(t, "")
+
+	rootSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(keyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+	secondRootSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(targetsKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = r.ListPendingPolicyRoles(testCtx)
+	assert.ErrorIs(t, err, policy.ErrNoStagedPolicy)
+	err = r.ApplyStagedPolicy(testCtx, false)
+	assert.ErrorIs(t, err, policy.ErrNoStagedPolicy)
+	err = r.DiscardStagedPolicy()
+	assert.ErrorIs(t, err, policy.ErrNoStagedPolicy)
+
+	secondRootKey, err := tuf.LoadKeyFromBytes(targetsPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.AddRootKey(testCtx, rootSigner, secondRootKey, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.UpdateRootThreshold(testCtx, rootSigner, 2, false); err != nil {
+		t.Fatal(err)
+	}
+
+	// With a threshold of 2, changes are staged until a second root key signs
+	if err := r.UpdateRootThreshold(testCtx, rootSigner, 1, false); err != nil {
+		t.Fatal(err)
+	}
+
+	pendingRoles, err := r.ListPendingPolicyRoles(testCtx)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{policy.RootRoleName}, pendingRoles)
+
+	err = r.ApplyStagedPolicy(testCtx, false)
+	assert.ErrorIs(t, err, ErrStagedPolicyPending)
+
+	// Add the second signature directly to the staged policy, as is the case
+	// when it is fetched from another copy of the repository
+	stagedState, err := policy.LoadStagedState(testCtx, r.r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stagedState.RootEnvelope, err = dsse.SignEnvelope(testCtx, stagedState.RootEnvelope, secondRootSigner)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := stagedState.Stage(testCtx, r.r, "Update root threshold", false); err != nil {
+		t.Fatal(err)
+	}
+
+	pendingRoles, err = r.ListPendingPolicyRoles(testCtx)
+	assert.Nil(t, err)
+	assert.Empty(t, pendingRoles)
+
+	err = r.ApplyStagedPolicy(testCtx, false)
+	assert.Nil(t, err)
+
+	state, err := policy.LoadCurrentState(testCtx, r.r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootMetadata, err := state.GetRootMetadata()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 1, rootMetadata.Roles[policy.RootRoleName].Threshold)
+
+	_, err = policy.LoadStagedState(testCtx, r.r)
+	assert.ErrorIs(t, err, policy.ErrNoStagedPolicy)
+
+	// Staged changes can be discarded
+	if err := stagedState.Stage(testCtx, r.r, "Update root threshold", false); err != nil {
+		t.Fatal(err)
+	}
+	err = r.DiscardStagedPolicy()
+	assert.Nil(t, err)
+
+	_, err = policy.LoadStagedState(testCtx, r.r)
+	assert.ErrorIs(t, err, policy.ErrNoStagedPolicy)
+}
+
+func TestGetPolicyVersions(t *testing.T) {
+	repo := createTestRepositoryWithPolicy(t, "")
+
+	versions, err := repo.GetPolicyVersions(testCtx)
+	assert.Nil(t, err)
+	assert.Contains(t, versions, policy.RootRoleName)
+	assert.Contains(t, versions, policy.TargetsRoleName)
+}
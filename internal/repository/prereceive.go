@@ -0,0 +1,159 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/gittuf/gittuf/internal/attestations"
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+var (
+	ErrRSLRewritten           = errors.New("push rewrites the RSL")
+	ErrUpdateNotRecordedInRSL = errors.New("reference update is not recorded in the RSL")
+)
+
+// VerifyProposedUpdates verifies the reference updates proposed by a push,
+// such as those received by Git's pre-receive hook, before they are accepted.
+// Objects sent by the push are read from objectDirs in addition to the
+// repository. Each updated reference must be recorded in the pushed RSL, and
+// its new RSL entries must meet the repository's policy. RSL entries that
+// were accepted prior to the push are trusted. The RSL itself must only be
+// extended by the push. The returned error identifies all the updates that
+// failed verification.
+func (r *Repository) VerifyProposedUpdates(ctx context.Context, updates []*gitinterface.ReferenceUpdate, objectDirs []string, opts ...VerifyRefOption) error {
+	slog.Debug("Loading proposed repository state...")
+	proposedRepo, err := gitinterface.NewRepositoryWithProposedUpdates(r.r, objectDirs, updates)
+	if err != nil {
+		return err
+	}
+	proposed := &Repository{r: proposedRepo}
+
+	for _, update := range updates {
+		if update.RefName != rsl.Ref {
+			continue
+		}
+
+		slog.Debug("Checking if RSL is only extended...")
+		if err := proposed.verifyRSLUpdate(update); err != nil {
+			return err
+		}
+	}
+
+	if err := proposed.verifyPolicyExpiration(ctx, opts...); err != nil {
+		return err
+	}
+
+	var verificationErrs []error
+	for _, update := range updates {
+		if !isRecordedInRSL(update.RefName) {
+			slog.Debug(fmt.Sprintf("Reference '%s' is not recorded in the RSL, skipping...", update.RefName))
+			continue
+		}
+
+		slog.Debug(fmt.Sprintf("Verifying proposed update to '%s'...", update.RefName))
+		if err := r.verifyProposedUpdate(ctx, proposed, update); err != nil {
+			verificationErrs = append(verificationErrs, fmt.Errorf("unable to verify '%s': %w", update.RefName, err))
+		}
+	}
+
+	return errors.Join(verificationErrs...)
+}
+
+// verifyRSLUpdate checks that the proposed update to the RSL does not rewrite
+// it.
+func (r *Repository) verifyRSLUpdate(update *gitinterface.ReferenceUpdate) error {
+	if update.NewID.IsZero() {
+		return fmt.Errorf("%w: RSL cannot be deleted", ErrRSLRewritten)
+	}
+
+	if update.OldID.IsZero() {
+		return nil
+	}
+
+	oldTip, err := gitinterface.GetCommit(r.r, update.OldID)
+	if err != nil {
+		return err
+	}
+
+	knows, err := gitinterface.KnowsCommit(r.r, update.NewID, oldTip)
+	if err != nil {
+		return err
+	}
+	if !knows {
+		return ErrRSLRewritten
+	}
+
+	return nil
+}
+
+// verifyProposedUpdate verifies a single proposed update using the proposed
+// repository state. Verification starts from the latest entry for the
+// reference in the repository's current RSL, if one exists.
+func (r *Repository) verifyProposedUpdate(ctx context.Context, proposed *Repository, update *gitinterface.ReferenceUpdate) error {
+	latestEntry, _, err := rsl.GetLatestReferenceEntryForRef(proposed.r, update.RefName)
+	if err != nil {
+		if errors.Is(err, rsl.ErrRSLEntryNotFound) {
+			return ErrUpdateNotRecordedInRSL
+		}
+		return err
+	}
+	if latestEntry.TargetID != update.NewID {
+		return fmt.Errorf("%w: latest RSL entry records '%s'", ErrUpdateNotRecordedInRSL, latestEntry.TargetID.String())
+	}
+
+	var expectedTip plumbing.Hash
+	trustedEntry, _, err := rsl.GetLatestReferenceEntryForRef(r.r, update.RefName)
+	switch {
+	case err == nil:
+		trustedEntryT, err := rsl.GetEntry(proposed.r, trustedEntry.ID)
+		if err != nil {
+			return err
+		}
+
+		if _, isReferenceEntry := trustedEntryT.(*rsl.ReferenceEntry); isReferenceEntry {
+			slog.Debug(fmt.Sprintf("Verifying entries for '%s' from entry '%s'...", update.RefName, trustedEntry.ID.String()))
+			expectedTip, err = policy.VerifyRefFromEntry(ctx, proposed.r, update.RefName, trustedEntry.ID)
+		} else {
+			// The reference was last recorded in a checkpoint, which is
+			// trusted during full verification
+			expectedTip, err = policy.VerifyRefFull(ctx, proposed.r, update.RefName)
+		}
+		if err != nil {
+			return err
+		}
+	case errors.Is(err, rsl.ErrRSLEntryNotFound), errors.Is(err, plumbing.ErrReferenceNotFound):
+		slog.Debug(fmt.Sprintf("Verifying all entries for '%s'...", update.RefName))
+		expectedTip, err = policy.VerifyRefFull(ctx, proposed.r, update.RefName)
+		if err != nil {
+			return err
+		}
+	default:
+		return err
+	}
+
+	if expectedTip != update.NewID {
+		return ErrRefStateDoesNotMatchRSL
+	}
+
+	return nil
+}
+
+// isRecordedInRSL indicates if updates to the reference must be recorded in
+// the RSL. Of gittuf's references, only the policy and attestations are
+// recorded.
+func isRecordedInRSL(refName string) bool {
+	if refName == policy.PolicyRef || refName == attestations.Ref {
+		return true
+	}
+
+	return !strings.HasPrefix(refName, rsl.GittufNamespacePrefix)
+}
@@ -0,0 +1,114 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/common"
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyProposedUpdates(t *testing.T) {
+	refName := "refs/heads/main"
+
+	// setup creates a repository in which main has been accepted with one
+	// commit, and returns the tips of main and the RSL.
+	setup := func(t *testing.T) (*Repository, plumbing.Hash, plumbing.Hash) {
+		t.Helper()
+
+		repo := createTestRepositoryWithPolicy(t, "")
+		if err := repo.r.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(refName), plumbing.ZeroHash)); err != nil {
+			t.Fatal(err)
+		}
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo.r, refName, 1, gpgKeyBytes)
+		rslTip := common.CreateTestRSLReferenceEntryCommit(t, repo.r, rsl.NewReferenceEntry(refName, commitIDs[0]), gpgKeyBytes)
+
+		return repo, commitIDs[0], rslTip
+	}
+
+	// propose adds a commit to main signed using keyBytes and, if record is
+	// set, an RSL entry for it. The references are then reset so that the
+	// changes are only proposed, and the updates are returned.
+	propose := func(t *testing.T, repo *Repository, mainTip, rslTip plumbing.Hash, keyBytes []byte, record bool) []*gitinterface.ReferenceUpdate {
+		t.Helper()
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo.r, refName, 1, keyBytes)
+		updates := []*gitinterface.ReferenceUpdate{{RefName: refName, OldID: mainTip, NewID: commitIDs[0]}}
+
+		if record {
+			newRSLTip := common.CreateTestRSLReferenceEntryCommit(t, repo.r, rsl.NewReferenceEntry(refName, commitIDs[0]), keyBytes)
+			updates = append(updates, &gitinterface.ReferenceUpdate{RefName: rsl.Ref, OldID: rslTip, NewID: newRSLTip})
+		}
+
+		if err := repo.r.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(refName), mainTip)); err != nil {
+			t.Fatal(err)
+		}
+		if err := repo.r.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(rsl.Ref), rslTip)); err != nil {
+			t.Fatal(err)
+		}
+
+		return updates
+	}
+
+	t.Run("valid push", func(t *testing.T) {
+		repo, mainTip, rslTip := setup(t)
+		updates := propose(t, repo, mainTip, rslTip, gpgKeyBytes, true)
+
+		err := repo.VerifyProposedUpdates(testCtx, updates, nil)
+		assert.Nil(t, err)
+
+		// The repository is not modified
+		tip, err := gitinterface.GetTip(repo.r, refName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, mainTip, tip)
+	})
+
+	t.Run("policy violation", func(t *testing.T) {
+		repo, mainTip, rslTip := setup(t)
+		updates := propose(t, repo, mainTip, rslTip, gpgUnauthorizedKeyBytes, true)
+
+		err := repo.VerifyProposedUpdates(testCtx, updates, nil)
+		assert.ErrorIs(t, err, policy.ErrUnauthorizedSignature)
+	})
+
+	t.Run("update not recorded in RSL", func(t *testing.T) {
+		repo, mainTip, rslTip := setup(t)
+		updates := propose(t, repo, mainTip, rslTip, gpgKeyBytes, false)
+
+		err := repo.VerifyProposedUpdates(testCtx, updates, nil)
+		assert.ErrorIs(t, err, ErrUpdateNotRecordedInRSL)
+	})
+
+	t.Run("RSL rewritten", func(t *testing.T) {
+		repo, _, rslTip := setup(t)
+
+		rslTipCommit, err := gitinterface.GetCommit(repo.r, rslTip)
+		if err != nil {
+			t.Fatal(err)
+		}
+		updates := []*gitinterface.ReferenceUpdate{{RefName: rsl.Ref, OldID: rslTip, NewID: rslTipCommit.ParentHashes[0]}}
+
+		err = repo.VerifyProposedUpdates(testCtx, updates, nil)
+		assert.ErrorIs(t, err, ErrRSLRewritten)
+
+		updates = []*gitinterface.ReferenceUpdate{{RefName: rsl.Ref, OldID: rslTip, NewID: plumbing.ZeroHash}}
+		err = repo.VerifyProposedUpdates(testCtx, updates, nil)
+		assert.ErrorIs(t, err, ErrRSLRewritten)
+	})
+
+	t.Run("gittuf references not recorded in RSL are ignored", func(t *testing.T) {
+		repo, _, _ := setup(t)
+
+		updates := []*gitinterface.ReferenceUpdate{{RefName: policy.PolicyStagingRef, NewID: plumbing.ZeroHash}}
+		err := repo.VerifyProposedUpdates(testCtx, updates, nil)
+		assert.Nil(t, err)
+	})
+}
@@ -0,0 +1,412 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	sslibdsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
+)
+
+var ErrNoCompromisedKeys = errors.New("no compromised keys specified")
+
+// RecoveryReport records the actions taken, or in the case of a dry run, the
+// actions that would be taken, to recover from the compromise of one or more
+// keys.
+type RecoveryReport struct {
+	DryRun            bool                    `json:"dry_run"`
+	CompromisedKeyIDs []string                `json:"compromised_keyids"`
+	RevokedKeyIDs     []string                `json:"revoked_keyids"`
+	SkippedEntries    []*RecoverySkippedEntry `json:"skipped_entries"`
+	AnnotationEntryID string                  `json:"annotation_entry,omitempty"`
+	RestoredRefs      []*RecoveryRestoredRef  `json:"restored_refs"`
+	UnrestoredRefs    []string                `json:"unrestored_refs,omitempty"`
+}
+
+// RecoverySkippedEntry is an RSL entry that is skipped during recovery. If
+// the entry was signed using a compromised key, the key's ID is recorded in
+// SignedBy. Otherwise, the entry follows an entry for the same reference that
+// was signed using a compromised key.
+type RecoverySkippedEntry struct {
+	EntryID  string `json:"rsl_entry"`
+	RefName  string `json:"ref"`
+	TargetID string `json:"target"`
+	SignedBy string `json:"signed_by,omitempty"`
+}
+
+// RecoveryRestoredRef is a reference that is restored to its last known good
+// state during recovery. For branches, a commit that is tree-same with the
+// good target is added on top of the compromised target, so that clones that
+// fetched the compromised target can fast-forward. Other references are reset
+// to the good target.
+type RecoveryRestoredRef struct {
+	RefName           string `json:"ref"`
+	CompromisedTarget string `json:"compromised_target"`
+	GoodTarget        string `json:"good_target"`
+	Target            string `json:"target,omitempty"`
+	EntryID           string `json:"rsl_entry,omitempty"`
+}
+
+// RecoverFromKeyCompromise is the interface for the user to recover the
+// repository after one or more keys are compromised. The recovery is performed
+// as a sequence of signed updates:
+//
+//  1. The compromised keys are revoked in the root of trust using signer,
+//     which must be trusted for the root role. Keys that are already revoked
+//     are not revoked again.
+//  2. The RSL entries signed using a compromised key are identified, starting
+//     from sinceEntryID if specified or the beginning of the RSL otherwise.
+//     These entries, along with every subsequent entry for the same
+//     references, are skipped using a single RSL annotation.
+//  3. Each affected reference is restored to the target of its latest entry
+//     before the first compromised entry, and the restored state is recorded in
+//     the RSL.
+//
+// Entries for gittuf's references are not considered. Affected references
+// without a prior good state are not restored and are listed in the report for
+// manual remediation. If dryRun is set, the report is returned without making
+// any changes.
+func (r *Repository) RecoverFromKeyCompromise(ctx context.Context, signer sslibdsse.SignerVerifier, keyIDs []string, reason, sinceEntryID string, dryRun, signCommit bool) (*RecoveryReport, error) {
+	if len(keyIDs) == 0 {
+		return nil, ErrNoCompromisedKeys
+	}
+
+	rootKeyID, err := signer.KeyID()
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := r.loadStateForUpdate(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rootMetadata, err := r.loadRootMetadata(state, rootKeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	compromisedKeys, err := getCompromisedKeys(state, keyIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &RecoveryReport{
+		DryRun:            dryRun,
+		CompromisedKeyIDs: keyIDs,
+		RevokedKeyIDs:     []string{},
+		SkippedEntries:    []*RecoverySkippedEntry{},
+		RestoredRefs:      []*RecoveryRestoredRef{},
+	}
+
+	slog.Debug("Identifying RSL entries signed using compromised keys...")
+	skippedEntries, firstCompromisedEntries, err := r.findCompromisedEntries(ctx, compromisedKeys, sinceEntryID)
+	if err != nil {
+		return nil, err
+	}
+	report.SkippedEntries = skippedEntries
+
+	refNames := make([]string, 0, len(firstCompromisedEntries))
+	for refName := range firstCompromisedEntries {
+		refNames = append(refNames, refName)
+	}
+	sort.Strings(refNames)
+
+	for _, refName := range refNames {
+		goodEntry, _, err := rsl.GetLatestUnskippedReferenceEntryForRefBefore(r.r, refName, firstCompromisedEntries[refName])
+		if err != nil {
+			if !errors.Is(err, rsl.ErrRSLEntryNotFound) {
+				return nil, err
+			}
+			report.UnrestoredRefs = append(report.UnrestoredRefs, refName)
+			continue
+		}
+
+		latestEntry, _, err := rsl.GetLatestReferenceEntryForRef(r.r, refName)
+		if err != nil {
+			return nil, err
+		}
+
+		report.RestoredRefs = append(report.RestoredRefs, &RecoveryRestoredRef{
+			RefName:           refName,
+			CompromisedTarget: latestEntry.TargetID.String(),
+			GoodTarget:        goodEntry.TargetID.String(),
+		})
+	}
+
+	for _, keyID := range keyIDs {
+		if !rootMetadata.IsKeyRevoked(keyID) {
+			report.RevokedKeyIDs = append(report.RevokedKeyIDs, keyID)
+		}
+	}
+
+	if dryRun {
+		return report, nil
+	}
+
+	// Restoring branches requires the local branches to match the RSL, this is
+	// checked before any changes are made
+	for _, restoredRef := range report.RestoredRefs {
+		if strings.HasPrefix(restoredRef.RefName, gitinterface.BranchRefPrefix) {
+			if err := r.verifyRefTip(restoredRef.RefName, plumbing.NewHash(restoredRef.CompromisedTarget)); err != nil {
+				return nil, fmt.Errorf("unable to restore '%s': %w", restoredRef.RefName, err)
+			}
+		}
+	}
+
+	if len(report.RevokedKeyIDs) > 0 {
+		for _, keyID := range report.RevokedKeyIDs {
+			slog.Debug(fmt.Sprintf("Revoking key '%s'...", keyID))
+			rootMetadata, err = policy.RevokeKey(rootMetadata, keyID, reason)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		revoked := map[string]bool{}
+		for _, keyID := range report.RevokedKeyIDs {
+			revoked[keyID] = true
+		}
+		newRootPublicKeys := []*tuf.Key{}
+		for _, key := range state.RootPublicKeys {
+			if !revoked[key.KeyID] {
+				newRootPublicKeys = append(newRootPublicKeys, key)
+			}
+		}
+		state.RootPublicKeys = newRootPublicKeys
+
+		commitMessage := fmt.Sprintf("Revoke compromised keys '%s'\n\nReason: %s", strings.Join(report.RevokedKeyIDs, "', '"), reason)
+		if err := r.updateRootMetadata(ctx, state, signer, rootMetadata, commitMessage, signCommit); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(report.SkippedEntries) > 0 {
+		entryIDs := make([]plumbing.Hash, 0, len(report.SkippedEntries))
+		for _, skippedEntry := range report.SkippedEntries {
+			entryIDs = append(entryIDs, plumbing.NewHash(skippedEntry.EntryID))
+		}
+
+		slog.Debug("Creating RSL annotation to skip compromised entries...")
+		message := fmt.Sprintf("Skip entries after compromise of keys '%s'", strings.Join(keyIDs, "', '"))
+		if err := rsl.NewSkipAnnotationEntry(entryIDs, rsl.SkipReasonRevokedKey, message).Commit(r.r, signCommit); err != nil {
+			return nil, err
+		}
+
+		annotationEntry, err := rsl.GetLatestEntry(r.r)
+		if err != nil {
+			return nil, err
+		}
+		report.AnnotationEntryID = annotationEntry.GetID().String()
+	}
+
+	for _, restoredRef := range report.RestoredRefs {
+		if err := r.restoreRef(restoredRef, signCommit); err != nil {
+			return nil, fmt.Errorf("unable to restore '%s': %w", restoredRef.RefName, err)
+		}
+	}
+
+	return report, nil
+}
+
+// getCompromisedKeys returns the public keys for keyIDs from the state,
+// including keys that have already been revoked.
+func getCompromisedKeys(state *policy.State, keyIDs []string) ([]*tuf.Key, error) {
+	publicKeys, err := state.PublicKeys()
+	if err != nil {
+		return nil, err
+	}
+	revokedKeys, err := state.RevokedPublicKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	compromisedKeys := make([]*tuf.Key, 0, len(keyIDs))
+	for _, keyID := range keyIDs {
+		key, has := publicKeys[keyID]
+		if !has {
+			key, has = revokedKeys[keyID]
+		}
+		if !has {
+			return nil, fmt.Errorf("%w: '%s'", policy.ErrKeyNotInPolicy, keyID)
+		}
+		compromisedKeys = append(compromisedKeys, key)
+	}
+
+	return compromisedKeys, nil
+}
+
+// findCompromisedEntries returns the unskipped RSL entries from sinceEntryID
+// that were signed using one of the compromised keys, along with every
+// subsequent entry for the same references. It also returns the first entry
+// signed using a compromised key for each affected reference. If sinceEntryID
+// is empty, the search starts from the beginning of the RSL or its latest
+// checkpoint.
+func (r *Repository) findCompromisedEntries(ctx context.Context, compromisedKeys []*tuf.Key, sinceEntryID string) ([]*RecoverySkippedEntry, map[string]plumbing.Hash, error) {
+	sinceID := plumbing.ZeroHash
+	if sinceEntryID != "" {
+		sinceID = plumbing.NewHash(sinceEntryID)
+		if _, err := rsl.GetEntry(r.r, sinceID); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	iterator, err := rsl.GetLatestEntry(r.r)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	annotations := []*rsl.AnnotationEntry{}
+	entries := []*rsl.ReferenceEntry{}
+	for {
+		switch entry := iterator.(type) {
+		case *rsl.AnnotationEntry:
+			annotations = append(annotations, entry)
+		case *rsl.ReferenceEntry:
+			if !strings.HasPrefix(entry.RefName, rsl.GittufNamespacePrefix) {
+				entries = append(entries, entry)
+			}
+		}
+
+		if _, isCheckpoint := iterator.(*rsl.CheckpointEntry); isCheckpoint || iterator.GetID() == sinceID {
+			break
+		}
+
+		iterator, err = rsl.GetParentForEntry(r.r, iterator)
+		if err != nil {
+			if errors.Is(err, rsl.ErrRSLEntryNotFound) {
+				break
+			}
+			return nil, nil, err
+		}
+	}
+
+	skippedEntries := []*RecoverySkippedEntry{}
+	firstCompromisedEntries := map[string]plumbing.Hash{}
+	// Entries were collected from the latest, so they're processed in reverse
+	for i := len(entries) - 1; i >= 0; i-- {
+		entry := entries[i]
+		if entry.SkippedBy(annotations) {
+			continue
+		}
+
+		signedBy, err := r.getSigningKeyID(ctx, entry, compromisedKeys)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		if signedBy != "" {
+			if _, has := firstCompromisedEntries[entry.RefName]; !has {
+				firstCompromisedEntries[entry.RefName] = entry.ID
+			}
+		} else if _, has := firstCompromisedEntries[entry.RefName]; !has {
+			continue
+		}
+
+		skippedEntries = append(skippedEntries, &RecoverySkippedEntry{
+			EntryID:  entry.ID.String(),
+			RefName:  entry.RefName,
+			TargetID: entry.TargetID.String(),
+			SignedBy: signedBy,
+		})
+	}
+
+	return skippedEntries, firstCompromisedEntries, nil
+}
+
+// getSigningKeyID returns the ID of the key in keys that signed the RSL entry.
+// If none of the keys signed the entry, an empty string is returned.
+func (r *Repository) getSigningKeyID(ctx context.Context, entry *rsl.ReferenceEntry, keys []*tuf.Key) (string, error) {
+	entryCommit, err := gitinterface.GetCommit(r.r, entry.ID)
+	if err != nil {
+		return "", err
+	}
+
+	for _, key := range keys {
+		if err := gitinterface.VerifyCommitSignature(ctx, entryCommit, key); err == nil {
+			return key.KeyID, nil
+		}
+	}
+
+	return "", nil
+}
+
+// restoreRef restores the reference to its good target and records the
+// restored state in the RSL.
+func (r *Repository) restoreRef(restoredRef *RecoveryRestoredRef, signCommit bool) error {
+	goodTarget := plumbing.NewHash(restoredRef.GoodTarget)
+
+	if strings.HasPrefix(restoredRef.RefName, gitinterface.BranchRefPrefix) {
+		goodCommit, err := gitinterface.GetCommit(r.r, goodTarget)
+		if err != nil {
+			return err
+		}
+
+		slog.Debug(fmt.Sprintf("Adding commit to '%s' that restores '%s'...", restoredRef.RefName, restoredRef.GoodTarget))
+		message := fmt.Sprintf("Restore '%s' to '%s' after key compromise", restoredRef.RefName, restoredRef.GoodTarget)
+		restoredCommitID, err := gitinterface.Commit(r.r, goodCommit.TreeHash, restoredRef.RefName, message, signCommit)
+		if err != nil {
+			return err
+		}
+		if err := r.resetWorktreeIfCheckedOut(restoredRef.RefName, restoredCommitID); err != nil {
+			return err
+		}
+	} else {
+		slog.Debug(fmt.Sprintf("Resetting '%s' to '%s'...", restoredRef.RefName, restoredRef.GoodTarget))
+		if err := r.r.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(restoredRef.RefName), goodTarget)); err != nil {
+			return err
+		}
+	}
+
+	ref, err := r.r.Reference(plumbing.ReferenceName(restoredRef.RefName), true)
+	if err != nil {
+		return err
+	}
+	restoredRef.Target = ref.Hash().String()
+
+	slog.Debug(fmt.Sprintf("Recording restored state of '%s' in RSL...", restoredRef.RefName))
+	if err := rsl.NewReferenceEntry(restoredRef.RefName, ref.Hash()).Commit(r.r, signCommit); err != nil {
+		return err
+	}
+
+	latestEntry, err := rsl.GetLatestEntry(r.r)
+	if err != nil {
+		return err
+	}
+	restoredRef.EntryID = latestEntry.GetID().String()
+
+	return nil
+}
+
+// resetWorktreeIfCheckedOut updates the worktree to the target if the
+// reference is checked out.
+func (r *Repository) resetWorktreeIfCheckedOut(refName string, targetID plumbing.Hash) error {
+	head, err := r.r.Reference(plumbing.HEAD, false)
+	if err != nil {
+		return err
+	}
+	if head.Type() != plumbing.SymbolicReference || head.Target().String() != refName {
+		return nil
+	}
+
+	worktree, err := r.r.Worktree()
+	if err != nil {
+		if errors.Is(err, git.ErrIsBareRepository) {
+			return nil
+		}
+		return err
+	}
+
+	return worktree.Reset(&git.ResetOptions{Commit: targetID, Mode: git.MergeReset})
+}
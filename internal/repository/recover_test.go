@@ -0,0 +1,160 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/common"
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/internal/signerverifier"
+	"github.com/gittuf/gittuf/internal/signerverifier/gpg"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecoverFromKeyCompromise(t *testing.T) {
+	mainRef := "refs/heads/main"
+	featureRef := "refs/heads/feature"
+
+	rootSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(rootKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	compromisedKey, err := gpg.LoadGPGKeyFromBytes(gpgKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// setup creates a repository where the entries for main are: a good entry,
+	// an entry signed using the compromised key, and a subsequent entry. The
+	// only entry for feature is signed using the compromised key.
+	setup := func(t *testing.T) (*Repository, plumbing.Hash, []plumbing.Hash) {
+		t.Helper()
+
+		r := createTestRepositoryWithPolicy(t, "")
+
+		goodCommitIDs := common.AddNTestCommitsToSpecifiedRef(t, r.r, mainRef, 1, gpgUnauthorizedKeyBytes)
+		common.CreateTestRSLReferenceEntryCommit(t, r.r, rsl.NewReferenceEntry(mainRef, goodCommitIDs[0]), gpgUnauthorizedKeyBytes)
+
+		compromisedCommitIDs := common.AddNTestCommitsToSpecifiedRef(t, r.r, mainRef, 2, gpgKeyBytes)
+		compromisedEntryIDs := []plumbing.Hash{
+			common.CreateTestRSLReferenceEntryCommit(t, r.r, rsl.NewReferenceEntry(mainRef, compromisedCommitIDs[0]), gpgKeyBytes),
+			common.CreateTestRSLReferenceEntryCommit(t, r.r, rsl.NewReferenceEntry(mainRef, compromisedCommitIDs[1]), gpgUnauthorizedKeyBytes),
+		}
+
+		featureCommitIDs := common.AddNTestCommitsToSpecifiedRef(t, r.r, featureRef, 1, gpgKeyBytes)
+		compromisedEntryIDs = append(compromisedEntryIDs, common.CreateTestRSLReferenceEntryCommit(t, r.r, rsl.NewReferenceEntry(featureRef, featureCommitIDs[0]), gpgKeyBytes))
+
+		return r, goodCommitIDs[0], compromisedEntryIDs
+	}
+
+	t.Run("recover", func(t *testing.T) {
+		r, goodCommitID, compromisedEntryIDs := setup(t)
+
+		compromisedTip, err := r.r.Reference(plumbing.ReferenceName(mainRef), true)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		report, err := r.RecoverFromKeyCompromise(testCtx, rootSigner, []string{compromisedKey.KeyID}, "key leaked", "", false, false)
+		assert.Nil(t, err)
+		assert.False(t, report.DryRun)
+		assert.Equal(t, []string{compromisedKey.KeyID}, report.RevokedKeyIDs)
+		assert.Equal(t, []string{featureRef}, report.UnrestoredRefs)
+
+		skippedEntryIDs := []string{}
+		for _, skippedEntry := range report.SkippedEntries {
+			skippedEntryIDs = append(skippedEntryIDs, skippedEntry.EntryID)
+		}
+		assert.Equal(t, []string{compromisedEntryIDs[0].String(), compromisedEntryIDs[1].String(), compromisedEntryIDs[2].String()}, skippedEntryIDs)
+		assert.Equal(t, compromisedKey.KeyID, report.SkippedEntries[0].SignedBy)
+		assert.Empty(t, report.SkippedEntries[1].SignedBy)
+
+		annotations, err := rsl.GetAnnotationsForEntry(r.r, compromisedEntryIDs[0])
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, 1, len(annotations))
+		assert.Equal(t, report.AnnotationEntryID, annotations[0].ID.String())
+		assert.Equal(t, rsl.SkipReasonRevokedKey, annotations[0].SkipReason)
+
+		// main is restored using a commit that is tree-same with the good
+		// commit, and the restored state is recorded in the RSL
+		assert.Equal(t, 1, len(report.RestoredRefs))
+		restoredRef := report.RestoredRefs[0]
+		assert.Equal(t, mainRef, restoredRef.RefName)
+		assert.Equal(t, compromisedTip.Hash().String(), restoredRef.CompromisedTarget)
+		assert.Equal(t, goodCommitID.String(), restoredRef.GoodTarget)
+
+		restoredCommit, err := gitinterface.GetCommit(r.r, plumbing.NewHash(restoredRef.Target))
+		if err != nil {
+			t.Fatal(err)
+		}
+		goodCommit, err := gitinterface.GetCommit(r.r, goodCommitID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, goodCommit.TreeHash, restoredCommit.TreeHash)
+		assert.Equal(t, []plumbing.Hash{compromisedTip.Hash()}, restoredCommit.ParentHashes)
+
+		latestEntry, _, err := rsl.GetLatestUnskippedReferenceEntryForRef(r.r, mainRef)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, restoredRef.EntryID, latestEntry.ID.String())
+		assert.Equal(t, restoredCommit.Hash, latestEntry.TargetID)
+
+		state, err := policy.LoadCurrentState(testCtx, r.r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rootMetadata, err := state.GetRootMetadata()
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.True(t, rootMetadata.IsKeyRevoked(compromisedKey.KeyID))
+	})
+
+	t.Run("dry run", func(t *testing.T) {
+		r, _, _ := setup(t)
+
+		latestEntry, err := rsl.GetLatestEntry(r.r)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		report, err := r.RecoverFromKeyCompromise(testCtx, rootSigner, []string{compromisedKey.KeyID}, "key leaked", "", true, false)
+		assert.Nil(t, err)
+		assert.True(t, report.DryRun)
+		assert.Equal(t, 3, len(report.SkippedEntries))
+		assert.Equal(t, 1, len(report.RestoredRefs))
+		assert.Empty(t, report.RestoredRefs[0].EntryID)
+
+		currentEntry, err := rsl.GetLatestEntry(r.r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, latestEntry.GetID(), currentEntry.GetID())
+	})
+
+	t.Run("since entry", func(t *testing.T) {
+		r, _, compromisedEntryIDs := setup(t)
+
+		report, err := r.RecoverFromKeyCompromise(testCtx, rootSigner, []string{compromisedKey.KeyID}, "key leaked", compromisedEntryIDs[2].String(), true, false)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, len(report.SkippedEntries))
+		assert.Equal(t, featureRef, report.SkippedEntries[0].RefName)
+		assert.Empty(t, report.RestoredRefs)
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		r, _, _ := setup(t)
+
+		_, err := r.RecoverFromKeyCompromise(testCtx, rootSigner, []string{"unknown"}, "key leaked", "", false, false)
+		assert.ErrorIs(t, err, policy.ErrKeyNotInPolicy)
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"os"
 
 	"github.com/gittuf/gittuf/internal/attestations"
 	"github.com/gittuf/gittuf/internal/policy"
@@ -18,6 +19,8 @@ var (
 	ErrCannotReinitialize = errors.New("cannot reinitialize metadata, it exists already")
 )
 
+const gitDirKey = "GIT_DIR"
+
 type Repository struct {
 	r *git.Repository
 }
@@ -26,6 +29,32 @@ func LoadRepository() (*Repository, error) {
 	slog.Debug("Loading Git repository...")
 
 	repo, err := git.PlainOpenWithOptions(".", &git.PlainOpenOptions{DetectDotGit: true})
+	if errors.Is(err, git.ErrRepositoryNotExists) {
+		// The current directory may be a bare repository, such as when
+		// gittuf is invoked by a hook on a Git server, or the Git directory
+		// may be identified using GIT_DIR, such as in mirror jobs
+		gitDir := os.Getenv(gitDirKey)
+		if gitDir == "" {
+			gitDir = "."
+		}
+		repo, err = git.PlainOpen(gitDir)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &Repository{
+		r: repo,
+	}, nil
+}
+
+// LoadRepositoryAt loads the Git repository whose Git directory is at the
+// specified path. This is used when gittuf is invoked by Git with the Git
+// directory identified explicitly, such as in remote helpers.
+func LoadRepositoryAt(gitDir string) (*Repository, error) {
+	slog.Debug(fmt.Sprintf("Loading Git repository at '%s'...", gitDir))
+
+	repo, err := git.PlainOpen(gitDir)
 	if err != nil {
 		return nil, err
 	}
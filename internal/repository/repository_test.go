@@ -4,8 +4,13 @@ package repository
 
 import (
 	"context"
+	"os"
 	"testing"
 
+	"github.com/gittuf/gittuf/internal/common"
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/rsl"
 	"github.com/gittuf/gittuf/internal/signerverifier"
 	"github.com/gittuf/gittuf/internal/tuf"
 	"github.com/go-git/go-billy/v5/memfs"
@@ -18,6 +23,92 @@ func TestLoadRepository(t *testing.T) {
 	repository, err := LoadRepository()
 	assert.Nil(t, err)
 	assert.NotNil(t, repository.r)
+
+	currentDir, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(currentDir) //nolint:errcheck
+
+	t.Run("bare repository", func(t *testing.T) {
+		gitDir := t.TempDir()
+		if _, err := git.PlainInit(gitDir, true); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chdir(gitDir); err != nil {
+			t.Fatal(err)
+		}
+
+		repository, err := LoadRepository()
+		assert.Nil(t, err)
+		assert.True(t, isBare(t, repository))
+	})
+
+	t.Run("repository identified using GIT_DIR", func(t *testing.T) {
+		gitDir := t.TempDir()
+		if _, err := git.PlainInit(gitDir, true); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Chdir(t.TempDir()); err != nil {
+			t.Fatal(err)
+		}
+		t.Setenv(gitDirKey, gitDir)
+
+		repository, err := LoadRepository()
+		assert.Nil(t, err)
+		assert.True(t, isBare(t, repository))
+	})
+}
+
+func TestBareRepository(t *testing.T) {
+	// createTestRepositoryWithPolicy creates bare repositories on disk
+	repo := createTestRepositoryWithPolicy(t, t.TempDir())
+	assert.True(t, isBare(t, repo))
+
+	refName := "refs/heads/main"
+	commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo.r, refName, 2, gpgKeyBytes)
+	common.CreateTestRSLReferenceEntryCommit(t, repo.r, rsl.NewReferenceEntry(refName, commitIDs[1]), gpgKeyBytes)
+
+	// Unprotected refs are recorded and verified without signatures
+	unprotectedRefName := "refs/heads/feature"
+	common.AddNTestCommitsToSpecifiedRef(t, repo.r, unprotectedRefName, 1, gpgKeyBytes)
+	err := repo.RecordRSLEntryForReference(unprotectedRefName, false)
+	assert.Nil(t, err)
+	err = repo.VerifyRef(testCtx, unprotectedRefName, false)
+	assert.Nil(t, err)
+
+	rules, err := repo.ListRules(testCtx)
+	assert.Nil(t, err)
+	assert.Len(t, rules, 1)
+
+	err = repo.VerifyRef(testCtx, refName, false, WithJobs(2))
+	assert.Nil(t, err)
+
+	// Policy updates are recorded without a worktree
+	targetsSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(targetsKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = repo.RemoveDelegation(testCtx, targetsSigner, policy.TargetsRoleName, "protect-main", false)
+	assert.Nil(t, err)
+
+	rules, err = repo.ListRules(testCtx)
+	assert.Nil(t, err)
+	assert.Empty(t, rules)
+
+	tip, err := gitinterface.GetTip(repo.r, refName)
+	assert.Nil(t, err)
+	assert.Equal(t, commitIDs[1], tip)
+}
+
+func isBare(t *testing.T, repo *Repository) bool {
+	t.Helper()
+
+	config, err := repo.r.Config()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return config.Core.IsBare
 }
 
 func TestInitializeNamespaces(t *testing.T) {
@@ -5,14 +5,17 @@ package repository
 import (
 	"context"
 	"encoding/base64"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"log/slog"
+	"strings"
 
+	"github.com/gittuf/gittuf/internal/gitinterface"
 	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/signerverifier"
 	"github.com/gittuf/gittuf/internal/signerverifier/dsse"
-	sslibsv "github.com/gittuf/gittuf/internal/third_party/go-securesystemslib/signerverifier"
 	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/go-git/go-git/v5/plumbing"
 	sslibdsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
 )
 
@@ -23,8 +26,7 @@ func (r *Repository) InitializeRoot(ctx context.Context, signer sslibdsse.Signer
 		return err
 	}
 
-	rawKey := signer.Public()
-	publicKey, err := sslibsv.NewKey(rawKey)
+	publicKey, err := signerverifier.NewKeyFromSigner(signer)
 	if err != nil {
 		return err
 	}
@@ -62,8 +64,7 @@ func (r *Repository) AddRootKey(ctx context.Context, signer sslibdsse.SignerVeri
 		return err
 	}
 
-	slog.Debug("Loading current policy...")
-	state, err := policy.LoadCurrentState(ctx, r.r)
+	state, err := r.loadStateForUpdate(ctx)
 	if err != nil {
 		return err
 	}
@@ -73,6 +74,10 @@ func (r *Repository) AddRootKey(ctx context.Context, signer sslibdsse.SignerVeri
 		return err
 	}
 
+	if rootMetadata.IsKeyRevoked(newRootKey.KeyID) {
+		return policy.ErrKeyAlreadyRevoked
+	}
+
 	slog.Debug("Adding root key...")
 	rootMetadata = policy.AddRootKey(rootMetadata, newRootKey)
 
@@ -99,8 +104,7 @@ func (r *Repository) RemoveRootKey(ctx context.Context, signer sslibdsse.SignerV
 		return err
 	}
 
-	slog.Debug("Loading current policy...")
-	state, err := policy.LoadCurrentState(ctx, r.r)
+	state, err := r.loadStateForUpdate(ctx)
 	if err != nil {
 		return err
 	}
@@ -136,8 +140,7 @@ func (r *Repository) AddTopLevelTargetsKey(ctx context.Context, signer sslibdsse
 		return err
 	}
 
-	slog.Debug("Loading current policy...")
-	state, err := policy.LoadCurrentState(ctx, r.r)
+	state, err := r.loadStateForUpdate(ctx)
 	if err != nil {
 		return err
 	}
@@ -165,8 +168,7 @@ func (r *Repository) RemoveTopLevelTargetsKey(ctx context.Context, signer sslibd
 		return err
 	}
 
-	slog.Debug("Loading current policy...")
-	state, err := policy.LoadCurrentState(ctx, r.r)
+	state, err := r.loadStateForUpdate(ctx)
 	if err != nil {
 		return err
 	}
@@ -194,8 +196,7 @@ func (r *Repository) UpdateTopLevelTargetsThreshold(ctx context.Context, signer
 		return err
 	}
 
-	slog.Debug("Loading current policy...")
-	state, err := policy.LoadCurrentState(ctx, r.r)
+	state, err := r.loadStateForUpdate(ctx)
 	if err != nil {
 		return err
 	}
@@ -215,6 +216,272 @@ func (r *Repository) UpdateTopLevelTargetsThreshold(ctx context.Context, signer
 	return r.updateRootMetadata(ctx, state, signer, rootMetadata, commitMessage, signCommit)
 }
 
+// UpdateRootThreshold sets the threshold of valid signatures required for the
+// Root role. If the current threshold is greater than one, the change is staged
+// until it is signed by enough root keys using SignRoot.
+func (r *Repository) UpdateRootThreshold(ctx context.Context, signer sslibdsse.SignerVerifier, threshold int, signCommit bool) error {
+	rootKeyID, err := signer.KeyID()
+	if err != nil {
+		return err
+	}
+
+	state, err := r.loadStateForUpdate(ctx)
+	if err != nil {
+		return err
+	}
+
+	rootMetadata, err := r.loadRootMetadata(state, rootKeyID)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Updating root threshold...")
+	rootMetadata, err = policy.UpdateRootThreshold(rootMetadata, threshold)
+	if err != nil {
+		return err
+	}
+
+	commitMessage := fmt.Sprintf("Update root threshold to %d", threshold)
+	return r.updateRootMetadata(ctx, state, signer, rootMetadata, commitMessage, signCommit)
+}
+
+// AddRepositoryRoot is the interface for the user to record the root keys and
+// threshold expected for the root of trust of a repository in the
+// organization, when this repository is the organization's root of trust.
+func (r *Repository) AddRepositoryRoot(ctx context.Context, signer sslibdsse.SignerVerifier, repositoryName string, rootKeys []*tuf.Key, threshold int, signCommit bool) error {
+	rootKeyID, err := signer.KeyID()
+	if err != nil {
+		return err
+	}
+
+	state, err := r.loadStateForUpdate(ctx)
+	if err != nil {
+		return err
+	}
+
+	rootMetadata, err := r.loadRootMetadata(state, rootKeyID)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug(fmt.Sprintf("Adding root of trust for repository '%s'...", repositoryName))
+	rootMetadata, err = policy.AddRepositoryRoot(rootMetadata, repositoryName, rootKeys, threshold)
+	if err != nil {
+		return err
+	}
+
+	commitMessage := fmt.Sprintf("Add root of trust for repository '%s'", repositoryName)
+	return r.updateRootMetadata(ctx, state, signer, rootMetadata, commitMessage, signCommit)
+}
+
+// RemoveRepositoryRoot is the interface for the user to remove the expected
+// root of trust of a repository from the organization's root of trust.
+func (r *Repository) RemoveRepositoryRoot(ctx context.Context, signer sslibdsse.SignerVerifier, repositoryName string, signCommit bool) error {
+	rootKeyID, err := signer.KeyID()
+	if err != nil {
+		return err
+	}
+
+	state, err := r.loadStateForUpdate(ctx)
+	if err != nil {
+		return err
+	}
+
+	rootMetadata, err := r.loadRootMetadata(state, rootKeyID)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug(fmt.Sprintf("Removing root of trust for repository '%s'...", repositoryName))
+	rootMetadata, err = policy.RemoveRepositoryRoot(rootMetadata, repositoryName)
+	if err != nil {
+		return err
+	}
+
+	commitMessage := fmt.Sprintf("Remove root of trust for repository '%s'", repositoryName)
+	return r.updateRootMetadata(ctx, state, signer, rootMetadata, commitMessage, signCommit)
+}
+
+// AddGlobalRule is the interface for the user to add a global rule to the
+// root of trust. The rule requires threshold signatures for changes to the Git
+// references that match rulePatterns, regardless of the rules in policy.
+func (r *Repository) AddGlobalRule(ctx context.Context, signer sslibdsse.SignerVerifier, ruleName string, rulePatterns []string, threshold int, signCommit bool) error {
+	rootKeyID, err := signer.KeyID()
+	if err != nil {
+		return err
+	}
+
+	state, err := r.loadStateForUpdate(ctx)
+	if err != nil {
+		return err
+	}
+
+	rootMetadata, err := r.loadRootMetadata(state, rootKeyID)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug(fmt.Sprintf("Adding global rule '%s'...", ruleName))
+	rootMetadata, err = policy.AddGlobalRule(rootMetadata, ruleName, rulePatterns, threshold)
+	if err != nil {
+		return err
+	}
+
+	commitMessage := fmt.Sprintf("Add global rule '%s'", ruleName)
+	return r.updateRootMetadata(ctx, state, signer, rootMetadata, commitMessage, signCommit)
+}
+
+// RemoveGlobalRule is the interface for the user to remove a global rule from
+// the root of trust.
+func (r *Repository) RemoveGlobalRule(ctx context.Context, signer sslibdsse.SignerVerifier, ruleName string, signCommit bool) error {
+	rootKeyID, err := signer.KeyID()
+	if err != nil {
+		return err
+	}
+
+	state, err := r.loadStateForUpdate(ctx)
+	if err != nil {
+		return err
+	}
+
+	rootMetadata, err := r.loadRootMetadata(state, rootKeyID)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug(fmt.Sprintf("Removing global rule '%s'...", ruleName))
+	rootMetadata, err = policy.RemoveGlobalRule(rootMetadata, ruleName)
+	if err != nil {
+		return err
+	}
+
+	commitMessage := fmt.Sprintf("Remove global rule '%s'", ruleName)
+	return r.updateRootMetadata(ctx, state, signer, rootMetadata, commitMessage, signCommit)
+}
+
+// SetOrganizationRoot is the interface for the user to record that the
+// repository's root of trust is delegated to by the organization root of
+// trust at location, under repositoryName. If location is empty, the
+// organization root of trust is unset.
+func (r *Repository) SetOrganizationRoot(ctx context.Context, signer sslibdsse.SignerVerifier, location, repositoryName string, signCommit bool) error {
+	rootKeyID, err := signer.KeyID()
+	if err != nil {
+		return err
+	}
+
+	state, err := r.loadStateForUpdate(ctx)
+	if err != nil {
+		return err
+	}
+
+	rootMetadata, err := r.loadRootMetadata(state, rootKeyID)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Setting organization root of trust...")
+	rootMetadata, err = policy.SetOrganizationRoot(rootMetadata, location, repositoryName)
+	if err != nil {
+		return err
+	}
+
+	commitMessage := fmt.Sprintf("Set organization root of trust to '%s'", location)
+	if location == "" {
+		commitMessage = "Unset organization root of trust"
+	}
+	return r.updateRootMetadata(ctx, state, signer, rootMetadata, commitMessage, signCommit)
+}
+
+// SetAlgorithmPolicy is the interface for the user to set the minimum
+// acceptable signing algorithms in the root of trust. If algorithmPolicy
+// places no restrictions, the algorithm policy is unset.
+func (r *Repository) SetAlgorithmPolicy(ctx context.Context, signer sslibdsse.SignerVerifier, algorithmPolicy *tuf.AlgorithmPolicy, signCommit bool) error {
+	rootKeyID, err := signer.KeyID()
+	if err != nil {
+		return err
+	}
+
+	state, err := r.loadStateForUpdate(ctx)
+	if err != nil {
+		return err
+	}
+
+	rootMetadata, err := r.loadRootMetadata(state, rootKeyID)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Setting algorithm policy...")
+	rootMetadata, err = policy.SetAlgorithmPolicy(rootMetadata, algorithmPolicy)
+	if err != nil {
+		return err
+	}
+
+	commitMessage := "Set algorithm policy"
+	if rootMetadata.AlgorithmPolicy == nil {
+		commitMessage = "Unset algorithm policy"
+	}
+	return r.updateRootMetadata(ctx, state, signer, rootMetadata, commitMessage, signCommit)
+}
+
+// SignRoot adds the signer's signature to the staged root of trust. Once the
+// staged root of trust is signed by a threshold of the currently trusted root
+// keys, the staged changes are committed to the policy namespace.
+func (r *Repository) SignRoot(ctx context.Context, signer sslibdsse.SignerVerifier, signCommit bool) error {
+	rootKeyID, err := signer.KeyID()
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Loading staged policy...")
+	state, err := policy.LoadStagedState(ctx, r.r)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Loading current policy...")
+	currentState, err := policy.LoadCurrentState(ctx, r.r)
+	if err != nil {
+		return err
+	}
+
+	// Only signatures from currently trusted root keys count towards the
+	// threshold
+	if _, err := r.loadRootMetadata(currentState, rootKeyID); err != nil {
+		return err
+	}
+
+	slog.Debug("Signing staged root metadata...")
+	env, err := dsse.SignEnvelope(ctx, state.RootEnvelope, signer)
+	if err != nil {
+		return err
+	}
+	state.RootEnvelope = env
+
+	commitMessage, err := r.loadStagedCommitMessage()
+	if err != nil {
+		return err
+	}
+
+	return r.commitPolicyUpdate(ctx, state, commitMessage, signCommit)
+}
+
+// loadStagedCommitMessage returns the message recorded for the staged policy
+// changes, so that it can be reused when the changes are committed.
+func (r *Repository) loadStagedCommitMessage() (string, error) {
+	stagedRef, err := r.r.Reference(plumbing.ReferenceName(policy.PolicyStagingRef), true)
+	if err != nil {
+		return "", err
+	}
+
+	stagedCommit, err := gitinterface.GetCommit(r.r, stagedRef.Hash())
+	if err != nil {
+		return "", err
+	}
+
+	return stagedCommit.Message, nil
+}
+
 func (r *Repository) loadRootMetadata(state *policy.State, keyID string) (*tuf.RootMetadata, error) {
 	slog.Debug("Loading current root metadata...")
 	rootMetadata, err := state.GetRootMetadata()
@@ -231,7 +498,7 @@ func (r *Repository) loadRootMetadata(state *policy.State, keyID string) (*tuf.R
 
 func (r *Repository) updateRootMetadata(ctx context.Context, state *policy.State, signer sslibdsse.SignerVerifier, rootMetadata *tuf.RootMetadata, commitMessage string, signCommit bool) error {
 	rootMetadata.SetVersion(rootMetadata.Version + 1)
-	rootMetadataBytes, err := json.Marshal(rootMetadata)
+	rootMetadataBytes, err := tuf.EncodeCanonical(rootMetadata)
 	if err != nil {
 		return err
 	}
@@ -248,6 +515,167 @@ func (r *Repository) updateRootMetadata(ctx context.Context, state *policy.State
 
 	state.RootEnvelope = env
 
+	return r.commitPolicyUpdate(ctx, state, commitMessage, signCommit)
+}
+
+// loadStateForUpdate returns the staged policy state if one exists, so that
+// changes that are still being signed can be built upon. Otherwise, the
+// current policy state is returned.
+func (r *Repository) loadStateForUpdate(ctx context.Context) (*policy.State, error) {
+	slog.Debug("Loading staged policy...")
+	state, err := policy.LoadStagedState(ctx, r.r)
+	if err == nil {
+		return state, nil
+	}
+	if !errors.Is(err, policy.ErrNoStagedPolicy) {
+		return nil, err
+	}
+
+	slog.Debug("Loading current policy...")
+	return policy.LoadCurrentState(ctx, r.r)
+}
+
+// commitPolicyUpdate commits state to the policy namespace if its root of trust
+// is signed by a threshold of the currently trusted root keys, its policy files
+// are signed by a threshold of their trusted keys, and its snapshot metadata,
+// if required, is current, discarding any staged changes. Otherwise, state is
+// staged until other key holders sign it using SignRoot, SignTargets, or
+// SignSnapshot.
+func (r *Repository) commitPolicyUpdate(ctx context.Context, state *policy.State, commitMessage string, signCommit bool) error {
+	currentState, err := policy.LoadCurrentState(ctx, r.r)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Verifying new keys meet algorithm policy...")
+	if err := state.VerifyNewKeyAlgorithms(currentState); err != nil {
+		return err
+	}
+
+	slog.Debug("Verifying policy signatures meet thresholds...")
+	pendingRoles, err := state.PendingRoles(ctx, currentState)
+	if err != nil {
+		return err
+	}
+	if len(pendingRoles) > 0 {
+		slog.Debug(fmt.Sprintf("Staging policy until %s are signed by a threshold of their keys...", strings.Join(pendingRoles, ", ")))
+		return state.Stage(ctx, r.r, commitMessage, signCommit)
+	}
+
 	slog.Debug("Committing policy...")
-	return state.Commit(ctx, r.r, commitMessage, signCommit)
+	if err := state.Commit(ctx, r.r, commitMessage, signCommit); err != nil {
+		return err
+	}
+
+	return policy.DiscardStagedState(r.r)
+}
+
+// AddGitHubAppKey sets the key trusted to sign GitHub pull request approval
+// attestations in the root of trust.
+func (r *Repository) AddGitHubAppKey(ctx context.Context, signer sslibdsse.SignerVerifier, appKey *tuf.Key, signCommit bool) error {
+	rootKeyID, err := signer.KeyID()
+	if err != nil {
+		return err
+	}
+
+	state, err := r.loadStateForUpdate(ctx)
+	if err != nil {
+		return err
+	}
+
+	rootMetadata, err := r.loadRootMetadata(state, rootKeyID)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Adding GitHub app key...")
+	rootMetadata, err = policy.AddGitHubAppKey(rootMetadata, appKey)
+	if err != nil {
+		return err
+	}
+
+	commitMessage := fmt.Sprintf("Add GitHub app key '%s' to root", appKey.KeyID)
+	return r.updateRootMetadata(ctx, state, signer, rootMetadata, commitMessage, signCommit)
+}
+
+// RemoveGitHubAppKey removes the GitHub app role from the root of trust.
+func (r *Repository) RemoveGitHubAppKey(ctx context.Context, signer sslibdsse.SignerVerifier, signCommit bool) error {
+	rootKeyID, err := signer.KeyID()
+	if err != nil {
+		return err
+	}
+
+	state, err := r.loadStateForUpdate(ctx)
+	if err != nil {
+		return err
+	}
+
+	rootMetadata, err := r.loadRootMetadata(state, rootKeyID)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Removing GitHub app key...")
+	rootMetadata, err = policy.DeleteGitHubAppKey(rootMetadata)
+	if err != nil {
+		return err
+	}
+
+	commitMessage := "Remove GitHub app key from root"
+	return r.updateRootMetadata(ctx, state, signer, rootMetadata, commitMessage, signCommit)
+}
+
+// AddGitLabAppKey sets the key trusted to sign GitLab merge request approval
+// attestations in the root of trust.
+func (r *Repository) AddGitLabAppKey(ctx context.Context, signer sslibdsse.SignerVerifier, appKey *tuf.Key, signCommit bool) error {
+	rootKeyID, err := signer.KeyID()
+	if err != nil {
+		return err
+	}
+
+	state, err := r.loadStateForUpdate(ctx)
+	if err != nil {
+		return err
+	}
+
+	rootMetadata, err := r.loadRootMetadata(state, rootKeyID)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Adding GitLab app key...")
+	rootMetadata, err = policy.AddGitLabAppKey(rootMetadata, appKey)
+	if err != nil {
+		return err
+	}
+
+	commitMessage := fmt.Sprintf("Add GitLab app key '%s' to root", appKey.KeyID)
+	return r.updateRootMetadata(ctx, state, signer, rootMetadata, commitMessage, signCommit)
+}
+
+// RemoveGitLabAppKey removes the GitLab app role from the root of trust.
+func (r *Repository) RemoveGitLabAppKey(ctx context.Context, signer sslibdsse.SignerVerifier, signCommit bool) error {
+	rootKeyID, err := signer.KeyID()
+	if err != nil {
+		return err
+	}
+
+	state, err := r.loadStateForUpdate(ctx)
+	if err != nil {
+		return err
+	}
+
+	rootMetadata, err := r.loadRootMetadata(state, rootKeyID)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Removing GitLab app key...")
+	rootMetadata, err = policy.DeleteGitLabAppKey(rootMetadata)
+	if err != nil {
+		return err
+	}
+
+	commitMessage := "Remove GitLab app key from root"
+	return r.updateRootMetadata(ctx, state, signer, rootMetadata, commitMessage, signCommit)
 }
@@ -8,6 +8,8 @@ import (
 	"github.com/gittuf/gittuf/internal/policy"
 	"github.com/gittuf/gittuf/internal/signerverifier"
 	"github.com/gittuf/gittuf/internal/signerverifier/dsse"
+	"github.com/gittuf/gittuf/internal/signerverifier/gpg"
+	artifacts "github.com/gittuf/gittuf/internal/testartifacts"
 	sslibsv "github.com/gittuf/gittuf/internal/third_party/go-securesystemslib/signerverifier"
 	"github.com/gittuf/gittuf/internal/tuf"
 	sslibdsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
@@ -71,7 +73,7 @@ func TestAddRootKey(t *testing.T) {
 	rootMetadata, err := state.GetRootMetadata()
 	assert.Nil(t, err)
 	assert.Equal(t, 2, rootMetadata.Version)
-	assert.Equal(t, []string{originalKeyID, newRootKey.KeyID}, rootMetadata.Roles[policy.RootRoleName].KeyIDs)
+	assert.ElementsMatch(t, []string{originalKeyID, newRootKey.KeyID}, rootMetadata.Roles[policy.RootRoleName].KeyIDs)
 	assert.Equal(t, originalKeyID, state.RootEnvelope.Signatures[0].KeyID)
 	assert.Equal(t, 2, len(state.RootPublicKeys))
 
@@ -234,7 +236,7 @@ func TestRemoveTopLevelTargetsKey(t *testing.T) {
 	}
 
 	assert.Equal(t, 3, rootMetadata.Version)
-	assert.Equal(t, rootKey.KeyID, rootMetadata.Roles[policy.TargetsRoleName].KeyIDs[0])
+	assert.ElementsMatch(t, []string{rootKey.KeyID, targetsKey.KeyID}, rootMetadata.Roles[policy.TargetsRoleName].KeyIDs)
 	assert.Contains(t, rootMetadata.Roles[policy.TargetsRoleName].KeyIDs, rootKey.KeyID)
 	assert.Contains(t, rootMetadata.Roles[policy.TargetsRoleName].KeyIDs, targetsKey.KeyID)
 	err = dsse.VerifyEnvelope(testCtx, state.RootEnvelope, []sslibdsse.Verifier{sv}, 1)
@@ -313,3 +315,363 @@ func TestUpdateTopLevelTargetsThreshold(t *testing.T) {
 	assert.Equal(t, 2, len(rootMetadata.Roles[policy.TargetsRoleName].KeyIDs))
 	assert.Equal(t, 2, rootMetadata.Roles[policy.TargetsRoleName].Threshold)
 }
+
+func TestUpdateRootThreshold(t *testing.T) {
+	r, keyBytes := createTestRepositoryWithRoot(t, "")
+
+	rootSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(keyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = r.UpdateRootThreshold(testCtx, rootSigner, 2, false)
+	assert.ErrorIs(t, err, policy.ErrCannotMeetThreshold)
+
+	newRootKey, err := tuf.LoadKeyFromBytes(targetsPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.AddRootKey(testCtx, rootSigner, newRootKey, false); err != nil {
+		t.Fatal(err)
+	}
+
+	// The current threshold is 1, so the change is applied immediately
+	err = r.UpdateRootThreshold(testCtx, rootSigner, 2, false)
+	assert.Nil(t, err)
+
+	state, err := policy.LoadCurrentState(testCtx, r.r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootMetadata, err := state.GetRootMetadata()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 3, rootMetadata.Version)
+	assert.Equal(t, 2, rootMetadata.Roles[policy.RootRoleName].Threshold)
+
+	_, err = policy.LoadStagedState(testCtx, r.r)
+	assert.ErrorIs(t, err, policy.ErrNoStagedPolicy)
+}
+
+func TestAddRepositoryRoot(t *testing.T) {
+	r, keyBytes := createTestRepositoryWithRoot(t, "")
+
+	rootSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(keyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repositoryKey, err := tuf.LoadKeyFromBytes(targetsPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = r.AddRepositoryRoot(testCtx, rootSigner, "repository", []*tuf.Key{repositoryKey}, 2, false)
+	assert.ErrorIs(t, err, policy.ErrCannotMeetThreshold)
+
+	err = r.AddRepositoryRoot(testCtx, rootSigner, "repository", []*tuf.Key{repositoryKey}, 1, false)
+	assert.Nil(t, err)
+
+	state, err := policy.LoadCurrentState(testCtx, r.r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootMetadata, err := state.GetRootMetadata()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 2, rootMetadata.Version)
+	assert.Equal(t, &tuf.RepositoryRoot{Keys: map[string]*tuf.Key{repositoryKey.KeyID: repositoryKey}, Threshold: 1}, rootMetadata.RepositoryRoots["repository"])
+
+	err = r.RemoveRepositoryRoot(testCtx, rootSigner, "repository", false)
+	assert.Nil(t, err)
+
+	state, err = policy.LoadCurrentState(testCtx, r.r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootMetadata, err = state.GetRootMetadata()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Nil(t, rootMetadata.RepositoryRoots)
+
+	err = r.RemoveRepositoryRoot(testCtx, rootSigner, "repository", false)
+	assert.ErrorIs(t, err, policy.ErrRepositoryRootNil)
+}
+
+func TestSetOrganizationRoot(t *testing.T) {
+	r, keyBytes := createTestRepositoryWithRoot(t, "")
+
+	rootSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(keyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = r.SetOrganizationRoot(testCtx, rootSigner, "https://example.com/org-root", "repository", false)
+	assert.Nil(t, err)
+
+	state, err := policy.LoadCurrentState(testCtx, r.r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootMetadata, err := state.GetRootMetadata()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, &tuf.OrganizationRoot{Location: "https://example.com/org-root", RepositoryName: "repository"}, rootMetadata.OrganizationRoot)
+
+	err = r.SetOrganizationRoot(testCtx, rootSigner, "", "", false)
+	assert.Nil(t, err)
+
+	state, err = policy.LoadCurrentState(testCtx, r.r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootMetadata, err = state.GetRootMetadata()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Nil(t, rootMetadata.OrganizationRoot)
+}
+
+func TestSetAlgorithmPolicy(t *testing.T) {
+	r, keyBytes := createTestRepositoryWithRoot(t, "")
+
+	rootSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(keyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gpgKey, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	algorithmPolicy := &tuf.AlgorithmPolicy{MinimumRSABits: 4096, RejectSHA1: true}
+	err = r.SetAlgorithmPolicy(testCtx, rootSigner, algorithmPolicy, false)
+	assert.Nil(t, err)
+
+	state, err := policy.LoadCurrentState(testCtx, r.r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootMetadata, err := state.GetRootMetadata()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, algorithmPolicy, rootMetadata.AlgorithmPolicy)
+
+	// The GPG key uses a 3072-bit RSA key
+	err = r.AddTopLevelTargetsKey(testCtx, rootSigner, gpgKey, false)
+	assert.ErrorIs(t, err, policy.ErrKeyAlgorithmNotAllowed)
+
+	err = r.SetAlgorithmPolicy(testCtx, rootSigner, &tuf.AlgorithmPolicy{}, false)
+	assert.Nil(t, err)
+
+	err = r.AddTopLevelTargetsKey(testCtx, rootSigner, gpgKey, false)
+	assert.Nil(t, err)
+}
+
+func TestAddAndRemoveGlobalRule(t *testing.T) {
+	r, keyBytes := createTestRepositoryWithRoot(t, "")
+
+	rootSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(keyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = r.AddGlobalRule(testCtx, rootSigner, "protect-main-globally", []string{"git:refs/heads/main"}, 2, false)
+	assert.Nil(t, err)
+
+	state, err := policy.LoadCurrentState(testCtx, r.r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootMetadata, err := state.GetRootMetadata()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []*tuf.GlobalRule{{Name: "protect-main-globally", Paths: []string{"git:refs/heads/main"}, Threshold: 2}}, rootMetadata.GlobalRules)
+
+	err = r.AddGlobalRule(testCtx, rootSigner, "protect-main-globally", []string{"git:refs/heads/main"}, 2, false)
+	assert.ErrorIs(t, err, policy.ErrGlobalRuleExists)
+
+	err = r.RemoveGlobalRule(testCtx, rootSigner, "protect-main-globally", false)
+	assert.Nil(t, err)
+
+	state, err = policy.LoadCurrentState(testCtx, r.r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootMetadata, err = state.GetRootMetadata()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Nil(t, rootMetadata.GlobalRules)
+
+	err = r.RemoveGlobalRule(testCtx, rootSigner, "protect-main-globally", false)
+	assert.ErrorIs(t, err, policy.ErrGlobalRuleNotFound)
+}
+
+func TestAddGitHubAppKey(t *testing.T) {
+	r, keyBytes := createTestRepositoryWithRoot(t, "")
+
+	rootSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(keyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	appKey, err := tuf.LoadKeyFromBytes(targetsPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = r.AddGitHubAppKey(testCtx, rootSigner, appKey, false)
+	assert.Nil(t, err)
+
+	state, err := policy.LoadCurrentState(testCtx, r.r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootMetadata, err := state.GetRootMetadata()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{appKey.KeyID}, rootMetadata.Roles[policy.GitHubAppRoleName].KeyIDs)
+
+	err = r.RemoveGitHubAppKey(testCtx, rootSigner, false)
+	assert.Nil(t, err)
+
+	state, err = policy.LoadCurrentState(testCtx, r.r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootMetadata, err = state.GetRootMetadata()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NotContains(t, rootMetadata.Roles, policy.GitHubAppRoleName)
+}
+
+func TestAddGitLabAppKey(t *testing.T) {
+	r, keyBytes := createTestRepositoryWithRoot(t, "")
+
+	rootSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(keyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	appKey, err := tuf.LoadKeyFromBytes(targetsPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = r.AddGitLabAppKey(testCtx, rootSigner, appKey, false)
+	assert.Nil(t, err)
+
+	state, err := policy.LoadCurrentState(testCtx, r.r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootMetadata, err := state.GetRootMetadata()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{appKey.KeyID}, rootMetadata.Roles[policy.GitLabAppRoleName].KeyIDs)
+
+	err = r.RemoveGitLabAppKey(testCtx, rootSigner, false)
+	assert.Nil(t, err)
+
+	state, err = policy.LoadCurrentState(testCtx, r.r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootMetadata, err = state.GetRootMetadata()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.NotContains(t, rootMetadata.Roles, policy.GitLabAppRoleName)
+}
+
+func TestSignRoot(t *testing.T) {
+	r, keyBytes := createTestRepositoryWithRoot(t, "")
+
+	rootSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(keyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+	secondRootSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(targetsKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = r.SignRoot(testCtx, rootSigner, false)
+	assert.ErrorIs(t, err, policy.ErrNoStagedPolicy)
+
+	secondRootKey, err := tuf.LoadKeyFromBytes(targetsPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.AddRootKey(testCtx, rootSigner, secondRootKey, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.UpdateRootThreshold(testCtx, rootSigner, 2, false); err != nil {
+		t.Fatal(err)
+	}
+
+	// With a threshold of 2, changes are staged until a second root key signs
+	err = r.UpdateRootThreshold(testCtx, rootSigner, 1, false)
+	assert.Nil(t, err)
+
+	state, err := policy.LoadCurrentState(testCtx, r.r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootMetadata, err := state.GetRootMetadata()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 2, rootMetadata.Roles[policy.RootRoleName].Threshold)
+
+	stagedState, err := policy.LoadStagedState(testCtx, r.r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	stagedRootMetadata, err := stagedState.GetRootMetadata()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 1, stagedRootMetadata.Roles[policy.RootRoleName].Threshold)
+	assert.Equal(t, 1, len(stagedState.RootEnvelope.Signatures))
+
+	// Signing again using the same key does not meet the threshold
+	err = r.SignRoot(testCtx, rootSigner, false)
+	assert.Nil(t, err)
+	_, err = policy.LoadStagedState(testCtx, r.r)
+	assert.Nil(t, err)
+
+	unauthorizedSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(artifacts.SSLibKey3Private) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = r.SignRoot(testCtx, unauthorizedSigner, false)
+	assert.ErrorIs(t, err, ErrUnauthorizedKey)
+
+	err = r.SignRoot(testCtx, secondRootSigner, false)
+	assert.Nil(t, err)
+
+	state, err = policy.LoadCurrentState(testCtx, r.r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootMetadata, err = state.GetRootMetadata()
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 1, rootMetadata.Roles[policy.RootRoleName].Threshold)
+
+	_, err = policy.LoadStagedState(testCtx, r.r)
+	assert.ErrorIs(t, err, policy.ErrNoStagedPolicy)
+}
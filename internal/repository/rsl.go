@@ -7,24 +7,52 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"slices"
+	"strings"
+	"time"
 
+	"github.com/gittuf/gittuf/internal/cache"
 	"github.com/gittuf/gittuf/internal/dev"
 	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/gittuf/gittuf/internal/policy"
 	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/transport"
+	sslibdsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
 )
 
 var (
 	ErrCommitNotInRef = errors.New("specified commit is not in ref")
 	ErrPushingRSL     = errors.New("unable to push RSL")
 	ErrPullingRSL     = errors.New("unable to pull RSL")
+
+	ErrRemoteRSLHasUpdates = errors.New("remote RSL has updates that must be pulled first")
+	ErrRefNotRecordedInRSL = errors.New("reference is not recorded in the RSL")
 )
 
+// RecordRSLEntryOptions configures how RSL entries are recorded.
+type RecordRSLEntryOptions struct {
+	// RecordTargetDigest indicates that the SHA-256 digest of the target's
+	// contents must be recorded in the entry alongside its Git ID, so that
+	// verification can detect SHA-1 collisions.
+	RecordTargetDigest bool
+}
+
+type RecordRSLEntryOption func(*RecordRSLEntryOptions)
+
+// WithTargetDigest records the SHA-256 digest of the target's contents in the
+// entry, see gitinterface.ComputeSHA256Digest.
+func WithTargetDigest() RecordRSLEntryOption {
+	return func(o *RecordRSLEntryOptions) {
+		o.RecordTargetDigest = true
+	}
+}
+
 // RecordRSLEntryForReference is the interface for the user to add an RSL entry
 // for the specified Git reference.
-func (r *Repository) RecordRSLEntryForReference(refName string, signCommit bool) error {
+func (r *Repository) RecordRSLEntryForReference(refName string, signCommit bool, opts ...RecordRSLEntryOption) error {
 	slog.Debug("Identifying absolute reference path...")
 	absRefName, err := gitinterface.AbsoluteReference(r.r, refName)
 	if err != nil {
@@ -37,8 +65,21 @@ func (r *Repository) RecordRSLEntryForReference(refName string, signCommit bool)
 		return err
 	}
 
+	return r.RecordRSLEntryForRemoteReference(absRefName, ref.Hash(), signCommit, opts...)
+}
+
+// RecordRSLEntryForRemoteReference records an RSL entry for refName with the
+// specified target, without requiring refName to exist locally. This is used
+// when the local reference pushed to a remote has a different name, such as
+// for the refspec "HEAD:refs/heads/main". The refName must be absolute.
+func (r *Repository) RecordRSLEntryForRemoteReference(refName string, targetID plumbing.Hash, signCommit bool, opts ...RecordRSLEntryOption) error {
+	options := &RecordRSLEntryOptions{}
+	for _, fn := range opts {
+		fn(options)
+	}
+
 	slog.Debug("Checking for existing entry for reference with same target...")
-	isDuplicate, err := r.isDuplicateEntry(absRefName, ref.Hash())
+	isDuplicate, err := r.isDuplicateEntry(refName, targetID)
 	if err != nil {
 		return err
 	}
@@ -49,8 +90,45 @@ func (r *Repository) RecordRSLEntryForReference(refName string, signCommit bool)
 	// TODO: once policy verification is in place, the signing key used by
 	// signCommit must be verified for the refName in the delegation tree.
 
+	entry := rsl.NewReferenceEntry(refName, targetID)
+	if options.RecordTargetDigest {
+		slog.Debug(fmt.Sprintf("Computing SHA-256 digest of '%s'...", targetID.String()))
+		entry.TargetSHA256, err = gitinterface.ComputeSHA256Digest(r.r, targetID)
+		if err != nil {
+			return err
+		}
+	}
+
 	slog.Debug("Creating RSL reference entry...")
-	return rsl.NewReferenceEntry(absRefName, ref.Hash()).Commit(r.r, signCommit)
+	return entry.Commit(r.r, signCommit)
+}
+
+// RecordRSLEntryForDeletion records the deletion of the specified Git
+// reference in the RSL. The reference need not exist locally, as it may only be
+// deleted on the remote. If the reference was not previously recorded in the
+// RSL, ErrRefNotRecordedInRSL is returned.
+func (r *Repository) RecordRSLEntryForDeletion(refName string, signCommit bool) error {
+	slog.Debug("Identifying absolute reference path...")
+	absRefName, err := gitinterface.AbsoluteReference(r.r, refName)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Checking that reference is recorded in the RSL...")
+	latestEntry, _, err := rsl.GetLatestReferenceEntryForRef(r.r, absRefName)
+	if err != nil {
+		if errors.Is(err, rsl.ErrRSLEntryNotFound) {
+			return fmt.Errorf("%w: '%s'", ErrRefNotRecordedInRSL, absRefName)
+		}
+		return err
+	}
+	if latestEntry.IsDeletion() {
+		slog.Debug(fmt.Sprintf("Deletion of '%s' is already recorded in the RSL", absRefName))
+		return nil
+	}
+
+	slog.Debug("Creating RSL deletion entry...")
+	return rsl.NewDeletionEntry(absRefName).Commit(r.r, signCommit)
 }
 
 // RecordRSLEntryForReferenceAtTarget is a special version of
@@ -90,6 +168,241 @@ func (r *Repository) RecordRSLAnnotation(rslEntryIDs []string, skip bool, messag
 	return rsl.NewAnnotationEntry(rslEntryHashes, skip, message).Commit(r.r, signCommit)
 }
 
+// SkipRSLEntries is the interface for the user to mark one or more prior RSL
+// entries as to-be-skipped for the specified reason. The reason is recorded in
+// the annotation and reported by verification workflows.
+func (r *Repository) SkipRSLEntries(rslEntryIDs []string, reason rsl.SkipReason, message string, signCommit bool) error {
+	if _, err := rsl.ParseSkipReason(string(reason)); err != nil {
+		return err
+	}
+
+	rslEntryHashes := []plumbing.Hash{}
+	for _, id := range rslEntryIDs {
+		rslEntryHashes = append(rslEntryHashes, plumbing.NewHash(id))
+	}
+
+	slog.Debug(fmt.Sprintf("Creating RSL annotation to skip entries for '%s'...", reason))
+	return rsl.NewSkipAnnotationEntry(rslEntryHashes, reason, message).Commit(r.r, signCommit)
+}
+
+// CompactRSL replaces the RSL with a checkpoint that records the latest
+// unskipped target of every reference. The checkpoint is signed using signer,
+// which must be trusted for the Root role, so that verification can trust it in
+// place of the entries it seals. Every reference is fully verified before it is
+// sealed. The prior entries are retained in an archive ref, see
+// rsl.ArchiveRefPrefix. As the RSL's history is rewritten, the compacted RSL
+// must be force pushed to remotes.
+func (r *Repository) CompactRSL(ctx context.Context, signer sslibdsse.SignerVerifier, signCommit bool) error {
+	keyID, err := signer.KeyID()
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Loading current policy...")
+	state, err := policy.LoadCurrentState(ctx, r.r)
+	if err != nil {
+		return err
+	}
+	rootMetadata, err := state.GetRootMetadata()
+	if err != nil {
+		return err
+	}
+	if !isKeyAuthorized(rootMetadata.Roles[policy.RootRoleName].KeyIDs, keyID) {
+		return ErrUnauthorizedKey
+	}
+
+	latestEntry, err := rsl.GetLatestEntry(r.r)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Identifying latest state of all references...")
+	references, err := rsl.GetLatestUnskippedTargets(r.r)
+	if err != nil {
+		return err
+	}
+
+	for refName := range references {
+		if strings.HasPrefix(refName, rsl.GittufNamespacePrefix) {
+			continue
+		}
+
+		slog.Debug(fmt.Sprintf("Verifying '%s' before sealing its entries...", refName))
+		if _, err := policy.VerifyRefFull(ctx, r.r, refName); err != nil {
+			return fmt.Errorf("unable to verify '%s': %w", refName, err)
+		}
+	}
+
+	checkpoint := rsl.NewCheckpointEntry(latestEntry.GetID(), references)
+
+	slog.Debug(fmt.Sprintf("Signing checkpoint using '%s'...", keyID))
+	if err := checkpoint.Sign(ctx, signer); err != nil {
+		return err
+	}
+
+	slog.Debug("Replacing RSL with checkpoint...")
+	if err := checkpoint.Commit(r.r, signCommit); err != nil {
+		return err
+	}
+
+	slog.Debug("Verifying checkpoint...")
+	if _, err := policy.LoadCurrentState(ctx, r.r); err != nil {
+		// Restore the RSL's prior state
+		return errors.Join(err, r.r.Storer.SetReference(plumbing.NewHashReference(rsl.Ref, latestEntry.GetID())))
+	}
+
+	return nil
+}
+
+// GetRSLReferenceEntries returns the RSL's reference entries for refName, from
+// the latest entry to the earliest, along with the annotations that apply to
+// each returned entry. If refName is empty, the entries for all references are
+// returned. If limit is positive, at most limit entries are returned.
+func (r *Repository) GetRSLReferenceEntries(refName string, limit int) ([]*rsl.ReferenceEntry, map[plumbing.Hash][]*rsl.AnnotationEntry, error) {
+	entries := []*rsl.ReferenceEntry{}
+	allAnnotations := map[plumbing.Hash][]*rsl.AnnotationEntry{}
+
+	entry, err := rsl.GetLatestEntry(r.r)
+	for err == nil {
+		switch e := entry.(type) {
+		case *rsl.ReferenceEntry:
+			if refName == "" || e.RefName == refName {
+				entries = append(entries, e)
+			}
+		case *rsl.AnnotationEntry:
+			// Annotations are recorded after the entries they refer to
+			for _, entryID := range e.RSLEntryIDs {
+				allAnnotations[entryID] = append(allAnnotations[entryID], e)
+			}
+		}
+
+		if limit > 0 && len(entries) == limit {
+			break
+		}
+
+		entry, err = rsl.GetParentForEntry(r.r, entry)
+	}
+	if err != nil && !errors.Is(err, rsl.ErrRSLEntryNotFound) {
+		return nil, nil, err
+	}
+
+	annotations := map[plumbing.Hash][]*rsl.AnnotationEntry{}
+	for _, entry := range entries {
+		if entryAnnotations, has := allAnnotations[entry.ID]; has {
+			annotations[entry.ID] = entryAnnotations
+		}
+	}
+
+	return entries, annotations, nil
+}
+
+// GetLatestRSLEntryTime returns the time at which the latest RSL entry was
+// recorded.
+func (r *Repository) GetLatestRSLEntryTime() (time.Time, error) {
+	entry, err := rsl.GetLatestEntry(r.r)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	commit, err := gitinterface.GetCommit(r.r, entry.GetID())
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return commit.Committer.When, nil
+}
+
+// CheckRSL validates the structural integrity of the RSL, see
+// rsl.CheckIntegrity, and the indexes derived from it. If rebuildIndexes is
+// set, the derived indexes are discarded so that they are rebuilt from the RSL
+// the next time references are verified.
+func (r *Repository) CheckRSL(rebuildIndexes bool) (*rsl.IntegrityReport, error) {
+	slog.Debug("Checking RSL integrity...")
+	report, err := rsl.CheckIntegrity(r.r)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Debug("Checking verification cache...")
+	findings, err := r.checkVerificationCache()
+	if err != nil {
+		return nil, err
+	}
+	report.Findings = append(report.Findings, findings...)
+
+	if rebuildIndexes {
+		slog.Debug("Resetting verification cache...")
+		if err := cache.Reset(r.r); err != nil {
+			return nil, err
+		}
+		report.RebuiltIndexes = append(report.RebuiltIndexes, cache.Ref)
+	}
+
+	return report, nil
+}
+
+// checkVerificationCache checks that the entries recorded in the verification
+// cache exist in the RSL and are reference entries for the expected refs.
+func (r *Repository) checkVerificationCache() ([]*rsl.IntegrityFinding, error) {
+	findings := []*rsl.IntegrityFinding{}
+
+	verificationCache, err := cache.LoadVerificationCache(r.r)
+	if err != nil {
+		if errors.Is(err, cache.ErrInvalidVerificationCache) {
+			findings = append(findings, &rsl.IntegrityFinding{
+				Severity: rsl.IntegrityError,
+				Check:    rsl.IntegrityCheckVerificationCache,
+				Message:  fmt.Sprintf("verification cache cannot be loaded: %s", err.Error()),
+			})
+			return findings, nil
+		}
+		return nil, err
+	}
+
+	if verificationCache.PolicyEntryID != "" {
+		if _, err := rsl.GetEntry(r.r, plumbing.NewHash(verificationCache.PolicyEntryID)); err != nil {
+			findings = append(findings, &rsl.IntegrityFinding{
+				Severity: rsl.IntegrityError,
+				Check:    rsl.IntegrityCheckVerificationCache,
+				EntryID:  verificationCache.PolicyEntryID,
+				Message:  fmt.Sprintf("verification cache refers to policy entry that cannot be loaded: %s", err.Error()),
+			})
+		}
+	}
+
+	refNames := make([]string, 0, len(verificationCache.VerifiedEntries))
+	for refName := range verificationCache.VerifiedEntries {
+		refNames = append(refNames, refName)
+	}
+	slices.Sort(refNames)
+
+	for _, refName := range refNames {
+		entryID := verificationCache.VerifiedEntries[refName]
+		entry, err := rsl.GetEntry(r.r, plumbing.NewHash(entryID))
+		if err != nil {
+			findings = append(findings, &rsl.IntegrityFinding{
+				Severity: rsl.IntegrityError,
+				Check:    rsl.IntegrityCheckVerificationCache,
+				EntryID:  entryID,
+				Message:  fmt.Sprintf("verification cache entry for '%s' cannot be loaded: %s", refName, err.Error()),
+			})
+			continue
+		}
+
+		referenceEntry, isReferenceEntry := entry.(*rsl.ReferenceEntry)
+		if !isReferenceEntry || referenceEntry.RefName != refName {
+			findings = append(findings, &rsl.IntegrityFinding{
+				Severity: rsl.IntegrityError,
+				Check:    rsl.IntegrityCheckVerificationCache,
+				EntryID:  entryID,
+				Message:  fmt.Sprintf("verification cache entry for '%s' is not a reference entry for it", refName),
+			})
+		}
+	}
+
+	return findings, nil
+}
+
 // CheckRemoteRSLForUpdates checks if the RSL at the specified remote remote
 // repository has updated in comparison with the local repository's RSL. This is
 // done by fetching the remote RSL to the local repository's remote RSL tracker.
@@ -103,8 +416,8 @@ func (r *Repository) CheckRemoteRSLForUpdates(ctx context.Context, remoteName st
 
 	slog.Debug("Updating remote RSL tracker...")
 	if err := gitinterface.FetchRefSpec(ctx, r.r, remoteName, rslRemoteRefSpec); err != nil {
-		if errors.Is(err, transport.ErrEmptyRemoteRepository) {
-			// Check if remote is empty and exit appropriately
+		if errors.Is(err, transport.ErrEmptyRemoteRepository) || errors.Is(err, git.NoMatchingRefSpecError{}) {
+			// Check if remote is empty or has no RSL and exit appropriately
 			return false, false, nil
 		}
 		return false, false, err
@@ -112,6 +425,10 @@ func (r *Repository) CheckRemoteRSLForUpdates(ctx context.Context, remoteName st
 
 	remoteRefState, err := r.r.Reference(plumbing.ReferenceName(trackerRef), true)
 	if err != nil {
+		if errors.Is(err, plumbing.ErrReferenceNotFound) {
+			// Remote does not have an RSL
+			return false, false, nil
+		}
 		return false, false, err
 	}
 
@@ -169,9 +486,15 @@ func (r *Repository) CheckRemoteRSLForUpdates(ctx context.Context, remoteName st
 	return true, true, nil
 }
 
-// PushRSL pushes the local RSL to the specified remote. As this push defaults
-// to fast-forward only, divergent RSL states are detected.
+// PushRSL pushes the local RSL to the specified remote. If the remote RSL has
+// entries that are not in the local RSL, the push is aborted so that the
+// remote's updates can be pulled first. As the push defaults to fast-forward
+// only, concurrent updates to the remote are also detected.
 func (r *Repository) PushRSL(ctx context.Context, remoteName string) error {
+	if err := r.checkRemoteRSLBeforePush(ctx, remoteName); err != nil {
+		return errors.Join(ErrPushingRSL, err)
+	}
+
 	slog.Debug(fmt.Sprintf("Pushing RSL reference to '%s'...", remoteName))
 	if err := gitinterface.Push(ctx, r.r, remoteName, []string{rsl.Ref}); err != nil {
 		return errors.Join(ErrPushingRSL, err)
@@ -180,17 +503,87 @@ func (r *Repository) PushRSL(ctx context.Context, remoteName string) error {
 	return nil
 }
 
-// PullRSL pulls RSL contents from the specified remote to the local RSL. The
-// fetch is marked as fast forward only to detect RSL divergence.
+// PullRSL pulls RSL contents from the specified remote to the local RSL. If the
+// local and remote RSLs have diverged, the pull is aborted and the local RSL is
+// left unchanged.
 func (r *Repository) PullRSL(ctx context.Context, remoteName string) error {
-	slog.Debug(fmt.Sprintf("Pulling RSL reference from '%s'...", remoteName))
-	if err := gitinterface.Fetch(ctx, r.r, remoteName, []string{rsl.Ref}, true); err != nil {
+	gittufRefs := []string{rsl.Ref}
+	priorRefs, err := r.checkRemoteRSLBeforePull(ctx, remoteName, gittufRefs)
+	if err != nil {
 		return errors.Join(ErrPullingRSL, err)
 	}
 
+	slog.Debug(fmt.Sprintf("Pulling RSL reference from '%s'...", remoteName))
+	if err := gitinterface.Fetch(ctx, r.r, remoteName, gittufRefs, true); err != nil {
+		return errors.Join(ErrPullingRSL, err, r.restoreReferences(gittufRefs, priorRefs))
+	}
+
+	return nil
+}
+
+// checkRemoteRSLBeforePush returns an error if the remote RSL has entries that
+// are not in the local RSL.
+func (r *Repository) checkRemoteRSLBeforePush(ctx context.Context, remoteName string) error {
+	slog.Debug(fmt.Sprintf("Checking remote RSL in '%s' for updates...", remoteName))
+	hasUpdates, diverged, err := r.CheckRemoteRSLForUpdates(ctx, remoteName)
+	if err != nil {
+		return err
+	}
+	if diverged {
+		return ErrDivergedRSL
+	}
+	if hasUpdates {
+		return ErrRemoteRSLHasUpdates
+	}
+
 	return nil
 }
 
+// checkRemoteRSLBeforePull returns an error if the local and remote RSLs have
+// diverged. It returns the current state of the specified gittuf references so
+// they can be restored if the pull fails.
+func (r *Repository) checkRemoteRSLBeforePull(ctx context.Context, remoteName string, gittufRefs []string) (map[string]*plumbing.Reference, error) {
+	if ref, err := r.r.Reference(plumbing.ReferenceName(rsl.Ref), true); err == nil && !ref.Hash().IsZero() {
+		slog.Debug(fmt.Sprintf("Checking remote RSL in '%s' for updates...", remoteName))
+		_, diverged, err := r.CheckRemoteRSLForUpdates(ctx, remoteName)
+		if err != nil {
+			return nil, err
+		}
+		if diverged {
+			return nil, ErrDivergedRSL
+		}
+	} else if err != nil && !errors.Is(err, plumbing.ErrReferenceNotFound) {
+		return nil, err
+	}
+
+	return r.getGittufRefsBeforeFetch(gittufRefs)
+}
+
+// getGittufRefsBeforeFetch returns the current state of the specified gittuf
+// references so they can be restored if a fetch fails. References that have
+// not been initialized are removed as they cannot be fast-forwarded.
+func (r *Repository) getGittufRefsBeforeFetch(gittufRefs []string) (map[string]*plumbing.Reference, error) {
+	priorRefs := map[string]*plumbing.Reference{}
+	for _, gittufRef := range gittufRefs {
+		ref, err := r.r.Reference(plumbing.ReferenceName(gittufRef), true)
+		if err != nil {
+			if errors.Is(err, plumbing.ErrReferenceNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		if ref.Hash().IsZero() {
+			if err := r.r.Storer.RemoveReference(ref.Name()); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		priorRefs[gittufRef] = ref
+	}
+
+	return priorRefs, nil
+}
+
 // isDuplicateEntry checks if the latest unskipped entry for the ref has the
 // same target ID Note that it's legal for the RSL to have target A, then B,
 // then A again, this is not considered a duplicate entry
@@ -8,10 +8,16 @@ import (
 	"os"
 	"testing"
 
+	"github.com/gittuf/gittuf/internal/cache"
+	"github.com/gittuf/gittuf/internal/common"
 	"github.com/gittuf/gittuf/internal/dev"
 	"github.com/gittuf/gittuf/internal/gitinterface"
 	"github.com/gittuf/gittuf/internal/policy"
 	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/internal/signerverifier"
+	"github.com/gittuf/gittuf/internal/signerverifier/gpg"
+	artifacts "github.com/gittuf/gittuf/internal/testartifacts"
+	"github.com/gittuf/gittuf/internal/tuf"
 	"github.com/go-git/go-billy/v5/memfs"
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/config"
@@ -103,6 +109,27 @@ func TestRecordRSLEntryForReference(t *testing.T) {
 	assert.Equal(t, entry.GetID(), entryType.GetID())
 }
 
+func TestRecordRSLEntryForReferenceWithTargetDigest(t *testing.T) {
+	refName := "refs/heads/main"
+
+	repo := createTestRepositoryWithPolicy(t, "")
+	commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo.r, refName, 1, gpgKeyBytes)
+
+	err := repo.RecordRSLEntryForReference(refName, false, WithTargetDigest())
+	assert.Nil(t, err)
+
+	entry, _, err := rsl.GetLatestReferenceEntryForRef(repo.r, refName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expectedDigest, err := gitinterface.ComputeSHA256Digest(repo.r, commitIDs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, commitIDs[0], entry.TargetID)
+	assert.Equal(t, expectedDigest, entry.TargetSHA256)
+}
+
 func TestRecordRSLEntryForReferenceAtTarget(t *testing.T) {
 	t.Setenv(dev.DevModeKey, "1")
 
@@ -178,6 +205,115 @@ func TestRecordRSLEntryForReferenceAtTarget(t *testing.T) {
 	}
 }
 
+func TestRecordRSLEntryForRemoteReference(t *testing.T) {
+	r, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repo := &Repository{r: r}
+
+	if err := rsl.InitializeNamespace(repo.r); err != nil {
+		t.Fatal(err)
+	}
+
+	testHash := plumbing.NewHash("abcdef12345678900987654321fedcbaabcdef12")
+
+	// The reference does not exist locally
+	err = repo.RecordRSLEntryForRemoteReference("refs/heads/main", testHash, false)
+	assert.Nil(t, err)
+
+	latestEntry, err := rsl.GetLatestEntry(repo.r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entry, ok := latestEntry.(*rsl.ReferenceEntry)
+	if !ok {
+		t.Fatal(fmt.Errorf("invalid entry type"))
+	}
+	assert.Equal(t, "refs/heads/main", entry.RefName)
+	assert.Equal(t, testHash, entry.TargetID)
+
+	// Duplicate entries are not recorded
+	err = repo.RecordRSLEntryForRemoteReference("refs/heads/main", testHash, false)
+	assert.Nil(t, err)
+
+	newLatestEntry, err := rsl.GetLatestEntry(repo.r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, latestEntry.GetID(), newLatestEntry.GetID())
+}
+
+func TestRecordRSLEntryForDeletion(t *testing.T) {
+	refName := "refs/heads/main"
+
+	repo := createTestRepositoryWithPolicy(t, "")
+
+	err := repo.RecordRSLEntryForDeletion(refName, false)
+	assert.ErrorIs(t, err, ErrRefNotRecordedInRSL)
+
+	commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo.r, refName, 1, gpgKeyBytes)
+	common.CreateTestRSLReferenceEntryCommit(t, repo.r, rsl.NewReferenceEntry(refName, commitIDs[0]), gpgKeyBytes)
+
+	if err := repo.r.Storer.RemoveReference(plumbing.ReferenceName(refName)); err != nil {
+		t.Fatal(err)
+	}
+
+	err = repo.RecordRSLEntryForDeletion(refName, false)
+	assert.Nil(t, err)
+
+	latestEntry, _, err := rsl.GetLatestReferenceEntryForRef(repo.r, refName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, latestEntry.IsDeletion())
+
+	// Duplicate deletions are not recorded
+	err = repo.RecordRSLEntryForDeletion(refName, false)
+	assert.Nil(t, err)
+
+	newLatestEntry, err := rsl.GetLatestEntry(repo.r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, latestEntry.GetID(), newLatestEntry.GetID())
+
+	// The deletion is not signed using a key authorized to delete main
+	err = repo.VerifyRef(testCtx, refName, false, WithoutCache())
+	assert.ErrorIs(t, err, policy.ErrUnauthorizedDeletion)
+
+	t.Run("authorized deletion", func(t *testing.T) {
+		repo := createTestRepositoryWithPolicy(t, "")
+
+		targetsSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(targetsKeyBytes) //nolint:staticcheck
+		if err != nil {
+			t.Fatal(err)
+		}
+		deletionKey, err := gpg.LoadGPGKeyFromBytes(artifacts.GPGKey2Public)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := repo.SetDeletionAuthorizers(testCtx, targetsSigner, policy.TargetsRoleName, "protect-main", []*tuf.Key{deletionKey}, false); err != nil {
+			t.Fatal(err)
+		}
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo.r, refName, 1, gpgKeyBytes)
+		common.CreateTestRSLReferenceEntryCommit(t, repo.r, rsl.NewReferenceEntry(refName, commitIDs[0]), gpgKeyBytes)
+		common.CreateTestRSLReferenceEntryCommit(t, repo.r, rsl.NewDeletionEntry(refName), gpgUnauthorizedKeyBytes)
+
+		// The reference must not exist locally
+		err = repo.VerifyRef(testCtx, refName, false, WithoutCache())
+		assert.ErrorIs(t, err, ErrRefStateDoesNotMatchRSL)
+
+		if err := repo.r.Storer.RemoveReference(plumbing.ReferenceName(refName)); err != nil {
+			t.Fatal(err)
+		}
+		err = repo.VerifyRef(testCtx, refName, false, WithoutCache())
+		assert.Nil(t, err)
+	})
+}
+
 func TestRecordRSLAnnotation(t *testing.T) {
 	r, err := git.Init(memory.NewStorage(), memfs.New())
 	if err != nil {
@@ -238,6 +374,255 @@ func TestRecordRSLAnnotation(t *testing.T) {
 	assert.True(t, annotation.Skip)
 }
 
+func TestSkipRSLEntries(t *testing.T) {
+	r, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repo := &Repository{r: r}
+
+	if err := rsl.InitializeNamespace(repo.r); err != nil {
+		t.Fatal(err)
+	}
+
+	ref := plumbing.NewHashReference(plumbing.ReferenceName("refs/heads/main"), plumbing.ZeroHash)
+	if err := repo.r.Storer.SetReference(ref); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := repo.RecordRSLEntryForReference("refs/heads/main", false); err != nil {
+		t.Fatal(err)
+	}
+
+	latestEntry, err := rsl.GetLatestEntry(repo.r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entryID := latestEntry.GetID()
+
+	err = repo.SkipRSLEntries([]string{entryID.String()}, rsl.SkipReason("unknown"), "", false)
+	assert.ErrorIs(t, err, rsl.ErrInvalidSkipReason)
+
+	err = repo.SkipRSLEntries([]string{entryID.String()}, rsl.SkipReasonMistakenPush, "pushed to wrong branch", false)
+	assert.Nil(t, err)
+
+	latestEntry, err = rsl.GetLatestEntry(repo.r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.IsType(t, &rsl.AnnotationEntry{}, latestEntry)
+
+	annotation := latestEntry.(*rsl.AnnotationEntry)
+	assert.Equal(t, "pushed to wrong branch", annotation.Message)
+	assert.Equal(t, []plumbing.Hash{entryID}, annotation.RSLEntryIDs)
+	assert.True(t, annotation.Skip)
+	assert.Equal(t, rsl.SkipReasonMistakenPush, annotation.SkipReason)
+}
+
+func TestGetRSLReferenceEntries(t *testing.T) {
+	r, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repo := &Repository{r: r}
+
+	if err := rsl.InitializeNamespace(repo.r); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, annotations, err := repo.GetRSLReferenceEntries("", 0)
+	assert.Nil(t, err)
+	assert.Empty(t, entries)
+	assert.Empty(t, annotations)
+
+	for _, refName := range []string{"refs/heads/main", "refs/heads/feature", "refs/heads/main"} {
+		if err := rsl.NewReferenceEntry(refName, plumbing.ZeroHash).Commit(repo.r, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	latestEntry, err := rsl.GetLatestEntry(repo.r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.SkipRSLEntries([]string{latestEntry.GetID().String()}, rsl.SkipReasonMistakenPush, "pushed to wrong branch", false); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, annotations, err = repo.GetRSLReferenceEntries("", 0)
+	assert.Nil(t, err)
+	assert.Len(t, entries, 3)
+	assert.Equal(t, latestEntry.GetID(), entries[0].ID)
+	assert.Len(t, annotations, 1)
+	assert.True(t, entries[0].SkippedBy(annotations[entries[0].ID]))
+
+	entries, _, err = repo.GetRSLReferenceEntries("refs/heads/main", 0)
+	assert.Nil(t, err)
+	assert.Len(t, entries, 2)
+	for _, entry := range entries {
+		assert.Equal(t, "refs/heads/main", entry.RefName)
+	}
+
+	entries, _, err = repo.GetRSLReferenceEntries("", 1)
+	assert.Nil(t, err)
+	assert.Len(t, entries, 1)
+	assert.Equal(t, latestEntry.GetID(), entries[0].ID)
+}
+
+func TestCompactRSL(t *testing.T) {
+	rootSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(rootKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(targetsKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	refName := "refs/heads/main"
+
+	t.Run("successful compaction", func(t *testing.T) {
+		repo := createTestRepositoryWithPolicy(t, "")
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo.r, refName, 1, gpgKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		common.CreateTestRSLReferenceEntryCommit(t, repo.r, entry, gpgKeyBytes)
+
+		latestEntry, err := rsl.GetLatestEntry(repo.r)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = repo.CompactRSL(testCtx, rootSigner, false)
+		assert.Nil(t, err)
+
+		checkpointT, err := rsl.GetLatestEntry(repo.r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkpoint, isCheckpoint := checkpointT.(*rsl.CheckpointEntry)
+		assert.True(t, isCheckpoint)
+		assert.Equal(t, latestEntry.GetID(), checkpoint.ArchiveID)
+		assert.Equal(t, commitIDs[0], checkpoint.References[refName])
+
+		err = repo.VerifyRef(testCtx, refName, false)
+		assert.Nil(t, err)
+
+		// Entries after the checkpoint are verified using the checkpoint's
+		// policy
+		commitIDs = common.AddNTestCommitsToSpecifiedRef(t, repo.r, refName, 1, gpgKeyBytes)
+		entry = rsl.NewReferenceEntry(refName, commitIDs[0])
+		common.CreateTestRSLReferenceEntryCommit(t, repo.r, entry, gpgKeyBytes)
+
+		err = repo.VerifyRef(testCtx, refName, false)
+		assert.Nil(t, err)
+
+		commitIDs = common.AddNTestCommitsToSpecifiedRef(t, repo.r, refName, 1, gpgUnauthorizedKeyBytes)
+		entry = rsl.NewReferenceEntry(refName, commitIDs[0])
+		common.CreateTestRSLReferenceEntryCommit(t, repo.r, entry, gpgUnauthorizedKeyBytes)
+
+		err = repo.VerifyRef(testCtx, refName, false)
+		assert.ErrorIs(t, err, policy.ErrUnauthorizedSignature)
+	})
+
+	t.Run("unauthorized key", func(t *testing.T) {
+		repo := createTestRepositoryWithPolicy(t, "")
+
+		err := repo.CompactRSL(testCtx, targetsSigner, false)
+		assert.ErrorIs(t, err, ErrUnauthorizedKey)
+
+		// A checkpoint not signed by the root of trust is not trusted
+		latestEntry, err := rsl.GetLatestEntry(repo.r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		references, err := rsl.GetLatestUnskippedTargets(repo.r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		checkpoint := rsl.NewCheckpointEntry(latestEntry.GetID(), references)
+		if err := checkpoint.Sign(testCtx, targetsSigner); err != nil {
+			t.Fatal(err)
+		}
+		if err := checkpoint.Commit(repo.r, false); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = policy.LoadCurrentState(testCtx, repo.r)
+		assert.ErrorIs(t, err, policy.ErrUnverifiedCheckpoint)
+	})
+
+	t.Run("unverified history", func(t *testing.T) {
+		repo := createTestRepositoryWithPolicy(t, "")
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo.r, refName, 1, gpgUnauthorizedKeyBytes)
+		entry := rsl.NewReferenceEntry(refName, commitIDs[0])
+		common.CreateTestRSLReferenceEntryCommit(t, repo.r, entry, gpgUnauthorizedKeyBytes)
+
+		latestEntry, err := rsl.GetLatestEntry(repo.r)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = repo.CompactRSL(testCtx, rootSigner, false)
+		assert.ErrorIs(t, err, policy.ErrUnauthorizedSignature)
+
+		currentEntry, err := rsl.GetLatestEntry(repo.r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, latestEntry.GetID(), currentEntry.GetID())
+	})
+}
+
+func TestCheckRSL(t *testing.T) {
+	refName := "refs/heads/main"
+
+	repo := createTestRepositoryWithPolicy(t, "")
+
+	commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo.r, refName, 1, gpgKeyBytes)
+	entryID := common.CreateTestRSLReferenceEntryCommit(t, repo.r, rsl.NewReferenceEntry(refName, commitIDs[0]), gpgKeyBytes)
+
+	if err := repo.VerifyRef(testCtx, refName, false); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err := repo.CheckRSL(false)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, report.ErrorCount())
+	assert.Empty(t, report.RebuiltIndexes)
+
+	// Record an entry for the wrong ref in the verification cache
+	verificationCache, err := cache.LoadVerificationCache(repo.r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	verificationCache.VerifiedEntries["refs/heads/feature"] = entryID.String()
+	if err := verificationCache.Commit(repo.r); err != nil {
+		t.Fatal(err)
+	}
+
+	report, err = repo.CheckRSL(false)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, report.ErrorCount())
+	finding := report.Findings[len(report.Findings)-1]
+	assert.Equal(t, rsl.IntegrityCheckVerificationCache, finding.Check)
+	assert.Equal(t, entryID.String(), finding.EntryID)
+
+	report, err = repo.CheckRSL(true)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{cache.Ref}, report.RebuiltIndexes)
+
+	_, err = repo.r.Reference(plumbing.ReferenceName(cache.Ref), true)
+	assert.ErrorIs(t, err, plumbing.ErrReferenceNotFound)
+
+	report, err = repo.CheckRSL(false)
+	assert.Nil(t, err)
+	assert.Equal(t, 0, report.ErrorCount())
+}
+
 func TestCheckRemoteRSLForUpdates(t *testing.T) {
 	remoteName := "origin"
 	refName := "refs/heads/main"
@@ -561,3 +946,27 @@ func TestPullRSL(t *testing.T) {
 		assert.ErrorIs(t, err, ErrPullingRSL)
 	})
 }
+
+func TestGetLatestRSLEntryTime(t *testing.T) {
+	r, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repo := &Repository{r: r}
+
+	if err := rsl.InitializeNamespace(repo.r); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = repo.GetLatestRSLEntryTime()
+	assert.ErrorIs(t, err, rsl.ErrRSLEntryNotFound)
+
+	if err := rsl.NewReferenceEntry("refs/heads/main", plumbing.ZeroHash).Commit(repo.r, false); err != nil {
+		t.Fatal(err)
+	}
+
+	latestEntryTime, err := repo.GetLatestRSLEntryTime()
+	assert.Nil(t, err)
+	assert.False(t, latestEntryTime.IsZero())
+}
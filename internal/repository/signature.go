@@ -0,0 +1,60 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/gittuf/gittuf/internal/policy"
+	sslibdsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
+)
+
+var ErrSignatureNotFound = errors.New("no signature from the specified key found in metadata")
+
+// RemoveSignature removes the signature made by the specified key from the
+// envelope of the root of trust, the snapshot metadata, or a policy file, such
+// as a signature made using the wrong key or by a signer whose key has since
+// been revoked. The metadata itself is not modified. As with other policy
+// changes, the staged policy is updated if it exists, and the change is staged
+// if the metadata no longer meets its threshold.
+func (r *Repository) RemoveSignature(ctx context.Context, roleName, keyID string, signCommit bool) error {
+	state, err := r.loadStateForUpdate(ctx)
+	if err != nil {
+		return err
+	}
+
+	var env *sslibdsse.Envelope
+	switch roleName {
+	case policy.RootRoleName:
+		env = state.RootEnvelope
+	case policy.SnapshotRoleName:
+		env = state.SnapshotEnvelope
+	case policy.TargetsRoleName:
+		env = state.TargetsEnvelope
+	default:
+		env = state.DelegationEnvelopes[roleName]
+	}
+	if env == nil {
+		return policy.ErrMetadataNotFound
+	}
+
+	signatures := []sslibdsse.Signature{}
+	for _, signature := range env.Signatures {
+		if signature.KeyID == keyID {
+			continue
+		}
+		signatures = append(signatures, signature)
+	}
+	if len(signatures) == len(env.Signatures) {
+		return fmt.Errorf("%w: key '%s', metadata '%s'", ErrSignatureNotFound, keyID, roleName)
+	}
+
+	slog.Debug(fmt.Sprintf("Removing signature from key '%s' from '%s'...", keyID, roleName))
+	env.Signatures = signatures
+
+	commitMessage := fmt.Sprintf("Remove signature from key '%s' from '%s'", keyID, roleName)
+	return r.commitPolicyUpdate(ctx, state, commitMessage, signCommit)
+}
@@ -0,0 +1,81 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/signerverifier"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRemoveSignature(t *testing.T) {
+	r := createTestRepositoryWithPolicy(t, "")
+
+	// Add root key as a targets key and sign targets with it
+	rootSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(rootKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootPubKey, err := tuf.LoadKeyFromBytes(rootPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.AddTopLevelTargetsKey(testCtx, rootSigner, rootPubKey, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := r.SignTargets(testCtx, rootSigner, policy.TargetsRoleName, false); err != nil {
+		t.Fatal(err)
+	}
+
+	targetsPubKey, err := tuf.LoadKeyFromBytes(targetsPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("signature removed", func(t *testing.T) {
+		err := r.RemoveSignature(testCtx, policy.TargetsRoleName, rootPubKey.KeyID, false)
+		assert.Nil(t, err)
+
+		state, err := policy.LoadCurrentState(testCtx, r.r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, 1, len(state.TargetsEnvelope.Signatures))
+		assert.Equal(t, targetsPubKey.KeyID, state.TargetsEnvelope.Signatures[0].KeyID)
+	})
+
+	t.Run("signature not found", func(t *testing.T) {
+		err := r.RemoveSignature(testCtx, policy.TargetsRoleName, rootPubKey.KeyID, false)
+		assert.ErrorIs(t, err, ErrSignatureNotFound)
+	})
+
+	t.Run("metadata not found", func(t *testing.T) {
+		err := r.RemoveSignature(testCtx, "unknown", rootPubKey.KeyID, false)
+		assert.ErrorIs(t, err, policy.ErrMetadataNotFound)
+
+		// The snapshot role is not enabled
+		err = r.RemoveSignature(testCtx, policy.SnapshotRoleName, rootPubKey.KeyID, false)
+		assert.ErrorIs(t, err, policy.ErrMetadataNotFound)
+	})
+
+	t.Run("threshold no longer met", func(t *testing.T) {
+		// Removing the only valid signature stages the change
+		err := r.RemoveSignature(testCtx, policy.TargetsRoleName, targetsPubKey.KeyID, false)
+		assert.Nil(t, err)
+
+		state, err := policy.LoadCurrentState(testCtx, r.r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, 1, len(state.TargetsEnvelope.Signatures))
+
+		stagedState, err := policy.LoadStagedState(testCtx, r.r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Empty(t, stagedState.TargetsEnvelope.Signatures)
+	})
+}
@@ -0,0 +1,252 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/gittuf/gittuf/internal/attestations"
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/internal/tsa"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+var (
+	ErrSignedAfterRevocation = errors.New("signature was made after signing key was revoked")
+	ErrSignedAfterExpiry     = errors.New("signature was made after signed metadata expired")
+)
+
+// timestampableSignature is a signature on policy metadata, an attestation, or
+// an RSL entry that can be timestamped by a timestamp authority.
+type timestampableSignature struct {
+	description string
+	signature   []byte
+
+	// keyID is set for signatures on envelopes.
+	keyID string
+
+	// commit is set for signatures on RSL entries.
+	commit *object.Commit
+
+	// expires is set for signatures on policy metadata.
+	expires string
+}
+
+// TimestampSignatures obtains RFC 3161 timestamps from the timestamp authority
+// at tsaURL for the signatures on the current policy metadata, attestations,
+// and RSL entries, other than those for the attestations namespace. The
+// timestamps are stored in the attestations namespace. Signatures that were
+// timestamped previously are skipped.
+func (r *Repository) TimestampSignatures(ctx context.Context, tsaURL string, signCommit bool) error {
+	_, allAttestations, signatures, err := r.loadTimestampableSignatures(ctx)
+	if err != nil {
+		return err
+	}
+
+	timestamped := 0
+	for _, signature := range signatures {
+		if _, err := allAttestations.GetSignatureTimestampFor(r.r, signature.signature); err == nil {
+			continue
+		} else if !errors.Is(err, attestations.ErrSignatureTimestampNotFound) {
+			return err
+		}
+
+		slog.Debug(fmt.Sprintf("Timestamping %s...", signature.description))
+		response, err := tsa.Timestamp(ctx, tsaURL, signature.signature)
+		if err != nil {
+			return err
+		}
+
+		if err := allAttestations.SetSignatureTimestamp(r.r, signature.signature, response); err != nil {
+			return err
+		}
+		timestamped++
+	}
+
+	if timestamped == 0 {
+		slog.Debug("No new signatures to timestamp")
+		return nil
+	}
+
+	commitMessage := fmt.Sprintf("Add %d signature timestamps", timestamped)
+
+	return allAttestations.Commit(r.r, commitMessage, signCommit)
+}
+
+// verifySignatureTimestamps checks that every signature on the current policy
+// metadata, attestations, and RSL entries (other than those for the
+// attestations namespace) has a timestamp issued by a trusted timestamp
+// authority, if requested in the options. The timestamps must show that
+// signatures were made before the signing key was revoked and, for policy
+// metadata, before the metadata expired. The returned context carries the
+// timestamps so that policy verification trusts signatures made using revoked
+// keys before they were revoked.
+func (r *Repository) verifySignatureTimestamps(ctx context.Context, opts ...VerifyRefOption) (context.Context, error) {
+	options := &VerifyRefOptions{}
+	for _, fn := range opts {
+		fn(options)
+	}
+	if options.TimestampAuthorityCertificates == nil {
+		return ctx, nil
+	}
+
+	slog.Debug("Verifying signature timestamps for policy, attestations, and RSL entries...")
+	state, allAttestations, signatures, err := r.loadTimestampableSignatures(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rootMetadata, err := state.GetRootMetadata()
+	if err != nil {
+		return nil, err
+	}
+	revokedKeys, err := state.RevokedPublicKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, signature := range signatures {
+		response, err := allAttestations.GetSignatureTimestampFor(r.r, signature.signature)
+		if err != nil {
+			return nil, fmt.Errorf("unable to find timestamp for %s: %w", signature.description, err)
+		}
+
+		signedAt, err := tsa.Verify(response, signature.signature, options.TimestampAuthorityCertificates)
+		if err != nil {
+			return nil, fmt.Errorf("unable to verify timestamp for %s: %w", signature.description, err)
+		}
+
+		revocation := getRevocationForSignature(ctx, rootMetadata, revokedKeys, signature)
+		if revocation != nil {
+			revokedAt, err := time.Parse(time.RFC3339, revocation.RevokedAt)
+			if err != nil {
+				return nil, err
+			}
+			if !signedAt.Before(revokedAt) {
+				return nil, fmt.Errorf("%w: %s was made at %s, key '%s' was revoked at %s", ErrSignedAfterRevocation, signature.description, signedAt.UTC().Format(time.RFC3339), revocation.KeyID, revocation.RevokedAt)
+			}
+		}
+
+		if signature.expires != "" {
+			expires, err := time.Parse(time.RFC3339, signature.expires)
+			if err != nil {
+				return nil, err
+			}
+			if signedAt.After(expires) {
+				return nil, fmt.Errorf("%w: %s was made at %s, metadata expired at %s", ErrSignedAfterExpiry, signature.description, signedAt.UTC().Format(time.RFC3339), signature.expires)
+			}
+		}
+	}
+
+	return policy.WithSignatureTimestamps(ctx, func(signature []byte) (time.Time, error) {
+		response, err := allAttestations.GetSignatureTimestampFor(r.r, signature)
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		return tsa.Verify(response, signature, options.TimestampAuthorityCertificates)
+	}), nil
+}
+
+// loadTimestampableSignatures returns the current policy, the current
+// attestations, and every signature that must be timestamped.
+func (r *Repository) loadTimestampableSignatures(ctx context.Context) (*policy.State, *attestations.Attestations, []*timestampableSignature, error) {
+	state, allAttestations, envelopes, err := r.loadSignedEnvelopes(ctx)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	signatures := []*timestampableSignature{}
+	for _, env := range envelopes {
+		payload, err := env.DecodeB64Payload()
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		// Policy metadata records when it expires, attestations do not
+		metadata := struct {
+			Expires string `json:"expires"`
+		}{}
+		if err := json.Unmarshal(payload, &metadata); err != nil {
+			return nil, nil, nil, err
+		}
+
+		for _, signature := range env.Signatures {
+			signatureBytes, err := base64.StdEncoding.DecodeString(signature.Sig)
+			if err != nil {
+				return nil, nil, nil, err
+			}
+
+			signatures = append(signatures, &timestampableSignature{
+				description: fmt.Sprintf("signature by '%s' on %s", signature.KeyID, env.PayloadType),
+				signature:   signatureBytes,
+				keyID:       signature.KeyID,
+				expires:     metadata.Expires,
+			})
+		}
+	}
+
+	entry, err := rsl.GetLatestEntry(r.r)
+	if err != nil {
+		if errors.Is(err, rsl.ErrRSLEntryNotFound) {
+			return state, allAttestations, signatures, nil
+		}
+		return nil, nil, nil, err
+	}
+
+	for {
+		commit, err := gitinterface.GetCommit(r.r, entry.GetID())
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		// Entries for the attestations namespace are skipped, as recording
+		// timestamps itself creates such an entry
+		referenceEntry, isReferenceEntry := entry.(*rsl.ReferenceEntry)
+		isAttestationsEntry := isReferenceEntry && referenceEntry.RefName == attestations.Ref
+
+		if commit.PGPSignature != "" && !isAttestationsEntry {
+			signatures = append(signatures, &timestampableSignature{
+				description: fmt.Sprintf("signature on RSL entry '%s'", entry.GetID().String()),
+				signature:   []byte(commit.PGPSignature),
+				commit:      commit,
+			})
+		}
+
+		entry, err = rsl.GetParentForEntry(r.r, entry)
+		if err != nil {
+			if errors.Is(err, rsl.ErrRSLEntryNotFound) {
+				break
+			}
+			return nil, nil, nil, err
+		}
+	}
+
+	return state, allAttestations, signatures, nil
+}
+
+// getRevocationForSignature returns the revocation of the key that made the
+// signature, if that key has been revoked. For signatures on RSL entries, the
+// revoked keys in the policy are checked to identify the signing key.
+func getRevocationForSignature(ctx context.Context, rootMetadata *tuf.RootMetadata, revokedKeys map[string]*tuf.Key, signature *timestampableSignature) *tuf.KeyRevocation {
+	if signature.commit == nil {
+		return rootMetadata.RevokedKeys[signature.keyID]
+	}
+
+	for keyID, key := range revokedKeys {
+		if err := gitinterface.VerifyCommitSignature(ctx, signature.commit, key); err == nil {
+			return rootMetadata.RevokedKeys[keyID]
+		}
+	}
+
+	return nil
+}
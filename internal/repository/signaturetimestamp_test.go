@@ -0,0 +1,119 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gittuf/gittuf/internal/attestations"
+	"github.com/gittuf/gittuf/internal/common"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/internal/signerverifier"
+	"github.com/gittuf/gittuf/internal/signerverifier/gpg"
+	"github.com/gittuf/gittuf/internal/tsa"
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimestampSignatures(t *testing.T) {
+	repo := createTestRepositoryWithPolicy(t, "")
+	clock := clockwork.NewFakeClockAt(common.TestClock.Now())
+	server, chainPEM := common.NewTestTimestampAuthority(t, clock)
+	_, otherChainPEM := common.NewTestTimestampAuthority(t, clock)
+
+	chain, err := tsa.LoadCertificateChain(chainPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherChain, err := tsa.LoadCertificateChain(otherChainPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	refName := "refs/heads/main"
+	commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo.r, refName, 1, gpgKeyBytes)
+	common.CreateTestRSLReferenceEntryCommit(t, repo.r, rsl.NewReferenceEntry(refName, commitIDs[0]), gpgKeyBytes)
+
+	err = repo.VerifyRef(testCtx, refName, true, WithTimestampAuthority(chain))
+	assert.ErrorIs(t, err, attestations.ErrSignatureTimestampNotFound)
+
+	err = repo.TimestampSignatures(testCtx, server.URL, false)
+	assert.Nil(t, err)
+
+	err = repo.VerifyRef(testCtx, refName, true, WithTimestampAuthority(chain))
+	assert.Nil(t, err)
+
+	err = repo.VerifyRef(testCtx, refName, true, WithTimestampAuthority(otherChain))
+	assert.ErrorIs(t, err, tsa.ErrInvalidTimestamp)
+
+	// Signatures are not timestamped again
+	entry, _, err := rsl.GetLatestReferenceEntryForRef(repo.r, attestations.Ref)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = repo.TimestampSignatures(testCtx, server.URL, false)
+	assert.Nil(t, err)
+
+	latestEntry, _, err := rsl.GetLatestReferenceEntryForRef(repo.r, attestations.Ref)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, entry.ID, latestEntry.ID)
+
+	// Signatures made before the signing key was revoked remain valid
+	rootSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(rootKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+	gpgKey, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.RevokeKey(testCtx, rootSigner, gpgKey.KeyID, "compromised", false); err != nil {
+		t.Fatal(err)
+	}
+
+	err = repo.TimestampSignatures(testCtx, server.URL, false)
+	assert.Nil(t, err)
+
+	err = repo.VerifyRef(testCtx, refName, true, WithTimestampAuthority(chain))
+	assert.Nil(t, err)
+
+	// Signatures made after the signing key was revoked are rejected
+	clock.Advance(time.Since(clock.Now()) + time.Hour)
+
+	commitIDs = common.AddNTestCommitsToSpecifiedRef(t, repo.r, refName, 1, gpgKeyBytes)
+	common.CreateTestRSLReferenceEntryCommit(t, repo.r, rsl.NewReferenceEntry(refName, commitIDs[0]), gpgKeyBytes)
+
+	err = repo.TimestampSignatures(testCtx, server.URL, false)
+	assert.Nil(t, err)
+
+	err = repo.VerifyRef(testCtx, refName, true, WithTimestampAuthority(chain))
+	assert.ErrorIs(t, err, ErrSignedAfterRevocation)
+}
+
+func TestVerifySignatureTimestampsExpiry(t *testing.T) {
+	repo := createTestRepositoryWithPolicy(t, "")
+
+	// The timestamp authority reports a time after the policy metadata
+	// expires
+	clock := clockwork.NewFakeClockAt(time.Date(2090, time.January, 1, 0, 0, 0, 0, time.UTC))
+	server, chainPEM := common.NewTestTimestampAuthority(t, clock)
+
+	chain, err := tsa.LoadCertificateChain(chainPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	refName := "refs/heads/main"
+	commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo.r, refName, 1, gpgKeyBytes)
+	common.CreateTestRSLReferenceEntryCommit(t, repo.r, rsl.NewReferenceEntry(refName, commitIDs[0]), gpgKeyBytes)
+
+	err = repo.TimestampSignatures(testCtx, server.URL, false)
+	assert.Nil(t, err)
+
+	err = repo.VerifyRef(testCtx, refName, true, WithTimestampAuthority(chain))
+	assert.ErrorIs(t, err, ErrSignedAfterExpiry)
+}
@@ -0,0 +1,75 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sort"
+
+	"github.com/gittuf/gittuf/internal/common/set"
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+// SimulateVerify reports whether the staged policy, or the current policy if
+// nothing is staged, would permit an update to refName signed by the keys in
+// keyIDs. The files modified by the update are the specified paths along with
+// the paths changed by every commit between the ref's latest RSL entry and
+// targetID, if targetID is specified. targetID may be any revision that
+// resolves to a commit. No RSL entries are created.
+func (r *Repository) SimulateVerify(ctx context.Context, refName, targetID string, paths []string, keyIDs []string) (*policy.SimulationResult, error) {
+	state, err := r.loadStateForUpdate(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	refName, err = gitinterface.AbsoluteReference(r.r, refName)
+	if err != nil {
+		return nil, err
+	}
+
+	changedPaths := set.NewSet[string]()
+	for _, path := range paths {
+		changedPaths.Add(path)
+	}
+
+	if targetID != "" {
+		slog.Debug("Identifying paths changed by simulated update...")
+		latestEntryTarget := plumbing.ZeroHash
+		latestEntry, _, err := rsl.GetLatestReferenceEntryForRef(r.r, refName)
+		if err == nil {
+			latestEntryTarget = latestEntry.TargetID
+		} else if !errors.Is(err, rsl.ErrRSLEntryNotFound) {
+			return nil, err
+		}
+
+		target, err := r.r.ResolveRevision(plumbing.Revision(targetID))
+		if err != nil {
+			return nil, err
+		}
+
+		commits, err := gitinterface.GetCommitsBetweenRange(r.r, *target, latestEntryTarget)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, commit := range commits {
+			commitPaths, err := gitinterface.GetFilePathsChangedByCommit(r.r, commit)
+			if err != nil {
+				return nil, err
+			}
+			for _, path := range commitPaths {
+				changedPaths.Add(path)
+			}
+		}
+	}
+
+	slog.Debug("Simulating verification...")
+	sortedPaths := changedPaths.Contents()
+	sort.Strings(sortedPaths)
+	return state.Simulate(refName, sortedPaths, keyIDs)
+}
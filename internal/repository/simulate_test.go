@@ -0,0 +1,69 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/common"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/internal/signerverifier"
+	"github.com/gittuf/gittuf/internal/signerverifier/gpg"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSimulateVerify(t *testing.T) {
+	r := createTestRepositoryWithPolicy(t, "")
+
+	targetsSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(targetsKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsKey, err := tuf.LoadKeyFromBytes(targetsPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gpgKey, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := r.AddDelegation(testCtx, targetsSigner, policy.TargetsRoleName, "protect-file-1", []*tuf.Key{gpgKey}, []string{"file:1"}, 1, false); err != nil {
+		t.Fatal(err)
+	}
+
+	refName := "refs/heads/main"
+	commitIDs := common.AddNTestCommitsToSpecifiedRef(t, r.r, refName, 1, gpgKeyBytes)
+	if err := rsl.NewReferenceEntry(refName, commitIDs[0]).Commit(r.r, false); err != nil {
+		t.Fatal(err)
+	}
+	// Only the commits after the latest RSL entry are simulated, so file 1
+	// is not considered as it is unchanged since the entry
+	common.AddNTestCommitsToSpecifiedRef(t, r.r, refName, 2, gpgKeyBytes)
+
+	t.Run("authorized signer", func(t *testing.T) {
+		result, err := r.SimulateVerify(testCtx, "main", refName, nil, []string{gpgKey.KeyID})
+		assert.Nil(t, err)
+		assert.True(t, result.Allowed)
+	})
+
+	t.Run("unauthorized signer for explicit path", func(t *testing.T) {
+		result, err := r.SimulateVerify(testCtx, refName, "", []string{"1"}, []string{targetsKey.KeyID})
+		assert.Nil(t, err)
+		assert.False(t, result.Allowed)
+		assert.Equal(t, []string{"protect-file-1"}, result.Checks[1].Rules)
+	})
+
+	t.Run("unauthorized signer", func(t *testing.T) {
+		result, err := r.SimulateVerify(testCtx, "main", refName, []string{"3"}, []string{targetsKey.KeyID})
+		assert.Nil(t, err)
+		assert.False(t, result.Allowed)
+		assert.Equal(t, []*policy.SimulationCheck{
+			{Target: "git:refs/heads/main", Rules: []string{"protect-main"}, Allowed: false},
+			{Target: "file:2", Rules: []string{}, Allowed: true},
+			{Target: "file:3", Rules: []string{}, Allowed: true},
+		}, result.Checks)
+	})
+}
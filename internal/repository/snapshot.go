@@ -0,0 +1,146 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/signerverifier/dsse"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/go-git/go-git/v5/plumbing"
+	sslibdsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
+)
+
+var ErrNoSnapshotRole = errors.New("snapshot role is not defined in the root of trust")
+
+// AddSnapshotKey is the interface for the user to add an authorized key for the
+// snapshot role. Adding the first key enables the snapshot role, after which
+// every policy change must be recorded in snapshot metadata using
+// SignSnapshot before it is committed.
+func (r *Repository) AddSnapshotKey(ctx context.Context, signer sslibdsse.SignerVerifier, snapshotKey *tuf.Key, signCommit bool) error {
+	rootKeyID, err := signer.KeyID()
+	if err != nil {
+		return err
+	}
+
+	state, err := r.loadStateForUpdate(ctx)
+	if err != nil {
+		return err
+	}
+
+	rootMetadata, err := r.loadRootMetadata(state, rootKeyID)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Adding snapshot key...")
+	rootMetadata, err = policy.AddSnapshotKey(rootMetadata, snapshotKey)
+	if err != nil {
+		return fmt.Errorf("failed to add snapshot key: %w", err)
+	}
+
+	commitMessage := fmt.Sprintf("Add snapshot key '%s' to root", snapshotKey.KeyID)
+	return r.updateRootMetadata(ctx, state, signer, rootMetadata, commitMessage, signCommit)
+}
+
+// RemoveSnapshotKey is the interface for the user to de-authorize a key
+// trusted to sign the snapshot role. Removing the last key disables the
+// snapshot role.
+func (r *Repository) RemoveSnapshotKey(ctx context.Context, signer sslibdsse.SignerVerifier, snapshotKeyID string, signCommit bool) error {
+	rootKeyID, err := signer.KeyID()
+	if err != nil {
+		return err
+	}
+
+	state, err := r.loadStateForUpdate(ctx)
+	if err != nil {
+		return err
+	}
+
+	rootMetadata, err := r.loadRootMetadata(state, rootKeyID)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Removing snapshot key...")
+	rootMetadata, err = policy.DeleteSnapshotKey(rootMetadata, snapshotKeyID)
+	if err != nil {
+		return err
+	}
+
+	if _, has := rootMetadata.Roles[policy.SnapshotRoleName]; !has {
+		slog.Debug("Removing snapshot metadata as snapshot role is disabled...")
+		state.SnapshotEnvelope = nil
+	}
+
+	commitMessage := fmt.Sprintf("Remove snapshot key '%s' from root", snapshotKeyID)
+	return r.updateRootMetadata(ctx, state, signer, rootMetadata, commitMessage, signCommit)
+}
+
+// SignSnapshot records the policy files of the staged policy, or the current
+// policy if nothing is staged, in snapshot metadata and signs it. If the
+// snapshot metadata already records the policy files, the signature is added
+// to it. Staged changes are committed once all their signatures are present.
+func (r *Repository) SignSnapshot(ctx context.Context, signer sslibdsse.SignerVerifier, signCommit bool) error {
+	keyID, err := signer.KeyID()
+	if err != nil {
+		return err
+	}
+
+	state, err := r.loadStateForUpdate(ctx)
+	if err != nil {
+		return err
+	}
+
+	rootMetadata, err := state.GetRootMetadata()
+	if err != nil {
+		return err
+	}
+	snapshotRole, has := rootMetadata.Roles[policy.SnapshotRoleName]
+	if !has {
+		return ErrNoSnapshotRole
+	}
+	if !isKeyAuthorized(snapshotRole.KeyIDs, keyID) {
+		return ErrUnauthorizedKey
+	}
+
+	current, err := state.IsSnapshotCurrent()
+	if err != nil {
+		return err
+	}
+
+	env := state.SnapshotEnvelope
+	if !current {
+		slog.Debug("Generating snapshot metadata...")
+		snapshotMetadata, err := state.GenerateSnapshotMetadata()
+		if err != nil {
+			return err
+		}
+
+		env, err = dsse.CreateEnvelope(snapshotMetadata)
+		if err != nil {
+			return err
+		}
+	}
+
+	slog.Debug(fmt.Sprintf("Signing snapshot metadata using '%s'...", keyID))
+	env, err = dsse.SignEnvelope(ctx, env, signer)
+	if err != nil {
+		return err
+	}
+	state.SnapshotEnvelope = env
+
+	commitMessage, err := r.loadStagedCommitMessage()
+	if err != nil {
+		if !errors.Is(err, plumbing.ErrReferenceNotFound) {
+			return err
+		}
+		commitMessage = fmt.Sprintf("Add signature from key '%s' to snapshot", keyID)
+	}
+
+	return r.commitPolicyUpdate(ctx, state, commitMessage, signCommit)
+}
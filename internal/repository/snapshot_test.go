@@ -0,0 +1,107 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/signerverifier"
+	artifacts "github.com/gittuf/gittuf/internal/testartifacts"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignSnapshot(t *testing.T) {
+	r := createTestRepositoryWithPolicy(t, "")
+
+	rootSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(rootKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(targetsKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+	snapshotSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(artifacts.SSLibKey3Private) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+	snapshotKey, err := tuf.LoadKeyFromBytes(artifacts.SSLibKey3Public)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = r.SignSnapshot(testCtx, snapshotSigner, false)
+	assert.ErrorIs(t, err, ErrNoSnapshotRole)
+
+	// Enabling the snapshot role is staged until snapshot metadata is signed
+	err = r.AddSnapshotKey(testCtx, rootSigner, snapshotKey, false)
+	assert.Nil(t, err)
+
+	state, err := policy.LoadCurrentState(testCtx, r.r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hasSnapshotRole, err := state.HasSnapshotRole()
+	assert.Nil(t, err)
+	assert.False(t, hasSnapshotRole)
+
+	err = r.SignSnapshot(testCtx, targetsSigner, false)
+	assert.ErrorIs(t, err, ErrUnauthorizedKey)
+
+	err = r.SignSnapshot(testCtx, snapshotSigner, false)
+	assert.Nil(t, err)
+
+	state, err = policy.LoadCurrentState(testCtx, r.r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hasSnapshotRole, err = state.HasSnapshotRole()
+	assert.Nil(t, err)
+	assert.True(t, hasSnapshotRole)
+	assert.Nil(t, state.VerifySnapshot(testCtx))
+
+	_, err = policy.LoadStagedState(testCtx, r.r)
+	assert.ErrorIs(t, err, policy.ErrNoStagedPolicy)
+
+	// Policy changes are staged until snapshot metadata is signed
+	targetsPubKey, err := tuf.LoadKeyFromBytes(targetsPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = r.AddDelegation(testCtx, targetsSigner, policy.TargetsRoleName, "test-rule", []*tuf.Key{targetsPubKey}, []string{"git:refs/heads/test"}, 1, false)
+	assert.Nil(t, err)
+
+	state, err = policy.LoadCurrentState(testCtx, r.r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.False(t, state.HasRuleName("test-rule"))
+
+	err = r.SignSnapshot(testCtx, snapshotSigner, false)
+	assert.Nil(t, err)
+
+	state, err = policy.LoadCurrentState(testCtx, r.r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, state.HasRuleName("test-rule"))
+
+	snapshotMetadata, err := state.GetSnapshotMetadata()
+	assert.Nil(t, err)
+	assert.Equal(t, 2, snapshotMetadata.Version)
+
+	// Removing the last snapshot key disables the snapshot role
+	err = r.RemoveSnapshotKey(testCtx, rootSigner, snapshotKey.KeyID, false)
+	assert.Nil(t, err)
+
+	state, err = policy.LoadCurrentState(testCtx, r.r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hasSnapshotRole, err = state.HasSnapshotRole()
+	assert.Nil(t, err)
+	assert.False(t, hasSnapshotRole)
+	assert.Nil(t, state.SnapshotEnvelope)
+}
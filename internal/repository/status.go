@@ -0,0 +1,316 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	sslibdsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
+)
+
+// States of the local RSL relative to the remote's RSL.
+const (
+	SyncStateUpToDate = "up-to-date"
+	SyncStateAhead    = "ahead"
+	SyncStateBehind   = "behind"
+	SyncStateDiverged = "diverged"
+)
+
+// Status summarizes the gittuf state of the repository.
+type Status struct {
+	// RSL is the state of the RSL, nil if the repository does not have an
+	// RSL.
+	RSL *RSLStatus `json:"rsl"`
+
+	// Policy is the state of the policy, nil if the repository does not have
+	// a policy.
+	Policy *PolicyStatus `json:"policy"`
+
+	// Branch is the state of the current branch, nil if HEAD does not point
+	// to a branch with commits.
+	Branch *BranchStatus `json:"branch"`
+}
+
+// RSLStatus is the state of the local RSL relative to the remote's RSL.
+type RSLStatus struct {
+	LocalTip string `json:"local_tip"`
+
+	// Remote, RemoteTip, and State are only set if the remote's RSL is known.
+	Remote    string `json:"remote,omitempty"`
+	RemoteTip string `json:"remote_tip,omitempty"`
+	State     string `json:"state,omitempty"`
+}
+
+// PolicyStatus is the state of the policy and of the changes staged for it.
+type PolicyStatus struct {
+	Tip string `json:"tip"`
+
+	// StagedRoles are the roles with staged changes that have not been
+	// applied to the policy.
+	StagedRoles []string `json:"staged_roles,omitempty"`
+
+	// OutdatedRoles are the staged roles whose metadata has been updated in
+	// the policy since the changes were staged, so the staged changes have
+	// diverged from the policy.
+	OutdatedRoles []string `json:"outdated_roles,omitempty"`
+
+	// PendingRoles are the staged roles that are not yet signed by a
+	// threshold of their trusted keys.
+	PendingRoles []string `json:"pending_roles,omitempty"`
+}
+
+// BranchStatus is the state of the current branch relative to the RSL.
+type BranchStatus struct {
+	Name string `json:"name"`
+	Tip  string `json:"tip"`
+
+	// LatestEntry is the latest unskipped RSL entry for the branch, if any.
+	LatestEntry string `json:"latest_entry,omitempty"`
+
+	// Recorded indicates if the latest RSL entry for the branch records its
+	// tip.
+	Recorded bool `json:"recorded"`
+}
+
+// GetStatus returns the status of the repository's RSL, policy, and current
+// branch. The local RSL is compared with the RSL in the remote, which is
+// fetched to its remote tracker if fetch is set, otherwise the remote tracker's
+// current state is used.
+func (r *Repository) GetStatus(ctx context.Context, remoteName string, fetch bool) (*Status, error) {
+	status := &Status{}
+
+	rslStatus, err := r.getRSLStatus(ctx, remoteName, fetch)
+	if err != nil {
+		return nil, err
+	}
+	status.RSL = rslStatus
+
+	if rslStatus != nil {
+		policyStatus, err := r.getPolicyStatus(ctx)
+		if err != nil {
+			return nil, err
+		}
+		status.Policy = policyStatus
+	}
+
+	branchStatus, err := r.getBranchStatus()
+	if err != nil {
+		return nil, err
+	}
+	status.Branch = branchStatus
+
+	return status, nil
+}
+
+func (r *Repository) getRSLStatus(ctx context.Context, remoteName string, fetch bool) (*RSLStatus, error) {
+	localTip, err := gitinterface.GetTip(r.r, rsl.Ref)
+	if err != nil {
+		if errors.Is(err, plumbing.ErrReferenceNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	rslStatus := &RSLStatus{LocalTip: localTip.String()}
+
+	if _, err := r.r.Remote(remoteName); err != nil {
+		if errors.Is(err, git.ErrRemoteNotFound) {
+			return rslStatus, nil
+		}
+		return nil, err
+	}
+
+	trackerRef := rsl.RemoteTrackerRef(remoteName)
+	if fetch {
+		slog.Debug(fmt.Sprintf("Fetching RSL from '%s'...", remoteName))
+		refSpecs := []config.RefSpec{config.RefSpec(fmt.Sprintf("+%s:%s", rsl.Ref, trackerRef))}
+		if err := gitinterface.FetchRefSpec(ctx, r.r, remoteName, refSpecs); err != nil {
+			if errors.Is(err, transport.ErrEmptyRemoteRepository) || errors.Is(err, git.NoMatchingRefSpecError{}) {
+				// The remote does not have an RSL
+				return rslStatus, nil
+			}
+			return nil, err
+		}
+	}
+
+	remoteTip, err := gitinterface.GetTip(r.r, trackerRef)
+	if err != nil {
+		if errors.Is(err, plumbing.ErrReferenceNotFound) {
+			return rslStatus, nil
+		}
+		return nil, err
+	}
+
+	state, err := r.compareCommits(localTip, remoteTip)
+	if err != nil {
+		return nil, err
+	}
+
+	rslStatus.Remote = remoteName
+	rslStatus.RemoteTip = remoteTip.String()
+	rslStatus.State = state
+	return rslStatus, nil
+}
+
+// compareCommits returns the state of localID relative to remoteID.
+func (r *Repository) compareCommits(localID, remoteID plumbing.Hash) (string, error) {
+	switch {
+	case localID == remoteID:
+		return SyncStateUpToDate, nil
+	case localID.IsZero():
+		return SyncStateBehind, nil
+	case remoteID.IsZero():
+		return SyncStateAhead, nil
+	}
+
+	localCommit, err := gitinterface.GetCommit(r.r, localID)
+	if err != nil {
+		return "", err
+	}
+	remoteCommit, err := gitinterface.GetCommit(r.r, remoteID)
+	if err != nil {
+		return "", err
+	}
+
+	if knows, err := gitinterface.KnowsCommit(r.r, remoteID, localCommit); err != nil {
+		return "", err
+	} else if knows {
+		return SyncStateBehind, nil
+	}
+
+	if knows, err := gitinterface.KnowsCommit(r.r, localID, remoteCommit); err != nil {
+		return "", err
+	} else if knows {
+		return SyncStateAhead, nil
+	}
+
+	return SyncStateDiverged, nil
+}
+
+func (r *Repository) getPolicyStatus(ctx context.Context) (*PolicyStatus, error) {
+	currentState, err := policy.LoadCurrentState(ctx, r.r)
+	if err != nil {
+		if errors.Is(err, policy.ErrPolicyNotFound) || errors.Is(err, rsl.ErrRSLEntryNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	policyTip, err := gitinterface.GetTip(r.r, policy.PolicyRef)
+	if err != nil {
+		return nil, err
+	}
+	policyStatus := &PolicyStatus{Tip: policyTip.String()}
+
+	stagedState, err := policy.LoadStagedState(ctx, r.r)
+	if err != nil {
+		if errors.Is(err, policy.ErrNoStagedPolicy) {
+			return policyStatus, nil
+		}
+		return nil, err
+	}
+
+	currentEnvelopes := getRoleEnvelopes(currentState)
+	for roleName, stagedEnvelope := range getRoleEnvelopes(stagedState) {
+		currentEnvelope, has := currentEnvelopes[roleName]
+		if has && currentEnvelope.Payload == stagedEnvelope.Payload {
+			continue
+		}
+		policyStatus.StagedRoles = append(policyStatus.StagedRoles, roleName)
+
+		if !has {
+			continue
+		}
+
+		// A staged role is expected to be a newer version than the current
+		// metadata it was staged from
+		stagedVersion, err := getRoleVersion(stagedState, roleName)
+		if err != nil {
+			return nil, err
+		}
+		currentVersion, err := getRoleVersion(currentState, roleName)
+		if err != nil {
+			return nil, err
+		}
+		if stagedVersion <= currentVersion {
+			policyStatus.OutdatedRoles = append(policyStatus.OutdatedRoles, roleName)
+		}
+	}
+	sort.Strings(policyStatus.StagedRoles)
+	sort.Strings(policyStatus.OutdatedRoles)
+
+	pendingRoles, err := stagedState.PendingRoles(ctx, currentState)
+	if err != nil {
+		return nil, err
+	}
+	policyStatus.PendingRoles = pendingRoles
+
+	return policyStatus, nil
+}
+
+// getRoleEnvelopes returns the envelopes of the root and policy file roles in
+// the state, keyed by role name.
+func getRoleEnvelopes(state *policy.State) map[string]*sslibdsse.Envelope {
+	envelopes := map[string]*sslibdsse.Envelope{policy.RootRoleName: state.RootEnvelope}
+	if state.TargetsEnvelope != nil {
+		envelopes[policy.TargetsRoleName] = state.TargetsEnvelope
+	}
+	for roleName, envelope := range state.DelegationEnvelopes {
+		envelopes[roleName] = envelope
+	}
+	return envelopes
+}
+
+func getRoleVersion(state *policy.State, roleName string) (int, error) {
+	if roleName == policy.RootRoleName {
+		rootMetadata, err := state.GetRootMetadata()
+		if err != nil {
+			return 0, err
+		}
+		return rootMetadata.Version, nil
+	}
+
+	targetsMetadata, err := state.GetTargetsMetadata(roleName)
+	if err != nil {
+		return 0, err
+	}
+	return targetsMetadata.Version, nil
+}
+
+func (r *Repository) getBranchStatus() (*BranchStatus, error) {
+	head, err := r.r.Head()
+	if err != nil {
+		if errors.Is(err, plumbing.ErrReferenceNotFound) {
+			// HEAD points to a branch without commits
+			return nil, nil
+		}
+		return nil, err
+	}
+	if !head.Name().IsBranch() {
+		return nil, nil
+	}
+
+	branchStatus := &BranchStatus{Name: head.Name().String(), Tip: head.Hash().String()}
+
+	entry, _, err := rsl.GetLatestUnskippedReferenceEntryForRef(r.r, head.Name().String())
+	if err != nil {
+		if errors.Is(err, rsl.ErrRSLEntryNotFound) || errors.Is(err, plumbing.ErrReferenceNotFound) {
+			return branchStatus, nil
+		}
+		return nil, err
+	}
+
+	branchStatus.LatestEntry = entry.ID.String()
+	branchStatus.Recorded = entry.TargetID == head.Hash()
+	return branchStatus, nil
+}
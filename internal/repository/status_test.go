@@ -0,0 +1,144 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/common"
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/internal/signerverifier"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetStatus(t *testing.T) {
+	remoteName := "origin"
+	refName := "refs/heads/main"
+
+	t.Run("no gittuf state", func(t *testing.T) {
+		r, err := git.PlainInit(t.TempDir(), false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		repo := &Repository{r: r}
+
+		status, err := repo.GetStatus(testCtx, remoteName, true)
+		assert.Nil(t, err)
+		assert.Nil(t, status.RSL)
+		assert.Nil(t, status.Policy)
+		assert.Nil(t, status.Branch)
+	})
+
+	t.Run("RSL compared with remote", func(t *testing.T) {
+		repo := createTestRepositoryWithPolicy(t, "")
+
+		remoteTmpDir := t.TempDir()
+		if _, err := git.PlainInit(remoteTmpDir, true); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := repo.r.CreateRemote(&config.RemoteConfig{Name: remoteName, URLs: []string{remoteTmpDir}}); err != nil {
+			t.Fatal(err)
+		}
+
+		// The remote does not have an RSL
+		status, err := repo.GetStatus(testCtx, remoteName, true)
+		assert.Nil(t, err)
+		assert.Empty(t, status.RSL.Remote)
+
+		if err := gitinterface.Push(testCtx, repo.r, remoteName, []string{rsl.Ref, policy.PolicyRef}); err != nil {
+			t.Fatal(err)
+		}
+
+		status, err = repo.GetStatus(testCtx, remoteName, true)
+		assert.Nil(t, err)
+		assert.Equal(t, remoteName, status.RSL.Remote)
+		assert.Equal(t, status.RSL.LocalTip, status.RSL.RemoteTip)
+		assert.Equal(t, SyncStateUpToDate, status.RSL.State)
+
+		if err := rsl.NewReferenceEntry("refs/heads/feature", plumbing.ZeroHash).Commit(repo.r, false); err != nil {
+			t.Fatal(err)
+		}
+
+		status, err = repo.GetStatus(testCtx, remoteName, false)
+		assert.Nil(t, err)
+		assert.Equal(t, SyncStateAhead, status.RSL.State)
+	})
+
+	t.Run("current branch", func(t *testing.T) {
+		repo := createTestRepositoryWithPolicy(t, "")
+		if err := repo.r.Storer.SetReference(plumbing.NewSymbolicReference(plumbing.HEAD, plumbing.ReferenceName(refName))); err != nil {
+			t.Fatal(err)
+		}
+
+		// HEAD points to a branch without commits
+		status, err := repo.GetStatus(testCtx, remoteName, false)
+		assert.Nil(t, err)
+		assert.Nil(t, status.Branch)
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo.r, refName, 1, gpgKeyBytes)
+
+		status, err = repo.GetStatus(testCtx, remoteName, false)
+		assert.Nil(t, err)
+		assert.Equal(t, refName, status.Branch.Name)
+		assert.Equal(t, commitIDs[0].String(), status.Branch.Tip)
+		assert.Empty(t, status.Branch.LatestEntry)
+		assert.False(t, status.Branch.Recorded)
+
+		entryID := common.CreateTestRSLReferenceEntryCommit(t, repo.r, rsl.NewReferenceEntry(refName, commitIDs[0]), gpgKeyBytes)
+
+		status, err = repo.GetStatus(testCtx, remoteName, false)
+		assert.Nil(t, err)
+		assert.Equal(t, entryID.String(), status.Branch.LatestEntry)
+		assert.True(t, status.Branch.Recorded)
+
+		common.AddNTestCommitsToSpecifiedRef(t, repo.r, refName, 1, gpgKeyBytes)
+
+		status, err = repo.GetStatus(testCtx, remoteName, false)
+		assert.Nil(t, err)
+		assert.False(t, status.Branch.Recorded)
+	})
+
+	t.Run("staged policy", func(t *testing.T) {
+		repo, keyBytes := createTestRepositoryWithRoot(t, "")
+
+		rootSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(keyBytes) //nolint:staticcheck
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		status, err := repo.GetStatus(testCtx, remoteName, false)
+		assert.Nil(t, err)
+		assert.NotEmpty(t, status.Policy.Tip)
+		assert.Empty(t, status.Policy.StagedRoles)
+		assert.Empty(t, status.Policy.PendingRoles)
+
+		secondRootKey, err := tuf.LoadKeyFromBytes(targetsPubKeyBytes)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := repo.AddRootKey(testCtx, rootSigner, secondRootKey, false); err != nil {
+			t.Fatal(err)
+		}
+		if err := repo.UpdateRootThreshold(testCtx, rootSigner, 2, false); err != nil {
+			t.Fatal(err)
+		}
+
+		// With a threshold of 2, changes are staged until a second root key
+		// signs
+		if err := repo.UpdateRootThreshold(testCtx, rootSigner, 1, false); err != nil {
+			t.Fatal(err)
+		}
+
+		status, err = repo.GetStatus(testCtx, remoteName, false)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{policy.RootRoleName}, status.Policy.StagedRoles)
+		assert.Equal(t, []string{policy.RootRoleName}, status.Policy.PendingRoles)
+		assert.Empty(t, status.Policy.OutdatedRoles)
+	})
+}
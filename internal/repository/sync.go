@@ -8,24 +8,35 @@ import (
 	"fmt"
 	"log/slog"
 	"os"
+	"sort"
 	"strings"
 
+	"github.com/gittuf/gittuf/internal/attestations"
 	"github.com/gittuf/gittuf/internal/gitinterface"
 	"github.com/gittuf/gittuf/internal/policy"
 	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
 )
 
 var (
 	ErrCloningRepository = errors.New("unable to clone repository")
 	ErrDirExists         = errors.New("directory exists")
+	ErrPushingWithRSL    = errors.New("unable to push reference with RSL")
+	ErrPullingWithRSL    = errors.New("unable to pull reference with RSL")
+	ErrDivergedRSL       = errors.New("local and remote RSLs have diverged")
+
+	ErrCannotReplayRSLEntry = errors.New("unable to replay local RSL entry on remote RSL")
 )
 
 // Clone wraps a typical git clone invocation, fetching gittuf refs in addition
-// to the standard refs. It performs a verification of the RSL against the
-// specified HEAD after cloning the repository.
-// TODO: resolve how root keys are trusted / bootstrapped.
-func Clone(ctx context.Context, remoteURL, dir, initialBranch string) (*Repository, error) {
+// to the standard refs. After cloning the repository, it establishes the root of
+// trust and performs a full verification of the RSL against the specified HEAD.
+// If expectedRootKeyIDs are specified, the repository's initial root of trust
+// must be signed by a threshold of those keys. Otherwise, the initial root of
+// trust is trusted on first use.
+func Clone(ctx context.Context, remoteURL, dir, initialBranch string, expectedRootKeyIDs []string) (*Repository, error) {
 	slog.Debug(fmt.Sprintf("Cloning from '%s'...", remoteURL))
 
 	if dir == "" {
@@ -66,6 +77,583 @@ func Clone(ctx context.Context, remoteURL, dir, initialBranch string) (*Reposito
 
 	repository := &Repository{r: r}
 
+	if len(expectedRootKeyIDs) > 0 {
+		slog.Debug("Establishing root of trust using expected root keys...")
+		if err := policy.VerifyRootOfTrust(ctx, r, expectedRootKeyIDs); err != nil {
+			return repository, err
+		}
+	}
+
 	slog.Debug("Verifying HEAD...")
-	return repository, repository.VerifyRef(ctx, head.Target().String(), true)
+	return repository, repository.VerifyRef(ctx, head.Target().String(), false)
+}
+
+// maxPushAttempts is the number of times PushWithRSL attempts to push before
+// giving up when the remote RSL keeps moving.
+const maxPushAttempts = 3
+
+// PushWithRSL pushes the specified reference to the remote along with the RSL
+// and the gittuf policy and attestations references. An RSL entry is recorded
+// for the reference if its current target is not already recorded. All
+// references are pushed atomically, so the remote never receives the update to
+// the reference without the corresponding RSL entry.
+//
+// If the push is rejected because the remote RSL has moved, the local RSL
+// entries that are not in the remote RSL are replayed on top of the remote RSL
+// and the push is retried.
+func (r *Repository) PushWithRSL(ctx context.Context, remoteName, refName string, signCommit bool) error {
+	slog.Debug("Identifying absolute reference path...")
+	absRefName, err := gitinterface.AbsoluteReference(r.r, refName)
+	if err != nil {
+		return err
+	}
+
+	if err := r.RecordRSLEntryForReference(absRefName, signCommit); err != nil {
+		return err
+	}
+
+	refs := []string{absRefName, rsl.Ref}
+	for _, gittufRef := range []string{policy.PolicyRef, attestations.Ref} {
+		if _, err := r.r.Reference(plumbing.ReferenceName(gittufRef), true); err != nil {
+			if errors.Is(err, plumbing.ErrReferenceNotFound) {
+				continue
+			}
+			return err
+		}
+		refs = append(refs, gittufRef)
+	}
+
+	for attempt := 1; ; attempt++ {
+		slog.Debug(fmt.Sprintf("Pushing '%s' and gittuf references to '%s' (attempt %d)...", absRefName, remoteName, attempt))
+		pushErr := gitinterface.Push(ctx, r.r, remoteName, refs)
+		if pushErr == nil {
+			return nil
+		}
+		if attempt == maxPushAttempts {
+			return errors.Join(ErrPushingWithRSL, pushErr)
+		}
+
+		slog.Debug("Push failed, checking if remote RSL has moved...")
+		hasUpdates, _, err := r.CheckRemoteRSLForUpdates(ctx, remoteName)
+		if err != nil {
+			return errors.Join(ErrPushingWithRSL, pushErr, err)
+		}
+		if !hasUpdates {
+			return errors.Join(ErrPushingWithRSL, pushErr)
+		}
+
+		if err := r.replayRSLOnRemote(ctx, remoteName, signCommit); err != nil {
+			return errors.Join(ErrPushingWithRSL, err)
+		}
+	}
+}
+
+// replayRSLOnRemote rebases the local RSL on the remote RSL that was last
+// fetched into the remote tracker ref. Every local entry not in the remote RSL
+// is recreated on top of the remote RSL's tip. The gittuf references updated in
+// the remote RSL are fetched so that they can be pushed again. If the local RSL
+// cannot be replayed, it is left unchanged.
+func (r *Repository) replayRSLOnRemote(ctx context.Context, remoteName string, signCommit bool) error {
+	localRef, err := r.r.Reference(plumbing.ReferenceName(rsl.Ref), true)
+	if err != nil {
+		return err
+	}
+	remoteRef, err := r.r.Reference(plumbing.ReferenceName(rsl.RemoteTrackerRef(remoteName)), true)
+	if err != nil {
+		return err
+	}
+
+	localEntries, err := r.getRSLEntriesUnknownTo(localRef.Hash(), remoteRef.Hash())
+	if err != nil {
+		return err
+	}
+	remoteEntries, err := r.getRSLEntriesUnknownTo(remoteRef.Hash(), localRef.Hash())
+	if err != nil {
+		return err
+	}
+
+	// Identify the latest target of each reference updated in the remote RSL
+	remoteTargets := map[string]plumbing.Hash{}
+	for i := len(remoteEntries) - 1; i >= 0; i-- {
+		switch entry := remoteEntries[i].(type) {
+		case *rsl.ReferenceEntry:
+			remoteTargets[entry.RefName] = entry.TargetID
+		case *rsl.CheckpointEntry:
+			return fmt.Errorf("%w: remote RSL has been compacted", ErrCannotReplayRSLEntry)
+		}
+	}
+
+	gittufRefs := []string{}
+	for _, gittufRef := range []string{policy.PolicyRef, attestations.Ref} {
+		if _, has := remoteTargets[gittufRef]; has {
+			gittufRefs = append(gittufRefs, gittufRef)
+		}
+	}
+	if len(gittufRefs) > 0 {
+		slog.Debug("Fetching gittuf references updated in remote RSL...")
+		if err := gitinterface.Fetch(ctx, r.r, remoteName, gittufRefs, true); err != nil {
+			return errors.Join(ErrCannotReplayRSLEntry, err)
+		}
+	}
+
+	slog.Debug(fmt.Sprintf("Replaying %d local RSL entries on remote RSL...", len(localEntries)))
+	if err := r.r.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(rsl.Ref), remoteRef.Hash())); err != nil {
+		return err
+	}
+
+	if err := r.replayRSLEntries(localEntries, remoteTargets, signCommit); err != nil {
+		// Restore the local RSL's prior state
+		return errors.Join(err, r.r.Storer.SetReference(localRef))
+	}
+
+	return nil
+}
+
+// replayRSLEntries recreates the entries on top of the current RSL tip in the
+// order they are specified. Annotations that refer to replayed entries are
+// updated to refer to the recreated entries.
+func (r *Repository) replayRSLEntries(entries []rsl.Entry, remoteTargets map[string]plumbing.Hash, signCommit bool) error {
+	replayedIDs := map[plumbing.Hash]plumbing.Hash{}
+
+	for _, entry := range entries {
+		switch entry := entry.(type) {
+		case *rsl.ReferenceEntry:
+			if remoteTargetID, has := remoteTargets[entry.RefName]; has && remoteTargetID != entry.TargetID {
+				remoteTarget, err := gitinterface.GetCommit(r.r, remoteTargetID)
+				if err != nil {
+					return fmt.Errorf("%w: '%s' has been updated in remote RSL", ErrCannotReplayRSLEntry, entry.RefName)
+				}
+				knows, err := gitinterface.KnowsCommit(r.r, entry.TargetID, remoteTarget)
+				if err != nil {
+					return err
+				}
+				if !knows {
+					return fmt.Errorf("%w: '%s' has diverged from remote RSL", ErrCannotReplayRSLEntry, entry.RefName)
+				}
+			}
+
+			if err := rsl.NewReferenceEntry(entry.RefName, entry.TargetID).Commit(r.r, signCommit); err != nil {
+				return err
+			}
+		case *rsl.AnnotationEntry:
+			rslEntryIDs := make([]plumbing.Hash, 0, len(entry.RSLEntryIDs))
+			for _, id := range entry.RSLEntryIDs {
+				if replayedID, has := replayedIDs[id]; has {
+					id = replayedID
+				}
+				rslEntryIDs = append(rslEntryIDs, id)
+			}
+
+			annotation := &rsl.AnnotationEntry{RSLEntryIDs: rslEntryIDs, Skip: entry.Skip, SkipReason: entry.SkipReason, Message: entry.Message}
+			if err := annotation.Commit(r.r, signCommit); err != nil {
+				return err
+			}
+		case *rsl.CheckpointEntry:
+			return fmt.Errorf("%w: local RSL has been compacted", ErrCannotReplayRSLEntry)
+		}
+
+		replayedEntry, err := rsl.GetLatestEntry(r.r)
+		if err != nil {
+			return err
+		}
+		replayedIDs[entry.GetID()] = replayedEntry.GetID()
+	}
+
+	return nil
+}
+
+// getRSLEntriesUnknownTo returns the RSL entries reachable from tipID that are
+// not reachable from otherTipID, ordered from oldest to newest.
+func (r *Repository) getRSLEntriesUnknownTo(tipID, otherTipID plumbing.Hash) ([]rsl.Entry, error) {
+	entries := []rsl.Entry{}
+	if tipID.IsZero() {
+		return entries, nil
+	}
+
+	iterator, err := rsl.GetEntry(r.r, tipID)
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		if !otherTipID.IsZero() {
+			commitObj, err := gitinterface.GetCommit(r.r, iterator.GetID())
+			if err != nil {
+				return nil, err
+			}
+			knows, err := gitinterface.KnowsCommit(r.r, otherTipID, commitObj)
+			if err != nil {
+				return nil, err
+			}
+			if knows {
+				break
+			}
+		}
+
+		entries = append([]rsl.Entry{iterator}, entries...)
+
+		iterator, err = rsl.GetParentForEntry(r.r, iterator)
+		if err != nil {
+			if errors.Is(err, rsl.ErrRSLEntryNotFound) {
+				break
+			}
+			return nil, err
+		}
+	}
+
+	return entries, nil
+}
+
+// PullWithRSL fetches the specified reference from the remote along with the
+// RSL and the gittuf policy and attestations references. The new RSL entries
+// for the reference are verified against the policy, and the local reference
+// is fast-forwarded to the verified target only if verification passes. If
+// verification fails, the local gittuf references are restored to their prior
+// state.
+func (r *Repository) PullWithRSL(ctx context.Context, remoteName, refName string) error {
+	slog.Debug("Identifying absolute reference path...")
+	absRefName, err := gitinterface.AbsoluteReference(r.r, refName)
+	if err != nil {
+		if !errors.Is(err, gitinterface.ErrReferenceNotFound) {
+			return err
+		}
+		// The reference may not exist locally yet, assume it's a branch
+		absRefName = plumbing.NewBranchReferenceName(refName).String()
+	}
+
+	// If the local RSL exists, the entry to verify from is identified before
+	// the RSL is updated
+	var fromEntryID plumbing.Hash
+	if _, err := r.r.Reference(plumbing.ReferenceName(rsl.Ref), true); err == nil {
+		slog.Debug(fmt.Sprintf("Checking remote RSL in '%s' for updates...", remoteName))
+		_, diverged, err := r.CheckRemoteRSLForUpdates(ctx, remoteName)
+		if err != nil {
+			return errors.Join(ErrPullingWithRSL, err)
+		}
+		if diverged {
+			return errors.Join(ErrPullingWithRSL, ErrDivergedRSL)
+		}
+
+		fromEntry, _, err := rsl.GetLatestReferenceEntryForRef(r.r, absRefName)
+		if err == nil {
+			fromEntryID = fromEntry.ID
+		} else if !errors.Is(err, rsl.ErrRSLEntryNotFound) {
+			return err
+		}
+	} else if !errors.Is(err, plumbing.ErrReferenceNotFound) {
+		return err
+	}
+
+	gittufRefs := []string{rsl.Ref, policy.PolicyRef, attestations.Ref}
+	priorRefs := map[string]*plumbing.Reference{}
+	for _, gittufRef := range gittufRefs {
+		ref, err := r.r.Reference(plumbing.ReferenceName(gittufRef), true)
+		if err != nil {
+			if errors.Is(err, plumbing.ErrReferenceNotFound) {
+				continue
+			}
+			return err
+		}
+		priorRefs[gittufRef] = ref
+	}
+
+	slog.Debug(fmt.Sprintf("Fetching '%s' and gittuf references from '%s'...", absRefName, remoteName))
+	if err := r.fetchForPull(ctx, remoteName, absRefName); err != nil {
+		return errors.Join(ErrPullingWithRSL, err, r.restoreReferences(gittufRefs, priorRefs))
+	}
+
+	expectedTip, err := r.verifyPulledRef(ctx, remoteName, absRefName, fromEntryID)
+	if err != nil {
+		return errors.Join(ErrPullingWithRSL, err, r.restoreReferences(gittufRefs, priorRefs))
+	}
+
+	slog.Debug(fmt.Sprintf("Fast-forwarding '%s' to '%s'...", absRefName, expectedTip.String()))
+	if err := r.fastForwardRef(absRefName, expectedTip); err != nil {
+		return errors.Join(ErrPullingWithRSL, err)
+	}
+
+	return nil
+}
+
+// FetchGittufRefsIfStale checks if the RSL at the specified remote has entries
+// that are not in the local RSL and, if so, fetches the RSL, policy, and
+// attestations from the remote so that verification uses the latest state. The
+// fetched policy is verified before it is accepted locally, and the local
+// references are restored if verification fails. If the remote does not exist
+// or has no RSL, nothing is fetched. If the local and remote RSLs have
+// diverged, a warning is logged and the local references are left unchanged.
+// The returned value indicates if the local references were updated.
+func (r *Repository) FetchGittufRefsIfStale(ctx context.Context, remoteName string) (bool, error) {
+	if _, err := r.r.Remote(remoteName); err != nil {
+		if errors.Is(err, git.ErrRemoteNotFound) {
+			slog.Debug(fmt.Sprintf("Remote '%s' does not exist, not fetching gittuf references", remoteName))
+			return false, nil
+		}
+		return false, err
+	}
+
+	if _, err := r.r.Reference(plumbing.ReferenceName(rsl.Ref), true); err == nil {
+		slog.Debug(fmt.Sprintf("Checking remote RSL in '%s' for updates...", remoteName))
+		hasUpdates, diverged, err := r.CheckRemoteRSLForUpdates(ctx, remoteName)
+		if err != nil {
+			return false, err
+		}
+		if diverged {
+			slog.Warn(fmt.Sprintf("Local RSL has diverged from the RSL in '%s', verifying using local gittuf references", remoteName))
+			return false, nil
+		}
+		if !hasUpdates {
+			slog.Debug("Local gittuf references are up to date")
+			return false, nil
+		}
+	} else if !errors.Is(err, plumbing.ErrReferenceNotFound) {
+		return false, err
+	}
+
+	gittufRefs := []string{rsl.Ref, policy.PolicyRef, attestations.Ref}
+	priorRefs, err := r.getGittufRefsBeforeFetch(gittufRefs)
+	if err != nil {
+		return false, err
+	}
+
+	slog.Debug(fmt.Sprintf("Fetching gittuf references from '%s'...", remoteName))
+	if err := gitinterface.Fetch(ctx, r.r, remoteName, []string{rsl.Ref, policy.PolicyRef}, true); err != nil {
+		if errors.Is(err, git.NoMatchingRefSpecError{}) {
+			// The remote has no RSL or policy
+			return false, r.restoreReferences(gittufRefs, priorRefs)
+		}
+		return false, errors.Join(err, r.restoreReferences(gittufRefs, priorRefs))
+	}
+	if err := gitinterface.Fetch(ctx, r.r, remoteName, []string{attestations.Ref}, true); err != nil {
+		if !errors.Is(err, git.NoMatchingRefSpecError{}) {
+			return false, errors.Join(err, r.restoreReferences(gittufRefs, priorRefs))
+		}
+	}
+
+	slog.Debug("Verifying fetched policy...")
+	if err := r.verifyCurrentPolicy(ctx); err != nil {
+		return false, errors.Join(err, r.restoreReferences(gittufRefs, priorRefs))
+	}
+
+	return true, nil
+}
+
+// fetchForPull fetches the RSL and the policy to the local references, the
+// attestations if they exist in the remote, and the specified reference to its
+// remote tracker.
+func (r *Repository) fetchForPull(ctx context.Context, remoteName, refName string) error {
+	// Uninitialized references must be removed as they cannot be
+	// fast-forwarded
+	for _, gittufRef := range []string{rsl.Ref, policy.PolicyRef, attestations.Ref} {
+		ref, err := r.r.Reference(plumbing.ReferenceName(gittufRef), true)
+		if err != nil {
+			if errors.Is(err, plumbing.ErrReferenceNotFound) {
+				continue
+			}
+			return err
+		}
+		if ref.Hash().IsZero() {
+			if err := r.r.Storer.RemoveReference(ref.Name()); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := gitinterface.Fetch(ctx, r.r, remoteName, []string{rsl.Ref, policy.PolicyRef}, true); err != nil {
+		return err
+	}
+
+	if err := gitinterface.Fetch(ctx, r.r, remoteName, []string{attestations.Ref}, true); err != nil {
+		if !errors.Is(err, git.NoMatchingRefSpecError{}) {
+			return err
+		}
+	}
+
+	refSpec, err := gitinterface.RefSpec(r.r, refName, remoteName, false)
+	if err != nil {
+		return err
+	}
+	return gitinterface.FetchRefSpec(ctx, r.r, remoteName, []config.RefSpec{refSpec})
+}
+
+// verifyPulledRef verifies the RSL entries for the reference from the specified
+// entry, or the entire RSL if no entry is specified. It also checks that the
+// fetched target of the reference matches the latest RSL entry for it. The
+// verified target is returned.
+func (r *Repository) verifyPulledRef(ctx context.Context, remoteName, refName string, fromEntryID plumbing.Hash) (plumbing.Hash, error) {
+	if err := r.verifyPolicyExpiration(ctx); err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	var (
+		expectedTip plumbing.Hash
+		err         error
+	)
+
+	slog.Debug(fmt.Sprintf("Verifying gittuf policies for '%s'", refName))
+	if fromEntryID.IsZero() {
+		expectedTip, err = policy.VerifyRefFull(ctx, r.r, refName)
+	} else {
+		expectedTip, err = policy.VerifyRefFromEntry(ctx, r.r, refName, fromEntryID)
+	}
+	if err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	slog.Debug("Verifying if fetched tip of reference matches expected value from RSL...")
+	if err := r.verifyRefTip(gitinterface.RemoteRef(refName, remoteName), expectedTip); err != nil {
+		return plumbing.ZeroHash, err
+	}
+
+	return expectedTip, nil
+}
+
+// fastForwardRef updates the reference to the target, which must be a
+// descendant of the reference's current target. If the reference is checked out
+// in the worktree, the worktree is updated as well.
+func (r *Repository) fastForwardRef(refName string, targetID plumbing.Hash) error {
+	ref, err := r.r.Reference(plumbing.ReferenceName(refName), true)
+	if err != nil {
+		if !errors.Is(err, plumbing.ErrReferenceNotFound) {
+			return err
+		}
+		return r.r.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(refName), targetID))
+	}
+
+	if ref.Hash() == targetID {
+		return nil
+	}
+
+	if !ref.Hash().IsZero() {
+		currentCommit, err := gitinterface.GetCommit(r.r, ref.Hash())
+		if err != nil {
+			return err
+		}
+		knows, err := gitinterface.KnowsCommit(r.r, targetID, currentCommit)
+		if err != nil {
+			return err
+		}
+		if !knows {
+			return git.ErrNonFastForwardUpdate
+		}
+	}
+
+	head, err := r.r.Reference(plumbing.HEAD, false)
+	if err != nil {
+		return err
+	}
+	if head.Type() == plumbing.SymbolicReference && head.Target().String() == refName {
+		worktree, err := r.r.Worktree()
+		if err == nil {
+			return worktree.Reset(&git.ResetOptions{Commit: targetID, Mode: git.MergeReset})
+		}
+		if !errors.Is(err, git.ErrIsBareRepository) {
+			return err
+		}
+	}
+
+	return r.r.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(refName), targetID))
+}
+
+// restoreReferences resets the references to their prior state. References
+// that did not exist previously are removed.
+func (r *Repository) restoreReferences(refNames []string, priorRefs map[string]*plumbing.Reference) error {
+	for _, refName := range refNames {
+		if ref, has := priorRefs[refName]; has {
+			if err := r.r.Storer.SetReference(ref); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := r.r.Storer.RemoveReference(plumbing.ReferenceName(refName)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// VerifyFetchedRefs verifies references fetched from a remote before they are
+// applied locally. refs maps each reference, including gittuf's references, to
+// its tip on the remote, and the corresponding objects must already have been
+// fetched. Each reference outside gittuf's namespace is verified using all the
+// entries for it in the remote's RSL. The repository is not modified.
+func (r *Repository) VerifyFetchedRefs(ctx context.Context, refs map[string]plumbing.Hash, opts ...VerifyRefOption) error {
+	refNames := make([]string, 0, len(refs))
+	for refName := range refs {
+		refNames = append(refNames, refName)
+	}
+	sort.Strings(refNames)
+
+	updates := make([]*gitinterface.ReferenceUpdate, 0, len(refNames))
+	for _, refName := range refNames {
+		updates = append(updates, &gitinterface.ReferenceUpdate{RefName: refName, NewID: refs[refName]})
+	}
+
+	slog.Debug("Loading fetched repository state...")
+	fetchedRepo, err := gitinterface.NewRepositoryWithProposedUpdates(r.r, nil, updates)
+	if err != nil {
+		return err
+	}
+	fetched := &Repository{r: fetchedRepo}
+
+	if err := fetched.verifyPolicyExpiration(ctx, opts...); err != nil {
+		return err
+	}
+
+	var verificationErrs []error
+	for _, update := range updates {
+		if strings.HasPrefix(update.RefName, rsl.GittufNamespacePrefix) {
+			continue
+		}
+
+		slog.Debug(fmt.Sprintf("Verifying fetched reference '%s'...", update.RefName))
+		expectedTip, err := policy.VerifyRefFull(ctx, fetchedRepo, update.RefName)
+		if err == nil && expectedTip != update.NewID {
+			err = ErrRefStateDoesNotMatchRSL
+		}
+		if err != nil {
+			verificationErrs = append(verificationErrs, fmt.Errorf("unable to verify '%s': %w", update.RefName, err))
+		}
+	}
+
+	return errors.Join(verificationErrs...)
+}
+
+// TrackRemoteGittufRefs adds a fetch refspec to the specified remote so that
+// `git fetch` updates remote tracking refs for the remote's gittuf refs, such as
+// the remote's RSL tracker. Local gittuf refs are not updated by the refspec, so
+// they continue to be synced using gittuf. TrackRemoteGittufRefs returns false
+// if the remote already fetches the RSL.
+func (r *Repository) TrackRemoteGittufRefs(remoteName string) (bool, error) {
+	repoConfig, err := r.r.Config()
+	if err != nil {
+		return false, err
+	}
+
+	remoteConfig, has := repoConfig.Remotes[remoteName]
+	if !has {
+		return false, git.ErrRemoteNotFound
+	}
+
+	for _, refSpec := range remoteConfig.Fetch {
+		if refSpec.Match(plumbing.ReferenceName(rsl.Ref)) {
+			return false, nil
+		}
+	}
+
+	slog.Debug(fmt.Sprintf("Adding fetch refspec for gittuf refs to '%s'...", remoteName))
+	remoteConfig.Fetch = append(remoteConfig.Fetch, GittufFetchRefSpec(remoteName))
+	if err := r.r.SetConfig(repoConfig); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// GittufFetchRefSpec returns the fetch refspec that tracks the gittuf refs of
+// the specified remote.
+func GittufFetchRefSpec(remoteName string) config.RefSpec {
+	return config.RefSpec(fmt.Sprintf("+%s*:refs/remotes/%s/gittuf/*", rsl.GittufNamespacePrefix, remoteName))
 }
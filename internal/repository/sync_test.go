@@ -7,13 +7,17 @@ import (
 	"os"
 	"testing"
 
+	"github.com/gittuf/gittuf/internal/common"
 	"github.com/gittuf/gittuf/internal/gitinterface"
 	"github.com/gittuf/gittuf/internal/policy"
 	"github.com/gittuf/gittuf/internal/rsl"
 	"github.com/gittuf/gittuf/internal/signerverifier"
 	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/go-git/go-billy/v5/memfs"
 	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/storage/memory"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -94,7 +98,7 @@ func TestClone(t *testing.T) {
 		}
 		defer os.Chdir(currentDir) //nolint:errcheck
 
-		repo, err := Clone(context.Background(), remoteTmpDir, "", "")
+		repo, err := Clone(context.Background(), remoteTmpDir, "", "", nil)
 		assert.Nil(t, err)
 		head, err := repo.r.Head()
 		if err != nil {
@@ -123,7 +127,7 @@ func TestClone(t *testing.T) {
 		defer os.Chdir(currentDir) //nolint:errcheck
 
 		dirName := "myRepo"
-		repo, err := Clone(context.Background(), remoteTmpDir, dirName, "")
+		repo, err := Clone(context.Background(), remoteTmpDir, dirName, "", nil)
 		assert.Nil(t, err)
 		head, err := repo.r.Head()
 		if err != nil {
@@ -155,7 +159,7 @@ func TestClone(t *testing.T) {
 		}
 		defer os.Chdir(currentDir) //nolint:errcheck
 
-		repo, err := Clone(context.Background(), remoteTmpDir, "", anotherRefName)
+		repo, err := Clone(context.Background(), remoteTmpDir, "", anotherRefName, nil)
 		assert.Nil(t, err)
 		head, err := repo.r.Head()
 		if err != nil {
@@ -184,10 +188,10 @@ func TestClone(t *testing.T) {
 		}
 		defer os.Chdir(currentDir) //nolint:errcheck
 
-		_, err = Clone(context.Background(), remoteTmpDir, "", "")
+		_, err = Clone(context.Background(), remoteTmpDir, "", "", nil)
 		assert.Nil(t, err)
 
-		_, err = Clone(context.Background(), remoteTmpDir, "", "")
+		_, err = Clone(context.Background(), remoteTmpDir, "", "", nil)
 		assert.ErrorIs(t, err, ErrDirExists)
 	})
 
@@ -203,7 +207,7 @@ func TestClone(t *testing.T) {
 		if err := os.Mkdir(dirName, 0755); err != nil {
 			t.Fatal(err)
 		}
-		_, err = Clone(context.Background(), remoteTmpDir, dirName, "")
+		_, err = Clone(context.Background(), remoteTmpDir, dirName, "", nil)
 		assert.ErrorIs(t, err, ErrDirExists)
 	})
 
@@ -215,7 +219,7 @@ func TestClone(t *testing.T) {
 		}
 		defer os.Chdir(currentDir) //nolint:errcheck
 
-		repo, err := Clone(context.Background(), remoteTmpDir+"//", "", "")
+		repo, err := Clone(context.Background(), remoteTmpDir+"//", "", "", nil)
 		assert.Nil(t, err)
 		head, err := repo.r.Head()
 		if err != nil {
@@ -234,4 +238,418 @@ func TestClone(t *testing.T) {
 		}
 		assert.Equal(t, remotePolicyRef.Hash(), localPolicyRef.Hash())
 	})
+
+	t.Run("successful clone with expected root key", func(t *testing.T) {
+		localTmpDir := t.TempDir()
+
+		if err := os.Chdir(localTmpDir); err != nil {
+			t.Fatal(err)
+		}
+		defer os.Chdir(currentDir) //nolint:errcheck
+
+		rootKeyID, err := rootSigner.KeyID()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		repo, err := Clone(context.Background(), remoteTmpDir, "", "", []string{rootKeyID})
+		assert.Nil(t, err)
+		head, err := repo.r.Head()
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, commitID, head.Hash())
+	})
+
+	t.Run("unsuccessful clone with unexpected root key", func(t *testing.T) {
+		localTmpDir := t.TempDir()
+
+		if err := os.Chdir(localTmpDir); err != nil {
+			t.Fatal(err)
+		}
+		defer os.Chdir(currentDir) //nolint:errcheck
+
+		_, err := Clone(context.Background(), remoteTmpDir, "", "", []string{targetsPubKey.KeyID})
+		assert.ErrorIs(t, err, policy.ErrUntrustedRootOfTrust)
+	})
+}
+
+func TestPushWithRSL(t *testing.T) {
+	remoteName := "origin"
+	refName := "refs/heads/main"
+
+	setup := func(t *testing.T) (*Repository, *git.Repository) {
+		t.Helper()
+
+		remoteTmpDir := t.TempDir()
+		remoteRepo, err := git.PlainInit(remoteTmpDir, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		localRepo := createTestRepositoryWithPolicy(t, "")
+		if _, err := localRepo.r.CreateRemote(&config.RemoteConfig{
+			Name: remoteName,
+			URLs: []string{remoteTmpDir},
+		}); err != nil {
+			t.Fatal(err)
+		}
+
+		common.AddNTestCommitsToSpecifiedRef(t, localRepo.r, refName, 1, gpgKeyBytes)
+		if err := localRepo.PushWithRSL(testCtx, remoteName, refName, false); err != nil {
+			t.Fatal(err)
+		}
+
+		return localRepo, remoteRepo
+	}
+
+	t.Run("successful push", func(t *testing.T) {
+		localRepo, remoteRepo := setup(t)
+
+		assertLocalAndRemoteRefsMatch(t, localRepo.r, remoteRepo, refName)
+		assertLocalAndRemoteRefsMatch(t, localRepo.r, remoteRepo, rsl.Ref)
+		assertLocalAndRemoteRefsMatch(t, localRepo.r, remoteRepo, policy.PolicyRef)
+
+		ref, err := localRepo.r.Reference(plumbing.ReferenceName(refName), true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		entry, _, err := rsl.GetLatestReferenceEntryForRef(remoteRepo, refName)
+		assert.Nil(t, err)
+		assert.Equal(t, ref.Hash(), entry.TargetID)
+
+		// No updates, successful push
+		err = localRepo.PushWithRSL(testCtx, remoteName, refName, false)
+		assert.Nil(t, err)
+	})
+
+	t.Run("remote RSL moved, replay local entries", func(t *testing.T) {
+		localRepo, remoteRepo := setup(t)
+
+		if err := rsl.NewReferenceEntry("refs/heads/feature", plumbing.ZeroHash).Commit(remoteRepo, false); err != nil {
+			t.Fatal(err)
+		}
+		remoteEntry, err := rsl.GetLatestEntry(remoteRepo)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, localRepo.r, refName, 1, gpgKeyBytes)
+
+		err = localRepo.PushWithRSL(testCtx, remoteName, refName, false)
+		assert.Nil(t, err)
+
+		assertLocalAndRemoteRefsMatch(t, localRepo.r, remoteRepo, refName)
+		assertLocalAndRemoteRefsMatch(t, localRepo.r, remoteRepo, rsl.Ref)
+
+		latestEntry, err := rsl.GetLatestEntry(remoteRepo)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, commitIDs[0], latestEntry.(*rsl.ReferenceEntry).TargetID)
+
+		parentEntry, err := rsl.GetParentForEntry(remoteRepo, latestEntry)
+		assert.Nil(t, err)
+		assert.Equal(t, remoteEntry.GetID(), parentEntry.GetID())
+	})
+
+	t.Run("remote RSL updated same reference, unsuccessful push", func(t *testing.T) {
+		localRepo, remoteRepo := setup(t)
+
+		if err := rsl.NewReferenceEntry(refName, plumbing.NewHash("abcdef1234567890abcdef1234567890abcdef12")).Commit(remoteRepo, false); err != nil {
+			t.Fatal(err)
+		}
+
+		common.AddNTestCommitsToSpecifiedRef(t, localRepo.r, refName, 1, gpgKeyBytes)
+		if err := localRepo.RecordRSLEntryForReference(refName, false); err != nil {
+			t.Fatal(err)
+		}
+		localRSLTip, err := localRepo.r.Reference(plumbing.ReferenceName(rsl.Ref), true)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = localRepo.PushWithRSL(testCtx, remoteName, refName, false)
+		assert.ErrorIs(t, err, ErrPushingWithRSL)
+		assert.ErrorIs(t, err, ErrCannotReplayRSLEntry)
+
+		// Local RSL is left unchanged
+		currentRSLTip, err := localRepo.r.Reference(plumbing.ReferenceName(rsl.Ref), true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, localRSLTip.Hash(), currentRSLTip.Hash())
+	})
+}
+
+func TestPullWithRSL(t *testing.T) {
+	remoteName := "origin"
+	refName := "refs/heads/main"
+
+	setup := func(t *testing.T) (*Repository, *Repository) {
+		t.Helper()
+
+		remoteTmpDir := t.TempDir()
+		remoteRepo := createTestRepositoryWithPolicy(t, remoteTmpDir)
+
+		localR, err := git.Init(memory.NewStorage(), memfs.New())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := localR.CreateRemote(&config.RemoteConfig{
+			Name: remoteName,
+			URLs: []string{remoteTmpDir},
+		}); err != nil {
+			t.Fatal(err)
+		}
+
+		return &Repository{r: localR}, remoteRepo
+	}
+
+	addEntry := func(t *testing.T, repo *git.Repository, signingKeyBytes []byte) {
+		t.Helper()
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo, refName, 1, gpgKeyBytes)
+		common.CreateTestRSLReferenceEntryCommit(t, repo, rsl.NewReferenceEntry(refName, commitIDs[0]), signingKeyBytes)
+	}
+
+	t.Run("successful pull", func(t *testing.T) {
+		localRepo, remoteRepo := setup(t)
+
+		addEntry(t, remoteRepo.r, gpgKeyBytes)
+
+		err := localRepo.PullWithRSL(testCtx, remoteName, "main")
+		assert.Nil(t, err)
+
+		assertLocalAndRemoteRefsMatch(t, localRepo.r, remoteRepo.r, refName)
+		assertLocalAndRemoteRefsMatch(t, localRepo.r, remoteRepo.r, rsl.Ref)
+		assertLocalAndRemoteRefsMatch(t, localRepo.r, remoteRepo.r, policy.PolicyRef)
+
+		// New entries are verified from the previously pulled entry
+		addEntry(t, remoteRepo.r, gpgKeyBytes)
+
+		err = localRepo.PullWithRSL(testCtx, remoteName, "main")
+		assert.Nil(t, err)
+
+		assertLocalAndRemoteRefsMatch(t, localRepo.r, remoteRepo.r, refName)
+		assertLocalAndRemoteRefsMatch(t, localRepo.r, remoteRepo.r, rsl.Ref)
+	})
+
+	t.Run("unauthorized entry, unsuccessful pull", func(t *testing.T) {
+		localRepo, remoteRepo := setup(t)
+
+		addEntry(t, remoteRepo.r, gpgKeyBytes)
+		if err := localRepo.PullWithRSL(testCtx, remoteName, "main"); err != nil {
+			t.Fatal(err)
+		}
+
+		priorRef, err := localRepo.r.Reference(plumbing.ReferenceName(refName), true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		priorRSLRef, err := localRepo.r.Reference(plumbing.ReferenceName(rsl.Ref), true)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		addEntry(t, remoteRepo.r, gpgUnauthorizedKeyBytes)
+
+		err = localRepo.PullWithRSL(testCtx, remoteName, "main")
+		assert.ErrorIs(t, err, ErrPullingWithRSL)
+
+		// Local references are left unchanged
+		currentRef, err := localRepo.r.Reference(plumbing.ReferenceName(refName), true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, priorRef.Hash(), currentRef.Hash())
+		currentRSLRef, err := localRepo.r.Reference(plumbing.ReferenceName(rsl.Ref), true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, priorRSLRef.Hash(), currentRSLRef.Hash())
+	})
+}
+
+func TestFetchGittufRefsIfStale(t *testing.T) {
+	remoteName := "origin"
+
+	targetsSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(targetsKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsPubKey, err := tuf.LoadKeyFromBytes(targetsPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("local references are behind", func(t *testing.T) {
+		remoteTmpDir := t.TempDir()
+		remoteRepo := createTestRepositoryWithPolicy(t, remoteTmpDir)
+
+		localR, err := git.Init(memory.NewStorage(), memfs.New())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := localR.CreateRemote(&config.RemoteConfig{
+			Name: remoteName,
+			URLs: []string{remoteTmpDir},
+		}); err != nil {
+			t.Fatal(err)
+		}
+		localRepo := &Repository{r: localR}
+
+		if err := localRepo.PullPolicy(testCtx, remoteName); err != nil {
+			t.Fatal(err)
+		}
+
+		updated, err := localRepo.FetchGittufRefsIfStale(testCtx, remoteName)
+		assert.Nil(t, err)
+		assert.False(t, updated)
+
+		if err := remoteRepo.AddDelegation(testCtx, targetsSigner, policy.TargetsRoleName, "protect-feature", []*tuf.Key{targetsPubKey}, []string{"git:refs/heads/feature"}, 1, false); err != nil {
+			t.Fatal(err)
+		}
+
+		updated, err = localRepo.FetchGittufRefsIfStale(testCtx, remoteName)
+		assert.Nil(t, err)
+		assert.True(t, updated)
+
+		assertLocalAndRemoteRefsMatch(t, localRepo.r, remoteRepo.r, rsl.Ref)
+		assertLocalAndRemoteRefsMatch(t, localRepo.r, remoteRepo.r, policy.PolicyRef)
+	})
+
+	t.Run("local and remote RSLs have diverged", func(t *testing.T) {
+		remoteTmpDir := t.TempDir()
+		createTestRepositoryWithPolicy(t, remoteTmpDir)
+
+		localRepo := createTestRepositoryWithPolicy(t, "")
+		if _, err := localRepo.r.CreateRemote(&config.RemoteConfig{
+			Name: remoteName,
+			URLs: []string{remoteTmpDir},
+		}); err != nil {
+			t.Fatal(err)
+		}
+
+		priorRSLRef, err := localRepo.r.Reference(plumbing.ReferenceName(rsl.Ref), true)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		updated, err := localRepo.FetchGittufRefsIfStale(testCtx, remoteName)
+		assert.Nil(t, err)
+		assert.False(t, updated)
+
+		currentRSLRef, err := localRepo.r.Reference(plumbing.ReferenceName(rsl.Ref), true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, priorRSLRef.Hash(), currentRSLRef.Hash())
+	})
+
+	t.Run("remote does not exist", func(t *testing.T) {
+		localRepo := createTestRepositoryWithPolicy(t, "")
+
+		updated, err := localRepo.FetchGittufRefsIfStale(testCtx, remoteName)
+		assert.Nil(t, err)
+		assert.False(t, updated)
+	})
+}
+
+func TestVerifyFetchedRefs(t *testing.T) {
+	refName := "refs/heads/main"
+
+	// setup creates a repository in which main has one commit, and then
+	// creates a commit signed using keyBytes and an RSL entry for it as if
+	// they were fetched from a remote, without updating local references. The
+	// local tip of main, and the remote tips of main and the RSL are returned.
+	setup := func(t *testing.T, keyBytes []byte) (*Repository, plumbing.Hash, plumbing.Hash, plumbing.Hash) {
+		t.Helper()
+
+		repo := createTestRepositoryWithPolicy(t, "")
+		if err := repo.r.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(refName), plumbing.ZeroHash)); err != nil {
+			t.Fatal(err)
+		}
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo.r, refName, 1, gpgKeyBytes)
+		localRSLTip := common.CreateTestRSLReferenceEntryCommit(t, repo.r, rsl.NewReferenceEntry(refName, commitIDs[0]), gpgKeyBytes)
+
+		remoteCommitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo.r, refName, 1, keyBytes)
+		remoteRSLTip := common.CreateTestRSLReferenceEntryCommit(t, repo.r, rsl.NewReferenceEntry(refName, remoteCommitIDs[0]), keyBytes)
+
+		if err := repo.r.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(refName), commitIDs[0])); err != nil {
+			t.Fatal(err)
+		}
+		if err := repo.r.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(rsl.Ref), localRSLTip)); err != nil {
+			t.Fatal(err)
+		}
+
+		return repo, commitIDs[0], remoteCommitIDs[0], remoteRSLTip
+	}
+
+	t.Run("valid fetched state", func(t *testing.T) {
+		repo, localTip, remoteTip, remoteRSLTip := setup(t, gpgKeyBytes)
+
+		err := repo.VerifyFetchedRefs(testCtx, map[string]plumbing.Hash{refName: remoteTip, rsl.Ref: remoteRSLTip})
+		assert.Nil(t, err)
+
+		// The repository is not modified
+		tip, err := gitinterface.GetTip(repo.r, refName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, localTip, tip)
+	})
+
+	t.Run("policy violation", func(t *testing.T) {
+		repo, _, remoteTip, remoteRSLTip := setup(t, gpgUnauthorizedKeyBytes)
+
+		err := repo.VerifyFetchedRefs(testCtx, map[string]plumbing.Hash{refName: remoteTip, rsl.Ref: remoteRSLTip})
+		assert.ErrorIs(t, err, policy.ErrUnauthorizedSignature)
+	})
+
+	t.Run("reference does not match RSL", func(t *testing.T) {
+		repo, localTip, _, remoteRSLTip := setup(t, gpgKeyBytes)
+
+		err := repo.VerifyFetchedRefs(testCtx, map[string]plumbing.Hash{refName: localTip, rsl.Ref: remoteRSLTip})
+		assert.ErrorIs(t, err, ErrRefStateDoesNotMatchRSL)
+	})
+}
+
+func TestTrackRemoteGittufRefs(t *testing.T) {
+	remoteName := "origin"
+
+	r, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo := &Repository{r: r}
+
+	_, err = repo.TrackRemoteGittufRefs(remoteName)
+	assert.ErrorIs(t, err, git.ErrRemoteNotFound)
+
+	if _, err := r.CreateRemote(&config.RemoteConfig{
+		Name:  remoteName,
+		URLs:  []string{"https://example.com/repo.git"},
+		Fetch: []config.RefSpec{"+refs/heads/*:refs/remotes/origin/*"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	added, err := repo.TrackRemoteGittufRefs(remoteName)
+	assert.Nil(t, err)
+	assert.True(t, added)
+
+	remote, err := r.Remote(remoteName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []config.RefSpec{"+refs/heads/*:refs/remotes/origin/*", "+refs/gittuf/*:refs/remotes/origin/gittuf/*"}, remote.Config().Fetch)
+	assert.True(t, remote.Config().Fetch[1].Match(plumbing.ReferenceName(rsl.Ref)))
+	assert.Equal(t, rsl.RemoteTrackerRef(remoteName), remote.Config().Fetch[1].Dst(plumbing.ReferenceName(rsl.Ref)).String())
+
+	// The refspec is only added once
+	added, err = repo.TrackRemoteGittufRefs(remoteName)
+	assert.Nil(t, err)
+	assert.False(t, added)
 }
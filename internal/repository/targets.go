@@ -14,12 +14,12 @@ import (
 	sslibdsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
 )
 
-var ErrInvalidPolicyName = errors.New("invalid rule or policy file name, cannot be 'root'")
+var ErrInvalidPolicyName = errors.New("invalid rule or policy file name, cannot be 'root' or 'snapshot'")
 
 // InitializeTargets is the interface for the user to create the specified
 // policy file.
 func (r *Repository) InitializeTargets(ctx context.Context, signer sslibdsse.SignerVerifier, targetsRoleName string, signCommit bool) error {
-	if targetsRoleName == policy.RootRoleName {
+	if targetsRoleName == policy.RootRoleName || targetsRoleName == policy.SnapshotRoleName {
 		return ErrInvalidPolicyName
 	}
 
@@ -28,8 +28,7 @@ func (r *Repository) InitializeTargets(ctx context.Context, signer sslibdsse.Sig
 		return nil
 	}
 
-	slog.Debug("Loading current policy...")
-	state, err := policy.LoadCurrentState(ctx, r.r)
+	state, err := r.loadStateForUpdate(ctx)
 	if err != nil {
 		return err
 	}
@@ -67,14 +66,78 @@ func (r *Repository) InitializeTargets(ctx context.Context, signer sslibdsse.Sig
 
 	commitMessage := fmt.Sprintf("Initialize policy '%s'", targetsRoleName)
 
-	slog.Debug("Committing policy...")
-	return state.Commit(ctx, r.r, commitMessage, signCommit)
+	return r.commitPolicyUpdate(ctx, state, commitMessage, signCommit)
+}
+
+// InitializeTargetsFromTemplate is the interface for the user to create the
+// specified policy file populated with the rules of a policy template. Every
+// rule authorizes all of the keys in authorizedKeys. The policy file and all of
+// its rules are created in a single policy update.
+func (r *Repository) InitializeTargetsFromTemplate(ctx context.Context, signer sslibdsse.SignerVerifier, targetsRoleName, templateName string, authorizedKeys []*tuf.Key, signCommit bool) error {
+	if targetsRoleName == policy.RootRoleName || targetsRoleName == policy.SnapshotRoleName {
+		return ErrInvalidPolicyName
+	}
+
+	template, err := policy.GetTemplate(templateName)
+	if err != nil {
+		return err
+	}
+
+	keyID, err := signer.KeyID()
+	if err != nil {
+		return err
+	}
+
+	state, err := r.loadStateForUpdate(ctx)
+	if err != nil {
+		return err
+	}
+	if state.HasTargetsRole(targetsRoleName) {
+		return ErrCannotReinitialize
+	}
+
+	slog.Debug(fmt.Sprintf("Creating rule file from template '%s'...", templateName))
+	targetsMetadata, err := template.Apply(policy.InitializeTargetsMetadata(), authorizedKeys)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Checking if rules with same names exist...")
+	for _, rule := range targetsMetadata.Delegations.Roles {
+		if rule.Name != policy.AllowRuleName && state.HasRuleName(rule.Name) {
+			return fmt.Errorf("%w: '%s'", policy.ErrDuplicatedRuleName, rule.Name)
+		}
+	}
+
+	env, err := dsse.CreateEnvelope(targetsMetadata)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug(fmt.Sprintf("Signing initial rule file using '%s'...", keyID))
+	env, err = dsse.SignEnvelope(ctx, env, signer)
+	if err != nil {
+		return err
+	}
+
+	if targetsRoleName == policy.TargetsRoleName {
+		state.TargetsEnvelope = env
+	} else {
+		if state.DelegationEnvelopes == nil {
+			state.DelegationEnvelopes = map[string]*sslibdsse.Envelope{}
+		}
+		state.DelegationEnvelopes[targetsRoleName] = env
+	}
+
+	commitMessage := fmt.Sprintf("Initialize policy '%s' from template '%s'", targetsRoleName, templateName)
+
+	return r.commitPolicyUpdate(ctx, state, commitMessage, signCommit)
 }
 
 // AddDelegation is the interface for the user to add a new rule to gittuf
 // policy.
 func (r *Repository) AddDelegation(ctx context.Context, signer sslibdsse.SignerVerifier, targetsRoleName string, ruleName string, authorizedKeys []*tuf.Key, rulePatterns []string, threshold int, signCommit bool) error {
-	if ruleName == policy.RootRoleName {
+	if ruleName == policy.RootRoleName || ruleName == policy.SnapshotRoleName {
 		return ErrInvalidPolicyName
 	}
 
@@ -83,8 +146,7 @@ func (r *Repository) AddDelegation(ctx context.Context, signer sslibdsse.SignerV
 		return nil
 	}
 
-	slog.Debug("Loading current policy...")
-	state, err := policy.LoadCurrentState(ctx, r.r)
+	state, err := r.loadStateForUpdate(ctx)
 	if err != nil {
 		return err
 	}
@@ -136,14 +198,13 @@ func (r *Repository) AddDelegation(ctx context.Context, signer sslibdsse.SignerV
 
 	commitMessage := fmt.Sprintf("Add rule '%s' to policy '%s'", ruleName, targetsRoleName)
 
-	slog.Debug("Committing policy...")
-	return state.Commit(ctx, r.r, commitMessage, signCommit)
+	return r.commitPolicyUpdate(ctx, state, commitMessage, signCommit)
 }
 
 // UpdateDelegation is the interface for the user to update a rule to gittuf
 // policy.
 func (r *Repository) UpdateDelegation(ctx context.Context, signer sslibdsse.SignerVerifier, targetsRoleName string, ruleName string, authorizedKeys []*tuf.Key, rulePatterns []string, threshold int, signCommit bool) error {
-	if ruleName == policy.RootRoleName {
+	if ruleName == policy.RootRoleName || ruleName == policy.SnapshotRoleName {
 		return ErrInvalidPolicyName
 	}
 
@@ -152,8 +213,7 @@ func (r *Repository) UpdateDelegation(ctx context.Context, signer sslibdsse.Sign
 		return nil
 	}
 
-	slog.Debug("Loading current policy...")
-	state, err := policy.LoadCurrentState(ctx, r.r)
+	state, err := r.loadStateForUpdate(ctx)
 	if err != nil {
 		return err
 	}
@@ -200,8 +260,471 @@ func (r *Repository) UpdateDelegation(ctx context.Context, signer sslibdsse.Sign
 
 	commitMessage := fmt.Sprintf("Update rule '%s' in policy '%s'", ruleName, targetsRoleName)
 
-	slog.Debug("Committing policy...")
-	return state.Commit(ctx, r.r, commitMessage, signCommit)
+	return r.commitPolicyUpdate(ctx, state, commitMessage, signCommit)
+}
+
+// SetMergeStrategy is the interface for the user to set the merge strategy
+// used to verify the Git references protected by a rule.
+func (r *Repository) SetMergeStrategy(ctx context.Context, signer sslibdsse.SignerVerifier, targetsRoleName, ruleName, mergeStrategy string, signCommit bool) error {
+	keyID, err := signer.KeyID()
+	if err != nil {
+		return err
+	}
+
+	state, err := r.loadStateForUpdate(ctx)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Loading current rule file...")
+	if !state.HasTargetsRole(targetsRoleName) {
+		return policy.ErrMetadataNotFound
+	}
+
+	targetsMetadata, err := state.GetTargetsMetadata(targetsRoleName)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug(fmt.Sprintf("Setting merge strategy of rule to '%s'...", mergeStrategy))
+	targetsMetadata, err = policy.SetMergeStrategy(targetsMetadata, ruleName, mergeStrategy)
+	if err != nil {
+		return err
+	}
+
+	targetsMetadata.SetVersion(targetsMetadata.Version + 1)
+
+	env, err := dsse.CreateEnvelope(targetsMetadata)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug(fmt.Sprintf("Signing updated rule file using '%s'...", keyID))
+	env, err = dsse.SignEnvelope(ctx, env, signer)
+	if err != nil {
+		return err
+	}
+
+	if targetsRoleName == policy.TargetsRoleName {
+		state.TargetsEnvelope = env
+	} else {
+		state.DelegationEnvelopes[targetsRoleName] = env
+	}
+
+	commitMessage := fmt.Sprintf("Set merge strategy of rule '%s' in policy '%s' to '%s'", ruleName, targetsRoleName, mergeStrategy)
+
+	return r.commitPolicyUpdate(ctx, state, commitMessage, signCommit)
+}
+
+// SetRuleEffect is the interface for the user to set whether a rule allows or
+// denies changes to the namespaces it protects.
+func (r *Repository) SetRuleEffect(ctx context.Context, signer sslibdsse.SignerVerifier, targetsRoleName, ruleName, effect string, signCommit bool) error {
+	commitMessage := fmt.Sprintf("Set effect of rule '%s' in policy '%s' to '%s'", ruleName, targetsRoleName, effect)
+
+	return r.updateTargetsMetadata(ctx, signer, targetsRoleName, commitMessage, signCommit, func(targetsMetadata *tuf.TargetsMetadata) (*tuf.TargetsMetadata, error) {
+		slog.Debug(fmt.Sprintf("Setting effect of rule to '%s'...", effect))
+		return policy.SetRuleEffect(targetsMetadata, ruleName, effect)
+	})
+}
+
+// SetRulePriority is the interface for the user to set the priority of a rule.
+// Rules with a higher priority are evaluated before other rules in the same
+// policy file.
+func (r *Repository) SetRulePriority(ctx context.Context, signer sslibdsse.SignerVerifier, targetsRoleName, ruleName string, priority int, signCommit bool) error {
+	commitMessage := fmt.Sprintf("Set priority of rule '%s' in policy '%s' to %d", ruleName, targetsRoleName, priority)
+
+	return r.updateTargetsMetadata(ctx, signer, targetsRoleName, commitMessage, signCommit, func(targetsMetadata *tuf.TargetsMetadata) (*tuf.TargetsMetadata, error) {
+		slog.Debug(fmt.Sprintf("Setting priority of rule to %d...", priority))
+		return policy.SetRulePriority(targetsMetadata, ruleName, priority)
+	})
+}
+
+// ReorderRules is the interface for the user to change the order in which the
+// rules in a policy file are listed. ruleNames must include every rule in the
+// policy file.
+func (r *Repository) ReorderRules(ctx context.Context, signer sslibdsse.SignerVerifier, targetsRoleName string, ruleNames []string, signCommit bool) error {
+	commitMessage := fmt.Sprintf("Reorder rules in policy '%s'", targetsRoleName)
+
+	return r.updateTargetsMetadata(ctx, signer, targetsRoleName, commitMessage, signCommit, func(targetsMetadata *tuf.TargetsMetadata) (*tuf.TargetsMetadata, error) {
+		slog.Debug("Reordering rules...")
+		return policy.ReorderRules(targetsMetadata, ruleNames)
+	})
+}
+
+// SetForcePushAuthorizers is the interface for the user to set the keys that
+// may authorize rewriting the history of the Git references protected by a
+// rule. Passing no keys disallows history rewrites for the rule.
+func (r *Repository) SetForcePushAuthorizers(ctx context.Context, signer sslibdsse.SignerVerifier, targetsRoleName, ruleName string, authorizedKeys []*tuf.Key, signCommit bool) error {
+	keyID, err := signer.KeyID()
+	if err != nil {
+		return err
+	}
+
+	state, err := r.loadStateForUpdate(ctx)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Loading current rule file...")
+	if !state.HasTargetsRole(targetsRoleName) {
+		return policy.ErrMetadataNotFound
+	}
+
+	targetsMetadata, err := state.GetTargetsMetadata(targetsRoleName)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Setting keys authorized to approve force pushes for rule...")
+	targetsMetadata, err = policy.SetForcePushAuthorizers(targetsMetadata, ruleName, authorizedKeys)
+	if err != nil {
+		return err
+	}
+
+	targetsMetadata.SetVersion(targetsMetadata.Version + 1)
+
+	env, err := dsse.CreateEnvelope(targetsMetadata)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug(fmt.Sprintf("Signing updated rule file using '%s'...", keyID))
+	env, err = dsse.SignEnvelope(ctx, env, signer)
+	if err != nil {
+		return err
+	}
+
+	if targetsRoleName == policy.TargetsRoleName {
+		state.TargetsEnvelope = env
+	} else {
+		state.DelegationEnvelopes[targetsRoleName] = env
+	}
+
+	commitMessage := fmt.Sprintf("Set force push authorizers of rule '%s' in policy '%s'", ruleName, targetsRoleName)
+
+	return r.commitPolicyUpdate(ctx, state, commitMessage, signCommit)
+}
+
+// SetDeletionAuthorizers is the interface for the user to set the keys that
+// may delete the Git references protected by a rule. Passing no keys disallows
+// deleting the protected references.
+func (r *Repository) SetDeletionAuthorizers(ctx context.Context, signer sslibdsse.SignerVerifier, targetsRoleName, ruleName string, authorizedKeys []*tuf.Key, signCommit bool) error {
+	keyID, err := signer.KeyID()
+	if err != nil {
+		return err
+	}
+
+	state, err := r.loadStateForUpdate(ctx)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Loading current rule file...")
+	if !state.HasTargetsRole(targetsRoleName) {
+		return policy.ErrMetadataNotFound
+	}
+
+	targetsMetadata, err := state.GetTargetsMetadata(targetsRoleName)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Setting keys authorized to delete references for rule...")
+	targetsMetadata, err = policy.SetDeletionAuthorizers(targetsMetadata, ruleName, authorizedKeys)
+	if err != nil {
+		return err
+	}
+
+	targetsMetadata.SetVersion(targetsMetadata.Version + 1)
+
+	env, err := dsse.CreateEnvelope(targetsMetadata)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug(fmt.Sprintf("Signing updated rule file using '%s'...", keyID))
+	env, err = dsse.SignEnvelope(ctx, env, signer)
+	if err != nil {
+		return err
+	}
+
+	if targetsRoleName == policy.TargetsRoleName {
+		state.TargetsEnvelope = env
+	} else {
+		state.DelegationEnvelopes[targetsRoleName] = env
+	}
+
+	commitMessage := fmt.Sprintf("Set deletion authorizers of rule '%s' in policy '%s'", ruleName, targetsRoleName)
+
+	return r.commitPolicyUpdate(ctx, state, commitMessage, signCommit)
+}
+
+// SetSubmoduleConstraints is the interface for the user to set the URLs that
+// submodules at the paths protected by a rule may point to, and whether the
+// commits they pin must verify against the submodules' own RSLs.
+func (r *Repository) SetSubmoduleConstraints(ctx context.Context, signer sslibdsse.SignerVerifier, targetsRoleName, ruleName string, allowedURLs []string, requireVerification bool, signCommit bool) error {
+	keyID, err := signer.KeyID()
+	if err != nil {
+		return err
+	}
+
+	state, err := r.loadStateForUpdate(ctx)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Loading current rule file...")
+	if !state.HasTargetsRole(targetsRoleName) {
+		return policy.ErrMetadataNotFound
+	}
+
+	targetsMetadata, err := state.GetTargetsMetadata(targetsRoleName)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Setting submodule constraints for rule...")
+	targetsMetadata, err = policy.SetSubmoduleConstraints(targetsMetadata, ruleName, allowedURLs, requireVerification)
+	if err != nil {
+		return err
+	}
+
+	targetsMetadata.SetVersion(targetsMetadata.Version + 1)
+
+	env, err := dsse.CreateEnvelope(targetsMetadata)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug(fmt.Sprintf("Signing updated rule file using '%s'...", keyID))
+	env, err = dsse.SignEnvelope(ctx, env, signer)
+	if err != nil {
+		return err
+	}
+
+	if targetsRoleName == policy.TargetsRoleName {
+		state.TargetsEnvelope = env
+	} else {
+		state.DelegationEnvelopes[targetsRoleName] = env
+	}
+
+	commitMessage := fmt.Sprintf("Set submodule constraints of rule '%s' in policy '%s'", ruleName, targetsRoleName)
+
+	return r.commitPolicyUpdate(ctx, state, commitMessage, signCommit)
+}
+
+// SetRegoPolicy is the interface for the user to set the Rego policy that
+// changes to the Git references protected by a rule must meet. An empty module
+// removes the rule's Rego policy.
+func (r *Repository) SetRegoPolicy(ctx context.Context, signer sslibdsse.SignerVerifier, targetsRoleName, ruleName, module string, signCommit bool) error {
+	keyID, err := signer.KeyID()
+	if err != nil {
+		return err
+	}
+
+	state, err := r.loadStateForUpdate(ctx)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Loading current rule file...")
+	if !state.HasTargetsRole(targetsRoleName) {
+		return policy.ErrMetadataNotFound
+	}
+
+	targetsMetadata, err := state.GetTargetsMetadata(targetsRoleName)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Setting Rego policy for rule...")
+	targetsMetadata, err = policy.SetRegoPolicy(targetsMetadata, ruleName, module)
+	if err != nil {
+		return err
+	}
+
+	targetsMetadata.SetVersion(targetsMetadata.Version + 1)
+
+	env, err := dsse.CreateEnvelope(targetsMetadata)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug(fmt.Sprintf("Signing updated rule file using '%s'...", keyID))
+	env, err = dsse.SignEnvelope(ctx, env, signer)
+	if err != nil {
+		return err
+	}
+
+	if targetsRoleName == policy.TargetsRoleName {
+		state.TargetsEnvelope = env
+	} else {
+		state.DelegationEnvelopes[targetsRoleName] = env
+	}
+
+	commitMessage := fmt.Sprintf("Set Rego policy of rule '%s' in policy '%s'", ruleName, targetsRoleName)
+
+	return r.commitPolicyUpdate(ctx, state, commitMessage, signCommit)
+}
+
+// SetRuleConditions is the interface for the user to set the CEL expressions
+// that changes to the Git references protected by a rule must meet. Passing no
+// conditions removes the rule's conditions.
+func (r *Repository) SetRuleConditions(ctx context.Context, signer sslibdsse.SignerVerifier, targetsRoleName, ruleName string, conditions []string, signCommit bool) error {
+	keyID, err := signer.KeyID()
+	if err != nil {
+		return err
+	}
+
+	state, err := r.loadStateForUpdate(ctx)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Loading current rule file...")
+	if !state.HasTargetsRole(targetsRoleName) {
+		return policy.ErrMetadataNotFound
+	}
+
+	targetsMetadata, err := state.GetTargetsMetadata(targetsRoleName)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Setting conditions for rule...")
+	targetsMetadata, err = policy.SetRuleConditions(targetsMetadata, ruleName, conditions)
+	if err != nil {
+		return err
+	}
+
+	targetsMetadata.SetVersion(targetsMetadata.Version + 1)
+
+	env, err := dsse.CreateEnvelope(targetsMetadata)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug(fmt.Sprintf("Signing updated rule file using '%s'...", keyID))
+	env, err = dsse.SignEnvelope(ctx, env, signer)
+	if err != nil {
+		return err
+	}
+
+	if targetsRoleName == policy.TargetsRoleName {
+		state.TargetsEnvelope = env
+	} else {
+		state.DelegationEnvelopes[targetsRoleName] = env
+	}
+
+	commitMessage := fmt.Sprintf("Set conditions of rule '%s' in policy '%s'", ruleName, targetsRoleName)
+
+	return r.commitPolicyUpdate(ctx, state, commitMessage, signCommit)
+}
+
+// SetCommitMessageRules is the interface for the user to set the constraints
+// on the messages of commits added to the Git references protected by a rule.
+func (r *Repository) SetCommitMessageRules(ctx context.Context, signer sslibdsse.SignerVerifier, targetsRoleName, ruleName string, requireSignoff, requireConventionalCommits bool, pattern string, signCommit bool) error {
+	keyID, err := signer.KeyID()
+	if err != nil {
+		return err
+	}
+
+	state, err := r.loadStateForUpdate(ctx)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Loading current rule file...")
+	if !state.HasTargetsRole(targetsRoleName) {
+		return policy.ErrMetadataNotFound
+	}
+
+	targetsMetadata, err := state.GetTargetsMetadata(targetsRoleName)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Setting commit message rules for rule...")
+	targetsMetadata, err = policy.SetCommitMessageRules(targetsMetadata, ruleName, requireSignoff, requireConventionalCommits, pattern)
+	if err != nil {
+		return err
+	}
+
+	targetsMetadata.SetVersion(targetsMetadata.Version + 1)
+
+	env, err := dsse.CreateEnvelope(targetsMetadata)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug(fmt.Sprintf("Signing updated rule file using '%s'...", keyID))
+	env, err = dsse.SignEnvelope(ctx, env, signer)
+	if err != nil {
+		return err
+	}
+
+	if targetsRoleName == policy.TargetsRoleName {
+		state.TargetsEnvelope = env
+	} else {
+		state.DelegationEnvelopes[targetsRoleName] = env
+	}
+
+	commitMessage := fmt.Sprintf("Set commit message rules of rule '%s' in policy '%s'", ruleName, targetsRoleName)
+
+	return r.commitPolicyUpdate(ctx, state, commitMessage, signCommit)
+}
+
+// SetFileRestrictions is the interface for the user to set the restrictions on
+// the contents of files added or modified at the paths protected by a rule.
+func (r *Repository) SetFileRestrictions(ctx context.Context, signer sslibdsse.SignerVerifier, targetsRoleName, ruleName string, maxFileSize int64, forbiddenExtensions []string, forbidBinaryFiles bool, signCommit bool) error {
+	keyID, err := signer.KeyID()
+	if err != nil {
+		return err
+	}
+
+	state, err := r.loadStateForUpdate(ctx)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Loading current rule file...")
+	if !state.HasTargetsRole(targetsRoleName) {
+		return policy.ErrMetadataNotFound
+	}
+
+	targetsMetadata, err := state.GetTargetsMetadata(targetsRoleName)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Setting file restrictions for rule...")
+	targetsMetadata, err = policy.SetFileRestrictions(targetsMetadata, ruleName, maxFileSize, forbiddenExtensions, forbidBinaryFiles)
+	if err != nil {
+		return err
+	}
+
+	targetsMetadata.SetVersion(targetsMetadata.Version + 1)
+
+	env, err := dsse.CreateEnvelope(targetsMetadata)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug(fmt.Sprintf("Signing updated rule file using '%s'...", keyID))
+	env, err = dsse.SignEnvelope(ctx, env, signer)
+	if err != nil {
+		return err
+	}
+
+	if targetsRoleName == policy.TargetsRoleName {
+		state.TargetsEnvelope = env
+	} else {
+		state.DelegationEnvelopes[targetsRoleName] = env
+	}
+
+	commitMessage := fmt.Sprintf("Set file restrictions of rule '%s' in policy '%s'", ruleName, targetsRoleName)
+
+	return r.commitPolicyUpdate(ctx, state, commitMessage, signCommit)
 }
 
 // RemoveDelegation is the interface for a user to remove a rule from gittuf
@@ -212,8 +735,7 @@ func (r *Repository) RemoveDelegation(ctx context.Context, signer sslibdsse.Sign
 		return nil
 	}
 
-	slog.Debug("Loading current policy...")
-	state, err := policy.LoadCurrentState(ctx, r.r)
+	state, err := r.loadStateForUpdate(ctx)
 	if err != nil {
 		return err
 	}
@@ -260,8 +782,7 @@ func (r *Repository) RemoveDelegation(ctx context.Context, signer sslibdsse.Sign
 
 	commitMessage := fmt.Sprintf("Remove rule '%s' from policy '%s'", ruleName, targetsRoleName)
 
-	slog.Debug("Committing policy...")
-	return state.Commit(ctx, r.r, commitMessage, signCommit)
+	return r.commitPolicyUpdate(ctx, state, commitMessage, signCommit)
 }
 
 // AddKeyToTargets is the interface for a user to add a trusted key to the
@@ -272,8 +793,7 @@ func (r *Repository) AddKeyToTargets(ctx context.Context, signer sslibdsse.Signe
 		return nil
 	}
 
-	slog.Debug("Loading current policy...")
-	state, err := policy.LoadCurrentState(ctx, r.r)
+	state, err := r.loadStateForUpdate(ctx)
 	if err != nil {
 		return err
 	}
@@ -324,15 +844,13 @@ func (r *Repository) AddKeyToTargets(ctx context.Context, signer sslibdsse.Signe
 
 	commitMessage := fmt.Sprintf("Add keys to policy '%s'\n%s", targetsRoleName, keyIDs)
 
-	slog.Debug("Committing policy...")
-	return state.Commit(ctx, r.r, commitMessage, signCommit)
+	return r.commitPolicyUpdate(ctx, state, commitMessage, signCommit)
 }
 
 // SignTargets adds a signature to specified Targets role's envelope. Note that
 // the metadata itself is not modified, so its version remains the same.
 func (r *Repository) SignTargets(ctx context.Context, signer sslibdsse.SignerVerifier, targetsRoleName string, signCommit bool) error {
-	slog.Debug("Loading current policy...")
-	state, err := policy.LoadCurrentState(ctx, r.r)
+	state, err := r.loadStateForUpdate(ctx)
 	if err != nil {
 		return err
 	}
@@ -366,6 +884,5 @@ func (r *Repository) SignTargets(ctx context.Context, signer sslibdsse.SignerVer
 
 	commitMessage := fmt.Sprintf("Add signature from key '%s' to policy '%s'", keyID, targetsRoleName)
 
-	slog.Debug("Committing policy...")
-	return state.Commit(ctx, r.r, commitMessage, signCommit)
+	return r.commitPolicyUpdate(ctx, state, commitMessage, signCommit)
 }
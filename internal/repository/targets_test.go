@@ -66,6 +66,80 @@ func TestInitializeTargets(t *testing.T) {
 	})
 }
 
+func TestInitializeTargetsFromTemplate(t *testing.T) {
+	targetsKey, err := tuf.LoadKeyFromBytes(targetsPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gpgKey, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rootSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(rootKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targetsSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(targetsKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("successful initialization", func(t *testing.T) {
+		r, _ := createTestRepositoryWithRoot(t, "")
+
+		if err := r.AddTopLevelTargetsKey(testCtx, rootSigner, targetsKey, false); err != nil {
+			t.Fatal(err)
+		}
+
+		err := r.InitializeTargetsFromTemplate(testCtx, targetsSigner, policy.TargetsRoleName, "two-person-release-review", []*tuf.Key{targetsKey, gpgKey}, false)
+		assert.Nil(t, err)
+
+		state, err := policy.LoadCurrentState(context.Background(), r.r)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		targetsMetadata, err := state.GetTargetsMetadata(policy.TargetsRoleName)
+		assert.Nil(t, err)
+		assert.Equal(t, 1, targetsMetadata.Version)
+		assert.Equal(t, 3, len(targetsMetadata.Delegations.Roles))
+		assert.Equal(t, "protect-main", targetsMetadata.Delegations.Roles[0].Name)
+		assert.Equal(t, "protect-releases", targetsMetadata.Delegations.Roles[1].Name)
+		assert.Equal(t, 2, targetsMetadata.Delegations.Roles[1].Threshold)
+		// Key IDs are sorted when metadata is encoded
+		assert.Equal(t, []string{gpgKey.KeyID, targetsKey.KeyID}, targetsMetadata.Delegations.Roles[1].KeyIDs)
+		assert.Equal(t, policy.AllowRule(), targetsMetadata.Delegations.Roles[2])
+	})
+
+	t.Run("unknown template", func(t *testing.T) {
+		r, _ := createTestRepositoryWithRoot(t, "")
+
+		if err := r.AddTopLevelTargetsKey(testCtx, rootSigner, targetsKey, false); err != nil {
+			t.Fatal(err)
+		}
+
+		err := r.InitializeTargetsFromTemplate(testCtx, targetsSigner, policy.TargetsRoleName, "does-not-exist", []*tuf.Key{targetsKey}, false)
+		assert.ErrorIs(t, err, policy.ErrUnknownTemplate)
+	})
+
+	t.Run("existing policy file", func(t *testing.T) {
+		r := createTestRepositoryWithPolicy(t, "")
+
+		err := r.InitializeTargetsFromTemplate(testCtx, targetsSigner, policy.TargetsRoleName, "protect-main", []*tuf.Key{targetsKey}, false)
+		assert.ErrorIs(t, err, ErrCannotReinitialize)
+	})
+
+	t.Run("rule exists in another policy file", func(t *testing.T) {
+		r := createTestRepositoryWithPolicy(t, "")
+
+		err := r.InitializeTargetsFromTemplate(testCtx, targetsSigner, "protect-main", "protect-main", []*tuf.Key{targetsKey}, false)
+		assert.ErrorIs(t, err, policy.ErrDuplicatedRuleName)
+	})
+}
+
 func TestAddDelegation(t *testing.T) {
 	targetsSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(targetsKeyBytes) //nolint:staticcheck
 	if err != nil {
@@ -170,6 +244,334 @@ func TestUpdateDelegation(t *testing.T) {
 	})
 }
 
+func TestSetMergeStrategy(t *testing.T) {
+	r := createTestRepositoryWithPolicy(t, "")
+
+	targetsSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(targetsKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = r.SetMergeStrategy(testCtx, targetsSigner, policy.TargetsRoleName, "protect-main", policy.MergeStrategyFirstParent, false)
+	assert.Nil(t, err)
+
+	state, err := policy.LoadCurrentState(context.Background(), r.r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targetsMetadata, err := state.GetTargetsMetadata(policy.TargetsRoleName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, policy.MergeStrategyFirstParent, targetsMetadata.Delegations.Roles[0].MergeStrategy)
+
+	err = r.SetMergeStrategy(testCtx, targetsSigner, policy.TargetsRoleName, "protect-main", "squash", false)
+	assert.ErrorIs(t, err, policy.ErrUnknownMergeStrategy)
+}
+
+func TestSetRuleEffect(t *testing.T) {
+	r := createTestRepositoryWithPolicy(t, "")
+
+	targetsSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(targetsKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = r.SetRuleEffect(testCtx, targetsSigner, policy.TargetsRoleName, "protect-main", policy.RuleEffectDeny, false)
+	assert.Nil(t, err)
+
+	state, err := policy.LoadCurrentState(context.Background(), r.r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targetsMetadata, err := state.GetTargetsMetadata(policy.TargetsRoleName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, policy.RuleEffectDeny, targetsMetadata.Delegations.Roles[0].Effect)
+
+	err = r.SetRuleEffect(testCtx, targetsSigner, policy.TargetsRoleName, "protect-main", "block", false)
+	assert.ErrorIs(t, err, policy.ErrUnknownRuleEffect)
+}
+
+func TestSetRulePriority(t *testing.T) {
+	r := createTestRepositoryWithPolicy(t, "")
+
+	targetsSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(targetsKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = r.SetRulePriority(testCtx, targetsSigner, policy.TargetsRoleName, "protect-main", 10, false)
+	assert.Nil(t, err)
+
+	state, err := policy.LoadCurrentState(context.Background(), r.r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targetsMetadata, err := state.GetTargetsMetadata(policy.TargetsRoleName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, 10, targetsMetadata.Delegations.Roles[0].Priority)
+
+	err = r.SetRulePriority(testCtx, targetsSigner, policy.TargetsRoleName, "unknown-rule", 10, false)
+	assert.ErrorIs(t, err, policy.ErrDelegationNotFound)
+}
+
+func TestReorderRules(t *testing.T) {
+	r := createTestRepositoryWithPolicy(t, "")
+
+	targetsSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(targetsKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key, err := tuf.LoadKeyFromBytes(targetsPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := r.AddDelegation(testCtx, targetsSigner, policy.TargetsRoleName, "protect-feature", []*tuf.Key{key}, []string{"git:refs/heads/feature"}, 1, false); err != nil {
+		t.Fatal(err)
+	}
+
+	err = r.ReorderRules(testCtx, targetsSigner, policy.TargetsRoleName, []string{"protect-feature", "protect-main"}, false)
+	assert.Nil(t, err)
+
+	state, err := policy.LoadCurrentState(context.Background(), r.r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targetsMetadata, err := state.GetTargetsMetadata(policy.TargetsRoleName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, "protect-feature", targetsMetadata.Delegations.Roles[0].Name)
+	assert.Equal(t, "protect-main", targetsMetadata.Delegations.Roles[1].Name)
+	assert.Equal(t, policy.AllowRuleName, targetsMetadata.Delegations.Roles[2].Name)
+
+	err = r.ReorderRules(testCtx, targetsSigner, policy.TargetsRoleName, []string{"protect-main"}, false)
+	assert.ErrorIs(t, err, policy.ErrIncompleteRuleOrder)
+}
+
+func TestSetForcePushAuthorizers(t *testing.T) {
+	r := createTestRepositoryWithPolicy(t, "")
+
+	targetsSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(targetsKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	authorizedKey, err := tuf.LoadKeyFromBytes(targetsPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = r.SetForcePushAuthorizers(testCtx, targetsSigner, policy.TargetsRoleName, "protect-main", []*tuf.Key{authorizedKey}, false)
+	assert.Nil(t, err)
+
+	state, err := policy.LoadCurrentState(context.Background(), r.r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targetsMetadata, err := state.GetTargetsMetadata(policy.TargetsRoleName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{authorizedKey.KeyID}, targetsMetadata.Delegations.Roles[0].ForcePushKeyIDs)
+
+	err = r.SetForcePushAuthorizers(testCtx, targetsSigner, policy.TargetsRoleName, "unknown-rule", []*tuf.Key{authorizedKey}, false)
+	assert.ErrorIs(t, err, policy.ErrDelegationNotFound)
+}
+
+func TestSetDeletionAuthorizers(t *testing.T) {
+	r := createTestRepositoryWithPolicy(t, "")
+
+	targetsSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(targetsKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	authorizedKey, err := tuf.LoadKeyFromBytes(targetsPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = r.SetDeletionAuthorizers(testCtx, targetsSigner, policy.TargetsRoleName, "protect-main", []*tuf.Key{authorizedKey}, false)
+	assert.Nil(t, err)
+
+	state, err := policy.LoadCurrentState(context.Background(), r.r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targetsMetadata, err := state.GetTargetsMetadata(policy.TargetsRoleName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{authorizedKey.KeyID}, targetsMetadata.Delegations.Roles[0].DeletionKeyIDs)
+
+	err = r.SetDeletionAuthorizers(testCtx, targetsSigner, policy.TargetsRoleName, "unknown-rule", []*tuf.Key{authorizedKey}, false)
+	assert.ErrorIs(t, err, policy.ErrDelegationNotFound)
+}
+
+func TestSetSubmoduleConstraints(t *testing.T) {
+	r := createTestRepositoryWithPolicy(t, "")
+
+	targetsSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(targetsKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = r.SetSubmoduleConstraints(testCtx, targetsSigner, policy.TargetsRoleName, "protect-main", []string{"https://github.com/gittuf/*"}, true, false)
+	assert.Nil(t, err)
+
+	state, err := policy.LoadCurrentState(context.Background(), r.r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targetsMetadata, err := state.GetTargetsMetadata(policy.TargetsRoleName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, []string{"https://github.com/gittuf/*"}, targetsMetadata.Delegations.Roles[0].SubmoduleURLs)
+	assert.True(t, targetsMetadata.Delegations.Roles[0].RequireSubmoduleVerification)
+
+	err = r.SetSubmoduleConstraints(testCtx, targetsSigner, policy.TargetsRoleName, "unknown-rule", nil, true, false)
+	assert.ErrorIs(t, err, policy.ErrDelegationNotFound)
+}
+
+func TestSetRegoPolicy(t *testing.T) {
+	r := createTestRepositoryWithPolicy(t, "")
+
+	targetsSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(targetsKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	module := "package gittuf\n\nimport rego.v1\n\ndeny contains \"change must be approved\" if count(input.approvers) == 0\n"
+
+	err = r.SetRegoPolicy(testCtx, targetsSigner, policy.TargetsRoleName, "protect-main", module, false)
+	assert.Nil(t, err)
+
+	state, err := policy.LoadCurrentState(context.Background(), r.r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targetsMetadata, err := state.GetTargetsMetadata(policy.TargetsRoleName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, module, targetsMetadata.Delegations.Roles[0].RegoPolicy)
+
+	err = r.SetRegoPolicy(testCtx, targetsSigner, policy.TargetsRoleName, "protect-main", "package example\n", false)
+	assert.ErrorIs(t, err, policy.ErrInvalidRegoPolicy)
+
+	err = r.SetRegoPolicy(testCtx, targetsSigner, policy.TargetsRoleName, "unknown-rule", "", false)
+	assert.ErrorIs(t, err, policy.ErrDelegationNotFound)
+}
+
+func TestSetRuleConditions(t *testing.T) {
+	r := createTestRepositoryWithPolicy(t, "")
+
+	targetsSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(targetsKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	conditions := []string{"size(approvers) > 0"}
+
+	err = r.SetRuleConditions(testCtx, targetsSigner, policy.TargetsRoleName, "protect-main", conditions, false)
+	assert.Nil(t, err)
+
+	state, err := policy.LoadCurrentState(context.Background(), r.r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targetsMetadata, err := state.GetTargetsMetadata(policy.TargetsRoleName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, conditions, targetsMetadata.Delegations.Roles[0].Conditions)
+
+	err = r.SetRuleConditions(testCtx, targetsSigner, policy.TargetsRoleName, "protect-main", []string{"ref =="}, false)
+	assert.ErrorIs(t, err, policy.ErrInvalidRuleCondition)
+
+	err = r.SetRuleConditions(testCtx, targetsSigner, policy.TargetsRoleName, "unknown-rule", nil, false)
+	assert.ErrorIs(t, err, policy.ErrDelegationNotFound)
+}
+
+func TestSetCommitMessageRules(t *testing.T) {
+	r := createTestRepositoryWithPolicy(t, "")
+
+	targetsSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(targetsKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = r.SetCommitMessageRules(testCtx, targetsSigner, policy.TargetsRoleName, "protect-main", true, false, `#[0-9]+`, false)
+	assert.Nil(t, err)
+
+	state, err := policy.LoadCurrentState(context.Background(), r.r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targetsMetadata, err := state.GetTargetsMetadata(policy.TargetsRoleName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.True(t, targetsMetadata.Delegations.Roles[0].RequireSignoff)
+	assert.False(t, targetsMetadata.Delegations.Roles[0].RequireConventionalCommits)
+	assert.Equal(t, `#[0-9]+`, targetsMetadata.Delegations.Roles[0].CommitMessagePattern)
+
+	err = r.SetCommitMessageRules(testCtx, targetsSigner, policy.TargetsRoleName, "protect-main", false, false, "[", false)
+	assert.ErrorIs(t, err, policy.ErrInvalidCommitMessagePattern)
+
+	err = r.SetCommitMessageRules(testCtx, targetsSigner, policy.TargetsRoleName, "unknown-rule", true, false, "", false)
+	assert.ErrorIs(t, err, policy.ErrDelegationNotFound)
+}
+
+func TestSetFileRestrictions(t *testing.T) {
+	r := createTestRepositoryWithPolicy(t, "")
+
+	targetsSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(targetsKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = r.SetFileRestrictions(testCtx, targetsSigner, policy.TargetsRoleName, "protect-main", 1024, []string{".exe"}, true, false)
+	assert.Nil(t, err)
+
+	state, err := policy.LoadCurrentState(context.Background(), r.r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	targetsMetadata, err := state.GetTargetsMetadata(policy.TargetsRoleName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, int64(1024), targetsMetadata.Delegations.Roles[0].MaxFileSize)
+	assert.Equal(t, []string{".exe"}, targetsMetadata.Delegations.Roles[0].ForbiddenExtensions)
+	assert.True(t, targetsMetadata.Delegations.Roles[0].ForbidBinaryFiles)
+
+	err = r.SetFileRestrictions(testCtx, targetsSigner, policy.TargetsRoleName, "protect-main", -1, nil, false, false)
+	assert.ErrorIs(t, err, policy.ErrInvalidFileRestriction)
+
+	err = r.SetFileRestrictions(testCtx, targetsSigner, policy.TargetsRoleName, "unknown-rule", 0, nil, false, false)
+	assert.ErrorIs(t, err, policy.ErrDelegationNotFound)
+}
+
 func TestRemoveDelegation(t *testing.T) {
 	r := createTestRepositoryWithPolicy(t, "")
 
@@ -0,0 +1,115 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/gittuf/gittuf/internal/github"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/tuf"
+	sslibdsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
+)
+
+// AddTeamMembers is the interface for the user to add keys and people to a
+// team in a rule file, creating the team if it does not exist. Rules that
+// authorize the team trust the new members without being changed.
+func (r *Repository) AddTeamMembers(ctx context.Context, signer sslibdsse.SignerVerifier, targetsRoleName, teamID string, keys []*tuf.Key, personIDs []string, signCommit bool) error {
+	commitMessage := fmt.Sprintf("Add members to team '%s' in policy '%s'", teamID, targetsRoleName)
+
+	return r.updateTargetsMetadata(ctx, signer, targetsRoleName, commitMessage, signCommit, func(targetsMetadata *tuf.TargetsMetadata) (*tuf.TargetsMetadata, error) {
+		slog.Debug(fmt.Sprintf("Adding members to team '%s'...", teamID))
+		return policy.AddTeamMembers(targetsMetadata, teamID, keys, personIDs)
+	})
+}
+
+// RemoveTeamMembers is the interface for the user to remove keys and people
+// from a team in a rule file.
+func (r *Repository) RemoveTeamMembers(ctx context.Context, signer sslibdsse.SignerVerifier, targetsRoleName, teamID string, keyIDs, personIDs []string, signCommit bool) error {
+	commitMessage := fmt.Sprintf("Remove members from team '%s' in policy '%s'", teamID, targetsRoleName)
+
+	return r.updateTargetsMetadata(ctx, signer, targetsRoleName, commitMessage, signCommit, func(targetsMetadata *tuf.TargetsMetadata) (*tuf.TargetsMetadata, error) {
+		slog.Debug(fmt.Sprintf("Removing members from team '%s'...", teamID))
+		return policy.RemoveTeamMembers(targetsMetadata, teamID, keyIDs, personIDs)
+	})
+}
+
+// RemoveTeam is the interface for the user to remove a team from a rule file.
+// A team cannot be removed while a rule authorizes it.
+func (r *Repository) RemoveTeam(ctx context.Context, signer sslibdsse.SignerVerifier, targetsRoleName, teamID string, signCommit bool) error {
+	commitMessage := fmt.Sprintf("Remove team '%s' from policy '%s'", teamID, targetsRoleName)
+
+	return r.updateTargetsMetadata(ctx, signer, targetsRoleName, commitMessage, signCommit, func(targetsMetadata *tuf.TargetsMetadata) (*tuf.TargetsMetadata, error) {
+		slog.Debug(fmt.Sprintf("Removing team '%s' from rule file...", teamID))
+		return policy.RemoveTeam(targetsMetadata, teamID)
+	})
+}
+
+// SetAuthorizedTeams is the interface for the user to set the teams
+// authorized by a rule. Passing no teams removes all teams from the rule.
+func (r *Repository) SetAuthorizedTeams(ctx context.Context, signer sslibdsse.SignerVerifier, targetsRoleName, ruleName string, teamIDs []string, signCommit bool) error {
+	commitMessage := fmt.Sprintf("Set teams authorized by rule '%s' in policy '%s'", ruleName, targetsRoleName)
+
+	return r.updateTargetsMetadata(ctx, signer, targetsRoleName, commitMessage, signCommit, func(targetsMetadata *tuf.TargetsMetadata) (*tuf.TargetsMetadata, error) {
+		slog.Debug("Setting teams authorized by rule...")
+		return policy.SetAuthorizedTeams(targetsMetadata, ruleName, teamIDs)
+	})
+}
+
+// SyncGitHubTeam is the interface for the user to sync the people in a team in
+// a rule file with the members of a GitHub organization's team. Each member is
+// recorded as a person identified by their GitHub login, with the SSH signing
+// and GPG keys they have registered with GitHub. The returned diff summarizes
+// the changes to the team. If dryRun is set, or if the team already matches
+// the GitHub team, the policy is not changed.
+func (r *Repository) SyncGitHubTeam(ctx context.Context, signer sslibdsse.SignerVerifier, targetsRoleName string, client *github.Client, org, teamSlug, teamID string, dryRun, signCommit bool) (*policy.TeamDiff, error) {
+	slog.Debug(fmt.Sprintf("Fetching members of GitHub team '%s/%s'...", org, teamSlug))
+	logins, err := client.GetTeamMembers(ctx, org, teamSlug)
+	if err != nil {
+		return nil, err
+	}
+
+	members := map[string][]*tuf.Key{}
+	for _, login := range logins {
+		slog.Debug(fmt.Sprintf("Fetching signing keys of '%s'...", login))
+		keys, err := client.GetSigningKeys(ctx, login)
+		if err != nil {
+			return nil, err
+		}
+		members[login] = keys
+	}
+
+	state, err := r.loadStateForUpdate(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !state.HasTargetsRole(targetsRoleName) {
+		return nil, policy.ErrMetadataNotFound
+	}
+	targetsMetadata, err := state.GetTargetsMetadata(targetsRoleName)
+	if err != nil {
+		return nil, err
+	}
+
+	_, diff, err := policy.SyncTeamPeople(targetsMetadata, teamID, members)
+	if err != nil {
+		return nil, err
+	}
+	if dryRun || diff.IsEmpty() {
+		return diff, nil
+	}
+
+	commitMessage := fmt.Sprintf("Sync team '%s' in policy '%s' with GitHub team '%s/%s'", teamID, targetsRoleName, org, teamSlug)
+	err = r.updateTargetsMetadata(ctx, signer, targetsRoleName, commitMessage, signCommit, func(targetsMetadata *tuf.TargetsMetadata) (*tuf.TargetsMetadata, error) {
+		slog.Debug(fmt.Sprintf("Syncing team '%s'...", teamID))
+		targetsMetadata, _, err := policy.SyncTeamPeople(targetsMetadata, teamID, members)
+		return targetsMetadata, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return diff, nil
+}
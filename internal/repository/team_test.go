@@ -0,0 +1,167 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/common"
+	"github.com/gittuf/gittuf/internal/github"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/internal/signerverifier"
+	"github.com/gittuf/gittuf/internal/signerverifier/gpg"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTeam(t *testing.T) {
+	r := createTestRepositoryWithPolicy(t, "")
+
+	targetsSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(targetsKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gpgKey, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsKey, err := tuf.LoadKeyFromBytes(targetsPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := r.AddPerson(testCtx, targetsSigner, policy.TargetsRoleName, "alice", []*tuf.Key{gpgKey}, false); err != nil {
+		t.Fatal(err)
+	}
+
+	err = r.AddTeamMembers(testCtx, targetsSigner, policy.TargetsRoleName, "maintainers", []*tuf.Key{targetsKey}, []string{"alice"}, false)
+	assert.Nil(t, err)
+
+	err = r.AddDelegation(testCtx, targetsSigner, policy.TargetsRoleName, "protect-release", nil, []string{"git:refs/heads/release"}, 1, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = r.SetAuthorizedTeams(testCtx, targetsSigner, policy.TargetsRoleName, "protect-release", []string{"maintainers"}, false)
+	assert.Nil(t, err)
+
+	state, err := policy.LoadCurrentState(testCtx, r.r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsMetadata, err := state.GetTargetsMetadata(policy.TargetsRoleName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, &tuf.Team{TeamID: "maintainers", KeyIDs: []string{targetsKey.KeyID}, PersonIDs: []string{"alice"}}, targetsMetadata.Delegations.Teams["maintainers"])
+	assert.Equal(t, []string{"maintainers"}, targetsMetadata.Delegations.Roles[1].TeamIDs)
+
+	// Commits signed using a key of a team member are accepted
+	refName := "refs/heads/release"
+	commitIDs := common.AddNTestCommitsToSpecifiedRef(t, r.r, refName, 1, gpgKeyBytes)
+	common.CreateTestRSLReferenceEntryCommit(t, r.r, rsl.NewReferenceEntry(refName, commitIDs[0]), gpgKeyBytes)
+
+	err = r.VerifyRef(testCtx, refName, true)
+	assert.Nil(t, err)
+
+	// Once alice leaves the team, the rule no longer trusts her key
+	err = r.RemoveTeamMembers(testCtx, targetsSigner, policy.TargetsRoleName, "maintainers", nil, []string{"alice"}, false)
+	assert.Nil(t, err)
+
+	err = r.VerifyRef(testCtx, refName, true)
+	assert.ErrorIs(t, err, policy.ErrUnauthorizedSignature)
+
+	err = r.RemoveTeam(testCtx, targetsSigner, policy.TargetsRoleName, "maintainers", false)
+	assert.ErrorIs(t, err, policy.ErrTeamInUse)
+
+	err = r.SetAuthorizedTeams(testCtx, targetsSigner, policy.TargetsRoleName, "protect-release", nil, false)
+	assert.Nil(t, err)
+
+	err = r.RemoveTeam(testCtx, targetsSigner, policy.TargetsRoleName, "maintainers", false)
+	assert.Nil(t, err)
+}
+
+func TestSyncGitHubTeam(t *testing.T) {
+	r := createTestRepositoryWithPolicy(t, "")
+
+	targetsSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(targetsKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gpgKey, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rawKey, err := json.Marshal(string(gpgPubKeyBytes))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/orgs/gittuf/teams/maintainers/members", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `[{"login": "alice"}, {"login": "bob"}]`)
+	})
+	mux.HandleFunc("/users/alice/ssh_signing_keys", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `[]`)
+	})
+	mux.HandleFunc("/users/alice/gpg_keys", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprintf(w, `[{"key_id": "abc", "raw_key": %s}]`, rawKey)
+	})
+	mux.HandleFunc("/users/bob/ssh_signing_keys", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `[]`)
+	})
+	mux.HandleFunc("/users/bob/gpg_keys", func(w http.ResponseWriter, _ *http.Request) {
+		fmt.Fprint(w, `[]`)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	client := github.NewClient(server.URL, "")
+
+	t.Run("dry run", func(t *testing.T) {
+		diff, err := r.SyncGitHubTeam(testCtx, targetsSigner, policy.TargetsRoleName, client, "gittuf", "maintainers", "maintainers", true, false)
+		assert.Nil(t, err)
+		assert.Equal(t, policy.ChangeAdded, diff.Change)
+		assert.Equal(t, []string{"alice"}, diff.AddedPersonIDs)
+		assert.Equal(t, []string{"bob"}, diff.SkippedPersonIDs)
+
+		state, err := policy.LoadCurrentState(testCtx, r.r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsMetadata, err := state.GetTargetsMetadata(policy.TargetsRoleName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.NotContains(t, targetsMetadata.Delegations.Teams, "maintainers")
+	})
+
+	t.Run("sync", func(t *testing.T) {
+		diff, err := r.SyncGitHubTeam(testCtx, targetsSigner, policy.TargetsRoleName, client, "gittuf", "maintainers", "maintainers", false, false)
+		assert.Nil(t, err)
+		assert.False(t, diff.IsEmpty())
+
+		state, err := policy.LoadCurrentState(testCtx, r.r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		targetsMetadata, err := state.GetTargetsMetadata(policy.TargetsRoleName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, &tuf.Team{TeamID: "maintainers", PersonIDs: []string{"alice"}}, targetsMetadata.Delegations.Teams["maintainers"])
+		assert.Equal(t, &tuf.Person{PersonID: "alice", KeyIDs: []string{gpgKey.KeyID}}, targetsMetadata.Delegations.People["alice"])
+
+		// Syncing again makes no changes
+		diff, err = r.SyncGitHubTeam(testCtx, targetsSigner, policy.TargetsRoleName, client, "gittuf", "maintainers", "maintainers", false, false)
+		assert.Nil(t, err)
+		assert.True(t, diff.IsEmpty())
+	})
+}
@@ -0,0 +1,201 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/internal/signerverifier/dsse"
+	"github.com/gittuf/gittuf/internal/tuf"
+	sslibdsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
+)
+
+var (
+	ErrPushingTimestamp = errors.New("unable to push timestamp")
+	ErrPullingTimestamp = errors.New("unable to pull timestamp")
+)
+
+// AddTimestampKey is the interface for the user to add an authorized key for
+// the timestamp role. Adding the first key enables the timestamp role.
+func (r *Repository) AddTimestampKey(ctx context.Context, signer sslibdsse.SignerVerifier, timestampKey *tuf.Key, signCommit bool) error {
+	rootKeyID, err := signer.KeyID()
+	if err != nil {
+		return err
+	}
+
+	state, err := r.loadStateForUpdate(ctx)
+	if err != nil {
+		return err
+	}
+
+	rootMetadata, err := r.loadRootMetadata(state, rootKeyID)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Adding timestamp key...")
+	rootMetadata, err = policy.AddTimestampKey(rootMetadata, timestampKey)
+	if err != nil {
+		return fmt.Errorf("failed to add timestamp key: %w", err)
+	}
+
+	commitMessage := fmt.Sprintf("Add timestamp key '%s' to root", timestampKey.KeyID)
+	return r.updateRootMetadata(ctx, state, signer, rootMetadata, commitMessage, signCommit)
+}
+
+// RemoveTimestampKey is the interface for the user to de-authorize a key
+// trusted to sign the timestamp role. Removing the last key disables the
+// timestamp role.
+func (r *Repository) RemoveTimestampKey(ctx context.Context, signer sslibdsse.SignerVerifier, timestampKeyID string, signCommit bool) error {
+	rootKeyID, err := signer.KeyID()
+	if err != nil {
+		return err
+	}
+
+	state, err := r.loadStateForUpdate(ctx)
+	if err != nil {
+		return err
+	}
+
+	rootMetadata, err := r.loadRootMetadata(state, rootKeyID)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Removing timestamp key...")
+	rootMetadata, err = policy.DeleteTimestampKey(rootMetadata, timestampKeyID)
+	if err != nil {
+		return err
+	}
+
+	commitMessage := fmt.Sprintf("Remove timestamp key '%s' from root", timestampKeyID)
+	return r.updateRootMetadata(ctx, state, signer, rootMetadata, commitMessage, signCommit)
+}
+
+// UpdateTimestamp signs the current tip of the local RSL as timestamp metadata
+// that is valid for the specified duration. The timestamp must be refreshed
+// before it expires, typically by an automated service.
+func (r *Repository) UpdateTimestamp(ctx context.Context, signer sslibdsse.SignerVerifier, validity time.Duration, signCommit bool) error {
+	if validity <= 0 {
+		return ErrExpiryInPast
+	}
+
+	keyID, err := signer.KeyID()
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Loading current policy...")
+	state, err := policy.LoadCurrentState(ctx, r.r)
+	if err != nil {
+		return err
+	}
+
+	rootMetadata, err := state.GetRootMetadata()
+	if err != nil {
+		return err
+	}
+	timestampRole, has := rootMetadata.Roles[policy.TimestampRoleName]
+	if !has {
+		return policy.ErrNoTimestampRole
+	}
+	if !isKeyAuthorized(timestampRole.KeyIDs, keyID) {
+		return ErrUnauthorizedKey
+	}
+
+	version := 1
+	currentEnv, err := policy.LoadTimestamp(r.r)
+	if err == nil {
+		currentTimestamp, err := policy.GetTimestampMetadata(currentEnv)
+		if err != nil {
+			return err
+		}
+		version = currentTimestamp.Version + 1
+	} else if !errors.Is(err, policy.ErrTimestampNotFound) {
+		return err
+	}
+
+	rslTip, err := gitinterface.GetTip(r.r, rsl.Ref)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now().UTC()
+	timestampMetadata := tuf.NewTimestampMetadata()
+	timestampMetadata.SetVersion(version)
+	timestampMetadata.SetExpires(now.Add(validity).Format(time.RFC3339))
+	timestampMetadata.RSLTip = rslTip.String()
+	timestampMetadata.SignedAt = now.Format(time.RFC3339)
+
+	env, err := dsse.CreateEnvelope(timestampMetadata)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug(fmt.Sprintf("Signing RSL tip '%s' using '%s'...", rslTip.String(), keyID))
+	env, err = dsse.SignEnvelope(ctx, env, signer)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Committing timestamp...")
+	return policy.CommitTimestamp(r.r, env, signCommit)
+}
+
+// VerifyFreshness checks that the local RSL contains the RSL tip signed by the
+// timestamp role and that the timestamp has not expired. If remoteName is
+// specified, the RSL and timestamp are first fetched from the remote.
+func (r *Repository) VerifyFreshness(ctx context.Context, remoteName string) (*tuf.TimestampMetadata, error) {
+	if remoteName != "" {
+		if err := r.PullRSL(ctx, remoteName); err != nil {
+			return nil, err
+		}
+
+		if err := r.PullTimestamp(ctx, remoteName); err != nil {
+			return nil, err
+		}
+	}
+
+	slog.Debug("Loading current policy...")
+	state, err := policy.LoadCurrentState(ctx, r.r)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Debug("Loading timestamp...")
+	env, err := policy.LoadTimestamp(r.r)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Debug("Verifying timestamp...")
+	return state.VerifyFreshness(ctx, r.r, env, time.Now())
+}
+
+// PushTimestamp pushes the local timestamp to the specified remote.
+func (r *Repository) PushTimestamp(ctx context.Context, remoteName string) error {
+	slog.Debug(fmt.Sprintf("Pushing timestamp reference to '%s'...", remoteName))
+	if err := gitinterface.Push(ctx, r.r, remoteName, []string{policy.TimestampRef}); err != nil {
+		return errors.Join(ErrPushingTimestamp, err)
+	}
+
+	return nil
+}
+
+// PullTimestamp fetches the timestamp from the specified remote. The fetch is
+// marked as fast forward only so that an older timestamp is not accepted.
+func (r *Repository) PullTimestamp(ctx context.Context, remoteName string) error {
+	slog.Debug(fmt.Sprintf("Pulling timestamp reference from '%s'...", remoteName))
+	if err := gitinterface.Fetch(ctx, r.r, remoteName, []string{policy.TimestampRef}, true); err != nil {
+		return errors.Join(ErrPullingTimestamp, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,98 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/internal/signerverifier"
+	artifacts "github.com/gittuf/gittuf/internal/testartifacts"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyFreshness(t *testing.T) {
+	r := createTestRepositoryWithPolicy(t, "")
+
+	rootSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(rootKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+	targetsSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(targetsKeyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+	timestampSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(artifacts.SSLibKey3Private) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+	timestampKey, err := tuf.LoadKeyFromBytes(artifacts.SSLibKey3Public)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = r.VerifyFreshness(testCtx, "")
+	assert.ErrorIs(t, err, policy.ErrTimestampNotFound)
+
+	err = r.UpdateTimestamp(testCtx, timestampSigner, time.Hour, false)
+	assert.ErrorIs(t, err, policy.ErrNoTimestampRole)
+
+	if err := r.AddTimestampKey(testCtx, rootSigner, timestampKey, false); err != nil {
+		t.Fatal(err)
+	}
+
+	err = r.UpdateTimestamp(testCtx, targetsSigner, time.Hour, false)
+	assert.ErrorIs(t, err, ErrUnauthorizedKey)
+
+	err = r.UpdateTimestamp(testCtx, timestampSigner, 0, false)
+	assert.ErrorIs(t, err, ErrExpiryInPast)
+
+	err = r.UpdateTimestamp(testCtx, timestampSigner, time.Hour, false)
+	assert.Nil(t, err)
+
+	rslTip, err := gitinterface.GetTip(r.r, rsl.Ref)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	timestampMetadata, err := r.VerifyFreshness(testCtx, "")
+	assert.Nil(t, err)
+	assert.Equal(t, rslTip.String(), timestampMetadata.RSLTip)
+	assert.Equal(t, 1, timestampMetadata.Version)
+
+	t.Run("expired timestamp", func(t *testing.T) {
+		state, err := policy.LoadCurrentState(testCtx, r.r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		env, err := policy.LoadTimestamp(r.r)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = state.VerifyFreshness(testCtx, r.r, env, time.Now().Add(2*time.Hour))
+		assert.ErrorIs(t, err, policy.ErrTimestampExpired)
+	})
+
+	t.Run("withheld RSL entries", func(t *testing.T) {
+		if err := rsl.NewReferenceEntry("refs/heads/main", rslTip).Commit(r.r, false); err != nil {
+			t.Fatal(err)
+		}
+		if err := r.UpdateTimestamp(testCtx, timestampSigner, time.Hour, false); err != nil {
+			t.Fatal(err)
+		}
+
+		// Roll the local RSL back to before the timestamped entry
+		if err := r.r.Storer.SetReference(plumbing.NewHashReference(rsl.Ref, rslTip)); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err := r.VerifyFreshness(testCtx, "")
+		assert.ErrorIs(t, err, policy.ErrRSLNotFresh)
+	})
+}
@@ -0,0 +1,176 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/gittuf/gittuf/internal/attestations"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/tlog"
+	"github.com/gittuf/gittuf/internal/tuf"
+	sslibdsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
+	"github.com/sigstore/rekor/pkg/generated/models"
+)
+
+// UploadToTransparencyLog records the signatures on the current policy
+// metadata and attestations in the Rekor transparency log at rekorURL. The log
+// entries, including their inclusion proofs, are stored in the attestations
+// namespace. Signatures that were recorded previously, or that were made using
+// keys that are not in the policy or are not supported by the log, are
+// skipped.
+func (r *Repository) UploadToTransparencyLog(ctx context.Context, rekorURL string, signCommit bool) error {
+	state, allAttestations, envelopes, err := r.loadSignedEnvelopes(ctx)
+	if err != nil {
+		return err
+	}
+
+	publicKeys, err := state.PublicKeys()
+	if err != nil {
+		return err
+	}
+
+	uploaded := 0
+	for _, env := range envelopes {
+		for _, signature := range env.Signatures {
+			key, supported := getTransparencyLogKey(publicKeys, signature.KeyID)
+			if !supported {
+				continue
+			}
+
+			if _, err := allAttestations.GetTransparencyLogEntryFor(r.r, env, key.KeyID); err == nil {
+				continue
+			} else if !errors.Is(err, attestations.ErrTransparencyLogEntryNotFound) {
+				return err
+			}
+
+			slog.Debug(fmt.Sprintf("Uploading signature by '%s' to transparency log...", key.KeyID))
+			entry, err := tlog.Upload(ctx, rekorURL, env, key)
+			if err != nil {
+				return err
+			}
+
+			entryBytes, err := json.Marshal(entry)
+			if err != nil {
+				return err
+			}
+
+			if err := allAttestations.SetTransparencyLogEntry(r.r, env, key.KeyID, entryBytes); err != nil {
+				return err
+			}
+			uploaded++
+		}
+	}
+
+	if uploaded == 0 {
+		slog.Debug("No new signatures to upload to transparency log")
+		return nil
+	}
+
+	commitMessage := fmt.Sprintf("Add %d transparency log entries", uploaded)
+
+	return allAttestations.Commit(r.r, commitMessage, signCommit)
+}
+
+// verifyTransparencyLog checks that every signature on the current policy
+// metadata and attestations made using a key supported by the transparency log
+// has a valid log entry, if requested in the options.
+func (r *Repository) verifyTransparencyLog(ctx context.Context, opts ...VerifyRefOption) error {
+	options := &VerifyRefOptions{}
+	for _, fn := range opts {
+		fn(options)
+	}
+	if options.TransparencyLogKeys == nil {
+		return nil
+	}
+
+	slog.Debug("Verifying transparency log entries for policy and attestations...")
+	state, allAttestations, envelopes, err := r.loadSignedEnvelopes(ctx)
+	if err != nil {
+		return err
+	}
+
+	publicKeys, err := state.PublicKeys()
+	if err != nil {
+		return err
+	}
+
+	for _, env := range envelopes {
+		for _, signature := range env.Signatures {
+			key, supported := getTransparencyLogKey(publicKeys, signature.KeyID)
+			if !supported {
+				continue
+			}
+
+			entryBytes, err := allAttestations.GetTransparencyLogEntryFor(r.r, env, key.KeyID)
+			if err != nil {
+				return fmt.Errorf("unable to find transparency log entry for signature by '%s': %w", key.KeyID, err)
+			}
+
+			entry := &models.LogEntryAnon{}
+			if err := json.Unmarshal(entryBytes, entry); err != nil {
+				return err
+			}
+
+			if err := tlog.VerifyEntry(ctx, entry, env, key.KeyID, options.TransparencyLogKeys); err != nil {
+				return fmt.Errorf("unable to verify transparency log entry for signature by '%s': %w", key.KeyID, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// loadSignedEnvelopes returns the current policy, the current attestations,
+// and every envelope in the policy and attestations.
+func (r *Repository) loadSignedEnvelopes(ctx context.Context) (*policy.State, *attestations.Attestations, []*sslibdsse.Envelope, error) {
+	state, err := policy.LoadCurrentState(ctx, r.r)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	envelopes := []*sslibdsse.Envelope{state.RootEnvelope}
+	if state.TargetsEnvelope != nil {
+		envelopes = append(envelopes, state.TargetsEnvelope)
+	}
+	for _, env := range state.DelegationEnvelopes {
+		envelopes = append(envelopes, env)
+	}
+	if state.SnapshotEnvelope != nil {
+		envelopes = append(envelopes, state.SnapshotEnvelope)
+	}
+
+	allAttestations, err := attestations.LoadCurrentAttestations(r.r)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	attestationEnvelopes, err := allAttestations.GetAllEnvelopes(r.r)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	envelopes = append(envelopes, attestationEnvelopes...)
+
+	return state, allAttestations, envelopes, nil
+}
+
+// getTransparencyLogKey returns the policy key with keyID if it can be used to
+// verify transparency log entries.
+func getTransparencyLogKey(publicKeys map[string]*tuf.Key, keyID string) (*tuf.Key, bool) {
+	key, has := publicKeys[keyID]
+	if !has {
+		slog.Debug(fmt.Sprintf("Skipping signature by '%s' as key is not in policy", keyID))
+		return nil, false
+	}
+
+	if _, err := tlog.PublicKeyPEM(key); err != nil {
+		slog.Debug(fmt.Sprintf("Skipping signature by '%s': %s", keyID, err.Error()))
+		return nil, false
+	}
+
+	return key, true
+}
@@ -0,0 +1,50 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/attestations"
+	"github.com/gittuf/gittuf/internal/common"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/internal/tlog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUploadToTransparencyLog(t *testing.T) {
+	repo := createTestRepositoryWithPolicy(t, "")
+	server, trustedLogs := common.NewTestRekorServer(t)
+	_, otherTrustedLogs := common.NewTestRekorServer(t)
+
+	refName := "refs/heads/main"
+	commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo.r, refName, 1, gpgKeyBytes)
+	common.CreateTestRSLReferenceEntryCommit(t, repo.r, rsl.NewReferenceEntry(refName, commitIDs[0]), gpgKeyBytes)
+
+	err := repo.VerifyRef(testCtx, refName, true, WithTransparencyLog(trustedLogs))
+	assert.ErrorIs(t, err, attestations.ErrTransparencyLogEntryNotFound)
+
+	err = repo.UploadToTransparencyLog(testCtx, server.URL, false)
+	assert.Nil(t, err)
+
+	err = repo.VerifyRef(testCtx, refName, true, WithTransparencyLog(trustedLogs))
+	assert.Nil(t, err)
+
+	err = repo.VerifyRef(testCtx, refName, true, WithTransparencyLog(otherTrustedLogs))
+	assert.ErrorIs(t, err, tlog.ErrInvalidEntry)
+
+	// Signatures are not uploaded again
+	entry, _, err := rsl.GetLatestReferenceEntryForRef(repo.r, attestations.Ref)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = repo.UploadToTransparencyLog(testCtx, server.URL, false)
+	assert.Nil(t, err)
+
+	latestEntry, _, err := rsl.GetLatestReferenceEntryForRef(repo.r, attestations.Ref)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, entry.ID, latestEntry.ID)
+}
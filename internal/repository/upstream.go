@@ -0,0 +1,221 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/gittuf/gittuf/internal/attestations"
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+// UpstreamRefPrefix is the namespace an upstream repository's gittuf
+// references are mirrored into. For example, the upstream "origin"'s RSL is
+// mirrored to "refs/gittuf/upstream/origin/reference-state-log".
+const UpstreamRefPrefix = "refs/gittuf/upstream/"
+
+var (
+	ErrInvalidUpstreamName   = errors.New("upstream name must be non-empty and must not contain '/'")
+	ErrUpstreamRSLRewritten  = errors.New("upstream RSL has been rewritten since it was last propagated")
+	ErrUpstreamNotPropagated = errors.New("upstream's gittuf references have not been propagated")
+)
+
+// UpstreamRef returns the reference that the upstream's gittuf reference
+// refName is mirrored to.
+func UpstreamRef(upstreamName, refName string) string {
+	return UpstreamRefPrefix + upstreamName + "/" + strings.TrimPrefix(refName, rsl.GittufNamespacePrefix)
+}
+
+// PropagateUpstream mirrors the RSL, policy, and attestations of the upstream
+// repository at upstreamURL into the repository's upstream namespace for
+// upstreamName. The upstream's root of trust is verified after fetching, and
+// the mirrored references are not updated if the upstream RSL was rewritten
+// since the last propagation.
+func (r *Repository) PropagateUpstream(ctx context.Context, upstreamName, upstreamURL string) error {
+	if upstreamName == "" || strings.Contains(upstreamName, "/") {
+		return ErrInvalidUpstreamName
+	}
+
+	remote := git.NewRemote(r.r.Storer, &config.RemoteConfig{Name: upstreamName, URLs: []string{upstreamURL}})
+	remoteRefs, err := remote.ListContext(ctx, &git.ListOptions{})
+	if err != nil {
+		return err
+	}
+	remoteRefNames := map[string]bool{}
+	for _, ref := range remoteRefs {
+		remoteRefNames[ref.Name().String()] = true
+	}
+
+	refNames := []string{}
+	refSpecs := []config.RefSpec{}
+	priorRefs := map[string]*plumbing.Reference{}
+	for _, refName := range []string{rsl.Ref, policy.PolicyRef, attestations.Ref} {
+		if !remoteRefNames[refName] {
+			if refName == attestations.Ref {
+				// The upstream may not have any attestations
+				continue
+			}
+			return fmt.Errorf("upstream '%s' does not have '%s'", upstreamName, refName)
+		}
+
+		upstreamRef := UpstreamRef(upstreamName, refName)
+		refNames = append(refNames, upstreamRef)
+		refSpecs = append(refSpecs, config.RefSpec(fmt.Sprintf("+%s:%s", refName, upstreamRef)))
+
+		ref, err := r.r.Reference(plumbing.ReferenceName(upstreamRef), true)
+		if err == nil {
+			priorRefs[upstreamRef] = ref
+		} else if !errors.Is(err, plumbing.ErrReferenceNotFound) {
+			return err
+		}
+	}
+
+	slog.Debug(fmt.Sprintf("Fetching gittuf references from upstream '%s'...", upstreamName))
+	if err := remote.FetchContext(ctx, &git.FetchOptions{RefSpecs: refSpecs}); err != nil && !errors.Is(err, git.NoErrAlreadyUpToDate) {
+		return err
+	}
+
+	if err := r.verifyPropagatedUpstream(ctx, upstreamName, priorRefs); err != nil {
+		slog.Debug("Unable to verify upstream, restoring prior state...")
+		return errors.Join(err, r.restoreReferences(refNames, priorRefs))
+	}
+
+	return nil
+}
+
+// VerifyRefAgainstUpstream verifies the changes made to refName in the
+// repository, which are not known to the upstream, against the upstream's
+// current policy. The upstream's gittuf references must be propagated first
+// using PropagateUpstream.
+func (r *Repository) VerifyRefAgainstUpstream(ctx context.Context, upstreamName, refName string) error {
+	upstreamRepo, err := r.loadUpstream(upstreamName)
+	if err != nil {
+		return err
+	}
+	upstreamRSLTip, err := gitinterface.GetTip(upstreamRepo, rsl.Ref)
+	if err != nil {
+		return err
+	}
+
+	slog.Debug(fmt.Sprintf("Loading policy of upstream '%s'...", upstreamName))
+	state, err := policy.LoadCurrentState(ctx, upstreamRepo)
+	if err != nil {
+		return err
+	}
+	attestationsState, err := attestations.LoadCurrentAttestations(upstreamRepo)
+	if err != nil {
+		return err
+	}
+
+	refName, err = gitinterface.AbsoluteReference(r.r, refName)
+	if err != nil {
+		return err
+	}
+
+	entry, annotations, err := rsl.GetLatestReferenceEntryForRef(r.r, refName)
+	if err != nil {
+		return err
+	}
+	if err := r.verifyRefTip(refName, entry.TargetID); err != nil {
+		return err
+	}
+
+	for {
+		entryCommit, err := gitinterface.GetCommit(r.r, entry.ID)
+		if err != nil {
+			return err
+		}
+		knownToUpstream, err := gitinterface.KnowsCommit(r.r, upstreamRSLTip, entryCommit)
+		if err != nil {
+			return err
+		}
+		if knownToUpstream {
+			// The remaining entries were verified by the upstream
+			break
+		}
+
+		if !entry.SkippedBy(annotations) {
+			slog.Debug(fmt.Sprintf("Verifying entry '%s' against policy of upstream '%s'...", entry.ID.String(), upstreamName))
+			if err := policy.VerifyEntryWithState(ctx, r.r, state, attestationsState, entry); err != nil {
+				return fmt.Errorf("unable to verify entry '%s' against policy of upstream '%s': %w", entry.ID.String(), upstreamName, err)
+			}
+		}
+
+		entry, annotations, err = rsl.GetLatestReferenceEntryForRefBefore(r.r, refName, entry.ID)
+		if err != nil {
+			if errors.Is(err, rsl.ErrRSLEntryNotFound) {
+				break
+			}
+			return err
+		}
+	}
+
+	return nil
+}
+
+// verifyPropagatedUpstream checks that the mirrored upstream RSL extends the
+// previously mirrored RSL, if any, and that the upstream's root of trust can
+// be verified.
+func (r *Repository) verifyPropagatedUpstream(ctx context.Context, upstreamName string, priorRefs map[string]*plumbing.Reference) error {
+	upstreamRepo, err := r.loadUpstream(upstreamName)
+	if err != nil {
+		return err
+	}
+
+	if priorRSL, has := priorRefs[UpstreamRef(upstreamName, rsl.Ref)]; has {
+		upstreamRSLTip, err := gitinterface.GetTip(upstreamRepo, rsl.Ref)
+		if err != nil {
+			return err
+		}
+		priorRSLCommit, err := gitinterface.GetCommit(r.r, priorRSL.Hash())
+		if err != nil {
+			return err
+		}
+
+		knowsPrior, err := gitinterface.KnowsCommit(r.r, upstreamRSLTip, priorRSLCommit)
+		if err != nil {
+			return err
+		}
+		if !knowsPrior {
+			return ErrUpstreamRSLRewritten
+		}
+	}
+
+	slog.Debug(fmt.Sprintf("Verifying root of trust of upstream '%s'...", upstreamName))
+	_, err = policy.LoadCurrentState(ctx, upstreamRepo)
+	return err
+}
+
+// loadUpstream returns a view of the repository in which the gittuf
+// references are replaced with those mirrored from the upstream.
+func (r *Repository) loadUpstream(upstreamName string) (*git.Repository, error) {
+	updates := []*gitinterface.ReferenceUpdate{}
+	for _, refName := range []string{rsl.Ref, policy.PolicyRef, attestations.Ref} {
+		ref, err := r.r.Reference(plumbing.ReferenceName(UpstreamRef(upstreamName, refName)), true)
+		if err != nil {
+			if errors.Is(err, plumbing.ErrReferenceNotFound) {
+				if refName == attestations.Ref {
+					// The upstream may not have any attestations, ensure
+					// the repository's own attestations aren't used
+					updates = append(updates, &gitinterface.ReferenceUpdate{RefName: refName, NewID: plumbing.ZeroHash})
+					continue
+				}
+				return nil, fmt.Errorf("%w: '%s'", ErrUpstreamNotPropagated, upstreamName)
+			}
+			return nil, err
+		}
+
+		updates = append(updates, &gitinterface.ReferenceUpdate{RefName: refName, NewID: ref.Hash()})
+	}
+
+	return gitinterface.NewRepositoryWithProposedUpdates(r.r, nil, updates)
+}
@@ -0,0 +1,115 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/common"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPropagateUpstream(t *testing.T) {
+	upstreamDir := t.TempDir()
+	upstream := createTestRepositoryWithPolicy(t, upstreamDir)
+
+	forkRepo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	fork := &Repository{r: forkRepo}
+
+	t.Run("invalid upstream name", func(t *testing.T) {
+		err := fork.PropagateUpstream(testCtx, "up/stream", upstreamDir)
+		assert.ErrorIs(t, err, ErrInvalidUpstreamName)
+	})
+
+	t.Run("propagate upstream", func(t *testing.T) {
+		err := fork.PropagateUpstream(testCtx, "upstream", upstreamDir)
+		assert.Nil(t, err)
+
+		upstreamRSLTip, err := upstream.r.Reference(plumbing.ReferenceName(rsl.Ref), true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		mirroredRSLTip, err := fork.r.Reference(plumbing.ReferenceName(UpstreamRef("upstream", rsl.Ref)), true)
+		assert.Nil(t, err)
+		assert.Equal(t, upstreamRSLTip.Hash(), mirroredRSLTip.Hash())
+
+		_, err = fork.r.Reference(plumbing.ReferenceName(rsl.Ref), true)
+		assert.ErrorIs(t, err, plumbing.ErrReferenceNotFound)
+	})
+
+	t.Run("upstream RSL rewritten", func(t *testing.T) {
+		mirroredRSLTip, err := fork.r.Reference(plumbing.ReferenceName(UpstreamRef("upstream", rsl.Ref)), true)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		latestEntry, err := rsl.GetLatestEntry(upstream.r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		parentEntry, err := rsl.GetParentForEntry(upstream.r, latestEntry)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := upstream.r.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(rsl.Ref), parentEntry.GetID())); err != nil {
+			t.Fatal(err)
+		}
+		if err := rsl.NewReferenceEntry("refs/heads/feature", plumbing.ZeroHash).Commit(upstream.r, false); err != nil {
+			t.Fatal(err)
+		}
+
+		err = fork.PropagateUpstream(testCtx, "upstream", upstreamDir)
+		assert.ErrorIs(t, err, ErrUpstreamRSLRewritten)
+
+		currentRSLTip, err := fork.r.Reference(plumbing.ReferenceName(UpstreamRef("upstream", rsl.Ref)), true)
+		assert.Nil(t, err)
+		assert.Equal(t, mirroredRSLTip.Hash(), currentRSLTip.Hash())
+	})
+}
+
+func TestVerifyRefAgainstUpstream(t *testing.T) {
+	refName := "refs/heads/main"
+
+	upstreamDir := t.TempDir()
+	createTestRepositoryWithPolicy(t, upstreamDir)
+
+	forkRepo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	fork := &Repository{r: forkRepo}
+	if err := rsl.InitializeNamespace(fork.r); err != nil {
+		t.Fatal(err)
+	}
+	if err := fork.r.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(refName), plumbing.ZeroHash)); err != nil {
+		t.Fatal(err)
+	}
+
+	commitIDs := common.AddNTestCommitsToSpecifiedRef(t, fork.r, refName, 1, gpgKeyBytes)
+	common.CreateTestRSLReferenceEntryCommit(t, fork.r, rsl.NewReferenceEntry(refName, commitIDs[0]), gpgKeyBytes)
+
+	err = fork.VerifyRefAgainstUpstream(testCtx, "upstream", refName)
+	assert.ErrorIs(t, err, ErrUpstreamNotPropagated)
+
+	if err := fork.PropagateUpstream(testCtx, "upstream", upstreamDir); err != nil {
+		t.Fatal(err)
+	}
+
+	err = fork.VerifyRefAgainstUpstream(testCtx, "upstream", refName)
+	assert.Nil(t, err)
+
+	commitIDs = common.AddNTestCommitsToSpecifiedRef(t, fork.r, refName, 1, gpgUnauthorizedKeyBytes)
+	common.CreateTestRSLReferenceEntryCommit(t, fork.r, rsl.NewReferenceEntry(refName, commitIDs[0]), gpgUnauthorizedKeyBytes)
+
+	err = fork.VerifyRefAgainstUpstream(testCtx, "upstream", refName)
+	assert.ErrorIs(t, err, policy.ErrUnauthorizedSignature)
+}
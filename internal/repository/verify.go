@@ -7,11 +7,16 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"sync"
+	"time"
 
-	"github.com/gittuf/gittuf/internal/dev"
+	"github.com/gittuf/gittuf/internal/cache"
 	"github.com/gittuf/gittuf/internal/gitinterface"
 	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/internal/tsa"
 	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/sigstore/cosign/v2/pkg/cosign"
 )
 
 // ErrRefStateDoesNotMatchRSL is returned when a Git reference being verified
@@ -23,39 +28,449 @@ import (
 // another is to create a new RSL entry for the current state.
 var ErrRefStateDoesNotMatchRSL = errors.New("Git reference's current state does not match latest RSL entry") //nolint:stylecheck
 
-func (r *Repository) VerifyRef(ctx context.Context, target string, latestOnly bool) error {
+// VerifyRefOptions contains the configurable parameters for verifying a
+// reference.
+type VerifyRefOptions struct {
+	// ExpiryGracePeriod is the duration for which expired policy metadata is
+	// accepted with a warning.
+	ExpiryGracePeriod time.Duration
+
+	// UseCache indicates if the verification cache must be used to skip RSL
+	// entries verified in prior runs. It only applies to full verification.
+	UseCache bool
+
+	// Jobs is the maximum number of RSL entries, or references when verifying
+	// multiple references, that are verified concurrently.
+	Jobs int
+
+	// RequireProvenance indicates that tags being verified must have SLSA
+	// provenance generated by one of ProvenanceBuilderIDs from the repository
+	// at ProvenanceSourceURI. If ProvenanceSourceURI is empty, the URL of the
+	// origin remote is used.
+	RequireProvenance    bool
+	ProvenanceBuilderIDs []string
+	ProvenanceSourceURI  string
+
+	// TransparencyLogKeys, if set, requires that the signatures on the current
+	// policy metadata and attestations are recorded in a transparency log
+	// trusted using these keys.
+	TransparencyLogKeys *cosign.TrustedTransparencyLogPubKeys
+
+	// TimestampAuthorityCertificates, if set, requires that the signatures on
+	// the current policy metadata, attestations, and RSL entries carry
+	// timestamps issued by a timestamp authority trusted using these
+	// certificates.
+	TimestampAuthorityCertificates *tsa.CertificateChain
+
+	// MissingObjectsRemote, if set, is the remote that objects required for
+	// verification are fetched from when they are missing from a shallow or
+	// partial clone. Otherwise, the missing objects are reported.
+	MissingObjectsRemote string
+
+	// Explain, if set, receives the decision trace for each RSL entry that is
+	// verified.
+	Explain policy.ExplainFunc
+}
+
+type VerifyRefOption func(*VerifyRefOptions)
+
+// WithJobs sets the maximum number of RSL entries, or references when
+// verifying multiple references, that are verified concurrently. By default,
+// verification is sequential.
+func WithJobs(jobs int) VerifyRefOption {
+	return func(o *VerifyRefOptions) {
+		o.Jobs = jobs
+	}
+}
+
+// WithoutCache disables the use of the verification cache, so that the entire
+// RSL is verified for the reference. By default, the cache is used.
+func WithoutCache() VerifyRefOption {
+	return func(o *VerifyRefOptions) {
+		o.UseCache = false
+	}
+}
+
+// WithExpiryGracePeriod sets the duration for which expired policy metadata is
+// accepted with a warning. By default, policy.DefaultExpiryGracePeriod is used.
+func WithExpiryGracePeriod(gracePeriod time.Duration) VerifyRefOption {
+	return func(o *VerifyRefOptions) {
+		o.ExpiryGracePeriod = gracePeriod
+	}
+}
+
+// WithProvenance requires that tags being verified have SLSA provenance
+// generated by one of the builders from the repository at sourceURI. If
+// sourceURI is empty, the URL of the origin remote is used.
+func WithProvenance(builderIDs []string, sourceURI string) VerifyRefOption {
+	return func(o *VerifyRefOptions) {
+		o.RequireProvenance = true
+		o.ProvenanceBuilderIDs = builderIDs
+		o.ProvenanceSourceURI = sourceURI
+	}
+}
+
+// WithTransparencyLog requires that the signatures on the current policy
+// metadata and attestations are recorded in a transparency log trusted using
+// the keys. Signatures made using keys the log does not support are not
+// checked.
+func WithTransparencyLog(trustedLogs *cosign.TrustedTransparencyLogPubKeys) VerifyRefOption {
+	return func(o *VerifyRefOptions) {
+		o.TransparencyLogKeys = trustedLogs
+	}
+}
+
+// WithTimestampAuthority requires that signatures carry timestamps issued by a
+// timestamp authority trusted using the certificate chain, and that the
+// timestamps show signatures were made while the signing keys were trusted.
+func WithTimestampAuthority(chain *tsa.CertificateChain) VerifyRefOption {
+	return func(o *VerifyRefOptions) {
+		o.TimestampAuthorityCertificates = chain
+	}
+}
+
+// WithExplanation passes the decision trace for each RSL entry that is verified
+// to fn, such as the rules that matched the entry, the keys counted towards
+// each rule's threshold, and the signatures that were rejected. Entries skipped
+// using the verification cache are not explained.
+func WithExplanation(fn policy.ExplainFunc) VerifyRefOption {
+	return func(o *VerifyRefOptions) {
+		o.Explain = fn
+	}
+}
+
+func (r *Repository) VerifyRef(ctx context.Context, target string, latestOnly bool, opts ...VerifyRefOption) error {
+	verified, err := r.verifyRef(ctx, target, latestOnly, opts...)
+	if err != nil {
+		return err
+	}
+	if verified == nil {
+		return nil
+	}
+
+	return r.updateVerificationCache(verified)
+}
+
+// RefVerificationResult records the outcome of verifying a reference, for use
+// in machine-readable output.
+type RefVerificationResult struct {
+	Ref      string `json:"ref"`
+	Verified bool   `json:"verified"`
+	Error    string `json:"error,omitempty"`
+
+	err error
+}
+
+// NewRefVerificationResult returns the result of verifying the target, where
+// err is the error verification failed with, if any.
+func NewRefVerificationResult(target string, err error) *RefVerificationResult {
+	result := &RefVerificationResult{Ref: target, Verified: err == nil, err: err}
+	if err != nil {
+		result.Error = err.Error()
+	}
+	return result
+}
+
+// Err returns the error verification of the reference failed with, if any.
+func (r *RefVerificationResult) Err() error {
+	return r.err
+}
+
+// VerifyRefs verifies each of the targets, verifying up to the configured
+// number of jobs concurrently. When multiple targets are verified concurrently,
+// the RSL entries of each target are verified sequentially. The errors for all
+// targets that fail verification are returned together.
+func (r *Repository) VerifyRefs(ctx context.Context, targets []string, latestOnly bool, opts ...VerifyRefOption) error {
+	options := &VerifyRefOptions{Jobs: 1}
+	for _, fn := range opts {
+		fn(options)
+	}
+
+	if len(targets) == 1 || options.Jobs <= 1 {
+		for _, target := range targets {
+			if err := r.VerifyRef(ctx, target, latestOnly, opts...); err != nil {
+				return fmt.Errorf("unable to verify '%s': %w", target, err)
+			}
+		}
+		return nil
+	}
+
+	errs, err := r.verifyRefsConcurrently(ctx, targets, latestOnly, options.Jobs, opts...)
+	for index, targetErr := range errs {
+		if targetErr != nil {
+			errs[index] = fmt.Errorf("unable to verify '%s': %w", targets[index], targetErr)
+		}
+	}
+	errs = append(errs, err)
+
+	return errors.Join(errs...)
+}
+
+// VerifyRefsWithResults verifies each of the targets like VerifyRefs, but
+// continues past targets that fail verification and returns the result for
+// every target. An error is only returned if verification could not be
+// performed or recorded.
+func (r *Repository) VerifyRefsWithResults(ctx context.Context, targets []string, latestOnly bool, opts ...VerifyRefOption) ([]*RefVerificationResult, error) {
+	options := &VerifyRefOptions{Jobs: 1}
+	for _, fn := range opts {
+		fn(options)
+	}
+
 	var (
-		expectedTip plumbing.Hash
-		err         error
+		errs []error
+		err  error
 	)
+	if len(targets) == 1 || options.Jobs <= 1 {
+		errs = make([]error, len(targets))
+		for index, target := range targets {
+			errs[index] = r.VerifyRef(ctx, target, latestOnly, opts...)
+		}
+	} else {
+		errs, err = r.verifyRefsConcurrently(ctx, targets, latestOnly, options.Jobs, opts...)
+		if err != nil {
+			return nil, err
+		}
+	}
 
-	slog.Debug("Identifying absolute reference path...")
-	target, err = gitinterface.AbsoluteReference(r.r, target)
+	results := make([]*RefVerificationResult, 0, len(targets))
+	for index, target := range targets {
+		results = append(results, NewRefVerificationResult(target, errs[index]))
+	}
+
+	return results, nil
+}
+
+// verifyRefsConcurrently verifies the targets using up to jobs workers, and
+// returns the error each target failed verification with, if any. The returned
+// error is set if the workers could not be created or the verification cache
+// could not be updated.
+func (r *Repository) verifyRefsConcurrently(ctx context.Context, targets []string, latestOnly bool, jobs int, opts ...VerifyRefOption) ([]error, error) {
+	// Each worker reads from the repository using its own handle
+	workers := make([]*Repository, min(jobs, len(targets)))
+	for i := range workers {
+		handle, err := gitinterface.NewRepositoryHandle(r.r)
+		if err != nil {
+			return nil, err
+		}
+		workers[i] = &Repository{r: handle}
+	}
+
+	refOpts := make([]VerifyRefOption, 0, len(opts)+1)
+	refOpts = append(refOpts, opts...)
+	refOpts = append(refOpts, WithJobs(1))
+
+	verified := make([]*verifiedEntry, len(targets))
+	errs := make([]error, len(targets))
+	queue := make(chan int)
+	var wg sync.WaitGroup
+	for _, worker := range workers {
+		wg.Add(1)
+		go func(worker *Repository) {
+			defer wg.Done()
+			for index := range queue {
+				verified[index], errs[index] = worker.verifyRef(ctx, targets[index], latestOnly, refOpts...)
+			}
+		}(worker)
+	}
+	for index := range targets {
+		queue <- index
+	}
+	close(queue)
+	wg.Wait()
+
+	// The cache is updated once all workers are done so that it is not
+	// written to concurrently
+	entries := []*verifiedEntry{}
+	for _, entry := range verified {
+		if entry != nil {
+			entries = append(entries, entry)
+		}
+	}
+	if len(entries) > 0 {
+		if err := r.updateVerificationCache(entries...); err != nil {
+			return errs, err
+		}
+	}
+
+	return errs, nil
+}
+
+// VerifyRefFromEntry verifies the target ref using only the RSL entries from
+// the specified entry. Entries prior to it are trusted without verification.
+func (r *Repository) VerifyRefFromEntry(ctx context.Context, target, entryID string, opts ...VerifyRefOption) error {
+	return r.verifyRefFrom(ctx, target, func(ctx context.Context, target string, verificationOpts ...policy.VerificationOption) (plumbing.Hash, error) {
+		slog.Debug(fmt.Sprintf("Verifying gittuf policies for '%s' from entry '%s'", target, entryID))
+		if err := r.ensureObjectsForVerification(ctx, target, plumbing.NewHash(entryID), opts...); err != nil {
+			return plumbing.ZeroHash, err
+		}
+		return policy.VerifyRefFromEntry(ctx, r.r, target, plumbing.NewHash(entryID), verificationOpts...)
+	}, opts...)
+}
+
+// VerifyRefFromCommit verifies the target ref using only the RSL entries that
+// record the specified commit or its descendants. This is useful to verify the
+// changes introduced by a push, starting from the commit the push was based on.
+func (r *Repository) VerifyRefFromCommit(ctx context.Context, target, commitID string, opts ...VerifyRefOption) error {
+	return r.verifyRefFrom(ctx, target, func(ctx context.Context, target string, verificationOpts ...policy.VerificationOption) (plumbing.Hash, error) {
+		slog.Debug(fmt.Sprintf("Verifying gittuf policies for '%s' from commit '%s'", target, commitID))
+		commitHash, err := r.r.ResolveRevision(plumbing.Revision(commitID))
+		if err != nil {
+			return plumbing.ZeroHash, err
+		}
+
+		commit, err := gitinterface.GetCommit(r.r, *commitHash)
+		if err == nil {
+			if fromEntry, _, err := rsl.GetFirstReferenceEntryForCommitInRef(r.r, target, commit); err == nil {
+				if err := r.ensureObjectsForVerification(ctx, target, fromEntry.ID, opts...); err != nil {
+					return plumbing.ZeroHash, err
+				}
+			}
+		}
+
+		return policy.VerifyRefFromCommit(ctx, r.r, target, *commitHash, verificationOpts...)
+	}, opts...)
+}
+
+func (r *Repository) VerifyCommit(ctx context.Context, ids ...string) map[string]string {
+	slog.Debug("Verifying commit signature...")
+	return policy.VerifyCommit(ctx, r.r, ids...)
+}
+
+func (r *Repository) VerifyTag(ctx context.Context, ids []string) map[string]string {
+	slog.Debug("Verifying tag signature...")
+	return policy.VerifyTag(ctx, r.r, ids)
+}
+
+// VerifyTagRef verifies the tag identified by id end-to-end. The tag's RSL
+// entry and tag object must be signed by keys authorized for the tag, the tag
+// must not have been moved after it was first recorded in the RSL, and the tag
+// must match the RSL locally. If provenance is required, the tag must also
+// have valid SLSA provenance.
+func (r *Repository) VerifyTagRef(ctx context.Context, id string, opts ...VerifyRefOption) error {
+	options := &VerifyRefOptions{}
+	for _, fn := range opts {
+		fn(options)
+	}
+
+	if err := r.verifyPolicyExpiration(ctx, opts...); err != nil {
+		return err
+	}
+
+	if err := r.verifyTransparencyLog(ctx, opts...); err != nil {
+		return err
+	}
+
+	ctx, err := r.verifySignatureTimestamps(ctx, opts...)
 	if err != nil {
 		return err
 	}
+	if options.Explain != nil {
+		ctx = policy.WithExplanation(ctx, options.Explain)
+	}
 
-	slog.Debug(fmt.Sprintf("Verifying gittuf policies for '%s'", target))
+	slog.Debug(fmt.Sprintf("Verifying gittuf policies for tag '%s'", id))
+	tagRef, expectedTip, err := policy.VerifyTagRef(ctx, r.r, id, policy.WithJobs(options.Jobs))
+	if err != nil {
+		return err
+	}
 
-	if latestOnly {
-		expectedTip, err = policy.VerifyRef(ctx, r.r, target)
-	} else {
-		expectedTip, err = policy.VerifyRefFull(ctx, r.r, target)
+	slog.Debug("Verifying if tag matches expected value from RSL...")
+	if err := r.verifyRefTip(tagRef, expectedTip); err != nil {
+		return err
+	}
+
+	if !options.RequireProvenance {
+		return nil
+	}
+
+	sourceURI := options.ProvenanceSourceURI
+	if sourceURI == "" {
+		remote, err := r.r.Remote(gitinterface.DefaultRemoteName)
+		if err != nil {
+			return fmt.Errorf("unable to identify repository location for provenance: %w", err)
+		}
+		sourceURI = remote.Config().URLs[0]
 	}
+
+	slog.Debug(fmt.Sprintf("Verifying provenance for tag '%s'...", id))
+	return policy.VerifyTagProvenance(r.r, tagRef, options.ProvenanceBuilderIDs, sourceURI)
+}
+
+// VerifyOrganizationRoot fetches the policy of the organization root of trust
+// the repository's root of trust delegates to, and verifies that the
+// repository's current root of trust is signed by a threshold of the keys the
+// organization expects for the repository. The organization's own root of
+// trust is verified from its first policy entry.
+func (r *Repository) VerifyOrganizationRoot(ctx context.Context) error {
+	state, err := policy.LoadCurrentState(ctx, r.r)
 	if err != nil {
 		return err
 	}
 
-	slog.Debug("Verifying if tip of reference matches expected value from RSL...")
-	return r.verifyRefTip(target, expectedTip)
+	rootMetadata, err := state.GetRootMetadata()
+	if err != nil {
+		return err
+	}
+	if rootMetadata.OrganizationRoot == nil {
+		return policy.ErrNoOrganizationRoot
+	}
+
+	slog.Debug(fmt.Sprintf("Fetching organization root of trust from '%s'...", rootMetadata.OrganizationRoot.Location))
+	organizationRepo, err := gitinterface.FetchToMemory(ctx, rootMetadata.OrganizationRoot.Location, []string{rsl.Ref, policy.PolicyRef})
+	if err != nil {
+		return err
+	}
+
+	slog.Debug("Loading organization root of trust...")
+	organizationState, err := policy.LoadCurrentState(ctx, organizationRepo)
+	if err != nil {
+		return fmt.Errorf("unable to load organization root of trust: %w", err)
+	}
+
+	return policy.VerifyRootWithOrganizationRoot(ctx, state, organizationState)
 }
 
-func (r *Repository) VerifyRefFromEntry(ctx context.Context, target, entryID string) error {
-	if !dev.InDevMode() {
-		return dev.ErrNotInDevMode
+// verifyPolicyExpiration checks that the repository's current policy has not
+// expired. Historical policies are not checked as they have been superseded.
+func (r *Repository) verifyPolicyExpiration(ctx context.Context, opts ...VerifyRefOption) error {
+	options := &VerifyRefOptions{ExpiryGracePeriod: policy.DefaultExpiryGracePeriod}
+	for _, fn := range opts {
+		fn(options)
+	}
+
+	slog.Debug("Checking if current policy has expired...")
+	state, err := policy.LoadCurrentState(ctx, r.r)
+	if err != nil {
+		return err
+	}
+
+	return state.VerifyExpiration(time.Now(), options.ExpiryGracePeriod)
+}
+
+// verifyRefFrom verifies the target ref using the verify function to verify a
+// bounded range of its RSL entries, and checks that the ref's tip matches the
+// expected value from the RSL.
+func (r *Repository) verifyRefFrom(ctx context.Context, target string, verify func(context.Context, string, ...policy.VerificationOption) (plumbing.Hash, error), opts ...VerifyRefOption) error {
+	options := &VerifyRefOptions{Jobs: 1}
+	for _, fn := range opts {
+		fn(options)
+	}
+
+	if err := r.verifyPolicyExpiration(ctx, opts...); err != nil {
+		return err
+	}
+
+	if err := r.verifyTransparencyLog(ctx, opts...); err != nil {
+		return err
 	}
 
-	var err error
+	ctx, err := r.verifySignatureTimestamps(ctx, opts...)
+	if err != nil {
+		return err
+	}
+	if options.Explain != nil {
+		ctx = policy.WithExplanation(ctx, options.Explain)
+	}
 
 	slog.Debug("Identifying absolute reference path...")
 	target, err = gitinterface.AbsoluteReference(r.r, target)
@@ -63,8 +478,7 @@ func (r *Repository) VerifyRefFromEntry(ctx context.Context, target, entryID str
 		return err
 	}
 
-	slog.Debug(fmt.Sprintf("Verifying gittuf policies for '%s' from entry '%s'", target, entryID))
-	expectedTip, err := policy.VerifyRefFromEntry(ctx, r.r, target, plumbing.NewHash(entryID))
+	expectedTip, err := verify(ctx, target, policy.WithJobs(options.Jobs))
 	if err != nil {
 		return err
 	}
@@ -73,19 +487,215 @@ func (r *Repository) VerifyRefFromEntry(ctx context.Context, target, entryID str
 	return r.verifyRefTip(target, expectedTip)
 }
 
-func (r *Repository) VerifyCommit(ctx context.Context, ids ...string) map[string]string {
-	slog.Debug("Verifying commit signature...")
-	return policy.VerifyCommit(ctx, r.r, ids...)
+// verifiedEntry identifies the latest RSL entry verified for a reference using
+// the verification cache.
+type verifiedEntry struct {
+	target        string
+	policyEntryID plumbing.Hash
+	entryID       plumbing.Hash
 }
 
-func (r *Repository) VerifyTag(ctx context.Context, ids []string) map[string]string {
-	slog.Debug("Verifying tag signature...")
-	return policy.VerifyTag(ctx, r.r, ids)
+// verifyRef verifies the target ref. If the verification cache was used, the
+// verified entry that must be recorded in the cache is returned.
+func (r *Repository) verifyRef(ctx context.Context, target string, latestOnly bool, opts ...VerifyRefOption) (*verifiedEntry, error) {
+	var (
+		expectedTip plumbing.Hash
+		verified    *verifiedEntry
+		err         error
+	)
+
+	options := &VerifyRefOptions{ExpiryGracePeriod: policy.DefaultExpiryGracePeriod, UseCache: true, Jobs: 1}
+	for _, fn := range opts {
+		fn(options)
+	}
+
+	if err := r.verifyPolicyExpiration(ctx, opts...); err != nil {
+		return nil, err
+	}
+
+	if err := r.verifyTransparencyLog(ctx, opts...); err != nil {
+		return nil, err
+	}
+
+	ctx, err = r.verifySignatureTimestamps(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+	if options.Explain != nil {
+		ctx = policy.WithExplanation(ctx, options.Explain)
+	}
+
+	slog.Debug("Identifying absolute reference path...")
+	target, err = gitinterface.AbsoluteReference(r.r, target)
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Debug(fmt.Sprintf("Verifying gittuf policies for '%s'", target))
+
+	switch {
+	case latestOnly:
+		if err := r.ensureObjectsForLatestEntry(ctx, target, opts...); err != nil {
+			return nil, err
+		}
+		expectedTip, err = policy.VerifyRef(ctx, r.r, target)
+	case options.UseCache:
+		expectedTip, verified, err = r.verifyRefFullUsingCache(ctx, target, opts...)
+	default:
+		if err := r.ensureObjectsForVerification(ctx, target, plumbing.ZeroHash, opts...); err != nil {
+			return nil, err
+		}
+		expectedTip, err = policy.VerifyRefFull(ctx, r.r, target, policy.WithJobs(options.Jobs))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	slog.Debug("Verifying if tip of reference matches expected value from RSL...")
+	return verified, r.verifyRefTip(target, expectedTip)
+}
+
+// verifyRefFullUsingCache verifies the RSL for the target ref, starting from
+// the latest entry recorded in the verification cache for the ref. If the
+// cached entry cannot be used, the entire RSL is verified. The latest verified
+// entry is returned so that it can be recorded in the cache.
+func (r *Repository) verifyRefFullUsingCache(ctx context.Context, target string, opts ...VerifyRefOption) (plumbing.Hash, *verifiedEntry, error) {
+	options := &VerifyRefOptions{Jobs: 1}
+	for _, fn := range opts {
+		fn(options)
+	}
+
+	policyEntry, _, err := rsl.GetLatestReferenceEntryForRef(r.r, policy.PolicyRef)
+	if err != nil {
+		return plumbing.ZeroHash, nil, err
+	}
+
+	latestEntry, _, err := rsl.GetLatestReferenceEntryForRef(r.r, target)
+	if err != nil {
+		return plumbing.ZeroHash, nil, err
+	}
+
+	slog.Debug("Loading verification cache...")
+	verificationCache, err := cache.LoadVerificationCache(r.r)
+	if err != nil {
+		return plumbing.ZeroHash, nil, err
+	}
+
+	cachedEntryID := verificationCache.GetVerifiedEntry(policyEntry.ID, target)
+	isCacheUsable, err := r.isCachedEntryUsable(cachedEntryID, latestEntry)
+	if err != nil {
+		return plumbing.ZeroHash, nil, err
+	}
+
+	var expectedTip plumbing.Hash
+	switch {
+	case isCacheUsable && cachedEntryID == latestEntry.ID:
+		slog.Debug(fmt.Sprintf("Latest entry '%s' was verified previously", latestEntry.ID.String()))
+		expectedTip = latestEntry.TargetID
+	case isCacheUsable:
+		slog.Debug(fmt.Sprintf("Verifying entries since previously verified entry '%s'...", cachedEntryID.String()))
+		if err := r.ensureObjectsForVerification(ctx, target, cachedEntryID, opts...); err != nil {
+			return plumbing.ZeroHash, nil, err
+		}
+		expectedTip, err = policy.VerifyRefFromEntry(ctx, r.r, target, cachedEntryID, policy.WithJobs(options.Jobs))
+	default:
+		if err := r.ensureObjectsForVerification(ctx, target, plumbing.ZeroHash, opts...); err != nil {
+			return plumbing.ZeroHash, nil, err
+		}
+		expectedTip, err = policy.VerifyRefFull(ctx, r.r, target, policy.WithJobs(options.Jobs))
+	}
+	if err != nil {
+		return plumbing.ZeroHash, nil, err
+	}
+
+	return expectedTip, &verifiedEntry{target: target, policyEntryID: policyEntry.ID, entryID: latestEntry.ID}, nil
+}
+
+// updateVerificationCache records the verified entries in the verification
+// cache.
+func (r *Repository) updateVerificationCache(entries ...*verifiedEntry) error {
+	slog.Debug("Updating verification cache...")
+	verificationCache, err := cache.LoadVerificationCache(r.r)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		verificationCache.SetVerifiedEntry(entry.policyEntryID, entry.target, entry.entryID)
+	}
+
+	return verificationCache.Commit(r.r)
+}
+
+// isCachedEntryUsable checks that the cached entry for a ref can be used as the
+// starting point for verification. The entry must be a reference entry that is
+// still in the RSL and must not have been skipped since it was verified.
+func (r *Repository) isCachedEntryUsable(cachedEntryID plumbing.Hash, latestEntry *rsl.ReferenceEntry) (bool, error) {
+	if cachedEntryID.IsZero() {
+		return false, nil
+	}
+
+	// The latest entry for the ref is in the RSL, but may be recorded by a
+	// checkpoint
+	if cachedEntryID != latestEntry.ID {
+		rslRef, err := r.r.Reference(plumbing.ReferenceName(rsl.Ref), true)
+		if err != nil {
+			return false, err
+		}
+		cachedEntryCommit, err := gitinterface.GetCommit(r.r, cachedEntryID)
+		if err != nil {
+			if errors.Is(err, plumbing.ErrObjectNotFound) {
+				return false, nil
+			}
+			return false, err
+		}
+		knows, err := gitinterface.KnowsCommit(r.r, rslRef.Hash(), cachedEntryCommit)
+		if err != nil {
+			return false, err
+		}
+		if !knows {
+			slog.Debug(fmt.Sprintf("Cached entry '%s' is no longer in the RSL", cachedEntryID.String()))
+			return false, nil
+		}
+
+		cachedEntry, err := rsl.GetEntry(r.r, cachedEntryID)
+		if err != nil {
+			return false, err
+		}
+		if _, isReferenceEntry := cachedEntry.(*rsl.ReferenceEntry); !isReferenceEntry {
+			return false, nil
+		}
+	}
+
+	_, annotations, err := rsl.GetReferenceEntriesInRangeForRef(r.r, cachedEntryID, latestEntry.ID, latestEntry.RefName)
+	if err != nil {
+		return false, err
+	}
+	for _, annotation := range annotations[cachedEntryID] {
+		if annotation.Skip {
+			slog.Debug(fmt.Sprintf("Cached entry '%s' has been skipped", cachedEntryID.String()))
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// WithMissingObjectsFetchedFrom fetches the objects required for verification
+// from the remote when they are missing from a shallow or partial clone.
+func WithMissingObjectsFetchedFrom(remoteName string) VerifyRefOption {
+	return func(o *VerifyRefOptions) {
+		o.MissingObjectsRemote = remoteName
+	}
 }
 
 func (r *Repository) verifyRefTip(target string, expectedTip plumbing.Hash) error {
 	ref, err := r.r.Reference(plumbing.ReferenceName(target), true)
 	if err != nil {
+		if errors.Is(err, plumbing.ErrReferenceNotFound) && expectedTip.IsZero() {
+			// The RSL records the deletion of the reference
+			return nil
+		}
 		return err
 	}
 
@@ -95,3 +705,145 @@ func (r *Repository) verifyRefTip(target string, expectedTip plumbing.Hash) erro
 
 	return nil
 }
+
+// ensureObjectsForLatestEntry checks that the objects required to verify the
+// latest RSL entry for the target are in the repository, as with
+// ensureObjectsForVerification.
+func (r *Repository) ensureObjectsForLatestEntry(ctx context.Context, target string, opts ...VerifyRefOption) error {
+	latestEntry, _, err := rsl.GetLatestReferenceEntryForRef(r.r, target)
+	if err != nil {
+		if errors.Is(err, rsl.ErrRSLEntryNotFound) {
+			// Verification reports the missing entry
+			return nil
+		}
+		return err
+	}
+
+	return r.ensureObjectsForVerification(ctx, target, latestEntry.ID, opts...)
+}
+
+// ensureObjectsForVerification checks that the objects required to verify the
+// target's RSL entries from fromEntryID to the latest entry are in the
+// repository. If fromEntryID is the zero hash, all of the target's entries are
+// checked. This only applies to shallow and partial clones. Missing objects are
+// fetched from the remote set using WithMissingObjectsFetchedFrom. If they
+// cannot be fetched, a gitinterface.MissingObjectsError identifying them is
+// returned.
+func (r *Repository) ensureObjectsForVerification(ctx context.Context, target string, fromEntryID plumbing.Hash, opts ...VerifyRefOption) error {
+	options := &VerifyRefOptions{}
+	for _, fn := range opts {
+		fn(options)
+	}
+
+	isShallow, err := gitinterface.IsShallowClone(r.r)
+	if err != nil {
+		return err
+	}
+	isPartial, err := gitinterface.IsPartialClone(r.r)
+	if err != nil {
+		return err
+	}
+	if !isShallow && !isPartial {
+		return nil
+	}
+
+	latestEntry, _, err := rsl.GetLatestReferenceEntryForRef(r.r, target)
+	if err != nil {
+		if errors.Is(err, rsl.ErrRSLEntryNotFound) {
+			// Verification reports the missing entry
+			return nil
+		}
+		return err
+	}
+
+	if fromEntryID.IsZero() {
+		firstEntry, _, err := rsl.GetFirstEntry(r.r)
+		if err != nil {
+			return err
+		}
+		fromEntryID = firstEntry.ID
+	}
+
+	entries, _, err := rsl.GetReferenceEntriesInRangeForRef(r.r, fromEntryID, latestEntry.ID, target)
+	if err != nil {
+		return err
+	}
+
+	missing, err := r.getMissingObjectsForEntries(target, entries)
+	if err != nil {
+		return err
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+
+	if options.MissingObjectsRemote == "" {
+		return &gitinterface.MissingObjectsError{ObjectIDs: missing}
+	}
+
+	slog.Debug(fmt.Sprintf("Fetching %d objects missing from clone from '%s'...", len(missing), options.MissingObjectsRemote))
+	if err := gitinterface.FetchObjects(ctx, r.r, options.MissingObjectsRemote, missing); err != nil {
+		return fmt.Errorf("unable to fetch missing objects: %w", errors.Join(err, &gitinterface.MissingObjectsError{ObjectIDs: missing}))
+	}
+
+	// The existing handle does not see packfiles added by the fetch
+	handle, err := gitinterface.NewRepositoryHandle(r.r)
+	if err != nil {
+		return err
+	}
+	r.r = handle
+
+	missing, err = r.getMissingObjectsForEntries(target, entries)
+	if err != nil {
+		return err
+	}
+	if len(missing) != 0 {
+		return &gitinterface.MissingObjectsError{ObjectIDs: missing}
+	}
+
+	return nil
+}
+
+// getMissingObjectsForEntries returns the IDs of the objects required to
+// verify the target's entries that are not in the repository.
+func (r *Repository) getMissingObjectsForEntries(target string, entries []*rsl.ReferenceEntry) ([]plumbing.Hash, error) {
+	missing := []plumbing.Hash{}
+	seen := map[plumbing.Hash]bool{}
+
+	var priorEntry *rsl.ReferenceEntry
+	for _, entry := range entries {
+		if entry.RefName != target {
+			continue
+		}
+
+		priorTargetID := plumbing.ZeroHash
+		if priorEntry != nil {
+			priorTargetID = priorEntry.TargetID
+		} else {
+			priorRefEntry, _, err := rsl.GetLatestReferenceEntryForRefBefore(r.r, target, entry.ID)
+			if err == nil {
+				priorTargetID = priorRefEntry.TargetID
+			} else if !errors.Is(err, rsl.ErrRSLEntryNotFound) {
+				return nil, err
+			}
+		}
+		priorEntry = entry
+
+		if entry.IsDeletion() {
+			continue
+		}
+
+		entryMissing, err := gitinterface.GetMissingObjects(r.r, entry.TargetID, priorTargetID, entry.TargetSHA256 != "")
+		if err != nil {
+			return nil, err
+		}
+		for _, objectID := range entryMissing {
+			if !seen[objectID] {
+				seen[objectID] = true
+				missing = append(missing, objectID)
+			}
+		}
+	}
+
+	return missing, nil
+}
@@ -5,12 +5,19 @@ package repository
 import (
 	"context"
 	"fmt"
+	"os/exec"
 	"testing"
 
+	"github.com/gittuf/gittuf/internal/attestations"
+	"github.com/gittuf/gittuf/internal/cache"
 	"github.com/gittuf/gittuf/internal/common"
-	"github.com/gittuf/gittuf/internal/dev"
+	"github.com/gittuf/gittuf/internal/gitinterface"
 	"github.com/gittuf/gittuf/internal/policy"
 	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/internal/signerverifier"
+	"github.com/gittuf/gittuf/internal/signerverifier/gpg"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/go-git/go-git/v5/config"
 	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/stretchr/testify/assert"
 )
@@ -73,9 +80,167 @@ func TestVerifyRef(t *testing.T) {
 	assert.ErrorIs(t, err, ErrRefStateDoesNotMatchRSL)
 }
 
-func TestVerifyRefFromEntry(t *testing.T) {
-	t.Setenv(dev.DevModeKey, "1")
+func TestVerifyRefs(t *testing.T) {
+	repo := createTestRepositoryWithPolicy(t, t.TempDir())
+
+	refNames := []string{"refs/heads/main", "refs/heads/feature", "refs/heads/release"}
+	for _, refName := range refNames {
+		for i := 0; i < 2; i++ {
+			commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo.r, refName, 1, gpgKeyBytes)
+			common.CreateTestRSLReferenceEntryCommit(t, repo.r, rsl.NewReferenceEntry(refName, commitIDs[0]), gpgKeyBytes)
+		}
+	}
+
+	err := repo.VerifyRefs(testCtx, refNames, false, WithJobs(4))
+	assert.Nil(t, err)
+
+	// All verified references are cached
+	policyEntry, _, err := rsl.GetLatestReferenceEntryForRef(repo.r, policy.PolicyRef)
+	if err != nil {
+		t.Fatal(err)
+	}
+	verificationCache, err := cache.LoadVerificationCache(repo.r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, refName := range refNames {
+		assert.NotEqual(t, plumbing.ZeroHash, verificationCache.GetVerifiedEntry(policyEntry.ID, refName))
+	}
+
+	// RSL entries for a single reference are verified concurrently
+	err = repo.VerifyRef(testCtx, "refs/heads/main", false, WithJobs(4), WithoutCache())
+	assert.Nil(t, err)
+
+	commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo.r, "refs/heads/main", 1, gpgUnauthorizedKeyBytes)
+	common.CreateTestRSLReferenceEntryCommit(t, repo.r, rsl.NewReferenceEntry("refs/heads/main", commitIDs[0]), gpgUnauthorizedKeyBytes)
+
+	err = repo.VerifyRef(testCtx, "refs/heads/main", false, WithJobs(4), WithoutCache())
+	assert.ErrorIs(t, err, policy.ErrUnauthorizedSignature)
+
+	err = repo.VerifyRefs(testCtx, refNames, false, WithJobs(4), WithoutCache())
+	assert.ErrorIs(t, err, policy.ErrUnauthorizedSignature)
+	assert.ErrorContains(t, err, "refs/heads/main")
+	assert.NotContains(t, err.Error(), "refs/heads/feature")
+
+	// Every reference has a result, whether verified sequentially or
+	// concurrently
+	for _, jobs := range []int{1, 4} {
+		results, err := repo.VerifyRefsWithResults(testCtx, refNames, false, WithJobs(jobs), WithoutCache())
+		assert.Nil(t, err)
+		assert.Len(t, results, len(refNames))
+		for i, result := range results {
+			assert.Equal(t, refNames[i], result.Ref)
+			if refNames[i] == "refs/heads/main" {
+				assert.False(t, result.Verified)
+				assert.ErrorIs(t, result.Err(), policy.ErrUnauthorizedSignature)
+				assert.NotEmpty(t, result.Error)
+			} else {
+				assert.True(t, result.Verified)
+				assert.Nil(t, result.Err())
+				assert.Empty(t, result.Error)
+			}
+		}
+	}
+}
+
+func TestVerifyRefUsingCache(t *testing.T) {
+	refName := "refs/heads/main"
+
+	addEntry := func(t *testing.T, repo *Repository, signingKeyBytes []byte) plumbing.Hash {
+		t.Helper()
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo.r, refName, 1, gpgKeyBytes)
+		return common.CreateTestRSLReferenceEntryCommit(t, repo.r, rsl.NewReferenceEntry(refName, commitIDs[0]), signingKeyBytes)
+	}
+
+	getCachedEntry := func(t *testing.T, repo *Repository) plumbing.Hash {
+		t.Helper()
+
+		policyEntry, _, err := rsl.GetLatestReferenceEntryForRef(repo.r, policy.PolicyRef)
+		if err != nil {
+			t.Fatal(err)
+		}
+		verificationCache, err := cache.LoadVerificationCache(repo.r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return verificationCache.GetVerifiedEntry(policyEntry.ID, refName)
+	}
+
+	t.Run("verified entries are cached", func(t *testing.T) {
+		repo := createTestRepositoryWithPolicy(t, "")
+
+		entryID := addEntry(t, repo, gpgKeyBytes)
+		err := repo.VerifyRef(testCtx, refName, false)
+		assert.Nil(t, err)
+		assert.Equal(t, entryID, getCachedEntry(t, repo))
+
+		// Only new entries are verified
+		entryID = addEntry(t, repo, gpgKeyBytes)
+		err = repo.VerifyRef(testCtx, refName, false)
+		assert.Nil(t, err)
+		assert.Equal(t, entryID, getCachedEntry(t, repo))
+
+		addEntry(t, repo, gpgUnauthorizedKeyBytes)
+		err = repo.VerifyRef(testCtx, refName, false)
+		assert.ErrorIs(t, err, policy.ErrUnauthorizedSignature)
+		assert.Equal(t, entryID, getCachedEntry(t, repo))
+	})
+
+	t.Run("cache not used", func(t *testing.T) {
+		repo := createTestRepositoryWithPolicy(t, "")
+
+		addEntry(t, repo, gpgKeyBytes)
+		err := repo.VerifyRef(testCtx, refName, false, WithoutCache())
+		assert.Nil(t, err)
+		assert.Equal(t, plumbing.ZeroHash, getCachedEntry(t, repo))
+	})
+
+	t.Run("cached entry skipped", func(t *testing.T) {
+		repo := createTestRepositoryWithPolicy(t, "")
+
+		entryID := addEntry(t, repo, gpgKeyBytes)
+		if err := repo.VerifyRef(testCtx, refName, false); err != nil {
+			t.Fatal(err)
+		}
 
+		common.CreateTestRSLAnnotationEntryCommit(t, repo.r, rsl.NewAnnotationEntry([]plumbing.Hash{entryID}, true, "skip"), gpgKeyBytes)
+
+		// The result matches verification without the cache
+		expectedErr := repo.VerifyRef(testCtx, refName, false, WithoutCache())
+		err := repo.VerifyRef(testCtx, refName, false)
+		assert.Equal(t, expectedErr, err)
+	})
+
+	t.Run("cache invalidated by policy change", func(t *testing.T) {
+		repo := createTestRepositoryWithPolicy(t, "")
+
+		addEntry(t, repo, gpgKeyBytes)
+		if err := repo.VerifyRef(testCtx, refName, false); err != nil {
+			t.Fatal(err)
+		}
+
+		targetsSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(targetsKeyBytes) //nolint:staticcheck
+		if err != nil {
+			t.Fatal(err)
+		}
+		gpgKey, err := gpg.LoadGPGKeyFromBytes(gpgPubKeyBytes)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := repo.AddDelegation(testCtx, targetsSigner, policy.TargetsRoleName, "protect-feature", []*tuf.Key{gpgKey}, []string{"git:refs/heads/feature"}, 1, false); err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, plumbing.ZeroHash, getCachedEntry(t, repo))
+
+		entryID := addEntry(t, repo, gpgKeyBytes)
+		err = repo.VerifyRef(testCtx, refName, false)
+		assert.Nil(t, err)
+		assert.Equal(t, entryID, getCachedEntry(t, repo))
+	})
+}
+
+func TestVerifyRefFromEntry(t *testing.T) {
 	repo := createTestRepositoryWithPolicy(t, "")
 
 	refName := "refs/heads/main"
@@ -147,3 +312,220 @@ func TestVerifyRefFromEntry(t *testing.T) {
 	err = repo.VerifyRefFromEntry(testCtx, refName, violatingEntryID.String())
 	assert.ErrorIs(t, err, policy.ErrUnauthorizedSignature)
 }
+
+func TestVerifyRefFromCommit(t *testing.T) {
+	repo := createTestRepositoryWithPolicy(t, "")
+
+	refName := "refs/heads/main"
+	if err := repo.r.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(refName), plumbing.ZeroHash)); err != nil {
+		t.Fatal(err)
+	}
+
+	// Policy violation
+	violatingCommitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo.r, refName, 1, gpgUnauthorizedKeyBytes)
+	entry := rsl.NewReferenceEntry(refName, violatingCommitIDs[0])
+	common.CreateTestRSLReferenceEntryCommit(t, repo.r, entry, gpgUnauthorizedKeyBytes)
+
+	// No policy violation
+	commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo.r, refName, 2, gpgKeyBytes)
+	entry = rsl.NewReferenceEntry(refName, commitIDs[0])
+	common.CreateTestRSLReferenceEntryCommit(t, repo.r, entry, gpgKeyBytes)
+	entry = rsl.NewReferenceEntry(refName, commitIDs[1])
+	common.CreateTestRSLReferenceEntryCommit(t, repo.r, entry, gpgKeyBytes)
+
+	tests := map[string]struct {
+		target     string
+		fromCommit string
+		err        error
+	}{
+		"absolute ref, from non-violating": {
+			target:     "refs/heads/main",
+			fromCommit: commitIDs[0].String(),
+		},
+		"relative ref, from non-violating": {
+			target:     "main",
+			fromCommit: commitIDs[0].String(),
+		},
+		"absolute ref, from violating": {
+			target:     "refs/heads/main",
+			fromCommit: violatingCommitIDs[0].String(),
+			err:        policy.ErrUnauthorizedSignature,
+		},
+		"unknown commit": {
+			target:     "refs/heads/main",
+			fromCommit: plumbing.ZeroHash.String(),
+			err:        plumbing.ErrReferenceNotFound,
+		},
+	}
+
+	for name, test := range tests {
+		err := repo.VerifyRefFromCommit(testCtx, test.target, test.fromCommit)
+		if test.err != nil {
+			assert.ErrorIs(t, err, test.err, fmt.Sprintf("unexpected error in test '%s'", name))
+		} else {
+			assert.Nil(t, err, fmt.Sprintf("unexpected error in test '%s'", name))
+		}
+	}
+}
+
+func TestVerifyRefShallowClone(t *testing.T) {
+	remoteDir := t.TempDir()
+	remoteRepo := createTestRepositoryWithPolicy(t, remoteDir)
+
+	refName := "refs/heads/main"
+	commitIDs := common.AddNTestCommitsToSpecifiedRef(t, remoteRepo.r, refName, 3, gpgKeyBytes)
+	for _, commitID := range commitIDs {
+		common.CreateTestRSLReferenceEntryCommit(t, remoteRepo.r, rsl.NewReferenceEntry(refName, commitID), gpgKeyBytes)
+	}
+	if err := remoteRepo.r.Storer.SetReference(plumbing.NewSymbolicReference(plumbing.HEAD, plumbing.ReferenceName(refName))); err != nil {
+		t.Fatal(err)
+	}
+
+	// Only the latest commit is cloned, while the RSL and policy have
+	// their full history
+	localDir := t.TempDir()
+	for _, args := range [][]string{
+		{"clone", "--quiet", "--bare", "--depth=1", "file://" + remoteDir, localDir},
+		{"--git-dir", localDir, "fetch", "--quiet", "origin", "refs/gittuf/*:refs/gittuf/*"},
+	} {
+		if output, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+			t.Fatalf("%s: %s", err, output)
+		}
+	}
+
+	t.Run("missing objects are reported", func(t *testing.T) {
+		repo, err := LoadRepositoryAt(localDir)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = repo.VerifyRef(testCtx, refName, false, WithoutCache())
+		var missingObjectsErr *gitinterface.MissingObjectsError
+		assert.ErrorAs(t, err, &missingObjectsErr)
+		assert.ElementsMatch(t, commitIDs[:2], missingObjectsErr.ObjectIDs)
+	})
+
+	t.Run("only objects for the latest entry are required", func(t *testing.T) {
+		repo, err := LoadRepositoryAt(localDir)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// The latest entry's changes are identified relative to the
+		// prior entry's commit
+		err = repo.VerifyRef(testCtx, refName, true)
+		var missingObjectsErr *gitinterface.MissingObjectsError
+		assert.ErrorAs(t, err, &missingObjectsErr)
+		assert.Equal(t, []plumbing.Hash{commitIDs[1]}, missingObjectsErr.ObjectIDs)
+	})
+
+	t.Run("missing objects are fetched", func(t *testing.T) {
+		repo, err := LoadRepositoryAt(localDir)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = repo.VerifyRef(testCtx, refName, false, WithoutCache(), WithMissingObjectsFetchedFrom(gitinterface.DefaultRemoteName))
+		assert.Nil(t, err)
+
+		for _, commitID := range commitIDs {
+			_, err := gitinterface.GetCommit(repo.r, commitID)
+			assert.Nil(t, err)
+		}
+	})
+}
+
+func TestVerifyTagRef(t *testing.T) {
+	repo := createTestRepositoryWithPolicy(t, "")
+
+	refName := "refs/heads/main"
+	commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo.r, refName, 1, gpgKeyBytes)
+	common.CreateTestRSLReferenceEntryCommit(t, repo.r, rsl.NewReferenceEntry(refName, commitIDs[0]), gpgKeyBytes)
+
+	tagID := common.CreateTestSignedTag(t, repo.r, "v1", commitIDs[0], gpgKeyBytes)
+	common.CreateTestRSLReferenceEntryCommit(t, repo.r, rsl.NewReferenceEntry("refs/tags/v1", tagID), gpgKeyBytes)
+
+	err := repo.VerifyTagRef(testCtx, "v1")
+	assert.Nil(t, err)
+
+	err = repo.VerifyTagRef(testCtx, "v1", WithJobs(4))
+	assert.Nil(t, err)
+
+	// Tag signed using key not in policy
+	tagID = common.CreateTestSignedTag(t, repo.r, "v2", commitIDs[0], gpgUnauthorizedKeyBytes)
+	common.CreateTestRSLReferenceEntryCommit(t, repo.r, rsl.NewReferenceEntry("refs/tags/v2", tagID), gpgUnauthorizedKeyBytes)
+
+	err = repo.VerifyTagRef(testCtx, "v2")
+	assert.ErrorIs(t, err, policy.ErrUnauthorizedSignature)
+
+	err = repo.VerifyTagRef(testCtx, "main")
+	assert.ErrorIs(t, err, policy.ErrNotTag)
+
+	// Provenance is required
+	builderID := "https://example.com/builder"
+	sourceURI := "https://example.com/repo"
+
+	err = repo.VerifyTagRef(testCtx, "v1", WithProvenance([]string{builderID}, sourceURI))
+	assert.ErrorIs(t, err, attestations.ErrProvenanceNotFound)
+
+	if err := repo.AddProvenance("v1", createProvenanceEnvelopeBytes(t, builderID, "git+"+sourceURI+"@refs/tags/v1", commitIDs[0].String()), false); err != nil {
+		t.Fatal(err)
+	}
+
+	err = repo.VerifyTagRef(testCtx, "v1", WithProvenance([]string{builderID}, sourceURI))
+	assert.Nil(t, err)
+
+	err = repo.VerifyTagRef(testCtx, "v1", WithProvenance([]string{"https://example.com/other-builder"}, sourceURI))
+	assert.ErrorIs(t, err, policy.ErrProvenanceBuilderNotTrusted)
+
+	// The source is compared with the origin remote if unspecified
+	if _, err := repo.r.CreateRemote(&config.RemoteConfig{Name: gitinterface.DefaultRemoteName, URLs: []string{"https://example.com/other-repo"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	err = repo.VerifyTagRef(testCtx, "v1", WithProvenance([]string{builderID}, ""))
+	assert.ErrorIs(t, err, policy.ErrProvenanceSourceMismatch)
+}
+
+func TestVerifyOrganizationRoot(t *testing.T) {
+	organizationDir := t.TempDir()
+	organizationRepo, keyBytes := createTestRepositoryWithRoot(t, organizationDir)
+	repo, _ := createTestRepositoryWithRoot(t, "")
+
+	rootSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(keyBytes) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+	rootKey, err := tuf.LoadKeyFromBytes(rootPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, err := tuf.LoadKeyFromBytes(targetsPubKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = repo.VerifyOrganizationRoot(testCtx)
+	assert.ErrorIs(t, err, policy.ErrNoOrganizationRoot)
+
+	if err := repo.SetOrganizationRoot(testCtx, rootSigner, organizationDir, "repository", false); err != nil {
+		t.Fatal(err)
+	}
+
+	err = repo.VerifyOrganizationRoot(testCtx)
+	assert.ErrorIs(t, err, policy.ErrRepositoryRootNil)
+
+	if err := organizationRepo.AddRepositoryRoot(testCtx, rootSigner, "repository", []*tuf.Key{rootKey}, 1, false); err != nil {
+		t.Fatal(err)
+	}
+
+	err = repo.VerifyOrganizationRoot(testCtx)
+	assert.Nil(t, err)
+
+	if err := organizationRepo.AddRepositoryRoot(testCtx, rootSigner, "repository", []*tuf.Key{otherKey}, 1, false); err != nil {
+		t.Fatal(err)
+	}
+
+	err = repo.VerifyOrganizationRoot(testCtx)
+	assert.ErrorIs(t, err, policy.ErrUntrustedByOrganization)
+}
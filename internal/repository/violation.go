@@ -0,0 +1,60 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"fmt"
+	"log/slog"
+
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+// ViolatingEntry is an RSL entry that failed verification.
+type ViolatingEntry struct {
+	ID       plumbing.Hash
+	RefName  string
+	TargetID plumbing.Hash
+
+	// SignerName and SignerEmail identify who recorded the entry, i.e., the
+	// committer of the entry's commit.
+	SignerName  string
+	SignerEmail string
+
+	// Signed indicates if the entry's commit is signed.
+	Signed bool
+
+	// Err is the reason the entry failed verification.
+	Err error
+}
+
+// GetViolatingEntries returns the RSL entries that failed verification
+// according to err, such as the error returned when verifying a reference.
+// Failures that are not caused by a specific RSL entry are not included.
+func (r *Repository) GetViolatingEntries(err error) []*ViolatingEntry {
+	entryErrs := policy.GetEntryVerificationErrors(err)
+
+	violatingEntries := make([]*ViolatingEntry, 0, len(entryErrs))
+	for _, entryErr := range entryErrs {
+		violatingEntry := &ViolatingEntry{
+			ID:       entryErr.Entry.ID,
+			RefName:  entryErr.Entry.RefName,
+			TargetID: entryErr.Entry.TargetID,
+			Err:      entryErr.Err,
+		}
+
+		commit, err := gitinterface.GetCommit(r.r, entryErr.Entry.ID)
+		if err == nil {
+			violatingEntry.SignerName = commit.Committer.Name
+			violatingEntry.SignerEmail = commit.Committer.Email
+			violatingEntry.Signed = commit.PGPSignature != ""
+		} else {
+			slog.Debug(fmt.Sprintf("Unable to load commit for RSL entry '%s': %s", entryErr.Entry.ID.String(), err.Error()))
+		}
+
+		violatingEntries = append(violatingEntries, violatingEntry)
+	}
+
+	return violatingEntries
+}
@@ -0,0 +1,41 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/common"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetViolatingEntries(t *testing.T) {
+	refName := "refs/heads/main"
+
+	repo := createTestRepositoryWithPolicy(t, "")
+	if err := repo.r.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(refName), plumbing.ZeroHash)); err != nil {
+		t.Fatal(err)
+	}
+
+	commitIDs := common.AddNTestCommitsToSpecifiedRef(t, repo.r, refName, 1, gpgUnauthorizedKeyBytes)
+	entryID := common.CreateTestRSLReferenceEntryCommit(t, repo.r, rsl.NewReferenceEntry(refName, commitIDs[0]), gpgUnauthorizedKeyBytes)
+
+	err := repo.VerifyRef(testCtx, refName, false)
+	assert.ErrorIs(t, err, policy.ErrUnauthorizedSignature)
+
+	violatingEntries := repo.GetViolatingEntries(fmt.Errorf("unable to verify '%s': %w", refName, err))
+	if assert.Len(t, violatingEntries, 1) {
+		assert.Equal(t, entryID, violatingEntries[0].ID)
+		assert.Equal(t, refName, violatingEntries[0].RefName)
+		assert.Equal(t, commitIDs[0], violatingEntries[0].TargetID)
+		assert.NotEmpty(t, violatingEntries[0].SignerEmail)
+		assert.True(t, violatingEntries[0].Signed)
+		assert.ErrorIs(t, violatingEntries[0].Err, policy.ErrUnauthorizedSignature)
+	}
+
+	assert.Empty(t, repo.GetViolatingEntries(ErrRefStateDoesNotMatchRSL))
+}
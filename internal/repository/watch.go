@@ -0,0 +1,132 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+
+	"github.com/gittuf/gittuf/internal/attestations"
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+var (
+	ErrRemoteHasNoRSL       = errors.New("remote does not have an RSL")
+	ErrRefMissingFromRemote = errors.New("reference recorded in the remote's RSL is missing from the remote")
+)
+
+// watchedRemoteRefTemplate is the template for the remote tracking references
+// that the references of a watched remote are fetched into.
+const watchedRemoteRefTemplate = "refs/remotes/%s/gittuf-watch/%s"
+
+// ListRemoteRefs returns the tips of the references advertised by the remote.
+func (r *Repository) ListRemoteRefs(ctx context.Context, remoteName string) (map[string]plumbing.Hash, error) {
+	return gitinterface.ListRemoteRefs(ctx, r.r, remoteName)
+}
+
+// VerifyRemoteRefs verifies the state of the remote, where remoteRefs maps the
+// references advertised by the remote to their tips, see ListRemoteRefs. Every
+// reference recorded in the remote's RSL is verified using the remote's RSL
+// and policy, and must match its latest entry in the remote's RSL. The objects
+// required for verification are fetched into remote tracking references
+// dedicated to watching the remote, and the local references are not
+// modified.
+func (r *Repository) VerifyRemoteRefs(ctx context.Context, remoteName string, remoteRefs map[string]plumbing.Hash, opts ...VerifyRefOption) error {
+	if _, has := remoteRefs[rsl.Ref]; !has {
+		return ErrRemoteHasNoRSL
+	}
+
+	refs := map[string]plumbing.Hash{}
+	for _, gittufRef := range []string{rsl.Ref, policy.PolicyRef, attestations.Ref} {
+		if tip, has := remoteRefs[gittufRef]; has {
+			refs[gittufRef] = tip
+		}
+	}
+
+	slog.Debug(fmt.Sprintf("Fetching gittuf references from '%s'...", remoteName))
+	if err := r.fetchWatchedRefs(ctx, remoteName, refs); err != nil {
+		return err
+	}
+
+	// Identify the references recorded in the remote's RSL
+	remoteView, err := r.ViewRemote(remoteRefs)
+	if err != nil {
+		return err
+	}
+	entries, annotations, err := remoteView.GetRSLReferenceEntries("", 0)
+	if err != nil {
+		return err
+	}
+
+	recorded := map[string]bool{}
+	missingErrs := []error{}
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.RefName, rsl.GittufNamespacePrefix) || recorded[entry.RefName] {
+			continue
+		}
+		if entry.SkippedBy(annotations[entry.ID]) {
+			continue
+		}
+		recorded[entry.RefName] = true
+
+		tip, has := remoteRefs[entry.RefName]
+		switch {
+		case has:
+			refs[entry.RefName] = tip
+		case !entry.IsDeletion():
+			missingErrs = append(missingErrs, fmt.Errorf("%w: '%s'", ErrRefMissingFromRemote, entry.RefName))
+		}
+	}
+
+	slog.Debug(fmt.Sprintf("Fetching references recorded in RSL from '%s'...", remoteName))
+	if err := r.fetchWatchedRefs(ctx, remoteName, refs); err != nil {
+		return err
+	}
+
+	return errors.Join(append(missingErrs, r.VerifyFetchedRefs(ctx, refs, opts...))...)
+}
+
+// ViewRemote returns a read-only view of the repository in which the RSL is the
+// remote's RSL, where remoteRefs maps the references advertised by the remote
+// to their tips, see ListRemoteRefs. The view can be used to inspect the
+// remote's RSL and policy once they have been fetched, such as by
+// VerifyRemoteRefs.
+func (r *Repository) ViewRemote(remoteRefs map[string]plumbing.Hash) (*Repository, error) {
+	remoteRSLTip, has := remoteRefs[rsl.Ref]
+	if !has {
+		return nil, ErrRemoteHasNoRSL
+	}
+
+	remoteRepo, err := gitinterface.NewRepositoryWithProposedUpdates(r.r, nil, []*gitinterface.ReferenceUpdate{{RefName: rsl.Ref, NewID: remoteRSLTip}})
+	if err != nil {
+		return nil, err
+	}
+
+	return &Repository{r: remoteRepo}, nil
+}
+
+// fetchWatchedRefs fetches the references from the remote into the remote
+// tracking references dedicated to watching the remote.
+func (r *Repository) fetchWatchedRefs(ctx context.Context, remoteName string, refs map[string]plumbing.Hash) error {
+	refNames := make([]string, 0, len(refs))
+	for refName := range refs {
+		refNames = append(refNames, refName)
+	}
+	sort.Strings(refNames)
+
+	refSpecs := make([]config.RefSpec, 0, len(refNames))
+	for _, refName := range refNames {
+		trackerRef := fmt.Sprintf(watchedRemoteRefTemplate, remoteName, strings.TrimPrefix(refName, "refs/"))
+		refSpecs = append(refSpecs, config.RefSpec(fmt.Sprintf("+%s:%s", refName, trackerRef)))
+	}
+
+	return gitinterface.FetchRefSpec(ctx, r.r, remoteName, refSpecs)
+}
@@ -0,0 +1,108 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package repository
+
+import (
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/common"
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVerifyRemoteRefs(t *testing.T) {
+	remoteName := "origin"
+	refName := "refs/heads/main"
+
+	setup := func(t *testing.T) (*Repository, *git.Repository) {
+		t.Helper()
+
+		remoteTmpDir := t.TempDir()
+		remoteRepo, err := git.PlainInit(remoteTmpDir, true)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		localRepo := createTestRepositoryWithPolicy(t, "")
+		if _, err := localRepo.r.CreateRemote(&config.RemoteConfig{
+			Name: remoteName,
+			URLs: []string{remoteTmpDir},
+		}); err != nil {
+			t.Fatal(err)
+		}
+
+		commitIDs := common.AddNTestCommitsToSpecifiedRef(t, localRepo.r, refName, 1, gpgKeyBytes)
+		common.CreateTestRSLReferenceEntryCommit(t, localRepo.r, rsl.NewReferenceEntry(refName, commitIDs[0]), gpgKeyBytes)
+		if err := gitinterface.Push(testCtx, localRepo.r, remoteName, []string{refName, rsl.Ref, policy.PolicyRef}); err != nil {
+			t.Fatal(err)
+		}
+
+		return localRepo, remoteRepo
+	}
+
+	t.Run("valid remote state", func(t *testing.T) {
+		localRepo, _ := setup(t)
+
+		localTip, err := gitinterface.GetTip(localRepo.r, refName)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		remoteRefs, err := localRepo.ListRemoteRefs(testCtx, remoteName)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = localRepo.VerifyRemoteRefs(testCtx, remoteName, remoteRefs)
+		assert.Nil(t, err)
+
+		// The local reference is not modified
+		tip, err := gitinterface.GetTip(localRepo.r, refName)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, localTip, tip)
+	})
+
+	t.Run("reference moved without RSL entry", func(t *testing.T) {
+		localRepo, remoteRepo := setup(t)
+
+		common.AddNTestCommitsToSpecifiedRef(t, remoteRepo, refName, 1, gpgKeyBytes)
+
+		remoteRefs, err := localRepo.ListRemoteRefs(testCtx, remoteName)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = localRepo.VerifyRemoteRefs(testCtx, remoteName, remoteRefs)
+		assert.ErrorIs(t, err, ErrRefStateDoesNotMatchRSL)
+	})
+
+	t.Run("reference deleted without RSL entry", func(t *testing.T) {
+		localRepo, remoteRepo := setup(t)
+
+		if err := remoteRepo.Storer.RemoveReference(plumbing.ReferenceName(refName)); err != nil {
+			t.Fatal(err)
+		}
+
+		remoteRefs, err := localRepo.ListRemoteRefs(testCtx, remoteName)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = localRepo.VerifyRemoteRefs(testCtx, remoteName, remoteRefs)
+		assert.ErrorIs(t, err, ErrRefMissingFromRemote)
+	})
+
+	t.Run("remote without RSL", func(t *testing.T) {
+		localRepo, _ := setup(t)
+
+		err := localRepo.VerifyRemoteRefs(testCtx, remoteName, map[string]plumbing.Hash{})
+		assert.ErrorIs(t, err, ErrRemoteHasNoRSL)
+	})
+}
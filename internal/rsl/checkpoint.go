@@ -0,0 +1,343 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package rsl
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/gittuf/gittuf/internal/signerverifier/dsse"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/filemode"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	sslibdsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
+)
+
+const (
+	CheckpointEntryHeader = "RSL Checkpoint Entry"
+	ArchiveIDKey          = "archiveID"
+
+	// ArchiveRefPrefix is the prefix of the refs that retain the RSL entries
+	// sealed by a checkpoint. The archive ref for a checkpoint is the prefix
+	// followed by the checkpoint's archive ID.
+	ArchiveRefPrefix = "refs/gittuf/reference-state-log-archive/"
+
+	checkpointEnvelopeTreeEntryName = "checkpoint.json"
+)
+
+var (
+	ErrRSLChanged                 = errors.New("RSL has changed since checkpoint was created")
+	ErrCheckpointNotSigned        = errors.New("checkpoint has not been signed")
+	ErrCheckpointPayloadMismatch  = errors.New("checkpoint's signed payload does not match checkpoint entry")
+	ErrCheckpointMissingPolicyRef = errors.New("checkpoint does not record the policy reference")
+)
+
+// CheckpointEntry is a type of RSL record that seals all prior RSL entries. It
+// records the latest unskipped target of every reference at the time it was
+// created and is always the first entry in the RSL it belongs to. The sealed
+// entries are retained in an archive ref. A checkpoint is signed using a DSSE
+// envelope stored in the entry's tree, so that its trustworthiness can be
+// established using the keys of the policy it records. It implements the Entry
+// interface.
+type CheckpointEntry struct {
+	// ID contains the Git hash for the commit corresponding to the checkpoint.
+	ID plumbing.Hash
+
+	// ArchiveID contains the Git hash for the latest RSL entry sealed by the
+	// checkpoint.
+	ArchiveID plumbing.Hash
+
+	// References maps each reference to the latest unskipped target recorded
+	// for it in the sealed entries.
+	References map[string]plumbing.Hash
+
+	// Envelope contains the signatures for the checkpoint.
+	Envelope *sslibdsse.Envelope
+}
+
+// checkpointPayload is the signed representation of a checkpoint.
+type checkpointPayload struct {
+	ArchiveID  string            `json:"archiveID"`
+	References map[string]string `json:"references"`
+}
+
+// NewCheckpointEntry returns a CheckpointEntry that seals the RSL up to and
+// including archiveID.
+func NewCheckpointEntry(archiveID plumbing.Hash, references map[string]plumbing.Hash) *CheckpointEntry {
+	return &CheckpointEntry{ArchiveID: archiveID, References: references}
+}
+
+func (c *CheckpointEntry) GetID() plumbing.Hash {
+	return c.ID
+}
+
+// Sign adds a signature using signer to the checkpoint's envelope.
+func (c *CheckpointEntry) Sign(ctx context.Context, signer sslibdsse.SignerVerifier) error {
+	if c.Envelope == nil {
+		env, err := dsse.CreateEnvelope(c.payload())
+		if err != nil {
+			return err
+		}
+		c.Envelope = env
+	}
+
+	env, err := dsse.SignEnvelope(ctx, c.Envelope, signer)
+	if err != nil {
+		return err
+	}
+	c.Envelope = env
+
+	return nil
+}
+
+// VerifyPayload checks that the payload of the checkpoint's envelope matches
+// the checkpoint entry. It does not verify the envelope's signatures.
+func (c *CheckpointEntry) VerifyPayload() error {
+	if c.Envelope == nil {
+		return ErrCheckpointNotSigned
+	}
+
+	payloadBytes, err := base64.StdEncoding.DecodeString(c.Envelope.Payload)
+	if err != nil {
+		return err
+	}
+
+	signedPayload := &checkpointPayload{}
+	if err := json.Unmarshal(payloadBytes, signedPayload); err != nil {
+		return errors.Join(ErrCheckpointPayloadMismatch, err)
+	}
+
+	expectedPayload := c.payload()
+	if signedPayload.ArchiveID != expectedPayload.ArchiveID || len(signedPayload.References) != len(expectedPayload.References) {
+		return ErrCheckpointPayloadMismatch
+	}
+	for refName, targetID := range expectedPayload.References {
+		if signedPayload.References[refName] != targetID {
+			return ErrCheckpointPayloadMismatch
+		}
+	}
+
+	return nil
+}
+
+// Commit creates the checkpoint in the RSL. The entries currently in the RSL
+// are moved to the checkpoint's archive ref, and the RSL is replaced with the
+// checkpoint as its only entry. The checkpoint must have been created for the
+// current tip of the RSL and must be signed.
+func (c *CheckpointEntry) Commit(repo *git.Repository, sign bool) error {
+	if c.Envelope == nil {
+		return ErrCheckpointNotSigned
+	}
+	if _, has := c.References[gittufPolicyRef]; !has {
+		return ErrCheckpointMissingPolicyRef
+	}
+
+	ref, err := repo.Reference(plumbing.ReferenceName(Ref), true)
+	if err != nil {
+		return err
+	}
+	if ref.Hash() != c.ArchiveID {
+		return ErrRSLChanged
+	}
+
+	envBytes, err := json.Marshal(c.Envelope)
+	if err != nil {
+		return err
+	}
+	blobID, err := gitinterface.WriteBlob(repo, envBytes)
+	if err != nil {
+		return err
+	}
+	treeID, err := gitinterface.WriteTree(repo, []object.TreeEntry{{Name: checkpointEnvelopeTreeEntryName, Mode: filemode.Regular, Hash: blobID}})
+	if err != nil {
+		return err
+	}
+
+	message, _ := c.createCommitMessage() // we have an error return for annotations, always nil here
+
+	archiveRef := plumbing.NewHashReference(plumbing.ReferenceName(ArchiveRefPrefix+c.ArchiveID.String()), c.ArchiveID)
+	if err := repo.Storer.SetReference(archiveRef); err != nil {
+		return err
+	}
+
+	// Reset the RSL so that the checkpoint is created without a parent
+	if err := repo.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(Ref), plumbing.ZeroHash)); err != nil {
+		return err
+	}
+
+	c.ID, err = gitinterface.Commit(repo, treeID, Ref, message, sign)
+	if err != nil {
+		// Restore the RSL's prior state
+		return errors.Join(err, repo.Storer.SetReference(ref))
+	}
+
+	return nil
+}
+
+// ReferenceEntryFor returns a reference entry that records the checkpoint's
+// target for refName. The returned entry has the checkpoint's ID. If the
+// checkpoint does not record refName, nil is returned.
+func (c *CheckpointEntry) ReferenceEntryFor(refName string) *ReferenceEntry {
+	targetID, has := c.References[refName]
+	if !has {
+		return nil
+	}
+
+	return &ReferenceEntry{ID: c.ID, RefName: refName, TargetID: targetID}
+}
+
+// ReferenceEntries returns a reference entry for every reference recorded in
+// the checkpoint. The entry for the policy reference is listed first, followed
+// by the rest in lexical order of their reference names.
+func (c *CheckpointEntry) ReferenceEntries() []*ReferenceEntry {
+	entries := []*ReferenceEntry{}
+	for _, refName := range c.refNames() {
+		entries = append(entries, c.ReferenceEntryFor(refName))
+	}
+	return entries
+}
+
+func (c *CheckpointEntry) refNames() []string {
+	refNames := []string{}
+	for refName := range c.References {
+		if refName != gittufPolicyRef {
+			refNames = append(refNames, refName)
+		}
+	}
+	sort.Strings(refNames)
+
+	if _, has := c.References[gittufPolicyRef]; has {
+		refNames = append([]string{gittufPolicyRef}, refNames...)
+	}
+	return refNames
+}
+
+func (c *CheckpointEntry) payload() *checkpointPayload {
+	payload := &checkpointPayload{
+		ArchiveID:  c.ArchiveID.String(),
+		References: map[string]string{},
+	}
+	for refName, targetID := range c.References {
+		payload.References[refName] = targetID.String()
+	}
+	return payload
+}
+
+func (c *CheckpointEntry) createCommitMessage() (string, error) {
+	lines := []string{
+		CheckpointEntryHeader,
+		"",
+		fmt.Sprintf("%s: %s", ArchiveIDKey, c.ArchiveID.String()),
+	}
+	for _, refName := range c.refNames() {
+		lines = append(lines, fmt.Sprintf("%s: %s", RefKey, refName))
+		lines = append(lines, fmt.Sprintf("%s: %s", TargetIDKey, c.References[refName].String()))
+	}
+	return strings.Join(lines, "\n"), nil
+}
+
+// GetLatestUnskippedTargets returns the latest target recorded for every
+// reference in the RSL that has not been skipped by an annotation.
+func GetLatestUnskippedTargets(repo *git.Repository) (map[string]plumbing.Hash, error) {
+	iterator, err := GetLatestEntry(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	targets := map[string]plumbing.Hash{}
+	allAnnotations := []*AnnotationEntry{}
+	for {
+		switch entry := iterator.(type) {
+		case *ReferenceEntry:
+			if _, has := targets[entry.RefName]; !has && !entry.SkippedBy(allAnnotations) {
+				targets[entry.RefName] = entry.TargetID
+			}
+		case *AnnotationEntry:
+			allAnnotations = append(allAnnotations, entry)
+		case *CheckpointEntry:
+			for _, refEntry := range entry.ReferenceEntries() {
+				if _, has := targets[refEntry.RefName]; !has && !refEntry.SkippedBy(allAnnotations) {
+					targets[refEntry.RefName] = refEntry.TargetID
+				}
+			}
+		}
+
+		iterator, err = GetParentForEntry(repo, iterator)
+		if err != nil {
+			if errors.Is(err, ErrRSLEntryNotFound) {
+				return targets, nil
+			}
+			return nil, err
+		}
+	}
+}
+
+func parseCheckpointEntryText(id plumbing.Hash, text string) (*CheckpointEntry, error) {
+	lines := strings.Split(text, "\n")
+	if len(lines) < 3 {
+		return nil, ErrInvalidRSLEntry
+	}
+	lines = lines[2:]
+
+	checkpoint := &CheckpointEntry{ID: id, References: map[string]plumbing.Hash{}}
+	refName := ""
+	for _, l := range lines {
+		l = strings.TrimSpace(l)
+
+		ls := strings.Split(l, ":")
+		if len(ls) < 2 {
+			return nil, ErrInvalidRSLEntry
+		}
+
+		switch strings.TrimSpace(ls[0]) {
+		case ArchiveIDKey:
+			checkpoint.ArchiveID = plumbing.NewHash(strings.TrimSpace(ls[1]))
+		case RefKey:
+			refName = strings.TrimSpace(ls[1])
+		case TargetIDKey:
+			if refName == "" {
+				return nil, ErrInvalidRSLEntry
+			}
+			checkpoint.References[refName] = plumbing.NewHash(strings.TrimSpace(ls[1]))
+			refName = ""
+		}
+	}
+
+	return checkpoint, nil
+}
+
+// loadCheckpointEnvelope reads the checkpoint's envelope from the tree of its
+// commit.
+func loadCheckpointEnvelope(repo *git.Repository, commitObj *object.Commit, checkpoint *CheckpointEntry) error {
+	tree, err := gitinterface.GetTree(repo, commitObj.TreeHash)
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range tree.Entries {
+		if entry.Name != checkpointEnvelopeTreeEntryName {
+			continue
+		}
+
+		contents, err := gitinterface.ReadBlob(repo, entry.Hash)
+		if err != nil {
+			return err
+		}
+
+		env := &sslibdsse.Envelope{}
+		if err := json.Unmarshal(contents, env); err != nil {
+			return err
+		}
+		checkpoint.Envelope = env
+		return nil
+	}
+
+	return ErrCheckpointNotSigned
+}
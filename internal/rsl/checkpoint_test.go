@@ -0,0 +1,186 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package rsl
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/gittuf/gittuf/internal/signerverifier"
+	artifacts "github.com/gittuf/gittuf/internal/testartifacts"
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckpointEntry(t *testing.T) {
+	signer, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(artifacts.SSLibKey1Private) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := InitializeNamespace(repo); err != nil {
+		t.Fatal(err)
+	}
+
+	emptyTreeHash, err := gitinterface.WriteTree(repo, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	policyID := plumbing.NewHash("1111111111111111111111111111111111111111")
+	mainID := plumbing.NewHash("2222222222222222222222222222222222222222")
+	featureID := plumbing.NewHash("3333333333333333333333333333333333333333")
+
+	for _, entry := range []*ReferenceEntry{
+		NewReferenceEntry(gittufPolicyRef, policyID),
+		NewReferenceEntry("refs/heads/main", emptyTreeHash),
+		NewReferenceEntry("refs/heads/feature", featureID),
+		NewReferenceEntry("refs/heads/main", mainID),
+	} {
+		if err := entry.Commit(repo, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Skip the latest entry for main
+	latestEntry, err := GetLatestEntry(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := NewAnnotationEntry([]plumbing.Hash{latestEntry.GetID()}, true, "skip").Commit(repo, false); err != nil {
+		t.Fatal(err)
+	}
+	latestEntry, err = GetLatestEntry(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	references, err := GetLatestUnskippedTargets(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, map[string]plumbing.Hash{
+		gittufPolicyRef:      policyID,
+		"refs/heads/main":    emptyTreeHash,
+		"refs/heads/feature": featureID,
+	}, references)
+
+	checkpoint := NewCheckpointEntry(latestEntry.GetID(), references)
+
+	t.Run("unsigned checkpoint", func(t *testing.T) {
+		err := checkpoint.Commit(repo, false)
+		assert.ErrorIs(t, err, ErrCheckpointNotSigned)
+	})
+
+	if err := checkpoint.Sign(context.Background(), signer); err != nil {
+		t.Fatal(err)
+	}
+	assert.Nil(t, checkpoint.VerifyPayload())
+
+	t.Run("RSL changed", func(t *testing.T) {
+		staleCheckpoint := NewCheckpointEntry(plumbing.ZeroHash, references)
+		if err := staleCheckpoint.Sign(context.Background(), signer); err != nil {
+			t.Fatal(err)
+		}
+
+		err := staleCheckpoint.Commit(repo, false)
+		assert.ErrorIs(t, err, ErrRSLChanged)
+	})
+
+	if err := checkpoint.Commit(repo, false); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("archive retained", func(t *testing.T) {
+		archiveRef, err := repo.Reference(plumbing.ReferenceName(ArchiveRefPrefix+latestEntry.GetID().String()), true)
+		assert.Nil(t, err)
+		assert.Equal(t, latestEntry.GetID(), archiveRef.Hash())
+	})
+
+	t.Run("load checkpoint", func(t *testing.T) {
+		entry, err := GetLatestEntry(repo)
+		assert.Nil(t, err)
+
+		loadedCheckpoint, isCheckpoint := entry.(*CheckpointEntry)
+		assert.True(t, isCheckpoint)
+		assert.Equal(t, checkpoint.ID, loadedCheckpoint.ID)
+		assert.Equal(t, latestEntry.GetID(), loadedCheckpoint.ArchiveID)
+		assert.Equal(t, references, loadedCheckpoint.References)
+		assert.Equal(t, checkpoint.Envelope, loadedCheckpoint.Envelope)
+		assert.Nil(t, loadedCheckpoint.VerifyPayload())
+
+		_, err = GetParentForEntry(repo, loadedCheckpoint)
+		assert.ErrorIs(t, err, ErrRSLEntryNotFound)
+	})
+
+	t.Run("tampered checkpoint", func(t *testing.T) {
+		tamperedCheckpoint := NewCheckpointEntry(checkpoint.ArchiveID, map[string]plumbing.Hash{gittufPolicyRef: policyID, "refs/heads/main": mainID})
+		tamperedCheckpoint.Envelope = checkpoint.Envelope
+
+		err := tamperedCheckpoint.VerifyPayload()
+		assert.ErrorIs(t, err, ErrCheckpointPayloadMismatch)
+	})
+
+	if err := NewReferenceEntry("refs/heads/main", mainID).Commit(repo, false); err != nil {
+		t.Fatal(err)
+	}
+	latestMainEntry, err := GetLatestEntry(repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("walk RSL with checkpoint", func(t *testing.T) {
+		firstEntry, _, err := GetFirstEntry(repo)
+		assert.Nil(t, err)
+		assert.Equal(t, &ReferenceEntry{ID: checkpoint.ID, RefName: gittufPolicyRef, TargetID: policyID}, firstEntry)
+
+		entry, _, err := GetLatestReferenceEntryForRef(repo, "refs/heads/feature")
+		assert.Nil(t, err)
+		assert.Equal(t, &ReferenceEntry{ID: checkpoint.ID, RefName: "refs/heads/feature", TargetID: featureID}, entry)
+
+		entry, _, err = GetLatestReferenceEntryForRefBefore(repo, "refs/heads/main", latestMainEntry.GetID())
+		assert.Nil(t, err)
+		assert.Equal(t, &ReferenceEntry{ID: checkpoint.ID, RefName: "refs/heads/main", TargetID: emptyTreeHash}, entry)
+
+		_, _, err = GetLatestReferenceEntryForRef(repo, "refs/heads/unknown")
+		assert.ErrorIs(t, err, ErrRSLEntryNotFound)
+
+		entries, _, err := GetReferenceEntriesInRangeForRef(repo, checkpoint.ID, latestMainEntry.GetID(), "refs/heads/main")
+		assert.Nil(t, err)
+		assert.Equal(t, []*ReferenceEntry{
+			{ID: checkpoint.ID, RefName: gittufPolicyRef, TargetID: policyID},
+			{ID: checkpoint.ID, RefName: "refs/heads/main", TargetID: emptyTreeHash},
+			{ID: latestMainEntry.GetID(), RefName: "refs/heads/main", TargetID: mainID},
+		}, entries)
+	})
+}
+
+func TestCheckpointEntryCreateCommitMessage(t *testing.T) {
+	checkpoint := NewCheckpointEntry(plumbing.NewHash("abcdef1234567890abcdef1234567890abcdef12"), map[string]plumbing.Hash{
+		"refs/heads/main": plumbing.NewHash("1111111111111111111111111111111111111111"),
+		gittufPolicyRef:   plumbing.NewHash("2222222222222222222222222222222222222222"),
+	})
+
+	expectedMessage := `RSL Checkpoint Entry
+
+archiveID: abcdef1234567890abcdef1234567890abcdef12
+ref: refs/gittuf/policy
+targetID: 2222222222222222222222222222222222222222
+ref: refs/heads/main
+targetID: 1111111111111111111111111111111111111111`
+
+	message, err := checkpoint.createCommitMessage()
+	assert.Nil(t, err)
+	assert.Equal(t, expectedMessage, message)
+
+	entry, err := parseRSLEntryText(checkpoint.ID, message)
+	assert.Nil(t, err)
+	assert.Equal(t, checkpoint, entry)
+}
@@ -0,0 +1,218 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package rsl
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+// IntegritySeverity indicates how serious an integrity finding is. Errors are
+// corrupt or malformed entries that prevent the RSL from being used, while
+// warnings are unexpected but do not prevent verification.
+type IntegritySeverity string
+
+const (
+	IntegrityError   IntegritySeverity = "error"
+	IntegrityWarning IntegritySeverity = "warning"
+)
+
+// Identifiers for each of the checks performed by CheckIntegrity.
+const (
+	IntegrityCheckMissingEntry      = "missing-entry"
+	IntegrityCheckBranch            = "branch"
+	IntegrityCheckInvalidFormat     = "invalid-format"
+	IntegrityCheckUnexpectedTree    = "unexpected-tree"
+	IntegrityCheckAnnotationTarget  = "annotation-target"
+	IntegrityCheckCheckpoint        = "checkpoint"
+	IntegrityCheckUnsignedEntry     = "unsigned-entry"
+	IntegrityCheckTimestampOrder    = "timestamp-order"
+	IntegrityCheckVerificationCache = "verification-cache"
+)
+
+// IntegrityFinding records a problem identified in the RSL.
+type IntegrityFinding struct {
+	Severity IntegritySeverity `json:"severity"`
+	Check    string            `json:"check"`
+	EntryID  string            `json:"rslEntry,omitempty"`
+	Message  string            `json:"message"`
+}
+
+// IntegrityReport records the results of checking the RSL's integrity.
+type IntegrityReport struct {
+	EntriesChecked int                 `json:"entriesChecked"`
+	Findings       []*IntegrityFinding `json:"findings"`
+
+	// RebuiltIndexes lists the indexes derived from the RSL that were
+	// rebuilt, if requested.
+	RebuiltIndexes []string `json:"rebuiltIndexes,omitempty"`
+}
+
+// ErrorCount returns the number of findings that are errors.
+func (r *IntegrityReport) ErrorCount() int {
+	count := 0
+	for _, finding := range r.Findings {
+		if finding.Severity == IntegrityError {
+			count++
+		}
+	}
+	return count
+}
+
+func (r *IntegrityReport) addFinding(severity IntegritySeverity, check string, entryID plumbing.Hash, message string) {
+	finding := &IntegrityFinding{Severity: severity, Check: check, Message: message}
+	if !entryID.IsZero() {
+		finding.EntryID = entryID.String()
+	}
+	r.Findings = append(r.Findings, finding)
+}
+
+// CheckIntegrity validates the structure of the RSL, walking back from its
+// latest entry. It checks that every entry exists, has a single parent, and is
+// correctly formatted; that annotations refer to prior reference entries; that
+// checkpoints are the first entry and match their signed payload; that entries
+// are signed; and that entries are not timestamped before their parents. The
+// walk continues past entries that cannot be parsed so that every corrupt
+// entry is reported. Signatures are not verified against policy.
+func CheckIntegrity(repo *git.Repository) (*IntegrityReport, error) {
+	report := &IntegrityReport{Findings: []*IntegrityFinding{}}
+
+	ref, err := repo.Reference(plumbing.ReferenceName(Ref), true)
+	if err != nil {
+		return nil, err
+	}
+
+	// Entries are collected from the latest to the earliest
+	entries := []Entry{}
+	hasCheckpoint := false
+
+	var child *object.Commit
+	currentID := ref.Hash()
+	for !currentID.IsZero() {
+		entryCommit, err := gitinterface.GetCommit(repo, currentID)
+		if err != nil {
+			report.addFinding(IntegrityError, IntegrityCheckMissingEntry, currentID, fmt.Sprintf("entry cannot be loaded: %s", err.Error()))
+			break
+		}
+		report.EntriesChecked++
+
+		if child != nil && child.Committer.When.Before(entryCommit.Committer.When) {
+			report.addFinding(IntegrityWarning, IntegrityCheckTimestampOrder, child.Hash, fmt.Sprintf("entry is timestamped before its parent '%s'", entryCommit.Hash.String()))
+		}
+
+		if entryCommit.PGPSignature == "" {
+			report.addFinding(IntegrityWarning, IntegrityCheckUnsignedEntry, entryCommit.Hash, "entry is not signed")
+		}
+
+		if len(entryCommit.ParentHashes) > 1 {
+			report.addFinding(IntegrityError, IntegrityCheckBranch, entryCommit.Hash, fmt.Sprintf("entry has %d parents, only the first is checked", len(entryCommit.ParentHashes)))
+		}
+
+		entry := checkEntry(repo, report, entryCommit)
+		if entry != nil {
+			entries = append(entries, entry)
+			if _, isCheckpoint := entry.(*CheckpointEntry); isCheckpoint {
+				hasCheckpoint = true
+			}
+		}
+
+		child = entryCommit
+		currentID = plumbing.ZeroHash
+		if len(entryCommit.ParentHashes) > 0 {
+			currentID = entryCommit.ParentHashes[0]
+		}
+	}
+
+	// Annotations must refer to reference entries that precede them
+	priorEntries := map[plumbing.Hash]bool{}
+	for i := len(entries) - 1; i >= 0; i-- {
+		switch entry := entries[i].(type) {
+		case *ReferenceEntry:
+			priorEntries[entry.ID] = true
+		case *AnnotationEntry:
+			for _, entryID := range entry.RSLEntryIDs {
+				if priorEntries[entryID] {
+					continue
+				}
+				if hasCheckpoint {
+					// The entry may have been sealed by the checkpoint
+					report.addFinding(IntegrityWarning, IntegrityCheckAnnotationTarget, entry.ID, fmt.Sprintf("annotation refers to entry '%s' that is not in the RSL, it may have been sealed by a checkpoint", entryID.String()))
+					continue
+				}
+				report.addFinding(IntegrityError, IntegrityCheckAnnotationTarget, entry.ID, fmt.Sprintf("annotation refers to entry '%s' that is not a prior reference entry", entryID.String()))
+			}
+		}
+	}
+
+	return report, nil
+}
+
+// checkEntry parses the entry recorded in the commit and checks its format,
+// recording any problems in the report. If the entry cannot be parsed, nil is
+// returned.
+func checkEntry(repo *git.Repository, report *IntegrityReport, entryCommit *object.Commit) Entry {
+	message := strings.TrimSpace(entryCommit.Message)
+	if !strings.HasPrefix(message, ReferenceEntryHeader) && !strings.HasPrefix(message, AnnotationEntryHeader) && !strings.HasPrefix(message, CheckpointEntryHeader) {
+		report.addFinding(IntegrityError, IntegrityCheckInvalidFormat, entryCommit.Hash, "entry has unknown header")
+		return nil
+	}
+
+	entry, err := parseRSLEntryText(entryCommit.Hash, entryCommit.Message)
+	if err != nil {
+		report.addFinding(IntegrityError, IntegrityCheckInvalidFormat, entryCommit.Hash, fmt.Sprintf("entry cannot be parsed: %s", err.Error()))
+		return nil
+	}
+
+	switch entry := entry.(type) {
+	case *ReferenceEntry:
+		if !strings.HasPrefix(entry.RefName, gitinterface.RefPrefix) {
+			report.addFinding(IntegrityError, IntegrityCheckInvalidFormat, entry.ID, fmt.Sprintf("entry has invalid ref '%s'", entry.RefName))
+		}
+		if targetID := getEntryField(message, TargetIDKey); !plumbing.IsHash(targetID) {
+			report.addFinding(IntegrityError, IntegrityCheckInvalidFormat, entry.ID, fmt.Sprintf("entry has invalid target ID '%s'", targetID))
+		}
+		if entry.TargetSHA256 != "" {
+			if digest, err := hex.DecodeString(entry.TargetSHA256); err != nil || len(digest) != sha256.Size {
+				report.addFinding(IntegrityError, IntegrityCheckInvalidFormat, entry.ID, fmt.Sprintf("entry has invalid target SHA-256 digest '%s'", entry.TargetSHA256))
+			}
+		}
+	case *AnnotationEntry:
+		if len(entry.RSLEntryIDs) == 0 {
+			report.addFinding(IntegrityError, IntegrityCheckInvalidFormat, entry.ID, "annotation does not refer to any entries")
+		}
+	case *CheckpointEntry:
+		if len(entryCommit.ParentHashes) != 0 {
+			report.addFinding(IntegrityError, IntegrityCheckCheckpoint, entry.ID, "checkpoint is not the first entry in the RSL")
+		}
+		if err := loadCheckpointEnvelope(repo, entryCommit, entry); err != nil {
+			report.addFinding(IntegrityError, IntegrityCheckCheckpoint, entry.ID, fmt.Sprintf("checkpoint envelope cannot be loaded: %s", err.Error()))
+		} else if err := entry.VerifyPayload(); err != nil {
+			report.addFinding(IntegrityError, IntegrityCheckCheckpoint, entry.ID, fmt.Sprintf("checkpoint does not match its signed payload: %s", err.Error()))
+		}
+		// Checkpoints store their envelope in the tree
+		return entry
+	}
+
+	if entryCommit.TreeHash != gitinterface.EmptyTree() {
+		report.addFinding(IntegrityError, IntegrityCheckUnexpectedTree, entryCommit.Hash, "entry does not use the empty tree")
+	}
+
+	return entry
+}
+
+// getEntryField returns the value recorded for key in the entry's message.
+func getEntryField(message, key string) string {
+	for _, line := range strings.Split(message, "\n") {
+		if value, found := strings.CutPrefix(strings.TrimSpace(line), key+":"); found {
+			return strings.TrimSpace(value)
+		}
+	}
+	return ""
+}
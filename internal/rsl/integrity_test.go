@@ -0,0 +1,140 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package rsl
+
+import (
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/gitinterface"
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/storage/memory"
+	"github.com/jonboulle/clockwork"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckIntegrity(t *testing.T) {
+	createTestRSL := func(t *testing.T) *git.Repository {
+		t.Helper()
+
+		repo, err := git.Init(memory.NewStorage(), memfs.New())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := InitializeNamespace(repo); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := NewReferenceEntry("refs/heads/main", plumbing.ZeroHash).Commit(repo, false); err != nil {
+			t.Fatal(err)
+		}
+		entry, err := GetLatestEntry(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := NewAnnotationEntry([]plumbing.Hash{entry.GetID()}, false, annotationMessage).Commit(repo, false); err != nil {
+			t.Fatal(err)
+		}
+
+		return repo
+	}
+
+	getErrorChecks := func(report *IntegrityReport) []string {
+		checks := []string{}
+		for _, finding := range report.Findings {
+			if finding.Severity == IntegrityError {
+				checks = append(checks, finding.Check)
+			}
+		}
+		return checks
+	}
+
+	t.Run("valid RSL", func(t *testing.T) {
+		repo := createTestRSL(t)
+
+		report, err := CheckIntegrity(repo)
+		assert.Nil(t, err)
+		assert.Equal(t, 2, report.EntriesChecked)
+		assert.Equal(t, 0, report.ErrorCount())
+
+		// Entries in the test RSL are not signed
+		assert.Equal(t, 2, len(report.Findings))
+		for _, finding := range report.Findings {
+			assert.Equal(t, IntegrityWarning, finding.Severity)
+			assert.Equal(t, IntegrityCheckUnsignedEntry, finding.Check)
+		}
+	})
+
+	t.Run("annotation refers to annotation", func(t *testing.T) {
+		repo := createTestRSL(t)
+
+		annotation, err := GetLatestEntry(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := NewAnnotationEntry([]plumbing.Hash{annotation.GetID()}, false, annotationMessage).Commit(repo, false); err != nil {
+			t.Fatal(err)
+		}
+
+		report, err := CheckIntegrity(repo)
+		assert.Nil(t, err)
+		assert.Equal(t, []string{IntegrityCheckAnnotationTarget}, getErrorChecks(report))
+	})
+
+	t.Run("malformed entry", func(t *testing.T) {
+		repo := createTestRSL(t)
+
+		malformedEntryID, err := gitinterface.Commit(repo, gitinterface.EmptyTree(), Ref, "not an RSL entry", false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := NewReferenceEntry("main", plumbing.ZeroHash).Commit(repo, false); err != nil {
+			t.Fatal(err)
+		}
+
+		report, err := CheckIntegrity(repo)
+		assert.Nil(t, err)
+		// The walk continues past the malformed entry
+		assert.Equal(t, 4, report.EntriesChecked)
+		assert.Equal(t, []string{IntegrityCheckInvalidFormat, IntegrityCheckInvalidFormat}, getErrorChecks(report))
+
+		malformedEntryFound := false
+		for _, finding := range report.Findings {
+			if finding.EntryID == malformedEntryID.String() && finding.Check == IntegrityCheckInvalidFormat {
+				malformedEntryFound = true
+			}
+		}
+		assert.True(t, malformedEntryFound)
+	})
+
+	t.Run("branch in RSL", func(t *testing.T) {
+		repo := createTestRSL(t)
+
+		ref, err := repo.Reference(plumbing.ReferenceName(Ref), true)
+		if err != nil {
+			t.Fatal(err)
+		}
+		firstEntry, _, err := GetFirstEntry(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+		gitConfig, err := repo.ConfigScoped(0)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		message, _ := NewReferenceEntry("refs/heads/main", plumbing.ZeroHash).createCommitMessage()
+		commit := gitinterface.CreateCommitObject(gitConfig, gitinterface.EmptyTree(), []plumbing.Hash{ref.Hash(), firstEntry.GetID()}, message, clockwork.NewRealClock())
+		commitID, err := gitinterface.ApplyCommit(repo, commit, ref)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		report, err := CheckIntegrity(repo)
+		assert.Nil(t, err)
+		assert.Equal(t, 3, report.EntriesChecked)
+		assert.Equal(t, []string{IntegrityCheckBranch}, getErrorChecks(report))
+		assert.Equal(t, commitID.String(), report.Findings[1].EntryID)
+	})
+}
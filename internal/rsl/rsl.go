@@ -20,14 +20,20 @@ const (
 	ReferenceEntryHeader       = "RSL Reference Entry"
 	RefKey                     = "ref"
 	TargetIDKey                = "targetID"
+	TargetSHA256Key            = "targetSHA256"
 	AnnotationEntryHeader      = "RSL Annotation Entry"
 	AnnotationMessageBlockType = "MESSAGE"
 	BeginMessage               = "-----BEGIN MESSAGE-----"
 	EndMessage                 = "-----END MESSAGE-----"
 	EntryIDKey                 = "entryID"
 	SkipKey                    = "skip"
+	SkipReasonKey              = "skipReason"
 
 	remoteTrackerRef = "refs/remotes/%s/gittuf/reference-state-log"
+
+	// gittufPolicyRef is the policy reference, which cannot be imported from
+	// the policy package.
+	gittufPolicyRef = "refs/gittuf/policy"
 )
 
 var (
@@ -37,6 +43,7 @@ var (
 	ErrInvalidRSLEntry         = errors.New("RSL entry has invalid format or is of unexpected type")
 	ErrRSLEntryDoesNotMatchRef = errors.New("RSL entry does not match requested ref")
 	ErrNoRecordOfCommit        = errors.New("commit has not been encountered before")
+	ErrInvalidSkipReason       = errors.New("unknown reason for skipping RSL entries")
 )
 
 // InitializeNamespace creates a git ref for the reference state log. Initially,
@@ -84,6 +91,11 @@ type ReferenceEntry struct {
 
 	// TargetID contains the Git hash for the object expected at RefName.
 	TargetID plumbing.Hash
+
+	// TargetSHA256 optionally contains the hex encoded SHA-256 digest of the
+	// contents of TargetID, see gitinterface.ComputeSHA256Digest. It allows
+	// verification to detect SHA-1 collisions for TargetID.
+	TargetSHA256 string
 }
 
 // NewReferenceEntry returns a ReferenceEntry object for a normal RSL entry.
@@ -91,10 +103,21 @@ func NewReferenceEntry(refName string, targetID plumbing.Hash) *ReferenceEntry {
 	return &ReferenceEntry{RefName: refName, TargetID: targetID}
 }
 
+// NewDeletionEntry returns a ReferenceEntry object that records the deletion of
+// the reference. Deletions are recorded using the zero hash as the target.
+func NewDeletionEntry(refName string) *ReferenceEntry {
+	return NewReferenceEntry(refName, plumbing.ZeroHash)
+}
+
 func (e *ReferenceEntry) GetID() plumbing.Hash {
 	return e.ID
 }
 
+// IsDeletion returns true if the entry records the deletion of the reference.
+func (e *ReferenceEntry) IsDeletion() bool {
+	return e.TargetID.IsZero()
+}
+
 // Commit creates a commit object in the RSL for the ReferenceEntry.
 func (e *ReferenceEntry) Commit(repo *git.Repository, sign bool) error {
 	message, _ := e.createCommitMessage() // we have an error return for annotations, always nil here
@@ -116,13 +139,21 @@ func (e *ReferenceEntry) CommitUsingSpecificKey(repo *git.Repository, signingKey
 // Skipped returns true if any of the annotations mark the entry as
 // to-be-skipped.
 func (e *ReferenceEntry) SkippedBy(annotations []*AnnotationEntry) bool {
+	return len(e.SkipReasons(annotations)) != 0
+}
+
+// SkipReasons returns the reasons recorded by each of the annotations that mark
+// the entry as to-be-skipped, in the order of the annotations. Annotations that
+// do not record a reason contribute SkipReasonUnspecified.
+func (e *ReferenceEntry) SkipReasons(annotations []*AnnotationEntry) []SkipReason {
+	reasons := []SkipReason{}
 	for _, annotation := range annotations {
 		if annotation.RefersTo(e.ID) && annotation.Skip {
-			return true
+			reasons = append(reasons, annotation.SkipReason)
 		}
 	}
 
-	return false
+	return reasons
 }
 
 func (e *ReferenceEntry) createCommitMessage() (string, error) {
@@ -132,6 +163,9 @@ func (e *ReferenceEntry) createCommitMessage() (string, error) {
 		fmt.Sprintf("%s: %s", RefKey, e.RefName),
 		fmt.Sprintf("%s: %s", TargetIDKey, e.TargetID.String()),
 	}
+	if e.TargetSHA256 != "" {
+		lines = append(lines, fmt.Sprintf("%s: %s", TargetSHA256Key, e.TargetSHA256))
+	}
 	return strings.Join(lines, "\n"), nil
 }
 
@@ -149,6 +183,10 @@ type AnnotationEntry struct {
 	// Skip indicates if the RSLEntryIDs must be skipped during gittuf workflows.
 	Skip bool
 
+	// SkipReason records why the RSLEntryIDs must be skipped. It is only set
+	// when Skip is true.
+	SkipReason SkipReason
+
 	// Message contains any messages or notes added by a user for the annotation.
 	Message string
 }
@@ -159,6 +197,12 @@ func NewAnnotationEntry(rslEntryIDs []plumbing.Hash, skip bool, message string)
 	return &AnnotationEntry{RSLEntryIDs: rslEntryIDs, Skip: skip, Message: message}
 }
 
+// NewSkipAnnotationEntry returns an Annotation object that marks one or more
+// prior RSL entries as to-be-skipped for the specified reason.
+func NewSkipAnnotationEntry(rslEntryIDs []plumbing.Hash, reason SkipReason, message string) *AnnotationEntry {
+	return &AnnotationEntry{RSLEntryIDs: rslEntryIDs, Skip: true, SkipReason: reason, Message: message}
+}
+
 func (a *AnnotationEntry) GetID() plumbing.Hash {
 	return a.ID
 }
@@ -205,6 +249,9 @@ func (a *AnnotationEntry) createCommitMessage() (string, error) {
 
 	if a.Skip {
 		lines = append(lines, fmt.Sprintf("%s: true", SkipKey))
+		if a.SkipReason != SkipReasonUnspecified {
+			lines = append(lines, fmt.Sprintf("%s: %s", SkipReasonKey, a.SkipReason))
+		}
 	} else {
 		lines = append(lines, fmt.Sprintf("%s: false", SkipKey))
 	}
@@ -231,7 +278,7 @@ func GetEntry(repo *git.Repository, entryID plumbing.Hash) (Entry, error) {
 		return nil, ErrRSLEntryNotFound
 	}
 
-	return parseRSLEntryText(entryID, commitObj.Message)
+	return loadEntry(repo, commitObj)
 }
 
 // GetParentForEntry returns the entry's parent RSL entry.
@@ -292,6 +339,13 @@ func GetNonGittufParentReferenceEntryForEntry(repo *git.Repository, entry Entry)
 			}
 		case *AnnotationEntry:
 			allAnnotations = append(allAnnotations, iterator)
+		case *CheckpointEntry:
+			for _, entry := range iterator.ReferenceEntries() {
+				if !strings.HasPrefix(entry.RefName, GittufNamespacePrefix) {
+					targetEntry = entry
+					break
+				}
+			}
 		}
 
 		if targetEntry != nil {
@@ -322,7 +376,7 @@ func GetLatestEntry(repo *git.Repository) (Entry, error) {
 		return nil, ErrRSLEntryNotFound
 	}
 
-	return parseRSLEntryText(commitObj.Hash, commitObj.Message)
+	return loadEntry(repo, commitObj)
 }
 
 // GetLatestNonGittufReferenceEntry returns the first reference entry that is
@@ -344,6 +398,13 @@ func GetLatestNonGittufReferenceEntry(repo *git.Repository) (*ReferenceEntry, []
 			}
 		case *AnnotationEntry:
 			allAnnotations = append(allAnnotations, iterator)
+		case *CheckpointEntry:
+			for _, entry := range iterator.ReferenceEntries() {
+				if !strings.HasPrefix(entry.RefName, GittufNamespacePrefix) {
+					targetEntry = entry
+					break
+				}
+			}
 		}
 
 		if targetEntry != nil {
@@ -415,6 +476,8 @@ func GetLatestReferenceEntryForRefBefore(repo *git.Repository, refName string, a
 			}
 		case *AnnotationEntry:
 			allAnnotations = append(allAnnotations, iterator)
+		case *CheckpointEntry:
+			targetEntry = iterator.ReferenceEntryFor(refName)
 		}
 
 		if targetEntry != nil {
@@ -463,8 +526,35 @@ func GetLatestUnskippedReferenceEntryForRefBefore(repo *git.Repository, refName
 	}
 }
 
+// GetAnnotationsForEntry returns the annotations in the RSL that refer to the
+// specified entry. As annotations can only refer to prior entries, only the
+// portion of the RSL after the entry is searched.
+func GetAnnotationsForEntry(repo *git.Repository, entryID plumbing.Hash) ([]*AnnotationEntry, error) {
+	allAnnotations := []*AnnotationEntry{}
+
+	iteratorT, err := GetLatestEntry(repo)
+	if err != nil {
+		return nil, err
+	}
+
+	for iteratorT.GetID() != entryID {
+		if annotation, isAnnotation := iteratorT.(*AnnotationEntry); isAnnotation {
+			allAnnotations = append(allAnnotations, annotation)
+		}
+
+		iteratorT, err = GetParentForEntry(repo, iteratorT)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return filterAnnotationsForRelevantAnnotations(allAnnotations, entryID), nil
+}
+
 // GetFirstEntry returns the very first entry in the RSL. It is expected to be
-// a reference entry as the first entry in the RSL cannot be an annotation.
+// a reference entry as the first entry in the RSL cannot be an annotation. If
+// the RSL begins with a checkpoint, the checkpoint's entry for the policy
+// reference is returned.
 func GetFirstEntry(repo *git.Repository) (*ReferenceEntry, []*AnnotationEntry, error) {
 	iteratorT, err := GetLatestEntry(repo)
 	if err != nil {
@@ -482,12 +572,16 @@ func GetFirstEntry(repo *git.Repository) (*ReferenceEntry, []*AnnotationEntry, e
 		parentT, err := GetParentForEntry(repo, iteratorT)
 		if err != nil {
 			if errors.Is(err, ErrRSLEntryNotFound) {
-				entry, ok := iteratorT.(*ReferenceEntry)
-				if !ok {
+				switch entry := iteratorT.(type) {
+				case *ReferenceEntry:
+					firstEntry = entry
+				case *CheckpointEntry:
+					firstEntry = entry.ReferenceEntryFor(gittufPolicyRef)
+				}
+				if firstEntry == nil {
 					// The first entry cannot be an annotation
 					return nil, nil, ErrInvalidRSLEntry
 				}
-				firstEntry = entry
 				break
 			}
 
@@ -556,6 +650,49 @@ func GetFirstReferenceEntryForCommit(repo *git.Repository, commit *object.Commit
 	}
 }
 
+// GetFirstReferenceEntryForCommitInRef returns the first reference entry for
+// the ref that either records the commit itself or a descendent of the commit.
+// Unlike GetFirstReferenceEntryForCommit, only the entries for the specified
+// ref are considered.
+func GetFirstReferenceEntryForCommitInRef(repo *git.Repository, refName string, commit *object.Commit) (*ReferenceEntry, []*AnnotationEntry, error) {
+	firstEntry, firstAnnotations, err := GetLatestReferenceEntryForRef(repo, refName)
+	if err != nil {
+		if errors.Is(err, ErrRSLEntryNotFound) {
+			return nil, nil, ErrNoRecordOfCommit
+		}
+		return nil, nil, err
+	}
+
+	knowsCommit, err := gitinterface.KnowsCommit(repo, firstEntry.TargetID, commit)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !knowsCommit {
+		return nil, nil, ErrNoRecordOfCommit
+	}
+
+	for {
+		iteratorEntry, iteratorAnnotations, err := GetLatestReferenceEntryForRefBefore(repo, refName, firstEntry.ID)
+		if err != nil {
+			if errors.Is(err, ErrRSLEntryNotFound) {
+				return firstEntry, firstAnnotations, nil
+			}
+			return nil, nil, err
+		}
+
+		knowsCommit, err := gitinterface.KnowsCommit(repo, iteratorEntry.TargetID, commit)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !knowsCommit {
+			return firstEntry, firstAnnotations, nil
+		}
+
+		firstEntry = iteratorEntry
+		firstAnnotations = iteratorAnnotations
+	}
+}
+
 // GetReferenceEntriesInRange returns a list of reference entries between the
 // specified range and a map of annotations that refer to each reference entry
 // in the range. The annotations map is keyed by the ID of the reference entry,
@@ -622,7 +759,16 @@ func GetReferenceEntriesInRangeForRef(repo *git.Repository, firstID, lastID plum
 	// Handle the item corresponding to first explicitly
 	// If it's an annotation, ignore it as it refers to something before the
 	// range we care about
-	if entry, isEntry := iterator.(*ReferenceEntry); isEntry {
+	firstEntries := []*ReferenceEntry{}
+	switch entry := iterator.(type) {
+	case *ReferenceEntry:
+		firstEntries = append(firstEntries, entry)
+	case *CheckpointEntry:
+		firstEntries = entry.ReferenceEntries()
+	}
+	// Entries are added to the stack in reverse as it's reversed below
+	for i := len(firstEntries) - 1; i >= 0; i-- {
+		entry := firstEntries[i]
 		if len(refName) == 0 || entry.RefName == refName || strings.HasPrefix(entry.RefName, GittufNamespacePrefix) {
 			// It's a relevant entry if:
 			// a) there's no refName set, or
@@ -662,11 +808,31 @@ func GetReferenceEntriesInRangeForRef(repo *git.Repository, firstID, lastID plum
 	return allEntries, annotationMap, nil
 }
 
+// loadEntry parses the RSL entry recorded in the commit. For checkpoints, the
+// envelope stored in the commit's tree is also loaded.
+func loadEntry(repo *git.Repository, commitObj *object.Commit) (Entry, error) {
+	entry, err := parseRSLEntryText(commitObj.Hash, commitObj.Message)
+	if err != nil {
+		return nil, err
+	}
+
+	if checkpoint, isCheckpoint := entry.(*CheckpointEntry); isCheckpoint {
+		if err := loadCheckpointEnvelope(repo, commitObj, checkpoint); err != nil {
+			return nil, err
+		}
+	}
+
+	return entry, nil
+}
+
 func parseRSLEntryText(id plumbing.Hash, text string) (Entry, error) {
 	text = strings.TrimSpace(text)
 	if strings.HasPrefix(text, AnnotationEntryHeader) {
 		return parseAnnotationEntryText(id, text)
 	}
+	if strings.HasPrefix(text, CheckpointEntryHeader) {
+		return parseCheckpointEntryText(id, text)
+	}
 	return parseReferenceEntryText(id, text)
 }
 
@@ -691,6 +857,8 @@ func parseReferenceEntryText(id plumbing.Hash, text string) (*ReferenceEntry, er
 			entry.RefName = strings.TrimSpace(ls[1])
 		case TargetIDKey:
 			entry.TargetID = plumbing.NewHash(strings.TrimSpace(ls[1]))
+		case TargetSHA256Key:
+			entry.TargetSHA256 = strings.TrimSpace(ls[1])
 		}
 	}
 
@@ -734,6 +902,8 @@ func parseAnnotationEntryText(id plumbing.Hash, text string) (*AnnotationEntry,
 			} else {
 				annotation.Skip = false
 			}
+		case SkipReasonKey:
+			annotation.SkipReason = SkipReason(strings.TrimSpace(ls[1]))
 		}
 	}
 
@@ -830,6 +830,91 @@ func TestGetFirstReferenceEntryForCommit(t *testing.T) {
 	}
 }
 
+func TestGetFirstReferenceEntryForCommitInRef(t *testing.T) {
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := InitializeNamespace(repo); err != nil {
+		t.Fatal(err)
+	}
+
+	emptyTreeHash, err := gitinterface.WriteTree(repo, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mainRef := "refs/heads/main"
+	featureRef := "refs/heads/feature"
+	if err := repo.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(mainRef), plumbing.ZeroHash)); err != nil {
+		t.Fatal(err)
+	}
+
+	commitIDs := []plumbing.Hash{}
+	entryIDs := []plumbing.Hash{}
+	for i := 0; i < 3; i++ {
+		commitID, err := gitinterface.Commit(repo, emptyTreeHash, mainRef, "Test commit", false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		commitIDs = append(commitIDs, commitID)
+
+		if err := NewReferenceEntry(mainRef, commitID).Commit(repo, false); err != nil {
+			t.Fatal(err)
+		}
+		latestEntry, err := GetLatestEntry(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+		entryIDs = append(entryIDs, latestEntry.GetID())
+
+		// The feature branch records the same commit after main
+		if err := NewReferenceEntry(featureRef, commitID).Commit(repo, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for i, commitID := range commitIDs {
+		commit, err := gitinterface.GetCommit(repo, commitID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		entry, _, err := GetFirstReferenceEntryForCommitInRef(repo, mainRef, commit)
+		assert.Nil(t, err)
+		assert.Equal(t, entryIDs[i], entry.ID)
+		assert.Equal(t, mainRef, entry.RefName)
+
+		entry, _, err = GetFirstReferenceEntryForCommitInRef(repo, featureRef, commit)
+		assert.Nil(t, err)
+		assert.Equal(t, featureRef, entry.RefName)
+		assert.Equal(t, commitID, entry.TargetID)
+	}
+
+	commit, err := gitinterface.GetCommit(repo, commitIDs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _, err = GetFirstReferenceEntryForCommitInRef(repo, "refs/heads/unknown", commit)
+	assert.ErrorIs(t, err, ErrNoRecordOfCommit)
+
+	// Commit not recorded for the ref
+	if err := repo.Storer.SetReference(plumbing.NewHashReference(plumbing.ReferenceName(mainRef), commitIDs[len(commitIDs)-1])); err != nil {
+		t.Fatal(err)
+	}
+	commitID, err := gitinterface.Commit(repo, emptyTreeHash, mainRef, "Test commit", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	commit, err = gitinterface.GetCommit(repo, commitID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _, err = GetFirstReferenceEntryForCommitInRef(repo, mainRef, commit)
+	assert.ErrorIs(t, err, ErrNoRecordOfCommit)
+}
+
 func TestGetReferenceEntriesInRange(t *testing.T) {
 	refName := "refs/heads/main"
 	anotherRefName := "refs/heads/feature"
@@ -1185,6 +1270,58 @@ func TestGetLatestUnskippedReferenceEntryForRefBefore(t *testing.T) {
 	assert.ErrorIs(t, err, ErrRSLEntryNotFound)
 }
 
+func TestGetAnnotationsForEntry(t *testing.T) {
+	refName := "refs/heads/main"
+
+	repo, err := git.Init(memory.NewStorage(), memfs.New())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := InitializeNamespace(repo); err != nil {
+		t.Fatal(err)
+	}
+
+	entryIDs := []plumbing.Hash{}
+	for i := 0; i < 2; i++ {
+		if err := NewReferenceEntry(refName, plumbing.ZeroHash).Commit(repo, false); err != nil {
+			t.Fatal(err)
+		}
+
+		e, err := GetLatestEntry(repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+		entryIDs = append(entryIDs, e.GetID())
+	}
+
+	annotations, err := GetAnnotationsForEntry(repo, entryIDs[0])
+	assert.Nil(t, err)
+	assert.Empty(t, annotations)
+
+	if err := NewAnnotationEntry([]plumbing.Hash{entryIDs[0]}, false, "first").Commit(repo, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := NewAnnotationEntry([]plumbing.Hash{entryIDs[1]}, false, "second").Commit(repo, false); err != nil {
+		t.Fatal(err)
+	}
+
+	annotations, err = GetAnnotationsForEntry(repo, entryIDs[0])
+	assert.Nil(t, err)
+	if assert.Len(t, annotations, 1) {
+		assert.Equal(t, "first", annotations[0].Message)
+	}
+
+	annotations, err = GetAnnotationsForEntry(repo, entryIDs[1])
+	assert.Nil(t, err)
+	if assert.Len(t, annotations, 1) {
+		assert.Equal(t, "second", annotations[0].Message)
+	}
+
+	_, err = GetAnnotationsForEntry(repo, plumbing.NewHash("abcdef12345678900987654321fedcbaabcdef12"))
+	assert.NotNil(t, err)
+}
+
 func TestAnnotationEntryRefersTo(t *testing.T) {
 	// We use these as stand-ins for actual RSL IDs that have the same data type
 	emptyBlobID := gitinterface.EmptyBlob()
@@ -1223,6 +1360,38 @@ func TestAnnotationEntryRefersTo(t *testing.T) {
 	}
 }
 
+func TestReferenceEntrySkipReasons(t *testing.T) {
+	entryID := plumbing.NewHash("abcdef12345678900987654321fedcbaabcdef12")
+	entry := &ReferenceEntry{ID: entryID, RefName: "refs/heads/main"}
+
+	annotations := []*AnnotationEntry{
+		NewAnnotationEntry([]plumbing.Hash{entryID}, false, "not a skip"),
+		NewAnnotationEntry([]plumbing.Hash{entryID}, true, "skip without reason"),
+		NewSkipAnnotationEntry([]plumbing.Hash{plumbing.ZeroHash}, SkipReasonMistakenPush, "another entry"),
+		NewSkipAnnotationEntry([]plumbing.Hash{entryID}, SkipReasonForcePushRecovery, ""),
+	}
+
+	assert.Equal(t, []SkipReason{SkipReasonUnspecified, SkipReasonForcePushRecovery}, entry.SkipReasons(annotations))
+	assert.True(t, entry.SkippedBy(annotations))
+
+	assert.Empty(t, entry.SkipReasons(annotations[:1]))
+	assert.False(t, entry.SkippedBy(annotations[:1]))
+}
+
+func TestParseSkipReason(t *testing.T) {
+	for _, reason := range SkipReasons() {
+		parsedReason, err := ParseSkipReason(string(reason))
+		assert.Nil(t, err)
+		assert.Equal(t, reason, parsedReason)
+	}
+
+	_, err := ParseSkipReason("")
+	assert.ErrorIs(t, err, ErrInvalidSkipReason)
+
+	_, err = ParseSkipReason("unknown")
+	assert.ErrorIs(t, err, ErrInvalidSkipReason)
+}
+
 func TestReferenceEntryCreateCommitMessage(t *testing.T) {
 	tests := map[string]struct {
 		entry           *ReferenceEntry
@@ -1242,6 +1411,14 @@ func TestReferenceEntryCreateCommitMessage(t *testing.T) {
 			},
 			expectedMessage: fmt.Sprintf("%s\n\n%s: %s\n%s: %s", ReferenceEntryHeader, RefKey, "refs/heads/main", TargetIDKey, "abcdef12345678900987654321fedcbaabcdef12"),
 		},
+		"entry, with target digest": {
+			entry: &ReferenceEntry{
+				RefName:      "refs/heads/main",
+				TargetID:     plumbing.NewHash("abcdef12345678900987654321fedcbaabcdef12"),
+				TargetSHA256: "abcdef",
+			},
+			expectedMessage: fmt.Sprintf("%s\n\n%s: %s\n%s: %s\n%s: %s", ReferenceEntryHeader, RefKey, "refs/heads/main", TargetIDKey, "abcdef12345678900987654321fedcbaabcdef12", TargetSHA256Key, "abcdef"),
+		},
 	}
 
 	for name, test := range tests {
@@ -1283,6 +1460,15 @@ func TestAnnotationEntryCreateCommitMessage(t *testing.T) {
 			},
 			expectedMessage: fmt.Sprintf("%s\n\n%s: %s\n%s: %s\n%s\n%s\n%s", AnnotationEntryHeader, EntryIDKey, plumbing.ZeroHash.String(), SkipKey, "true", BeginMessage, base64.StdEncoding.EncodeToString([]byte("message1\nmessage2")), EndMessage),
 		},
+		"annotation, with skip reason and message": {
+			entry: &AnnotationEntry{
+				RSLEntryIDs: []plumbing.Hash{plumbing.ZeroHash},
+				Skip:        true,
+				SkipReason:  SkipReasonMistakenPush,
+				Message:     "message",
+			},
+			expectedMessage: fmt.Sprintf("%s\n\n%s: %s\n%s: %s\n%s: %s\n%s\n%s\n%s", AnnotationEntryHeader, EntryIDKey, plumbing.ZeroHash.String(), SkipKey, "true", SkipReasonKey, "mistaken-push", BeginMessage, base64.StdEncoding.EncodeToString([]byte("message")), EndMessage),
+		},
 		"annotation, no message, skip false": {
 			entry: &AnnotationEntry{
 				RSLEntryIDs: []plumbing.Hash{plumbing.ZeroHash},
@@ -1336,6 +1522,15 @@ func TestParseRSLEntryText(t *testing.T) {
 			},
 			message: fmt.Sprintf("%s\n\n%s: %s\n%s: %s", ReferenceEntryHeader, RefKey, "refs/heads/main", TargetIDKey, "abcdef12345678900987654321fedcbaabcdef12"),
 		},
+		"entry, with target digest": {
+			expectedEntry: &ReferenceEntry{
+				ID:           plumbing.ZeroHash,
+				RefName:      "refs/heads/main",
+				TargetID:     plumbing.NewHash("abcdef12345678900987654321fedcbaabcdef12"),
+				TargetSHA256: "abcdef",
+			},
+			message: fmt.Sprintf("%s\n\n%s: %s\n%s: %s\n%s: %s", ReferenceEntryHeader, RefKey, "refs/heads/main", TargetIDKey, "abcdef12345678900987654321fedcbaabcdef12", TargetSHA256Key, "abcdef"),
+		},
 		"entry, missing header": {
 			expectedError: ErrInvalidRSLEntry,
 			message:       fmt.Sprintf("%s: %s\n%s: %s", RefKey, "refs/heads/main", TargetIDKey, plumbing.ZeroHash.String()),
@@ -1371,6 +1566,16 @@ func TestParseRSLEntryText(t *testing.T) {
 			},
 			message: fmt.Sprintf("%s\n\n%s: %s\n%s: %s\n%s\n%s\n%s", AnnotationEntryHeader, EntryIDKey, plumbing.ZeroHash.String(), SkipKey, "true", BeginMessage, base64.StdEncoding.EncodeToString([]byte("message1\nmessage2")), EndMessage),
 		},
+		"annotation, with skip reason and message": {
+			expectedEntry: &AnnotationEntry{
+				ID:          plumbing.ZeroHash,
+				RSLEntryIDs: []plumbing.Hash{plumbing.ZeroHash},
+				Skip:        true,
+				SkipReason:  SkipReasonRevokedKey,
+				Message:     "message",
+			},
+			message: fmt.Sprintf("%s\n\n%s: %s\n%s: %s\n%s: %s\n%s\n%s\n%s", AnnotationEntryHeader, EntryIDKey, plumbing.ZeroHash.String(), SkipKey, "true", SkipReasonKey, "revoked-key", BeginMessage, base64.StdEncoding.EncodeToString([]byte("message")), EndMessage),
+		},
 		"annotation, no message, skip false": {
 			expectedEntry: &AnnotationEntry{
 				ID:          plumbing.ZeroHash,
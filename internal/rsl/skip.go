@@ -0,0 +1,60 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package rsl
+
+import "fmt"
+
+// SkipReason records why an annotation marks RSL entries as to-be-skipped.
+type SkipReason string
+
+const (
+	// SkipReasonUnspecified is used for annotations that do not record why
+	// entries are skipped.
+	SkipReasonUnspecified SkipReason = ""
+
+	// SkipReasonForcePushRecovery indicates that the entries were made
+	// obsolete by a force push and that the reference was subsequently
+	// restored.
+	SkipReasonForcePushRecovery SkipReason = "force-push-recovery"
+
+	// SkipReasonMistakenPush indicates that the entries record a push that
+	// should not have happened, such as one to the wrong reference.
+	SkipReasonMistakenPush SkipReason = "mistaken-push"
+
+	// SkipReasonRevokedKey indicates that the entries were signed using a key
+	// that has since been revoked or compromised.
+	SkipReasonRevokedKey SkipReason = "revoked-key"
+)
+
+// SkipReasons returns all the known reasons for skipping RSL entries.
+func SkipReasons() []SkipReason {
+	return []SkipReason{SkipReasonForcePushRecovery, SkipReasonMistakenPush, SkipReasonRevokedKey}
+}
+
+// ParseSkipReason returns the SkipReason corresponding to reason. An error is
+// returned if reason is not one of the known reasons.
+func ParseSkipReason(reason string) (SkipReason, error) {
+	for _, skipReason := range SkipReasons() {
+		if string(skipReason) == reason {
+			return skipReason, nil
+		}
+	}
+
+	return SkipReasonUnspecified, fmt.Errorf("%w: '%s'", ErrInvalidSkipReason, reason)
+}
+
+// Description returns a human readable description of the reason.
+func (r SkipReason) Description() string {
+	switch r {
+	case SkipReasonUnspecified:
+		return "no reason specified"
+	case SkipReasonForcePushRecovery:
+		return "recovery from force push"
+	case SkipReasonMistakenPush:
+		return "mistaken push"
+	case SkipReasonRevokedKey:
+		return "signed using revoked key"
+	default:
+		return fmt.Sprintf("unknown reason '%s'", string(r))
+	}
+}
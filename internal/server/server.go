@@ -0,0 +1,287 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Package server implements gittuf's verification server, which exposes
+// verification and policy queries for a set of repositories over HTTP.
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/gittuf/gittuf/internal/metrics"
+	"github.com/gittuf/gittuf/pkg/gittuf"
+)
+
+// Server serves verification and policy queries for a set of repositories.
+type Server struct {
+	repositories map[string]*repository
+	metrics      *metrics.Metrics
+}
+
+type Option func(*Server)
+
+// WithMetrics records the server's verifications in m, and exposes m on the
+// /metrics endpoint.
+func WithMetrics(m *metrics.Metrics) Option {
+	return func(s *Server) {
+		s.metrics = m
+	}
+}
+
+// repository is a repository served by the server. Requests for the same
+// repository are handled one at a time, as verification updates the
+// repository's verification cache.
+type repository struct {
+	mu   sync.Mutex
+	repo *gittuf.Repository
+}
+
+// New returns a server for the repositories, where each repository's path is
+// keyed by the name it is served as.
+func New(repositoryPaths map[string]string, opts ...Option) (*Server, error) {
+	repositories := map[string]*repository{}
+	for name, path := range repositoryPaths {
+		slog.Debug(fmt.Sprintf("Opening repository '%s' at '%s'...", name, path))
+		repo, err := gittuf.Open(path)
+		if err != nil {
+			return nil, fmt.Errorf("unable to open repository '%s' at '%s': %w", name, path, err)
+		}
+		repositories[name] = &repository{repo: repo}
+	}
+
+	s := &Server{repositories: repositories}
+	for _, fn := range opts {
+		fn(s)
+	}
+
+	return s, nil
+}
+
+// VerifyRefRequest is the request to verify a reference.
+type VerifyRefRequest struct {
+	Ref        string `json:"ref"`
+	LatestOnly bool   `json:"latest_only,omitempty"`
+	FromEntry  string `json:"from_entry,omitempty"`
+	FromCommit string `json:"from_commit,omitempty"`
+}
+
+// VerifyTagRequest is the request to verify a tag.
+type VerifyTagRequest struct {
+	Tag string `json:"tag"`
+}
+
+// VerificationResult is the response to a verification request. A
+// verification failure is reported using Verified and Error rather than the
+// response's status.
+type VerificationResult struct {
+	Repository string `json:"repository"`
+	Target     string `json:"target"`
+	Verified   bool   `json:"verified"`
+	Error      string `json:"error,omitempty"`
+}
+
+// errorResponse is the response when a request cannot be handled.
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// Handler returns the HTTP handler for the server's API:
+//
+//	GET  /healthz
+//	GET  /v1/repositories
+//	POST /v1/repositories/{name}/verify-ref
+//	POST /v1/repositories/{name}/verify-tag
+//	GET  /v1/repositories/{name}/rules
+//	GET  /v1/repositories/{name}/who-can-authorize?target=<target>
+//	GET  /v1/repositories/{name}/rsl?ref=<ref>&limit=<limit>
+//	GET  /metrics, if the server records metrics
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc("GET /v1/repositories", s.listRepositories)
+	mux.HandleFunc("POST /v1/repositories/{name}/verify-ref", s.withRepository(s.verifyRef))
+	mux.HandleFunc("POST /v1/repositories/{name}/verify-tag", s.withRepository(s.verifyTag))
+	mux.HandleFunc("GET /v1/repositories/{name}/rules", s.withRepository(s.rules))
+	mux.HandleFunc("GET /v1/repositories/{name}/who-can-authorize", s.withRepository(s.whoCanAuthorize))
+	mux.HandleFunc("GET /v1/repositories/{name}/rsl", s.withRepository(s.rsl))
+	if s.metrics != nil {
+		mux.HandleFunc("GET /metrics", s.serveMetrics)
+	}
+
+	return mux
+}
+
+func (s *Server) listRepositories(w http.ResponseWriter, _ *http.Request) {
+	names := make([]string, 0, len(s.repositories))
+	for name := range s.repositories {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	writeJSON(w, http.StatusOK, names)
+}
+
+// withRepository returns a handler that invokes handle with the repository
+// named in the request's path, holding the repository's lock.
+func (s *Server) withRepository(handle func(http.ResponseWriter, *http.Request, string, *gittuf.Repository)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		name := r.PathValue("name")
+		repo, has := s.repositories[name]
+		if !has {
+			writeError(w, http.StatusNotFound, fmt.Errorf("unknown repository '%s'", name))
+			return
+		}
+
+		repo.mu.Lock()
+		defer repo.mu.Unlock()
+
+		handle(w, r, name, repo.repo)
+	}
+}
+
+func (s *Server) verifyRef(w http.ResponseWriter, r *http.Request, name string, repo *gittuf.Repository) {
+	request := &VerifyRefRequest{}
+	if err := json.NewDecoder(r.Body).Decode(request); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if request.Ref == "" {
+		writeError(w, http.StatusBadRequest, errors.New("ref must be specified"))
+		return
+	}
+
+	opts := []gittuf.VerifyOption{}
+	switch {
+	case request.FromEntry != "":
+		opts = append(opts, gittuf.WithFromEntry(request.FromEntry))
+	case request.FromCommit != "":
+		opts = append(opts, gittuf.WithFromCommit(request.FromCommit))
+	case request.LatestOnly:
+		opts = append(opts, gittuf.WithLatestOnly())
+	}
+
+	slog.Debug(fmt.Sprintf("Verifying '%s' in repository '%s'...", request.Ref, name))
+	err := repo.VerifyRef(r.Context(), request.Ref, opts...)
+	s.recordVerification(name, err)
+	writeJSON(w, http.StatusOK, newVerificationResult(name, request.Ref, err))
+}
+
+func (s *Server) verifyTag(w http.ResponseWriter, r *http.Request, name string, repo *gittuf.Repository) {
+	request := &VerifyTagRequest{}
+	if err := json.NewDecoder(r.Body).Decode(request); err != nil {
+		writeError(w, http.StatusBadRequest, err)
+		return
+	}
+	if request.Tag == "" {
+		writeError(w, http.StatusBadRequest, errors.New("tag must be specified"))
+		return
+	}
+
+	slog.Debug(fmt.Sprintf("Verifying tag '%s' in repository '%s'...", request.Tag, name))
+	err := repo.VerifyTag(r.Context(), request.Tag)
+	s.recordVerification(name, err)
+	writeJSON(w, http.StatusOK, newVerificationResult(name, request.Tag, err))
+}
+
+func (s *Server) rules(w http.ResponseWriter, r *http.Request, _ string, repo *gittuf.Repository) {
+	rules, err := repo.Rules(r.Context())
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, rules)
+}
+
+func (s *Server) whoCanAuthorize(w http.ResponseWriter, r *http.Request, _ string, repo *gittuf.Repository) {
+	target := r.URL.Query().Get("target")
+	if target == "" {
+		writeError(w, http.StatusBadRequest, errors.New("target must be specified"))
+		return
+	}
+
+	authorization, err := repo.WhoCanAuthorize(r.Context(), target)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, authorization)
+}
+
+func (s *Server) rsl(w http.ResponseWriter, r *http.Request, _ string, repo *gittuf.Repository) {
+	limit := 0
+	if value := r.URL.Query().Get("limit"); value != "" {
+		var err error
+		limit, err = strconv.Atoi(value)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, fmt.Errorf("invalid limit: %w", err))
+			return
+		}
+	}
+
+	entries, err := repo.RSLEntries(r.URL.Query().Get("ref"), limit)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, entries)
+}
+
+func (s *Server) recordVerification(name string, err error) {
+	if s.metrics != nil {
+		s.metrics.RecordVerification(name, err)
+	}
+}
+
+// serveMetrics updates the RSL lag and policy versions of each repository
+// before serving the metrics, as the repositories are updated outside the
+// server.
+func (s *Server) serveMetrics(w http.ResponseWriter, r *http.Request) {
+	for name, repo := range s.repositories {
+		repo.mu.Lock()
+		if latestEntryTime, err := repo.repo.LatestRSLEntryTime(); err == nil {
+			s.metrics.SetRSLLag(name, time.Since(latestEntryTime))
+		} else {
+			slog.Debug(fmt.Sprintf("Unable to determine RSL lag of repository '%s': %s", name, err.Error()))
+		}
+		if versions, err := repo.repo.PolicyVersions(r.Context()); err == nil {
+			s.metrics.SetPolicyVersions(name, versions)
+		} else {
+			slog.Debug(fmt.Sprintf("Unable to determine policy versions of repository '%s': %s", name, err.Error()))
+		}
+		repo.mu.Unlock()
+	}
+
+	s.metrics.Handler().ServeHTTP(w, r)
+}
+
+func newVerificationResult(name, target string, err error) *VerificationResult {
+	result := &VerificationResult{Repository: name, Target: target, Verified: err == nil}
+	if err != nil {
+		result.Error = err.Error()
+	}
+	return result
+}
+
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, &errorResponse{Error: err.Error()})
+}
+
+func writeJSON(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(value); err != nil {
+		slog.Debug(fmt.Sprintf("Unable to write response: %s", err.Error()))
+	}
+}
@@ -0,0 +1,103 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/metrics"
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/gittuf/gittuf/pkg/gittuf"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServer(t *testing.T) {
+	tmpDir := t.TempDir()
+	r, err := git.PlainInit(tmpDir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := rsl.InitializeNamespace(r); err != nil {
+		t.Fatal(err)
+	}
+	if err := rsl.NewReferenceEntry("refs/heads/main", plumbing.ZeroHash).Commit(r, false); err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = New(map[string]string{"missing": t.TempDir()})
+	assert.ErrorIs(t, err, git.ErrRepositoryNotExists)
+
+	s, err := New(map[string]string{"test": tmpDir}, WithMetrics(metrics.New(func(error) string { return "error" })))
+	if err != nil {
+		t.Fatal(err)
+	}
+	handler := s.Handler()
+
+	serve := func(method, target, body string) *httptest.ResponseRecorder {
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, httptest.NewRequest(method, target, strings.NewReader(body)))
+		return recorder
+	}
+
+	t.Run("list repositories", func(t *testing.T) {
+		response := serve(http.MethodGet, "/v1/repositories", "")
+		assert.Equal(t, http.StatusOK, response.Code)
+
+		names := []string{}
+		if err := json.NewDecoder(response.Body).Decode(&names); err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, []string{"test"}, names)
+	})
+
+	t.Run("unknown repository", func(t *testing.T) {
+		response := serve(http.MethodGet, "/v1/repositories/unknown/rsl", "")
+		assert.Equal(t, http.StatusNotFound, response.Code)
+	})
+
+	t.Run("read RSL", func(t *testing.T) {
+		response := serve(http.MethodGet, "/v1/repositories/test/rsl?ref=refs/heads/main", "")
+		assert.Equal(t, http.StatusOK, response.Code)
+
+		entries := []*gittuf.RSLEntry{}
+		if err := json.NewDecoder(response.Body).Decode(&entries); err != nil {
+			t.Fatal(err)
+		}
+		assert.Len(t, entries, 1)
+		assert.Equal(t, "refs/heads/main", entries[0].RefName)
+	})
+
+	t.Run("invalid verification request", func(t *testing.T) {
+		response := serve(http.MethodPost, "/v1/repositories/test/verify-ref", "{}")
+		assert.Equal(t, http.StatusBadRequest, response.Code)
+	})
+
+	t.Run("verification failure", func(t *testing.T) {
+		response := serve(http.MethodPost, "/v1/repositories/test/verify-ref", `{"ref": "refs/heads/main", "latest_only": true}`)
+		assert.Equal(t, http.StatusOK, response.Code)
+
+		result := &VerificationResult{}
+		if err := json.NewDecoder(response.Body).Decode(result); err != nil {
+			t.Fatal(err)
+		}
+		assert.Equal(t, "test", result.Repository)
+		assert.Equal(t, "refs/heads/main", result.Target)
+		assert.False(t, result.Verified)
+		assert.NotEmpty(t, result.Error)
+	})
+	t.Run("metrics", func(t *testing.T) {
+		response := serve(http.MethodGet, "/metrics", "")
+		assert.Equal(t, http.StatusOK, response.Code)
+
+		body := response.Body.String()
+		assert.Contains(t, body, `gittuf_verifications_total{repository="test",result="failure"} 1`)
+		assert.Contains(t, body, `gittuf_verification_failures_total{repository="test",type="error"} 1`)
+		assert.Contains(t, body, `gittuf_rsl_lag_seconds{repository="test"}`)
+	})
+}
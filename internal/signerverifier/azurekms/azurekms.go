@@ -0,0 +1,324 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package azurekms
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"os"
+	"os/exec"
+	"strings"
+
+	sslibsv "github.com/gittuf/gittuf/internal/third_party/go-securesystemslib/signerverifier"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/secure-systems-lab/go-securesystemslib/dsse"
+	"golang.org/x/crypto/cryptobyte"
+	"golang.org/x/crypto/cryptobyte/asn1"
+)
+
+const (
+	// KeyReferencePrefix identifies Azure Key Vault key references, which
+	// have the form azurekms://<vault-name>.vault.azure.net/<key>[/<version>].
+	KeyReferencePrefix = "azurekms://"
+
+	// AccessTokenEnvVar can be set to an access token for the Key Vault
+	// resource. If unset, the token is obtained using the Azure CLI.
+	AccessTokenEnvVar = "AZURE_KEYVAULT_ACCESS_TOKEN"
+
+	apiVersion = "7.4"
+)
+
+var (
+	ErrInvalidKeyReference = errors.New("invalid Azure Key Vault key reference")
+	ErrUnsupportedKey      = errors.New("Azure Key Vault key type is not supported by gittuf")
+	ErrNoAccessToken       = errors.New("unable to obtain access token for Azure Key Vault")
+	ErrKeyVaultRequest     = errors.New("Azure Key Vault request failed")
+	ErrInvalidSignature    = errors.New("Azure Key Vault returned an invalid signature")
+)
+
+// vaultScheme is the scheme used to reach the vault, it is a variable to
+// allow tests to use a local server.
+var vaultScheme = "https"
+
+// SignerVerifier implements the dsse.SignerVerifier interface for a key held
+// in Azure Key Vault. Signatures are verified locally using the key's public
+// key.
+type SignerVerifier struct {
+	keyURL    string
+	algorithm string
+	hash      crypto.Hash
+	key       *tuf.Key
+	verifier  dsse.SignerVerifier
+}
+
+// NewSignerVerifierFromURI returns a SignerVerifier for the Key Vault key
+// identified by keyRef. If the reference does not include a key version, the
+// current version of the key is used.
+func NewSignerVerifierFromURI(ctx context.Context, keyRef string) (*SignerVerifier, error) {
+	keyURL, err := parseKeyReference(keyRef)
+	if err != nil {
+		return nil, err
+	}
+
+	response := struct {
+		Key jsonWebKey `json:"key"`
+	}{}
+	if err := request(ctx, http.MethodGet, keyURL, nil, &response); err != nil {
+		return nil, err
+	}
+
+	publicKey, algorithm, hash, err := response.Key.publicKey()
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := sslibsv.NewKey(publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	verifier, err := sslibsv.NewVerifierFromSSLibKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	// Pin the key version so that all signatures are created using the key
+	// that was loaded.
+	if response.Key.KeyID != "" {
+		keyURL = response.Key.KeyID
+	}
+
+	return &SignerVerifier{
+		keyURL:    keyURL,
+		algorithm: algorithm,
+		hash:      hash,
+		key:       key,
+		verifier:  verifier,
+	}, nil
+}
+
+// LoadPublicKey returns the public key of the Key Vault key identified by
+// keyRef for use in gittuf metadata.
+func LoadPublicKey(ctx context.Context, keyRef string) (*tuf.Key, error) {
+	sv, err := NewSignerVerifierFromURI(ctx, keyRef)
+	if err != nil {
+		return nil, err
+	}
+
+	return sv.key, nil
+}
+
+// Sign computes the digest of data and signs it using Key Vault.
+func (sv *SignerVerifier) Sign(ctx context.Context, data []byte) ([]byte, error) {
+	hasher := sv.hash.New()
+	hasher.Write(data)
+	digest := hasher.Sum(nil)
+
+	requestBody := map[string]string{
+		"alg":   sv.algorithm,
+		"value": base64.RawURLEncoding.EncodeToString(digest),
+	}
+	response := struct {
+		Value string `json:"value"`
+	}{}
+	if err := request(ctx, http.MethodPost, sv.keyURL+"/sign", requestBody, &response); err != nil {
+		return nil, err
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(response.Value, "="))
+	if err != nil {
+		return nil, errors.Join(ErrInvalidSignature, err)
+	}
+
+	if strings.HasPrefix(sv.algorithm, "ES") {
+		// Key Vault returns ECDSA signatures as r || s, gittuf expects them
+		// to be ASN.1 encoded
+		return encodeECDSASignature(signature)
+	}
+
+	return signature, nil
+}
+
+// Verify verifies sig against data using the key's public key.
+func (sv *SignerVerifier) Verify(ctx context.Context, data, sig []byte) error {
+	return sv.verifier.Verify(ctx, data, sig)
+}
+
+// KeyID returns the identifier of the key's public key as recorded in gittuf
+// metadata.
+func (sv *SignerVerifier) KeyID() (string, error) {
+	return sv.key.KeyID, nil
+}
+
+// Public returns the public key of the Key Vault key.
+func (sv *SignerVerifier) Public() crypto.PublicKey {
+	return sv.verifier.Public()
+}
+
+type jsonWebKey struct {
+	KeyID   string `json:"kid"`
+	KeyType string `json:"kty"`
+	Curve   string `json:"crv"`
+	X       string `json:"x"`
+	Y       string `json:"y"`
+	N       string `json:"n"`
+	E       string `json:"e"`
+}
+
+// publicKey returns the public key and the Key Vault signing algorithm and
+// hash function that produce signatures compatible with gittuf's ECDSA and
+// RSA-PSS verifiers.
+func (k *jsonWebKey) publicKey() (crypto.PublicKey, string, crypto.Hash, error) {
+	switch k.KeyType {
+	case "EC", "EC-HSM":
+		var (
+			curve     elliptic.Curve
+			algorithm string
+			hash      crypto.Hash
+		)
+		switch k.Curve {
+		case "P-256":
+			curve, algorithm, hash = elliptic.P256(), "ES256", crypto.SHA256
+		case "P-384":
+			curve, algorithm, hash = elliptic.P384(), "ES384", crypto.SHA384
+		case "P-521":
+			curve, algorithm, hash = elliptic.P521(), "ES512", crypto.SHA512
+		default:
+			return nil, "", 0, fmt.Errorf("%w: curve %s", ErrUnsupportedKey, k.Curve)
+		}
+
+		x, err := decodeBigInt(k.X)
+		if err != nil {
+			return nil, "", 0, err
+		}
+		y, err := decodeBigInt(k.Y)
+		if err != nil {
+			return nil, "", 0, err
+		}
+
+		return &ecdsa.PublicKey{Curve: curve, X: x, Y: y}, algorithm, hash, nil
+	case "RSA", "RSA-HSM":
+		n, err := decodeBigInt(k.N)
+		if err != nil {
+			return nil, "", 0, err
+		}
+		e, err := decodeBigInt(k.E)
+		if err != nil {
+			return nil, "", 0, err
+		}
+
+		return &rsa.PublicKey{N: n, E: int(e.Int64())}, "PS256", crypto.SHA256, nil
+	default:
+		return nil, "", 0, fmt.Errorf("%w: %s", ErrUnsupportedKey, k.KeyType)
+	}
+}
+
+func decodeBigInt(value string) (*big.Int, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(value, "="))
+	if err != nil {
+		return nil, errors.Join(ErrUnsupportedKey, err)
+	}
+
+	return new(big.Int).SetBytes(decoded), nil
+}
+
+func encodeECDSASignature(signature []byte) ([]byte, error) {
+	if len(signature) == 0 || len(signature)%2 != 0 {
+		return nil, ErrInvalidSignature
+	}
+
+	r := new(big.Int).SetBytes(signature[:len(signature)/2])
+	s := new(big.Int).SetBytes(signature[len(signature)/2:])
+
+	var builder cryptobyte.Builder
+	builder.AddASN1(asn1.SEQUENCE, func(b *cryptobyte.Builder) {
+		b.AddASN1BigInt(r)
+		b.AddASN1BigInt(s)
+	})
+	return builder.Bytes()
+}
+
+func parseKeyReference(keyRef string) (string, error) {
+	if !strings.HasPrefix(keyRef, KeyReferencePrefix) {
+		return "", ErrInvalidKeyReference
+	}
+
+	components := strings.Split(strings.TrimPrefix(keyRef, KeyReferencePrefix), "/")
+	if len(components) < 2 || len(components) > 3 {
+		return "", fmt.Errorf("%w: expected %s<vault-name>.vault.azure.net/<key>[/<version>]", ErrInvalidKeyReference, KeyReferencePrefix)
+	}
+	for _, component := range components {
+		if component == "" {
+			return "", fmt.Errorf("%w: expected %s<vault-name>.vault.azure.net/<key>[/<version>]", ErrInvalidKeyReference, KeyReferencePrefix)
+		}
+	}
+
+	return fmt.Sprintf("%s://%s/keys/%s", vaultScheme, components[0], strings.Join(components[1:], "/")), nil
+}
+
+func request(ctx context.Context, method, url string, requestBody, response any) error {
+	token, err := getAccessToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	var body io.Reader
+	if requestBody != nil {
+		encodedBody, err := json.Marshal(requestBody)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(encodedBody)
+	}
+
+	httpRequest, err := http.NewRequestWithContext(ctx, method, fmt.Sprintf("%s?api-version=%s", url, apiVersion), body)
+	if err != nil {
+		return err
+	}
+	httpRequest.Header.Set("Authorization", "Bearer "+token)
+	httpRequest.Header.Set("Content-Type", "application/json")
+
+	httpResponse, err := http.DefaultClient.Do(httpRequest)
+	if err != nil {
+		return errors.Join(ErrKeyVaultRequest, err)
+	}
+	defer httpResponse.Body.Close() //nolint:errcheck
+
+	responseBody, err := io.ReadAll(httpResponse.Body)
+	if err != nil {
+		return errors.Join(ErrKeyVaultRequest, err)
+	}
+	if httpResponse.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: %s: %s", ErrKeyVaultRequest, httpResponse.Status, strings.TrimSpace(string(responseBody)))
+	}
+
+	if err := json.Unmarshal(responseBody, response); err != nil {
+		return errors.Join(ErrKeyVaultRequest, err)
+	}
+
+	return nil
+}
+
+func getAccessToken(ctx context.Context) (string, error) {
+	if token := os.Getenv(AccessTokenEnvVar); token != "" {
+		return token, nil
+	}
+
+	output, err := exec.CommandContext(ctx, "az", "account", "get-access-token", "--resource", "https://vault.azure.net", "--query", "accessToken", "--output", "tsv").Output()
+	if err != nil {
+		return "", errors.Join(ErrNoAccessToken, err)
+	}
+
+	return strings.TrimSpace(string(output)), nil
+}
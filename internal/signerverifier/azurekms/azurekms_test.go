@@ -0,0 +1,126 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package azurekms
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	sslibsv "github.com/gittuf/gittuf/internal/third_party/go-securesystemslib/signerverifier"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignerVerifier(t *testing.T) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer test-token" || r.URL.Query().Get("api-version") != apiVersion {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/keys/root":
+			json.NewEncoder(w).Encode(map[string]any{ //nolint:errcheck
+				"key": map[string]string{
+					"kid": server.URL + "/keys/root/1",
+					"kty": "EC",
+					"crv": "P-256",
+					"x":   base64.RawURLEncoding.EncodeToString(privateKey.X.FillBytes(make([]byte, 32))),
+					"y":   base64.RawURLEncoding.EncodeToString(privateKey.Y.FillBytes(make([]byte, 32))),
+				},
+			})
+		case "/keys/root/1/sign":
+			request := map[string]string{}
+			if err := json.NewDecoder(r.Body).Decode(&request); err != nil || request["alg"] != "ES256" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			digest, err := base64.RawURLEncoding.DecodeString(request["value"])
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			r, s, err := ecdsa.Sign(rand.Reader, privateKey, digest)
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			signature := append(r.FillBytes(make([]byte, 32)), s.FillBytes(make([]byte, 32))...)
+			json.NewEncoder(w).Encode(map[string]string{"value": base64.RawURLEncoding.EncodeToString(signature)}) //nolint:errcheck
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	originalScheme := vaultScheme
+	vaultScheme = "http"
+	defer func() { vaultScheme = originalScheme }()
+	t.Setenv(AccessTokenEnvVar, "test-token")
+
+	ctx := context.Background()
+	keyRef := KeyReferencePrefix + strings.TrimPrefix(server.URL, "http://") + "/root"
+
+	sv, err := NewSignerVerifierFromURI(ctx, keyRef)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedKey, err := sslibsv.NewKey(privateKey.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyID, err := sv.KeyID()
+	assert.Nil(t, err)
+	assert.Equal(t, expectedKey.KeyID, keyID)
+
+	key, err := LoadPublicKey(ctx, keyRef)
+	assert.Nil(t, err)
+	assert.Equal(t, expectedKey, key)
+
+	data := []byte("test data")
+	signature, err := sv.Sign(ctx, data)
+	assert.Nil(t, err)
+	assert.Nil(t, sv.Verify(ctx, data, signature))
+
+	// Signatures must also verify using the key recorded in policy
+	verifier, err := sslibsv.NewVerifierFromSSLibKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Nil(t, verifier.Verify(ctx, data, signature))
+	assert.NotNil(t, verifier.Verify(ctx, []byte("other data"), signature))
+}
+
+func TestParseKeyReference(t *testing.T) {
+	keyURL, err := parseKeyReference(KeyReferencePrefix + "gittuf.vault.azure.net/root")
+	assert.Nil(t, err)
+	assert.Equal(t, "https://gittuf.vault.azure.net/keys/root", keyURL)
+
+	keyURL, err = parseKeyReference(KeyReferencePrefix + "gittuf.vault.azure.net/root/1234")
+	assert.Nil(t, err)
+	assert.Equal(t, "https://gittuf.vault.azure.net/keys/root/1234", keyURL)
+
+	_, err = parseKeyReference(KeyReferencePrefix + "gittuf.vault.azure.net")
+	assert.ErrorIs(t, err, ErrInvalidKeyReference)
+
+	_, err = parseKeyReference(KeyReferencePrefix + "gittuf.vault.azure.net//1234")
+	assert.ErrorIs(t, err, ErrInvalidKeyReference)
+
+	_, err = parseKeyReference("gittuf.vault.azure.net/root")
+	assert.ErrorIs(t, err, ErrInvalidKeyReference)
+}
@@ -5,19 +5,19 @@ package dsse
 import (
 	"context"
 	"encoding/base64"
-	"encoding/json"
 
 	"github.com/gittuf/gittuf/internal/signerverifier/common"
+	"github.com/gittuf/gittuf/internal/tuf"
 	"github.com/secure-systems-lab/go-securesystemslib/dsse"
 )
 
 const PayloadType = "application/vnd.gittuf+json"
 
 // CreateEnvelope is an opinionated interface to create a DSSE envelope. It
-// accepts instances of tuf.RootMetadata, tuf.TargetsMetadata, etc. and marshals
-// the input prior to storing it as the envelope's payload.
+// accepts instances of tuf.RootMetadata, tuf.TargetsMetadata, etc. and encodes
+// the input canonically prior to storing it as the envelope's payload.
 func CreateEnvelope(v any) (*dsse.Envelope, error) {
-	b, err := json.Marshal(v)
+	b, err := tuf.EncodeCanonical(v)
 	if err != nil {
 		return nil, err
 	}
@@ -85,6 +85,65 @@ func VerifyEnvelope(ctx context.Context, envelope *dsse.Envelope, verifiers []ds
 		return err
 	}
 
-	_, err = ev.Verify(ctx, envelope)
+	_, err = ev.Verify(ctx, matchableEnvelope(envelope, verifiers))
 	return err
 }
+
+// GetAcceptedKeyIDs verifies a DSSE envelope using the verifiers passed into it
+// and returns the IDs of the keys with valid signatures on the envelope. At
+// least one signature must be valid.
+func GetAcceptedKeyIDs(ctx context.Context, envelope *dsse.Envelope, verifiers []dsse.Verifier) ([]string, error) {
+	if len(verifiers) == 0 {
+		return nil, common.ErrInvalidThreshold
+	}
+
+	ev, err := dsse.NewMultiEnvelopeVerifier(1, verifiers...)
+	if err != nil {
+		return nil, err
+	}
+
+	acceptedKeys, err := ev.Verify(ctx, matchableEnvelope(envelope, verifiers))
+	if err != nil {
+		return nil, err
+	}
+
+	keyIDs := make([]string, 0, len(acceptedKeys))
+	for _, acceptedKey := range acceptedKeys {
+		keyIDs = append(keyIDs, acceptedKey.KeyID)
+	}
+
+	return keyIDs, nil
+}
+
+// matchableEnvelope returns a copy of envelope in which the key IDs of
+// signatures that do not match any verifier's key ID are cleared. Verifiers
+// only check signatures with matching key IDs, so this allows a verifier for a
+// pattern of identities, such as a Sigstore identity pattern, to check
+// signatures from the identities it matches.
+func matchableEnvelope(envelope *dsse.Envelope, verifiers []dsse.Verifier) *dsse.Envelope {
+	if envelope == nil {
+		return nil
+	}
+
+	verifierKeyIDs := map[string]bool{}
+	for _, verifier := range verifiers {
+		keyID, err := verifier.KeyID()
+		if err == nil {
+			verifierKeyIDs[keyID] = true
+		}
+	}
+
+	signatures := make([]dsse.Signature, 0, len(envelope.Signatures))
+	for _, signature := range envelope.Signatures {
+		if !verifierKeyIDs[signature.KeyID] {
+			signature.KeyID = ""
+		}
+		signatures = append(signatures, signature)
+	}
+
+	return &dsse.Envelope{
+		PayloadType: envelope.PayloadType,
+		Payload:     envelope.Payload,
+		Signatures:  signatures,
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/gittuf/gittuf/internal/signerverifier"
+	"github.com/gittuf/gittuf/internal/signerverifier/common"
 	artifacts "github.com/gittuf/gittuf/internal/testartifacts"
 	"github.com/gittuf/gittuf/internal/tuf"
 	sslibdsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
@@ -24,7 +25,7 @@ func TestCreateEnvelope(t *testing.T) {
 	env, err := CreateEnvelope(rootMetadata)
 	assert.Nil(t, err)
 	assert.Equal(t, PayloadType, env.PayloadType)
-	assert.Equal(t, "eyJ0eXBlIjoicm9vdCIsInNwZWNfdmVyc2lvbiI6IjEuMCIsImNvbnNpc3RlbnRfc25hcHNob3QiOnRydWUsInZlcnNpb24iOjAsImV4cGlyZXMiOiIiLCJrZXlzIjpudWxsLCJyb2xlcyI6bnVsbH0=", env.Payload)
+	assert.Equal(t, "eyJjb25zaXN0ZW50X3NuYXBzaG90Ijp0cnVlLCJleHBpcmVzIjoiIiwia2V5cyI6bnVsbCwicm9sZXMiOm51bGwsInNjaGVtYV92ZXJzaW9uIjoxLCJzcGVjX3ZlcnNpb24iOiIxLjAiLCJ0eXBlIjoicm9vdCIsInZlcnNpb24iOjB9", env.Payload)
 }
 
 func TestSignEnvelope(t *testing.T) {
@@ -87,3 +88,34 @@ func createSignedEnvelope() (*sslibdsse.Envelope, error) {
 
 	return env, nil
 }
+
+func TestGetAcceptedKeyIDs(t *testing.T) {
+	env, err := createSignedEnvelope()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key, err := tuf.LoadKeyFromBytes(publicKeyBytes)
+	if err != nil {
+		t.Fatal(err)
+	}
+	verifier, err := signerverifier.NewSignerVerifierFromTUFKey(key) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyIDs, err := GetAcceptedKeyIDs(context.Background(), env, []sslibdsse.Verifier{verifier})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{key.KeyID}, keyIDs)
+
+	// Signatures whose key ID matches no verifier are checked by all
+	// verifiers, as is the case for identity patterns
+	env.Signatures[0].KeyID = "jane.doe@example.com::https://github.com/login/oauth"
+	keyIDs, err = GetAcceptedKeyIDs(context.Background(), env, []sslibdsse.Verifier{verifier})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{key.KeyID}, keyIDs)
+	assert.Equal(t, "jane.doe@example.com::https://github.com/login/oauth", env.Signatures[0].KeyID)
+
+	_, err = GetAcceptedKeyIDs(context.Background(), env, nil)
+	assert.ErrorIs(t, err, common.ErrInvalidThreshold)
+}
@@ -0,0 +1,203 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package signerverifier
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ed25519"
+	"crypto/sha1" //nolint:gosec
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"hash"
+
+	sslibsv "github.com/gittuf/gittuf/internal/third_party/go-securesystemslib/signerverifier"
+	"golang.org/x/crypto/pbkdf2"
+	"golang.org/x/crypto/ssh"
+)
+
+const encryptedPKCS8PEMType = "ENCRYPTED PRIVATE KEY"
+
+var (
+	ErrIncorrectPassphrase      = errors.New("incorrect passphrase for private key")
+	ErrUnsupportedKeyEncryption = errors.New("unsupported private key encryption")
+)
+
+// IsEncryptedPrivateKey returns true if the PEM encoded private key is
+// protected with a passphrase. The OpenSSH private key format, the PKCS #8
+// EncryptedPrivateKeyInfo format, and the legacy PEM encryption used by
+// OpenSSL for PKCS #1 and SEC 1 keys are recognized.
+func IsEncryptedPrivateKey(keyBytes []byte) bool {
+	block, _ := pem.Decode(keyBytes)
+	if block == nil {
+		return false
+	}
+
+	switch {
+	case block.Type == encryptedPKCS8PEMType:
+		return true
+	case x509.IsEncryptedPEMBlock(block): //nolint:staticcheck
+		return true
+	default:
+		var missingErr *ssh.PassphraseMissingError
+		_, err := ssh.ParseRawPrivateKey(keyBytes)
+		return errors.As(err, &missingErr)
+	}
+}
+
+// DecryptPrivateKey decrypts the passphrase protected private key and returns
+// it as a PEM encoded PKCS #8 private key, which can be loaded like any
+// unencrypted private key.
+func DecryptPrivateKey(keyBytes, passphrase []byte) ([]byte, error) {
+	block, _ := pem.Decode(keyBytes)
+	if block == nil {
+		return nil, sslibsv.ErrNoPEMBlock
+	}
+
+	var (
+		privateKey any
+		err        error
+	)
+	if block.Type == encryptedPKCS8PEMType {
+		privateKey, err = decryptPKCS8PrivateKey(block.Bytes, passphrase)
+	} else {
+		privateKey, err = ssh.ParseRawPrivateKeyWithPassphrase(keyBytes, passphrase)
+		if errors.Is(err, x509.IncorrectPasswordError) {
+			err = ErrIncorrectPassphrase
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// The OpenSSH format returns a pointer to ED25519 keys
+	if key, ok := privateKey.(*ed25519.PrivateKey); ok {
+		privateKey = *key
+	}
+
+	privateKeyBytes, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: sslibsv.PrivateKeyPEM, Bytes: privateKeyBytes}), nil
+}
+
+var (
+	oidPBES2  = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 13}
+	oidPBKDF2 = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 12}
+
+	oidHMACWithSHA1   = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 7}
+	oidHMACWithSHA256 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 9}
+	oidHMACWithSHA512 = asn1.ObjectIdentifier{1, 2, 840, 113549, 2, 11}
+
+	oidAES128CBC = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 2}
+	oidAES192CBC = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 22}
+	oidAES256CBC = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 42}
+)
+
+// encryptedPrivateKeyInfo is defined in RFC 5958.
+type encryptedPrivateKeyInfo struct {
+	EncryptionAlgorithm pkix.AlgorithmIdentifier
+	EncryptedData       []byte
+}
+
+// pbes2Params is defined in RFC 8018.
+type pbes2Params struct {
+	KeyDerivationFunc pkix.AlgorithmIdentifier
+	EncryptionScheme  pkix.AlgorithmIdentifier
+}
+
+// pbkdf2Params is defined in RFC 8018.
+type pbkdf2Params struct {
+	Salt           []byte
+	IterationCount int
+	KeyLength      int                      `asn1:"optional"`
+	PRF            pkix.AlgorithmIdentifier `asn1:"optional"`
+}
+
+// decryptPKCS8PrivateKey decrypts a DER encoded PKCS #8
+// EncryptedPrivateKeyInfo. Only PBES2 with PBKDF2 and AES-CBC is supported,
+// which is what OpenSSL uses by default.
+func decryptPKCS8PrivateKey(der, passphrase []byte) (any, error) {
+	var info encryptedPrivateKeyInfo
+	if _, err := asn1.Unmarshal(der, &info); err != nil {
+		return nil, err
+	}
+	if !info.EncryptionAlgorithm.Algorithm.Equal(oidPBES2) {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedKeyEncryption, info.EncryptionAlgorithm.Algorithm)
+	}
+
+	var params pbes2Params
+	if _, err := asn1.Unmarshal(info.EncryptionAlgorithm.Parameters.FullBytes, &params); err != nil {
+		return nil, err
+	}
+	if !params.KeyDerivationFunc.Algorithm.Equal(oidPBKDF2) {
+		return nil, fmt.Errorf("%w: key derivation function %s", ErrUnsupportedKeyEncryption, params.KeyDerivationFunc.Algorithm)
+	}
+
+	var kdfParams pbkdf2Params
+	if _, err := asn1.Unmarshal(params.KeyDerivationFunc.Parameters.FullBytes, &kdfParams); err != nil {
+		return nil, err
+	}
+
+	var prf func() hash.Hash
+	switch {
+	case len(kdfParams.PRF.Algorithm) == 0, kdfParams.PRF.Algorithm.Equal(oidHMACWithSHA1):
+		prf = sha1.New
+	case kdfParams.PRF.Algorithm.Equal(oidHMACWithSHA256):
+		prf = sha256.New
+	case kdfParams.PRF.Algorithm.Equal(oidHMACWithSHA512):
+		prf = sha512.New
+	default:
+		return nil, fmt.Errorf("%w: pseudorandom function %s", ErrUnsupportedKeyEncryption, kdfParams.PRF.Algorithm)
+	}
+
+	var keyLength int
+	switch {
+	case params.EncryptionScheme.Algorithm.Equal(oidAES128CBC):
+		keyLength = 16
+	case params.EncryptionScheme.Algorithm.Equal(oidAES192CBC):
+		keyLength = 24
+	case params.EncryptionScheme.Algorithm.Equal(oidAES256CBC):
+		keyLength = 32
+	default:
+		return nil, fmt.Errorf("%w: cipher %s", ErrUnsupportedKeyEncryption, params.EncryptionScheme.Algorithm)
+	}
+
+	var iv []byte
+	if _, err := asn1.Unmarshal(params.EncryptionScheme.Parameters.FullBytes, &iv); err != nil {
+		return nil, err
+	}
+	if len(iv) != aes.BlockSize || len(info.EncryptedData) == 0 || len(info.EncryptedData)%aes.BlockSize != 0 {
+		return nil, ErrIncorrectPassphrase
+	}
+
+	key := pbkdf2.Key(passphrase, kdfParams.Salt, kdfParams.IterationCount, keyLength, prf)
+	blockCipher, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	plaintext := make([]byte, len(info.EncryptedData))
+	cipher.NewCBCDecrypter(blockCipher, iv).CryptBlocks(plaintext, info.EncryptedData)
+
+	// An incorrect passphrase is usually detected by invalid PKCS #7 padding,
+	// otherwise by the decrypted key failing to parse
+	padding := int(plaintext[len(plaintext)-1])
+	if padding == 0 || padding > aes.BlockSize || !bytes.Equal(plaintext[len(plaintext)-padding:], bytes.Repeat([]byte{byte(padding)}, padding)) {
+		return nil, ErrIncorrectPassphrase
+	}
+
+	privateKey, err := x509.ParsePKCS8PrivateKey(plaintext[:len(plaintext)-padding])
+	if err != nil {
+		return nil, ErrIncorrectPassphrase
+	}
+	return privateKey, nil
+}
@@ -0,0 +1,89 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package signerverifier
+
+import (
+	"context"
+	"testing"
+
+	artifacts "github.com/gittuf/gittuf/internal/testartifacts"
+	sslibsv "github.com/gittuf/gittuf/internal/third_party/go-securesystemslib/signerverifier"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecryptPrivateKey(t *testing.T) {
+	publicKey, err := tuf.LoadKeyFromBytes(artifacts.SSHECDSAPublic)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string][]byte{
+		"OpenSSH":    artifacts.EncryptedOpenSSHECDSAPrivate,
+		"PKCS #8":    artifacts.EncryptedPKCS8ECDSAPrivate,
+		"legacy PEM": artifacts.EncryptedLegacyPEMECDSAPrivate,
+	}
+
+	for name, keyBytes := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.True(t, IsEncryptedPrivateKey(keyBytes))
+
+			_, err := DecryptPrivateKey(keyBytes, []byte("incorrect"))
+			assert.ErrorIs(t, err, ErrIncorrectPassphrase)
+
+			decryptedKey, err := DecryptPrivateKey(keyBytes, []byte(artifacts.EncryptedKeyPassphrase))
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.False(t, IsEncryptedPrivateKey(decryptedKey))
+
+			signer, err := sslibsv.NewSignerVerifierFromPEM(decryptedKey)
+			if err != nil {
+				t.Fatal(err)
+			}
+			keyID, err := signer.KeyID()
+			assert.Nil(t, err)
+			assert.Equal(t, publicKey.KeyID, keyID)
+		})
+	}
+
+	t.Run("generated key", func(t *testing.T) {
+		privateKey, publicKey, err := GenerateKeyPair(ED25519KeyType)
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.False(t, IsEncryptedPrivateKey(privateKey))
+
+		encryptedKey, err := EncryptPrivateKey(privateKey, []byte(artifacts.EncryptedKeyPassphrase))
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.True(t, IsEncryptedPrivateKey(encryptedKey))
+
+		decryptedKey, err := DecryptPrivateKey(encryptedKey, []byte(artifacts.EncryptedKeyPassphrase))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		signer, err := sslibsv.NewSignerVerifierFromPEM(decryptedKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		key, err := tuf.LoadKeyFromBytes(publicKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+		keyID, err := signer.KeyID()
+		assert.Nil(t, err)
+		assert.Equal(t, key.KeyID, keyID)
+
+		_, err = signer.Sign(context.Background(), []byte("message"))
+		assert.Nil(t, err)
+	})
+
+	t.Run("unencrypted keys", func(t *testing.T) {
+		assert.False(t, IsEncryptedPrivateKey(artifacts.SSHECDSAPrivate))
+		assert.False(t, IsEncryptedPrivateKey(artifacts.SSHECDSAPublic))
+		assert.False(t, IsEncryptedPrivateKey([]byte("not a key")))
+	})
+}
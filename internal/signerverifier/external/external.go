@@ -0,0 +1,161 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Package external implements a signer that delegates signing to a program
+// provided by the user, similar to Git's gpg.program. The program is invoked
+// with one of the following subcommands appended to its arguments:
+//
+//   - public-key: the program writes the PEM encoded public key (RSA, ECDSA,
+//     or ED25519) to stdout.
+//   - sign: the program reads the payload to sign from stdin and writes the
+//     base64 encoded signature to stdout. The signature must use the scheme
+//     gittuf uses for the key type: RSA-PSS with SHA-256, ECDSA with a hash
+//     matching the curve size (ASN.1 encoded), or ED25519.
+//
+// A non-zero exit code indicates failure, the program's stderr is included in
+// the error returned by gittuf.
+package external
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	sslibsv "github.com/gittuf/gittuf/internal/third_party/go-securesystemslib/signerverifier"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/secure-systems-lab/go-securesystemslib/dsse"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+)
+
+const (
+	// KeyReferencePrefix identifies external signer programs, for example
+	// exec:/usr/local/bin/my-signer --profile release. The program and its
+	// arguments are separated by whitespace.
+	KeyReferencePrefix = "exec:"
+
+	publicKeyCommand = "public-key"
+	signCommand      = "sign"
+)
+
+var (
+	ErrInvalidKeyReference = errors.New("invalid external signer reference, expected exec:<program> [args]")
+	ErrSignerProgramFailed = errors.New("external signer program failed")
+)
+
+// SignerVerifier implements the dsse.SignerVerifier interface using an
+// external signer program. Signatures are verified locally using the public
+// key reported by the program.
+type SignerVerifier struct {
+	program  string
+	args     []string
+	key      *tuf.Key
+	verifier dsse.SignerVerifier
+}
+
+// NewSignerVerifierFromURI returns a SignerVerifier for the program identified
+// by keyRef.
+func NewSignerVerifierFromURI(ctx context.Context, keyRef string) (*SignerVerifier, error) {
+	if !strings.HasPrefix(keyRef, KeyReferencePrefix) {
+		return nil, ErrInvalidKeyReference
+	}
+
+	command := strings.Fields(strings.TrimPrefix(keyRef, KeyReferencePrefix))
+	if len(command) == 0 {
+		return nil, ErrInvalidKeyReference
+	}
+
+	sv := &SignerVerifier{
+		program: command[0],
+		args:    command[1:],
+	}
+
+	output, err := sv.run(ctx, publicKeyCommand, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	publicKey, err := cryptoutils.UnmarshalPEMToPublicKey(output)
+	if err != nil {
+		return nil, fmt.Errorf("%w: unable to parse public key: %w", ErrSignerProgramFailed, err)
+	}
+
+	sv.key, err = sslibsv.NewKey(publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	sv.verifier, err = sslibsv.NewVerifierFromSSLibKey(sv.key)
+	if err != nil {
+		return nil, err
+	}
+
+	return sv, nil
+}
+
+// LoadPublicKey returns the public key reported by the program identified by
+// keyRef for use in gittuf metadata.
+func LoadPublicKey(ctx context.Context, keyRef string) (*tuf.Key, error) {
+	sv, err := NewSignerVerifierFromURI(ctx, keyRef)
+	if err != nil {
+		return nil, err
+	}
+
+	return sv.key, nil
+}
+
+// Sign passes data to the program and returns the signature it creates. The
+// signature is checked against the program's public key so that a misbehaving
+// program is caught before metadata is written.
+func (sv *SignerVerifier) Sign(ctx context.Context, data []byte) ([]byte, error) {
+	output, err := sv.run(ctx, signCommand, data)
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(strings.TrimSpace(string(output)))
+	if err != nil {
+		return nil, fmt.Errorf("%w: unable to decode signature: %w", ErrSignerProgramFailed, err)
+	}
+
+	if err := sv.verifier.Verify(ctx, data, signature); err != nil {
+		return nil, fmt.Errorf("%w: signature does not match public key: %w", ErrSignerProgramFailed, err)
+	}
+
+	return signature, nil
+}
+
+// Verify verifies sig against data using the program's public key.
+func (sv *SignerVerifier) Verify(ctx context.Context, data, sig []byte) error {
+	return sv.verifier.Verify(ctx, data, sig)
+}
+
+// KeyID returns the identifier of the program's public key as recorded in
+// gittuf metadata.
+func (sv *SignerVerifier) KeyID() (string, error) {
+	return sv.key.KeyID, nil
+}
+
+// Public returns the public key reported by the program.
+func (sv *SignerVerifier) Public() crypto.PublicKey {
+	return sv.verifier.Public()
+}
+
+func (sv *SignerVerifier) run(ctx context.Context, subcommand string, stdin []byte) ([]byte, error) {
+	args := append(append([]string{}, sv.args...), subcommand)
+
+	cmd := exec.CommandContext(ctx, sv.program, args...)
+	cmd.Stdin = bytes.NewReader(stdin)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w: %s", ErrSignerProgramFailed, err, strings.TrimSpace(stderr.String()))
+	}
+
+	return output, nil
+}
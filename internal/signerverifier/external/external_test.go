@@ -0,0 +1,119 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package external
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"testing"
+
+	artifacts "github.com/gittuf/gittuf/internal/testartifacts"
+	sslibsv "github.com/gittuf/gittuf/internal/third_party/go-securesystemslib/signerverifier"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"github.com/stretchr/testify/assert"
+)
+
+const helperEnvVar = "GITTUF_TEST_EXTERNAL_SIGNER"
+
+// TestHelperProcess is not a real test, it acts as the external signer
+// program when the test binary is invoked by the tests below.
+func TestHelperProcess(t *testing.T) {
+	mode := os.Getenv(helperEnvVar)
+	if mode == "" {
+		t.Skip("only used as external signer program")
+	}
+
+	signer, err := sslibsv.NewSignerVerifierFromPEM(artifacts.SSHECDSAPrivate)
+	if err != nil {
+		fmt.Fprint(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	switch os.Args[len(os.Args)-1] {
+	case publicKeyCommand:
+		publicKey, err := cryptoutils.MarshalPublicKeyToPEM(signer.Public())
+		if err != nil {
+			fmt.Fprint(os.Stderr, err)
+			os.Exit(1)
+		}
+		fmt.Print(string(publicKey))
+	case signCommand:
+		data, err := io.ReadAll(os.Stdin)
+		if err != nil {
+			fmt.Fprint(os.Stderr, err)
+			os.Exit(1)
+		}
+		if mode == "bad-signature" {
+			data = append(data, 'x')
+		}
+
+		signature, err := signer.Sign(context.Background(), data)
+		if err != nil {
+			fmt.Fprint(os.Stderr, err)
+			os.Exit(1)
+		}
+		fmt.Println(base64.StdEncoding.EncodeToString(signature))
+	default:
+		fmt.Fprint(os.Stderr, "unknown command")
+		os.Exit(1)
+	}
+
+	os.Exit(0)
+}
+
+func TestSignerVerifier(t *testing.T) {
+	keyRef := fmt.Sprintf("%s%s -test.run=TestHelperProcess --", KeyReferencePrefix, os.Args[0])
+	ctx := context.Background()
+
+	t.Run("valid signer", func(t *testing.T) {
+		t.Setenv(helperEnvVar, "valid")
+
+		sv, err := NewSignerVerifierFromURI(ctx, keyRef)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expectedKey, err := sslibsv.LoadKey(artifacts.SSHECDSAPublic)
+		if err != nil {
+			t.Fatal(err)
+		}
+		keyID, err := sv.KeyID()
+		assert.Nil(t, err)
+		assert.Equal(t, expectedKey.KeyID, keyID)
+
+		key, err := LoadPublicKey(ctx, keyRef)
+		assert.Nil(t, err)
+		assert.Equal(t, expectedKey.KeyID, key.KeyID)
+
+		data := []byte("test data")
+		signature, err := sv.Sign(ctx, data)
+		assert.Nil(t, err)
+		assert.Nil(t, sv.Verify(ctx, data, signature))
+		assert.NotNil(t, sv.Verify(ctx, []byte("other data"), signature))
+	})
+
+	t.Run("signer returns invalid signature", func(t *testing.T) {
+		t.Setenv(helperEnvVar, "bad-signature")
+
+		sv, err := NewSignerVerifierFromURI(ctx, keyRef)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = sv.Sign(ctx, []byte("test data"))
+		assert.ErrorIs(t, err, ErrSignerProgramFailed)
+	})
+
+	t.Run("program fails", func(t *testing.T) {
+		_, err := NewSignerVerifierFromURI(ctx, KeyReferencePrefix+"false")
+		assert.ErrorIs(t, err, ErrSignerProgramFailed)
+	})
+
+	t.Run("no program", func(t *testing.T) {
+		_, err := NewSignerVerifierFromURI(ctx, KeyReferencePrefix+"  ")
+		assert.ErrorIs(t, err, ErrInvalidKeyReference)
+	})
+}
@@ -0,0 +1,87 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package signerverifier
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"strings"
+
+	"github.com/gittuf/gittuf/internal/signerverifier/common"
+	sslibsv "github.com/gittuf/gittuf/internal/third_party/go-securesystemslib/signerverifier"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"golang.org/x/crypto/ssh"
+)
+
+// Fingerprints are the fingerprints of a public key in the formats commonly
+// used by other tools, to help confirm that a gittuf key ID refers to the
+// expected key.
+type Fingerprints struct {
+	// SSHSHA256 is the fingerprint printed by `ssh-keygen -l`.
+	SSHSHA256 string `json:"sshSHA256"`
+
+	// SSHMD5 is the fingerprint printed by `ssh-keygen -l -E md5`.
+	SSHMD5 string `json:"sshMD5"`
+
+	// PKIXSHA256 is the hex encoded SHA-256 digest of the DER encoded PKIX
+	// public key, as printed by `openssl pkey -pubin -outform DER | sha256sum`.
+	PKIXSHA256 string `json:"pkixSHA256"`
+
+	// AuthorizedKey is the public key in the OpenSSH authorized_keys format.
+	AuthorizedKey string `json:"authorizedKey"`
+}
+
+// GetFingerprints returns the fingerprints of an RSA, ECDSA, or ED25519 key.
+// Other keys, such as Sigstore identities, do not have fingerprints.
+func GetFingerprints(key *tuf.Key) (*Fingerprints, error) {
+	publicKey, err := getPublicKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	sshPublicKey, err := ssh.NewPublicKey(publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	pkixBytes, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		return nil, err
+	}
+	pkixDigest := sha256.Sum256(pkixBytes)
+
+	return &Fingerprints{
+		SSHSHA256:     ssh.FingerprintSHA256(sshPublicKey),
+		SSHMD5:        "MD5:" + ssh.FingerprintLegacyMD5(sshPublicKey),
+		PKIXSHA256:    hex.EncodeToString(pkixDigest[:]),
+		AuthorizedKey: strings.TrimSpace(string(ssh.MarshalAuthorizedKey(sshPublicKey))),
+	}, nil
+}
+
+// getPublicKey returns the crypto.PublicKey for the key. ED25519 keys are hex
+// encoded, while RSA and ECDSA keys are PEM encoded.
+func getPublicKey(key *tuf.Key) (crypto.PublicKey, error) {
+	switch key.KeyType {
+	case ED25519KeyType:
+		publicBytes, err := hex.DecodeString(key.KeyVal.Public)
+		if err != nil {
+			return nil, err
+		}
+		if len(publicBytes) != ed25519.PublicKeySize {
+			return nil, common.ErrUnknownKeyType
+		}
+		return ed25519.PublicKey(publicBytes), nil
+	case ECDSAKeyType, RSAKeyType:
+		block, _ := pem.Decode([]byte(key.KeyVal.Public))
+		if block == nil {
+			return nil, sslibsv.ErrNoPEMBlock
+		}
+		return x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, common.ErrUnknownKeyType
+	}
+}
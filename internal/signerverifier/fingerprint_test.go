@@ -0,0 +1,62 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package signerverifier
+
+import (
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/signerverifier/common"
+	"github.com/gittuf/gittuf/internal/signerverifier/sigstore"
+	artifacts "github.com/gittuf/gittuf/internal/testartifacts"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetFingerprints(t *testing.T) {
+	t.Run("ECDSA key", func(t *testing.T) {
+		key, err := tuf.LoadKeyFromBytes(artifacts.SSHECDSAPublic)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Expected values computed using ssh-keygen and openssl
+		fingerprints, err := GetFingerprints(key)
+		assert.Nil(t, err)
+		assert.Equal(t, "SHA256:oNYBImx035m3rl1Sn/+j5DPrlS9+zXn7k3mjNrC5eto", fingerprints.SSHSHA256)
+		assert.Equal(t, "MD5:67:55:5f:ae:f5:b9:27:a2:81:68:a3:24:97:0c:df:91", fingerprints.SSHMD5)
+		assert.Equal(t, "d537b6ae681be6a1bb4591e52c4f6c6a7a9b393c18f3f9501ab3e1d82f7fc88a", fingerprints.PKIXSHA256)
+	})
+
+	t.Run("RSA key", func(t *testing.T) {
+		key, err := tuf.LoadKeyFromBytes(artifacts.SSHRSAPublic)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		fingerprints, err := GetFingerprints(key)
+		assert.Nil(t, err)
+		assert.Equal(t, "SHA256:ESJezAOo+BsiEpddzRXS6+wtF16FID4NCd+3gj96rFo", fingerprints.SSHSHA256)
+	})
+
+	t.Run("ED25519 key", func(t *testing.T) {
+		_, publicKey, err := GenerateKeyPair(ED25519KeyType)
+		if err != nil {
+			t.Fatal(err)
+		}
+		key, err := tuf.LoadKeyFromBytes(publicKey)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		fingerprints, err := GetFingerprints(key)
+		assert.Nil(t, err)
+		assert.Contains(t, fingerprints.AuthorizedKey, "ssh-ed25519 ")
+	})
+
+	t.Run("Sigstore identity", func(t *testing.T) {
+		key := sigstore.NewKey("jane.doe@example.com", "https://github.com/login/oauth")
+
+		_, err := GetFingerprints(key)
+		assert.ErrorIs(t, err, common.ErrUnknownKeyType)
+	})
+}
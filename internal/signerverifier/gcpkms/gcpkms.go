@@ -0,0 +1,231 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package gcpkms
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/exec"
+	"regexp"
+	"strings"
+
+	sslibsv "github.com/gittuf/gittuf/internal/third_party/go-securesystemslib/signerverifier"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/secure-systems-lab/go-securesystemslib/dsse"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+)
+
+const (
+	// KeyReferencePrefix identifies Cloud KMS key references, which have the
+	// form gcpkms://projects/<project>/locations/<location>/keyRings/<ring>/cryptoKeys/<key>/cryptoKeyVersions/<version>.
+	KeyReferencePrefix = "gcpkms://"
+
+	// AccessTokenEnvVar can be set to an OAuth access token to use when
+	// calling Cloud KMS. If unset, the token is obtained using gcloud.
+	AccessTokenEnvVar = "GOOGLE_OAUTH_ACCESS_TOKEN"
+)
+
+var (
+	ErrInvalidKeyReference  = errors.New("invalid Cloud KMS key reference")
+	ErrUnsupportedAlgorithm = errors.New("Cloud KMS key algorithm is not supported by gittuf")
+	ErrNoAccessToken        = errors.New("unable to obtain access token for Cloud KMS")
+	ErrKMSRequestFailed     = errors.New("Cloud KMS request failed")
+)
+
+// endpoint is the Cloud KMS API endpoint, it is a variable to allow tests to
+// use a local server.
+var endpoint = "https://cloudkms.googleapis.com"
+
+var keyVersionPattern = regexp.MustCompile(`^projects/[^/]+/locations/[^/]+/keyRings/[^/]+/cryptoKeys/[^/]+/cryptoKeyVersions/[^/]+$`)
+
+// supportedAlgorithms maps the Cloud KMS algorithms that produce signatures
+// compatible with gittuf's ECDSA and RSA-PSS verifiers to the hash function
+// used to compute the digest that is signed.
+var supportedAlgorithms = map[string]crypto.Hash{
+	"EC_SIGN_P256_SHA256":      crypto.SHA256,
+	"EC_SIGN_P384_SHA384":      crypto.SHA384,
+	"RSA_SIGN_PSS_2048_SHA256": crypto.SHA256,
+	"RSA_SIGN_PSS_3072_SHA256": crypto.SHA256,
+	"RSA_SIGN_PSS_4096_SHA256": crypto.SHA256,
+}
+
+// SignerVerifier implements the dsse.SignerVerifier interface for an
+// asymmetric signing key version stored in Cloud KMS. Signatures are verified
+// locally using the key version's public key.
+type SignerVerifier struct {
+	name     string
+	hash     crypto.Hash
+	key      *tuf.Key
+	verifier dsse.SignerVerifier
+}
+
+// NewSignerVerifierFromURI returns a SignerVerifier for the Cloud KMS key
+// version identified by keyRef.
+func NewSignerVerifierFromURI(ctx context.Context, keyRef string) (*SignerVerifier, error) {
+	name, err := parseKeyReference(keyRef)
+	if err != nil {
+		return nil, err
+	}
+
+	response := struct {
+		PEM       string `json:"pem"`
+		Algorithm string `json:"algorithm"`
+	}{}
+	if err := request(ctx, http.MethodGet, name+"/publicKey", nil, &response); err != nil {
+		return nil, err
+	}
+
+	hash, supported := supportedAlgorithms[response.Algorithm]
+	if !supported {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedAlgorithm, response.Algorithm)
+	}
+
+	publicKey, err := cryptoutils.UnmarshalPEMToPublicKey([]byte(response.PEM))
+	if err != nil {
+		return nil, err
+	}
+
+	key, err := sslibsv.NewKey(publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	verifier, err := sslibsv.NewVerifierFromSSLibKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SignerVerifier{
+		name:     name,
+		hash:     hash,
+		key:      key,
+		verifier: verifier,
+	}, nil
+}
+
+// LoadPublicKey returns the public key of the Cloud KMS key version
+// identified by keyRef for use in gittuf metadata.
+func LoadPublicKey(ctx context.Context, keyRef string) (*tuf.Key, error) {
+	sv, err := NewSignerVerifierFromURI(ctx, keyRef)
+	if err != nil {
+		return nil, err
+	}
+
+	return sv.key, nil
+}
+
+// Sign computes the digest of data and signs it using Cloud KMS.
+func (sv *SignerVerifier) Sign(ctx context.Context, data []byte) ([]byte, error) {
+	hasher := sv.hash.New()
+	hasher.Write(data)
+	digest := hasher.Sum(nil)
+
+	digestName := "sha256"
+	if sv.hash == crypto.SHA384 {
+		digestName = "sha384"
+	}
+
+	requestBody := map[string]map[string][]byte{
+		"digest": {digestName: digest},
+	}
+	response := struct {
+		Signature []byte `json:"signature"`
+	}{}
+	if err := request(ctx, http.MethodPost, sv.name+":asymmetricSign", requestBody, &response); err != nil {
+		return nil, err
+	}
+
+	return response.Signature, nil
+}
+
+// Verify verifies sig against data using the key version's public key.
+func (sv *SignerVerifier) Verify(ctx context.Context, data, sig []byte) error {
+	return sv.verifier.Verify(ctx, data, sig)
+}
+
+// KeyID returns the identifier of the key version's public key as recorded in
+// gittuf metadata.
+func (sv *SignerVerifier) KeyID() (string, error) {
+	return sv.key.KeyID, nil
+}
+
+// Public returns the public key of the key version.
+func (sv *SignerVerifier) Public() crypto.PublicKey {
+	return sv.verifier.Public()
+}
+
+func parseKeyReference(keyRef string) (string, error) {
+	if !strings.HasPrefix(keyRef, KeyReferencePrefix) {
+		return "", ErrInvalidKeyReference
+	}
+
+	name := strings.TrimPrefix(keyRef, KeyReferencePrefix)
+	if !keyVersionPattern.MatchString(name) {
+		return "", fmt.Errorf("%w: expected %sprojects/<project>/locations/<location>/keyRings/<ring>/cryptoKeys/<key>/cryptoKeyVersions/<version>", ErrInvalidKeyReference, KeyReferencePrefix)
+	}
+
+	return name, nil
+}
+
+func request(ctx context.Context, method, path string, requestBody, response any) error {
+	token, err := getAccessToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	var body io.Reader
+	if requestBody != nil {
+		encodedBody, err := json.Marshal(requestBody)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(encodedBody)
+	}
+
+	httpRequest, err := http.NewRequestWithContext(ctx, method, fmt.Sprintf("%s/v1/%s", endpoint, path), body)
+	if err != nil {
+		return err
+	}
+	httpRequest.Header.Set("Authorization", "Bearer "+token)
+	httpRequest.Header.Set("Content-Type", "application/json")
+
+	httpResponse, err := http.DefaultClient.Do(httpRequest)
+	if err != nil {
+		return errors.Join(ErrKMSRequestFailed, err)
+	}
+	defer httpResponse.Body.Close() //nolint:errcheck
+
+	responseBody, err := io.ReadAll(httpResponse.Body)
+	if err != nil {
+		return errors.Join(ErrKMSRequestFailed, err)
+	}
+	if httpResponse.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: %s: %s", ErrKMSRequestFailed, httpResponse.Status, strings.TrimSpace(string(responseBody)))
+	}
+
+	if err := json.Unmarshal(responseBody, response); err != nil {
+		return errors.Join(ErrKMSRequestFailed, err)
+	}
+
+	return nil
+}
+
+func getAccessToken(ctx context.Context) (string, error) {
+	if token := os.Getenv(AccessTokenEnvVar); token != "" {
+		return token, nil
+	}
+
+	output, err := exec.CommandContext(ctx, "gcloud", "auth", "print-access-token").Output()
+	if err != nil {
+		return "", errors.Join(ErrNoAccessToken, err)
+	}
+
+	return strings.TrimSpace(string(output)), nil
+}
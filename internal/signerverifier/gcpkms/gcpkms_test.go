@@ -0,0 +1,115 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package gcpkms
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	sslibsv "github.com/gittuf/gittuf/internal/third_party/go-securesystemslib/signerverifier"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"github.com/stretchr/testify/assert"
+)
+
+const testKeyName = "projects/gittuf/locations/global/keyRings/gittuf/cryptoKeys/root/cryptoKeyVersions/1"
+
+func TestSignerVerifier(t *testing.T) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicKeyPEM, err := cryptoutils.MarshalPublicKeyToPEM(privateKey.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer test-token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/v1/" + testKeyName + "/publicKey":
+			json.NewEncoder(w).Encode(map[string]string{ //nolint:errcheck
+				"pem":       string(publicKeyPEM),
+				"algorithm": "EC_SIGN_P256_SHA256",
+			})
+		case "/v1/" + testKeyName + ":asymmetricSign":
+			request := struct {
+				Digest struct {
+					SHA256 []byte `json:"sha256"`
+				} `json:"digest"`
+			}{}
+			if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			signature, err := ecdsa.SignASN1(rand.Reader, privateKey, request.Digest.SHA256)
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			json.NewEncoder(w).Encode(map[string][]byte{"signature": signature}) //nolint:errcheck
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	originalEndpoint := endpoint
+	endpoint = server.URL
+	defer func() { endpoint = originalEndpoint }()
+	t.Setenv(AccessTokenEnvVar, "test-token")
+
+	ctx := context.Background()
+
+	sv, err := NewSignerVerifierFromURI(ctx, KeyReferencePrefix+testKeyName)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedKey, err := sslibsv.NewKey(privateKey.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyID, err := sv.KeyID()
+	assert.Nil(t, err)
+	assert.Equal(t, expectedKey.KeyID, keyID)
+
+	key, err := LoadPublicKey(ctx, KeyReferencePrefix+testKeyName)
+	assert.Nil(t, err)
+	assert.Equal(t, expectedKey, key)
+
+	data := []byte("test data")
+	signature, err := sv.Sign(ctx, data)
+	assert.Nil(t, err)
+	assert.Nil(t, sv.Verify(ctx, data, signature))
+
+	// Signatures must also verify using the key recorded in policy
+	verifier, err := sslibsv.NewVerifierFromSSLibKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Nil(t, verifier.Verify(ctx, data, signature))
+	assert.NotNil(t, verifier.Verify(ctx, []byte("other data"), signature))
+}
+
+func TestParseKeyReference(t *testing.T) {
+	name, err := parseKeyReference(KeyReferencePrefix + testKeyName)
+	assert.Nil(t, err)
+	assert.Equal(t, testKeyName, name)
+
+	_, err = parseKeyReference(KeyReferencePrefix + "projects/gittuf/locations/global/keyRings/gittuf/cryptoKeys/root")
+	assert.ErrorIs(t, err, ErrInvalidKeyReference)
+
+	_, err = parseKeyReference(testKeyName)
+	assert.ErrorIs(t, err, ErrInvalidKeyReference)
+}
@@ -4,15 +4,21 @@ package gpg
 
 import (
 	"bytes"
+	"crypto"
+	"errors"
 	"fmt"
 	"strings"
 
 	"github.com/ProtonMail/go-crypto/openpgp"
+	"github.com/ProtonMail/go-crypto/openpgp/armor"
+	"github.com/ProtonMail/go-crypto/openpgp/packet"
 	"github.com/gittuf/gittuf/internal/signerverifier"
 	sslibsv "github.com/gittuf/gittuf/internal/third_party/go-securesystemslib/signerverifier"
 	"github.com/gittuf/gittuf/internal/tuf"
 )
 
+var ErrInvalidSignature = errors.New("unable to parse GPG signature")
+
 // LoadGPGKeyFromBytes returns a tuf.Key for a GPG / PGP key passed in as
 // armored bytes. The returned tuf.Key uses the primary key's fingerprint as the
 // key ID.
@@ -37,3 +43,53 @@ func LoadGPGKeyFromBytes(contents []byte) (*tuf.Key, error) {
 
 	return gpgKey, nil
 }
+
+// RSAKeySizes returns the size in bits of each RSA key in the GPG key,
+// including subkeys.
+func RSAKeySizes(key *tuf.Key) ([]int, error) {
+	keyring, err := openpgp.ReadArmoredKeyRing(strings.NewReader(key.KeyVal.Public))
+	if err != nil {
+		return nil, err
+	}
+
+	sizes := []int{}
+	for _, entity := range keyring {
+		publicKeys := []*packet.PublicKey{entity.PrimaryKey}
+		for _, subkey := range entity.Subkeys {
+			publicKeys = append(publicKeys, subkey.PublicKey)
+		}
+
+		for _, publicKey := range publicKeys {
+			if publicKey.PubKeyAlgo != packet.PubKeyAlgoRSA && publicKey.PubKeyAlgo != packet.PubKeyAlgoRSASignOnly {
+				continue
+			}
+
+			bits, err := publicKey.BitLength()
+			if err != nil {
+				return nil, err
+			}
+			sizes = append(sizes, int(bits))
+		}
+	}
+
+	return sizes, nil
+}
+
+// SignatureHash returns the hash algorithm used by the armored GPG signature.
+func SignatureHash(signature []byte) (crypto.Hash, error) {
+	block, err := armor.Decode(bytes.NewReader(signature))
+	if err != nil {
+		return 0, errors.Join(ErrInvalidSignature, err)
+	}
+
+	p, err := packet.Read(block.Body)
+	if err != nil {
+		return 0, errors.Join(ErrInvalidSignature, err)
+	}
+	sig, isSignature := p.(*packet.Signature)
+	if !isSignature {
+		return 0, ErrInvalidSignature
+	}
+
+	return sig.Hash, nil
+}
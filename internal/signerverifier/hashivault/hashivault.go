@@ -0,0 +1,327 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package hashivault
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	sslibsv "github.com/gittuf/gittuf/internal/third_party/go-securesystemslib/signerverifier"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/secure-systems-lab/go-securesystemslib/dsse"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+)
+
+const (
+	// KeyReferencePrefix identifies Vault transit key references, which have
+	// the form hashivault://<key>.
+	KeyReferencePrefix = "hashivault://"
+
+	// The Vault server and authentication are configured using the standard
+	// Vault environment variables. Either a token or AppRole credentials must
+	// be set.
+	AddressEnvVar      = "VAULT_ADDR"
+	TokenEnvVar        = "VAULT_TOKEN"
+	NamespaceEnvVar    = "VAULT_NAMESPACE"
+	RoleIDEnvVar       = "VAULT_ROLE_ID"
+	SecretIDEnvVar     = "VAULT_SECRET_ID"
+	TransitMountEnvVar = "VAULT_TRANSIT_MOUNT"
+
+	defaultTransitMount = "transit"
+)
+
+var (
+	ErrInvalidKeyReference = errors.New("invalid Vault transit key reference")
+	ErrUnsupportedKey      = errors.New("Vault transit key type is not supported by gittuf")
+	ErrNoAddress           = errors.New("Vault address not set, set VAULT_ADDR")
+	ErrNoCredentials       = errors.New("Vault credentials not set, set VAULT_TOKEN or VAULT_ROLE_ID and VAULT_SECRET_ID")
+	ErrVaultRequest        = errors.New("Vault request failed")
+	ErrInvalidSignature    = errors.New("Vault returned an invalid signature")
+)
+
+// SignerVerifier implements the dsse.SignerVerifier interface for a key held
+// in Vault's transit secrets engine. Signatures are verified locally using the
+// key's public key.
+type SignerVerifier struct {
+	client    *client
+	name      string
+	version   int
+	key       *tuf.Key
+	verifier  dsse.SignerVerifier
+	hash      crypto.Hash
+	hashName  string
+	isRSA     bool
+	isED25519 bool
+}
+
+// NewSignerVerifierFromURI returns a SignerVerifier for the latest version of
+// the transit key identified by keyRef.
+func NewSignerVerifierFromURI(ctx context.Context, keyRef string) (*SignerVerifier, error) {
+	name, err := parseKeyReference(keyRef)
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := newClientFromEnv(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	response := struct {
+		Data struct {
+			Type          string `json:"type"`
+			LatestVersion int    `json:"latest_version"`
+			Keys          map[string]struct {
+				PublicKey string `json:"public_key"`
+			} `json:"keys"`
+		} `json:"data"`
+	}{}
+	if err := client.request(ctx, http.MethodGet, fmt.Sprintf("%s/keys/%s", client.mount, name), nil, &response); err != nil {
+		return nil, err
+	}
+
+	sv := &SignerVerifier{
+		client:  client,
+		name:    name,
+		version: response.Data.LatestVersion,
+	}
+
+	keyType := response.Data.Type
+	keyVersion, has := response.Data.Keys[strconv.Itoa(sv.version)]
+	if !has {
+		return nil, fmt.Errorf("%w: public key for version %d not found", ErrVaultRequest, sv.version)
+	}
+
+	var publicKey crypto.PublicKey
+	switch keyType {
+	case "ed25519":
+		keyBytes, err := base64.StdEncoding.DecodeString(keyVersion.PublicKey)
+		if err != nil {
+			return nil, err
+		}
+		publicKey = ed25519.PublicKey(keyBytes)
+		sv.isED25519 = true
+	case "ecdsa-p256", "ecdsa-p384", "ecdsa-p521", "rsa-2048", "rsa-3072", "rsa-4096":
+		publicKey, err = cryptoutils.UnmarshalPEMToPublicKey([]byte(keyVersion.PublicKey))
+		if err != nil {
+			return nil, err
+		}
+
+		// Match the hash functions used by gittuf's ECDSA and RSA-PSS
+		// verifiers
+		switch keyType {
+		case "ecdsa-p384":
+			sv.hash, sv.hashName = crypto.SHA384, "sha2-384"
+		case "ecdsa-p521":
+			sv.hash, sv.hashName = crypto.SHA512, "sha2-512"
+		default:
+			sv.hash, sv.hashName = crypto.SHA256, "sha2-256"
+		}
+		sv.isRSA = strings.HasPrefix(keyType, "rsa")
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedKey, keyType)
+	}
+
+	sv.key, err = sslibsv.NewKey(publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	sv.verifier, err = sslibsv.NewVerifierFromSSLibKey(sv.key)
+	if err != nil {
+		return nil, err
+	}
+
+	return sv, nil
+}
+
+// LoadPublicKey returns the public key of the latest version of the transit
+// key identified by keyRef for use in gittuf metadata.
+func LoadPublicKey(ctx context.Context, keyRef string) (*tuf.Key, error) {
+	sv, err := NewSignerVerifierFromURI(ctx, keyRef)
+	if err != nil {
+		return nil, err
+	}
+
+	return sv.key, nil
+}
+
+// Sign signs data using the transit engine. Data is hashed locally before
+// signing for ECDSA and RSA keys.
+func (sv *SignerVerifier) Sign(ctx context.Context, data []byte) ([]byte, error) {
+	requestBody := map[string]any{
+		"key_version": sv.version,
+	}
+
+	path := fmt.Sprintf("%s/sign/%s", sv.client.mount, sv.name)
+	if sv.isED25519 {
+		requestBody["input"] = base64.StdEncoding.EncodeToString(data)
+	} else {
+		hasher := sv.hash.New()
+		hasher.Write(data)
+
+		path = fmt.Sprintf("%s/%s", path, sv.hashName)
+		requestBody["input"] = base64.StdEncoding.EncodeToString(hasher.Sum(nil))
+		requestBody["prehashed"] = true
+		if sv.isRSA {
+			requestBody["signature_algorithm"] = "pss"
+			requestBody["salt_length"] = "hash"
+		} else {
+			requestBody["marshaling_algorithm"] = "asn1"
+		}
+	}
+
+	response := struct {
+		Data struct {
+			Signature string `json:"signature"`
+		} `json:"data"`
+	}{}
+	if err := sv.client.request(ctx, http.MethodPost, path, requestBody, &response); err != nil {
+		return nil, err
+	}
+
+	// Signatures have the form vault:v<version>:<base64 signature>
+	components := strings.SplitN(response.Data.Signature, ":", 3)
+	if len(components) != 3 || components[0] != "vault" {
+		return nil, ErrInvalidSignature
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(components[2])
+	if err != nil {
+		return nil, errors.Join(ErrInvalidSignature, err)
+	}
+
+	return signature, nil
+}
+
+// Verify verifies sig against data using the key's public key.
+func (sv *SignerVerifier) Verify(ctx context.Context, data, sig []byte) error {
+	return sv.verifier.Verify(ctx, data, sig)
+}
+
+// KeyID returns the identifier of the key's public key as recorded in gittuf
+// metadata.
+func (sv *SignerVerifier) KeyID() (string, error) {
+	return sv.key.KeyID, nil
+}
+
+// Public returns the public key of the transit key.
+func (sv *SignerVerifier) Public() crypto.PublicKey {
+	return sv.verifier.Public()
+}
+
+func parseKeyReference(keyRef string) (string, error) {
+	if !strings.HasPrefix(keyRef, KeyReferencePrefix) {
+		return "", ErrInvalidKeyReference
+	}
+
+	name := strings.TrimPrefix(keyRef, KeyReferencePrefix)
+	if name == "" || strings.Contains(name, "/") {
+		return "", fmt.Errorf("%w: expected %s<key>", ErrInvalidKeyReference, KeyReferencePrefix)
+	}
+
+	return name, nil
+}
+
+type client struct {
+	address   string
+	token     string
+	namespace string
+	mount     string
+}
+
+// newClientFromEnv returns a client configured using the Vault environment
+// variables. If a token is not set, AppRole credentials are exchanged for one.
+func newClientFromEnv(ctx context.Context) (*client, error) {
+	c := &client{
+		address:   strings.TrimSuffix(os.Getenv(AddressEnvVar), "/"),
+		token:     os.Getenv(TokenEnvVar),
+		namespace: os.Getenv(NamespaceEnvVar),
+		mount:     strings.Trim(os.Getenv(TransitMountEnvVar), "/"),
+	}
+	if c.address == "" {
+		return nil, ErrNoAddress
+	}
+	if c.mount == "" {
+		c.mount = defaultTransitMount
+	}
+
+	if c.token != "" {
+		return c, nil
+	}
+
+	roleID, secretID := os.Getenv(RoleIDEnvVar), os.Getenv(SecretIDEnvVar)
+	if roleID == "" || secretID == "" {
+		return nil, ErrNoCredentials
+	}
+
+	response := struct {
+		Auth struct {
+			ClientToken string `json:"client_token"`
+		} `json:"auth"`
+	}{}
+	requestBody := map[string]string{"role_id": roleID, "secret_id": secretID}
+	if err := c.request(ctx, http.MethodPost, "auth/approle/login", requestBody, &response); err != nil {
+		return nil, err
+	}
+	if response.Auth.ClientToken == "" {
+		return nil, ErrNoCredentials
+	}
+	c.token = response.Auth.ClientToken
+
+	return c, nil
+}
+
+func (c *client) request(ctx context.Context, method, path string, requestBody, response any) error {
+	var body io.Reader
+	if requestBody != nil {
+		encodedBody, err := json.Marshal(requestBody)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(encodedBody)
+	}
+
+	httpRequest, err := http.NewRequestWithContext(ctx, method, fmt.Sprintf("%s/v1/%s", c.address, path), body)
+	if err != nil {
+		return err
+	}
+	if c.token != "" {
+		httpRequest.Header.Set("X-Vault-Token", c.token)
+	}
+	if c.namespace != "" {
+		httpRequest.Header.Set("X-Vault-Namespace", c.namespace)
+	}
+	httpRequest.Header.Set("Content-Type", "application/json")
+
+	httpResponse, err := http.DefaultClient.Do(httpRequest)
+	if err != nil {
+		return errors.Join(ErrVaultRequest, err)
+	}
+	defer httpResponse.Body.Close() //nolint:errcheck
+
+	responseBody, err := io.ReadAll(httpResponse.Body)
+	if err != nil {
+		return errors.Join(ErrVaultRequest, err)
+	}
+	if httpResponse.StatusCode != http.StatusOK {
+		return fmt.Errorf("%w: %s: %s", ErrVaultRequest, httpResponse.Status, strings.TrimSpace(string(responseBody)))
+	}
+
+	if err := json.Unmarshal(responseBody, response); err != nil {
+		return errors.Join(ErrVaultRequest, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,144 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package hashivault
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	sslibsv "github.com/gittuf/gittuf/internal/third_party/go-securesystemslib/signerverifier"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSignerVerifier(t *testing.T) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	publicKeyPEM, err := cryptoutils.MarshalPublicKeyToPEM(privateKey.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v1/auth/approle/login" {
+			request := map[string]string{}
+			if err := json.NewDecoder(r.Body).Decode(&request); err != nil || request["role_id"] != "role" || request["secret_id"] != "secret" {
+				w.WriteHeader(http.StatusForbidden)
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]any{"auth": map[string]string{"client_token": "test-token"}}) //nolint:errcheck
+			return
+		}
+
+		if r.Header.Get("X-Vault-Token") != "test-token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+
+		switch r.URL.Path {
+		case "/v1/transit/keys/root":
+			json.NewEncoder(w).Encode(map[string]any{ //nolint:errcheck
+				"data": map[string]any{
+					"type":           "ecdsa-p256",
+					"latest_version": 1,
+					"keys": map[string]any{
+						"1": map[string]string{"public_key": string(publicKeyPEM)},
+					},
+				},
+			})
+		case "/v1/transit/sign/root/sha2-256":
+			request := struct {
+				Input     string `json:"input"`
+				Prehashed bool   `json:"prehashed"`
+			}{}
+			if err := json.NewDecoder(r.Body).Decode(&request); err != nil || !request.Prehashed {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			digest, err := base64.StdEncoding.DecodeString(request.Input)
+			if err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			signature, err := ecdsa.SignASN1(rand.Reader, privateKey, digest)
+			if err != nil {
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+			json.NewEncoder(w).Encode(map[string]any{ //nolint:errcheck
+				"data": map[string]string{"signature": "vault:v1:" + base64.StdEncoding.EncodeToString(signature)},
+			})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	t.Setenv(AddressEnvVar, server.URL)
+	t.Setenv(TokenEnvVar, "")
+	t.Setenv(RoleIDEnvVar, "role")
+	t.Setenv(SecretIDEnvVar, "secret")
+	t.Setenv(TransitMountEnvVar, "")
+
+	ctx := context.Background()
+	keyRef := KeyReferencePrefix + "root"
+
+	sv, err := NewSignerVerifierFromURI(ctx, keyRef)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedKey, err := sslibsv.NewKey(privateKey.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+	keyID, err := sv.KeyID()
+	assert.Nil(t, err)
+	assert.Equal(t, expectedKey.KeyID, keyID)
+
+	key, err := LoadPublicKey(ctx, keyRef)
+	assert.Nil(t, err)
+	assert.Equal(t, expectedKey, key)
+
+	data := []byte("test data")
+	signature, err := sv.Sign(ctx, data)
+	assert.Nil(t, err)
+	assert.Nil(t, sv.Verify(ctx, data, signature))
+
+	// Signatures must also verify using the key recorded in policy
+	verifier, err := sslibsv.NewVerifierFromSSLibKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Nil(t, verifier.Verify(ctx, data, signature))
+	assert.NotNil(t, verifier.Verify(ctx, []byte("other data"), signature))
+
+	t.Run("no credentials", func(t *testing.T) {
+		t.Setenv(RoleIDEnvVar, "")
+
+		_, err := NewSignerVerifierFromURI(ctx, keyRef)
+		assert.ErrorIs(t, err, ErrNoCredentials)
+	})
+}
+
+func TestParseKeyReference(t *testing.T) {
+	name, err := parseKeyReference(KeyReferencePrefix + "root")
+	assert.Nil(t, err)
+	assert.Equal(t, "root", name)
+
+	_, err = parseKeyReference(KeyReferencePrefix)
+	assert.ErrorIs(t, err, ErrInvalidKeyReference)
+
+	_, err = parseKeyReference(KeyReferencePrefix + "transit/root")
+	assert.ErrorIs(t, err, ErrInvalidKeyReference)
+}
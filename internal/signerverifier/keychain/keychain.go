@@ -0,0 +1,165 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Package keychain stores gittuf signing keys in the operating system's
+// keychain, so that private keys do not have to be kept in files on disk. The
+// macOS Keychain is accessed using the security tool, the Windows Credential
+// Manager using the Windows API, and the Secret Service (e.g., GNOME Keyring
+// or KWallet) on other platforms using libsecret's secret-tool.
+package keychain
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	sslibsv "github.com/gittuf/gittuf/internal/third_party/go-securesystemslib/signerverifier"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/secure-systems-lab/go-securesystemslib/dsse"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	// KeyReferencePrefix identifies keys stored in the OS keychain, for
+	// example keychain:release.
+	KeyReferencePrefix = "keychain:"
+
+	// service is the service (or target prefix) keys are stored under in
+	// the keychain.
+	service = "gittuf"
+)
+
+var (
+	ErrInvalidKeyReference = errors.New("invalid keychain key reference, expected keychain:<name>")
+	ErrInvalidKeyName      = errors.New("keychain key names may only contain letters, digits, '.', '_', and '-'")
+	ErrKeyNotFound         = errors.New("key not found in OS keychain")
+	ErrKeychainFailed      = errors.New("unable to access OS keychain")
+	ErrNotPrivateKey       = errors.New("only unencrypted private keys can be stored in the OS keychain")
+)
+
+var keyNamePattern = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+
+// backend abstracts the platform's keychain. Secrets are stored as base64
+// encoded strings. ErrKeyNotFound is returned for keys that do not exist.
+type backend interface {
+	get(ctx context.Context, name string) (string, error)
+	set(ctx context.Context, name, secret string) error
+	delete(ctx context.Context, name string) error
+}
+
+// keychain is a variable to allow tests to use an in-memory backend.
+var keychain backend = newOSBackend()
+
+// NewSignerVerifierFromURI returns a signer for the key in the OS keychain
+// identified by keyRef.
+func NewSignerVerifierFromURI(ctx context.Context, keyRef string) (dsse.SignerVerifier, error) {
+	name, err := parseKeyReference(keyRef)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := keychain.get(ctx, name)
+	if err != nil {
+		return nil, withKeyName(err, name)
+	}
+
+	keyBytes, err := base64.StdEncoding.DecodeString(secret)
+	if err != nil {
+		return nil, fmt.Errorf("%w: unable to decode key '%s': %w", ErrKeychainFailed, name, err)
+	}
+
+	return sslibsv.NewSignerVerifierFromPEM(keyBytes)
+}
+
+// LoadPublicKey returns the public key of the key in the OS keychain identified
+// by keyRef for use in gittuf metadata.
+func LoadPublicKey(ctx context.Context, keyRef string) (*tuf.Key, error) {
+	sv, err := NewSignerVerifierFromURI(ctx, keyRef)
+	if err != nil {
+		return nil, err
+	}
+
+	return sslibsv.NewKey(sv.Public())
+}
+
+// StoreKey stores the PEM encoded private key in the OS keychain with the
+// specified name, replacing any key with the same name. The key can then be
+// used for signing as keychain:<name>.
+func StoreKey(ctx context.Context, name string, privateKeyPEM []byte) error {
+	if !keyNamePattern.MatchString(name) {
+		return fmt.Errorf("%w: '%s'", ErrInvalidKeyName, name)
+	}
+
+	privateKey, err := ssh.ParseRawPrivateKey(privateKeyPEM)
+	if err != nil {
+		return fmt.Errorf("%w: %w", ErrNotPrivateKey, err)
+	}
+
+	// The OpenSSH format returns a pointer to ED25519 keys
+	if key, ok := privateKey.(*ed25519.PrivateKey); ok {
+		privateKey = *key
+	}
+
+	// Keys are stored as PKCS #8 so that all key types and source formats
+	// can be loaded
+	privateKeyBytes, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		return err
+	}
+	normalizedKeyPEM := pem.EncodeToMemory(&pem.Block{Type: sslibsv.PrivateKeyPEM, Bytes: privateKeyBytes})
+
+	return keychain.set(ctx, name, base64.StdEncoding.EncodeToString(normalizedKeyPEM))
+}
+
+// HasKey returns true if a key with the specified name is stored in the OS
+// keychain.
+func HasKey(ctx context.Context, name string) (bool, error) {
+	if !keyNamePattern.MatchString(name) {
+		return false, fmt.Errorf("%w: '%s'", ErrInvalidKeyName, name)
+	}
+
+	_, err := keychain.get(ctx, name)
+	switch {
+	case err == nil:
+		return true, nil
+	case errors.Is(err, ErrKeyNotFound):
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+// DeleteKey removes the key with the specified name from the OS keychain.
+func DeleteKey(ctx context.Context, name string) error {
+	if !keyNamePattern.MatchString(name) {
+		return fmt.Errorf("%w: '%s'", ErrInvalidKeyName, name)
+	}
+
+	return withKeyName(keychain.delete(ctx, name), name)
+}
+
+func parseKeyReference(keyRef string) (string, error) {
+	if !strings.HasPrefix(keyRef, KeyReferencePrefix) {
+		return "", ErrInvalidKeyReference
+	}
+
+	name := strings.TrimPrefix(keyRef, KeyReferencePrefix)
+	if !keyNamePattern.MatchString(name) {
+		return "", fmt.Errorf("%w: %w", ErrInvalidKeyReference, ErrInvalidKeyName)
+	}
+
+	return name, nil
+}
+
+// withKeyName adds the key's name to ErrKeyNotFound returned by the backend.
+func withKeyName(err error, name string) error {
+	if errors.Is(err, ErrKeyNotFound) {
+		return fmt.Errorf("%w: '%s'", ErrKeyNotFound, name)
+	}
+	return err
+}
@@ -0,0 +1,104 @@
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !windows
+
+package keychain
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// securityItemNotFound is the exit code of the macOS security tool when the
+// item does not exist.
+const securityItemNotFound = 44
+
+// execBackend accesses the keychain using the platform's command line tool,
+// security on macOS and libsecret's secret-tool elsewhere.
+type execBackend struct {
+	// program is a variable to allow tests to use a different program.
+	program string
+}
+
+func newOSBackend() backend {
+	if runtime.GOOS == "darwin" {
+		return &execBackend{program: "security"}
+	}
+	return &execBackend{program: "secret-tool"}
+}
+
+func (b *execBackend) get(ctx context.Context, name string) (string, error) {
+	var args []string
+	if b.program == "security" {
+		args = []string{"find-generic-password", "-s", service, "-a", name, "-w"}
+	} else {
+		args = []string{"lookup", "service", service, "account", name}
+	}
+
+	output, err := b.run(ctx, "", args...)
+	if err != nil {
+		return "", err
+	}
+
+	secret := strings.TrimSpace(output)
+	if secret == "" {
+		return "", ErrKeyNotFound
+	}
+	return secret, nil
+}
+
+func (b *execBackend) set(ctx context.Context, name, secret string) error {
+	if b.program == "security" {
+		// The secret is passed using security's interactive mode so that it
+		// is not visible in the process list
+		command := fmt.Sprintf("add-generic-password -U -s %s -a %s -l \"gittuf key %s\" -w %s\n", service, name, name, secret)
+		_, err := b.run(ctx, command, "-i")
+		return err
+	}
+
+	_, err := b.run(ctx, secret, "store", "--label", fmt.Sprintf("gittuf key %s", name), "service", service, "account", name)
+	return err
+}
+
+func (b *execBackend) delete(ctx context.Context, name string) error {
+	if b.program == "security" {
+		_, err := b.run(ctx, "", "delete-generic-password", "-s", service, "-a", name)
+		return err
+	}
+
+	_, err := b.run(ctx, "", "clear", "service", service, "account", name)
+	return err
+}
+
+func (b *execBackend) run(ctx context.Context, stdin string, args ...string) (string, error) {
+	cmd := exec.CommandContext(ctx, b.program, args...)
+	cmd.Stdin = strings.NewReader(stdin)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	output, err := cmd.Output()
+	if err == nil {
+		return string(output), nil
+	}
+
+	// secret-tool exits with 1 without an error message when the item does
+	// not exist
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		notFound := exitErr.ExitCode() == securityItemNotFound && b.program == "security"
+		notFound = notFound || (exitErr.ExitCode() == 1 && b.program != "security" && stderr.Len() == 0)
+		if notFound {
+			return "", ErrKeyNotFound
+		}
+	}
+
+	if stderr.Len() == 0 {
+		return "", fmt.Errorf("%w: %s: %w", ErrKeychainFailed, b.program, err)
+	}
+	return "", fmt.Errorf("%w: %s: %w: %s", ErrKeychainFailed, b.program, err, strings.TrimSpace(stderr.String()))
+}
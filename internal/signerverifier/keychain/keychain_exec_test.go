@@ -0,0 +1,64 @@
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build !windows
+
+package keychain
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecBackend(t *testing.T) {
+	ctx := context.Background()
+
+	// writeProgram writes a shell script that stands in for secret-tool
+	writeProgram := func(t *testing.T, script string) *execBackend {
+		t.Helper()
+
+		program := filepath.Join(t.TempDir(), "secret-tool")
+		if err := os.WriteFile(program, []byte("#!/bin/sh\n"+script), 0o755); err != nil { //nolint:gosec
+			t.Fatal(err)
+		}
+		return &execBackend{program: program}
+	}
+
+	t.Run("secret found", func(t *testing.T) {
+		b := writeProgram(t, `[ "$1 $5" = "lookup release" ] && echo c2VjcmV0`)
+
+		secret, err := b.get(ctx, "release")
+		assert.Nil(t, err)
+		assert.Equal(t, "c2VjcmV0", secret)
+	})
+
+	t.Run("secret not found", func(t *testing.T) {
+		b := writeProgram(t, "exit 1")
+
+		_, err := b.get(ctx, "release")
+		assert.ErrorIs(t, err, ErrKeyNotFound)
+	})
+
+	t.Run("keychain unavailable", func(t *testing.T) {
+		b := writeProgram(t, "echo 'Cannot autolaunch D-Bus' >&2; exit 1")
+
+		_, err := b.get(ctx, "release")
+		assert.ErrorIs(t, err, ErrKeychainFailed)
+		assert.Contains(t, err.Error(), "Cannot autolaunch D-Bus")
+	})
+
+	t.Run("store secret", func(t *testing.T) {
+		stored := filepath.Join(t.TempDir(), "stored")
+		b := writeProgram(t, `cat > `+stored)
+
+		err := b.set(ctx, "release", "c2VjcmV0")
+		assert.Nil(t, err)
+
+		contents, err := os.ReadFile(stored)
+		assert.Nil(t, err)
+		assert.Equal(t, "c2VjcmV0", string(contents))
+	})
+}
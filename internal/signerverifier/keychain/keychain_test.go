@@ -0,0 +1,112 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package keychain
+
+import (
+	"context"
+	"testing"
+
+	artifacts "github.com/gittuf/gittuf/internal/testartifacts"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryBackend is an in-memory keychain used in tests.
+type memoryBackend map[string]string
+
+func (b memoryBackend) get(_ context.Context, name string) (string, error) {
+	secret, has := b[name]
+	if !has {
+		return "", ErrKeyNotFound
+	}
+	return secret, nil
+}
+
+func (b memoryBackend) set(_ context.Context, name, secret string) error {
+	b[name] = secret
+	return nil
+}
+
+func (b memoryBackend) delete(_ context.Context, name string) error {
+	if _, has := b[name]; !has {
+		return ErrKeyNotFound
+	}
+	delete(b, name)
+	return nil
+}
+
+func TestKeychain(t *testing.T) {
+	originalKeychain := keychain
+	keychain = memoryBackend{}
+	t.Cleanup(func() { keychain = originalKeychain })
+
+	ctx := context.Background()
+
+	expectedKey, err := tuf.LoadKeyFromBytes(artifacts.SSHECDSAPublic)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("store and load key", func(t *testing.T) {
+		// The key is stored in the OpenSSH format
+		hasKey, err := HasKey(ctx, "release")
+		assert.Nil(t, err)
+		assert.False(t, hasKey)
+
+		err = StoreKey(ctx, "release", artifacts.SSHECDSAPrivate)
+		assert.Nil(t, err)
+
+		hasKey, err = HasKey(ctx, "release")
+		assert.Nil(t, err)
+		assert.True(t, hasKey)
+
+		signer, err := NewSignerVerifierFromURI(ctx, "keychain:release")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		keyID, err := signer.KeyID()
+		assert.Nil(t, err)
+		assert.Equal(t, expectedKey.KeyID, keyID)
+
+		signature, err := signer.Sign(ctx, []byte("message"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		assert.Nil(t, signer.Verify(ctx, []byte("message"), signature))
+
+		key, err := LoadPublicKey(ctx, "keychain:release")
+		assert.Nil(t, err)
+		assert.Equal(t, expectedKey.KeyID, key.KeyID)
+	})
+
+	t.Run("delete key", func(t *testing.T) {
+		err := DeleteKey(ctx, "release")
+		assert.Nil(t, err)
+
+		_, err = NewSignerVerifierFromURI(ctx, "keychain:release")
+		assert.ErrorIs(t, err, ErrKeyNotFound)
+
+		err = DeleteKey(ctx, "release")
+		assert.ErrorIs(t, err, ErrKeyNotFound)
+	})
+
+	t.Run("invalid keys", func(t *testing.T) {
+		err := StoreKey(ctx, "release", artifacts.SSHECDSAPublic)
+		assert.ErrorIs(t, err, ErrNotPrivateKey)
+
+		err = StoreKey(ctx, "release", artifacts.EncryptedOpenSSHECDSAPrivate)
+		assert.ErrorIs(t, err, ErrNotPrivateKey)
+	})
+
+	t.Run("invalid names", func(t *testing.T) {
+		err := StoreKey(ctx, "release key", artifacts.SSHECDSAPrivate)
+		assert.ErrorIs(t, err, ErrInvalidKeyName)
+
+		_, err = NewSignerVerifierFromURI(ctx, "keychain:")
+		assert.ErrorIs(t, err, ErrInvalidKeyReference)
+
+		_, err = NewSignerVerifierFromURI(ctx, "release")
+		assert.ErrorIs(t, err, ErrInvalidKeyReference)
+	})
+}
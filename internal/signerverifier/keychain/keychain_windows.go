@@ -0,0 +1,115 @@
+// SPDX-License-Identifier: Apache-2.0
+
+//go:build windows
+
+package keychain
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"unsafe"
+
+	"golang.org/x/sys/windows"
+)
+
+const (
+	credTypeGeneric         = 1
+	credPersistLocalMachine = 2
+)
+
+var (
+	advapi32        = windows.NewLazySystemDLL("advapi32.dll")
+	procCredReadW   = advapi32.NewProc("CredReadW")
+	procCredWriteW  = advapi32.NewProc("CredWriteW")
+	procCredDeleteW = advapi32.NewProc("CredDeleteW")
+	procCredFree    = advapi32.NewProc("CredFree")
+)
+
+// credential is the CREDENTIALW structure used by the Credential Manager API.
+type credential struct {
+	Flags              uint32
+	Type               uint32
+	TargetName         *uint16
+	Comment            *uint16
+	LastWritten        windows.Filetime
+	CredentialBlobSize uint32
+	CredentialBlob     *byte
+	Persist            uint32
+	AttributeCount     uint32
+	Attributes         uintptr
+	TargetAlias        *uint16
+	UserName           *uint16
+}
+
+// credentialManagerBackend stores keys as generic credentials in the Windows
+// Credential Manager, with the target name gittuf:<name>.
+type credentialManagerBackend struct{}
+
+func newOSBackend() backend {
+	return &credentialManagerBackend{}
+}
+
+func (b *credentialManagerBackend) get(_ context.Context, name string) (string, error) {
+	target, err := windows.UTF16PtrFromString(targetName(name))
+	if err != nil {
+		return "", err
+	}
+
+	var cred *credential
+	if ret, _, err := procCredReadW.Call(uintptr(unsafe.Pointer(target)), credTypeGeneric, 0, uintptr(unsafe.Pointer(&cred))); ret == 0 {
+		return "", wrapError(err)
+	}
+	defer procCredFree.Call(uintptr(unsafe.Pointer(cred))) //nolint:errcheck
+
+	return string(unsafe.Slice(cred.CredentialBlob, cred.CredentialBlobSize)), nil
+}
+
+func (b *credentialManagerBackend) set(_ context.Context, name, secret string) error {
+	target, err := windows.UTF16PtrFromString(targetName(name))
+	if err != nil {
+		return err
+	}
+	comment, err := windows.UTF16PtrFromString(fmt.Sprintf("gittuf key %s", name))
+	if err != nil {
+		return err
+	}
+
+	blob := []byte(secret)
+	cred := &credential{
+		Type:               credTypeGeneric,
+		TargetName:         target,
+		Comment:            comment,
+		CredentialBlobSize: uint32(len(blob)),
+		CredentialBlob:     &blob[0],
+		Persist:            credPersistLocalMachine,
+	}
+
+	if ret, _, err := procCredWriteW.Call(uintptr(unsafe.Pointer(cred)), 0); ret == 0 {
+		return wrapError(err)
+	}
+	return nil
+}
+
+func (b *credentialManagerBackend) delete(_ context.Context, name string) error {
+	target, err := windows.UTF16PtrFromString(targetName(name))
+	if err != nil {
+		return err
+	}
+
+	if ret, _, err := procCredDeleteW.Call(uintptr(unsafe.Pointer(target)), credTypeGeneric, 0); ret == 0 {
+		return wrapError(err)
+	}
+	return nil
+}
+
+func targetName(name string) string {
+	return service + ":" + name
+}
+
+func wrapError(err error) error {
+	if errors.Is(err, windows.ERROR_NOT_FOUND) {
+		return ErrKeyNotFound
+	}
+	return fmt.Errorf("%w: %w", ErrKeychainFailed, err)
+}
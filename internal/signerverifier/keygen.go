@@ -0,0 +1,88 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package signerverifier
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+
+	"github.com/gittuf/gittuf/internal/signerverifier/common"
+	sslibsv "github.com/gittuf/gittuf/internal/third_party/go-securesystemslib/signerverifier"
+	"golang.org/x/crypto/ssh"
+)
+
+var ErrEmptyPassphrase = errors.New("passphrase must not be empty")
+
+// GenerateKeyPair creates a new key of the specified type, either
+// ED25519KeyType or ECDSAKeyType, and returns its PEM encoded private key in
+// PKCS #8 form and its PEM encoded public key in PKIX form. ECDSA keys use the
+// P-256 curve.
+func GenerateKeyPair(keyType string) ([]byte, []byte, error) {
+	var (
+		privateKey crypto.PrivateKey
+		publicKey  crypto.PublicKey
+	)
+
+	switch keyType {
+	case ED25519KeyType:
+		public, private, err := ed25519.GenerateKey(rand.Reader)
+		if err != nil {
+			return nil, nil, err
+		}
+		privateKey, publicKey = private, public
+	case ECDSAKeyType:
+		private, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			return nil, nil, err
+		}
+		privateKey, publicKey = private, private.Public()
+	default:
+		return nil, nil, common.ErrUnknownKeyType
+	}
+
+	privateKeyBytes, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	publicKeyBytes, err := x509.MarshalPKIXPublicKey(publicKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	privateKeyPEM := pem.EncodeToMemory(&pem.Block{Type: sslibsv.PrivateKeyPEM, Bytes: privateKeyBytes})
+	publicKeyPEM := pem.EncodeToMemory(&pem.Block{Type: sslibsv.PublicKeyPEM, Bytes: publicKeyBytes})
+
+	return privateKeyPEM, publicKeyPEM, nil
+}
+
+// EncryptPrivateKey encrypts the PEM encoded PKCS #8 private key returned by
+// GenerateKeyPair using the passphrase. The encrypted key is returned in the
+// OpenSSH private key format, which is also readable by ssh-keygen.
+func EncryptPrivateKey(privateKeyPEM, passphrase []byte) ([]byte, error) {
+	if len(passphrase) == 0 {
+		return nil, ErrEmptyPassphrase
+	}
+
+	block, _ := pem.Decode(privateKeyPEM)
+	if block == nil {
+		return nil, sslibsv.ErrNoPEMBlock
+	}
+
+	privateKey, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	encryptedBlock, err := ssh.MarshalPrivateKeyWithPassphrase(privateKey, "", passphrase)
+	if err != nil {
+		return nil, err
+	}
+
+	return pem.EncodeToMemory(encryptedBlock), nil
+}
@@ -0,0 +1,110 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package signerverifier
+
+import (
+	"context"
+	"crypto"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/signerverifier/common"
+	sslibsv "github.com/gittuf/gittuf/internal/third_party/go-securesystemslib/signerverifier"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/ssh"
+)
+
+func TestGenerateKeyPair(t *testing.T) {
+	for _, keyType := range []string{ED25519KeyType, ECDSAKeyType} {
+		t.Run(keyType, func(t *testing.T) {
+			privateKeyPEM, publicKeyPEM, err := GenerateKeyPair(keyType)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			signer, err := sslibsv.NewSignerVerifierFromPEM(privateKeyPEM)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			key, err := tuf.LoadKeyFromBytes(publicKeyPEM)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, keyType, key.KeyType)
+
+			keyID, err := signer.KeyID()
+			assert.Nil(t, err)
+			assert.Equal(t, key.KeyID, keyID)
+
+			message := []byte("message")
+			signature, err := signer.Sign(context.Background(), message)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Nil(t, signer.Verify(context.Background(), message, signature))
+		})
+	}
+
+	t.Run("unknown key type", func(t *testing.T) {
+		_, _, err := GenerateKeyPair(RSAKeyType)
+		assert.ErrorIs(t, err, common.ErrUnknownKeyType)
+	})
+}
+
+func TestEncryptPrivateKey(t *testing.T) {
+	passphrase := []byte("correct horse battery staple")
+
+	for _, keyType := range []string{ED25519KeyType, ECDSAKeyType} {
+		t.Run(keyType, func(t *testing.T) {
+			privateKeyPEM, _, err := GenerateKeyPair(keyType)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			encryptedKeyPEM, err := EncryptPrivateKey(privateKeyPEM, passphrase)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = ssh.ParseRawPrivateKey(encryptedKeyPEM)
+			var missingErr *ssh.PassphraseMissingError
+			assert.ErrorAs(t, err, &missingErr)
+
+			_, err = ssh.ParseRawPrivateKeyWithPassphrase(encryptedKeyPEM, []byte("incorrect"))
+			assert.ErrorIs(t, err, x509.IncorrectPasswordError)
+
+			decryptedKey, err := ssh.ParseRawPrivateKeyWithPassphrase(encryptedKeyPEM, passphrase)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			block, _ := pem.Decode(privateKeyPEM)
+			privateKey, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+			if err != nil {
+				t.Fatal(err)
+			}
+			expectedPublicKey, err := ssh.NewPublicKey(privateKey.(crypto.Signer).Public())
+			if err != nil {
+				t.Fatal(err)
+			}
+			decryptedSigner, err := ssh.NewSignerFromKey(decryptedKey)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Equal(t, expectedPublicKey.Marshal(), decryptedSigner.PublicKey().Marshal())
+		})
+	}
+
+	t.Run("empty passphrase", func(t *testing.T) {
+		privateKeyPEM, _, err := GenerateKeyPair(ED25519KeyType)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = EncryptPrivateKey(privateKeyPEM, nil)
+		assert.ErrorIs(t, err, ErrEmptyPassphrase)
+	})
+}
@@ -0,0 +1,264 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package piv
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	sslibsv "github.com/gittuf/gittuf/internal/third_party/go-securesystemslib/signerverifier"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/secure-systems-lab/go-securesystemslib/dsse"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"golang.org/x/term"
+)
+
+const (
+	// KeyReferencePrefix identifies keys in a YubiKey PIV slot, for example
+	// piv:9c. A specific reader can be selected using piv:9c?reader=<name>.
+	KeyReferencePrefix = "piv:"
+
+	// PINEnvVar can be set to the PIV PIN to avoid prompting for it.
+	PINEnvVar = "GITTUF_PIV_PIN"
+)
+
+var (
+	ErrInvalidKeyReference = errors.New("invalid PIV key reference, expected piv:9a or piv:9c")
+	ErrUnsupportedKey      = errors.New("PIV key algorithm is not supported by gittuf, use an ECCP256 or ECCP384 key")
+	ErrPIVToolFailed       = errors.New("unable to run yubico-piv-tool")
+	ErrNoPIN               = errors.New("PIV PIN required, set GITTUF_PIV_PIN or run gittuf in a terminal")
+)
+
+// pivTool is the Yubico utility used to talk to the YubiKey, it is a variable
+// to allow tests to use a different program.
+var pivTool = "yubico-piv-tool"
+
+// supportedSlots are the PIV slots that hold signing keys.
+var supportedSlots = map[string]bool{
+	"9a": true, // PIV authentication
+	"9c": true, // Digital signature
+}
+
+// Prompter abstracts the interaction with the user when signing with a PIV
+// key.
+type Prompter interface {
+	// PIN returns the PIN for the YubiKey.
+	PIN(slot string) (string, error)
+
+	// Touch is called before signing to tell the user they may need to touch
+	// the YubiKey.
+	Touch(slot string)
+}
+
+// SignerVerifier implements the dsse.SignerVerifier interface for a key held
+// in a YubiKey PIV slot. Signatures are verified locally using the slot's
+// public key.
+type SignerVerifier struct {
+	Prompter Prompter
+
+	slot      string
+	reader    string
+	algorithm string
+	hash      string
+	key       *tuf.Key
+	verifier  dsse.SignerVerifier
+}
+
+// NewSignerVerifierFromURI returns a SignerVerifier for the PIV slot
+// identified by keyRef. The public key is read from the certificate stored in
+// the slot.
+func NewSignerVerifierFromURI(ctx context.Context, keyRef string) (*SignerVerifier, error) {
+	slot, reader, err := parseKeyReference(keyRef)
+	if err != nil {
+		return nil, err
+	}
+
+	sv := &SignerVerifier{
+		Prompter: &terminalPrompter{},
+		slot:     slot,
+		reader:   reader,
+	}
+
+	output, err := sv.run(ctx, "--action", "read-certificate", "--slot", slot, "--key-format", "PEM")
+	if err != nil {
+		return nil, err
+	}
+
+	certs, err := cryptoutils.UnmarshalCertificatesFromPEM(output)
+	if err != nil {
+		return nil, err
+	}
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("%w: no certificate in slot %s", ErrPIVToolFailed, slot)
+	}
+
+	publicKey, ok := certs[0].PublicKey.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, ErrUnsupportedKey
+	}
+	// Match the hash function used by gittuf's ECDSA verifier
+	switch publicKey.Curve {
+	case elliptic.P256():
+		sv.algorithm, sv.hash = "ECCP256", "SHA256"
+	case elliptic.P384():
+		sv.algorithm, sv.hash = "ECCP384", "SHA384"
+	default:
+		return nil, ErrUnsupportedKey
+	}
+
+	sv.key, err = sslibsv.NewKey(publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	sv.verifier, err = sslibsv.NewVerifierFromSSLibKey(sv.key)
+	if err != nil {
+		return nil, err
+	}
+
+	return sv, nil
+}
+
+// LoadPublicKey returns the public key in the PIV slot identified by keyRef for
+// use in gittuf metadata.
+func LoadPublicKey(ctx context.Context, keyRef string) (*tuf.Key, error) {
+	sv, err := NewSignerVerifierFromURI(ctx, keyRef)
+	if err != nil {
+		return nil, err
+	}
+
+	return sv.key, nil
+}
+
+// Sign signs data using the key in the PIV slot, prompting for the PIN and
+// touch as needed.
+func (sv *SignerVerifier) Sign(ctx context.Context, data []byte) ([]byte, error) {
+	pin, err := sv.Prompter.PIN(sv.slot)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpDir, err := os.MkdirTemp("", "gittuf-piv-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmpDir) //nolint:errcheck
+
+	inputPath := filepath.Join(tmpDir, "data")
+	outputPath := filepath.Join(tmpDir, "signature")
+	if err := os.WriteFile(inputPath, data, 0o600); err != nil {
+		return nil, err
+	}
+
+	sv.Prompter.Touch(sv.slot)
+
+	// yubico-piv-tool hashes the input and returns an ASN.1 encoded ECDSA
+	// signature
+	if _, err := sv.run(ctx,
+		"--action", "verify-pin", "--pin", pin,
+		"--action", "sign", "--slot", sv.slot,
+		"--algorithm", sv.algorithm, "--hash", sv.hash,
+		"--input", inputPath, "--output", outputPath,
+	); err != nil {
+		return nil, err
+	}
+
+	return os.ReadFile(outputPath)
+}
+
+// Verify verifies sig against data using the slot's public key.
+func (sv *SignerVerifier) Verify(ctx context.Context, data, sig []byte) error {
+	return sv.verifier.Verify(ctx, data, sig)
+}
+
+// KeyID returns the identifier of the slot's public key as recorded in gittuf
+// metadata.
+func (sv *SignerVerifier) KeyID() (string, error) {
+	return sv.key.KeyID, nil
+}
+
+// Public returns the public key in the PIV slot.
+func (sv *SignerVerifier) Public() crypto.PublicKey {
+	return sv.verifier.Public()
+}
+
+func (sv *SignerVerifier) run(ctx context.Context, args ...string) ([]byte, error) {
+	if sv.reader != "" {
+		args = append([]string{"--reader", sv.reader}, args...)
+	}
+
+	cmd := exec.CommandContext(ctx, pivTool, args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w: %s", ErrPIVToolFailed, err, strings.TrimSpace(stderr.String()))
+	}
+
+	return output, nil
+}
+
+func parseKeyReference(keyRef string) (string, string, error) {
+	if !strings.HasPrefix(keyRef, KeyReferencePrefix) {
+		return "", "", ErrInvalidKeyReference
+	}
+
+	slot, query, _ := strings.Cut(strings.TrimPrefix(keyRef, KeyReferencePrefix), "?")
+	slot = strings.ToLower(slot)
+	if !supportedSlots[slot] {
+		return "", "", ErrInvalidKeyReference
+	}
+
+	values, err := url.ParseQuery(query)
+	if err != nil {
+		return "", "", errors.Join(ErrInvalidKeyReference, err)
+	}
+
+	return slot, values.Get("reader"), nil
+}
+
+// terminalPrompter reads the PIN from GITTUF_PIV_PIN or the terminal, and
+// prints touch reminders to stderr.
+type terminalPrompter struct {
+	pin string
+}
+
+func (p *terminalPrompter) PIN(_ string) (string, error) {
+	if p.pin != "" {
+		return p.pin, nil
+	}
+
+	if pin := os.Getenv(PINEnvVar); pin != "" {
+		p.pin = pin
+		return p.pin, nil
+	}
+
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		return "", ErrNoPIN
+	}
+
+	fmt.Fprint(os.Stderr, "Enter PIV PIN: ")
+	pin, err := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Fprintln(os.Stderr)
+	if err != nil {
+		return "", err
+	}
+
+	p.pin = string(pin)
+	return p.pin, nil
+}
+
+func (p *terminalPrompter) Touch(slot string) {
+	fmt.Fprintf(os.Stderr, "Touch your YubiKey if it is blinking to sign using slot %s...\n", slot)
+}
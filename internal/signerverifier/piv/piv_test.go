@@ -0,0 +1,67 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package piv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseKeyReference(t *testing.T) {
+	tests := map[string]struct {
+		keyRef         string
+		expectedSlot   string
+		expectedReader string
+		expectedError  error
+	}{
+		"authentication slot": {
+			keyRef:       "piv:9a",
+			expectedSlot: "9a",
+		},
+		"signature slot with reader": {
+			keyRef:         "piv:9C?reader=Yubico%20YubiKey%20OTP%2BFIDO%2BCCID",
+			expectedSlot:   "9c",
+			expectedReader: "Yubico YubiKey OTP+FIDO+CCID",
+		},
+		"key management slot": {
+			keyRef:        "piv:9d",
+			expectedError: ErrInvalidKeyReference,
+		},
+		"no slot": {
+			keyRef:        "piv:",
+			expectedError: ErrInvalidKeyReference,
+		},
+		"not a PIV reference": {
+			keyRef:        "9c",
+			expectedError: ErrInvalidKeyReference,
+		},
+	}
+
+	for name, test := range tests {
+		slot, reader, err := parseKeyReference(test.keyRef)
+		if test.expectedError != nil {
+			assert.ErrorIs(t, err, test.expectedError, name)
+			continue
+		}
+
+		assert.Nil(t, err, name)
+		assert.Equal(t, test.expectedSlot, slot, name)
+		assert.Equal(t, test.expectedReader, reader, name)
+	}
+}
+
+func TestTerminalPrompter(t *testing.T) {
+	t.Setenv(PINEnvVar, "123456")
+
+	prompter := &terminalPrompter{}
+	pin, err := prompter.PIN("9c")
+	assert.Nil(t, err)
+	assert.Equal(t, "123456", pin)
+
+	// The PIN is cached for subsequent signatures
+	t.Setenv(PINEnvVar, "")
+	pin, err = prompter.PIN("9c")
+	assert.Nil(t, err)
+	assert.Equal(t, "123456", pin)
+}
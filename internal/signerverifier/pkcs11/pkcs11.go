@@ -0,0 +1,374 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package pkcs11
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	sslibsv "github.com/gittuf/gittuf/internal/third_party/go-securesystemslib/signerverifier"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/secure-systems-lab/go-securesystemslib/dsse"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+)
+
+const (
+	// KeyReferencePrefix identifies PKCS#11 URIs (RFC 7512). The supported
+	// attributes are object (the key's label), id, slot-id, token,
+	// module-path, pin-value, and pin-source. For example:
+	// pkcs11:token=gittuf;object=root?module-path=/usr/lib/softhsm/libsofthsm2.so
+	KeyReferencePrefix = "pkcs11:"
+
+	// ModulePathEnvVar and PINEnvVar are used when the URI does not specify
+	// the module or PIN.
+	ModulePathEnvVar = "GITTUF_PKCS11_MODULE"
+	PINEnvVar        = "GITTUF_PKCS11_PIN"
+)
+
+var (
+	ErrInvalidKeyReference = errors.New("invalid PKCS#11 URI")
+	ErrNoModulePath        = errors.New("PKCS#11 module not specified, set module-path in the URI or GITTUF_PKCS11_MODULE")
+	ErrNoObjectSpecified   = errors.New("PKCS#11 URI must identify the key using object or id")
+	ErrKeyNotFound         = errors.New("PKCS#11 key not found")
+	ErrUnsupportedKey      = errors.New("PKCS#11 key type is not supported by gittuf")
+	ErrPKCS11ToolFailed    = errors.New("unable to run pkcs11-tool")
+)
+
+// pkcs11Tool is the OpenSC utility used to talk to the PKCS#11 module, it is a
+// variable to allow tests to use a different program.
+var pkcs11Tool = "pkcs11-tool"
+
+// KeyObject describes a key found on a PKCS#11 token.
+type KeyObject struct {
+	Label string
+	ID    string
+	Type  string
+}
+
+// SignerVerifier implements the dsse.SignerVerifier interface for a private
+// key stored on an HSM or smartcard accessed via PKCS#11. Signatures are
+// verified locally using the key's public key.
+type SignerVerifier struct {
+	uri      *URI
+	key      *tuf.Key
+	verifier dsse.SignerVerifier
+	hash     crypto.Hash
+	isRSA    bool
+}
+
+// URI holds the attributes of a PKCS#11 URI used by gittuf.
+type URI struct {
+	ModulePath string
+	SlotID     string
+	Token      string
+	Object     string
+	ID         string
+	PIN        string
+}
+
+// ParseURI parses a PKCS#11 URI, filling in the module path and PIN from the
+// environment if they are not set in the URI.
+func ParseURI(keyRef string) (*URI, error) {
+	if !strings.HasPrefix(keyRef, KeyReferencePrefix) {
+		return nil, ErrInvalidKeyReference
+	}
+
+	path, query, _ := strings.Cut(strings.TrimPrefix(keyRef, KeyReferencePrefix), "?")
+
+	uri := &URI{}
+	attributes := map[string]string{}
+	for _, component := range []struct {
+		value     string
+		separator string
+	}{{path, ";"}, {query, "&"}} {
+		if component.value == "" {
+			continue
+		}
+		for _, attribute := range strings.Split(component.value, component.separator) {
+			name, value, found := strings.Cut(attribute, "=")
+			if !found {
+				return nil, fmt.Errorf("%w: malformed attribute '%s'", ErrInvalidKeyReference, attribute)
+			}
+			value, err := url.PathUnescape(value)
+			if err != nil {
+				return nil, errors.Join(ErrInvalidKeyReference, err)
+			}
+			attributes[name] = value
+		}
+	}
+
+	uri.ModulePath = attributes["module-path"]
+	uri.SlotID = attributes["slot-id"]
+	uri.Token = attributes["token"]
+	uri.Object = attributes["object"]
+	uri.ID = attributes["id"]
+	uri.PIN = attributes["pin-value"]
+
+	if pinSource, has := attributes["pin-source"]; has && uri.PIN == "" {
+		pin, err := os.ReadFile(strings.TrimPrefix(pinSource, "file:"))
+		if err != nil {
+			return nil, err
+		}
+		uri.PIN = strings.TrimSpace(string(pin))
+	}
+	if uri.PIN == "" {
+		uri.PIN = os.Getenv(PINEnvVar)
+	}
+	if uri.ModulePath == "" {
+		uri.ModulePath = os.Getenv(ModulePathEnvVar)
+	}
+
+	if uri.ModulePath == "" {
+		return nil, ErrNoModulePath
+	}
+	if uri.Object == "" && uri.ID == "" {
+		return nil, ErrNoObjectSpecified
+	}
+
+	return uri, nil
+}
+
+// NewSignerVerifierFromURI returns a SignerVerifier for the key identified by
+// the PKCS#11 URI keyRef.
+func NewSignerVerifierFromURI(ctx context.Context, keyRef string) (*SignerVerifier, error) {
+	uri, err := ParseURI(keyRef)
+	if err != nil {
+		return nil, err
+	}
+
+	publicKey, err := readPublicKey(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+
+	sv := &SignerVerifier{uri: uri}
+	switch k := publicKey.(type) {
+	case *ecdsa.PublicKey:
+		// Match the hash function used by gittuf's ECDSA verifier
+		switch bitSize := k.Params().BitSize; {
+		case bitSize <= 256:
+			sv.hash = crypto.SHA256
+		case bitSize <= 384:
+			sv.hash = crypto.SHA384
+		default:
+			sv.hash = crypto.SHA512
+		}
+	case *rsa.PublicKey:
+		sv.hash = crypto.SHA256
+		sv.isRSA = true
+	default:
+		return nil, ErrUnsupportedKey
+	}
+
+	sv.key, err = sslibsv.NewKey(publicKey)
+	if err != nil {
+		return nil, err
+	}
+
+	sv.verifier, err = sslibsv.NewVerifierFromSSLibKey(sv.key)
+	if err != nil {
+		return nil, err
+	}
+
+	return sv, nil
+}
+
+// LoadPublicKey returns the public key of the key identified by the PKCS#11
+// URI keyRef for use in gittuf metadata.
+func LoadPublicKey(ctx context.Context, keyRef string) (*tuf.Key, error) {
+	sv, err := NewSignerVerifierFromURI(ctx, keyRef)
+	if err != nil {
+		return nil, err
+	}
+
+	return sv.key, nil
+}
+
+// ListKeys returns the private keys available on the token in the specified
+// slot. If slotID is empty, the first slot with a token is used.
+func ListKeys(ctx context.Context, modulePath, slotID, pin string) ([]*KeyObject, error) {
+	uri := &URI{ModulePath: modulePath, SlotID: slotID, PIN: pin}
+
+	args := append(uri.tokenArgs(), "--list-objects", "--type", "privkey")
+	if pin != "" {
+		args = append(args, "--login", "--pin", pin)
+	}
+
+	output, err := runPKCS11Tool(ctx, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return parseObjects(output), nil
+}
+
+// Sign computes the digest of data and signs it using the PKCS#11 token.
+func (sv *SignerVerifier) Sign(ctx context.Context, data []byte) ([]byte, error) {
+	hasher := sv.hash.New()
+	hasher.Write(data)
+	digest := hasher.Sum(nil)
+
+	tmpDir, err := os.MkdirTemp("", "gittuf-pkcs11-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmpDir) //nolint:errcheck
+
+	inputPath := filepath.Join(tmpDir, "digest")
+	outputPath := filepath.Join(tmpDir, "signature")
+	if err := os.WriteFile(inputPath, digest, 0o600); err != nil {
+		return nil, err
+	}
+
+	args := append(sv.uri.tokenArgs(), sv.uri.objectArgs()...)
+	args = append(args, "--sign", "--input-file", inputPath, "--output-file", outputPath)
+	if sv.uri.PIN != "" {
+		args = append(args, "--login", "--pin", sv.uri.PIN)
+	}
+	if sv.isRSA {
+		args = append(args, "--mechanism", "RSA-PKCS-PSS", "--hash-algorithm", "SHA256", "--mgf", "MGF1-SHA256")
+	} else {
+		args = append(args, "--mechanism", "ECDSA", "--signature-format", "openssl")
+	}
+
+	if _, err := runPKCS11Tool(ctx, args...); err != nil {
+		return nil, err
+	}
+
+	return os.ReadFile(outputPath)
+}
+
+// Verify verifies sig against data using the key's public key.
+func (sv *SignerVerifier) Verify(ctx context.Context, data, sig []byte) error {
+	return sv.verifier.Verify(ctx, data, sig)
+}
+
+// KeyID returns the identifier of the key's public key as recorded in gittuf
+// metadata.
+func (sv *SignerVerifier) KeyID() (string, error) {
+	return sv.key.KeyID, nil
+}
+
+// Public returns the public key of the PKCS#11 key.
+func (sv *SignerVerifier) Public() crypto.PublicKey {
+	return sv.verifier.Public()
+}
+
+func (u *URI) tokenArgs() []string {
+	args := []string{"--module", u.ModulePath}
+	switch {
+	case u.SlotID != "":
+		args = append(args, "--slot", u.SlotID)
+	case u.Token != "":
+		args = append(args, "--token-label", u.Token)
+	}
+	return args
+}
+
+func (u *URI) objectArgs() []string {
+	args := []string{}
+	if u.Object != "" {
+		args = append(args, "--label", u.Object)
+	}
+	if u.ID != "" {
+		args = append(args, "--id", fmt.Sprintf("%x", u.ID))
+	}
+	return args
+}
+
+func readPublicKey(ctx context.Context, uri *URI) (crypto.PublicKey, error) {
+	tmpDir, err := os.MkdirTemp("", "gittuf-pkcs11-")
+	if err != nil {
+		return nil, err
+	}
+	defer os.RemoveAll(tmpDir) //nolint:errcheck
+
+	outputPath := filepath.Join(tmpDir, "public")
+	args := append(uri.tokenArgs(), uri.objectArgs()...)
+	args = append(args, "--read-object", "--type", "pubkey", "--output-file", outputPath)
+
+	if _, err := runPKCS11Tool(ctx, args...); err != nil {
+		// Help the user discover the right label
+		keys, listErr := ListKeys(ctx, uri.ModulePath, uri.SlotID, uri.PIN)
+		if listErr == nil {
+			labels := []string{}
+			for _, key := range keys {
+				labels = append(labels, key.Label)
+			}
+			return nil, fmt.Errorf("%w: available keys: %s", ErrKeyNotFound, strings.Join(labels, ", "))
+		}
+		return nil, errors.Join(ErrKeyNotFound, err)
+	}
+
+	publicKeyBytes, err := os.ReadFile(outputPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if publicKey, err := x509.ParsePKIXPublicKey(publicKeyBytes); err == nil {
+		return publicKey, nil
+	}
+	return cryptoutils.UnmarshalPEMToPublicKey(publicKeyBytes)
+}
+
+// parseObjects parses the objects listed by pkcs11-tool --list-objects.
+func parseObjects(output []byte) []*KeyObject {
+	objects := []*KeyObject{}
+
+	var current *KeyObject
+	scanner := bufio.NewScanner(bytes.NewReader(output))
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+
+		if !strings.HasPrefix(line, " ") && strings.Contains(line, "Object;") {
+			current = &KeyObject{}
+			if _, keyType, found := strings.Cut(line, ";"); found {
+				current.Type = strings.TrimSpace(keyType)
+			}
+			objects = append(objects, current)
+			continue
+		}
+		if current == nil {
+			continue
+		}
+
+		name, value, found := strings.Cut(trimmed, ":")
+		if !found {
+			continue
+		}
+		switch strings.TrimSpace(name) {
+		case "label":
+			current.Label = strings.TrimSpace(value)
+		case "ID":
+			current.ID = strings.TrimSpace(value)
+		}
+	}
+
+	return objects
+}
+
+func runPKCS11Tool(ctx context.Context, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, pkcs11Tool, args...)
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w: %s", ErrPKCS11ToolFailed, err, strings.TrimSpace(stderr.String()))
+	}
+
+	return output, nil
+}
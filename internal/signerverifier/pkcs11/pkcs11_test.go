@@ -0,0 +1,89 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package pkcs11
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseURI(t *testing.T) {
+	t.Setenv(ModulePathEnvVar, "")
+	t.Setenv(PINEnvVar, "")
+
+	t.Run("all attributes in URI", func(t *testing.T) {
+		uri, err := ParseURI("pkcs11:token=gittuf;object=root%20key;slot-id=1?module-path=/usr/lib/softhsm/libsofthsm2.so&pin-value=1234")
+		assert.Nil(t, err)
+		assert.Equal(t, &URI{
+			ModulePath: "/usr/lib/softhsm/libsofthsm2.so",
+			SlotID:     "1",
+			Token:      "gittuf",
+			Object:     "root key",
+			PIN:        "1234",
+		}, uri)
+		assert.Equal(t, []string{"--module", "/usr/lib/softhsm/libsofthsm2.so", "--slot", "1"}, uri.tokenArgs())
+		assert.Equal(t, []string{"--label", "root key"}, uri.objectArgs())
+	})
+
+	t.Run("module and PIN from environment", func(t *testing.T) {
+		t.Setenv(ModulePathEnvVar, "/usr/lib/opensc-pkcs11.so")
+		t.Setenv(PINEnvVar, "5678")
+
+		uri, err := ParseURI("pkcs11:token=gittuf;object=root")
+		assert.Nil(t, err)
+		assert.Equal(t, "/usr/lib/opensc-pkcs11.so", uri.ModulePath)
+		assert.Equal(t, "5678", uri.PIN)
+		assert.Equal(t, []string{"--module", "/usr/lib/opensc-pkcs11.so", "--token-label", "gittuf"}, uri.tokenArgs())
+	})
+
+	t.Run("PIN from file", func(t *testing.T) {
+		pinFile := filepath.Join(t.TempDir(), "pin")
+		if err := os.WriteFile(pinFile, []byte("4321\n"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		uri, err := ParseURI("pkcs11:object=root?module-path=/usr/lib/softhsm/libsofthsm2.so&pin-source=file:" + pinFile)
+		assert.Nil(t, err)
+		assert.Equal(t, "4321", uri.PIN)
+	})
+
+	t.Run("no module", func(t *testing.T) {
+		_, err := ParseURI("pkcs11:object=root")
+		assert.ErrorIs(t, err, ErrNoModulePath)
+	})
+
+	t.Run("no object", func(t *testing.T) {
+		_, err := ParseURI("pkcs11:token=gittuf?module-path=/usr/lib/softhsm/libsofthsm2.so")
+		assert.ErrorIs(t, err, ErrNoObjectSpecified)
+	})
+
+	t.Run("malformed URI", func(t *testing.T) {
+		_, err := ParseURI("pkcs11:object")
+		assert.ErrorIs(t, err, ErrInvalidKeyReference)
+
+		_, err = ParseURI("object=root")
+		assert.ErrorIs(t, err, ErrInvalidKeyReference)
+	})
+}
+
+func TestParseObjects(t *testing.T) {
+	output := []byte(`Private Key Object; EC
+  label:      root
+  ID:         01
+  Usage:      sign
+  Access:     sensitive, always sensitive, never extractable, local
+Private Key Object; RSA
+  label:      targets
+  ID:         02
+  Usage:      decrypt, sign, unwrap
+`)
+
+	objects := parseObjects(output)
+	assert.Equal(t, []*KeyObject{
+		{Label: "root", ID: "01", Type: "EC"},
+		{Label: "targets", ID: "02", Type: "RSA"},
+	}, objects)
+}
@@ -8,6 +8,9 @@ import (
 	"encoding/json"
 
 	"github.com/gittuf/gittuf/internal/signerverifier/common"
+	"github.com/gittuf/gittuf/internal/signerverifier/sigstore"
+	"github.com/gittuf/gittuf/internal/signerverifier/sshca"
+	"github.com/gittuf/gittuf/internal/signerverifier/x509"
 	sslibsv "github.com/gittuf/gittuf/internal/third_party/go-securesystemslib/signerverifier"
 	"github.com/gittuf/gittuf/internal/tuf"
 	"github.com/secure-systems-lab/go-securesystemslib/dsse"
@@ -18,8 +21,10 @@ const (
 	ECDSAKeyType    = sslibsv.ECDSAKeyType
 	RSAKeyType      = sslibsv.RSAKeyType
 	GPGKeyType      = "gpg"
-	FulcioKeyType   = "sigstore-oidc"
-	FulcioKeyScheme = "fulcio"
+	FulcioKeyType   = sigstore.KeyType
+	FulcioKeyScheme = sigstore.KeyScheme
+	X509KeyType     = x509.KeyType
+	SSHCAKeyType    = sshca.KeyType
 	RekorServer     = "https://rekor.sigstore.dev"
 )
 
@@ -36,8 +41,9 @@ type keyVal struct {
 	Public  string `json:"public"`
 }
 
-// NewSignerVerifierFromTUFKey returns a verifier for RSA, ED25519, and ECDSA
-// keys. While this is called signerverifier, tuf.Key only supports public keys.
+// NewSignerVerifierFromTUFKey returns a verifier for RSA, ED25519, ECDSA,
+// Sigstore, and X.509 keys. While this is called signerverifier, tuf.Key only
+// supports public keys.
 //
 // Deprecated: Switch to upstream key loading APIs.
 func NewSignerVerifierFromTUFKey(key *tuf.Key) (dsse.SignerVerifier, error) {
@@ -48,10 +54,27 @@ func NewSignerVerifierFromTUFKey(key *tuf.Key) (dsse.SignerVerifier, error) {
 		return sslibsv.NewECDSASignerVerifierFromSSLibKey(key)
 	case RSAKeyType:
 		return sslibsv.NewRSAPSSSignerVerifierFromSSLibKey(key)
+	case FulcioKeyType:
+		return sigstore.NewVerifierFromKey(key)
+	case X509KeyType:
+		return x509.NewVerifierFromKey(key)
 	}
 	return nil, common.ErrUnknownKeyType
 }
 
+// NewKeyFromSigner returns the tuf.Key that can be used to verify signatures
+// created by the signer.
+func NewKeyFromSigner(signer dsse.SignerVerifier) (*tuf.Key, error) {
+	if keylessSigner, ok := signer.(*sigstore.Signer); ok {
+		return keylessSigner.Key()
+	}
+	if x509Signer, ok := signer.(*x509.Signer); ok {
+		return x509Signer.Key()
+	}
+
+	return sslibsv.NewKey(signer.Public())
+}
+
 // NewSignerVerifierFromSecureSystemsLibFormat parses the bytes of a public or
 // private key in the legacy sslib encoding format. This will eventually be
 // removed as gittuf switches to standard on-disk key serialization.
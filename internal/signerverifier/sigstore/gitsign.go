@@ -0,0 +1,204 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sigstore
+
+import (
+	"context"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"time"
+
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/sigstore/cosign/v2/pkg/cosign"
+	gitsignGit "github.com/sigstore/gitsign/pkg/git"
+	gitsignRekor "github.com/sigstore/gitsign/pkg/rekor"
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"github.com/sigstore/sigstore/pkg/fulcioroots"
+)
+
+// RootFileEnvKey is the environment variable that, when set, points to a PEM
+// file with the Fulcio root and intermediate certificates to trust instead of
+// those distributed using Sigstore's TUF repository. This matches the variable
+// used by other Sigstore clients.
+const RootFileEnvKey = "SIGSTORE_ROOT_FILE"
+
+// TrustedRoot contains the Sigstore trust material used to verify
+// signatures: the Fulcio certificate authority, and the keys of the
+// certificate transparency and Rekor transparency logs. If CTLogPubKeys is
+// nil, certificates are not required to embed signed certificate timestamps,
+// which may be the case for private Sigstore deployments.
+type TrustedRoot struct {
+	FulcioRoots         *x509.CertPool
+	FulcioIntermediates *x509.CertPool
+	CTLogPubKeys        *cosign.TrustedTransparencyLogPubKeys
+	RekorPubKeys        *cosign.TrustedTransparencyLogPubKeys
+}
+
+// GetTrustedRoot returns the trust material of the public good Sigstore
+// instance. It is obtained using Sigstore's TUF repository, starting from the
+// TUF root bundled with the Sigstore client libraries, and cached locally
+// after the first update. The Fulcio certificates can be overridden using
+// RootFileEnvKey, and the log keys using SIGSTORE_CT_LOG_PUBLIC_KEY_FILE and
+// SIGSTORE_REKOR_PUBLIC_KEY.
+func GetTrustedRoot(ctx context.Context) (*TrustedRoot, error) {
+	trustedRoot := &TrustedRoot{}
+
+	if rootFile := os.Getenv(RootFileEnvKey); rootFile != "" {
+		slog.Debug(fmt.Sprintf("Loading Fulcio certificates from '%s'...", rootFile))
+		roots, intermediates, err := loadFulcioCertificates(rootFile)
+		if err != nil {
+			return nil, err
+		}
+		trustedRoot.FulcioRoots = roots
+		trustedRoot.FulcioIntermediates = intermediates
+	} else {
+		roots, err := fulcioroots.Get()
+		if err != nil {
+			return nil, err
+		}
+		intermediates, err := fulcioroots.GetIntermediates()
+		if err != nil {
+			return nil, err
+		}
+		trustedRoot.FulcioRoots = roots
+		trustedRoot.FulcioIntermediates = intermediates
+	}
+
+	ctPubKeys, err := cosign.GetCTLogPubs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	trustedRoot.CTLogPubKeys = ctPubKeys
+
+	rekorPubKeys, err := cosign.GetRekorPubs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	trustedRoot.RekorPubKeys = rekorPubKeys
+
+	return trustedRoot, nil
+}
+
+// VerifyGitSignature verifies a Git signature created using gitsign against
+// the public good Sigstore instance. See VerifyGitSignatureWithTrustedRoot.
+func VerifyGitSignature(ctx context.Context, key *tuf.Key, data, signature []byte) error {
+	trustedRoot, err := GetTrustedRoot(ctx)
+	if err != nil {
+		return errors.Join(ErrVerifyingSignature, err)
+	}
+
+	return VerifyGitSignatureWithTrustedRoot(ctx, key, data, signature, trustedRoot, DefaultRekorURL)
+}
+
+// VerifyGitSignatureWithTrustedRoot verifies a detached CMS signature over
+// data created using gitsign. The Fulcio certificate used to sign must chain
+// to trustedRoot and be issued for an identity trusted by key. The signature
+// must also be recorded in the Rekor transparency log while the certificate
+// was valid. Recent versions of gitsign embed the log entry in the signature,
+// which is verified offline. Otherwise, the entry is looked up in the Rekor
+// instance at rekorURL.
+func VerifyGitSignatureWithTrustedRoot(ctx context.Context, key *tuf.Key, data, signature []byte, trustedRoot *TrustedRoot, rekorURL string) error {
+	if key.KeyType != KeyType {
+		return ErrNotSigstoreKey
+	}
+
+	certVerifier, err := gitsignGit.NewCertVerifier(
+		gitsignGit.WithRootPool(trustedRoot.FulcioRoots),
+		gitsignGit.WithIntermediatePool(trustedRoot.FulcioIntermediates),
+	)
+	if err != nil {
+		return errors.Join(ErrVerifyingSignature, err)
+	}
+
+	cert, err := certVerifier.Verify(ctx, data, signature, true)
+	if err != nil {
+		return errors.Join(ErrVerifyingSignature, err)
+	}
+
+	// Check the identity before looking for the transparency log entry, which
+	// may require contacting Rekor
+	checkOpts := &cosign.CheckOpts{
+		RootCerts:         trustedRoot.FulcioRoots,
+		IntermediateCerts: trustedRoot.FulcioIntermediates,
+		CTLogPubKeys:      trustedRoot.CTLogPubKeys,
+		Identities:        []cosign.Identity{CertificateIdentity(key)},
+		IgnoreSCT:         trustedRoot.CTLogPubKeys == nil,
+	}
+	if _, err := cosign.ValidateAndUnpackCert(cert, checkOpts); err != nil {
+		return errors.Join(ErrUnexpectedIdentity, err)
+	}
+
+	tlogEntry, err := getGitSignatureTLogEntry(ctx, data, signature, cert, trustedRoot, rekorURL)
+	if err != nil {
+		return errors.Join(ErrMissingTLogEntry, err)
+	}
+	if tlogEntry.IntegratedTime == nil {
+		return ErrMissingTLogEntry
+	}
+
+	// Fulcio certificates are short lived, so the signature is only trusted if
+	// it was logged while the certificate was valid
+	if err := cosign.CheckExpiry(cert, time.Unix(*tlogEntry.IntegratedTime, 0)); err != nil {
+		return errors.Join(ErrVerifyingSignature, err)
+	}
+
+	return nil
+}
+
+// getGitSignatureTLogEntry returns the verified Rekor entry for a gitsign
+// signature, preferring the entry embedded in the signature.
+func getGitSignatureTLogEntry(ctx context.Context, data, signature []byte, cert *x509.Certificate, trustedRoot *TrustedRoot, rekorURL string) (*models.LogEntryAnon, error) {
+	rekor, err := gitsignRekor.NewWithOptions(ctx, rekorURL, gitsignRekor.WithCosignRekorKeyProvider(func(_ context.Context) (*cosign.TrustedTransparencyLogPubKeys, error) {
+		return trustedRoot.RekorPubKeys, nil
+	}))
+	if err != nil {
+		return nil, err
+	}
+
+	tlogEntry, err := rekor.VerifyInclusion(ctx, signature, cert)
+	if err == nil {
+		return tlogEntry, nil
+	}
+	slog.Debug(fmt.Sprintf("Signature does not embed a valid transparency log entry (%s), searching Rekor...", err.Error()))
+
+	// Older versions of gitsign record the object's ID in the log instead
+	objectID, err := gitsignGit.ObjectHash(data, signature)
+	if err != nil {
+		return nil, err
+	}
+
+	return rekor.Verify(ctx, objectID, cert)
+}
+
+// loadFulcioCertificates reads the PEM encoded certificates in path,
+// returning the self-signed certificates as roots and the rest as
+// intermediates.
+func loadFulcioCertificates(path string) (*x509.CertPool, *x509.CertPool, error) {
+	contents, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+	certs, err := cryptoutils.UnmarshalCertificatesFromPEM(contents)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(certs) == 0 {
+		return nil, nil, fmt.Errorf("no certificates found in '%s'", path)
+	}
+
+	roots := x509.NewCertPool()
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs {
+		if cert.CheckSignatureFrom(cert) == nil {
+			roots.AddCert(cert)
+		} else {
+			intermediates.AddCert(cert)
+		}
+	}
+
+	return roots, intermediates, nil
+}
@@ -0,0 +1,186 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sigstore
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/digitorus/pkcs7"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testGitsignIdentity = "jane.doe@example.com"
+	testGitsignIssuer   = "https://github.com/login/oauth"
+)
+
+func TestVerifyGitSignatureWithTrustedRoot(t *testing.T) {
+	rootKey, root := createTestFulcioRoot(t)
+	signature, data := createTestGitsignSignature(t, rootKey, root)
+
+	trustedRoot := &TrustedRoot{
+		FulcioRoots:         x509.NewCertPool(),
+		FulcioIntermediates: x509.NewCertPool(),
+	}
+	trustedRoot.FulcioRoots.AddCert(root)
+
+	// The test signature does not embed a log entry, and the log does not
+	// have one either
+	rekor := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("[]")) //nolint:errcheck
+	}))
+	defer rekor.Close()
+
+	key := NewKey(testGitsignIdentity, testGitsignIssuer)
+
+	t.Run("no transparency log entry", func(t *testing.T) {
+		err := VerifyGitSignatureWithTrustedRoot(context.Background(), key, data, signature, trustedRoot, rekor.URL)
+		assert.ErrorIs(t, err, ErrMissingTLogEntry)
+	})
+
+	t.Run("unexpected identity", func(t *testing.T) {
+		key := NewKey("john.doe@example.com", testGitsignIssuer)
+
+		err := VerifyGitSignatureWithTrustedRoot(context.Background(), key, data, signature, trustedRoot, rekor.URL)
+		assert.ErrorIs(t, err, ErrUnexpectedIdentity)
+
+		key, err = NewPatternKey(`.*@example\.com`, `https://github\.com/.*`)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = VerifyGitSignatureWithTrustedRoot(context.Background(), key, data, signature, trustedRoot, rekor.URL)
+		assert.ErrorIs(t, err, ErrMissingTLogEntry)
+	})
+
+	t.Run("untrusted root", func(t *testing.T) {
+		_, otherRoot := createTestFulcioRoot(t)
+		otherTrustedRoot := &TrustedRoot{
+			FulcioRoots:         x509.NewCertPool(),
+			FulcioIntermediates: x509.NewCertPool(),
+		}
+		otherTrustedRoot.FulcioRoots.AddCert(otherRoot)
+
+		err := VerifyGitSignatureWithTrustedRoot(context.Background(), key, data, signature, otherTrustedRoot, rekor.URL)
+		assert.ErrorIs(t, err, ErrVerifyingSignature)
+	})
+
+	t.Run("modified data", func(t *testing.T) {
+		err := VerifyGitSignatureWithTrustedRoot(context.Background(), key, []byte("modified"), signature, trustedRoot, rekor.URL)
+		assert.ErrorIs(t, err, ErrVerifyingSignature)
+	})
+
+	t.Run("root file", func(t *testing.T) {
+		rootPEM, err := cryptoutils.MarshalCertificateToPEM(root)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rootFile := filepath.Join(t.TempDir(), "fulcio.pem")
+		if err := os.WriteFile(rootFile, rootPEM, 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		roots, intermediates, err := loadFulcioCertificates(rootFile)
+		assert.Nil(t, err)
+		assert.True(t, roots.Equal(trustedRoot.FulcioRoots))
+		assert.True(t, intermediates.Equal(x509.NewCertPool()))
+	})
+}
+
+func createTestFulcioRoot(t *testing.T) (*ecdsa.PrivateKey, *x509.Certificate) {
+	t.Helper()
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: "gittuf test Fulcio"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, template, privateKey.Public(), privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(certDER)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return privateKey, cert
+}
+
+// createTestGitsignSignature returns a PEM encoded detached signature over a
+// commit, created using a certificate issued by root in the same way Fulcio
+// issues certificates to gitsign.
+func createTestGitsignSignature(t *testing.T, rootKey *ecdsa.PrivateKey, root *x509.Certificate) ([]byte, []byte) {
+	t.Helper()
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:   big.NewInt(time.Now().UnixNano()),
+		NotBefore:      time.Now().Add(-time.Minute),
+		NotAfter:       time.Now().Add(10 * time.Minute),
+		EmailAddresses: []string{testGitsignIdentity},
+		KeyUsage:       x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:    []x509.ExtKeyUsage{x509.ExtKeyUsageCodeSigning},
+		ExtraExtensions: []pkix.Extension{{
+			// Fulcio's OIDC issuer extension
+			Id:    asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 57264, 1, 1},
+			Value: []byte(testGitsignIssuer),
+		}},
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, template, root, privateKey.Public(), rootKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := x509.ParseCertificate(certDER)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data := []byte("tree 4b825dc642cb6eb9a060e54bf8d69288fbee4904\nauthor Jane Doe <jane.doe@example.com> 1700000000 +0000\ncommitter Jane Doe <jane.doe@example.com> 1700000000 +0000\n\nTest commit\n")
+
+	signedData, err := pkcs7.NewSignedData(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signedData.SetDigestAlgorithm(pkcs7.OIDDigestAlgorithmSHA256)
+	if err := signedData.AddSigner(cert, privateKey, pkcs7.SignerInfoConfig{}); err != nil {
+		t.Fatal(err)
+	}
+	signedData.Detach()
+	signature, err := signedData.Finish()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return pem.EncodeToMemory(&pem.Block{Type: "SIGNED MESSAGE", Bytes: signature}), data
+}
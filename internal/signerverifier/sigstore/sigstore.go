@@ -0,0 +1,542 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sigstore
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	sslibsv "github.com/gittuf/gittuf/internal/third_party/go-securesystemslib/signerverifier"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/sigstore/cosign/v2/pkg/cosign"
+	"github.com/sigstore/cosign/v2/pkg/providers"
+	rekorclient "github.com/sigstore/rekor/pkg/client"
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+
+	// Register the ambient OIDC token providers supported for keyless
+	// signing.
+	_ "github.com/sigstore/cosign/v2/pkg/providers/envvar"
+	_ "github.com/sigstore/cosign/v2/pkg/providers/filesystem"
+	_ "github.com/sigstore/cosign/v2/pkg/providers/github"
+)
+
+const (
+	KeyType   = "sigstore-oidc"
+	KeyScheme = "fulcio"
+
+	DefaultFulcioURL = "https://fulcio.sigstore.dev"
+	DefaultRekorURL  = "https://rekor.sigstore.dev"
+
+	oidcAudience = "sigstore"
+)
+
+var (
+	ErrNoIDToken          = errors.New("unable to find OIDC identity token for keyless signing, set SIGSTORE_ID_TOKEN")
+	ErrInvalidIDToken     = errors.New("unable to parse OIDC identity token")
+	ErrRequestingCert     = errors.New("unable to obtain signing certificate from Fulcio")
+	ErrUnexpectedIdentity = errors.New("identity in signing certificate does not match expected identity")
+	ErrInvalidSignature   = errors.New("unable to parse Sigstore signature bundle")
+	ErrMissingTLogEntry   = errors.New("Sigstore signature bundle does not contain transparency log entry")
+	ErrNotSigstoreKey     = errors.New("key is not a Sigstore identity")
+	ErrVerifyingSignature = errors.New("unable to verify Sigstore signature")
+	ErrSignerCannotVerify = errors.New("keyless signer cannot be used for verification")
+	ErrVerifierCannotSign = errors.New("Sigstore verifier cannot be used for signing")
+	ErrInvalidPattern     = errors.New("invalid Sigstore identity pattern")
+)
+
+// Bundle is the signature stored in DSSE envelopes signed keylessly. In
+// addition to the raw signature, it carries the ephemeral Fulcio certificate
+// used to sign and the transparency log entry that records when the signature
+// was created.
+type Bundle struct {
+	Certificate []byte               `json:"certificate"`
+	Signature   []byte               `json:"signature"`
+	TLogEntry   *models.LogEntryAnon `json:"tlogEntry,omitempty"`
+}
+
+// Signer implements the dsse.SignerVerifier interface using ephemeral keys
+// certified by Fulcio for an OIDC identity. The identity is discovered from
+// the ambient environment the first time the signer is used.
+type Signer struct {
+	FulcioURL string
+	RekorURL  string
+
+	// Identity and Issuer, if set, must match the identity in the certificate
+	// issued by Fulcio.
+	Identity string
+	Issuer   string
+
+	privateKey  *ecdsa.PrivateKey
+	certificate []byte
+	identity    string
+	issuer      string
+}
+
+// NewSigner returns a Signer using the public good Sigstore instance. If
+// identity and issuer are not empty, they are checked against the identity
+// Fulcio certifies.
+func NewSigner(identity, issuer string) *Signer {
+	return &Signer{
+		FulcioURL: DefaultFulcioURL,
+		RekorURL:  DefaultRekorURL,
+		Identity:  identity,
+		Issuer:    issuer,
+	}
+}
+
+// KeyID returns the identifier of the signer's identity in the format
+// `identity::issuer`, matching keys added using the `fulcio:` prefix.
+func (s *Signer) KeyID() (string, error) {
+	if err := s.loadCertificate(context.Background()); err != nil {
+		return "", err
+	}
+
+	return KeyID(s.identity, s.issuer), nil
+}
+
+// Key returns the gittuf key that corresponds to the signer's identity.
+func (s *Signer) Key() (*tuf.Key, error) {
+	if err := s.loadCertificate(context.Background()); err != nil {
+		return nil, err
+	}
+
+	return NewKey(s.identity, s.issuer), nil
+}
+
+// Public returns nil as the ephemeral signing key is not tied to the identity.
+func (s *Signer) Public() crypto.PublicKey {
+	return nil
+}
+
+// Sign creates a signature for data using the ephemeral key, records it in the
+// transparency log, and returns the encoded Bundle.
+func (s *Signer) Sign(ctx context.Context, data []byte) ([]byte, error) {
+	if err := s.loadCertificate(ctx); err != nil {
+		return nil, err
+	}
+
+	digest := sha256.Sum256(data)
+	sig, err := ecdsa.SignASN1(rand.Reader, s.privateKey, digest[:])
+	if err != nil {
+		return nil, err
+	}
+
+	rekor, err := rekorclient.GetRekorClient(s.RekorURL)
+	if err != nil {
+		return nil, err
+	}
+
+	hasher := sha256.New()
+	hasher.Write(data)
+	entry, err := cosign.TLogUpload(ctx, rekor, sig, hasher, s.certificate)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(&Bundle{
+		Certificate: s.certificate,
+		Signature:   sig,
+		TLogEntry:   entry,
+	})
+}
+
+// Verify is not supported by the Signer, use a Verifier instead.
+func (s *Signer) Verify(_ context.Context, _, _ []byte) error {
+	return ErrSignerCannotVerify
+}
+
+func (s *Signer) loadCertificate(ctx context.Context) error {
+	if s.certificate != nil {
+		return nil
+	}
+
+	if !providers.Enabled(ctx) {
+		return ErrNoIDToken
+	}
+	token, err := providers.Provide(ctx, oidcAudience)
+	if err != nil {
+		return errors.Join(ErrNoIDToken, err)
+	}
+
+	subject, err := getTokenSubject(token)
+	if err != nil {
+		return err
+	}
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return err
+	}
+
+	certificate, err := requestCertificate(ctx, s.FulcioURL, token, subject, privateKey)
+	if err != nil {
+		return err
+	}
+
+	cert, err := parseCertificate(certificate)
+	if err != nil {
+		return err
+	}
+	identity, issuer := getCertificateIdentity(cert)
+	if (s.Identity != "" && s.Identity != identity) || (s.Issuer != "" && s.Issuer != issuer) {
+		return fmt.Errorf("%w: certificate issued for '%s'", ErrUnexpectedIdentity, KeyID(identity, issuer))
+	}
+
+	s.privateKey = privateKey
+	s.certificate = certificate
+	s.identity = identity
+	s.issuer = issuer
+
+	return nil
+}
+
+// Verifier implements the dsse.SignerVerifier interface for signatures
+// created by Signer. It checks that the Fulcio certificate in the bundle was
+// issued for the expected identity, and that the signature was recorded in the
+// transparency log while the certificate was valid.
+type Verifier struct {
+	keyID    string
+	identity cosign.Identity
+}
+
+// NewVerifierFromKey returns a Verifier for a gittuf key of type KeyType.
+func NewVerifierFromKey(key *tuf.Key) (*Verifier, error) {
+	if key.KeyType != KeyType {
+		return nil, ErrNotSigstoreKey
+	}
+
+	return &Verifier{
+		keyID:    key.KeyID,
+		identity: CertificateIdentity(key),
+	}, nil
+}
+
+// KeyID returns the identifier of the verifier's key.
+func (v *Verifier) KeyID() (string, error) {
+	return v.keyID, nil
+}
+
+// Public returns nil as Sigstore identities do not have a static public key.
+func (v *Verifier) Public() crypto.PublicKey {
+	return nil
+}
+
+// Sign is not supported by the Verifier.
+func (v *Verifier) Sign(_ context.Context, _ []byte) ([]byte, error) {
+	return nil, ErrVerifierCannotSign
+}
+
+// Verify checks the encoded Bundle in sig against data.
+func (v *Verifier) Verify(ctx context.Context, data, sig []byte) error {
+	bundle := &Bundle{}
+	if err := json.Unmarshal(sig, bundle); err != nil {
+		return errors.Join(ErrInvalidSignature, err)
+	}
+	if bundle.TLogEntry == nil || bundle.TLogEntry.IntegratedTime == nil {
+		return ErrMissingTLogEntry
+	}
+
+	cert, err := parseCertificate(bundle.Certificate)
+	if err != nil {
+		return errors.Join(ErrInvalidSignature, err)
+	}
+
+	trustedRoot, err := GetTrustedRoot(ctx)
+	if err != nil {
+		return errors.Join(ErrVerifyingSignature, err)
+	}
+
+	checkOpts := &cosign.CheckOpts{
+		RootCerts:         trustedRoot.FulcioRoots,
+		IntermediateCerts: trustedRoot.FulcioIntermediates,
+		CTLogPubKeys:      trustedRoot.CTLogPubKeys,
+		Identities:        []cosign.Identity{v.identity},
+	}
+
+	verifier, err := cosign.ValidateAndUnpackCert(cert, checkOpts)
+	if err != nil {
+		return errors.Join(ErrVerifyingSignature, err)
+	}
+
+	if err := verifier.VerifySignature(bytes.NewReader(bundle.Signature), bytes.NewReader(data)); err != nil {
+		return errors.Join(ErrVerifyingSignature, err)
+	}
+
+	// Ensure the transparency log entry is for this signature before using
+	// its timestamp
+	if err := verifyTLogEntryBody(bundle, data); err != nil {
+		return err
+	}
+	if err := cosign.VerifyTLogEntryOffline(ctx, bundle.TLogEntry, trustedRoot.RekorPubKeys); err != nil {
+		return errors.Join(ErrVerifyingSignature, err)
+	}
+	if err := cosign.CheckExpiry(cert, time.Unix(*bundle.TLogEntry.IntegratedTime, 0)); err != nil {
+		return errors.Join(ErrVerifyingSignature, err)
+	}
+
+	return nil
+}
+
+// KeyID returns the key ID used for a Sigstore identity.
+func KeyID(identity, issuer string) string {
+	return fmt.Sprintf("%s::%s", identity, issuer)
+}
+
+// NewKey returns a gittuf key for the specified Sigstore identity.
+func NewKey(identity, issuer string) *tuf.Key {
+	return &sslibsv.SSLibKey{
+		KeyID:   KeyID(identity, issuer),
+		KeyType: KeyType,
+		Scheme:  KeyScheme,
+		KeyVal: sslibsv.KeyVal{
+			Identity: identity,
+			Issuer:   issuer,
+		},
+	}
+}
+
+// PatternKeyID returns the key ID used for the Sigstore identities matching
+// identityPattern and issuerPattern.
+func PatternKeyID(identityPattern, issuerPattern string) string {
+	return fmt.Sprintf("regexp:%s::%s", identityPattern, issuerPattern)
+}
+
+// NewPatternKey returns a gittuf key that authorizes every Sigstore identity
+// whose certificate's subject alternative name matches identityPattern and
+// whose OIDC issuer matches issuerPattern. The patterns are regular
+// expressions that must match the entire value. All identities matching the
+// key are treated as a single key, so they count once towards a threshold.
+func NewPatternKey(identityPattern, issuerPattern string) (*tuf.Key, error) {
+	for _, pattern := range []string{identityPattern, issuerPattern} {
+		if pattern == "" {
+			return nil, fmt.Errorf("%w: pattern must not be empty", ErrInvalidPattern)
+		}
+		if _, err := regexp.Compile(anchorPattern(pattern)); err != nil {
+			return nil, errors.Join(ErrInvalidPattern, err)
+		}
+	}
+
+	return &sslibsv.SSLibKey{
+		KeyID:   PatternKeyID(identityPattern, issuerPattern),
+		KeyType: KeyType,
+		Scheme:  KeyScheme,
+		KeyVal: sslibsv.KeyVal{
+			IdentityPattern: identityPattern,
+			IssuerPattern:   issuerPattern,
+		},
+	}, nil
+}
+
+// CertificateIdentity returns the identity that a Fulcio certificate must be
+// issued for to be trusted as key.
+func CertificateIdentity(key *tuf.Key) cosign.Identity {
+	identity := cosign.Identity{
+		Issuer:  key.KeyVal.Issuer,
+		Subject: key.KeyVal.Identity,
+	}
+	if key.KeyVal.IdentityPattern != "" {
+		identity.Subject = ""
+		identity.SubjectRegExp = anchorPattern(key.KeyVal.IdentityPattern)
+	}
+	if key.KeyVal.IssuerPattern != "" {
+		identity.Issuer = ""
+		identity.IssuerRegExp = anchorPattern(key.KeyVal.IssuerPattern)
+	}
+
+	return identity
+}
+
+func anchorPattern(pattern string) string {
+	return fmt.Sprintf("^(?:%s)$", pattern)
+}
+
+type fulcioCertificateRequest struct {
+	Credentials struct {
+		OIDCIdentityToken string `json:"oidcIdentityToken"`
+	} `json:"credentials"`
+	PublicKeyRequest struct {
+		PublicKey struct {
+			Algorithm string `json:"algorithm"`
+			Content   string `json:"content"`
+		} `json:"publicKey"`
+		ProofOfPossession []byte `json:"proofOfPossession"`
+	} `json:"publicKeyRequest"`
+}
+
+type fulcioCertificateChain struct {
+	Chain struct {
+		Certificates []string `json:"certificates"`
+	} `json:"chain"`
+}
+
+type fulcioCertificateResponse struct {
+	SignedCertificateEmbeddedSct *fulcioCertificateChain `json:"signedCertificateEmbeddedSct"`
+	SignedCertificateDetachedSct *fulcioCertificateChain `json:"signedCertificateDetachedSct"`
+}
+
+// requestCertificate uses Fulcio's v2 API to obtain a certificate for the
+// public key of privateKey. The PEM encoded leaf certificate is returned.
+func requestCertificate(ctx context.Context, fulcioURL, token, subject string, privateKey *ecdsa.PrivateKey) ([]byte, error) {
+	publicKeyPEM, err := cryptoutils.MarshalPublicKeyToPEM(privateKey.Public())
+	if err != nil {
+		return nil, err
+	}
+
+	subjectDigest := sha256.Sum256([]byte(subject))
+	proof, err := ecdsa.SignASN1(rand.Reader, privateKey, subjectDigest[:])
+	if err != nil {
+		return nil, err
+	}
+
+	request := &fulcioCertificateRequest{}
+	request.Credentials.OIDCIdentityToken = token
+	request.PublicKeyRequest.PublicKey.Algorithm = "ECDSA"
+	request.PublicKeyRequest.PublicKey.Content = string(publicKeyPEM)
+	request.PublicKeyRequest.ProofOfPossession = proof
+
+	requestBody, err := json.Marshal(request)
+	if err != nil {
+		return nil, err
+	}
+
+	httpRequest, err := http.NewRequestWithContext(ctx, http.MethodPost, strings.TrimSuffix(fulcioURL, "/")+"/api/v2/signingCert", bytes.NewReader(requestBody))
+	if err != nil {
+		return nil, err
+	}
+	httpRequest.Header.Set("Content-Type", "application/json")
+
+	httpResponse, err := http.DefaultClient.Do(httpRequest)
+	if err != nil {
+		return nil, errors.Join(ErrRequestingCert, err)
+	}
+	defer httpResponse.Body.Close() //nolint:errcheck
+
+	responseBody, err := io.ReadAll(httpResponse.Body)
+	if err != nil {
+		return nil, errors.Join(ErrRequestingCert, err)
+	}
+	if httpResponse.StatusCode != http.StatusOK && httpResponse.StatusCode != http.StatusCreated {
+		return nil, fmt.Errorf("%w: %s: %s", ErrRequestingCert, httpResponse.Status, strings.TrimSpace(string(responseBody)))
+	}
+
+	response := &fulcioCertificateResponse{}
+	if err := json.Unmarshal(responseBody, response); err != nil {
+		return nil, errors.Join(ErrRequestingCert, err)
+	}
+
+	chain := response.SignedCertificateEmbeddedSct
+	if chain == nil {
+		chain = response.SignedCertificateDetachedSct
+	}
+	if chain == nil || len(chain.Chain.Certificates) == 0 {
+		return nil, fmt.Errorf("%w: response does not contain certificate", ErrRequestingCert)
+	}
+
+	return []byte(chain.Chain.Certificates[0]), nil
+}
+
+// getTokenSubject returns the subject of the OIDC token that Fulcio expects
+// the proof of possession to be computed over. This is the email claim for
+// tokens that include it, and the sub claim otherwise. The token's signature
+// is not checked, that is Fulcio's responsibility.
+func getTokenSubject(token string) (string, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return "", ErrInvalidIDToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return "", errors.Join(ErrInvalidIDToken, err)
+	}
+
+	claims := struct {
+		Subject string `json:"sub"`
+		Email   string `json:"email"`
+	}{}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return "", errors.Join(ErrInvalidIDToken, err)
+	}
+
+	if claims.Email != "" {
+		return claims.Email, nil
+	}
+	if claims.Subject == "" {
+		return "", ErrInvalidIDToken
+	}
+	return claims.Subject, nil
+}
+
+func getCertificateIdentity(cert *x509.Certificate) (string, string) {
+	identity := ""
+	if sans := cryptoutils.GetSubjectAlternateNames(cert); len(sans) > 0 {
+		identity = sans[0]
+	}
+
+	extensions := cosign.CertExtensions{Cert: cert}
+	return identity, extensions.GetIssuer()
+}
+
+func parseCertificate(certificate []byte) (*x509.Certificate, error) {
+	certs, err := cryptoutils.UnmarshalCertificatesFromPEM(certificate)
+	if err != nil {
+		return nil, err
+	}
+	if len(certs) == 0 {
+		return nil, ErrInvalidSignature
+	}
+
+	return certs[0], nil
+}
+
+// verifyTLogEntryBody checks that the hashedrekord entry in the transparency
+// log records the bundle's signature over data.
+func verifyTLogEntryBody(bundle *Bundle, data []byte) error {
+	encodedBody, ok := bundle.TLogEntry.Body.(string)
+	if !ok {
+		return ErrMissingTLogEntry
+	}
+	body, err := base64.StdEncoding.DecodeString(encodedBody)
+	if err != nil {
+		return errors.Join(ErrInvalidSignature, err)
+	}
+
+	entry := struct {
+		Spec struct {
+			Data struct {
+				Hash struct {
+					Value string `json:"value"`
+				} `json:"hash"`
+			} `json:"data"`
+			Signature struct {
+				Content []byte `json:"content"`
+			} `json:"signature"`
+		} `json:"spec"`
+	}{}
+	if err := json.Unmarshal(body, &entry); err != nil {
+		return errors.Join(ErrInvalidSignature, err)
+	}
+
+	digest := sha256.Sum256(data)
+	if entry.Spec.Data.Hash.Value != fmt.Sprintf("%x", digest) || !bytes.Equal(entry.Spec.Signature.Content, bundle.Signature) {
+		return fmt.Errorf("%w: transparency log entry does not match signature", ErrVerifyingSignature)
+	}
+
+	return nil
+}
@@ -0,0 +1,117 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sigstore
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetTokenSubject(t *testing.T) {
+	createToken := func(claims map[string]string) string {
+		payload, err := json.Marshal(claims)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return "header." + base64.RawURLEncoding.EncodeToString(payload) + ".signature"
+	}
+
+	t.Run("email claim", func(t *testing.T) {
+		subject, err := getTokenSubject(createToken(map[string]string{"sub": "1234", "email": "jane.doe@example.com"}))
+		assert.Nil(t, err)
+		assert.Equal(t, "jane.doe@example.com", subject)
+	})
+
+	t.Run("sub claim", func(t *testing.T) {
+		subject, err := getTokenSubject(createToken(map[string]string{"sub": "repo:gittuf/gittuf:ref:refs/heads/main"}))
+		assert.Nil(t, err)
+		assert.Equal(t, "repo:gittuf/gittuf:ref:refs/heads/main", subject)
+	})
+
+	t.Run("no subject", func(t *testing.T) {
+		_, err := getTokenSubject(createToken(map[string]string{}))
+		assert.ErrorIs(t, err, ErrInvalidIDToken)
+	})
+
+	t.Run("malformed token", func(t *testing.T) {
+		_, err := getTokenSubject("not-a-token")
+		assert.ErrorIs(t, err, ErrInvalidIDToken)
+	})
+}
+
+func TestVerifier(t *testing.T) {
+	key := NewKey("jane.doe@example.com", "https://github.com/login/oauth")
+	assert.Equal(t, "jane.doe@example.com::https://github.com/login/oauth", key.KeyID)
+
+	verifier, err := NewVerifierFromKey(key)
+	assert.Nil(t, err)
+
+	keyID, err := verifier.KeyID()
+	assert.Nil(t, err)
+	assert.Equal(t, key.KeyID, keyID)
+
+	t.Run("malformed bundle", func(t *testing.T) {
+		err := verifier.Verify(context.Background(), []byte("data"), []byte("not-a-bundle"))
+		assert.ErrorIs(t, err, ErrInvalidSignature)
+	})
+
+	t.Run("bundle without transparency log entry", func(t *testing.T) {
+		bundle, err := json.Marshal(&Bundle{Signature: []byte("signature")})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = verifier.Verify(context.Background(), []byte("data"), bundle)
+		assert.ErrorIs(t, err, ErrMissingTLogEntry)
+	})
+
+	t.Run("not a Sigstore key", func(t *testing.T) {
+		key := NewKey("jane.doe@example.com", "https://github.com/login/oauth")
+		key.KeyType = "ecdsa"
+
+		_, err := NewVerifierFromKey(key)
+		assert.ErrorIs(t, err, ErrNotSigstoreKey)
+	})
+}
+
+func TestNewPatternKey(t *testing.T) {
+	key, err := NewPatternKey(".*@example\\.com", "https://github\\.com/login/oauth")
+	assert.Nil(t, err)
+	assert.Equal(t, "regexp:.*@example\\.com::https://github\\.com/login/oauth", key.KeyID)
+	assert.Equal(t, KeyType, key.KeyType)
+
+	identity := CertificateIdentity(key)
+	assert.Empty(t, identity.Subject)
+	assert.Empty(t, identity.Issuer)
+	assert.Equal(t, "^(?:.*@example\\.com)$", identity.SubjectRegExp)
+	assert.Equal(t, "^(?:https://github\\.com/login/oauth)$", identity.IssuerRegExp)
+
+	// Patterns must match the entire identity
+	assert.Regexp(t, identity.SubjectRegExp, "jane.doe@example.com")
+	assert.NotRegexp(t, identity.SubjectRegExp, "jane.doe@example.com.evil")
+
+	verifier, err := NewVerifierFromKey(key)
+	assert.Nil(t, err)
+	keyID, err := verifier.KeyID()
+	assert.Nil(t, err)
+	assert.Equal(t, key.KeyID, keyID)
+
+	t.Run("exact identity", func(t *testing.T) {
+		identity := CertificateIdentity(NewKey("jane.doe@example.com", "https://github.com/login/oauth"))
+		assert.Equal(t, "jane.doe@example.com", identity.Subject)
+		assert.Equal(t, "https://github.com/login/oauth", identity.Issuer)
+		assert.Empty(t, identity.SubjectRegExp)
+	})
+
+	t.Run("invalid pattern", func(t *testing.T) {
+		_, err := NewPatternKey("(", "https://github.com/login/oauth")
+		assert.ErrorIs(t, err, ErrInvalidPattern)
+
+		_, err = NewPatternKey(".*@example.com", "")
+		assert.ErrorIs(t, err, ErrInvalidPattern)
+	})
+}
@@ -0,0 +1,221 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sshagent
+
+import (
+	"context"
+	"crypto"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"os"
+	"strings"
+
+	sslibsv "github.com/gittuf/gittuf/internal/third_party/go-securesystemslib/signerverifier"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/secure-systems-lab/go-securesystemslib/dsse"
+	"golang.org/x/crypto/cryptobyte"
+	"golang.org/x/crypto/cryptobyte/asn1"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+)
+
+const (
+	// KeyReferencePrefix identifies keys held by a running ssh-agent. The
+	// prefix is followed by the key's SHA256 fingerprint (as printed by
+	// ssh-add -l) or its comment. If nothing follows the prefix, the agent
+	// must hold exactly one supported key.
+	KeyReferencePrefix = "ssh-agent:"
+
+	authSockEnvVar = "SSH_AUTH_SOCK"
+)
+
+var (
+	ErrNoAgent          = errors.New("unable to connect to ssh-agent, is SSH_AUTH_SOCK set?")
+	ErrKeyNotFound      = errors.New("key not found in ssh-agent")
+	ErrAmbiguousKey     = errors.New("multiple keys in ssh-agent match, specify the key's fingerprint")
+	ErrUnsupportedKey   = errors.New("ssh-agent key type is not supported by gittuf, use an ED25519 or ECDSA key")
+	ErrInvalidSignature = errors.New("ssh-agent returned an invalid signature")
+)
+
+// SignerVerifier implements the dsse.SignerVerifier interface for a key held
+// by ssh-agent. The private key is never read by gittuf, signatures are
+// created by the agent and are verified locally using the key's public key.
+type SignerVerifier struct {
+	agent     agent.Agent
+	agentKey  *agent.Key
+	key       *tuf.Key
+	verifier  dsse.SignerVerifier
+	isECDSA   bool
+	closeFunc func() error
+}
+
+// NewSignerVerifierFromURI connects to the agent listening on SSH_AUTH_SOCK
+// and returns a SignerVerifier for the key identified by keyRef.
+func NewSignerVerifierFromURI(_ context.Context, keyRef string) (*SignerVerifier, error) {
+	if !strings.HasPrefix(keyRef, KeyReferencePrefix) {
+		return nil, ErrKeyNotFound
+	}
+
+	socket := os.Getenv(authSockEnvVar)
+	if socket == "" {
+		return nil, ErrNoAgent
+	}
+	conn, err := net.Dial("unix", socket)
+	if err != nil {
+		return nil, errors.Join(ErrNoAgent, err)
+	}
+
+	sv, err := NewSignerVerifierFromAgent(agent.NewClient(conn), strings.TrimPrefix(keyRef, KeyReferencePrefix))
+	if err != nil {
+		conn.Close() //nolint:errcheck
+		return nil, err
+	}
+	sv.closeFunc = conn.Close
+
+	return sv, nil
+}
+
+// NewSignerVerifierFromAgent returns a SignerVerifier for the key held by
+// sshAgent whose SHA256 fingerprint or comment matches selector.
+func NewSignerVerifierFromAgent(sshAgent agent.Agent, selector string) (*SignerVerifier, error) {
+	agentKeys, err := sshAgent.List()
+	if err != nil {
+		return nil, errors.Join(ErrNoAgent, err)
+	}
+
+	var (
+		matched   *agent.Key
+		available = []string{}
+	)
+	for _, agentKey := range agentKeys {
+		fingerprint := ssh.FingerprintSHA256(agentKey)
+		available = append(available, fmt.Sprintf("%s (%s)", fingerprint, agentKey.Comment))
+
+		if selector != "" && selector != fingerprint && selector != agentKey.Comment {
+			continue
+		}
+		if selector == "" && !isSupportedKeyType(agentKey.Format) {
+			continue
+		}
+		if matched != nil {
+			return nil, ErrAmbiguousKey
+		}
+		matched = agentKey
+	}
+	if matched == nil {
+		return nil, fmt.Errorf("%w: available keys: %s", ErrKeyNotFound, strings.Join(available, ", "))
+	}
+	if !isSupportedKeyType(matched.Format) {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedKey, matched.Format)
+	}
+
+	publicKey, err := ssh.ParsePublicKey(matched.Blob)
+	if err != nil {
+		return nil, err
+	}
+	cryptoPublicKey, ok := publicKey.(ssh.CryptoPublicKey)
+	if !ok {
+		return nil, ErrUnsupportedKey
+	}
+
+	key, err := sslibsv.NewKey(cryptoPublicKey.CryptoPublicKey())
+	if err != nil {
+		return nil, err
+	}
+
+	verifier, err := sslibsv.NewVerifierFromSSLibKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SignerVerifier{
+		agent:    sshAgent,
+		agentKey: matched,
+		key:      key,
+		verifier: verifier,
+		isECDSA:  strings.HasPrefix(matched.Format, "ecdsa-sha2-"),
+	}, nil
+}
+
+// LoadPublicKey returns the public key of the agent key identified by keyRef
+// for use in gittuf metadata.
+func LoadPublicKey(ctx context.Context, keyRef string) (*tuf.Key, error) {
+	sv, err := NewSignerVerifierFromURI(ctx, keyRef)
+	if err != nil {
+		return nil, err
+	}
+	defer sv.Close() //nolint:errcheck
+
+	return sv.key, nil
+}
+
+// Sign asks the agent to sign data. ECDSA signatures are converted from the
+// SSH wire format to the ASN.1 encoding used by gittuf.
+func (sv *SignerVerifier) Sign(_ context.Context, data []byte) ([]byte, error) {
+	signature, err := sv.agent.Sign(sv.agentKey, data)
+	if err != nil {
+		return nil, err
+	}
+
+	if !sv.isECDSA {
+		return signature.Blob, nil
+	}
+
+	return encodeECDSASignature(signature.Blob)
+}
+
+// Verify verifies sig against data using the key's public key.
+func (sv *SignerVerifier) Verify(ctx context.Context, data, sig []byte) error {
+	return sv.verifier.Verify(ctx, data, sig)
+}
+
+// KeyID returns the identifier of the key's public key as recorded in gittuf
+// metadata.
+func (sv *SignerVerifier) KeyID() (string, error) {
+	return sv.key.KeyID, nil
+}
+
+// Public returns the public key of the agent key.
+func (sv *SignerVerifier) Public() crypto.PublicKey {
+	return sv.verifier.Public()
+}
+
+// Close closes the connection to the agent, if the SignerVerifier opened it.
+func (sv *SignerVerifier) Close() error {
+	if sv.closeFunc == nil {
+		return nil
+	}
+	return sv.closeFunc()
+}
+
+// isSupportedKeyType returns true for agent keys whose signatures can be
+// verified using gittuf's ED25519 and ECDSA verifiers. RSA signatures created
+// by ssh-agent use PKCS #1 v1.5 rather than PSS, and security key (sk-*)
+// signatures cover additional authenticator data, so neither is supported.
+func isSupportedKeyType(format string) bool {
+	switch format {
+	case ssh.KeyAlgoED25519, ssh.KeyAlgoECDSA256, ssh.KeyAlgoECDSA384, ssh.KeyAlgoECDSA521:
+		return true
+	default:
+		return false
+	}
+}
+
+func encodeECDSASignature(blob []byte) ([]byte, error) {
+	signature := struct {
+		R *big.Int
+		S *big.Int
+	}{}
+	if err := ssh.Unmarshal(blob, &signature); err != nil {
+		return nil, errors.Join(ErrInvalidSignature, err)
+	}
+
+	var builder cryptobyte.Builder
+	builder.AddASN1(asn1.SEQUENCE, func(b *cryptobyte.Builder) {
+		b.AddASN1BigInt(signature.R)
+		b.AddASN1BigInt(signature.S)
+	})
+	return builder.Bytes()
+}
@@ -0,0 +1,97 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sshagent
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+
+	sslibsv "github.com/gittuf/gittuf/internal/third_party/go-securesystemslib/signerverifier"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/ssh"
+	"golang.org/x/crypto/ssh/agent"
+)
+
+func TestSignerVerifier(t *testing.T) {
+	ecdsaKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, ed25519Key, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keyring := agent.NewKeyring()
+	for comment, key := range map[string]any{"ecdsa@gittuf": ecdsaKey, "ed25519@gittuf": ed25519Key, "rsa@gittuf": rsaKey} {
+		if err := keyring.Add(agent.AddedKey{PrivateKey: key, Comment: comment}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ecdsaSSHKey, err := ssh.NewPublicKey(ecdsaKey.Public())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := map[string]struct {
+		selector  string
+		publicKey any
+	}{
+		"ECDSA key by fingerprint": {selector: ssh.FingerprintSHA256(ecdsaSSHKey), publicKey: ecdsaKey.Public()},
+		"ED25519 key by comment":   {selector: "ed25519@gittuf", publicKey: ed25519Key.Public()},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			sv, err := NewSignerVerifierFromAgent(keyring, test.selector)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			expectedKey, err := sslibsv.NewKey(test.publicKey)
+			if err != nil {
+				t.Fatal(err)
+			}
+			keyID, err := sv.KeyID()
+			assert.Nil(t, err)
+			assert.Equal(t, expectedKey.KeyID, keyID)
+
+			data := []byte("test data")
+			signature, err := sv.Sign(context.Background(), data)
+			assert.Nil(t, err)
+
+			// Signatures must verify using the key recorded in policy
+			verifier, err := sslibsv.NewVerifierFromSSLibKey(expectedKey)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert.Nil(t, verifier.Verify(context.Background(), data, signature))
+			assert.NotNil(t, verifier.Verify(context.Background(), []byte("other data"), signature))
+		})
+	}
+
+	t.Run("RSA key", func(t *testing.T) {
+		_, err := NewSignerVerifierFromAgent(keyring, "rsa@gittuf")
+		assert.ErrorIs(t, err, ErrUnsupportedKey)
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		_, err := NewSignerVerifierFromAgent(keyring, "unknown@gittuf")
+		assert.ErrorIs(t, err, ErrKeyNotFound)
+	})
+
+	t.Run("multiple supported keys without selector", func(t *testing.T) {
+		_, err := NewSignerVerifierFromAgent(keyring, "")
+		assert.ErrorIs(t, err, ErrAmbiguousKey)
+	})
+}
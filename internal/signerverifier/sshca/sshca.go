@@ -0,0 +1,134 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Package sshca implements verification of Git signatures created using SSH
+// certificates. Rather than authorizing each user's SSH key, gittuf policy
+// authorizes an SSH certificate authority along with the principal that
+// certificates it issues must be valid for.
+package sshca
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	sslibsv "github.com/gittuf/gittuf/internal/third_party/go-securesystemslib/signerverifier"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/hiddeco/sshsig"
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	KeyType   = "ssh-ca"
+	KeyScheme = "ssh-certificate"
+
+	namespaceSSHSignature = "git"
+)
+
+var (
+	ErrNotSSHCAKey          = errors.New("key is not an SSH certificate authority")
+	ErrInvalidCAKey         = errors.New("unable to parse SSH certificate authority public key")
+	ErrNoPrincipal          = errors.New("SSH certificate authority key must specify a principal")
+	ErrNotCertificate       = errors.New("signature was not created using an SSH certificate")
+	ErrNotUserCertificate   = errors.New("SSH certificate is not a user certificate")
+	ErrUntrustedAuthority   = errors.New("SSH certificate was not issued by trusted authority")
+	ErrInvalidCertificate   = errors.New("SSH certificate is not valid")
+	ErrVerifyingSignature   = errors.New("unable to verify SSH certificate signature")
+	ErrUnexpectedSSHSigType = errors.New("unable to parse SSH signature")
+)
+
+// KeyID returns the key ID used for certificates issued by the authority with
+// the specified public key for principal.
+func KeyID(principal string, authority ssh.PublicKey) string {
+	return fmt.Sprintf("%s::%s", principal, ssh.FingerprintSHA256(authority))
+}
+
+// NewKey returns a gittuf key that trusts SSH user certificates issued for
+// principal by the certificate authority whose public key is in the
+// authorized_keys format.
+func NewKey(principal string, authorityPublicKey []byte) (*tuf.Key, error) {
+	if principal == "" {
+		return nil, ErrNoPrincipal
+	}
+
+	authority, _, _, _, err := ssh.ParseAuthorizedKey(authorityPublicKey)
+	if err != nil {
+		return nil, errors.Join(ErrInvalidCAKey, err)
+	}
+	if _, isCertificate := authority.(*ssh.Certificate); isCertificate {
+		return nil, fmt.Errorf("%w: expected public key, found certificate", ErrInvalidCAKey)
+	}
+
+	return &sslibsv.SSLibKey{
+		KeyID:   KeyID(principal, authority),
+		KeyType: KeyType,
+		Scheme:  KeyScheme,
+		KeyVal: sslibsv.KeyVal{
+			Public:   strings.TrimSpace(string(ssh.MarshalAuthorizedKey(authority))),
+			Identity: principal,
+		},
+	}, nil
+}
+
+// VerifyCertificate checks that certificate is a user certificate issued by
+// the authority recorded in key, that it is valid for the key's principal,
+// and that it is valid at the specified time.
+func VerifyCertificate(key *tuf.Key, certificate *ssh.Certificate, at time.Time) error {
+	if key.KeyType != KeyType {
+		return ErrNotSSHCAKey
+	}
+
+	authority, _, _, _, err := ssh.ParseAuthorizedKey([]byte(key.KeyVal.Public))
+	if err != nil {
+		return errors.Join(ErrInvalidCAKey, err)
+	}
+
+	if certificate.CertType != ssh.UserCert {
+		return ErrNotUserCertificate
+	}
+	if !bytes.Equal(certificate.SignatureKey.Marshal(), authority.Marshal()) {
+		return ErrUntrustedAuthority
+	}
+
+	// An empty list of principals in a certificate means it is valid for any
+	// principal, but gittuf requires the principal to be listed explicitly
+	if !slices.Contains(certificate.ValidPrincipals, key.KeyVal.Identity) {
+		return fmt.Errorf("%w: certificate is not valid for principal '%s'", ErrInvalidCertificate, key.KeyVal.Identity)
+	}
+
+	checker := &ssh.CertChecker{
+		Clock: func() time.Time { return at },
+	}
+	if err := checker.CheckCert(key.KeyVal.Identity, certificate); err != nil {
+		return errors.Join(ErrInvalidCertificate, err)
+	}
+
+	return nil
+}
+
+// VerifySignature verifies the armored SSH signature over data, as created
+// by Git when signing using an SSH certificate. The certificate embedded in
+// the signature must be trusted by key at the specified time.
+func VerifySignature(key *tuf.Key, data, signature []byte, at time.Time) error {
+	sshSignature, err := sshsig.Unarmor(signature)
+	if err != nil {
+		return errors.Join(ErrUnexpectedSSHSigType, err)
+	}
+
+	certificate, isCertificate := sshSignature.PublicKey.(*ssh.Certificate)
+	if !isCertificate {
+		return ErrNotCertificate
+	}
+
+	if err := VerifyCertificate(key, certificate, at); err != nil {
+		return err
+	}
+
+	if err := sshsig.Verify(bytes.NewReader(data), sshSignature, certificate, sshSignature.HashAlgorithm, namespaceSSHSignature); err != nil {
+		return errors.Join(ErrVerifyingSignature, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,146 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package sshca
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/rand"
+	"testing"
+	"time"
+
+	"github.com/hiddeco/sshsig"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/ssh"
+)
+
+const testPrincipal = "jane.doe"
+
+func TestVerifySignature(t *testing.T) {
+	authority := createTestSigner(t)
+	authorityPublicKey := ssh.MarshalAuthorizedKey(authority.PublicKey())
+
+	key, err := NewKey(testPrincipal, authorityPublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, KeyID(testPrincipal, authority.PublicKey()), key.KeyID)
+
+	now := time.Now()
+	data := []byte("data")
+
+	t.Run("valid signature", func(t *testing.T) {
+		signature := createTestSignature(t, authority, ssh.UserCert, []string{testPrincipal}, now, data)
+
+		err := VerifySignature(key, data, signature, now)
+		assert.Nil(t, err)
+	})
+
+	t.Run("modified data", func(t *testing.T) {
+		signature := createTestSignature(t, authority, ssh.UserCert, []string{testPrincipal}, now, data)
+
+		err := VerifySignature(key, []byte("modified"), signature, now)
+		assert.ErrorIs(t, err, ErrVerifyingSignature)
+	})
+
+	t.Run("unexpected principal", func(t *testing.T) {
+		signature := createTestSignature(t, authority, ssh.UserCert, []string{"john.doe"}, now, data)
+
+		err := VerifySignature(key, data, signature, now)
+		assert.ErrorIs(t, err, ErrInvalidCertificate)
+
+		// Certificates without principals are not trusted
+		signature = createTestSignature(t, authority, ssh.UserCert, nil, now, data)
+
+		err = VerifySignature(key, data, signature, now)
+		assert.ErrorIs(t, err, ErrInvalidCertificate)
+	})
+
+	t.Run("expired certificate", func(t *testing.T) {
+		signature := createTestSignature(t, authority, ssh.UserCert, []string{testPrincipal}, now, data)
+
+		err := VerifySignature(key, data, signature, now.Add(2*time.Hour))
+		assert.ErrorIs(t, err, ErrInvalidCertificate)
+	})
+
+	t.Run("untrusted authority", func(t *testing.T) {
+		otherAuthority := createTestSigner(t)
+		signature := createTestSignature(t, otherAuthority, ssh.UserCert, []string{testPrincipal}, now, data)
+
+		err := VerifySignature(key, data, signature, now)
+		assert.ErrorIs(t, err, ErrUntrustedAuthority)
+	})
+
+	t.Run("host certificate", func(t *testing.T) {
+		signature := createTestSignature(t, authority, ssh.HostCert, []string{testPrincipal}, now, data)
+
+		err := VerifySignature(key, data, signature, now)
+		assert.ErrorIs(t, err, ErrNotUserCertificate)
+	})
+
+	t.Run("signature without certificate", func(t *testing.T) {
+		signer := createTestSigner(t)
+		sig, err := sshsig.Sign(bytes.NewReader(data), signer, sshsig.HashSHA512, namespaceSSHSignature)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = VerifySignature(key, data, sshsig.Armor(sig), now)
+		assert.ErrorIs(t, err, ErrNotCertificate)
+	})
+
+	t.Run("invalid keys", func(t *testing.T) {
+		_, err := NewKey("", authorityPublicKey)
+		assert.ErrorIs(t, err, ErrNoPrincipal)
+
+		_, err = NewKey(testPrincipal, []byte("not a key"))
+		assert.ErrorIs(t, err, ErrInvalidCAKey)
+	})
+}
+
+func createTestSigner(t *testing.T) ssh.Signer {
+	t.Helper()
+
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	signer, err := ssh.NewSignerFromKey(privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return signer
+}
+
+// createTestSignature returns an armored signature over data created using a
+// new key and a certificate for it issued by authority, valid for an hour on
+// either side of now.
+func createTestSignature(t *testing.T, authority ssh.Signer, certType uint32, principals []string, now time.Time, data []byte) []byte {
+	t.Helper()
+
+	signer := createTestSigner(t)
+	certificate := &ssh.Certificate{
+		Key:             signer.PublicKey(),
+		CertType:        certType,
+		KeyId:           testPrincipal,
+		ValidPrincipals: principals,
+		ValidAfter:      uint64(now.Add(-time.Hour).Unix()),
+		ValidBefore:     uint64(now.Add(time.Hour).Unix()),
+	}
+	if err := certificate.SignCert(rand.Reader, authority); err != nil {
+		t.Fatal(err)
+	}
+
+	certSigner, err := ssh.NewCertSigner(certificate, signer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sig, err := sshsig.Sign(bytes.NewReader(data), certSigner, sshsig.HashSHA512, namespaceSSHSignature)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return sshsig.Armor(sig)
+}
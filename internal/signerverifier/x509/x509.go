@@ -0,0 +1,301 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Package x509 implements signing and verification using X.509 certificates
+// issued by a certificate authority, as used for S/MIME. Signatures are
+// detached CMS (PKCS #7) signatures, which is also the format Git uses for
+// signatures created with gpg.format=x509. gittuf policy records the identity
+// that is trusted along with the root certificates it must be issued by.
+package x509
+
+import (
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/digitorus/pkcs7"
+	sslibsv "github.com/gittuf/gittuf/internal/third_party/go-securesystemslib/signerverifier"
+	"github.com/gittuf/gittuf/internal/tuf"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+)
+
+const (
+	KeyType   = "x509"
+	KeyScheme = "cms"
+
+	// KeyReferencePrefix identifies an X.509 signer in the format
+	// x509:<private-key-path>::<certificate-chain-path>. The certificate
+	// chain is PEM encoded and starts with the signer's certificate.
+	KeyReferencePrefix = "x509:"
+)
+
+var (
+	ErrInvalidKeyReference  = errors.New("invalid X.509 signer reference, expected x509:<private-key-path>::<certificate-chain-path>")
+	ErrInvalidCertificate   = errors.New("unable to parse X.509 certificate")
+	ErrNotX509Key           = errors.New("key is not an X.509 identity")
+	ErrInvalidSignature     = errors.New("unable to parse X.509 signature")
+	ErrVerifyingSignature   = errors.New("unable to verify X.509 signature")
+	ErrUnexpectedIdentity   = errors.New("signing certificate was not issued for expected identity")
+	ErrUnknownRoot          = errors.New("certificate chain does not end in a root certificate")
+	ErrVerifierCannotSign   = errors.New("X.509 verifier cannot be used for signing")
+	ErrUnsupportedSignerKey = errors.New("private key cannot be used for signing")
+)
+
+// KeyID returns the key ID used for identity when issued by one of roots.
+func KeyID(identity string, roots []*x509.Certificate) string {
+	hasher := sha256.New()
+	for _, root := range roots {
+		hasher.Write(root.Raw)
+	}
+
+	return fmt.Sprintf("%s::%s", identity, hex.EncodeToString(hasher.Sum(nil)))
+}
+
+// NewKey returns a gittuf key that trusts certificates issued for identity by
+// one of the PEM encoded root certificates in rootsPEM. The identity must
+// match one of the certificate's subject alternative names, such as an email
+// address.
+func NewKey(identity string, rootsPEM []byte) (*tuf.Key, error) {
+	roots, err := cryptoutils.UnmarshalCertificatesFromPEM(rootsPEM)
+	if err != nil {
+		return nil, errors.Join(ErrInvalidCertificate, err)
+	}
+	if len(roots) == 0 {
+		return nil, ErrInvalidCertificate
+	}
+
+	encodedRoots, err := cryptoutils.MarshalCertificatesToPEM(roots)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sslibsv.SSLibKey{
+		KeyID:   KeyID(identity, roots),
+		KeyType: KeyType,
+		Scheme:  KeyScheme,
+		KeyVal: sslibsv.KeyVal{
+			Identity:    identity,
+			Certificate: strings.TrimSpace(string(encodedRoots)),
+		},
+	}, nil
+}
+
+// VerifySignature verifies the detached CMS signature over data, which may be
+// PEM encoded. The signing certificate must chain to one of the roots recorded
+// in key, and must be issued for the key's identity.
+func VerifySignature(key *tuf.Key, data, signature []byte) error {
+	if key.KeyType != KeyType {
+		return ErrNotX509Key
+	}
+
+	roots, err := cryptoutils.UnmarshalCertificatesFromPEM([]byte(key.KeyVal.Certificate))
+	if err != nil {
+		return errors.Join(ErrInvalidCertificate, err)
+	}
+	rootPool := x509.NewCertPool()
+	for _, root := range roots {
+		rootPool.AddCert(root)
+	}
+
+	if block, _ := pem.Decode(signature); block != nil {
+		signature = block.Bytes
+	}
+
+	p7, err := pkcs7.Parse(signature)
+	if err != nil {
+		return errors.Join(ErrInvalidSignature, err)
+	}
+	signer := p7.GetOnlySigner()
+	if signer == nil {
+		return fmt.Errorf("%w: signature must have exactly one signer", ErrInvalidSignature)
+	}
+
+	if !slices.Contains(cryptoutils.GetSubjectAlternateNames(signer), key.KeyVal.Identity) {
+		return ErrUnexpectedIdentity
+	}
+
+	// The signature is detached, so the signed content is supplied separately
+	p7.Content = data
+	if err := p7.VerifyWithChain(rootPool); err != nil {
+		return errors.Join(ErrVerifyingSignature, err)
+	}
+
+	return nil
+}
+
+// Verifier implements the dsse.SignerVerifier interface for signatures
+// created by an X.509 certificate trusted by a gittuf key.
+type Verifier struct {
+	key *tuf.Key
+}
+
+// NewVerifierFromKey returns a Verifier for a gittuf key of type KeyType.
+func NewVerifierFromKey(key *tuf.Key) (*Verifier, error) {
+	if key.KeyType != KeyType {
+		return nil, ErrNotX509Key
+	}
+
+	return &Verifier{key: key}, nil
+}
+
+// KeyID returns the identifier of the verifier's key.
+func (v *Verifier) KeyID() (string, error) {
+	return v.key.KeyID, nil
+}
+
+// Public returns nil as the key trusts certificates rather than a single
+// public key.
+func (v *Verifier) Public() crypto.PublicKey {
+	return nil
+}
+
+// Sign is not supported by the Verifier.
+func (v *Verifier) Sign(_ context.Context, _ []byte) ([]byte, error) {
+	return nil, ErrVerifierCannotSign
+}
+
+// Verify checks the CMS signature sig over data.
+func (v *Verifier) Verify(_ context.Context, data, sig []byte) error {
+	return VerifySignature(v.key, data, sig)
+}
+
+// Signer implements the dsse.SignerVerifier interface using a private key
+// and its X.509 certificate chain. Signatures embed the chain so they can be
+// verified using only the root certificates recorded in gittuf policy.
+type Signer struct {
+	privateKey  crypto.Signer
+	certificate *x509.Certificate
+	chain       []*x509.Certificate
+}
+
+// NewSigner returns a Signer for the PEM encoded private key and certificate
+// chain. The chain starts with the certificate for the private key, and may
+// include intermediate and root certificates.
+func NewSigner(privateKeyPEM, chainPEM []byte) (*Signer, error) {
+	privateKey, err := cryptoutils.UnmarshalPEMToPrivateKey(privateKeyPEM, cryptoutils.SkipPassword)
+	if err != nil {
+		return nil, err
+	}
+	signer, ok := privateKey.(crypto.Signer)
+	if !ok {
+		return nil, ErrUnsupportedSignerKey
+	}
+
+	chain, err := cryptoutils.UnmarshalCertificatesFromPEM(chainPEM)
+	if err != nil {
+		return nil, errors.Join(ErrInvalidCertificate, err)
+	}
+	if len(chain) == 0 {
+		return nil, ErrInvalidCertificate
+	}
+
+	if err := cryptoutils.EqualKeys(signer.Public(), chain[0].PublicKey); err != nil {
+		return nil, fmt.Errorf("%w: certificate does not match private key", ErrInvalidCertificate)
+	}
+
+	return &Signer{
+		privateKey:  signer,
+		certificate: chain[0],
+		chain:       chain[1:],
+	}, nil
+}
+
+// NewSignerFromURI returns a Signer for the private key and certificate chain
+// files identified by keyRef.
+func NewSignerFromURI(keyRef string) (*Signer, error) {
+	privateKeyPath, chainPath, found := strings.Cut(strings.TrimPrefix(keyRef, KeyReferencePrefix), "::")
+	if !strings.HasPrefix(keyRef, KeyReferencePrefix) || !found || privateKeyPath == "" || chainPath == "" {
+		return nil, ErrInvalidKeyReference
+	}
+
+	privateKeyPEM, err := os.ReadFile(privateKeyPath)
+	if err != nil {
+		return nil, err
+	}
+	chainPEM, err := os.ReadFile(chainPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewSigner(privateKeyPEM, chainPEM)
+}
+
+// KeyID returns an identifier for the signer's certificate. Verifiers identify
+// keys using the roots trusted in policy instead, so this need not match the
+// ID of the key that verifies the signer's signatures.
+func (s *Signer) KeyID() (string, error) {
+	return KeyID(s.identity(), []*x509.Certificate{s.certificate}), nil
+}
+
+// Key returns the gittuf key that trusts the signer's identity when issued by
+// the root at the end of the signer's certificate chain.
+func (s *Signer) Key() (*tuf.Key, error) {
+	if len(s.chain) == 0 {
+		return nil, ErrUnknownRoot
+	}
+
+	root := s.chain[len(s.chain)-1]
+	if err := root.CheckSignatureFrom(root); err != nil {
+		return nil, errors.Join(ErrUnknownRoot, err)
+	}
+
+	rootPEM, err := cryptoutils.MarshalCertificateToPEM(root)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewKey(s.identity(), rootPEM)
+}
+
+// Public returns the public key of the signer's certificate.
+func (s *Signer) Public() crypto.PublicKey {
+	return s.privateKey.Public()
+}
+
+// Sign creates a detached CMS signature over data that embeds the signer's
+// certificate chain.
+func (s *Signer) Sign(_ context.Context, data []byte) ([]byte, error) {
+	signedData, err := pkcs7.NewSignedData(data)
+	if err != nil {
+		return nil, err
+	}
+	signedData.SetDigestAlgorithm(pkcs7.OIDDigestAlgorithmSHA256)
+
+	if err := signedData.AddSignerChain(s.certificate, s.privateKey, s.chain, pkcs7.SignerInfoConfig{}); err != nil {
+		return nil, err
+	}
+	signedData.Detach()
+
+	return signedData.Finish()
+}
+
+// Verify checks sig using the signer's certificate without validating its
+// chain.
+func (s *Signer) Verify(_ context.Context, data, sig []byte) error {
+	p7, err := pkcs7.Parse(sig)
+	if err != nil {
+		return errors.Join(ErrInvalidSignature, err)
+	}
+	p7.Content = data
+
+	if err := p7.Verify(); err != nil {
+		return errors.Join(ErrVerifyingSignature, err)
+	}
+
+	return nil
+}
+
+func (s *Signer) identity() string {
+	if sans := cryptoutils.GetSubjectAlternateNames(s.certificate); len(sans) > 0 {
+		return sans[0]
+	}
+
+	return s.certificate.Subject.CommonName
+}
@@ -0,0 +1,211 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package x509
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gittuf/gittuf/internal/signerverifier/dsse"
+	sslibdsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"github.com/stretchr/testify/assert"
+)
+
+const testIdentity = "jane.doe@example.com"
+
+func TestSignAndVerify(t *testing.T) {
+	rootCertificate := createTestCertificate(t, nil, "")
+	root := rootCertificate.certificate
+	leaf := createTestCertificate(t, rootCertificate, testIdentity)
+
+	rootPEM, err := cryptoutils.MarshalCertificateToPEM(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	privateKeyPEM, err := cryptoutils.MarshalPrivateKeyToPEM(leaf.privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	chainPEM, err := cryptoutils.MarshalCertificatesToPEM([]*x509.Certificate{leaf.certificate, root})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signer, err := NewSigner(privateKeyPEM, chainPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	key, err := NewKey(testIdentity, rootPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert.Equal(t, KeyID(testIdentity, []*x509.Certificate{root}), key.KeyID)
+
+	// The signer can derive the key from the root at the end of its chain
+	signerKey, err := signer.Key()
+	assert.Nil(t, err)
+	assert.Equal(t, key, signerKey)
+
+	data := []byte("data")
+	signature, err := signer.Sign(context.Background(), data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("valid signature", func(t *testing.T) {
+		err := VerifySignature(key, data, signature)
+		assert.Nil(t, err)
+
+		err = signer.Verify(context.Background(), data, signature)
+		assert.Nil(t, err)
+	})
+
+	t.Run("modified data", func(t *testing.T) {
+		err := VerifySignature(key, []byte("modified"), signature)
+		assert.ErrorIs(t, err, ErrVerifyingSignature)
+	})
+
+	t.Run("unexpected identity", func(t *testing.T) {
+		key, err := NewKey("john.doe@example.com", rootPEM)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = VerifySignature(key, data, signature)
+		assert.ErrorIs(t, err, ErrUnexpectedIdentity)
+	})
+
+	t.Run("untrusted root", func(t *testing.T) {
+		otherRoot := createTestCertificate(t, nil, "")
+		otherRootPEM, err := cryptoutils.MarshalCertificateToPEM(otherRoot.certificate)
+		if err != nil {
+			t.Fatal(err)
+		}
+		key, err := NewKey(testIdentity, otherRootPEM)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		err = VerifySignature(key, data, signature)
+		assert.ErrorIs(t, err, ErrVerifyingSignature)
+	})
+
+	t.Run("DSSE envelope", func(t *testing.T) {
+		env, err := dsse.CreateEnvelope(map[string]string{"hello": "world"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		env, err = dsse.SignEnvelope(context.Background(), env, signer)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		verifier, err := NewVerifierFromKey(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		keyIDs, err := dsse.GetAcceptedKeyIDs(context.Background(), env, []sslibdsse.Verifier{verifier})
+		assert.Nil(t, err)
+		assert.Equal(t, []string{key.KeyID}, keyIDs)
+	})
+
+	t.Run("signer from files", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		privateKeyPath := filepath.Join(tmpDir, "key.pem")
+		chainPath := filepath.Join(tmpDir, "chain.pem")
+		if err := os.WriteFile(privateKeyPath, privateKeyPEM, 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(chainPath, chainPEM, 0o600); err != nil {
+			t.Fatal(err)
+		}
+
+		signer, err := NewSignerFromURI(KeyReferencePrefix + privateKeyPath + "::" + chainPath)
+		assert.Nil(t, err)
+		keyID, err := signer.KeyID()
+		assert.Nil(t, err)
+		assert.Equal(t, KeyID(testIdentity, []*x509.Certificate{leaf.certificate}), keyID)
+
+		_, err = NewSignerFromURI(KeyReferencePrefix + privateKeyPath)
+		assert.ErrorIs(t, err, ErrInvalidKeyReference)
+	})
+
+	t.Run("signer without root", func(t *testing.T) {
+		chainPEM, err := cryptoutils.MarshalCertificateToPEM(leaf.certificate)
+		if err != nil {
+			t.Fatal(err)
+		}
+		signer, err := NewSigner(privateKeyPEM, chainPEM)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = signer.Key()
+		assert.ErrorIs(t, err, ErrUnknownRoot)
+	})
+}
+
+type testCertificate struct {
+	privateKey  crypto.Signer
+	certificate *x509.Certificate
+}
+
+// createTestCertificate creates a self-signed CA certificate if issuer is nil,
+// and a certificate for the email address identity issued by issuer
+// otherwise.
+func createTestCertificate(t *testing.T, issuer *testCertificate, identity string) *testCertificate {
+	t.Helper()
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(time.Now().UnixNano()),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	var (
+		parent    *x509.Certificate
+		parentKey crypto.Signer
+	)
+	if issuer == nil {
+		template.Subject = pkix.Name{CommonName: "gittuf test CA"}
+		template.IsCA = true
+		template.BasicConstraintsValid = true
+		template.KeyUsage = x509.KeyUsageCertSign
+		parent = template
+		parentKey = privateKey
+	} else {
+		parent = issuer.certificate
+		parentKey = issuer.privateKey
+		template.EmailAddresses = []string{identity}
+		template.KeyUsage = x509.KeyUsageDigitalSignature
+		template.ExtKeyUsage = []x509.ExtKeyUsage{x509.ExtKeyUsageEmailProtection}
+	}
+
+	certificateDER, err := x509.CreateCertificate(rand.Reader, template, parent, privateKey.Public(), parentKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	certificate, err := x509.ParseCertificate(certificateDER)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &testCertificate{privateKey: privateKey, certificate: certificate}
+}
@@ -0,0 +1,18 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package artifacts
+
+import _ "embed"
+
+// EncryptedKeyPassphrase is the passphrase the encrypted keys are protected
+// with. Each encrypted key is SSHECDSAPrivate in a different format.
+const EncryptedKeyPassphrase = "gittuf"
+
+//go:embed testdata/keys/encrypted/openssh-ecdsa
+var EncryptedOpenSSHECDSAPrivate []byte
+
+//go:embed testdata/keys/encrypted/pkcs8-ecdsa.pem
+var EncryptedPKCS8ECDSAPrivate []byte
+
+//go:embed testdata/keys/encrypted/legacy-ecdsa.pem
+var EncryptedLegacyPEMECDSAPrivate []byte
@@ -0,0 +1,17 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package artifacts
+
+import _ "embed"
+
+// The X.509 test certificates are valid until 2124. The signer's certificate
+// is issued for jane.doe@example.com by the root.
+
+//go:embed testdata/keys/x509/root.pem
+var X509Root []byte
+
+//go:embed testdata/keys/x509/signer
+var X509SignerPrivate []byte
+
+//go:embed testdata/keys/x509/signer-chain.pem
+var X509SignerChain []byte
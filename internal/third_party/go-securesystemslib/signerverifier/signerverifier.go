@@ -42,6 +42,9 @@ type KeyVal struct {
 	Certificate string `json:"certificate,omitempty"`
 	Identity    string `json:"identity,omitempty"`
 	Issuer      string `json:"issuer,omitempty"`
+
+	IdentityPattern string `json:"identity_pattern,omitempty"`
+	IssuerPattern   string `json:"issuer_pattern,omitempty"`
 }
 
 // LoadKey returns an SSLibKey object when provided a PEM encoded key.
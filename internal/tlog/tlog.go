@@ -0,0 +1,159 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Package tlog records the signatures on gittuf's DSSE envelopes in a Rekor
+// transparency log and verifies the inclusion of the recorded entries.
+package tlog
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	sslibsv "github.com/gittuf/gittuf/internal/third_party/go-securesystemslib/signerverifier"
+	"github.com/gittuf/gittuf/internal/tuf"
+	sslibdsse "github.com/secure-systems-lab/go-securesystemslib/dsse"
+	"github.com/sigstore/cosign/v2/pkg/cosign"
+	rekorclient "github.com/sigstore/rekor/pkg/client"
+	"github.com/sigstore/rekor/pkg/generated/models"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+)
+
+const (
+	DefaultRekorURL = "https://rekor.sigstore.dev"
+
+	dsseEntryKind = "dsse"
+)
+
+var (
+	ErrUnsupportedKeyType = errors.New("key type is not supported for transparency log entries")
+	ErrSignatureNotFound  = errors.New("envelope is not signed by key")
+	ErrEntryMismatch      = errors.New("transparency log entry does not match signature")
+	ErrInvalidEntry       = errors.New("unable to verify transparency log entry")
+)
+
+// Upload records the signature by key on the envelope in the Rekor
+// transparency log at rekorURL. Each signature is recorded separately, so that
+// signatures added to the envelope later can also be recorded. The entry
+// returned includes the inclusion proof for the signature.
+func Upload(ctx context.Context, rekorURL string, env *sslibdsse.Envelope, key *tuf.Key) (*models.LogEntryAnon, error) {
+	publicKey, err := PublicKeyPEM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	signedEnv, err := envelopeWithSignature(env, key.KeyID)
+	if err != nil {
+		return nil, err
+	}
+
+	envBytes, err := json.Marshal(signedEnv)
+	if err != nil {
+		return nil, err
+	}
+
+	rekor, err := rekorclient.GetRekorClient(rekorURL)
+	if err != nil {
+		return nil, err
+	}
+
+	return cosign.TLogUploadDSSEEnvelope(ctx, rekor, envBytes, publicKey)
+}
+
+// VerifyEntry checks that the transparency log entry records the signature by
+// the key with keyID on the envelope, and that the entry's inclusion proof and
+// signed entry timestamp are valid for one of the trusted logs.
+func VerifyEntry(ctx context.Context, entry *models.LogEntryAnon, env *sslibdsse.Envelope, keyID string, trustedLogs *cosign.TrustedTransparencyLogPubKeys) error {
+	signedEnv, err := envelopeWithSignature(env, keyID)
+	if err != nil {
+		return err
+	}
+
+	encodedBody, ok := entry.Body.(string)
+	if !ok {
+		return ErrInvalidEntry
+	}
+	body, err := base64.StdEncoding.DecodeString(encodedBody)
+	if err != nil {
+		return errors.Join(ErrInvalidEntry, err)
+	}
+
+	dsseEntry := struct {
+		Kind string `json:"kind"`
+		Spec struct {
+			PayloadHash struct {
+				Algorithm string `json:"algorithm"`
+				Value     string `json:"value"`
+			} `json:"payloadHash"`
+			Signatures []struct {
+				Signature string `json:"signature"`
+			} `json:"signatures"`
+		} `json:"spec"`
+	}{}
+	if err := json.Unmarshal(body, &dsseEntry); err != nil {
+		return errors.Join(ErrInvalidEntry, err)
+	}
+
+	payload, err := signedEnv.DecodeB64Payload()
+	if err != nil {
+		return err
+	}
+	payloadHash := sha256.Sum256(payload)
+
+	if dsseEntry.Kind != dsseEntryKind || dsseEntry.Spec.PayloadHash.Value != hex.EncodeToString(payloadHash[:]) {
+		return ErrEntryMismatch
+	}
+	if len(dsseEntry.Spec.Signatures) != 1 || dsseEntry.Spec.Signatures[0].Signature != signedEnv.Signatures[0].Sig {
+		return ErrEntryMismatch
+	}
+
+	if err := cosign.VerifyTLogEntryOffline(ctx, entry, trustedLogs); err != nil {
+		return errors.Join(ErrInvalidEntry, err)
+	}
+
+	return nil
+}
+
+// GetTrustedLogs returns the keys of the public good Rekor instance from the
+// Sigstore TUF root. The environment variable SIGSTORE_REKOR_PUBLIC_KEY can be
+// set to the path of the key of another instance to trust instead.
+func GetTrustedLogs(ctx context.Context) (*cosign.TrustedTransparencyLogPubKeys, error) {
+	return cosign.GetRekorPubs(ctx)
+}
+
+// PublicKeyPEM returns the PEM encoded public key for keys that can be used to
+// verify transparency log entries.
+func PublicKeyPEM(key *tuf.Key) ([]byte, error) {
+	switch key.KeyType {
+	case sslibsv.ECDSAKeyType, sslibsv.RSAKeyType:
+		return []byte(key.KeyVal.Public), nil
+	case sslibsv.ED25519KeyType:
+		publicKey, err := hex.DecodeString(key.KeyVal.Public)
+		if err != nil {
+			return nil, err
+		}
+		return cryptoutils.MarshalPublicKeyToPEM(ed25519.PublicKey(publicKey))
+	default:
+		return nil, fmt.Errorf("%w: '%s'", ErrUnsupportedKeyType, key.KeyType)
+	}
+}
+
+// envelopeWithSignature returns a copy of the envelope with only the
+// signature by the key with keyID.
+func envelopeWithSignature(env *sslibdsse.Envelope, keyID string) (*sslibdsse.Envelope, error) {
+	for _, signature := range env.Signatures {
+		if signature.KeyID == keyID {
+			return &sslibdsse.Envelope{
+				PayloadType: env.PayloadType,
+				Payload:     env.Payload,
+				Signatures:  []sslibdsse.Signature{signature},
+			}, nil
+		}
+	}
+
+	return nil, ErrSignatureNotFound
+}
@@ -0,0 +1,103 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package tlog
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/common"
+	"github.com/gittuf/gittuf/internal/signerverifier"
+	"github.com/gittuf/gittuf/internal/signerverifier/dsse"
+	artifacts "github.com/gittuf/gittuf/internal/testartifacts"
+	"github.com/gittuf/gittuf/internal/tuf"
+	ita "github.com/in-toto/attestation/go/v1"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUploadAndVerifyEntry(t *testing.T) {
+	ctx := context.Background()
+	server, trustedLogs := common.NewTestRekorServer(t)
+
+	firstSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(artifacts.SSLibKey1Private) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+	firstKey, err := tuf.LoadKeyFromBytes(artifacts.SSLibKey1Public)
+	if err != nil {
+		t.Fatal(err)
+	}
+	secondSigner, err := signerverifier.NewSignerVerifierFromSecureSystemsLibFormat(artifacts.SSLibKey2Private) //nolint:staticcheck
+	if err != nil {
+		t.Fatal(err)
+	}
+	secondKey, err := tuf.LoadKeyFromBytes(artifacts.SSLibKey2Public)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	env, err := dsse.CreateEnvelope(&ita.Statement{Type: ita.StatementTypeUri, PredicateType: "https://gittuf.dev/test/v0.1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The envelope must be signed by the key
+	_, err = Upload(ctx, server.URL, env, firstKey)
+	assert.ErrorIs(t, err, ErrSignatureNotFound)
+
+	env, err = dsse.SignEnvelope(ctx, env, firstSigner)
+	if err != nil {
+		t.Fatal(err)
+	}
+	env, err = dsse.SignEnvelope(ctx, env, secondSigner)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	firstEntry, err := Upload(ctx, server.URL, env, firstKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	secondEntry, err := Upload(ctx, server.URL, env, secondKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	err = VerifyEntry(ctx, firstEntry, env, firstKey.KeyID, trustedLogs)
+	assert.Nil(t, err)
+
+	err = VerifyEntry(ctx, secondEntry, env, secondKey.KeyID, trustedLogs)
+	assert.Nil(t, err)
+
+	// An entry for another signature does not verify
+	err = VerifyEntry(ctx, firstEntry, env, secondKey.KeyID, trustedLogs)
+	assert.ErrorIs(t, err, ErrEntryMismatch)
+
+	// An entry for another payload does not verify
+	otherEnv, err := dsse.CreateEnvelope(&ita.Statement{Type: ita.StatementTypeUri, PredicateType: "https://gittuf.dev/other/v0.1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherEnv.Signatures = env.Signatures
+	err = VerifyEntry(ctx, firstEntry, otherEnv, firstKey.KeyID, trustedLogs)
+	assert.ErrorIs(t, err, ErrEntryMismatch)
+
+	// The entry must be signed by a trusted log
+	_, otherTrustedLogs := common.NewTestRekorServer(t)
+	err = VerifyEntry(ctx, firstEntry, env, firstKey.KeyID, otherTrustedLogs)
+	assert.ErrorIs(t, err, ErrInvalidEntry)
+}
+
+func TestPublicKeyPEM(t *testing.T) {
+	key, err := tuf.LoadKeyFromBytes(artifacts.SSLibKey1Public)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	publicKey, err := PublicKeyPEM(key)
+	assert.Nil(t, err)
+	assert.Contains(t, string(publicKey), "BEGIN PUBLIC KEY")
+
+	_, err = PublicKeyPEM(&tuf.Key{KeyType: "gpg"})
+	assert.ErrorIs(t, err, ErrUnsupportedKeyType)
+}
@@ -0,0 +1,137 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Package tsa obtains RFC 3161 timestamps for signatures from a timestamp
+// authority and verifies them, establishing when each signature was made.
+package tsa
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/digitorus/timestamp"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"github.com/sigstore/timestamp-authority/pkg/verification"
+)
+
+const (
+	timestampQueryContentType = "application/timestamp-query"
+	timestampReplyContentType = "application/timestamp-reply"
+)
+
+var (
+	ErrInvalidTimestamp        = errors.New("unable to verify timestamp")
+	ErrNoRootCertificate       = errors.New("certificate chain for timestamp authority has no root certificate")
+	ErrTimestampRequestFailed  = errors.New("timestamp authority did not issue timestamp")
+	ErrInvalidTimestampRequest = errors.New("timestamp response does not match request")
+)
+
+// CertificateChain is the set of certificates used to verify timestamps issued
+// by a timestamp authority.
+type CertificateChain struct {
+	Roots         []*x509.Certificate
+	Intermediates []*x509.Certificate
+}
+
+// LoadCertificateChain returns the certificate chain encoded in the PEM
+// contents. Self-signed certificates are treated as roots, while all other
+// certificates are treated as intermediates or the timestamp authority's own
+// certificate.
+func LoadCertificateChain(contents []byte) (*CertificateChain, error) {
+	certificates, err := cryptoutils.UnmarshalCertificatesFromPEM(contents)
+	if err != nil {
+		return nil, err
+	}
+
+	chain := &CertificateChain{}
+	for _, certificate := range certificates {
+		if bytes.Equal(certificate.RawIssuer, certificate.RawSubject) && certificate.CheckSignatureFrom(certificate) == nil {
+			chain.Roots = append(chain.Roots, certificate)
+		} else {
+			chain.Intermediates = append(chain.Intermediates, certificate)
+		}
+	}
+
+	if len(chain.Roots) == 0 {
+		return nil, ErrNoRootCertificate
+	}
+
+	return chain, nil
+}
+
+// Timestamp requests a timestamp for the signature from the timestamp
+// authority at tsaURL. The DER encoded timestamp response is returned.
+func Timestamp(ctx context.Context, tsaURL string, signature []byte) ([]byte, error) {
+	nonce, err := rand.Int(rand.Reader, new(big.Int).Lsh(big.NewInt(1), 64))
+	if err != nil {
+		return nil, err
+	}
+
+	requestBytes, err := timestamp.CreateRequest(bytes.NewReader(signature), &timestamp.RequestOptions{
+		Hash:         crypto.SHA256,
+		Certificates: true,
+		Nonce:        nonce,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, tsaURL, bytes.NewReader(requestBytes))
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("Content-Type", timestampQueryContentType)
+	request.Header.Set("Accept", timestampReplyContentType)
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close() //nolint:errcheck
+
+	responseBytes, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: status %d: %s", ErrTimestampRequestFailed, response.StatusCode, strings.TrimSpace(string(responseBytes)))
+	}
+
+	ts, err := timestamp.ParseResponse(responseBytes)
+	if err != nil {
+		return nil, errors.Join(ErrTimestampRequestFailed, err)
+	}
+
+	hashedSignature := sha256.Sum256(signature)
+	if !bytes.Equal(ts.HashedMessage, hashedSignature[:]) || ts.Nonce == nil || ts.Nonce.Cmp(nonce) != 0 {
+		return nil, ErrInvalidTimestampRequest
+	}
+
+	return responseBytes, nil
+}
+
+// Verify checks that the timestamp response was issued for the signature by a
+// timestamp authority trusted using the certificate chain, and returns the
+// time the timestamp authority recorded for the signature.
+func Verify(response, signature []byte, chain *CertificateChain) (time.Time, error) {
+	ts, err := verification.VerifyTimestampResponse(response, bytes.NewReader(signature), verification.VerifyOpts{
+		Roots:         chain.Roots,
+		Intermediates: chain.Intermediates,
+	})
+	if err != nil {
+		return time.Time{}, errors.Join(ErrInvalidTimestamp, err)
+	}
+
+	return ts.Time, nil
+}
@@ -0,0 +1,61 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package tsa
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/common"
+	"github.com/sigstore/sigstore/pkg/cryptoutils"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimestampAndVerify(t *testing.T) {
+	ctx := context.Background()
+	signature := []byte("signature")
+
+	server, chainPEM := common.NewTestTimestampAuthority(t, common.TestClock)
+	_, otherChainPEM := common.NewTestTimestampAuthority(t, common.TestClock)
+
+	chain, err := LoadCertificateChain(chainPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherChain, err := LoadCertificateChain(otherChainPEM)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	response, err := Timestamp(ctx, server.URL, signature)
+	assert.Nil(t, err)
+
+	timestampTime, err := Verify(response, signature, chain)
+	assert.Nil(t, err)
+	assert.True(t, common.TestClock.Now().Equal(timestampTime))
+
+	// The timestamp must be for the signature
+	_, err = Verify(response, []byte("other signature"), chain)
+	assert.ErrorIs(t, err, ErrInvalidTimestamp)
+
+	// The timestamp must be issued by a trusted timestamp authority
+	_, err = Verify(response, signature, otherChain)
+	assert.ErrorIs(t, err, ErrInvalidTimestamp)
+}
+
+func TestLoadCertificateChain(t *testing.T) {
+	_, chainPEM := common.NewTestTimestampAuthority(t, common.TestClock)
+
+	chain, err := LoadCertificateChain(chainPEM)
+	assert.Nil(t, err)
+	assert.Len(t, chain.Roots, 1)
+	assert.Len(t, chain.Intermediates, 1)
+
+	// The chain must include a root certificate
+	leafPEM, err := cryptoutils.MarshalCertificatesToPEM(chain.Intermediates)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = LoadCertificateChain(leafPEM)
+	assert.ErrorIs(t, err, ErrNoRootCertificate)
+}
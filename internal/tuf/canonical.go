@@ -0,0 +1,86 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package tuf
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+)
+
+// setFields are the names of metadata fields whose values are unordered sets.
+// Their elements are sorted when metadata is canonicalized, so the order in
+// which key IDs, people, or teams were added to a role does not affect the
+// encoded metadata.
+var setFields = map[string]bool{
+	"keyids":               true,
+	"personids":            true,
+	"teamids":              true,
+	"force_push_keyids":    true,
+	"deletion_keyids":      true,
+	"submodule_urls":       true,
+	"conditions":           true,
+	"forbidden_extensions": true,
+}
+
+// EncodeCanonical returns the canonical JSON encoding of metadata, such as
+// RootMetadata or TargetsMetadata. Object keys are sorted, insignificant
+// whitespace is omitted, characters are not escaped unless JSON requires it,
+// and the elements of fields that are sets are sorted. As a result, the same
+// logical metadata is always encoded to identical bytes, so independent signers
+// of the metadata sign identical payloads.
+func EncodeCanonical(metadata any) ([]byte, error) {
+	metadataBytes, err := json.Marshal(metadata)
+	if err != nil {
+		return nil, err
+	}
+
+	// Decode into generic values so that all objects, including structs, are
+	// encoded with sorted keys. Numbers are preserved as is.
+	decoder := json.NewDecoder(bytes.NewReader(metadataBytes))
+	decoder.UseNumber()
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	sortSets(value)
+
+	buf := new(bytes.Buffer)
+	encoder := json.NewEncoder(buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(value); err != nil {
+		return nil, err
+	}
+
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n")), nil
+}
+
+// sortSets sorts the elements of set fields in value in place.
+func sortSets(value any) {
+	switch value := value.(type) {
+	case map[string]any:
+		for name, fieldValue := range value {
+			if elements, isList := fieldValue.([]any); isList && setFields[name] {
+				sortStrings(elements)
+			}
+			sortSets(fieldValue)
+		}
+	case []any:
+		for _, element := range value {
+			sortSets(element)
+		}
+	}
+}
+
+// sortStrings sorts elements if they are all strings.
+func sortStrings(elements []any) {
+	for _, element := range elements {
+		if _, isString := element.(string); !isString {
+			return
+		}
+	}
+
+	sort.Slice(elements, func(i, j int) bool {
+		return elements[i].(string) < elements[j].(string)
+	})
+}
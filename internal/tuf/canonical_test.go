@@ -0,0 +1,58 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package tuf
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeCanonical(t *testing.T) {
+	t.Run("keys sorted and HTML not escaped", func(t *testing.T) {
+		metadata := &TargetsMetadata{
+			Type:        "targets",
+			SpecVersion: specVersion,
+			Version:     2,
+			Expires:     "2030-01-01T00:00:00Z",
+			Targets:     map[string]any{},
+			Delegations: &Delegations{
+				Keys: map[string]*Key{},
+				Roles: []Delegation{
+					{
+						Name:  "protect-<main>",
+						Paths: []string{"git:refs/heads/main", "file:a&b"},
+						Role:  Role{KeyIDs: []string{}, Threshold: 1},
+					},
+				},
+			},
+		}
+
+		encoded, err := EncodeCanonical(metadata)
+		assert.Nil(t, err)
+		assert.Equal(t, `{"delegations":{"keys":{},"roles":[{"keyids":[],"name":"protect-<main>","paths":["git:refs/heads/main","file:a&b"],"terminating":false,"threshold":1}]},"expires":"2030-01-01T00:00:00Z","spec_version":"1.0","targets":{},"type":"targets","version":2}`, string(encoded))
+	})
+
+	t.Run("sets sorted", func(t *testing.T) {
+		metadata := &RootMetadata{
+			Roles: map[string]Role{
+				"root": {KeyIDs: []string{"b", "c", "a"}, Threshold: 2},
+			},
+		}
+		reordered := &RootMetadata{
+			Roles: map[string]Role{
+				"root": {KeyIDs: []string{"c", "a", "b"}, Threshold: 2},
+			},
+		}
+
+		encoded, err := EncodeCanonical(metadata)
+		assert.Nil(t, err)
+		reorderedEncoded, err := EncodeCanonical(reordered)
+		assert.Nil(t, err)
+		assert.Equal(t, encoded, reorderedEncoded)
+		assert.Contains(t, string(encoded), `"keyids":["a","b","c"]`)
+
+		// The metadata itself is not modified
+		assert.Equal(t, []string{"b", "c", "a"}, metadata.Roles["root"].KeyIDs)
+	})
+}
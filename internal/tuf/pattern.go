@@ -0,0 +1,56 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package tuf
+
+import (
+	"path"
+	"strings"
+)
+
+// recursiveWildcard is a path segment that matches zero or more segments.
+const recursiveWildcard = "**"
+
+// MatchPattern reports whether target matches pattern. Patterns use the syntax
+// of path.Match, so "*" does not match "/". Additionally, a "**" path segment
+// matches zero or more segments, so "file:docs/**" matches every file under
+// docs and "file:**/*.md" matches every Markdown file. A pattern's scheme, such
+// as "file:", must be matched exactly when the pattern uses "**".
+func MatchPattern(pattern, target string) bool {
+	if !strings.Contains(pattern, recursiveWildcard) {
+		ok, _ := path.Match(pattern, target)
+		return ok
+	}
+
+	if scheme, rest, hasScheme := strings.Cut(pattern, ":"); hasScheme && !strings.Contains(scheme, "/") {
+		targetRest, found := strings.CutPrefix(target, scheme+":")
+		if !found {
+			return false
+		}
+		pattern, target = rest, targetRest
+	}
+
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(target, "/"))
+}
+
+func matchSegments(patternSegments, targetSegments []string) bool {
+	for len(patternSegments) > 0 {
+		if patternSegments[0] == recursiveWildcard {
+			for i := 0; i <= len(targetSegments); i++ {
+				if matchSegments(patternSegments[1:], targetSegments[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+
+		if len(targetSegments) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(patternSegments[0], targetSegments[0]); !ok {
+			return false
+		}
+		patternSegments, targetSegments = patternSegments[1:], targetSegments[1:]
+	}
+
+	return len(targetSegments) == 0
+}
@@ -0,0 +1,35 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package tuf
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMatchPattern(t *testing.T) {
+	tests := map[string]struct {
+		pattern  string
+		target   string
+		expected bool
+	}{
+		"exact match":                      {pattern: "git:refs/heads/main", target: "git:refs/heads/main", expected: true},
+		"single wildcard":                  {pattern: "file:docs/*", target: "file:docs/README.md", expected: true},
+		"single wildcard does not recurse": {pattern: "file:docs/*", target: "file:docs/guides/setup.md", expected: false},
+		"recursive wildcard":               {pattern: "file:docs/**", target: "file:docs/guides/setup.md", expected: true},
+		"recursive wildcard direct child":  {pattern: "file:docs/**", target: "file:docs/README.md", expected: true},
+		"recursive wildcard other dir":     {pattern: "file:docs/**", target: "file:src/main.go", expected: false},
+		"leading recursive wildcard":       {pattern: "file:**/*.md", target: "file:docs/guides/setup.md", expected: true},
+		"leading recursive wildcard root":  {pattern: "file:**/*.md", target: "file:README.md", expected: true},
+		"middle recursive wildcard":        {pattern: "file:src/**/test/*", target: "file:src/a/b/test/main_test.go", expected: true},
+		"middle recursive wildcard zero":   {pattern: "file:src/**/test/*", target: "file:src/test/main_test.go", expected: true},
+		"middle recursive wildcard miss":   {pattern: "file:src/**/test/*", target: "file:src/a/main_test.go", expected: false},
+		"scheme mismatch":                  {pattern: "file:**", target: "git:refs/heads/main", expected: false},
+		"everything":                       {pattern: "file:**", target: "file:a/b/c", expected: true},
+	}
+
+	for name, test := range tests {
+		assert.Equal(t, test.expected, MatchPattern(test.pattern, test.target), name)
+	}
+}
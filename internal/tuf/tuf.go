@@ -11,7 +11,6 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"errors"
-	"path"
 
 	"github.com/gittuf/gittuf/internal/third_party/go-securesystemslib/signerverifier"
 	"github.com/secure-systems-lab/go-securesystemslib/cjson"
@@ -19,6 +18,14 @@ import (
 
 const specVersion = "1.0"
 
+// SchemaVersion is the version of gittuf's metadata schema that new root and
+// targets metadata is created with. Metadata created using an older schema is
+// upgraded using `gittuf migrate`. The versions are:
+//
+//	0: metadata created before schema versions were recorded
+//	1: metadata encoded canonically, see EncodeCanonical
+const SchemaVersion = 1
+
 var (
 	ErrTargetsNotEmpty = errors.New("`targets` field in gittuf Targets metadata must be empty")
 )
@@ -83,13 +90,89 @@ type Role struct {
 
 // RootMetadata defines the schema of TUF's Root role.
 type RootMetadata struct {
-	Type               string          `json:"type"`
-	SpecVersion        string          `json:"spec_version"`
-	ConsistentSnapshot bool            `json:"consistent_snapshot"` // TODO: how do we handle this?
-	Version            int             `json:"version"`
-	Expires            string          `json:"expires"`
-	Keys               map[string]*Key `json:"keys"`
-	Roles              map[string]Role `json:"roles"`
+	Type               string                    `json:"type"`
+	SpecVersion        string                    `json:"spec_version"`
+	ConsistentSnapshot bool                      `json:"consistent_snapshot"` // TODO: how do we handle this?
+	SchemaVersion      int                       `json:"schema_version,omitempty"`
+	Version            int                       `json:"version"`
+	Expires            string                    `json:"expires"`
+	Keys               map[string]*Key           `json:"keys"`
+	Roles              map[string]Role           `json:"roles"`
+	RevokedKeys        map[string]*KeyRevocation `json:"revoked_keys,omitempty"`
+
+	// RepositoryRoots is set when this repository is an organization's root
+	// of trust. It records the keys trusted to sign the root of trust of each
+	// repository in the organization.
+	RepositoryRoots map[string]*RepositoryRoot `json:"repository_roots,omitempty"`
+
+	// OrganizationRoot is set when this repository's root of trust is
+	// delegated to by an organization's root of trust.
+	OrganizationRoot *OrganizationRoot `json:"organization_root,omitempty"`
+
+	// AlgorithmPolicy, if set, restricts the keys and signature algorithms
+	// that policy may use.
+	AlgorithmPolicy *AlgorithmPolicy `json:"algorithm_policy,omitempty"`
+
+	// GlobalRules apply to the matching Git references regardless of the
+	// rules delegated to by policy.
+	GlobalRules []*GlobalRule `json:"global_rules,omitempty"`
+}
+
+// AlgorithmPolicy records the minimum acceptable signing algorithms for the
+// repository. Zero values do not restrict keys or signatures.
+type AlgorithmPolicy struct {
+	// AllowedKeyTypes lists the key types that may be used, such as "rsa",
+	// "ed25519", or "gpg". If empty, all key types are allowed.
+	AllowedKeyTypes []string `json:"allowed_key_types,omitempty"`
+
+	// MinimumRSABits is the minimum size of RSA keys, including RSA keys
+	// used for GPG signatures.
+	MinimumRSABits int `json:"minimum_rsa_bits,omitempty"`
+
+	// RejectSHA1 rejects GPG signatures that use SHA-1.
+	RejectSHA1 bool `json:"reject_sha1,omitempty"`
+}
+
+// RepositoryRoot records the keys and threshold an organization's root of
+// trust expects for a repository's root of trust.
+type RepositoryRoot struct {
+	Keys      map[string]*Key `json:"keys"`
+	Threshold int             `json:"threshold"`
+}
+
+// OrganizationRoot records where the organization's root-of-trust repository
+// can be fetched from, and the name this repository is recorded under in it.
+type OrganizationRoot struct {
+	Location       string `json:"location"`
+	RepositoryName string `json:"repository_name"`
+}
+
+// GlobalRule requires a threshold of signatures for changes to the Git
+// references that match any of its patterns. The signatures are counted across
+// the keys trusted by all the rules that apply to the reference, or across all
+// the keys in policy if no rule applies.
+type GlobalRule struct {
+	Name      string   `json:"name"`
+	Paths     []string `json:"paths"`
+	Threshold int      `json:"threshold"`
+}
+
+// Matches checks if any of the global rule's patterns match the target.
+func (g *GlobalRule) Matches(target string) bool {
+	for _, pattern := range g.Paths {
+		if MatchPattern(pattern, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// KeyRevocation records that a key is no longer trusted in any role, along
+// with why and when it was revoked.
+type KeyRevocation struct {
+	KeyID     string `json:"keyid"`
+	Reason    string `json:"reason"`
+	RevokedAt string `json:"revoked_at"`
 }
 
 // NewRootMetadata returns a new instance of RootMetadata.
@@ -98,6 +181,7 @@ func NewRootMetadata() *RootMetadata {
 		Type:               "root",
 		SpecVersion:        specVersion,
 		ConsistentSnapshot: true,
+		SchemaVersion:      SchemaVersion,
 	}
 }
 
@@ -130,22 +214,39 @@ func (r *RootMetadata) AddRole(roleName string, role Role) {
 	r.Roles[roleName] = role
 }
 
+// AddRevokedKey records a key revocation in the RootMetadata instance.
+func (r *RootMetadata) AddRevokedKey(revocation *KeyRevocation) {
+	if r.RevokedKeys == nil {
+		r.RevokedKeys = map[string]*KeyRevocation{}
+	}
+
+	r.RevokedKeys[revocation.KeyID] = revocation
+}
+
+// IsKeyRevoked returns true if the key matching keyID has been revoked.
+func (r *RootMetadata) IsKeyRevoked(keyID string) bool {
+	_, revoked := r.RevokedKeys[keyID]
+	return revoked
+}
+
 // TargetsMetadata defines the schema of TUF's Targets role.
 type TargetsMetadata struct {
-	Type        string         `json:"type"`
-	SpecVersion string         `json:"spec_version"`
-	Version     int            `json:"version"`
-	Expires     string         `json:"expires"`
-	Targets     map[string]any `json:"targets"`
-	Delegations *Delegations   `json:"delegations"`
+	Type          string         `json:"type"`
+	SpecVersion   string         `json:"spec_version"`
+	SchemaVersion int            `json:"schema_version,omitempty"`
+	Version       int            `json:"version"`
+	Expires       string         `json:"expires"`
+	Targets       map[string]any `json:"targets"`
+	Delegations   *Delegations   `json:"delegations"`
 }
 
 // NewTargetsMetadata returns a new instance of TargetsMetadata.
 func NewTargetsMetadata() *TargetsMetadata {
 	return &TargetsMetadata{
-		Type:        "targets",
-		SpecVersion: specVersion,
-		Delegations: &Delegations{},
+		Type:          "targets",
+		SpecVersion:   specVersion,
+		SchemaVersion: SchemaVersion,
+		Delegations:   &Delegations{},
 	}
 }
 
@@ -171,8 +272,18 @@ func (t *TargetsMetadata) Validate() error {
 // Delegations defines the schema for specifying delegations in TUF's Targets
 // metadata.
 type Delegations struct {
-	Keys  map[string]*Key `json:"keys"`
-	Roles []Delegation    `json:"roles"`
+	Keys   map[string]*Key    `json:"keys"`
+	People map[string]*Person `json:"people,omitempty"`
+	Teams  map[string]*Team   `json:"teams,omitempty"`
+	Roles  []Delegation       `json:"roles"`
+}
+
+// Person groups the keys that belong to a single human. A rule that authorizes
+// a person trusts all of the person's keys, and the person counts once towards
+// the rule's threshold regardless of how many of their keys are used.
+type Person struct {
+	PersonID string   `json:"personid"`
+	KeyIDs   []string `json:"keyids"`
 }
 
 // AddKey adds a delegations key.
@@ -184,6 +295,35 @@ func (d *Delegations) AddKey(key *Key) {
 	d.Keys[key.KeyID] = key
 }
 
+// Team is a named set of people and keys. A rule that authorizes a team
+// authorizes each of the team's members, and each member counts towards the
+// rule's threshold as if the rule authorized them directly.
+type Team struct {
+	TeamID    string   `json:"teamid"`
+	KeyIDs    []string `json:"keyids,omitempty"`
+	PersonIDs []string `json:"personids,omitempty"`
+}
+
+// AddPerson adds a person to the delegations, replacing any existing person
+// with the same ID.
+func (d *Delegations) AddPerson(person *Person) {
+	if d.People == nil {
+		d.People = map[string]*Person{}
+	}
+
+	d.People[person.PersonID] = person
+}
+
+// AddTeam adds a team to the delegations, replacing any existing team with the
+// same ID.
+func (d *Delegations) AddTeam(team *Team) {
+	if d.Teams == nil {
+		d.Teams = map[string]*Team{}
+	}
+
+	d.Teams[team.TeamID] = team
+}
+
 // AddDelegation adds a new delegation.
 func (d *Delegations) AddDelegation(delegation Delegation) {
 	if d.Roles == nil {
@@ -196,7 +336,7 @@ func (d *Delegations) AddDelegation(delegation Delegation) {
 // Matches checks if any of the delegation's patterns match the target.
 func (d *Delegation) Matches(target string) bool {
 	for _, pattern := range d.Paths {
-		if ok, _ := path.Match(pattern, target); ok {
+		if MatchPattern(pattern, target) {
 			return true
 		}
 	}
@@ -211,5 +351,134 @@ type Delegation struct {
 	Paths       []string         `json:"paths"`
 	Terminating bool             `json:"terminating"`
 	Custom      *json.RawMessage `json:"custom,omitempty"`
+	// MergeStrategy determines how commits introduced by merges into the
+	// protected Git references are verified. If unset, all commits are
+	// verified.
+	MergeStrategy string `json:"merge_strategy,omitempty"`
+	// Effect determines whether the delegation allows or denies changes to
+	// the namespaces it protects. If unset, the delegation is an allow rule.
+	Effect string `json:"effect,omitempty"`
+	// Priority determines the order in which the delegations in a metadata
+	// file are evaluated. Delegations with a higher priority are evaluated
+	// first, and delegations with the same priority are evaluated in the
+	// order they are listed.
+	Priority int `json:"priority,omitempty"`
+	// ForcePushKeyIDs lists the keys that may authorize rewriting the history
+	// of the protected Git references. If unset, history rewrites are not
+	// permitted.
+	ForcePushKeyIDs []string `json:"force_push_keyids,omitempty"`
+	// DeletionKeyIDs lists the keys that may delete the protected Git
+	// references. If unset, the protected references may not be deleted.
+	DeletionKeyIDs []string `json:"deletion_keyids,omitempty"`
+	// SubmoduleURLs lists the URLs that submodules at the protected paths may
+	// point to. Each entry is matched as a glob pattern. If unset, submodules
+	// may point to any URL.
+	SubmoduleURLs []string `json:"submodule_urls,omitempty"`
+	// RequireSubmoduleVerification requires that the commit pinned by a
+	// submodule at the protected paths is recorded in and verified against
+	// the submodule's own RSL.
+	RequireSubmoduleVerification bool `json:"require_submodule_verification,omitempty"`
+	// RegoPolicy is a Rego module that constrains changes to the protected
+	// Git references beyond who signed them. A change is rejected if the
+	// module's data.gittuf.deny rule produces any messages for it.
+	RegoPolicy string `json:"rego_policy,omitempty"`
+	// Conditions lists CEL expressions that must all evaluate to true for a
+	// change to the protected Git references to be accepted.
+	Conditions []string `json:"conditions,omitempty"`
+	// RequireSignoff requires the messages of commits added to the protected
+	// Git references to include a Developer Certificate of Origin
+	// Signed-off-by trailer for the commit's author.
+	RequireSignoff bool `json:"require_signoff,omitempty"`
+	// RequireConventionalCommits requires the messages of commits added to
+	// the protected Git references to follow the Conventional Commits
+	// specification.
+	RequireConventionalCommits bool `json:"require_conventional_commits,omitempty"`
+	// CommitMessagePattern is a regular expression that the messages of
+	// commits added to the protected Git references must match, such as a
+	// pattern for issue references.
+	CommitMessagePattern string `json:"commit_message_pattern,omitempty"`
+	// MaxFileSize is the maximum size in bytes of files added or modified at
+	// the protected paths. If unset, files may be of any size.
+	MaxFileSize int64 `json:"max_file_size,omitempty"`
+	// ForbiddenExtensions lists the file extensions, such as ".exe", that
+	// files added or modified at the protected paths may not have.
+	ForbiddenExtensions []string `json:"forbidden_extensions,omitempty"`
+	// ForbidBinaryFiles prevents binary files from being added or modified at
+	// the protected paths.
+	ForbidBinaryFiles bool `json:"forbid_binary_files,omitempty"`
+	// PersonIDs lists the people authorized by the delegation in addition to
+	// the keys in KeyIDs. Each person counts once towards the threshold.
+	PersonIDs []string `json:"personids,omitempty"`
+	// TeamIDs lists the teams authorized by the delegation. Each member of
+	// each team is authorized as if listed in KeyIDs or PersonIDs.
+	TeamIDs []string `json:"teamids,omitempty"`
 	Role
 }
+
+// SnapshotMetadata defines the schema of TUF's Snapshot role. It binds the
+// versions and hashes of all policy files in a policy state together.
+type SnapshotMetadata struct {
+	Type        string                       `json:"type"`
+	SpecVersion string                       `json:"spec_version"`
+	Version     int                          `json:"version"`
+	Expires     string                       `json:"expires"`
+	Meta        map[string]*SnapshotFileMeta `json:"meta"`
+}
+
+// SnapshotFileMeta records the version and hashes of a single policy file in
+// SnapshotMetadata.
+type SnapshotFileMeta struct {
+	Version int               `json:"version"`
+	Hashes  map[string]string `json:"hashes"`
+}
+
+// NewSnapshotMetadata returns a new instance of SnapshotMetadata.
+func NewSnapshotMetadata() *SnapshotMetadata {
+	return &SnapshotMetadata{
+		Type:        "snapshot",
+		SpecVersion: specVersion,
+		Meta:        map[string]*SnapshotFileMeta{},
+	}
+}
+
+// SetVersion sets the version of the SnapshotMetadata to the value passed in.
+func (s *SnapshotMetadata) SetVersion(version int) {
+	s.Version = version
+}
+
+// SetExpires sets the expiry date of the SnapshotMetadata to the value passed
+// in.
+func (s *SnapshotMetadata) SetExpires(expires string) {
+	s.Expires = expires
+}
+
+// TimestampMetadata defines the schema of gittuf's timestamp role. It records
+// the tip of the RSL at the time it was signed so that clients can detect when
+// newer RSL entries are withheld from them.
+type TimestampMetadata struct {
+	Type        string `json:"type"`
+	SpecVersion string `json:"spec_version"`
+	Version     int    `json:"version"`
+	Expires     string `json:"expires"`
+	RSLTip      string `json:"rsl_tip"`
+	SignedAt    string `json:"signed_at"`
+}
+
+// NewTimestampMetadata returns a new instance of TimestampMetadata.
+func NewTimestampMetadata() *TimestampMetadata {
+	return &TimestampMetadata{
+		Type:        "timestamp",
+		SpecVersion: specVersion,
+	}
+}
+
+// SetVersion sets the version of the TimestampMetadata to the value passed in.
+func (t *TimestampMetadata) SetVersion(version int) {
+	t.Version = version
+}
+
+// SetExpires sets the expiry date of the TimestampMetadata to the value passed
+// in.
+func (t *TimestampMetadata) SetExpires(expires string) {
+	t.Expires = expires
+}
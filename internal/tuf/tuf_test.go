@@ -132,6 +132,20 @@ func TestTargetsMetadataAndDelegations(t *testing.T) {
 		assert.Equal(t, key, delegations.Keys[key.KeyID])
 	})
 
+	t.Run("test AddPerson", func(t *testing.T) {
+		assert.Nil(t, delegations.People)
+		person := &Person{PersonID: "alice", KeyIDs: []string{key.KeyID}}
+		delegations.AddPerson(person)
+		assert.Equal(t, person, delegations.People["alice"])
+	})
+
+	t.Run("test AddTeam", func(t *testing.T) {
+		assert.Nil(t, delegations.Teams)
+		team := &Team{TeamID: "maintainers", PersonIDs: []string{"alice"}}
+		delegations.AddTeam(team)
+		assert.Equal(t, team, delegations.Teams["maintainers"])
+	})
+
 	t.Run("test AddDelegation", func(t *testing.T) {
 		assert.Nil(t, delegations.Roles)
 		d := Delegation{
@@ -3,10 +3,13 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"os"
+	"os/signal"
 	"runtime/debug"
 
+	"github.com/gittuf/gittuf/internal/cmd/common"
 	"github.com/gittuf/gittuf/internal/cmd/profile"
 	"github.com/gittuf/gittuf/internal/cmd/root"
 )
@@ -22,15 +25,20 @@ func main() {
 			debug.PrintStack()
 			fmt.Fprintln(os.Stderr, "\nPlease consider filing a bug on https:/github.com/gittuf/gittuf/issues with the stack trace and steps to reproduce this state. Thanks!")
 
-			os.Exit(1) // this is the last possible deferred function to run
+			os.Exit(common.ExitCodeError) // this is the last possible deferred function to run
 		}
 	}()
 
+	// Cancel in-progress operations such as verification on interrupt
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
 	rootCmd := root.New()
-	if err := rootCmd.Execute(); err != nil {
+	if err := rootCmd.ExecuteContext(ctx); err != nil {
 		// We can ignore the linter here (deferred functions are not executed
 		// when os.Exit is invoked) because if we do have an error, we don't
 		// have a panic, which is what the deferred function is looking for.
-		os.Exit(1) //nolint:gocritic
+		// The exit code identifies the class of failure, see common.ExitCode.
+		os.Exit(common.ExitCode(err)) //nolint:gocritic
 	}
 }
@@ -0,0 +1,59 @@
+// SPDX-License-Identifier: Apache-2.0
+
+// Package gittuf is the supported Go API for embedding gittuf in other tools,
+// such as forges, CI systems, and deployment gates. It can open a Git
+// repository, verify its references and tags against the repository's gittuf
+// policy, query the policy, and read the Reference State Log (RSL), without
+// invoking the gittuf binary.
+//
+// Errors returned by this package can be matched against the errors defined
+// here using errors.Is to identify why verification failed.
+package gittuf
+
+import (
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/gittuf/gittuf/internal/rsl"
+)
+
+var (
+	// ErrPolicyNotFound indicates the repository does not have a gittuf
+	// policy.
+	ErrPolicyNotFound = policy.ErrPolicyNotFound
+
+	// ErrUnauthorizedSignature indicates verification failed because a
+	// required signature is missing or was made using a key that is not
+	// authorized.
+	ErrUnauthorizedSignature = policy.ErrUnauthorizedSignature
+
+	// ErrThresholdUnmet indicates valid signatures were found, but not enough
+	// of them to meet the threshold required by the policy.
+	ErrThresholdUnmet = policy.ErrThresholdUnmet
+
+	// ErrKeyRevoked indicates verification failed because a signature was
+	// made using a key that has been revoked.
+	ErrKeyRevoked = policy.ErrKeyRevoked
+
+	// ErrRSLEntryNotFound indicates the requested RSL entry does not exist.
+	ErrRSLEntryNotFound = rsl.ErrRSLEntryNotFound
+
+	// ErrRSLNotFresh indicates the RSL is not fresh according to the
+	// repository's timestamp policy.
+	ErrRSLNotFresh = policy.ErrRSLNotFresh
+)
+
+// Repository is a Git repository that uses gittuf.
+type Repository struct {
+	r *repository.Repository
+}
+
+// Open opens the Git repository at path, which is either the repository's
+// working tree or its Git directory.
+func Open(path string) (*Repository, error) {
+	repo, err := repository.LoadRepositoryAt(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Repository{r: repo}, nil
+}
@@ -0,0 +1,54 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package gittuf
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gittuf/gittuf/internal/rsl"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRepository(t *testing.T) {
+	_, err := Open(t.TempDir())
+	assert.ErrorIs(t, err, git.ErrRepositoryNotExists)
+
+	tmpDir := t.TempDir()
+	r, err := git.PlainInit(tmpDir, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := rsl.InitializeNamespace(r); err != nil {
+		t.Fatal(err)
+	}
+	if err := rsl.NewReferenceEntry("refs/heads/main", plumbing.ZeroHash).Commit(r, false); err != nil {
+		t.Fatal(err)
+	}
+
+	repo, err := Open(tmpDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("read RSL", func(t *testing.T) {
+		entries, err := repo.RSLEntries("refs/heads/main", 0)
+		assert.Nil(t, err)
+		assert.Len(t, entries, 1)
+		assert.Equal(t, "refs/heads/main", entries[0].RefName)
+		assert.Equal(t, plumbing.ZeroHash.String(), entries[0].TargetID)
+		assert.False(t, entries[0].Skipped)
+	})
+
+	t.Run("verify without policy", func(t *testing.T) {
+		err := repo.VerifyRef(context.Background(), "refs/heads/main", WithLatestOnly())
+		assert.NotNil(t, err)
+	})
+
+	t.Run("query rules without policy", func(t *testing.T) {
+		_, err := repo.Rules(context.Background())
+		assert.NotNil(t, err)
+	})
+}
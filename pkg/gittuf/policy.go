@@ -0,0 +1,89 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package gittuf
+
+import (
+	"context"
+
+	"github.com/gittuf/gittuf/internal/policy"
+)
+
+type (
+	// Authorization describes the rules that protect a target, and the
+	// principals that can meet each rule.
+	Authorization = policy.Authorization
+
+	// RuleAuthorization describes the principals that can meet a rule. The
+	// rule is met when Threshold distinct principals approve the change.
+	RuleAuthorization = policy.RuleAuthorization
+
+	// AuthorizedPrincipal is a person or a key that does not belong to a
+	// person, along with the keys it can use to approve changes.
+	AuthorizedPrincipal = policy.AuthorizedPrincipal
+)
+
+// Rule is a rule in the repository's current policy.
+type Rule struct {
+	// Name is the name of the rule.
+	Name string `json:"name"`
+
+	// Patterns are the namespaces the rule protects, such as
+	// "git:refs/heads/main" or "file:src/*".
+	Patterns []string `json:"patterns"`
+
+	// Threshold is the number of distinct principals that must approve a
+	// change to meet the rule.
+	Threshold int `json:"threshold"`
+
+	// KeyIDs, PersonIDs, and TeamIDs identify the principals authorized by
+	// the rule.
+	KeyIDs    []string `json:"keyids"`
+	PersonIDs []string `json:"personids,omitempty"`
+	TeamIDs   []string `json:"teamids,omitempty"`
+
+	// Deny indicates the rule denies changes to the namespaces it protects,
+	// unless the change is approved by the rule's principals.
+	Deny bool `json:"deny,omitempty"`
+
+	// Depth is the depth of the rule in the policy's delegation tree, where
+	// the rules in the primary rule file have depth 0.
+	Depth int `json:"depth"`
+}
+
+// Rules returns the rules in the repository's current policy, in the order
+// they are evaluated. A rule's delegated rules follow the rule.
+func (r *Repository) Rules(ctx context.Context) ([]*Rule, error) {
+	delegations, err := r.r.ListRules(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rules := make([]*Rule, 0, len(delegations))
+	for _, delegation := range delegations {
+		rules = append(rules, &Rule{
+			Name:      delegation.Delegation.Name,
+			Patterns:  delegation.Delegation.Paths,
+			Threshold: delegation.Delegation.Threshold,
+			KeyIDs:    delegation.Delegation.KeyIDs,
+			PersonIDs: delegation.Delegation.PersonIDs,
+			TeamIDs:   delegation.Delegation.TeamIDs,
+			Deny:      delegation.Delegation.Effect == policy.RuleEffectDeny,
+			Depth:     delegation.Depth,
+		})
+	}
+
+	return rules, nil
+}
+
+// WhoCanAuthorize returns the rules, thresholds, and principals that can
+// authorize a change to target under the current policy. The target may be a
+// Git reference, or a path prefixed with a namespace scheme such as "file:".
+func (r *Repository) WhoCanAuthorize(ctx context.Context, target string) (*Authorization, error) {
+	return r.r.WhoCanAuthorize(ctx, target)
+}
+
+// PolicyVersions returns the versions of the current policy's root and
+// top-level targets metadata, keyed by role name.
+func (r *Repository) PolicyVersions(ctx context.Context) (map[string]int, error) {
+	return r.r.GetPolicyVersions(ctx)
+}
@@ -0,0 +1,59 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package gittuf
+
+import "time"
+
+// RSLEntry is a reference entry in the repository's Reference State Log (RSL).
+type RSLEntry struct {
+	// ID is the ID of the entry's commit in the RSL.
+	ID string `json:"id"`
+
+	// RefName is the Git reference the entry is for.
+	RefName string `json:"ref"`
+
+	// TargetID is the Git ID the entry records for RefName. It is the zero
+	// hash if the entry records the deletion of RefName.
+	TargetID string `json:"target_id"`
+
+	// Skipped indicates an annotation marks the entry as to-be-skipped, such
+	// as when the entry was revoked.
+	Skipped bool `json:"skipped"`
+
+	// Annotations are the messages of the annotations that refer to the
+	// entry.
+	Annotations []string `json:"annotations,omitempty"`
+}
+
+// RSLEntries returns the RSL's reference entries for refName, such as
+// "refs/heads/main", from the latest entry to the earliest. If refName is
+// empty, the entries for all references are returned. If limit is positive, at
+// most limit entries are returned.
+func (r *Repository) RSLEntries(refName string, limit int) ([]*RSLEntry, error) {
+	entries, annotations, err := r.r.GetRSLReferenceEntries(refName, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	rslEntries := make([]*RSLEntry, 0, len(entries))
+	for _, entry := range entries {
+		rslEntry := &RSLEntry{
+			ID:       entry.ID.String(),
+			RefName:  entry.RefName,
+			TargetID: entry.TargetID.String(),
+			Skipped:  entry.SkippedBy(annotations[entry.ID]),
+		}
+		for _, annotation := range annotations[entry.ID] {
+			rslEntry.Annotations = append(rslEntry.Annotations, annotation.Message)
+		}
+		rslEntries = append(rslEntries, rslEntry)
+	}
+
+	return rslEntries, nil
+}
+
+// LatestRSLEntryTime returns the time at which the latest RSL entry was
+// recorded.
+func (r *Repository) LatestRSLEntryTime() (time.Time, error) {
+	return r.r.GetLatestRSLEntryTime()
+}
@@ -0,0 +1,162 @@
+// SPDX-License-Identifier: Apache-2.0
+
+package gittuf
+
+import (
+	"context"
+	"time"
+
+	"github.com/gittuf/gittuf/internal/policy"
+	"github.com/gittuf/gittuf/internal/repository"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+// DefaultExpiryGracePeriod is the duration after expiry during which policy
+// metadata is accepted by default.
+const DefaultExpiryGracePeriod = policy.DefaultExpiryGracePeriod
+
+// VerifyOptions contains the configurable parameters for verifying a
+// reference.
+type VerifyOptions struct {
+	// LatestOnly indicates that only the latest RSL entry for the reference
+	// must be verified, using the latest policy.
+	LatestOnly bool
+
+	// FromEntry, if set, is the ID of the RSL entry verification starts from.
+	// Entries prior to it are trusted without verification.
+	FromEntry string
+
+	// FromCommit, if set, is the ID of the commit whose first RSL entry
+	// verification starts from. Entries prior to it are trusted without
+	// verification.
+	FromCommit string
+
+	// ExpiryGracePeriod is the duration after expiry during which policy
+	// metadata is accepted with a warning.
+	ExpiryGracePeriod time.Duration
+
+	// UseCache indicates if RSL entries verified in prior runs may be skipped.
+	UseCache bool
+
+	// Jobs is the maximum number of RSL entries that are verified
+	// concurrently.
+	Jobs int
+
+	// Explain, if set, receives the decision trace for each RSL entry that is
+	// verified.
+	Explain func(entryID, step string)
+}
+
+type VerifyOption func(*VerifyOptions)
+
+// WithLatestOnly verifies only the latest RSL entry for the reference using
+// the latest policy.
+func WithLatestOnly() VerifyOption {
+	return func(o *VerifyOptions) {
+		o.LatestOnly = true
+	}
+}
+
+// WithFromEntry verifies the reference's RSL entries from the specified entry,
+// trusting prior entries.
+func WithFromEntry(entryID string) VerifyOption {
+	return func(o *VerifyOptions) {
+		o.FromEntry = entryID
+	}
+}
+
+// WithFromCommit verifies the reference's RSL entries from the first entry that
+// records the specified commit, trusting prior entries.
+func WithFromCommit(commitID string) VerifyOption {
+	return func(o *VerifyOptions) {
+		o.FromCommit = commitID
+	}
+}
+
+// WithExpiryGracePeriod sets the duration after expiry during which policy
+// metadata is accepted. By default, DefaultExpiryGracePeriod is used.
+func WithExpiryGracePeriod(gracePeriod time.Duration) VerifyOption {
+	return func(o *VerifyOptions) {
+		o.ExpiryGracePeriod = gracePeriod
+	}
+}
+
+// WithoutCache verifies every RSL entry for the reference, including entries
+// verified in prior runs.
+func WithoutCache() VerifyOption {
+	return func(o *VerifyOptions) {
+		o.UseCache = false
+	}
+}
+
+// WithJobs sets the maximum number of RSL entries that are verified
+// concurrently. By default, entries are verified one at a time.
+func WithJobs(jobs int) VerifyOption {
+	return func(o *VerifyOptions) {
+		o.Jobs = jobs
+	}
+}
+
+// WithExplanation passes the decision trace for each RSL entry that is verified
+// to fn, such as the rules that matched the entry, the keys counted towards
+// each rule's threshold, and the signatures that were rejected. When entries
+// are verified concurrently, fn may be invoked concurrently.
+func WithExplanation(fn func(entryID, step string)) VerifyOption {
+	return func(o *VerifyOptions) {
+		o.Explain = fn
+	}
+}
+
+// VerifyRef verifies the RSL entries of the reference against the applicable
+// gittuf policies, and checks that the reference matches its latest RSL entry.
+// By default, all entries for the reference are verified, reusing the results
+// of prior runs.
+func (r *Repository) VerifyRef(ctx context.Context, refName string, opts ...VerifyOption) error {
+	options := &VerifyOptions{ExpiryGracePeriod: DefaultExpiryGracePeriod, UseCache: true, Jobs: 1}
+	for _, fn := range opts {
+		fn(options)
+	}
+
+	verifyOpts := options.toRepositoryOptions()
+	switch {
+	case options.FromEntry != "":
+		return r.r.VerifyRefFromEntry(ctx, refName, options.FromEntry, verifyOpts...)
+	case options.FromCommit != "":
+		return r.r.VerifyRefFromCommit(ctx, refName, options.FromCommit, verifyOpts...)
+	default:
+		return r.r.VerifyRef(ctx, refName, options.LatestOnly, verifyOpts...)
+	}
+}
+
+// VerifyTag verifies the tag end-to-end. The tag's RSL entry and tag object
+// must be signed by keys authorized for the tag, the tag must not have been
+// moved after it was first recorded in the RSL, and the tag must match the RSL.
+// The tag can be specified by name, reference, or tag object ID.
+func (r *Repository) VerifyTag(ctx context.Context, tag string, opts ...VerifyOption) error {
+	options := &VerifyOptions{ExpiryGracePeriod: DefaultExpiryGracePeriod, Jobs: 1}
+	for _, fn := range opts {
+		fn(options)
+	}
+
+	return r.r.VerifyTagRef(ctx, tag, options.toRepositoryOptions()...)
+}
+
+// toRepositoryOptions returns the options used to verify using the internal
+// repository API.
+func (o *VerifyOptions) toRepositoryOptions() []repository.VerifyRefOption {
+	opts := []repository.VerifyRefOption{
+		repository.WithExpiryGracePeriod(o.ExpiryGracePeriod),
+		repository.WithJobs(o.Jobs),
+	}
+	if !o.UseCache {
+		opts = append(opts, repository.WithoutCache())
+	}
+	if o.Explain != nil {
+		explain := o.Explain
+		opts = append(opts, repository.WithExplanation(func(entryID plumbing.Hash, step string) {
+			explain(entryID.String(), step)
+		}))
+	}
+
+	return opts
+}